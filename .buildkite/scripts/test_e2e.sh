@@ -47,6 +47,7 @@ set -x
 ${test_runner_binary} \
     ${BUILDKITE:+--basedir ${TEST_BASE_DIR:-$PWD}/e2e} \
     --basedir.no_cleanup \
+    ${BUILDKITE:+--artifacts.dir ${TEST_BASE_DIR:-$PWD}/e2e-artifacts} \
     --e2e.node.binary ${node_binary} \
     --e2e/runtime.runtime.binary_dir.default ${WORKDIR}/target/default/debug \
     --e2e/runtime.runtime.binary_dir.intel-sgx ${WORKDIR}/target/sgx/x86_64-fortanix-unknown-sgx/debug \
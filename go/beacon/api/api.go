@@ -27,9 +27,15 @@ const (
 	BackendVRF = "vrf"
 )
 
-// ErrBeaconNotAvailable is the error returned when a beacon is not
-// available for the requested height for any reason.
-var ErrBeaconNotAvailable = errors.New(ModuleName, 1, "beacon: random beacon not available")
+var (
+	// ErrBeaconNotAvailable is the error returned when a beacon is not
+	// available for the requested height for any reason.
+	ErrBeaconNotAvailable = errors.New(ModuleName, 1, "beacon: random beacon not available")
+
+	// ErrProofsNotAvailable is the error returned when VRF proofs are not
+	// available for the requested epoch for any reason.
+	ErrProofsNotAvailable = errors.New(ModuleName, 2, "beacon: VRF proofs not available")
+)
 
 // EpochTime is the number of intervals (epochs) since a fixed instant
 // in time/block height (epoch date/height).
@@ -49,6 +55,15 @@ type EpochTimeState struct {
 	Height int64     `json:"height"`
 }
 
+// EpochQuery is a query for data generated in a specific epoch.
+type EpochQuery struct {
+	// Height is the block height at which to perform the query.
+	Height int64 `json:"height"`
+
+	// Epoch is the epoch for which the data is requested.
+	Epoch EpochTime `json:"epoch"`
+}
+
 // Backend is a random beacon/time keeping implementation.
 type Backend interface {
 	// GetBaseEpoch returns the base epoch.
@@ -96,6 +111,19 @@ type Backend interface {
 	// return the beacon for the latest finalized block.
 	GetBeacon(context.Context, int64) ([]byte, error)
 
+	// GetBeaconAt gets the beacon that was generated for the given epoch,
+	// as seen at the given block height.
+	//
+	// Only beacons of the last HistoryRetention epochs are available.
+	GetBeaconAt(context.Context, *EpochQuery) ([]byte, error)
+
+	// GetProofs gets the VRF proofs that were submitted during the given
+	// epoch, as seen at the given block height.
+	//
+	// Only proofs of the last HistoryRetention epochs are available and
+	// only when using the VRF backend.
+	GetProofs(context.Context, *EpochQuery) (*VRFProofs, error)
+
 	// StateToGenesis returns the genesis state at specified block height.
 	StateToGenesis(context.Context, int64) (*Genesis, error)
 
@@ -133,6 +161,23 @@ type ConsensusParameters struct {
 
 	// VRFParamenters are the beacon parameters for the VRF backend.
 	VRFParameters *VRFParameters `json:"vrf_parameters,omitempty"`
+
+	// HistoryRetention is the number of past epochs for which the beacon
+	// (and VRF proofs when using the VRF backend) is kept in state.
+	// Zero disables history.
+	HistoryRetention uint64 `json:"history_retention,omitempty"`
+}
+
+// Interval returns the epoch interval (in blocks) of the configured backend.
+func (p *ConsensusParameters) Interval() int64 {
+	switch {
+	case p.Backend == BackendInsecure && p.InsecureParameters != nil:
+		return p.InsecureParameters.Interval
+	case p.Backend == BackendVRF && p.VRFParameters != nil:
+		return p.VRFParameters.Interval
+	default:
+		return 0
+	}
 }
 
 // InsecureParameters are the beacon parameters for the insecure backend.
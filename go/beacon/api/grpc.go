@@ -26,6 +26,10 @@ var (
 	methodWaitEpoch = serviceName.NewMethod("WaitEpoch", EpochTime(0))
 	// methodGetBeacon is the GetBeacon method.
 	methodGetBeacon = serviceName.NewMethod("GetBeacon", int64(0))
+	// methodGetBeaconAt is the GetBeaconAt method.
+	methodGetBeaconAt = serviceName.NewMethod("GetBeaconAt", EpochQuery{})
+	// methodGetProofs is the GetProofs method.
+	methodGetProofs = serviceName.NewMethod("GetProofs", EpochQuery{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodConsensusParameters is the ConsensusParameters method.
@@ -63,6 +67,14 @@ var (
 				MethodName: methodGetBeacon.ShortName(),
 				Handler:    handlerGetBeacon,
 			},
+			{
+				MethodName: methodGetBeaconAt.ShortName(),
+				Handler:    handlerGetBeaconAt,
+			},
+			{
+				MethodName: methodGetProofs.ShortName(),
+				Handler:    handlerGetProofs,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -216,6 +228,52 @@ func handlerGetBeacon( //nolint:golint
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetBeaconAt( //nolint:golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query EpochQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetBeaconAt(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetBeaconAt.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetBeaconAt(ctx, req.(*EpochQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerGetProofs( //nolint:golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query EpochQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetProofs(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetProofs.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetProofs(ctx, req.(*EpochQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerStateToGenesis( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -384,6 +442,22 @@ func (c *beaconClient) GetBeacon(ctx context.Context, height int64) ([]byte, err
 	return rsp, nil
 }
 
+func (c *beaconClient) GetBeaconAt(ctx context.Context, query *EpochQuery) ([]byte, error) {
+	var rsp []byte
+	if err := c.conn.Invoke(ctx, methodGetBeaconAt.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *beaconClient) GetProofs(ctx context.Context, query *EpochQuery) (*VRFProofs, error) {
+	var rsp VRFProofs
+	if err := c.conn.Invoke(ctx, methodGetProofs.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *beaconClient) StateToGenesis(ctx context.Context, height int64) (*Genesis, error) {
 	var rsp Genesis
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
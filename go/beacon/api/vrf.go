@@ -77,6 +77,18 @@ type PrevVRFState struct {
 	CanElectCommittees bool `json:"can_elect,omitempty"`
 }
 
+// VRFProofs are the VRF proofs submitted during an epoch.
+type VRFProofs struct {
+	// Epoch is the epoch during which the proofs were submitted.
+	Epoch EpochTime `json:"epoch"`
+
+	// Alpha is the VRF alpha_string input that was active during the epoch.
+	Alpha []byte `json:"alpha"`
+
+	// Pi are the pi_string (VRF proof) outputs submitted during the epoch.
+	Pi map[signature.PublicKey]*signature.Proof `json:"pi,omitempty"`
+}
+
 // VRFProve is a VRF proof transaction payload.
 type VRFProve struct {
 	Epoch EpochTime `json:"epoch"`
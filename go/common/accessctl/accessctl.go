@@ -12,8 +12,15 @@ import (
 // Subject is an access control subject.
 type Subject string
 
-// AnySubject is a wildcard subject. When set for an action in a policy, it matches any subject.
-const AnySubject Subject = "*"
+const (
+	// AnySubject is a wildcard subject. When set for an action in a policy, it matches any
+	// authenticated subject.
+	AnySubject Subject = "*"
+
+	// AnonymousSubject is the subject of clients that did not authenticate. It is only allowed to
+	// perform actions for which anonymous access has been explicitly allowed.
+	AnonymousSubject Subject = "anonymous"
+)
 
 // SubjectFromX509Certificate returns a Subject from the given X.509
 // certificate.
@@ -70,6 +77,13 @@ func (p Policy) AllowAll(act Action) {
 	p[act][AnySubject] = true
 }
 
+// AllowAnonymous adds a policy rule that allows anyone, including clients that did not
+// authenticate, to perform the given action.
+func (p Policy) AllowAnonymous(act Action) {
+	p.AllowAll(act)
+	p.Allow(AnonymousSubject, act)
+}
+
 // Deny removes a policy rule that allows the given Subject to perform the
 // given Action.
 func (p Policy) Deny(sub Subject, act Action) {
@@ -85,6 +99,10 @@ func (p Policy) IsAllowed(sub Subject, act Action) bool {
 	if p[act] == nil {
 		return false
 	}
+	if sub == AnonymousSubject {
+		// Anonymous access must be explicitly allowed.
+		return p[act][AnonymousSubject]
+	}
 	return p[act][AnySubject] || p[act][sub]
 }
 
@@ -53,6 +53,17 @@ func TestPolicy(t *testing.T) {
 	policy.Deny(AnySubject, "write")
 	require.False(policy.IsAllowed("anne", "write"), "Anne should not have write access")
 	require.True(policy.IsAllowed("bob", "write"), "Bob should have write access")
+
+	// Anonymous rules.
+	require.False(policy.IsAllowed(AnonymousSubject, "write"), "Anonymous should not have write access")
+	policy.AllowAll("write")
+	require.False(policy.IsAllowed(AnonymousSubject, "write"), "Anonymous should not have write access")
+	policy.AllowAnonymous("read")
+	require.True(policy.IsAllowed(AnonymousSubject, "read"), "Anonymous should have read access")
+	require.True(policy.IsAllowed("bob", "read"), "Bob should have read access")
+	policy.Deny(AnonymousSubject, "read")
+	require.False(policy.IsAllowed(AnonymousSubject, "read"), "Anonymous should not have read access")
+	require.True(policy.IsAllowed("bob", "read"), "Bob should have read access")
 }
 
 func TestSubjectFromCertificate(t *testing.T) {
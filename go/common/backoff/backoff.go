@@ -1,7 +1,13 @@
 // Package backoff contains helpers for dealing with backoffs.
 package backoff
 
-import "github.com/cenkalti/backoff/v4"
+import (
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	"github.com/oasisprotocol/oasis-core/go/common/clock"
+)
 
 // NewExponentialBackOff creates an instance of ExponentialBackOff using reasonable defaults.
 func NewExponentialBackOff() *backoff.ExponentialBackOff {
@@ -9,3 +15,45 @@ func NewExponentialBackOff() *backoff.ExponentialBackOff {
 	boff.MaxElapsedTime = 0 // Make sure that the backoff never stops by default.
 	return boff
 }
+
+// NewExponentialBackOffWithClock creates an instance of ExponentialBackOff using reasonable
+// defaults that measures elapsed time using the given clock.
+func NewExponentialBackOffWithClock(c clock.Clock) *backoff.ExponentialBackOff {
+	boff := NewExponentialBackOff()
+	boff.Clock = c
+	boff.Reset()
+	return boff
+}
+
+// Retry is like backoff.Retry but waits between retries using the given clock.
+func Retry(c clock.Clock, o backoff.Operation, b backoff.BackOff) error {
+	return backoff.RetryNotifyWithTimer(o, b, nil, NewTimer(c))
+}
+
+// NewTimer creates a backoff timer backed by the given clock.
+func NewTimer(c clock.Clock) backoff.Timer {
+	return &timer{clock: c}
+}
+
+type timer struct {
+	clock clock.Clock
+	timer clock.Timer
+}
+
+func (t *timer) C() <-chan time.Time {
+	return t.timer.C()
+}
+
+func (t *timer) Start(d time.Duration) {
+	if t.timer == nil {
+		t.timer = t.clock.NewTimer(d)
+		return
+	}
+	t.timer.Reset(d)
+}
+
+func (t *timer) Stop() {
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
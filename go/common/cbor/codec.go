@@ -4,8 +4,10 @@ import (
 	"encoding/binary"
 	"errors"
 	"io"
+	"net"
 	"sync"
 
+	"github.com/fxamacker/cbor/v2"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -42,25 +44,31 @@ type MessageReader struct {
 // Read deserializes a single CBOR-encoded Message from the underlying reader.
 func (c *MessageReader) Read(msg interface{}) error {
 	// Read 32-bit length prefix.
-	rawLength := make([]byte, 4)
-	if _, err := io.ReadAtLeast(c.reader, rawLength, 4); err != nil {
+	var rawLength [4]byte
+	if _, err := io.ReadFull(c.reader, rawLength[:]); err != nil {
 		return err
 	}
 
 	labels := prometheus.Labels{"module": c.module, "call": "read"}
-	length := binary.BigEndian.Uint32(rawLength)
+	length := binary.BigEndian.Uint32(rawLength[:])
 	codecValueSize.With(labels).Observe(float64(length))
 	if length > maxMessageSize {
 		return errMessageTooLarge
 	}
 
-	// Decode message bytes.
+	// Decode the message directly from the underlying reader. The decoder only grows its buffer
+	// as data arrives, so the announced length does not cause a large up-front allocation, and
+	// the message is decoded in a single pass.
 	r := io.LimitReader(c.reader, int64(length))
 	dec := NewDecoder(r)
 	if err := dec.Decode(msg); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return errMessageMalformed
+		}
 		return err
 	}
-	if r.(*io.LimitedReader).N > 0 {
+	if uint32(dec.NumBytesRead()) != length {
+		// Message has trailing data.
 		return errMessageMalformed
 	}
 
@@ -69,16 +77,35 @@ func (c *MessageReader) Read(msg interface{}) error {
 
 // MessageWriter is a writer wrapper that encodes Messages structures to CBOR.
 type MessageWriter struct {
+	lock sync.Mutex
+
 	writer io.Writer
 
 	// module is the module name where the message was created.
 	module string
+
+	enc   *cbor.Encoder
+	frame frameBuffer
 }
 
 // Write serializes a single Message to CBOR and writes it to the underlying writer.
+//
+// The message is encoded into a buffer that is retained by the writer and reused for
+// subsequent messages, and the encoded message is passed to the underlying writer
+// together with its length prefix without copying it again.
 func (c *MessageWriter) Write(msg interface{}) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	// Encode into CBOR.
-	data := Marshal(msg)
+	if c.enc == nil {
+		c.enc = encMode.NewEncoder(&c.frame)
+	}
+	defer c.frame.reset()
+	if err := c.enc.Encode(msg); err != nil {
+		return err
+	}
+	data := c.frame.bytes()
 	length := len(data)
 	labels := prometheus.Labels{"module": c.module, "call": "write"}
 	codecValueSize.With(labels).Observe(float64(length))
@@ -86,19 +113,55 @@ func (c *MessageWriter) Write(msg interface{}) error {
 		return errMessageTooLarge
 	}
 
-	// Write 32-bit length prefix and encoded data.
-	rawLength := make([]byte, 4)
-	binary.BigEndian.PutUint32(rawLength, uint32(length))
-	if _, err := c.writer.Write(rawLength); err != nil {
-		return err
-	}
-	if _, err := c.writer.Write(data); err != nil {
+	// Write 32-bit length prefix and encoded data. When the underlying writer supports
+	// it (e.g., for network connections), both are written using a single vectored write.
+	var rawLength [4]byte
+	binary.BigEndian.PutUint32(rawLength[:], uint32(length))
+	bufs := net.Buffers{rawLength[:], data}
+	if _, err := bufs.WriteTo(c.writer); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// frameBuffer collects the output of the CBOR encoder.
+//
+// The encoder passes the whole encoded message to a single Write call, in which case
+// the frame buffer only references the encoder's internal buffer instead of copying
+// it. The referenced data is only valid until the next call to the encoder.
+type frameBuffer struct {
+	ref []byte
+	buf []byte
+}
+
+func (f *frameBuffer) Write(p []byte) (int, error) {
+	switch {
+	case f.ref == nil && f.buf == nil:
+		f.ref = p
+	case f.ref != nil:
+		// Multiple writes, fall back to copying.
+		f.buf = append(f.buf[:0], f.ref...)
+		f.ref = nil
+		fallthrough
+	default:
+		f.buf = append(f.buf, p...)
+	}
+	return len(p), nil
+}
+
+func (f *frameBuffer) bytes() []byte {
+	if f.ref != nil {
+		return f.ref
+	}
+	return f.buf
+}
+
+func (f *frameBuffer) reset() {
+	f.ref = nil
+	f.buf = nil
+}
+
 // MessageCodec is a length-prefixed Message encoder/decoder.
 type MessageCodec struct {
 	MessageReader
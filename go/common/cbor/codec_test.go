@@ -3,6 +3,7 @@ package cbor
 import (
 	"bytes"
 	"encoding/binary"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -71,3 +72,85 @@ func TestCodecMalformed(t *testing.T) {
 	require.Error(err, "Read should fail with malformed message")
 	require.EqualValues(errMessageMalformed, err)
 }
+
+func TestCodecTrailingData(t *testing.T) {
+	require := require.New(t)
+
+	var buffer bytes.Buffer
+	codec := NewMessageCodec(&buffer, t.Name())
+
+	err := codec.Write(42)
+	require.NoError(err, "Write")
+
+	// Corrupt the buffer to include trailing data after the message.
+	buffer.WriteByte(0x00)
+	binary.BigEndian.PutUint32(buffer.Bytes()[:4], uint32(buffer.Len()-4))
+
+	var x int
+	err = codec.Read(&x)
+	require.Error(err, "Read should fail with trailing data")
+	require.EqualValues(errMessageMalformed, err)
+}
+
+func TestFrameBuffer(t *testing.T) {
+	require := require.New(t)
+
+	var f frameBuffer
+	data := []byte("hello")
+	_, _ = f.Write(data)
+	require.Equal(data, f.bytes())
+	require.True(&data[0] == &f.bytes()[0], "single write should not copy")
+
+	_, _ = f.Write([]byte(" world"))
+	require.Equal([]byte("hello world"), f.bytes(), "multiple writes should be concatenated")
+
+	f.reset()
+	require.Empty(f.bytes())
+}
+
+type batchMessage struct {
+	Inputs [][]byte
+}
+
+func newBatchMessage() *batchMessage {
+	var msg batchMessage
+	for i := 0; i < 1000; i++ {
+		msg.Inputs = append(msg.Inputs, bytes.Repeat([]byte{byte(i)}, 1024))
+	}
+	return &msg
+}
+
+func BenchmarkCodecWrite(b *testing.B) {
+	msg := newBatchMessage()
+	codec := NewMessageCodec(struct {
+		io.Reader
+		io.Writer
+	}{nil, io.Discard}, b.Name())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := codec.Write(msg); err != nil {
+			b.Fatalf("Write: %s", err)
+		}
+	}
+}
+
+func BenchmarkCodecRead(b *testing.B) {
+	var buffer bytes.Buffer
+	codec := NewMessageCodec(&buffer, b.Name())
+	if err := codec.Write(newBatchMessage()); err != nil {
+		b.Fatalf("Write: %s", err)
+	}
+	raw := buffer.Bytes()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		codec.MessageReader.reader = bytes.NewReader(raw)
+		var msg batchMessage
+		if err := codec.Read(&msg); err != nil {
+			b.Fatalf("Read: %s", err)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Package clock provides a time source abstraction that can be replaced with a deterministic one
+// in tests of timeout-driven code.
+package clock
+
+import "time"
+
+// Clock is a source of time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+
+	// After waits for the duration to elapse and then sends the current time on the returned
+	// channel.
+	After(d time.Duration) <-chan time.Time
+
+	// NewTimer creates a new Timer that will send the current time on its channel after at least
+	// duration d.
+	NewTimer(d time.Duration) Timer
+
+	// NewTicker returns a new Ticker containing a channel that will send the current time on the
+	// channel after each tick. The period of the ticks is specified by the duration argument.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is a single event timer.
+type Timer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+
+	// Stop prevents the Timer from firing. It returns true if the call stops the timer, false if
+	// the timer has already expired or been stopped.
+	Stop() bool
+
+	// Reset changes the timer to expire after duration d. It returns true if the timer had been
+	// active, false if the timer had expired or been stopped.
+	Reset(d time.Duration) bool
+}
+
+// Ticker is a periodic event timer.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+
+	// Stop turns off the ticker. After Stop, no more ticks will be sent.
+	Stop()
+}
+
+// System is the clock backed by the system wall clock.
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return &systemTimer{time.NewTimer(d)}
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return &systemTicker{time.NewTicker(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t *systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t *systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
@@ -0,0 +1,126 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func requireFired(t *testing.T, ch <-chan time.Time, expected time.Time, msgAndArgs ...interface{}) {
+	select {
+	case v := <-ch:
+		require.Equal(t, expected, v, msgAndArgs...)
+	default:
+		require.Fail(t, "timer should have fired", msgAndArgs...)
+	}
+}
+
+func requireNotFired(t *testing.T, ch <-chan time.Time, msgAndArgs ...interface{}) {
+	select {
+	case <-ch:
+		require.Fail(t, "timer should not have fired", msgAndArgs...)
+	default:
+	}
+}
+
+func TestMockTimer(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(1_000_000, 0)
+	m := NewMock(start)
+	require.Equal(start, m.Now())
+
+	timer := m.NewTimer(10 * time.Second)
+	after := m.After(5 * time.Second)
+	require.Equal(2, m.Waiters())
+
+	m.Advance(4 * time.Second)
+	requireNotFired(t, timer.C(), "timer")
+	requireNotFired(t, after, "after")
+	require.Equal(4*time.Second, m.Since(start))
+
+	m.Advance(1 * time.Second)
+	requireFired(t, after, start.Add(5*time.Second), "after")
+	requireNotFired(t, timer.C(), "timer")
+
+	// Firing should happen at the timer deadline even when advancing past it.
+	m.Advance(time.Minute)
+	requireFired(t, timer.C(), start.Add(10*time.Second), "timer")
+	require.Equal(start.Add(65*time.Second), m.Now())
+	require.Equal(0, m.Waiters())
+
+	// Stopped timers should not fire.
+	timer = m.NewTimer(time.Second)
+	require.True(timer.Stop(), "Stop should stop an active timer")
+	require.False(timer.Stop(), "Stop should return false for a stopped timer")
+	m.Advance(time.Second)
+	requireNotFired(t, timer.C(), "stopped timer")
+
+	// Reset timers should fire after the new duration.
+	require.False(timer.Reset(2*time.Second), "Reset should return false for a stopped timer")
+	m.Advance(time.Second)
+	requireNotFired(t, timer.C(), "reset timer")
+	m.Advance(time.Second)
+	requireFired(t, timer.C(), start.Add(68*time.Second), "reset timer")
+
+	// Zero duration timers should fire immediately.
+	requireFired(t, m.After(0), m.Now(), "zero duration")
+}
+
+func TestMockTicker(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(1_000_000, 0)
+	m := NewMock(start)
+
+	ticker := m.NewTicker(time.Second)
+	m.Advance(500 * time.Millisecond)
+	requireNotFired(t, ticker.C(), "ticker")
+	m.Advance(500 * time.Millisecond)
+	requireFired(t, ticker.C(), start.Add(time.Second), "first tick")
+
+	// Ticks should be dropped in case the receiver is lagging behind.
+	m.Advance(3 * time.Second)
+	requireFired(t, ticker.C(), start.Add(2*time.Second), "lagging tick")
+	requireNotFired(t, ticker.C(), "dropped ticks")
+
+	ticker.Stop()
+	m.Advance(time.Minute)
+	requireNotFired(t, ticker.C(), "stopped ticker")
+	require.Equal(0, m.Waiters())
+}
+
+func TestMockBlockUntil(t *testing.T) {
+	require := require.New(t)
+
+	m := NewMock(time.Unix(0, 0))
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		<-m.After(time.Hour)
+	}()
+
+	m.BlockUntil(1)
+	m.Advance(time.Hour)
+
+	select {
+	case <-doneCh:
+	case <-time.After(5 * time.Second):
+		require.Fail("waiter should have been released")
+	}
+}
+
+func TestSystem(t *testing.T) {
+	require := require.New(t)
+
+	now := System.Now()
+	timer := System.NewTimer(time.Millisecond)
+	<-timer.C()
+	require.False(timer.Stop(), "Stop should return false for an expired timer")
+	require.True(System.Since(now) >= time.Millisecond)
+
+	ticker := System.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	<-ticker.C()
+}
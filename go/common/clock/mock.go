@@ -0,0 +1,214 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+var _ Clock = (*Mock)(nil)
+
+// Mock is a deterministic clock whose time only moves when explicitly advanced.
+//
+// Timers and tickers created by the mock clock fire synchronously as part of the call that moves
+// the time past their deadline.
+type Mock struct {
+	sync.Mutex
+
+	now    time.Time
+	timers []*mockTimer
+	cond   *sync.Cond
+}
+
+type mockTimer struct {
+	mock *Mock
+
+	deadline time.Time
+	period   time.Duration
+	ch       chan time.Time
+}
+
+// Now returns the current mock time.
+func (m *Mock) Now() time.Time {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.now
+}
+
+// Since returns the mock time elapsed since t.
+func (m *Mock) Since(t time.Time) time.Duration {
+	return m.Now().Sub(t)
+}
+
+// After waits for the mock duration to elapse and then sends the mock time on the returned
+// channel.
+func (m *Mock) After(d time.Duration) <-chan time.Time {
+	return m.NewTimer(d).C()
+}
+
+// NewTimer creates a new Timer that will fire once the mock time is advanced by at least d.
+func (m *Mock) NewTimer(d time.Duration) Timer {
+	m.Lock()
+	defer m.Unlock()
+
+	t := &mockTimer{
+		mock: m,
+		ch:   make(chan time.Time, 1),
+	}
+	m.scheduleLocked(t, d)
+	return t
+}
+
+// NewTicker creates a new Ticker that will tick each time the mock time is advanced by d.
+func (m *Mock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	t := &mockTimer{
+		mock:   m,
+		period: d,
+		ch:     make(chan time.Time, 1),
+	}
+	m.scheduleLocked(t, d)
+	return &mockTicker{t}
+}
+
+// Advance moves the mock time forward by d, firing all timers and tickers that expire in the
+// meantime in deadline order.
+func (m *Mock) Advance(d time.Duration) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.setLocked(m.now.Add(d))
+}
+
+// Set moves the mock time to t, firing all timers and tickers that expire in the meantime in
+// deadline order. Setting a time in the past does not fire any timers.
+func (m *Mock) Set(t time.Time) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.setLocked(t)
+}
+
+// BlockUntil blocks until at least n timers or tickers are waiting on the mock clock.
+//
+// This can be used to make sure that the code under test has armed its timers before advancing
+// the time.
+func (m *Mock) BlockUntil(n int) {
+	m.Lock()
+	defer m.Unlock()
+
+	for len(m.timers) < n {
+		m.cond.Wait()
+	}
+}
+
+// Waiters returns the number of timers and tickers waiting on the mock clock.
+func (m *Mock) Waiters() int {
+	m.Lock()
+	defer m.Unlock()
+
+	return len(m.timers)
+}
+
+func (m *Mock) setLocked(target time.Time) {
+	for {
+		next := m.nextLocked()
+		if next == nil || next.deadline.After(target) {
+			break
+		}
+		if next.deadline.After(m.now) {
+			m.now = next.deadline
+		}
+
+		m.removeLocked(next)
+		select {
+		case next.ch <- m.now:
+		default:
+			// Drop the tick in case the receiver is lagging behind, like time.Ticker does.
+		}
+		if next.period > 0 {
+			m.scheduleLocked(next, next.period)
+		}
+	}
+	if target.After(m.now) {
+		m.now = target
+	}
+}
+
+func (m *Mock) nextLocked() *mockTimer {
+	var next *mockTimer
+	for _, t := range m.timers {
+		if next == nil || t.deadline.Before(next.deadline) {
+			next = t
+		}
+	}
+	return next
+}
+
+func (m *Mock) scheduleLocked(t *mockTimer, d time.Duration) {
+	t.deadline = m.now.Add(d)
+	m.timers = append(m.timers, t)
+	m.cond.Broadcast()
+
+	if d <= 0 {
+		// Fire immediately in case the duration has already elapsed.
+		m.setLocked(m.now)
+	}
+}
+
+func (m *Mock) removeLocked(t *mockTimer) bool {
+	for i, v := range m.timers {
+		if v == t {
+			m.timers = append(m.timers[:i], m.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (t *mockTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *mockTimer) Stop() bool {
+	t.mock.Lock()
+	defer t.mock.Unlock()
+
+	return t.mock.removeLocked(t)
+}
+
+func (t *mockTimer) Reset(d time.Duration) bool {
+	t.mock.Lock()
+	defer t.mock.Unlock()
+
+	active := t.mock.removeLocked(t)
+	t.mock.scheduleLocked(t, d)
+	return active
+}
+
+type mockTicker struct {
+	t *mockTimer
+}
+
+func (t *mockTicker) C() <-chan time.Time {
+	return t.t.ch
+}
+
+func (t *mockTicker) Stop() {
+	t.t.Stop()
+}
+
+// NewMock creates a new mock clock set to the given time.
+func NewMock(now time.Time) *Mock {
+	m := &Mock{
+		now: now,
+	}
+	m.cond = sync.NewCond(&m.Mutex)
+	return m
+}
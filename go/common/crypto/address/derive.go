@@ -0,0 +1,55 @@
+package address
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+var (
+	// StakingV0Context is the unique context for v0 staking account addresses.
+	StakingV0Context = NewContext("oasis-core/address: staking", 0)
+	// RuntimeV0Context is the unique context for v0 runtime account addresses.
+	RuntimeV0Context = NewContext("oasis-core/address: runtime", 0)
+	// ModuleV0Context is the unique context for v0 module account addresses.
+	ModuleV0Context = NewContext("oasis-core/address: module", 0)
+	// RuntimeMessageV0Context is the unique context for v0 addresses of accounts originating
+	// runtime messages.
+	RuntimeMessageV0Context = NewContext("oasis-core/address: runtime message", 0)
+)
+
+// NewStakingAddress derives the staking account address for the given public key, i.e. entity ID.
+func NewStakingAddress(pk signature.PublicKey) Address {
+	pkData, _ := pk.MarshalBinary()
+	return NewAddress(StakingV0Context, pkData)
+}
+
+// NewRuntimeAddress derives the runtime account address for the given runtime ID.
+func NewRuntimeAddress(id common.Namespace) Address {
+	nsData, _ := id.MarshalBinary()
+	return NewAddress(RuntimeV0Context, nsData)
+}
+
+// NewModuleAddress derives the address of an account of the given kind owned by the given
+// module.
+//
+// The module and kind are separated by a single dot, so the module name must not contain any dots.
+// This routine will panic if the module name is malformed.
+func NewModuleAddress(module, kind string) Address {
+	if len(module) == 0 || strings.Contains(module, ".") {
+		panic(fmt.Sprintf("address: malformed module name '%s'", module))
+	}
+	return NewAddress(ModuleV0Context, []byte(module+"."+kind))
+}
+
+// NewRuntimeMessageAddress derives the address of an account originating runtime messages on behalf
+// of the given runtime-specific origin (e.g., a runtime account address).
+//
+// This makes it possible to attribute messages emitted by the runtime to different origins while
+// still preventing any collisions with addresses derived for other runtimes.
+func NewRuntimeMessageAddress(id common.Namespace, origin []byte) Address {
+	nsData, _ := id.MarshalBinary()
+	return NewAddress(RuntimeMessageV0Context, append(nsData, origin...))
+}
@@ -0,0 +1,41 @@
+package address
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+func TestDerive(t *testing.T) {
+	require := require.New(t)
+
+	id := common.NewTestNamespaceFromSeed([]byte("runtime address test 1"), 0)
+	pk := signature.NewPublicKey("1abe11edc001ffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+	for _, tc := range []struct {
+		addr     Address
+		expected string
+		msg      string
+	}{
+		{NewStakingAddress(pk), "00f7c4ded62113e2c282ea867223a544ed55d6ba6e", "staking address"},
+		{NewRuntimeAddress(id), "007ffb94b3bb8436d3413556667d5aeac4845531ed", "runtime address"},
+		{NewModuleAddress("staking", "rewards"), "00df4552f39402aa4e1da9c0e4593c0d0f7b2662c9", "module address"},
+		{NewRuntimeMessageAddress(id, []byte("origin")), "00e8c39f699783bc989413f410ceb9746246c8ed88", "runtime message address"},
+	} {
+		require.EqualValues(tc.expected, hex.EncodeToString(tc.addr[:]), tc.msg+" should be correct")
+	}
+
+	// Make sure domain separation works.
+	require.NotEqualValues(NewRuntimeAddress(id), NewRuntimeMessageAddress(id, nil),
+		"runtime message addresses should be separated from runtime addresses")
+	require.NotEqualValues(NewModuleAddress("staking", "rewards"), NewModuleAddress("staking", "fees"),
+		"module addresses for different kinds should be different")
+
+	// Make sure malformed module names are rejected.
+	require.Panics(func() { NewModuleAddress("", "rewards") }, "empty module name should panic")
+	require.Panics(func() { NewModuleAddress("staking.v1", "rewards") }, "module name with dots should panic")
+}
@@ -0,0 +1,161 @@
+package file
+
+import (
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+	"github.com/oasisprotocol/deoxysii"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	mrae "github.com/oasisprotocol/oasis-core/go/common/crypto/mrae/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	oasisPem "github.com/oasisprotocol/oasis-core/go/common/pem"
+)
+
+const (
+	encryptedPrivateKeyPemType = "ENCRYPTED ED25519 PRIVATE KEY"
+
+	encryptedPrivateKeyVersion = 1
+
+	// Default scrypt parameters used when encrypting private keys. The parameters are stored
+	// together with the encrypted key so they can be changed without breaking existing keys.
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+	// scryptMaxCost is the maximum product of the scrypt N, r and p parameters accepted when
+	// decrypting a private key. As scrypt uses 128·N·r bytes of memory and does work proportional
+	// to N·r·p, this bounds the resources used for a malformed key file (at most 1 GiB).
+	scryptMaxCost = 1 << 23
+
+	scryptSaltSize = 32
+)
+
+var (
+	// ErrPassphraseRequired is the error returned when loading an encrypted private key without
+	// a passphrase.
+	ErrPassphraseRequired = errors.New("signature/signer/file: private key is encrypted, passphrase required")
+
+	// ErrInvalidPassphrase is the error returned when an encrypted private key cannot be
+	// decrypted with the given passphrase.
+	ErrInvalidPassphrase = errors.New("signature/signer/file: invalid passphrase")
+)
+
+// kdfParameters are the scrypt key derivation parameters.
+type kdfParameters struct {
+	Salt []byte `json:"salt"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+}
+
+func (p *kdfParameters) deriveKey(passphrase []byte) ([]byte, error) {
+	if p.N <= 0 || p.R <= 0 || p.P <= 0 {
+		return nil, fmt.Errorf("signature/signer/file: invalid scrypt parameters (n: %d r: %d p: %d)", p.N, p.R, p.P)
+	}
+	if p.N > scryptMaxCost || p.R > scryptMaxCost || p.P > scryptMaxCost {
+		return nil, fmt.Errorf("signature/signer/file: scrypt cost parameters too large (n: %d r: %d p: %d)", p.N, p.R, p.P)
+	}
+	// Each parameter is at most 2^23, so the products cannot overflow.
+	nr := uint64(p.N) * uint64(p.R)
+	if nr > scryptMaxCost || nr*uint64(p.P) > scryptMaxCost {
+		return nil, fmt.Errorf("signature/signer/file: scrypt cost parameters too large (n: %d r: %d p: %d)", p.N, p.R, p.P)
+	}
+	return scrypt.Key(passphrase, p.Salt, p.N, p.R, p.P, deoxysii.KeySize)
+}
+
+// encryptedPrivateKey is a passphrase encrypted private key.
+type encryptedPrivateKey struct {
+	cbor.Versioned
+
+	// KDF are the key derivation parameters used to derive the encryption key from the
+	// passphrase.
+	KDF kdfParameters `json:"kdf"`
+	// Nonce is the nonce used to encrypt the private key.
+	Nonce []byte `json:"nonce"`
+	// Ciphertext is the encrypted private key.
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func (s *Signer) marshalEncryptedPEM(passphrase []byte) ([]byte, error) {
+	ek := encryptedPrivateKey{
+		Versioned: cbor.NewVersioned(encryptedPrivateKeyVersion),
+		KDF: kdfParameters{
+			Salt: make([]byte, scryptSaltSize),
+			N:    scryptN,
+			R:    scryptR,
+			P:    scryptP,
+		},
+		Nonce: make([]byte, deoxysii.NonceSize),
+	}
+	if _, err := rand.Read(ek.KDF.Salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(ek.Nonce); err != nil {
+		return nil, err
+	}
+
+	key, err := ek.KDF.deriveKey(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := deoxysii.New(key)
+	if err != nil {
+		return nil, err
+	}
+	defer aead.(mrae.ResetAble).Reset()
+	ek.Ciphertext = aead.Seal(nil, ek.Nonce, s.privateKey[:], []byte(encryptedPrivateKeyPemType))
+
+	return oasisPem.Marshal(encryptedPrivateKeyPemType, cbor.Marshal(ek))
+}
+
+func (s *Signer) unmarshalEncryptedPEM(data, passphrase []byte) error {
+	data, err := oasisPem.Unmarshal(encryptedPrivateKeyPemType, data)
+	if err != nil {
+		return err
+	}
+	if len(passphrase) == 0 {
+		return ErrPassphraseRequired
+	}
+
+	var ek encryptedPrivateKey
+	if err = cbor.Unmarshal(data, &ek); err != nil {
+		return fmt.Errorf("signature/signer/file: malformed encrypted private key: %w", err)
+	}
+	if ek.V != encryptedPrivateKeyVersion {
+		return fmt.Errorf("signature/signer/file: unsupported encrypted private key version: %d", ek.V)
+	}
+	if len(ek.Nonce) != deoxysii.NonceSize {
+		return signature.ErrMalformedPrivateKey
+	}
+
+	key, err := ek.KDF.deriveKey(passphrase)
+	if err != nil {
+		return err
+	}
+	aead, err := deoxysii.New(key)
+	if err != nil {
+		return err
+	}
+	defer aead.(mrae.ResetAble).Reset()
+	privateKey, err := aead.Open(nil, ek.Nonce, ek.Ciphertext, []byte(encryptedPrivateKeyPemType))
+	if err != nil {
+		return ErrInvalidPassphrase
+	}
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return signature.ErrMalformedPrivateKey
+	}
+
+	s.privateKey = ed25519.PrivateKey(privateKey)
+
+	return nil
+}
+
+// isEncryptedPEM checks whether the given PEM file contains an encrypted private key.
+func isEncryptedPEM(data []byte) bool {
+	blk, _ := pem.Decode(data)
+	return blk != nil && blk.Type == encryptedPrivateKeyPemType
+}
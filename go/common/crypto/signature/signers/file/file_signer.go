@@ -51,24 +51,43 @@ var (
 	}
 )
 
+// FactoryConfig is the file signer factory configuration.
+type FactoryConfig struct {
+	// DataDir is the directory containing the private keys.
+	DataDir string
+
+	// Passphrase is the passphrase used to encrypt the private keys at rest. If empty, private
+	// keys are stored unencrypted.
+	//
+	// When a passphrase is configured, any unencrypted private keys are encrypted when loaded.
+	Passphrase []byte
+}
+
 // NewFactory creates a new factory with the specified roles, with the
-// specified dataDir.
+// specified dataDir or factory configuration.
 func NewFactory(config interface{}, roles ...signature.SignerRole) (signature.SignerFactory, error) {
-	dataDir, ok := config.(string)
-	if !ok {
+	var cfg FactoryConfig
+	switch c := config.(type) {
+	case string:
+		cfg.DataDir = c
+	case *FactoryConfig:
+		cfg = *c
+	default:
 		return nil, errors.New("signature/signer/file: invalid file signer configuration provided")
 	}
 
 	return &Factory{
-		roles:   append([]signature.SignerRole{}, roles...),
-		dataDir: dataDir,
+		roles:      append([]signature.SignerRole{}, roles...),
+		dataDir:    cfg.DataDir,
+		passphrase: append([]byte{}, cfg.Passphrase...),
 	}, nil
 }
 
 // Factory is a PEM file backed SignerFactory.
 type Factory struct {
-	roles   []signature.SignerRole
-	dataDir string
+	roles      []signature.SignerRole
+	dataDir    string
+	passphrase []byte
 }
 
 // EnsureRole ensures that the SignerFactory is configured for the given
@@ -111,7 +130,7 @@ func (fac *Factory) Generate(role signature.SignerRole, rng io.Reader) (signatur
 		privateKey: privateKey,
 		role:       role,
 	}
-	buf, err := signer.marshalPEM()
+	buf, err := signer.marshal(fac.passphrase)
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +141,31 @@ func (fac *Factory) Generate(role signature.SignerRole, rng io.Reader) (signatur
 	return signer, nil
 }
 
+// ChangePassphrase re-encrypts all existing private keys of the factory's roles with the new
+// passphrase. An empty passphrase stores the private keys unencrypted.
+func (fac *Factory) ChangePassphrase(passphrase []byte) error {
+	for _, role := range fac.roles {
+		fn := filepath.Join(fac.dataDir, rolePEMFiles[role])
+		signer, err := fac.loadSigner(fn, role)
+		switch {
+		case err == nil:
+		case errors.Is(err, signature.ErrNotExist):
+			continue
+		default:
+			return fmt.Errorf("signature/signer/file: failed to load %s: %w", fn, err)
+		}
+
+		err = writeSigner(fn, signer, passphrase)
+		signer.Reset()
+		if err != nil {
+			return fmt.Errorf("signature/signer/file: failed to write %s: %w", fn, err)
+		}
+	}
+	fac.passphrase = append([]byte{}, passphrase...)
+
+	return nil
+}
+
 // Load will load the private key corresponding to the role, and return a Signer
 // ready for use.
 func (fac *Factory) Load(role signature.SignerRole) (signature.Signer, error) {
@@ -139,6 +183,15 @@ func (fac *Factory) ForceLoad(fn string) (signature.Signer, error) {
 }
 
 func (fac *Factory) doLoad(fn string, role signature.SignerRole) (signature.Signer, error) {
+	signer, err := fac.loadSigner(fn, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return signer, nil
+}
+
+func (fac *Factory) loadSigner(fn string, role signature.SignerRole) (*Signer, error) {
 	f, err := os.Open(fn)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -163,14 +216,46 @@ func (fac *Factory) doLoad(fn string, role signature.SignerRole) (signature.Sign
 	}
 
 	var signer Signer
-	if err = signer.unmarshalPEM(buf); err != nil {
+	encrypted := isEncryptedPEM(buf)
+	if encrypted {
+		err = signer.unmarshalEncryptedPEM(buf, fac.passphrase)
+	} else {
+		err = signer.unmarshalPEM(buf)
+	}
+	if err != nil {
 		return nil, err
 	}
 	signer.role = role
 
+	// Migrate unencrypted private keys in case a passphrase is configured.
+	if !encrypted && len(fac.passphrase) > 0 {
+		if err = writeSigner(fn, &signer, fac.passphrase); err != nil {
+			return nil, fmt.Errorf("signature/signer/file: failed to encrypt %s: %w", fn, err)
+		}
+	}
+
 	return &signer, nil
 }
 
+// writeSigner atomically replaces the given PEM file with the signer's private key.
+func writeSigner(fn string, signer *Signer, passphrase []byte) error {
+	buf, err := signer.marshal(passphrase)
+	if err != nil {
+		return err
+	}
+
+	tmpFn := fn + ".tmp"
+	_ = os.Remove(tmpFn)
+	if err = ioutil.WriteFile(tmpFn, buf, filePerm); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpFn, fn); err != nil {
+		_ = os.Remove(tmpFn)
+		return err
+	}
+	return nil
+}
+
 // Signer is a PEM file backed Signer.
 type Signer struct {
 	privateKey ed25519.PrivateKey
@@ -215,6 +300,13 @@ func (s *Signer) Prove(alphaString []byte) ([]byte, error) {
 	return ecvrf.Prove(s.privateKey, alphaString), nil
 }
 
+func (s *Signer) marshal(passphrase []byte) ([]byte, error) {
+	if len(passphrase) > 0 {
+		return s.marshalEncryptedPEM(passphrase)
+	}
+	return s.marshalPEM()
+}
+
 func (s *Signer) marshalPEM() ([]byte, error) {
 	return pem.Marshal(privateKeyPemType, s.privateKey[:])
 }
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -51,3 +52,95 @@ func TestFileSigner(t *testing.T) {
 	require.NoError(err, "LoadPEM(fn, nil), exists")
 	require.Equal(signer, signer2, "Generated = Loaded")
 }
+
+func TestEncryptedFileSigner(t *testing.T) {
+	require := require.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "oasis-signature-test")
+	require.NoError(err, "TempDir()")
+	defer os.RemoveAll(tmpDir)
+
+	rolePEMFiles[signature.SignerUnknown] = "unit_test.pem"
+	fn := filepath.Join(tmpDir, rolePEMFiles[signature.SignerUnknown])
+
+	// Generate an unencrypted key.
+	plainFactory, err := NewFactory(tmpDir, signature.SignerUnknown)
+	require.NoError(err, "NewFactory()")
+	signer, err := plainFactory.Generate(signature.SignerUnknown, rand.Reader)
+	require.NoError(err, "Generate(SignerUnknown, rand.Reader)")
+	buf, err := ioutil.ReadFile(fn)
+	require.NoError(err, "ReadFile")
+	require.False(isEncryptedPEM(buf), "generated key should not be encrypted")
+
+	// Loading with a passphrase should migrate the key.
+	encFactory, err := NewFactory(&FactoryConfig{
+		DataDir:    tmpDir,
+		Passphrase: []byte("passphrase"),
+	}, signature.SignerUnknown)
+	require.NoError(err, "NewFactory(encrypted)")
+	signer2, err := encFactory.Load(signature.SignerUnknown)
+	require.NoError(err, "Load(encrypted)")
+	require.Equal(signer.Public(), signer2.Public(), "migrated key should be the same")
+	buf, err = ioutil.ReadFile(fn)
+	require.NoError(err, "ReadFile")
+	require.True(isEncryptedPEM(buf), "migrated key should be encrypted")
+	fi, err := os.Stat(fn)
+	require.NoError(err, "Stat")
+	require.EqualValues(filePerm, fi.Mode().Perm(), "migrated key should have correct permissions")
+
+	// Loading without or with an invalid passphrase should fail.
+	_, err = plainFactory.Load(signature.SignerUnknown)
+	require.ErrorIs(err, ErrPassphraseRequired, "Load(encrypted) without passphrase")
+	badFactory, err := NewFactory(&FactoryConfig{
+		DataDir:    tmpDir,
+		Passphrase: []byte("invalid"),
+	}, signature.SignerUnknown)
+	require.NoError(err, "NewFactory(invalid passphrase)")
+	_, err = badFactory.Load(signature.SignerUnknown)
+	require.ErrorIs(err, ErrInvalidPassphrase, "Load(encrypted) with invalid passphrase")
+
+	// Change the passphrase.
+	err = encFactory.(*Factory).ChangePassphrase([]byte("new passphrase"))
+	require.NoError(err, "ChangePassphrase")
+	newFactory, err := NewFactory(&FactoryConfig{
+		DataDir:    tmpDir,
+		Passphrase: []byte("new passphrase"),
+	}, signature.SignerUnknown)
+	require.NoError(err, "NewFactory(new passphrase)")
+	signer3, err := newFactory.Load(signature.SignerUnknown)
+	require.NoError(err, "Load(new passphrase)")
+	require.Equal(signer.Public(), signer3.Public(), "re-encrypted key should be the same")
+
+	// Remove the passphrase.
+	err = newFactory.(*Factory).ChangePassphrase(nil)
+	require.NoError(err, "ChangePassphrase(nil)")
+	signer4, err := plainFactory.Load(signature.SignerUnknown)
+	require.NoError(err, "Load(decrypted)")
+	require.Equal(signer.Public(), signer4.Public(), "decrypted key should be the same")
+}
+
+func TestKDFParametersBounds(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		n, r, p int
+		valid   bool
+	}{
+		{scryptN, scryptR, scryptP, true},
+		{1 << 21, 8, 1, false},
+		{1 << 10, 1 << 14, 1, false},
+		{1 << 10, 8, 1 << 11, false},
+		{1 << 10, 1 << 30, 1 << 30, false},
+		{1 << 10, 0, 1, false},
+		{1 << 10, 8, -1, false},
+	} {
+		kdf := kdfParameters{Salt: make([]byte, scryptSaltSize), N: tc.n, R: tc.r, P: tc.p}
+		_, err := kdf.deriveKey([]byte("passphrase"))
+		switch tc.valid {
+		case true:
+			require.NoError(err, "deriveKey(n: %d r: %d p: %d)", tc.n, tc.r, tc.p)
+		case false:
+			require.Error(err, "deriveKey(n: %d r: %d p: %d)", tc.n, tc.r, tc.p)
+		}
+	}
+}
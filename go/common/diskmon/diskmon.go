@@ -0,0 +1,394 @@
+// Package diskmon implements a disk space monitor.
+//
+// The monitor periodically checks the available space of all file systems
+// backing the monitored directories and classifies the situation into one of
+// multiple degradation levels, so that the node can react before running out
+// of disk space (which could corrupt its databases).
+package diskmon
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/service"
+)
+
+// Level is the disk space degradation level.
+type Level uint8
+
+const (
+	// LevelOK means that there is enough disk space available.
+	LevelOK Level = iota
+	// LevelLow means that disk space is running low and that unneeded data
+	// should be aggressively pruned.
+	LevelLow
+	// LevelCritical means that disk space is critically low and that all
+	// non-essential work should be refused.
+	LevelCritical
+	// LevelShutdown means that disk space is about to run out and that the
+	// node should be shut down before its databases get corrupted.
+	LevelShutdown
+)
+
+// String returns a string representation of a degradation level.
+func (l Level) String() string {
+	switch l {
+	case LevelOK:
+		return "ok"
+	case LevelLow:
+		return "low"
+	case LevelCritical:
+		return "critical"
+	case LevelShutdown:
+		return "shutdown"
+	default:
+		return "[unknown]"
+	}
+}
+
+// MarshalText encodes a degradation level into text form.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText decodes a text slice into a degradation level.
+func (l *Level) UnmarshalText(text []byte) error {
+	for _, v := range []Level{LevelOK, LevelLow, LevelCritical, LevelShutdown} {
+		if v.String() == string(text) {
+			*l = v
+			return nil
+		}
+	}
+	return fmt.Errorf("diskmon: invalid level: '%s'", string(text))
+}
+
+// Config is the disk space monitor configuration.
+type Config struct {
+	// Paths are the monitored directories. All immediate subdirectories of
+	// the given directories are also monitored in case they reside on a
+	// different file system (e.g., because they are symlinks or mount points).
+	Paths []string
+
+	// Interval is the interval between checks.
+	Interval time.Duration
+
+	// LowThreshold is the percentage of free space below which the low level
+	// is entered.
+	LowThreshold float64
+	// CriticalThreshold is the percentage of free space below which the
+	// critical level is entered.
+	CriticalThreshold float64
+	// ShutdownThreshold is the percentage of free space below which the
+	// shutdown level is entered.
+	ShutdownThreshold float64
+}
+
+// ValidateBasic performs basic configuration validity checks.
+func (c *Config) ValidateBasic() error {
+	if len(c.Paths) == 0 {
+		return fmt.Errorf("diskmon: no monitored paths")
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("diskmon: invalid check interval: %s", c.Interval)
+	}
+	for _, t := range []float64{c.LowThreshold, c.CriticalThreshold, c.ShutdownThreshold} {
+		if t < 0 || t > 100 {
+			return fmt.Errorf("diskmon: invalid threshold: %f", t)
+		}
+	}
+	if c.LowThreshold < c.CriticalThreshold || c.CriticalThreshold < c.ShutdownThreshold {
+		return fmt.Errorf("diskmon: thresholds must satisfy low >= critical >= shutdown")
+	}
+	return nil
+}
+
+// Level returns the degradation level for the given percentage of free space.
+func (c *Config) Level(freePercent float64) Level {
+	switch {
+	case freePercent < c.ShutdownThreshold:
+		return LevelShutdown
+	case freePercent < c.CriticalThreshold:
+		return LevelCritical
+	case freePercent < c.LowThreshold:
+		return LevelLow
+	default:
+		return LevelOK
+	}
+}
+
+// PathStatus is the status of a single monitored file system.
+type PathStatus struct {
+	// Path is the (first) monitored path residing on the file system.
+	Path string `json:"path"`
+	// Total is the total size of the file system (in bytes).
+	Total uint64 `json:"total"`
+	// Free is the space available to the node (in bytes).
+	Free uint64 `json:"free"`
+	// FreePercent is the percentage of available space.
+	FreePercent float64 `json:"free_percent"`
+	// Level is the degradation level of the file system.
+	Level Level `json:"level"`
+}
+
+// Status is the disk space monitor status.
+type Status struct {
+	// Level is the overall (worst) degradation level.
+	Level Level `json:"level"`
+	// Paths is the status of all monitored file systems.
+	Paths []PathStatus `json:"paths"`
+	// LastCheck is the time of the last check.
+	LastCheck time.Time `json:"last_check"`
+	// LastError is the error encountered during the last check, if any.
+	LastError string `json:"last_error,omitempty"`
+}
+
+var (
+	diskFreeGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_node_disk_free_bytes",
+			Help: "Space available to the node on the file systems backing the data directory (bytes).",
+		},
+		[]string{"path"},
+	)
+	diskLevelGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "oasis_node_disk_level",
+			Help: "Disk space degradation level (0 - ok, 1 - low, 2 - critical, 3 - shutdown).",
+		},
+	)
+
+	diskmonCollectors = []prometheus.Collector{
+		diskFreeGauge,
+		diskLevelGauge,
+	}
+
+	metricsOnce sync.Once
+)
+
+// StatFunc returns the total and available space of the file system the
+// given path resides on, together with an identifier of the file system.
+type StatFunc func(path string) (total, free uint64, fsID uint64, err error)
+
+// Monitor is a disk space monitor.
+type Monitor struct {
+	service.BaseBackgroundService
+
+	sync.RWMutex
+
+	cfg    *Config
+	statFn StatFunc
+
+	status   Status
+	notifier *pubsub.Broker
+
+	ctx       context.Context
+	cancelCtx context.CancelFunc
+	quitCh    chan struct{}
+}
+
+// Implements service.BackgroundService.
+func (m *Monitor) Start() error {
+	go m.worker()
+	return nil
+}
+
+// Implements service.BackgroundService.
+func (m *Monitor) Stop() {
+	m.cancelCtx()
+}
+
+// Implements service.BackgroundService.
+func (m *Monitor) Quit() <-chan struct{} {
+	return m.quitCh
+}
+
+// Status returns the current disk space monitor status.
+func (m *Monitor) Status() *Status {
+	m.RLock()
+	defer m.RUnlock()
+
+	status := m.status
+	status.Paths = append([]PathStatus{}, m.status.Paths...)
+	return &status
+}
+
+// WatchLevel returns a channel that produces a stream of degradation levels.
+// The current level is sent immediately after subscribing.
+func (m *Monitor) WatchLevel() (<-chan Level, pubsub.ClosableSubscription) {
+	typedCh := make(chan Level)
+	sub := m.notifier.Subscribe()
+	sub.Unwrap(typedCh)
+	return typedCh, sub
+}
+
+// Check performs a single check and returns the updated status.
+func (m *Monitor) Check() *Status {
+	paths, err := m.collectPaths()
+	status := Status{
+		LastCheck: time.Now(),
+	}
+
+	// Deduplicate paths residing on the same file system.
+	seen := make(map[uint64]bool)
+	for _, path := range paths {
+		total, free, fsID, serr := m.statFn(path)
+		if serr != nil {
+			if err == nil {
+				err = serr
+			}
+			continue
+		}
+		if seen[fsID] {
+			continue
+		}
+		seen[fsID] = true
+
+		ps := PathStatus{
+			Path:  path,
+			Total: total,
+			Free:  free,
+		}
+		if total > 0 {
+			ps.FreePercent = 100 * float64(free) / float64(total)
+		} else {
+			ps.FreePercent = 100
+		}
+		ps.Level = m.cfg.Level(ps.FreePercent)
+		if ps.Level > status.Level {
+			status.Level = ps.Level
+		}
+		status.Paths = append(status.Paths, ps)
+
+		diskFreeGauge.WithLabelValues(path).Set(float64(free))
+	}
+	if err != nil {
+		status.LastError = err.Error()
+		m.Logger.Error("failed to check disk space",
+			"err", err,
+		)
+	}
+	diskLevelGauge.Set(float64(status.Level))
+
+	m.Lock()
+	oldLevel := m.status.Level
+	m.status = status
+	m.Unlock()
+
+	if status.Level != oldLevel {
+		logFn := m.Logger.Warn
+		if status.Level == LevelOK {
+			logFn = m.Logger.Info
+		}
+		logFn("disk space degradation level changed",
+			"old_level", oldLevel,
+			"level", status.Level,
+			"paths", status.Paths,
+		)
+	}
+	// Always broadcast so that watchers can retry their actions.
+	m.notifier.Broadcast(status.Level)
+
+	return m.Status()
+}
+
+func (m *Monitor) collectPaths() ([]string, error) {
+	var paths []string
+	for _, path := range m.cfg.Paths {
+		paths = append(paths, path)
+
+		entries, err := ioutil.ReadDir(path)
+		if err != nil {
+			return paths, fmt.Errorf("diskmon: failed to read directory '%s': %w", path, err)
+		}
+		for _, entry := range entries {
+			subPath := filepath.Join(path, entry.Name())
+			if entry.Mode()&os.ModeSymlink != 0 {
+				// Resolve symlinks as they may point to other file systems.
+				resolved, err := filepath.EvalSymlinks(subPath)
+				if err != nil {
+					continue
+				}
+				subPath = resolved
+			} else if !entry.IsDir() {
+				continue
+			}
+			paths = append(paths, subPath)
+		}
+	}
+	sort.Strings(paths[len(m.cfg.Paths):])
+	return paths, nil
+}
+
+func (m *Monitor) worker() {
+	defer close(m.quitCh)
+
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		m.Check()
+
+		select {
+		case <-ticker.C:
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
+func statFs(path string) (uint64, uint64, uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, 0, 0, fmt.Errorf("diskmon: failed to stat file system of '%s': %w", path, err)
+	}
+
+	var fi syscall.Stat_t
+	if err := syscall.Stat(path, &fi); err != nil {
+		return 0, 0, 0, fmt.Errorf("diskmon: failed to stat '%s': %w", path, err)
+	}
+
+	// Field types differ between platforms.
+	bsize := uint64(st.Bsize) // nolint: unconvert
+	fsID := uint64(fi.Dev)    // nolint: unconvert
+
+	return st.Blocks * bsize, st.Bavail * bsize, fsID, nil
+}
+
+// New creates a new disk space monitor.
+func New(cfg *Config) (*Monitor, error) {
+	return NewWithStatFunc(cfg, statFs)
+}
+
+// NewWithStatFunc creates a new disk space monitor which uses the given
+// function to query file system usage. This is mostly useful for tests.
+func NewWithStatFunc(cfg *Config, statFn StatFunc) (*Monitor, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(diskmonCollectors...)
+	})
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	return &Monitor{
+		BaseBackgroundService: *service.NewBaseBackgroundService("diskmon"),
+		cfg:                   cfg,
+		statFn:                statFn,
+		notifier:              pubsub.NewBroker(true),
+		ctx:                   ctx,
+		cancelCtx:             cancelCtx,
+		quitCh:                make(chan struct{}),
+	}, nil
+}
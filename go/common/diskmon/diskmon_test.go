@@ -0,0 +1,156 @@
+package diskmon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig(dir string) *Config {
+	return &Config{
+		Paths:             []string{dir},
+		Interval:          time.Second,
+		LowThreshold:      10,
+		CriticalThreshold: 5,
+		ShutdownThreshold: 2,
+	}
+}
+
+func TestConfigValidateBasic(t *testing.T) {
+	require := require.New(t)
+
+	cfg := testConfig("/tmp")
+	require.NoError(cfg.ValidateBasic(), "valid config")
+
+	invalid := *cfg
+	invalid.Paths = nil
+	require.Error(invalid.ValidateBasic(), "no paths")
+
+	invalid = *cfg
+	invalid.Interval = 0
+	require.Error(invalid.ValidateBasic(), "zero interval")
+
+	invalid = *cfg
+	invalid.LowThreshold = 101
+	require.Error(invalid.ValidateBasic(), "threshold above 100%")
+
+	invalid = *cfg
+	invalid.ShutdownThreshold = -1
+	require.Error(invalid.ValidateBasic(), "negative threshold")
+
+	invalid = *cfg
+	invalid.CriticalThreshold = 20
+	require.Error(invalid.ValidateBasic(), "critical above low")
+
+	invalid = *cfg
+	invalid.ShutdownThreshold = 6
+	require.Error(invalid.ValidateBasic(), "shutdown above critical")
+}
+
+func TestConfigLevel(t *testing.T) {
+	cfg := testConfig("/tmp")
+	for _, tc := range []struct {
+		free  float64
+		level Level
+	}{
+		{100, LevelOK},
+		{10, LevelOK},
+		{9.9, LevelLow},
+		{5, LevelLow},
+		{4.9, LevelCritical},
+		{2, LevelCritical},
+		{1.9, LevelShutdown},
+		{0, LevelShutdown},
+	} {
+		require.Equal(t, tc.level, cfg.Level(tc.free), "level for %f%% free", tc.free)
+	}
+}
+
+func TestLevelText(t *testing.T) {
+	require := require.New(t)
+
+	for _, l := range []Level{LevelOK, LevelLow, LevelCritical, LevelShutdown} {
+		text, err := l.MarshalText()
+		require.NoError(err, "MarshalText")
+
+		var decoded Level
+		err = decoded.UnmarshalText(text)
+		require.NoError(err, "UnmarshalText")
+		require.Equal(l, decoded, "round trip")
+	}
+
+	var l Level
+	require.Error(l.UnmarshalText([]byte("invalid")), "invalid level")
+}
+
+func TestMonitor(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "oasis-diskmon-test")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	// One subdirectory on the same file system and one on a different one.
+	sameDir := filepath.Join(dir, "same")
+	otherDir := filepath.Join(dir, "other")
+	require.NoError(os.Mkdir(sameDir, 0o700), "Mkdir")
+	require.NoError(os.Mkdir(otherDir, 0o700), "Mkdir")
+	require.NoError(ioutil.WriteFile(filepath.Join(dir, "file"), []byte("data"), 0o600), "WriteFile")
+
+	var (
+		lock      sync.Mutex
+		otherFree uint64 = 50
+	)
+	statFn := func(path string) (uint64, uint64, uint64, error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		switch path {
+		case otherDir:
+			return 100, otherFree, 2, nil
+		default:
+			return 1000, 500, 1, nil
+		}
+	}
+
+	m, err := NewWithStatFunc(testConfig(dir), statFn)
+	require.NoError(err, "NewWithStatFunc")
+
+	ch, sub := m.WatchLevel()
+	defer sub.Close()
+
+	status := m.Check()
+	require.Equal(LevelOK, status.Level, "overall level")
+	require.Len(status.Paths, 2, "paths should be deduplicated by file system")
+	require.Equal(dir, status.Paths[0].Path)
+	require.EqualValues(50, status.Paths[0].FreePercent)
+	require.Equal(otherDir, status.Paths[1].Path)
+	require.Empty(status.LastError)
+	require.Equal(LevelOK, <-ch)
+
+	for _, tc := range []struct {
+		free  uint64
+		level Level
+	}{
+		{9, LevelLow},
+		{3, LevelCritical},
+		{1, LevelShutdown},
+		{20, LevelOK},
+	} {
+		lock.Lock()
+		otherFree = tc.free
+		lock.Unlock()
+
+		status = m.Check()
+		require.Equal(tc.level, status.Level, "overall level with %d%% free", tc.free)
+		require.Equal(tc.level, status.Paths[1].Level, "path level")
+		require.Equal(LevelOK, status.Paths[0].Level, "path level")
+		require.Equal(tc.level, <-ch, "watched level")
+		require.Equal(status.Level, m.Status().Level, "status level")
+	}
+}
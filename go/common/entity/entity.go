@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/url"
 	"path/filepath"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
@@ -32,14 +33,29 @@ var (
 const (
 	// LatestDescriptorVersion is the latest descriptor version that should be
 	// used for all new descriptors. Using earlier versions may be rejected.
-	LatestDescriptorVersion = 2
+	LatestDescriptorVersion = 3
 
 	// MinDescriptorVersion is the minimum descriptor version that is allowed.
 	MinDescriptorVersion = 1
 	// MaxDescriptorVersion is the maximum descriptor version that is allowed.
 	MaxDescriptorVersion = LatestDescriptorVersion
+
+	// MaxMetadataURLLength is the maximum length of the entity metadata URL.
+	MaxMetadataURLLength = 1024
 )
 
+// KeyUsage are the declared usage constraints of the entity signing key.
+type KeyUsage struct {
+	// CanSignNodes specifies whether the entity is allowed to control nodes.
+	CanSignNodes bool `json:"can_sign_nodes,omitempty"`
+
+	// CanSignRuntimes specifies whether the entity is allowed to control runtimes.
+	CanSignRuntimes bool `json:"can_sign_runtimes,omitempty"`
+
+	// CanSignGovernance specifies whether the entity is allowed to take part in governance.
+	CanSignGovernance bool `json:"can_sign_governance,omitempty"`
+}
+
 // Entity represents an entity that controls one or more Nodes and or
 // services.
 type Entity struct { // nolint: maligned
@@ -52,11 +68,20 @@ type Entity struct { // nolint: maligned
 	// will sign the descriptor with the node signing key rather than the
 	// entity signing key.
 	Nodes []signature.PublicKey `json:"nodes,omitempty"`
+
+	// MetadataURL is an optional URL of the entity metadata (e.g., name,
+	// contact information).
+	MetadataURL string `json:"metadata_url,omitempty"`
+
+	// KeyUsage are the optional usage constraints of the entity signing key.
+	// If not set, the key is not constrained.
+	KeyUsage *KeyUsage `json:"key_usage,omitempty"`
 }
 
-// UnmarshalCBOR is a custom deserializer that handles both v1 and v2 Entity
-// structures.  A v1 structure is converted to v2 seamlessly if the field
+// UnmarshalCBOR is a custom deserializer that handles v1, v2 and v3 Entity
+// structures.  A v1 structure is converted to v3 seamlessly if the field
 // AllowEntitySignedNodes is false or missing, otherwise an error is returned.
+// A v2 structure is always converted to v3 seamlessly.
 func (e *Entity) UnmarshalCBOR(data []byte) error {
 	// Determine Entity structure version.
 	v, err := cbor.GetVersion(data)
@@ -71,6 +96,10 @@ func (e *Entity) UnmarshalCBOR(data []byte) error {
 			ID                     signature.PublicKey   `json:"id"`
 			Nodes                  []signature.PublicKey `json:"nodes,omitempty"`
 			AllowEntitySignedNodes bool                  `json:"allow_entity_signed_nodes,omitempty"`
+
+			// Decoded only to be rejected, the fields were added in version 3.
+			MetadataURL string    `json:"metadata_url,omitempty"`
+			KeyUsage    *KeyUsage `json:"key_usage,omitempty"`
 		}
 		var ev1 EntityV1
 		if err = cbor.Unmarshal(data, &ev1); err != nil {
@@ -80,15 +109,41 @@ func (e *Entity) UnmarshalCBOR(data []byte) error {
 		if ev1.AllowEntitySignedNodes {
 			return fmt.Errorf("entity descriptor must have allow_entity_signed_nodes set to false")
 		}
+		if ev1.MetadataURL != "" || ev1.KeyUsage != nil {
+			return fmt.Errorf("entity descriptor version %d does not support metadata URL or key usage", v)
+		}
 		// Convert into new format.
-		e.Versioned = cbor.NewVersioned(2)
+		e.Versioned = cbor.NewVersioned(3)
 		e.ID = ev1.ID
 		e.Nodes = ev1.Nodes
 		return nil
 	case 2:
+		// Old version did not have the metadata URL and key usage fields.
+		type EntityV2 struct {
+			cbor.Versioned
+			ID    signature.PublicKey   `json:"id"`
+			Nodes []signature.PublicKey `json:"nodes,omitempty"`
+
+			// Decoded only to be rejected, the fields were added in version 3.
+			MetadataURL string    `json:"metadata_url,omitempty"`
+			KeyUsage    *KeyUsage `json:"key_usage,omitempty"`
+		}
+		var ev2 EntityV2
+		if err = cbor.Unmarshal(data, &ev2); err != nil {
+			return err
+		}
+		if ev2.MetadataURL != "" || ev2.KeyUsage != nil {
+			return fmt.Errorf("entity descriptor version %d does not support metadata URL or key usage", v)
+		}
+		// Convert into new format.
+		e.Versioned = cbor.NewVersioned(3)
+		e.ID = ev2.ID
+		e.Nodes = ev2.Nodes
+		return nil
+	case 3:
 		// New version, call the default unmarshaler.
-		type ev2 Entity
-		return cbor.Unmarshal(data, (*ev2)(e))
+		type ev3 Entity
+		return cbor.Unmarshal(data, (*ev3)(e))
 	default:
 		return fmt.Errorf("invalid entity descriptor version: %v", v)
 	}
@@ -116,9 +171,40 @@ func (e *Entity) ValidateBasic(strictVersion bool) error {
 			)
 		}
 	}
+
+	if e.MetadataURL != "" {
+		if len(e.MetadataURL) > MaxMetadataURLLength {
+			return fmt.Errorf("metadata URL too long (max: %d)", MaxMetadataURLLength)
+		}
+		u, err := url.Parse(e.MetadataURL)
+		if err != nil {
+			return fmt.Errorf("malformed metadata URL: %w", err)
+		}
+		if u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("metadata URL must be an absolute https URL")
+		}
+	}
+	if !e.CanSignNodes() && len(e.Nodes) > 0 {
+		return fmt.Errorf("entity key usage does not allow nodes")
+	}
 	return nil
 }
 
+// CanSignNodes returns true iff the entity is allowed to control nodes.
+func (e *Entity) CanSignNodes() bool {
+	return e.KeyUsage == nil || e.KeyUsage.CanSignNodes
+}
+
+// CanSignRuntimes returns true iff the entity is allowed to control runtimes.
+func (e *Entity) CanSignRuntimes() bool {
+	return e.KeyUsage == nil || e.KeyUsage.CanSignRuntimes
+}
+
+// CanSignGovernance returns true iff the entity is allowed to take part in governance.
+func (e *Entity) CanSignGovernance() bool {
+	return e.KeyUsage == nil || e.KeyUsage.CanSignGovernance
+}
+
 // HasNode checks if the given node is in this entity's node whitelist.
 func (e *Entity) HasNode(id signature.PublicKey) bool {
 	for _, pk := range e.Nodes {
@@ -195,6 +281,8 @@ func GenerateWithSigner(baseDir string, signer signature.Signer, template *Entit
 	}
 	if template != nil {
 		ent.Nodes = template.Nodes
+		ent.MetadataURL = template.MetadataURL
+		ent.KeyUsage = template.KeyUsage
 	}
 
 	if err := ent.Save(baseDir); err != nil {
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -30,14 +31,14 @@ func TestEntityDescriptorVersioning(t *testing.T) {
 	require.NoError(cbor.Unmarshal(cbor.Marshal(ev1), &uv1t1), "v1 unmarshal with no AllowEntitySignedNodes field should pass")
 	require.EqualValues(ev1.ID, uv1t1.ID)
 	require.EqualValues(ev1.Nodes, uv1t1.Nodes)
-	require.EqualValues(cbor.NewVersioned(2), uv1t1.Versioned)
+	require.EqualValues(cbor.NewVersioned(3), uv1t1.Versioned)
 
 	var uv1t2 Entity
 	ev1.AllowEntitySignedNodes = false
 	require.NoError(cbor.Unmarshal(cbor.Marshal(ev1), &uv1t2), "v1 unmarshal with AllowEntitySignedNodes field set to false should pass")
 	require.EqualValues(ev1.ID, uv1t2.ID)
 	require.EqualValues(ev1.Nodes, uv1t2.Nodes)
-	require.EqualValues(cbor.NewVersioned(2), uv1t2.Versioned)
+	require.EqualValues(cbor.NewVersioned(3), uv1t2.Versioned)
 
 	var uv1t3 Entity
 	ev1.AllowEntitySignedNodes = true
@@ -56,5 +57,91 @@ func TestEntityDescriptorVersioning(t *testing.T) {
 	require.NoError(cbor.Unmarshal(cbor.Marshal(ev2), &uv2t1), "v2 unmarshal should pass")
 	require.EqualValues(ev2.ID, uv2t1.ID)
 	require.EqualValues(ev2.Nodes, uv2t1.Nodes)
-	require.EqualValues(cbor.NewVersioned(2), uv2t1.Versioned)
+	require.EqualValues(cbor.NewVersioned(3), uv2t1.Versioned)
+	require.Empty(uv2t1.MetadataURL)
+	require.Nil(uv2t1.KeyUsage)
+
+	var uv2t2 Entity
+	ev2m := ev2
+	ev2m.MetadataURL = "https://example.com/entity.json"
+	err := cbor.Unmarshal(cbor.Marshal(ev2m), &uv2t2)
+	require.EqualError(err, "entity descriptor version 2 does not support metadata URL or key usage", "v2 unmarshal with a metadata URL should fail")
+
+	var uv2t3 Entity
+	ev2k := ev2
+	ev2k.KeyUsage = &KeyUsage{CanSignNodes: true}
+	err = cbor.Unmarshal(cbor.Marshal(ev2k), &uv2t3)
+	require.EqualError(err, "entity descriptor version 2 does not support metadata URL or key usage", "v2 unmarshal with key usage should fail")
+
+	var uv1t4 Entity
+	ev1.AllowEntitySignedNodes = false
+	ev1.KeyUsage = &KeyUsage{}
+	err = cbor.Unmarshal(cbor.Marshal(ev1), &uv1t4)
+	require.EqualError(err, "entity descriptor version 1 does not support metadata URL or key usage", "v1 unmarshal with key usage should fail")
+
+	k3 := memorySigner.NewTestSigner("test entity v3")
+	ev3 := Entity{
+		Versioned:   cbor.NewVersioned(3),
+		ID:          k3.Public(),
+		MetadataURL: "https://example.com/entity.json",
+		KeyUsage: &KeyUsage{
+			CanSignRuntimes:   true,
+			CanSignGovernance: true,
+		},
+	}
+
+	var uv3t1 Entity
+	require.NoError(cbor.Unmarshal(cbor.Marshal(ev3), &uv3t1), "v3 unmarshal should pass")
+	require.EqualValues(ev3, uv3t1)
+	require.NoError(uv3t1.ValidateBasic(true), "v3 descriptor should be valid")
+	require.False(uv3t1.CanSignNodes())
+	require.True(uv3t1.CanSignRuntimes())
+	require.True(uv3t1.CanSignGovernance())
+}
+
+func TestEntityDescriptorValidateBasic(t *testing.T) {
+	require := require.New(t)
+
+	signer := memorySigner.NewTestSigner("test entity validate basic")
+	nodeSigner := memorySigner.NewTestSigner("test entity validate basic node")
+
+	ent := Entity{
+		Versioned: cbor.NewVersioned(LatestDescriptorVersion),
+		ID:        signer.Public(),
+		Nodes:     []signature.PublicKey{nodeSigner.Public()},
+	}
+	require.NoError(ent.ValidateBasic(true), "unconstrained entity should be valid")
+	require.True(ent.CanSignNodes())
+	require.True(ent.CanSignRuntimes())
+	require.True(ent.CanSignGovernance())
+
+	ent.KeyUsage = &KeyUsage{CanSignNodes: true}
+	require.NoError(ent.ValidateBasic(true), "entity allowed to have nodes should be valid")
+	ent.KeyUsage = &KeyUsage{CanSignGovernance: true}
+	require.Error(ent.ValidateBasic(true), "entity with nodes not allowed to have nodes should be invalid")
+	ent.Nodes = nil
+	require.NoError(ent.ValidateBasic(true))
+
+	for _, tc := range []struct {
+		url   string
+		valid bool
+	}{
+		{"https://example.com/entity.json", true},
+		{"http://example.com/entity.json", false},
+		{"https:///entity.json", false},
+		{"example.com/entity.json", false},
+		{"https://example.com/" + strings.Repeat("a", MaxMetadataURLLength), false},
+	} {
+		ent.MetadataURL = tc.url
+		switch tc.valid {
+		case true:
+			require.NoError(ent.ValidateBasic(true), "metadata URL %s should be valid", tc.url)
+		case false:
+			require.Error(ent.ValidateBasic(true), "metadata URL %s should be invalid", tc.url)
+		}
+	}
+
+	ent.MetadataURL = "https://example.com/entity.json"
+	ent.Versioned = cbor.NewVersioned(2)
+	require.Error(ent.ValidateBasic(true), "non-latest descriptor should be invalid in strict mode")
 }
@@ -0,0 +1,217 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// CompressionNone disables compression of client requests.
+	CompressionNone = "none"
+	// CompressionAuto compresses client requests using the per-service default compressors.
+	CompressionAuto = "auto"
+	// CompressorGzip is the name of the gzip compressor.
+	CompressorGzip = gzip.Name
+	// CompressorZstd is the name of the zstd compressor.
+	CompressorZstd = "zstd"
+)
+
+var serviceCompressors sync.Map
+
+// WithDefaultCompression sets the compressor that clients which opted into automatic compression
+// use by default when calling methods of the service and returns the service name.
+//
+// Servers accept requests compressed with any registered compressor and compress responses using
+// the same compressor as the request.
+func (sn ServiceName) WithDefaultCompression(compressor string) ServiceName {
+	switch compressor {
+	case CompressorGzip, CompressorZstd:
+	default:
+		panic(fmt.Errorf("service: unsupported compressor: %s", compressor))
+	}
+	serviceCompressors.Store(sn, compressor)
+	return sn
+}
+
+// DefaultCompression returns the default compressor of the service, if any.
+func (sn ServiceName) DefaultCompression() (string, bool) {
+	compressor, ok := serviceCompressors.Load(sn)
+	if !ok {
+		return "", false
+	}
+	return compressor.(string), true
+}
+
+// ValidateCompression checks whether the given client compression mode is valid.
+func ValidateCompression(mode string) error {
+	switch mode {
+	case CompressionNone, CompressionAuto, CompressorGzip, CompressorZstd:
+		return nil
+	default:
+		return fmt.Errorf("grpc: unsupported compression mode: %s", mode)
+	}
+}
+
+// compressionNegotiator selects the compressor for client calls and remembers the compressors
+// that the remote server does not support.
+type compressionNegotiator struct {
+	mode        string
+	unsupported sync.Map
+}
+
+// compressorFor returns the compressor that should be used for the given method, if any.
+func (n *compressionNegotiator) compressorFor(method string, opts []grpc.CallOption) string {
+	// Respect any explicit per-call compressor selection.
+	for _, opt := range opts {
+		if _, ok := opt.(grpc.CompressorCallOption); ok {
+			return ""
+		}
+	}
+
+	var compressor string
+	switch n.mode {
+	case CompressionNone:
+		return ""
+	case CompressionAuto:
+		compressor, _ = ServiceNameFromMethod(method).DefaultCompression()
+	default:
+		compressor = n.mode
+	}
+	if compressor == "" {
+		return ""
+	}
+	if _, unsupported := n.unsupported.Load(compressor); unsupported {
+		return ""
+	}
+	return compressor
+}
+
+// isUnsupportedCompressor returns true iff the error indicates that the remote server does not
+// support the given compressor.
+func isUnsupportedCompressor(err error, compressor string) bool {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Unimplemented {
+		return false
+	}
+	return strings.Contains(st.Message(), "Decompressor is not installed") && strings.Contains(st.Message(), compressor)
+}
+
+func (n *compressionNegotiator) unaryInterceptor(
+	ctx context.Context,
+	method string,
+	req, rsp interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	compressor := n.compressorFor(method, opts)
+	if compressor == "" {
+		return invoker(ctx, method, req, rsp, cc, opts...)
+	}
+
+	err := invoker(ctx, method, req, rsp, cc, append(opts, grpc.UseCompressor(compressor))...)
+	if !isUnsupportedCompressor(err, compressor) {
+		return err
+	}
+
+	// The server does not support the compressor, retry uncompressed and stop using it.
+	n.unsupported.Store(compressor, true)
+	return invoker(ctx, method, req, rsp, cc, opts...)
+}
+
+func (n *compressionNegotiator) streamInterceptor(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	// NOTE: Streams are not retried as the server only rejects the compressor after the stream
+	//       has been established, they only use compressors that have not been rejected before.
+	if compressor := n.compressorFor(method, opts); compressor != "" {
+		opts = append(opts, grpc.UseCompressor(compressor))
+	}
+	return streamer(ctx, desc, cc, method, opts...)
+}
+
+func newCompressionNegotiator(mode string) *compressionNegotiator {
+	return &compressionNegotiator{
+		mode: mode,
+	}
+}
+
+// zstdCompressor implements gRPC's encoding.Compressor interface using zstd.
+//
+// Whole messages are (de)compressed at once as gRPC buffers them anyway, which allows a single
+// encoder and decoder to be shared between concurrent calls.
+type zstdCompressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+type zstdWriter struct {
+	bytes.Buffer
+
+	encoder *zstd.Encoder
+	w       io.Writer
+}
+
+func (z *zstdWriter) Close() error {
+	_, err := z.w.Write(z.encoder.EncodeAll(z.Bytes(), nil))
+	return err
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return &zstdWriter{
+		encoder: c.encoder,
+		w:       w,
+	}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data, err = c.decoder.DecodeAll(data, nil)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+func (c *zstdCompressor) Name() string {
+	return CompressorZstd
+}
+
+func newZstdCompressor() *zstdCompressor {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		panic(fmt.Errorf("grpc: failed to create zstd encoder: %w", err))
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxRecvMsgSize))
+	if err != nil {
+		panic(fmt.Errorf("grpc: failed to create zstd decoder: %w", err))
+	}
+	return &zstdCompressor{
+		encoder: encoder,
+		decoder: decoder,
+	}
+}
+
+func init() {
+	encoding.RegisterCompressor(newZstdCompressor())
+}
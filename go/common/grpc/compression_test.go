@@ -0,0 +1,188 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+)
+
+var compressionTestServiceName = NewServiceName("CompressionTestService").WithDefaultCompression(CompressorZstd)
+
+type CompressionTestRequest struct {
+	Payload []byte `json:"payload"`
+}
+
+type CompressionTestResponse struct {
+	Payload     []byte `json:"payload"`
+	Compression string `json:"compression"`
+}
+
+type CompressionTestService interface {
+	Echo(context.Context, *CompressionTestRequest) (*CompressionTestResponse, error)
+}
+
+type compressionTestServer struct{}
+
+func (s *compressionTestServer) Echo(ctx context.Context, req *CompressionTestRequest) (*CompressionTestResponse, error) {
+	rsp := &CompressionTestResponse{
+		Payload: req.Payload,
+	}
+	if st, ok := grpc.ServerTransportStreamFromContext(ctx).(interface{ RecvCompress() string }); ok {
+		rsp.Compression = st.RecvCompress()
+	}
+	return rsp, nil
+}
+
+func handlerCompressionTestEcho( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	req := new(CompressionTestRequest)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompressionTestService).Echo(ctx, req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: fmt.Sprintf("/%s/Echo", compressionTestServiceName),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompressionTestService).Echo(ctx, req.(*CompressionTestRequest))
+	}
+	return interceptor(ctx, req, info, handler)
+}
+
+var compressionTestServiceDesc = grpc.ServiceDesc{
+	ServiceName: string(compressionTestServiceName),
+	HandlerType: (*CompressionTestService)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Echo",
+			Handler:    handlerCompressionTestEcho,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func TestCompression(t *testing.T) {
+	require := require.New(t)
+
+	// Generate temporary filename for the socket.
+	f, err := ioutil.TempFile("", "oasis-grpc-compression-test-socket")
+	require.NoError(err, "TempFile")
+	// Remove the file as we only need the name.
+	f.Close()
+	os.Remove(f.Name())
+
+	grpcServer, err := NewServer(&ServerConfig{
+		Path: f.Name(),
+	})
+	require.NoError(err, "NewServer")
+	defer os.Remove(f.Name())
+
+	grpcServer.Server().RegisterService(&compressionTestServiceDesc, &compressionTestServer{})
+
+	err = grpcServer.Start()
+	require.NoErrorf(err, "Failed to start the gRPC server")
+	defer grpcServer.Stop()
+
+	payload := bytes.Repeat([]byte("compressible payload "), 10_000)
+	method := fmt.Sprintf("/%s/Echo", compressionTestServiceName)
+
+	defer viper.Set(CfgClientCompression, CompressionNone)
+	for _, tc := range []struct {
+		mode     string
+		expected string
+	}{
+		{CompressionNone, ""},
+		{CompressionAuto, CompressorZstd},
+		{CompressorGzip, CompressorGzip},
+		{CompressorZstd, CompressorZstd},
+	} {
+		viper.Set(CfgClientCompression, tc.mode)
+
+		conn, err := Dial("unix:"+f.Name(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(err, "Dial")
+
+		var rsp CompressionTestResponse
+		err = conn.Invoke(context.Background(), method, &CompressionTestRequest{Payload: payload}, &rsp)
+		require.NoError(err, "Invoke (%s)", tc.mode)
+		require.Equal(payload, rsp.Payload, "payload should survive compression (%s)", tc.mode)
+		require.Equal(tc.expected, rsp.Compression, "request should use the expected compressor (%s)", tc.mode)
+
+		// Explicit per-call compressor selection should take precedence.
+		err = conn.Invoke(context.Background(), method, &CompressionTestRequest{Payload: payload}, &rsp, grpc.UseCompressor(CompressorGzip))
+		require.NoError(err, "Invoke with explicit compressor (%s)", tc.mode)
+		require.Equal(CompressorGzip, rsp.Compression, "explicit compressor should be used (%s)", tc.mode)
+
+		conn.Close()
+	}
+
+	viper.Set(CfgClientCompression, "brotli")
+	_, err = Dial("unix:"+f.Name(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.Error(err, "Dial should fail with an unsupported compression mode")
+}
+
+func TestCompressionNegotiation(t *testing.T) {
+	require := require.New(t)
+
+	method := fmt.Sprintf("/%s/Echo", compressionTestServiceName)
+	negotiator := newCompressionNegotiator(CompressionAuto)
+
+	// Simulate a server that does not support zstd.
+	var used []string
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		var compressor string
+		for _, opt := range opts {
+			if co, ok := opt.(grpc.CompressorCallOption); ok {
+				compressor = co.CompressorType
+			}
+		}
+		used = append(used, compressor)
+		if compressor == CompressorZstd {
+			return status.Errorf(codes.Unimplemented, "grpc: Decompressor is not installed for grpc-encoding %q", compressor)
+		}
+		return nil
+	}
+
+	err := negotiator.unaryInterceptor(context.Background(), method, nil, nil, nil, invoker)
+	require.NoError(err, "call should fall back to no compression")
+	require.Equal([]string{CompressorZstd, ""}, used)
+
+	// The unsupported compressor should not be used again.
+	used = nil
+	err = negotiator.unaryInterceptor(context.Background(), method, nil, nil, nil, invoker)
+	require.NoError(err, "call should not use the unsupported compressor")
+	require.Equal([]string{""}, used)
+
+	// Other errors should not cause a fallback.
+	negotiator = newCompressionNegotiator(CompressorZstd)
+	used = nil
+	err = negotiator.unaryInterceptor(context.Background(), method, nil, nil, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			used = append(used, "")
+			return status.Errorf(codes.Unimplemented, "unknown method")
+		},
+	)
+	require.Error(err, "other errors should be propagated")
+	require.Len(used, 1, "other errors should not be retried")
+
+	// Methods of services without a default compressor should not be compressed in auto mode.
+	require.Equal("", newCompressionNegotiator(CompressionAuto).compressorFor("/oasis-core.Unknown/Method", nil))
+	require.NotNil(encoding.GetCompressor(CompressorZstd), "zstd compressor should be registered")
+}
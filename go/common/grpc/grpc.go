@@ -31,6 +31,8 @@ import (
 const (
 	// CfgLogDebug enables verbose gRPC debug output.
 	CfgLogDebug = "grpc.log.debug"
+	// CfgClientCompression configures the compression of gRPC client requests.
+	CfgClientCompression = "grpc.client.compression"
 
 	maxRecvMsgSize = 104857600 // 100 MiB
 	maxSendMsgSize = 104857600 // 100 MiB
@@ -66,6 +68,34 @@ var (
 		},
 		[]string{"call"},
 	)
+	grpcServerPeerCalls = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_grpc_server_peer_calls",
+			Help: "Number of gRPC calls by caller identity class.",
+		},
+		[]string{"call", "peer"},
+	)
+	grpcServerPeerLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_grpc_server_peer_latency",
+			Help: "gRPC call latency by caller identity class (seconds).",
+		},
+		[]string{"call", "peer"},
+	)
+	grpcServerPeerInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_grpc_server_peer_in_flight",
+			Help: "Number of gRPC calls currently being served by caller identity class.",
+		},
+		[]string{"call", "peer"},
+	)
+	grpcServerPeerStreamWrites = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_grpc_server_peer_stream_writes",
+			Help: "Number of gRPC stream writes by caller identity class.",
+		},
+		[]string{"call", "peer"},
+	)
 	grpcClientCalls = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "oasis_grpc_client_calls",
@@ -95,6 +125,10 @@ var (
 		grpcServerCalls,
 		grpcServerLatency,
 		grpcServerStreamWrites,
+		grpcServerPeerCalls,
+		grpcServerPeerLatency,
+		grpcServerPeerInFlight,
+		grpcServerPeerStreamWrites,
 	}
 
 	serverKeepAliveParams = keepalive.ServerParameters{
@@ -440,6 +474,9 @@ type ServerConfig struct { // nolint: maligned
 	// ClientCommonName is the expected common name on client TLS certificates. If not specified,
 	// the default identity.CommonName will be used.
 	ClientCommonName string
+	// PeerClassifier is the classifier used to label the per-peer server metrics with the identity
+	// class of the caller. If not specified, per-peer server metrics are not exported.
+	PeerClassifier PeerClassifier
 	// CustomOptions is an array of extra options for the grpc server.
 	CustomOptions []grpc.ServerOption
 }
@@ -601,16 +638,25 @@ func NewServer(config *ServerConfig) (*Server, error) {
 		config.ClientCommonName = identity.CommonName
 	}
 	var wrapper *grpcWrapper
-	unaryInterceptors := []grpc.UnaryServerInterceptor{
+	var unaryInterceptors []grpc.UnaryServerInterceptor
+	var streamInterceptors []grpc.StreamServerInterceptor
+	if config.PeerClassifier != nil {
+		metrics := &serverPeerMetrics{
+			classifier: config.PeerClassifier,
+		}
+		unaryInterceptors = append(unaryInterceptors, metrics.unaryInterceptor)
+		streamInterceptors = append(streamInterceptors, metrics.streamInterceptor)
+	}
+	unaryInterceptors = append(unaryInterceptors,
 		logAdapter.unaryLogger,
 		serverUnaryErrorMapper,
 		auth.UnaryServerInterceptor(config.AuthFunc),
-	}
-	streamInterceptors := []grpc.StreamServerInterceptor{
+	)
+	streamInterceptors = append(streamInterceptors,
 		logAdapter.streamLogger,
 		serverStreamErrorMapper,
 		auth.StreamServerInterceptor(config.AuthFunc),
-	}
+	)
 	if config.InstallWrapper {
 		wrapper = newWrapper()
 		unaryInterceptors = append(unaryInterceptors, wrapper.unaryInterceptor)
@@ -672,6 +718,12 @@ func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 		prometheus.MustRegister(grpcCollectors...)
 	})
 
+	compression := viper.GetString(CfgClientCompression)
+	if err := ValidateCompression(compression); err != nil {
+		return nil, err
+	}
+	negotiator := newCompressionNegotiator(compression)
+
 	logger := logging.GetLogger("grpc/client")
 	logAdapter := newGrpcLogAdapter(logger)
 	dialOpts := []grpc.DialOption{
@@ -680,8 +732,8 @@ func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 			grpc.MaxCallSendMsgSize(maxSendMsgSize),
 			grpc.MaxCallRecvMsgSize(maxRecvMsgSize),
 		),
-		grpc.WithChainUnaryInterceptor(logAdapter.unaryClientLogger, clientUnaryErrorMapper),
-		grpc.WithChainStreamInterceptor(logAdapter.streamClientLogger, clientStreamErrorMapper),
+		grpc.WithChainUnaryInterceptor(logAdapter.unaryClientLogger, clientUnaryErrorMapper, negotiator.unaryInterceptor),
+		grpc.WithChainStreamInterceptor(logAdapter.streamClientLogger, clientStreamErrorMapper, negotiator.streamInterceptor),
 	}
 	dialOpts = append(dialOpts, opts...)
 	return grpc.Dial(target, dialOpts...)
@@ -690,6 +742,7 @@ func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 func init() {
 	Flags.Bool(CfgLogDebug, false, "gRPC request/responses in debug logs (very verbose)")
 	_ = Flags.MarkHidden(CfgLogDebug)
+	Flags.String(CfgClientCompression, CompressionNone, "gRPC client request compression (none, auto, gzip, zstd)")
 
 	_ = viper.BindPFlags(Flags)
 }
@@ -0,0 +1,63 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+// serverPeerMetrics is a set of server interceptors that export per-method metrics labeled by
+// the identity class of the caller.
+type serverPeerMetrics struct {
+	classifier PeerClassifier
+}
+
+func (m *serverPeerMetrics) labels(ctx context.Context, method string) prometheus.Labels {
+	return prometheus.Labels{
+		"call": method,
+		"peer": string(m.classifier.ClassifyPeer(ctx)),
+	}
+}
+
+func (m *serverPeerMetrics) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	labels := m.labels(ctx, info.FullMethod)
+	grpcServerPeerCalls.With(labels).Inc()
+
+	inFlight := grpcServerPeerInFlight.With(labels)
+	inFlight.Inc()
+	defer inFlight.Dec()
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	grpcServerPeerLatency.With(labels).Observe(time.Since(start).Seconds())
+
+	return resp, err
+}
+
+func (m *serverPeerMetrics) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	labels := m.labels(ss.Context(), info.FullMethod)
+	grpcServerPeerCalls.With(labels).Inc()
+
+	inFlight := grpcServerPeerInFlight.With(labels)
+	inFlight.Inc()
+	defer inFlight.Dec()
+
+	return handler(srv, &metricsServerStream{
+		ServerStream: ss,
+		writes:       grpcServerPeerStreamWrites.With(labels),
+	})
+}
+
+// metricsServerStream wraps the server stream and counts all sent messages.
+type metricsServerStream struct {
+	grpc.ServerStream
+
+	writes prometheus.Counter
+}
+
+func (s *metricsServerStream) SendMsg(m interface{}) error {
+	s.writes.Inc()
+	return s.ServerStream.SendMsg(m)
+}
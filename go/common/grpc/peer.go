@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/oasisprotocol/oasis-core/go/common/accessctl"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+// ForwardedSubjectMD is name of the metadata field in which the actual subject should be passed in
+// case sentry forwarded the request.
+const ForwardedSubjectMD = "forwarded-subject"
+
+// PeerClass is the identity class of a gRPC caller.
+type PeerClass string
+
+const (
+	// PeerClassAnonymous is the class of callers that did not present a client certificate.
+	PeerClassAnonymous PeerClass = "anonymous"
+	// PeerClassAuthenticated is the class of callers that presented a client certificate which
+	// does not belong to any known committee member.
+	PeerClassAuthenticated PeerClass = "authenticated"
+	// PeerClassCommittee is the class of callers that presented a client certificate belonging to
+	// a known committee member.
+	PeerClassCommittee PeerClass = "committee"
+	// PeerClassSentryProxied is the class of callers whose requests were forwarded by a sentry.
+	PeerClassSentryProxied PeerClass = "sentry_proxied"
+)
+
+// PeerClassifier classifies gRPC callers by their identity.
+type PeerClassifier interface {
+	// ClassifyPeer returns the identity class of the caller of the request with the given context.
+	ClassifyPeer(ctx context.Context) PeerClass
+}
+
+// CommitteePeerClassifier is a peer classifier that recognizes committee members based on the
+// TLS public keys of the committee nodes.
+type CommitteePeerClassifier struct {
+	sync.RWMutex
+
+	committees map[string]map[accessctl.Subject]bool
+}
+
+// ClassifyPeer implements PeerClassifier.
+func (c *CommitteePeerClassifier) ClassifyPeer(ctx context.Context) PeerClass {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(ForwardedSubjectMD)) > 0 {
+		return PeerClassSentryProxied
+	}
+
+	peer, ok := peer.FromContext(ctx)
+	if !ok {
+		return PeerClassAnonymous
+	}
+	tlsAuth, ok := peer.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsAuth.State.PeerCertificates) != 1 {
+		return PeerClassAnonymous
+	}
+	subject := accessctl.SubjectFromX509Certificate(tlsAuth.State.PeerCertificates[0])
+
+	c.RLock()
+	defer c.RUnlock()
+	for _, members := range c.committees {
+		if members[subject] {
+			return PeerClassCommittee
+		}
+	}
+	return PeerClassAuthenticated
+}
+
+// SetCommitteeMembers replaces the TLS public keys of the members of the given committee.
+//
+// Passing an empty list of keys removes the committee.
+func (c *CommitteePeerClassifier) SetCommitteeMembers(committee string, keys []signature.PublicKey) {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(keys) == 0 {
+		delete(c.committees, committee)
+		return
+	}
+
+	members := make(map[accessctl.Subject]bool, len(keys))
+	for _, key := range keys {
+		members[accessctl.SubjectFromPublicKey(key)] = true
+	}
+	c.committees[committee] = members
+}
+
+// NewCommitteePeerClassifier creates a new (empty) CommitteePeerClassifier.
+func NewCommitteePeerClassifier() *CommitteePeerClassifier {
+	return &CommitteePeerClassifier{
+		committees: make(map[string]map[accessctl.Subject]bool),
+	}
+}
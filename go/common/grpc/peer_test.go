@@ -0,0 +1,52 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	cmnTLS "github.com/oasisprotocol/oasis-core/go/common/crypto/tls"
+)
+
+func TestCommitteePeerClassifier(t *testing.T) {
+	require := require.New(t)
+
+	cert, err := cmnTLS.Generate("oasis-node")
+	require.NoError(err, "Generate")
+	x509Cert, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(err, "ParseCertificate")
+	var pubKey signature.PublicKey
+	err = pubKey.UnmarshalBinary(x509Cert.PublicKey.(ed25519.PublicKey))
+	require.NoError(err, "UnmarshalBinary")
+
+	peerCtx := func(certs ...*x509.Certificate) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{
+				State: tls.ConnectionState{PeerCertificates: certs},
+			},
+		})
+	}
+
+	classifier := NewCommitteePeerClassifier()
+
+	require.Equal(PeerClassAnonymous, classifier.ClassifyPeer(context.Background()), "no peer")
+	require.Equal(PeerClassAnonymous, classifier.ClassifyPeer(peerCtx()), "no client certificate")
+	require.Equal(PeerClassAuthenticated, classifier.ClassifyPeer(peerCtx(x509Cert)), "unknown client certificate")
+
+	classifier.SetCommitteeMembers("runtime", []signature.PublicKey{pubKey})
+	require.Equal(PeerClassCommittee, classifier.ClassifyPeer(peerCtx(x509Cert)), "committee member")
+
+	forwardedCtx := metadata.NewIncomingContext(peerCtx(x509Cert), metadata.Pairs(ForwardedSubjectMD, ""))
+	require.Equal(PeerClassSentryProxied, classifier.ClassifyPeer(forwardedCtx), "forwarded by sentry")
+
+	classifier.SetCommitteeMembers("runtime", nil)
+	require.Equal(PeerClassAuthenticated, classifier.ClassifyPeer(peerCtx(x509Cert)), "removed committee member")
+}
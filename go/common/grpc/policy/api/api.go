@@ -17,7 +17,7 @@ import (
 const (
 	// ForwardedSubjectMD is name of the metadata field in which the actual
 	// subject should be passed in case sentry forwarded the request.
-	ForwardedSubjectMD = "forwarded-subject"
+	ForwardedSubjectMD = grpc.ForwardedSubjectMD
 )
 
 // PolicyWatcher is a policy watcher interface.
@@ -133,11 +133,16 @@ func (c *DynamicRuntimePolicyChecker) CheckAccessAllowed(
 	if !ok {
 		return status.Errorf(codes.PermissionDenied, "grpc: unexpected peer authentication credentials")
 	}
-	if nPeerCerts := len(tlsAuth.State.PeerCertificates); nPeerCerts != 1 {
+	var subject accessctl.Subject
+	switch nPeerCerts := len(tlsAuth.State.PeerCertificates); nPeerCerts {
+	case 0:
+		// Clients without a certificate are anonymous.
+		subject = accessctl.AnonymousSubject
+	case 1:
+		subject = accessctl.SubjectFromX509Certificate(tlsAuth.State.PeerCertificates[0])
+	default:
 		return status.Errorf(codes.PermissionDenied, fmt.Sprintf("grpc: unexpected number of peer certificates: %d", nPeerCerts))
 	}
-	peerCert := tlsAuth.State.PeerCertificates[0]
-	subject := accessctl.SubjectFromX509Certificate(peerCert)
 	policy := c.accessPolicies[runtimeID]
 
 	// If no policy defined, reject.
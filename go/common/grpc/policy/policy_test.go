@@ -91,12 +91,12 @@ func TestAccessPolicy(t *testing.T) {
 	conn := connectToGrpcServer(ctx, t, address, clientTLSCredsWithoutCert)
 	defer conn.Close()
 	// Create a new ping client.
-	client := cmnTesting.NewPingClient(conn)
+	anonClient := cmnTesting.NewPingClient(conn)
 	pingQuery := &cmnTesting.PingQuery{Namespace: testNs}
-	_, err = client.Ping(ctx, pingQuery)
+	_, err = anonClient.Ping(ctx, pingQuery)
 	require.EqualError(
 		err,
-		"rpc error: code = PermissionDenied desc = grpc: unexpected number of peer certificates: 0",
+		fmt.Sprintf("rpc error: code = PermissionDenied desc = grpc: calling /oasis-core.PingService/Ping method for runtime %s not allowed for client %s", testNs, accessctl.AnonymousSubject),
 		"Calling Ping without a client certificate should not be allowed",
 	)
 
@@ -104,7 +104,7 @@ func TestAccessPolicy(t *testing.T) {
 	conn = connectToGrpcServer(ctx, t, address, clientTLSCreds)
 	defer conn.Close()
 	// Create a new ping client.
-	client = cmnTesting.NewPingClient(conn)
+	client := cmnTesting.NewPingClient(conn)
 
 	expectedStr := fmt.Sprintf("rpc error: code = PermissionDenied desc = grpc: calling /oasis-core.PingService/Ping method for runtime %s not allowed for client %s", testNs, accessctl.SubjectFromX509Certificate(clientX509Cert))
 	_, err = client.Ping(ctx, pingQuery)
@@ -124,4 +124,21 @@ func TestAccessPolicy(t *testing.T) {
 	res, err := client.Ping(ctx, pingQuery)
 	require.NoError(err, "Calling Ping with proper access policy set should succeed")
 	require.IsType(&cmnTesting.PingResponse{}, res, "Calling Ping should return a response of the correct type")
+
+	// Allowing all authenticated clients should not allow anonymous access.
+	policy = accessctl.NewPolicy()
+	policy.AllowAll(accessctl.Action(cmnTesting.MethodPing.FullName()))
+	policyChecker.SetAccessPolicy(policy, testNs)
+
+	_, err = client.Ping(ctx, pingQuery)
+	require.NoError(err, "Calling Ping with a client certificate should succeed")
+	_, err = anonClient.Ping(ctx, pingQuery)
+	require.Equal(codes.PermissionDenied, status.Code(err), "Calling Ping without a client certificate should not be allowed")
+
+	// Explicitly allow anonymous access.
+	policy.AllowAnonymous(accessctl.Action(cmnTesting.MethodPing.FullName()))
+	policyChecker.SetAccessPolicy(policy, testNs)
+
+	_, err = anonClient.Ping(ctx, pingQuery)
+	require.NoError(err, "Calling Ping without a client certificate should succeed with anonymous access")
 }
@@ -0,0 +1,136 @@
+package pubsub
+
+import (
+	"github.com/eapache/channels"
+)
+
+// OverflowPolicy is the policy applied when a bounded subscription buffer is full.
+type OverflowPolicy uint8
+
+const (
+	// DropOldest discards the oldest buffered value to make room for the new one.
+	DropOldest OverflowPolicy = iota
+	// DropNewest discards the new value.
+	DropNewest
+	// Block waits until the subscriber makes room in the buffer.
+	//
+	// Note: This blocks delivery to all of the broker's subscribers, so it should only be used
+	// for subscribers that must not miss any values and are known to keep up.
+	Block
+)
+
+// String returns a string representation of the overflow policy.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case DropOldest:
+		return "drop oldest"
+	case DropNewest:
+		return "drop newest"
+	case Block:
+		return "block"
+	default:
+		return "[unknown overflow policy]"
+	}
+}
+
+type subscribeOptions struct {
+	bufferSize int64
+	policy     OverflowPolicy
+}
+
+// SubscribeOption is an option for configuring a subscription.
+type SubscribeOption func(o *subscribeOptions)
+
+// WithBufferSize sets the subscription buffer size. In case the size is negative (or zero) an
+// unbounded buffer is used, which is also the default.
+func WithBufferSize(size int64) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.bufferSize = size
+	}
+}
+
+// WithOverflowPolicy sets the policy applied when the bounded subscription buffer is full. The
+// default policy is DropOldest.
+func WithOverflowPolicy(policy OverflowPolicy) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.policy = policy
+	}
+}
+
+// boundedSubscriber is a subscriber with a bounded buffer and an overflow policy.
+type boundedSubscriber struct {
+	broker string
+	policy OverflowPolicy
+
+	ch channels.Channel
+}
+
+func (s *boundedSubscriber) publish(v interface{}) {
+	switch s.policy {
+	case Block:
+		s.ch.In() <- v
+	case DropNewest:
+		select {
+		case s.ch.In() <- v:
+		default:
+			droppedNotifications.WithLabelValues(s.broker).Inc()
+		}
+	default:
+		for {
+			select {
+			case s.ch.In() <- v:
+				return
+			default:
+			}
+
+			// Buffer is full, discard the oldest value. As this is the only sender, the loop
+			// terminates once there is room in the buffer.
+			select {
+			case <-s.ch.Out():
+				droppedNotifications.WithLabelValues(s.broker).Inc()
+			default:
+			}
+		}
+	}
+}
+
+func (s *boundedSubscriber) close() {
+	s.ch.Close()
+}
+
+// SubscribeWithOptions subscribes to the Broker's broadcasts, and returns a subscription handle
+// that can be used to receive broadcasts. The subscription buffer size and overflow policy are
+// configured via the given options. In addition it also takes a per-subscription on-subscribe
+// callback hook.
+//
+// Note: The on-subscribe hooks must not publish more values than fit into the buffer.
+func (b *Broker) SubscribeWithOptions(onSubscribeHook OnSubscribeHook, opts ...SubscribeOption) *Subscription {
+	var o subscribeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.bufferSize <= 0 {
+		return b.SubscribeEx(int64(channels.Infinity), onSubscribeHook)
+	}
+
+	ch := channels.NewNativeChannel(channels.BufferCap(o.bufferSize))
+	sub := &boundedSubscriber{
+		broker: b.name,
+		policy: o.policy,
+		ch:     ch,
+	}
+	b.subscribe(sub, func() {
+		if onSubscribeHook != nil {
+			onSubscribeHook(ch)
+		}
+		if b.onSubscribeHook != nil {
+			b.onSubscribeHook(ch)
+		}
+	})
+
+	return &Subscription{
+		b:   b,
+		ch:  ch,
+		sub: sub,
+	}
+}
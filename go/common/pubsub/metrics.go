@@ -0,0 +1,28 @@
+package pubsub
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	droppedNotifications = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_pubsub_dropped_notifications",
+			Help: "Number of notifications dropped due to full subscriber buffers.",
+		},
+		[]string{"broker"},
+	)
+	pubsubCollectors = []prometheus.Collector{
+		droppedNotifications,
+	}
+
+	metricsOnce sync.Once
+)
+
+func initMetrics() {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(pubsubCollectors...)
+	})
+}
@@ -12,10 +12,31 @@ type broadcastedValue struct {
 	v interface{}
 }
 
+// subscriber is a Broker subscriber.
+type subscriber interface {
+	// publish publishes a value to the subscriber.
+	publish(v interface{})
+	// close closes the subscriber.
+	close()
+}
+
+// channelSubscriber is an untyped subscriber backed by a channels.Channel.
+type channelSubscriber struct {
+	ch channels.Channel
+}
+
+func (s *channelSubscriber) publish(v interface{}) {
+	s.ch.In() <- v
+}
+
+func (s *channelSubscriber) close() {
+	s.ch.Close()
+}
+
 type cmdCtx struct {
-	ch              channels.Channel
+	sub             subscriber
 	errCh           chan error
-	onSubscribeHook OnSubscribeHook
+	onSubscribeHook func()
 
 	isSubscribe bool
 }
@@ -45,8 +66,9 @@ func NewContextSubscription(ctx context.Context) (context.Context, ClosableSubsc
 
 // Subscription is a Broker subscription instance.
 type Subscription struct {
-	b  *Broker
-	ch channels.Channel
+	b   *Broker
+	ch  channels.Channel
+	sub subscriber
 }
 
 // Untyped returns the subscription's untyped output.  Effort should be
@@ -63,26 +85,20 @@ func (s *Subscription) Unwrap(ch interface{}) {
 
 // Close unsubscribes from the Broker.
 func (s *Subscription) Close() {
-	ctx := &cmdCtx{
-		ch:          s.ch,
-		errCh:       make(chan error),
-		isSubscribe: false,
-	}
-
-	s.b.cmdCh <- ctx
-	if err := <-ctx.errCh; err != nil {
-		panic(err)
-	}
+	s.b.unsubscribe(s.sub)
 }
 
 // Broker is a pub/sub broker instance.
 type Broker struct {
-	subscribers     map[channels.Channel]bool
+	name string
+
+	subscribers     map[subscriber]bool
 	cmdCh           chan *cmdCtx
 	broadcastCh     channels.Channel
 	lastBroadcasted *broadcastedValue
 
-	onSubscribeHook OnSubscribeHook
+	pubLastOnSubscribe bool
+	onSubscribeHook    OnSubscribeHook
 }
 
 // OnSubscribeHook is the on-subscribe callback hook prototype.
@@ -121,19 +137,20 @@ func (b *Broker) SubscribeEx(buffer int64, onSubscribeHook OnSubscribeHook) *Sub
 	} else {
 		ch = channels.NewRingChannel(channels.BufferCap(buffer))
 	}
-	ctx := &cmdCtx{
-		ch:              ch,
-		errCh:           make(chan error),
-		onSubscribeHook: onSubscribeHook,
-		isSubscribe:     true,
-	}
-
-	b.cmdCh <- ctx
-	<-ctx.errCh
+	sub := &channelSubscriber{ch}
+	b.subscribe(sub, func() {
+		if onSubscribeHook != nil {
+			onSubscribeHook(ch)
+		}
+		if b.onSubscribeHook != nil {
+			b.onSubscribeHook(ch)
+		}
+	})
 
 	return &Subscription{
-		b:  b,
-		ch: ctx.ch,
+		b:   b,
+		ch:  ch,
+		sub: sub,
 	}
 }
 
@@ -145,49 +162,78 @@ func (b *Broker) Broadcast(v interface{}) {
 	b.broadcastCh.In() <- v
 }
 
+func (b *Broker) subscribe(sub subscriber, onSubscribeHook func()) {
+	ctx := &cmdCtx{
+		sub:             sub,
+		errCh:           make(chan error),
+		onSubscribeHook: onSubscribeHook,
+		isSubscribe:     true,
+	}
+
+	b.cmdCh <- ctx
+	<-ctx.errCh
+}
+
+func (b *Broker) unsubscribe(sub subscriber) {
+	ctx := &cmdCtx{
+		sub:         sub,
+		errCh:       make(chan error),
+		isSubscribe: false,
+	}
+
+	b.cmdCh <- ctx
+	if err := <-ctx.errCh; err != nil {
+		panic(err)
+	}
+}
+
 func (b *Broker) worker() {
 	for {
 		select {
 		case ctx := <-b.cmdCh:
 			if ctx.isSubscribe {
 				if ctx.onSubscribeHook != nil {
-					ctx.onSubscribeHook(ctx.ch)
+					ctx.onSubscribeHook()
 				}
-				if b.onSubscribeHook != nil {
-					b.onSubscribeHook(ctx.ch)
+				if b.pubLastOnSubscribe && b.lastBroadcasted != nil {
+					ctx.sub.publish(b.lastBroadcasted.v)
 				}
-				b.subscribers[ctx.ch] = true
+				b.subscribers[ctx.sub] = true
 				close(ctx.errCh)
 			} else {
-				if !b.subscribers[ctx.ch] {
-					ctx.errCh <- errors.New("pubsub: unsubscribed an unknown channel")
+				if !b.subscribers[ctx.sub] {
+					ctx.errCh <- errors.New("pubsub: unsubscribed an unknown subscriber")
 				} else {
-					delete(b.subscribers, ctx.ch)
-					ctx.ch.Close() // Close the no longer subscribed channel.
+					delete(b.subscribers, ctx.sub)
+					ctx.sub.close() // Close the no longer subscribed channel.
 					close(ctx.errCh)
 				}
 			}
 		case v := <-b.broadcastCh.Out():
-			for ch := range b.subscribers {
-				ch.In() <- v
+			for sub := range b.subscribers {
+				sub.publish(v)
 			}
 			b.lastBroadcasted = &broadcastedValue{v}
 		}
 	}
 }
 
+// BrokerOption is an option for configuring a Broker.
+type BrokerOption func(b *Broker)
+
+// WithName sets the broker name used to label broker metrics.
+func WithName(name string) BrokerOption {
+	return func(b *Broker) {
+		b.name = name
+	}
+}
+
 // NewBroker creates a new pub/sub broker.  If pubLastOnSubscribe is set,
 // the last broadcasted value will automatically be published to new
 // subscribers, if one exists.
-func NewBroker(pubLastOnSubscribe bool) *Broker {
-	b := newBroker()
-	if pubLastOnSubscribe {
-		b.onSubscribeHook = func(ch channels.Channel) {
-			if b.lastBroadcasted != nil {
-				ch.In() <- b.lastBroadcasted.v
-			}
-		}
-	}
+func NewBroker(pubLastOnSubscribe bool, opts ...BrokerOption) *Broker {
+	b := newBroker(opts...)
+	b.pubLastOnSubscribe = pubLastOnSubscribe
 
 	go b.worker()
 
@@ -196,8 +242,8 @@ func NewBroker(pubLastOnSubscribe bool) *Broker {
 
 // NewBrokerEx creates a new pub/sub broker, with a hook to be called
 // when a new subscriber is registered.
-func NewBrokerEx(onSubscribeHook OnSubscribeHook) *Broker {
-	b := newBroker()
+func NewBrokerEx(onSubscribeHook OnSubscribeHook, opts ...BrokerOption) *Broker {
+	b := newBroker(opts...)
 	b.onSubscribeHook = onSubscribeHook
 
 	go b.worker()
@@ -205,10 +251,17 @@ func NewBrokerEx(onSubscribeHook OnSubscribeHook) *Broker {
 	return b
 }
 
-func newBroker() *Broker {
-	return &Broker{
-		subscribers: make(map[channels.Channel]bool),
+func newBroker(opts ...BrokerOption) *Broker {
+	initMetrics()
+
+	b := &Broker{
+		name:        "unnamed",
+		subscribers: make(map[subscriber]bool),
 		cmdCh:       make(chan *cmdCtx),
 		broadcastCh: channels.NewInfiniteChannel(),
 	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
 }
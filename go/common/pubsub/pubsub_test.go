@@ -19,6 +19,9 @@ func TestPubSub(t *testing.T) {
 	t.Run("PubLastOnSubscribe", testLastOnSubscribe)
 	t.Run("SubscribeEx", testSubscribeEx)
 	t.Run("NewBrokerEx", testNewBrokerEx)
+	t.Run("OptionsUnbounded", testOptionsUnbounded)
+	t.Run("OverflowPolicies", testOverflowPolicies)
+	t.Run("SubscribeWithOptionsHook", testSubscribeWithOptionsHook)
 }
 
 func testBasicInfinity(t *testing.T) {
@@ -154,3 +157,76 @@ func testNewBrokerEx(t *testing.T) {
 		require.Equal(t, sub.ch, callbackCh, "Callback channel != Subscription, inner channel")
 	}
 }
+
+func testOptionsUnbounded(t *testing.T) {
+	broker := NewBroker(true)
+	broker.Broadcast(23)
+
+	sub := broker.SubscribeWithOptions(nil)
+	ch := make(chan int)
+	sub.Unwrap(ch)
+	for i := 0; i < 10; i++ {
+		broker.Broadcast(i)
+	}
+	for _, i := range append([]int{23}, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9) {
+		select {
+		case v := <-ch:
+			require.Equal(t, i, v, "Buffered Broadcast()")
+		case <-time.After(recvTimeout):
+			t.Fatalf("Failed to receive value, buffered Broadcast()")
+		}
+	}
+
+	require.NotPanics(t, func() { sub.Close() }, "Close()")
+	_, ok := <-ch
+	require.False(t, ok, "channel should be closed after Close()")
+}
+
+func testOverflowPolicies(t *testing.T) {
+	broker := NewBroker(false, WithName("test"))
+
+	oldest := broker.SubscribeWithOptions(nil, WithBufferSize(bufferSize))
+	newest := broker.SubscribeWithOptions(nil, WithBufferSize(bufferSize), WithOverflowPolicy(DropNewest))
+	blocking := broker.SubscribeWithOptions(nil, WithBufferSize(bufferSize), WithOverflowPolicy(Block))
+
+	// Broadcast more values than the buffers can hold while draining the blocking subscriber.
+	const total = bufferSize + 10
+	for i := 0; i < total; i++ {
+		broker.Broadcast(i)
+	}
+	for i := 0; i < total; i++ {
+		select {
+		case v := <-blocking.Untyped():
+			require.Equal(t, i, v, "Block policy should not drop values")
+		case <-time.After(recvTimeout):
+			t.Fatalf("Failed to receive value, blocking subscriber")
+		}
+	}
+	// Ensure the broker is done publishing the last value to all subscribers.
+	broker.Subscribe().Close()
+
+	for i := total - bufferSize; i < total; i++ {
+		require.Equal(t, i, <-oldest.Untyped(), "DropOldest policy should keep the newest values")
+	}
+	for i := 0; i < bufferSize; i++ {
+		require.Equal(t, i, <-newest.Untyped(), "DropNewest policy should keep the oldest values")
+	}
+
+	for _, sub := range []*Subscription{oldest, newest, blocking} {
+		require.NotPanics(t, func() { sub.Close() }, "Close()")
+	}
+	require.Len(t, broker.subscribers, 0, "Subscriber map, post Close()")
+}
+
+func testSubscribeWithOptionsHook(t *testing.T) {
+	broker := NewBroker(false)
+
+	sub := broker.SubscribeWithOptions(func(ch channels.Channel) {
+		ch.In() <- 42
+	}, WithBufferSize(bufferSize))
+	broker.Broadcast(23)
+
+	require.Equal(t, 42, <-sub.Untyped(), "on-subscribe hook value")
+	require.Equal(t, 23, <-sub.Untyped(), "Broadcast()")
+	sub.Close()
+}
@@ -0,0 +1,60 @@
+package workerpool
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	poolQueueSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_workerpool_queue_size",
+			Help: "Number of queued jobs in a priority worker pool class.",
+		},
+		[]string{"pool", "class"},
+	)
+	poolRunningJobs = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_workerpool_running_jobs",
+			Help: "Number of running jobs in a priority worker pool class.",
+		},
+		[]string{"pool", "class"},
+	)
+	poolCompletedJobs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_workerpool_completed_jobs",
+			Help: "Number of completed jobs in a priority worker pool class.",
+		},
+		[]string{"pool", "class"},
+	)
+	poolRejectedJobs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_workerpool_rejected_jobs",
+			Help: "Number of jobs rejected due to a full queue in a priority worker pool class.",
+		},
+		[]string{"pool", "class"},
+	)
+	poolQueueWaitTime = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_workerpool_queue_wait_time",
+			Help: "Time jobs spent queued in a priority worker pool class (seconds).",
+		},
+		[]string{"pool", "class"},
+	)
+	workerpoolCollectors = []prometheus.Collector{
+		poolQueueSize,
+		poolRunningJobs,
+		poolCompletedJobs,
+		poolRejectedJobs,
+		poolQueueWaitTime,
+	}
+
+	metricsOnce sync.Once
+)
+
+func initMetrics() {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(workerpoolCollectors...)
+	})
+}
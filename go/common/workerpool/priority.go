@@ -0,0 +1,265 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+// DefaultStarvationThreshold is the default number of times a class with queued jobs can be
+// passed over in favor of higher priority classes before it is scheduled regardless of priority.
+const DefaultStarvationThreshold = 16
+
+var (
+	// ErrQueueFull is the error returned when a job is submitted to a class with a full queue.
+	ErrQueueFull = errors.New("workerpool: queue full")
+	// ErrUnknownClass is the error returned when a job is submitted to an unknown class.
+	ErrUnknownClass = errors.New("workerpool: unknown class")
+	// ErrStopped is the error returned when a job is submitted to a stopped pool.
+	ErrStopped = errors.New("workerpool: pool stopped")
+)
+
+// ClassConfig is the configuration of a priority pool job class.
+type ClassConfig struct {
+	// Name is the name of the class.
+	Name string
+	// Priority is the priority of the class. Jobs of classes with higher priority are scheduled
+	// before jobs of classes with lower priority.
+	Priority int
+	// MaxConcurrency is the maximum number of jobs of the class that may run concurrently. Zero
+	// means that the number of concurrently running jobs is only limited by the number of workers.
+	MaxConcurrency uint
+	// QueueSize is the maximum number of queued jobs of the class. Zero means unbounded.
+	QueueSize uint
+}
+
+type priorityJob struct {
+	job        func()
+	completeCh chan struct{}
+	queuedAt   time.Time
+}
+
+type jobClass struct {
+	ClassConfig
+
+	queue   []*priorityJob
+	running uint
+	skipped uint
+}
+
+func (c *jobClass) isRunnable() bool {
+	if len(c.queue) == 0 {
+		return false
+	}
+	return c.MaxConcurrency == 0 || c.running < c.MaxConcurrency
+}
+
+// PriorityPool is a pool of goroutine workers shared between multiple classes of jobs.
+//
+// Each class has its own bounded queue and concurrency limit. Workers pick jobs from the runnable
+// class with the highest priority. To avoid starvation, a class that has been passed over too many
+// times is scheduled next regardless of its priority.
+type PriorityPool struct {
+	lock sync.Mutex
+	cond *sync.Cond
+
+	name                string
+	starvationThreshold uint
+
+	classes []*jobClass
+	byName  map[string]*jobClass
+
+	stopped bool
+	quitCh  chan struct{}
+
+	workerGroup sync.WaitGroup
+
+	logger *logging.Logger
+}
+
+// Submit adds a job of the given class to the pool's queue and returns a channel that will be
+// closed once the job is complete.
+func (p *PriorityPool) Submit(class string, job func()) (<-chan struct{}, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.stopped {
+		return nil, ErrStopped
+	}
+	c, ok := p.byName[class]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownClass, class)
+	}
+	if c.QueueSize > 0 && uint(len(c.queue)) >= c.QueueSize {
+		poolRejectedJobs.With(p.metricLabels(c)).Inc()
+		return nil, ErrQueueFull
+	}
+
+	pj := &priorityJob{
+		job:        job,
+		completeCh: make(chan struct{}),
+		queuedAt:   time.Now(),
+	}
+	c.queue = append(c.queue, pj)
+	poolQueueSize.With(p.metricLabels(c)).Set(float64(len(c.queue)))
+
+	p.cond.Signal()
+
+	return pj.completeCh, nil
+}
+
+// Stop causes all worker goroutines to shut down once they finish their current job. Any queued
+// jobs are discarded.
+//
+// The pool must not be used for any further jobs after calling this method.
+func (p *PriorityPool) Stop() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.stopped {
+		return
+	}
+	p.stopped = true
+	for _, c := range p.classes {
+		c.queue = nil
+		poolQueueSize.With(p.metricLabels(c)).Set(0)
+	}
+	p.cond.Broadcast()
+}
+
+// Quit returns a channel that will be closed when the pool stops.
+func (p *PriorityPool) Quit() <-chan struct{} {
+	return p.quitCh
+}
+
+func (p *PriorityPool) metricLabels(c *jobClass) prometheus.Labels {
+	return prometheus.Labels{
+		"pool":  p.name,
+		"class": c.Name,
+	}
+}
+
+// next selects the class of the next job to run. Must be called with the lock held.
+func (p *PriorityPool) next() *jobClass {
+	var (
+		best    *jobClass
+		starved *jobClass
+	)
+	for _, c := range p.classes {
+		if !c.isRunnable() {
+			continue
+		}
+		if c.skipped >= p.starvationThreshold && (starved == nil || c.skipped > starved.skipped) {
+			starved = c
+		}
+		if best == nil || c.Priority > best.Priority {
+			best = c
+		}
+	}
+	if starved != nil {
+		best = starved
+	}
+	if best == nil {
+		return nil
+	}
+
+	// Classes that could have run but were passed over age so that they eventually get scheduled.
+	for _, c := range p.classes {
+		if c != best && c.isRunnable() {
+			c.skipped++
+		}
+	}
+	best.skipped = 0
+
+	return best
+}
+
+func (p *PriorityPool) worker() {
+	defer p.workerGroup.Done()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for {
+		var c *jobClass
+		for {
+			if p.stopped {
+				return
+			}
+			if c = p.next(); c != nil {
+				break
+			}
+			p.cond.Wait()
+		}
+
+		job := c.queue[0]
+		c.queue[0] = nil
+		c.queue = c.queue[1:]
+		c.running++
+
+		labels := p.metricLabels(c)
+		poolQueueSize.With(labels).Set(float64(len(c.queue)))
+		poolRunningJobs.With(labels).Set(float64(c.running))
+		poolQueueWaitTime.With(labels).Observe(time.Since(job.queuedAt).Seconds())
+
+		p.lock.Unlock()
+		job.job()
+		close(job.completeCh)
+		p.lock.Lock()
+
+		c.running--
+		poolRunningJobs.With(labels).Set(float64(c.running))
+		poolCompletedJobs.With(labels).Inc()
+
+		// A job completing may make a concurrency-limited class runnable again.
+		p.cond.Broadcast()
+	}
+}
+
+func (p *PriorityPool) lifetimeManager() {
+	p.workerGroup.Wait()
+	close(p.quitCh)
+}
+
+// NewPriority creates and returns a new priority worker pool with the given number of worker
+// goroutines and job classes.
+func NewPriority(name string, workers uint, classes ...ClassConfig) (*PriorityPool, error) {
+	if workers == 0 {
+		return nil, fmt.Errorf("workerpool/%s: pool must always have at least one worker", name)
+	}
+	if len(classes) == 0 {
+		return nil, fmt.Errorf("workerpool/%s: pool must have at least one class", name)
+	}
+
+	initMetrics()
+
+	p := &PriorityPool{
+		name:                name,
+		starvationThreshold: DefaultStarvationThreshold,
+		byName:              make(map[string]*jobClass),
+		quitCh:              make(chan struct{}),
+		logger:              logging.GetLogger(fmt.Sprintf("workerpool/%s", name)),
+	}
+	p.cond = sync.NewCond(&p.lock)
+	for _, cfg := range classes {
+		if _, exists := p.byName[cfg.Name]; exists {
+			return nil, fmt.Errorf("workerpool/%s: duplicate class: %s", name, cfg.Name)
+		}
+		c := &jobClass{ClassConfig: cfg}
+		p.classes = append(p.classes, c)
+		p.byName[cfg.Name] = c
+	}
+
+	p.workerGroup.Add(int(workers))
+	for i := uint(0); i < workers; i++ {
+		go p.worker()
+	}
+	go p.lifetimeManager()
+
+	return p, nil
+}
@@ -0,0 +1,205 @@
+package workerpool
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testTimeout = 5 * time.Second
+
+func waitDone(t *testing.T, ch <-chan struct{}) {
+	select {
+	case <-ch:
+	case <-time.After(testTimeout):
+		t.Fatalf("timed out waiting for job completion")
+	}
+}
+
+func TestPriorityPoolOrdering(t *testing.T) {
+	require := require.New(t)
+
+	p, err := NewPriority("test_ordering", 1,
+		ClassConfig{Name: "low", Priority: 0},
+		ClassConfig{Name: "high", Priority: 1},
+	)
+	require.NoError(err, "NewPriority")
+	defer p.Stop()
+
+	// Block the only worker so that all other jobs get queued.
+	blockCh := make(chan struct{})
+	startedCh := make(chan struct{})
+	_, err = p.Submit("low", func() {
+		close(startedCh)
+		<-blockCh
+	})
+	require.NoError(err, "Submit")
+	waitDone(t, startedCh)
+
+	var (
+		lock    sync.Mutex
+		order   []string
+		doneChs []<-chan struct{}
+	)
+	record := func(name string) func() {
+		return func() {
+			lock.Lock()
+			defer lock.Unlock()
+			order = append(order, name)
+		}
+	}
+	for _, class := range []string{"low", "high", "low", "high"} {
+		var doneCh <-chan struct{}
+		doneCh, err = p.Submit(class, record(class))
+		require.NoError(err, "Submit")
+		doneChs = append(doneChs, doneCh)
+	}
+
+	close(blockCh)
+	for _, doneCh := range doneChs {
+		waitDone(t, doneCh)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Equal([]string{"high", "high", "low", "low"}, order, "higher priority jobs should run first")
+}
+
+func TestPriorityPoolQueueFull(t *testing.T) {
+	require := require.New(t)
+
+	p, err := NewPriority("test_queue_full", 1,
+		ClassConfig{Name: "bounded", QueueSize: 1},
+	)
+	require.NoError(err, "NewPriority")
+	defer p.Stop()
+
+	_, err = p.Submit("unknown", func() {})
+	require.ErrorIs(err, ErrUnknownClass)
+
+	blockCh := make(chan struct{})
+	startedCh := make(chan struct{})
+	_, err = p.Submit("bounded", func() {
+		close(startedCh)
+		<-blockCh
+	})
+	require.NoError(err, "Submit")
+	waitDone(t, startedCh)
+
+	doneCh, err := p.Submit("bounded", func() {})
+	require.NoError(err, "Submit")
+	_, err = p.Submit("bounded", func() {})
+	require.ErrorIs(err, ErrQueueFull)
+
+	close(blockCh)
+	waitDone(t, doneCh)
+
+	p.Stop()
+	_, err = p.Submit("bounded", func() {})
+	require.ErrorIs(err, ErrStopped)
+	waitDone(t, p.Quit())
+}
+
+func TestPriorityPoolConcurrency(t *testing.T) {
+	require := require.New(t)
+
+	p, err := NewPriority("test_concurrency", 4,
+		ClassConfig{Name: "limited", MaxConcurrency: 2},
+	)
+	require.NoError(err, "NewPriority")
+	defer p.Stop()
+
+	var (
+		lock       sync.Mutex
+		running    int
+		maxRunning int
+		doneChs    []<-chan struct{}
+	)
+	for i := 0; i < 16; i++ {
+		var doneCh <-chan struct{}
+		doneCh, err = p.Submit("limited", func() {
+			lock.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			lock.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			lock.Lock()
+			running--
+			lock.Unlock()
+		})
+		require.NoError(err, "Submit")
+		doneChs = append(doneChs, doneCh)
+	}
+	for _, doneCh := range doneChs {
+		waitDone(t, doneCh)
+	}
+
+	require.Equal(2, maxRunning, "class concurrency limit should be respected")
+}
+
+func TestPriorityPoolStarvation(t *testing.T) {
+	require := require.New(t)
+
+	p, err := NewPriority("test_starvation", 1,
+		ClassConfig{Name: "low", Priority: 0},
+		ClassConfig{Name: "high", Priority: 1},
+	)
+	require.NoError(err, "NewPriority")
+	defer p.Stop()
+
+	blockCh := make(chan struct{})
+	startedCh := make(chan struct{})
+	_, err = p.Submit("high", func() {
+		close(startedCh)
+		<-blockCh
+	})
+	require.NoError(err, "Submit")
+	waitDone(t, startedCh)
+
+	var (
+		lock      sync.Mutex
+		highCount int
+		lowAfter  int
+	)
+	lowDoneCh, err := p.Submit("low", func() {
+		lock.Lock()
+		defer lock.Unlock()
+		lowAfter = highCount
+	})
+	require.NoError(err, "Submit")
+
+	var last <-chan struct{}
+	for i := 0; i < 2*DefaultStarvationThreshold; i++ {
+		last, err = p.Submit("high", func() {
+			lock.Lock()
+			defer lock.Unlock()
+			highCount++
+		})
+		require.NoError(err, "Submit")
+	}
+
+	close(blockCh)
+	waitDone(t, lowDoneCh)
+	waitDone(t, last)
+
+	require.Equal(DefaultStarvationThreshold, lowAfter, "low priority job should run once starved")
+}
+
+func TestNewPriorityInvalid(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewPriority("test_invalid", 0, ClassConfig{Name: "class"})
+	require.Error(err, "pool without workers should be rejected")
+
+	_, err = NewPriority("test_invalid", 1)
+	require.Error(err, "pool without classes should be rejected")
+
+	_, err = NewPriority("test_invalid", 1, ClassConfig{Name: "class"}, ClassConfig{Name: "class"})
+	require.Error(err, "pool with duplicate classes should be rejected")
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"context"
+	"time"
+)
+
+// AddressBookEntry is an entry in the consensus P2P address book.
+type AddressBookEntry struct {
+	// Address is the peer address in the <id>@<host>:<port> form.
+	Address string `json:"address"`
+	// Source is the address of the peer from which the address has been learned.
+	Source string `json:"source,omitempty"`
+	// Good is true iff the node has successfully connected to the peer before.
+	Good bool `json:"good"`
+	// Attempts is the number of failed connection attempts since the last success.
+	Attempts int32 `json:"attempts"`
+	// LastAttempt is the time of the last connection attempt.
+	LastAttempt time.Time `json:"last_attempt"`
+	// LastSuccess is the time of the last successful connection.
+	LastSuccess time.Time `json:"last_success"`
+}
+
+// AddressBook is the consensus P2P address book.
+type AddressBook struct {
+	// Entries are the known peer addresses.
+	Entries []*AddressBookEntry `json:"entries"`
+}
+
+// AddressBookProvider is the interface implemented by consensus backends that maintain a P2P
+// address book (e.g., seed nodes).
+type AddressBookProvider interface {
+	// GetAddressBook returns the current contents of the address book.
+	GetAddressBook(ctx context.Context) (*AddressBook, error)
+
+	// ClearAddressBook removes all learned addresses from the address book.
+	ClearAddressBook(ctx context.Context) error
+}
@@ -4,6 +4,7 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -100,6 +101,15 @@ type ClientBackend interface {
 	// in a block. Use SubmitTxNoWait if you only need to broadcast the transaction.
 	SubmitTx(ctx context.Context, tx *transaction.SignedTransaction) error
 
+	// SubmitTxWithProof submits a signed consensus transaction, waits for the transaction to be
+	// included in a block and returns a proof of inclusion.
+	SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*transaction.Proof, error)
+
+	// SubmitTxBatch submits a batch of signed consensus transactions, but does not wait for the
+	// transactions to be included in a block. The CheckTx result of each transaction is returned
+	// in the same order as the submitted transactions.
+	SubmitTxBatch(ctx context.Context, txs []*transaction.SignedTransaction) ([]*SubmitTxBatchResult, error)
+
 	// StateToGenesis returns the genesis state at the specified block height.
 	StateToGenesis(ctx context.Context, height int64) (*genesis.Document, error)
 
@@ -109,6 +119,12 @@ type ClientBackend interface {
 	// GetBlock returns a consensus block at a specific height.
 	GetBlock(ctx context.Context, height int64) (*Block, error)
 
+	// GetValidatorSet returns the consensus validator set, including the validators' voting
+	// powers, together with the consensus parameters at a specific height.
+	//
+	// Any height for which consensus state is retained can be queried.
+	GetValidatorSet(ctx context.Context, height int64) (*ValidatorSet, error)
+
 	// GetTransactions returns a list of all transactions contained within a
 	// consensus block at a specific height.
 	//
@@ -137,6 +153,10 @@ type ClientBackend interface {
 	// GetStatus returns the current status overview.
 	GetStatus(ctx context.Context) (*Status, error)
 
+	// GetNetworkMetadata returns the network metadata needed to bootstrap clients (e.g., to set
+	// up signing contexts) without fetching the whole genesis document.
+	GetNetworkMetadata(ctx context.Context) (*NetworkMetadata, error)
+
 	// GetNextBlockState returns the state of the next block being voted on by validators.
 	GetNextBlockState(ctx context.Context) (*NextBlockState, error)
 
@@ -242,6 +262,36 @@ type Status struct { // nolint: maligned
 	IsValidator bool `json:"is_validator"`
 }
 
+// NetworkMetadata is the network metadata needed to bootstrap clients.
+type NetworkMetadata struct {
+	// ChainContext is the chain domain separation context.
+	ChainContext string `json:"chain_context"`
+
+	// GenesisHeight is the height of the genesis block.
+	GenesisHeight int64 `json:"genesis_height"`
+	// GenesisHash is the hash of the genesis block. It is empty in case the genesis block is not
+	// available (e.g., because it has been pruned).
+	GenesisHash hash.Hash `json:"genesis_hash"`
+
+	// Height is the height at which the parameters below were queried.
+	Height int64 `json:"height"`
+
+	// Versions are the protocol versions used by the node.
+	Versions version.ProtocolVersions `json:"versions"`
+
+	// EpochInterval is the epoch interval (in blocks).
+	EpochInterval int64 `json:"epoch_interval"`
+
+	// MaxTxSize is the maximum transaction size (in bytes).
+	MaxTxSize uint64 `json:"max_tx_size"`
+	// MaxBlockGas is the maximum amount of gas in a block.
+	MaxBlockGas transaction.Gas `json:"max_block_gas"`
+	// GasCosts are the base consensus transaction gas costs.
+	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
+	// StakingGasCosts are the staking transaction gas costs.
+	StakingGasCosts transaction.Costs `json:"staking_gas_costs,omitempty"`
+}
+
 // Backend is an interface that a consensus backend must provide.
 type Backend interface {
 	service.BackgroundService
@@ -306,6 +356,46 @@ type GetSignerNonceRequest struct {
 	Height         int64           `json:"height"`
 }
 
+// Validator is a consensus validator.
+type Validator struct {
+	// ID is the validator's node identifier. It is empty in case the node is not registered
+	// at the queried height.
+	ID signature.PublicKey `json:"id"`
+	// EntityID is the identifier of the entity controlling the validator. It is empty in case
+	// the node is not registered at the queried height.
+	EntityID signature.PublicKey `json:"entity_id"`
+	// ConsensusID is the validator's consensus public key.
+	ConsensusID signature.PublicKey `json:"consensus_id"`
+	// VotingPower is the validator's consensus voting power.
+	VotingPower int64 `json:"voting_power"`
+}
+
+// ValidatorSet is the consensus validator set together with the consensus parameters at a
+// specific height.
+type ValidatorSet struct {
+	// Height is the block height the validator set is for.
+	Height int64 `json:"height"`
+	// Validators are the validators, in the order used by the consensus backend.
+	Validators []*Validator `json:"validators"`
+	// TotalVotingPower is the sum of all validators' voting powers.
+	TotalVotingPower int64 `json:"total_voting_power"`
+	// Parameters are the consensus parameters at the given height.
+	Parameters *Parameters `json:"parameters"`
+}
+
+// MaxSubmitTxBatchSize is the maximum number of transactions that can be submitted in a single
+// SubmitTxBatch call.
+const MaxSubmitTxBatchSize = 1024
+
+// SubmitTxBatchResult is the result of submitting a single transaction as part of a batch.
+type SubmitTxBatchResult struct {
+	// Hash is the hash of the submitted transaction.
+	Hash hash.Hash `json:"hash"`
+	// Error is the CheckTx error in case the transaction has been rejected. A successful result
+	// means that the transaction has been accepted into the local mempool.
+	Error results.Error `json:"error"`
+}
+
 // TransactionsWithResults is GetTransactionsWithResults response.
 //
 // Results[i] are the results of executing Transactions[i].
@@ -313,3 +403,51 @@ type TransactionsWithResults struct {
 	Transactions [][]byte          `json:"transactions"`
 	Results      []*results.Result `json:"results"`
 }
+
+// TransactionWithResult is a decoded transaction together with its execution result.
+type TransactionWithResult struct {
+	// Raw is the raw transaction as included in the block.
+	Raw []byte `json:"raw"`
+	// Signed is the decoded signed transaction.
+	//
+	// It is nil in case the raw transaction could not be decoded.
+	Signed *transaction.SignedTransaction `json:"signed,omitempty"`
+	// Transaction is the decoded transaction body.
+	//
+	// It is nil in case the raw transaction could not be decoded.
+	Transaction *transaction.Transaction `json:"transaction,omitempty"`
+	// Result is the transaction execution result.
+	Result *results.Result `json:"result"`
+}
+
+// Decode decodes all of the transactions and pairs them with their execution results.
+//
+// Malformed transactions do not cause decoding to fail as they may legitimately be included in a
+// block (and have a failed execution result). The signatures are not verified.
+func (t *TransactionsWithResults) Decode() ([]*TransactionWithResult, error) {
+	if len(t.Transactions) != len(t.Results) {
+		return nil, fmt.Errorf("consensus: transactions/results length mismatch (%d != %d)",
+			len(t.Transactions), len(t.Results),
+		)
+	}
+
+	txs := make([]*TransactionWithResult, 0, len(t.Transactions))
+	for i, raw := range t.Transactions {
+		txr := &TransactionWithResult{
+			Raw:    raw,
+			Result: t.Results[i],
+		}
+
+		var sigTx transaction.SignedTransaction
+		if err := cbor.Unmarshal(raw, &sigTx); err == nil {
+			var tx transaction.Transaction
+			if err = cbor.Unmarshal(sigTx.Blob, &tx); err == nil {
+				txr.Signed = &sigTx
+				txr.Transaction = &tx
+			}
+		}
+
+		txs = append(txs, txr)
+	}
+	return txs, nil
+}
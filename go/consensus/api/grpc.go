@@ -20,12 +20,16 @@ import (
 
 var (
 	// serviceName is the gRPC service name.
-	serviceName = cmnGrpc.NewServiceName("Consensus")
+	serviceName = cmnGrpc.NewServiceName("Consensus").WithDefaultCompression(cmnGrpc.CompressorZstd)
 	// lightServiceName is the gRPC service name for the light consensus interface.
 	lightServiceName = cmnGrpc.NewServiceName("ConsensusLight")
 
 	// methodSubmitTx is the SubmitTx method.
 	methodSubmitTx = serviceName.NewMethod("SubmitTx", transaction.SignedTransaction{})
+	// methodSubmitTxWithProof is the SubmitTxWithProof method.
+	methodSubmitTxWithProof = serviceName.NewMethod("SubmitTxWithProof", transaction.SignedTransaction{})
+	// methodSubmitTxBatch is the SubmitTxBatch method.
+	methodSubmitTxBatch = serviceName.NewMethod("SubmitTxBatch", []*transaction.SignedTransaction{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodEstimateGas is the EstimateGas method.
@@ -34,6 +38,8 @@ var (
 	methodGetSignerNonce = serviceName.NewMethod("GetSignerNonce", &GetSignerNonceRequest{})
 	// methodGetBlock is the GetBlock method.
 	methodGetBlock = serviceName.NewMethod("GetBlock", int64(0))
+	// methodGetValidatorSet is the GetValidatorSet method.
+	methodGetValidatorSet = serviceName.NewMethod("GetValidatorSet", int64(0))
 	// methodGetTransactions is the GetTransactions method.
 	methodGetTransactions = serviceName.NewMethod("GetTransactions", int64(0))
 	// methodGetTransactionsWithResults is the GetTransactionsWithResults method.
@@ -46,6 +52,8 @@ var (
 	methodGetChainContext = serviceName.NewMethod("GetChainContext", nil)
 	// methodGetStatus is the GetStatus method.
 	methodGetStatus = serviceName.NewMethod("GetStatus", nil)
+	// methodGetNetworkMetadata is the GetNetworkMetadata method.
+	methodGetNetworkMetadata = serviceName.NewMethod("GetNetworkMetadata", nil)
 	// methodGetNextBlockState is the GetNextBlockState method.
 	methodGetNextBlockState = serviceName.NewMethod("GetNextBlockState", nil)
 
@@ -76,6 +84,14 @@ var (
 				MethodName: methodSubmitTx.ShortName(),
 				Handler:    handlerSubmitTx,
 			},
+			{
+				MethodName: methodSubmitTxWithProof.ShortName(),
+				Handler:    handlerSubmitTxWithProof,
+			},
+			{
+				MethodName: methodSubmitTxBatch.ShortName(),
+				Handler:    handlerSubmitTxBatch,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -92,6 +108,10 @@ var (
 				MethodName: methodGetBlock.ShortName(),
 				Handler:    handlerGetBlock,
 			},
+			{
+				MethodName: methodGetValidatorSet.ShortName(),
+				Handler:    handlerGetValidatorSet,
+			},
 			{
 				MethodName: methodGetTransactions.ShortName(),
 				Handler:    handlerGetTransactions,
@@ -116,6 +136,10 @@ var (
 				MethodName: methodGetStatus.ShortName(),
 				Handler:    handlerGetStatus,
 			},
+			{
+				MethodName: methodGetNetworkMetadata.ShortName(),
+				Handler:    handlerGetNetworkMetadata,
+			},
 			{
 				MethodName: methodGetNextBlockState.ShortName(),
 				Handler:    handlerGetNextBlockState,
@@ -190,6 +214,52 @@ func handlerSubmitTx( // nolint: golint
 	return interceptor(ctx, rq, info, handler)
 }
 
+func handlerSubmitTxWithProof( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(transaction.SignedTransaction)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).SubmitTxWithProof(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSubmitTxWithProof.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).SubmitTxWithProof(ctx, req.(*transaction.SignedTransaction))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
+func handlerSubmitTxBatch( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var txs []*transaction.SignedTransaction
+	if err := dec(&txs); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).SubmitTxBatch(ctx, txs)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSubmitTxBatch.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).SubmitTxBatch(ctx, req.([]*transaction.SignedTransaction))
+	}
+	return interceptor(ctx, txs, info, handler)
+}
+
 func handlerStateToGenesis( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -282,6 +352,29 @@ func handlerGetBlock( // nolint: golint
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetValidatorSet( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).GetValidatorSet(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetValidatorSet.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetValidatorSet(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerGetTransactions( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -404,6 +497,25 @@ func handlerGetStatus( // nolint: golint
 	return interceptor(ctx, nil, info, handler)
 }
 
+func handlerGetNetworkMetadata( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(ClientBackend).GetNetworkMetadata(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetNetworkMetadata.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetNetworkMetadata(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
 func handlerGetNextBlockState( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -701,6 +813,22 @@ func (c *consensusClient) SubmitTx(ctx context.Context, tx *transaction.SignedTr
 	return c.conn.Invoke(ctx, methodSubmitTx.FullName(), tx, nil)
 }
 
+func (c *consensusClient) SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*transaction.Proof, error) {
+	var proof transaction.Proof
+	if err := c.conn.Invoke(ctx, methodSubmitTxWithProof.FullName(), tx, &proof); err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
+func (c *consensusClient) SubmitTxBatch(ctx context.Context, txs []*transaction.SignedTransaction) ([]*SubmitTxBatchResult, error) {
+	var rsp []*SubmitTxBatchResult
+	if err := c.conn.Invoke(ctx, methodSubmitTxBatch.FullName(), txs, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *consensusClient) StateToGenesis(ctx context.Context, height int64) (*genesis.Document, error) {
 	var rsp genesis.Document
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
@@ -733,6 +861,14 @@ func (c *consensusClient) GetBlock(ctx context.Context, height int64) (*Block, e
 	return &rsp, nil
 }
 
+func (c *consensusClient) GetValidatorSet(ctx context.Context, height int64) (*ValidatorSet, error) {
+	var rsp ValidatorSet
+	if err := c.conn.Invoke(ctx, methodGetValidatorSet.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *consensusClient) GetTransactions(ctx context.Context, height int64) ([][]byte, error) {
 	var rsp [][]byte
 	if err := c.conn.Invoke(ctx, methodGetTransactions.FullName(), height, &rsp); err != nil {
@@ -781,6 +917,14 @@ func (c *consensusClient) GetStatus(ctx context.Context) (*Status, error) {
 	return &rsp, nil
 }
 
+func (c *consensusClient) GetNetworkMetadata(ctx context.Context) (*NetworkMetadata, error) {
+	var rsp NetworkMetadata
+	if err := c.conn.Invoke(ctx, methodGetNetworkMetadata.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *consensusClient) GetNextBlockState(ctx context.Context) (*NextBlockState, error) {
 	var rsp NextBlockState
 	if err := c.conn.Invoke(ctx, methodGetNextBlockState.FullName(), nil, &rsp); err != nil {
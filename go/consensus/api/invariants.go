@@ -0,0 +1,32 @@
+package api
+
+import (
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+)
+
+// InvariantViolation is a violation of a consensus state invariant.
+type InvariantViolation struct {
+	// Module is the name of the module that registered the invariant.
+	Module string `json:"module"`
+	// Name is the name of the invariant.
+	Name string `json:"name"`
+	// Error is the reason why the invariant does not hold.
+	Error string `json:"error"`
+}
+
+// InvariantsReport is the result of checking the consensus state invariants.
+type InvariantsReport struct {
+	// Height is the consensus height at which the invariants were checked.
+	Height int64 `json:"height"`
+	// Epoch is the epoch at which the invariants were checked.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// Checked is the number of invariants that were checked.
+	Checked int `json:"checked"`
+	// Violations are the violated invariants.
+	Violations []*InvariantViolation `json:"violations,omitempty"`
+}
+
+// IsValid returns true iff none of the invariants were violated.
+func (r *InvariantsReport) IsValid() bool {
+	return len(r.Violations) == 0
+}
@@ -0,0 +1,93 @@
+// Package proof implements proofs of consensus state.
+package proof
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+// StateProof is a Merkle proof of a consensus state key/value pair against a consensus state root.
+//
+// A proof for a key that does not exist in the state proves its non-existence.
+type StateProof struct {
+	// Root is the consensus state root against which the proof was generated.
+	//
+	// When using the Tendermint consensus backend, the root hash is equal to the application hash
+	// included in the block header at height Root.Version+1.
+	Root node.Root `json:"root"`
+	// Key is the state key that the proof is for.
+	Key []byte `json:"key"`
+	// Proof is the raw MKVS proof.
+	Proof syncer.Proof `json:"proof"`
+}
+
+// Generate generates a state proof for the given key using the given read syncer.
+func Generate(ctx context.Context, rs syncer.ReadSyncer, root node.Root, key []byte) (*StateProof, error) {
+	rsp, err := rs.SyncGet(ctx, &syncer.GetRequest{
+		Tree: syncer.TreeID{
+			Root:     root,
+			Position: root.Hash,
+		},
+		Key: key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("proof: failed to generate proof: %w", err)
+	}
+
+	return &StateProof{
+		Root:  root,
+		Key:   key,
+		Proof: rsp.Proof,
+	}, nil
+}
+
+// Verify verifies the state proof against the given trusted state root and the expected key.
+//
+// On success it returns the proven value or nil in case the key does not exist in the state.
+func (p *StateProof) Verify(ctx context.Context, root node.Root, key []byte) ([]byte, error) {
+	if !p.Root.Equal(&root) {
+		return nil, fmt.Errorf("proof: root mismatch (expected: %s got: %s)", root, p.Root)
+	}
+	if !bytes.Equal(p.Key, key) {
+		return nil, fmt.Errorf("proof: key mismatch (expected: %X got: %X)", key, p.Key)
+	}
+
+	tree := mkvs.NewWithRoot(&proofReadSyncer{proof: &p.Proof}, nil, root)
+	defer tree.Close()
+
+	value, err := tree.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("proof: verification failed: %w", err)
+	}
+	return value, nil
+}
+
+// proofReadSyncer is a read syncer that serves a single pre-fetched proof. The tree using the
+// syncer verifies the proof against its root before using it.
+type proofReadSyncer struct {
+	proof *syncer.Proof
+}
+
+func (rs *proofReadSyncer) SyncGet(ctx context.Context, request *syncer.GetRequest) (*syncer.ProofResponse, error) {
+	if rs.proof == nil {
+		// The proof has already been consumed and does not contain all the required nodes.
+		return nil, syncer.ErrUnsupported
+	}
+	proof := rs.proof
+	rs.proof = nil
+
+	return &syncer.ProofResponse{Proof: *proof}, nil
+}
+
+func (rs *proofReadSyncer) SyncGetPrefixes(ctx context.Context, request *syncer.GetPrefixesRequest) (*syncer.ProofResponse, error) {
+	return nil, syncer.ErrUnsupported
+}
+
+func (rs *proofReadSyncer) SyncIterate(ctx context.Context, request *syncer.IterateRequest) (*syncer.ProofResponse, error) {
+	return nil, syncer.ErrUnsupported
+}
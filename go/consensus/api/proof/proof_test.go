@@ -0,0 +1,75 @@
+package proof
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+func TestStateProof(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	tree := mkvs.New(nil, nil, node.RootTypeState)
+	defer tree.Close()
+
+	for i := 0; i < 100; i++ {
+		err := tree.Insert(ctx, []byte(fmt.Sprintf("key %d", i)), []byte(fmt.Sprintf("value %d", i)))
+		require.NoError(err, "Insert")
+	}
+	var ns common.Namespace
+	_, rootHash, err := tree.Commit(ctx, ns, 42)
+	require.NoError(err, "Commit")
+
+	root := node.Root{
+		Namespace: ns,
+		Version:   42,
+		Type:      node.RootTypeState,
+		Hash:      rootHash,
+	}
+
+	// Existing key.
+	key := []byte("key 10")
+	p, err := Generate(ctx, tree, root, key)
+	require.NoError(err, "Generate")
+	value, err := p.Verify(ctx, root, key)
+	require.NoError(err, "Verify")
+	require.EqualValues([]byte("value 10"), value, "proven value should be correct")
+
+	// Non-existing key.
+	missingKey := []byte("missing key")
+	p2, err := Generate(ctx, tree, root, missingKey)
+	require.NoError(err, "Generate")
+	value, err = p2.Verify(ctx, root, missingKey)
+	require.NoError(err, "Verify")
+	require.Nil(value, "proven value should be nil for non-existing key")
+
+	// Key mismatch.
+	_, err = p.Verify(ctx, root, []byte("key 11"))
+	require.Error(err, "Verify should fail for a different key")
+
+	// Root mismatch.
+	badRoot := root
+	badRoot.Hash = hash.NewFromBytes([]byte("bogus root"))
+	_, err = p.Verify(ctx, badRoot, key)
+	require.Error(err, "Verify should fail for a different root")
+
+	// Proof for a different root.
+	forged := *p
+	forged.Root = badRoot
+	_, err = forged.Verify(ctx, badRoot, key)
+	require.Error(err, "Verify should fail for a proof that does not match the root")
+
+	// Proof for a different key.
+	forged = *p2
+	forged.Key = key
+	_, err = forged.Verify(ctx, root, key)
+	require.Error(err, "Verify should fail for a proof that does not include the key")
+}
@@ -32,6 +32,9 @@ type Fee struct {
 	Amount quantity.Quantity `json:"amount"`
 	// Gas is the maximum gas that a transaction can use.
 	Gas Gas `json:"gas"`
+	// Tip is an optional tip that is paid on top of the fee amount and goes to the
+	// proposer of the block including the transaction in full.
+	Tip *quantity.Quantity `json:"tip,omitempty"`
 }
 
 // PrettyPrint writes a pretty-printed representation of the fee to the given
@@ -41,6 +44,12 @@ func (f Fee) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
 	token.PrettyPrintAmount(ctx, f.Amount, w)
 	fmt.Fprintln(w)
 
+	if f.Tip != nil {
+		fmt.Fprintf(w, "%sTip: ", prefix)
+		token.PrettyPrintAmount(ctx, *f.Tip, w)
+		fmt.Fprintln(w)
+	}
+
 	fmt.Fprintf(w, "%sGas limit: %d\n", prefix, f.Gas)
 	fmt.Fprintf(w, "%s(gas price: ", prefix)
 	token.PrettyPrintAmount(ctx, *f.GasPrice(), w)
@@ -55,7 +64,28 @@ func (f Fee) PrettyType() (interface{}, error) {
 
 // GasPrice returns the gas price implied by the amount and gas.
 func (f Fee) GasPrice() *quantity.Quantity {
-	if f.Amount.IsZero() || f.Gas == 0 {
+	return f.gasPrice(&f.Amount)
+}
+
+// TotalAmount returns the total amount paid by the sender, including the tip.
+func (f Fee) TotalAmount() *quantity.Quantity {
+	total := f.Amount.Clone()
+	if f.Tip != nil {
+		if err := total.Add(f.Tip); err != nil {
+			// Should never happen.
+			panic(err)
+		}
+	}
+	return total
+}
+
+// EffectiveGasPrice returns the gas price implied by the total amount (including the tip) and gas.
+func (f Fee) EffectiveGasPrice() *quantity.Quantity {
+	return f.gasPrice(f.TotalAmount())
+}
+
+func (f Fee) gasPrice(amount *quantity.Quantity) *quantity.Quantity {
+	if amount.IsZero() || f.Gas == 0 {
 		return quantity.NewQuantity()
 	}
 
@@ -65,7 +95,7 @@ func (f Fee) GasPrice() *quantity.Quantity {
 		panic(err)
 	}
 
-	amt := f.Amount.Clone()
+	amt := amount.Clone()
 	if err := amt.Quo(&gasQ); err != nil {
 		// Should never happen.
 		panic(err)
@@ -20,3 +20,19 @@ func TestFeeGasPrice(t *testing.T) {
 	require.NoError(t, referencePrice.FromUint64(1), "import reference price")
 	require.Zero(t, gasPrice.Cmp(&referencePrice), "price matches")
 }
+
+func TestFeeTip(t *testing.T) {
+	require := require.New(t)
+
+	fee := Fee{
+		Amount: *quantity.NewFromUint64(1000),
+		Gas:    100,
+	}
+	require.EqualValues(quantity.NewFromUint64(1000), fee.TotalAmount(), "total amount without tip")
+	require.EqualValues(quantity.NewFromUint64(10), fee.EffectiveGasPrice(), "effective gas price without tip")
+
+	fee.Tip = quantity.NewFromUint64(500)
+	require.EqualValues(quantity.NewFromUint64(1500), fee.TotalAmount(), "total amount with tip")
+	require.EqualValues(quantity.NewFromUint64(10), fee.GasPrice(), "gas price should not include tip")
+	require.EqualValues(quantity.NewFromUint64(15), fee.EffectiveGasPrice(), "effective gas price with tip")
+}
@@ -2,6 +2,7 @@ package results
 
 import (
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
@@ -24,10 +25,20 @@ type Error struct {
 	Message string `json:"message,omitempty"`
 }
 
+// Err returns the typed error corresponding to the transaction execution error or nil in case
+// execution was successful.
+func (e *Error) Err() error {
+	if e.Code == errors.CodeNoError {
+		return nil
+	}
+	return errors.FromCode(e.Module, e.Code, e.Message)
+}
+
 // Result is a transaction execution result.
 type Result struct {
-	Error  Error    `json:"error"`
-	Events []*Event `json:"events"`
+	Error   Error           `json:"error"`
+	Events  []*Event        `json:"events"`
+	GasUsed transaction.Gas `json:"gas_used,omitempty"`
 }
 
 // IsSuccess returns true if transaction execution was successful.
@@ -221,6 +221,15 @@ func Sign(signer signature.Signer, tx *Transaction) (*SignedTransaction, error)
 	return &SignedTransaction{Signed: *signed}, nil
 }
 
+// Proof is a proof of transaction inclusion in a block.
+type Proof struct {
+	// Height is the block height at which the transaction was published.
+	Height int64 `json:"height"`
+
+	// RawProof is the actual raw proof.
+	RawProof []byte `json:"raw_proof"`
+}
+
 // MethodSeparator is the separator used to separate backend name from method name.
 const MethodSeparator = "."
 
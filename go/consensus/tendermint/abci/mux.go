@@ -22,6 +22,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
@@ -65,6 +66,11 @@ var (
 	metricsOnce sync.Once
 )
 
+func init() {
+	// Both the genesis digest and the init chain events keys share the same prefix.
+	api.RegisterStateKeyFormats("consensus", keyformat.New(StateKeyGenesisDigest[0]))
+}
+
 // ApplicationConfig is the configuration for the consensus application.
 type ApplicationConfig struct { // nolint: maligned
 	DataDir         string
@@ -187,6 +193,11 @@ func (a *ApplicationServer) EstimateGas(caller signature.PublicKey, tx *transact
 	return a.mux.EstimateGas(caller, tx)
 }
 
+// CheckInvariants checks all registered consensus state invariants against the latest state.
+func (a *ApplicationServer) CheckInvariants() (*consensus.InvariantsReport, error) {
+	return a.mux.checkInvariants()
+}
+
 // State returns the application state.
 func (a *ApplicationServer) State() api.ApplicationQueryState {
 	return a.mux.state
@@ -360,6 +371,15 @@ func (mux *abciMux) InitChain(req types.RequestInitChain) types.ResponseInitChai
 	if err = state.SetConsensusParameters(ctx, &st.Consensus.Parameters); err != nil {
 		panic(fmt.Errorf("mux: failed to set consensus parameters: %w", err))
 	}
+	// Record the state versions of all applications.
+	for _, app := range mux.appsByLexOrder {
+		if app.StateVersion() == 0 {
+			continue
+		}
+		if err = state.SetStateVersion(ctx, app.Name(), app.StateVersion()); err != nil {
+			panic(fmt.Errorf("mux: failed to set state version of application '%s': %w", app.Name(), err))
+		}
+	}
 	// Since InitChain does not have a commit step, perform some state updates here.
 	if err = mux.state.doInitChain(st.Time); err != nil {
 		panic(fmt.Errorf("mux: failed to init chain state: %w", err))
@@ -663,6 +683,23 @@ func (mux *abciMux) EstimateGas(caller signature.PublicKey, tx *transaction.Tran
 	return ctx.Gas().GasUsed(), nil
 }
 
+func (mux *abciMux) checkInvariants() (*consensus.InvariantsReport, error) {
+	if atomic.LoadInt64(&mux.lastBeginBlock) == blockHeightInvalid {
+		return nil, consensus.ErrNoCommittedBlocks
+	}
+
+	// Similar to EstimateGas, this method can be called in parallel to the consensus layer so
+	// use a simulation context which operates on a separate tree at the latest height.
+	ctx := mux.state.NewContext(api.ContextSimulateTx, time.Time{})
+	defer ctx.Close()
+
+	epoch, err := mux.state.GetCurrentEpoch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current epoch: %w", err)
+	}
+	return api.CheckInvariants(ctx, epoch), nil
+}
+
 func (mux *abciMux) notifyInvalidatedCheckTx(txHash hash.Hash, err error) {
 	if item, exists := mux.invalidatedTxs.Load(txHash); exists {
 		// Notify subscriber.
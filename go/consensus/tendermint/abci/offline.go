@@ -0,0 +1,80 @@
+package abci
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	tmcommon "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/common"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	storageDB "github.com/oasisprotocol/oasis-core/go/storage/database"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+)
+
+var _ api.ApplicationQueryState = (*offlineQueryState)(nil)
+
+// OpenOfflineState opens the ABCI state storage of the node with the given data directory for
+// offline access (e.g., by debug commands) and returns the application query state at the given
+// height. A zero height selects the most recent height.
+//
+// The caller is responsible for cleaning up the storage backend of the returned state.
+func OpenOfflineState(ctx context.Context, dataDir string, height int64, readOnly bool) (api.ApplicationQueryState, error) {
+	ldb, _, stateRoot, err := InitStateStorage(
+		ctx,
+		&ApplicationConfig{
+			DataDir:             filepath.Join(dataDir, tmcommon.StateDir),
+			StorageBackend:      storageDB.BackendNameBadgerDB, // No other backend for now.
+			MemoryOnlyStorage:   false,
+			ReadOnlyStorage:     readOnly,
+			DisableCheckpointer: true,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize ABCI storage backend: %w", err)
+	}
+
+	latestHeight := int64(stateRoot.Version)
+	if height == 0 {
+		height = latestHeight
+	}
+	if height <= 0 || height > latestHeight {
+		ldb.Cleanup()
+		return nil, fmt.Errorf("state at height %d does not exist (latest height: %d)", height, latestHeight)
+	}
+
+	return &offlineQueryState{
+		ldb:    ldb,
+		height: height,
+	}, nil
+}
+
+type offlineQueryState struct {
+	ldb    storage.LocalBackend
+	height int64
+}
+
+func (qs *offlineQueryState) Storage() storage.LocalBackend {
+	return qs.ldb
+}
+
+func (qs *offlineQueryState) Checkpointer() checkpoint.Checkpointer {
+	return nil
+}
+
+func (qs *offlineQueryState) BlockHeight() int64 {
+	return qs.height
+}
+
+func (qs *offlineQueryState) GetEpoch(ctx context.Context, blockHeight int64) (beacon.EpochTime, error) {
+	// This is only required because certain registry backend queries need the epoch to filter
+	// out expired nodes. It is not implemented because offline state access does not involve
+	// any of the relevant queries.
+	return beacon.EpochTime(0), fmt.Errorf("abci/offlineQueryState: GetEpoch not supported")
+}
+
+func (qs *offlineQueryState) LastRetainedVersion() (int64, error) {
+	// This is not required for offline state access.
+	return 0, fmt.Errorf("abci/offlineQueryState: LastRetainedVersion not supported")
+}
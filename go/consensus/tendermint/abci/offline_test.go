@@ -0,0 +1,78 @@
+package abci
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	tmcommon "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/common"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	storageDB "github.com/oasisprotocol/oasis-core/go/storage/database"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+)
+
+func TestOpenOfflineState(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	ctx := context.Background()
+
+	// Create some state versions.
+	ldb, ndb, _, err := InitStateStorage(ctx, &ApplicationConfig{
+		DataDir:        filepath.Join(dataDir, tmcommon.StateDir),
+		StorageBackend: storageDB.BackendNameBadgerDB,
+	})
+	require.NoError(err, "InitStateStorage")
+	tree := mkvs.New(nil, ndb, storage.RootTypeState)
+	for version := uint64(1); version <= 3; version++ {
+		err = tree.Insert(ctx, []byte("key"), []byte(fmt.Sprintf("value:%d", version)))
+		require.NoError(err, "Insert")
+		_, rootHash, cerr := tree.Commit(ctx, common.Namespace{}, version)
+		require.NoError(cerr, "Commit")
+		err = ndb.Finalize(ctx, []storage.Root{{Version: version, Type: storage.RootTypeState, Hash: rootHash}})
+		require.NoError(err, "Finalize")
+	}
+	tree.Close()
+	ldb.Cleanup()
+
+	for _, tc := range []struct {
+		height         int64
+		expectedHeight int64
+	}{
+		{0, 3},
+		{3, 3},
+		{2, 2},
+	} {
+		qs, err := OpenOfflineState(ctx, dataDir, tc.height, true)
+		require.NoError(err, "OpenOfflineState")
+		require.EqualValues(tc.expectedHeight, qs.BlockHeight(), "state should be opened at the requested height")
+		require.Nil(qs.Checkpointer(), "offline state should not have a checkpointer")
+		_, err = qs.GetEpoch(ctx, qs.BlockHeight())
+		require.Error(err, "GetEpoch should not be supported")
+
+		roots, err := qs.Storage().NodeDB().GetRootsForVersion(ctx, uint64(qs.BlockHeight()))
+		require.NoError(err, "GetRootsForVersion")
+		require.Len(roots, 1)
+		st := mkvs.NewWithRoot(nil, qs.Storage().NodeDB(), roots[0])
+		value, err := st.Get(ctx, []byte("key"))
+		require.NoError(err, "Get")
+		require.EqualValues(fmt.Sprintf("value:%d", tc.expectedHeight), value, "state should be at the requested height")
+		st.Close()
+
+		qs.Storage().Cleanup()
+	}
+
+	for _, height := range []int64{-1, 4} {
+		_, err = OpenOfflineState(ctx, dataDir, height, true)
+		require.Error(err, "OpenOfflineState should fail for non-existent heights")
+	}
+
+	// Storage should be cleaned up on failure so it can be opened again.
+	qs, err := OpenOfflineState(ctx, dataDir, 0, false)
+	require.NoError(err, "OpenOfflineState")
+	qs.Storage().Cleanup()
+}
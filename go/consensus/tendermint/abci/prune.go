@@ -22,6 +22,11 @@ const (
 	// LogEventABCIPruneDelete is a log event value that signals an ABCI pruning
 	// delete event.
 	LogEventABCIPruneDelete = "tendermint/abci/prune"
+
+	// minKept is the minimum number of retained versions. The roothash
+	// checkCommittees call requires at least 1 previous block for timekeeping
+	// purposes.
+	minKept = 1
 )
 
 // PruneStrategy is the strategy to use when pruning the ABCI mux state.
@@ -100,6 +105,10 @@ func (p *nonePruner) Prune(ctx context.Context, latestVersion uint64) error {
 	return nil
 }
 
+func (p *nonePruner) PruneUntil(ctx context.Context, version uint64, progressFn func(version uint64)) error {
+	return api.ErrPruningDisabled
+}
+
 func (p *nonePruner) RegisterHandler(handler api.StatePruneHandler) {
 }
 
@@ -110,6 +119,12 @@ func (p *nonePruner) GetLastRetainedVersion() uint64 {
 type genericPruner struct {
 	sync.Mutex
 
+	// pruneLock serializes automatic and on-demand pruning.
+	pruneLock sync.Mutex
+	// commitLock is held while deleting a version so that pruning is serialized with state
+	// commits.
+	commitLock sync.Locker
+
 	logger *logging.Logger
 	ndb    nodedb.NodeDB
 
@@ -140,7 +155,14 @@ func (p *genericPruner) GetLastRetainedVersion() uint64 {
 }
 
 func (p *genericPruner) Prune(ctx context.Context, latestVersion uint64) error {
-	if err := p.doPrune(ctx, latestVersion); err != nil {
+	if latestVersion < p.keepN {
+		return nil
+	}
+
+	p.pruneLock.Lock()
+	defer p.pruneLock.Unlock()
+
+	if err := p.doPrune(ctx, latestVersion, latestVersion-p.keepN, nil); err != nil {
 		p.logger.Error("Prune",
 			"err", err,
 		)
@@ -149,17 +171,50 @@ func (p *genericPruner) Prune(ctx context.Context, latestVersion uint64) error {
 	return nil
 }
 
-func (p *genericPruner) doPrune(ctx context.Context, latestVersion uint64) error {
-	if latestVersion < p.keepN {
+func (p *genericPruner) PruneUntil(ctx context.Context, version uint64, progressFn func(version uint64)) error {
+	p.pruneLock.Lock()
+	defer p.pruneLock.Unlock()
+
+	latestVersion, err := p.ndb.GetLatestVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest version: %w", err)
+	}
+	if latestVersion < minKept {
 		return nil
 	}
+	if maxVersion := latestVersion - minKept; version > maxVersion {
+		version = maxVersion
+	}
 
+	p.logger.Info("pruning state on demand",
+		"latest_version", latestVersion,
+		"preserve_from", version,
+	)
+
+	if err = p.doPrune(ctx, latestVersion, version, progressFn); err != nil {
+		p.logger.Error("PruneUntil",
+			"err", err,
+		)
+		return err
+	}
+	if p.earliestVersion < version {
+		return fmt.Errorf("abci/pruner: pruning blocked at version %d", p.earliestVersion)
+	}
+	return nil
+}
+
+// Guarded by p.pruneLock.
+func (p *genericPruner) doPrune(
+	ctx context.Context,
+	latestVersion uint64,
+	preserveFrom uint64,
+	progressFn func(version uint64),
+) error {
 	p.logger.Debug("Prune: Start",
 		"latest_version", latestVersion,
 		"start_version", p.earliestVersion,
 	)
 
-	preserveFrom := latestVersion - p.keepN
 PruneLoop:
 	for i := p.earliestVersion; i <= latestVersion; i++ {
 		if i >= preserveFrom {
@@ -187,9 +242,14 @@ PruneLoop:
 			logging.LogEvent, LogEventABCIPruneDelete,
 		)
 
+		p.commitLock.Lock()
 		err := p.ndb.Prune(ctx, i)
+		p.commitLock.Unlock()
 		switch err {
 		case nil:
+			if progressFn != nil {
+				progressFn(i)
+			}
 		case nodedb.ErrNotEarliest:
 			p.logger.Debug("Prune: skipping non-earliest version",
 				"version", i,
@@ -226,11 +286,9 @@ func (p *genericPruner) RegisterHandler(handler api.StatePruneHandler) {
 	p.handlers = append(p.handlers, handler)
 }
 
-func newStatePruner(cfg *PruneConfig, ndb nodedb.NodeDB) (StatePruner, error) {
-	// The roothash checkCommittees call requires at least 1 previous block
-	// for timekeeping purposes.
-	const minKept = 1
-
+// newStatePruner creates a new state pruner. The given commit lock must be held by the caller
+// while committing new versions to the node database.
+func newStatePruner(cfg *PruneConfig, ndb nodedb.NodeDB, commitLock sync.Locker) (StatePruner, error) {
 	logger := logging.GetLogger("abci-mux/pruner")
 
 	var statePruner StatePruner
@@ -243,9 +301,10 @@ func newStatePruner(cfg *PruneConfig, ndb nodedb.NodeDB) (StatePruner, error) {
 		}
 
 		statePruner = &genericPruner{
-			logger: logger,
-			ndb:    ndb,
-			keepN:  cfg.NumKept,
+			commitLock: commitLock,
+			logger:     logger,
+			ndb:        ndb,
+			keepN:      cfg.NumKept,
 		}
 	default:
 		return nil, fmt.Errorf("abci/pruner: unsupported pruning strategy: %v", cfg.Strategy)
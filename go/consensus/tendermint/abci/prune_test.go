@@ -5,25 +5,28 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 	mkvsDB "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	mkvsBadgerDB "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
 	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 )
 
-func TestPruneKeepN(t *testing.T) {
+func createTestNodeDB(t *testing.T, numVersions uint64) mkvsDB.NodeDB {
 	require := require.New(t)
 
 	// Create a new random temporary directory under /tmp.
 	dir, err := ioutil.TempDir("", "abci-prune.test.badger")
 	require.NoError(err, "TempDir")
-	defer os.RemoveAll(dir)
+	t.Cleanup(func() { os.RemoveAll(dir) })
 
 	// Create a Badger-backed Node DB.
 	ndb, err := mkvsBadgerDB.New(&mkvsDB.Config{
@@ -32,10 +35,11 @@ func TestPruneKeepN(t *testing.T) {
 		MaxCacheSize: 16 * 1024 * 1024,
 	})
 	require.NoError(err, "New")
+	t.Cleanup(ndb.Close)
 	tree := mkvs.New(nil, ndb, mkvsNode.RootTypeState)
 
 	ctx := context.Background()
-	for i := uint64(1); i <= 11; i++ {
+	for i := uint64(1); i <= numVersions; i++ {
 		err = tree.Insert(ctx, []byte(fmt.Sprintf("key:%d", i)), []byte(fmt.Sprintf("value:%d", i)))
 		require.NoError(err, "Insert")
 
@@ -45,6 +49,14 @@ func TestPruneKeepN(t *testing.T) {
 		err = ndb.Finalize(ctx, []mkvsNode.Root{{Namespace: common.Namespace{}, Version: i, Type: mkvsNode.RootTypeState, Hash: rootHash}})
 		require.NoError(err, "Finalize")
 	}
+	return ndb
+}
+
+func TestPruneKeepN(t *testing.T) {
+	require := require.New(t)
+
+	ndb := createTestNodeDB(t, 11)
+	ctx := context.Background()
 
 	earliestVersion, err := ndb.GetEarliestVersion(ctx)
 	require.NoError(err, "GetEarliestVersion")
@@ -56,7 +68,7 @@ func TestPruneKeepN(t *testing.T) {
 	pruner, err := newStatePruner(&PruneConfig{
 		Strategy: PruneKeepN,
 		NumKept:  2,
-	}, ndb)
+	}, ndb, &sync.Mutex{})
 	require.NoError(err, "newStatePruner failed")
 
 	earliestVersion, err = ndb.GetEarliestVersion(ctx)
@@ -82,3 +94,78 @@ func TestPruneKeepN(t *testing.T) {
 	lastRetainedVersion = pruner.GetLastRetainedVersion()
 	require.EqualValues(9, lastRetainedVersion, "last retained version should be correct")
 }
+
+func TestPruneNone(t *testing.T) {
+	require := require.New(t)
+
+	ndb := createTestNodeDB(t, 11)
+	ctx := context.Background()
+
+	pruner, err := newStatePruner(&PruneConfig{
+		Strategy: PruneNone,
+	}, ndb, &sync.Mutex{})
+	require.NoError(err, "newStatePruner failed")
+
+	err = pruner.Prune(ctx, 11)
+	require.NoError(err, "Prune")
+	err = pruner.PruneUntil(ctx, 5, nil)
+	require.ErrorIs(err, api.ErrPruningDisabled, "PruneUntil should fail when pruning is disabled")
+
+	earliestVersion, err := ndb.GetEarliestVersion(ctx)
+	require.NoError(err, "GetEarliestVersion")
+	require.EqualValues(1, earliestVersion, "nothing should be pruned")
+	require.EqualValues(0, pruner.GetLastRetainedVersion(), "all versions should be retained")
+}
+
+func TestPruneUntil(t *testing.T) {
+	require := require.New(t)
+
+	ndb := createTestNodeDB(t, 11)
+	ctx := context.Background()
+
+	var commitLock sync.Mutex
+	pruner, err := newStatePruner(&PruneConfig{
+		Strategy: PruneKeepN,
+		NumKept:  100,
+	}, ndb, &commitLock)
+	require.NoError(err, "newStatePruner failed")
+
+	// Automatic pruning should not prune anything.
+	err = pruner.Prune(ctx, 11)
+	require.NoError(err, "Prune")
+	earliestVersion, err := ndb.GetEarliestVersion(ctx)
+	require.NoError(err, "GetEarliestVersion")
+	require.EqualValues(1, earliestVersion, "earliest version should be correct")
+
+	// Pruning on demand should wait for any state commits in progress.
+	commitLock.Lock()
+	var pruned []uint64
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- pruner.PruneUntil(ctx, 5, func(version uint64) {
+			pruned = append(pruned, version)
+		})
+	}()
+	require.Never(func() bool { return len(errCh) > 0 }, 100*time.Millisecond, 10*time.Millisecond,
+		"pruning should wait for state commits",
+	)
+	earliestVersion, err = ndb.GetEarliestVersion(ctx)
+	require.NoError(err, "GetEarliestVersion")
+	require.EqualValues(1, earliestVersion, "nothing should be pruned during a state commit")
+	commitLock.Unlock()
+
+	// Pruning on demand should prune all versions below the given version.
+	require.NoError(<-errCh, "PruneUntil")
+	require.EqualValues([]uint64{1, 2, 3, 4}, pruned, "progress should be reported for each version")
+	earliestVersion, err = ndb.GetEarliestVersion(ctx)
+	require.NoError(err, "GetEarliestVersion")
+	require.EqualValues(5, earliestVersion, "earliest version should be correct")
+	require.EqualValues(5, pruner.GetLastRetainedVersion(), "last retained version should be correct")
+
+	// The most recent versions should never be pruned.
+	err = pruner.PruneUntil(ctx, 100, nil)
+	require.NoError(err, "PruneUntil")
+	earliestVersion, err = ndb.GetEarliestVersion(ctx)
+	require.NoError(err, "GetEarliestVersion")
+	require.EqualValues(10, earliestVersion, "earliest version should be correct")
+}
@@ -546,12 +546,6 @@ func newApplicationState(ctx context.Context, upgrader upgrade.Backend, cfg *App
 	deliverTxTree := mkvs.NewWithRoot(nil, ndb, *stateRoot, mkvs.WithoutWriteLog())
 	checkTxTree := mkvs.NewWithRoot(nil, ndb, *stateRoot, mkvs.WithoutWriteLog())
 
-	// Initialize the state pruner.
-	statePruner, err := newStatePruner(&cfg.Pruning, ndb)
-	if err != nil {
-		return nil, fmt.Errorf("state: failed to create pruner: %w", err)
-	}
-
 	var minGasPrice quantity.Quantity
 	if err = minGasPrice.FromInt64(int64(cfg.MinGasPrice)); err != nil {
 		return nil, fmt.Errorf("state: invalid minimum gas price: %w", err)
@@ -568,7 +562,6 @@ func newApplicationState(ctx context.Context, upgrader upgrade.Backend, cfg *App
 		checkTxTree:        checkTxTree,
 		stateRoot:          *stateRoot,
 		storage:            ldb,
-		statePruner:        statePruner,
 		prunerClosedCh:     make(chan struct{}),
 		prunerNotifyCh:     channels.NewRingChannel(1),
 		pruneInterval:      cfg.Pruning.PruneInterval,
@@ -580,6 +573,11 @@ func newApplicationState(ctx context.Context, upgrader upgrade.Backend, cfg *App
 		metricsClosedCh:    make(chan struct{}),
 	}
 
+	// Initialize the state pruner. Pruning is serialized with state commits.
+	if s.statePruner, err = newStatePruner(&cfg.Pruning, ndb, &s.blockLock); err != nil {
+		return nil, fmt.Errorf("state: failed to create pruner: %w", err)
+	}
+
 	// Refresh consensus parameters when loading state if we are past genesis.
 	if latestVersion >= s.initialHeight {
 		if err = s.doCommitOrInitChainLocked(time.Time{}); err != nil {
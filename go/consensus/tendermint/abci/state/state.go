@@ -16,6 +16,16 @@ import (
 // Value is CBOR-serialized consensusGenesis.Parameters.
 var parametersKeyFmt = keyformat.New(0xF1)
 
+// stateVersionKeyFmt is the key format used for application state versions.
+//
+// Key format is: 0xF2 <app-name (bytes)>.
+// Value is CBOR-serialized uint64.
+var stateVersionKeyFmt = keyformat.New(0xF2, []byte{})
+
+// InitialStateVersion is the state version of applications that have no state version recorded,
+// as state created before state versions were tracked uses the initial state schema.
+const InitialStateVersion uint64 = 1
+
 // ImmutableState is an immutable consensus backend state wrapper.
 type ImmutableState struct {
 	is *api.ImmutableState
@@ -48,6 +58,47 @@ func (s *ImmutableState) ConsensusParameters(ctx context.Context) (*consensusGen
 	return &params, nil
 }
 
+// StateVersion returns the state version of the given application.
+func (s *ImmutableState) StateVersion(ctx context.Context, app string) (uint64, error) {
+	raw, err := s.is.Get(ctx, stateVersionKeyFmt.Encode([]byte(app)))
+	if err != nil {
+		return 0, api.UnavailableStateError(err)
+	}
+	if raw == nil {
+		return InitialStateVersion, nil
+	}
+
+	var version uint64
+	if err = cbor.Unmarshal(raw, &version); err != nil {
+		return 0, api.UnavailableStateError(err)
+	}
+	return version, nil
+}
+
+// StateVersions returns the recorded state versions of all applications.
+func (s *ImmutableState) StateVersions(ctx context.Context) (map[string]uint64, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	versions := make(map[string]uint64)
+	for it.Seek(stateVersionKeyFmt.Encode()); it.Valid(); it.Next() {
+		var app []byte
+		if !stateVersionKeyFmt.Decode(it.Key(), &app) {
+			break
+		}
+
+		var version uint64
+		if err := cbor.Unmarshal(it.Value(), &version); err != nil {
+			return nil, api.UnavailableStateError(err)
+		}
+		versions[string(app)] = version
+	}
+	if it.Err() != nil {
+		return nil, api.UnavailableStateError(it.Err())
+	}
+	return versions, nil
+}
+
 // MutableState is a mutable consensus backend state wrapper.
 type MutableState struct {
 	*ImmutableState
@@ -66,6 +117,17 @@ func (s *MutableState) SetConsensusParameters(ctx context.Context, params *conse
 	return api.UnavailableStateError(err)
 }
 
+// SetStateVersion sets the state version of the given application.
+//
+// NOTE: This method must only be called from InitChain/EndBlock contexts.
+func (s *MutableState) SetStateVersion(ctx context.Context, app string, version uint64) error {
+	if err := s.is.CheckContextMode(ctx, []api.ContextMode{api.ContextInitChain, api.ContextEndBlock}); err != nil {
+		return err
+	}
+	err := s.ms.Insert(ctx, stateVersionKeyFmt.Encode([]byte(app)), cbor.Marshal(version))
+	return api.UnavailableStateError(err)
+}
+
 // NewMutableState creates a new mutable consensus backend state wrapper.
 func NewMutableState(tree mkvs.KeyValueTree) *MutableState {
 	return &MutableState{
@@ -75,3 +137,11 @@ func NewMutableState(tree mkvs.KeyValueTree) *MutableState {
 		ms: tree,
 	}
 }
+
+func init() {
+	api.RegisterStateKeyFormats(
+		"consensus",
+		parametersKeyFmt,
+		stateVersionKeyFmt,
+	)
+}
@@ -229,6 +229,9 @@ type Backend interface {
 
 	// Pruner returns the state pruner.
 	Pruner() StatePruner
+
+	// CheckInvariants checks all registered consensus state invariants against the latest state.
+	CheckInvariants(ctx context.Context) (*consensus.InvariantsReport, error)
 }
 
 // StatePruneHandler is a handler that is called when versions are pruned
@@ -249,6 +252,14 @@ type StatePruneHandler interface {
 type StatePruner interface {
 	// RegisterHandler registers a prune handler.
 	RegisterHandler(handler StatePruneHandler)
+
+	// PruneUntil purges all versions below the given version, regardless of
+	// the number of versions retained by the configured pruning strategy. The
+	// most recent versions required for correct operation are never pruned.
+	// In case state pruning is disabled, ErrPruningDisabled is returned.
+	//
+	// The optional progress callback is called after each pruned version.
+	PruneUntil(ctx context.Context, version uint64, progressFn func(version uint64)) error
 }
 
 // TransactionAuthHandler is the interface for ABCI applications that handle
@@ -52,6 +52,13 @@ type Application interface {
 	// ID returns the unique identifier of the application.
 	ID() uint8
 
+	// StateVersion returns the version of the application's state schema.
+	//
+	// The version must be incremented whenever the state schema changes in a way that requires
+	// existing state to be migrated (see the upgrade migrations package). Applications without
+	// any state should return zero in which case no state version is recorded.
+	StateVersion() uint64
+
 	// Methods returns the list of supported methods.
 	Methods() []transaction.MethodName
 
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+// InvariantFunc is a function that checks an invariant of the consensus state at the given epoch.
+//
+// Invariant functions must not modify the state.
+type InvariantFunc func(ctx *Context, epoch beacon.EpochTime) error
+
+// Invariant is a registered consensus state invariant.
+type Invariant struct {
+	// Module is the name of the module that registered the invariant.
+	Module string
+	// Name is the name of the invariant.
+	Name string
+	// Check is the function checking the invariant.
+	Check InvariantFunc
+}
+
+var invariants struct {
+	sync.RWMutex
+
+	registered map[string]*Invariant
+}
+
+// RegisterInvariant registers a new consensus state invariant for the given module.
+//
+// Invariant names must be unique within a module. If they are not, this method will panic.
+func RegisterInvariant(module, name string, fn InvariantFunc) {
+	invariants.Lock()
+	defer invariants.Unlock()
+
+	if invariants.registered == nil {
+		invariants.registered = make(map[string]*Invariant)
+	}
+	id := module + "/" + name
+	if _, ok := invariants.registered[id]; ok {
+		panic(fmt.Errorf("invariants: invariant '%s' already registered", id))
+	}
+	invariants.registered[id] = &Invariant{
+		Module: module,
+		Name:   name,
+		Check:  fn,
+	}
+}
+
+// Invariants returns all registered invariants, ordered by module and name.
+func Invariants() []*Invariant {
+	invariants.RLock()
+	defer invariants.RUnlock()
+
+	result := make([]*Invariant, 0, len(invariants.registered))
+	for _, inv := range invariants.registered {
+		result = append(result, inv)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Module != result[j].Module {
+			return result[i].Module < result[j].Module
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// CheckInvariants checks all registered invariants against the state of the given context and
+// returns a report of all violations.
+func CheckInvariants(ctx *Context, epoch beacon.EpochTime) *consensus.InvariantsReport {
+	report := &consensus.InvariantsReport{
+		Height: ctx.BlockHeight(),
+		Epoch:  epoch,
+	}
+	for _, inv := range Invariants() {
+		report.Checked++
+		if err := inv.Check(ctx, epoch); err != nil {
+			report.Violations = append(report.Violations, &consensus.InvariantViolation{
+				Module: inv.Module,
+				Name:   inv.Name,
+				Error:  err.Error(),
+			})
+		}
+	}
+	return report
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+)
+
+func TestInvariants(t *testing.T) {
+	require := require.New(t)
+
+	var checkedEpoch beacon.EpochTime
+	RegisterInvariant("test_invariants", "ok", func(ctx *Context, epoch beacon.EpochTime) error {
+		checkedEpoch = epoch
+		return nil
+	})
+	RegisterInvariant("test_invariants", "broken", func(ctx *Context, epoch beacon.EpochTime) error {
+		return fmt.Errorf("broken")
+	})
+	require.Panics(func() {
+		RegisterInvariant("test_invariants", "ok", func(ctx *Context, epoch beacon.EpochTime) error {
+			return nil
+		})
+	}, "registering a duplicate invariant should panic")
+
+	var names []string
+	for _, inv := range Invariants() {
+		if inv.Module == "test_invariants" {
+			names = append(names, inv.Name)
+		}
+	}
+	require.EqualValues([]string{"broken", "ok"}, names, "invariants should be sorted")
+
+	now := time.Unix(1580461674, 0)
+	appState := NewMockApplicationState(&MockApplicationStateConfig{})
+	ctx := appState.NewContext(ContextDeliverTx, now)
+	defer ctx.Close()
+
+	report := CheckInvariants(ctx, 42)
+	require.False(report.IsValid(), "report should not be valid")
+	require.EqualValues(42, report.Epoch)
+	require.EqualValues(42, checkedEpoch, "invariants should be checked at the given epoch")
+	require.Len(Invariants(), report.Checked, "all invariants should be checked")
+	require.Len(report.Violations, 1)
+	require.EqualValues("test_invariants", report.Violations[0].Module)
+	require.EqualValues("broken", report.Violations[0].Name)
+	require.EqualValues("broken", report.Violations[0].Error)
+}
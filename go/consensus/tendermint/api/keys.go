@@ -0,0 +1,150 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+)
+
+var stateKeys struct {
+	sync.RWMutex
+
+	// prefixes maps state key prefixes to the modules owning them.
+	prefixes map[byte]string
+}
+
+// RegisterStateKeyFormats registers the key formats used by the given module in the consensus
+// state. Registering key formats allows the module state to be inspected via IterateModule and
+// guards against different modules using the same key prefix.
+//
+// A prefix may only be registered by a single module. If it is not, this method will panic.
+func RegisterStateKeyFormats(module string, kfs ...*keyformat.KeyFormat) {
+	stateKeys.Lock()
+	defer stateKeys.Unlock()
+
+	if stateKeys.prefixes == nil {
+		stateKeys.prefixes = make(map[byte]string)
+	}
+	for _, kf := range kfs {
+		prefix := kf.Prefix()
+		if existing, ok := stateKeys.prefixes[prefix]; ok && existing != module {
+			panic(fmt.Errorf("state: key prefix 0x%02x of module '%s' already registered by module '%s'",
+				prefix,
+				module,
+				existing,
+			))
+		}
+		stateKeys.prefixes[prefix] = module
+	}
+}
+
+// StateModules returns the sorted names of all modules that registered state key formats.
+func StateModules() []string {
+	stateKeys.RLock()
+	defer stateKeys.RUnlock()
+
+	seen := make(map[string]bool)
+	var modules []string
+	for _, module := range stateKeys.prefixes {
+		if seen[module] {
+			continue
+		}
+		seen[module] = true
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+// StateKeyPrefixes returns the sorted state key prefixes registered by the given module.
+func StateKeyPrefixes(module string) []byte {
+	stateKeys.RLock()
+	defer stateKeys.RUnlock()
+
+	var prefixes []byte
+	for prefix, m := range stateKeys.prefixes {
+		if m == module {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		return prefixes[i] < prefixes[j]
+	})
+	return prefixes
+}
+
+// StateKeyModule returns the module that registered the prefix of the given state key.
+func StateKeyModule(key []byte) (string, bool) {
+	if len(key) == 0 {
+		return "", false
+	}
+
+	stateKeys.RLock()
+	defer stateKeys.RUnlock()
+
+	module, ok := stateKeys.prefixes[key[0]]
+	return module, ok
+}
+
+// IterateModule calls the given function for each state entry under the key prefixes registered
+// by the given module, in key order. Iteration stops on the first error returned by the function.
+func (s *ImmutableState) IterateModule(ctx context.Context, module string, fn func(key, value []byte) error) error {
+	prefixes := StateKeyPrefixes(module)
+	if len(prefixes) == 0 {
+		return fmt.Errorf("state: no key formats registered for module '%s'", module)
+	}
+
+	it := s.NewIterator(ctx)
+	defer it.Close()
+
+	for _, prefix := range prefixes {
+		p := []byte{prefix}
+		for it.Seek(p); it.Valid(); it.Next() {
+			if !bytes.HasPrefix(it.Key(), p) {
+				break
+			}
+			if err := fn(it.Key(), it.Value()); err != nil {
+				return err
+			}
+		}
+		if it.Err() != nil {
+			return UnavailableStateError(it.Err())
+		}
+	}
+	return nil
+}
+
+// CheckStateKeys verifies that all keys in the state belong to a registered module.
+func (s *ImmutableState) CheckStateKeys(ctx context.Context) error {
+	it := s.NewIterator(ctx)
+	defer it.Close()
+
+	for it.Rewind(); it.Valid(); {
+		key := it.Key()
+		if _, ok := StateKeyModule(key); !ok {
+			return fmt.Errorf("state: key %X does not belong to any registered module", key)
+		}
+
+		// Skip to the next prefix.
+		if key[0] == 0xff {
+			break
+		}
+		it.Seek([]byte{key[0] + 1})
+	}
+	if it.Err() != nil {
+		return UnavailableStateError(it.Err())
+	}
+	return nil
+}
+
+func init() {
+	RegisterInvariant("consensus", "state_keys", func(ctx *Context, epoch beacon.EpochTime) error {
+		st := &ImmutableState{ImmutableKeyValueTree: ctx.State()}
+		return st.CheckStateKeys(ctx)
+	})
+}
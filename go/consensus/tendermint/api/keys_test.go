@@ -0,0 +1,84 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+)
+
+func TestStateKeyFormats(t *testing.T) {
+	require := require.New(t)
+
+	fooKeyFmt := keyformat.New(0xE1, uint64(0))
+	fooOtherKeyFmt := keyformat.New(0xE0)
+	barKeyFmt := keyformat.New(0xE2)
+
+	RegisterStateKeyFormats("test_foo", fooKeyFmt, fooOtherKeyFmt)
+	RegisterStateKeyFormats("test_bar", barKeyFmt)
+	// Registering the same prefix by the same module should be allowed.
+	RegisterStateKeyFormats("test_foo", fooKeyFmt)
+
+	require.Panics(func() {
+		RegisterStateKeyFormats("test_bar", keyformat.New(0xE1))
+	}, "registering a prefix owned by another module should panic")
+
+	require.Contains(StateModules(), "test_foo")
+	require.Contains(StateModules(), "test_bar")
+	require.EqualValues([]byte{0xE0, 0xE1}, StateKeyPrefixes("test_foo"), "prefixes should be sorted")
+	require.EqualValues([]byte{0xE2}, StateKeyPrefixes("test_bar"))
+	require.Empty(StateKeyPrefixes("test_missing"))
+
+	module, ok := StateKeyModule(fooKeyFmt.Encode(uint64(1)))
+	require.True(ok)
+	require.EqualValues("test_foo", module)
+	_, ok = StateKeyModule([]byte{0xE3})
+	require.False(ok)
+	_, ok = StateKeyModule(nil)
+	require.False(ok)
+
+	now := time.Unix(1580461674, 0)
+	appState := NewMockApplicationState(&MockApplicationStateConfig{})
+	ctx := appState.NewContext(ContextDeliverTx, now)
+	defer ctx.Close()
+
+	tree := ctx.State()
+	for _, key := range [][]byte{
+		fooKeyFmt.Encode(uint64(2)),
+		fooKeyFmt.Encode(uint64(1)),
+		fooOtherKeyFmt.Encode(),
+		barKeyFmt.Encode(),
+	} {
+		err := tree.Insert(ctx, key, []byte("value"))
+		require.NoError(err, "Insert")
+	}
+
+	st := &ImmutableState{ImmutableKeyValueTree: ctx.State()}
+	var keys [][]byte
+	err := st.IterateModule(ctx, "test_foo", func(key, value []byte) error {
+		require.EqualValues([]byte("value"), value)
+		keys = append(keys, append([]byte{}, key...))
+		return nil
+	})
+	require.NoError(err, "IterateModule")
+	require.EqualValues([][]byte{
+		fooOtherKeyFmt.Encode(),
+		fooKeyFmt.Encode(uint64(1)),
+		fooKeyFmt.Encode(uint64(2)),
+	}, keys, "IterateModule should only return module keys in order")
+
+	err = st.IterateModule(ctx, "test_missing", func(key, value []byte) error {
+		return nil
+	})
+	require.Error(err, "IterateModule should fail for unregistered modules")
+
+	err = st.CheckStateKeys(ctx)
+	require.NoError(err, "CheckStateKeys")
+
+	err = tree.Insert(ctx, []byte{0xE3, 0x01}, []byte("value"))
+	require.NoError(err, "Insert")
+	err = st.CheckStateKeys(ctx)
+	require.Error(err, "CheckStateKeys should fail for keys with unregistered prefixes")
+}
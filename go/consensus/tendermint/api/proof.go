@@ -0,0 +1,77 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+)
+
+// merkleLeafPrefix is the domain separation prefix used for leaves of Tendermint Merkle trees.
+const merkleLeafPrefix = 0x00
+
+// TransactionProof is a Merkle proof of transaction inclusion in the transactions tree of a
+// Tendermint block.
+//
+// The leaves of the tree are SHA-256 hashes of the raw transactions and the root of the tree is
+// the data hash contained in the block header.
+type TransactionProof struct {
+	// Total is the total number of transactions in the block.
+	Total int64 `json:"total"`
+	// Index is the index of the transaction in the block.
+	Index int64 `json:"index"`
+	// Aunts are the hashes of the sibling nodes on the path from the leaf to the root.
+	Aunts [][]byte `json:"aunts"`
+}
+
+// NewTransactionProof generates a proof of inclusion of the transaction at the given index among
+// transactions included in a block at the given height.
+func NewTransactionProof(height int64, txs [][]byte, index int) (*transaction.Proof, error) {
+	if index < 0 || index >= len(txs) {
+		return nil, fmt.Errorf("tendermint: invalid transaction index: %d", index)
+	}
+
+	tmTxs := make(tmtypes.Txs, 0, len(txs))
+	for _, tx := range txs {
+		tmTxs = append(tmTxs, tx)
+	}
+	txProof := tmTxs.Proof(index)
+
+	return &transaction.Proof{
+		Height: height,
+		RawProof: cbor.Marshal(&TransactionProof{
+			Total: txProof.Proof.Total,
+			Index: txProof.Proof.Index,
+			Aunts: txProof.Proof.Aunts,
+		}),
+	}, nil
+}
+
+// VerifyTransactionProof verifies that the given raw transaction is included in a block with the
+// given data hash.
+func VerifyTransactionProof(proof *transaction.Proof, dataHash []byte, tx []byte) error {
+	var txProof TransactionProof
+	if err := cbor.Unmarshal(proof.RawProof, &txProof); err != nil {
+		return fmt.Errorf("tendermint: malformed transaction proof: %w", err)
+	}
+
+	// The leaves of the tree are transaction hashes, with the leaf hash being domain separated.
+	leaf := tmtypes.Tx(tx).Hash()
+	mp := merkle.Proof{
+		Total:    txProof.Total,
+		Index:    txProof.Index,
+		LeafHash: tmhash.Sum(append([]byte{merkleLeafPrefix}, leaf...)),
+		Aunts:    txProof.Aunts,
+	}
+	if err := mp.ValidateBasic(); err != nil {
+		return fmt.Errorf("tendermint: invalid transaction proof: %w", err)
+	}
+	if err := mp.Verify(dataHash, leaf); err != nil {
+		return fmt.Errorf("tendermint: transaction proof verification failed: %w", err)
+	}
+	return nil
+}
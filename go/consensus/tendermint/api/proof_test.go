@@ -0,0 +1,34 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestTransactionProof(t *testing.T) {
+	require := require.New(t)
+
+	txs := [][]byte{[]byte("tx 1"), []byte("tx 2"), []byte("tx 3")}
+	tmTxs := tmtypes.Txs{txs[0], txs[1], txs[2]}
+	dataHash := tmTxs.Hash()
+
+	_, err := NewTransactionProof(42, txs, len(txs))
+	require.Error(err, "NewTransactionProof should fail with an invalid index")
+
+	for i, tx := range txs {
+		proof, err := NewTransactionProof(42, txs, i)
+		require.NoError(err, "NewTransactionProof")
+		require.EqualValues(42, proof.Height)
+
+		err = VerifyTransactionProof(proof, dataHash, tx)
+		require.NoError(err, "VerifyTransactionProof")
+
+		err = VerifyTransactionProof(proof, dataHash, []byte("other tx"))
+		require.Error(err, "VerifyTransactionProof should fail for a different transaction")
+
+		err = VerifyTransactionProof(proof, tmtypes.Txs{txs[0]}.Hash(), tx)
+		require.Error(err, "VerifyTransactionProof should fail for a different data hash")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/proof"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
@@ -18,11 +19,21 @@ import (
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
-// ErrNoState is the error returned when state is nil.
-var ErrNoState = errors.New("tendermint: no state available (app not registered?)")
+var (
+	// ErrNoState is the error returned when state is nil.
+	ErrNoState = errors.New("tendermint: no state available (app not registered?)")
+	// ErrProofUnavailable is the error returned when a state proof cannot be generated for
+	// the given state (e.g., because the state is not backed by a committed root).
+	ErrProofUnavailable = errors.New("tendermint: state proof not available")
+	// ErrPruningDisabled is the error returned when on-demand pruning is requested while state
+	// pruning is disabled.
+	ErrPruningDisabled = errors.New("tendermint: state pruning is disabled")
+)
 
 // ApplicationState is the overall past, present and future state of all multiplexed applications.
 type ApplicationState interface {
@@ -243,6 +254,21 @@ func NewMockApplicationState(cfg *MockApplicationStateConfig) MockApplicationSta
 // ImmutableState is an immutable state wrapper.
 type ImmutableState struct {
 	mkvs.ImmutableKeyValueTree
+
+	// root is the committed state root backing the immutable state. It is only set for states
+	// created for external queries.
+	root *node.Root
+}
+
+// GetProof returns a proof of the given key against the state root backing this immutable state.
+//
+// Proofs are only available for states created for external (non-ABCI) queries.
+func (s *ImmutableState) GetProof(ctx context.Context, key []byte) (*proof.StateProof, error) {
+	rs, ok := s.ImmutableKeyValueTree.(syncer.ReadSyncer)
+	if s.root == nil || !ok {
+		return nil, ErrProofUnavailable
+	}
+	return proof.Generate(ctx, rs, *s.root, key)
 }
 
 // CheckContextMode checks if the passed context is an ABCI context and is using one of the
@@ -285,7 +311,7 @@ func NewImmutableState(ctx context.Context, state ApplicationQueryState, version
 		// - If this request was made from an ABCI app and is for the current (future) height.
 		//
 		if abciCtx.IsInitChain() || version == abciCtx.BlockHeight()+1 {
-			return &ImmutableState{abciCtx.State()}, nil
+			return &ImmutableState{ImmutableKeyValueTree: abciCtx.State()}, nil
 		}
 	}
 
@@ -314,5 +340,5 @@ func NewImmutableState(ctx context.Context, state ApplicationQueryState, version
 	}
 	tree := mkvs.NewWithRoot(nil, ndb, roots[0], mkvs.WithoutWriteLog())
 
-	return &ImmutableState{tree}, nil
+	return &ImmutableState{ImmutableKeyValueTree: tree, root: &roots[0]}, nil
 }
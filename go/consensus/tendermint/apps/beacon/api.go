@@ -14,6 +14,9 @@ const (
 	// AppID is the unique application identifier.
 	AppID uint8 = 0x40
 
+	// StateVersion is the version of the application's state schema.
+	StateVersion uint64 = 1
+
 	// AppName is the ABCI application name.
 	// Run before all other applications.
 	AppName string = "000_beacon"
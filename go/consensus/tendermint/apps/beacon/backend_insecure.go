@@ -147,7 +147,7 @@ func (impl *backendInsecure) onEpochChangeBeacon(
 		"height", ctx.BlockHeight(),
 	)
 
-	return impl.app.onNewBeacon(ctx, b)
+	return impl.app.onNewBeacon(ctx, params, epoch, b)
 }
 
 func (impl *backendInsecure) ExecuteTx(
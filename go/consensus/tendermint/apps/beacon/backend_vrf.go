@@ -195,6 +195,17 @@ func (impl *backendVRF) OnBeginBlock(
 	}
 	impl.app.doEmitEpochEvent(ctx, future.Epoch)
 
+	// Record the proofs submitted during the previous epoch.
+	if params.HistoryRetention > 0 {
+		if err = state.SetVRFProofs(ctx, &beacon.VRFProofs{
+			Epoch: vrfState.Epoch,
+			Alpha: vrfState.Alpha,
+			Pi:    vrfState.Pi,
+		}); err != nil {
+			return fmt.Errorf("beacon: failed to set historic VRF proofs: %w", err)
+		}
+	}
+
 	// Generate a new alpha, and update the rest of the state.
 	vrfState.PrevState = &beacon.PrevVRFState{
 		Pi:                 vrfState.Pi,
@@ -223,7 +234,7 @@ func (impl *backendVRF) OnBeginBlock(
 	// this could consider aggregating all of the beta values from
 	// VRF proofs, though that is also merely "probably ok".
 	entropy := GetBeacon(future.Epoch, prodEntropyCtx, req.Header.GetLastCommitHash())
-	if err = impl.app.onNewBeacon(ctx, entropy); err != nil {
+	if err = impl.app.onNewBeacon(ctx, params, future.Epoch, entropy); err != nil {
 		return fmt.Errorf("beacon: failed to generate debug entropy")
 	}
 
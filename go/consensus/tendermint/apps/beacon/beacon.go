@@ -36,6 +36,10 @@ func (app *beaconApplication) ID() uint8 {
 	return AppID
 }
 
+func (app *beaconApplication) StateVersion() uint64 {
+	return StateVersion
+}
+
 func (app *beaconApplication) Methods() []transaction.MethodName {
 	return Methods
 }
@@ -111,17 +115,39 @@ func (app *beaconApplication) scheduleEpochTransitionBlock(
 	return nil
 }
 
-func (app *beaconApplication) onNewBeacon(ctx *api.Context, beacon []byte) error {
+func (app *beaconApplication) onNewBeacon(
+	ctx *api.Context,
+	params *beacon.ConsensusParameters,
+	epoch beacon.EpochTime,
+	newBeacon []byte,
+) error {
 	state := beaconState.NewMutableState(ctx.State())
 
-	if err := state.SetBeacon(ctx, beacon); err != nil {
+	if err := state.SetBeacon(ctx, newBeacon); err != nil {
 		ctx.Logger().Error("onNewBeacon: failed to set beacon",
 			"err", err,
 		)
 		return fmt.Errorf("beacon: failed to set beacon: %w", err)
 	}
 
-	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyBeacon, beacon))
+	// Update the beacon history, pruning anything past the retention period.
+	var pruneBefore beacon.EpochTime
+	switch retention := beacon.EpochTime(params.HistoryRetention); {
+	case retention == 0:
+		pruneBefore = epoch + 1
+	default:
+		if err := state.SetBeaconAt(ctx, epoch, newBeacon); err != nil {
+			return fmt.Errorf("beacon: failed to set historic beacon: %w", err)
+		}
+		if epoch >= retention {
+			pruneBefore = epoch + 1 - retention
+		}
+	}
+	if err := state.PruneHistory(ctx, pruneBefore); err != nil {
+		return fmt.Errorf("beacon: failed to prune beacon history: %w", err)
+	}
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyBeacon, newBeacon))
 
 	return nil
 }
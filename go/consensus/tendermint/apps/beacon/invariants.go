@@ -0,0 +1,19 @@
+package beacon
+
+import (
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+)
+
+func checkEpoch(ctx *api.Context, epoch beacon.EpochTime) error {
+	if epoch == beacon.EpochInvalid {
+		return fmt.Errorf("current epoch is invalid")
+	}
+	return nil
+}
+
+func init() {
+	api.RegisterInvariant(beacon.ModuleName, "epoch", checkEpoch)
+}
@@ -11,6 +11,8 @@ import (
 // Query is the beacon query interface.
 type Query interface {
 	Beacon(context.Context) ([]byte, error)
+	BeaconAt(context.Context, beacon.EpochTime) ([]byte, error)
+	VRFProofs(context.Context, beacon.EpochTime) (*beacon.VRFProofs, error)
 	Epoch(context.Context) (beacon.EpochTime, int64, error)
 	FutureEpoch(context.Context) (*beacon.EpochTimeState, error)
 	Genesis(context.Context) (*beacon.Genesis, error)
@@ -40,6 +42,14 @@ func (bq *beaconQuerier) Beacon(ctx context.Context) ([]byte, error) {
 	return bq.state.Beacon(ctx)
 }
 
+func (bq *beaconQuerier) BeaconAt(ctx context.Context, epoch beacon.EpochTime) ([]byte, error) {
+	return bq.state.BeaconAt(ctx, epoch)
+}
+
+func (bq *beaconQuerier) VRFProofs(ctx context.Context, epoch beacon.EpochTime) (*beacon.VRFProofs, error) {
+	return bq.state.VRFProofs(ctx, epoch)
+}
+
 func (bq *beaconQuerier) Epoch(ctx context.Context) (beacon.EpochTime, int64, error) {
 	return bq.state.GetEpoch(ctx)
 }
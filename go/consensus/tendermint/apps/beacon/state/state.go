@@ -205,3 +205,19 @@ func NewMutableState(tree mkvs.KeyValueTree) *MutableState {
 		ms: tree,
 	}
 }
+
+func init() {
+	abciAPI.RegisterStateKeyFormats(
+		beacon.ModuleName,
+		epochCurrentKeyFmt,
+		epochFutureKeyFmt,
+		beaconKeyFmt,
+		parametersKeyFmt,
+		deprecatedPvssStateKeyFmt,
+		epochPendingMockKeyFmt,
+		deprecatedPvssPendingMockEpochKeyFmt,
+		vrfStateKeyFmt,
+		beaconHistoryKeyFmt,
+		vrfProofsHistoryKeyFmt,
+	)
+}
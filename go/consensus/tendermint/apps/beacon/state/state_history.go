@@ -0,0 +1,102 @@
+package state
+
+import (
+	"context"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+)
+
+var (
+	// beaconHistoryKeyFmt is the historic random beacon key format.
+	//
+	// Key format is: 0x47 <epoch (uint64)>.
+	// Value is raw random beacon.
+	beaconHistoryKeyFmt = keyformat.New(0x47, uint64(0))
+	// vrfProofsHistoryKeyFmt is the historic VRF proofs key format.
+	//
+	// Key format is: 0x48 <epoch (uint64)>.
+	// Value is CBOR-serialized beacon.VRFProofs.
+	vrfProofsHistoryKeyFmt = keyformat.New(0x48, uint64(0))
+)
+
+// BeaconAt gets the random beacon value generated for the given epoch.
+func (s *ImmutableState) BeaconAt(ctx context.Context, epoch beacon.EpochTime) ([]byte, error) {
+	data, err := s.is.Get(ctx, beaconHistoryKeyFmt.Encode(uint64(epoch)))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if data == nil {
+		return nil, beacon.ErrBeaconNotAvailable
+	}
+	return data, nil
+}
+
+// VRFProofs gets the VRF proofs submitted during the given epoch.
+func (s *ImmutableState) VRFProofs(ctx context.Context, epoch beacon.EpochTime) (*beacon.VRFProofs, error) {
+	data, err := s.is.Get(ctx, vrfProofsHistoryKeyFmt.Encode(uint64(epoch)))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if data == nil {
+		return nil, beacon.ErrProofsNotAvailable
+	}
+
+	var proofs beacon.VRFProofs
+	if err = cbor.Unmarshal(data, &proofs); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &proofs, nil
+}
+
+// SetBeaconAt stores the random beacon value generated for the given epoch.
+func (s *MutableState) SetBeaconAt(ctx context.Context, epoch beacon.EpochTime, newBeacon []byte) error {
+	err := s.ms.Insert(ctx, beaconHistoryKeyFmt.Encode(uint64(epoch)), newBeacon)
+	return abciAPI.UnavailableStateError(err)
+}
+
+// SetVRFProofs stores the VRF proofs submitted during an epoch.
+func (s *MutableState) SetVRFProofs(ctx context.Context, proofs *beacon.VRFProofs) error {
+	err := s.ms.Insert(ctx, vrfProofsHistoryKeyFmt.Encode(uint64(proofs.Epoch)), cbor.Marshal(proofs))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// PruneHistory removes all historic random beacon values and VRF proofs for epochs before the
+// given epoch.
+func (s *MutableState) PruneHistory(ctx context.Context, before beacon.EpochTime) error {
+	for _, keyFmt := range []*keyformat.KeyFormat{beaconHistoryKeyFmt, vrfProofsHistoryKeyFmt} {
+		if err := s.pruneHistory(ctx, keyFmt, before); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MutableState) pruneHistory(ctx context.Context, keyFmt *keyformat.KeyFormat, before beacon.EpochTime) error {
+	it := s.ms.NewIterator(ctx)
+	defer it.Close()
+
+	var epochs []uint64
+	for it.Seek(keyFmt.Encode()); it.Valid(); it.Next() {
+		var epoch uint64
+		if !keyFmt.Decode(it.Key(), &epoch) {
+			break
+		}
+		if beacon.EpochTime(epoch) >= before {
+			break
+		}
+		epochs = append(epochs, epoch)
+	}
+	if err := it.Err(); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+
+	for _, epoch := range epochs {
+		if err := s.ms.Remove(ctx, keyFmt.Encode(epoch)); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+)
+
+func TestBeaconHistory(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock, now)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	_, err := s.BeaconAt(ctx, 1)
+	require.ErrorIs(err, beacon.ErrBeaconNotAvailable, "BeaconAt should fail for missing epochs")
+	_, err = s.VRFProofs(ctx, 1)
+	require.ErrorIs(err, beacon.ErrProofsNotAvailable, "VRFProofs should fail for missing epochs")
+
+	for epoch := beacon.EpochTime(1); epoch <= 5; epoch++ {
+		b := make([]byte, beacon.BeaconSize)
+		b[0] = byte(epoch)
+		err = s.SetBeaconAt(ctx, epoch, b)
+		require.NoError(err, "SetBeaconAt")
+		err = s.SetVRFProofs(ctx, &beacon.VRFProofs{Epoch: epoch, Alpha: b})
+		require.NoError(err, "SetVRFProofs")
+	}
+
+	b, err := s.BeaconAt(ctx, 3)
+	require.NoError(err, "BeaconAt")
+	require.EqualValues(3, b[0], "BeaconAt should return the beacon of the given epoch")
+	proofs, err := s.VRFProofs(ctx, 3)
+	require.NoError(err, "VRFProofs")
+	require.EqualValues(3, proofs.Epoch, "VRFProofs should return the proofs of the given epoch")
+	require.EqualValues(b, proofs.Alpha)
+
+	err = s.PruneHistory(ctx, 4)
+	require.NoError(err, "PruneHistory")
+
+	for epoch := beacon.EpochTime(1); epoch <= 5; epoch++ {
+		_, err = s.BeaconAt(ctx, epoch)
+		_, perr := s.VRFProofs(ctx, epoch)
+		if epoch < 4 {
+			require.ErrorIs(err, beacon.ErrBeaconNotAvailable, "pruned beacons should not be available")
+			require.ErrorIs(perr, beacon.ErrProofsNotAvailable, "pruned proofs should not be available")
+			continue
+		}
+		require.NoError(err, "non-pruned beacons should be available")
+		require.NoError(perr, "non-pruned proofs should be available")
+	}
+}
@@ -0,0 +1,9 @@
+// Package api defines the governance application API for other applications.
+package api
+
+type messageKind uint8
+
+// MessageChangeRuntimePause is the message kind for runtime pause changes made via passed
+// governance proposals. The message is the runtime pause proposal that has been passed. Any errors
+// returned from the handler will cause the proposal execution to fail.
+var MessageChangeRuntimePause = messageKind(0)
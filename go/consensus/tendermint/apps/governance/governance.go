@@ -10,6 +10,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/api"
 	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/state"
 	registryapp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
@@ -26,6 +27,7 @@ var _ api.Application = (*governanceApplication)(nil)
 
 type governanceApplication struct {
 	state api.ApplicationState
+	md    api.MessageDispatcher
 }
 
 func (app *governanceApplication) Name() string {
@@ -36,6 +38,10 @@ func (app *governanceApplication) ID() uint8 {
 	return AppID
 }
 
+func (app *governanceApplication) StateVersion() uint64 {
+	return StateVersion
+}
+
 func (app *governanceApplication) Methods() []transaction.MethodName {
 	return governance.Methods
 }
@@ -50,6 +56,7 @@ func (app *governanceApplication) Dependencies() []string {
 
 func (app *governanceApplication) OnRegister(state api.ApplicationState, md api.MessageDispatcher) {
 	app.state = state
+	app.md = md
 
 	// Subscribe to messages emitted by other apps.
 	md.Subscribe(api.MessageStateSyncCompleted, app)
@@ -260,6 +267,17 @@ func (app *governanceApplication) executeProposal(ctx *api.Context, state *gover
 				)
 			}
 		}
+	case proposal.Content.ChangeTokenMetadata != nil:
+		// Execute token metadata change proposal.
+		stakeState := stakingState.NewMutableState(ctx.State())
+		if err := stakeState.SetTokenMetadata(ctx, &proposal.Content.ChangeTokenMetadata.Metadata); err != nil {
+			return fmt.Errorf("failed to set token metadata: %w", err)
+		}
+	case proposal.Content.ChangeRuntimePause != nil:
+		// Execute runtime pause change proposal.
+		if err := app.md.Publish(ctx, governanceApi.MessageChangeRuntimePause, proposal.Content.ChangeRuntimePause); err != nil {
+			return fmt.Errorf("failed to change runtime pause status: %w", err)
+		}
 	default:
 		return governance.ErrInvalidArgument
 	}
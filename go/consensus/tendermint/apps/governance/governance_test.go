@@ -26,6 +26,7 @@ import (
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -471,6 +472,18 @@ func TestExecuteProposal(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"executing change token metadata proposal should work",
+			&governance.Proposal{
+				ID: 13,
+				Content: governance.ProposalContent{
+					ChangeTokenMetadata: &governance.ChangeTokenMetadataProposal{
+						Metadata: token.Metadata{Symbol: "NEW", ValueExponent: 6},
+					},
+				},
+			},
+			nil,
+		},
 	} {
 		err = app.executeProposal(ctx, state, tc.proposal)
 		if tc.err != nil {
@@ -489,6 +502,11 @@ func TestExecuteProposal(t *testing.T) {
 		err = state.SetProposal(ctx, tc.proposal)
 		require.NoError(err, "SetProposal")
 	}
+
+	// Token metadata should be updated.
+	meta, err := stakingState.NewMutableState(ctx.State()).TokenMetadata(ctx)
+	require.NoError(err, "TokenMetadata")
+	require.Equal(&token.Metadata{Symbol: "NEW", ValueExponent: 6}, meta, "token metadata should be updated")
 }
 
 func TestBeginBlock(t *testing.T) {
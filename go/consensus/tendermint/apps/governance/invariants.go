@@ -0,0 +1,67 @@
+package governance
+
+import (
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+)
+
+// checkProposals checks that the governance parameters, proposals, votes and pending upgrades
+// are valid.
+func checkProposals(ctx *api.Context, epoch beacon.EpochTime) error {
+	st := governanceState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+	govDeposits, err := stakeState.GovernanceDeposits(ctx)
+	if err != nil {
+		return fmt.Errorf("GovernanceDeposits: %w", err)
+	}
+
+	params, err := st.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("ConsensusParameters: %w", err)
+	}
+	err = params.SanityCheck()
+	if err != nil {
+		return fmt.Errorf("SanityCheck ConsensusParameters: %w", err)
+	}
+	// Sanity check proposals.
+	proposals, err := st.Proposals(ctx)
+	if err != nil {
+		return fmt.Errorf("Proposals(): %w", err)
+	}
+	err = governance.SanityCheckProposals(proposals, epoch, govDeposits)
+	if err != nil {
+		return fmt.Errorf("SanityCheck Proposals: %w", err)
+	}
+	// Sanity check votes.
+	for _, p := range proposals {
+		var votes []*governance.VoteEntry
+		votes, err = st.Votes(ctx, p.ID)
+		if err != nil {
+			return fmt.Errorf("Votes(): %w", err)
+		}
+		err = governance.SanityCheckVotes(p, votes)
+		if err != nil {
+			return fmt.Errorf("SanityCheckVotes: %w", err)
+		}
+	}
+	// Sanity check pending upgrades.
+	pendingUpgrades, err := st.PendingUpgrades(ctx)
+	if err != nil {
+		return fmt.Errorf("PendingUpgrades: %w", err)
+	}
+	err = governance.SanityCheckPendingUpgrades(pendingUpgrades, epoch, params)
+	if err != nil {
+		return fmt.Errorf("SanityCheck PendingUpgrades: %w", err)
+	}
+
+	return nil
+}
+
+func init() {
+	api.RegisterInvariant(governance.ModuleName, "proposals", checkProposals)
+}
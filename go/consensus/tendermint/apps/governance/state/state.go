@@ -359,3 +359,15 @@ func (s *MutableState) SetConsensusParameters(ctx context.Context, params *gover
 	err := s.ms.Insert(ctx, parametersKeyFmt.Encode(), cbor.Marshal(params))
 	return api.UnavailableStateError(err)
 }
+
+func init() {
+	api.RegisterStateKeyFormats(
+		governance.ModuleName,
+		nextProposalIdentifierKeyFmt,
+		proposalsKeyFmt,
+		activeProposalsKeyFmt,
+		votesKeyFmt,
+		pendingUpgradesKeyFmt,
+		parametersKeyFmt,
+	)
+}
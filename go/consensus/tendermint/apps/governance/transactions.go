@@ -127,6 +127,25 @@ func (app *governanceApplication) submitProposal(
 		if upgrade.Descriptor.Epoch < params.UpgradeCancelMinEpochDiff+epoch {
 			return governance.ErrUpgradeTooSoon
 		}
+
+	case proposalContent.ChangeRuntimePause != nil:
+		// Ensure the runtime exists and is not governed by an entity. Entity-governed runtimes are
+		// paused and resumed by their owners directly.
+		var rt *registryAPI.Runtime
+		rt, err = registryState.NewMutableState(ctx.State()).AnyRuntime(ctx, proposalContent.ChangeRuntimePause.RuntimeID)
+		if err != nil {
+			ctx.Logger().Error("governance: failed to fetch runtime",
+				"runtime_id", proposalContent.ChangeRuntimePause.RuntimeID,
+				"err", err,
+			)
+			return err
+		}
+		if rt.GovernanceModel == registryAPI.GovernanceEntity {
+			ctx.Logger().Error("governance: cannot change pause status of an entity-governed runtime",
+				"runtime_id", rt.ID,
+			)
+			return governance.ErrInvalidArgument
+		}
 	}
 
 	// Deposit proposal funds.
@@ -218,6 +237,12 @@ func (app *governanceApplication) castVote(
 	default:
 		return fmt.Errorf("governance: failed to query entity: %w", err)
 	}
+	if !submitterEntity.CanSignGovernance() {
+		ctx.Logger().Error("governance: submitter entity key usage does not allow governance",
+			"submitter", ctx.TxSigner(),
+		)
+		return governance.ErrNotEligible
+	}
 	schedulerState := schedulerState.NewMutableState(ctx.State())
 	currentValidators, err := schedulerState.CurrentValidators(ctx)
 	if err != nil {
@@ -7,6 +7,9 @@ const (
 	// AppID is the unique application identifier.
 	AppID uint8 = 0x07
 
+	// StateVersion is the version of the application's state schema.
+	StateVersion uint64 = 1
+
 	// AppName is the ABCI application name.
 	AppName string = "999_keymanager"
 )
@@ -0,0 +1,63 @@
+package keymanager
+
+import (
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/api"
+)
+
+// updateChurpStatus advances the CHURP handoffs of the given key manager status at an epoch
+// transition.
+//
+// In case the next handoff is due and enough nodes applied with matching verification matrix
+// checksums, the handoff is completed and the applicants become the new committee. Otherwise
+// the handoff is retried in the next epoch. In both cases all applications are cleared.
+func (app *keymanagerApplication) updateChurpStatus(ctx *tmapi.Context, epoch beacon.EpochTime, status *api.Status) {
+	if status.Scheme() != api.SchemeCHURP {
+		status.Churp = nil
+		return
+	}
+	policy := status.Policy.Policy.Churp
+
+	if status.Churp == nil {
+		// This must be a new CHURP key manager, schedule the initial dealing phase.
+		status.Churp = &api.ChurpStatus{
+			NextHandoff: epoch + 1,
+		}
+		return
+	}
+	if epoch < status.Churp.NextHandoff {
+		return
+	}
+
+	// Copy the status to avoid modifying the old status which is compared against.
+	churp := *status.Churp
+	status.Churp = &churp
+
+	checksum, committee := churp.TallyApplications()
+	switch {
+	case len(committee) < policy.MinCommitteeSize():
+		ctx.Logger().Warn("CHURP handoff failed, not enough applications",
+			"id", status.ID,
+			"handoff", churp.NextHandoff,
+			"applications", len(churp.Applications),
+			"committee_size", len(committee),
+			"min_committee_size", policy.MinCommitteeSize(),
+		)
+
+		churp.NextHandoff = epoch + 1
+	default:
+		ctx.Logger().Debug("CHURP handoff completed",
+			"id", status.ID,
+			"handoff", churp.NextHandoff,
+			"checksum", checksum,
+			"committee", committee,
+		)
+
+		churp.Handoff = churp.NextHandoff
+		churp.Checksum = checksum
+		churp.Committee = committee
+		churp.NextHandoff = epoch + policy.HandoffInterval
+	}
+	churp.Applications = nil
+}
@@ -0,0 +1,92 @@
+package keymanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/api"
+)
+
+func TestUpdateChurpStatus(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	app := &keymanagerApplication{state: appState}
+
+	var nodes []signature.PublicKey
+	for _, seed := range []string{"churp node 1", "churp node 2", "churp node 3", "churp node 4"} {
+		nodes = append(nodes, memorySigner.NewTestSigner(seed).Public())
+	}
+	var checksum, otherChecksum hash.Hash
+	checksum.FromBytes([]byte("checksum"))
+	otherChecksum.FromBytes([]byte("other checksum"))
+
+	status := &api.Status{
+		Policy: &api.SignedPolicySGX{
+			Policy: api.PolicySGX{
+				Scheme: api.SchemeCHURP,
+				Churp: &api.ChurpPolicy{
+					Threshold:       1,
+					HandoffInterval: 5,
+				},
+			},
+		},
+	}
+
+	// The initial dealing phase should be scheduled for the next epoch.
+	app.updateChurpStatus(ctx, 10, status)
+	require.NotNil(status.Churp)
+	require.EqualValues(0, status.Churp.Handoff)
+	require.EqualValues(11, status.Churp.NextHandoff)
+
+	// Nothing should happen before the next handoff.
+	status.Churp.Applications = map[signature.PublicKey]hash.Hash{
+		nodes[0]: checksum,
+		nodes[1]: checksum,
+		nodes[2]: otherChecksum,
+	}
+	app.updateChurpStatus(ctx, 10, status)
+	require.EqualValues(11, status.Churp.NextHandoff)
+	require.Len(status.Churp.Applications, 3)
+
+	// Not enough matching applications, the handoff should be retried.
+	oldChurp := status.Churp
+	app.updateChurpStatus(ctx, 11, status)
+	require.EqualValues(0, status.Churp.Handoff)
+	require.EqualValues(12, status.Churp.NextHandoff)
+	require.Empty(status.Churp.Applications)
+	require.Len(oldChurp.Applications, 3, "old status should not be modified")
+
+	// Enough matching applications, the handoff should complete.
+	status.Churp.Applications = map[signature.PublicKey]hash.Hash{
+		nodes[0]: checksum,
+		nodes[1]: checksum,
+		nodes[2]: otherChecksum,
+		nodes[3]: checksum,
+	}
+	app.updateChurpStatus(ctx, 12, status)
+	require.EqualValues(12, status.Churp.Handoff)
+	require.EqualValues(17, status.Churp.NextHandoff)
+	require.EqualValues(&checksum, status.Churp.Checksum)
+	require.Len(status.Churp.Committee, 3)
+	require.True(status.Churp.IsMember(nodes[0]))
+	require.True(status.Churp.IsMember(nodes[1]))
+	require.False(status.Churp.IsMember(nodes[2]))
+	require.True(status.Churp.IsMember(nodes[3]))
+	require.Empty(status.Churp.Applications)
+
+	// Non-CHURP key managers should not have a CHURP status.
+	status.Policy.Policy.Scheme = api.SchemeReplicated
+	app.updateChurpStatus(ctx, 13, status)
+	require.Nil(status.Churp)
+}
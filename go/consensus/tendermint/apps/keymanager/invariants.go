@@ -0,0 +1,27 @@
+package keymanager
+
+import (
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	keymanagerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/keymanager/state"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/api"
+)
+
+func checkStatuses(ctx *tmapi.Context, epoch beacon.EpochTime) error {
+	st := keymanagerState.NewMutableState(ctx.State())
+
+	statuses, err := st.Statuses(ctx)
+	if err != nil {
+		return fmt.Errorf("Statuses(): %w", err)
+	}
+	if err = api.SanityCheckStatuses(statuses); err != nil {
+		return fmt.Errorf("SanityCheckStatuses: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	tmapi.RegisterInvariant(api.ModuleName, "statuses", checkStatuses)
+}
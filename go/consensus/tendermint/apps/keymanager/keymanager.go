@@ -35,6 +35,10 @@ func (app *keymanagerApplication) ID() uint8 {
 	return AppID
 }
 
+func (app *keymanagerApplication) StateVersion() uint64 {
+	return StateVersion
+}
+
 func (app *keymanagerApplication) Methods() []transaction.MethodName {
 	return api.Methods
 }
@@ -74,6 +78,12 @@ func (app *keymanagerApplication) ExecuteTx(ctx *tmapi.Context, tx *transaction.
 			return err
 		}
 		return app.updatePolicy(ctx, state, &sigPol)
+	case api.MethodApplyChurp:
+		var churpApp api.ChurpApplication
+		if err := cbor.Unmarshal(tx.Body, &churpApp); err != nil {
+			return err
+		}
+		return app.applyChurp(ctx, state, &churpApp)
 	default:
 		return fmt.Errorf("keymanager: invalid method: %s", tx.Method)
 	}
@@ -135,7 +145,7 @@ func (app *keymanagerApplication) onEpochChange(ctx *tmapi.Context, epoch beacon
 				)
 
 				// Suspend runtime.
-				if err := regState.SuspendRuntime(ctx, rt.ID); err != nil {
+				if err := registryapp.SuspendRuntime(ctx, regState, rt.ID, registry.SuspensionReasonInsufficientStake); err != nil {
 					return err
 				}
 
@@ -163,6 +173,7 @@ func (app *keymanagerApplication) onEpochChange(ctx *tmapi.Context, epoch beacon
 		}
 
 		newStatus := app.generateStatus(ctx, rt, oldStatus, nodes)
+		app.updateChurpStatus(ctx, epoch, newStatus)
 		if forceEmit || !bytes.Equal(cbor.Marshal(oldStatus), cbor.Marshal(newStatus)) {
 			ctx.Logger().Debug("status updated",
 				"id", newStatus.ID,
@@ -198,6 +209,7 @@ func (app *keymanagerApplication) generateStatus(ctx *tmapi.Context, kmrt *regis
 		IsSecure:      oldStatus.IsSecure,
 		Checksum:      oldStatus.Checksum,
 		Policy:        oldStatus.Policy,
+		Churp:         oldStatus.Churp,
 	}
 
 	var rawPolicy []byte
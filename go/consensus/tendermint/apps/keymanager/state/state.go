@@ -101,3 +101,10 @@ func NewMutableState(tree mkvs.KeyValueTree) *MutableState {
 		ms: tree,
 	}
 }
+
+func init() {
+	abciAPI.RegisterStateKeyFormats(
+		api.ModuleName,
+		statusKeyFmt,
+	)
+}
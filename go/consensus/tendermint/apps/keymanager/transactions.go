@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	keymanagerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/keymanager/state"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
@@ -85,3 +87,67 @@ func (app *keymanagerApplication) updatePolicy(
 
 	return nil
 }
+
+func (app *keymanagerApplication) applyChurp(
+	ctx *tmapi.Context,
+	state *keymanagerState.MutableState,
+	churpApp *api.ChurpApplication,
+) error {
+	status, err := state.Status(ctx, churpApp.ID)
+	if err != nil {
+		return err
+	}
+	if status.Scheme() != api.SchemeCHURP || status.Churp == nil {
+		return fmt.Errorf("%w: key manager does not use CHURP: %s", api.ErrInvalidChurpApplication, churpApp.ID)
+	}
+
+	// Ensure that the application is for the next handoff.
+	if churpApp.Epoch != status.Churp.NextHandoff {
+		return fmt.Errorf("%w: invalid handoff epoch (expected: %d got: %d)",
+			api.ErrInvalidChurpApplication, status.Churp.NextHandoff, churpApp.Epoch,
+		)
+	}
+
+	// Ensure that the tx signer is one of the active key manager nodes.
+	nodeID := ctx.TxSigner()
+	var isActive bool
+	for _, id := range status.Nodes {
+		if id.Equal(nodeID) {
+			isActive = true
+			break
+		}
+	}
+	if !isActive {
+		return fmt.Errorf("%w: signer is not an active key manager node: %s", api.ErrInvalidChurpApplication, nodeID)
+	}
+	if _, ok := status.Churp.Applications[nodeID]; ok {
+		return fmt.Errorf("%w: node already applied for the handoff", api.ErrInvalidChurpApplication)
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this operation.
+	regState := registryState.NewMutableState(ctx.State())
+	regParams, err := regState.ConsensusParameters(ctx)
+	if err != nil {
+		return err
+	}
+	if err = ctx.Gas().UseGas(1, registry.GasOpUpdateKeyManager, regParams.GasCosts); err != nil {
+		return err
+	}
+
+	if status.Churp.Applications == nil {
+		status.Churp.Applications = make(map[signature.PublicKey]hash.Hash)
+	}
+	status.Churp.Applications[nodeID] = churpApp.Checksum
+
+	if err = state.SetStatus(ctx, status); err != nil {
+		return fmt.Errorf("failed to set keymanager status: %w", err)
+	}
+
+	ctx.EmitEvent(tmapi.NewEventBuilder(app.Name()).Attribute(KeyStatusUpdate, cbor.Marshal([]*api.Status{status})))
+
+	return nil
+}
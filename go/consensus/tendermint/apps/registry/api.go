@@ -9,6 +9,9 @@ const (
 	// AppID is the unique application identifier.
 	AppID uint8 = 0x01
 
+	// StateVersion is the version of the application's state schema.
+	StateVersion uint64 = 1
+
 	// AppName is the ABCI application name.
 	AppName string = "200_registry"
 )
@@ -26,6 +29,14 @@ var (
 	// descriptor).
 	KeyRuntimeRegistered = []byte("runtime.registered")
 
+	// KeyRuntimeSuspended is the ABCI event attribute for runtime
+	// suspensions (value is a CBOR serialized RuntimeSuspendedEvent).
+	KeyRuntimeSuspended = []byte("runtime.suspended")
+
+	// KeyRuntimeResumed is the ABCI event attribute for suspended
+	// runtime resumptions (value is a CBOR serialized RuntimeResumedEvent).
+	KeyRuntimeResumed = []byte("runtime.resumed")
+
 	// KeyEntityRegistered is the ABCI event attribute for new entity
 	// registrations (value is the CBOR serialized entity descriptor).
 	KeyEntityRegistered = []byte("entity.registered")
@@ -43,6 +54,11 @@ var (
 	// vector of node descriptors).
 	KeyNodesExpired = []byte("nodes.expired")
 
+	// KeyNodeExpiring is the ABCI event attribute for nodes whose
+	// registration will expire at the end of the current epoch (value
+	// is a CBOR serialized NodeExpiringEvent).
+	KeyNodeExpiring = []byte("nodes.expiring")
+
 	// KeyNodeUnfrozen is the ABCI event attribute for when nodes
 	// become unfrozen (value is CBOR serialized node ID).
 	KeyNodeUnfrozen = []byte("nodes.unfrozen")
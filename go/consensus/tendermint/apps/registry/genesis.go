@@ -83,7 +83,7 @@ func (app *registryApplication) InitChain(ctx *abciAPI.Context, request types.Re
 			)
 			return fmt.Errorf("registry: genesis suspended runtime registration failure: %w", err)
 		}
-		if err := state.SuspendRuntime(ctx, rt.ID); err != nil {
+		if err := state.SuspendRuntime(ctx, rt.ID, registry.SuspensionReasonUnknown); err != nil {
 			return fmt.Errorf("registry: failed to suspend runtime at genesis: %w", err)
 		}
 	}
@@ -0,0 +1,62 @@
+package registry
+
+import (
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+// checkDescriptors checks that all entity, runtime and node descriptors are valid and consistent
+// with each other.
+func checkDescriptors(ctx *api.Context, epoch beacon.EpochTime) error {
+	st := registryState.NewMutableState(ctx.State())
+
+	params, err := st.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("ConsensusParameters: %w", err)
+	}
+
+	// Check entities.
+	signedEntities, err := st.SignedEntities(ctx)
+	if err != nil {
+		return fmt.Errorf("SignedEntities: %w", err)
+	}
+	seenEntities, err := registry.SanityCheckEntities(ctx.Logger(), signedEntities)
+	if err != nil {
+		return fmt.Errorf("SanityCheckEntities: %w", err)
+	}
+
+	// Check runtimes.
+	runtimes, err := st.Runtimes(ctx)
+	if err != nil {
+		return fmt.Errorf("Runtimes(): %w", err)
+	}
+	suspendedRuntimes, err := st.SuspendedRuntimes(ctx)
+	if err != nil {
+		return fmt.Errorf("SuspendedRuntimes: %w", err)
+	}
+
+	runtimeLookup, err := registry.SanityCheckRuntimes(ctx.Logger(), params, runtimes, suspendedRuntimes, false)
+	if err != nil {
+		return fmt.Errorf("SanityCheckRuntimes: %w", err)
+	}
+
+	// Check nodes.
+	signedNodes, err := st.SignedNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("SignedNodes: %w", err)
+	}
+	_, err = registry.SanityCheckNodes(ctx.Logger(), params, signedNodes, seenEntities, runtimeLookup, false, epoch, ctx.Now())
+	if err != nil {
+		return fmt.Errorf("SanityCheckNodes: %w", err)
+	}
+
+	return nil
+}
+
+func init() {
+	api.RegisterInvariant(registry.ModuleName, "descriptors", checkDescriptors)
+}
@@ -17,11 +17,16 @@ import (
 type Query interface {
 	Entity(context.Context, signature.PublicKey) (*entity.Entity, error)
 	Entities(context.Context) ([]*entity.Entity, error)
+	ArchivedEntity(context.Context, signature.PublicKey) (*registry.ArchivedEntity, error)
 	Node(context.Context, signature.PublicKey) (*node.Node, error)
+	NodeWithProof(context.Context, signature.PublicKey) (*registry.NodeWithProof, error)
 	NodeByConsensusAddress(context.Context, []byte) (*node.Node, error)
 	NodeStatus(context.Context, signature.PublicKey) (*registry.NodeStatus, error)
+	ArchivedNode(context.Context, signature.PublicKey) (*registry.ArchivedNode, error)
 	Nodes(context.Context) ([]*node.Node, error)
 	Runtime(context.Context, common.Namespace) (*registry.Runtime, error)
+	RuntimeWithProof(context.Context, common.Namespace) (*registry.RuntimeWithProof, error)
+	RuntimeStatus(context.Context, common.Namespace) (*registry.RuntimeStatus, error)
 	Runtimes(ctx context.Context, includeSuspended bool) ([]*registry.Runtime, error)
 	Genesis(context.Context) (*registry.Genesis, error)
 }
@@ -54,6 +59,10 @@ func (rq *registryQuerier) Entities(ctx context.Context) ([]*entity.Entity, erro
 	return rq.state.Entities(ctx)
 }
 
+func (rq *registryQuerier) ArchivedEntity(ctx context.Context, id signature.PublicKey) (*registry.ArchivedEntity, error) {
+	return rq.state.ArchivedEntity(ctx, id)
+}
+
 func (rq *registryQuerier) Node(ctx context.Context, id signature.PublicKey) (*node.Node, error) {
 	epoch, err := rq.queryState.GetEpoch(ctx, rq.height)
 	if err != nil {
@@ -72,10 +81,32 @@ func (rq *registryQuerier) Node(ctx context.Context, id signature.PublicKey) (*n
 	return node, nil
 }
 
+func (rq *registryQuerier) NodeWithProof(ctx context.Context, id signature.PublicKey) (*registry.NodeWithProof, error) {
+	epoch, err := rq.queryState.GetEpoch(ctx, rq.height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch: %w", err)
+	}
+
+	nwp, err := rq.state.NodeWithProof(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Do not return expired nodes.
+	if nwp.Node.IsExpired(uint64(epoch)) {
+		return nil, registry.ErrNoSuchNode
+	}
+	return nwp, nil
+}
+
 func (rq *registryQuerier) NodeByConsensusAddress(ctx context.Context, address []byte) (*node.Node, error) {
 	return rq.state.NodeByConsensusAddress(ctx, address)
 }
 
+func (rq *registryQuerier) ArchivedNode(ctx context.Context, id signature.PublicKey) (*registry.ArchivedNode, error) {
+	return rq.state.ArchivedNode(ctx, id)
+}
+
 func (rq *registryQuerier) NodeStatus(ctx context.Context, id signature.PublicKey) (*registry.NodeStatus, error) {
 	return rq.state.NodeStatus(ctx, id)
 }
@@ -106,6 +137,14 @@ func (rq *registryQuerier) Runtime(ctx context.Context, id common.Namespace) (*r
 	return rq.state.Runtime(ctx, id)
 }
 
+func (rq *registryQuerier) RuntimeWithProof(ctx context.Context, id common.Namespace) (*registry.RuntimeWithProof, error) {
+	return rq.state.RuntimeWithProof(ctx, id)
+}
+
+func (rq *registryQuerier) RuntimeStatus(ctx context.Context, id common.Namespace) (*registry.RuntimeStatus, error) {
+	return rq.state.RuntimeStatus(ctx, id)
+}
+
 func (rq *registryQuerier) Runtimes(ctx context.Context, includeSuspended bool) ([]*registry.Runtime, error) {
 	if includeSuspended {
 		return rq.state.AllRuntimes(ctx)
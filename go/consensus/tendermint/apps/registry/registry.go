@@ -8,11 +8,13 @@ import (
 	"github.com/tendermint/tendermint/abci/types"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	registryApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
 	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/api"
 	stakingapp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking"
@@ -37,6 +39,10 @@ func (app *registryApplication) ID() uint8 {
 	return AppID
 }
 
+func (app *registryApplication) StateVersion() uint64 {
+	return StateVersion
+}
+
 func (app *registryApplication) Methods() []transaction.MethodName {
 	return registry.Methods
 }
@@ -118,6 +124,12 @@ func (app *registryApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tran
 			return err
 		}
 		return app.registerRuntime(ctx, state, &rt)
+	case registry.MethodProveFreshness:
+		var blob registry.Blob
+		if err := cbor.Unmarshal(tx.Body, &blob); err != nil {
+			return err
+		}
+		return app.proveFreshness(ctx, state)
 	default:
 		return registry.ErrInvalidArgument
 	}
@@ -167,9 +179,20 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 	// period and then removed. This is required so that expired nodes
 	// can still get slashed while inside the debonding interval as
 	// otherwise the nodes could not be resolved.
-	var expiredNodes []*node.Node
+	var (
+		expiredNodes  []*node.Node
+		expiringNodes []*registry.NodeExpiringEvent
+	)
 	for _, node := range nodes {
 		if !node.IsExpired(uint64(registryEpoch)) {
+			// Notify nodes whose registration expires at the end of this epoch
+			// so that they can re-register in time.
+			if node.Expiration == uint64(registryEpoch) {
+				expiringNodes = append(expiringNodes, &registry.NodeExpiringEvent{
+					NodeID:     node.ID,
+					Expiration: node.Expiration,
+				})
+			}
 			continue
 		}
 
@@ -199,6 +222,11 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 			ctx.Logger().Debug("removing expired node",
 				"node_id", node.ID,
 			)
+			if params.ArchiveRetention > 0 {
+				if err = state.ArchiveNode(ctx, node.ID, registryEpoch); err != nil {
+					return fmt.Errorf("registry: onRegistryEpochChanged: couldn't archive node: %w", err)
+				}
+			}
 			if err = state.RemoveNode(ctx, node); err != nil {
 				return fmt.Errorf("registry: onRegistryEpochChanged: couldn't remove node: %w", err)
 			}
@@ -213,6 +241,20 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 		}
 	}
 
+	// Prune archived descriptors that are past the retention horizon.
+	if params.ArchiveRetention > 0 && registryEpoch > params.ArchiveRetention {
+		if err = state.PruneArchive(ctx, registryEpoch-params.ArchiveRetention); err != nil {
+			return fmt.Errorf("registry: onRegistryEpochChanged: couldn't prune archive: %w", err)
+		}
+	}
+
+	// Resume runtimes that were suspended due to insufficient stake in case the owning entity
+	// has since added enough stake. Runtimes suspended for other reasons are resumed once nodes
+	// pay maintenance fees for them again.
+	if err = app.resumeStakeSuspendedRuntimes(ctx, state, params, stakeAcc); err != nil {
+		return fmt.Errorf("registry: onRegistryEpochChanged: %w", err)
+	}
+
 	if !params.DebugBypassStake {
 		if err = stakeAcc.Commit(); err != nil {
 			return fmt.Errorf("registry: onRegistryEpochChanged: failed to commit stake accumulator: %w", err)
@@ -229,12 +271,122 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 		// so the change is picked up.
 		evb = evb.Attribute(KeyNodesExpired, cbor.Marshal(expiredNodes))
 	}
+	for _, ev := range expiringNodes {
+		evb = evb.Attribute(KeyNodeExpiring, cbor.Marshal(ev))
+	}
 
 	ctx.EmitEvent(evb)
 
 	return nil
 }
 
+func (app *registryApplication) resumeStakeSuspendedRuntimes(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	params *registry.ConsensusParameters,
+	stakeAcc *stakingState.StakeAccumulatorCache,
+) error {
+	runtimes, err := state.SuspendedRuntimes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get suspended runtimes: %w", err)
+	}
+
+	for _, rt := range runtimes {
+		var status *registry.RuntimeStatus
+		if status, err = state.RuntimeStatus(ctx, rt.ID); err != nil {
+			return fmt.Errorf("failed to get runtime status: %w", err)
+		}
+		if status.SuspensionReason != registry.SuspensionReasonInsufficientStake {
+			continue
+		}
+
+		var sufficientStake bool
+		if sufficientStake, err = hasSufficientRuntimeStake(ctx, params, stakeAcc, rt); err != nil {
+			return err
+		}
+		if !sufficientStake {
+			continue
+		}
+
+		if err = app.resumeRuntime(ctx, state, rt); err != nil {
+			return fmt.Errorf("failed to resume suspended runtime %s: %w", rt.ID, err)
+		}
+
+		ctx.Logger().Debug("resumed runtime after stake was replenished",
+			"runtime_id", rt.ID,
+		)
+	}
+	return nil
+}
+
+// resumeRuntime resumes a previously suspended runtime, notifies other interested applications
+// and emits the corresponding events.
+//
+// Returns registry.ErrNoSuchRuntime in case the runtime is not suspended.
+func (app *registryApplication) resumeRuntime(ctx *api.Context, state *registryState.MutableState, rt *registry.Runtime) error {
+	if err := state.ResumeRuntime(ctx, rt.ID); err != nil {
+		return err
+	}
+
+	// Notify other interested applications about the resumed runtime.
+	if err := app.md.Publish(ctx, registryApi.MessageRuntimeResumed, rt); err != nil {
+		ctx.Logger().Error("failed to dispatch runtime resumption message",
+			"err", err,
+		)
+		return err
+	}
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).
+		Attribute(KeyRuntimeRegistered, cbor.Marshal(rt)).
+		Attribute(KeyRuntimeResumed, cbor.Marshal(&registry.RuntimeResumedEvent{RuntimeID: rt.ID})),
+	)
+	return nil
+}
+
+// hasSufficientRuntimeStake checks whether the runtime's owner has enough stake to cover the
+// runtime's stake claims.
+func hasSufficientRuntimeStake(
+	ctx *api.Context,
+	params *registry.ConsensusParameters,
+	stakeAcc *stakingState.StakeAccumulatorCache,
+	rt *registry.Runtime,
+) (bool, error) {
+	if params.DebugBypassStake || rt.GovernanceModel == registry.GovernanceConsensus {
+		return true, nil
+	}
+
+	acctAddr := rt.StakingAddress()
+	if acctAddr == nil {
+		// This should never happen.
+		ctx.Logger().Error("unknown runtime governance model",
+			"rt_id", rt.ID,
+			"gov_model", rt.GovernanceModel,
+		)
+		return false, fmt.Errorf("unknown runtime governance model on runtime %s: %s", rt.ID, rt.GovernanceModel)
+	}
+	return stakeAcc.CheckStakeClaims(*acctAddr) == nil, nil
+}
+
+// SuspendRuntime suspends a runtime for the given reason and emits a runtime suspended event.
+func SuspendRuntime(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	id common.Namespace,
+	reason registry.RuntimeSuspensionReason,
+) error {
+	if err := state.SuspendRuntime(ctx, id, reason); err != nil {
+		return err
+	}
+
+	ctx.EmitEvent(api.NewEventBuilder(AppName).
+		Attribute(KeyRuntimeSuspended, cbor.Marshal(&registry.RuntimeSuspendedEvent{
+			RuntimeID: id,
+			Reason:    reason,
+		})),
+	)
+	return nil
+}
+
 // New constructs a new registry application instance.
 func New() api.Application {
 	return &registryApplication{}
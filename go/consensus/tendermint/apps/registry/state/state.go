@@ -3,17 +3,21 @@ package state
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/proof"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	tmcrypto "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/crypto"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 )
 
 var (
@@ -69,6 +73,30 @@ var (
 	//
 	// Value is empty.
 	runtimeByEntityKeyFmt = keyformat.New(0x19, keyformat.H(&signature.PublicKey{}), keyformat.H(&common.Namespace{}))
+	// runtimeSuspensionReasonKeyFmt is the key format used for suspended runtime suspension
+	// reasons.
+	//
+	// Value is CBOR-serialized runtime suspension reason.
+	runtimeSuspensionReasonKeyFmt = keyformat.New(0x1f, keyformat.H(&common.Namespace{}))
+	// archivedEntityKeyFmt is the key format used for archived descriptors of deregistered
+	// entities.
+	//
+	// Value is CBOR-serialized registry.ArchivedEntity.
+	archivedEntityKeyFmt = keyformat.New(0x1b, keyformat.H(&signature.PublicKey{}))
+	// archivedNodeKeyFmt is the key format used for archived descriptors of removed nodes.
+	//
+	// Value is CBOR-serialized registry.ArchivedNode.
+	archivedNodeKeyFmt = keyformat.New(0x1c, keyformat.H(&signature.PublicKey{}))
+	// archivedEntityByEpochKeyFmt is the key format used for the archived entity by
+	// deregistration epoch index.
+	//
+	// Value is empty.
+	archivedEntityByEpochKeyFmt = keyformat.New(0x1d, uint64(0), keyformat.H(&signature.PublicKey{}))
+	// archivedNodeByEpochKeyFmt is the key format used for the archived node by deregistration
+	// epoch index.
+	//
+	// Value is empty.
+	archivedNodeByEpochKeyFmt = keyformat.New(0x1e, uint64(0), keyformat.H(&signature.PublicKey{}))
 )
 
 // ImmutableState is the immutable registry state wrapper.
@@ -180,6 +208,45 @@ func (s *ImmutableState) Node(ctx context.Context, id signature.PublicKey) (*nod
 	return &node, nil
 }
 
+// NodeWithProof looks up a specific node by its identifier and returns it together with a proof
+// of the node state against the consensus state root.
+func (s *ImmutableState) NodeWithProof(ctx context.Context, id signature.PublicKey) (*registry.NodeWithProof, error) {
+	node, err := s.Node(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.is.GetProof(ctx, signedNodeKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, err
+	}
+	return &registry.NodeWithProof{
+		Node:  node,
+		Proof: p,
+	}, nil
+}
+
+// VerifyNodeProof verifies the node proof for the given node identifier against a trusted
+// consensus state root and returns the proven node descriptor.
+func VerifyNodeProof(ctx context.Context, root mkvsNode.Root, id signature.PublicKey, p *proof.StateProof) (*node.Node, error) {
+	signedNodeRaw, err := p.Verify(ctx, root, signedNodeKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, err
+	}
+	if signedNodeRaw == nil {
+		return nil, registry.ErrNoSuchNode
+	}
+
+	var signedNode node.MultiSignedNode
+	if err = cbor.Unmarshal(signedNodeRaw, &signedNode); err != nil {
+		return nil, fmt.Errorf("tendermint/registry: malformed signed node: %w", err)
+	}
+	var node node.Node
+	if err = cbor.Unmarshal(signedNode.Blob, &node); err != nil {
+		return nil, fmt.Errorf("tendermint/registry: malformed node: %w", err)
+	}
+	return &node, nil
+}
+
 // NodeIDByConsensusAddress looks up a specific node ID by its consensus address.
 //
 // If you need to get the actual node descriptor, use NodeByConsensusAddress instead.
@@ -285,6 +352,43 @@ func (s *ImmutableState) Runtime(ctx context.Context, id common.Namespace) (*reg
 	return s.getRuntime(ctx, runtimeKeyFmt, id)
 }
 
+// RuntimeWithProof looks up a runtime by its identifier and returns it together with a proof of
+// the runtime state against the consensus state root.
+//
+// This excludes any suspended runtimes.
+func (s *ImmutableState) RuntimeWithProof(ctx context.Context, id common.Namespace) (*registry.RuntimeWithProof, error) {
+	rt, err := s.Runtime(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.is.GetProof(ctx, runtimeKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, err
+	}
+	return &registry.RuntimeWithProof{
+		Runtime: rt,
+		Proof:   p,
+	}, nil
+}
+
+// VerifyRuntimeProof verifies the runtime proof for the given runtime identifier against a trusted
+// consensus state root and returns the proven runtime descriptor.
+func VerifyRuntimeProof(ctx context.Context, root mkvsNode.Root, id common.Namespace, p *proof.StateProof) (*registry.Runtime, error) {
+	raw, err := p.Verify(ctx, root, runtimeKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, registry.ErrNoSuchRuntime
+	}
+
+	var runtime registry.Runtime
+	if err = cbor.Unmarshal(raw, &runtime); err != nil {
+		return nil, fmt.Errorf("tendermint/registry: malformed runtime: %w", err)
+	}
+	return &runtime, nil
+}
+
 // SuspendedRuntime looks up a suspended runtime by its identifier and
 // returns it.
 func (s *ImmutableState) SuspendedRuntime(ctx context.Context, id common.Namespace) (*registry.Runtime, error) {
@@ -386,6 +490,65 @@ func (s *ImmutableState) NodeStatus(ctx context.Context, id signature.PublicKey)
 	return &status, nil
 }
 
+// RuntimeStatus returns the status of a runtime (suspended included).
+func (s *ImmutableState) RuntimeStatus(ctx context.Context, id common.Namespace) (*registry.RuntimeStatus, error) {
+	if _, err := s.Runtime(ctx, id); err != registry.ErrNoSuchRuntime {
+		if err != nil {
+			return nil, err
+		}
+		return &registry.RuntimeStatus{}, nil
+	}
+	if _, err := s.SuspendedRuntime(ctx, id); err != nil {
+		return nil, err
+	}
+
+	status := registry.RuntimeStatus{Suspended: true}
+	value, err := s.is.Get(ctx, runtimeSuspensionReasonKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if value != nil {
+		if err = cbor.Unmarshal(value, &status.SuspensionReason); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+	}
+	return &status, nil
+}
+
+// ArchivedEntity looks up the archived descriptor of a deregistered entity by its identifier.
+func (s *ImmutableState) ArchivedEntity(ctx context.Context, id signature.PublicKey) (*registry.ArchivedEntity, error) {
+	data, err := s.is.Get(ctx, archivedEntityKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if data == nil {
+		return nil, registry.ErrNoSuchEntity
+	}
+
+	var archived registry.ArchivedEntity
+	if err = cbor.Unmarshal(data, &archived); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &archived, nil
+}
+
+// ArchivedNode looks up the archived descriptor of a removed node by its identifier.
+func (s *ImmutableState) ArchivedNode(ctx context.Context, id signature.PublicKey) (*registry.ArchivedNode, error) {
+	data, err := s.is.Get(ctx, archivedNodeKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if data == nil {
+		return nil, registry.ErrNoSuchNode
+	}
+
+	var archived registry.ArchivedNode
+	if err = cbor.Unmarshal(data, &archived); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &archived, nil
+}
+
 // GetEntityNodes returns nodes registered by given entity.
 // Note that this returns both active and expired nodes.
 func (s *ImmutableState) GetEntityNodes(ctx context.Context, id signature.PublicKey) ([]*node.Node, error) {
@@ -510,10 +673,142 @@ type MutableState struct {
 
 // SetEntity sets a signed entity descriptor for a registered entity.
 func (s *MutableState) SetEntity(ctx context.Context, ent *entity.Entity, sigEnt *entity.SignedEntity) error {
-	err := s.ms.Insert(ctx, signedEntityKeyFmt.Encode(&ent.ID), cbor.Marshal(sigEnt))
+	if err := s.ms.Insert(ctx, signedEntityKeyFmt.Encode(&ent.ID), cbor.Marshal(sigEnt)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+
+	// Remove any archived descriptor in case the entity has been registered again.
+	archived, err := s.ArchivedEntity(ctx, ent.ID)
+	switch err {
+	case nil:
+		return s.removeArchived(ctx, archivedEntityKeyFmt, archivedEntityByEpochKeyFmt, ent.ID, archived.DeregisteredAt)
+	case registry.ErrNoSuchEntity:
+		return nil
+	default:
+		return err
+	}
+}
+
+// ArchiveEntity archives the descriptor of a registered entity that is about to be deregistered.
+func (s *MutableState) ArchiveEntity(ctx context.Context, id signature.PublicKey, epoch beacon.EpochTime) error {
+	data, err := s.getSignedEntityRaw(ctx, id)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return registry.ErrNoSuchEntity
+	}
+
+	var signedEntity entity.SignedEntity
+	if err = cbor.Unmarshal(data, &signedEntity); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	archived := registry.ArchivedEntity{
+		Entity:         &signedEntity,
+		DeregisteredAt: epoch,
+	}
+	return s.archive(ctx, archivedEntityKeyFmt, archivedEntityByEpochKeyFmt, id, epoch, cbor.Marshal(archived))
+}
+
+// ArchiveNode archives the descriptor of a registered node that is about to be removed.
+func (s *MutableState) ArchiveNode(ctx context.Context, id signature.PublicKey, epoch beacon.EpochTime) error {
+	data, err := s.getSignedNodeRaw(ctx, id)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return registry.ErrNoSuchNode
+	}
+
+	var signedNode node.MultiSignedNode
+	if err = cbor.Unmarshal(data, &signedNode); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	archived := registry.ArchivedNode{
+		Node:           &signedNode,
+		DeregisteredAt: epoch,
+	}
+	return s.archive(ctx, archivedNodeKeyFmt, archivedNodeByEpochKeyFmt, id, epoch, cbor.Marshal(archived))
+}
+
+func (s *MutableState) archive(
+	ctx context.Context,
+	keyFmt, indexKeyFmt *keyformat.KeyFormat,
+	id signature.PublicKey,
+	epoch beacon.EpochTime,
+	value []byte,
+) error {
+	if err := s.ms.Insert(ctx, keyFmt.Encode(&id), value); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err := s.ms.Insert(ctx, indexKeyFmt.Encode(uint64(epoch), &id), []byte(""))
 	return abciAPI.UnavailableStateError(err)
 }
 
+func (s *MutableState) removeArchived(
+	ctx context.Context,
+	keyFmt, indexKeyFmt *keyformat.KeyFormat,
+	id signature.PublicKey,
+	epoch beacon.EpochTime,
+) error {
+	if err := s.ms.Remove(ctx, keyFmt.Encode(&id)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err := s.ms.Remove(ctx, indexKeyFmt.Encode(uint64(epoch), &id))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// PruneArchive removes all archived descriptors of entities and nodes that were deregistered
+// before the given epoch.
+func (s *MutableState) PruneArchive(ctx context.Context, before beacon.EpochTime) error {
+	for _, fmts := range []struct {
+		keyFmt      *keyformat.KeyFormat
+		indexKeyFmt *keyformat.KeyFormat
+	}{
+		{archivedEntityKeyFmt, archivedEntityByEpochKeyFmt},
+		{archivedNodeKeyFmt, archivedNodeByEpochKeyFmt},
+	} {
+		if err := s.pruneArchive(ctx, fmts.keyFmt, fmts.indexKeyFmt, before); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MutableState) pruneArchive(
+	ctx context.Context,
+	keyFmt, indexKeyFmt *keyformat.KeyFormat,
+	before beacon.EpochTime,
+) error {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var toDelete [][]byte
+	for it.Seek(indexKeyFmt.Encode()); it.Valid(); it.Next() {
+		var (
+			epoch uint64
+			hID   keyformat.PreHashed
+		)
+		if !indexKeyFmt.Decode(it.Key(), &epoch, &hID) {
+			break
+		}
+		if beacon.EpochTime(epoch) >= before {
+			break
+		}
+		toDelete = append(toDelete, it.Key(), keyFmt.Encode(&hID))
+	}
+	if it.Err() != nil {
+		return abciAPI.UnavailableStateError(it.Err())
+	}
+
+	for _, key := range toDelete {
+		if err := s.ms.Remove(ctx, key); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+	return nil
+}
+
 // RemoveEntity removes a previously registered entity.
 func (s *MutableState) RemoveEntity(ctx context.Context, id signature.PublicKey) (*entity.Entity, error) {
 	data, err := s.ms.RemoveExisting(ctx, signedEntityKeyFmt.Encode(&id))
@@ -606,6 +901,19 @@ func (s *MutableState) SetNode(ctx context.Context, existingNode, node *node.Nod
 		return abciAPI.UnavailableStateError(err)
 	}
 
+	// Remove any archived descriptor in case the node has been registered again.
+	if existingNode == nil {
+		var archived *registry.ArchivedNode
+		archived, err = s.ArchivedNode(ctx, node.ID)
+		switch err {
+		case nil:
+			return s.removeArchived(ctx, archivedNodeKeyFmt, archivedNodeByEpochKeyFmt, node.ID, archived.DeregisteredAt)
+		case registry.ErrNoSuchNode:
+		default:
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -660,8 +968,8 @@ func (s *MutableState) SetRuntime(ctx context.Context, rt *registry.Runtime, sus
 	return abciAPI.UnavailableStateError(err)
 }
 
-// SuspendRuntime marks a runtime as suspended.
-func (s *MutableState) SuspendRuntime(ctx context.Context, id common.Namespace) error {
+// SuspendRuntime marks a runtime as suspended for the given reason.
+func (s *MutableState) SuspendRuntime(ctx context.Context, id common.Namespace, reason registry.RuntimeSuspensionReason) error {
 	data, err := s.ms.RemoveExisting(ctx, runtimeKeyFmt.Encode(&id))
 	if err != nil {
 		return abciAPI.UnavailableStateError(err)
@@ -669,7 +977,10 @@ func (s *MutableState) SuspendRuntime(ctx context.Context, id common.Namespace)
 	if data == nil {
 		return registry.ErrNoSuchRuntime
 	}
-	err = s.ms.Insert(ctx, suspendedRuntimeKeyFmt.Encode(&id), data)
+	if err = s.ms.Insert(ctx, suspendedRuntimeKeyFmt.Encode(&id), data); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err = s.ms.Insert(ctx, runtimeSuspensionReasonKeyFmt.Encode(&id), cbor.Marshal(reason))
 	return abciAPI.UnavailableStateError(err)
 }
 
@@ -682,7 +993,10 @@ func (s *MutableState) ResumeRuntime(ctx context.Context, id common.Namespace) e
 	if data == nil {
 		return registry.ErrNoSuchRuntime
 	}
-	err = s.ms.Insert(ctx, runtimeKeyFmt.Encode(&id), data)
+	if err = s.ms.Insert(ctx, runtimeKeyFmt.Encode(&id), data); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err = s.ms.Remove(ctx, runtimeSuspensionReasonKeyFmt.Encode(&id))
 	return abciAPI.UnavailableStateError(err)
 }
 
@@ -712,3 +1026,25 @@ func NewMutableState(tree mkvs.KeyValueTree) *MutableState {
 		ms: tree,
 	}
 }
+
+func init() {
+	abciAPI.RegisterStateKeyFormats(
+		registry.ModuleName,
+		signedEntityKeyFmt,
+		signedNodeKeyFmt,
+		signedNodeByEntityKeyFmt,
+		runtimeKeyFmt,
+		nodeByConsAddressKeyFmt,
+		nodeStatusKeyFmt,
+		parametersKeyFmt,
+		keyMapKeyFmt,
+		suspendedRuntimeKeyFmt,
+		runtimeByEntityKeyFmt,
+		runtimeSuspensionReasonKeyFmt,
+		archivedEntityKeyFmt,
+		archivedNodeKeyFmt,
+		archivedEntityByEpochKeyFmt,
+		archivedNodeByEpochKeyFmt,
+		deprecatedBeaconPointMapKeyFmt,
+	)
+}
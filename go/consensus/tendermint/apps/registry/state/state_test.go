@@ -6,9 +6,12 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	tmcrypto "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/crypto"
@@ -160,3 +163,170 @@ func TestNodeUpdate(t *testing.T) {
 	require.Error(err, "TLS mapping should be gone")
 	require.Equal(registry.ErrNoSuchNode, err, "TLS mapping should be gone")
 }
+
+func TestRuntimeSuspension(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock, now)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	rt := registry.Runtime{
+		Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+		ID:        common.NewTestNamespaceFromSeed([]byte("consensus/tendermint/apps/registry/state: runtime"), 0),
+		EntityID:  entitySigner.Public(),
+	}
+
+	_, err := s.RuntimeStatus(ctx, rt.ID)
+	require.ErrorIs(err, registry.ErrNoSuchRuntime, "RuntimeStatus should fail for unknown runtimes")
+	err = s.SuspendRuntime(ctx, rt.ID, registry.SuspensionReasonNoCommittee)
+	require.ErrorIs(err, registry.ErrNoSuchRuntime, "SuspendRuntime should fail for unknown runtimes")
+
+	err = s.SetRuntime(ctx, &rt, false)
+	require.NoError(err, "SetRuntime")
+
+	status, err := s.RuntimeStatus(ctx, rt.ID)
+	require.NoError(err, "RuntimeStatus")
+	require.False(status.Suspended, "runtime should not be suspended")
+
+	err = s.SuspendRuntime(ctx, rt.ID, registry.SuspensionReasonInsufficientStake)
+	require.NoError(err, "SuspendRuntime")
+
+	_, err = s.Runtime(ctx, rt.ID)
+	require.ErrorIs(err, registry.ErrNoSuchRuntime, "suspended runtime should not be returned by Runtime")
+	status, err = s.RuntimeStatus(ctx, rt.ID)
+	require.NoError(err, "RuntimeStatus")
+	require.True(status.Suspended, "runtime should be suspended")
+	require.Equal(registry.SuspensionReasonInsufficientStake, status.SuspensionReason, "suspension reason should be recorded")
+
+	err = s.ResumeRuntime(ctx, rt.ID)
+	require.NoError(err, "ResumeRuntime")
+
+	_, err = s.Runtime(ctx, rt.ID)
+	require.NoError(err, "resumed runtime should be returned by Runtime")
+	status, err = s.RuntimeStatus(ctx, rt.ID)
+	require.NoError(err, "RuntimeStatus")
+	require.False(status.Suspended, "runtime should not be suspended after resumption")
+	require.Equal(registry.SuspensionReasonUnknown, status.SuspensionReason, "suspension reason should be cleared")
+}
+
+func TestArchive(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock, now)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+	}
+	sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, &ent)
+	require.NoError(err, "SignEntity")
+	err = s.SetEntity(ctx, &ent, sigEnt)
+	require.NoError(err, "SetEntity")
+
+	n := node.Node{
+		Versioned: cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+		ID:        nodeSigner.Public(),
+		EntityID:  entitySigner.Public(),
+		P2P: node.P2PInfo{
+			ID: p2pSigner1.Public(),
+		},
+		Consensus: node.ConsensusInfo{
+			ID: consensusSigner1.Public(),
+		},
+		TLS: node.TLSInfo{
+			PubKey: tlsSigner1.Public(),
+		},
+	}
+	err = s.SetNode(ctx, nil, &n, mustMultiSignNode(t, &n))
+	require.NoError(err, "SetNode")
+
+	_, err = s.ArchivedEntity(ctx, ent.ID)
+	require.ErrorIs(err, registry.ErrNoSuchEntity, "ArchivedEntity should fail for registered entities")
+	_, err = s.ArchivedNode(ctx, n.ID)
+	require.ErrorIs(err, registry.ErrNoSuchNode, "ArchivedNode should fail for registered nodes")
+
+	// Archive and remove the node.
+	err = s.ArchiveNode(ctx, n.ID, 10)
+	require.NoError(err, "ArchiveNode")
+	err = s.RemoveNode(ctx, &n)
+	require.NoError(err, "RemoveNode")
+
+	archivedNode, err := s.ArchivedNode(ctx, n.ID)
+	require.NoError(err, "ArchivedNode")
+	require.EqualValues(10, archivedNode.DeregisteredAt, "deregistration epoch should be recorded")
+	var archivedDesc node.Node
+	err = cbor.Unmarshal(archivedNode.Node.Blob, &archivedDesc)
+	require.NoError(err, "Unmarshal")
+	require.EqualValues(n, archivedDesc, "archived node descriptor should be correct")
+
+	// Archive and remove the entity.
+	err = s.ArchiveEntity(ctx, ent.ID, 20)
+	require.NoError(err, "ArchiveEntity")
+	_, err = s.RemoveEntity(ctx, ent.ID)
+	require.NoError(err, "RemoveEntity")
+
+	archivedEntity, err := s.ArchivedEntity(ctx, ent.ID)
+	require.NoError(err, "ArchivedEntity")
+	require.EqualValues(20, archivedEntity.DeregisteredAt, "deregistration epoch should be recorded")
+	require.EqualValues(sigEnt, archivedEntity.Entity, "archived entity descriptor should be correct")
+
+	err = s.ArchiveEntity(ctx, ent.ID, 20)
+	require.ErrorIs(err, registry.ErrNoSuchEntity, "ArchiveEntity should fail for unknown entities")
+
+	// Pruning should only remove descriptors deregistered before the given epoch.
+	err = s.PruneArchive(ctx, 15)
+	require.NoError(err, "PruneArchive")
+	_, err = s.ArchivedNode(ctx, n.ID)
+	require.ErrorIs(err, registry.ErrNoSuchNode, "archived node should be pruned")
+	_, err = s.ArchivedEntity(ctx, ent.ID)
+	require.NoError(err, "archived entity should not be pruned")
+
+	// Re-registering the entity should remove the archived descriptor.
+	err = s.SetEntity(ctx, &ent, sigEnt)
+	require.NoError(err, "SetEntity")
+	_, err = s.ArchivedEntity(ctx, ent.ID)
+	require.ErrorIs(err, registry.ErrNoSuchEntity, "re-registered entity should not be archived")
+
+	err = s.PruneArchive(ctx, 100)
+	require.NoError(err, "PruneArchive")
+	_, err = s.Entity(ctx, ent.ID)
+	require.NoError(err, "pruning should not affect registered entities")
+}
+
+func TestKeyFormatPrefixes(t *testing.T) {
+	require := require.New(t)
+
+	// Prefixes must never be reused, including the ones of deprecated key formats, as leftover
+	// entries may still exist in the state of upgraded networks.
+	seen := make(map[byte]bool)
+	for _, kf := range []*keyformat.KeyFormat{
+		signedEntityKeyFmt,
+		signedNodeKeyFmt,
+		signedNodeByEntityKeyFmt,
+		runtimeKeyFmt,
+		nodeByConsAddressKeyFmt,
+		nodeStatusKeyFmt,
+		parametersKeyFmt,
+		keyMapKeyFmt,
+		suspendedRuntimeKeyFmt,
+		runtimeByEntityKeyFmt,
+		runtimeSuspensionReasonKeyFmt,
+		archivedEntityKeyFmt,
+		archivedNodeKeyFmt,
+		archivedEntityByEpochKeyFmt,
+		archivedNodeByEpochKeyFmt,
+		deprecatedBeaconPointMapKeyFmt,
+	} {
+		require.False(seen[kf.Prefix()], "key prefix 0x%02x should only be used once", kf.Prefix())
+		seen[kf.Prefix()] = true
+	}
+}
@@ -2,6 +2,7 @@ package registry
 
 import (
 	"fmt"
+	"math"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
@@ -130,6 +131,25 @@ func (app *registryApplication) deregisterEntity(ctx *api.Context, state *regist
 		return registry.ErrEntityHasRuntimes
 	}
 
+	// Archive the entity descriptor so it can still be queried after deregistration.
+	if params.ArchiveRetention > 0 {
+		var epoch beacon.EpochTime
+		epoch, err = app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+		if err != nil {
+			ctx.Logger().Error("DeregisterEntity: failed to get epoch",
+				"err", err,
+			)
+			return err
+		}
+		switch err = state.ArchiveEntity(ctx, id, epoch); err {
+		case nil:
+		case registry.ErrNoSuchEntity:
+			return err
+		default:
+			return fmt.Errorf("DeregisterEntity: failed to archive entity: %w", err)
+		}
+	}
+
 	removedEntity, err := state.RemoveEntity(ctx, id)
 	switch err {
 	case nil:
@@ -310,6 +330,13 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 		}
 	}
 
+	// Check runtime's per-role admission policies.
+	for _, rt := range paidRuntimes {
+		if err = verifyPerRoleAdmissionPolicy(ctx, state, newNode, rt, epoch); err != nil {
+			return err
+		}
+	}
+
 	// Ensure node is not expired. Even though the expiration in the
 	// current epoch is technically not yet expired, we treat it as
 	// expired as it doesn't make sense to have a new node that will
@@ -333,6 +360,12 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 	existingNode, err := state.Node(ctx, newNode.ID)
 	isNewNode := err == registry.ErrNoSuchNode
 	isExpiredNode := err == nil && existingNode.IsExpired(uint64(epoch))
+	// Nodes that re-register within the grace period after their expiration are treated as if
+	// they renewed their registration in time.
+	inGracePeriod := isExpiredNode &&
+		params.NodeExpirationGracePeriod > 0 &&
+		math.MaxUint64-existingNode.Expiration >= uint64(params.NodeExpirationGracePeriod) &&
+		existingNode.Expiration+uint64(params.NodeExpirationGracePeriod) >= uint64(epoch)
 	if !isNewNode && err != nil {
 		// Something went horribly wrong, and we failed to query the node.
 		ctx.Logger().Error("RegisterNode: failed to query node",
@@ -426,7 +459,7 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 
 	// Initialize/update the node status depending on what has changed.
 	var statusDirty bool
-	if isNewNode || isExpiredNode {
+	if isNewNode || (isExpiredNode && !inGracePeriod) {
 		// Node doesn't exist (or is expired).
 		statusDirty = true
 		if status != nil {
@@ -443,6 +476,18 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 
 	} else {
 		// Node exists, and the registration is just getting renewed.
+		if isExpiredNode {
+			// Node re-registered within the grace period, so it keeps its election
+			// eligibility.
+			ctx.Logger().Debug("RegisterNode: node re-registered within grace period",
+				"node_id", newNode.ID,
+				"expiration", existingNode.Expiration,
+				"epoch", epoch,
+			)
+			statusDirty = true
+			status.ExpirationProcessed = false
+		}
+
 		var beaconParams *beacon.ConsensusParameters
 		beaconState := beaconState.NewMutableState(ctx.State())
 		if beaconParams, err = beaconState.ConsensusParameters(ctx); err != nil {
@@ -464,7 +509,8 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 				return !existingNode.VRF.ID.Equal(newNode.VRF.ID)
 			}()
 
-			if statusDirty = vrfChanged; statusDirty {
+			if vrfChanged {
+				statusDirty = true
 				status.ElectionEligibleAfter = beacon.EpochInvalid
 			}
 		}
@@ -483,38 +529,20 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 	for _, rt := range paidRuntimes {
 		// Only resume a runtime if the entity has enough stake to avoid having the runtime be
 		// suspended again on the next epoch transition.
-		if !params.DebugBypassStake && rt.GovernanceModel != registry.GovernanceConsensus {
-			acctAddr := rt.StakingAddress()
-			if acctAddr == nil {
-				// This should never happen.
-				ctx.Logger().Error("unknown runtime governance model",
-					"rt_id", rt.ID,
-					"gov_model", rt.GovernanceModel,
-				)
-				return fmt.Errorf("unknown runtime governance model on runtime %s: %s", rt.ID, rt.GovernanceModel)
-			}
-
-			if err = stakeAcc.CheckStakeClaims(*acctAddr); err != nil {
-				continue
-			}
+		var sufficientStake bool
+		if sufficientStake, err = hasSufficientRuntimeStake(ctx, params, stakeAcc, rt); err != nil {
+			return err
+		}
+		if !sufficientStake {
+			continue
 		}
 
-		err := state.ResumeRuntime(ctx, rt.ID)
+		err = app.resumeRuntime(ctx, state, rt)
 		switch err {
 		case nil:
 			ctx.Logger().Debug("RegisterNode: resumed runtime",
 				"runtime_id", rt.ID,
 			)
-
-			// Notify other interested applications about the resumed runtime.
-			if err = app.md.Publish(ctx, registryApi.MessageRuntimeResumed, rt); err != nil {
-				ctx.Logger().Error("RegisterNode: failed to dispatch runtime resumption message",
-					"err", err,
-				)
-				return err
-			}
-
-			ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyRuntimeRegistered, cbor.Marshal(rt)))
 		case registry.ErrNoSuchRuntime:
 			// Runtime was not suspended.
 		default:
@@ -538,6 +566,67 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 	return nil
 }
 
+// verifyPerRoleAdmissionPolicy verifies that the given node satisfies the per-role admission
+// policies of the given runtime.
+func verifyPerRoleAdmissionPolicy(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	newNode *node.Node,
+	rt *registry.Runtime,
+	epoch beacon.EpochTime,
+) error {
+	for _, role := range node.Roles() {
+		rp, ok := rt.AdmissionPolicy.PerRole[role]
+		if !ok || !newNode.HasRoles(role) {
+			continue
+		}
+
+		maxNodes, allowed := rp.MaxNodesForEntity(newNode.EntityID)
+		if !allowed {
+			ctx.Logger().Error("RegisterNode: node's entity not in a runtime's per-role whitelist",
+				"entity", newNode.EntityID,
+				"role", role.String(),
+				"runtime", rt.ID,
+			)
+			return registry.ErrForbidden
+		}
+		if maxNodes == 0 {
+			continue
+		}
+
+		// Count existing nodes with the same role owned by entity.
+		nodes, err := state.GetEntityNodes(ctx, newNode.EntityID)
+		if err != nil {
+			ctx.Logger().Error("RegisterNode: failed to query entity nodes",
+				"err", err,
+				"entity", newNode.EntityID,
+			)
+			return err
+		}
+		var curNodes uint16
+		for _, n := range nodes {
+			if n.ID.Equal(newNode.ID) || n.IsExpired(uint64(epoch)) || n.GetRuntime(rt.ID) == nil {
+				// Skip existing node when re-registering. Also skip expired nodes and nodes
+				// that haven't registered for the same runtime.
+				continue
+			}
+			if n.HasRoles(role) {
+				curNodes++
+			}
+		}
+		if curNodes >= maxNodes {
+			ctx.Logger().Error("RegisterNode: too many nodes with given role already registered for runtime",
+				"role", role.String(),
+				"runtime", rt.ID,
+				"num_registered_nodes", curNodes,
+				"max_nodes", maxNodes,
+			)
+			return registry.ErrForbidden
+		}
+	}
+	return nil
+}
+
 func (app *registryApplication) unfreezeNode(
 	ctx *api.Context,
 	state *registryState.MutableState,
@@ -713,6 +802,25 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 		}
 	}
 
+	// Make sure that the controlling entity is allowed to control runtimes.
+	if rt.GovernanceModel == registry.GovernanceEntity {
+		var ent *entity.Entity
+		ent, err = state.Entity(ctx, rt.EntityID)
+		if err != nil {
+			ctx.Logger().Error("RegisterRuntime: failed to fetch controlling entity",
+				"err", err,
+				"entity", rt.EntityID,
+			)
+			return err
+		}
+		if !ent.CanSignRuntimes() {
+			ctx.Logger().Error("RegisterRuntime: entity key usage does not allow runtimes",
+				"entity", rt.EntityID,
+			)
+			return registry.ErrForbidden
+		}
+	}
+
 	// Make sure that the entity or runtime has enough stake.
 	// Runtimes using the consensus layer governance model do not require stake.
 	if !params.DebugBypassStake && rt.GovernanceModel != registry.GovernanceConsensus {
@@ -777,3 +885,28 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 
 	return nil
 }
+
+func (app *registryApplication) proveFreshness(
+	ctx *api.Context,
+	state *registryState.MutableState,
+) error {
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("ProveFreshness: failed to fetch consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if err = ctx.Gas().UseGas(1, registry.GasOpProveFreshness, params.GasCosts); err != nil {
+		return err
+	}
+
+	// The transaction itself has no effect on the state. Its inclusion in a block serves as
+	// a proof that the blob existed at the given height.
+	return nil
+}
@@ -298,6 +298,152 @@ func TestRegisterNode(t *testing.T) {
 			false,
 			false,
 		},
+		// Compute node without per-role admission policy stake.
+		{
+			"ComputeNodeWithoutPerRoleStake",
+			func(tcd *testCaseData) {
+				// Create a new runtime.
+				rt := registry.Runtime{
+					Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+					ID:        common.NewTestNamespaceFromSeed([]byte("consensus/tendermint/apps/registry: runtime: ComputeNodeWithoutPerRoleStake"), 0),
+					Kind:      registry.KindCompute,
+					AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+						AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+						PerRole: map[node.RolesMask]registry.PerRoleAdmissionPolicy{
+							node.RoleComputeWorker: {
+								MinStake: quantity.NewFromUint64(1000),
+							},
+						},
+					},
+					GovernanceModel: registry.GovernanceEntity,
+				}
+				_ = state.SetRuntime(ctx, &rt, false)
+
+				tcd.node.AddRoles(node.RoleComputeWorker)
+				tcd.node.Runtimes = []*node.Runtime{
+					{ID: rt.ID},
+				}
+			},
+			nil,
+			false,
+			false,
+		},
+		// Compute node with enough per-role admission policy stake.
+		{
+			"ComputeNodeWithPerRoleStake",
+			func(tcd *testCaseData) {
+				// Create a new runtime.
+				rt := registry.Runtime{
+					Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+					ID:        common.NewTestNamespaceFromSeed([]byte("consensus/tendermint/apps/registry: runtime: ComputeNodeWithPerRoleStake"), 0),
+					Kind:      registry.KindCompute,
+					AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+						AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+						PerRole: map[node.RolesMask]registry.PerRoleAdmissionPolicy{
+							node.RoleComputeWorker: {
+								MinStake: quantity.NewFromUint64(1000),
+							},
+						},
+					},
+					GovernanceModel: registry.GovernanceEntity,
+				}
+				_ = state.SetRuntime(ctx, &rt, false)
+
+				// Add bonded stake (hacky, without a self-delegation).
+				_ = stakeState.SetAccount(ctx, staking.NewAddress(tcd.node.EntityID), &staking.Account{
+					Escrow: staking.EscrowAccount{
+						Active: staking.SharePool{
+							Balance: *quantity.NewFromUint64(1000),
+						},
+					},
+				})
+
+				tcd.node.AddRoles(node.RoleComputeWorker)
+				tcd.node.Runtimes = []*node.Runtime{
+					{ID: rt.ID},
+				}
+			},
+			nil,
+			true,
+			true,
+		},
+		// Compute node of an entity not in the per-role whitelist.
+		{
+			"ComputeNodeNotInPerRoleWhitelist",
+			func(tcd *testCaseData) {
+				// Create a new runtime.
+				rt := registry.Runtime{
+					Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+					ID:        common.NewTestNamespaceFromSeed([]byte("consensus/tendermint/apps/registry: runtime: ComputeNodeNotInPerRoleWhitelist"), 0),
+					Kind:      registry.KindCompute,
+					AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+						AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+						PerRole: map[node.RolesMask]registry.PerRoleAdmissionPolicy{
+							node.RoleComputeWorker: {
+								EntityWhitelist: &registry.EntityWhitelistRoleAdmissionPolicy{
+									Entities: map[signature.PublicKey]registry.EntityWhitelistRoleConfig{
+										tcData["ComputeNode"].entitySigner.Public(): {},
+									},
+								},
+							},
+						},
+					},
+					GovernanceModel: registry.GovernanceEntity,
+				}
+				_ = state.SetRuntime(ctx, &rt, false)
+
+				tcd.node.AddRoles(node.RoleComputeWorker)
+				tcd.node.Runtimes = []*node.Runtime{
+					{ID: rt.ID},
+				}
+			},
+			nil,
+			false,
+			false,
+		},
+		// Compute node of an entity that already has the maximum number of nodes.
+		{
+			"ComputeNodePerRoleMaxNodesPerEntity",
+			func(tcd *testCaseData) {
+				// Create a new runtime.
+				rt := registry.Runtime{
+					Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+					ID:        common.NewTestNamespaceFromSeed([]byte("consensus/tendermint/apps/registry: runtime: ComputeNodePerRoleMaxNodesPerEntity"), 0),
+					Kind:      registry.KindCompute,
+					AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+						AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+						PerRole: map[node.RolesMask]registry.PerRoleAdmissionPolicy{
+							node.RoleComputeWorker: {
+								MaxNodesPerEntity: 1,
+							},
+						},
+					},
+					GovernanceModel: registry.GovernanceEntity,
+				}
+				_ = state.SetRuntime(ctx, &rt, false)
+
+				// Add an existing compute node of the same entity.
+				existing := node.Node{
+					Versioned:  cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+					ID:         memorySigner.NewTestSigner("consensus/tendermint/apps/registry: existing node").Public(),
+					EntityID:   tcd.node.EntityID,
+					Expiration: 3,
+					Roles:      node.RoleComputeWorker,
+					Runtimes:   []*node.Runtime{{ID: rt.ID}},
+				}
+				_ = state.SetNode(ctx, nil, &existing, &node.MultiSignedNode{
+					MultiSigned: signature.MultiSigned{Blob: cbor.Marshal(existing)},
+				})
+
+				tcd.node.AddRoles(node.RoleComputeWorker)
+				tcd.node.Runtimes = []*node.Runtime{
+					{ID: rt.ID},
+				}
+			},
+			nil,
+			false,
+			false,
+		},
 		// Updating a node should be allowed.
 		{
 			"UpdateValidator",
@@ -498,3 +644,152 @@ func TestRegisterNode(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterNodeGracePeriod(t *testing.T) {
+	require := requirePkg.New(t)
+
+	now := time.Unix(1580461674, 0)
+	cfg := abciAPI.MockApplicationStateConfig{}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+	beaconState := beaconState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		MaxNodeExpiration:         5,
+		NodeExpirationGracePeriod: 2,
+	})
+	require.NoError(err, "registry.SetConsensusParameters")
+	err = beaconState.SetConsensusParameters(ctx, &beacon.ConsensusParameters{
+		Backend: beacon.BackendInsecure,
+	})
+	require.NoError(err, "beacon.SetConsensusParameters")
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		Thresholds: map[staking.ThresholdKind]quantity.Quantity{
+			staking.KindEntity:        *quantity.NewFromUint64(0),
+			staking.KindNodeValidator: *quantity.NewFromUint64(0),
+		},
+	})
+	require.NoError(err, "staking.SetConsensusParameters")
+
+	entitySigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: grace period: entity signer")
+	nodeSigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: grace period: node signer")
+	consensusSigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: grace period: consensus signer")
+	p2pSigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: grace period: p2p signer")
+	tlsSigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: grace period: tls signer")
+	vrfSigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: grace period: vrf signer").(signature.VRFSigner)
+
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+		Nodes:     []signature.PublicKey{nodeSigner.Public()},
+	}
+	sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, &ent)
+	require.NoError(err, "SignEntity")
+	err = state.SetEntity(ctx, &ent, sigEnt)
+	require.NoError(err, "SetEntity")
+
+	var address node.Address
+	err = address.UnmarshalText([]byte("8.8.8.8:1234"))
+	require.NoError(err, "address.UnmarshalText")
+
+	register := func(epoch beacon.EpochTime, expiration uint64) {
+		cfg.CurrentEpoch = epoch
+
+		n := node.Node{
+			Versioned:  cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+			ID:         nodeSigner.Public(),
+			EntityID:   ent.ID,
+			Expiration: expiration,
+			P2P: node.P2PInfo{
+				ID:        p2pSigner.Public(),
+				Addresses: []node.Address{address},
+			},
+			Consensus: node.ConsensusInfo{
+				ID: consensusSigner.Public(),
+				Addresses: []node.ConsensusAddress{
+					{ID: consensusSigner.Public(), Address: address},
+				},
+			},
+			TLS: node.TLSInfo{
+				PubKey: tlsSigner.Public(),
+				Addresses: []node.TLSAddress{
+					{PubKey: tlsSigner.Public(), Address: address},
+				},
+			},
+			VRF: &node.VRFInfo{
+				ID: vrfSigner.Public(),
+			},
+			Roles: node.RoleValidator,
+		}
+		signers := []signature.Signer{nodeSigner, p2pSigner, consensusSigner, tlsSigner, vrfSigner}
+		sigNode, serr := node.MultiSignNode(signers, registry.RegisterNodeSignatureContext, &n)
+		require.NoError(serr, "MultiSignNode")
+
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(nodeSigner.Public())
+		err = app.registerNode(txCtx, state, sigNode)
+		require.NoError(err, "node registration should succeed")
+	}
+	eligibleAfter := func() beacon.EpochTime {
+		status, serr := state.NodeStatus(ctx, nodeSigner.Public())
+		require.NoError(serr, "NodeStatus")
+		return status.ElectionEligibleAfter
+	}
+
+	// New nodes are not immediately eligible for elections.
+	register(0, 3)
+	require.Equal(beacon.EpochInvalid, eligibleAfter(), "new node should not be eligible")
+
+	// Simulate the node becoming eligible.
+	err = state.SetNodeStatus(ctx, nodeSigner.Public(), &registry.NodeStatus{
+		ElectionEligibleAfter: 1,
+		ExpirationProcessed:   true,
+	})
+	require.NoError(err, "SetNodeStatus")
+
+	// Re-registration within the grace period should keep eligibility.
+	register(5, 7)
+	require.EqualValues(1, eligibleAfter(), "node re-registered within grace period should remain eligible")
+	status, err := state.NodeStatus(ctx, nodeSigner.Public())
+	require.NoError(err, "NodeStatus")
+	require.False(status.ExpirationProcessed, "expiration processed flag should be reset")
+
+	// Re-registration after the grace period should reset eligibility.
+	register(10, 12)
+	require.Equal(beacon.EpochInvalid, eligibleAfter(), "node re-registered after grace period should not be eligible")
+}
+
+func TestProveFreshness(t *testing.T) {
+	require := requirePkg.New(t)
+
+	now := time.Unix(1580461674, 0)
+	cfg := abciAPI.MockApplicationStateConfig{}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		GasCosts: registry.DefaultGasCosts,
+	})
+	require.NoError(err, "registry.SetConsensusParameters")
+
+	ctx.SetGasAccountant(abciAPI.NewGasAccountant(registry.DefaultGasCosts[registry.GasOpProveFreshness]))
+	err = app.proveFreshness(ctx, state)
+	require.NoError(err, "proveFreshness")
+	require.EqualValues(registry.DefaultGasCosts[registry.GasOpProveFreshness], ctx.Gas().GasUsed())
+
+	ctx.SetGasAccountant(abciAPI.NewGasAccountant(registry.DefaultGasCosts[registry.GasOpProveFreshness] - 1))
+	err = app.proveFreshness(ctx, state)
+	require.ErrorIs(err, abciAPI.ErrOutOfGas, "proveFreshness should fail when out of gas")
+}
@@ -15,6 +15,9 @@ const (
 	// AppID is the unique application identifier.
 	AppID uint8 = 0x02
 
+	// StateVersion is the version of the application's state schema.
+	StateVersion uint64 = 1
+
 	// AppName is the ABCI application name.
 	AppName string = "999_roothash"
 )
@@ -47,6 +50,15 @@ var (
 	// KeyFinalized is an ABCI event attribute key for finalized blocks
 	// (value is a CBOR serialized ValueFinalized).
 	KeyFinalized = []byte("finalized")
+	// KeyRoundFailed is an ABCI event attribute key for failed rounds
+	// (value is a CBOR serialized ValueRoundFailed).
+	KeyRoundFailed = []byte("round-failed")
+	// KeyMessage is an ABCI event attribute key for processed runtime messages
+	// (value is a CBOR serialized ValueMessage).
+	KeyMessage = []byte("message")
+	// KeyRuntimePaused is an ABCI event attribute key for runtimes being paused or resumed
+	// (value is a CBOR serialized ValueRuntimePaused).
+	KeyRuntimePaused = []byte("runtime-paused")
 )
 
 // QueryForRuntime returns a query for filtering transactions processed by the roothash application
@@ -73,8 +85,26 @@ type ValueFinalized struct {
 	Event roothash.FinalizedEvent `json:"event"`
 }
 
+// ValueRoundFailed is the value component of a KeyRoundFailed.
+type ValueRoundFailed struct {
+	ID    common.Namespace          `json:"id"`
+	Event roothash.RoundFailedEvent `json:"event"`
+}
+
 // ValueExecutionDiscrepancyDetected is the value component of a KeyMergeDiscrepancyDetected.
 type ValueExecutionDiscrepancyDetected struct {
 	ID    common.Namespace                           `json:"id"`
 	Event roothash.ExecutionDiscrepancyDetectedEvent `json:"event"`
 }
+
+// ValueMessage is the value component of a KeyMessage.
+type ValueMessage struct {
+	ID    common.Namespace      `json:"id"`
+	Event roothash.MessageEvent `json:"event"`
+}
+
+// ValueRuntimePaused is the value component of a KeyRuntimePaused.
+type ValueRuntimePaused struct {
+	ID    common.Namespace            `json:"id"`
+	Event roothash.RuntimePausedEvent `json:"event"`
+}
@@ -74,6 +74,7 @@ func (rq *rootHashQuerier) Genesis(ctx context.Context) (*roothashAPI.Genesis, e
 				Round:     rt.CurrentBlock.Header.Round,
 			},
 			MessageResults: lastRoundResults.Messages,
+			Paused:         rt.Paused,
 		}
 
 		rtStates[rt.Runtime.ID] = &rtState
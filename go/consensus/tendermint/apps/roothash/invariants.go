@@ -0,0 +1,65 @@
+package roothash
+
+import (
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/state"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+)
+
+// checkBlocks checks that the latest blocks of all runtimes are valid.
+func checkBlocks(ctx *tmapi.Context, epoch beacon.EpochTime) error {
+	st := roothashState.NewMutableState(ctx.State())
+
+	runtimes, err := st.Runtimes(ctx)
+	if err != nil {
+		return fmt.Errorf("Runtimes(): %w", err)
+	}
+
+	blocks := make(map[common.Namespace]*block.Block)
+	for _, rt := range runtimes {
+		blocks[rt.Runtime.ID] = rt.CurrentBlock
+	}
+	if err = roothash.SanityCheckBlocks(blocks); err != nil {
+		return fmt.Errorf("SanityCheckBlocks: %w", err)
+	}
+	return nil
+}
+
+// checkRoundTimeouts checks that the runtime timeout state is consistent with actual timeouts.
+func checkRoundTimeouts(ctx *tmapi.Context, epoch beacon.EpochTime) error {
+	st := roothashState.NewMutableState(ctx.State())
+
+	runtimes, err := st.Runtimes(ctx)
+	if err != nil {
+		return fmt.Errorf("Runtimes(): %w", err)
+	}
+	runtimesByID := make(map[common.Namespace]*roothash.RuntimeState)
+	for _, rt := range runtimes {
+		runtimesByID[rt.Runtime.ID] = rt
+	}
+
+	runtimeIDs, heights, err := st.RuntimesWithRoundTimeoutsAny(ctx)
+	if err != nil {
+		return fmt.Errorf("RuntimesWithRoundTimeoutsAny: %w", err)
+	}
+	for i, id := range runtimeIDs {
+		height := heights[i]
+		if height < ctx.BlockHeight() {
+			return fmt.Errorf("round timeout for runtime %s was scheduled at %d but did not trigger", id, height)
+		}
+		if !runtimesByID[id].ExecutorPool.IsTimeout(height) {
+			return fmt.Errorf("runtime %s scheduled for timeout at %d but would not actually trigger", id, height)
+		}
+	}
+	return nil
+}
+
+func init() {
+	tmapi.RegisterInvariant(roothash.ModuleName, "blocks", checkBlocks)
+	tmapi.RegisterInvariant(roothash.ModuleName, "round_timeouts", checkRoundTimeouts)
+}
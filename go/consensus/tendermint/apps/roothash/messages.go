@@ -1,7 +1,11 @@
 package roothash
 
 import (
+	"fmt"
+	"math"
+
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
@@ -9,6 +13,40 @@ import (
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
+// messageGasAccountant is a gas accountant that enforces a gas limit for executing a single
+// runtime message while also charging the gas to the parent gas accountant.
+type messageGasAccountant struct {
+	parent tmapi.GasAccountant
+
+	limit   transaction.Gas
+	usedGas transaction.Gas
+}
+
+func (ga *messageGasAccountant) UseGas(multiplier int, op transaction.Op, costs transaction.Costs) error {
+	if multiplier < 0 {
+		panic("gas: multiplier must be >= 0")
+	}
+
+	amount := costs[op] * transaction.Gas(multiplier)
+	if math.MaxUint64-ga.usedGas < amount || ga.usedGas+amount > ga.limit {
+		return fmt.Errorf("%w (limit: %d wanted: %d)", roothash.ErrMessageOutOfGas, ga.limit, ga.usedGas+amount)
+	}
+	if err := ga.parent.UseGas(multiplier, op, costs); err != nil {
+		return err
+	}
+
+	ga.usedGas += amount
+	return nil
+}
+
+func (ga *messageGasAccountant) GasWanted() transaction.Gas {
+	return ga.limit
+}
+
+func (ga *messageGasAccountant) GasUsed() transaction.Gas {
+	return ga.usedGas
+}
+
 func (app *rootHashApplication) processRuntimeMessages(
 	ctx *tmapi.Context,
 	rtState *roothash.RuntimeState,
@@ -30,28 +68,52 @@ func (app *rootHashApplication) processRuntimeMessages(
 		defer ctx.Close()
 	}
 
-	var results []*roothash.MessageEvent
+	var (
+		results      []*roothash.MessageEvent
+		roundGasUsed transaction.Gas
+	)
+	execParams := rtState.Runtime.Executor
 	for i, msg := range msgs {
 		ctx.Logger().Debug("dispatching runtime message",
 			"index", i,
 			"body", msg,
 		)
 
+		// Enforce the per-message and per-round message gas limits.
+		gasLimit := transaction.Gas(math.MaxUint64)
+		if execParams.MaxMessageGas > 0 {
+			gasLimit = execParams.MaxMessageGas
+		}
+		if execParams.MaxRoundMessageGas > 0 && execParams.MaxRoundMessageGas-roundGasUsed < gasLimit {
+			gasLimit = execParams.MaxRoundMessageGas - roundGasUsed
+		}
+		msgGas := &messageGasAccountant{
+			parent: ctx.Gas(),
+			limit:  gasLimit,
+		}
+		msgCtx := ctx.NewChild()
+		msgCtx.SetGasAccountant(msgGas)
+
 		var err error
 		switch {
 		case msg.Staking != nil:
-			err = app.md.Publish(ctx, roothashApi.RuntimeMessageStaking, msg.Staking)
+			err = app.md.Publish(msgCtx, roothashApi.RuntimeMessageStaking, msg.Staking)
 		case msg.Registry != nil:
-			err = app.md.Publish(ctx, roothashApi.RuntimeMessageRegistry, msg.Registry)
+			err = app.md.Publish(msgCtx, roothashApi.RuntimeMessageRegistry, msg.Registry)
 		default:
 			// Unsupported message.
 			err = roothash.ErrInvalidArgument
 		}
+		msgCtx.Close()
+		roundGasUsed += msgGas.GasUsed()
+
 		if err != nil {
 			ctx.Logger().Warn("failed to process runtime message",
 				"err", err,
 				"runtime_id", rtState.Runtime.ID,
 				"msg_index", i,
+				"gas_used", msgGas.GasUsed(),
+				"gas_limit", gasLimit,
 			)
 		}
 
@@ -62,9 +124,10 @@ func (app *rootHashApplication) processRuntimeMessages(
 
 		module, code := errors.Code(err)
 		results = append(results, &roothash.MessageEvent{
-			Index:  uint32(i),
-			Module: module,
-			Code:   code,
+			Index:   uint32(i),
+			Module:  module,
+			Code:    code,
+			GasUsed: msgGas.GasUsed(),
 		})
 	}
 	return results, nil
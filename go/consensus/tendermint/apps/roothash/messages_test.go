@@ -0,0 +1,102 @@
+package roothash
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestMessageGasAccountant(t *testing.T) {
+	require := require.New(t)
+
+	const op transaction.Op = "test"
+	costs := transaction.Costs{op: 10}
+
+	parent := tmapi.NewGasAccountant(100)
+	ga := &messageGasAccountant{parent: parent, limit: 25}
+
+	require.NoError(ga.UseGas(1, op, costs), "UseGas within limit")
+	require.NoError(ga.UseGas(1, op, costs), "UseGas within limit")
+	require.EqualValues(20, ga.GasUsed())
+	require.EqualValues(20, parent.GasUsed(), "gas should be charged to the parent")
+
+	err := ga.UseGas(1, op, costs)
+	require.True(errors.Is(err, roothash.ErrMessageOutOfGas), "UseGas over message limit")
+	require.EqualValues(20, ga.GasUsed(), "failed UseGas should not use gas")
+	require.EqualValues(20, parent.GasUsed(), "failed UseGas should not charge the parent")
+
+	// Parent limits should also be respected.
+	parent = tmapi.NewGasAccountant(15)
+	ga = &messageGasAccountant{parent: parent, limit: 100}
+	require.NoError(ga.UseGas(1, op, costs), "UseGas within limit")
+	err = ga.UseGas(1, op, costs)
+	require.True(errors.Is(err, tmapi.ErrOutOfGas), "UseGas over parent limit")
+	require.EqualValues(10, ga.GasUsed())
+}
+
+func TestProcessRuntimeMessagesGasLimits(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := tmapi.NewMockApplicationState(&tmapi.MockApplicationStateConfig{})
+	ctx := appState.NewContext(tmapi.ContextEndBlock, now)
+	defer ctx.Close()
+
+	var md testMsgDispatcher
+	app := rootHashApplication{appState, &md}
+
+	rtState := &roothash.RuntimeState{
+		Runtime: &registry.Runtime{
+			Executor: registry.ExecutorParameters{
+				MaxMessages:        32,
+				MaxMessageGas:      2000,
+				MaxRoundMessageGas: 4500,
+			},
+		},
+	}
+	msgs := []message.Message{
+		// Transfer costs 1000 gas.
+		{Staking: &message.StakingMessage{Transfer: &staking.Transfer{}}},
+		// Update runtime costs 3000 gas which is over the per-message limit.
+		{Registry: &message.RegistryMessage{UpdateRuntime: &registry.Runtime{}}},
+		// Withdraw costs 2000 gas.
+		{Staking: &message.StakingMessage{Withdraw: &staking.Withdraw{}}},
+		// Add escrow costs 2000 gas which is over the remaining per-round limit.
+		{Staking: &message.StakingMessage{AddEscrow: &staking.Escrow{}}},
+		// Transfer costs 1000 gas.
+		{Staking: &message.StakingMessage{Transfer: &staking.Transfer{}}},
+	}
+
+	results, err := app.processRuntimeMessages(ctx, rtState, msgs)
+	require.NoError(err, "processRuntimeMessages")
+	require.Len(results, len(msgs))
+
+	module, code := errors.Code(roothash.ErrMessageOutOfGas)
+	for i, tc := range []struct {
+		success bool
+		gasUsed transaction.Gas
+	}{
+		{true, 1000},
+		{false, 0},
+		{true, 2000},
+		{false, 0},
+		{true, 1000},
+	} {
+		require.EqualValues(i, results[i].Index, "message index")
+		require.Equal(tc.success, results[i].IsSuccess(), "message %d success", i)
+		require.Equal(tc.gasUsed, results[i].GasUsed, "message %d gas used", i)
+		if !tc.success {
+			require.Equal(module, results[i].Module, "message %d error module", i)
+			require.Equal(code, results[i].Code, "message %d error code", i)
+		}
+	}
+}
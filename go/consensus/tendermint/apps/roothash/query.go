@@ -15,6 +15,7 @@ type Query interface {
 	LatestBlock(context.Context, common.Namespace) (*block.Block, error)
 	GenesisBlock(context.Context, common.Namespace) (*block.Block, error)
 	RuntimeState(context.Context, common.Namespace) (*roothash.RuntimeState, error)
+	RuntimeStateWithProof(context.Context, common.Namespace) (*roothash.RuntimeStateWithProof, error)
 	LastRoundResults(context.Context, common.Namespace) (*roothash.RoundResults, error)
 	Genesis(context.Context) (*roothash.Genesis, error)
 	ConsensusParameters(context.Context) (*roothash.ConsensusParameters, error)
@@ -58,6 +59,10 @@ func (rq *rootHashQuerier) RuntimeState(ctx context.Context, id common.Namespace
 	return rq.state.RuntimeState(ctx, id)
 }
 
+func (rq *rootHashQuerier) RuntimeStateWithProof(ctx context.Context, id common.Namespace) (*roothash.RuntimeStateWithProof, error) {
+	return rq.state.RuntimeStateWithProof(ctx, id)
+}
+
 func (rq *rootHashQuerier) LastRoundResults(ctx context.Context, id common.Namespace) (*roothash.RoundResults, error) {
 	return rq.state.LastRoundResults(ctx, id)
 }
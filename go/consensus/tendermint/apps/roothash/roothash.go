@@ -14,6 +14,8 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/api"
+	registryapp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry"
 	registryApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
 	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/api"
@@ -22,6 +24,7 @@ import (
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
 	stakingapp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
@@ -45,6 +48,10 @@ func (app *rootHashApplication) ID() uint8 {
 	return AppID
 }
 
+func (app *rootHashApplication) StateVersion() uint64 {
+	return StateVersion
+}
+
 func (app *rootHashApplication) Methods() []transaction.MethodName {
 	return roothash.Methods
 }
@@ -66,6 +73,7 @@ func (app *rootHashApplication) OnRegister(state tmapi.ApplicationState, md tmap
 	md.Subscribe(registryApi.MessageRuntimeUpdated, app)
 	md.Subscribe(registryApi.MessageRuntimeResumed, app)
 	md.Subscribe(roothashApi.RuntimeMessageNoop, app)
+	md.Subscribe(governanceApi.MessageChangeRuntimePause, app)
 }
 
 func (app *rootHashApplication) OnCleanup() {
@@ -171,13 +179,18 @@ func (app *rootHashApplication) onCommitteeChanged(ctx *tmapi.Context, state *ro
 			}
 		}
 		if (empty || !sufficientStake) && !params.DebugDoNotSuspendRuntimes {
-			if err = app.suspendUnpaidRuntime(ctx, rtState, regState); err != nil {
+			reason := registry.SuspensionReasonInsufficientStake
+			if empty {
+				reason = registry.SuspensionReasonNoCommittee
+			}
+			if err = app.suspendUnpaidRuntime(ctx, rtState, regState, reason); err != nil {
 				return err
 			}
 		}
 
-		// If the committee has actually changed, force a new round.
-		if !rtState.Suspended {
+		// If the committee has actually changed, force a new round. Paused runtimes keep waiting
+		// until they are resumed.
+		if !rtState.Suspended && !rtState.Paused {
 			ctx.Logger().Debug("updating committee for runtime",
 				"runtime_id", rt.ID,
 			)
@@ -214,12 +227,14 @@ func (app *rootHashApplication) suspendUnpaidRuntime(
 	ctx *tmapi.Context,
 	rtState *roothash.RuntimeState,
 	regState *registryState.MutableState,
+	reason registry.RuntimeSuspensionReason,
 ) error {
 	ctx.Logger().Warn("maintenance fees not paid for runtime or owner debonded, suspending",
 		"runtime_id", rtState.Runtime.ID,
+		"reason", reason,
 	)
 
-	if err := regState.SuspendRuntime(ctx, rtState.Runtime.ID); err != nil {
+	if err := registryapp.SuspendRuntime(ctx, regState, rtState.Runtime.ID, reason); err != nil {
 		return err
 	}
 
@@ -236,6 +251,96 @@ func (app *rootHashApplication) suspendUnpaidRuntime(
 	return nil
 }
 
+// setRuntimePaused pauses or resumes the given runtime.
+//
+// Pausing a runtime emits an empty block signalling that the runtime is suspended and clears the
+// executor pool so that no new executor commitments are accepted. Resuming a runtime that is not
+// otherwise suspended immediately reinstates the executor committee of the current epoch.
+func (app *rootHashApplication) setRuntimePaused(
+	ctx *tmapi.Context,
+	state *roothashState.MutableState,
+	runtimeID common.Namespace,
+	paused bool,
+) error {
+	rtState, err := state.RuntimeState(ctx, runtimeID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch runtime state: %w", err)
+	}
+	if rtState.Paused == paused {
+		return fmt.Errorf("%w: runtime pause status already set to %t", roothash.ErrInvalidArgument, paused)
+	}
+
+	switch paused {
+	case true:
+		ctx.Logger().Warn("pausing runtime",
+			"runtime_id", runtimeID,
+			"round", rtState.CurrentBlock.Header.Round,
+		)
+
+		if !rtState.Suspended {
+			// Emit an empty block signalling that the runtime was suspended.
+			if err = app.emitEmptyBlock(ctx, rtState, block.Suspended); err != nil {
+				return fmt.Errorf("failed to emit empty block: %w", err)
+			}
+
+			// Make sure to only reset the executor pool after any timeouts have been cleared as
+			// otherwise the emitEmptyBlock method will forget to clear them.
+			rtState.ExecutorPool = nil
+		}
+	case false:
+		ctx.Logger().Info("resuming runtime",
+			"runtime_id", runtimeID,
+			"round", rtState.CurrentBlock.Header.Round,
+		)
+
+		if !rtState.Suspended {
+			var epoch beacon.EpochTime
+			epoch, err = app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+			if err != nil {
+				return fmt.Errorf("failed to get epoch: %w", err)
+			}
+
+			schedState := schedulerState.NewMutableState(ctx.State())
+			regState := registryState.NewMutableState(ctx.State())
+			executorPool, empty, perr := app.prepareNewCommittees(ctx, epoch, rtState, schedState, regState)
+			if perr != nil {
+				return perr
+			}
+
+			// In case there are no committees, the runtime will get suspended at the next epoch.
+			if !empty {
+				// Emit an empty epoch transition block so that the committees can start a new
+				// round.
+				if err = app.emitEmptyBlock(ctx, rtState, block.EpochTransition); err != nil {
+					return fmt.Errorf("failed to emit empty block: %w", err)
+				}
+
+				rtState.ExecutorPool = executorPool
+				rtState.ExecutorPool.Round = rtState.CurrentBlock.Header.Round
+			}
+		}
+	}
+	rtState.Paused = paused
+
+	if err = state.SetRuntimeState(ctx, rtState); err != nil {
+		return fmt.Errorf("failed to set runtime state: %w", err)
+	}
+
+	tagV := ValueRuntimePaused{
+		ID: runtimeID,
+		Event: roothash.RuntimePausedEvent{
+			Paused: paused,
+			Round:  rtState.CurrentBlock.Header.Round,
+		},
+	}
+	ctx.EmitEvent(
+		tmapi.NewEventBuilder(app.Name()).
+			Attribute(KeyRuntimePaused, cbor.Marshal(tagV)).
+			Attribute(KeyRuntimeID, ValueRuntimeID(runtimeID)),
+	)
+	return nil
+}
+
 func (app *rootHashApplication) prepareNewCommittees(
 	ctx *tmapi.Context,
 	epoch beacon.EpochTime,
@@ -303,6 +408,36 @@ func (app *rootHashApplication) emitEmptyBlock(ctx *tmapi.Context, runtime *root
 	return nil
 }
 
+// failRound emits an empty block for a failed round and updates the consecutive failed round
+// counters of the runtime.
+func (app *rootHashApplication) failRound(ctx *tmapi.Context, rtState *roothash.RuntimeState, livenessFailure bool) error {
+	hdrType := block.RoundFailed
+	rtState.FailedRounds++
+	if livenessFailure {
+		hdrType = block.LivenessFailure
+		rtState.LivenessFailures++
+	}
+
+	if err := app.emitEmptyBlock(ctx, rtState, hdrType); err != nil {
+		return fmt.Errorf("failed to emit empty block: %w", err)
+	}
+
+	tagV := ValueRoundFailed{
+		ID: rtState.Runtime.ID,
+		Event: roothash.RoundFailedEvent{
+			Round:           rtState.CurrentBlock.Header.Round,
+			LivenessFailure: livenessFailure,
+			FailedRounds:    rtState.FailedRounds,
+		},
+	}
+	ctx.EmitEvent(
+		tmapi.NewEventBuilder(app.Name()).
+			Attribute(KeyRoundFailed, cbor.Marshal(tagV)).
+			Attribute(KeyRuntimeID, ValueRuntimeID(rtState.Runtime.ID)),
+	)
+	return nil
+}
+
 func (app *rootHashApplication) ExecuteMessage(ctx *tmapi.Context, kind, msg interface{}) error {
 	switch kind {
 	case registryApi.MessageNewRuntimeRegistered:
@@ -331,6 +466,21 @@ func (app *rootHashApplication) ExecuteMessage(ctx *tmapi.Context, kind, msg int
 	case roothashApi.RuntimeMessageNoop:
 		// Noop message always succeeds.
 		return nil
+	case governanceApi.MessageChangeRuntimePause:
+		// A runtime pause change proposal has passed.
+		proposal := msg.(*governance.ChangeRuntimePauseProposal)
+
+		regState := registryState.NewMutableState(ctx.State())
+		rt, err := regState.AnyRuntime(ctx, proposal.RuntimeID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch runtime: %w", err)
+		}
+		if rt.GovernanceModel == registry.GovernanceEntity {
+			return fmt.Errorf("%w: entity-governed runtimes cannot be paused via governance", roothash.ErrForbidden)
+		}
+
+		state := roothashState.NewMutableState(ctx.State())
+		return app.setRuntimePaused(ctx, state, proposal.RuntimeID, proposal.Paused)
 	default:
 		return roothash.ErrInvalidArgument
 	}
@@ -372,6 +522,13 @@ func (app *rootHashApplication) ExecuteTx(ctx *tmapi.Context, tx *transaction.Tr
 		}
 
 		return app.submitEvidence(ctx, state, &ev)
+	case roothash.MethodSetRuntimePaused:
+		var rp roothash.SetRuntimePaused
+		if err := cbor.Unmarshal(tx.Body, &rp); err != nil {
+			return err
+		}
+
+		return app.setRuntimePausedTx(ctx, state, &rp)
 	default:
 		return roothash.ErrInvalidArgument
 	}
@@ -406,12 +563,14 @@ func (app *rootHashApplication) onNewRuntime(ctx *tmapi.Context, runtime *regist
 	// Fill the Header fields with Genesis runtime states, if this was called during InitChain().
 	genesisBlock.Header.Round = runtime.Genesis.Round
 	genesisBlock.Header.StateRoot = runtime.Genesis.StateRoot
+	var paused bool
 	if ctx.IsInitChain() {
 		// NOTE: Outside InitChain the genesis argument will be nil.
 		if genesisRts := genesis.RuntimeStates[runtime.ID]; genesisRts != nil {
 			genesisBlock.Header.Round = genesisRts.Round
 			genesisBlock.Header.StateRoot = genesisRts.StateRoot
-			if suspended {
+			paused = genesisRts.Paused
+			if suspended || paused {
 				genesisBlock.Header.HeaderType = block.Suspended
 			}
 
@@ -427,6 +586,7 @@ func (app *rootHashApplication) onNewRuntime(ctx *tmapi.Context, runtime *regist
 	// Create new state containing the genesis block.
 	err = state.SetRuntimeState(ctx, &roothash.RuntimeState{
 		Runtime:            runtime,
+		Paused:             paused,
 		CurrentBlock:       genesisBlock,
 		CurrentBlockHeight: ctx.BlockHeight() + 1, // Current height is ctx.BlockHeight() + 1
 		LastNormalRound:    genesisBlock.Header.Round,
@@ -525,7 +685,14 @@ func (app *rootHashApplication) tryFinalizeExecutorCommits(
 	round := rtState.CurrentBlock.Header.Round + 1
 	pool := rtState.ExecutorPool
 
-	commit, err := pool.TryFinalize(ctx.BlockHeight(), runtime.Executor.RoundTimeout, forced, true)
+	state := roothashState.NewMutableState(ctx.State())
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get consensus parameters: %w", err)
+	}
+	roundTimeout := rtState.RoundTimeout(params)
+
+	commit, err := pool.TryFinalize(ctx.BlockHeight(), roundTimeout, forced, true)
 	if err == commitment.ErrDiscrepancyDetected {
 		ctx.Logger().Warn("executor discrepancy detected",
 			"round", round,
@@ -547,10 +714,17 @@ func (app *rootHashApplication) tryFinalizeExecutorCommits(
 		// We may also be able to already perform discrepancy resolution, check if this is possible
 		// by retrying finalization. We must make sure to not affect the computed timeout.
 		nextTimeout := pool.NextTimeout
-		commit, err = pool.TryFinalize(ctx.BlockHeight(), runtime.Executor.RoundTimeout, false, false)
+		commit, err = pool.TryFinalize(ctx.BlockHeight(), roundTimeout, false, false)
 		pool.NextTimeout = nextTimeout
 	}
 
+	// Not receiving enough commitments before the round timeout is a liveness failure.
+	var livenessFailure bool
+	switch err {
+	case commitment.ErrNoProposerCommitment, commitment.ErrInsufficientVotes:
+		livenessFailure = forced
+	}
+
 	switch err {
 	case nil:
 		// Round has been finalized.
@@ -645,15 +819,18 @@ func (app *rootHashApplication) tryFinalizeExecutorCommits(
 		rtState.LastNormalHeight = ctx.BlockHeight() + 1
 
 		// Set last normal round results.
-		state := roothashState.NewMutableState(ctx.State())
 		err = state.SetLastRoundResults(ctx, rtState.Runtime.ID, &roothash.RoundResults{
 			Messages:            messageResults,
 			GoodComputeEntities: goodComputeEntities,
 			BadComputeEntities:  badComputeEntities,
+			FailedRounds:        rtState.FailedRounds,
+			LivenessFailures:    rtState.LivenessFailures,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to set last round results: %w", err)
 		}
+		rtState.FailedRounds = 0
+		rtState.LivenessFailures = 0
 
 		tagV := ValueFinalized{
 			ID: rtState.Runtime.ID,
@@ -667,6 +844,18 @@ func (app *rootHashApplication) tryFinalizeExecutorCommits(
 				Attribute(KeyRuntimeID, ValueRuntimeID(rtState.Runtime.ID)),
 		)
 
+		// Emit message results so that runtime authors can inspect failed messages.
+		for _, result := range messageResults {
+			ctx.EmitEvent(
+				tmapi.NewEventBuilder(app.Name()).
+					Attribute(KeyMessage, cbor.Marshal(&ValueMessage{
+						ID:    rtState.Runtime.ID,
+						Event: *result,
+					})).
+					Attribute(KeyRuntimeID, ValueRuntimeID(rtState.Runtime.ID)),
+			)
+		}
+
 		return nil
 	case commitment.ErrStillWaiting:
 		// Need more commits.
@@ -685,14 +874,11 @@ func (app *rootHashApplication) tryFinalizeExecutorCommits(
 	ctx.Logger().Error("round failed",
 		"round", round,
 		"err", err,
+		"liveness_failure", livenessFailure,
 		logging.LogEvent, roothash.LogEventRoundFailed,
 	)
 
-	if err := app.emitEmptyBlock(ctx, rtState, block.RoundFailed); err != nil {
-		return fmt.Errorf("failed to emit empty block: %w", err)
-	}
-
-	return nil
+	return app.failRound(ctx, rtState, livenessFailure)
 }
 
 func (app *rootHashApplication) tryFinalizeBlock(
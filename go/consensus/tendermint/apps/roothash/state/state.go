@@ -8,9 +8,11 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/proof"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 )
 
 var (
@@ -120,6 +122,41 @@ func (s *ImmutableState) RuntimeState(ctx context.Context, id common.Namespace)
 	return &state, nil
 }
 
+// RuntimeStateWithProof returns the roothash runtime state for a specific runtime together with
+// a proof of the runtime state against the consensus state root.
+func (s *ImmutableState) RuntimeStateWithProof(ctx context.Context, id common.Namespace) (*roothash.RuntimeStateWithProof, error) {
+	state, err := s.RuntimeState(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.is.GetProof(ctx, runtimeKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, err
+	}
+	return &roothash.RuntimeStateWithProof{
+		RuntimeState: state,
+		Proof:        p,
+	}, nil
+}
+
+// VerifyRuntimeStateProof verifies the runtime state proof for the given runtime against a trusted
+// consensus state root and returns the proven runtime state.
+func VerifyRuntimeStateProof(ctx context.Context, root node.Root, id common.Namespace, p *proof.StateProof) (*roothash.RuntimeState, error) {
+	raw, err := p.Verify(ctx, root, runtimeKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, roothash.ErrInvalidRuntime
+	}
+
+	var state roothash.RuntimeState
+	if err = cbor.Unmarshal(raw, &state); err != nil {
+		return nil, fmt.Errorf("tendermint/roothash: malformed runtime state: %w", err)
+	}
+	return &state, nil
+}
+
 // LastRoundResults returns the last normal round results for a specific runtime.
 func (s *ImmutableState) LastRoundResults(ctx context.Context, id common.Namespace) (*roothash.RoundResults, error) {
 	raw, err := s.is.Get(ctx, lastRoundResultsKeyFmt.Encode(&id))
@@ -309,3 +346,17 @@ func (s *MutableState) RemoveExpiredEvidence(ctx context.Context, runtimeID comm
 
 	return nil
 }
+
+func init() {
+	api.RegisterStateKeyFormats(
+		roothash.ModuleName,
+		runtimeKeyFmt,
+		parametersKeyFmt,
+		roundTimeoutQueueKeyFmt,
+		deprecatedRejectTransactionsKeyFmt,
+		evidenceKeyFmt,
+		stateRootKeyFmt,
+		ioRootKeyFmt,
+		lastRoundResultsKeyFmt,
+	)
+}
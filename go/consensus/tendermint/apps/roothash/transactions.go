@@ -10,8 +10,8 @@ import (
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
 	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
-	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
@@ -32,6 +32,9 @@ func (app *rootHashApplication) getRuntimeState(
 	if rtState.Suspended {
 		return nil, nil, roothash.ErrRuntimeSuspended
 	}
+	if rtState.Paused {
+		return nil, nil, roothash.ErrRuntimePaused
+	}
 	if rtState.ExecutorPool == nil {
 		return nil, nil, roothash.ErrNoExecutorPool
 	}
@@ -101,8 +104,8 @@ func (app *rootHashApplication) executorProposerTimeout(
 		"err", err,
 		logging.LogEvent, roothash.LogEventRoundFailed,
 	)
-	if err = app.emitEmptyBlock(ctx, rtState, block.RoundFailed); err != nil {
-		return fmt.Errorf("failed to emit empty block: %w", err)
+	if err = app.failRound(ctx, rtState, true); err != nil {
+		return err
 	}
 
 	// Update runtime state.
@@ -319,3 +322,64 @@ func (app *rootHashApplication) submitEvidence(
 
 	return nil
 }
+
+func (app *rootHashApplication) setRuntimePausedTx(
+	ctx *abciAPI.Context,
+	state *roothashState.MutableState,
+	rp *roothash.SetRuntimePaused,
+) error {
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("SetRuntimePaused: failed to fetch consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if err = ctx.Gas().UseGas(1, roothash.GasOpSetRuntimePaused, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early for simulation as we only need gas accounting.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	// Only the owner of an entity-governed runtime may pause or resume it.
+	regState := registryState.NewMutableState(ctx.State())
+	rt, err := regState.AnyRuntime(ctx, rp.ID)
+	if err != nil {
+		return fmt.Errorf("roothash: failed to fetch runtime: %w", err)
+	}
+	if rt.GovernanceModel != registry.GovernanceEntity {
+		ctx.Logger().Error("SetRuntimePaused: runtime is not governed by an entity",
+			"runtime_id", rt.ID,
+			"governance_model", rt.GovernanceModel,
+		)
+		return roothash.ErrForbidden
+	}
+	if !rt.EntityID.Equal(ctx.TxSigner()) {
+		ctx.Logger().Error("SetRuntimePaused: transaction not signed by the runtime owner",
+			"runtime_id", rt.ID,
+			"entity", rt.EntityID,
+			"signer", ctx.TxSigner(),
+		)
+		return roothash.ErrForbidden
+	}
+	ent, err := regState.Entity(ctx, rt.EntityID)
+	if err != nil {
+		return fmt.Errorf("roothash: failed to fetch runtime owner: %w", err)
+	}
+	if !ent.CanSignRuntimes() {
+		ctx.Logger().Error("SetRuntimePaused: entity key usage does not allow runtimes",
+			"entity", rt.EntityID,
+		)
+		return roothash.ErrForbidden
+	}
+
+	return app.setRuntimePaused(ctx, state, rp.ID, rp.Paused)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
@@ -40,9 +41,12 @@ func (nd *testMsgDispatcher) Subscribe(interface{}, abciAPI.MessageSubscriber) {
 
 // Implements MessageDispatcher.
 func (nd *testMsgDispatcher) Publish(ctx *abciAPI.Context, kind, msg interface{}) error {
-	// Either we need to be in simulation mode or the gas accountant must be a no-op one.
-	if !ctx.IsSimulation() && ctx.Gas() != abciAPI.NewNopGasAccountant() {
-		panic("gas estimation should always use simulation mode")
+	// Either we need to be in simulation mode or the message gas accountant must not charge gas
+	// to anything other than a no-op gas accountant.
+	if !ctx.IsSimulation() {
+		if ga, ok := ctx.Gas().(*messageGasAccountant); !ok || ga.parent != abciAPI.NewNopGasAccountant() {
+			panic("gas estimation should always use simulation mode")
+		}
 	}
 
 	gasCosts := transaction.Costs{
@@ -610,3 +614,113 @@ func TestEvidence(t *testing.T) {
 	require.NoError(err, "Account()")
 	require.EqualValues(entityEscrow, &entAcc.Escrow.Active.Balance, "entity was slashed expected amount")
 }
+
+func TestSetRuntimePaused(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	genesisTestHelpers.SetTestChainContext()
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	var md testMsgDispatcher
+	app := rootHashApplication{appState, &md}
+
+	// Initialize registry state with an entity-governed runtime.
+	regState := registryState.NewMutableState(ctx.State())
+	ent, entitySigner, _ := entity.TestEntity()
+	sigEntity, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, ent)
+	require.NoError(err, "SignEntity")
+	err = regState.SetEntity(ctx, ent, sigEntity)
+	require.NoError(err, "SetEntity")
+
+	runtime := registry.Runtime{
+		EntityID:        ent.ID,
+		Kind:            registry.KindCompute,
+		GovernanceModel: registry.GovernanceEntity,
+		Executor: registry.ExecutorParameters{
+			MaxMessages: 32,
+		},
+	}
+	err = regState.SetRuntime(ctx, &runtime, false)
+	require.NoError(err, "SetRuntime")
+
+	// Initialize scheduler state.
+	sk, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+	schedulerState := schedulerState.NewMutableState(ctx.State())
+	executorCommittee := scheduler.Committee{
+		RuntimeID: runtime.ID,
+		Kind:      scheduler.KindComputeExecutor,
+		Members: []*scheduler.CommitteeNode{
+			{
+				Role:      scheduler.RoleWorker,
+				PublicKey: sk.Public(),
+			},
+		},
+	}
+	err = schedulerState.PutCommittee(ctx, &executorCommittee)
+	require.NoError(err, "PutCommittee")
+
+	// Initialize roothash state.
+	roothashState := roothashState.NewMutableState(ctx.State())
+	err = roothashState.SetConsensusParameters(ctx, &roothash.ConsensusParameters{
+		MaxRuntimeMessages: 32,
+	})
+	require.NoError(err, "SetConsensusParameters")
+	blk := block.NewGenesisBlock(runtime.ID, 0)
+	err = roothashState.SetRuntimeState(ctx, &roothash.RuntimeState{
+		Runtime:            &runtime,
+		GenesisBlock:       blk,
+		CurrentBlock:       blk,
+		CurrentBlockHeight: 1,
+		LastNormalRound:    0,
+		LastNormalHeight:   1,
+		ExecutorPool: &commitment.Pool{
+			Runtime:   &runtime,
+			Committee: &executorCommittee,
+		},
+	})
+	require.NoError(err, "SetRuntimeState")
+
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer txCtx.Close()
+	txCtx.SetGasAccountant(abciAPI.NewGasAccountant(transaction.Gas(math.MaxUint64)))
+
+	// Only the runtime owner should be able to pause the runtime.
+	txCtx.SetTxSigner(sk.Public())
+	err = app.setRuntimePausedTx(txCtx, roothashState, &roothash.SetRuntimePaused{ID: runtime.ID, Paused: true})
+	require.ErrorIs(err, roothash.ErrForbidden, "SetRuntimePaused should fail for non-owners")
+
+	txCtx.SetTxSigner(ent.ID)
+	err = app.setRuntimePausedTx(txCtx, roothashState, &roothash.SetRuntimePaused{ID: runtime.ID, Paused: true})
+	require.NoError(err, "SetRuntimePaused")
+
+	rtState, err := roothashState.RuntimeState(txCtx, runtime.ID)
+	require.NoError(err, "RuntimeState")
+	require.True(rtState.Paused, "runtime should be paused")
+	require.Nil(rtState.ExecutorPool, "executor pool should be cleared")
+	require.EqualValues(block.Suspended, rtState.CurrentBlock.Header.HeaderType)
+
+	// Pausing an already paused runtime should fail.
+	err = app.setRuntimePausedTx(txCtx, roothashState, &roothash.SetRuntimePaused{ID: runtime.ID, Paused: true})
+	require.ErrorIs(err, roothash.ErrInvalidArgument, "SetRuntimePaused should fail for paused runtimes")
+
+	// Executor commitments should be rejected.
+	err = app.executorCommit(txCtx, roothashState, &roothash.ExecutorCommit{ID: runtime.ID})
+	require.ErrorIs(err, roothash.ErrRuntimePaused, "ExecutorCommit should fail for paused runtimes")
+
+	// Resuming the runtime should reinstate the executor committee.
+	err = app.setRuntimePausedTx(txCtx, roothashState, &roothash.SetRuntimePaused{ID: runtime.ID, Paused: false})
+	require.NoError(err, "SetRuntimePaused")
+
+	rtState, err = roothashState.RuntimeState(txCtx, runtime.ID)
+	require.NoError(err, "RuntimeState")
+	require.False(rtState.Paused, "runtime should be resumed")
+	require.NotNil(rtState.ExecutorPool, "executor pool should be set")
+	require.EqualValues(block.EpochTransition, rtState.CurrentBlock.Header.HeaderType)
+	require.EqualValues(rtState.CurrentBlock.Header.Round, rtState.ExecutorPool.Round)
+}
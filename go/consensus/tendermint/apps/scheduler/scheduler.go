@@ -45,6 +45,9 @@ var (
 
 type schedulerApplication struct {
 	state api.ApplicationState
+
+	// trace is the optional election trace used by simulated elections.
+	trace *electionTrace
 }
 
 func (app *schedulerApplication) Name() string {
@@ -55,6 +58,10 @@ func (app *schedulerApplication) ID() uint8 {
 	return AppID
 }
 
+func (app *schedulerApplication) StateVersion() uint64 {
+	return StateVersion
+}
+
 func (app *schedulerApplication) Methods() []transaction.MethodName {
 	return nil
 }
@@ -97,136 +104,153 @@ func (app *schedulerApplication) BeginBlock(ctx *api.Context, request types.Requ
 			return nil
 		}
 
-		state := schedulerState.NewMutableState(ctx.State())
-		params, err := state.ConsensusParameters(ctx)
-		if err != nil {
-			ctx.Logger().Error("failed to fetch consensus parameters",
-				"err", err,
-			)
-			return err
-		}
+		return app.elect(ctx, epoch, epochChanged)
+	}
+	return nil
+}
+
+// elect performs all elections for the given epoch.
+func (app *schedulerApplication) elect(ctx *api.Context, epoch beacon.EpochTime, epochChanged bool) error {
+	state := schedulerState.NewMutableState(ctx.State())
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("failed to fetch consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+
+	beaconState := beaconState.NewMutableState(ctx.State())
+	beaconParameters, err := beaconState.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("tendermint/scheduler: couldn't get beacon parameters: %w", err)
+	}
+	// If weak alphas are allowed then skip the eligibility check as
+	// well because the byzantine node and associated tests are extremely
+	// fragile, and breaks in hard-to-debug ways if timekeeping isn't
+	// exactly how it expects.
+	filterCommitteeNodes := beaconParameters.Backend == beacon.BackendVRF && !params.DebugAllowWeakAlpha
+	if app.trace != nil && beaconParameters.Backend == beacon.BackendVRF {
+		// Simulated elections have no VRF proofs available, so they always
+		// use the per-epoch entropy instead.
+		simParameters := *beaconParameters
+		simParameters.Backend = beacon.BackendInsecure
+		beaconParameters = &simParameters
+	}
+
+	regState := registryState.NewMutableState(ctx.State())
+	runtimes, err := regState.Runtimes(ctx)
+	if err != nil {
+		return fmt.Errorf("tendermint/scheduler: couldn't get runtimes: %w", err)
+	}
+	allNodes, err := regState.Nodes(ctx)
+	if err != nil {
+		return fmt.Errorf("tendermint/scheduler: couldn't get nodes: %w", err)
+	}
 
-		beaconState := beaconState.NewMutableState(ctx.State())
-		beaconParameters, err := beaconState.ConsensusParameters(ctx)
+	// Filter nodes.
+	var nodes, committeeNodes []*node.Node
+	for _, node := range allNodes {
+		var status *registry.NodeStatus
+		status, err = regState.NodeStatus(ctx, node.ID)
 		if err != nil {
-			return fmt.Errorf("tendermint/scheduler: couldn't get beacon parameters: %w", err)
+			return fmt.Errorf("tendermint/scheduler: couldn't get node status: %w", err)
 		}
-		// If weak alphas are allowed then skip the eligibility check as
-		// well because the byzantine node and associated tests are extremely
-		// fragile, and breaks in hard-to-debug ways if timekeeping isn't
-		// exactly how it expects.
-		filterCommitteeNodes := beaconParameters.Backend == beacon.BackendVRF && !params.DebugAllowWeakAlpha
-
-		regState := registryState.NewMutableState(ctx.State())
-		runtimes, err := regState.Runtimes(ctx)
-		if err != nil {
-			return fmt.Errorf("tendermint/scheduler: couldn't get runtimes: %w", err)
+
+		// Nodes which are currently frozen cannot be scheduled.
+		if status.IsFrozen() {
+			app.trace.filter(node, "", FilterFrozen)
+			continue
 		}
-		allNodes, err := regState.Nodes(ctx)
-		if err != nil {
-			return fmt.Errorf("tendermint/scheduler: couldn't get nodes: %w", err)
+		// Expired nodes cannot be scheduled (nodes can be expired and not yet removed).
+		if node.IsExpired(uint64(epoch)) {
+			app.trace.filter(node, "", FilterExpired)
+			continue
 		}
 
-		// Filter nodes.
-		var nodes, committeeNodes []*node.Node
-		for _, node := range allNodes {
-			var status *registry.NodeStatus
-			status, err = regState.NodeStatus(ctx, node.ID)
-			if err != nil {
-				return fmt.Errorf("tendermint/scheduler: couldn't get node status: %w", err)
-			}
-
-			// Nodes which are currently frozen cannot be scheduled.
-			if status.IsFrozen() {
-				continue
-			}
-			// Expired nodes cannot be scheduled (nodes can be expired and not yet removed).
-			if node.IsExpired(uint64(epoch)) {
-				continue
-			}
-
-			nodes = append(nodes, node)
-			if !filterCommitteeNodes || (status.ElectionEligibleAfter != beacon.EpochInvalid && epoch > status.ElectionEligibleAfter) {
-				committeeNodes = append(committeeNodes, node)
-			}
+		nodes = append(nodes, node)
+		if !filterCommitteeNodes || (status.ElectionEligibleAfter != beacon.EpochInvalid && epoch > status.ElectionEligibleAfter) {
+			committeeNodes = append(committeeNodes, node)
+		} else {
+			app.trace.filter(node, "", FilterNotEligibleYet)
 		}
+	}
 
-		var stakeAcc *stakingState.StakeAccumulatorCache
-		if !params.DebugBypassStake {
-			stakeAcc, err = stakingState.NewStakeAccumulatorCache(ctx)
-			if err != nil {
-				return fmt.Errorf("tendermint/scheduler: failed to create stake accumulator cache: %w", err)
-			}
-			defer stakeAcc.Discard()
+	var stakeAcc *stakingState.StakeAccumulatorCache
+	if !params.DebugBypassStake {
+		stakeAcc, err = stakingState.NewStakeAccumulatorCache(ctx)
+		if err != nil {
+			return fmt.Errorf("tendermint/scheduler: failed to create stake accumulator cache: %w", err)
 		}
+		defer stakeAcc.Discard()
+	}
 
-		var entitiesEligibleForReward map[staking.Address]bool
-		if epochChanged {
-			// For elections on epoch changes, distribute rewards to entities with any eligible nodes.
-			entitiesEligibleForReward = make(map[staking.Address]bool)
-		}
+	var entitiesEligibleForReward map[staking.Address]bool
+	if epochChanged {
+		// For elections on epoch changes, distribute rewards to entities with any eligible nodes.
+		entitiesEligibleForReward = make(map[staking.Address]bool)
+	}
+
+	// Handle the validator election first, because no consensus is
+	// catastrophic, while failing to elect other committees is not.
+	var validatorEntities map[staking.Address]bool
+	if validatorEntities, err = app.electValidators(
+		ctx,
+		app.state,
+		beaconState,
+		beaconParameters,
+		stakeAcc,
+		entitiesEligibleForReward,
+		nodes,
+		params,
+	); err != nil {
+		// It is unclear what the behavior should be if the validator
+		// election fails.  The system can not ensure integrity, so
+		// presumably manual intervention is required...
+		return fmt.Errorf("tendermint/scheduler: couldn't elect validators: %w", err)
+	}
 
-		// Handle the validator election first, because no consensus is
-		// catastrophic, while failing to elect other committees is not.
-		var validatorEntities map[staking.Address]bool
-		if validatorEntities, err = app.electValidators(
+	kinds := []scheduler.CommitteeKind{
+		scheduler.KindComputeExecutor,
+	}
+	for _, kind := range kinds {
+		if err = app.electAllCommittees(
 			ctx,
 			app.state,
+			params,
 			beaconState,
 			beaconParameters,
 			stakeAcc,
 			entitiesEligibleForReward,
-			nodes,
-			params,
+			validatorEntities,
+			runtimes,
+			committeeNodes,
+			kind,
 		); err != nil {
-			// It is unclear what the behavior should be if the validator
-			// election fails.  The system can not ensure integrity, so
-			// presumably manual intervention is required...
-			return fmt.Errorf("tendermint/scheduler: couldn't elect validators: %w", err)
-		}
-
-		kinds := []scheduler.CommitteeKind{
-			scheduler.KindComputeExecutor,
-		}
-		for _, kind := range kinds {
-			if err = app.electAllCommittees(
-				ctx,
-				app.state,
-				params,
-				beaconState,
-				beaconParameters,
-				stakeAcc,
-				entitiesEligibleForReward,
-				validatorEntities,
-				runtimes,
-				committeeNodes,
-				kind,
-			); err != nil {
-				return fmt.Errorf("tendermint/scheduler: couldn't elect %s committees: %w", kind, err)
-			}
-		}
-		ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyElected, cbor.Marshal(kinds)))
-
-		var kindNames []string
-		for _, kind := range kinds {
-			kindNames = append(kindNames, kind.String())
+			return fmt.Errorf("tendermint/scheduler: couldn't elect %s committees: %w", kind, err)
 		}
-		var runtimeIDs []string
-		for _, rt := range runtimes {
-			runtimeIDs = append(runtimeIDs, rt.ID.String())
-		}
-		ctx.Logger().Debug("finished electing committees",
-			"epoch", epoch,
-			"kinds", kindNames,
-			"runtimes", runtimeIDs,
-		)
+	}
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyElected, cbor.Marshal(kinds)))
 
-		if entitiesEligibleForReward != nil {
-			accountAddrs := stakingAddressMapToSortedSlice(entitiesEligibleForReward)
-			stakingSt := stakingState.NewMutableState(ctx.State())
-			if err = stakingSt.AddRewards(ctx, epoch, &params.RewardFactorEpochElectionAny, accountAddrs); err != nil {
-				return fmt.Errorf("tendermint/scheduler: failed to add rewards: %w", err)
-			}
+	var kindNames []string
+	for _, kind := range kinds {
+		kindNames = append(kindNames, kind.String())
+	}
+	var runtimeIDs []string
+	for _, rt := range runtimes {
+		runtimeIDs = append(runtimeIDs, rt.ID.String())
+	}
+	ctx.Logger().Debug("finished electing committees",
+		"epoch", epoch,
+		"kinds", kindNames,
+		"runtimes", runtimeIDs,
+	)
+
+	if entitiesEligibleForReward != nil {
+		accountAddrs := stakingAddressMapToSortedSlice(entitiesEligibleForReward)
+		stakingSt := stakingState.NewMutableState(ctx.State())
+		if err = stakingSt.AddRewards(ctx, epoch, &params.RewardFactorEpochElectionAny, accountAddrs); err != nil {
+			return fmt.Errorf("tendermint/scheduler: failed to add rewards: %w", err)
 		}
 	}
 	return nil
@@ -308,29 +332,31 @@ func (app *schedulerApplication) EndBlock(ctx *api.Context, req types.RequestEnd
 	return resp, nil
 }
 
-func (app *schedulerApplication) isSuitableExecutorWorker(ctx *api.Context, n *node.Node, rt *registry.Runtime) bool {
+// checkExecutorWorker checks whether the node is suitable to be an executor
+// worker for the given runtime and returns the reason in case it is not.
+func (app *schedulerApplication) checkExecutorWorker(ctx *api.Context, n *node.Node, rt *registry.Runtime) FilterReason {
 	if !n.HasRoles(node.RoleComputeWorker) {
-		return false
+		return FilterRoleMismatch
 	}
 	for _, nrt := range n.Runtimes {
 		if !nrt.ID.Equal(&rt.ID) {
 			continue
 		}
 		if nrt.Version.MaskNonMajor() != rt.Version.Version.MaskNonMajor() {
-			return false
+			return FilterVersionMismatch
 		}
 		switch rt.TEEHardware {
 		case node.TEEHardwareInvalid:
 			if nrt.Capabilities.TEE != nil {
-				return false
+				return FilterTEEMismatch
 			}
-			return true
+			return ""
 		default:
 			if nrt.Capabilities.TEE == nil {
-				return false
+				return FilterTEEMismatch
 			}
 			if nrt.Capabilities.TEE.Hardware != rt.TEEHardware {
-				return false
+				return FilterTEEMismatch
 			}
 			if err := nrt.Capabilities.TEE.Verify(ctx.Now(), rt.Version.TEE); err != nil {
 				ctx.Logger().Warn("failed to verify node TEE attestaion",
@@ -339,12 +365,12 @@ func (app *schedulerApplication) isSuitableExecutorWorker(ctx *api.Context, n *n
 					"time_stamp", ctx.Now(),
 					"runtime", rt.ID,
 				)
-				return false
+				return FilterTEEMismatch
 			}
-			return true
+			return ""
 		}
 	}
-	return false
+	return FilterRuntimeMismatch
 }
 
 // GetPerm generates a permutation that we use to choose nodes from a list of eligible nodes to elect.
@@ -407,11 +433,13 @@ func (app *schedulerApplication) electValidators(
 	entities := make(map[staking.Address]bool)
 	for _, n := range nodes {
 		if !n.HasRoles(node.RoleValidator) {
+			app.trace.filter(n, electionValidators, FilterRoleMismatch)
 			continue
 		}
 		entAddr := staking.NewAddress(n.EntityID)
 		if stakeAcc != nil {
 			if err := stakeAcc.CheckStakeClaims(entAddr); err != nil {
+				app.trace.filter(n, electionValidators, FilterInsufficientStake)
 				continue
 			}
 		}
@@ -499,6 +527,11 @@ electLoop:
 		}
 	}
 
+	app.trace.notElected(electionValidators, nodeList, func(n *node.Node) bool {
+		_, ok := newValidators[n.Consensus.ID]
+		return ok
+	}, params.MaxValidatorsPerEntity)
+
 	if len(newValidators) == 0 {
 		return nil, fmt.Errorf("tendermint/scheduler: failed to elect any validators")
 	}
@@ -178,6 +178,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 					"kind", kind,
 					"runtime_id", rt.ID,
 				)
+				app.trace.drop(kind, rt.ID, "epoch had weak VRF alpha")
 				if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
 					return fmt.Errorf("tendermint/scheduler: failed to drop committee: %w", err)
 				}
@@ -194,11 +195,11 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 	// Determine the committee size, and pre-filter the node-list based
 	// on eligibility, entity stake and other criteria.
 
-	var isSuitableFn func(*api.Context, *node.Node, *registry.Runtime) bool
+	var checkSuitableFn func(*api.Context, *node.Node, *registry.Runtime) FilterReason
 	groupSizes := make(map[scheduler.Role]int)
 	switch kind {
 	case scheduler.KindComputeExecutor:
-		isSuitableFn = app.isSuitableExecutorWorker
+		checkSuitableFn = app.checkExecutorWorker
 		groupSizes[scheduler.RoleWorker] = int(rt.Executor.GroupSize)
 		groupSizes[scheduler.RoleBackupWorker] = int(rt.Executor.GroupBackupSize)
 	default:
@@ -211,6 +212,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 			"kind", kind,
 			"runtime_id", rt.ID,
 		)
+		app.trace.drop(kind, rt.ID, "empty committee not allowed")
 		if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
 			return fmt.Errorf("tendermint/scheduler: failed to drop committee: %w", err)
 		}
@@ -221,17 +223,20 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 	cs := rt.Constraints[kind]
 
 	// Perform pre-election eligiblity filtering.
+	election := committeeElection(kind, rt.ID, scheduler.RoleInvalid)
 	nodeLists := make(map[scheduler.Role][]*node.Node)
 	for _, n := range nodeList {
 		// Check if an entity has enough stake.
 		entAddr := staking.NewAddress(n.EntityID)
 		if stakeAcc != nil {
 			if err = stakeAcc.CheckStakeClaims(entAddr); err != nil {
+				app.trace.filter(n, election, FilterInsufficientStake)
 				continue
 			}
 		}
 		// Check general node compatibility.
-		if !isSuitableFn(ctx, n, rt) {
+		if reason := checkSuitableFn(ctx, n, rt); reason != "" {
+			app.trace.filter(n, election, reason)
 			continue
 		}
 
@@ -254,6 +259,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 					"runtime_id", rt.ID,
 					"id", n.ID,
 				)
+				app.trace.filter(n, election, FilterNoVRFProof)
 				continue
 			}
 		}
@@ -269,6 +275,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 			if cs[role].ValidatorSet != nil {
 				if !validatorEntities[entAddr] {
 					// Not eligible if not in the validator set.
+					app.trace.filter(n, committeeElection(kind, rt.ID, role), FilterNotValidator)
 					continue
 				}
 			}
@@ -308,6 +315,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 				"nr_nodes", nrNodes,
 				"min_pool_size", minPoolSize,
 			)
+			app.trace.drop(kind, rt.ID, fmt.Sprintf("not enough eligible %s nodes (%d < %d)", role, nrNodes, minPoolSize))
 			if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
 				return fmt.Errorf("tendermint/scheduler: failed to drop committee: %w", err)
 			}
@@ -322,6 +330,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 				"wanted_nodes", wantedNodes,
 				"nr_nodes", nrNodes,
 			)
+			app.trace.drop(kind, rt.ID, fmt.Sprintf("committee size exceeds available %s nodes (%d > %d)", role, wantedNodes, nrNodes))
 			if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
 				return fmt.Errorf("tendermint/scheduler: failed to drop committee: %w", err)
 			}
@@ -429,6 +438,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 					"nr_nodes", nrNodes,
 					"mandatory_nodes", len(toForce),
 				)
+				app.trace.drop(kind, rt.ID, "available nodes can't fulfill forced committee members")
 				if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
 					return fmt.Errorf("tendermint/scheduler: failed to drop committee: %w", err)
 				}
@@ -449,6 +459,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 			// Check election-time scheduling constraints.
 			if mn := cs[role].MaxNodes; mn != nil {
 				if nodesPerEntity[n.EntityID] >= int(mn.Limit) {
+					app.trace.filter(n, committeeElection(kind, rt.ID, role), FilterEntityLimit)
 					continue
 				}
 				nodesPerEntity[n.EntityID]++
@@ -467,6 +478,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 				"runtime_id", rt.ID,
 				"available", len(elected),
 			)
+			app.trace.drop(kind, rt.ID, fmt.Sprintf("insufficient %s nodes that satisfy constraints to elect", role))
 			if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
 				return fmt.Errorf("tendermint/scheduler: failed to drop committee: %w", err)
 			}
@@ -489,6 +501,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 								"existing", mustBeScheduler.PublicKey,
 								"new", n.PublicKey,
 							)
+							app.trace.drop(kind, rt.ID, "already have a forced scheduler")
 							if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
 								return fmt.Errorf("tendermint/scheduler: failed to drop committee: %w", err)
 							}
@@ -506,6 +519,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 			if mustBeScheduler == nil {
 				if len(mayBeAny) == 0 && len(mustNotBeScheduler) > 0 {
 					ctx.Logger().Error("can't fulfil not committee scheduler requirements")
+					app.trace.drop(kind, rt.ID, "can't fulfil not committee scheduler requirements")
 					if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
 						return fmt.Errorf("tendermint/scheduler: failed to drop committee: %w", err)
 					}
@@ -521,6 +535,15 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 		}
 
 		members = append(members, elected...)
+
+		app.trace.notElected(committeeElection(kind, rt.ID, role), nodeLists[role], func(n *node.Node) bool {
+			for _, cn := range elected {
+				if cn.PublicKey.Equal(n.ID) {
+					return true
+				}
+			}
+			return false
+		}, 0)
 	}
 
 	committee := &scheduler.Committee{
@@ -0,0 +1,233 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/beacon/state"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+// electionValidators is the name of the validator election in election traces.
+const electionValidators = "validators"
+
+// FilterReason is the reason why a node was not elected.
+type FilterReason string
+
+const (
+	// FilterFrozen means that the node is frozen.
+	FilterFrozen = FilterReason("frozen")
+	// FilterExpired means that the node registration has expired.
+	FilterExpired = FilterReason("expired")
+	// FilterNotEligibleYet means that the node is not yet eligible for committee elections.
+	FilterNotEligibleYet = FilterReason("not yet eligible for committee elections")
+	// FilterInsufficientStake means that the node's entity does not have enough stake.
+	FilterInsufficientStake = FilterReason("insufficient stake")
+	// FilterRoleMismatch means that the node does not have the role required by the election.
+	FilterRoleMismatch = FilterReason("missing required role")
+	// FilterRuntimeMismatch means that the node does not support the runtime.
+	FilterRuntimeMismatch = FilterReason("runtime not supported")
+	// FilterVersionMismatch means that the node runs an incompatible runtime version.
+	FilterVersionMismatch = FilterReason("incompatible runtime version")
+	// FilterTEEMismatch means that the node's TEE capability does not satisfy the runtime.
+	FilterTEEMismatch = FilterReason("TEE capability mismatch")
+	// FilterNoVRFProof means that the node did not submit a VRF proof.
+	FilterNoVRFProof = FilterReason("no VRF proof")
+	// FilterNotValidator means that the node's entity is not in the validator set.
+	FilterNotValidator = FilterReason("entity not in validator set")
+	// FilterEntityLimit means that the entity already has the maximum number of nodes elected.
+	FilterEntityLimit = FilterReason("entity node limit reached")
+	// FilterNotSelected means that the node was eligible but was not selected.
+	FilterNotSelected = FilterReason("not selected")
+)
+
+// FilteredNode is a node that was not elected in a simulated election.
+type FilteredNode struct {
+	// NodeID is the node identifier.
+	NodeID signature.PublicKey `json:"node_id"`
+	// EntityID is the identifier of the entity controlling the node.
+	EntityID signature.PublicKey `json:"entity_id"`
+	// Election is the election the node was filtered out of. It is empty in
+	// case the node was filtered out of all elections.
+	Election string `json:"election,omitempty"`
+	// Reason is the reason why the node was not elected.
+	Reason FilterReason `json:"reason"`
+}
+
+// DroppedCommittee is a committee that could not be elected in a simulated
+// election.
+type DroppedCommittee struct {
+	// Kind is the committee kind.
+	Kind scheduler.CommitteeKind `json:"kind"`
+	// RuntimeID is the runtime identifier.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Reason is the reason why the committee could not be elected.
+	Reason string `json:"reason"`
+}
+
+// ElectionSimulation is the outcome of simulated elections.
+type ElectionSimulation struct {
+	// Epoch is the epoch the elections were simulated for.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// Validators is the elected validator set.
+	Validators []*consensus.Validator `json:"validators"`
+	// Committees are the elected committees.
+	Committees []*scheduler.Committee `json:"committees"`
+	// Dropped are the committees that could not be elected.
+	Dropped []*DroppedCommittee `json:"dropped,omitempty"`
+	// Filtered are the nodes that were not elected together with the reasons.
+	Filtered []*FilteredNode `json:"filtered,omitempty"`
+	// Error is the error that caused the elections to fail (if any).
+	Error string `json:"error,omitempty"`
+}
+
+// electionTrace records the reasons for election outcomes. All methods are
+// safe to call on a nil trace in which case nothing is recorded.
+type electionTrace struct {
+	filtered []*FilteredNode
+	dropped  []*DroppedCommittee
+
+	seen map[string]map[signature.PublicKey]bool
+}
+
+func (t *electionTrace) filter(n *node.Node, election string, reason FilterReason) {
+	if t == nil {
+		return
+	}
+	if t.seen == nil {
+		t.seen = make(map[string]map[signature.PublicKey]bool)
+	}
+	if t.seen[election] == nil {
+		t.seen[election] = make(map[signature.PublicKey]bool)
+	}
+	t.seen[election][n.ID] = true
+	t.filtered = append(t.filtered, &FilteredNode{
+		NodeID:   n.ID,
+		EntityID: n.EntityID,
+		Election: election,
+		Reason:   reason,
+	})
+}
+
+func (t *electionTrace) drop(kind scheduler.CommitteeKind, runtimeID common.Namespace, reason string) {
+	if t == nil {
+		return
+	}
+	t.dropped = append(t.dropped, &DroppedCommittee{
+		Kind:      kind,
+		RuntimeID: runtimeID,
+		Reason:    reason,
+	})
+}
+
+// notElected records the eligible nodes that did not make it into the
+// elected set, unless a more specific reason has already been recorded.
+func (t *electionTrace) notElected(
+	election string,
+	eligible []*node.Node,
+	isElected func(*node.Node) bool,
+	maxPerEntity int,
+) {
+	if t == nil {
+		return
+	}
+
+	perEntity := make(map[signature.PublicKey]int)
+	for _, n := range eligible {
+		if isElected(n) {
+			perEntity[n.EntityID]++
+		}
+	}
+	for _, n := range eligible {
+		if isElected(n) || t.seen[election][n.ID] {
+			continue
+		}
+		reason := FilterNotSelected
+		if maxPerEntity > 0 && perEntity[n.EntityID] >= maxPerEntity {
+			reason = FilterEntityLimit
+		}
+		t.filter(n, election, reason)
+	}
+}
+
+func committeeElection(kind scheduler.CommitteeKind, runtimeID common.Namespace, role scheduler.Role) string {
+	if role == scheduler.RoleInvalid {
+		return fmt.Sprintf("%s/%s", kind, runtimeID)
+	}
+	return fmt.Sprintf("%s/%s/%s", kind, runtimeID, role)
+}
+
+// SimulateElections performs the elections that would take place at the start
+// of the given epoch on top of the given state and reports the outcome.
+//
+// As VRF proofs are not part of the state, the simulated elections always
+// shuffle nodes using the passed entropy instead.
+//
+// Failed elections are reported in the outcome together with the nodes that
+// were filtered out before the failure.
+//
+// The simulation modifies the state so the caller must discard it afterwards.
+func SimulateElections(ctx *api.Context, epoch beacon.EpochTime, entropy []byte) (*ElectionSimulation, error) {
+	beaconSt := beaconState.NewMutableState(ctx.State())
+	if err := beaconSt.SetEpoch(ctx, epoch, ctx.BlockHeight()); err != nil {
+		return nil, fmt.Errorf("tendermint/scheduler: failed to set epoch: %w", err)
+	}
+	if err := beaconSt.SetBeacon(ctx, entropy); err != nil {
+		return nil, fmt.Errorf("tendermint/scheduler: failed to set beacon: %w", err)
+	}
+
+	app := &schedulerApplication{
+		state: ctx.AppState(),
+		trace: &electionTrace{},
+	}
+	electErr := app.elect(ctx, epoch, true)
+
+	sim := &ElectionSimulation{
+		Epoch:    epoch,
+		Dropped:  app.trace.dropped,
+		Filtered: app.trace.filtered,
+	}
+	if electErr != nil {
+		sim.Error = electErr.Error()
+		return sim, nil
+	}
+
+	schedState := schedulerState.NewMutableState(ctx.State())
+	validators, err := schedState.PendingValidators(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tendermint/scheduler: failed to query pending validators: %w", err)
+	}
+	regState := registryState.NewMutableState(ctx.State())
+	for consensusID, power := range validators {
+		v := &consensus.Validator{
+			ConsensusID: consensusID,
+			VotingPower: power,
+		}
+		var n *node.Node
+		if n, err = regState.NodeBySubKey(ctx, consensusID); err == nil {
+			v.ID = n.ID
+			v.EntityID = n.EntityID
+		}
+		sim.Validators = append(sim.Validators, v)
+	}
+	sort.Slice(sim.Validators, func(i, j int) bool {
+		if sim.Validators[i].VotingPower != sim.Validators[j].VotingPower {
+			return sim.Validators[i].VotingPower > sim.Validators[j].VotingPower
+		}
+		return sim.Validators[i].ConsensusID.String() < sim.Validators[j].ConsensusID.String()
+	})
+
+	if sim.Committees, err = schedState.AllCommittees(ctx); err != nil {
+		return nil, fmt.Errorf("tendermint/scheduler: failed to query committees: %w", err)
+	}
+
+	return sim, nil
+}
@@ -0,0 +1,141 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/beacon/state"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestSimulateElections(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{})
+	initCtx := appState.NewContext(api.ContextInitChain, now)
+	defer initCtx.Close()
+
+	err := schedulerState.NewMutableState(initCtx.State()).SetConsensusParameters(initCtx, &scheduler.ConsensusParameters{
+		MinValidators:          1,
+		MaxValidators:          10,
+		MaxValidatorsPerEntity: 1,
+		DebugBypassStake:       true,
+	})
+	require.NoError(err, "SetConsensusParameters")
+	err = beaconState.NewMutableState(initCtx.State()).SetConsensusParameters(initCtx, &beacon.ConsensusParameters{
+		Backend: beacon.BackendInsecure,
+	})
+	require.NoError(err, "SetConsensusParameters")
+	err = stakingState.NewMutableState(initCtx.State()).SetConsensusParameters(initCtx, &staking.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	regState := registryState.NewMutableState(initCtx.State())
+	rt := &registry.Runtime{
+		Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+		ID:        common.NewTestNamespaceFromSeed([]byte("simulate runtime"), 0),
+		Kind:      registry.KindCompute,
+		Executor: registry.ExecutorParameters{
+			GroupSize: 1,
+		},
+		GovernanceModel: registry.GovernanceEntity,
+	}
+	err = regState.SetRuntime(initCtx, rt, false)
+	require.NoError(err, "SetRuntime")
+
+	entityID1 := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000001")
+	entityID2 := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000002")
+
+	addNode := func(name string, entityID signature.PublicKey, roles node.RolesMask, expiration uint64, frozen bool, runtimes ...*node.Runtime) *node.Node {
+		nodeSigner := memorySigner.NewTestSigner("simulate node " + name)
+		n := &node.Node{
+			Versioned:  cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+			ID:         nodeSigner.Public(),
+			EntityID:   entityID,
+			Expiration: expiration,
+			Roles:      roles,
+			Runtimes:   runtimes,
+			TLS:        node.TLSInfo{PubKey: memorySigner.NewTestSigner("simulate node TLS " + name).Public()},
+			P2P:        node.P2PInfo{ID: memorySigner.NewTestSigner("simulate node P2P " + name).Public()},
+			Consensus:  node.ConsensusInfo{ID: memorySigner.NewTestSigner("simulate node consensus " + name).Public()},
+		}
+		sigNode, nerr := node.MultiSignNode([]signature.Signer{nodeSigner}, registry.RegisterNodeSignatureContext, n)
+		require.NoError(nerr, "MultiSignNode")
+		require.NoError(regState.SetNode(initCtx, nil, n, sigNode), "SetNode")
+
+		status := &registry.NodeStatus{ElectionEligibleAfter: 0}
+		if frozen {
+			status.FreezeEndTime = 100
+		}
+		require.NoError(regState.SetNodeStatus(initCtx, n.ID, status), "SetNodeStatus")
+		return n
+	}
+
+	compatible := &node.Runtime{ID: rt.ID}
+	incompatible := &node.Runtime{ID: rt.ID, Version: version.Version{Major: 1}}
+
+	validator := addNode("validator", entityID1, node.RoleValidator|node.RoleComputeWorker, 10, false, compatible)
+	worker := addNode("worker", entityID2, node.RoleComputeWorker, 10, false, compatible)
+	frozen := addNode("frozen", entityID2, node.RoleValidator, 10, true)
+	expired := addNode("expired", entityID2, node.RoleValidator, 1, false)
+	noRuntime := addNode("no runtime", entityID2, node.RoleComputeWorker, 10, false)
+	oldVersion := addNode("old version", entityID1, node.RoleComputeWorker, 10, false, incompatible)
+
+	ctx := appState.NewContext(api.ContextBeginBlock, now)
+	defer ctx.Close()
+
+	sim, err := SimulateElections(ctx, 2, []byte("mock random beacon mock beacon!!"))
+	require.NoError(err, "SimulateElections")
+	require.EqualValues(2, sim.Epoch)
+	require.Empty(sim.Dropped, "no committees should be dropped")
+
+	require.Len(sim.Validators, 1, "one validator should be elected")
+	require.Equal(validator.ID, sim.Validators[0].ID)
+	require.Equal(validator.EntityID, sim.Validators[0].EntityID)
+	require.Equal(validator.Consensus.ID, sim.Validators[0].ConsensusID)
+	require.EqualValues(1, sim.Validators[0].VotingPower)
+
+	require.Len(sim.Committees, 1, "one committee should be elected")
+	require.Len(sim.Committees[0].Members, 1, "committee should have one member")
+	member := sim.Committees[0].Members[0].PublicKey
+	require.True(member.Equal(validator.ID) || member.Equal(worker.ID), "committee member should be eligible")
+
+	reasons := make(map[signature.PublicKey]map[string]FilterReason)
+	for _, f := range sim.Filtered {
+		if reasons[f.NodeID] == nil {
+			reasons[f.NodeID] = make(map[string]FilterReason)
+		}
+		reasons[f.NodeID][f.Election] = f.Reason
+	}
+	executorElection := committeeElection(scheduler.KindComputeExecutor, rt.ID, scheduler.RoleInvalid)
+	workerElection := committeeElection(scheduler.KindComputeExecutor, rt.ID, scheduler.RoleWorker)
+
+	require.Equal(FilterFrozen, reasons[frozen.ID][""])
+	require.Equal(FilterExpired, reasons[expired.ID][""])
+	require.Equal(FilterRoleMismatch, reasons[worker.ID][electionValidators])
+	require.Equal(FilterRoleMismatch, reasons[noRuntime.ID][electionValidators])
+	require.Equal(FilterRuntimeMismatch, reasons[noRuntime.ID][executorElection])
+	require.Equal(FilterVersionMismatch, reasons[oldVersion.ID][executorElection])
+	notElected := worker
+	if member.Equal(worker.ID) {
+		notElected = validator
+	}
+	require.Equal(FilterNotSelected, reasons[notElected.ID][workerElection])
+	require.NotContains(reasons[member], executorElection, "elected committee member should not be filtered")
+	require.NotContains(reasons[member], workerElection, "elected committee member should not be filtered")
+}
@@ -227,3 +227,13 @@ func NewMutableState(tree mkvs.KeyValueTree) *MutableState {
 		ms: tree,
 	}
 }
+
+func init() {
+	abciAPI.RegisterStateKeyFormats(
+		api.ModuleName,
+		committeeKeyFmt,
+		validatorsCurrentKeyFmt,
+		validatorsPendingKeyFmt,
+		parametersKeyFmt,
+	)
+}
@@ -8,6 +8,9 @@ import (
 const (
 	// AppID is the unique application identifier.
 	AppID uint8 = 0x05
+
+	// StateVersion is the version of the application's state schema.
+	StateVersion uint64 = 1
 )
 
 var (
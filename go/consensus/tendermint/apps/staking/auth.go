@@ -58,7 +58,7 @@ func (app *stakingApplication) PostExecuteTx(ctx *api.Context, tx *transaction.T
 	}
 
 	// Deduct fee and increment the nonce.
-	if err := account.General.Balance.Sub(&fee.Amount); err != nil {
+	if err := account.General.Balance.Sub(fee.TotalAmount()); err != nil {
 		return transaction.ErrInsufficientFeeBalance
 	}
 
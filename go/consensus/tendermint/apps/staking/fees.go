@@ -108,6 +108,84 @@ func (app *stakingApplication) disburseFeesP(
 	return nil
 }
 
+// disburseTips disburses the tips paid in the current block to the proposer in full.
+//
+// In case of errors the state may be inconsistent.
+func (app *stakingApplication) disburseTips(
+	ctx *abciAPI.Context,
+	stakeState *stakingState.MutableState,
+	proposerEntity *signature.PublicKey,
+	totalTips *quantity.Quantity,
+) error {
+	if totalTips.IsZero() {
+		return nil
+	}
+	ctx.Logger().Debug("disbursing tips",
+		"total_amount", totalTips,
+	)
+
+	amount := totalTips.Clone()
+	to := staking.CommonPoolAddress
+	switch proposerEntity {
+	case nil:
+		// Put the tips into the common pool in case there is no proposer entity to pay.
+		commonPool, err := stakeState.CommonPool(ctx)
+		if err != nil {
+			return fmt.Errorf("CommonPool: %w", err)
+		}
+		if err = quantity.Move(commonPool, totalTips, amount); err != nil {
+			return fmt.Errorf("move tips: %w", err)
+		}
+		if err = stakeState.SetCommonPool(ctx, commonPool); err != nil {
+			return fmt.Errorf("failed to set common pool: %w", err)
+		}
+	default:
+		to = staking.NewAddress(*proposerEntity)
+		proposerAcct, err := stakeState.Account(ctx, to)
+		if err != nil {
+			return fmt.Errorf("failed to fetch proposer account: %w", err)
+		}
+		if err = quantity.Move(&proposerAcct.General.Balance, totalTips, amount); err != nil {
+			return fmt.Errorf("move tips: %w", err)
+		}
+		if err = stakeState.SetAccount(ctx, to, proposerAcct); err != nil {
+			return fmt.Errorf("failed to set account: %w", err)
+		}
+	}
+
+	// Emit transfer event.
+	ctx.EmitEvent(abciAPI.NewEventBuilder(app.Name()).TypedAttribute(&staking.TransferEvent{
+		From:   staking.FeeAccumulatorAddress,
+		To:     to,
+		Amount: *amount,
+	}))
+
+	return nil
+}
+
+// recordFeeSummary emits the fee summary event for the current block and records it in state so
+// that it can be used for computing recent fee statistics.
+func (app *stakingApplication) recordFeeSummary(ctx *abciAPI.Context, stakeState *stakingState.MutableState) error {
+	// Only keep the fee summaries of the most recent blocks.
+	if pruneHeight := ctx.BlockHeight() + 2 - staking.FeeStatisticsWindow; pruneHeight > 0 {
+		if err := stakeState.PruneFeeSummaries(ctx, pruneHeight); err != nil {
+			return fmt.Errorf("failed to prune fee summaries: %w", err)
+		}
+	}
+
+	fs := stakingState.BlockFeeSummary(ctx)
+	if fs == nil {
+		return nil
+	}
+	if err := stakeState.AddFeeSummary(ctx, fs); err != nil {
+		return fmt.Errorf("failed to add fee summary: %w", err)
+	}
+
+	ctx.EmitEvent(abciAPI.NewEventBuilder(app.Name()).TypedAttribute(fs))
+
+	return nil
+}
+
 // disburseFeesVQ disburses persisted fees to the voters and next proposer.
 //
 // In case of errors the state may be inconsistent.
@@ -11,6 +11,7 @@ import (
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 )
 
 func (app *stakingApplication) initParameters(ctx *abciAPI.Context, state *stakingState.MutableState, st *staking.Genesis) error {
@@ -24,6 +25,20 @@ func (app *stakingApplication) initParameters(ctx *abciAPI.Context, state *staki
 	return nil
 }
 
+func (app *stakingApplication) initTokenMetadata(ctx *abciAPI.Context, state *stakingState.MutableState, st *staking.Genesis) error {
+	meta := token.Metadata{
+		Symbol:        st.TokenSymbol,
+		ValueExponent: st.TokenValueExponent,
+	}
+	if err := meta.ValidateBasic(); err != nil {
+		return fmt.Errorf("tendermint/staking: invalid token metadata: %w", err)
+	}
+	if err := state.SetTokenMetadata(ctx, &meta); err != nil {
+		return fmt.Errorf("tendermint/staking: failed to set token metadata: %w", err)
+	}
+	return nil
+}
+
 func (app *stakingApplication) initCommonPool(ctx *abciAPI.Context, st *staking.Genesis, totalSupply *quantity.Quantity) error {
 	if !st.CommonPool.IsValid() {
 		return fmt.Errorf("tendermint/staking: invalid genesis state CommonPool")
@@ -285,6 +300,27 @@ func (app *stakingApplication) initDebondingDelegations(ctx *abciAPI.Context, st
 	return nil
 }
 
+func (app *stakingApplication) initRedelegations(ctx *abciAPI.Context, state *stakingState.MutableState, st *staking.Genesis) error {
+	for delegatorAddr, redelegations := range st.Redelegations {
+		for escrowAddr, rds := range redelegations {
+			for idx, rd := range rds {
+				if rd == nil {
+					return fmt.Errorf(
+						"tendermint/staking: genesis redelegation from %s by %s with index %d is nil",
+						escrowAddr, delegatorAddr, idx,
+					)
+				}
+				if err := state.SetRedelegation(ctx, delegatorAddr, escrowAddr, rd); err != nil {
+					return fmt.Errorf("tendermint/staking: failed to set redelegation from %s by %s index %d: %w",
+						escrowAddr, delegatorAddr, idx, err,
+					)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // InitChain initializes the chain from genesis.
 func (app *stakingApplication) InitChain(ctx *abciAPI.Context, request types.RequestInitChain, doc *genesis.Document) error {
 	st := &doc.Staking
@@ -298,6 +334,10 @@ func (app *stakingApplication) InitChain(ctx *abciAPI.Context, request types.Req
 		return err
 	}
 
+	if err := app.initTokenMetadata(ctx, state, st); err != nil {
+		return err
+	}
+
 	if err := app.initCommonPool(ctx, st, &totalSupply); err != nil {
 		return err
 	}
@@ -326,6 +366,10 @@ func (app *stakingApplication) InitChain(ctx *abciAPI.Context, request types.Req
 		return err
 	}
 
+	if err := app.initRedelegations(ctx, state, st); err != nil {
+		return err
+	}
+
 	ctx.Logger().Debug("InitChain: allocations complete",
 		"common_pool", st.CommonPool,
 		"total_supply", totalSupply,
@@ -381,6 +425,10 @@ func (sq *stakingQuerier) Genesis(ctx context.Context) (*staking.Genesis, error)
 	if err != nil {
 		return nil, err
 	}
+	redelegations, err := sq.state.Redelegations(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	params, err := sq.state.ConsensusParameters(ctx)
 	if err != nil {
@@ -396,6 +444,7 @@ func (sq *stakingQuerier) Genesis(ctx context.Context) (*staking.Genesis, error)
 		Ledger:               ledger,
 		Delegations:          delegations,
 		DebondingDelegations: debondingDelegations,
+		Redelegations:        redelegations,
 	}
 	return &gen, nil
 }
@@ -0,0 +1,200 @@
+package staking
+
+import (
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// checkTotalSupply checks that all accounts are valid and that the total supply adds up (common
+// pool + all balances in the ledger + governance deposits + last block fees).
+func checkTotalSupply(ctx *api.Context, epoch beacon.EpochTime) error {
+	st := stakingState.NewMutableState(ctx.State())
+
+	parameters, err := st.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("ConsensusParameters: %w", err)
+	}
+
+	totalSupply, err := st.TotalSupply(ctx)
+	if err != nil {
+		return fmt.Errorf("TotalSupply: %w", err)
+	}
+	if !totalSupply.IsValid() {
+		return fmt.Errorf("total supply %v is invalid", totalSupply)
+	}
+
+	commonPool, err := st.CommonPool(ctx)
+	if err != nil {
+		return fmt.Errorf("CommonPool: %w", err)
+	}
+	if !commonPool.IsValid() {
+		return fmt.Errorf("common pool %v is invalid", commonPool)
+	}
+
+	// Check if the total supply adds up (common pool + all balances in the ledger).
+	// Check all commission schedules.
+	var total quantity.Quantity
+	addresses, err := st.Addresses(ctx)
+	if err != nil {
+		return fmt.Errorf("Addresses(): %w", err)
+	}
+	for _, addr := range addresses {
+		var acct *staking.Account
+		acct, err = st.Account(ctx, addr)
+		if err != nil {
+			return fmt.Errorf("Account(): %w", err)
+		}
+		err = staking.SanityCheckAccount(&total, parameters, epoch, addr, acct)
+		if err != nil {
+			return fmt.Errorf("SanityCheckAccount %s: %w", addr, err)
+		}
+	}
+
+	totalFees, err := st.LastBlockFees(ctx)
+	if err != nil {
+		return fmt.Errorf("LastBlockFees: %w", err)
+	}
+	if !totalFees.IsValid() {
+		return fmt.Errorf("last block fees %v is invalid", totalFees)
+	}
+
+	governanceDeposits, err := st.GovernanceDeposits(ctx)
+	if err != nil {
+		return fmt.Errorf("GovernanceDeposits: %w", err)
+	}
+	if !governanceDeposits.IsValid() {
+		return fmt.Errorf("governance deposits %v is invalid", governanceDeposits)
+	}
+
+	_ = total.Add(governanceDeposits)
+	_ = total.Add(commonPool)
+	_ = total.Add(totalFees)
+	if total.Cmp(totalSupply) != 0 {
+		return fmt.Errorf(
+			"balances in accounts plus governance deposits (%s), plus common pool (%s), plus last block fees (%s), does not add up to total supply (%s)",
+			governanceDeposits.String(), commonPool.String(), totalFees.String(), totalSupply.String(),
+		)
+	}
+
+	return nil
+}
+
+// checkDelegations checks that the shares of all (debonding) delegations to an account add up to
+// the account's total escrow shares.
+func checkDelegations(ctx *api.Context, epoch beacon.EpochTime) error {
+	st := stakingState.NewMutableState(ctx.State())
+
+	// All shares of all delegations for a given account must add up to account's Escrow.Active.TotalShares.
+	addressesDelegationsMap, err := st.Delegations(ctx)
+	if err != nil {
+		return fmt.Errorf("Delegations(): %w", err)
+	}
+	var acct *staking.Account
+	for address, delegations := range addressesDelegationsMap {
+		acct, err = st.Account(ctx, address)
+		if err != nil {
+			return fmt.Errorf("Account() %s: %w", address, err)
+		}
+		if err = staking.SanityCheckDelegations(address, acct, delegations); err != nil {
+			return err
+		}
+	}
+
+	// All shares of all debonding delegations for a given account must add up to account's Escrow.Debonding.TotalShares.
+	addressesDebondingDelegationsMap, err := st.DebondingDelegations(ctx)
+	if err != nil {
+		return fmt.Errorf("DebondingDelegations: %w", err)
+	}
+	for address, debondingDelegations := range addressesDebondingDelegationsMap {
+		acct, err = st.Account(ctx, address)
+		if err != nil {
+			return fmt.Errorf("Account() %s: %w", address, err)
+		}
+		if err = staking.SanityCheckDebondingDelegations(address, acct, debondingDelegations); err != nil {
+			return err
+		}
+	}
+
+	// Check the above two invariants for each account as well.
+	addresses, err := st.Addresses(ctx)
+	if err != nil {
+		return fmt.Errorf("Addresses(): %w", err)
+	}
+	for _, addr := range addresses {
+		acct, err = st.Account(ctx, addr)
+		if err != nil {
+			return fmt.Errorf("Account(): %w", err)
+		}
+		if err = staking.SanityCheckAccountShares(
+			addr, acct, addressesDelegationsMap[addr],
+			addressesDebondingDelegationsMap[addr],
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkStakeClaims checks that all entities have enough stake to satisfy their stake claims.
+func checkStakeClaims(ctx *api.Context, epoch beacon.EpochTime) error {
+	regSt := registryState.NewMutableState(ctx.State())
+	stakingSt := stakingState.NewMutableState(ctx.State())
+
+	regParams, err := regSt.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get registry consensus parameters: %w", err)
+	}
+	stakingParams, err := stakingSt.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get staking consensus parameters: %w", err)
+	}
+
+	// Skip checks if stake is being bypassed.
+	if regParams.DebugBypassStake {
+		return nil
+	}
+
+	// Get registered entities.
+	entities, err := regSt.Entities(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get entities: %w", err)
+	}
+	// Get registered nodes.
+	nodes, err := regSt.Nodes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get node registrations: %w", err)
+	}
+	// Get registered runtimes.
+	runtimes, err := regSt.AllRuntimes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get runtime registrations: %w", err)
+	}
+	// Get staking accounts.
+	accounts := make(map[staking.Address]*staking.Account)
+	addresses, err := stakingSt.Addresses(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get staking addresses: %w", err)
+	}
+	for _, addr := range addresses {
+		accounts[addr], err = stakingSt.Account(ctx, addr)
+		if err != nil {
+			return fmt.Errorf("failed to get staking account %s: %w", addr, err)
+		}
+	}
+
+	return registry.SanityCheckStake(entities, accounts, nodes, runtimes, stakingParams.Thresholds, false)
+}
+
+func init() {
+	api.RegisterInvariant(staking.ModuleName, "total_supply", checkTotalSupply)
+	api.RegisterInvariant(staking.ModuleName, "delegations", checkDelegations)
+	api.RegisterInvariant(staking.ModuleName, "stake_claims", checkStakeClaims)
+}
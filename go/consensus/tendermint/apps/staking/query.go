@@ -2,16 +2,19 @@ package staking
 
 import (
 	"context"
+	"fmt"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 )
 
 // Query is the staking query interface.
 type Query interface {
+	TokenMetadata(context.Context) (*token.Metadata, error)
 	TotalSupply(context.Context) (*quantity.Quantity, error)
 	CommonPool(context.Context) (*quantity.Quantity, error)
 	LastBlockFees(context.Context) (*quantity.Quantity, error)
@@ -20,14 +23,18 @@ type Query interface {
 	DebondingInterval(context.Context) (beacon.EpochTime, error)
 	Addresses(context.Context) ([]staking.Address, error)
 	Account(context.Context, staking.Address) (*staking.Account, error)
+	AccountWithProof(context.Context, staking.Address) (*staking.AccountWithProof, error)
 	DelegationsFor(context.Context, staking.Address) (map[staking.Address]*staking.Delegation, error)
 	DelegationInfosFor(context.Context, staking.Address) (map[staking.Address]*staking.DelegationInfo, error)
 	DelegationsTo(context.Context, staking.Address) (map[staking.Address]*staking.Delegation, error)
 	DebondingDelegationsFor(context.Context, staking.Address) (map[staking.Address][]*staking.DebondingDelegation, error)
 	DebondingDelegationInfosFor(context.Context, staking.Address) (map[staking.Address][]*staking.DebondingDelegationInfo, error)
 	DebondingDelegationsTo(context.Context, staking.Address) (map[staking.Address][]*staking.DebondingDelegation, error)
+	RedelegationsFor(context.Context, staking.Address) (map[staking.Address][]*staking.Redelegation, error)
 	Genesis(context.Context) (*staking.Genesis, error)
 	ConsensusParameters(context.Context) (*staking.ConsensusParameters, error)
+	Evidence(context.Context) ([]*staking.EvidenceEvent, error)
+	FeeStatistics(context.Context) (*staking.FeeStatistics, error)
 }
 
 // QueryFactory is the staking query factory.
@@ -48,6 +55,10 @@ type stakingQuerier struct {
 	state *stakingState.ImmutableState
 }
 
+func (sq *stakingQuerier) TokenMetadata(ctx context.Context) (*token.Metadata, error) {
+	return sq.state.TokenMetadata(ctx)
+}
+
 func (sq *stakingQuerier) TotalSupply(ctx context.Context) (*quantity.Quantity, error) {
 	return sq.state.TotalSupply(ctx)
 }
@@ -123,6 +134,14 @@ func (sq *stakingQuerier) Account(ctx context.Context, addr staking.Address) (*s
 	}
 }
 
+func (sq *stakingQuerier) AccountWithProof(ctx context.Context, addr staking.Address) (*staking.AccountWithProof, error) {
+	if addr.IsReserved() {
+		// Reserved accounts are not stored as regular accounts in state.
+		return nil, fmt.Errorf("%w: reserved account address", abciAPI.ErrProofUnavailable)
+	}
+	return sq.state.AccountWithProof(ctx, addr)
+}
+
 func (sq *stakingQuerier) DelegationsFor(ctx context.Context, addr staking.Address) (map[staking.Address]*staking.Delegation, error) {
 	return sq.state.DelegationsFor(ctx, addr)
 }
@@ -181,10 +200,26 @@ func (sq *stakingQuerier) DebondingDelegationsTo(ctx context.Context, addr staki
 	return sq.state.DebondingDelegationsTo(ctx, addr)
 }
 
+func (sq *stakingQuerier) RedelegationsFor(ctx context.Context, addr staking.Address) (map[staking.Address][]*staking.Redelegation, error) {
+	return sq.state.RedelegationsFor(ctx, addr)
+}
+
 func (sq *stakingQuerier) ConsensusParameters(ctx context.Context) (*staking.ConsensusParameters, error) {
 	return sq.state.ConsensusParameters(ctx)
 }
 
+func (sq *stakingQuerier) Evidence(ctx context.Context) ([]*staking.EvidenceEvent, error) {
+	return sq.state.Evidence(ctx)
+}
+
+func (sq *stakingQuerier) FeeStatistics(ctx context.Context) (*staking.FeeStatistics, error) {
+	summaries, err := sq.state.FeeSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return staking.NewFeeStatistics(summaries), nil
+}
+
 func (app *stakingApplication) QueryFactory() interface{} {
 	return &QueryFactory{app.state}
 }
@@ -0,0 +1,169 @@
+package staking
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// isDustAccount returns true iff the given account only holds a dust general balance and can be
+// removed from state.
+//
+// Accounts with a non-zero nonce are never considered dust as removing them would reset the nonce
+// and allow previously signed transactions to be replayed.
+func isDustAccount(params *staking.ConsensusParameters, acct *staking.Account) bool {
+	if acct.General.Nonce != 0 || len(acct.General.Allowances) > 0 {
+		return false
+	}
+	if !acct.General.Balance.IsZero() && acct.General.Balance.Cmp(&params.MinBalance) >= 0 {
+		return false
+	}
+
+	esc := &acct.Escrow
+	if !esc.Active.Balance.IsZero() || !esc.Active.TotalShares.IsZero() {
+		return false
+	}
+	if !esc.Debonding.Balance.IsZero() || !esc.Debonding.TotalShares.IsZero() {
+		return false
+	}
+	if len(esc.CommissionSchedule.Rates) > 0 || len(esc.CommissionSchedule.Bounds) > 0 {
+		return false
+	}
+	return len(esc.StakeAccumulator.Claims) == 0
+}
+
+// maxDustReaperAccounts is the maximum number of accounts examined by the dust account reaper
+// in a single epoch.
+const maxDustReaperAccounts = 1000
+
+// reapDustAccounts removes dust accounts from state and transfers their remaining general
+// balances to the common pool.
+//
+// In case of errors the state may be inconsistent.
+func (app *stakingApplication) reapDustAccounts(ctx *abciAPI.Context, stakeState *stakingState.MutableState) error {
+	return app.reapDustAccountsBatch(ctx, stakeState, maxDustReaperAccounts)
+}
+
+// reapDustAccountsBatch examines at most limit accounts, starting at the persisted reaper cursor,
+// and removes any dust accounts among them. The cursor is then advanced so that the next epoch
+// continues where this one stopped, wrapping around at the end of the ledger.
+func (app *stakingApplication) reapDustAccountsBatch(
+	ctx *abciAPI.Context,
+	stakeState *stakingState.MutableState,
+	limit int,
+) error {
+	params, err := stakeState.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if !params.ReapDustAccounts {
+		return nil
+	}
+
+	cursor, err := stakeState.DustReaperCursor(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch reaper cursor: %w", err)
+	}
+	// Fetch an additional address to serve as the cursor for the next epoch.
+	addresses, err := stakeState.AddressesFrom(ctx, cursor, limit+1)
+	if err != nil {
+		return fmt.Errorf("failed to fetch addresses: %w", err)
+	}
+	var nextCursor staking.Address
+	if len(addresses) > limit {
+		nextCursor = addresses[limit]
+		addresses = addresses[:limit]
+	}
+	if err = stakeState.SetDustReaperCursor(ctx, nextCursor); err != nil {
+		return fmt.Errorf("failed to set reaper cursor: %w", err)
+	}
+
+	// Runtime accounts are managed by their runtimes and must never be removed.
+	regState := registryState.NewMutableState(ctx.State())
+	runtimes, err := regState.AllRuntimes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch runtimes: %w", err)
+	}
+	runtimeAddrs := make(map[staking.Address]bool)
+	for _, rt := range runtimes {
+		runtimeAddrs[staking.NewRuntimeAddress(rt.ID)] = true
+	}
+
+	commonPool, err := stakeState.CommonPool(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch common pool: %w", err)
+	}
+
+	var (
+		numReaped  int
+		delegators map[staking.Address]bool
+	)
+	for _, addr := range addresses {
+		if addr.IsReserved() || runtimeAddrs[addr] {
+			continue
+		}
+
+		var acct *staking.Account
+		acct, err = stakeState.Account(ctx, addr)
+		if err != nil {
+			return fmt.Errorf("failed to fetch account: %w", err)
+		}
+		if !isDustAccount(params, acct) {
+			continue
+		}
+
+		// Accounts may delegate without ever sending a transaction (e.g., via runtime messages).
+		// The set of delegators is only built once a dust account is found.
+		if delegators == nil {
+			if delegators, err = stakeState.Delegators(ctx); err != nil {
+				return fmt.Errorf("failed to fetch delegators: %w", err)
+			}
+		}
+		if delegators[addr] {
+			continue
+		}
+
+		amount := acct.General.Balance.Clone()
+		if err = quantity.Move(commonPool, &acct.General.Balance, amount); err != nil {
+			return fmt.Errorf("failed to move dust balance to common pool: %w", err)
+		}
+		if err = stakeState.RemoveAccount(ctx, addr); err != nil {
+			return fmt.Errorf("failed to remove account: %w", err)
+		}
+
+		ctx.Logger().Debug("reaped dust account",
+			"account", addr,
+			"amount", amount,
+		)
+
+		if !amount.IsZero() {
+			ctx.EmitEvent(abciAPI.NewEventBuilder(app.Name()).TypedAttribute(&staking.TransferEvent{
+				From:   addr,
+				To:     staking.CommonPoolAddress,
+				Amount: *amount,
+			}))
+		}
+		ctx.EmitEvent(abciAPI.NewEventBuilder(app.Name()).TypedAttribute(&staking.AccountReapedEvent{
+			Account: addr,
+			Amount:  *amount,
+		}))
+		numReaped++
+	}
+	if numReaped == 0 {
+		return nil
+	}
+
+	if err = stakeState.SetCommonPool(ctx, commonPool); err != nil {
+		return fmt.Errorf("failed to set common pool: %w", err)
+	}
+
+	ctx.Logger().Info("reaped dust accounts",
+		"num_reaped", numReaped,
+	)
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package staking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestReapDustAccounts(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	dustAddr := staking.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	emptyAddr := staking.NewAddress(signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	nonceAddr := staking.NewAddress(signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	richAddr := staking.NewAddress(signature.NewPublicKey("dddfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	delegatorAddr := staking.NewAddress(signature.NewPublicKey("eeefffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	for addr, acct := range map[staking.Address]*staking.Account{
+		dustAddr: {
+			General: staking.GeneralAccount{Balance: *quantity.NewFromUint64(50)},
+		},
+		emptyAddr: {},
+		nonceAddr: {
+			General: staking.GeneralAccount{Balance: *quantity.NewFromUint64(50), Nonce: 1},
+		},
+		richAddr: {
+			General: staking.GeneralAccount{Balance: *quantity.NewFromUint64(1000)},
+			Escrow: staking.EscrowAccount{
+				Active: staking.SharePool{
+					Balance:     *quantity.NewFromUint64(100),
+					TotalShares: *quantity.NewFromUint64(100),
+				},
+			},
+		},
+		delegatorAddr: {},
+	} {
+		err = stakeState.SetAccount(ctx, addr, acct)
+		require.NoError(err, "SetAccount")
+	}
+	err = stakeState.SetDelegation(ctx, delegatorAddr, richAddr, &staking.Delegation{
+		Shares: *quantity.NewFromUint64(100),
+	})
+	require.NoError(err, "SetDelegation")
+	err = stakeState.SetCommonPool(ctx, quantity.NewFromUint64(10_000))
+	require.NoError(err, "SetCommonPool")
+
+	// Nothing should be reaped when reaping is disabled.
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		MinBalance: *quantity.NewFromUint64(100),
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+	err = app.reapDustAccounts(ctx, stakeState)
+	require.NoError(err, "reapDustAccounts")
+	addresses, err := stakeState.Addresses(ctx)
+	require.NoError(err, "Addresses")
+	require.Len(addresses, 5, "no accounts should be reaped when reaping is disabled")
+
+	// Only dust accounts should be reaped when reaping is enabled.
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		MinBalance:       *quantity.NewFromUint64(100),
+		ReapDustAccounts: true,
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+	err = app.reapDustAccounts(ctx, stakeState)
+	require.NoError(err, "reapDustAccounts")
+
+	addresses, err = stakeState.Addresses(ctx)
+	require.NoError(err, "Addresses")
+	require.ElementsMatch([]staking.Address{nonceAddr, richAddr, delegatorAddr}, addresses, "remaining accounts")
+
+	commonPool, err := stakeState.CommonPool(ctx)
+	require.NoError(err, "CommonPool")
+	require.EqualValues(*quantity.NewFromUint64(10_050), *commonPool, "dust should be moved to the common pool")
+
+	var numReaped int
+	for _, ev := range ctx.GetEvents() {
+		for _, pair := range ev.GetAttributes() {
+			if abciAPI.IsAttributeKind(pair.GetKey(), &staking.AccountReapedEvent{}) {
+				numReaped++
+			}
+		}
+	}
+	require.Equal(2, numReaped, "an event should be emitted for each reaped account")
+}
+
+func TestReapDustAccountsCursor(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		MinBalance:       *quantity.NewFromUint64(100),
+		ReapDustAccounts: true,
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	escrowAddr := staking.NewAddress(signature.NewPublicKey("dddfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	debondingAddr := staking.NewAddress(signature.NewPublicKey("eeefffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	err = stakeState.SetAccount(ctx, escrowAddr, &staking.Account{
+		General: staking.GeneralAccount{Balance: *quantity.NewFromUint64(1000)},
+	})
+	require.NoError(err, "SetAccount")
+	err = stakeState.SetAccount(ctx, debondingAddr, &staking.Account{})
+	require.NoError(err, "SetAccount")
+	err = stakeState.SetDebondingDelegation(ctx, debondingAddr, escrowAddr, 10, &staking.DebondingDelegation{
+		Shares:        *quantity.NewFromUint64(100),
+		DebondEndTime: 10,
+	})
+	require.NoError(err, "SetDebondingDelegation")
+
+	dustAddrs := []staking.Address{
+		staking.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")),
+		staking.NewAddress(signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")),
+		staking.NewAddress(signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")),
+	}
+	for _, addr := range dustAddrs {
+		err = stakeState.SetAccount(ctx, addr, &staking.Account{})
+		require.NoError(err, "SetAccount")
+	}
+
+	// Each epoch should only examine a limited number of accounts.
+	err = app.reapDustAccountsBatch(ctx, stakeState, 2)
+	require.NoError(err, "reapDustAccountsBatch")
+	addresses, err := stakeState.Addresses(ctx)
+	require.NoError(err, "Addresses")
+	require.GreaterOrEqual(len(addresses), 3, "only the examined dust accounts should be reaped")
+	require.Less(len(addresses), 5, "examined dust accounts should be reaped")
+	cursor, err := stakeState.DustReaperCursor(ctx)
+	require.NoError(err, "DustReaperCursor")
+	require.Contains(addresses, cursor, "cursor should point to the next account")
+
+	// The next epochs should continue at the cursor and wrap around at the end of the ledger.
+	for i := 0; i < 2; i++ {
+		err = app.reapDustAccountsBatch(ctx, stakeState, 2)
+		require.NoError(err, "reapDustAccountsBatch")
+	}
+	cursor, err = stakeState.DustReaperCursor(ctx)
+	require.NoError(err, "DustReaperCursor")
+	require.Equal(staking.Address{}, cursor, "cursor should wrap around")
+
+	addresses, err = stakeState.Addresses(ctx)
+	require.NoError(err, "Addresses")
+	require.ElementsMatch([]staking.Address{escrowAddr, debondingAddr}, addresses, "remaining accounts")
+}
@@ -8,7 +8,6 @@ import (
 
 	tmcrypto "github.com/tendermint/tendermint/crypto"
 
-	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
@@ -18,6 +17,7 @@ import (
 
 func onEvidenceByzantineConsensus(
 	ctx *abciAPI.Context,
+	index uint32,
 	reason staking.SlashReason,
 	addr tmcrypto.Address,
 	height int64,
@@ -48,6 +48,18 @@ func onEvidenceByzantineConsensus(
 		return nil
 	}
 
+	epoch, err := ctx.AppState().GetEpoch(context.Background(), ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
+	ev := &staking.EvidenceEvent{
+		Reason:   reason,
+		Height:   height,
+		Epoch:    epoch,
+		NodeID:   node.ID,
+		EntityID: node.EntityID,
+	}
+
 	// Do not slash a frozen validator.
 	if nodeStatus.IsFrozen() {
 		ctx.Logger().Debug("not slashing frozen validator",
@@ -55,7 +67,7 @@ func onEvidenceByzantineConsensus(
 			"entity_id", node.EntityID,
 			"freeze_end_time", nodeStatus.FreezeEndTime,
 		)
-		return nil
+		return recordEvidence(ctx, stakeState, index, ev)
 	}
 
 	// Retrieve the slash procedure.
@@ -72,12 +84,6 @@ func onEvidenceByzantineConsensus(
 	// Freeze validator to prevent it being slashed again. This also prevents the
 	// validator from being scheduled in the next epoch.
 	if penalty.FreezeInterval > 0 {
-		var epoch beacon.EpochTime
-		epoch, err = ctx.AppState().GetEpoch(context.Background(), ctx.BlockHeight()+1)
-		if err != nil {
-			return err
-		}
-
 		// Check for overflow.
 		if math.MaxUint64-penalty.FreezeInterval < epoch {
 			nodeStatus.FreezeEndTime = registry.FreezeForever
@@ -88,7 +94,7 @@ func onEvidenceByzantineConsensus(
 
 	// Slash validator.
 	entityAddr := staking.NewAddress(node.EntityID)
-	_, err = stakeState.SlashEscrow(ctx, entityAddr, &penalty.Amount)
+	slashed, err := stakeState.SlashEscrow(ctx, entityAddr, &penalty.Amount)
 	if err != nil {
 		ctx.Logger().Error("failed to slash validator entity",
 			"err", err,
@@ -113,5 +119,26 @@ func onEvidenceByzantineConsensus(
 		"entity_id", node.EntityID,
 	)
 
+	ev.Amount = *slashed
+	return recordEvidence(ctx, stakeState, index, ev)
+}
+
+// recordEvidence emits an event for processed evidence and stores it so that it can be queried
+// during the debonding interval.
+func recordEvidence(
+	ctx *abciAPI.Context,
+	stakeState *stakingState.MutableState,
+	index uint32,
+	ev *staking.EvidenceEvent,
+) error {
+	if err := stakeState.AddEvidence(ctx, ctx.BlockHeight()+1, index, ev); err != nil {
+		ctx.Logger().Error("failed to record evidence",
+			"err", err,
+		)
+		return err
+	}
+
+	ctx.EmitEvent(abciAPI.NewEventBuilder(AppName).TypedAttribute(ev))
+
 	return nil
 }
@@ -39,7 +39,7 @@ func TestOnEvidenceByzantineConsensus(t *testing.T) {
 	stakeState := stakingState.NewMutableState(ctx.State())
 
 	// Validator address is not known as there are no nodes.
-	err := onEvidenceByzantineConsensus(ctx, staking.SlashConsensusEquivocation, validatorAddress, 1, now, 1)
+	err := onEvidenceByzantineConsensus(ctx, 0, staking.SlashConsensusEquivocation, validatorAddress, 1, now, 1)
 	require.NoError(err, "should not fail when validator address is not known")
 
 	// Add entity.
@@ -64,7 +64,7 @@ func TestOnEvidenceByzantineConsensus(t *testing.T) {
 	require.NoError(err, "SetNode")
 
 	// Should not fail if node status is not available.
-	err = onEvidenceByzantineConsensus(ctx, staking.SlashConsensusEquivocation, validatorAddress, 1, now, 1)
+	err = onEvidenceByzantineConsensus(ctx, 1, staking.SlashConsensusEquivocation, validatorAddress, 1, now, 1)
 	require.NoError(err, "should not fail when node status is not available")
 
 	// Add node status.
@@ -72,7 +72,7 @@ func TestOnEvidenceByzantineConsensus(t *testing.T) {
 	require.NoError(err, "SetNodeStatus")
 
 	// Should fail if unable to get the slashing procedure.
-	err = onEvidenceByzantineConsensus(ctx, staking.SlashConsensusEquivocation, validatorAddress, 1, now, 1)
+	err = onEvidenceByzantineConsensus(ctx, 2, staking.SlashConsensusEquivocation, validatorAddress, 1, now, 1)
 	require.Error(err, "should fail when unable to get the slashing procedure")
 
 	// Add slashing procedure.
@@ -90,7 +90,7 @@ func TestOnEvidenceByzantineConsensus(t *testing.T) {
 
 	// Should not fail if the validator has no stake (which is in any case an
 	// invariant violation as a validator needs to have some stake).
-	err = onEvidenceByzantineConsensus(ctx, staking.SlashConsensusEquivocation, validatorAddress, 1, now, 1)
+	err = onEvidenceByzantineConsensus(ctx, 3, staking.SlashConsensusEquivocation, validatorAddress, 1, now, 1)
 	require.NoError(err, "should not fail when validator has no stake")
 	// Node should be frozen.
 	status, err := regState.NodeStatus(ctx, nod.ID)
@@ -99,7 +99,7 @@ func TestOnEvidenceByzantineConsensus(t *testing.T) {
 	require.EqualValues(registry.FreezeForever, status.FreezeEndTime, "node should be frozen forever")
 
 	// Should not fail slashing a frozen node.
-	err = onEvidenceByzantineConsensus(ctx, staking.SlashConsensusEquivocation, validatorAddress, 1, now, 1)
+	err = onEvidenceByzantineConsensus(ctx, 4, staking.SlashConsensusEquivocation, validatorAddress, 1, now, 1)
 	require.NoError(err, "should not fail when validator is frozen")
 	// Unfreeze the node.
 	err = regState.SetNodeStatus(ctx, nod.ID, &registry.NodeStatus{FreezeEndTime: 0})
@@ -124,7 +124,7 @@ func TestOnEvidenceByzantineConsensus(t *testing.T) {
 	require.NoError(err, "SetAccount")
 
 	// Should slash.
-	err = onEvidenceByzantineConsensus(ctx, staking.SlashConsensusEquivocation, validatorAddress, 1, now, 1)
+	err = onEvidenceByzantineConsensus(ctx, 5, staking.SlashConsensusEquivocation, validatorAddress, 1, now, 1)
 	require.NoError(err, "slashing should succeed")
 
 	// Entity stake should be slashed.
@@ -140,6 +140,32 @@ func TestOnEvidenceByzantineConsensus(t *testing.T) {
 	require.EqualValues(registry.FreezeForever, status.FreezeEndTime, "node should be frozen forever")
 
 	// Should not fail in case the slashing penalty is not configured.
-	err = onEvidenceByzantineConsensus(ctx, staking.SlashConsensusLightClientAttack, validatorAddress, 1, now, 1)
+	err = onEvidenceByzantineConsensus(ctx, 6, staking.SlashConsensusLightClientAttack, validatorAddress, 1, now, 1)
 	require.NoError(err, "slashing should not fail")
+
+	// Evidence should be recorded for all resolved nodes.
+	evidence, err := stakeState.Evidence(ctx)
+	require.NoError(err, "Evidence")
+	require.Len(evidence, 4, "evidence should be recorded for all resolved nodes")
+	for _, ev := range evidence {
+		require.EqualValues(42, ev.Epoch, "evidence epoch should be correct")
+		require.EqualValues(nod.ID, ev.NodeID, "evidence node ID should be correct")
+		require.EqualValues(ent.ID, ev.EntityID, "evidence entity ID should be correct")
+	}
+	require.EqualValues(staking.SlashConsensusEquivocation, evidence[2].Reason, "evidence reason should be correct")
+	require.EqualValues(slashAmount, evidence[2].Amount, "slashed amount should be recorded")
+	require.EqualValues(staking.SlashConsensusLightClientAttack, evidence[3].Reason, "evidence reason should be correct")
+	require.True(evidence[3].Amount.IsZero(), "frozen node should not be slashed")
+
+	// Pruning should remove old evidence.
+	err = stakeState.PruneEvidence(ctx, 42)
+	require.NoError(err, "PruneEvidence")
+	evidence, err = stakeState.Evidence(ctx)
+	require.NoError(err, "Evidence")
+	require.Len(evidence, 4, "evidence from the given epoch should not be pruned")
+	err = stakeState.PruneEvidence(ctx, 43)
+	require.NoError(err, "PruneEvidence")
+	evidence, err = stakeState.Evidence(ctx)
+	require.NoError(err, "Evidence")
+	require.Empty(evidence, "evidence from previous epochs should be pruned")
 }
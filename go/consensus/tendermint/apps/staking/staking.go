@@ -32,6 +32,10 @@ func (app *stakingApplication) ID() uint8 {
 	return AppID
 }
 
+func (app *stakingApplication) StateVersion() uint64 {
+	return StateVersion
+}
+
 func (app *stakingApplication) Methods() []transaction.MethodName {
 	return staking.Methods
 }
@@ -93,7 +97,7 @@ func (app *stakingApplication) BeginBlock(ctx *api.Context, request types.Reques
 
 	// Iterate over any submitted evidence of a validator misbehaving. Note that
 	// the actual evidence has already been verified by Tendermint to be valid.
-	for _, evidence := range request.ByzantineValidators {
+	for i, evidence := range request.ByzantineValidators {
 		var reason staking.SlashReason
 		switch evidence.Type {
 		case types.EvidenceType_DUPLICATE_VOTE:
@@ -107,7 +111,7 @@ func (app *stakingApplication) BeginBlock(ctx *api.Context, request types.Reques
 			continue
 		}
 
-		if err = onEvidenceByzantineConsensus(ctx, reason, evidence.Validator.Address, evidence.Height, evidence.Time, evidence.Validator.Power); err != nil {
+		if err = onEvidenceByzantineConsensus(ctx, uint32(i), reason, evidence.Validator.Address, evidence.Height, evidence.Time, evidence.Validator.Power); err != nil {
 			return err
 		}
 	}
@@ -191,16 +195,32 @@ func (app *stakingApplication) ExecuteTx(ctx *api.Context, tx *transaction.Trans
 		}
 
 		return app.withdraw(ctx, state, &withdraw)
+	case staking.MethodRedelegate:
+		var redelegate staking.Redelegate
+		if err := cbor.Unmarshal(tx.Body, &redelegate); err != nil {
+			return err
+		}
+
+		return app.redelegate(ctx, state, &redelegate)
 	default:
 		return staking.ErrInvalidArgument
 	}
 }
 
 func (app *stakingApplication) EndBlock(ctx *api.Context, request types.RequestEndBlock) (types.ResponseEndBlock, error) {
+	stakeState := stakingState.NewMutableState(ctx.State())
+	if err := app.recordFeeSummary(ctx, stakeState); err != nil {
+		return types.ResponseEndBlock{}, fmt.Errorf("record fee summary: %w", err)
+	}
+
 	fees := stakingState.BlockFees(ctx)
-	if err := app.disburseFeesP(ctx, stakingState.NewMutableState(ctx.State()), stakingState.BlockProposer(ctx), &fees); err != nil {
+	if err := app.disburseFeesP(ctx, stakeState, stakingState.BlockProposer(ctx), &fees); err != nil {
 		return types.ResponseEndBlock{}, fmt.Errorf("disburse fees proposer: %w", err)
 	}
+	tips := stakingState.BlockTips(ctx)
+	if err := app.disburseTips(ctx, stakeState, stakingState.BlockProposer(ctx), &tips); err != nil {
+		return types.ResponseEndBlock{}, fmt.Errorf("disburse tips: %w", err)
+	}
 
 	if changed, epoch := app.state.EpochChanged(ctx); changed {
 		return types.ResponseEndBlock{}, app.onEpochChange(ctx, epoch)
@@ -217,6 +237,12 @@ func (app *stakingApplication) onEpochChange(ctx *api.Context, epoch beacon.Epoc
 		return fmt.Errorf("failed to query expired debonding queue: %w", err)
 	}
 	for _, e := range expiredDebondingQueue {
+		// Complete any re-delegations first as those take their shares out of the debonding
+		// delegation and only the remainder is released into the general account.
+		if err = app.completeRedelegations(ctx, state, e); err != nil {
+			return fmt.Errorf("failed to complete redelegations: %w", err)
+		}
+
 		deb := e.Delegation
 		shareAmount := deb.Shares.Clone()
 		delegator, err := state.Account(ctx, e.DelegatorAddr)
@@ -281,6 +307,11 @@ func (app *stakingApplication) onEpochChange(ctx *api.Context, epoch beacon.Epoc
 			"num_shares", shareAmount,
 		)
 
+		if shareAmount.IsZero() {
+			// Everything has been re-delegated.
+			continue
+		}
+
 		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&staking.ReclaimEscrowEvent{
 			Owner:  e.DelegatorAddr,
 			Escrow: e.EscrowAddr,
@@ -289,6 +320,22 @@ func (app *stakingApplication) onEpochChange(ctx *api.Context, epoch beacon.Epoc
 		}))
 	}
 
+	// Prune evidence processed before the start of the debonding interval.
+	debondingInterval, err := state.DebondingInterval(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query debonding interval: %w", err)
+	}
+	if epoch > debondingInterval {
+		if err = state.PruneEvidence(ctx, epoch-debondingInterval); err != nil {
+			return fmt.Errorf("failed to prune evidence: %w", err)
+		}
+	}
+
+	// Remove dust accounts.
+	if err = app.reapDustAccounts(ctx, state); err != nil {
+		return fmt.Errorf("staking/tendermint: failed to reap dust accounts: %w", err)
+	}
+
 	// Add signing rewards.
 	if err := app.rewardEpochSigning(ctx, epoch); err != nil {
 		ctx.Logger().Error("failed to add signing rewards",
@@ -300,6 +347,120 @@ func (app *stakingApplication) onEpochChange(ctx *api.Context, epoch beacon.Epoc
 	return nil
 }
 
+func (app *stakingApplication) completeRedelegations(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	e *stakingState.DebondingQueueEntry,
+) error {
+	redelegations, err := state.PendingRedelegations(ctx, e.DelegatorAddr, e.EscrowAddr, e.Epoch)
+	if err != nil {
+		return fmt.Errorf("failed to query pending redelegations: %w", err)
+	}
+	if len(redelegations) == 0 {
+		return nil
+	}
+
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+
+	for _, rd := range redelegations {
+		if err = state.RemoveRedelegation(ctx, e.DelegatorAddr, e.EscrowAddr, rd); err != nil {
+			return fmt.Errorf("failed to remove redelegation: %w", err)
+		}
+		if e.Delegation.Shares.Cmp(&rd.Shares) < 0 {
+			ctx.Logger().Error("redelegation shares exceed debonding delegation shares",
+				"escrow_addr", e.EscrowAddr,
+				"delegator_addr", e.DelegatorAddr,
+				"to", rd.To,
+				"shares", rd.Shares,
+				"debonding_shares", e.Delegation.Shares,
+			)
+			return fmt.Errorf("staking/tendermint: inconsistent redelegation shares")
+		}
+
+		escrow, err := state.Account(ctx, e.EscrowAddr)
+		if err != nil {
+			return fmt.Errorf("failed to query escrow account: %w", err)
+		}
+		to, err := state.Account(ctx, rd.To)
+		if err != nil {
+			return fmt.Errorf("failed to query destination account: %w", err)
+		}
+
+		// In case delegation has been disabled in the meantime or the destination pool has lost
+		// everything due to slashing, the stake is released into the general account instead.
+		if params.DisableDelegation || (!to.Escrow.Active.TotalShares.IsZero() && to.Escrow.Active.Balance.IsZero()) {
+			ctx.Logger().Warn("unable to complete redelegation, releasing stake instead",
+				"escrow_addr", e.EscrowAddr,
+				"delegator_addr", e.DelegatorAddr,
+				"to", rd.To,
+				"shares", rd.Shares,
+			)
+			continue
+		}
+
+		delegation, err := state.Delegation(ctx, e.DelegatorAddr, rd.To)
+		if err != nil {
+			return fmt.Errorf("failed to query delegation: %w", err)
+		}
+
+		var baseUnits quantity.Quantity
+		if err = escrow.Escrow.Debonding.Withdraw(&baseUnits, &e.Delegation.Shares, &rd.Shares); err != nil {
+			ctx.Logger().Error("failed to redeem redelegated debonding shares",
+				"err", err,
+				"escrow_addr", e.EscrowAddr,
+				"delegator_addr", e.DelegatorAddr,
+				"shares", rd.Shares,
+			)
+			return fmt.Errorf("staking/tendermint: failed to redeem debonding shares: %w", err)
+		}
+		stakeAmount := baseUnits.Clone()
+
+		newShares, err := to.Escrow.Active.Deposit(&delegation.Shares, &baseUnits, stakeAmount)
+		if err != nil {
+			ctx.Logger().Error("failed to escrow redelegated stake",
+				"err", err,
+				"escrow_addr", e.EscrowAddr,
+				"delegator_addr", e.DelegatorAddr,
+				"to", rd.To,
+				"base_units", stakeAmount,
+			)
+			return fmt.Errorf("staking/tendermint: failed to escrow redelegated stake: %w", err)
+		}
+
+		// Update state.
+		if err = state.SetAccount(ctx, e.EscrowAddr, escrow); err != nil {
+			return fmt.Errorf("failed to set escrow (%s) account: %w", e.EscrowAddr, err)
+		}
+		if err = state.SetAccount(ctx, rd.To, to); err != nil {
+			return fmt.Errorf("failed to set destination (%s) account: %w", rd.To, err)
+		}
+		if err = state.SetDelegation(ctx, e.DelegatorAddr, rd.To, delegation); err != nil {
+			return fmt.Errorf("failed to set delegation: %w", err)
+		}
+
+		ctx.Logger().Debug("redelegated stake",
+			"escrow_addr", e.EscrowAddr,
+			"delegator_addr", e.DelegatorAddr,
+			"to", rd.To,
+			"base_units", stakeAmount,
+			"new_shares", newShares,
+		)
+
+		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&staking.RedelegateEscrowEvent{
+			Owner:     e.DelegatorAddr,
+			From:      e.EscrowAddr,
+			To:        rd.To,
+			Amount:    *stakeAmount,
+			NewShares: *newShares,
+		}))
+	}
+
+	return nil
+}
+
 // New constructs a new staking application instance.
 func New() api.Application {
 	return &stakingApplication{}
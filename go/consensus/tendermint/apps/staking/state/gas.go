@@ -22,6 +22,10 @@ func (fak feeAccumulatorKey) NewDefault() interface{} {
 // in a block.
 type feeAccumulator struct {
 	balance quantity.Quantity
+	tips    quantity.Quantity
+
+	// gasPrices are the effective gas prices of all transactions in the block.
+	gasPrices []*quantity.Quantity
 }
 
 // AuthenticateAndPayFees authenticates the message signer and makes sure that
@@ -75,7 +79,7 @@ func AuthenticateAndPayFees(
 
 		// Check that there is enough balance to pay fees. For the non-CheckTx case
 		// this happens during Move below.
-		if account.General.Balance.Cmp(&fee.Amount) < 0 {
+		if account.General.Balance.Cmp(fee.TotalAmount()) < 0 {
 			return transaction.ErrInsufficientFeeBalance
 		}
 
@@ -92,11 +96,20 @@ func AuthenticateAndPayFees(
 		return nil
 	}
 
-	// Transfer fee to per-block fee accumulator.
+	// Transfer fee and tip to per-block fee accumulator.
 	feeAcc := ctx.BlockContext().Get(feeAccumulatorKey{}).(*feeAccumulator)
+	if account.General.Balance.Cmp(fee.TotalAmount()) < 0 {
+		return fmt.Errorf("staking: failed to pay fees: %w", transaction.ErrInsufficientFeeBalance)
+	}
 	if err := quantity.Move(&feeAcc.balance, &account.General.Balance, &fee.Amount); err != nil {
 		return fmt.Errorf("staking: failed to pay fees: %w", err)
 	}
+	if fee.Tip != nil {
+		if err := quantity.Move(&feeAcc.tips, &account.General.Balance, fee.Tip); err != nil {
+			return fmt.Errorf("staking: failed to pay tip: %w", err)
+		}
+	}
+	feeAcc.gasPrices = append(feeAcc.gasPrices, fee.EffectiveGasPrice())
 
 	account.General.Nonce++
 	if err := state.SetAccount(ctx, addr, account); err != nil {
@@ -104,11 +117,11 @@ func AuthenticateAndPayFees(
 	}
 
 	// Emit transfer event if fee is non-zero.
-	if !fee.Amount.IsZero() {
+	if totalAmount := fee.TotalAmount(); !totalAmount.IsZero() {
 		ctx.EmitEvent(abciAPI.NewEventBuilder(AppName).TypedAttribute(&staking.TransferEvent{
 			From:   addr,
 			To:     staking.FeeAccumulatorAddress,
-			Amount: fee.Amount,
+			Amount: *totalAmount,
 		}))
 	}
 
@@ -127,6 +140,21 @@ func BlockFees(ctx *abciAPI.Context) quantity.Quantity {
 	return ctx.BlockContext().Get(feeAccumulatorKey{}).(*feeAccumulator).balance
 }
 
+// BlockTips returns the accumulated tip balance for the current block.
+func BlockTips(ctx *abciAPI.Context) quantity.Quantity {
+	return ctx.BlockContext().Get(feeAccumulatorKey{}).(*feeAccumulator).tips
+}
+
+// BlockFeeSummary returns the fee summary for the current block. In case no transactions have
+// been included in the current block, nil is returned.
+func BlockFeeSummary(ctx *abciAPI.Context) *staking.FeeSummaryEvent {
+	feeAcc := ctx.BlockContext().Get(feeAccumulatorKey{}).(*feeAccumulator)
+	if len(feeAcc.gasPrices) == 0 {
+		return nil
+	}
+	return staking.NewFeeSummaryEvent(ctx.BlockHeight()+1, &feeAcc.balance, &feeAcc.tips, feeAcc.gasPrices)
+}
+
 // proposerKey is the block context key.
 type proposerKey struct{}
 
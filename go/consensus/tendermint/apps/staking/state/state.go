@@ -13,9 +13,12 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/proof"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 )
 
 var (
@@ -65,6 +68,29 @@ var (
 	//
 	// Value is a CBOR-serialized quantity.
 	governanceDepositsKeyFmt = keyformat.New(0x59)
+	// evidenceKeyFmt is the key format used for recently processed evidence of validator
+	// misbehavior (epoch, height, index).
+	//
+	// Value is a CBOR-serialized staking.EvidenceEvent.
+	evidenceKeyFmt = keyformat.New(0x5a, uint64(0), uint64(0), uint32(0))
+	// feeSummaryKeyFmt is the key format used for fee summaries of recent blocks (height).
+	//
+	// Value is a CBOR-serialized staking.FeeSummaryEvent.
+	feeSummaryKeyFmt = keyformat.New(0x5b, uint64(0))
+	// redelegationKeyFmt is the key format used for pending re-delegations
+	// (delegator address, source escrow address, epoch, destination escrow address).
+	//
+	// Value is a CBOR-serialized staking.Redelegation.
+	redelegationKeyFmt = keyformat.New(0x5c, &staking.Address{}, &staking.Address{}, uint64(0), &staking.Address{})
+	// tokenMetadataKeyFmt is the key format used for the token metadata.
+	//
+	// Value is a CBOR-serialized token.Metadata.
+	tokenMetadataKeyFmt = keyformat.New(0x5d)
+	// dustReaperCursorKeyFmt is the key format used for the address at which the dust account
+	// reaper continues at the next epoch.
+	//
+	// Value is a CBOR-serialized account address.
+	dustReaperCursorKeyFmt = keyformat.New(0x5e)
 
 	logger = logging.GetLogger("tendermint/staking")
 )
@@ -100,6 +126,26 @@ func (s *ImmutableState) CommonPool(ctx context.Context) (*quantity.Quantity, er
 	return s.loadStoredBalance(ctx, commonPoolKeyFmt)
 }
 
+// TokenMetadata returns the token metadata.
+//
+// In case the token metadata has not been stored in state (e.g., for networks that were started
+// before it was introduced), nil is returned.
+func (s *ImmutableState) TokenMetadata(ctx context.Context) (*token.Metadata, error) {
+	raw, err := s.is.Get(ctx, tokenMetadataKeyFmt.Encode())
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var meta token.Metadata
+	if err = cbor.Unmarshal(raw, &meta); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &meta, nil
+}
+
 // ConsensusParameters returns the consensus parameters.
 func (s *ImmutableState) ConsensusParameters(ctx context.Context) (*staking.ConsensusParameters, error) {
 	raw, err := s.is.Get(ctx, parametersKeyFmt.Encode())
@@ -174,6 +220,46 @@ func (s *ImmutableState) Addresses(ctx context.Context) ([]staking.Address, erro
 	return addresses, nil
 }
 
+// AddressesFrom returns at most limit non-empty addresses from the staking ledger, starting at
+// the given address.
+func (s *ImmutableState) AddressesFrom(ctx context.Context, start staking.Address, limit int) ([]staking.Address, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var addresses []staking.Address
+	for it.Seek(accountKeyFmt.Encode(&start)); it.Valid() && len(addresses) < limit; it.Next() {
+		var addr staking.Address
+		if !accountKeyFmt.Decode(it.Key(), &addr) {
+			break
+		}
+
+		addresses = append(addresses, addr)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return addresses, nil
+}
+
+// DustReaperCursor returns the address at which the dust account reaper should continue.
+//
+// In case the reaper should start at the beginning of the ledger, an empty address is returned.
+func (s *ImmutableState) DustReaperCursor(ctx context.Context) (staking.Address, error) {
+	var addr staking.Address
+	value, err := s.is.Get(ctx, dustReaperCursorKeyFmt.Encode())
+	if err != nil {
+		return addr, abciAPI.UnavailableStateError(err)
+	}
+	if value == nil {
+		return addr, nil
+	}
+
+	if err = cbor.Unmarshal(value, &addr); err != nil {
+		return addr, abciAPI.UnavailableStateError(err)
+	}
+	return addr, nil
+}
+
 // Account returns the staking account for the given account address.
 func (s *ImmutableState) Account(ctx context.Context, address staking.Address) (*staking.Account, error) {
 	if !address.IsValid() {
@@ -195,6 +281,41 @@ func (s *ImmutableState) Account(ctx context.Context, address staking.Address) (
 	return &ent, nil
 }
 
+// AccountWithProof returns the staking account for the given account address together with
+// a proof of the account state against the consensus state root.
+func (s *ImmutableState) AccountWithProof(ctx context.Context, address staking.Address) (*staking.AccountWithProof, error) {
+	account, err := s.Account(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.is.GetProof(ctx, accountKeyFmt.Encode(&address))
+	if err != nil {
+		return nil, err
+	}
+	return &staking.AccountWithProof{
+		Account: account,
+		Proof:   p,
+	}, nil
+}
+
+// VerifyAccountProof verifies the account proof for the given account address against a trusted
+// consensus state root and returns the proven account.
+func VerifyAccountProof(ctx context.Context, root node.Root, address staking.Address, p *proof.StateProof) (*staking.Account, error) {
+	value, err := p.Verify(ctx, root, accountKeyFmt.Encode(&address))
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return &staking.Account{}, nil
+	}
+
+	var ent staking.Account
+	if err = cbor.Unmarshal(value, &ent); err != nil {
+		return nil, fmt.Errorf("tendermint/staking: malformed account: %w", err)
+	}
+	return &ent, nil
+}
+
 // EscrowBalance returns the escrow balance for the given account address.
 func (s *ImmutableState) EscrowBalance(ctx context.Context, address staking.Address) (*quantity.Quantity, error) {
 	account, err := s.Account(ctx, address)
@@ -286,6 +407,34 @@ func (s *ImmutableState) DelegationsFor(
 	return delegations, nil
 }
 
+// Delegators returns the addresses of all accounts that have active or debonding delegations.
+func (s *ImmutableState) Delegators(ctx context.Context) (map[staking.Address]bool, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	delegators := make(map[staking.Address]bool)
+	for it.Seek(delegationKeyFmt.Encode()); it.Valid(); it.Next() {
+		var escrowAddr staking.Address
+		var delegatorAddr staking.Address
+		if !delegationKeyFmt.Decode(it.Key(), &escrowAddr, &delegatorAddr) {
+			break
+		}
+		delegators[delegatorAddr] = true
+	}
+	for it.Seek(debondingDelegationKeyFmt.Encode()); it.Valid(); it.Next() {
+		var delegatorAddr staking.Address
+		var escrowAddr staking.Address
+		if !debondingDelegationKeyFmt.Decode(it.Key(), &delegatorAddr, &escrowAddr) {
+			break
+		}
+		delegators[delegatorAddr] = true
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return delegators, nil
+}
+
 func (s *ImmutableState) DelegationsTo(
 	ctx context.Context,
 	destAddr staking.Address,
@@ -429,6 +578,103 @@ func (s *ImmutableState) DebondingDelegationsTo(
 	return delegations, nil
 }
 
+// Redelegations returns all pending re-delegations.
+func (s *ImmutableState) Redelegations(
+	ctx context.Context,
+) (map[staking.Address]map[staking.Address][]*staking.Redelegation, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	redelegations := make(map[staking.Address]map[staking.Address][]*staking.Redelegation)
+	for it.Seek(redelegationKeyFmt.Encode()); it.Valid(); it.Next() {
+		var delegatorAddr staking.Address
+		var escrowAddr staking.Address
+		if !redelegationKeyFmt.Decode(it.Key(), &delegatorAddr, &escrowAddr) {
+			break
+		}
+
+		var rd staking.Redelegation
+		if err := cbor.Unmarshal(it.Value(), &rd); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		if redelegations[delegatorAddr] == nil {
+			redelegations[delegatorAddr] = make(map[staking.Address][]*staking.Redelegation)
+		}
+		redelegations[delegatorAddr][escrowAddr] = append(redelegations[delegatorAddr][escrowAddr], &rd)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return redelegations, nil
+}
+
+// RedelegationsFor returns the pending re-delegations of the given delegator, keyed by the
+// source escrow address.
+func (s *ImmutableState) RedelegationsFor(
+	ctx context.Context,
+	delegatorAddr staking.Address,
+) (map[staking.Address][]*staking.Redelegation, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	redelegations := make(map[staking.Address][]*staking.Redelegation)
+	for it.Seek(redelegationKeyFmt.Encode(&delegatorAddr)); it.Valid(); it.Next() {
+		var decDelegatorAddr staking.Address
+		var escrowAddr staking.Address
+		if !redelegationKeyFmt.Decode(it.Key(), &decDelegatorAddr, &escrowAddr) || !decDelegatorAddr.Equal(delegatorAddr) {
+			break
+		}
+
+		var rd staking.Redelegation
+		if err := cbor.Unmarshal(it.Value(), &rd); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		redelegations[escrowAddr] = append(redelegations[escrowAddr], &rd)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return redelegations, nil
+}
+
+// PendingRedelegations returns the re-delegations of the given delegator out of the given escrow
+// account that complete at the given epoch.
+func (s *ImmutableState) PendingRedelegations(
+	ctx context.Context,
+	delegatorAddr, escrowAddr staking.Address,
+	epoch beacon.EpochTime,
+) ([]*staking.Redelegation, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var redelegations []*staking.Redelegation
+	for it.Seek(redelegationKeyFmt.Encode(&delegatorAddr, &escrowAddr, uint64(epoch))); it.Valid(); it.Next() {
+		var (
+			decDelegatorAddr staking.Address
+			decEscrowAddr    staking.Address
+			decEpoch         uint64
+		)
+		if !redelegationKeyFmt.Decode(it.Key(), &decDelegatorAddr, &decEscrowAddr, &decEpoch) {
+			break
+		}
+		if !decDelegatorAddr.Equal(delegatorAddr) || !decEscrowAddr.Equal(escrowAddr) || decEpoch != uint64(epoch) {
+			break
+		}
+
+		var rd staking.Redelegation
+		if err := cbor.Unmarshal(it.Value(), &rd); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+		redelegations = append(redelegations, &rd)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return redelegations, nil
+}
+
 type DebondingQueueEntry struct {
 	Epoch         beacon.EpochTime
 	DelegatorAddr staking.Address
@@ -488,6 +734,53 @@ func (s *ImmutableState) GovernanceDeposits(ctx context.Context) (*quantity.Quan
 	return s.loadStoredBalance(ctx, governanceDepositsKeyFmt)
 }
 
+// Evidence returns the recently processed evidence of validator misbehavior.
+func (s *ImmutableState) Evidence(ctx context.Context) ([]*staking.EvidenceEvent, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var evidence []*staking.EvidenceEvent
+	for it.Seek(evidenceKeyFmt.Encode()); it.Valid(); it.Next() {
+		if !evidenceKeyFmt.Decode(it.Key()) {
+			break
+		}
+
+		var ev staking.EvidenceEvent
+		if err := cbor.Unmarshal(it.Value(), &ev); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+		evidence = append(evidence, &ev)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return evidence, nil
+}
+
+// FeeSummaries returns the fee summaries of recent blocks containing transactions, ordered by
+// height.
+func (s *ImmutableState) FeeSummaries(ctx context.Context) ([]*staking.FeeSummaryEvent, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var summaries []*staking.FeeSummaryEvent
+	for it.Seek(feeSummaryKeyFmt.Encode()); it.Valid(); it.Next() {
+		if !feeSummaryKeyFmt.Decode(it.Key()) {
+			break
+		}
+
+		var fs staking.FeeSummaryEvent
+		if err := cbor.Unmarshal(it.Value(), &fs); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+		summaries = append(summaries, &fs)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return summaries, nil
+}
+
 type EpochSigning struct {
 	Total    uint64
 	ByEntity map[signature.PublicKey]uint64
@@ -572,6 +865,24 @@ func (s *MutableState) SetAccount(ctx context.Context, addr staking.Address, acc
 	return abciAPI.UnavailableStateError(err)
 }
 
+// RemoveAccount removes the staking account for the given account address.
+func (s *MutableState) RemoveAccount(ctx context.Context, addr staking.Address) error {
+	err := s.ms.Remove(ctx, accountKeyFmt.Encode(&addr))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// SetDustReaperCursor sets the address at which the dust account reaper should continue.
+//
+// Setting an empty address makes the reaper start at the beginning of the ledger.
+func (s *MutableState) SetDustReaperCursor(ctx context.Context, addr staking.Address) error {
+	if addr.Equal(staking.Address{}) {
+		err := s.ms.Remove(ctx, dustReaperCursorKeyFmt.Encode())
+		return abciAPI.UnavailableStateError(err)
+	}
+	err := s.ms.Insert(ctx, dustReaperCursorKeyFmt.Encode(), cbor.Marshal(addr))
+	return abciAPI.UnavailableStateError(err)
+}
+
 func (s *MutableState) SetTotalSupply(ctx context.Context, q *quantity.Quantity) error {
 	err := s.ms.Insert(ctx, totalSupplyKeyFmt.Encode(), cbor.Marshal(q))
 	return abciAPI.UnavailableStateError(err)
@@ -582,6 +893,17 @@ func (s *MutableState) SetCommonPool(ctx context.Context, q *quantity.Quantity)
 	return abciAPI.UnavailableStateError(err)
 }
 
+// SetTokenMetadata sets the token metadata.
+//
+// NOTE: This method must only be called from InitChain/EndBlock contexts.
+func (s *MutableState) SetTokenMetadata(ctx context.Context, meta *token.Metadata) error {
+	if err := s.is.CheckContextMode(ctx, []abciAPI.ContextMode{abciAPI.ContextInitChain, abciAPI.ContextEndBlock}); err != nil {
+		return err
+	}
+	err := s.ms.Insert(ctx, tokenMetadataKeyFmt.Encode(), cbor.Marshal(meta))
+	return abciAPI.UnavailableStateError(err)
+}
+
 // SetConsensusParameters sets staking consensus parameters.
 //
 // NOTE: This method must only be called from InitChain/EndBlock contexts.
@@ -663,6 +985,52 @@ func (s *MutableState) SetDebondingDelegation(
 	return nil
 }
 
+// SetRedelegation records a pending re-delegation of the given delegator out of the given escrow
+// account. If a re-delegation with the same destination and end time already exists, the shares
+// are merged.
+func (s *MutableState) SetRedelegation(
+	ctx context.Context,
+	delegatorAddr, escrowAddr staking.Address,
+	rd *staking.Redelegation,
+) error {
+	key := redelegationKeyFmt.Encode(&delegatorAddr, &escrowAddr, uint64(rd.DebondEndTime), &rd.To)
+
+	// Create a copy so we don't modify the passed in object in case we are merging
+	// it with an existing re-delegation.
+	redel := staking.Redelegation{
+		To:            rd.To,
+		Shares:        *rd.Shares.Clone(),
+		DebondEndTime: rd.DebondEndTime,
+	}
+
+	value, err := s.is.Get(ctx, key)
+	if err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	if value != nil {
+		var existing staking.Redelegation
+		if err = cbor.Unmarshal(value, &existing); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+		if err = redel.Shares.Add(&existing.Shares); err != nil {
+			return fmt.Errorf("error merging redelegations: %w", err)
+		}
+	}
+
+	err = s.ms.Insert(ctx, key, cbor.Marshal(redel))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// RemoveRedelegation removes a pending re-delegation.
+func (s *MutableState) RemoveRedelegation(
+	ctx context.Context,
+	delegatorAddr, escrowAddr staking.Address,
+	rd *staking.Redelegation,
+) error {
+	err := s.ms.Remove(ctx, redelegationKeyFmt.Encode(&delegatorAddr, &escrowAddr, uint64(rd.DebondEndTime), &rd.To))
+	return abciAPI.UnavailableStateError(err)
+}
+
 func (s *MutableState) RemoveFromDebondingQueue(
 	ctx context.Context,
 	epoch beacon.EpochTime,
@@ -692,6 +1060,68 @@ func (s *MutableState) SetGovernanceDeposits(ctx context.Context, q *quantity.Qu
 	return abciAPI.UnavailableStateError(err)
 }
 
+// AddEvidence records processed evidence of validator misbehavior.
+func (s *MutableState) AddEvidence(ctx context.Context, height int64, index uint32, ev *staking.EvidenceEvent) error {
+	err := s.ms.Insert(ctx, evidenceKeyFmt.Encode(uint64(ev.Epoch), uint64(height), index), cbor.Marshal(ev))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// PruneEvidence removes all evidence that has been processed before the given epoch.
+func (s *MutableState) PruneEvidence(ctx context.Context, epoch beacon.EpochTime) error {
+	it := s.ms.NewIterator(ctx)
+	defer it.Close()
+
+	var toDelete [][]byte
+	for it.Seek(evidenceKeyFmt.Encode()); it.Valid(); it.Next() {
+		var decEpoch uint64
+		if !evidenceKeyFmt.Decode(it.Key(), &decEpoch) || decEpoch >= uint64(epoch) {
+			break
+		}
+		toDelete = append(toDelete, it.Key())
+	}
+	if it.Err() != nil {
+		return abciAPI.UnavailableStateError(it.Err())
+	}
+
+	for _, key := range toDelete {
+		if err := s.ms.Remove(ctx, key); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+	return nil
+}
+
+// AddFeeSummary records the fee summary of a block.
+func (s *MutableState) AddFeeSummary(ctx context.Context, fs *staking.FeeSummaryEvent) error {
+	err := s.ms.Insert(ctx, feeSummaryKeyFmt.Encode(uint64(fs.Height)), cbor.Marshal(fs))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// PruneFeeSummaries removes all fee summaries of blocks before the given height.
+func (s *MutableState) PruneFeeSummaries(ctx context.Context, height int64) error {
+	it := s.ms.NewIterator(ctx)
+	defer it.Close()
+
+	var toDelete [][]byte
+	for it.Seek(feeSummaryKeyFmt.Encode()); it.Valid(); it.Next() {
+		var decHeight uint64
+		if !feeSummaryKeyFmt.Decode(it.Key(), &decHeight) || decHeight >= uint64(height) {
+			break
+		}
+		toDelete = append(toDelete, it.Key())
+	}
+	if it.Err() != nil {
+		return abciAPI.UnavailableStateError(it.Err())
+	}
+
+	for _, key := range toDelete {
+		if err := s.ms.Remove(ctx, key); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+	return nil
+}
+
 func slashPool(dst *quantity.Quantity, p *staking.SharePool, amount, total *quantity.Quantity) error {
 	if total.IsZero() {
 		// Nothing to slash.
@@ -1312,3 +1742,23 @@ func NewMutableState(tree mkvs.KeyValueTree) *MutableState {
 		ms: tree,
 	}
 }
+
+func init() {
+	abciAPI.RegisterStateKeyFormats(
+		staking.ModuleName,
+		accountKeyFmt,
+		totalSupplyKeyFmt,
+		commonPoolKeyFmt,
+		delegationKeyFmt,
+		debondingDelegationKeyFmt,
+		debondingQueueKeyFmt,
+		parametersKeyFmt,
+		lastBlockFeesKeyFmt,
+		epochSigningKeyFmt,
+		governanceDepositsKeyFmt,
+		evidenceKeyFmt,
+		feeSummaryKeyFmt,
+		redelegationKeyFmt,
+		tokenMetadataKeyFmt,
+	)
+}
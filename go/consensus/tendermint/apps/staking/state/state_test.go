@@ -648,3 +648,44 @@ func TestTransferFromCommon(t *testing.T) {
 	require.EqualValues(*quantity.NewFromUint64(100), acc1.General.Balance, "amount should be unchanged")
 	require.EqualValues(*quantity.NewFromUint64(0), acc1.Escrow.Active.Balance, "escrow amount should be unchanged")
 }
+
+func TestFeeSummaries(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	summaries, err := s.FeeSummaries(ctx)
+	require.NoError(err, "FeeSummaries")
+	require.Empty(summaries, "there should be no fee summaries initially")
+
+	for _, height := range []int64{12, 10, 11} {
+		fs := staking.NewFeeSummaryEvent(
+			height,
+			quantity.NewFromUint64(100),
+			quantity.NewFromUint64(10),
+			[]*quantity.Quantity{quantity.NewFromUint64(uint64(height))},
+		)
+		err = s.AddFeeSummary(ctx, fs)
+		require.NoError(err, "AddFeeSummary")
+	}
+
+	summaries, err = s.FeeSummaries(ctx)
+	require.NoError(err, "FeeSummaries")
+	require.Len(summaries, 3, "all fee summaries should be returned")
+	for i, fs := range summaries {
+		require.EqualValues(10+i, fs.Height, "fee summaries should be ordered by height")
+		require.EqualValues(*quantity.NewFromUint64(uint64(fs.Height)), fs.MedianGasPrice, "fee summary should round trip")
+	}
+
+	err = s.PruneFeeSummaries(ctx, 12)
+	require.NoError(err, "PruneFeeSummaries")
+	summaries, err = s.FeeSummaries(ctx)
+	require.NoError(err, "FeeSummaries")
+	require.Len(summaries, 1, "old fee summaries should be pruned")
+	require.EqualValues(12, summaries[0].Height, "recent fee summaries should be kept")
+}
@@ -20,6 +20,15 @@ func isTransferPermitted(params *staking.ConsensusParameters, fromAddr staking.A
 	return
 }
 
+// checkMinBalance checks that the remaining general balance of an account is either zero or not
+// lower than the minimum balance.
+func checkMinBalance(params *staking.ConsensusParameters, balance *quantity.Quantity) error {
+	if balance.IsZero() || balance.Cmp(&params.MinBalance) >= 0 {
+		return nil
+	}
+	return staking.ErrUnderMinBalance
+}
+
 func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.MutableState, xfer *staking.Transfer) error {
 	if ctx.IsCheckOnly() {
 		return nil
@@ -44,6 +53,11 @@ func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.Mu
 		return staking.ErrForbidden
 	}
 
+	// Check if sender transfers at least a minimum amount.
+	if xfer.Amount.Cmp(&params.MinTransfer) < 0 {
+		return staking.ErrUnderMinTransferAmount
+	}
+
 	from, err := state.Account(ctx, fromAddr)
 	if err != nil {
 		return fmt.Errorf("failed to fetch account: %w", err)
@@ -78,6 +92,9 @@ func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.Mu
 			)
 			return err
 		}
+		if err = checkMinBalance(params, &from.General.Balance); err != nil {
+			return err
+		}
 
 		if err = state.SetAccount(ctx, xfer.To, to); err != nil {
 			return fmt.Errorf("failed to set account: %w", err)
@@ -140,6 +157,9 @@ func (app *stakingApplication) burn(ctx *api.Context, state *stakingState.Mutabl
 		)
 		return err
 	}
+	if err = checkMinBalance(params, &from.General.Balance); err != nil {
+		return err
+	}
 
 	totalSupply, err := state.TotalSupply(ctx)
 	if err != nil {
@@ -240,6 +260,9 @@ func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.M
 		)
 		return err
 	}
+	if err = checkMinBalance(params, &from.General.Balance); err != nil {
+		return err
+	}
 
 	// Commit accounts.
 	if err = state.SetAccount(ctx, fromAddr, from); err != nil {
@@ -600,6 +623,11 @@ func (app *stakingApplication) withdraw(
 		return staking.ErrInvalidArgument
 	}
 
+	// Check if beneficiary withdraws at least a minimum amount.
+	if withdraw.Amount.Cmp(&params.MinTransfer) < 0 {
+		return staking.ErrUnderMinTransferAmount
+	}
+
 	from, err := state.Account(ctx, withdraw.From)
 	if err != nil {
 		return fmt.Errorf("failed to fetch account: %w", err)
@@ -632,6 +660,9 @@ func (app *stakingApplication) withdraw(
 	if err = quantity.Move(&to.General.Balance, &from.General.Balance, &withdraw.Amount); err != nil {
 		return staking.ErrInsufficientBalance
 	}
+	if err = checkMinBalance(params, &from.General.Balance); err != nil {
+		return err
+	}
 
 	if err = state.SetAccount(ctx, toAddr, to); err != nil {
 		return fmt.Errorf("failed to set account: %w", err)
@@ -656,3 +687,179 @@ func (app *stakingApplication) withdraw(
 
 	return nil
 }
+
+func (app *stakingApplication) redelegate(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	redelegate *staking.Redelegate,
+) error {
+	// No sense if there is nothing to re-delegate or the accounts are the same.
+	if redelegate.Shares.IsZero() || redelegate.From.Equal(redelegate.To) {
+		return staking.ErrInvalidArgument
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if err = ctx.Gas().UseGas(1, staking.GasOpRedelegate, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early for simulation as we only need gas accounting.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	// Re-delegations are disabled in case either max re-delegations is zero or if delegation is
+	// disabled (at least one of the accounts is not the delegator's own account).
+	if params.DisableDelegation || params.MaxRedelegations == 0 {
+		return staking.ErrForbidden
+	}
+
+	delegatorAddr := ctx.CallerAddress()
+	if delegatorAddr.IsReserved() || redelegate.From.IsReserved() || redelegate.To.IsReserved() {
+		return staking.ErrForbidden
+	}
+
+	from, err := state.Account(ctx, redelegate.From)
+	if err != nil {
+		return fmt.Errorf("failed to fetch account: %w", err)
+	}
+
+	// Fetch delegation.
+	delegation, err := state.Delegation(ctx, delegatorAddr, redelegate.From)
+	if err != nil {
+		return fmt.Errorf("failed to fetch delegation: %w", err)
+	}
+
+	// Fetch debonding interval and current epoch.
+	debondingInterval, err := state.DebondingInterval(ctx)
+	if err != nil {
+		ctx.Logger().Error("Redelegate: failed to query debonding interval",
+			"err", err,
+		)
+		return err
+	}
+	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
+
+	rd := staking.Redelegation{
+		To:            redelegate.To,
+		DebondEndTime: epoch + debondingInterval,
+	}
+
+	// Make sure the account does not go over the limit of pending re-delegations. Re-delegations
+	// with the same source, destination and end time are merged and do not count separately.
+	redelegations, err := state.RedelegationsFor(ctx, delegatorAddr)
+	if err != nil {
+		return fmt.Errorf("failed to fetch redelegations: %w", err)
+	}
+	var (
+		numRedelegations uint32
+		merged           bool
+	)
+	for escrowAddr, rds := range redelegations {
+		for _, existing := range rds {
+			numRedelegations++
+			if escrowAddr.Equal(redelegate.From) && existing.To.Equal(rd.To) && existing.DebondEndTime == rd.DebondEndTime {
+				merged = true
+			}
+		}
+	}
+	if !merged && numRedelegations >= params.MaxRedelegations {
+		return staking.ErrTooManyRedelegations
+	}
+
+	// Check if the re-delegated stake is at least the minimum delegation amount.
+	stakeAmount, err := from.Escrow.Active.StakeForShares(&redelegate.Shares)
+	if err != nil {
+		return fmt.Errorf("failed to compute stake for shares: %w", err)
+	}
+	if stakeAmount.Cmp(&params.MinDelegationAmount) < 0 {
+		return staking.ErrUnderMinDelegationAmount
+	}
+
+	deb := staking.DebondingDelegation{
+		DebondEndTime: rd.DebondEndTime,
+	}
+
+	var baseUnits quantity.Quantity
+
+	if err = from.Escrow.Active.Withdraw(&baseUnits, &delegation.Shares, &redelegate.Shares); err != nil {
+		ctx.Logger().Error("Redelegate: failed to redeem escrow shares",
+			"err", err,
+			"owner", delegatorAddr,
+			"from", redelegate.From,
+			"to", redelegate.To,
+			"shares", redelegate.Shares,
+		)
+		return err
+	}
+
+	var debondingShares *quantity.Quantity
+	if debondingShares, err = from.Escrow.Debonding.Deposit(&deb.Shares, &baseUnits, stakeAmount); err != nil {
+		ctx.Logger().Error("Redelegate: failed to debond shares",
+			"err", err,
+			"owner", delegatorAddr,
+			"from", redelegate.From,
+			"to", redelegate.To,
+			"shares", redelegate.Shares,
+			"base_units", stakeAmount,
+		)
+		return err
+	}
+
+	if !baseUnits.IsZero() {
+		ctx.Logger().Error("Redelegate: inconsistency in transferring stake from active escrow to debonding",
+			"remaining_base_units", baseUnits,
+		)
+		return staking.ErrInvalidArgument
+	}
+	rd.Shares = *debondingShares.Clone()
+
+	// The re-delegated shares are part of a regular debonding delegation so that they are subject
+	// to the same rules (e.g., slashing) until the end of the debonding period.
+	if err = state.SetDebondingDelegation(ctx, delegatorAddr, redelegate.From, deb.DebondEndTime, &deb); err != nil {
+		return fmt.Errorf("failed to set debonding delegation: %w", err)
+	}
+	if err = state.SetRedelegation(ctx, delegatorAddr, redelegate.From, &rd); err != nil {
+		return fmt.Errorf("failed to set redelegation: %w", err)
+	}
+
+	if err = state.SetDelegation(ctx, delegatorAddr, redelegate.From, delegation); err != nil {
+		return fmt.Errorf("failed to set delegation: %w", err)
+	}
+	if err = state.SetAccount(ctx, redelegate.From, from); err != nil {
+		return fmt.Errorf("failed to set account: %w", err)
+	}
+
+	ctx.Logger().Debug("Redelegate: started redelegating stake",
+		"owner", delegatorAddr,
+		"from", redelegate.From,
+		"to", redelegate.To,
+		"base_units", stakeAmount,
+		"active_shares", redelegate.Shares,
+		"debonding_shares", debondingShares,
+		"debond_end_time", deb.DebondEndTime,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&staking.RedelegationStartEscrowEvent{
+		Owner:           delegatorAddr,
+		From:            redelegate.From,
+		To:              redelegate.To,
+		Amount:          *stakeAmount,
+		ActiveShares:    redelegate.Shares,
+		DebondingShares: *debondingShares,
+		DebondEndTime:   deb.DebondEndTime,
+	}))
+
+	return nil
+}
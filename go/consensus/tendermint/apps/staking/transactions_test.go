@@ -115,6 +115,10 @@ func TestReservedAddresses(t *testing.T) {
 
 	err = app.withdraw(txCtx, stakeState, &staking.Withdraw{})
 	require.EqualError(err, "staking: forbidden by policy", "withdraw for reserved address should error")
+
+	// NOTE: We need to specify redelegate shares since that is checked before the check for reserved address.
+	err = app.redelegate(txCtx, stakeState, &staking.Redelegate{To: staking.Address{1}, Shares: *q.Clone()})
+	require.EqualError(err, "staking: forbidden by policy", "redelegate for reserved address should error")
 }
 
 func TestAllow(t *testing.T) {
@@ -688,3 +692,212 @@ func TestAllowEscrowMessages(t *testing.T) {
 	err = app.reclaimEscrow(txCtx, stakeState, &staking.ReclaimEscrow{Account: addr1, Shares: *quantity.NewFromUint64(1)})
 	require.NoError(err, "reclaim escrow message should work")
 }
+
+func TestRedelegate(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+	app := &stakingApplication{
+		state: appState,
+	}
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DebondingInterval: 1,
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+	pk3 := signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr3 := staking.NewAddress(pk3)
+	pk4 := signature.NewPublicKey("dddfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr4 := staking.NewAddress(pk4)
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100_000),
+		},
+	})
+	require.NoError(err, "SetAccount")
+
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(pk1)
+
+	err = app.addEscrow(txCtx, stakeState, &staking.Escrow{Account: addr2, Amount: *quantity.NewFromUint64(10_000)})
+	require.NoError(err, "AddEscrow")
+
+	// Re-delegations should be disabled by default.
+	err = app.redelegate(txCtx, stakeState, &staking.Redelegate{From: addr2, To: addr3, Shares: *quantity.NewFromUint64(4_000)})
+	require.Equal(staking.ErrForbidden, err, "redelegate should fail when disabled")
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DebondingInterval: 1,
+		MaxRedelegations:  1,
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	err = app.redelegate(txCtx, stakeState, &staking.Redelegate{From: addr2, To: addr2, Shares: *quantity.NewFromUint64(4_000)})
+	require.Equal(staking.ErrInvalidArgument, err, "redelegate to the same account should fail")
+
+	err = app.redelegate(txCtx, stakeState, &staking.Redelegate{From: addr2, To: addr3, Shares: *quantity.NewFromUint64(3_000)})
+	require.NoError(err, "Redelegate")
+	err = app.redelegate(txCtx, stakeState, &staking.Redelegate{From: addr2, To: addr3, Shares: *quantity.NewFromUint64(1_000)})
+	require.NoError(err, "Redelegate with the same destination should be merged")
+	err = app.redelegate(txCtx, stakeState, &staking.Redelegate{From: addr2, To: addr4, Shares: *quantity.NewFromUint64(1_000)})
+	require.Equal(staking.ErrTooManyRedelegations, err, "redelegate should fail when over the limit")
+
+	// Reclaim some more so that the debonding delegation is only partially re-delegated.
+	err = app.reclaimEscrow(txCtx, stakeState, &staking.ReclaimEscrow{Account: addr2, Shares: *quantity.NewFromUint64(1_000)})
+	require.NoError(err, "ReclaimEscrow")
+
+	redelegations, err := stakeState.RedelegationsFor(ctx, addr1)
+	require.NoError(err, "RedelegationsFor")
+	require.Len(redelegations, 1, "there should be redelegations from one account")
+	require.Len(redelegations[addr2], 1, "there should be a single merged redelegation")
+	require.Equal(addr3, redelegations[addr2][0].To, "redelegation destination should be correct")
+	require.EqualValues(1, redelegations[addr2][0].DebondEndTime, "redelegation end time should be correct")
+	require.EqualValues(*quantity.NewFromUint64(4_000), redelegations[addr2][0].Shares, "redelegation shares should be correct")
+
+	deb, err := stakeState.DebondingDelegation(ctx, addr1, addr2, 1)
+	require.NoError(err, "DebondingDelegation")
+	require.EqualValues(*quantity.NewFromUint64(5_000), deb.Shares, "debonding delegation should include redelegated shares")
+
+	// Complete the re-delegation.
+	err = app.onEpochChange(ctx, 1)
+	require.NoError(err, "onEpochChange")
+
+	redelegations, err = stakeState.RedelegationsFor(ctx, addr1)
+	require.NoError(err, "RedelegationsFor")
+	require.Empty(redelegations, "there should be no more pending redelegations")
+
+	del, err := stakeState.Delegation(ctx, addr1, addr3)
+	require.NoError(err, "Delegation")
+	require.EqualValues(*quantity.NewFromUint64(4_000), del.Shares, "redelegated shares should be escrowed")
+	del, err = stakeState.Delegation(ctx, addr1, addr2)
+	require.NoError(err, "Delegation")
+	require.EqualValues(*quantity.NewFromUint64(5_000), del.Shares, "remaining shares should stay escrowed")
+
+	acct, err := stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(91_000), acct.General.Balance, "only reclaimed stake should be released")
+	acct, err = stakeState.Account(ctx, addr3)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(4_000), acct.Escrow.Active.Balance, "redelegated stake should be escrowed")
+	acct, err = stakeState.Account(ctx, addr2)
+	require.NoError(err, "Account")
+	require.True(acct.Escrow.Debonding.Balance.IsZero(), "debonding pool should be empty")
+}
+
+func TestMinTransferAndBalance(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		MinTransfer: *quantity.NewFromUint64(100),
+		MinBalance:  *quantity.NewFromUint64(500),
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+
+	for _, addr := range []staking.Address{addr1, addr2} {
+		err = stakeState.SetAccount(ctx, addr, &staking.Account{
+			General: staking.GeneralAccount{
+				Balance: *quantity.NewFromUint64(1000),
+			},
+		})
+		require.NoError(err, "SetAccount")
+	}
+
+	for _, tc := range []struct {
+		msg      string
+		txSigner signature.PublicKey
+		xfer     *staking.Transfer
+		burn     *staking.Burn
+		err      error
+	}{
+		{
+			"transfer should fail when under min transfer amount",
+			pk1,
+			&staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(50)},
+			nil,
+			staking.ErrUnderMinTransferAmount,
+		},
+		{
+			"transfer should fail when remaining balance is under min balance",
+			pk1,
+			&staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(600)},
+			nil,
+			staking.ErrUnderMinBalance,
+		},
+		{
+			"transfer should succeed when remaining balance is at least min balance",
+			pk1,
+			&staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(500)},
+			nil,
+			nil,
+		},
+		{
+			"transfer should succeed when depleting the balance",
+			pk1,
+			&staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(500)},
+			nil,
+			nil,
+		},
+		{
+			"burn should fail when remaining balance is under min balance",
+			pk2,
+			nil,
+			&staking.Burn{Amount: *quantity.NewFromUint64(1600)},
+			staking.ErrUnderMinBalance,
+		},
+		{
+			"burn should succeed when remaining balance is at least min balance",
+			pk2,
+			nil,
+			&staking.Burn{Amount: *quantity.NewFromUint64(1500)},
+			nil,
+		},
+	} {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(tc.txSigner)
+
+		switch {
+		case tc.xfer != nil:
+			err = app.transfer(txCtx, stakeState, tc.xfer)
+		case tc.burn != nil:
+			err = app.burn(txCtx, stakeState, tc.burn)
+		}
+		require.Equal(tc.err, err, tc.msg)
+	}
+
+	acct1, err := stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	require.True(acct1.General.Balance.IsZero(), "account 1 balance should be depleted")
+	acct2, err := stakeState.Account(ctx, addr2)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(500), acct2.General.Balance, "account 2 balance")
+}
@@ -6,6 +6,10 @@ const (
 	// so no need to reserve a low sequential identifier.
 	AppID uint8 = 0x98
 
+	// StateVersion is the version of the application's state schema.
+	// This application doesn't have any state, so no state version is recorded.
+	StateVersion uint64 = 0
+
 	// AppName is the ABCI application name.
 	AppName string = "999_supplementarysanity"
 )
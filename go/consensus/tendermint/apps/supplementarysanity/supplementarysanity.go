@@ -3,10 +3,11 @@ package supplementarysanity
 import (
 	"fmt"
 	"math/rand"
+	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tendermint/tendermint/abci/types"
 
-	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
@@ -17,6 +18,15 @@ import (
 var (
 	logger = logging.GetLogger("supplementarysanity")
 
+	invariantViolations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_consensus_invariant_violations",
+			Help: "Number of detected consensus state invariant violations.",
+		},
+		[]string{"module", "invariant"},
+	)
+	metricsOnce sync.Once
+
 	_ api.Application = (*supplementarySanityApplication)(nil)
 )
 
@@ -29,6 +39,7 @@ type supplementarySanityApplication struct {
 	interval        int64
 	currentInterval int64
 	checkHeight     int64
+	haltOnViolation bool
 }
 
 func (app *supplementarySanityApplication) Name() string {
@@ -39,6 +50,10 @@ func (app *supplementarySanityApplication) ID() uint8 {
 	return AppID
 }
 
+func (app *supplementarySanityApplication) StateVersion() uint64 {
+	return StateVersion
+}
+
 func (app *supplementarySanityApplication) Methods() []transaction.MethodName {
 	return nil
 }
@@ -110,32 +125,39 @@ func (app *supplementarySanityApplication) endBlockImpl(ctx *api.Context, reques
 	if err != nil {
 		return fmt.Errorf("tendermint/supplementarysanity: failed to GetEpoch: %w", err)
 	}
-	for _, tt := range []struct {
-		name    string
-		checker func(ctx *api.Context, now beacon.EpochTime) error
-	}{
-		{"checkEpochTime", checkEpochTime},
-		{"checkRegistry", checkRegistry},
-		{"checkRootHash", checkRootHash},
-		{"checkStaking", checkStaking},
-		{"checkKeyManager", checkKeyManager},
-		{"checkScheduler", checkScheduler},
-		{"checkBeacon", checkBeacon},
-		{"checkConsensus", checkConsensus},
-		{"checkGovernance", checkGovernance},
-		{"checkHalt", checkHalt},
-		{"checkStakeClaims", checkStakeClaims},
-	} {
-		if err := tt.checker(ctx, now); err != nil {
-			return fmt.Errorf("tendermint/supplementarysanity: check failed %s: %w", tt.name, err)
-		}
+	report := api.CheckInvariants(ctx, now)
+	if report.IsValid() {
+		return nil
 	}
 
-	return nil
+	for _, v := range report.Violations {
+		invariantViolations.With(prometheus.Labels{"module": v.Module, "invariant": v.Name}).Inc()
+		logger.Error("consensus state invariant violated",
+			"height", request.Height,
+			"module", v.Module,
+			"invariant", v.Name,
+			"err", v.Error,
+		)
+	}
+	if !app.haltOnViolation {
+		return nil
+	}
+	v := report.Violations[0]
+	return fmt.Errorf("tendermint/supplementarysanity: invariant %s/%s violated: %s", v.Module, v.Name, v.Error)
 }
 
-func New(interval uint64) api.Application {
+// New creates a new supplementary sanity application that checks all registered consensus state
+// invariants at a random height in each interval of the given number of blocks.
+//
+// In case haltOnViolation is set, any invariant violation halts the node. Otherwise violations
+// are only logged and reported via metrics.
+func New(interval uint64, haltOnViolation bool) api.Application {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(invariantViolations)
+	})
+
 	return &supplementarySanityApplication{
-		interval: int64(interval),
+		interval:        int64(interval),
+		haltOnViolation: haltOnViolation,
 	}
 }
@@ -0,0 +1,171 @@
+// Package archive implements exporting of consensus blocks to an archive
+// before they are pruned and serving them from the archive afterwards.
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+)
+
+// ErrNotFound is the error returned when the requested object is not in the
+// archive.
+var ErrNotFound = errors.New("archive: not found")
+
+// Store is an archive storage backend.
+type Store interface {
+	// Name returns the name of the storage backend.
+	Name() string
+
+	// Put stores the given object under the given key, overwriting any
+	// existing object.
+	Put(ctx context.Context, key string, data []byte) error
+
+	// Get retrieves the object stored under the given key.
+	//
+	// If there is no such object, ErrNotFound is returned.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// Record is an archived consensus block together with its transactions and
+// their results.
+type Record struct {
+	// Block is the consensus block.
+	Block *consensus.Block `json:"block"`
+	// TransactionsWithResults are the block's transactions and their results.
+	TransactionsWithResults *consensus.TransactionsWithResults `json:"txs_with_results"`
+}
+
+func recordKey(height int64) string {
+	// Pad the height so that the keys are ordered by height when listed.
+	return fmt.Sprintf("blocks/%020d", height)
+}
+
+// BlockSource is the source of the archived blocks.
+type BlockSource interface {
+	// GetBlock returns a consensus block at a specific height.
+	GetBlock(ctx context.Context, height int64) (*consensus.Block, error)
+
+	// GetTransactionsWithResults returns a list of transactions and their
+	// execution results, contained within a consensus block at a specific
+	// height.
+	GetTransactionsWithResults(ctx context.Context, height int64) (*consensus.TransactionsWithResults, error)
+}
+
+type archiver struct {
+	logger *logging.Logger
+
+	store  Store
+	source BlockSource
+}
+
+// Implements api.StatePruneHandler.
+func (a *archiver) Prune(ctx context.Context, version uint64) error {
+	height := int64(version)
+	if height < 1 {
+		// Height zero refers to the latest block, there is nothing to archive.
+		return nil
+	}
+
+	blk, err := a.source.GetBlock(ctx, height)
+	if err != nil {
+		return fmt.Errorf("archive: failed to get block %d: %w", height, err)
+	}
+	txs, err := a.source.GetTransactionsWithResults(ctx, height)
+	if err != nil {
+		return fmt.Errorf("archive: failed to get transactions for block %d: %w", height, err)
+	}
+
+	rec := Record{
+		Block:                   blk,
+		TransactionsWithResults: txs,
+	}
+	if err = a.store.Put(ctx, recordKey(height), cbor.Marshal(rec)); err != nil {
+		a.logger.Error("failed to archive block",
+			"err", err,
+			"height", height,
+		)
+		return fmt.Errorf("archive: failed to store block %d: %w", height, err)
+	}
+
+	a.logger.Debug("archived block",
+		"height", height,
+	)
+
+	return nil
+}
+
+// NewArchiver creates a new state prune handler that exports blocks to the
+// given archive before they are pruned.
+//
+// In case exporting a block fails, pruning of the block is blocked until
+// exporting succeeds.
+func NewArchiver(store Store, source BlockSource) api.StatePruneHandler {
+	return &archiver{
+		logger: logging.GetLogger("consensus/tendermint/archive").With("store", store.Name()),
+		store:  store,
+		source: source,
+	}
+}
+
+// Reader serves consensus blocks from an archive.
+type Reader struct {
+	store Store
+}
+
+// GetRecord returns the archived record for the given height.
+//
+// If the block is not in the archive, ErrNotFound is returned.
+func (r *Reader) GetRecord(ctx context.Context, height int64) (*Record, error) {
+	if height < 1 {
+		return nil, ErrNotFound
+	}
+
+	data, err := r.store.Get(ctx, recordKey(height))
+	if err != nil {
+		return nil, err
+	}
+
+	var rec Record
+	if err = cbor.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("archive: malformed record for block %d: %w", height, err)
+	}
+	if rec.Block == nil || rec.Block.Height != height {
+		return nil, fmt.Errorf("archive: corrupted record for block %d", height)
+	}
+	return &rec, nil
+}
+
+// GetBlock returns the archived consensus block at the given height.
+func (r *Reader) GetBlock(ctx context.Context, height int64) (*consensus.Block, error) {
+	rec, err := r.GetRecord(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return rec.Block, nil
+}
+
+// GetTransactionsWithResults returns the archived transactions and their
+// results for the block at the given height.
+func (r *Reader) GetTransactionsWithResults(ctx context.Context, height int64) (*consensus.TransactionsWithResults, error) {
+	rec, err := r.GetRecord(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	if rec.TransactionsWithResults == nil {
+		return &consensus.TransactionsWithResults{}, nil
+	}
+	return rec.TransactionsWithResults, nil
+}
+
+// NewReader creates a new archive reader.
+func NewReader(store Store) *Reader {
+	return &Reader{
+		store: store,
+	}
+}
@@ -0,0 +1,59 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+)
+
+type dirStore struct {
+	dir string
+}
+
+func (s *dirStore) Name() string {
+	return "dir"
+}
+
+func (s *dirStore) Put(ctx context.Context, key string, data []byte) error {
+	fn := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := common.Mkdir(filepath.Dir(fn)); err != nil {
+		return fmt.Errorf("archive/dir: failed to create directory: %w", err)
+	}
+
+	// Write to a temporary file first so that a crash never leaves a
+	// partially written object behind.
+	tmpFn := fn + ".tmp"
+	if err := ioutil.WriteFile(tmpFn, data, 0o600); err != nil {
+		return fmt.Errorf("archive/dir: failed to write object: %w", err)
+	}
+	if err := os.Rename(tmpFn, fn); err != nil {
+		return fmt.Errorf("archive/dir: failed to rename object: %w", err)
+	}
+	return nil
+}
+
+func (s *dirStore) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, filepath.FromSlash(key)))
+	switch {
+	case err == nil:
+		return data, nil
+	case os.IsNotExist(err):
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("archive/dir: failed to read object: %w", err)
+	}
+}
+
+// NewDirStore creates a new archive store backed by a local directory.
+func NewDirStore(dir string) (Store, error) {
+	if err := common.Mkdir(dir); err != nil {
+		return nil, fmt.Errorf("archive/dir: failed to create archive directory: %w", err)
+	}
+	return &dirStore{
+		dir: dir,
+	}, nil
+}
@@ -0,0 +1,191 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	s3RequestTimeout = 30 * time.Second
+	s3MaxErrorBody   = 1024
+
+	s3SigningAlgorithm = "AWS4-HMAC-SHA256"
+	s3SignedHeaders    = "host;x-amz-content-sha256;x-amz-date"
+)
+
+// S3Config is the configuration of an S3-compatible archive store.
+type S3Config struct {
+	// Endpoint is the URL of the S3-compatible service.
+	Endpoint string
+	// Region is the region of the bucket.
+	Region string
+	// Bucket is the name of the bucket.
+	Bucket string
+	// Prefix is the optional prefix of all archived objects.
+	Prefix string
+
+	// AccessKeyID is the access key identifier.
+	AccessKeyID string
+	// SecretAccessKey is the secret access key.
+	SecretAccessKey string
+}
+
+type s3Store struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+
+	now func() time.Time
+}
+
+func (s *s3Store) Name() string {
+	return "s3"
+}
+
+func (s *s3Store) objectURL(key string) string {
+	// Use path-style addressing as it is supported by all S3-compatible services.
+	u := *s.endpoint
+	u.Path = path.Join("/", s.endpoint.Path, s.cfg.Bucket, s.cfg.Prefix, key)
+	return u.String()
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("archive/s3: failed to create request: %w", err)
+	}
+	s.sign(req, data)
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("archive/s3: put request failed: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return s3Error("put", rsp)
+	}
+	return nil
+}
+
+func (s *s3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("archive/s3: failed to create request: %w", err)
+	}
+	s.sign(req, nil)
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("archive/s3: get request failed: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	switch rsp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, s3Error("get", rsp)
+	}
+
+	data, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("archive/s3: failed to read object: %w", err)
+	}
+	return data, nil
+}
+
+// sign signs the request using AWS Signature Version 4.
+func (s *s3Store) sign(req *http.Request, payload []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	payloadHash := sha256.Sum256(payload)
+	payloadHashHex := hex.EncodeToString(payloadHash[:])
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHashHex)
+
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHashHex + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		s3SignedHeaders,
+		payloadHashHex,
+	}, "\n")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		s3SigningAlgorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SigningAlgorithm, s.cfg.AccessKeyID, scope, s3SignedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func s3Error(op string, rsp *http.Response) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(rsp.Body, s3MaxErrorBody))
+	return fmt.Errorf("archive/s3: %s request failed: %s: %s", op, rsp.Status, strings.TrimSpace(string(body)))
+}
+
+// NewS3Store creates a new archive store backed by an S3-compatible object
+// storage service.
+func NewS3Store(cfg S3Config) (Store, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("archive/s3: malformed endpoint: %w", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("archive/s3: unsupported endpoint scheme: '%s'", endpoint.Scheme)
+	}
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("archive/s3: bucket not configured")
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("archive/s3: region not configured")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("archive/s3: credentials not configured")
+	}
+
+	return &s3Store{
+		cfg:      cfg,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: s3RequestTimeout},
+		now:      time.Now,
+	}, nil
+}
@@ -194,6 +194,24 @@ func (sc *serviceClient) GetBeacon(ctx context.Context, height int64) ([]byte, e
 	return q.Beacon(ctx)
 }
 
+func (sc *serviceClient) GetBeaconAt(ctx context.Context, query *beaconAPI.EpochQuery) ([]byte, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.BeaconAt(ctx, query.Epoch)
+}
+
+func (sc *serviceClient) GetProofs(ctx context.Context, query *beaconAPI.EpochQuery) (*beaconAPI.VRFProofs, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.VRFProofs(ctx, query.Epoch)
+}
+
 func (sc *serviceClient) GetVRFState(ctx context.Context, height int64) (*beaconAPI.VRFState, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -49,6 +50,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/abci"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/supplementarysanity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/archive"
 	tmbeacon "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/beacon"
 	tmcommon "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/common"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/crypto"
@@ -83,6 +85,21 @@ const (
 	CfgABCIPruneNumKept = "consensus.tendermint.abci.prune.num_kept"
 	// CfgABCIPruneInterval configures the ABCI state pruning interval.
 	CfgABCIPruneInterval = "consensus.tendermint.abci.prune.interval"
+	// CfgABCIPruneArchiveDir configures the directory where blocks are archived before being
+	// pruned.
+	CfgABCIPruneArchiveDir = "consensus.tendermint.abci.prune.archive.dir"
+	// CfgABCIPruneArchiveS3Endpoint configures the S3-compatible service endpoint where blocks
+	// are archived before being pruned.
+	//
+	// The credentials are taken from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
+	// environment variables.
+	CfgABCIPruneArchiveS3Endpoint = "consensus.tendermint.abci.prune.archive.s3.endpoint"
+	// CfgABCIPruneArchiveS3Region configures the region of the S3 archive bucket.
+	CfgABCIPruneArchiveS3Region = "consensus.tendermint.abci.prune.archive.s3.region"
+	// CfgABCIPruneArchiveS3Bucket configures the S3 archive bucket.
+	CfgABCIPruneArchiveS3Bucket = "consensus.tendermint.abci.prune.archive.s3.bucket"
+	// CfgABCIPruneArchiveS3Prefix configures the prefix of the archived S3 objects.
+	CfgABCIPruneArchiveS3Prefix = "consensus.tendermint.abci.prune.archive.s3.prefix"
 
 	// CfgCheckpointerDisabled disables the ABCI state checkpointer.
 	CfgCheckpointerDisabled = "consensus.tendermint.checkpointer.disabled"
@@ -112,6 +129,9 @@ const (
 	CfgSupplementarySanityEnabled = "consensus.tendermint.supplementarysanity.enabled"
 	// CfgSupplementarySanityInterval configures the supplementary sanity check interval.
 	CfgSupplementarySanityInterval = "consensus.tendermint.supplementarysanity.interval"
+	// CfgSupplementarySanityHaltOnViolation configures whether the node should halt in case any
+	// of the supplementary sanity checks fails.
+	CfgSupplementarySanityHaltOnViolation = "consensus.tendermint.supplementarysanity.halt_on_violation"
 
 	// CfgConsensusStateSyncEnabled enabled consensus state sync.
 	CfgConsensusStateSyncEnabled = "consensus.tendermint.state_sync.enabled"
@@ -167,6 +187,7 @@ type fullService struct { // nolint: maligned
 	failMonitor   *failMonitor
 
 	stateStore tmstate.Store
+	archive    *archive.Reader
 
 	beacon        beaconAPI.Backend
 	governance    governanceAPI.Backend
@@ -445,18 +466,68 @@ func (t *fullService) RegisterHaltHook(hook consensusAPI.HaltHook) {
 }
 
 func (t *fullService) SubmitTx(ctx context.Context, tx *transaction.SignedTransaction) error {
+	_, err := t.submitTx(ctx, tx)
+	return err
+}
+
+func (t *fullService) SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*transaction.Proof, error) {
+	data, err := t.submitTx(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := t.GetTransactions(ctx, data.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.NewTransactionProof(data.Height, txs, int(data.Index))
+}
+
+func (t *fullService) SubmitTxBatch(ctx context.Context, txs []*transaction.SignedTransaction) ([]*consensusAPI.SubmitTxBatchResult, error) {
+	if len(txs) > consensusAPI.MaxSubmitTxBatchSize {
+		return nil, fmt.Errorf("%w: too many transactions in batch (max: %d)",
+			consensusAPI.ErrInvalidArgument,
+			consensusAPI.MaxSubmitTxBatchSize,
+		)
+	}
+
+	rsp := make([]*consensusAPI.SubmitTxBatchResult, 0, len(txs))
+	for _, tx := range txs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		data := cbor.Marshal(tx)
+		result := &consensusAPI.SubmitTxBatchResult{
+			Hash: hash.NewFromBytes(data),
+		}
+		if err := t.broadcastTxRaw(data); err != nil {
+			module, code := errors.Code(err)
+			result.Error = results.Error{
+				Module:  module,
+				Code:    code,
+				Message: err.Error(),
+			}
+		}
+		rsp = append(rsp, result)
+	}
+	return rsp, nil
+}
+
+func (t *fullService) submitTx(ctx context.Context, tx *transaction.SignedTransaction) (*tmtypes.EventDataTx, error) {
 	// Subscribe to the transaction being included in a block.
 	data := cbor.Marshal(tx)
 	query := tmtypes.EventQueryTxFor(data)
 	subID := t.newSubscriberID()
 	txSub, err := t.subscribe(subID, query)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if ptrSub, ok := txSub.(*tendermintPubsubBuffer).tmSubscription.(*tmpubsub.Subscription); ok && ptrSub == nil {
 		t.Logger.Debug("broadcastTx: service has shut down. Cancel our context to recover")
 		<-ctx.Done()
-		return ctx.Err()
+		return nil, ctx.Err()
 	}
 
 	defer t.unsubscribe(subID, query) // nolint: errcheck
@@ -466,28 +537,29 @@ func (t *fullService) SubmitTx(ctx context.Context, tx *transaction.SignedTransa
 
 	recheckCh, recheckSub, err := t.mux.WatchInvalidatedTx(txHash)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer recheckSub.Close()
 
 	// First try to broadcast.
 	if err := t.broadcastTxRaw(data); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Wait for the transaction to be included in a block.
 	select {
 	case v := <-recheckCh:
-		return v
+		return nil, v
 	case v := <-txSub.Out():
-		if result := v.Data().(tmtypes.EventDataTx).Result; !result.IsOK() {
-			return errors.FromCode(result.GetCodespace(), result.GetCode(), result.GetLog())
+		data := v.Data().(tmtypes.EventDataTx)
+		if result := data.Result; !result.IsOK() {
+			return nil, errors.FromCode(result.GetCodespace(), result.GetCode(), result.GetLog())
 		}
-		return nil
+		return &data, nil
 	case <-txSub.Cancelled():
-		return context.Canceled
+		return nil, context.Canceled
 	case <-ctx.Done():
-		return ctx.Err()
+		return nil, ctx.Err()
 	}
 }
 
@@ -640,6 +712,9 @@ func (t *fullService) Governance() governanceAPI.Backend {
 func (t *fullService) GetBlock(ctx context.Context, height int64) (*consensusAPI.Block, error) {
 	blk, err := t.GetTendermintBlock(ctx, height)
 	if err != nil {
+		if rec, aErr := t.getArchivedRecord(ctx, height); aErr == nil {
+			return rec.Block, nil
+		}
 		return nil, err
 	}
 	if blk == nil {
@@ -649,6 +724,40 @@ func (t *fullService) GetBlock(ctx context.Context, height int64) (*consensusAPI
 	return api.NewBlock(blk), nil
 }
 
+// getArchivedRecord returns the archived record for a block that is no longer
+// available locally.
+func (t *fullService) getArchivedRecord(ctx context.Context, height int64) (*archive.Record, error) {
+	if t.archive == nil {
+		return nil, archive.ErrNotFound
+	}
+	return t.archive.GetRecord(ctx, height)
+}
+
+func (t *fullService) newArchiveStore() (archive.Store, error) {
+	dir := viper.GetString(CfgABCIPruneArchiveDir)
+	endpoint := viper.GetString(CfgABCIPruneArchiveS3Endpoint)
+	switch {
+	case dir != "" && endpoint != "":
+		return nil, fmt.Errorf("only one of %s and %s may be set", CfgABCIPruneArchiveDir, CfgABCIPruneArchiveS3Endpoint)
+	case dir != "":
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(t.dataDir, dir)
+		}
+		return archive.NewDirStore(dir)
+	case endpoint != "":
+		return archive.NewS3Store(archive.S3Config{
+			Endpoint:        endpoint,
+			Region:          viper.GetString(CfgABCIPruneArchiveS3Region),
+			Bucket:          viper.GetString(CfgABCIPruneArchiveS3Bucket),
+			Prefix:          viper.GetString(CfgABCIPruneArchiveS3Prefix),
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		})
+	default:
+		return nil, nil
+	}
+}
+
 func (t *fullService) GetSignerNonce(ctx context.Context, req *consensusAPI.GetSignerNonceRequest) (uint64, error) {
 	return t.mux.TransactionAuthHandler().GetSignerNonce(ctx, req)
 }
@@ -656,6 +765,9 @@ func (t *fullService) GetSignerNonce(ctx context.Context, req *consensusAPI.GetS
 func (t *fullService) GetTransactions(ctx context.Context, height int64) ([][]byte, error) {
 	blk, err := t.GetTendermintBlock(ctx, height)
 	if err != nil {
+		if rec, aErr := t.getArchivedRecord(ctx, height); aErr == nil && rec.TransactionsWithResults != nil {
+			return rec.TransactionsWithResults.Transactions, nil
+		}
 		return nil, err
 	}
 	if blk == nil {
@@ -674,6 +786,9 @@ func (t *fullService) GetTransactionsWithResults(ctx context.Context, height int
 
 	blk, err := t.GetTendermintBlock(ctx, height)
 	if err != nil {
+		if rec, aErr := t.getArchivedRecord(ctx, height); aErr == nil && rec.TransactionsWithResults != nil {
+			return rec.TransactionsWithResults, nil
+		}
 		return nil, err
 	}
 	if blk == nil {
@@ -695,6 +810,7 @@ func (t *fullService) GetTransactionsWithResults(ctx context.Context, height int
 				Code:    rs.GetCode(),
 				Message: rs.GetLog(),
 			},
+			GasUsed: transaction.Gas(rs.GetGasUsed()),
 		}
 
 		// Transaction staking events.
@@ -849,6 +965,47 @@ func (t *fullService) GetStatus(ctx context.Context) (*consensusAPI.Status, erro
 	return status, nil
 }
 
+func (t *fullService) GetNetworkMetadata(ctx context.Context) (*consensusAPI.NetworkMetadata, error) {
+	if err := t.ensureStarted(ctx); err != nil {
+		return nil, err
+	}
+
+	blk, err := t.GetBlock(ctx, consensusAPI.HeightLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	cp, err := t.GetParameters(ctx, blk.Height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consensus parameters: %w", err)
+	}
+	bp, err := t.Beacon().ConsensusParameters(ctx, blk.Height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get beacon consensus parameters: %w", err)
+	}
+	sp, err := t.Staking().ConsensusParameters(ctx, blk.Height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staking consensus parameters: %w", err)
+	}
+
+	meta := &consensusAPI.NetworkMetadata{
+		ChainContext:    t.genesis.ChainContext(),
+		GenesisHeight:   t.genesis.Height,
+		Height:          blk.Height,
+		Versions:        version.Versions,
+		EpochInterval:   bp.Interval(),
+		MaxTxSize:       cp.Parameters.MaxTxSize,
+		MaxBlockGas:     cp.Parameters.MaxBlockGas,
+		GasCosts:        cp.Parameters.GasCosts,
+		StakingGasCosts: sp.GasCosts,
+	}
+	// We may not be able to fetch the genesis block in case it has been pruned.
+	if genBlk, gErr := t.GetBlock(ctx, t.genesis.Height); gErr == nil {
+		meta.GenesisHash = genBlk.Hash
+	}
+
+	return meta, nil
+}
+
 func (t *fullService) GetNextBlockState(ctx context.Context) (*consensusAPI.NextBlockState, error) {
 	if !t.started() {
 		return nil, fmt.Errorf("tendermint: not yet started")
@@ -1039,7 +1196,10 @@ func (t *fullService) initialize() error {
 
 	// Enable supplementary sanity checks when enabled.
 	if viper.GetBool(CfgSupplementarySanityEnabled) {
-		ssa := supplementarysanity.New(viper.GetUint64(CfgSupplementarySanityInterval))
+		ssa := supplementarysanity.New(
+			viper.GetUint64(CfgSupplementarySanityInterval),
+			viper.GetBool(CfgSupplementarySanityHaltOnViolation),
+		)
 		if err = t.RegisterApplication(ssa); err != nil {
 			return fmt.Errorf("failed to register supplementary sanity check app: %w", err)
 		}
@@ -1131,6 +1291,13 @@ func (t *fullService) Pruner() api.StatePruner {
 	return t.mux.Pruner()
 }
 
+func (t *fullService) CheckInvariants(ctx context.Context) (*consensusAPI.InvariantsReport, error) {
+	if err := t.ensureStarted(ctx); err != nil {
+		return nil, err
+	}
+	return t.mux.CheckInvariants()
+}
+
 func (t *fullService) lazyInit() error {
 	if t.isInitialized {
 		return nil
@@ -1167,6 +1334,16 @@ func (t *fullService) lazyInit() error {
 		return err
 	}
 
+	// Archive blocks before they are pruned, if configured.
+	archiveStore, err := t.newArchiveStore()
+	if err != nil {
+		return fmt.Errorf("failed to initialize block archive: %w", err)
+	}
+	if archiveStore != nil {
+		t.mux.Pruner().RegisterHandler(archive.NewArchiver(archiveStore, t))
+		t.archive = archive.NewReader(archiveStore)
+	}
+
 	// Tendermint needs the on-disk directories to be present when
 	// launched like this, so create the relevant sub-directories
 	// under the node DataDir.
@@ -1581,6 +1758,11 @@ func init() {
 	Flags.String(CfgABCIPruneStrategy, abci.PruneDefault, "ABCI state pruning strategy")
 	Flags.Uint64(CfgABCIPruneNumKept, 3600, "ABCI state versions kept (when applicable)")
 	Flags.Duration(CfgABCIPruneInterval, 2*time.Minute, "ABCI state pruning interval")
+	Flags.String(CfgABCIPruneArchiveDir, "", "archive blocks to the given directory before pruning them")
+	Flags.String(CfgABCIPruneArchiveS3Endpoint, "", "archive blocks to the given S3-compatible endpoint before pruning them")
+	Flags.String(CfgABCIPruneArchiveS3Region, "us-east-1", "S3 archive bucket region")
+	Flags.String(CfgABCIPruneArchiveS3Bucket, "", "S3 archive bucket")
+	Flags.String(CfgABCIPruneArchiveS3Prefix, "", "S3 archive object prefix")
 	Flags.Bool(CfgCheckpointerDisabled, false, "Disable the ABCI state checkpointer")
 	Flags.Duration(CfgCheckpointerCheckInterval, 1*time.Minute, "ABCI state checkpointer check interval")
 	Flags.StringSlice(CfgSentryUpstreamAddress, []string{}, "Tendermint nodes for which we act as sentry of the form ID@ip:port")
@@ -1593,6 +1775,7 @@ func init() {
 
 	Flags.Bool(CfgSupplementarySanityEnabled, false, "enable supplementary sanity checks (slows down consensus)")
 	Flags.Uint64(CfgSupplementarySanityInterval, 10, "supplementary sanity check interval (in blocks)")
+	Flags.Bool(CfgSupplementarySanityHaltOnViolation, true, "halt the node when a supplementary sanity check fails (otherwise only log and report via metrics)")
 
 	// State sync.
 	Flags.Bool(CfgConsensusStateSyncEnabled, false, "enable state sync")
@@ -1607,6 +1790,7 @@ func init() {
 
 	_ = Flags.MarkHidden(CfgSupplementarySanityEnabled)
 	_ = Flags.MarkHidden(CfgSupplementarySanityInterval)
+	_ = Flags.MarkHidden(CfgSupplementarySanityHaltOnViolation)
 
 	_ = viper.BindPFlags(Flags)
 	Flags.AddFlagSet(db.Flags)
@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 
+	tmed "github.com/tendermint/tendermint/crypto/ed25519"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	coreState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/abci/state"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/crypto"
+	registryAPI "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
@@ -86,6 +90,61 @@ func (t *fullService) GetParameters(ctx context.Context, height int64) (*consens
 	}, nil
 }
 
+// Implements ClientBackend.
+func (t *fullService) GetValidatorSet(ctx context.Context, height int64) (*consensusAPI.ValidatorSet, error) {
+	if err := t.ensureStarted(ctx); err != nil {
+		return nil, err
+	}
+
+	tmHeight, err := t.heightToTendermintHeight(height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Don't use the client as that imposes stupid pagination. Access the state database directly.
+	vals, err := t.stateStore.LoadValidators(tmHeight)
+	if err != nil {
+		return nil, consensusAPI.ErrVersionNotFound
+	}
+	params, err := t.GetParameters(ctx, tmHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	regState, err := registryState.NewImmutableState(ctx, t.mux.State(), tmHeight)
+	if err != nil {
+		return nil, fmt.Errorf("tendermint: failed to initialize registry state: %w", err)
+	}
+
+	vs := &consensusAPI.ValidatorSet{
+		Height:           tmHeight,
+		Validators:       make([]*consensusAPI.Validator, 0, len(vals.Validators)),
+		TotalVotingPower: vals.TotalVotingPower(),
+		Parameters:       params,
+	}
+	for _, v := range vals.Validators {
+		tmPk, ok := v.PubKey.(tmed.PubKey)
+		if !ok {
+			return nil, fmt.Errorf("tendermint: unsupported validator public key type: %T", v.PubKey)
+		}
+
+		val := &consensusAPI.Validator{
+			ConsensusID: crypto.PublicKeyFromTendermint(&tmPk),
+			VotingPower: v.VotingPower,
+		}
+		switch n, nerr := regState.NodeByConsensusAddress(ctx, v.Address); nerr {
+		case nil:
+			val.ID = n.ID
+			val.EntityID = n.EntityID
+		case registryAPI.ErrNoSuchNode:
+		default:
+			return nil, fmt.Errorf("tendermint: failed to look up validator node: %w", nerr)
+		}
+		vs.Validators = append(vs.Validators, val)
+	}
+	return vs, nil
+}
+
 // Implements LightClientBackend.
 func (t *fullService) State() syncer.ReadSyncer {
 	return t.mux.State().Storage()
@@ -17,6 +17,7 @@ import (
 	tmtypes "github.com/tendermint/tendermint/types"
 	tmdb "github.com/tendermint/tm-db"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
@@ -24,6 +25,7 @@ import (
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/common"
+	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
@@ -159,22 +161,36 @@ func (lc *lightClient) GetVerifiedLightBlock(ctx context.Context, height int64)
 	return lc.tmc.VerifyLightBlockAtHeight(ctx, height, time.Now())
 }
 
+// Implements Client.
+func (lc *lightClient) GetLatestVerifiedLightBlock(ctx context.Context) (*tmtypes.LightBlock, error) {
+	if _, err := lc.tmc.Update(ctx, time.Now()); err != nil {
+		return nil, err
+	}
+	return lc.tmc.TrustedLightBlock(0)
+}
+
 // Implements Client.
 func (lc *lightClient) GetVerifiedParameters(ctx context.Context, height int64) (*tmproto.ConsensusParams, error) {
 	p, err := lc.getPrimary().GetParameters(ctx, height)
 	if err != nil {
 		return nil, err
 	}
+	return lc.verifyParameters(ctx, p)
+}
+
+// verifyParameters verifies the Tendermint consensus parameters included in the given parameters
+// response against the consensus parameters hash in the corresponding verified header.
+func (lc *lightClient) verifyParameters(ctx context.Context, p *consensus.Parameters) (*tmproto.ConsensusParams, error) {
 	if p.Height <= 0 {
 		return nil, fmt.Errorf("malformed height in response: %d", p.Height)
 	}
 
 	// Decode Tendermint-specific parameters.
 	var params tmproto.ConsensusParams
-	if err = params.Unmarshal(p.Meta); err != nil {
+	if err := params.Unmarshal(p.Meta); err != nil {
 		return nil, fmt.Errorf("malformed parameters: %w", err)
 	}
-	if err = tmtypes.ValidateConsensusParams(params); err != nil {
+	if err := tmtypes.ValidateConsensusParams(params); err != nil {
 		return nil, fmt.Errorf("malformed parameters: %w", err)
 	}
 
@@ -195,12 +211,40 @@ func (lc *lightClient) GetVerifiedParameters(ctx context.Context, height int64)
 	return &params, nil
 }
 
+// Implements Client.
+func (lc *lightClient) GetVerifiedStateRoot(ctx context.Context, height int64) (*mkvsNode.Root, error) {
+	if height <= 0 {
+		return nil, fmt.Errorf("malformed height: %d", height)
+	}
+
+	// The application hash committed in the header at height+1 is the state root after executing
+	// the block at the given height.
+	l, err := lc.tmc.VerifyLightBlockAtHeight(ctx, height+1, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch header %d from light client: %w", height+1, err)
+	}
+
+	var stateRoot hash.Hash
+	if err = stateRoot.UnmarshalBinary(l.AppHash); err != nil {
+		return nil, fmt.Errorf("malformed application hash: %w", err)
+	}
+	return &mkvsNode.Root{
+		Version: uint64(height),
+		Type:    mkvsNode.RootTypeState,
+		Hash:    stateRoot,
+	}, nil
+}
+
 func (lc *lightClient) getPrimary() consensus.LightClientBackend {
 	return lc.tmc.Primary().(*lightClientProvider).client
 }
 
 // NewClient creates a new light client.
 func NewClient(ctx context.Context, cfg ClientConfig) (Client, error) {
+	return newClient(ctx, cfg)
+}
+
+func newClient(ctx context.Context, cfg ClientConfig) (*lightClient, error) {
 	if numNodes := len(cfg.ConsensusNodes); numNodes < 2 {
 		return nil, fmt.Errorf("at least two consensus nodes must be provided (got %d)", numNodes)
 	}
@@ -214,6 +258,12 @@ func NewClient(ctx context.Context, cfg ClientConfig) (Client, error) {
 		providers = append(providers, p)
 	}
 
+	return newClientWithProviders(ctx, cfg, providers)
+}
+
+// newClientWithProviders creates a new light client using the given providers. The first provider
+// is considered the primary and the rest are used as witnesses.
+func newClientWithProviders(ctx context.Context, cfg ClientConfig, providers []tmlightprovider.Provider) (*lightClient, error) {
 	tmc, err := tmlight.NewClient(
 		ctx,
 		cfg.GenesisDocument.ChainID,
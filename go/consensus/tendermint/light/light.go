@@ -8,6 +8,7 @@ import (
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 )
 
 // Client is a Tendermint consensus light client that talks with a remote oasis-node that is using
@@ -18,6 +19,17 @@ type Client interface {
 	// GetVerifiedLightBlock returns a verified light block.
 	GetVerifiedLightBlock(ctx context.Context, height int64) (*tmtypes.LightBlock, error)
 
+	// GetLatestVerifiedLightBlock attempts to advance the light client to the latest block
+	// available from the primary and returns the latest verified light block.
+	GetLatestVerifiedLightBlock(ctx context.Context) (*tmtypes.LightBlock, error)
+
 	// GetVerifiedParameters returns verified consensus parameters.
 	GetVerifiedParameters(ctx context.Context, height int64) (*tmproto.ConsensusParams, error)
+
+	// GetVerifiedStateRoot returns the verified consensus state root of the state at the given
+	// height. It can be used to verify consensus state proofs obtained for queries at that height.
+	//
+	// Since the state root is committed in the header of the next block, this requires the block
+	// at height+1 to be available.
+	GetVerifiedStateRoot(ctx context.Context, height int64) (*mkvsNode.Root, error)
 }
@@ -0,0 +1,544 @@
+package light
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	tmlight "github.com/tendermint/tendermint/light"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+const (
+	// CfgConsensusNode specifies nodes exposing public consensus services which are used to
+	// follow the chain in light mode.
+	CfgConsensusNode = "consensus.tendermint.light.consensus_node"
+	// CfgTrustPeriod is the light client trust period.
+	CfgTrustPeriod = "consensus.tendermint.light.trust_period"
+	// CfgTrustHeight is the known trusted height for the light client.
+	CfgTrustHeight = "consensus.tendermint.light.trust_height"
+	// CfgTrustHash is the known trusted block header hash for the light client.
+	CfgTrustHash = "consensus.tendermint.light.trust_hash"
+	// CfgUpdateInterval is the interval at which the light client follows new headers.
+	CfgUpdateInterval = "consensus.tendermint.light.update_interval"
+
+	// initRetryInterval is the interval at which light client initialization is retried.
+	initRetryInterval = 5 * time.Second
+)
+
+// Flags has the configuration flags.
+var Flags = flag.NewFlagSet("", flag.ContinueOnError)
+
+type lightService struct {
+	sync.RWMutex
+
+	identity *identity.Identity
+	doc      *genesis.Document
+	cfg      ClientConfig
+
+	ctx       context.Context
+	cancelCtx context.CancelFunc
+
+	// clientFactory creates the light client used for following the chain.
+	clientFactory func(ctx context.Context, cfg ClientConfig) (*lightClient, error)
+
+	lc          *lightClient
+	latestBlock *consensus.Block
+	blockNotify *pubsub.Broker
+
+	updateInterval time.Duration
+
+	syncedOnce sync.Once
+	syncedCh   chan struct{}
+	stopOnce   sync.Once
+	quitCh     chan struct{}
+
+	logger *logging.Logger
+}
+
+// Name returns the service name.
+func (srv *lightService) Name() string {
+	return "tendermint/light"
+}
+
+// Start starts the service.
+func (srv *lightService) Start() error {
+	go srv.worker()
+	return nil
+}
+
+// Stop halts the service.
+func (srv *lightService) Stop() {
+	srv.stopOnce.Do(func() {
+		srv.cancelCtx()
+	})
+}
+
+// Quit returns a channel that will be closed when the service terminates.
+func (srv *lightService) Quit() <-chan struct{} {
+	return srv.quitCh
+}
+
+// Cleanup performs the service specific post-termination cleanup.
+func (srv *lightService) Cleanup() {
+	// No cleanup in particular.
+}
+
+func (srv *lightService) worker() {
+	defer close(srv.quitCh)
+
+	// Initialize the light client. This requires the trust root to be verified against the
+	// configured consensus nodes so retry until they become available.
+	var lc *lightClient
+	for {
+		var err error
+		if lc, err = srv.clientFactory(srv.ctx, srv.cfg); err == nil {
+			break
+		}
+		srv.logger.Error("failed to initialize light client, retrying",
+			"err", err,
+		)
+
+		select {
+		case <-srv.ctx.Done():
+			return
+		case <-time.After(initRetryInterval):
+		}
+	}
+
+	srv.Lock()
+	srv.lc = lc
+	srv.Unlock()
+
+	ticker := time.NewTicker(srv.updateInterval)
+	defer ticker.Stop()
+
+	for {
+		srv.update(lc)
+
+		select {
+		case <-srv.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (srv *lightService) update(lc *lightClient) {
+	tlb, err := lc.GetLatestVerifiedLightBlock(srv.ctx)
+	if err != nil {
+		srv.logger.Warn("failed to follow latest header",
+			"err", err,
+		)
+		return
+	}
+
+	srv.Lock()
+	if srv.latestBlock != nil && srv.latestBlock.Height >= tlb.Height {
+		srv.Unlock()
+		return
+	}
+	blk := newBlock(tlb)
+	srv.latestBlock = blk
+	srv.Unlock()
+
+	srv.blockNotify.Broadcast(blk)
+	srv.syncedOnce.Do(func() {
+		srv.logger.Info("light client synced",
+			"height", blk.Height,
+		)
+		close(srv.syncedCh)
+	})
+}
+
+func (srv *lightService) client() (*lightClient, error) {
+	srv.RLock()
+	defer srv.RUnlock()
+
+	if srv.lc == nil {
+		return nil, consensus.ErrNoCommittedBlocks
+	}
+	return srv.lc, nil
+}
+
+func (srv *lightService) verifiedLightBlock(ctx context.Context, height int64) (*tmtypes.LightBlock, error) {
+	lc, err := srv.client()
+	if err != nil {
+		return nil, err
+	}
+
+	var tlb *tmtypes.LightBlock
+	switch height {
+	case consensus.HeightLatest:
+		tlb, err = lc.tmc.TrustedLightBlock(0)
+	default:
+		tlb, err = lc.GetVerifiedLightBlock(ctx, height)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: tendermint/light: failed to verify light block: %s", consensus.ErrVersionNotFound, err)
+	}
+	return tlb, nil
+}
+
+func newBlock(tlb *tmtypes.LightBlock) *consensus.Block {
+	return api.NewBlock(&tmtypes.Block{Header: *tlb.Header})
+}
+
+// Implements Backend.
+func (srv *lightService) Synced() <-chan struct{} {
+	return srv.syncedCh
+}
+
+// Implements Backend.
+func (srv *lightService) SupportedFeatures() consensus.FeatureMask {
+	return consensus.FeatureMask(0)
+}
+
+// Implements Backend.
+func (srv *lightService) GetStatus(ctx context.Context) (*consensus.Status, error) {
+	status := &consensus.Status{
+		Version:       version.ConsensusProtocol,
+		Backend:       api.BackendName,
+		Features:      srv.SupportedFeatures(),
+		ChainContext:  srv.doc.ChainContext(),
+		GenesisHeight: srv.doc.Height,
+	}
+
+	srv.RLock()
+	blk := srv.latestBlock
+	srv.RUnlock()
+	if blk != nil {
+		status.LatestHeight = blk.Height
+		status.LatestHash = blk.Hash
+		status.LatestTime = blk.Time
+		status.LatestStateRoot = blk.StateRoot
+	}
+
+	return status, nil
+}
+
+// Implements Backend.
+func (srv *lightService) GetNetworkMetadata(ctx context.Context) (*consensus.NetworkMetadata, error) {
+	return nil, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *lightService) GetNextBlockState(ctx context.Context) (*consensus.NextBlockState, error) {
+	return nil, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *lightService) GetGenesisDocument(ctx context.Context) (*genesis.Document, error) {
+	return srv.doc, nil
+}
+
+// Implements Backend.
+func (srv *lightService) GetChainContext(ctx context.Context) (string, error) {
+	return srv.doc.ChainContext(), nil
+}
+
+// Implements Backend.
+func (srv *lightService) GetAddresses() ([]node.ConsensusAddress, error) {
+	// Light nodes do not participate in the consensus P2P network.
+	return []node.ConsensusAddress{}, nil
+}
+
+// Implements Backend.
+func (srv *lightService) Checkpointer() checkpoint.Checkpointer {
+	return nil
+}
+
+// Implements Backend.
+func (srv *lightService) SubmitEvidence(ctx context.Context, evidence *consensus.Evidence) error {
+	lc, err := srv.client()
+	if err != nil {
+		return err
+	}
+	return lc.SubmitEvidence(ctx, evidence)
+}
+
+// Implements Backend.
+func (srv *lightService) SubmitTx(ctx context.Context, tx *transaction.SignedTransaction) error {
+	// Waiting for inclusion would require transaction results which cannot be verified without
+	// consensus state, so only SubmitTxNoWait is supported.
+	return consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *lightService) SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*transaction.Proof, error) {
+	return nil, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *lightService) SubmitTxBatch(ctx context.Context, txs []*transaction.SignedTransaction) ([]*consensus.SubmitTxBatchResult, error) {
+	return nil, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *lightService) StateToGenesis(ctx context.Context, height int64) (*genesis.Document, error) {
+	return nil, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *lightService) EstimateGas(ctx context.Context, req *consensus.EstimateGasRequest) (transaction.Gas, error) {
+	return 0, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *lightService) GetBlock(ctx context.Context, height int64) (*consensus.Block, error) {
+	tlb, err := srv.verifiedLightBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return newBlock(tlb), nil
+}
+
+// Implements Backend.
+func (srv *lightService) GetTransactions(ctx context.Context, height int64) ([][]byte, error) {
+	return nil, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *lightService) GetTransactionsWithResults(ctx context.Context, height int64) (*consensus.TransactionsWithResults, error) {
+	return nil, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *lightService) GetUnconfirmedTransactions(ctx context.Context) ([][]byte, error) {
+	return nil, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *lightService) WatchBlocks(ctx context.Context) (<-chan *consensus.Block, pubsub.ClosableSubscription, error) {
+	typedCh := make(chan *consensus.Block)
+	sub := srv.blockNotify.Subscribe()
+	sub.Unwrap(typedCh)
+
+	return typedCh, sub, nil
+}
+
+// Implements Backend.
+func (srv *lightService) GetSignerNonce(ctx context.Context, req *consensus.GetSignerNonceRequest) (uint64, error) {
+	return 0, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *lightService) GetValidatorSet(ctx context.Context, height int64) (*consensus.ValidatorSet, error) {
+	return nil, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *lightService) GetLightBlock(ctx context.Context, height int64) (*consensus.LightBlock, error) {
+	tlb, err := srv.verifiedLightBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	protoLb, err := tlb.ToProto()
+	if err != nil {
+		return nil, fmt.Errorf("tendermint/light: failed to convert light block: %w", err)
+	}
+	meta, err := protoLb.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("tendermint/light: failed to marshal light block: %w", err)
+	}
+
+	return &consensus.LightBlock{
+		Height: tlb.Height,
+		Meta:   meta,
+	}, nil
+}
+
+// Implements Backend.
+func (srv *lightService) GetParameters(ctx context.Context, height int64) (*consensus.Parameters, error) {
+	lc, err := srv.client()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := lc.getPrimary().GetParameters(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = lc.verifyParameters(ctx, p); err != nil {
+		return nil, fmt.Errorf("tendermint/light: failed to verify parameters: %w", err)
+	}
+	return p, nil
+}
+
+// Implements Backend.
+func (srv *lightService) State() syncer.ReadSyncer {
+	lc, err := srv.client()
+	if err != nil {
+		return syncer.NopReadSyncer
+	}
+	// State is fetched from the primary consensus node. Consumers verify the returned proofs
+	// against state roots obtained from verified light blocks.
+	return lc.State()
+}
+
+// Implements Backend.
+func (srv *lightService) ConsensusKey() signature.PublicKey {
+	return srv.identity.ConsensusSigner.Public()
+}
+
+// Implements Backend.
+func (srv *lightService) SubmitTxNoWait(ctx context.Context, tx *transaction.SignedTransaction) error {
+	lc, err := srv.client()
+	if err != nil {
+		return err
+	}
+	return lc.SubmitTxNoWait(ctx, tx)
+}
+
+// Implements Backend.
+func (srv *lightService) RegisterHaltHook(consensus.HaltHook) {
+	panic(consensus.ErrUnsupported)
+}
+
+// Note: SupportedFeatures() indicates that the backend does not support
+// consensus services so the caller is at fault for not adhering to the
+// SupportedFeatures flag, in case any of the following methods is called.
+
+// Implements Backend.
+func (srv *lightService) Beacon() beacon.Backend {
+	panic(consensus.ErrUnsupported)
+}
+
+// Implements Backend.
+func (srv *lightService) KeyManager() keymanager.Backend {
+	panic(consensus.ErrUnsupported)
+}
+
+// Implements Backend.
+func (srv *lightService) Registry() registry.Backend {
+	panic(consensus.ErrUnsupported)
+}
+
+// Implements Backend.
+func (srv *lightService) RootHash() roothash.Backend {
+	panic(consensus.ErrUnsupported)
+}
+
+// Implements Backend.
+func (srv *lightService) Staking() staking.Backend {
+	panic(consensus.ErrUnsupported)
+}
+
+// Implements Backend.
+func (srv *lightService) Scheduler() scheduler.Backend {
+	panic(consensus.ErrUnsupported)
+}
+
+// Implements Backend.
+func (srv *lightService) Governance() governance.Backend {
+	panic(consensus.ErrUnsupported)
+}
+
+// Implements Backend.
+func (srv *lightService) SubmissionManager() consensus.SubmissionManager {
+	panic(consensus.ErrUnsupported)
+}
+
+// New creates a new light-mode consensus service.
+//
+// The light-mode service does not maintain any consensus state. Instead it follows the chain by
+// verifying headers obtained from the configured consensus nodes and serves verified light blocks
+// and consensus parameters. As it cannot provide any consensus services, runtime services are not
+// started and runtimes cannot be hosted on light nodes.
+func New(ctx context.Context, identity *identity.Identity, genesisProvider genesis.Provider) (consensus.Backend, error) {
+	doc, err := genesisProvider.GetGenesisDocument()
+	if err != nil {
+		return nil, fmt.Errorf("tendermint/light: failed to get genesis document: %w", err)
+	}
+	tmGenDoc, err := api.GetTendermintGenesisDocument(genesisProvider)
+	if err != nil {
+		return nil, fmt.Errorf("tendermint/light: failed to create genesis document: %w", err)
+	}
+
+	cfg := ClientConfig{
+		GenesisDocument: tmGenDoc,
+		TrustOptions: tmlight.TrustOptions{
+			Period: viper.GetDuration(CfgTrustPeriod),
+			Height: int64(viper.GetUint64(CfgTrustHeight)),
+		},
+	}
+	if cfg.TrustOptions.Hash, err = parseTrustHash(viper.GetString(CfgTrustHash)); err != nil {
+		return nil, err
+	}
+	if err = cfg.TrustOptions.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("tendermint/light: invalid trust options: %w", err)
+	}
+	for _, rawAddr := range viper.GetStringSlice(CfgConsensusNode) {
+		var addr node.TLSAddress
+		if err = addr.UnmarshalText([]byte(rawAddr)); err != nil {
+			return nil, fmt.Errorf("tendermint/light: failed to parse consensus node address (%s): %w", rawAddr, err)
+		}
+		cfg.ConsensusNodes = append(cfg.ConsensusNodes, addr)
+	}
+	if numNodes := len(cfg.ConsensusNodes); numNodes < 2 {
+		return nil, fmt.Errorf("tendermint/light: at least two consensus nodes must be provided (got %d)", numNodes)
+	}
+
+	updateInterval := viper.GetDuration(CfgUpdateInterval)
+	if updateInterval <= 0 {
+		return nil, fmt.Errorf("tendermint/light: invalid update interval: %s", updateInterval)
+	}
+
+	ctx, cancelCtx := context.WithCancel(ctx)
+	return &lightService{
+		identity:       identity,
+		doc:            doc,
+		cfg:            cfg,
+		ctx:            ctx,
+		cancelCtx:      cancelCtx,
+		clientFactory:  newClient,
+		blockNotify:    pubsub.NewBroker(true),
+		updateInterval: updateInterval,
+		syncedCh:       make(chan struct{}),
+		quitCh:         make(chan struct{}),
+		logger:         logging.GetLogger("consensus/tendermint/light"),
+	}, nil
+}
+
+func parseTrustHash(raw string) ([]byte, error) {
+	h, err := hex.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("tendermint/light: malformed trust hash: %w", err)
+	}
+	return h, nil
+}
+
+func init() {
+	Flags.StringSlice(CfgConsensusNode, []string{}, "light mode: consensus node to use for following the chain")
+	Flags.Duration(CfgTrustPeriod, 24*time.Hour, "light mode: light client trust period")
+	Flags.Uint64(CfgTrustHeight, 0, "light mode: light client trusted height")
+	Flags.String(CfgTrustHash, "", "light mode: light client trusted consensus header hash")
+	Flags.Duration(CfgUpdateInterval, 1*time.Second, "light mode: interval at which new headers are followed")
+
+	_ = viper.BindPFlags(Flags)
+}
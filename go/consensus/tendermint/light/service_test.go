@@ -0,0 +1,484 @@
+package light
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmlight "github.com/tendermint/tendermint/light"
+	tmlightprovider "github.com/tendermint/tendermint/light/provider"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
+	tmtypes "github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+	tmvers "github.com/tendermint/tendermint/version"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+const (
+	testChainID     = "test-chain"
+	testChainHeight = 10
+)
+
+// testChain is a chain of light blocks signed by a fixed validator set.
+//
+// The helpers are adapted from the Tendermint light client test suite.
+type testChain struct {
+	keys   []crypto.PrivKey
+	vals   *tmtypes.ValidatorSet
+	params *tmproto.ConsensusParams
+	blocks map[int64]*tmtypes.LightBlock
+}
+
+func newTestChain(numVals int) *testChain {
+	c := &testChain{
+		params: tmtypes.DefaultConsensusParams(),
+		blocks: make(map[int64]*tmtypes.LightBlock),
+	}
+	var vals []*tmtypes.Validator
+	for i := 0; i < numVals; i++ {
+		key := ed25519.GenPrivKey()
+		c.keys = append(c.keys, key)
+		vals = append(vals, tmtypes.NewValidator(key.PubKey(), 10))
+	}
+	c.vals = tmtypes.NewValidatorSet(vals)
+	return c
+}
+
+// genBlock generates a light block at the given height signed by all validators.
+func (c *testChain) genBlock(height int64, bTime time.Time) *tmtypes.LightBlock {
+	header := &tmtypes.Header{
+		Version:            tmversion.Consensus{Block: tmvers.BlockProtocol, App: 0},
+		ChainID:            testChainID,
+		Height:             height,
+		Time:               bTime,
+		ValidatorsHash:     c.vals.Hash(),
+		NextValidatorsHash: c.vals.Hash(),
+		DataHash:           tmtypes.Txs{}.Hash(),
+		AppHash:            tmhash.Sum([]byte(fmt.Sprintf("state root %d", height))),
+		ConsensusHash:      tmtypes.HashConsensusParams(*c.params),
+		ProposerAddress:    c.vals.Validators[0].Address,
+	}
+	blockID := tmtypes.BlockID{
+		Hash:          header.Hash(),
+		PartSetHeader: tmtypes.PartSetHeader{Total: 1, Hash: crypto.CRandBytes(32)},
+	}
+
+	commitSigs := make([]tmtypes.CommitSig, len(c.keys))
+	for _, key := range c.keys {
+		addr := key.PubKey().Address()
+		idx, _ := c.vals.GetByAddress(addr)
+		vote := &tmtypes.Vote{
+			ValidatorAddress: addr,
+			ValidatorIndex:   idx,
+			Height:           height,
+			Round:            1,
+			Timestamp:        tmtime.Now(),
+			Type:             tmproto.PrecommitType,
+			BlockID:          blockID,
+		}
+		sig, err := key.Sign(tmtypes.VoteSignBytes(testChainID, vote.ToProto()))
+		if err != nil {
+			panic(err)
+		}
+		vote.Signature = sig
+		commitSigs[idx] = vote.CommitSig()
+	}
+
+	return &tmtypes.LightBlock{
+		SignedHeader: &tmtypes.SignedHeader{
+			Header: header,
+			Commit: tmtypes.NewCommit(height, 1, blockID, commitSigs),
+		},
+		ValidatorSet: c.vals,
+	}
+}
+
+// genBlocks generates light blocks for heights from 1 to the given height, one per minute ending
+// a minute ago.
+func (c *testChain) genBlocks(height int64) {
+	start := time.Now().Add(-time.Duration(height+1) * time.Minute)
+	for h := int64(1); h <= height; h++ {
+		c.blocks[h] = c.genBlock(h, start.Add(time.Duration(h)*time.Minute))
+	}
+}
+
+// testBackend is a light client backend that serves light blocks of a test chain.
+type testBackend struct {
+	sync.Mutex
+
+	chain        *testChain
+	latestHeight int64
+	// overrides are light blocks served instead of the test chain's blocks.
+	overrides map[int64]*tmtypes.LightBlock
+	// params are the consensus parameters served instead of the test chain's parameters.
+	params *tmproto.ConsensusParams
+
+	txs      []*transaction.SignedTransaction
+	evidence []*consensus.Evidence
+}
+
+func (b *testBackend) setLatestHeight(height int64) {
+	b.Lock()
+	defer b.Unlock()
+	b.latestHeight = height
+}
+
+func (b *testBackend) GetLightBlock(ctx context.Context, height int64) (*consensus.LightBlock, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if height == consensus.HeightLatest {
+		height = b.latestHeight
+	}
+	tlb, ok := b.overrides[height]
+	if !ok {
+		tlb, ok = b.chain.blocks[height]
+	}
+	if !ok || height > b.latestHeight {
+		return nil, consensus.ErrVersionNotFound
+	}
+
+	protoLb, err := tlb.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	meta, err := protoLb.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &consensus.LightBlock{Height: height, Meta: meta}, nil
+}
+
+func (b *testBackend) GetParameters(ctx context.Context, height int64) (*consensus.Parameters, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if height == consensus.HeightLatest {
+		height = b.latestHeight
+	}
+	params := b.chain.params
+	if b.params != nil {
+		params = b.params
+	}
+	meta, err := params.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &consensus.Parameters{Height: height, Meta: meta}, nil
+}
+
+func (b *testBackend) State() syncer.ReadSyncer {
+	return b
+}
+
+func (b *testBackend) SyncGet(ctx context.Context, request *syncer.GetRequest) (*syncer.ProofResponse, error) {
+	return nil, syncer.ErrUnsupported
+}
+
+func (b *testBackend) SyncGetPrefixes(ctx context.Context, request *syncer.GetPrefixesRequest) (*syncer.ProofResponse, error) {
+	return nil, syncer.ErrUnsupported
+}
+
+func (b *testBackend) SyncIterate(ctx context.Context, request *syncer.IterateRequest) (*syncer.ProofResponse, error) {
+	return nil, syncer.ErrUnsupported
+}
+
+func (b *testBackend) SubmitTxNoWait(ctx context.Context, tx *transaction.SignedTransaction) error {
+	b.Lock()
+	defer b.Unlock()
+	b.txs = append(b.txs, tx)
+	return nil
+}
+
+func (b *testBackend) SubmitEvidence(ctx context.Context, evidence *consensus.Evidence) error {
+	b.Lock()
+	defer b.Unlock()
+	b.evidence = append(b.evidence, evidence)
+	return nil
+}
+
+// newTestService creates a light service following the given backend. The light client is only
+// created once the returned ready channel is closed.
+func newTestService(t *testing.T, backend *testBackend) (*lightService, chan struct{}) {
+	ready := make(chan struct{})
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	srv := &lightService{
+		doc: &genesis.Document{Height: 1, ChainID: testChainID},
+		cfg: ClientConfig{
+			GenesisDocument: &tmtypes.GenesisDoc{ChainID: testChainID},
+			TrustOptions: tmlight.TrustOptions{
+				Period: 24 * time.Hour,
+				Height: 1,
+				Hash:   backend.chain.blocks[1].Hash(),
+			},
+		},
+		ctx:       ctx,
+		cancelCtx: cancelCtx,
+		clientFactory: func(ctx context.Context, cfg ClientConfig) (*lightClient, error) {
+			select {
+			case <-ready:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+
+			// Use the same backend for the primary and the witness.
+			var providers []tmlightprovider.Provider
+			for i := 0; i < 2; i++ {
+				providers = append(providers, &lightClientProvider{
+					chainID: cfg.GenesisDocument.ChainID,
+					client:  backend,
+				})
+			}
+			return newClientWithProviders(ctx, cfg, providers)
+		},
+		blockNotify:    pubsub.NewBroker(true),
+		updateInterval: 10 * time.Millisecond,
+		syncedCh:       make(chan struct{}),
+		quitCh:         make(chan struct{}),
+		logger:         logging.GetLogger("consensus/tendermint/light/test"),
+	}
+	t.Cleanup(func() {
+		srv.Stop()
+		<-srv.Quit()
+	})
+	return srv, ready
+}
+
+// startTestService creates and starts a light service following a new test chain and waits for it
+// to sync.
+func startTestService(t *testing.T) (*lightService, *testBackend) {
+	chain := newTestChain(4)
+	chain.genBlocks(testChainHeight)
+	backend := &testBackend{chain: chain, latestHeight: testChainHeight}
+
+	srv, ready := newTestService(t, backend)
+	require.NoError(t, srv.Start(), "Start")
+	close(ready)
+
+	select {
+	case <-srv.Synced():
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "light service should sync")
+	}
+	return srv, backend
+}
+
+func TestServiceStart(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	chain := newTestChain(4)
+	chain.genBlocks(testChainHeight + 1)
+	backend := &testBackend{chain: chain, latestHeight: testChainHeight}
+
+	srv, ready := newTestService(t, backend)
+	require.NoError(srv.Start(), "Start")
+
+	// Queries should fail until the light client is initialized.
+	_, err := srv.GetBlock(ctx, consensus.HeightLatest)
+	require.ErrorIs(err, consensus.ErrNoCommittedBlocks, "GetBlock before initialization")
+	_, err = srv.GetParameters(ctx, consensus.HeightLatest)
+	require.ErrorIs(err, consensus.ErrNoCommittedBlocks, "GetParameters before initialization")
+	require.Equal(syncer.NopReadSyncer, srv.State(), "State before initialization")
+	select {
+	case <-srv.Synced():
+		require.FailNow("light service should not be synced before initialization")
+	default:
+	}
+	status, err := srv.GetStatus(ctx)
+	require.NoError(err, "GetStatus")
+	require.EqualValues(0, status.LatestHeight, "status should not include a latest block")
+
+	close(ready)
+	select {
+	case <-srv.Synced():
+	case <-time.After(10 * time.Second):
+		require.FailNow("light service should sync")
+	}
+
+	status, err = srv.GetStatus(ctx)
+	require.NoError(err, "GetStatus")
+	require.EqualValues(testChainHeight, status.LatestHeight, "status should include the latest block")
+	require.EqualValues(chain.blocks[testChainHeight].Hash(), status.LatestHash[:], "status should include the latest block")
+	require.Equal(srv.doc.ChainContext(), status.ChainContext)
+
+	// New blocks should be followed and broadcast.
+	ch, sub, err := srv.WatchBlocks(ctx)
+	require.NoError(err, "WatchBlocks")
+	defer sub.Close()
+
+	backend.setLatestHeight(testChainHeight + 1)
+	for {
+		select {
+		case blk := <-ch:
+			if blk.Height < testChainHeight+1 {
+				continue
+			}
+			require.EqualValues(testChainHeight+1, blk.Height, "new blocks should be followed")
+			require.EqualValues(chain.blocks[testChainHeight+1].Hash(), blk.Hash[:])
+		case <-time.After(10 * time.Second):
+			require.FailNow("new blocks should be followed")
+		}
+		break
+	}
+
+	srv.Stop()
+	select {
+	case <-srv.Quit():
+	case <-time.After(10 * time.Second):
+		require.FailNow("light service should stop")
+	}
+}
+
+func TestServiceVerify(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Valid", func(t *testing.T) {
+		require := require.New(t)
+		srv, backend := startTestService(t)
+
+		for _, height := range []int64{2, testChainHeight / 2, testChainHeight} {
+			blk, err := srv.GetBlock(ctx, height)
+			require.NoError(err, "GetBlock(%d)", height)
+			require.EqualValues(height, blk.Height)
+			require.EqualValues(backend.chain.blocks[height].Hash(), blk.Hash[:], "block hash should match")
+			require.EqualValues(backend.chain.blocks[height].AppHash, blk.StateRoot.Hash[:], "state root should match")
+		}
+
+		blk, err := srv.GetBlock(ctx, consensus.HeightLatest)
+		require.NoError(err, "GetBlock(HeightLatest)")
+		require.EqualValues(testChainHeight, blk.Height, "latest block should be the latest verified block")
+
+		_, err = srv.GetBlock(ctx, testChainHeight+1)
+		require.ErrorIs(err, consensus.ErrVersionNotFound, "GetBlock for a future height")
+	})
+
+	t.Run("Forged", func(t *testing.T) {
+		require := require.New(t)
+		srv, backend := startTestService(t)
+
+		// Serve a block at the next height signed by a different validator set.
+		forged := newTestChain(4)
+		backend.Lock()
+		backend.overrides = map[int64]*tmtypes.LightBlock{
+			testChainHeight + 1: forged.genBlock(testChainHeight+1, time.Now().Add(-time.Second)),
+		}
+		backend.Unlock()
+		backend.setLatestHeight(testChainHeight + 1)
+
+		_, err := srv.GetBlock(ctx, testChainHeight+1)
+		require.ErrorIs(err, consensus.ErrVersionNotFound, "forged blocks should not be verified")
+		_, err = srv.GetLightBlock(ctx, testChainHeight+1)
+		require.ErrorIs(err, consensus.ErrVersionNotFound, "forged blocks should not be verified")
+
+		// The latest block should not advance to the forged block.
+		time.Sleep(10 * srv.updateInterval)
+		status, err := srv.GetStatus(ctx)
+		require.NoError(err, "GetStatus")
+		require.EqualValues(testChainHeight, status.LatestHeight, "forged blocks should not be followed")
+	})
+}
+
+func TestServiceQuery(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("LightBlock", func(t *testing.T) {
+		require := require.New(t)
+		srv, backend := startTestService(t)
+
+		for _, height := range []int64{testChainHeight / 2, consensus.HeightLatest} {
+			lb, err := srv.GetLightBlock(ctx, height)
+			require.NoError(err, "GetLightBlock(%d)", height)
+
+			var protoLb tmproto.LightBlock
+			require.NoError(protoLb.Unmarshal(lb.Meta), "light block should be a Tendermint light block")
+			tlb, err := tmtypes.LightBlockFromProto(&protoLb)
+			require.NoError(err, "LightBlockFromProto")
+			require.EqualValues(lb.Height, tlb.Height)
+			require.EqualValues(backend.chain.blocks[lb.Height].Hash(), tlb.Hash(), "light block should match")
+		}
+	})
+
+	t.Run("Parameters", func(t *testing.T) {
+		require := require.New(t)
+		srv, backend := startTestService(t)
+
+		p, err := srv.GetParameters(ctx, testChainHeight/2)
+		require.NoError(err, "GetParameters")
+		require.EqualValues(testChainHeight/2, p.Height)
+
+		// Parameters not matching the verified header should be rejected.
+		params := tmtypes.DefaultConsensusParams()
+		params.Block.MaxGas = 1000
+		backend.Lock()
+		backend.params = params
+		backend.Unlock()
+		_, err = srv.GetParameters(ctx, testChainHeight/2)
+		require.Error(err, "GetParameters should fail for mismatched parameters")
+	})
+
+	t.Run("StateRoot", func(t *testing.T) {
+		require := require.New(t)
+		srv, backend := startTestService(t)
+
+		lc, err := srv.client()
+		require.NoError(err, "client")
+		root, err := lc.GetVerifiedStateRoot(ctx, testChainHeight/2)
+		require.NoError(err, "GetVerifiedStateRoot")
+		require.EqualValues(testChainHeight/2, root.Version)
+		require.EqualValues(backend.chain.blocks[testChainHeight/2+1].AppHash, root.Hash[:],
+			"state root should be committed in the next header",
+		)
+
+		_, err = lc.GetVerifiedStateRoot(ctx, testChainHeight)
+		require.Error(err, "GetVerifiedStateRoot should fail without the next header")
+	})
+
+	t.Run("Forwarded", func(t *testing.T) {
+		require := require.New(t)
+		srv, backend := startTestService(t)
+
+		require.Equal(backend, srv.State(), "state should be fetched from the primary")
+
+		tx := &transaction.SignedTransaction{}
+		require.NoError(srv.SubmitTxNoWait(ctx, tx), "SubmitTxNoWait")
+		evidence := &consensus.Evidence{Meta: []byte("evidence")}
+		require.NoError(srv.SubmitEvidence(ctx, evidence), "SubmitEvidence")
+		backend.Lock()
+		require.Equal([]*transaction.SignedTransaction{tx}, backend.txs, "transactions should be submitted to the primary")
+		require.Equal([]*consensus.Evidence{evidence}, backend.evidence, "evidence should be submitted to the primary")
+		backend.Unlock()
+
+		require.ErrorIs(srv.SubmitTx(ctx, tx), consensus.ErrUnsupported, "SubmitTx")
+		_, err := srv.GetTransactions(ctx, consensus.HeightLatest)
+		require.ErrorIs(err, consensus.ErrUnsupported, "GetTransactions")
+	})
+}
+
+func TestParseTrustHash(t *testing.T) {
+	require := require.New(t)
+
+	var h hash.Hash
+	h.FromBytes([]byte("trusted header"))
+	raw, err := parseTrustHash(h.Hex())
+	require.NoError(err, "parseTrustHash")
+	require.EqualValues(h[:], raw)
+
+	_, err = parseTrustHash("not hex")
+	require.Error(err, "parseTrustHash should fail for malformed hashes")
+}
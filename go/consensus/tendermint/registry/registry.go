@@ -44,6 +44,7 @@ type serviceClient struct {
 	nodeNotifier     *pubsub.Broker
 	nodeListNotifier *pubsub.Broker
 	runtimeNotifier  *pubsub.Broker
+	eventNotifier    *pubsub.Broker
 }
 
 // NodeListEpochInternalEvent is the per-epoch node list event.
@@ -73,6 +74,15 @@ func (sc *serviceClient) GetEntities(ctx context.Context, height int64) ([]*enti
 	return q.Entities(ctx)
 }
 
+func (sc *serviceClient) GetArchivedEntity(ctx context.Context, query *api.IDQuery) (*api.ArchivedEntity, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.ArchivedEntity(ctx, query.ID)
+}
+
 func (sc *serviceClient) WatchEntities(ctx context.Context) (<-chan *api.EntityEvent, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *api.EntityEvent)
 	sub := sc.entityNotifier.Subscribe()
@@ -90,6 +100,15 @@ func (sc *serviceClient) GetNode(ctx context.Context, query *api.IDQuery) (*node
 	return q.Node(ctx, query.ID)
 }
 
+func (sc *serviceClient) GetNodeWithProof(ctx context.Context, query *api.IDQuery) (*api.NodeWithProof, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.NodeWithProof(ctx, query.ID)
+}
+
 func (sc *serviceClient) GetNodeStatus(ctx context.Context, query *api.IDQuery) (*api.NodeStatus, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -99,6 +118,15 @@ func (sc *serviceClient) GetNodeStatus(ctx context.Context, query *api.IDQuery)
 	return q.NodeStatus(ctx, query.ID)
 }
 
+func (sc *serviceClient) GetArchivedNode(ctx context.Context, query *api.IDQuery) (*api.ArchivedNode, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.ArchivedNode(ctx, query.ID)
+}
+
 func (sc *serviceClient) GetNodes(ctx context.Context, height int64) ([]*node.Node, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -142,6 +170,32 @@ func (sc *serviceClient) GetRuntime(ctx context.Context, query *api.NamespaceQue
 	return q.Runtime(ctx, query.ID)
 }
 
+func (sc *serviceClient) GetRuntimeWithProof(ctx context.Context, query *api.NamespaceQuery) (*api.RuntimeWithProof, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.RuntimeWithProof(ctx, query.ID)
+}
+
+func (sc *serviceClient) GetRuntimeStatus(ctx context.Context, query *api.NamespaceQuery) (*api.RuntimeStatus, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.RuntimeStatus(ctx, query.ID)
+}
+
+func (sc *serviceClient) WatchEvents(ctx context.Context) (<-chan *api.Event, pubsub.ClosableSubscription, error) {
+	typedCh := make(chan *api.Event)
+	sub := sc.eventNotifier.Subscribe()
+	sub.Unwrap(typedCh)
+
+	return typedCh, sub, nil
+}
+
 func (sc *serviceClient) WatchRuntimes(ctx context.Context) (<-chan *api.Runtime, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *api.Runtime)
 	sub := sc.runtimeNotifier.Subscribe()
@@ -247,6 +301,8 @@ func (sc *serviceClient) DeliverEvent(ctx context.Context, height int64, tx tmty
 
 	// Notify subscribers of events.
 	for _, ev := range events {
+		sc.eventNotifier.Broadcast(ev)
+
 		if ev.EntityEvent != nil {
 			sc.entityNotifier.Broadcast(ev.EntityEvent)
 		}
@@ -322,6 +378,24 @@ func EventsFromTendermint(
 					RuntimeEvent: &api.RuntimeEvent{Runtime: &rt},
 				}
 				events = append(events, evt)
+			case bytes.Equal(key, app.KeyRuntimeSuspended):
+				// Runtime suspended event.
+				var ev api.RuntimeSuspendedEvent
+				if err := cbor.Unmarshal(val, &ev); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("registry: corrupt RuntimeSuspended event: %w", err))
+					continue
+				}
+
+				events = append(events, &api.Event{Height: height, TxHash: txHash, RuntimeSuspendedEvent: &ev})
+			case bytes.Equal(key, app.KeyRuntimeResumed):
+				// Runtime resumed event.
+				var ev api.RuntimeResumedEvent
+				if err := cbor.Unmarshal(val, &ev); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("registry: corrupt RuntimeResumed event: %w", err))
+					continue
+				}
+
+				events = append(events, &api.Event{Height: height, TxHash: txHash, RuntimeResumedEvent: &ev})
 			case bytes.Equal(key, app.KeyEntityRegistered):
 				// Entity registered event.
 				var ent entity.Entity
@@ -361,6 +435,15 @@ func EventsFromTendermint(
 					IsRegistration: true,
 				}
 				events = append(events, &api.Event{Height: height, TxHash: txHash, NodeEvent: nev})
+			case bytes.Equal(key, app.KeyNodeExpiring):
+				// Node expiring event.
+				var ev api.NodeExpiringEvent
+				if err := cbor.Unmarshal(val, &ev); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("registry: corrupt NodeExpiring event: %w", err))
+					continue
+				}
+
+				events = append(events, &api.Event{Height: height, TxHash: txHash, NodeExpiringEvent: &ev})
 			case bytes.Equal(key, app.KeyNodeUnfrozen):
 				// Node unfrozen event.
 				var nid signature.PublicKey
@@ -415,6 +498,7 @@ func New(ctx context.Context, backend tmapi.Backend) (ServiceClient, error) {
 		querier:        a.QueryFactory().(*app.QueryFactory),
 		entityNotifier: pubsub.NewBroker(false),
 		nodeNotifier:   pubsub.NewBroker(false),
+		eventNotifier:  pubsub.NewBroker(false),
 	}
 	sc.nodeListNotifier = pubsub.NewBrokerEx(func(ch channels.Channel) {
 		wr := ch.In()
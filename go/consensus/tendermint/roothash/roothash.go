@@ -39,6 +39,8 @@ type ServiceClient interface {
 type runtimeBrokers struct {
 	sync.Mutex
 
+	// Block and event subscriptions use unbounded buffers as subscribers (e.g., the runtime
+	// workers) rely on receiving every block and event and have no way to recover from drops.
 	blockNotifier *pubsub.Broker
 	eventNotifier *pubsub.Broker
 
@@ -59,6 +61,11 @@ type cmdTrackRuntime struct {
 	blockHistory api.BlockHistory
 }
 
+type cmdUntrackRuntime struct {
+	runtimeID common.Namespace
+	doneCh    chan struct{}
+}
+
 type serviceClient struct {
 	tmapi.BaseServiceClient
 	sync.RWMutex
@@ -133,6 +140,16 @@ func (sc *serviceClient) GetRuntimeState(ctx context.Context, request *api.Runti
 	return q.RuntimeState(ctx, request.RuntimeID)
 }
 
+// Implements api.Backend.
+func (sc *serviceClient) GetRuntimeStateWithProof(ctx context.Context, request *api.RuntimeRequest) (*api.RuntimeStateWithProof, error) {
+	q, err := sc.querier.QueryAt(ctx, request.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.RuntimeStateWithProof(ctx, request.RuntimeID)
+}
+
 // Implements api.Backend.
 func (sc *serviceClient) GetLastRoundResults(ctx context.Context, request *api.RuntimeRequest) (*api.RoundResults, error) {
 	q, err := sc.querier.QueryAt(ctx, request.Height)
@@ -236,6 +253,31 @@ func (sc *serviceClient) trackRuntime(ctx context.Context, id common.Namespace,
 	return nil
 }
 
+// Implements api.Backend.
+func (sc *serviceClient) UntrackRuntime(ctx context.Context, runtimeID common.Namespace) error {
+	cmd := &cmdUntrackRuntime{
+		runtimeID: runtimeID,
+		doneCh:    make(chan struct{}),
+	}
+
+	select {
+	case sc.cmdCh <- cmd:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// Wait for the command to be processed so that the caller can safely release the block
+	// history once this method returns.
+	select {
+	case <-cmd.doneCh:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	sc.pruneHandler.untrackRuntime(runtimeID)
+	return nil
+}
+
 // Implements api.Backend.
 func (sc *serviceClient) StateToGenesis(ctx context.Context, height int64) (*api.Genesis, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
@@ -491,6 +533,16 @@ func (sc *serviceClient) DeliverCommand(ctx context.Context, height int64, cmd i
 		}
 		// Make sure we reindex again when receiving the first event.
 		tr.reindexDone = false
+	case *cmdUntrackRuntime:
+		// Request to stop tracking a runtime.
+		if sc.trackedRuntime[c.runtimeID] != nil {
+			sc.logger.Debug("no longer tracking runtime",
+				"runtime_id", c.runtimeID,
+				"height", height,
+			)
+			delete(sc.trackedRuntime, c.runtimeID)
+		}
+		close(c.doneCh)
 	default:
 		return fmt.Errorf("roothash: unknown command: %T", cmd)
 	}
@@ -686,6 +738,16 @@ func EventsFromTendermint(
 
 				ev := &api.Event{RuntimeID: value.ID, Height: height, TxHash: txHash, Finalized: &value.Event}
 				events = append(events, ev)
+			case bytes.Equal(key, app.KeyRoundFailed):
+				// Round failed event.
+				var value app.ValueRoundFailed
+				if err := cbor.Unmarshal(val, &value); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("roothash: corrupt RoundFailed event: %w", err))
+					continue
+				}
+
+				ev := &api.Event{RuntimeID: value.ID, Height: height, TxHash: txHash, RoundFailed: &value.Event}
+				events = append(events, ev)
 			case bytes.Equal(key, app.KeyExecutionDiscrepancyDetected):
 				// An execution discrepancy has been detected.
 				var value app.ValueExecutionDiscrepancyDetected
@@ -706,6 +768,26 @@ func EventsFromTendermint(
 
 				ev := &api.Event{RuntimeID: value.ID, Height: height, TxHash: txHash, ExecutorCommitted: &value.Event}
 				events = append(events, ev)
+			case bytes.Equal(key, app.KeyMessage):
+				// A runtime message has been processed.
+				var value app.ValueMessage
+				if err := cbor.Unmarshal(val, &value); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("roothash: corrupt ValueMessage event: %w", err))
+					continue
+				}
+
+				ev := &api.Event{RuntimeID: value.ID, Height: height, TxHash: txHash, Message: &value.Event}
+				events = append(events, ev)
+			case bytes.Equal(key, app.KeyRuntimePaused):
+				// A runtime has been paused or resumed.
+				var value app.ValueRuntimePaused
+				if err := cbor.Unmarshal(val, &value); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("roothash: corrupt ValueRuntimePaused event: %w", err))
+					continue
+				}
+
+				ev := &api.Event{RuntimeID: value.ID, Height: height, TxHash: txHash, RuntimePaused: &value.Event}
+				events = append(events, ev)
 			case bytes.Equal(key, app.KeyRuntimeID):
 				// Runtime ID attribute (Base64-encoded to allow queries).
 			default:
@@ -731,6 +813,18 @@ func (ph *pruneHandler) trackRuntime(bh api.BlockHistory) {
 	ph.trackedRuntimes = append(ph.trackedRuntimes, bh)
 }
 
+func (ph *pruneHandler) untrackRuntime(runtimeID common.Namespace) {
+	ph.Lock()
+	defer ph.Unlock()
+
+	for i, bh := range ph.trackedRuntimes {
+		if bh.RuntimeID() == runtimeID {
+			ph.trackedRuntimes = append(ph.trackedRuntimes[:i], ph.trackedRuntimes[i+1:]...)
+			return
+		}
+	}
+}
+
 // Implements api.StatePruneHandler.
 func (ph *pruneHandler) Prune(ctx context.Context, version uint64) error {
 	ph.Lock()
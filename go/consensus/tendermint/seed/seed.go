@@ -2,12 +2,15 @@ package seed
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"github.com/tendermint/tendermint/config"
@@ -46,24 +49,74 @@ const (
 	// This is set to the same value as in tendermint.
 	tendermintSeedDisconnectWaitPeriod = 28 * time.Hour
 
+	// metricsUpdateInterval is the interval at which the seed node metrics are updated.
+	metricsUpdateInterval = 10 * time.Second
+
+	// bucketTypeOld is the address book bucket type of addresses that the node has successfully
+	// connected to.
+	bucketTypeOld = 0x02
+
+	// CfgDisconnectWaitPeriod configures the period after which the seed node disconnects from
+	// peers that it has crawled.
+	CfgDisconnectWaitPeriod = "consensus.tendermint.seed.disconnect_wait_period"
+	// CfgPrivatePeerIDs configures the IDs of peers whose addresses should never be gossiped.
+	CfgPrivatePeerIDs = "consensus.tendermint.seed.private_peer_id"
+
 	// CfgDebugDisableAddrBookFromGenesis disables populating seed node address book from genesis.
 	// This flag is used to disable initial addr book population from genesis in some E2E tests to
 	// test the seed node functionality.
 	CfgDebugDisableAddrBookFromGenesis = "consensus.tendermint.seed.debug.disable_addr_book_from_genesis"
 )
 
-// Flags has the configuration flags.
-var Flags = flag.NewFlagSet("", flag.ContinueOnError)
+var (
+	// Flags has the configuration flags.
+	Flags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	addrBookSize = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "oasis_tendermint_seed_addrbook_size",
+			Help: "Number of addresses in the seed node address book.",
+		},
+	)
+	numPeers = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "oasis_tendermint_seed_peers",
+			Help: "Number of peers connected to the seed node.",
+		},
+	)
+	seedCollectors = []prometheus.Collector{
+		addrBookSize,
+		numPeers,
+	}
+
+	metricsOnce sync.Once
+
+	_ consensus.AddressBookProvider = (*seedService)(nil)
+)
+
+// addrBookJSON is the serialized form of the Tendermint address book.
+type addrBookJSON struct {
+	Addrs []*struct {
+		Addr        *p2p.NetAddress `json:"addr"`
+		Src         *p2p.NetAddress `json:"src"`
+		Attempts    int32           `json:"attempts"`
+		BucketType  byte            `json:"bucket_type"`
+		LastAttempt time.Time       `json:"last_attempt"`
+		LastSuccess time.Time       `json:"last_success"`
+	} `json:"addrs"`
+}
 
 type seedService struct {
 	identity *identity.Identity
 
 	doc *genesis.Document
 
-	addr      *p2p.NetAddress
-	transport *p2p.MultiplexTransport
-	addrBook  pex.AddrBook
-	p2pSwitch *p2p.Switch
+	addr         *p2p.NetAddress
+	transport    *p2p.MultiplexTransport
+	addrBook     pex.AddrBook
+	addrBookPath string
+	addrBookLock sync.Mutex
+	p2pSwitch    *p2p.Switch
 
 	stopOnce sync.Once
 	quitCh   chan struct{}
@@ -85,9 +138,27 @@ func (srv *seedService) Start() error {
 		return fmt.Errorf("tendermint/seed: failed to start P2P switch: %w", err)
 	}
 
+	go srv.metricsWorker()
+
 	return nil
 }
 
+func (srv *seedService) metricsWorker() {
+	ticker := time.NewTicker(metricsUpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		addrBookSize.Set(float64(srv.addrBook.Size()))
+		numPeers.Set(float64(srv.p2pSwitch.Peers().Size()))
+
+		select {
+		case <-srv.quitCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // Stop halts the service.
 func (srv *seedService) Stop() {
 	srv.stopOnce.Do(func() {
@@ -148,6 +219,79 @@ func (srv *seedService) GetStatus(ctx context.Context) (*consensus.Status, error
 	return status, nil
 }
 
+// Implements consensus.AddressBookProvider.
+func (srv *seedService) GetAddressBook(ctx context.Context) (*consensus.AddressBook, error) {
+	srv.addrBookLock.Lock()
+	defer srv.addrBookLock.Unlock()
+
+	// The address book does not expose its entries, so persist it and read back the result.
+	srv.addrBook.Save()
+	raw, err := ioutil.ReadFile(srv.addrBookPath)
+	if err != nil {
+		return nil, fmt.Errorf("tendermint/seed: failed to read address book: %w", err)
+	}
+	var ab addrBookJSON
+	if err = json.Unmarshal(raw, &ab); err != nil {
+		return nil, fmt.Errorf("tendermint/seed: malformed address book: %w", err)
+	}
+
+	book := &consensus.AddressBook{
+		Entries: make([]*consensus.AddressBookEntry, 0, len(ab.Addrs)),
+	}
+	for _, ka := range ab.Addrs {
+		if ka.Addr == nil {
+			continue
+		}
+		entry := &consensus.AddressBookEntry{
+			Address:     ka.Addr.String(),
+			Good:        ka.BucketType == bucketTypeOld,
+			Attempts:    ka.Attempts,
+			LastAttempt: ka.LastAttempt,
+			LastSuccess: ka.LastSuccess,
+		}
+		if ka.Src != nil {
+			entry.Source = ka.Src.String()
+		}
+		book.Entries = append(book.Entries, entry)
+	}
+	return book, nil
+}
+
+// Implements consensus.AddressBookProvider.
+func (srv *seedService) ClearAddressBook(ctx context.Context) error {
+	book, err := srv.GetAddressBook(ctx)
+	if err != nil {
+		return err
+	}
+
+	srv.addrBookLock.Lock()
+	defer srv.addrBookLock.Unlock()
+
+	for _, entry := range book.Entries {
+		addr, perr := p2p.NewNetAddressString(entry.Address)
+		if perr != nil {
+			continue
+		}
+		srv.addrBook.RemoveAddress(addr)
+	}
+
+	// Make sure the genesis validators remain known so the seed node stays useful.
+	if !(viper.GetBool(CfgDebugDisableAddrBookFromGenesis) && cmflags.DebugDontBlameOasis()) {
+		if err = populateAddrBookFromGenesis(srv.addrBook, srv.doc, srv.addr); err != nil {
+			return fmt.Errorf("tendermint/seed: failed to populate address book from genesis: %w", err)
+		}
+	}
+	srv.addrBook.Save()
+	addrBookSize.Set(float64(srv.addrBook.Size()))
+
+	return nil
+}
+
+// Implements Backend.
+func (srv *seedService) GetNetworkMetadata(ctx context.Context) (*consensus.NetworkMetadata, error) {
+	return nil, consensus.ErrUnsupported
+}
+
 // Implements Backend.
 func (srv *seedService) GetNextBlockState(ctx context.Context) (*consensus.NextBlockState, error) {
 	return nil, consensus.ErrUnsupported
@@ -194,6 +338,16 @@ func (srv *seedService) SubmitTx(ctx context.Context, tx *transaction.SignedTran
 	return consensus.ErrUnsupported
 }
 
+// Implements Backend.
+func (srv *seedService) SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*transaction.Proof, error) {
+	return nil, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *seedService) SubmitTxBatch(ctx context.Context, txs []*transaction.SignedTransaction) ([]*consensus.SubmitTxBatchResult, error) {
+	return nil, consensus.ErrUnsupported
+}
+
 // Implements Backend.
 func (srv *seedService) StateToGenesis(ctx context.Context, height int64) (*genesis.Document, error) {
 	return nil, consensus.ErrUnsupported
@@ -234,6 +388,11 @@ func (srv *seedService) GetSignerNonce(ctx context.Context, req *consensus.GetSi
 	return 0, consensus.ErrUnsupported
 }
 
+// Implements Backend.
+func (srv *seedService) GetValidatorSet(ctx context.Context, height int64) (*consensus.ValidatorSet, error) {
+	return nil, consensus.ErrUnsupported
+}
+
 // Implements Backend.
 func (srv *seedService) GetLightBlock(ctx context.Context, height int64) (*consensus.LightBlock, error) {
 	return nil, consensus.ErrUnsupported
@@ -316,6 +475,10 @@ func New(dataDir string, identity *identity.Identity, genesisProvider genesis.Pr
 	// and reaches into tendermint to spin up the minimum components required
 	// to get the PEX reactor to operate in seed mode.
 
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(seedCollectors...)
+	})
+
 	srv := &seedService{
 		quitCh:   make(chan struct{}),
 		identity: identity,
@@ -337,6 +500,12 @@ func New(dataDir string, identity *identity.Identity, genesisProvider genesis.Pr
 	p2pCfg.AddrBookStrict = !(viper.GetBool(tmcommon.CfgDebugP2PAddrBookLenient) && cmflags.DebugDontBlameOasis())
 	p2pCfg.AllowDuplicateIP = viper.GetBool(tmcommon.CfgDebugP2PAllowDuplicateIP) && cmflags.DebugDontBlameOasis()
 
+	var privatePeerIDs []string
+	for _, id := range viper.GetStringSlice(CfgPrivatePeerIDs) {
+		privatePeerIDs = append(privatePeerIDs, strings.ToLower(id))
+	}
+	p2pCfg.PrivatePeerIDs = strings.Join(privatePeerIDs, ",")
+
 	nodeKey := &p2p.NodeKey{PrivKey: crypto.SignerToTendermint(identity.P2PSigner)}
 
 	doc, err := genesisProvider.GetGenesisDocument()
@@ -366,9 +535,10 @@ func New(dataDir string, identity *identity.Identity, genesisProvider genesis.Pr
 	}
 	srv.transport = p2p.NewMultiplexTransport(nodeInfo, *nodeKey, p2p.MConnConfig(p2pCfg))
 
-	addrBookPath := filepath.Join(seedDataDir, tmcommon.ConfigDir, "addrbook.json")
-	srv.addrBook = pex.NewAddrBook(addrBookPath, p2pCfg.AddrBookStrict)
+	srv.addrBookPath = filepath.Join(seedDataDir, tmcommon.ConfigDir, "addrbook.json")
+	srv.addrBook = pex.NewAddrBook(srv.addrBookPath, p2pCfg.AddrBookStrict)
 	srv.addrBook.SetLogger(logger.With("module", "book"))
+	srv.addrBook.AddPrivateIDs(privatePeerIDs)
 	if err = srv.addrBook.Start(); err != nil {
 		return nil, fmt.Errorf("tendermint/seed: failed to start address book: %w", err)
 	}
@@ -390,7 +560,7 @@ func New(dataDir string, identity *identity.Identity, genesisProvider genesis.Pr
 	pexReactor := pex.NewReactor(srv.addrBook, &pex.ReactorConfig{
 		SeedMode:                 p2pCfg.SeedMode,
 		Seeds:                    seeds,
-		SeedDisconnectWaitPeriod: tendermintSeedDisconnectWaitPeriod,
+		SeedDisconnectWaitPeriod: viper.GetDuration(CfgDisconnectWaitPeriod),
 	})
 	pexReactor.SetLogger(logger.With("module", "pex"))
 
@@ -449,6 +619,8 @@ func populateAddrBookFromGenesis(addrBook p2p.AddrBook, doc *genesis.Document, o
 }
 
 func init() {
+	Flags.Duration(CfgDisconnectWaitPeriod, tendermintSeedDisconnectWaitPeriod, "period after which crawled peers are disconnected")
+	Flags.StringSlice(CfgPrivatePeerIDs, []string{}, "IDs of peers whose addresses should never be gossiped")
 	Flags.Bool(CfgDebugDisableAddrBookFromGenesis, false, "disable populating address book with genesis validators")
 
 	_ = Flags.MarkHidden(CfgDebugDisableAddrBookFromGenesis)
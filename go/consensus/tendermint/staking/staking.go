@@ -11,14 +11,15 @@ import (
 	tmrpctypes "github.com/tendermint/tendermint/rpc/core/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 
-	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	app "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking"
 	"github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 )
 
 // ServiceClient is the scheduler service client interface.
@@ -39,21 +40,47 @@ type serviceClient struct {
 }
 
 func (sc *serviceClient) TokenSymbol(ctx context.Context) (string, error) {
-	genesis, err := sc.backend.GetGenesisDocument(ctx)
+	meta, err := sc.TokenMetadata(ctx, consensus.HeightLatest)
 	if err != nil {
 		return "", err
 	}
 
-	return genesis.Staking.TokenSymbol, nil
+	return meta.Symbol, nil
 }
 
 func (sc *serviceClient) TokenValueExponent(ctx context.Context) (uint8, error) {
-	genesis, err := sc.backend.GetGenesisDocument(ctx)
+	meta, err := sc.TokenMetadata(ctx, consensus.HeightLatest)
 	if err != nil {
 		return 0, err
 	}
 
-	return genesis.Staking.TokenValueExponent, nil
+	return meta.ValueExponent, nil
+}
+
+func (sc *serviceClient) TokenMetadata(ctx context.Context, height int64) (*token.Metadata, error) {
+	q, err := sc.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := q.TokenMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if meta != nil {
+		return meta, nil
+	}
+
+	// Token metadata has not been stored in state, fall back to the genesis document.
+	genesis, err := sc.backend.GetGenesisDocument(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.Metadata{
+		Symbol:        genesis.Staking.TokenSymbol,
+		ValueExponent: genesis.Staking.TokenValueExponent,
+	}, nil
 }
 
 func (sc *serviceClient) TotalSupply(ctx context.Context, height int64) (*quantity.Quantity, error) {
@@ -119,6 +146,15 @@ func (sc *serviceClient) Account(ctx context.Context, query *api.OwnerQuery) (*a
 	return q.Account(ctx, query.Owner)
 }
 
+func (sc *serviceClient) AccountWithProof(ctx context.Context, query *api.OwnerQuery) (*api.AccountWithProof, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.AccountWithProof(ctx, query.Owner)
+}
+
 func (sc *serviceClient) DelegationsFor(ctx context.Context, query *api.OwnerQuery) (map[api.Address]*api.Delegation, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -173,6 +209,15 @@ func (sc *serviceClient) DebondingDelegationsTo(ctx context.Context, query *api.
 	return q.DebondingDelegationsTo(ctx, query.Owner)
 }
 
+func (sc *serviceClient) RedelegationsFor(ctx context.Context, query *api.OwnerQuery) (map[api.Address][]*api.Redelegation, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.RedelegationsFor(ctx, query.Owner)
+}
+
 func (sc *serviceClient) Allowance(ctx context.Context, query *api.AllowanceQuery) (*quantity.Quantity, error) {
 	acct, err := sc.Account(ctx, &api.OwnerQuery{
 		Height: query.Height,
@@ -197,15 +242,13 @@ func (sc *serviceClient) StateToGenesis(ctx context.Context, height int64) (*api
 		return nil, err
 	}
 
-	// Add static values to the genesis document.
-	genesis.TokenSymbol, err = sc.TokenSymbol(ctx)
-	if err != nil {
-		return nil, err
-	}
-	genesis.TokenValueExponent, err = sc.TokenValueExponent(ctx)
+	// Add token metadata to the genesis document.
+	meta, err := sc.TokenMetadata(ctx, height)
 	if err != nil {
 		return nil, err
 	}
+	genesis.TokenSymbol = meta.Symbol
+	genesis.TokenValueExponent = meta.ValueExponent
 
 	return genesis, nil
 }
@@ -232,20 +275,15 @@ func (sc *serviceClient) GetEvents(ctx context.Context, height int64) ([]*api.Ev
 		return nil, err
 	}
 
+	// Decode events in the order in which they were emitted during block
+	// execution.
 	var events []*api.Event
-	// Decode events from block results.
 	blockEvs, err := EventsFromTendermint(nil, results.Height, results.BeginBlockEvents)
 	if err != nil {
 		return nil, err
 	}
 	events = append(events, blockEvs...)
 
-	blockEvs, err = EventsFromTendermint(nil, results.Height, results.EndBlockEvents)
-	if err != nil {
-		return nil, err
-	}
-	events = append(events, blockEvs...)
-
 	// Decode events from transaction results.
 	for txIdx, txResult := range results.TxsResults {
 		// The order of transactions in txns and results.TxsResults is
@@ -258,9 +296,69 @@ func (sc *serviceClient) GetEvents(ctx context.Context, height int64) ([]*api.Ev
 		events = append(events, evs...)
 	}
 
+	blockEvs, err = EventsFromTendermint(nil, results.Height, results.EndBlockEvents)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, blockEvs...)
+
 	return events, nil
 }
 
+func (sc *serviceClient) GetEventsFrom(ctx context.Context, query *api.EventsQuery) (*api.EventsPage, error) {
+	limit := query.Limit
+	if limit == 0 || limit > api.MaxEventsLimit {
+		limit = api.MaxEventsLimit
+	}
+	kinds := make(map[string]bool)
+	for _, kind := range query.Kinds {
+		kinds[kind] = true
+	}
+
+	latest, err := sc.backend.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return nil, err
+	}
+	if query.Cursor.Height <= 0 {
+		return nil, fmt.Errorf("staking: invalid cursor height: %d", query.Cursor.Height)
+	}
+
+	page := &api.EventsPage{
+		Events: []*api.Event{},
+		Next:   query.Cursor,
+	}
+	lastHeight := query.Cursor.Height + api.MaxEventsHeights - 1
+	if lastHeight > latest.Height {
+		lastHeight = latest.Height
+	}
+	for height := query.Cursor.Height; height <= lastHeight; height++ {
+		events, err := sc.GetEvents(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+
+		var index uint64
+		if height == query.Cursor.Height {
+			index = query.Cursor.Index
+		}
+		for ; index < uint64(len(events)); index++ {
+			if uint64(len(page.Events)) >= limit {
+				page.Next = api.EventCursor{Height: height, Index: index}
+				return page, nil
+			}
+
+			ev := events[index]
+			if len(kinds) > 0 && !kinds[ev.Kind()] {
+				continue
+			}
+			page.Events = append(page.Events, ev)
+		}
+		page.Next = api.EventCursor{Height: height + 1}
+	}
+
+	return page, nil
+}
+
 func (sc *serviceClient) WatchEvents(ctx context.Context) (<-chan *api.Event, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *api.Event)
 	sub := sc.eventNotifier.Subscribe()
@@ -278,6 +376,24 @@ func (sc *serviceClient) ConsensusParameters(ctx context.Context, height int64)
 	return q.ConsensusParameters(ctx)
 }
 
+func (sc *serviceClient) Evidence(ctx context.Context, height int64) ([]*api.EvidenceEvent, error) {
+	q, err := sc.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.Evidence(ctx)
+}
+
+func (sc *serviceClient) FeeStatistics(ctx context.Context, height int64) (*api.FeeStatistics, error) {
+	q, err := sc.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.FeeStatistics(ctx)
+}
+
 func (sc *serviceClient) Cleanup() {
 }
 
@@ -324,83 +440,18 @@ func EventsFromTendermint(
 		}
 
 		for _, pair := range tmEv.GetAttributes() {
-			key := pair.GetKey()
-			val := pair.GetValue()
-
-			switch {
-			case tmapi.IsAttributeKind(key, &api.TakeEscrowEvent{}):
-				// Take escrow event.
-				var e api.TakeEscrowEvent
-				if err := cbor.Unmarshal(val, &e); err != nil {
-					errs = multierror.Append(errs, fmt.Errorf("staking: corrupt TakeEscrow event: %w", err))
-					continue
-				}
-
-				evt := &api.Event{Height: height, TxHash: txHash, Escrow: &api.EscrowEvent{Take: &e}}
-				events = append(events, evt)
-			case tmapi.IsAttributeKind(key, &api.TransferEvent{}):
-				// Transfer event.
-				var e api.TransferEvent
-				if err := cbor.Unmarshal(val, &e); err != nil {
-					errs = multierror.Append(errs, fmt.Errorf("staking: corrupt Transfer event: %w", err))
-					continue
-				}
-
-				evt := &api.Event{Height: height, TxHash: txHash, Transfer: &e}
-				events = append(events, evt)
-			case tmapi.IsAttributeKind(key, &api.ReclaimEscrowEvent{}):
-				// Reclaim escrow event.
-				var e api.ReclaimEscrowEvent
-				if err := cbor.Unmarshal(val, &e); err != nil {
-					errs = multierror.Append(errs, fmt.Errorf("staking: corrupt ReclaimEscrow event: %w", err))
-					continue
-				}
-
-				evt := &api.Event{Height: height, TxHash: txHash, Escrow: &api.EscrowEvent{Reclaim: &e}}
-				events = append(events, evt)
-			case tmapi.IsAttributeKind(key, &api.AddEscrowEvent{}):
-				// Add escrow event.
-				var e api.AddEscrowEvent
-				if err := cbor.Unmarshal(val, &e); err != nil {
-					errs = multierror.Append(errs, fmt.Errorf("staking: corrupt AddEscrow event: %w", err))
-					continue
-				}
-
-				evt := &api.Event{Height: height, TxHash: txHash, Escrow: &api.EscrowEvent{Add: &e}}
-				events = append(events, evt)
-			case tmapi.IsAttributeKind(key, &api.DebondingStartEscrowEvent{}):
-				// Debonding start escrow event.
-				var e api.DebondingStartEscrowEvent
-				if err := cbor.Unmarshal(val, &e); err != nil {
-					errs = multierror.Append(errs, fmt.Errorf("staking: corrupt DebondingStart escrow event: %w", err))
-					continue
-				}
-
-				evt := &api.Event{Height: height, TxHash: txHash, Escrow: &api.EscrowEvent{DebondingStart: &e}}
-				events = append(events, evt)
-			case tmapi.IsAttributeKind(key, &api.BurnEvent{}):
-				// Burn event.
-				var e api.BurnEvent
-				if err := cbor.Unmarshal(val, &e); err != nil {
-					errs = multierror.Append(errs, fmt.Errorf("staking: corrupt Burn event: %w", err))
-					continue
-				}
-
-				evt := &api.Event{Height: height, TxHash: txHash, Burn: &e}
-				events = append(events, evt)
-			case tmapi.IsAttributeKind(key, &api.AllowanceChangeEvent{}):
-				// Allowance change event.
-				var e api.AllowanceChangeEvent
-				if err := cbor.Unmarshal(val, &e); err != nil {
-					errs = multierror.Append(errs, fmt.Errorf("staking: corrupt AllowanceChange event: %w", err))
-					continue
-				}
-
-				evt := &api.Event{Height: height, TxHash: txHash, AllowanceChange: &e}
-				events = append(events, evt)
-			default:
-				errs = multierror.Append(errs, fmt.Errorf("staking: unknown event type: key: %s, val: %s", key, val))
+			body, err := api.DecodeEventBody(string(pair.GetKey()), pair.GetValue())
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+
+			evt, err := api.NewEvent(height, txHash, body)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				continue
 			}
+			events = append(events, evt)
 		}
 	}
 
@@ -11,6 +11,7 @@ import (
 	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/common"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/full"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/light"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/seed"
 	genesisAPI "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	upgradeAPI "github.com/oasisprotocol/oasis-core/go/upgrade/api"
@@ -27,6 +28,9 @@ const (
 
 	// ModeSeed is the name of the seed-only node consensus mode.
 	ModeSeed = "seed"
+
+	// ModeLight is the name of the light client node consensus mode.
+	ModeLight = "light"
 )
 
 // Flags has the configuration flags.
@@ -47,15 +51,19 @@ func New(
 	case ModeSeed:
 		// Seed-only node.
 		return seed.New(dataDir, identity, genesisProvider)
+	case ModeLight:
+		// Light client node.
+		return light.New(ctx, identity, genesisProvider)
 	default:
 		return nil, fmt.Errorf("tendermint: unsupported mode: %s", mode)
 	}
 }
 
 func init() {
-	Flags.String(CfgMode, ModeFull, "tendermint mode (full, seed)")
+	Flags.String(CfgMode, ModeFull, "tendermint mode (full, seed, light)")
 
 	_ = viper.BindPFlags(Flags)
 	Flags.AddFlagSet(common.Flags)
 	Flags.AddFlagSet(full.Flags)
+	Flags.AddFlagSet(light.Flags)
 }
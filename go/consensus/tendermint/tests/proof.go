@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+// WaitStateRoot waits for the block following the given height and returns the consensus state
+// root of the state at the given height, suitable for verifying state proofs.
+func WaitStateRoot(ctx context.Context, backend consensus.Backend, height int64) (*mkvsNode.Root, error) {
+	ch, sub, err := backend.WatchBlocks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch blocks: %w", err)
+	}
+	defer sub.Close()
+
+	for {
+		blk, err := backend.GetBlock(ctx, consensus.HeightLatest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest block: %w", err)
+		}
+		if blk.Height > height {
+			if blk, err = backend.GetBlock(ctx, height+1); err != nil {
+				return nil, fmt.Errorf("failed to get block %d: %w", height+1, err)
+			}
+			return &blk.StateRoot, nil
+		}
+
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -10,8 +10,11 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
@@ -52,6 +55,17 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 	// We run this test without pruning. All we check is that we retain everything as configured.
 	require.EqualValues(1, status.LastRetainedHeight, "last retained height must be 1")
 
+	meta, err := backend.GetNetworkMetadata(ctx)
+	require.NoError(err, "GetNetworkMetadata")
+	require.EqualValues(chainCtx, meta.ChainContext, "network metadata chain context should be correct")
+	require.EqualValues(status.GenesisHeight, meta.GenesisHeight, "network metadata genesis height should match")
+	require.EqualValues(status.GenesisHash, meta.GenesisHash, "network metadata genesis hash should match")
+	require.EqualValues(version.Versions, meta.Versions, "network metadata protocol versions should match")
+	require.True(meta.Height >= status.LatestHeight, "network metadata height should not be stale")
+	beaconParams, err := backend.Beacon().ConsensusParameters(ctx, meta.Height)
+	require.NoError(err, "Beacon.ConsensusParameters")
+	require.EqualValues(beaconParams.Interval(), meta.EpochInterval, "network metadata epoch interval should match")
+
 	blk, err = backend.GetBlock(ctx, status.LatestHeight)
 	require.NoError(err, "GetBlock")
 
@@ -74,6 +88,14 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 		len(txsWithResults.Transactions),
 		"GetTransactionsWithResults.Results length mismatch",
 	)
+	decodedTxs, err := txsWithResults.Decode()
+	require.NoError(err, "TransactionsWithResults.Decode")
+	require.Len(decodedTxs, len(txs), "TransactionsWithResults.Decode length mismatch")
+	for i, tx := range decodedTxs {
+		require.EqualValues(txs[i], tx.Raw, "decoded raw transaction should match")
+		require.NotNil(tx.Transaction, "transaction should be decoded")
+		require.Equal(tx.Result.IsSuccess(), tx.Result.Error.Err() == nil, "typed error should match result")
+	}
 
 	_, err = backend.GetUnconfirmedTransactions(ctx)
 	require.NoError(err, "GetUnconfirmedTransactions")
@@ -134,6 +156,23 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 	require.NoError(err, "GetParameters(HeightLatest)")
 	require.NotEqual(0, lparams.Parameters.StateCheckpointInterval, "returned parameters should contain parameters")
 
+	vs, err := backend.GetValidatorSet(ctx, blk.Height)
+	require.NoError(err, "GetValidatorSet")
+	require.Equal(blk.Height, vs.Height, "returned validator set height should be correct")
+	require.NotEmpty(vs.Validators, "returned validator set should not be empty")
+	var totalVotingPower int64
+	for _, v := range vs.Validators {
+		require.True(v.VotingPower > 0, "validator voting power should be positive")
+		require.True(v.ID.IsValid(), "validator should be resolved to a node")
+		totalVotingPower += v.VotingPower
+	}
+	require.Equal(totalVotingPower, vs.TotalVotingPower, "total voting power should be correct")
+	require.NotNil(vs.Parameters, "returned validator set should contain parameters")
+	require.Equal(params.Parameters, vs.Parameters.Parameters, "returned parameters should be correct")
+
+	_, err = backend.GetValidatorSet(ctx, consensus.HeightLatest)
+	require.NoError(err, "GetValidatorSet(HeightLatest)")
+
 	err = backend.SubmitTxNoWait(ctx, &transaction.SignedTransaction{})
 	require.Error(err, "SubmitTxNoWait should fail with invalid transaction")
 
@@ -152,6 +191,17 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 	require.Error(err, "SubmitTxNoWait(duplicate)")
 	require.True(errors.Is(err, consensus.ErrDuplicateTx), "SubmitTxNoWait should return ErrDuplicateTx on duplicate tx")
 
+	// Submit a batch of transactions and make sure per-transaction results are returned.
+	testTx2 := transaction.NewTransaction(1, nil, staking.MethodTransfer, &staking.Transfer{})
+	testSigTx2, err := transaction.Sign(testSigner, testTx2)
+	require.NoError(err, "transaction.Sign")
+	batchResults, err := backend.SubmitTxBatch(ctx, []*transaction.SignedTransaction{testSigTx2, testSigTx})
+	require.NoError(err, "SubmitTxBatch")
+	require.Len(batchResults, 2, "SubmitTxBatch should return a result for each transaction")
+	require.Equal(hash.NewFromBytes(cbor.Marshal(testSigTx2)), batchResults[0].Hash, "SubmitTxBatch should return the transaction hash")
+	require.NoError(batchResults[0].Error.Err(), "SubmitTxBatch should accept a new transaction")
+	require.True(errors.Is(batchResults[1].Error.Err(), consensus.ErrDuplicateTx), "SubmitTxBatch should return ErrDuplicateTx on duplicate tx")
+
 	// We should be able to do remote state queries. Of course the state format is backend-specific
 	// so we simply perform some usual storage operations like fetching random keys and iterating
 	// through everything.
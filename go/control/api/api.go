@@ -9,15 +9,18 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/diskmon"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	block "github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 	commonWorker "github.com/oasisprotocol/oasis-core/go/worker/common/api"
+	executorWorker "github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
 	storageWorker "github.com/oasisprotocol/oasis-core/go/worker/storage/api"
 )
 
@@ -51,6 +54,62 @@ type NodeController interface {
 
 	// GetStatus returns the current status overview of the node.
 	GetStatus(ctx context.Context) (*Status, error)
+
+	// PruneConsensus starts pruning all consensus state and blocks below the
+	// given height. Pruning is performed asynchronously and the returned job
+	// can be used to query its progress.
+	PruneConsensus(ctx context.Context, height uint64) (*PruneJob, error)
+
+	// PruneRuntime starts pruning all runtime history and storage below the
+	// given round. Pruning is performed asynchronously and the returned job
+	// can be used to query its progress.
+	PruneRuntime(ctx context.Context, req *PruneRuntimeRequest) (*PruneJob, error)
+
+	// GetPruneJob returns the status of the given prune job.
+	GetPruneJob(ctx context.Context, id uint64) (*PruneJob, error)
+
+	// GetPruneJobs returns the status of all prune jobs started since the
+	// node has been started.
+	GetPruneJobs(ctx context.Context) ([]*PruneJob, error)
+
+	// CheckConsensusInvariants checks all registered consensus state
+	// invariants against the latest consensus state.
+	CheckConsensusInvariants(ctx context.Context) (*consensus.InvariantsReport, error)
+
+	// GetAddressBook returns the contents of the consensus P2P address book.
+	//
+	// This is only supported by consensus backends that maintain an address
+	// book (e.g., seed nodes).
+	GetAddressBook(ctx context.Context) (*consensus.AddressBook, error)
+
+	// ClearAddressBook removes all learned addresses from the consensus P2P
+	// address book.
+	ClearAddressBook(ctx context.Context) error
+
+	// GetTxPoolTransactions returns information about all transactions in
+	// the node's transaction pool for the given runtime.
+	GetTxPoolTransactions(ctx context.Context, runtimeID common.Namespace) ([]*txpool.TransactionInfo, error)
+
+	// RemoveTxPoolTransaction removes a transaction from the node's
+	// transaction pool for the given runtime.
+	RemoveTxPoolTransaction(ctx context.Context, req *RemoveTxPoolTransactionRequest) error
+
+	// AddRuntime starts hosting a new runtime without restarting the node.
+	//
+	// This is only supported by client nodes and the change is not persisted
+	// across node restarts.
+	AddRuntime(ctx context.Context, req *AddRuntimeRequest) error
+
+	// RemoveRuntime stops hosting the given runtime without restarting the
+	// node.
+	//
+	// This is only supported by client nodes and the change is not persisted
+	// across node restarts.
+	RemoveRuntime(ctx context.Context, runtimeID common.Namespace) error
+
+	// GetExecutionReports returns the most recent per-round execution reports
+	// of the node's executor worker for the given runtime, newest first.
+	GetExecutionReports(ctx context.Context, req *GetExecutionReportsRequest) ([]*executorWorker.ExecutionReport, error)
 }
 
 // Status is the current status overview.
@@ -72,6 +131,12 @@ type Status struct {
 
 	// PendingUpgrades are the node's pending upgrades.
 	PendingUpgrades []*upgrade.PendingUpgrade `json:"pending_upgrades"`
+
+	// Disk is the node's disk space status in case the disk space monitor is enabled.
+	Disk *diskmon.Status `json:"disk,omitempty"`
+
+	// P2P is the status of the runtime worker P2P network in case it is enabled.
+	P2P *commonWorker.P2PStatus `json:"p2p,omitempty"`
 }
 
 // IdentityStatus is the current node identity status, listing all the public keys that identify
@@ -154,6 +219,39 @@ type ControlledNode interface {
 
 	// GetPendingUpgrade returns the node's pending upgrades.
 	GetPendingUpgrades(ctx context.Context) ([]*upgrade.PendingUpgrade, error)
+
+	// GetDiskStatus returns the node's disk space status or nil in case the disk space monitor
+	// is disabled.
+	GetDiskStatus(ctx context.Context) (*diskmon.Status, error)
+
+	// GetP2PStatus returns the status of the runtime worker P2P network or nil in case the P2P
+	// network is disabled.
+	GetP2PStatus(ctx context.Context) (*commonWorker.P2PStatus, error)
+
+	// PruneConsensus prunes all consensus state and blocks below the given
+	// height. The progress callback is called with the last pruned height.
+	PruneConsensus(ctx context.Context, height uint64, progressFn func(height uint64)) error
+
+	// PruneRuntime prunes all runtime history and storage below the given
+	// round. The progress callback is called with the last pruned round.
+	PruneRuntime(ctx context.Context, runtimeID common.Namespace, round uint64, progressFn func(round uint64)) error
+
+	// CheckConsensusInvariants checks all registered consensus state invariants against the
+	// latest consensus state.
+	CheckConsensusInvariants(ctx context.Context) (*consensus.InvariantsReport, error)
+
+	// GetTxPool returns the node's transaction pool for the given runtime.
+	GetTxPool(ctx context.Context, runtimeID common.Namespace) (txpool.TransactionPool, error)
+
+	// AddRuntime starts hosting a new runtime.
+	AddRuntime(ctx context.Context, req *AddRuntimeRequest) error
+
+	// RemoveRuntime stops hosting the given runtime.
+	RemoveRuntime(ctx context.Context, runtimeID common.Namespace) error
+
+	// GetExecutionReports returns up to limit most recent execution reports of the node's
+	// executor worker for the given runtime.
+	GetExecutionReports(ctx context.Context, runtimeID common.Namespace, limit uint64) ([]*executorWorker.ExecutionReport, error)
 }
 
 // DebugModuleName is the module name for the debug controller service.
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+)
+
+// ErrExecutionReportsUnavailable is the error returned when the node does not run an executor
+// worker for the given runtime.
+var ErrExecutionReportsUnavailable = errors.New(ModuleName, 7, "control: execution reports not available")
+
+// GetExecutionReportsRequest is a GetExecutionReports request.
+type GetExecutionReportsRequest struct {
+	// RuntimeID is the identifier of the runtime.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Limit is the maximum number of reports to return. Zero returns all retained reports.
+	Limit uint64 `json:"limit,omitempty"`
+}
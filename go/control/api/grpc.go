@@ -5,8 +5,12 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
 	upgradeApi "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	executorWorker "github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
 )
 
 var (
@@ -29,6 +33,30 @@ var (
 	methodCancelUpgrade = serviceName.NewMethod("CancelUpgrade", nil)
 	// methodGetStatus is the GetStatus method.
 	methodGetStatus = serviceName.NewMethod("GetStatus", nil)
+	// methodPruneConsensus is the PruneConsensus method.
+	methodPruneConsensus = serviceName.NewMethod("PruneConsensus", uint64(0))
+	// methodPruneRuntime is the PruneRuntime method.
+	methodPruneRuntime = serviceName.NewMethod("PruneRuntime", PruneRuntimeRequest{})
+	// methodGetPruneJob is the GetPruneJob method.
+	methodGetPruneJob = serviceName.NewMethod("GetPruneJob", uint64(0))
+	// methodGetPruneJobs is the GetPruneJobs method.
+	methodGetPruneJobs = serviceName.NewMethod("GetPruneJobs", nil)
+	// methodCheckConsensusInvariants is the CheckConsensusInvariants method.
+	methodCheckConsensusInvariants = serviceName.NewMethod("CheckConsensusInvariants", nil)
+	// methodGetAddressBook is the GetAddressBook method.
+	methodGetAddressBook = serviceName.NewMethod("GetAddressBook", nil)
+	// methodClearAddressBook is the ClearAddressBook method.
+	methodClearAddressBook = serviceName.NewMethod("ClearAddressBook", nil)
+	// methodGetTxPoolTransactions is the GetTxPoolTransactions method.
+	methodGetTxPoolTransactions = serviceName.NewMethod("GetTxPoolTransactions", common.Namespace{})
+	// methodRemoveTxPoolTransaction is the RemoveTxPoolTransaction method.
+	methodRemoveTxPoolTransaction = serviceName.NewMethod("RemoveTxPoolTransaction", RemoveTxPoolTransactionRequest{})
+	// methodAddRuntime is the AddRuntime method.
+	methodAddRuntime = serviceName.NewMethod("AddRuntime", AddRuntimeRequest{})
+	// methodRemoveRuntime is the RemoveRuntime method.
+	methodRemoveRuntime = serviceName.NewMethod("RemoveRuntime", common.Namespace{})
+	// methodGetExecutionReports is the GetExecutionReports method.
+	methodGetExecutionReports = serviceName.NewMethod("GetExecutionReports", GetExecutionReportsRequest{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -67,6 +95,54 @@ var (
 				MethodName: methodGetStatus.ShortName(),
 				Handler:    handlerGetStatus,
 			},
+			{
+				MethodName: methodPruneConsensus.ShortName(),
+				Handler:    handlerPruneConsensus,
+			},
+			{
+				MethodName: methodPruneRuntime.ShortName(),
+				Handler:    handlerPruneRuntime,
+			},
+			{
+				MethodName: methodGetPruneJob.ShortName(),
+				Handler:    handlerGetPruneJob,
+			},
+			{
+				MethodName: methodGetPruneJobs.ShortName(),
+				Handler:    handlerGetPruneJobs,
+			},
+			{
+				MethodName: methodCheckConsensusInvariants.ShortName(),
+				Handler:    handlerCheckConsensusInvariants,
+			},
+			{
+				MethodName: methodGetAddressBook.ShortName(),
+				Handler:    handlerGetAddressBook,
+			},
+			{
+				MethodName: methodClearAddressBook.ShortName(),
+				Handler:    handlerClearAddressBook,
+			},
+			{
+				MethodName: methodGetTxPoolTransactions.ShortName(),
+				Handler:    handlerGetTxPoolTransactions,
+			},
+			{
+				MethodName: methodRemoveTxPoolTransaction.ShortName(),
+				Handler:    handlerRemoveTxPoolTransaction,
+			},
+			{
+				MethodName: methodAddRuntime.ShortName(),
+				Handler:    handlerAddRuntime,
+			},
+			{
+				MethodName: methodRemoveRuntime.ShortName(),
+				Handler:    handlerRemoveRuntime,
+			},
+			{
+				MethodName: methodGetExecutionReports.ShortName(),
+				Handler:    handlerGetExecutionReports,
+			},
 		},
 		Streams: []grpc.StreamDesc{},
 	}
@@ -236,6 +312,266 @@ func handlerGetStatus( // nolint: golint
 	return interceptor(ctx, nil, info, handler)
 }
 
+func handlerPruneConsensus( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height uint64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).PruneConsensus(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodPruneConsensus.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).PruneConsensus(ctx, req.(uint64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
+func handlerPruneRuntime( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req PruneRuntimeRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).PruneRuntime(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodPruneRuntime.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).PruneRuntime(ctx, req.(*PruneRuntimeRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+func handlerGetPruneJob( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var id uint64
+	if err := dec(&id); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).GetPruneJob(ctx, id)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetPruneJob.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetPruneJob(ctx, req.(uint64))
+	}
+	return interceptor(ctx, id, info, handler)
+}
+
+func handlerGetPruneJobs( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(NodeController).GetPruneJobs(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetPruneJobs.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetPruneJobs(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerCheckConsensusInvariants( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(NodeController).CheckConsensusInvariants(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodCheckConsensusInvariants.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).CheckConsensusInvariants(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerGetAddressBook( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(NodeController).GetAddressBook(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetAddressBook.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetAddressBook(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerClearAddressBook( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return nil, srv.(NodeController).ClearAddressBook(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodClearAddressBook.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).ClearAddressBook(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerGetTxPoolTransactions( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).GetTxPoolTransactions(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTxPoolTransactions.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetTxPoolTransactions(ctx, req.(common.Namespace))
+	}
+	return interceptor(ctx, runtimeID, info, handler)
+}
+
+func handlerRemoveTxPoolTransaction( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req RemoveTxPoolTransactionRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).RemoveTxPoolTransaction(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodRemoveTxPoolTransaction.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).RemoveTxPoolTransaction(ctx, req.(*RemoveTxPoolTransactionRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+func handlerAddRuntime( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req AddRuntimeRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).AddRuntime(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodAddRuntime.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).AddRuntime(ctx, req.(*AddRuntimeRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+func handlerRemoveRuntime( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).RemoveRuntime(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodRemoveRuntime.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).RemoveRuntime(ctx, req.(common.Namespace))
+	}
+	return interceptor(ctx, runtimeID, info, handler)
+}
+
+func handlerGetExecutionReports( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req GetExecutionReportsRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).GetExecutionReports(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetExecutionReports.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetExecutionReports(ctx, req.(*GetExecutionReportsRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
 // RegisterService registers a new node controller service with the given gRPC server.
 func RegisterService(server *grpc.Server, service NodeController) {
 	server.RegisterService(&serviceDesc, service)
@@ -289,6 +625,86 @@ func (c *nodeControllerClient) GetStatus(ctx context.Context) (*Status, error) {
 	return &rsp, nil
 }
 
+func (c *nodeControllerClient) PruneConsensus(ctx context.Context, height uint64) (*PruneJob, error) {
+	var rsp PruneJob
+	if err := c.conn.Invoke(ctx, methodPruneConsensus.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) PruneRuntime(ctx context.Context, req *PruneRuntimeRequest) (*PruneJob, error) {
+	var rsp PruneJob
+	if err := c.conn.Invoke(ctx, methodPruneRuntime.FullName(), req, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) GetPruneJob(ctx context.Context, id uint64) (*PruneJob, error) {
+	var rsp PruneJob
+	if err := c.conn.Invoke(ctx, methodGetPruneJob.FullName(), id, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) GetPruneJobs(ctx context.Context) ([]*PruneJob, error) {
+	var rsp []*PruneJob
+	if err := c.conn.Invoke(ctx, methodGetPruneJobs.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *nodeControllerClient) CheckConsensusInvariants(ctx context.Context) (*consensus.InvariantsReport, error) {
+	var rsp consensus.InvariantsReport
+	if err := c.conn.Invoke(ctx, methodCheckConsensusInvariants.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) GetAddressBook(ctx context.Context) (*consensus.AddressBook, error) {
+	var rsp consensus.AddressBook
+	if err := c.conn.Invoke(ctx, methodGetAddressBook.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) ClearAddressBook(ctx context.Context) error {
+	return c.conn.Invoke(ctx, methodClearAddressBook.FullName(), nil, nil)
+}
+
+func (c *nodeControllerClient) GetTxPoolTransactions(ctx context.Context, runtimeID common.Namespace) ([]*txpool.TransactionInfo, error) {
+	var rsp []*txpool.TransactionInfo
+	if err := c.conn.Invoke(ctx, methodGetTxPoolTransactions.FullName(), runtimeID, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *nodeControllerClient) RemoveTxPoolTransaction(ctx context.Context, req *RemoveTxPoolTransactionRequest) error {
+	return c.conn.Invoke(ctx, methodRemoveTxPoolTransaction.FullName(), req, nil)
+}
+
+func (c *nodeControllerClient) AddRuntime(ctx context.Context, req *AddRuntimeRequest) error {
+	return c.conn.Invoke(ctx, methodAddRuntime.FullName(), req, nil)
+}
+
+func (c *nodeControllerClient) RemoveRuntime(ctx context.Context, runtimeID common.Namespace) error {
+	return c.conn.Invoke(ctx, methodRemoveRuntime.FullName(), runtimeID, nil)
+}
+
+func (c *nodeControllerClient) GetExecutionReports(ctx context.Context, req *GetExecutionReportsRequest) ([]*executorWorker.ExecutionReport, error) {
+	var rsp []*executorWorker.ExecutionReport
+	if err := c.conn.Invoke(ctx, methodGetExecutionReports.FullName(), req, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 // NewNodeControllerClient creates a new gRPC node controller client service.
 func NewNodeControllerClient(c *grpc.ClientConn) NodeController {
 	return &nodeControllerClient{c}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+)
+
+type testNodeController struct {
+	NodeController
+
+	runtimes map[common.Namespace]*AddRuntimeRequest
+}
+
+func (nc *testNodeController) AddRuntime(ctx context.Context, req *AddRuntimeRequest) error {
+	if req.Path == "" {
+		return ErrRuntimeManagementUnsupported
+	}
+	nc.runtimes[req.RuntimeID] = req
+	return nil
+}
+
+func (nc *testNodeController) RemoveRuntime(ctx context.Context, runtimeID common.Namespace) error {
+	if _, ok := nc.runtimes[runtimeID]; !ok {
+		return ErrRuntimeManagementUnsupported
+	}
+	delete(nc.runtimes, runtimeID)
+	return nil
+}
+
+func TestRuntimeManagementGRPC(t *testing.T) {
+	require := require.New(t)
+
+	// Generate temporary filename for the socket.
+	f, err := ioutil.TempFile("", "oasis-control-grpc-test-socket")
+	require.NoError(err, "TempFile")
+	// Remove the file as we only need the name.
+	f.Close()
+	os.Remove(f.Name())
+
+	grpcServer, err := cmnGrpc.NewServer(&cmnGrpc.ServerConfig{
+		Path: f.Name(),
+	})
+	require.NoError(err, "NewServer")
+	defer os.Remove(f.Name())
+
+	nc := &testNodeController{
+		runtimes: make(map[common.Namespace]*AddRuntimeRequest),
+	}
+	RegisterService(grpcServer.Server(), nc)
+
+	err = grpcServer.Start()
+	require.NoError(err, "Start")
+	defer grpcServer.Stop()
+
+	conn, err := cmnGrpc.Dial("unix:"+f.Name(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(err, "Dial")
+	defer conn.Close()
+	client := NewNodeControllerClient(conn)
+
+	ctx := context.Background()
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("control api test runtime"), 0)
+
+	req := &AddRuntimeRequest{
+		RuntimeID:    runtimeID,
+		Path:         "/path/to/runtime",
+		SGXSignature: "/path/to/runtime.sig",
+	}
+	err = client.AddRuntime(ctx, req)
+	require.NoError(err, "AddRuntime")
+	require.Equal(req, nc.runtimes[runtimeID], "AddRuntime request should round-trip")
+
+	// Errors should be propagated to the client.
+	err = client.AddRuntime(ctx, &AddRuntimeRequest{RuntimeID: runtimeID})
+	require.ErrorIs(err, ErrRuntimeManagementUnsupported, "AddRuntime error")
+
+	err = client.RemoveRuntime(ctx, runtimeID)
+	require.NoError(err, "RemoveRuntime")
+	require.Empty(nc.runtimes, "RemoveRuntime should remove the runtime")
+
+	err = client.RemoveRuntime(ctx, runtimeID)
+	require.ErrorIs(err, ErrRuntimeManagementUnsupported, "RemoveRuntime error")
+}
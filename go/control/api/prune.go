@@ -0,0 +1,90 @@
+package api
+
+import (
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+)
+
+// ModuleName is the module name for the node controller service.
+const ModuleName = "control"
+
+var (
+	// ErrPruneJobNotFound is the error returned when a prune job does not exist.
+	ErrPruneJobNotFound = errors.New(ModuleName, 1, "control: prune job not found")
+
+	// ErrPruneInProgress is the error returned when a prune job for the same
+	// target is already in progress.
+	ErrPruneInProgress = errors.New(ModuleName, 2, "control: prune already in progress")
+
+	// ErrPruneUnsupported is the error returned when pruning of the given
+	// target is not supported by the node.
+	ErrPruneUnsupported = errors.New(ModuleName, 3, "control: pruning not supported")
+)
+
+// PruneKind is the kind of a prune job.
+type PruneKind string
+
+const (
+	// PruneKindConsensus is the kind of prune jobs that prune consensus
+	// state and blocks.
+	PruneKindConsensus PruneKind = "consensus"
+	// PruneKindRuntime is the kind of prune jobs that prune runtime history
+	// and storage.
+	PruneKindRuntime PruneKind = "runtime"
+)
+
+// PruneJobState is the state of a prune job.
+type PruneJobState string
+
+const (
+	// PruneJobRunning is the state of a prune job that is in progress.
+	PruneJobRunning PruneJobState = "running"
+	// PruneJobCompleted is the state of a prune job that completed
+	// successfully.
+	PruneJobCompleted PruneJobState = "completed"
+	// PruneJobFailed is the state of a prune job that failed.
+	PruneJobFailed PruneJobState = "failed"
+)
+
+// PruneRuntimeRequest is a PruneRuntime request.
+type PruneRuntimeRequest struct {
+	// RuntimeID is the identifier of the runtime to prune.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Round is the round below which all runtime history and storage should
+	// be pruned.
+	Round uint64 `json:"round"`
+}
+
+// PruneJob is the status of an on-demand prune job.
+type PruneJob struct {
+	// ID is the prune job identifier.
+	ID uint64 `json:"id"`
+	// Kind is the kind of the prune job.
+	Kind PruneKind `json:"kind"`
+	// RuntimeID is the identifier of the pruned runtime (only for runtime
+	// prune jobs).
+	RuntimeID *common.Namespace `json:"runtime_id,omitempty"`
+
+	// Target is the height (or round) below which everything is pruned.
+	Target uint64 `json:"target"`
+	// LastPruned is the last pruned height (or round).
+	LastPruned uint64 `json:"last_pruned,omitempty"`
+
+	// State is the state of the prune job.
+	State PruneJobState `json:"state"`
+	// Error is the error that caused the prune job to fail.
+	Error string `json:"error,omitempty"`
+
+	// Started is the time the prune job was started.
+	Started time.Time `json:"started"`
+	// Finished is the time the prune job finished (either successfully or
+	// not).
+	Finished time.Time `json:"finished,omitempty"`
+}
+
+// IsDone returns true iff the prune job is no longer running.
+func (j *PruneJob) IsDone() bool {
+	return j.State != PruneJobRunning
+}
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+)
+
+// ErrRuntimeManagementUnsupported is the error returned when the node does not support adding
+// or removing runtimes while it is running.
+var ErrRuntimeManagementUnsupported = errors.New(ModuleName, 6, "control: runtime management not supported")
+
+// AddRuntimeRequest is an AddRuntime request.
+type AddRuntimeRequest struct {
+	// RuntimeID is the identifier of the runtime.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Path is the path to the runtime binary or runtime bundle on the node.
+	Path string `json:"path"`
+	// SGXSignature is the optional path to the runtime's SGX enclave signature on the node.
+	SGXSignature string `json:"sgx_signature,omitempty"`
+}
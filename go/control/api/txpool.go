@@ -0,0 +1,24 @@
+package api
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+)
+
+var (
+	// ErrTxPoolUnavailable is the error returned when the node does not maintain a transaction
+	// pool for the given runtime.
+	ErrTxPoolUnavailable = errors.New(ModuleName, 4, "control: transaction pool not available")
+
+	// ErrTxNotFound is the error returned when a transaction is not in the transaction pool.
+	ErrTxNotFound = errors.New(ModuleName, 5, "control: transaction not found")
+)
+
+// RemoveTxPoolTransactionRequest is a RemoveTxPoolTransaction request.
+type RemoveTxPoolTransactionRequest struct {
+	// RuntimeID is the identifier of the runtime.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Hash is the hash of the transaction to remove.
+	Hash hash.Hash `json:"hash"`
+}
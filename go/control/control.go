@@ -5,16 +5,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	executorWorker "github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
 )
 
 type nodeController struct {
 	node      control.ControlledNode
 	consensus consensus.Backend
 	upgrader  upgrade.Backend
+
+	pruneJobs *pruneJobs
 }
 
 func (c *nodeController) RequestShutdown(ctx context.Context, wait bool) error {
@@ -102,6 +107,16 @@ func (c *nodeController) GetStatus(ctx context.Context) (*control.Status, error)
 		return nil, fmt.Errorf("failed to get pending upgrades: %w", err)
 	}
 
+	disk, err := c.node.GetDiskStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disk status: %w", err)
+	}
+
+	p2p, err := c.node.GetP2PStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get P2P status: %w", err)
+	}
+
 	ident := c.node.GetIdentity()
 
 	return &control.Status{
@@ -116,14 +131,100 @@ func (c *nodeController) GetStatus(ctx context.Context) (*control.Status, error)
 		Runtimes:        runtimes,
 		Registration:    *rs,
 		PendingUpgrades: pendingUpgrades,
+		Disk:            disk,
+		P2P:             p2p,
 	}, nil
 }
 
+func (c *nodeController) PruneConsensus(ctx context.Context, height uint64) (*control.PruneJob, error) {
+	return c.pruneJobs.start(
+		control.PruneKindConsensus,
+		string(control.PruneKindConsensus),
+		control.PruneJob{Target: height},
+		func(ctx context.Context, progressFn func(uint64)) error {
+			return c.node.PruneConsensus(ctx, height, progressFn)
+		},
+	)
+}
+
+func (c *nodeController) PruneRuntime(ctx context.Context, req *control.PruneRuntimeRequest) (*control.PruneJob, error) {
+	runtimeID := req.RuntimeID
+	round := req.Round
+	return c.pruneJobs.start(
+		control.PruneKindRuntime,
+		fmt.Sprintf("%s/%s", control.PruneKindRuntime, runtimeID),
+		control.PruneJob{RuntimeID: &runtimeID, Target: round},
+		func(ctx context.Context, progressFn func(uint64)) error {
+			return c.node.PruneRuntime(ctx, runtimeID, round, progressFn)
+		},
+	)
+}
+
+func (c *nodeController) GetPruneJob(ctx context.Context, id uint64) (*control.PruneJob, error) {
+	return c.pruneJobs.get(id)
+}
+
+func (c *nodeController) GetPruneJobs(ctx context.Context) ([]*control.PruneJob, error) {
+	return c.pruneJobs.list(), nil
+}
+
+func (c *nodeController) CheckConsensusInvariants(ctx context.Context) (*consensus.InvariantsReport, error) {
+	return c.node.CheckConsensusInvariants(ctx)
+}
+
+func (c *nodeController) GetAddressBook(ctx context.Context) (*consensus.AddressBook, error) {
+	abp, ok := c.consensus.(consensus.AddressBookProvider)
+	if !ok {
+		return nil, fmt.Errorf("%w: consensus backend does not provide an address book", consensus.ErrUnsupported)
+	}
+	return abp.GetAddressBook(ctx)
+}
+
+func (c *nodeController) ClearAddressBook(ctx context.Context) error {
+	abp, ok := c.consensus.(consensus.AddressBookProvider)
+	if !ok {
+		return fmt.Errorf("%w: consensus backend does not provide an address book", consensus.ErrUnsupported)
+	}
+	return abp.ClearAddressBook(ctx)
+}
+
 // New creates a new oasis-node controller.
 func New(node control.ControlledNode, consensus consensus.Backend, upgrader upgrade.Backend) control.NodeController {
 	return &nodeController{
 		node:      node,
 		consensus: consensus,
 		upgrader:  upgrader,
+		pruneJobs: newPruneJobs(),
 	}
 }
+
+func (c *nodeController) GetTxPoolTransactions(ctx context.Context, runtimeID common.Namespace) ([]*txpool.TransactionInfo, error) {
+	txPool, err := c.node.GetTxPool(ctx, runtimeID)
+	if err != nil {
+		return nil, err
+	}
+	return txPool.GetTransactions(), nil
+}
+
+func (c *nodeController) RemoveTxPoolTransaction(ctx context.Context, req *control.RemoveTxPoolTransactionRequest) error {
+	txPool, err := c.node.GetTxPool(ctx, req.RuntimeID)
+	if err != nil {
+		return err
+	}
+	if !txPool.RemoveTx(req.Hash) {
+		return control.ErrTxNotFound
+	}
+	return nil
+}
+
+func (c *nodeController) AddRuntime(ctx context.Context, req *control.AddRuntimeRequest) error {
+	return c.node.AddRuntime(ctx, req)
+}
+
+func (c *nodeController) RemoveRuntime(ctx context.Context, runtimeID common.Namespace) error {
+	return c.node.RemoveRuntime(ctx, runtimeID)
+}
+
+func (c *nodeController) GetExecutionReports(ctx context.Context, req *control.GetExecutionReportsRequest) ([]*executorWorker.ExecutionReport, error) {
+	return c.node.GetExecutionReports(ctx, req.RuntimeID, req.Limit)
+}
@@ -0,0 +1,122 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
+)
+
+// pruneJobs tracks on-demand prune jobs.
+type pruneJobs struct {
+	sync.Mutex
+
+	logger *logging.Logger
+
+	lastID uint64
+	jobs   map[uint64]*control.PruneJob
+	// active maps prune targets to active prune job identifiers.
+	active map[string]uint64
+}
+
+func (pj *pruneJobs) start(
+	kind control.PruneKind,
+	target string,
+	job control.PruneJob,
+	fn func(ctx context.Context, progressFn func(uint64)) error,
+) (*control.PruneJob, error) {
+	pj.Lock()
+	defer pj.Unlock()
+
+	if id, ok := pj.active[target]; ok {
+		return nil, fmt.Errorf("%w: job %d", control.ErrPruneInProgress, id)
+	}
+
+	pj.lastID++
+	job.ID = pj.lastID
+	job.Kind = kind
+	job.State = control.PruneJobRunning
+	job.Started = time.Now()
+	pj.jobs[job.ID] = &job
+	pj.active[target] = job.ID
+
+	pj.logger.Info("starting prune job",
+		"id", job.ID,
+		"kind", kind,
+		"target", target,
+		"until", job.Target,
+	)
+
+	progressFn := func(pruned uint64) {
+		pj.Lock()
+		defer pj.Unlock()
+		pj.jobs[job.ID].LastPruned = pruned
+	}
+
+	go func(id uint64) {
+		// Prune jobs are not tied to the request context as they outlive the request.
+		err := fn(context.Background(), progressFn)
+
+		pj.Lock()
+		defer pj.Unlock()
+
+		j := pj.jobs[id]
+		j.Finished = time.Now()
+		switch err {
+		case nil:
+			j.State = control.PruneJobCompleted
+			pj.logger.Info("prune job completed",
+				"id", id,
+				"last_pruned", j.LastPruned,
+			)
+		default:
+			j.State = control.PruneJobFailed
+			j.Error = err.Error()
+			pj.logger.Error("prune job failed",
+				"err", err,
+				"id", id,
+			)
+		}
+		delete(pj.active, target)
+	}(job.ID)
+
+	jobCopy := job
+	return &jobCopy, nil
+}
+
+func (pj *pruneJobs) get(id uint64) (*control.PruneJob, error) {
+	pj.Lock()
+	defer pj.Unlock()
+
+	job, ok := pj.jobs[id]
+	if !ok {
+		return nil, control.ErrPruneJobNotFound
+	}
+	jobCopy := *job
+	return &jobCopy, nil
+}
+
+func (pj *pruneJobs) list() []*control.PruneJob {
+	pj.Lock()
+	defer pj.Unlock()
+
+	jobs := make([]*control.PruneJob, 0, len(pj.jobs))
+	for _, job := range pj.jobs {
+		jobCopy := *job
+		jobs = append(jobs, &jobCopy)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	return jobs
+}
+
+func newPruneJobs() *pruneJobs {
+	return &pruneJobs{
+		logger: logging.GetLogger("control/prune"),
+		jobs:   make(map[uint64]*control.PruneJob),
+		active: make(map[string]uint64),
+	}
+}
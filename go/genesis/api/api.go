@@ -51,6 +51,41 @@ type Document struct {
 	// Extra data is arbitrary extra data that is part of the
 	// genesis block but is otherwise ignored by the protocol.
 	ExtraData map[string][]byte `json:"extra_data"`
+	// Metadata is optional metadata about how the document was produced,
+	// which is otherwise ignored by the protocol.
+	Metadata *Metadata `json:"metadata,omitempty"`
+}
+
+// Metadata is genesis document metadata.
+type Metadata struct {
+	// Patches is the log of patches that were applied to the document, in
+	// the order in which they were applied.
+	Patches []*PatchRecord `json:"patches,omitempty"`
+}
+
+// PatchOperation is a single JSON patch (RFC 6902) operation.
+type PatchOperation struct {
+	// Op is the operation name (add, remove, replace, move, copy or test).
+	Op string `json:"op"`
+	// Path is the JSON pointer (RFC 6901) to the target location.
+	Path string `json:"path"`
+	// From is the JSON pointer to the source location for move and copy
+	// operations.
+	From string `json:"from,omitempty"`
+	// Value is the JSON-encoded value for add, replace and test operations.
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// PatchRecord is a record of a patch applied to a genesis document.
+type PatchRecord struct {
+	// Time is the time at which the patch was applied.
+	Time time.Time `json:"time"`
+	// Description is a human readable description of the patch.
+	Description string `json:"description"`
+	// PreviousHash is the hash of the document before the patch was applied.
+	PreviousHash hash.Hash `json:"previous_hash"`
+	// Operations are the applied patch operations.
+	Operations []PatchOperation `json:"operations"`
 }
 
 // Hash returns the cryptographic hash of the encoded genesis document.
@@ -0,0 +1,284 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	// CheckModuleGenesis is the module name used for document-level violations.
+	CheckModuleGenesis = "genesis"
+
+	checkModuleConsensus  = "consensus"
+	checkModuleBeacon     = "beacon"
+	checkModuleRegistry   = "registry"
+	checkModuleRootHash   = "roothash"
+	checkModuleStaking    = "staking"
+	checkModuleKeyManager = "keymanager"
+	checkModuleScheduler  = "scheduler"
+	checkModuleGovernance = "governance"
+)
+
+// Violation is a single violation found while checking a genesis document.
+type Violation struct {
+	// Module is the name of the module the violation was found in.
+	Module string `json:"module"`
+	// Check is the name of the check that failed.
+	Check string `json:"check"`
+	// Error is the description of the violation.
+	Error string `json:"error"`
+}
+
+// String returns a string representation of the violation.
+func (v Violation) String() string {
+	return fmt.Sprintf("%s/%s: %s", v.Module, v.Check, v.Error)
+}
+
+// CheckReport is the report of checking a genesis document.
+type CheckReport struct {
+	// ChainContext is the chain context of the checked document.
+	ChainContext string `json:"chain_context"`
+	// Violations is a list of all violations found.
+	Violations []Violation `json:"violations"`
+}
+
+// IsValid returns true iff no violations were found.
+func (r *CheckReport) IsValid() bool {
+	return len(r.Violations) == 0
+}
+
+// Add adds a new violation to the report.
+func (r *CheckReport) Add(module, check string, err error) {
+	r.Violations = append(r.Violations, Violation{
+		Module: module,
+		Check:  check,
+		Error:  err.Error(),
+	})
+}
+
+func (r *CheckReport) addIfErr(module, check string, err error) {
+	if err != nil {
+		r.Add(module, check, err)
+	}
+}
+
+// Check performs a full offline check of the genesis document.
+//
+// Unlike SanityCheck, which stops at the first error, all of the checks are
+// performed and all of the violations are collected into the report. In
+// addition to the per-module sanity checks, this also performs cross-module
+// invariant checks (e.g., referential integrity between the registry, the
+// roothash and the key manager).
+func (d *Document) Check() *CheckReport {
+	r := &CheckReport{
+		ChainContext: d.ChainContext(),
+		Violations:   []Violation{},
+	}
+
+	// Document-level checks.
+	if d.Height < 1 {
+		r.Add(CheckModuleGenesis, "height", fmt.Errorf("height must be >= 1"))
+	}
+	if strings.TrimSpace(d.ChainID) == "" {
+		r.Add(CheckModuleGenesis, "chain_id", fmt.Errorf("chain ID must not be empty"))
+	}
+	epoch := d.Beacon.Base
+	if d.HaltEpoch < epoch {
+		r.Add(CheckModuleGenesis, "halt_epoch", fmt.Errorf("halt epoch is in the past"))
+	}
+
+	// Per-module sanity checks.
+	pkBlacklist := make(map[signature.PublicKey]bool)
+	for _, v := range d.Consensus.Parameters.PublicKeyBlacklist {
+		pkBlacklist[v] = true
+	}
+	r.addIfErr(checkModuleConsensus, "sanity", d.Consensus.SanityCheck())
+	r.addIfErr(checkModuleBeacon, "sanity", d.Beacon.SanityCheck())
+	r.addIfErr(checkModuleRegistry, "sanity", d.Registry.SanityCheck(d.Time, epoch, d.Staking.Ledger, d.Staking.Parameters.Thresholds, pkBlacklist))
+	r.addIfErr(checkModuleRootHash, "sanity", d.RootHash.SanityCheck())
+	r.addIfErr(checkModuleStaking, "sanity", d.Staking.SanityCheck(epoch))
+	r.addIfErr(checkModuleKeyManager, "sanity", d.KeyManager.SanityCheck())
+	r.addIfErr(checkModuleScheduler, "sanity", d.Scheduler.SanityCheck(&d.Staking.TotalSupply))
+	r.addIfErr(checkModuleGovernance, "sanity", d.Governance.SanityCheck(epoch, &d.Staking.GovernanceDeposits))
+
+	// Cross-module invariant checks.
+	d.checkStakingSupply(r)
+	d.checkStakingDelegations(r)
+	d.checkReferentialIntegrity(r)
+
+	return r
+}
+
+func (d *Document) checkStakingSupply(r *CheckReport) {
+	var total quantity.Quantity
+	for addr, acct := range d.Staking.Ledger {
+		for _, q := range []*quantity.Quantity{
+			&acct.General.Balance,
+			&acct.Escrow.Active.Balance,
+			&acct.Escrow.Debonding.Balance,
+		} {
+			if err := total.Add(q); err != nil {
+				r.Add(checkModuleStaking, "total_supply", fmt.Errorf("account %s: %w", addr, err))
+				return
+			}
+		}
+	}
+	for _, q := range []*quantity.Quantity{
+		&d.Staking.GovernanceDeposits,
+		&d.Staking.CommonPool,
+		&d.Staking.LastBlockFees,
+	} {
+		if err := total.Add(q); err != nil {
+			r.Add(checkModuleStaking, "total_supply", err)
+			return
+		}
+	}
+
+	if total.Cmp(&d.Staking.TotalSupply) != 0 {
+		r.Add(checkModuleStaking, "total_supply", fmt.Errorf(
+			"sum of account balances, governance deposits, common pool and last block fees (%s) does not match total supply (%s)",
+			total, d.Staking.TotalSupply,
+		))
+	}
+}
+
+func (d *Document) checkStakingDelegations(r *CheckReport) {
+	for addr, delegations := range d.Staking.Delegations {
+		acct := d.Staking.Ledger[addr]
+		if acct == nil {
+			r.Add(checkModuleStaking, "delegations", fmt.Errorf("delegation specified for a nonexisting account: %s", addr))
+			continue
+		}
+		r.addIfErr(checkModuleStaking, "delegations", staking.SanityCheckDelegations(addr, acct, delegations))
+	}
+	for addr, delegations := range d.Staking.DebondingDelegations {
+		acct := d.Staking.Ledger[addr]
+		if acct == nil {
+			r.Add(checkModuleStaking, "debonding_delegations", fmt.Errorf("debonding delegation specified for a nonexisting account: %s", addr))
+			continue
+		}
+		r.addIfErr(checkModuleStaking, "debonding_delegations", staking.SanityCheckDebondingDelegations(addr, acct, delegations))
+	}
+	for addr, acct := range d.Staking.Ledger {
+		r.addIfErr(checkModuleStaking, "escrow_shares", staking.SanityCheckAccountShares(
+			addr, acct, d.Staking.Delegations[addr], d.Staking.DebondingDelegations[addr],
+		))
+	}
+}
+
+func (d *Document) checkReferentialIntegrity(r *CheckReport) { // nolint: gocyclo
+	// Entities.
+	entities := make(map[signature.PublicKey]*entity.Entity)
+	for _, signedEnt := range d.Registry.Entities {
+		var ent entity.Entity
+		if err := signedEnt.Open(registry.RegisterGenesisEntitySignatureContext, &ent); err != nil {
+			r.Add(checkModuleRegistry, "entities", fmt.Errorf("unable to open signed entity: %w", err))
+			continue
+		}
+		entities[ent.ID] = &ent
+	}
+
+	// Runtimes.
+	runtimes := make(map[common.Namespace]*registry.Runtime)
+	for _, rts := range [][]*registry.Runtime{d.Registry.Runtimes, d.Registry.SuspendedRuntimes} {
+		for _, rt := range rts {
+			if _, ok := runtimes[rt.ID]; ok {
+				r.Add(checkModuleRegistry, "runtimes", fmt.Errorf("duplicate runtime: %s", rt.ID))
+				continue
+			}
+			runtimes[rt.ID] = rt
+		}
+	}
+	for _, rt := range runtimes {
+		if rt.GovernanceModel == registry.GovernanceEntity {
+			if _, ok := entities[rt.EntityID]; !ok {
+				r.Add(checkModuleRegistry, "runtimes", fmt.Errorf("runtime %s references a missing entity: %s", rt.ID, rt.EntityID))
+			}
+		}
+		if rt.KeyManager != nil {
+			km, ok := runtimes[*rt.KeyManager]
+			switch {
+			case !ok:
+				r.Add(checkModuleRegistry, "runtimes", fmt.Errorf("runtime %s references a missing key manager: %s", rt.ID, rt.KeyManager))
+			case km.Kind != registry.KindKeyManager:
+				r.Add(checkModuleRegistry, "runtimes", fmt.Errorf("runtime %s references a non key manager runtime as its key manager: %s", rt.ID, rt.KeyManager))
+			}
+		}
+	}
+
+	// Nodes.
+	nodes := make(map[signature.PublicKey]*node.Node)
+	var numValidators int
+	for _, signedNode := range d.Registry.Nodes {
+		var n node.Node
+		if err := signedNode.Open(registry.RegisterGenesisNodeSignatureContext, &n); err != nil {
+			r.Add(checkModuleRegistry, "nodes", fmt.Errorf("unable to open signed node: %w", err))
+			continue
+		}
+		if _, ok := nodes[n.ID]; ok {
+			r.Add(checkModuleRegistry, "nodes", fmt.Errorf("duplicate node: %s", n.ID))
+			continue
+		}
+		nodes[n.ID] = &n
+
+		if _, ok := entities[n.EntityID]; !ok {
+			r.Add(checkModuleRegistry, "nodes", fmt.Errorf("node %s references a missing entity: %s", n.ID, n.EntityID))
+		}
+		for _, rt := range n.Runtimes {
+			if _, ok := runtimes[rt.ID]; !ok {
+				r.Add(checkModuleRegistry, "nodes", fmt.Errorf("node %s references a missing runtime: %s", n.ID, rt.ID))
+			}
+		}
+		if n.HasRoles(node.RoleValidator) && !n.IsExpired(uint64(d.Beacon.Base)) {
+			numValidators++
+		}
+	}
+	for id := range d.Registry.NodeStatuses {
+		if _, ok := nodes[id]; !ok {
+			r.Add(checkModuleRegistry, "node_statuses", fmt.Errorf("node status specified for a missing node: %s", id))
+		}
+	}
+
+	// Roothash runtime states.
+	for id := range d.RootHash.RuntimeStates {
+		rt, ok := runtimes[id]
+		switch {
+		case !ok:
+			r.Add(checkModuleRootHash, "runtime_states", fmt.Errorf("runtime state specified for a missing runtime: %s", id))
+		case rt.Kind != registry.KindCompute:
+			r.Add(checkModuleRootHash, "runtime_states", fmt.Errorf("runtime state specified for a non-compute runtime: %s", id))
+		}
+	}
+
+	// Key manager statuses.
+	for _, st := range d.KeyManager.Statuses {
+		rt, ok := runtimes[st.ID]
+		switch {
+		case !ok:
+			r.Add(checkModuleKeyManager, "statuses", fmt.Errorf("status specified for a missing runtime: %s", st.ID))
+		case rt.Kind != registry.KindKeyManager:
+			r.Add(checkModuleKeyManager, "statuses", fmt.Errorf("status specified for a non key manager runtime: %s", st.ID))
+		}
+		for _, id := range st.Nodes {
+			if _, ok := nodes[id]; !ok {
+				r.Add(checkModuleKeyManager, "statuses", fmt.Errorf("status for %s references a missing node: %s", st.ID, id))
+			}
+		}
+	}
+
+	// Scheduler parameters.
+	if numValidators < d.Scheduler.Parameters.MinValidators {
+		r.Add(checkModuleScheduler, "validators", fmt.Errorf(
+			"number of registered validator nodes (%d) is less than the minimum number of validators (%d)",
+			numValidators, d.Scheduler.Parameters.MinValidators,
+		))
+	}
+}
@@ -34,6 +34,8 @@ import (
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 	stakingTests "github.com/oasisprotocol/oasis-core/go/staking/tests"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -367,6 +369,45 @@ func TestGenesisSanityCheck(t *testing.T) {
 	require.NoError(rtsSanityCheck(d.RootHash, false), "non-empty StateRoot should pass")
 	require.NoError(rtsSanityCheck(d.RootHash, true), "non-empty StateRoot should pass")
 
+	validCheckpoint := &checkpoint.Metadata{
+		Version: 1,
+		Root: mkvsNode.Root{
+			Namespace: validNS,
+			Version:   0,
+			Type:      mkvsNode.RootTypeState,
+			Hash:      nonEmptyHash,
+		},
+		Chunks: []hash.Hash{nonEmptyHash},
+	}
+	d.RootHash.RuntimeStates[validNS].StateCheckpoint = validCheckpoint
+	require.NoError(rtsSanityCheck(d.RootHash, true), "valid state checkpoint should pass")
+
+	invalidCheckpoint := *validCheckpoint
+	invalidCheckpoint.Root.Version = 1
+	d.RootHash.RuntimeStates[validNS].StateCheckpoint = &invalidCheckpoint
+	require.Error(rtsSanityCheck(d.RootHash, true), "state checkpoint with invalid version should be rejected")
+
+	invalidCheckpoint = *validCheckpoint
+	invalidCheckpoint.Root.Hash = emptyHash
+	d.RootHash.RuntimeStates[validNS].StateCheckpoint = &invalidCheckpoint
+	require.Error(rtsSanityCheck(d.RootHash, true), "state checkpoint with invalid root should be rejected")
+
+	invalidCheckpoint = *validCheckpoint
+	invalidCheckpoint.Root.Type = mkvsNode.RootTypeIO
+	d.RootHash.RuntimeStates[validNS].StateCheckpoint = &invalidCheckpoint
+	require.Error(rtsSanityCheck(d.RootHash, true), "state checkpoint with invalid root type should be rejected")
+
+	invalidCheckpoint = *validCheckpoint
+	invalidCheckpoint.Chunks = nil
+	d.RootHash.RuntimeStates[validNS].StateCheckpoint = &invalidCheckpoint
+	require.Error(rtsSanityCheck(d.RootHash, true), "state checkpoint without chunks should be rejected")
+
+	invalidCheckpoint = *validCheckpoint
+	invalidCheckpoint.Root.Namespace = testRuntimeID
+	d.RootHash.RuntimeStates[validNS].StateCheckpoint = &invalidCheckpoint
+	require.NoError(rtsSanityCheck(d.RootHash, true), "state checkpoint namespace is not checked per-runtime")
+	require.Error(d.RootHash.SanityCheck(), "state checkpoint with invalid namespace should be rejected")
+
 	// Test registry genesis checks.
 	d = testDoc()
 	d.Registry.Entities = []*entity.SignedEntity{signedTestEntity}
@@ -905,3 +946,43 @@ func TestGenesisSanityCheck(t *testing.T) {
 	})
 	require.Error(d.SanityCheck(), "pending upgrades not UpgradeMinEpochDiff apart")
 }
+
+func TestGenesisCheck(t *testing.T) {
+	viper.Set(cmdFlags.CfgDebugDontBlameOasis, true)
+	require := require.New(t)
+
+	hasViolation := func(report *genesis.CheckReport, module, check string) bool {
+		for _, v := range report.Violations {
+			if v.Module == module && v.Check == check {
+				return true
+			}
+		}
+		return false
+	}
+
+	// The test document has no validator nodes.
+	d := testDoc()
+	report := d.Check()
+	require.Equal(d.ChainContext(), report.ChainContext, "report should include the chain context")
+	require.Len(report.Violations, 1, "test document should only be missing validators")
+	require.True(hasViolation(report, "scheduler", "validators"), "missing validators should be reported")
+
+	d = testDoc()
+	d.Scheduler.Parameters.MinValidators = 0
+	require.True(d.Check().IsValid(), "test document without minimum validators should be valid")
+
+	// Multiple violations across different modules should all be reported.
+	d = testDoc()
+	d.Scheduler.Parameters.MinValidators = 0
+	d.ChainID = ""
+	_ = d.Staking.TotalSupply.Add(quantity.NewFromUint64(1))
+	d.RootHash.RuntimeStates = map[common.Namespace]*roothash.GenesisRuntimeState{
+		hex2ns("0000000000000000000000000000000000000000000000000000000000000001", false): {},
+	}
+	report = d.Check()
+	require.False(report.IsValid(), "invalid document should not be valid")
+	require.True(hasViolation(report, "genesis", "chain_id"), "empty chain ID should be reported")
+	require.True(hasViolation(report, "staking", "sanity"), "staking sanity check failure should be reported")
+	require.True(hasViolation(report, "staking", "total_supply"), "total supply mismatch should be reported")
+	require.True(hasViolation(report, "roothash", "runtime_states"), "dangling runtime state should be reported")
+}
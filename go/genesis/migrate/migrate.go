@@ -0,0 +1,147 @@
+// Package migrate implements migrations of genesis documents between
+// consensus protocol versions.
+//
+// Each migration upgrades a raw (JSON-encoded) genesis document from one
+// major consensus protocol version to the next one. Migrations are chained
+// so that a document of any supported version can be upgraded to the
+// current format.
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+)
+
+var (
+	logger = logging.GetLogger("genesis/migrate")
+
+	migrationsLock sync.RWMutex
+	migrations     = make(map[uint16]*Migration)
+)
+
+// MigrationFunc is a function that migrates a raw genesis document to the
+// next version.
+type MigrationFunc func(raw []byte) ([]byte, error)
+
+// Migration is a genesis document migration.
+type Migration struct {
+	// FromVersion is the major consensus protocol version of the documents
+	// that the migration accepts. The migrated document is of the next
+	// major version.
+	FromVersion uint16
+
+	// Description is a short human readable description of the migration.
+	Description string
+
+	// Fn is the migration function.
+	Fn MigrationFunc
+}
+
+// CurrentVersion returns the genesis document version produced by the
+// migrations, which is the current major consensus protocol version.
+func CurrentVersion() uint16 {
+	return version.ConsensusProtocol.Major
+}
+
+// Register registers a new genesis document migration.
+//
+// This method will panic in case a migration from the same version is
+// already registered.
+func Register(m *Migration) {
+	migrationsLock.Lock()
+	defer migrationsLock.Unlock()
+
+	if m.Fn == nil {
+		panic(fmt.Sprintf("genesis/migrate: migration from version %d has no migration function", m.FromVersion))
+	}
+	if _, ok := migrations[m.FromVersion]; ok {
+		panic(fmt.Sprintf("genesis/migrate: migration from version %d already registered", m.FromVersion))
+	}
+	migrations[m.FromVersion] = m
+}
+
+// SupportedVersions returns the list of document versions that can be
+// migrated to the current version, in ascending order.
+func SupportedVersions() []uint16 {
+	migrationsLock.RLock()
+	defer migrationsLock.RUnlock()
+
+	var versions []uint16
+	current := CurrentVersion()
+	for v := range migrations {
+		if v >= current {
+			continue
+		}
+		if _, err := migrationPathLocked(v, current); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+func migrationPathLocked(from, to uint16) ([]*Migration, error) {
+	var path []*Migration
+	for v := from; v < to; v++ {
+		m, ok := migrations[v]
+		if !ok {
+			return nil, fmt.Errorf("genesis/migrate: no migration from version %d", v)
+		}
+		path = append(path, m)
+	}
+	return path, nil
+}
+
+// MigrateRaw migrates the given raw genesis document of the given version
+// to the current version and returns the migrated raw document.
+func MigrateRaw(raw []byte, fromVersion uint16) ([]byte, error) {
+	current := CurrentVersion()
+	if fromVersion > current {
+		return nil, fmt.Errorf("genesis/migrate: document version %d is newer than the current version %d", fromVersion, current)
+	}
+
+	migrationsLock.RLock()
+	path, err := migrationPathLocked(fromVersion, current)
+	migrationsLock.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range path {
+		logger.Info("migrating genesis document",
+			"from_version", m.FromVersion,
+			"to_version", m.FromVersion+1,
+			"description", m.Description,
+		)
+
+		if raw, err = m.Fn(raw); err != nil {
+			return nil, fmt.Errorf("genesis/migrate: migration from version %d failed: %w", m.FromVersion, err)
+		}
+	}
+	return raw, nil
+}
+
+// Migrate migrates the given raw genesis document of the given version to
+// the current version.
+//
+// Note that the returned document is not sanity checked and that its hash
+// (and thus the chain context) will generally differ from the hash of the
+// original document.
+func Migrate(raw []byte, fromVersion uint16) (*genesis.Document, error) {
+	raw, err := MigrateRaw(raw, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc genesis.Document
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("genesis/migrate: failed to parse migrated genesis document: %w", err)
+	}
+	return &doc, nil
+}
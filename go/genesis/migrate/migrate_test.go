@@ -0,0 +1,32 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	require := require.New(t)
+
+	current := CurrentVersion()
+	require.Contains(SupportedVersions(), current-1, "migration from the previous version should be supported")
+
+	raw := []byte(`{"height":1}`)
+	migrated, err := MigrateRaw(raw, current)
+	require.NoError(err, "MigrateRaw from the current version")
+	require.Equal(raw, migrated, "migrating from the current version should be a no-op")
+
+	_, err = MigrateRaw(raw, current+1)
+	require.Error(err, "MigrateRaw from a future version should fail")
+
+	_, err = MigrateRaw(raw, 0)
+	require.Error(err, "MigrateRaw from an unsupported version should fail")
+
+	_, err = Migrate([]byte("garbage"), current-1)
+	require.Error(err, "Migrate of a malformed document should fail")
+
+	require.Panics(func() {
+		Register(&Migration{FromVersion: current - 1, Fn: migrateV4})
+	}, "registering a duplicate migration should panic")
+}
@@ -0,0 +1,410 @@
+// Package patch implements declarative patching of genesis documents.
+//
+// Patches are expressed as JSON patch (RFC 6902) operations that are applied
+// to the JSON form of a genesis document. Every applied patch is recorded in
+// the document's metadata so that any manual changes made to a document
+// (e.g., during emergency network recovery procedures) remain auditable.
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+)
+
+const (
+	opAdd     = "add"
+	opRemove  = "remove"
+	opReplace = "replace"
+	opMove    = "move"
+	opCopy    = "copy"
+	opTest    = "test"
+
+	// metadataPath is the JSON pointer to the genesis document metadata,
+	// which may not be modified by patches.
+	metadataPath = "/metadata"
+)
+
+// Parse parses a JSON-encoded list of patch operations.
+func Parse(raw []byte) ([]genesis.PatchOperation, error) {
+	var ops []genesis.PatchOperation
+	if err := json.Unmarshal(raw, &ops); err != nil {
+		return nil, fmt.Errorf("genesis/patch: malformed patch: %w", err)
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("genesis/patch: empty patch")
+	}
+	for i, op := range ops {
+		if err := validateOperation(&op); err != nil {
+			return nil, fmt.Errorf("genesis/patch: operation %d: %w", i, err)
+		}
+	}
+	return ops, nil
+}
+
+func validateOperation(op *genesis.PatchOperation) error {
+	switch op.Op {
+	case opAdd, opReplace, opTest:
+		if len(op.Value) == 0 {
+			return fmt.Errorf("%s: missing value", op.Op)
+		}
+	case opRemove:
+	case opMove, opCopy:
+		if _, err := parsePointer(op.From); err != nil {
+			return fmt.Errorf("%s: bad from pointer: %w", op.Op, err)
+		}
+	default:
+		return fmt.Errorf("unsupported operation: '%s'", op.Op)
+	}
+
+	if _, err := parsePointer(op.Path); err != nil {
+		return fmt.Errorf("%s: bad path pointer: %w", op.Op, err)
+	}
+
+	if op.Path == "" && op.Op != opTest {
+		return fmt.Errorf("%s: patching the whole document is not allowed", op.Op)
+	}
+	// Do not allow tampering with the patch log.
+	if isMetadata(op.Path) && op.Op != opTest {
+		return fmt.Errorf("%s: patching the document metadata is not allowed", op.Op)
+	}
+	if op.Op == opMove && (op.From == "" || isMetadata(op.From)) {
+		return fmt.Errorf("%s: moving the document metadata is not allowed", op.Op)
+	}
+	return nil
+}
+
+func isMetadata(p string) bool {
+	return p == metadataPath || strings.HasPrefix(p, metadataPath+"/")
+}
+
+// ApplyRaw applies the given patch operations to a raw JSON document and
+// returns the patched raw document.
+//
+// The operations are applied atomically, either all of them are applied or
+// an error is returned.
+func ApplyRaw(raw []byte, ops []genesis.PatchOperation) ([]byte, error) {
+	doc, err := decode(raw)
+	if err != nil {
+		return nil, fmt.Errorf("genesis/patch: malformed document: %w", err)
+	}
+
+	for i, op := range ops {
+		if doc, err = applyOperation(doc, &op); err != nil {
+			return nil, fmt.Errorf("genesis/patch: operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	patched, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("genesis/patch: failed to encode patched document: %w", err)
+	}
+	return patched, nil
+}
+
+// Apply applies the given patch operations to a genesis document, records
+// the patch in the document's metadata and returns the patched document.
+func Apply(doc *genesis.Document, description string, ops []genesis.PatchOperation, now time.Time) (*genesis.Document, error) {
+	if description == "" {
+		return nil, fmt.Errorf("genesis/patch: missing patch description")
+	}
+	for i, op := range ops {
+		if err := validateOperation(&op); err != nil {
+			return nil, fmt.Errorf("genesis/patch: operation %d: %w", i, err)
+		}
+	}
+
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("genesis/patch: failed to encode document: %w", err)
+	}
+	patched, err := ApplyRaw(raw, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	var newDoc genesis.Document
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&newDoc); err != nil {
+		return nil, fmt.Errorf("genesis/patch: patched document is malformed: %w", err)
+	}
+
+	if newDoc.Metadata == nil {
+		newDoc.Metadata = &genesis.Metadata{}
+	}
+	newDoc.Metadata.Patches = append(newDoc.Metadata.Patches, &genesis.PatchRecord{
+		Time:         now.UTC(),
+		Description:  description,
+		PreviousHash: doc.Hash(),
+		Operations:   ops,
+	})
+
+	return &newDoc, nil
+}
+
+func decode(raw []byte) (interface{}, error) {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	// Preserve number representation so large integers are not mangled.
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func applyOperation(doc interface{}, op *genesis.PatchOperation) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case opAdd:
+		value, err := decode(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("malformed value: %w", err)
+		}
+		return add(doc, path, value)
+	case opRemove:
+		doc, _, err = remove(doc, path)
+		return doc, err
+	case opReplace:
+		value, err := decode(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("malformed value: %w", err)
+		}
+		if doc, _, err = remove(doc, path); err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case opMove:
+		from, _ := parsePointer(op.From)
+		if len(from) < len(path) && isPrefix(from, path) {
+			return nil, fmt.Errorf("cannot move a value into one of its children")
+		}
+		var value interface{}
+		if doc, value, err = remove(doc, from); err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case opCopy:
+		from, _ := parsePointer(op.From)
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		// Deep copy the value so that later operations do not alias it.
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		if value, err = decode(raw); err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case opTest:
+		expected, err := decode(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("malformed value: %w", err)
+		}
+		actual, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(expected, actual) {
+			return nil, fmt.Errorf("test failed")
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unsupported operation: '%s'", op.Op)
+	}
+}
+
+// parsePointer parses a JSON pointer (RFC 6901) into its reference tokens.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(p, "/") {
+		return nil, fmt.Errorf("pointer must start with '/': '%s'", p)
+	}
+
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if allowEnd && token == "-" {
+		return length, nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("bad array index: '%s'", token)
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("bad array index: '%s'", token)
+	}
+	max := length - 1
+	if allowEnd {
+		max = length
+	}
+	if idx < 0 || idx > max {
+		return 0, fmt.Errorf("array index out of bounds: %d", idx)
+	}
+	return idx, nil
+}
+
+func get(doc interface{}, path []string) (interface{}, error) {
+	cur := doc
+	for _, token := range path {
+		switch v := cur.(type) {
+		case map[string]interface{}:
+			child, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("member not found: '%s'", token)
+			}
+			cur = child
+		case []interface{}:
+			idx, err := arrayIndex(token, len(v), false)
+			if err != nil {
+				return nil, err
+			}
+			cur = v[idx]
+		default:
+			return nil, fmt.Errorf("cannot traverse into a scalar value: '%s'", token)
+		}
+	}
+	return cur, nil
+}
+
+// set replaces the value at the given (existing) path and returns the
+// updated document.
+func set(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch v := parent.(type) {
+	case map[string]interface{}:
+		v[last] = value
+	case []interface{}:
+		idx, err := arrayIndex(last, len(v), false)
+		if err != nil {
+			return nil, err
+		}
+		v[idx] = value
+	default:
+		return nil, fmt.Errorf("cannot traverse into a scalar value: '%s'", last)
+	}
+	return doc, nil
+}
+
+// add adds a value at the given path and returns the updated document.
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parentPath, last := path[:len(path)-1], path[len(path)-1]
+	parent, err := get(doc, parentPath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := parent.(type) {
+	case map[string]interface{}:
+		v[last] = value
+		return doc, nil
+	case []interface{}:
+		idx, err := arrayIndex(last, len(v), true)
+		if err != nil {
+			return nil, err
+		}
+		newArray := make([]interface{}, 0, len(v)+1)
+		newArray = append(newArray, v[:idx]...)
+		newArray = append(newArray, value)
+		newArray = append(newArray, v[idx:]...)
+		return set(doc, parentPath, newArray)
+	default:
+		return nil, fmt.Errorf("cannot add to a scalar value: '%s'", last)
+	}
+}
+
+// remove removes the value at the given path and returns the updated
+// document and the removed value.
+func remove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("cannot remove the whole document")
+	}
+
+	parentPath, last := path[:len(path)-1], path[len(path)-1]
+	parent, err := get(doc, parentPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch v := parent.(type) {
+	case map[string]interface{}:
+		value, ok := v[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("member not found: '%s'", last)
+		}
+		delete(v, last)
+		return doc, value, nil
+	case []interface{}:
+		idx, err := arrayIndex(last, len(v), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := v[idx]
+		newArray := make([]interface{}, 0, len(v)-1)
+		newArray = append(newArray, v[:idx]...)
+		newArray = append(newArray, v[idx+1:]...)
+		if doc, err = set(doc, parentPath, newArray); err != nil {
+			return nil, nil, err
+		}
+		return doc, value, nil
+	default:
+		return nil, nil, fmt.Errorf("cannot remove from a scalar value: '%s'", last)
+	}
+}
+
+// equal compares two decoded JSON values. Numbers are compared by their
+// textual representation.
+func equal(a, b interface{}) bool {
+	ra, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	rb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	// Object members are marshalled in sorted order so equal values have
+	// equal encodings.
+	return bytes.Equal(ra, rb)
+}
@@ -0,0 +1,145 @@
+package patch
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestApplyRaw(t *testing.T) {
+	require := require.New(t)
+
+	doc := []byte(`{"a":{"b":[1,2,3],"c":"x"},"d":18446744073709551615,"e~/f":true}`)
+	for _, tc := range []struct {
+		patch    string
+		expected string
+	}{
+		{`[{"op":"add","path":"/a/b/1","value":42}]`, `{"a":{"b":[1,42,2,3],"c":"x"},"d":18446744073709551615,"e~/f":true}`},
+		{`[{"op":"add","path":"/a/b/-","value":4}]`, `{"a":{"b":[1,2,3,4],"c":"x"},"d":18446744073709551615,"e~/f":true}`},
+		{`[{"op":"add","path":"/g","value":{"h":null}}]`, `{"a":{"b":[1,2,3],"c":"x"},"d":18446744073709551615,"e~/f":true,"g":{"h":null}}`},
+		{`[{"op":"remove","path":"/a/b/0"}]`, `{"a":{"b":[2,3],"c":"x"},"d":18446744073709551615,"e~/f":true}`},
+		{`[{"op":"remove","path":"/e~0~1f"}]`, `{"a":{"b":[1,2,3],"c":"x"},"d":18446744073709551615}`},
+		{`[{"op":"replace","path":"/a/c","value":"y"}]`, `{"a":{"b":[1,2,3],"c":"y"},"d":18446744073709551615,"e~/f":true}`},
+		{`[{"op":"replace","path":"/a/b/2","value":5}]`, `{"a":{"b":[1,2,5],"c":"x"},"d":18446744073709551615,"e~/f":true}`},
+		{`[{"op":"move","from":"/a/c","path":"/c"}]`, `{"a":{"b":[1,2,3]},"c":"x","d":18446744073709551615,"e~/f":true}`},
+		{`[{"op":"copy","from":"/a/b","path":"/b"},{"op":"remove","path":"/b/0"}]`, `{"a":{"b":[1,2,3],"c":"x"},"b":[2,3],"d":18446744073709551615,"e~/f":true}`},
+		{`[{"op":"test","path":"/d","value":18446744073709551615},{"op":"test","path":"/a","value":{"c":"x","b":[1,2,3]}}]`, string(doc)},
+	} {
+		ops, err := Parse([]byte(tc.patch))
+		require.NoError(err, "Parse(%s)", tc.patch)
+		patched, err := ApplyRaw(doc, ops)
+		require.NoError(err, "ApplyRaw(%s)", tc.patch)
+		require.JSONEq(tc.expected, string(patched), "ApplyRaw(%s)", tc.patch)
+	}
+
+	for _, patch := range []string{
+		`[{"op":"remove","path":"/x"}]`,
+		`[{"op":"remove","path":"/a/b/3"}]`,
+		`[{"op":"remove","path":"/a/b/01"}]`,
+		`[{"op":"add","path":"/a/c/x","value":1}]`,
+		`[{"op":"replace","path":"/x","value":1}]`,
+		`[{"op":"move","from":"/a","path":"/a/x"}]`,
+		`[{"op":"test","path":"/a/c","value":"y"}]`,
+		`[{"op":"replace","path":"/a/c","value":"y"},{"op":"test","path":"/a/c","value":"x"}]`,
+	} {
+		ops, err := Parse([]byte(patch))
+		require.NoError(err, "Parse(%s)", patch)
+		_, err = ApplyRaw(doc, ops)
+		require.Error(err, "ApplyRaw(%s) should fail", patch)
+	}
+
+	for _, patch := range []string{
+		`garbage`,
+		`[]`,
+		`[{"op":"frobnicate","path":"/a"}]`,
+		`[{"op":"add","path":"/a"}]`,
+		`[{"op":"remove","path":"a"}]`,
+		`[{"op":"remove","path":""}]`,
+		`[{"op":"remove","path":"/metadata/patches/0"}]`,
+		`[{"op":"move","from":"/metadata","path":"/x"}]`,
+	} {
+		_, err := Parse([]byte(patch))
+		require.Error(err, "Parse(%s) should fail", patch)
+	}
+}
+
+func TestApply(t *testing.T) {
+	require := require.New(t)
+
+	var rtID1, rtID2 common.Namespace
+	_ = rtID1.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001")
+	_ = rtID2.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000002")
+
+	doc := &genesis.Document{
+		Height:  42,
+		ChainID: "test",
+		Registry: registry.Genesis{
+			Runtimes: []*registry.Runtime{
+				{ID: rtID1, GovernanceModel: registry.GovernanceEntity},
+				{ID: rtID2, GovernanceModel: registry.GovernanceEntity},
+			},
+		},
+		Staking: staking.Genesis{
+			Ledger: map[staking.Address]*staking.Account{
+				staking.CommonPoolAddress: {},
+			},
+		},
+	}
+
+	now := time.Unix(1580461674, 0)
+	rawPatch := `[
+		{"op":"test","path":"/registry/runtimes/1/id","value":"` + rtID2.String() + `"},
+		{"op":"remove","path":"/registry/runtimes/1"},
+		{"op":"replace","path":"/staking/ledger/` + staking.CommonPoolAddress.String() + `/general/balance","value":"100"}
+	]`
+	ops, err := Parse([]byte(rawPatch))
+	require.NoError(err, "Parse")
+
+	_, err = Apply(doc, "", ops, now)
+	require.Error(err, "Apply without a description should fail")
+
+	newDoc, err := Apply(doc, "remove broken runtime", ops, now)
+	require.NoError(err, "Apply")
+	require.Len(newDoc.Registry.Runtimes, 1, "runtime should be removed")
+	require.Equal(rtID1, newDoc.Registry.Runtimes[0].ID)
+	require.EqualValues(*quantity.NewFromUint64(100), newDoc.Staking.Ledger[staking.CommonPoolAddress].General.Balance)
+	require.Len(doc.Registry.Runtimes, 2, "original document should not be modified")
+
+	require.NotNil(newDoc.Metadata, "patch should be recorded")
+	require.Len(newDoc.Metadata.Patches, 1, "patch should be recorded")
+	record := newDoc.Metadata.Patches[0]
+	require.Equal("remove broken runtime", record.Description)
+	require.True(now.Equal(record.Time))
+	require.Equal(doc.Hash(), record.PreviousHash)
+	require.Equal(ops, record.Operations)
+
+	// Patch records are appended and survive serialization.
+	ops2, err := Parse([]byte(`[{"op":"replace","path":"/chain_id","value":"test2"}]`))
+	require.NoError(err, "Parse")
+	newDoc2, err := Apply(newDoc, "change chain id", ops2, now)
+	require.NoError(err, "Apply")
+	require.Equal("test2", newDoc2.ChainID)
+
+	raw, err := newDoc2.CanonicalJSON()
+	require.NoError(err, "CanonicalJSON")
+	var decoded genesis.Document
+	err = json.Unmarshal(raw, &decoded)
+	require.NoError(err, "Unmarshal")
+	require.Len(decoded.Metadata.Patches, 2, "all patches should be recorded")
+	require.Equal(newDoc.Hash(), decoded.Metadata.Patches[1].PreviousHash)
+	require.Equal(newDoc2.Hash(), decoded.Hash(), "hash should survive serialization")
+
+	// Patches that result in an invalid document should be rejected.
+	ops3, err := Parse([]byte(`[{"op":"add","path":"/unknown_field","value":1}]`))
+	require.NoError(err, "Parse")
+	_, err = Apply(doc, "bad patch", ops3, now)
+	require.Error(err, "Apply resulting in a malformed document should fail")
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
@@ -14,6 +15,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -54,25 +56,52 @@ var (
 	_ prettyprint.PrettyPrinter = (*ProposalContent)(nil)
 	_ prettyprint.PrettyPrinter = (*UpgradeProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*CancelUpgradeProposal)(nil)
+	_ prettyprint.PrettyPrinter = (*ChangeTokenMetadataProposal)(nil)
+	_ prettyprint.PrettyPrinter = (*ChangeRuntimePauseProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*ProposalVote)(nil)
 )
 
 // ProposalContent is a consensus layer governance proposal content.
 type ProposalContent struct {
-	Upgrade       *UpgradeProposal       `json:"upgrade,omitempty"`
-	CancelUpgrade *CancelUpgradeProposal `json:"cancel_upgrade,omitempty"`
+	Upgrade             *UpgradeProposal             `json:"upgrade,omitempty"`
+	CancelUpgrade       *CancelUpgradeProposal       `json:"cancel_upgrade,omitempty"`
+	ChangeTokenMetadata *ChangeTokenMetadataProposal `json:"change_token_metadata,omitempty"`
+	ChangeRuntimePause  *ChangeRuntimePauseProposal  `json:"change_runtime_pause,omitempty"`
+}
+
+// numFieldsSet returns the number of proposal content fields that are set.
+func (p *ProposalContent) numFieldsSet() int {
+	var n int
+	if p.Upgrade != nil {
+		n++
+	}
+	if p.CancelUpgrade != nil {
+		n++
+	}
+	if p.ChangeTokenMetadata != nil {
+		n++
+	}
+	if p.ChangeRuntimePause != nil {
+		n++
+	}
+	return n
 }
 
 // ValidateBasic performs basic proposal content validity checks.
 func (p *ProposalContent) ValidateBasic() error {
 	switch {
-	case p.Upgrade != nil && p.CancelUpgrade != nil:
+	case p.numFieldsSet() > 1:
 		return fmt.Errorf("proposal content has multiple fields set")
 	case p.Upgrade != nil:
 		return p.Upgrade.ValidateBasic()
 	case p.CancelUpgrade != nil:
 		// No validation at this time.
 		return nil
+	case p.ChangeTokenMetadata != nil:
+		return p.ChangeTokenMetadata.ValidateBasic()
+	case p.ChangeRuntimePause != nil:
+		// No validation at this time.
+		return nil
 	default:
 		return fmt.Errorf("proposal content has no fields set")
 	}
@@ -88,6 +117,10 @@ func (p *ProposalContent) Equals(other *ProposalContent) bool {
 		return p.CancelUpgrade.ProposalID == other.CancelUpgrade.ProposalID
 	case p.Upgrade != nil && other.Upgrade != nil:
 		return p.Upgrade.Descriptor.Equals(&other.Upgrade.Descriptor)
+	case p.ChangeTokenMetadata != nil && other.ChangeTokenMetadata != nil:
+		return p.ChangeTokenMetadata.Metadata == other.ChangeTokenMetadata.Metadata
+	case p.ChangeRuntimePause != nil && other.ChangeRuntimePause != nil:
+		return *p.ChangeRuntimePause == *other.ChangeRuntimePause
 	default:
 		return false
 	}
@@ -96,15 +129,24 @@ func (p *ProposalContent) Equals(other *ProposalContent) bool {
 // PrettyPrint writes a pretty-printed representation of ProposalContent to the
 // given writer.
 func (p ProposalContent) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	if p.numFieldsSet() != 1 {
+		fmt.Fprintf(w, "%s%s\n", prefix, ProposalContentInvalidText)
+		return
+	}
+
 	switch {
-	case p.Upgrade != nil && p.CancelUpgrade == nil:
+	case p.Upgrade != nil:
 		fmt.Fprintf(w, "%sUpgrade:\n", prefix)
 		p.Upgrade.PrettyPrint(ctx, prefix+"  ", w)
-	case p.CancelUpgrade != nil && p.Upgrade == nil:
+	case p.CancelUpgrade != nil:
 		fmt.Fprintf(w, "%sCancel Upgrade:\n", prefix)
 		p.CancelUpgrade.PrettyPrint(ctx, prefix+"  ", w)
-	default:
-		fmt.Fprintf(w, "%s%s\n", prefix, ProposalContentInvalidText)
+	case p.ChangeTokenMetadata != nil:
+		fmt.Fprintf(w, "%sChange Token Metadata:\n", prefix)
+		p.ChangeTokenMetadata.PrettyPrint(ctx, prefix+"  ", w)
+	case p.ChangeRuntimePause != nil:
+		fmt.Fprintf(w, "%sChange Runtime Pause:\n", prefix)
+		p.ChangeRuntimePause.PrettyPrint(ctx, prefix+"  ", w)
 	}
 }
 
@@ -149,6 +191,47 @@ func (cu CancelUpgradeProposal) PrettyType() (interface{}, error) {
 	return cu, nil
 }
 
+// ChangeTokenMetadataProposal is a proposal to change the token metadata.
+type ChangeTokenMetadataProposal struct {
+	token.Metadata
+}
+
+// PrettyPrint writes a pretty-printed representation of ChangeTokenMetadataProposal
+// to the given writer.
+func (ct ChangeTokenMetadataProposal) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sSymbol:         %s\n", prefix, ct.Symbol)
+	fmt.Fprintf(w, "%sValue Exponent: %d\n", prefix, ct.ValueExponent)
+}
+
+// PrettyType returns a representation of ChangeTokenMetadataProposal that can be
+// used for pretty printing.
+func (ct ChangeTokenMetadataProposal) PrettyType() (interface{}, error) {
+	return ct, nil
+}
+
+// ChangeRuntimePauseProposal is a proposal to pause or resume a runtime.
+//
+// Only runtimes that are not governed by an entity can be paused or resumed via governance.
+type ChangeRuntimePauseProposal struct {
+	// RuntimeID is the identifier of the runtime.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Paused specifies whether the runtime should be paused or resumed.
+	Paused bool `json:"paused"`
+}
+
+// PrettyPrint writes a pretty-printed representation of ChangeRuntimePauseProposal
+// to the given writer.
+func (cp ChangeRuntimePauseProposal) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sRuntime ID: %s\n", prefix, cp.RuntimeID)
+	fmt.Fprintf(w, "%sPaused:     %t\n", prefix, cp.Paused)
+}
+
+// PrettyType returns a representation of ChangeRuntimePauseProposal that can be
+// used for pretty printing.
+func (cp ChangeRuntimePauseProposal) PrettyType() (interface{}, error) {
+	return cp, nil
+}
+
 // ProposalVote is a vote for a proposal.
 type ProposalVote struct {
 	// ID is the unique identifier of a proposal.
@@ -9,6 +9,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -59,6 +60,41 @@ func TestValidateBasic(t *testing.T) {
 			},
 			shouldErr: false,
 		},
+		{
+			msg: "change token metadata with invalid metadata should fail",
+			p: &ProposalContent{
+				ChangeTokenMetadata: &ChangeTokenMetadataProposal{
+					Metadata: token.Metadata{Symbol: "test", ValueExponent: 9},
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "change token metadata with valid metadata should not fail",
+			p: &ProposalContent{
+				ChangeTokenMetadata: &ChangeTokenMetadataProposal{
+					Metadata: token.Metadata{Symbol: "TEST", ValueExponent: 9},
+				},
+			},
+			shouldErr: false,
+		},
+		{
+			msg: "only one of CancelUpgrade/ChangeTokenMetadata fields should be set",
+			p: &ProposalContent{
+				CancelUpgrade: &CancelUpgradeProposal{},
+				ChangeTokenMetadata: &ChangeTokenMetadataProposal{
+					Metadata: token.Metadata{Symbol: "TEST", ValueExponent: 9},
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "change runtime pause proposal content should not fail",
+			p: &ProposalContent{
+				ChangeRuntimePause: &ChangeRuntimePauseProposal{Paused: true},
+			},
+			shouldErr: false,
+		},
 	} {
 		err := tc.p.ValidateBasic()
 		if tc.shouldErr {
@@ -136,6 +172,54 @@ func TestProposalContentEquals(t *testing.T) {
 			},
 			equals: false,
 		},
+		{
+			msg: "change token metadata proposals should be equal",
+			p1: &ProposalContent{
+				ChangeTokenMetadata: &ChangeTokenMetadataProposal{
+					Metadata: token.Metadata{Symbol: "TEST", ValueExponent: 9},
+				},
+			},
+			p2: &ProposalContent{
+				ChangeTokenMetadata: &ChangeTokenMetadataProposal{
+					Metadata: token.Metadata{Symbol: "TEST", ValueExponent: 9},
+				},
+			},
+			equals: true,
+		},
+		{
+			msg: "change token metadata proposals should not be equal",
+			p1: &ProposalContent{
+				ChangeTokenMetadata: &ChangeTokenMetadataProposal{
+					Metadata: token.Metadata{Symbol: "TEST", ValueExponent: 9},
+				},
+			},
+			p2: &ProposalContent{
+				ChangeTokenMetadata: &ChangeTokenMetadataProposal{
+					Metadata: token.Metadata{Symbol: "TEST", ValueExponent: 18},
+				},
+			},
+			equals: false,
+		},
+		{
+			msg: "change runtime pause proposals should be equal",
+			p1: &ProposalContent{
+				ChangeRuntimePause: &ChangeRuntimePauseProposal{Paused: true},
+			},
+			p2: &ProposalContent{
+				ChangeRuntimePause: &ChangeRuntimePauseProposal{Paused: true},
+			},
+			equals: true,
+		},
+		{
+			msg: "change runtime pause proposals should not be equal",
+			p1: &ProposalContent{
+				ChangeRuntimePause: &ChangeRuntimePauseProposal{Paused: true},
+			},
+			p2: &ProposalContent{
+				ChangeRuntimePause: &ChangeRuntimePauseProposal{Paused: false},
+			},
+			equals: false,
+		},
 	} {
 		require.Equal(t, tc.equals, tc.p1.Equals(tc.p2), tc.msg)
 	}
@@ -160,6 +244,20 @@ func TestProposalContentPrettyPrint(t *testing.T) {
 				CancelUpgrade: &CancelUpgradeProposal{ProposalID: 42},
 			},
 		},
+		{
+			expRegex: "^Change Token Metadata:\n  Symbol:         TEST\n",
+			p: &ProposalContent{
+				ChangeTokenMetadata: &ChangeTokenMetadataProposal{
+					Metadata: token.Metadata{Symbol: "TEST", ValueExponent: 9},
+				},
+			},
+		},
+		{
+			expRegex: "^Change Runtime Pause:\n  Runtime ID: [0-9a-f]+\n  Paused:     true\n",
+			p: &ProposalContent{
+				ChangeRuntimePause: &ChangeRuntimePauseProposal{Paused: true},
+			},
+		},
 		{
 			expRegex: ProposalContentInvalidText,
 			p:        &ProposalContent{},
@@ -220,6 +220,11 @@ type Config struct {
 	// production endpoint.
 	IsProduction bool
 
+	// BaseURL optionally overrides the base URL of the IAS-compatible
+	// attestation service. If empty, the Intel endpoint is used as
+	// determined by IsProduction.
+	BaseURL string
+
 	// DebugIsMock is set if set to true will return mock AVR responses
 	// and not actually contact IAS.
 	DebugIsMock bool
@@ -262,9 +267,14 @@ func New(cfg *Config) (api.Endpoint, error) {
 			QuoteSignatureType: cfg.QuoteSignatureType,
 		},
 	}
-	if cfg.IsProduction {
+	switch {
+	case cfg.BaseURL != "":
+		if e.baseURL, err = url.Parse(cfg.BaseURL); err != nil {
+			return nil, fmt.Errorf("ias: malformed base URL: %w", err)
+		}
+	case cfg.IsProduction:
 		e.baseURL, _ = url.Parse(iasAPIProductionBaseURL)
-	} else {
+	default:
 		e.baseURL, _ = url.Parse(iasAPITestingBaseURL)
 	}
 
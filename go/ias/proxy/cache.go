@@ -0,0 +1,117 @@
+package proxy
+
+import (
+	"context"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
+	"github.com/oasisprotocol/oasis-core/go/ias/api"
+)
+
+// CacheServiceName is the name of the persistent service store used by the
+// AVR cache.
+const CacheServiceName = "ias_proxy_avr_cache"
+
+var (
+	_ api.Endpoint = (*cachingEndpoint)(nil)
+
+	cacheKeyPrefix = []byte("avr.")
+)
+
+type cacheEntry struct {
+	AVR *ias.AVRBundle `json:"avr"`
+
+	// Expiration is the UNIX timestamp after which the entry is stale.
+	Expiration int64 `json:"expiration"`
+}
+
+type cachingEndpoint struct {
+	endpoint api.Endpoint
+	store    *persistent.ServiceStore
+	ttl      time.Duration
+
+	logger *logging.Logger
+}
+
+// cacheKey derives the cache key from the evidence. Since the AVR includes
+// both the quote and the nonce, all of the evidence (apart from the runtime
+// identifier which is only used for authentication) is covered.
+func cacheKey(evidence *api.Evidence) []byte {
+	h := hash.NewFrom(&api.Evidence{
+		Quote:       evidence.Quote,
+		PSEManifest: evidence.PSEManifest,
+		Nonce:       evidence.Nonce,
+	})
+	return append(append([]byte{}, cacheKeyPrefix...), h[:]...)
+}
+
+func (c *cachingEndpoint) VerifyEvidence(ctx context.Context, evidence *api.Evidence) (*ias.AVRBundle, error) {
+	key := cacheKey(evidence)
+
+	var entry cacheEntry
+	switch err := c.store.GetCBOR(key, &entry); err {
+	case nil:
+		if time.Now().Unix() < entry.Expiration && entry.AVR != nil {
+			iasCacheHits.Inc()
+			return entry.AVR, nil
+		}
+
+		// Stale entry, remove it.
+		if err = c.store.Delete(key); err != nil && err != persistent.ErrNotFound {
+			c.logger.Warn("failed to remove stale AVR cache entry",
+				"err", err,
+			)
+		}
+	case persistent.ErrNotFound:
+	default:
+		c.logger.Warn("failed to query AVR cache",
+			"err", err,
+		)
+	}
+	iasCacheMisses.Inc()
+
+	avr, err := c.endpoint.VerifyEvidence(ctx, evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	entry = cacheEntry{
+		AVR:        avr,
+		Expiration: time.Now().Add(c.ttl).Unix(),
+	}
+	if err = c.store.PutCBOR(key, &entry); err != nil {
+		c.logger.Warn("failed to store AVR cache entry",
+			"err", err,
+		)
+	}
+
+	return avr, nil
+}
+
+func (c *cachingEndpoint) GetSPIDInfo(ctx context.Context) (*api.SPIDInfo, error) {
+	return c.endpoint.GetSPIDInfo(ctx)
+}
+
+func (c *cachingEndpoint) GetSigRL(ctx context.Context, epidGID uint32) ([]byte, error) {
+	return c.endpoint.GetSigRL(ctx, epidGID)
+}
+
+func (c *cachingEndpoint) Cleanup() {
+	c.endpoint.Cleanup()
+}
+
+// NewCachingEndpoint wraps an endpoint so that AVRs are persistently cached
+// in the given service store for the given amount of time.
+func NewCachingEndpoint(endpoint api.Endpoint, store *persistent.ServiceStore, ttl time.Duration) api.Endpoint {
+	initMetrics()
+
+	return &cachingEndpoint{
+		endpoint: endpoint,
+		store:    store,
+		ttl:      ttl,
+		logger:   logging.GetLogger("ias/proxy/cache"),
+	}
+}
@@ -0,0 +1,208 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
+	"github.com/oasisprotocol/oasis-core/go/ias/api"
+)
+
+// DefaultFailureBackoff is the default amount of time an upstream provider
+// is skipped for after a failed request.
+const DefaultFailureBackoff = 30 * time.Second
+
+var (
+	_ api.Endpoint = (*failoverEndpoint)(nil)
+
+	// ErrNoProviders is the error returned when no upstream providers are
+	// configured.
+	ErrNoProviders = errors.New("ias/proxy: no upstream providers configured")
+)
+
+// Provider is an upstream attestation provider.
+type Provider struct {
+	// Name is the human readable name of the provider, used in logs and
+	// metrics.
+	Name string
+
+	// Endpoint is the provider's endpoint.
+	Endpoint api.Endpoint
+}
+
+type providerState struct {
+	Provider
+
+	unhealthyUntil time.Time
+}
+
+type failoverEndpoint struct {
+	sync.Mutex
+
+	providers []*providerState
+	backoff   time.Duration
+
+	logger *logging.Logger
+}
+
+// orderedProviders returns the list of providers in the order in which they
+// should be tried. Healthy providers come first, followed by the unhealthy
+// ones ordered by when their backoff expires.
+func (f *failoverEndpoint) orderedProviders() []*providerState {
+	f.Lock()
+	defer f.Unlock()
+
+	now := time.Now()
+	var healthy, unhealthy []*providerState
+	for _, p := range f.providers {
+		if now.Before(p.unhealthyUntil) {
+			unhealthy = append(unhealthy, p)
+			continue
+		}
+		healthy = append(healthy, p)
+	}
+	sort.SliceStable(unhealthy, func(i, j int) bool {
+		return unhealthy[i].unhealthyUntil.Before(unhealthy[j].unhealthyUntil)
+	})
+	return append(healthy, unhealthy...)
+}
+
+func (f *failoverEndpoint) markHealthy(p *providerState) {
+	f.Lock()
+	defer f.Unlock()
+
+	p.unhealthyUntil = time.Time{}
+	iasProviderHealthy.WithLabelValues(p.Name).Set(1)
+}
+
+func (f *failoverEndpoint) markUnhealthy(p *providerState, err error) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.logger.Warn("upstream provider request failed, failing over",
+		"provider", p.Name,
+		"err", err,
+		"backoff", f.backoff,
+	)
+
+	p.unhealthyUntil = time.Now().Add(f.backoff)
+	iasProviderHealthy.WithLabelValues(p.Name).Set(0)
+}
+
+// forEachProvider calls fn for each provider in failover order until one of
+// the calls succeeds.
+func (f *failoverEndpoint) forEachProvider(ctx context.Context, fn func(p *providerState) error) error {
+	var errs []error
+	for _, p := range f.orderedProviders() {
+		err := fn(p)
+		if err == nil {
+			f.markHealthy(p)
+			return nil
+		}
+		if ctx.Err() != nil {
+			// The caller gave up, this is not the provider's fault.
+			return ctx.Err()
+		}
+
+		f.markUnhealthy(p, err)
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+	}
+	return fmt.Errorf("ias/proxy: all upstream providers failed: %v", errs)
+}
+
+func (f *failoverEndpoint) VerifyEvidence(ctx context.Context, evidence *api.Evidence) (*ias.AVRBundle, error) {
+	// Reject malformed evidence before contacting any of the providers so
+	// that failures can be attributed to the providers themselves.
+	var quote ias.Quote
+	if err := quote.UnmarshalBinary(evidence.Quote); err != nil {
+		return nil, fmt.Errorf("ias/proxy: invalid quote: %w", err)
+	}
+	if err := quote.Verify(); err != nil {
+		return nil, fmt.Errorf("ias/proxy: invalid quote: %w", err)
+	}
+	if len(evidence.Nonce) > ias.NonceMaxLen {
+		return nil, fmt.Errorf("ias/proxy: invalid nonce length")
+	}
+
+	var avr *ias.AVRBundle
+	err := f.forEachProvider(ctx, func(p *providerState) error {
+		start := time.Now()
+		var err error
+		avr, err = p.Endpoint.VerifyEvidence(ctx, evidence)
+		iasVerifyLatency.WithLabelValues(p.Name).Observe(time.Since(start).Seconds())
+		if err != nil {
+			iasVerifyFailures.WithLabelValues(p.Name).Inc()
+			return err
+		}
+		iasVerifySuccesses.WithLabelValues(p.Name).Inc()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return avr, nil
+}
+
+func (f *failoverEndpoint) GetSPIDInfo(ctx context.Context) (*api.SPIDInfo, error) {
+	var spidInfo *api.SPIDInfo
+	err := f.forEachProvider(ctx, func(p *providerState) error {
+		var err error
+		spidInfo, err = p.Endpoint.GetSPIDInfo(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return spidInfo, nil
+}
+
+func (f *failoverEndpoint) GetSigRL(ctx context.Context, epidGID uint32) ([]byte, error) {
+	var sigRL []byte
+	err := f.forEachProvider(ctx, func(p *providerState) error {
+		var err error
+		sigRL, err = p.Endpoint.GetSigRL(ctx, epidGID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sigRL, nil
+}
+
+func (f *failoverEndpoint) Cleanup() {
+	for _, p := range f.providers {
+		p.Endpoint.Cleanup()
+	}
+}
+
+// NewFailoverEndpoint creates a new endpoint that dispatches requests to the
+// given upstream providers, in order, failing over to the next provider in
+// case a request fails. Providers that failed a request are skipped for the
+// given backoff period, unless no healthy providers remain.
+//
+// All providers are expected to share the same SPID.
+func NewFailoverEndpoint(providers []Provider, backoff time.Duration) (api.Endpoint, error) {
+	if len(providers) == 0 {
+		return nil, ErrNoProviders
+	}
+	if backoff <= 0 {
+		backoff = DefaultFailureBackoff
+	}
+
+	initMetrics()
+
+	f := &failoverEndpoint{
+		backoff: backoff,
+		logger:  logging.GetLogger("ias/proxy/failover"),
+	}
+	for _, p := range providers {
+		f.providers = append(f.providers, &providerState{Provider: p})
+		iasProviderHealthy.WithLabelValues(p.Name).Set(1)
+	}
+	return f, nil
+}
@@ -0,0 +1,98 @@
+package proxy
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	iasVerifyLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_ias_proxy_verify_latency",
+			Help: "IAS upstream evidence verification latency (seconds).",
+		},
+		[]string{"provider"},
+	)
+	iasVerifyFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_ias_proxy_verify_failures",
+			Help: "Number of failed IAS upstream evidence verifications.",
+		},
+		[]string{"provider"},
+	)
+	iasVerifySuccesses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_ias_proxy_verify_successes",
+			Help: "Number of successful IAS upstream evidence verifications.",
+		},
+		[]string{"provider"},
+	)
+	iasProviderHealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_ias_proxy_provider_healthy",
+			Help: "Whether the IAS upstream provider is considered healthy (1) or not (0).",
+		},
+		[]string{"provider"},
+	)
+	iasCacheHits = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "oasis_ias_proxy_cache_hits",
+			Help: "Number of AVR cache hits.",
+		},
+	)
+	iasCacheMisses = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "oasis_ias_proxy_cache_misses",
+			Help: "Number of AVR cache misses.",
+		},
+	)
+	iasQuotaLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_ias_proxy_quota_limit",
+			Help: "Per-client evidence verification request quota for the runtime.",
+		},
+		[]string{"runtime"},
+	)
+	iasQuotaUsed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_ias_proxy_quota_used",
+			Help: "Number of evidence verification requests made by the client in the current quota interval.",
+		},
+		[]string{"runtime", "client"},
+	)
+	iasQuotaRejections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_ias_proxy_quota_rejections",
+			Help: "Number of evidence verification requests rejected due to an exhausted quota.",
+		},
+		[]string{"runtime"},
+	)
+	iasAuthFailures = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "oasis_ias_proxy_auth_failures",
+			Help: "Number of requests rejected due to failed client authentication.",
+		},
+	)
+
+	iasCollectors = []prometheus.Collector{
+		iasVerifyLatency,
+		iasVerifyFailures,
+		iasVerifySuccesses,
+		iasProviderHealthy,
+		iasCacheHits,
+		iasCacheMisses,
+		iasQuotaLimit,
+		iasQuotaUsed,
+		iasQuotaRejections,
+		iasAuthFailures,
+	}
+
+	metricsOnce sync.Once
+)
+
+func initMetrics() {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(iasCollectors...)
+	})
+}
@@ -4,6 +4,7 @@ package proxy
 import (
 	"context"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
 	"github.com/oasisprotocol/oasis-core/go/ias/api"
@@ -32,14 +33,49 @@ func (n *noOpAuthenticator) VerifyEvidence(ctx context.Context, evidence *api.Ev
 type proxyEndpoint struct {
 	endpoint      api.Endpoint
 	authenticator Authenticator
+	policy        *Policy
+	quotas        *quotaTracker
 
 	logger *logging.Logger
 }
 
+// authenticateClient authenticates the gRPC client based on the node
+// identity TLS certificate it presented, if required by the policy.
+func (p *proxyEndpoint) authenticateClient(ctx context.Context) (signature.PublicKey, error) {
+	if !p.policy.AuthenticateClients {
+		return signature.PublicKey{}, nil
+	}
+
+	client, err := ClientFromContext(ctx, p.policy.AllowedClients)
+	if err != nil {
+		iasAuthFailures.Inc()
+		p.logger.Warn("failed to authenticate IAS proxy client",
+			"err", err,
+		)
+		return client, err
+	}
+	return client, nil
+}
+
 func (p *proxyEndpoint) VerifyEvidence(ctx context.Context, evidence *api.Evidence) (*ias.AVRBundle, error) {
-	if err := p.authenticator.VerifyEvidence(ctx, evidence); err != nil {
+	client, err := p.authenticateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = p.authenticator.VerifyEvidence(ctx, evidence); err != nil {
 		p.logger.Warn("failed to authenticate IAS VerifyEvidence request",
 			"err", err,
+			"client", client,
+		)
+		return nil, err
+	}
+
+	if err = p.quotas.charge(client, evidence.RuntimeID); err != nil {
+		p.logger.Warn("rejecting IAS VerifyEvidence request, quota exceeded",
+			"err", err,
+			"client", client,
+			"runtime_id", evidence.RuntimeID,
 		)
 		return nil, err
 	}
@@ -48,10 +84,18 @@ func (p *proxyEndpoint) VerifyEvidence(ctx context.Context, evidence *api.Eviden
 }
 
 func (p *proxyEndpoint) GetSPIDInfo(ctx context.Context) (*api.SPIDInfo, error) {
+	if _, err := p.authenticateClient(ctx); err != nil {
+		return nil, err
+	}
+
 	return p.endpoint.GetSPIDInfo(ctx)
 }
 
 func (p *proxyEndpoint) GetSigRL(ctx context.Context, epidGID uint32) ([]byte, error) {
+	if _, err := p.authenticateClient(ctx); err != nil {
+		return nil, err
+	}
+
 	// TODO: Validate the EPID group ID.
 	return p.endpoint.GetSigRL(ctx, epidGID)
 }
@@ -60,14 +104,25 @@ func (p *proxyEndpoint) Cleanup() {
 }
 
 // New creates a new proxy endpoint.
-func New(endpoint api.Endpoint, authenticator Authenticator) api.Endpoint {
+//
+// The policy controls client authentication and per-client runtime quotas,
+// and may be nil in which case clients are neither authenticated nor subject
+// to any quotas.
+func New(endpoint api.Endpoint, authenticator Authenticator, policy *Policy) api.Endpoint {
+	initMetrics()
+
 	if authenticator == nil {
 		authenticator = &noOpAuthenticator{}
 	}
+	if policy == nil {
+		policy = &Policy{}
+	}
 
 	return &proxyEndpoint{
 		endpoint:      endpoint,
 		authenticator: authenticator,
+		policy:        policy,
+		quotas:        newQuotaTracker(policy),
 		logger:        logging.GetLogger("ias/proxy"),
 	}
 }
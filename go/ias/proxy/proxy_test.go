@@ -0,0 +1,238 @@
+package proxy
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	tlsCert "github.com/oasisprotocol/oasis-core/go/common/crypto/tls"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
+	"github.com/oasisprotocol/oasis-core/go/ias/api"
+)
+
+type testEndpoint struct {
+	fail  bool
+	calls int
+}
+
+func (e *testEndpoint) VerifyEvidence(ctx context.Context, evidence *api.Evidence) (*ias.AVRBundle, error) {
+	e.calls++
+	if e.fail {
+		return nil, fmt.Errorf("test: upstream failure")
+	}
+	return &ias.AVRBundle{Body: []byte(fmt.Sprintf("avr %d", e.calls))}, nil
+}
+
+func (e *testEndpoint) GetSPIDInfo(ctx context.Context) (*api.SPIDInfo, error) {
+	return &api.SPIDInfo{}, nil
+}
+
+func (e *testEndpoint) GetSigRL(ctx context.Context, epidGID uint32) ([]byte, error) {
+	return nil, nil
+}
+
+func (e *testEndpoint) Cleanup() {
+}
+
+func testEvidence(t *testing.T, nonce string) *api.Evidence {
+	quote := ias.Quote{
+		Body: ias.Body{
+			Version:       2,
+			SignatureType: ias.SignatureLinkable,
+		},
+	}
+	rawQuote, err := quote.MarshalBinary()
+	require.NoError(t, err, "quote.MarshalBinary")
+
+	return &api.Evidence{
+		Quote: rawQuote,
+		Nonce: nonce,
+	}
+}
+
+func TestFailoverEndpoint(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewFailoverEndpoint(nil, 0)
+	require.ErrorIs(err, ErrNoProviders, "NewFailoverEndpoint should fail without providers")
+
+	primary := &testEndpoint{fail: true}
+	secondary := &testEndpoint{}
+	ep, err := NewFailoverEndpoint([]Provider{
+		{Name: "primary", Endpoint: primary},
+		{Name: "secondary", Endpoint: secondary},
+	}, time.Hour)
+	require.NoError(err, "NewFailoverEndpoint")
+
+	ctx := context.Background()
+	evidence := testEvidence(t, "nonce")
+
+	avr, err := ep.VerifyEvidence(ctx, evidence)
+	require.NoError(err, "VerifyEvidence should fail over to the secondary provider")
+	require.NotNil(avr)
+	require.Equal(1, primary.calls, "primary should be tried first")
+	require.Equal(1, secondary.calls, "secondary should be tried after primary fails")
+
+	// The primary is now unhealthy and should be skipped.
+	_, err = ep.VerifyEvidence(ctx, evidence)
+	require.NoError(err, "VerifyEvidence")
+	require.Equal(1, primary.calls, "unhealthy primary should be skipped")
+	require.Equal(2, secondary.calls, "secondary should be used")
+
+	// If all providers fail, unhealthy providers should still be tried.
+	secondary.fail = true
+	_, err = ep.VerifyEvidence(ctx, evidence)
+	require.Error(err, "VerifyEvidence should fail when all providers fail")
+	require.Equal(2, primary.calls, "unhealthy primary should be retried as a last resort")
+	require.Equal(3, secondary.calls)
+
+	// Malformed evidence should not be dispatched at all.
+	_, err = ep.VerifyEvidence(ctx, &api.Evidence{Quote: []byte("garbage")})
+	require.Error(err, "VerifyEvidence should reject malformed quotes")
+	require.Equal(2, primary.calls)
+	require.Equal(3, secondary.calls)
+}
+
+func TestCachingEndpoint(t *testing.T) {
+	require := require.New(t)
+
+	dataDir, err := ioutil.TempDir("", "oasis-ias-proxy-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	commonStore, err := persistent.NewCommonStore(dataDir)
+	require.NoError(err, "NewCommonStore")
+	defer commonStore.Close()
+	store, err := commonStore.GetServiceStore(CacheServiceName)
+	require.NoError(err, "GetServiceStore")
+
+	upstream := &testEndpoint{}
+	ep := NewCachingEndpoint(upstream, store, time.Hour)
+
+	ctx := context.Background()
+	evidence := testEvidence(t, "nonce")
+
+	avr1, err := ep.VerifyEvidence(ctx, evidence)
+	require.NoError(err, "VerifyEvidence")
+	avr2, err := ep.VerifyEvidence(ctx, evidence)
+	require.NoError(err, "VerifyEvidence")
+	require.EqualValues(avr1, avr2, "cached AVR should be returned")
+	require.Equal(1, upstream.calls, "upstream should only be contacted once")
+
+	// Different nonces must result in a different AVR.
+	avr3, err := ep.VerifyEvidence(ctx, testEvidence(t, "other nonce"))
+	require.NoError(err, "VerifyEvidence")
+	require.NotEqualValues(avr1, avr3, "AVRs for different nonces should differ")
+	require.Equal(2, upstream.calls)
+
+	// Expired entries should not be used.
+	ep = NewCachingEndpoint(upstream, store, -time.Second)
+	evidence = testEvidence(t, "expiring nonce")
+	_, err = ep.VerifyEvidence(ctx, evidence)
+	require.NoError(err, "VerifyEvidence")
+	require.Equal(3, upstream.calls, "upstream should be contacted")
+	_, err = ep.VerifyEvidence(ctx, evidence)
+	require.NoError(err, "VerifyEvidence")
+	require.Equal(4, upstream.calls, "expired entry should not be used")
+}
+
+func testClientContext(t *testing.T, commonName string) (context.Context, signature.PublicKey) {
+	cert, err := tlsCert.Generate(commonName)
+	require.NoError(t, err, "Generate")
+	x509Cert, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err, "ParseCertificate")
+
+	var pk signature.PublicKey
+	err = pk.UnmarshalBinary(x509Cert.PublicKey.(ed25519.PublicKey))
+	require.NoError(t, err, "UnmarshalBinary")
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{x509Cert},
+			},
+		},
+	})
+	return ctx, pk
+}
+
+func TestProxyPolicy(t *testing.T) {
+	require := require.New(t)
+
+	var otherRuntimeID common.Namespace
+	err := otherRuntimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(err, "UnmarshalHex")
+
+	clientCtx, clientPk := testClientContext(t, identity.CommonName)
+	otherCtx, _ := testClientContext(t, identity.CommonName)
+	badCtx, _ := testClientContext(t, "not-a-node")
+
+	upstream := &testEndpoint{}
+	ep := New(upstream, nil, &Policy{
+		AuthenticateClients: true,
+		DefaultQuota: Quota{
+			Requests: 2,
+			Interval: time.Hour,
+		},
+		RuntimeQuotas: map[common.Namespace]Quota{
+			otherRuntimeID: {Requests: 1},
+		},
+	})
+
+	evidence := testEvidence(t, "nonce")
+	otherEvidence := testEvidence(t, "nonce")
+	otherEvidence.RuntimeID = otherRuntimeID
+
+	// Unauthenticated clients should be rejected.
+	_, err = ep.VerifyEvidence(context.Background(), evidence)
+	require.ErrorIs(err, ErrClientUnauthenticated, "VerifyEvidence should fail without a client certificate")
+	_, err = ep.VerifyEvidence(badCtx, evidence)
+	require.ErrorIs(err, ErrClientUnauthenticated, "VerifyEvidence should fail with a non-node certificate")
+	_, err = ep.GetSPIDInfo(badCtx)
+	require.ErrorIs(err, ErrClientUnauthenticated, "GetSPIDInfo should fail with a non-node certificate")
+	require.Equal(0, upstream.calls, "upstream should not be contacted")
+
+	// Quotas should be enforced per client and runtime.
+	for i := 0; i < 2; i++ {
+		_, err = ep.VerifyEvidence(clientCtx, evidence)
+		require.NoError(err, "VerifyEvidence")
+	}
+	_, err = ep.VerifyEvidence(clientCtx, evidence)
+	require.ErrorIs(err, ErrQuotaExceeded, "VerifyEvidence should fail after exhausting the quota")
+	require.Equal(2, upstream.calls)
+
+	_, err = ep.VerifyEvidence(clientCtx, otherEvidence)
+	require.NoError(err, "VerifyEvidence should use a separate quota for other runtimes")
+	_, err = ep.VerifyEvidence(clientCtx, otherEvidence)
+	require.ErrorIs(err, ErrQuotaExceeded, "VerifyEvidence should enforce runtime-specific quotas")
+
+	_, err = ep.VerifyEvidence(otherCtx, evidence)
+	require.NoError(err, "VerifyEvidence should use a separate quota for other clients")
+	require.Equal(4, upstream.calls)
+
+	// Only allowed clients should be accepted, if configured.
+	ep = New(upstream, nil, &Policy{
+		AuthenticateClients: true,
+		AllowedClients: map[signature.PublicKey]bool{
+			clientPk: true,
+		},
+	})
+	_, err = ep.VerifyEvidence(clientCtx, evidence)
+	require.NoError(err, "VerifyEvidence should succeed for an allowed client")
+	_, err = ep.VerifyEvidence(otherCtx, evidence)
+	require.ErrorIs(err, ErrClientUnauthenticated, "VerifyEvidence should fail for a client that is not allowed")
+}
@@ -0,0 +1,182 @@
+package proxy
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	cmnTLS "github.com/oasisprotocol/oasis-core/go/common/crypto/tls"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+)
+
+// DefaultQuotaInterval is the default interval over which client quotas are
+// enforced.
+const DefaultQuotaInterval = time.Hour
+
+var (
+	// ErrClientUnauthenticated is the error returned when the client did not
+	// present an acceptable node identity TLS certificate.
+	ErrClientUnauthenticated = errors.New("ias/proxy: client not authenticated")
+
+	// ErrQuotaExceeded is the error returned when the client has exhausted
+	// its quota for the given runtime.
+	ErrQuotaExceeded = errors.New("ias/proxy: quota exceeded")
+)
+
+// Quota is a limit on the number of evidence verification requests.
+type Quota struct {
+	// Requests is the maximum number of evidence verification requests that
+	// a single client may make for a runtime within each interval. Zero
+	// means unlimited.
+	Requests uint64
+
+	// Interval is the interval over which the quota is enforced. If zero,
+	// DefaultQuotaInterval is used.
+	Interval time.Duration
+}
+
+// Policy is the IAS proxy client policy.
+type Policy struct {
+	// AuthenticateClients specifies whether clients must present a node
+	// identity TLS certificate.
+	AuthenticateClients bool
+
+	// AllowedClients is the optional set of client TLS public keys that are
+	// allowed to use the proxy. If empty, any authenticated client is
+	// allowed.
+	AllowedClients map[signature.PublicKey]bool
+
+	// DefaultQuota is the per-client quota applied to runtimes without a
+	// runtime-specific quota.
+	DefaultQuota Quota
+
+	// RuntimeQuotas are the per-client runtime-specific quotas.
+	RuntimeQuotas map[common.Namespace]Quota
+}
+
+func (p *Policy) quotaFor(runtimeID common.Namespace) Quota {
+	q, ok := p.RuntimeQuotas[runtimeID]
+	if !ok {
+		q = p.DefaultQuota
+	}
+	if q.Interval <= 0 {
+		q.Interval = DefaultQuotaInterval
+	}
+	return q
+}
+
+// ClientFromContext returns the public key of the node identity TLS
+// certificate presented by the gRPC client.
+func ClientFromContext(ctx context.Context, allowed map[signature.PublicKey]bool) (signature.PublicKey, error) {
+	var pk signature.PublicKey
+
+	peer, ok := peer.FromContext(ctx)
+	if !ok {
+		return pk, fmt.Errorf("%w: failed to obtain connection peer from context", ErrClientUnauthenticated)
+	}
+	tlsAuth, ok := peer.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return pk, fmt.Errorf("%w: unexpected peer authentication credentials", ErrClientUnauthenticated)
+	}
+	if nPeerCerts := len(tlsAuth.State.PeerCertificates); nPeerCerts != 1 {
+		return pk, fmt.Errorf("%w: unexpected number of peer certificates: %d", ErrClientUnauthenticated, nPeerCerts)
+	}
+	peerCert := tlsAuth.State.PeerCertificates[0]
+
+	opts := cmnTLS.VerifyOptions{
+		CommonName:       identity.CommonName,
+		AllowUnknownKeys: true,
+	}
+	if len(allowed) > 0 {
+		opts.Keys = allowed
+	}
+	if err := cmnTLS.VerifyCertificate([][]byte{peerCert.Raw}, opts); err != nil {
+		return pk, fmt.Errorf("%w: %s", ErrClientUnauthenticated, err)
+	}
+
+	// The certificate verification above ensures this is an Ed25519 key.
+	if err := pk.UnmarshalBinary(peerCert.PublicKey.(ed25519.PublicKey)); err != nil {
+		return pk, fmt.Errorf("%w: bad public key: %s", ErrClientUnauthenticated, err)
+	}
+	return pk, nil
+}
+
+type quotaKey struct {
+	client    signature.PublicKey
+	runtimeID common.Namespace
+}
+
+type quotaWindow struct {
+	start time.Time
+	used  uint64
+}
+
+type quotaTracker struct {
+	sync.Mutex
+
+	policy  *Policy
+	windows map[quotaKey]*quotaWindow
+}
+
+// charge accounts for a single evidence verification request made by the
+// given client for the given runtime and returns an error if the client's
+// quota has been exhausted.
+func (t *quotaTracker) charge(client signature.PublicKey, runtimeID common.Namespace) error {
+	quota := t.policy.quotaFor(runtimeID)
+	if quota.Requests == 0 {
+		return nil
+	}
+	iasQuotaLimit.WithLabelValues(runtimeID.String()).Set(float64(quota.Requests))
+
+	t.Lock()
+	defer t.Unlock()
+
+	now := time.Now()
+	t.pruneLocked(now)
+
+	key := quotaKey{client: client, runtimeID: runtimeID}
+	w, ok := t.windows[key]
+	if !ok {
+		w = &quotaWindow{start: now}
+		t.windows[key] = w
+	}
+	if w.used >= quota.Requests {
+		iasQuotaRejections.WithLabelValues(runtimeID.String()).Inc()
+		return fmt.Errorf("%w: %d requests per %s for runtime %s",
+			ErrQuotaExceeded,
+			quota.Requests,
+			quota.Interval,
+			runtimeID,
+		)
+	}
+	w.used++
+	iasQuotaUsed.WithLabelValues(runtimeID.String(), client.String()).Set(float64(w.used))
+
+	return nil
+}
+
+// pruneLocked removes all expired quota windows.
+func (t *quotaTracker) pruneLocked(now time.Time) {
+	for key, w := range t.windows {
+		if now.Sub(w.start) < t.policy.quotaFor(key.runtimeID).Interval {
+			continue
+		}
+		delete(t.windows, key)
+		iasQuotaUsed.DeleteLabelValues(key.runtimeID.String(), key.client.String())
+	}
+}
+
+func newQuotaTracker(policy *Policy) *quotaTracker {
+	return &quotaTracker{
+		policy:  policy,
+		windows: make(map[quotaKey]*quotaWindow),
+	}
+}
@@ -48,6 +48,7 @@ var (
 	// Methods is the list of all methods supported by the key manager backend.
 	Methods = []transaction.MethodName{
 		MethodUpdatePolicy,
+		MethodApplyChurp,
 	}
 
 	initResponseContext = signature.NewContext("oasis-core/keymanager: init response")
@@ -72,6 +73,18 @@ type Status struct {
 
 	// Policy is the key manager policy.
 	Policy *SignedPolicySGX `json:"policy"`
+
+	// Churp is the status of the CHURP handoffs in case the key manager uses the CHURP key
+	// management scheme.
+	Churp *ChurpStatus `json:"churp,omitempty"`
+}
+
+// Scheme returns the key management scheme selected by the key manager policy.
+func (s *Status) Scheme() Scheme {
+	if s.Policy == nil {
+		return SchemeReplicated
+	}
+	return s.Policy.Policy.Scheme
 }
 
 // Backend is a key manager management implementation.
@@ -179,6 +192,18 @@ func SanityCheckStatuses(statuses []*Status) error {
 				return err
 			}
 		}
+
+		// Verify CHURP status.
+		if status.Churp != nil {
+			if status.Scheme() != SchemeCHURP {
+				return fmt.Errorf("keymanager: sanity check failed: CHURP status for non-CHURP key manager %s", status.ID)
+			}
+			for _, node := range status.Churp.Committee {
+				if !node.IsValid() {
+					return fmt.Errorf("keymanager: sanity check failed: CHURP committee node ID %s is invalid", node.String())
+				}
+			}
+		}
 	}
 	return nil
 }
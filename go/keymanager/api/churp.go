@@ -0,0 +1,178 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+)
+
+var (
+	// ErrUnsupportedScheme is the error returned when the parsed key management scheme is
+	// malformed or unknown.
+	ErrUnsupportedScheme = errors.New(ModuleName, 2, "keymanager: unsupported key management scheme")
+
+	// ErrInvalidChurpApplication is the error returned when a CHURP handoff application is
+	// invalid.
+	ErrInvalidChurpApplication = errors.New(ModuleName, 3, "keymanager: invalid CHURP application")
+
+	// MethodApplyChurp is the method name for CHURP handoff applications.
+	MethodApplyChurp = transaction.NewMethodName(ModuleName, "ApplyChurp", ChurpApplication{})
+)
+
+// Scheme is a key management scheme.
+type Scheme uint8
+
+const (
+	// SchemeReplicated is the scheme where each key manager node holds a full replica of the
+	// master secret, obtained by replicating it from other nodes.
+	SchemeReplicated Scheme = 0
+
+	// SchemeCHURP is the threshold scheme where the master secret is shared among the key
+	// manager committee using a bivariate polynomial and the shares are periodically handed
+	// off to a new committee (CHURP).
+	SchemeCHURP Scheme = 1
+
+	schemeReplicated = "replicated"
+	schemeCHURP      = "churp"
+)
+
+// String returns a string representation of a key management scheme.
+func (s Scheme) String() string {
+	switch s {
+	case SchemeReplicated:
+		return schemeReplicated
+	case SchemeCHURP:
+		return schemeCHURP
+	default:
+		return "[unsupported key management scheme]"
+	}
+}
+
+// FromString deserializes a string into a Scheme.
+func (s *Scheme) FromString(str string) error {
+	switch strings.ToLower(str) {
+	case schemeReplicated:
+		*s = SchemeReplicated
+	case schemeCHURP:
+		*s = SchemeCHURP
+	default:
+		return ErrUnsupportedScheme
+	}
+
+	return nil
+}
+
+// ChurpPolicy are the parameters of a CHURP key manager.
+type ChurpPolicy struct {
+	// Threshold is the degree of the secret-sharing polynomial. Any Threshold+1 committee
+	// members can reconstruct the master secret while Threshold members learn nothing about it.
+	Threshold uint8 `json:"threshold"`
+
+	// HandoffInterval is the number of epochs between consecutive handoffs.
+	HandoffInterval beacon.EpochTime `json:"handoff_interval"`
+}
+
+// MinCommitteeSize returns the minimum number of nodes that need to apply in order for a
+// handoff to succeed.
+func (p *ChurpPolicy) MinCommitteeSize() int {
+	return 2*int(p.Threshold) + 1
+}
+
+// ValidateBasic performs basic CHURP policy validity checks.
+func (p *ChurpPolicy) ValidateBasic() error {
+	if p.Threshold == 0 {
+		return fmt.Errorf("keymanager: sanity check failed: CHURP threshold must be positive")
+	}
+	if p.HandoffInterval == 0 {
+		return fmt.Errorf("keymanager: sanity check failed: CHURP handoff interval must be positive")
+	}
+	return nil
+}
+
+// ChurpStatus is the current status of a CHURP key manager.
+type ChurpStatus struct {
+	// Handoff is the epoch of the last successfully completed handoff. Zero means that the
+	// initial dealing phase has not yet completed.
+	Handoff beacon.EpochTime `json:"handoff"`
+
+	// NextHandoff is the epoch of the next handoff.
+	NextHandoff beacon.EpochTime `json:"next_handoff"`
+
+	// Checksum is the checksum of the verification matrix of the shares handed off in the last
+	// successfully completed handoff.
+	Checksum *hash.Hash `json:"checksum,omitempty"`
+
+	// Committee is the list of nodes holding shares after the last successfully completed
+	// handoff, sorted by node ID.
+	Committee []signature.PublicKey `json:"committee,omitempty"`
+
+	// Applications are the verification matrix checksums submitted by nodes applying to join
+	// the committee in the next handoff.
+	Applications map[signature.PublicKey]hash.Hash `json:"applications,omitempty"`
+}
+
+// IsMember returns true iff the given node is a member of the current committee.
+func (s *ChurpStatus) IsMember(id signature.PublicKey) bool {
+	for _, member := range s.Committee {
+		if member.Equal(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// TallyApplications returns the checksum supported by the largest number of applications and the
+// sorted list of nodes that submitted it. In case of ties the smallest checksum wins.
+func (s *ChurpStatus) TallyApplications() (*hash.Hash, []signature.PublicKey) {
+	votes := make(map[hash.Hash][]signature.PublicKey)
+	for id, checksum := range s.Applications {
+		votes[checksum] = append(votes[checksum], id)
+	}
+
+	var (
+		best      *hash.Hash
+		bestNodes []signature.PublicKey
+	)
+	for checksum, nodes := range votes {
+		checksum := checksum
+		switch {
+		case len(nodes) > len(bestNodes):
+		case len(nodes) == len(bestNodes) && bytes.Compare(checksum[:], best[:]) < 0:
+		default:
+			continue
+		}
+		best = &checksum
+		bestNodes = nodes
+	}
+	sort.Slice(bestNodes, func(i, j int) bool {
+		return bytes.Compare(bestNodes[i][:], bestNodes[j][:]) < 0
+	})
+	return best, bestNodes
+}
+
+// ChurpApplication is an application of a key manager node to join the committee in the next
+// CHURP handoff.
+type ChurpApplication struct {
+	// ID is the runtime ID of the key manager.
+	ID common.Namespace `json:"id"`
+
+	// Epoch is the epoch of the handoff the node is applying for.
+	Epoch beacon.EpochTime `json:"epoch"`
+
+	// Checksum is the checksum of the verification matrix of the bivariate share that the node
+	// prepared for the handoff.
+	Checksum hash.Hash `json:"checksum"`
+}
+
+// NewApplyChurpTx creates a new CHURP handoff application transaction.
+func NewApplyChurpTx(nonce uint64, fee *transaction.Fee, app *ChurpApplication) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodApplyChurp, app)
+}
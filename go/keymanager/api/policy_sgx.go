@@ -23,6 +23,12 @@ type PolicySGX struct {
 
 	// Enclaves is the per-key manager enclave ID access control policy.
 	Enclaves map[sgx.EnclaveIdentity]*EnclavePolicySGX `json:"enclaves"`
+
+	// Scheme is the key management scheme used by the key manager.
+	Scheme Scheme `json:"scheme,omitempty"`
+
+	// Churp are the CHURP parameters, required iff the CHURP scheme is used.
+	Churp *ChurpPolicy `json:"churp,omitempty"`
 }
 
 // EnclavePolicySGX is the per-SGX key manager enclave ID access control policy.
@@ -59,6 +65,22 @@ func SanityCheckSignedPolicySGX(currentSigPol, newSigPol *SignedPolicySGX) error
 		}
 	}
 
+	switch newSigPol.Policy.Scheme {
+	case SchemeReplicated:
+		if newSigPol.Policy.Churp != nil {
+			return fmt.Errorf("keymanager: sanity check failed: CHURP parameters set for replicated scheme")
+		}
+	case SchemeCHURP:
+		if newSigPol.Policy.Churp == nil {
+			return fmt.Errorf("keymanager: sanity check failed: missing CHURP parameters")
+		}
+		if err := newSigPol.Policy.Churp.ValidateBasic(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("keymanager: sanity check failed: %w", ErrUnsupportedScheme)
+	}
+
 	// If a prior version of the policy is not provided, then there is nothing
 	// more to check.  Even with a prior version of the document, since policy
 	// updates can happen independently of a new version of the enclave, it's
@@ -76,5 +98,9 @@ func SanityCheckSignedPolicySGX(currentSigPol, newSigPol *SignedPolicySGX) error
 		return fmt.Errorf("keymanager: sanity check failed: SGX policy serial number did not increase")
 	}
 
+	if currentPol.Scheme != newPol.Scheme {
+		return fmt.Errorf("keymanager: sanity check failed: key management scheme changed from %s to %s", currentPol.Scheme, newPol.Scheme)
+	}
+
 	return nil
 }
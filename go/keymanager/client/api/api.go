@@ -1,7 +1,13 @@
 // Package api defines the key manager client API.
 package api
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
 
 // Client is the key manager client interface.
 type Client interface {
@@ -12,3 +18,41 @@ type Client interface {
 	// completed and the client is ready to service requests.
 	Initialized() <-chan struct{}
 }
+
+// SessionState is the state of a session with a key manager node.
+type SessionState string
+
+const (
+	// SessionStateEstablished is the state of a session with a key manager node whose attestation
+	// has been successfully verified. Only such nodes are used to service requests.
+	SessionStateEstablished SessionState = "established"
+	// SessionStateRejected is the state of a session with a key manager node that failed
+	// verification. Such nodes are not used until they pass verification again.
+	SessionStateRejected SessionState = "rejected"
+)
+
+// SessionStatus is the status of a session with a key manager node.
+type SessionStatus struct {
+	// NodeID is the identifier of the key manager node.
+	NodeID signature.PublicKey `json:"node_id"`
+	// State is the state of the session.
+	State SessionState `json:"state"`
+	// VerifiedAt is the time of the last session verification.
+	VerifiedAt time.Time `json:"verified_at"`
+	// Error is the reason for the last verification failure, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Status is the key manager client status.
+type Status struct {
+	// KeyManagerID is the identifier of the key manager runtime, if known.
+	KeyManagerID *common.Namespace `json:"key_manager_id,omitempty"`
+	// PolicySerial is the serial number of the key manager policy used for verification.
+	PolicySerial uint32 `json:"policy_serial"`
+	// Initialized is true when at least one session has been established.
+	Initialized bool `json:"initialized"`
+	// Sessions are the statuses of sessions with the key manager nodes.
+	Sessions []*SessionStatus `json:"sessions"`
+	// Error is the reason for the last failure to update sessions, if any.
+	Error string `json:"error,omitempty"`
+}
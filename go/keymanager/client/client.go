@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -18,6 +19,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	clientApi "github.com/oasisprotocol/oasis-core/go/keymanager/client/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	enclaverpc "github.com/oasisprotocol/oasis-core/go/runtime/enclaverpc/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/nodes"
@@ -30,8 +32,12 @@ const (
 	maxRetries    = 15
 )
 
-// ErrKeyManagerNotAvailable is the error when a key manager is not available.
-var ErrKeyManagerNotAvailable = errors.New("keymanager/client: key manager not available")
+var (
+	// ErrKeyManagerNotAvailable is the error when a key manager is not available.
+	ErrKeyManagerNotAvailable = errors.New("keymanager/client: key manager not available")
+
+	_ clientApi.Client = (*Client)(nil)
+)
 
 // Client is a key manager client instance.
 type Client struct {
@@ -46,6 +52,9 @@ type Client struct {
 	committeeWatcher nodes.VersionedNodeDescriptorWatcher
 	committeeClient  grpc.NodesClient
 
+	statusLock sync.RWMutex
+	status     clientApi.Status
+
 	logger *logging.Logger
 }
 
@@ -54,6 +63,27 @@ func (c *Client) Initialized() <-chan struct{} {
 	return c.initCh
 }
 
+// GetStatus returns the key manager client status.
+func (c *Client) GetStatus() *clientApi.Status {
+	c.statusLock.RLock()
+	defer c.statusLock.RUnlock()
+
+	status := c.status
+	status.Sessions = make([]*clientApi.SessionStatus, 0, len(c.status.Sessions))
+	for _, sess := range c.status.Sessions {
+		sessCopy := *sess
+		status.Sessions = append(status.Sessions, &sessCopy)
+	}
+	return &status
+}
+
+func (c *Client) setStatus(status clientApi.Status) {
+	c.statusLock.Lock()
+	defer c.statusLock.Unlock()
+
+	c.status = status
+}
+
 // CallRemote calls the key manager via remote EnclaveRPC.
 func (c *Client) CallRemote(ctx context.Context, data []byte) ([]byte, error) {
 	select {
@@ -127,11 +157,24 @@ func (c *Client) worker() {
 	}
 	defer rtSub.Close()
 
-	var kmID *common.Namespace
+	// Periodically re-verify sessions as node attestations may expire or be updated.
+	verifyTicker := time.NewTicker(sessionVerifyInterval)
+	defer verifyTicker.Stop()
+
+	var (
+		kmID     *common.Namespace
+		kmStatus *api.Status
+	)
 	for {
 		select {
 		case <-c.ctx.Done():
 			return
+		case <-verifyTicker.C:
+			if kmStatus == nil {
+				continue
+			}
+
+			c.updateState(kmStatus)
 		case st := <-stCh:
 			// Ignore status updates if key manager is not yet known (is nil) or if the status
 			// update is for a different key manager.
@@ -139,6 +182,7 @@ func (c *Client) worker() {
 				continue
 			}
 
+			kmStatus = st
 			c.updateState(st)
 		case rt := <-rtCh:
 			kmID = rt.KeyManager
@@ -165,6 +209,7 @@ func (c *Client) worker() {
 				continue
 			}
 
+			kmStatus = st
 			c.updateState(st)
 		}
 	}
@@ -178,29 +223,75 @@ func (c *Client) updateState(status *api.Status) {
 	c.committeeWatcher.Reset()
 	defer c.committeeWatcher.Freeze(0)
 
+	kmID := status.ID
+	newStatus := clientApi.Status{
+		KeyManagerID: &kmID,
+		Initialized:  c.initialized,
+		Sessions:     []*clientApi.SessionStatus{},
+	}
+	if status.Policy != nil {
+		newStatus.PolicySerial = status.Policy.Policy.Serial
+	}
+	defer func() {
+		c.setStatus(newStatus)
+	}()
+
 	// It's not possible to service requests for this key manager.
 	if !status.IsInitialized || len(status.Nodes) == 0 {
 		c.logger.Warn("key manager not initialized or has no nodes",
 			"id", status.ID,
 			"status", status,
 		)
+		newStatus.Error = "key manager not initialized or has no nodes"
+		return
+	}
+
+	rt, err := c.consensus.Registry().GetRuntime(c.ctx, &registry.NamespaceQuery{
+		ID:     status.ID,
+		Height: consensus.HeightLatest,
+	})
+	if err != nil {
+		c.logger.Warn("failed to get key manager runtime descriptor",
+			"err", err,
+		)
+		newStatus.Error = fmt.Sprintf("failed to get key manager runtime descriptor: %s", err)
 		return
 	}
 
+	// Only use nodes that pass attestation verification against the key manager runtime's TEE
+	// constraints and the key manager policy.
+	now := time.Now()
+	var established int
 	for _, nodeID := range status.Nodes {
-		_, err := c.committeeWatcher.WatchNode(c.ctx, nodeID)
-		if err != nil {
+		sess := c.verifySession(c.ctx, rt, status.Policy, nodeID, now)
+		newStatus.Sessions = append(newStatus.Sessions, sess)
+		if sess.State != clientApi.SessionStateEstablished {
+			continue
+		}
+
+		if _, err = c.committeeWatcher.WatchNode(c.ctx, nodeID); err != nil {
 			c.logger.Warn("failed to watch node",
 				"err", err,
 			)
+			sess.State = clientApi.SessionStateRejected
+			sess.Error = fmt.Sprintf("failed to watch node: %s", err)
 			continue
 		}
+		established++
+	}
+	if established == 0 {
+		c.logger.Warn("no key manager nodes passed verification",
+			"id", status.ID,
+		)
+		newStatus.Error = "no key manager nodes passed verification"
+		return
 	}
 
 	if !c.initialized {
 		close(c.initCh)
 		c.initialized = true
 	}
+	newStatus.Initialized = true
 }
 
 // New creates a new key manager client instance.
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	clientApi "github.com/oasisprotocol/oasis-core/go/keymanager/client/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+// sessionVerifyInterval is the interval at which established sessions are re-verified.
+const sessionVerifyInterval = 5 * time.Minute
+
+var (
+	errNoRuntime          = errors.New("keymanager/client: node does not host the key manager runtime")
+	errMissingAttestation = errors.New("keymanager/client: node is missing a TEE attestation")
+)
+
+// verifySession verifies the latest descriptor of the given key manager node and returns the
+// resulting session status.
+func (c *Client) verifySession(
+	ctx context.Context,
+	rt *registry.Runtime,
+	policy *api.SignedPolicySGX,
+	nodeID signature.PublicKey,
+	ts time.Time,
+) *clientApi.SessionStatus {
+	sess := &clientApi.SessionStatus{
+		NodeID:     nodeID,
+		State:      clientApi.SessionStateEstablished,
+		VerifiedAt: ts,
+	}
+
+	err := func() error {
+		n, err := c.consensus.Registry().GetNode(ctx, &registry.IDQuery{
+			ID:     nodeID,
+			Height: consensus.HeightLatest,
+		})
+		if err != nil {
+			return fmt.Errorf("keymanager/client: failed to fetch node descriptor: %w", err)
+		}
+		nodeRt := n.GetRuntime(rt.ID)
+		if nodeRt == nil {
+			return errNoRuntime
+		}
+		return verifyNodeRuntime(nodeRt, rt, policy, ts)
+	}()
+	if err != nil {
+		c.logger.Warn("key manager node failed verification",
+			"node_id", nodeID,
+			"err", err,
+		)
+		sess.State = clientApi.SessionStateRejected
+		sess.Error = err.Error()
+	}
+	return sess
+}
+
+// verifyNodeRuntime verifies that the key manager runtime hosted by a node satisfies the TEE
+// constraints of the registered key manager runtime and that the attested enclave is allowed by
+// the key manager policy.
+func verifyNodeRuntime(nodeRt *node.Runtime, rt *registry.Runtime, policy *api.SignedPolicySGX, ts time.Time) error {
+	if rt.TEEHardware == node.TEEHardwareInvalid {
+		// Nothing to verify for key managers that do not run in a TEE.
+		return nil
+	}
+
+	tee := nodeRt.Capabilities.TEE
+	if tee == nil {
+		return errMissingAttestation
+	}
+	if tee.Hardware != rt.TEEHardware {
+		return registry.ErrTEEHardwareMismatch
+	}
+	if err := tee.Verify(ts, rt.Version.TEE); err != nil {
+		return fmt.Errorf("keymanager/client: attestation verification failed: %w", err)
+	}
+
+	if policy == nil || tee.Hardware != node.TEEHardwareIntelSGX {
+		return nil
+	}
+
+	// Verify the attestation against the enclaves allowed by the key manager policy, while keeping
+	// the quote status requirements of the runtime.
+	var cs node.SGXConstraints
+	if err := cbor.Unmarshal(rt.Version.TEE, &cs); err != nil {
+		return fmt.Errorf("keymanager/client: malformed SGX constraints: %w", err)
+	}
+	cs.Enclaves = make([]sgx.EnclaveIdentity, 0, len(policy.Policy.Enclaves))
+	for enclave := range policy.Policy.Enclaves {
+		cs.Enclaves = append(cs.Enclaves, enclave)
+	}
+	if err := tee.Verify(ts, cbor.Marshal(cs)); err != nil {
+		return fmt.Errorf("keymanager/client: enclave not allowed by key manager policy: %w", err)
+	}
+
+	return nil
+}
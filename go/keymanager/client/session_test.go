@@ -0,0 +1,38 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+func TestVerifyNodeRuntime(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	var nodeRt node.Runtime
+
+	// Key managers that do not run in a TEE do not require attestation.
+	rt := &registry.Runtime{TEEHardware: node.TEEHardwareInvalid}
+	err := verifyNodeRuntime(&nodeRt, rt, nil, now)
+	require.NoError(err, "verifyNodeRuntime should succeed for non-TEE key managers")
+
+	// Key managers that run in a TEE require attestation.
+	rt.TEEHardware = node.TEEHardwareIntelSGX
+	err = verifyNodeRuntime(&nodeRt, rt, nil, now)
+	require.ErrorIs(err, errMissingAttestation, "verifyNodeRuntime should fail without attestation")
+
+	// The attested TEE hardware must match the registered one.
+	nodeRt.Capabilities.TEE = &node.CapabilityTEE{Hardware: node.TEEHardwareReserved}
+	err = verifyNodeRuntime(&nodeRt, rt, nil, now)
+	require.ErrorIs(err, registry.ErrTEEHardwareMismatch, "verifyNodeRuntime should fail on TEE hardware mismatch")
+
+	// Malformed attestations must be rejected.
+	nodeRt.Capabilities.TEE = &node.CapabilityTEE{Hardware: node.TEEHardwareIntelSGX}
+	err = verifyNodeRuntime(&nodeRt, rt, nil, now)
+	require.Error(err, "verifyNodeRuntime should fail on malformed attestation")
+}
@@ -198,6 +198,7 @@ func init() {
 	rootCmd.Flags().AddFlagSet(fixtures.FileFixtureFlags)
 
 	dumpFixtureCmd.Flags().AddFlagSet(fixtures.DefaultFixtureFlags)
+	dumpFixtureCmd.Flags().AddFlagSet(fixtures.FileFixtureFlags)
 	rootCmd.AddCommand(dumpFixtureCmd)
 
 	cobra.OnInitialize(func() {
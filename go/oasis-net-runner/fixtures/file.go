@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
 )
@@ -14,21 +17,72 @@ const (
 	cfgFile = "fixture.file"
 )
 
-// newFixtureFromFile parses given JSON file and creates new fixture object from it.
-func newFixtureFromFile(path string) (*oasis.NetworkFixture, error) {
-	f := oasis.NetworkFixture{}
+// yamlToJSON converts a YAML document into an equivalent JSON document so that
+// the JSON struct tags and custom JSON unmarshalers can be reused.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(normalizeYAML(v))
+}
+
+// normalizeYAML converts any maps with non-string keys into maps with string
+// keys as required by the JSON encoder.
+func normalizeYAML(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, e := range vv {
+			vv[k] = normalizeYAML(e)
+		}
+		return vv
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(vv))
+		for k, e := range vv {
+			m[fmt.Sprintf("%v", k)] = normalizeYAML(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range vv {
+			vv[i] = normalizeYAML(e)
+		}
+		return vv
+	default:
+		return v
+	}
+}
+
+// unmarshalFile parses the given JSON or YAML (based on the file extension)
+// file into v.
+func unmarshalFile(path string, v interface{}) error {
 	data, err := ioutil.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("newFixtureFromFile: failed to open fixture file: %w", err)
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if data, err = yamlToJSON(data); err != nil {
+			return fmt.Errorf("failed to parse YAML from file: %w", err)
+		}
+	default:
 	}
-	if err = json.Unmarshal(data, &f); err != nil {
-		return nil, fmt.Errorf("newFixtureFromFile: failed to unmarshal JSON from fixture file: %w", err)
+	if err = json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON from file: %w", err)
+	}
+	return nil
+}
+
+// newFixtureFromFile parses given JSON or YAML file and creates new fixture object from it.
+func newFixtureFromFile(path string) (*oasis.NetworkFixture, error) {
+	f := oasis.NetworkFixture{}
+	if err := unmarshalFile(path, &f); err != nil {
+		return nil, fmt.Errorf("newFixtureFromFile: %w", err)
 	}
 
 	return &f, nil
 }
 
 func init() {
-	FileFixtureFlags.String(cfgFile, "", "path to JSON-encoded fixture input file")
+	FileFixtureFlags.String(cfgFile, "", "path to JSON or YAML-encoded fixture input file")
 	_ = viper.BindPFlags(FileFixtureFlags)
 }
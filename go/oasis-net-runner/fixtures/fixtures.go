@@ -3,6 +3,7 @@ package fixtures
 
 import (
 	"encoding/json"
+	"fmt"
 
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -18,11 +19,17 @@ var (
 	DefaultFixtureFlags = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
-// GetFixture generates fixture object from given file or default fixture, if no fixtures file provided.
+// GetFixture generates fixture object from given file or template, or the
+// default fixture, if neither is provided.
 func GetFixture() (f *oasis.NetworkFixture, err error) {
-	if viper.IsSet(cfgFile) {
+	switch {
+	case viper.IsSet(cfgFile) && viper.IsSet(cfgTemplate):
+		err = fmt.Errorf("GetFixture: only one of %s and %s may be set", cfgFile, cfgTemplate)
+	case viper.IsSet(cfgFile):
 		f, err = newFixtureFromFile(viper.GetString(cfgFile))
-	} else {
+	case viper.IsSet(cfgTemplate):
+		f, err = newFixtureFromTemplate(viper.GetString(cfgTemplate))
+	default:
 		f, err = newDefaultFixture()
 	}
 	if err != nil {
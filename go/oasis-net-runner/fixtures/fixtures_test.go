@@ -2,12 +2,15 @@ package fixtures
 
 import (
 	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/byzantine"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
 )
 
 func TestDefaultFixture(t *testing.T) {
@@ -39,3 +42,81 @@ func TestCustomFixture(t *testing.T) {
 	require.Nil(t, err)
 	require.EqualValues(t, f, fs)
 }
+
+func TestTemplateFixture(t *testing.T) {
+	require := require.New(t)
+
+	tmpl := []byte(`
+network:
+  epochtime_mock: true
+validators:
+  count: 3
+  sentries: 1
+keymanager:
+  id: "c000000000000000ffffffffffffffffffffffffffffffffffffffffffffffff"
+  binary: simple-keymanager
+  replicas: 2
+clients: 1
+runtimes:
+  - id: "8000000000000000000000000000000000000000000000000000000000000000"
+    binary: simple-keyvalue
+    executor_group_size: 2
+    executor_group_backup_size: 1
+    compute_workers: 3
+byzantine:
+  - runtime: 0
+    attack: executor_wrong
+  - runtime: 0
+    attack: executor_scheduler_straggler
+`)
+	tmpFile, err := ioutil.TempFile("", "oasis-net-runner-template.*.yaml")
+	require.NoError(err, "TempFile")
+	defer os.Remove(tmpFile.Name())
+	_, _ = tmpFile.Write(tmpl)
+	tmpFile.Close()
+
+	f, err := newFixtureFromTemplate(tmpFile.Name())
+	require.NoError(err, "newFixtureFromTemplate")
+	require.Len(f.Validators, 3)
+	require.Len(f.Sentries, 3, "each validator should have a sentry")
+	require.Len(f.Keymanagers, 2)
+	require.Len(f.Runtimes, 2, "key manager and compute runtime")
+	require.Len(f.ComputeWorkers, 3)
+	require.Len(f.Clients, 1)
+	require.EqualValues([]int{1}, f.Clients[0].Runtimes)
+	require.Len(f.ByzantineNodes, 2)
+	require.EqualValues(1, f.ByzantineNodes[0].Runtime, "byzantine runtime index should account for the key manager")
+	require.NotEqual(f.ByzantineNodes[0].IdentitySeed, f.ByzantineNodes[1].IdentitySeed)
+	for i, v := range f.Validators {
+		require.Len(v.Sentries, 1)
+		require.EqualValues([]int{i}, f.Sentries[v.Sentries[0]].Validators)
+	}
+
+	// Invalid attack profiles should be rejected.
+	bad := Template{
+		Validators: TemplateValidators{Count: 1},
+		Runtimes:   []TemplateRuntime{{ComputeWorkers: 2}},
+		Byzantine:  []TemplateByzantine{{Attack: "nonexistent"}},
+	}
+	_, err = bad.Fixture()
+	require.Error(err, "unknown attack profiles should be rejected")
+
+	// Duplicate identity seeds should be rejected.
+	bad.Byzantine = []TemplateByzantine{{Attack: "executor_wrong"}, {Attack: "executor_straggler"}}
+	_, err = bad.Fixture()
+	require.Error(err, "duplicate identity seeds should be rejected")
+
+	// Invalid executor scripts should be rejected.
+	bad.Byzantine = []TemplateByzantine{{Attack: "executor_honest", ExecutorScript: "dishonest"}}
+	_, err = bad.Fixture()
+	require.Error(err, "invalid executor scripts should be rejected")
+
+	good := bad
+	good.Byzantine = []TemplateByzantine{{Attack: "executor_honest", ExecutorScript: "honest; withhold"}}
+	f, err = good.Fixture()
+	require.NoError(err, "Fixture")
+	require.Contains(f.ByzantineNodes[0].ExtraArgs, oasis.Argument{
+		Name:   byzantine.CfgExecutorScript,
+		Values: []string{"honest; withhold"},
+	})
+}
@@ -0,0 +1,463 @@
+package fixtures
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/spf13/viper"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/byzantine"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+const cfgTemplate = "fixture.template"
+
+// Template is a declarative description of a network that is expanded into a
+// full network fixture.
+//
+// Entity 0 is always the debug test entity which owns all of the runtimes,
+// entity 1 owns all of the nodes.
+type Template struct {
+	// Network contains the network-wide configuration.
+	Network TemplateNetwork `json:"network"`
+
+	// Entities is the number of additional (non debug) entities.
+	Entities int `json:"entities,omitempty"`
+
+	// Validators is the validator configuration.
+	Validators TemplateValidators `json:"validators"`
+
+	// Seeds is the number of seed nodes.
+	Seeds int `json:"seeds,omitempty"`
+
+	// Clients is the number of client nodes. All client nodes have all of the
+	// compute runtimes enabled.
+	Clients int `json:"clients,omitempty"`
+
+	// KeyManager is the optional key manager configuration. If configured,
+	// all compute runtimes will use the key manager.
+	KeyManager *TemplateKeyManager `json:"keymanager,omitempty"`
+
+	// Runtimes are the compute runtimes.
+	Runtimes []TemplateRuntime `json:"runtimes,omitempty"`
+
+	// Byzantine are the byzantine nodes.
+	Byzantine []TemplateByzantine `json:"byzantine,omitempty"`
+}
+
+// TemplateNetwork is the network-wide template configuration.
+type TemplateNetwork struct { // nolint: maligned
+	NodeBinary              string  `json:"node_binary,omitempty"`
+	RuntimeLoader           string  `json:"runtime_loader,omitempty"`
+	RuntimeProvisioner      string  `json:"runtime_provisioner,omitempty"`
+	TEEHardware             string  `json:"tee_hardware,omitempty"`
+	EpochtimeMock           bool    `json:"epochtime_mock,omitempty"`
+	HaltEpoch               *uint64 `json:"halt_epoch,omitempty"`
+	InitialHeight           int64   `json:"initial_height,omitempty"`
+	DeterministicIdentities bool    `json:"deterministic_identities,omitempty"`
+	FundEntities            bool    `json:"fund_entities,omitempty"`
+}
+
+// TemplateValidators is the validator template configuration.
+type TemplateValidators struct {
+	// Count is the number of validators.
+	Count int `json:"count"`
+
+	// Sentries is the number of sentry nodes protecting each validator.
+	Sentries int `json:"sentries,omitempty"`
+}
+
+// TemplateKeyManager is the key manager template configuration.
+type TemplateKeyManager struct {
+	// ID is the key manager runtime identifier.
+	ID common.Namespace `json:"id"`
+
+	// Binary is the path to the key manager runtime binary.
+	Binary string `json:"binary"`
+
+	// Replicas is the number of key manager nodes.
+	Replicas int `json:"replicas"`
+
+	// Sentries is the number of sentry nodes protecting each key manager node.
+	Sentries int `json:"sentries,omitempty"`
+}
+
+// TemplateRuntime is the compute runtime template configuration.
+type TemplateRuntime struct {
+	// ID is the runtime identifier.
+	ID common.Namespace `json:"id"`
+
+	// Binary is the path to the runtime binary.
+	Binary string `json:"binary"`
+
+	// ExecutorGroupSize is the size of the executor committee.
+	ExecutorGroupSize uint16 `json:"executor_group_size,omitempty"`
+
+	// ExecutorGroupBackupSize is the number of backup executor workers.
+	ExecutorGroupBackupSize uint16 `json:"executor_group_backup_size,omitempty"`
+
+	// ComputeWorkers is the number of compute workers dedicated to the
+	// runtime.
+	ComputeWorkers int `json:"compute_workers"`
+
+	// Sentries is the number of sentry nodes protecting each compute worker.
+	Sentries int `json:"sentries,omitempty"`
+}
+
+// TemplateByzantine is the byzantine node template configuration.
+type TemplateByzantine struct {
+	// Runtime is the index of the (template) compute runtime the byzantine
+	// node participates in.
+	Runtime int `json:"runtime"`
+
+	// Attack is the name of the attack profile.
+	Attack string `json:"attack"`
+
+	// IdentitySeed optionally overrides the attack profile's identity seed.
+	IdentitySeed string `json:"identity_seed,omitempty"`
+
+	// ActivationEpoch is the epoch at which the byzantine node activates.
+	ActivationEpoch beacon.EpochTime `json:"activation_epoch,omitempty"`
+
+	// ExecutorScript is an optional executor script which selects the
+	// executor behavior in each round (only for executor attack profiles).
+	ExecutorScript string `json:"executor_script,omitempty"`
+}
+
+// byzantineProfile is a byzantine node attack profile.
+type byzantineProfile struct {
+	script       string
+	identitySeed string
+	extraArgs    []oasis.Argument
+	forceElect   *scheduler.ForceElectCommitteeRole
+}
+
+func executorProfile(mode byzantine.ExecutorMode, isScheduler bool, extraArgs ...oasis.Argument) byzantineProfile {
+	p := byzantineProfile{
+		script:       "executor",
+		identitySeed: oasis.ByzantineDefaultIdentitySeed,
+		forceElect: &scheduler.ForceElectCommitteeRole{
+			Kind:        scheduler.KindComputeExecutor,
+			Role:        scheduler.RoleWorker,
+			IsScheduler: isScheduler,
+		},
+	}
+	if isScheduler {
+		p.identitySeed = oasis.ByzantineSlot1IdentitySeed
+		p.extraArgs = append(p.extraArgs, oasis.Argument{Name: byzantine.CfgSchedulerRoleExpected})
+	}
+	if mode != byzantine.ModeExecutorHonest {
+		p.extraArgs = append(p.extraArgs, oasis.Argument{Name: byzantine.CfgExecutorMode, Values: []string{mode.String()}})
+	}
+	p.extraArgs = append(p.extraArgs, extraArgs...)
+	return p
+}
+
+// byzantineProfiles are the supported byzantine node attack profiles.
+var byzantineProfiles = map[string]byzantineProfile{
+	"executor_honest":                       executorProfile(byzantine.ModeExecutorHonest, false),
+	"executor_wrong":                        executorProfile(byzantine.ModeExecutorWrong, false),
+	"executor_straggler":                    executorProfile(byzantine.ModeExecutorStraggler, false),
+	"executor_failure_indicating":           executorProfile(byzantine.ModeExecutorFailureIndicating, false),
+	"executor_scheduler_honest":             executorProfile(byzantine.ModeExecutorHonest, true),
+	"executor_scheduler_wrong":              executorProfile(byzantine.ModeExecutorWrong, true),
+	"executor_scheduler_straggler":          executorProfile(byzantine.ModeExecutorStraggler, true),
+	"executor_scheduler_failure_indicating": executorProfile(byzantine.ModeExecutorFailureIndicating, true),
+	"executor_scheduler_bogus": executorProfile(byzantine.ModeExecutorHonest, true,
+		oasis.Argument{Name: byzantine.CfgExecutorProposeBogusTx},
+	),
+}
+
+// ByzantineAttacks returns the names of all supported byzantine attack
+// profiles.
+func ByzantineAttacks() []string {
+	var attacks []string
+	for name := range byzantineProfiles {
+		attacks = append(attacks, name)
+	}
+	sort.Strings(attacks)
+	return attacks
+}
+
+// templateSentries provisions the given number of sentries and returns their indices.
+func templateSentries(f *oasis.NetworkFixture, n int) []int {
+	var indices []int
+	for i := 0; i < n; i++ {
+		indices = append(indices, len(f.Sentries))
+		f.Sentries = append(f.Sentries, oasis.SentryFixture{})
+	}
+	return indices
+}
+
+// Fixture expands the template into a network fixture.
+func (t *Template) Fixture() (*oasis.NetworkFixture, error) { // nolint: gocyclo
+	if t.Validators.Count < 1 {
+		return nil, fmt.Errorf("template: at least one validator is required")
+	}
+	if t.KeyManager != nil && t.KeyManager.Replicas < 1 {
+		return nil, fmt.Errorf("template: at least one key manager replica is required")
+	}
+
+	var tee node.TEEHardware
+	if err := tee.FromString(t.Network.TEEHardware); err != nil {
+		return nil, fmt.Errorf("template: %w", err)
+	}
+	var mrSigner *sgx.MrSigner
+	if tee == node.TEEHardwareIntelSGX {
+		mrSigner = &sgx.FortanixDummyMrSigner
+	}
+	haltEpoch := uint64(math.MaxUint64)
+	if t.Network.HaltEpoch != nil {
+		haltEpoch = *t.Network.HaltEpoch
+	}
+	initialHeight := t.Network.InitialHeight
+	if initialHeight == 0 {
+		initialHeight = 1
+	}
+	nodeBinary := t.Network.NodeBinary
+	if nodeBinary == "" {
+		nodeBinary = "oasis-node"
+	}
+	provisioner := t.Network.RuntimeProvisioner
+	if provisioner == "" {
+		provisioner = "sandboxed"
+	}
+
+	f := &oasis.NetworkFixture{
+		TEE: oasis.TEEFixture{
+			Hardware: tee,
+			MrSigner: mrSigner,
+		},
+		Network: oasis.NetworkCfg{
+			NodeBinary:             nodeBinary,
+			RuntimeSGXLoaderBinary: t.Network.RuntimeLoader,
+			Consensus: consensusGenesis.Genesis{
+				Parameters: consensusGenesis.Parameters{
+					TimeoutCommit: 1 * time.Second,
+				},
+			},
+			Beacon: beacon.ConsensusParameters{
+				Backend: beacon.BackendInsecure,
+			},
+			InitialHeight: initialHeight,
+			HaltEpoch:     haltEpoch,
+			IAS: oasis.IASCfg{
+				Mock: true,
+			},
+			DeterministicIdentities: t.Network.DeterministicIdentities,
+			FundEntities:            t.Network.FundEntities,
+		},
+		Entities: []oasis.EntityCfg{
+			{IsDebugTestEntity: true},
+			{},
+		},
+	}
+	if t.Network.EpochtimeMock || len(t.Byzantine) > 0 {
+		// Byzantine nodes rely on being elected in the first rounds.
+		f.Network.SetMockEpoch()
+	}
+	for i := 0; i < t.Entities; i++ {
+		f.Entities = append(f.Entities, oasis.EntityCfg{})
+	}
+
+	// Seeds.
+	seeds := t.Seeds
+	if seeds < 1 {
+		seeds = 1
+	}
+	for i := 0; i < seeds; i++ {
+		f.Seeds = append(f.Seeds, oasis.SeedFixture{})
+	}
+
+	// Validators.
+	for i := 0; i < t.Validators.Count; i++ {
+		sentries := templateSentries(f, t.Validators.Sentries)
+		for _, idx := range sentries {
+			f.Sentries[idx].Validators = []int{len(f.Validators)}
+		}
+		f.Validators = append(f.Validators, oasis.ValidatorFixture{
+			Entity:    1,
+			Sentries:  sentries,
+			Consensus: oasis.ConsensusFixture{SupplementarySanityInterval: 1},
+		})
+	}
+
+	// Key manager.
+	keymanagerIdx := -1
+	if km := t.KeyManager; km != nil {
+		keymanagerIdx = len(f.Runtimes)
+		f.Runtimes = append(f.Runtimes, oasis.RuntimeFixture{
+			ID:         km.ID,
+			Kind:       registry.KindKeyManager,
+			Entity:     0,
+			Keymanager: -1,
+			Binaries: map[node.TEEHardware][]string{
+				tee: {km.Binary},
+			},
+			AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+				AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+			},
+			GovernanceModel: registry.GovernanceEntity,
+		})
+		f.KeymanagerPolicies = []oasis.KeymanagerPolicyFixture{
+			{Runtime: keymanagerIdx, Serial: 1},
+		}
+		for i := 0; i < km.Replicas; i++ {
+			sentries := templateSentries(f, km.Sentries)
+			for _, idx := range sentries {
+				f.Sentries[idx].KeymanagerWorkers = []int{len(f.Keymanagers)}
+			}
+			f.Keymanagers = append(f.Keymanagers, oasis.KeymanagerFixture{
+				Runtime:            keymanagerIdx,
+				Entity:             1,
+				RuntimeProvisioner: provisioner,
+				Sentries:           sentries,
+			})
+		}
+	}
+
+	// Clients.
+	for i := 0; i < t.Clients; i++ {
+		f.Clients = append(f.Clients, oasis.ClientFixture{
+			RuntimeProvisioner: provisioner,
+		})
+	}
+
+	// Compute runtimes.
+	runtimeIndices := make([]int, 0, len(t.Runtimes))
+	for _, rt := range t.Runtimes {
+		groupSize := rt.ExecutorGroupSize
+		if groupSize == 0 {
+			groupSize = 2
+		}
+		if rt.ComputeWorkers < int(groupSize) {
+			return nil, fmt.Errorf("template: runtime %s: not enough compute workers (%d) for executor group size %d",
+				rt.ID, rt.ComputeWorkers, groupSize,
+			)
+		}
+
+		rtIdx := len(f.Runtimes)
+		runtimeIndices = append(runtimeIndices, rtIdx)
+		f.Runtimes = append(f.Runtimes, oasis.RuntimeFixture{
+			ID:         rt.ID,
+			Kind:       registry.KindCompute,
+			Entity:     0,
+			Keymanager: keymanagerIdx,
+			Binaries: map[node.TEEHardware][]string{
+				tee: {rt.Binary},
+			},
+			Executor: registry.ExecutorParameters{
+				GroupSize:       groupSize,
+				GroupBackupSize: rt.ExecutorGroupBackupSize,
+				RoundTimeout:    20,
+				MaxMessages:     128,
+			},
+			TxnScheduler: registry.TxnSchedulerParameters{
+				Algorithm:         registry.TxnSchedulerSimple,
+				MaxBatchSize:      1,
+				MaxBatchSizeBytes: 16 * 1024 * 1024, // 16 MiB
+				BatchFlushTimeout: 20 * time.Second,
+				ProposerTimeout:   20,
+			},
+			AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+				AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+			},
+			GovernanceModel: registry.GovernanceEntity,
+		})
+
+		for i := 0; i < rt.ComputeWorkers; i++ {
+			sentries := templateSentries(f, rt.Sentries)
+			for _, idx := range sentries {
+				f.Sentries[idx].ComputeWorkers = []int{len(f.ComputeWorkers)}
+			}
+			f.ComputeWorkers = append(f.ComputeWorkers, oasis.ComputeWorkerFixture{
+				Entity:             1,
+				Runtimes:           []int{rtIdx},
+				RuntimeProvisioner: provisioner,
+				Sentries:           sentries,
+			})
+		}
+		for i := range f.Clients {
+			f.Clients[i].Runtimes = append(f.Clients[i].Runtimes, rtIdx)
+		}
+	}
+
+	// Byzantine nodes.
+	usedSeeds := make(map[string]bool)
+	for i, bt := range t.Byzantine {
+		profile, ok := byzantineProfiles[bt.Attack]
+		if !ok {
+			return nil, fmt.Errorf("template: byzantine node %d: unknown attack profile: %s (supported: %v)",
+				i, bt.Attack, ByzantineAttacks(),
+			)
+		}
+		if bt.Runtime < 0 || bt.Runtime >= len(runtimeIndices) {
+			return nil, fmt.Errorf("template: byzantine node %d: invalid runtime index: %d", i, bt.Runtime)
+		}
+
+		seed := profile.identitySeed
+		if bt.IdentitySeed != "" {
+			seed = bt.IdentitySeed
+		}
+		if usedSeeds[seed] {
+			return nil, fmt.Errorf("template: byzantine node %d: duplicate identity seed, use identity_seed to override", i)
+		}
+		usedSeeds[seed] = true
+
+		extraArgs := profile.extraArgs
+		if bt.ExecutorScript != "" {
+			if profile.script != "executor" {
+				return nil, fmt.Errorf("template: byzantine node %d: executor script not supported by attack profile: %s", i, bt.Attack)
+			}
+			if _, err := byzantine.ParseExecutorScript(bt.ExecutorScript); err != nil {
+				return nil, fmt.Errorf("template: byzantine node %d: %w", i, err)
+			}
+			extraArgs = append(append([]oasis.Argument{}, extraArgs...), oasis.Argument{
+				Name:   byzantine.CfgExecutorScript,
+				Values: []string{bt.ExecutorScript},
+			})
+		}
+
+		f.ByzantineNodes = append(f.ByzantineNodes, oasis.ByzantineFixture{
+			Script:           profile.script,
+			ExtraArgs:        extraArgs,
+			IdentitySeed:     seed,
+			Entity:           1,
+			ActivationEpoch:  bt.ActivationEpoch,
+			Runtime:          runtimeIndices[bt.Runtime],
+			ForceElectParams: profile.forceElect,
+			Consensus:        oasis.ConsensusFixture{SupplementarySanityInterval: 1},
+		})
+	}
+
+	return f, nil
+}
+
+// newFixtureFromTemplate parses the given JSON or YAML template file and
+// expands it into a new fixture object.
+func newFixtureFromTemplate(path string) (*oasis.NetworkFixture, error) {
+	var t Template
+	if err := unmarshalFile(path, &t); err != nil {
+		return nil, fmt.Errorf("newFixtureFromTemplate: %w", err)
+	}
+
+	f, err := t.Fixture()
+	if err != nil {
+		return nil, fmt.Errorf("newFixtureFromTemplate: %w", err)
+	}
+	return f, nil
+}
+
+func init() {
+	FileFixtureFlags.String(cfgTemplate, "", "path to JSON or YAML-encoded network template file")
+	_ = viper.BindPFlags(FileFixtureFlags)
+}
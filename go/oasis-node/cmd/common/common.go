@@ -53,6 +53,11 @@ var (
 	isNodeCmd bool
 )
 
+// ConfigFile returns the configuration file iff one is set.
+func ConfigFile() string {
+	return cfgFile
+}
+
 // DataDir returns the data directory iff one is set.
 func DataDir() string {
 	return viper.GetString(CfgDataDir)
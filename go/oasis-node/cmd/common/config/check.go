@@ -0,0 +1,162 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
+)
+
+// maxSuggestionDistance is the maximum edit distance of a known key from an
+// unknown key for it to be suggested as a replacement.
+const maxSuggestionDistance = 3
+
+// freeFormKeys are configuration keys whose sub-keys are not known in advance
+// (e.g., per-module log levels or node-local runtime configuration) and are
+// therefore not checked.
+var freeFormKeys = []string{
+	"log.level",
+	runtimeRegistry.CfgRuntimeConfig,
+}
+
+// Result is the result of a configuration check.
+type Result struct {
+	// Errors are configuration errors that prevent the node from starting.
+	Errors []error
+	// Warnings are configuration issues that should be addressed but do not
+	// prevent the node from starting.
+	Warnings []string
+}
+
+// Err returns an error iff the check discovered any configuration errors.
+func (r *Result) Err() error {
+	switch len(r.Errors) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("invalid configuration: %w", r.Errors[0])
+	default:
+		return fmt.Errorf("invalid configuration: %w (and %d more errors)", r.Errors[0], len(r.Errors)-1)
+	}
+}
+
+// Check checks the node configuration.
+//
+// The keys present in the given configuration file (if any) are checked
+// against the given set of flags, unknown keys are reported as errors and
+// keys of deprecated flags are reported as warnings. Afterwards the typed
+// configuration is loaded and validated.
+func Check(configFile string, known *flag.FlagSet) *Result {
+	var r Result
+
+	if configFile != "" {
+		if err := r.checkKeys(configFile, known); err != nil {
+			r.Errors = append(r.Errors, err)
+			return &r
+		}
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		r.Errors = append(r.Errors, err)
+		return &r
+	}
+	r.Errors = append(r.Errors, cfg.Validate()...)
+
+	return &r
+}
+
+func (r *Result) checkKeys(configFile string, known *flag.FlagSet) error {
+	v := viper.New()
+	v.SetConfigFile(configFile)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	freeForm := append([]string{}, freeFormKeys...)
+	known.VisitAll(func(f *flag.Flag) {
+		if f.Value.Type() == "stringToString" {
+			freeForm = append(freeForm, f.Name)
+		}
+	})
+
+	keys := v.AllKeys()
+	sort.Strings(keys)
+KeyLoop:
+	for _, key := range keys {
+		for _, prefix := range freeForm {
+			if key == prefix || strings.HasPrefix(key, prefix+".") {
+				continue KeyLoop
+			}
+		}
+
+		f := known.Lookup(key)
+		switch {
+		case f == nil:
+			msg := fmt.Sprintf("unknown configuration key '%s'", key)
+			if suggestion := suggestKey(key, known); suggestion != "" {
+				msg += fmt.Sprintf(" (did you mean '%s'?)", suggestion)
+			}
+			r.Errors = append(r.Errors, fmt.Errorf("%s", msg))
+		case f.Deprecated != "":
+			r.Warnings = append(r.Warnings, fmt.Sprintf("configuration key '%s' is deprecated: %s", key, f.Deprecated))
+		}
+	}
+	return nil
+}
+
+// suggestKey returns the known key closest to the given unknown key, or an
+// empty string if there is no sufficiently close key.
+func suggestKey(key string, known *flag.FlagSet) string {
+	var (
+		best     string
+		bestDist = maxSuggestionDistance + 1
+	)
+	known.VisitAll(func(f *flag.Flag) {
+		if f.Hidden || f.Deprecated != "" {
+			return
+		}
+		if d := editDistance(key, f.Name); d < bestDist || (d == bestDist && f.Name < best) {
+			best, bestDist = f.Name, d
+		}
+	})
+	if bestDist > maxSuggestionDistance {
+		return ""
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between the given strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
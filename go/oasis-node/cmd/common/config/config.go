@@ -0,0 +1,185 @@
+// Package config implements the typed node configuration and its validation.
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/ias"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
+)
+
+// Config is the typed node configuration.
+type Config struct {
+	// DataDir is the node data directory.
+	DataDir string
+	// DebugDontBlameOasis is true iff unsafe debug options are allowed.
+	DebugDontBlameOasis bool
+
+	// Runtime is the runtime configuration.
+	Runtime RuntimeConfig
+	// IAS is the IAS configuration.
+	IAS IASConfig
+}
+
+// RuntimeConfig is the runtime part of the node configuration.
+type RuntimeConfig struct {
+	// Mode is the runtime mode.
+	Mode runtimeRegistry.RuntimeMode
+	// Provisioner is the runtime provisioner.
+	Provisioner string
+	// Paths are the runtime resource paths keyed by runtime ID.
+	Paths map[string]string
+	// SandboxBinary is the path to the sandbox binary.
+	SandboxBinary string
+	// SGXLoader is the path to the SGX runtime loader.
+	SGXLoader string
+	// SGXSignatures are the SGX signature paths keyed by runtime ID.
+	SGXSignatures map[string]string
+}
+
+// IASConfig is the IAS part of the node configuration.
+type IASConfig struct {
+	// ProxyAddresses are the IAS proxy addresses.
+	ProxyAddresses []string
+}
+
+// Load loads the typed node configuration from the current configuration
+// sources (configuration file, command line flags and environment).
+func Load() (*Config, error) {
+	cfg := Config{
+		DataDir:             cmdCommon.DataDir(),
+		DebugDontBlameOasis: flags.DebugDontBlameOasis(),
+		Runtime: RuntimeConfig{
+			Provisioner:   viper.GetString(runtimeRegistry.CfgRuntimeProvisioner),
+			Paths:         viper.GetStringMapString(runtimeRegistry.CfgRuntimePaths),
+			SandboxBinary: viper.GetString(runtimeRegistry.CfgSandboxBinary),
+			SGXLoader:     viper.GetString(runtimeRegistry.CfgRuntimeSGXLoader),
+			SGXSignatures: viper.GetStringMapString(runtimeRegistry.CfgRuntimeSGXSignatures),
+		},
+		IAS: IASConfig{
+			ProxyAddresses: viper.GetStringSlice(ias.CfgProxyAddress),
+		},
+	}
+	if err := cfg.Runtime.Mode.UnmarshalText([]byte(viper.GetString(runtimeRegistry.CfgRuntimeMode))); err != nil {
+		return nil, fmt.Errorf("%s: %w", runtimeRegistry.CfgRuntimeMode, err)
+	}
+
+	return &cfg, nil
+}
+
+// Validate performs cross-field validation of the node configuration and
+// returns all of the discovered errors.
+func (cfg *Config) Validate() []error {
+	var errs []error
+	addErr := func(key, format string, a ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, a...)))
+	}
+
+	if cfg.DataDir == "" {
+		addErr(cmdCommon.CfgDataDir, "data directory must be set")
+	}
+
+	rt := &cfg.Runtime
+	switch rt.Mode {
+	case runtimeRegistry.RuntimeModeNone:
+		if len(rt.Paths) > 0 && !cfg.DebugDontBlameOasis {
+			addErr(runtimeRegistry.CfgRuntimePaths, "no runtimes should be configured when runtime mode is '%s'", rt.Mode)
+		}
+	default:
+		if len(rt.Paths) == 0 && !cfg.DebugDontBlameOasis {
+			addErr(runtimeRegistry.CfgRuntimePaths, "at least one runtime must be configured when runtime mode is '%s'", rt.Mode)
+		}
+	}
+
+	switch rt.Provisioner {
+	case runtimeRegistry.RuntimeProvisionerMock, runtimeRegistry.RuntimeProvisionerUnconfined:
+		if !cfg.DebugDontBlameOasis {
+			addErr(runtimeRegistry.CfgRuntimeProvisioner, "provisioner '%s' requires %s", rt.Provisioner, flags.CfgDebugDontBlameOasis)
+		}
+	case runtimeRegistry.RuntimeProvisionerSandboxed:
+		if len(rt.Paths) > 0 {
+			if err := checkFile(rt.SandboxBinary); err != nil {
+				addErr(runtimeRegistry.CfgSandboxBinary, "%s", err)
+			}
+		}
+	default:
+		addErr(runtimeRegistry.CfgRuntimeProvisioner, "unsupported provisioner '%s'", rt.Provisioner)
+	}
+
+	for _, id := range sortedKeys(rt.Paths) {
+		var ns common.Namespace
+		if err := ns.UnmarshalHex(id); err != nil {
+			addErr(runtimeRegistry.CfgRuntimePaths, "malformed runtime ID '%s': %s", id, err)
+			continue
+		}
+		// The mock provisioner does not use the runtime resources.
+		if rt.Provisioner == runtimeRegistry.RuntimeProvisionerMock {
+			continue
+		}
+		if err := checkFile(rt.Paths[id]); err != nil {
+			addErr(runtimeRegistry.CfgRuntimePaths, "runtime %s: %s", id, err)
+		}
+	}
+
+	// Validate TEE configuration.
+	if rt.SGXLoader != "" {
+		if rt.Provisioner == runtimeRegistry.RuntimeProvisionerMock {
+			addErr(runtimeRegistry.CfgRuntimeSGXLoader, "SGX is not supported by the '%s' provisioner", rt.Provisioner)
+		}
+		if err := checkFile(rt.SGXLoader); err != nil {
+			addErr(runtimeRegistry.CfgRuntimeSGXLoader, "%s", err)
+		}
+
+		switch rt.Mode {
+		case runtimeRegistry.RuntimeModeCompute, runtimeRegistry.RuntimeModeKeymanager:
+			if len(cfg.IAS.ProxyAddresses) == 0 && !cfg.DebugDontBlameOasis {
+				addErr(ias.CfgProxyAddress, "an IAS proxy must be configured when hosting SGX runtimes in runtime mode '%s'", rt.Mode)
+			}
+		default:
+		}
+	}
+	if len(rt.SGXSignatures) > 0 && rt.SGXLoader == "" {
+		addErr(runtimeRegistry.CfgRuntimeSGXSignatures, "SGX signatures are configured but %s is not set", runtimeRegistry.CfgRuntimeSGXLoader)
+	}
+	for _, id := range sortedKeys(rt.SGXSignatures) {
+		if _, ok := rt.Paths[id]; !ok {
+			addErr(runtimeRegistry.CfgRuntimeSGXSignatures, "runtime %s is not configured in %s", id, runtimeRegistry.CfgRuntimePaths)
+			continue
+		}
+		if err := checkFile(rt.SGXSignatures[id]); err != nil {
+			addErr(runtimeRegistry.CfgRuntimeSGXSignatures, "runtime %s: %s", id, err)
+		}
+	}
+
+	return errs
+}
+
+func checkFile(path string) error {
+	if path == "" {
+		return fmt.Errorf("path not set")
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	flag "github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+
+	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
+)
+
+const testRuntimeID = "8000000000000000000000000000000000000000000000000000000000000000"
+
+func TestCheckKeys(t *testing.T) {
+	require := require.New(t)
+
+	known := flag.NewFlagSet("", flag.ContinueOnError)
+	known.String("datadir", "", "")
+	known.String("runtime.mode", "", "")
+	known.StringToString(runtimeRegistry.CfgRuntimePaths, nil, "")
+	known.String("worker.old_option", "", "")
+	_ = known.MarkDeprecated("worker.old_option", "use worker.new_option instead")
+
+	cfgFile := filepath.Join(t.TempDir(), "config.yml")
+	err := os.WriteFile(cfgFile, []byte(`
+datadir: /node/data
+runtime:
+  mod: compute
+  paths:
+    `+testRuntimeID+`: /node/runtime
+  config:
+    `+testRuntimeID+`:
+      foo: bar
+log:
+  level:
+    default: info
+    tendermint: warn
+worker:
+  old_option: foo
+  unrelated: foo
+`), 0o600)
+	require.NoError(err, "WriteFile")
+
+	var r Result
+	err = r.checkKeys(cfgFile, known)
+	require.NoError(err, "checkKeys")
+	require.Len(r.Errors, 2, "unknown keys should be reported")
+	require.EqualError(r.Errors[0], "unknown configuration key 'runtime.mod' (did you mean 'runtime.mode'?)")
+	require.EqualError(r.Errors[1], "unknown configuration key 'worker.unrelated'")
+	require.Len(r.Warnings, 1, "deprecated keys should be reported")
+	require.Contains(r.Warnings[0], "use worker.new_option instead")
+	require.Error(r.Err(), "Err should return an error")
+
+	err = r.checkKeys(filepath.Join(t.TempDir(), "missing.yml"), known)
+	require.Error(err, "checkKeys should fail for missing configuration files")
+}
+
+func TestValidate(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	runtimePath := filepath.Join(dir, "runtime")
+	sandboxPath := filepath.Join(dir, "bwrap")
+	for _, fn := range []string{runtimePath, sandboxPath} {
+		require.NoError(os.WriteFile(fn, []byte{}, 0o600), "WriteFile")
+	}
+
+	newConfig := func() *Config {
+		return &Config{
+			DataDir: dir,
+			Runtime: RuntimeConfig{
+				Mode:          runtimeRegistry.RuntimeModeClient,
+				Provisioner:   runtimeRegistry.RuntimeProvisionerSandboxed,
+				Paths:         map[string]string{testRuntimeID: runtimePath},
+				SandboxBinary: sandboxPath,
+			},
+		}
+	}
+
+	cfg := newConfig()
+	require.Empty(cfg.Validate(), "valid configuration should pass")
+
+	cfg = newConfig()
+	cfg.DataDir = ""
+	require.Len(cfg.Validate(), 1, "missing data directory should fail")
+
+	cfg = newConfig()
+	cfg.Runtime.Mode = runtimeRegistry.RuntimeModeNone
+	require.Len(cfg.Validate(), 1, "runtimes in non-runtime mode should fail")
+
+	cfg = newConfig()
+	cfg.Runtime.Paths[testRuntimeID] = filepath.Join(dir, "missing")
+	require.Len(cfg.Validate(), 1, "missing runtime should fail")
+
+	cfg = newConfig()
+	cfg.Runtime.Paths = map[string]string{"invalid": runtimePath}
+	require.Len(cfg.Validate(), 1, "malformed runtime ID should fail")
+
+	cfg = newConfig()
+	cfg.Runtime.Provisioner = runtimeRegistry.RuntimeProvisionerMock
+	require.Len(cfg.Validate(), 1, "mock provisioner without debug flags should fail")
+	cfg.DebugDontBlameOasis = true
+	cfg.Runtime.Paths[testRuntimeID] = "mock-runtime"
+	require.Empty(cfg.Validate(), "mock provisioner with debug flags should pass")
+
+	cfg = newConfig()
+	cfg.Runtime.SGXSignatures = map[string]string{testRuntimeID: runtimePath}
+	require.Len(cfg.Validate(), 1, "SGX signatures without a loader should fail")
+
+	cfg = newConfig()
+	cfg.Runtime.Mode = runtimeRegistry.RuntimeModeCompute
+	cfg.Runtime.SGXLoader = sandboxPath
+	require.Len(cfg.Validate(), 1, "SGX loader without IAS proxy should fail")
+	cfg.IAS.ProxyAddresses = []string{"foo@127.0.0.1:8650"}
+	cfg.Runtime.SGXSignatures = map[string]string{
+		testRuntimeID: runtimePath,
+		"8000000000000000000000000000000000000000000000000000000000000001": runtimePath,
+	}
+	require.Len(cfg.Validate(), 1, "SGX signatures for unknown runtimes should fail")
+}
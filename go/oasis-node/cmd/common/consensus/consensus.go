@@ -15,6 +15,7 @@ import (
 	signerFile "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
 	signerPlugin "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/plugin"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	genesisAPI "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
@@ -33,6 +34,9 @@ const (
 	// CfgTxFeeGas configures the maximum gas limit.
 	CfgTxFeeGas = "transaction.fee.gas"
 
+	// CfgTxFeeTip configures the tip in base units.
+	CfgTxFeeTip = "transaction.fee.tip"
+
 	// CfgTxFile configures the filename for the transaction.
 	CfgTxFile = "transaction.file"
 
@@ -90,6 +94,15 @@ func GetTxNonceAndFee() (uint64, *transaction.Fee) {
 		os.Exit(1)
 	}
 	fee.Gas = transaction.Gas(viper.GetUint64(CfgTxFeeGas))
+	if tip := viper.GetString(CfgTxFeeTip); tip != "" && tip != "0" {
+		fee.Tip = quantity.NewQuantity()
+		if err := fee.Tip.UnmarshalText([]byte(tip)); err != nil {
+			logger.Error("failed to parse tip",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+	}
 	return nonce, &fee
 }
 
@@ -163,6 +176,7 @@ func init() {
 	TxFlags.Uint64(CfgTxNonce, 0, "nonce of the signing account")
 	TxFlags.Uint64(CfgTxFeeAmount, 0, "transaction fee in base units")
 	TxFlags.String(CfgTxFeeGas, "0", "maximum transaction gas limit")
+	TxFlags.String(CfgTxFeeTip, "0", "transaction tip in base units (paid to the block proposer)")
 	TxFlags.Bool(CfgTxUnsigned, false, "generate an unsigned transaction")
 	_ = viper.BindPFlags(TxFlags)
 	TxFlags.AddFlagSet(TxFileFlags)
@@ -0,0 +1,149 @@
+// Package gateway implements an HTTP/JSON gateway for the most commonly used
+// gRPC services of the node.
+package gateway
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/service"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	// CfgGatewayBind enables the HTTP/JSON gateway at the given address.
+	CfgGatewayBind = "grpc.gateway.bind"
+
+	readHeaderTimeout = 10 * time.Second
+)
+
+// Flags has the flags used by the gateway service.
+var Flags = flag.NewFlagSet("", flag.ContinueOnError)
+
+// backends are the gRPC service clients the gateway forwards requests to.
+type backends struct {
+	consensus     consensus.ClientBackend
+	staking       staking.Backend
+	registry      registry.Backend
+	governance    governance.Backend
+	runtimeClient runtimeClient.RuntimeClient
+}
+
+type gatewayService struct {
+	service.BaseBackgroundService
+
+	address     string
+	grpcAddress string
+
+	conn     *grpc.ClientConn
+	listener net.Listener
+	server   *http.Server
+
+	ctx   context.Context
+	errCh chan error
+}
+
+func (g *gatewayService) Start() error {
+	if g.address == "" {
+		return nil
+	}
+
+	g.Logger.Info("HTTP/JSON gateway is enabled",
+		"address", g.address,
+	)
+
+	conn, err := cmnGrpc.Dial(
+		g.grpcAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
+	)
+	if err != nil {
+		return err
+	}
+	g.conn = conn
+
+	listener, err := net.Listen("tcp", g.address)
+	if err != nil {
+		return err
+	}
+
+	g.listener = listener
+	g.server = &http.Server{
+		Handler: newHandler(&backends{
+			consensus:     consensus.NewConsensusClient(conn),
+			staking:       staking.NewStakingClient(conn),
+			registry:      registry.NewRegistryClient(conn),
+			governance:    governance.NewGovernanceClient(conn),
+			runtimeClient: runtimeClient.NewRuntimeClient(conn),
+		}, g.Logger),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go func() {
+		if err := g.server.Serve(g.listener); err != nil && err != http.ErrServerClosed {
+			g.BaseBackgroundService.Stop()
+			g.errCh <- err
+		}
+	}()
+
+	return nil
+}
+
+func (g *gatewayService) Stop() {
+	if g.server != nil {
+		select {
+		case err := <-g.errCh:
+			if err != nil {
+				g.Logger.Error("gateway server terminated uncleanly",
+					"err", err,
+				)
+			}
+		default:
+			_ = g.server.Shutdown(g.ctx)
+		}
+		g.server = nil
+	}
+}
+
+func (g *gatewayService) Cleanup() {
+	if g.listener != nil {
+		_ = g.listener.Close()
+		g.listener = nil
+	}
+	if g.conn != nil {
+		_ = g.conn.Close()
+		g.conn = nil
+	}
+}
+
+// New constructs a new gateway service which forwards requests to the gRPC
+// server at the given address.
+func New(ctx context.Context, grpcAddress string) (service.BackgroundService, error) {
+	address := viper.GetString(CfgGatewayBind)
+
+	return &gatewayService{
+		BaseBackgroundService: *service.NewBaseBackgroundService("gateway"),
+		address:               address,
+		grpcAddress:           grpcAddress,
+		ctx:                   ctx,
+		errCh:                 make(chan error),
+	}, nil
+}
+
+func init() {
+	Flags.String(CfgGatewayBind, "", "enable HTTP/JSON gateway at given address")
+
+	_ = viper.BindPFlags(Flags)
+}
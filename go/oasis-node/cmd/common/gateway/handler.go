@@ -0,0 +1,233 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+// maxRequestBodySize is the maximum size of a request body.
+const maxRequestBodySize = 4 * 1024 * 1024
+
+// errBadRequest is the error returned when a request is malformed.
+var errBadRequest = fmt.Errorf("gateway: bad request")
+
+// request is a parsed gateway request.
+type request struct {
+	// params are the path parameters.
+	params map[string]string
+	// height is the consensus height given via the height query parameter.
+	height int64
+	// body is the request body.
+	body []byte
+}
+
+// decodeBody decodes the JSON request body into v.
+func (r *request) decodeBody(v interface{}) error {
+	if err := json.Unmarshal(r.body, v); err != nil {
+		return fmt.Errorf("%w: malformed body: %s", errBadRequest, err)
+	}
+	return nil
+}
+
+// route is a mapping of an HTTP endpoint to a gRPC method.
+type route struct {
+	// method is the HTTP method.
+	method string
+	// path is the path pattern where path parameters are given in braces.
+	path string
+	// summary is a short description of the endpoint.
+	summary string
+	// height is true iff the endpoint supports the height query parameter.
+	height bool
+	// body is true iff the endpoint expects a JSON request body.
+	body bool
+
+	handle func(ctx context.Context, req *request) (interface{}, error)
+}
+
+// match checks whether the given path segments match the route and returns
+// the path parameters.
+func (rt *route) match(segments []string) (map[string]string, bool) {
+	pattern := splitPath(rt.path)
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, p := range pattern {
+		if name, ok := pathParam(p); ok {
+			params[name] = segments[i]
+			continue
+		}
+		if p != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func pathParam(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+// errorResponse is the body of a failed request.
+type errorResponse struct {
+	Module  string `json:"module,omitempty"`
+	Code    uint32 `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+type handler struct {
+	routes []*route
+	spec   []byte
+
+	logger *logging.Logger
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == openAPIPath {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(h.spec)
+		return
+	}
+
+	segments := splitPath(r.URL.Path)
+	var methodMismatch bool
+	for _, rt := range h.routes {
+		params, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		if rt.method != r.Method {
+			methodMismatch = true
+			continue
+		}
+
+		h.serveRoute(w, r, rt, params)
+		return
+	}
+
+	if methodMismatch {
+		h.writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("gateway: method not allowed"))
+		return
+	}
+	h.writeError(w, http.StatusNotFound, fmt.Errorf("gateway: not found"))
+}
+
+func (h *handler) serveRoute(w http.ResponseWriter, r *http.Request, rt *route, params map[string]string) {
+	req := &request{
+		params: params,
+		height: consensus.HeightLatest,
+	}
+	if rt.height {
+		if v := r.URL.Query().Get("height"); v != "" {
+			height, err := parseHeight(v)
+			if err != nil {
+				h.writeError(w, http.StatusBadRequest, err)
+				return
+			}
+			req.height = height
+		}
+	}
+	if rt.body {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, fmt.Errorf("%w: failed to read body: %s", errBadRequest, err))
+			return
+		}
+		req.body = body
+	}
+
+	rsp, err := rt.handle(r.Context(), req)
+	switch {
+	case err == nil:
+	case errors.Is(err, errBadRequest):
+		h.writeError(w, http.StatusBadRequest, err)
+		return
+	default:
+		h.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	data, err := json.Marshal(rsp)
+	if err != nil {
+		h.logger.Error("failed to marshal response",
+			"err", err,
+			"path", rt.path,
+		)
+		h.writeError(w, http.StatusInternalServerError, fmt.Errorf("gateway: failed to marshal response"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+func (h *handler) writeError(w http.ResponseWriter, status int, err error) {
+	rsp := errorResponse{
+		Message: err.Error(),
+	}
+	if module, code := errors.Code(err); module != errors.UnknownModule {
+		rsp.Module = module
+		rsp.Code = code
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(rsp)
+}
+
+func parseHeight(v string) (int64, error) {
+	if v == "latest" {
+		return consensus.HeightLatest, nil
+	}
+	height, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || height < 0 {
+		return 0, fmt.Errorf("%w: invalid height: %s", errBadRequest, v)
+	}
+	return height, nil
+}
+
+func parseRound(v string) (uint64, error) {
+	if v == "latest" {
+		return runtimeClient.RoundLatest, nil
+	}
+	round, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid round: %s", errBadRequest, v)
+	}
+	return round, nil
+}
+
+// unmarshalParam decodes the text form of the given path parameter into v.
+func unmarshalParam(req *request, name string, v interface{ UnmarshalText([]byte) error }) error {
+	if err := v.UnmarshalText([]byte(req.params[name])); err != nil {
+		return fmt.Errorf("%w: invalid %s: %s", errBadRequest, name, err)
+	}
+	return nil
+}
+
+func newHandler(b *backends, logger *logging.Logger) http.Handler {
+	routes := b.routes()
+	return &handler{
+		routes: routes,
+		spec:   newOpenAPISpec(routes),
+		logger: logger,
+	}
+}
@@ -0,0 +1,96 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+type testStakingBackend struct {
+	staking.Backend
+
+	query *staking.OwnerQuery
+}
+
+func (b *testStakingBackend) Account(ctx context.Context, query *staking.OwnerQuery) (*staking.Account, error) {
+	b.query = query
+	if query.Height == 666 {
+		return nil, staking.ErrInvalidArgument
+	}
+	return &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(42),
+		},
+	}, nil
+}
+
+func TestHandler(t *testing.T) {
+	require := require.New(t)
+
+	sb := &testStakingBackend{}
+	h := newHandler(&backends{staking: sb}, logging.GetLogger("gateway/test"))
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec
+	}
+
+	addr := staking.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	// Successful query.
+	rec := do(http.MethodGet, "/v1/staking/accounts/"+addr.String()+"?height=10")
+	require.Equal(http.StatusOK, rec.Code)
+	require.EqualValues(10, sb.query.Height, "height should be passed through")
+	require.Equal(addr, sb.query.Owner, "address should be passed through")
+	var acct staking.Account
+	require.NoError(json.Unmarshal(rec.Body.Bytes(), &acct))
+	require.EqualValues(*quantity.NewFromUint64(42), acct.General.Balance)
+
+	// Latest height.
+	rec = do(http.MethodGet, "/v1/staking/accounts/"+addr.String()+"?height=latest")
+	require.Equal(http.StatusOK, rec.Code)
+	require.EqualValues(0, sb.query.Height, "latest height should be passed through")
+
+	// Malformed parameters.
+	rec = do(http.MethodGet, "/v1/staking/accounts/"+addr.String()+"?height=foo")
+	require.Equal(http.StatusBadRequest, rec.Code)
+	rec = do(http.MethodGet, "/v1/staking/accounts/foo")
+	require.Equal(http.StatusBadRequest, rec.Code)
+
+	// Backend errors should include the module and code.
+	rec = do(http.MethodGet, "/v1/staking/accounts/"+addr.String()+"?height=666")
+	require.Equal(http.StatusInternalServerError, rec.Code)
+	var errRsp errorResponse
+	require.NoError(json.Unmarshal(rec.Body.Bytes(), &errRsp))
+	require.Equal(staking.ModuleName, errRsp.Module)
+	require.EqualValues(1, errRsp.Code)
+
+	// Unknown endpoints and methods.
+	rec = do(http.MethodGet, "/v1/staking/foo")
+	require.Equal(http.StatusNotFound, rec.Code)
+	rec = do(http.MethodPost, "/v1/staking/accounts/"+addr.String())
+	require.Equal(http.StatusMethodNotAllowed, rec.Code)
+
+	// OpenAPI description.
+	rec = do(http.MethodGet, openAPIPath)
+	require.Equal(http.StatusOK, rec.Code)
+	var doc openAPIDocument
+	require.NoError(json.Unmarshal(rec.Body.Bytes(), &doc))
+	op, ok := doc.Paths["/v1/staking/accounts/{address}"]["get"]
+	require.True(ok, "OpenAPI description should include the account endpoint")
+	require.Len(op.Parameters, 2, "account endpoint should have address and height parameters")
+	_, ok = doc.Paths["/v1/consensus/transactions"]["post"]
+	require.True(ok, "OpenAPI description should include the transaction submission endpoint")
+	require.True(strings.HasPrefix(doc.OpenAPI, "3."))
+}
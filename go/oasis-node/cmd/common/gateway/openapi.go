@@ -0,0 +1,110 @@
+package gateway
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+)
+
+// openAPIPath is the path at which the OpenAPI description of the gateway is served.
+const openAPIPath = "/v1/openapi.json"
+
+type openAPISchema struct {
+	Type string `json:"type"`
+}
+
+type openAPIParameter struct {
+	Name     string        `json:"name"`
+	In       string        `json:"in"`
+	Required bool          `json:"required"`
+	Schema   openAPISchema `json:"schema"`
+}
+
+type openAPIMediaType struct {
+	Schema openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIOperation struct {
+	Summary     string                     `json:"summary"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIDocument struct {
+	OpenAPI string                                 `json:"openapi"`
+	Info    openAPIInfo                            `json:"info"`
+	Paths   map[string]map[string]openAPIOperation `json:"paths"`
+}
+
+func newOpenAPISpec(routes []*route) []byte {
+	jsonContent := map[string]openAPIMediaType{
+		"application/json": {Schema: openAPISchema{Type: "object"}},
+	}
+
+	doc := openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info: openAPIInfo{
+			Title:   "Oasis Node HTTP/JSON Gateway",
+			Version: version.SoftwareVersion,
+		},
+		Paths: make(map[string]map[string]openAPIOperation),
+	}
+	for _, rt := range routes {
+		op := openAPIOperation{
+			Summary: rt.summary,
+			Responses: map[string]openAPIResponse{
+				"200":     {Description: "Successful response.", Content: jsonContent},
+				"default": {Description: "Error response.", Content: jsonContent},
+			},
+		}
+		for _, segment := range splitPath(rt.path) {
+			if name, ok := pathParam(segment); ok {
+				op.Parameters = append(op.Parameters, openAPIParameter{
+					Name:     name,
+					In:       "path",
+					Required: true,
+					Schema:   openAPISchema{Type: "string"},
+				})
+			}
+		}
+		if rt.height {
+			op.Parameters = append(op.Parameters, openAPIParameter{
+				Name:   "height",
+				In:     "query",
+				Schema: openAPISchema{Type: "string"},
+			})
+		}
+		if rt.body {
+			op.RequestBody = &openAPIRequestBody{
+				Required: true,
+				Content:  jsonContent,
+			}
+		}
+
+		if doc.Paths[rt.path] == nil {
+			doc.Paths[rt.path] = make(map[string]openAPIOperation)
+		}
+		doc.Paths[rt.path][strings.ToLower(rt.method)] = op
+	}
+
+	// Marshalling a document composed of plain structs and maps cannot fail.
+	spec, _ := json.Marshal(doc)
+	return spec
+}
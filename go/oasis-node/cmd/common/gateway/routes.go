@@ -0,0 +1,357 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// runtimeQueryRequest is the body of a runtime query request.
+type runtimeQueryRequest struct {
+	Round  *uint64 `json:"round,omitempty"`
+	Method string  `json:"method"`
+	Args   []byte  `json:"args"`
+}
+
+// runtimeSubmitTxRequest is the body of a runtime transaction submission request.
+type runtimeSubmitTxRequest struct {
+	Data []byte `json:"data"`
+}
+
+func (b *backends) routes() []*route {
+	return []*route{
+		// Consensus.
+		{
+			method:  http.MethodGet,
+			path:    "/v1/consensus/status",
+			summary: "Returns the current status overview.",
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				return b.consensus.GetStatus(ctx)
+			},
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/consensus/blocks/{height}",
+			summary: "Returns a consensus block at a specific height (or latest).",
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				height, err := parseHeight(req.params["height"])
+				if err != nil {
+					return nil, err
+				}
+				return b.consensus.GetBlock(ctx, height)
+			},
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/consensus/blocks/{height}/transactions",
+			summary: "Returns the consensus transactions and their results at a specific height (or latest).",
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				height, err := parseHeight(req.params["height"])
+				if err != nil {
+					return nil, err
+				}
+				return b.consensus.GetTransactionsWithResults(ctx, height)
+			},
+		},
+		{
+			method:  http.MethodPost,
+			path:    "/v1/consensus/transactions",
+			summary: "Submits a signed consensus transaction and waits for it to be included in a block.",
+			body:    true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				var tx transaction.SignedTransaction
+				if err := req.decodeBody(&tx); err != nil {
+					return nil, err
+				}
+				if err := b.consensus.SubmitTx(ctx, &tx); err != nil {
+					return nil, err
+				}
+				return struct{}{}, nil
+			},
+		},
+
+		// Staking.
+		{
+			method:  http.MethodGet,
+			path:    "/v1/staking/total_supply",
+			summary: "Returns the total supply in base units.",
+			height:  true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				return b.staking.TotalSupply(ctx, req.height)
+			},
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/staking/accounts/{address}",
+			summary: "Returns the staking account descriptor.",
+			height:  true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				query, err := ownerQuery(req)
+				if err != nil {
+					return nil, err
+				}
+				return b.staking.Account(ctx, query)
+			},
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/staking/accounts/{address}/delegations",
+			summary: "Returns the outgoing delegations of the account.",
+			height:  true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				query, err := ownerQuery(req)
+				if err != nil {
+					return nil, err
+				}
+				return b.staking.DelegationInfosFor(ctx, query)
+			},
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/staking/accounts/{address}/debonding_delegations",
+			summary: "Returns the outgoing debonding delegations of the account.",
+			height:  true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				query, err := ownerQuery(req)
+				if err != nil {
+					return nil, err
+				}
+				return b.staking.DebondingDelegationInfosFor(ctx, query)
+			},
+		},
+
+		// Registry.
+		{
+			method:  http.MethodGet,
+			path:    "/v1/registry/entities",
+			summary: "Returns all registered entities.",
+			height:  true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				return b.registry.GetEntities(ctx, req.height)
+			},
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/registry/entities/{id}",
+			summary: "Returns a registered entity.",
+			height:  true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				query, err := idQuery(req)
+				if err != nil {
+					return nil, err
+				}
+				return b.registry.GetEntity(ctx, query)
+			},
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/registry/nodes",
+			summary: "Returns all registered nodes.",
+			height:  true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				return b.registry.GetNodes(ctx, req.height)
+			},
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/registry/nodes/{id}",
+			summary: "Returns a registered node.",
+			height:  true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				query, err := idQuery(req)
+				if err != nil {
+					return nil, err
+				}
+				return b.registry.GetNode(ctx, query)
+			},
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/registry/runtimes",
+			summary: "Returns all registered runtimes.",
+			height:  true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				return b.registry.GetRuntimes(ctx, &registry.GetRuntimesQuery{Height: req.height})
+			},
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/registry/runtimes/{runtime_id}",
+			summary: "Returns a registered runtime.",
+			height:  true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				var id common.Namespace
+				if err := unmarshalParam(req, "runtime_id", &id); err != nil {
+					return nil, err
+				}
+				return b.registry.GetRuntime(ctx, &registry.NamespaceQuery{Height: req.height, ID: id})
+			},
+		},
+
+		// Governance.
+		{
+			method:  http.MethodGet,
+			path:    "/v1/governance/proposals",
+			summary: "Returns all governance proposals.",
+			height:  true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				return b.governance.Proposals(ctx, req.height)
+			},
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/governance/proposals/{id}",
+			summary: "Returns a governance proposal.",
+			height:  true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				query, err := proposalQuery(req)
+				if err != nil {
+					return nil, err
+				}
+				return b.governance.Proposal(ctx, query)
+			},
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/governance/proposals/{id}/votes",
+			summary: "Returns the votes cast for a governance proposal.",
+			height:  true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				query, err := proposalQuery(req)
+				if err != nil {
+					return nil, err
+				}
+				return b.governance.Votes(ctx, query)
+			},
+		},
+
+		// Runtime client.
+		{
+			method:  http.MethodGet,
+			path:    "/v1/runtimes/{runtime_id}/blocks/{round}",
+			summary: "Returns a runtime block at a specific round (or latest).",
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				id, round, err := runtimeRound(req)
+				if err != nil {
+					return nil, err
+				}
+				return b.runtimeClient.GetBlock(ctx, &runtimeClient.GetBlockRequest{RuntimeID: id, Round: round})
+			},
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/runtimes/{runtime_id}/blocks/{round}/transactions",
+			summary: "Returns the runtime transactions and their results at a specific round (or latest).",
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				id, round, err := runtimeRound(req)
+				if err != nil {
+					return nil, err
+				}
+				return b.runtimeClient.GetTransactionsWithResults(ctx, &runtimeClient.GetTransactionsRequest{RuntimeID: id, Round: round})
+			},
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/runtimes/{runtime_id}/blocks/{round}/events",
+			summary: "Returns the runtime events emitted at a specific round (or latest).",
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				id, round, err := runtimeRound(req)
+				if err != nil {
+					return nil, err
+				}
+				return b.runtimeClient.GetEvents(ctx, &runtimeClient.GetEventsRequest{RuntimeID: id, Round: round})
+			},
+		},
+		{
+			method:  http.MethodPost,
+			path:    "/v1/runtimes/{runtime_id}/query",
+			summary: "Performs a runtime-specific read-only query (the round defaults to latest).",
+			body:    true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				var id common.Namespace
+				if err := unmarshalParam(req, "runtime_id", &id); err != nil {
+					return nil, err
+				}
+				var q runtimeQueryRequest
+				if err := req.decodeBody(&q); err != nil {
+					return nil, err
+				}
+				round := runtimeClient.RoundLatest
+				if q.Round != nil {
+					round = *q.Round
+				}
+				return b.runtimeClient.Query(ctx, &runtimeClient.QueryRequest{
+					RuntimeID: id,
+					Round:     round,
+					Method:    q.Method,
+					Args:      q.Args,
+				})
+			},
+		},
+		{
+			method:  http.MethodPost,
+			path:    "/v1/runtimes/{runtime_id}/transactions",
+			summary: "Submits a runtime transaction and waits for its result.",
+			body:    true,
+			handle: func(ctx context.Context, req *request) (interface{}, error) {
+				var id common.Namespace
+				if err := unmarshalParam(req, "runtime_id", &id); err != nil {
+					return nil, err
+				}
+				var tx runtimeSubmitTxRequest
+				if err := req.decodeBody(&tx); err != nil {
+					return nil, err
+				}
+				return b.runtimeClient.SubmitTxMeta(ctx, &runtimeClient.SubmitTxRequest{
+					RuntimeID: id,
+					Data:      tx.Data,
+				})
+			},
+		},
+	}
+}
+
+func ownerQuery(req *request) (*staking.OwnerQuery, error) {
+	var addr staking.Address
+	if err := unmarshalParam(req, "address", &addr); err != nil {
+		return nil, err
+	}
+	return &staking.OwnerQuery{Height: req.height, Owner: addr}, nil
+}
+
+func idQuery(req *request) (*registry.IDQuery, error) {
+	var id signature.PublicKey
+	if err := unmarshalParam(req, "id", &id); err != nil {
+		return nil, err
+	}
+	return &registry.IDQuery{Height: req.height, ID: id}, nil
+}
+
+func proposalQuery(req *request) (*governance.ProposalQuery, error) {
+	id, err := strconv.ParseUint(req.params["id"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid proposal id: %s", errBadRequest, req.params["id"])
+	}
+	return &governance.ProposalQuery{Height: req.height, ProposalID: id}, nil
+}
+
+func runtimeRound(req *request) (common.Namespace, uint64, error) {
+	var id common.Namespace
+	if err := unmarshalParam(req, "runtime_id", &id); err != nil {
+		return id, 0, err
+	}
+	round, err := parseRound(req.params["round"])
+	if err != nil {
+		return id, 0, err
+	}
+	return id, round, nil
+}
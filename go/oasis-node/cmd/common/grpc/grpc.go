@@ -70,14 +70,9 @@ func NewServerTCP(cert *tls.Certificate, installWrapper bool) (*cmnGrpc.Server,
 // This internally takes a snapshot of the current global tracer, so
 // make sure you initialize the global tracer before calling this.
 func NewServerLocal(installWrapper bool) (*cmnGrpc.Server, error) {
-	dataDir := common.DataDir()
-	if dataDir == "" {
-		return nil, errors.New("data directory must be set")
-	}
-	path := filepath.Join(dataDir, LocalSocketFilename)
-	if viper.IsSet(CfgDebugGrpcInternalSocketPath) && flags.DebugDontBlameOasis() {
-		logger.Info("overriding internal socket path", "path", viper.GetString(CfgDebugGrpcInternalSocketPath))
-		path = viper.GetString(CfgDebugGrpcInternalSocketPath)
+	path, err := LocalSocketPath()
+	if err != nil {
+		return nil, err
 	}
 
 	config := &cmnGrpc.ServerConfig{
@@ -89,6 +84,21 @@ func NewServerLocal(installWrapper bool) (*cmnGrpc.Server, error) {
 	return cmnGrpc.NewServer(config)
 }
 
+// LocalSocketPath returns the path of the AF_LOCAL socket the internal gRPC
+// server listens on.
+func LocalSocketPath() (string, error) {
+	dataDir := common.DataDir()
+	if dataDir == "" {
+		return "", errors.New("data directory must be set")
+	}
+	path := filepath.Join(dataDir, LocalSocketFilename)
+	if viper.IsSet(CfgDebugGrpcInternalSocketPath) && flags.DebugDontBlameOasis() {
+		logger.Info("overriding internal socket path", "path", viper.GetString(CfgDebugGrpcInternalSocketPath))
+		path = viper.GetString(CfgDebugGrpcInternalSocketPath)
+	}
+	return path, nil
+}
+
 func NewClient(cmd *cobra.Command) (*grpc.ClientConn, error) {
 	addr, _ := cmd.Flags().GetString(CfgAddress)
 
@@ -0,0 +1,47 @@
+package signer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ReadPassphrase reads a passphrase from the given environment variable or file descriptor. If
+// neither is configured and prompt is non-empty, the passphrase is read from the terminal.
+//
+// An empty passphrase is returned in case no passphrase source is configured.
+func ReadPassphrase(envVar string, fd int, prompt string) ([]byte, error) {
+	switch {
+	case envVar != "":
+		passphrase, ok := os.LookupEnv(envVar)
+		if !ok {
+			return nil, fmt.Errorf("passphrase environment variable %s not set", envVar)
+		}
+		return []byte(passphrase), nil
+	case fd >= 0:
+		f := os.NewFile(uintptr(fd), "passphrase")
+		if f == nil {
+			return nil, fmt.Errorf("invalid passphrase file descriptor: %d", fd)
+		}
+		defer f.Close()
+
+		line, err := bufio.NewReader(f).ReadBytes('\n')
+		if err != nil && len(line) == 0 {
+			return nil, fmt.Errorf("failed to read passphrase from file descriptor %d: %w", fd, err)
+		}
+		return bytes.TrimRight(line, "\r\n"), nil
+	case prompt != "":
+		fmt.Fprint(os.Stderr, prompt)
+		passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passphrase from terminal: %w", err)
+		}
+		return passphrase, nil
+	default:
+		return nil, nil
+	}
+}
@@ -30,6 +30,16 @@ const (
 	// It also contains the private keys of a signer if using a file backend.
 	CfgCLISignerDir = "signer.dir"
 
+	// CfgSignerFilePassphraseEnv is the flag used to specify the name of the environment
+	// variable containing the file signer passphrase.
+	CfgSignerFilePassphraseEnv = "signer.file.passphrase_env"
+	// CfgSignerFilePassphraseFD is the flag used to specify the file descriptor from which the
+	// file signer passphrase is read.
+	CfgSignerFilePassphraseFD = "signer.file.passphrase_fd"
+	// CfgSignerFilePassphrasePrompt is the flag used to specify whether the file signer
+	// passphrase should be read from the terminal.
+	CfgSignerFilePassphrasePrompt = "signer.file.passphrase_prompt"
+
 	cfgSignerRemoteAddress    = "signer.remote.address"
 	cfgSignerRemoteClientCert = "signer.remote.client.certificate"
 	cfgSignerRemoteClientKey  = "signer.remote.client.key"
@@ -72,6 +82,20 @@ func CLIDirOrPwd() (string, error) {
 	return signerDir, nil
 }
 
+// FilePassphrase returns the configured file signer passphrase. An empty passphrase is returned
+// in case no passphrase is configured.
+func FilePassphrase() ([]byte, error) {
+	var prompt string
+	if viper.GetBool(CfgSignerFilePassphrasePrompt) {
+		prompt = "Signer passphrase: "
+	}
+	return ReadPassphrase(
+		viper.GetString(CfgSignerFilePassphraseEnv),
+		viper.GetInt(CfgSignerFilePassphraseFD),
+		prompt,
+	)
+}
+
 // NewFactory returns the appropriate SignerFactory based on flags.
 func NewFactory(signerBackend, signerDir string, roles ...signature.SignerRole) (signature.SignerFactory, error) {
 	signerBackend = strings.ToLower(signerBackend)
@@ -88,7 +112,15 @@ func NewFactory(signerBackend, signerDir string, roles ...signature.SignerRole)
 func doNewFactory(signerBackend, signerDir string, roles ...signature.SignerRole) (signature.SignerFactory, error) {
 	switch signerBackend {
 	case fileSigner.SignerName:
-		return fileSigner.NewFactory(signerDir, roles...)
+		passphrase, err := FilePassphrase()
+		if err != nil {
+			return nil, err
+		}
+		config := &fileSigner.FactoryConfig{
+			DataDir:    signerDir,
+			Passphrase: passphrase,
+		}
+		return fileSigner.NewFactory(config, roles...)
 	case memorySigner.SignerName:
 		if !testingAllowMemory {
 			return nil, fmt.Errorf("memory signer backend is only for testing")
@@ -177,6 +209,9 @@ func doNewComposite(signerDir string, roles ...signature.SignerRole) (signature.
 
 func init() {
 	Flags.StringP(CfgSigner, "s", "file", "signer backend [file, plugin, remote, composite]")
+	Flags.String(CfgSignerFilePassphraseEnv, "", "name of the environment variable containing the file signer private key passphrase")
+	Flags.Int(CfgSignerFilePassphraseFD, -1, "file descriptor from which to read the file signer private key passphrase")
+	Flags.Bool(CfgSignerFilePassphrasePrompt, false, "read the file signer private key passphrase from the terminal")
 	Flags.String(cfgSignerRemoteAddress, "", "remote signer server address")
 	Flags.String(cfgSignerRemoteClientCert, "", "remote signer client certificate path")
 	Flags.String(cfgSignerRemoteClientKey, "", "remote signer client certificate key path")
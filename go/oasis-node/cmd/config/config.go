@@ -0,0 +1,51 @@
+// Package config implements the config sub-commands.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdConfig "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/config"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/node"
+)
+
+var (
+	configCmd = &cobra.Command{
+		Use:   "config",
+		Short: "node configuration utilities",
+	}
+
+	configCheckCmd = &cobra.Command{
+		Use:   "check",
+		Short: "check the node configuration, exit with 0 if it is valid, 1 if not",
+		Run:   doCheck,
+	}
+)
+
+func doCheck(cmd *cobra.Command, args []string) {
+	result := cmdConfig.Check(cmdCommon.ConfigFile(), node.KnownFlags())
+	for _, warning := range result.Warnings {
+		fmt.Printf("warning: %s\n", warning)
+	}
+	for _, err := range result.Errors {
+		fmt.Printf("error: %s\n", err)
+	}
+	if len(result.Errors) > 0 {
+		os.Exit(1)
+	}
+
+	fmt.Println("configuration is valid")
+}
+
+// Register registers the config sub-command and all of its children.
+func Register(parentCmd *cobra.Command) {
+	// Allow the same flags as the node so that the full configuration
+	// (including command line overrides) can be checked.
+	configCheckCmd.Flags().AddFlagSet(node.Flags)
+
+	configCmd.AddCommand(configCheckCmd)
+	parentCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,75 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+)
+
+var (
+	controlAddrBookCmd = &cobra.Command{
+		Use:   "address-book",
+		Short: "inspect or clear the consensus P2P address book",
+	}
+
+	controlAddrBookShowCmd = &cobra.Command{
+		Use:   "show",
+		Short: "show the consensus P2P address book",
+		Args:  cobra.NoArgs,
+		Run:   doAddrBookShow,
+	}
+
+	controlAddrBookClearCmd = &cobra.Command{
+		Use:   "clear",
+		Short: "remove all learned addresses from the consensus P2P address book",
+		Args:  cobra.NoArgs,
+		Run:   doAddrBookClear,
+	}
+)
+
+func doAddrBookShow(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	logger.Debug("querying address book")
+
+	book, err := client.GetAddressBook(context.Background())
+	if err != nil {
+		logger.Error("failed to query address book",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	prettyBook, err := cmdCommon.PrettyJSONMarshal(book)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of address book",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyBook))
+}
+
+func doAddrBookClear(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	logger.Debug("clearing address book")
+
+	if err := client.ClearAddressBook(context.Background()); err != nil {
+		logger.Error("failed to clear address book",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func registerAddrBookCmd(parentCmd *cobra.Command) {
+	controlAddrBookCmd.AddCommand(controlAddrBookShowCmd)
+	controlAddrBookCmd.AddCommand(controlAddrBookClearCmd)
+	parentCmd.AddCommand(controlAddrBookCmd)
+}
@@ -63,6 +63,12 @@ var (
 		Run:   doStatus,
 	}
 
+	controlCheckInvariantsCmd = &cobra.Command{
+		Use:   "check-invariants",
+		Short: "check consensus state invariants, exit with 0 if they hold, 1 if not",
+		Run:   doCheckInvariants,
+	}
+
 	logger = logging.GetLogger("cmd/control")
 )
 
@@ -230,6 +236,33 @@ func doStatus(cmd *cobra.Command, args []string) {
 	fmt.Println(string(prettyStatus))
 }
 
+func doCheckInvariants(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	logger.Debug("checking consensus state invariants")
+
+	report, err := client.CheckConsensusInvariants(context.Background())
+	if err != nil {
+		logger.Error("failed to check consensus state invariants",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+	prettyReport, err := cmdCommon.PrettyJSONMarshal(report)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of invariants report",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyReport))
+
+	if !report.IsValid() {
+		os.Exit(1)
+	}
+}
+
 // Register registers the client sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	controlCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
@@ -242,5 +275,11 @@ func Register(parentCmd *cobra.Command) {
 	controlCmd.AddCommand(controlUpgradeBinaryCmd)
 	controlCmd.AddCommand(controlCancelUpgradeCmd)
 	controlCmd.AddCommand(controlStatusCmd)
+	controlCmd.AddCommand(controlCheckInvariantsCmd)
+	registerPruneCmd(controlCmd)
+	registerAddrBookCmd(controlCmd)
+	registerTxPoolCmd(controlCmd)
+	controlCmd.AddCommand(controlExecutionReportsCmd)
+	registerRuntimeCmd(controlCmd)
 	parentCmd.AddCommand(controlCmd)
 }
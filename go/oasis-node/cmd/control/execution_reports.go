@@ -0,0 +1,58 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+)
+
+var controlExecutionReportsCmd = &cobra.Command{
+	Use:   "execution-reports <runtime-id> [limit]",
+	Short: "show recent per-round execution reports of the executor worker",
+	Args:  cobra.RangeArgs(1, 2),
+	Run:   doExecutionReports,
+}
+
+func doExecutionReports(cmd *cobra.Command, args []string) {
+	req := control.GetExecutionReportsRequest{
+		RuntimeID: parseRuntimeID(args[0]),
+	}
+	if len(args) > 1 {
+		limit, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			logger.Error("malformed limit",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		req.Limit = limit
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	logger.Debug("querying execution reports")
+
+	reports, err := client.GetExecutionReports(context.Background(), &req)
+	if err != nil {
+		logger.Error("failed to query execution reports",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	pretty, err := cmdCommon.PrettyJSONMarshal(reports)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of execution reports",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(pretty))
+}
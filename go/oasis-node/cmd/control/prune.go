@@ -0,0 +1,192 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+)
+
+// pruneWaitInterval is the interval between prune job status queries when waiting for a prune
+// job to finish.
+const pruneWaitInterval = time.Second
+
+var (
+	pruneWait = false
+
+	controlPruneCmd = &cobra.Command{
+		Use:   "prune",
+		Short: "prune consensus or runtime data on demand",
+	}
+
+	controlPruneConsensusCmd = &cobra.Command{
+		Use:   "consensus <height>",
+		Short: "prune all consensus state and blocks below the given height",
+		Args:  cobra.ExactArgs(1),
+		Run:   doPruneConsensus,
+	}
+
+	controlPruneRuntimeCmd = &cobra.Command{
+		Use:   "runtime <runtime-id> <round>",
+		Short: "prune all runtime history and storage below the given round",
+		Args:  cobra.ExactArgs(2),
+		Run:   doPruneRuntime,
+	}
+
+	controlPruneStatusCmd = &cobra.Command{
+		Use:   "status [<job-id>]",
+		Short: "show the status of prune jobs",
+		Args:  cobra.MaximumNArgs(1),
+		Run:   doPruneStatus,
+	}
+)
+
+func printPruneJobs(v interface{}) {
+	pretty, err := cmdCommon.PrettyJSONMarshal(v)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of prune job status",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(pretty))
+}
+
+func waitPruneJob(client control.NodeController, job *control.PruneJob) *control.PruneJob {
+	for !job.IsDone() {
+		time.Sleep(pruneWaitInterval)
+
+		var err error
+		if job, err = client.GetPruneJob(context.Background(), job.ID); err != nil {
+			logger.Error("failed to query prune job status",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		logger.Info("pruning",
+			"id", job.ID,
+			"target", job.Target,
+			"last_pruned", job.LastPruned,
+		)
+	}
+	return job
+}
+
+func handlePruneJob(client control.NodeController, job *control.PruneJob, err error) {
+	if err != nil {
+		logger.Error("failed to start prune job",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if pruneWait {
+		job = waitPruneJob(client, job)
+	}
+	printPruneJobs(job)
+	if job.State == control.PruneJobFailed {
+		os.Exit(1)
+	}
+}
+
+func doPruneConsensus(cmd *cobra.Command, args []string) {
+	height, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		logger.Error("malformed height",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	job, err := client.PruneConsensus(context.Background(), height)
+	handlePruneJob(client, job, err)
+}
+
+func doPruneRuntime(cmd *cobra.Command, args []string) {
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(args[0]); err != nil {
+		logger.Error("malformed runtime ID",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	round, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		logger.Error("malformed round",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	job, err := client.PruneRuntime(context.Background(), &control.PruneRuntimeRequest{
+		RuntimeID: runtimeID,
+		Round:     round,
+	})
+	handlePruneJob(client, job, err)
+}
+
+func doPruneStatus(cmd *cobra.Command, args []string) {
+	var (
+		id  uint64
+		err error
+	)
+	if len(args) > 0 {
+		if id, err = strconv.ParseUint(args[0], 10, 64); err != nil {
+			logger.Error("malformed job ID",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	if len(args) == 0 {
+		var jobs []*control.PruneJob
+		if jobs, err = client.GetPruneJobs(context.Background()); err != nil {
+			logger.Error("failed to query prune jobs",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		printPruneJobs(jobs)
+		return
+	}
+
+	job, err := client.GetPruneJob(context.Background(), id)
+	if err != nil {
+		logger.Error("failed to query prune job status",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if pruneWait {
+		job = waitPruneJob(client, job)
+	}
+	printPruneJobs(job)
+}
+
+func registerPruneCmd(parentCmd *cobra.Command) {
+	for _, cmd := range []*cobra.Command{
+		controlPruneConsensusCmd,
+		controlPruneRuntimeCmd,
+		controlPruneStatusCmd,
+	} {
+		cmd.Flags().BoolVarP(&pruneWait, "wait", "w", false, "wait for the prune job to finish")
+		controlPruneCmd.AddCommand(cmd)
+	}
+	parentCmd.AddCommand(controlPruneCmd)
+}
@@ -0,0 +1,101 @@
+package control
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
+)
+
+var (
+	runtimeSGXSignature string
+
+	controlRuntimeCmd = &cobra.Command{
+		Use:   "runtime",
+		Short: "manage runtimes hosted by a running node",
+	}
+
+	controlRuntimeAddCmd = &cobra.Command{
+		Use:   "add <runtime-id> <path>",
+		Short: "start hosting a runtime",
+		Args:  cobra.ExactArgs(2),
+		Run:   doRuntimeAdd,
+	}
+
+	controlRuntimeRemoveCmd = &cobra.Command{
+		Use:   "remove <runtime-id>",
+		Short: "stop hosting a runtime",
+		Args:  cobra.ExactArgs(1),
+		Run:   doRuntimeRemove,
+	}
+)
+
+func doRuntimeAdd(cmd *cobra.Command, args []string) {
+	runtimeID := parseRuntimeID(args[0])
+	// The paths are resolved by the node, so make them absolute.
+	path, err := filepath.Abs(args[1])
+	if err != nil {
+		logger.Error("malformed runtime path",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	var sgxSignature string
+	if runtimeSGXSignature != "" {
+		if sgxSignature, err = filepath.Abs(runtimeSGXSignature); err != nil {
+			logger.Error("malformed SGX signature path",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	logger.Debug("adding runtime",
+		"runtime_id", runtimeID,
+		"path", path,
+	)
+
+	err = client.AddRuntime(context.Background(), &control.AddRuntimeRequest{
+		RuntimeID:    runtimeID,
+		Path:         path,
+		SGXSignature: sgxSignature,
+	})
+	if err != nil {
+		logger.Error("failed to add runtime",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func doRuntimeRemove(cmd *cobra.Command, args []string) {
+	runtimeID := parseRuntimeID(args[0])
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	logger.Debug("removing runtime",
+		"runtime_id", runtimeID,
+	)
+
+	if err := client.RemoveRuntime(context.Background(), runtimeID); err != nil {
+		logger.Error("failed to remove runtime",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func registerRuntimeCmd(parentCmd *cobra.Command) {
+	controlRuntimeAddCmd.Flags().StringVar(&runtimeSGXSignature, "sgx-signature", "", "path to the runtime's SGX enclave signature")
+
+	controlRuntimeCmd.AddCommand(controlRuntimeAddCmd)
+	controlRuntimeCmd.AddCommand(controlRuntimeRemoveCmd)
+	parentCmd.AddCommand(controlRuntimeCmd)
+}
@@ -0,0 +1,126 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
+)
+
+var (
+	controlTxPoolCmd = &cobra.Command{
+		Use:   "tx-pool",
+		Short: "inspect or modify the runtime transaction pool",
+	}
+
+	controlTxPoolListCmd = &cobra.Command{
+		Use:   "list <runtime-id>",
+		Short: "list transactions in the runtime transaction pool",
+		Args:  cobra.ExactArgs(1),
+		Run:   doTxPoolList,
+	}
+
+	controlTxPoolRemoveCmd = &cobra.Command{
+		Use:   "remove <runtime-id> <tx-hash>",
+		Short: "remove a transaction from the runtime transaction pool",
+		Args:  cobra.ExactArgs(2),
+		Run:   doTxPoolRemove,
+	}
+)
+
+type txPoolEntry struct {
+	*txpool.TransactionInfo
+
+	// Age is the time since the transaction has been received.
+	Age string `json:"age,omitempty"`
+}
+
+func parseRuntimeID(raw string) common.Namespace {
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(raw); err != nil {
+		logger.Error("malformed runtime ID",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	return runtimeID
+}
+
+func doTxPoolList(cmd *cobra.Command, args []string) {
+	runtimeID := parseRuntimeID(args[0])
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	logger.Debug("querying transaction pool")
+
+	txs, err := client.GetTxPoolTransactions(context.Background(), runtimeID)
+	if err != nil {
+		logger.Error("failed to query transaction pool",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	now := time.Now()
+	entries := make([]*txPoolEntry, 0, len(txs))
+	for _, tx := range txs {
+		entry := &txPoolEntry{TransactionInfo: tx}
+		if !tx.ReceivedAt.IsZero() {
+			entry.Age = now.Sub(tx.ReceivedAt).Round(time.Second).String()
+		}
+		entries = append(entries, entry)
+	}
+
+	pretty, err := cmdCommon.PrettyJSONMarshal(entries)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of transaction pool",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(pretty))
+}
+
+func doTxPoolRemove(cmd *cobra.Command, args []string) {
+	runtimeID := parseRuntimeID(args[0])
+	var txHash hash.Hash
+	if err := txHash.UnmarshalHex(args[1]); err != nil {
+		logger.Error("malformed transaction hash",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	logger.Debug("removing transaction from transaction pool",
+		"tx_hash", txHash,
+	)
+
+	err := client.RemoveTxPoolTransaction(context.Background(), &control.RemoveTxPoolTransactionRequest{
+		RuntimeID: runtimeID,
+		Hash:      txHash,
+	})
+	if err != nil {
+		logger.Error("failed to remove transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func registerTxPoolCmd(parentCmd *cobra.Command) {
+	controlTxPoolCmd.AddCommand(controlTxPoolListCmd)
+	controlTxPoolCmd.AddCommand(controlTxPoolRemoveCmd)
+	parentCmd.AddCommand(controlTxPoolCmd)
+}
@@ -2,7 +2,6 @@ package byzantine
 
 import (
 	"context"
-	"encoding/binary"
 	"fmt"
 	"strings"
 
@@ -20,7 +19,6 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/metrics"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
-	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/p2p"
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
@@ -42,6 +40,9 @@ const (
 	// CfgExecutorProposeBogusTx configures whether the executor in scheduler role should propose
 	// transactions that nobody else has.
 	CfgExecutorProposeBogusTx = "executor.propose_bogus_tx"
+	// CfgExecutorScript configures the byzantine executor script which selects the executor
+	// behavior in each round. If set, it takes precedence over the executor mode.
+	CfgExecutorScript = "executor.script"
 	// CfgVRFBeaconMode configures the byzantine VRF beacon mode.
 	CfgVRFBeaconMode = "vrf_beacon_mode"
 
@@ -138,6 +139,15 @@ func doExecutorScenario(cmd *cobra.Command, args []string) { //nolint: gocyclo
 		panic(err)
 	}
 
+	// Parse executor script, if configured.
+	var script ExecutorScript
+	if s := viper.GetString(CfgExecutorScript); s != "" {
+		var err error
+		if script, err = ParseExecutorScript(s); err != nil {
+			panic(fmt.Errorf("failed to parse executor script: %w", err))
+		}
+	}
+
 	isTxScheduler := viper.GetBool(CfgSchedulerRoleExpected)
 	b, err := initializeAndRegisterByzantineNode(
 		runtimeID,
@@ -153,6 +163,14 @@ func doExecutorScenario(cmd *cobra.Command, args []string) { //nolint: gocyclo
 		_ = b.stop()
 	}()
 
+	if script != nil {
+		if err = b.runExecutorScript(ctx, script); err != nil {
+			panic(fmt.Sprintf("executor script failed: %+v", err))
+		}
+		logger.Debug("executor script: completed")
+		return
+	}
+
 	if executorMode == ModeExecutorStraggler {
 		logger.Debug("executor straggler: stopping")
 		return
@@ -172,7 +190,7 @@ func doExecutorScenario(cmd *cobra.Command, args []string) { //nolint: gocyclo
 		}
 	case false:
 		// If we are not the scheduler, receive transactions and the proposal.
-		if err = cbc.receiveProposal(b.p2p); err != nil {
+		if err = cbc.receiveProposal(b.p2p, 0); err != nil {
 			panic(fmt.Sprintf("compute receive proposal failed: %+v", err))
 		}
 		logger.Debug("executor: received proposal", "proposal", cbc.proposal)
@@ -190,55 +208,10 @@ func doExecutorScenario(cmd *cobra.Command, args []string) { //nolint: gocyclo
 	}
 	defer cbc.closeTrees()
 
-	// Update current epoch to mimic the test key-value runtime.
-	var encodedEpoch [8]byte
-	binary.BigEndian.PutUint64(encodedEpoch[:], uint64(b.executorCommittee.ValidFor))
-
-	if err = cbc.stateTree.Insert(ctx, []byte{0x02}, encodedEpoch[:]); err != nil {
-		panic(fmt.Sprintf("compute state tree set failed: %+v", err))
-	}
-
 	switch executorMode {
-	case ModeExecutorHonest:
-		// Process transaction honestly.
-		switch len(cbc.txs) {
-		case 0:
-			// No transactions, don't modify anything else.
-		case 1:
-			// A single transaction, simulate the key-value runtime.
-			if err = cbc.stateTree.Insert(ctx, []byte("hello_key"), []byte("hello_value")); err != nil {
-				panic(fmt.Sprintf("compute state tree set failed: %+v", err))
-			}
-			if err = cbc.addResultSuccess(ctx, cbc.txs[0], nil, transaction.Tags{
-				transaction.Tag{Key: []byte("kv_op"), Value: []byte("insert")},
-				transaction.Tag{Key: []byte("kv_key"), Value: []byte("hello_key")},
-			}); err != nil {
-				panic(fmt.Sprintf("compute add result success failed: %+v", err))
-			}
-		default:
-			// Unsupported condition.
-			panic(fmt.Sprintf("unsupported number of transactions: %d", len(cbc.txs)))
-		}
-	case ModeExecutorWrong:
-		// Alter the state incorrectly.
-		if err = cbc.stateTree.Insert(ctx, []byte("hello_key"), []byte("wrong")); err != nil {
-			panic(fmt.Sprintf("compute state tree set failed: %+v", err))
-		}
-
-		switch len(cbc.txs) {
-		case 0:
-			// No transactions.
-		case 1:
-			// A single transaction.
-			if err = cbc.addResultSuccess(ctx, cbc.txs[0], nil, transaction.Tags{
-				transaction.Tag{Key: []byte("kv_op"), Value: []byte("insert")},
-				transaction.Tag{Key: []byte("kv_key"), Value: []byte("hello_key")},
-			}); err != nil {
-				panic(fmt.Sprintf("compute add result success failed: %+v", err))
-			}
-		default:
-			// Unsupported condition.
-			panic(fmt.Sprintf("unsupported number of transactions: %d", len(cbc.txs)))
+	case ModeExecutorHonest, ModeExecutorWrong:
+		if err = cbc.executeBatch(ctx, b, executorMode == ModeExecutorWrong); err != nil {
+			panic(fmt.Sprintf("compute execute batch failed: %+v", err))
 		}
 	case ModeExecutorFailureIndicating:
 		// No need to process anything as we'll submit a failure indicating commitment anyway.
@@ -292,6 +265,7 @@ func init() {
 	fs.Bool(CfgSchedulerRoleExpected, false, "is executor node expected to be scheduler or not")
 	fs.String(CfgExecutorMode, ModeExecutorHonest.String(), "configures executor mode")
 	fs.Bool(CfgExecutorProposeBogusTx, false, "whether the executor should propose bogus transactions")
+	fs.String(CfgExecutorScript, "", "configures executor script (e.g., 'honest; wrong*2; late:5s; withhold; equivocate')")
 	fs.String(CfgVRFBeaconMode, ModeVRFBeaconHonest.String(), "configures VRF beacon mode")
 	_ = viper.BindPFlags(fs)
 	byzantineCmd.PersistentFlags().AddFlagSet(fs)
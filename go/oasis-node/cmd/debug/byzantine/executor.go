@@ -154,7 +154,9 @@ func (cbc *computeBatchContext) prepareProposal(
 	return nil
 }
 
-func (cbc *computeBatchContext) receiveProposal(ph *p2pHandle) error {
+// receiveProposal receives a proposal and all of its transactions. In case
+// round is non-zero, proposals for other rounds are ignored.
+func (cbc *computeBatchContext) receiveProposal(ph *p2pHandle, round uint64) error {
 	var proposal *commitment.Proposal
 	existing := make(map[hash.Hash][]byte)
 	missing := make(map[hash.Hash]bool)
@@ -183,6 +185,9 @@ ReceiveProposal:
 			if msg.Proposal == nil {
 				continue
 			}
+			if round != 0 && msg.Proposal.Header.Round != round {
+				continue
+			}
 			if proposal != nil {
 				return fmt.Errorf("received multiple proposals while only expecting one")
 			}
@@ -0,0 +1,340 @@
+package byzantine
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+)
+
+// LogEventExecutorScriptStepCompleted is the event emitted when the byzantine
+// executor completes a single executor script step.
+const LogEventExecutorScriptStepCompleted = "byzantine/executor/script_step_completed"
+
+// ExecutorActionKind is the kind of action performed by the byzantine executor
+// in a single round.
+type ExecutorActionKind uint8
+
+// Executor action kinds.
+const (
+	// ExecutorActionHonest processes the batch honestly.
+	ExecutorActionHonest ExecutorActionKind = iota
+	// ExecutorActionWrong submits a commitment with an incorrect state root.
+	ExecutorActionWrong
+	// ExecutorActionWithhold processes the batch but never submits a commitment.
+	ExecutorActionWithhold
+	// ExecutorActionLate submits an honest commitment after a delay.
+	ExecutorActionLate
+	// ExecutorActionEquivocate submits two conflicting commitments for the
+	// same round.
+	ExecutorActionEquivocate
+	// ExecutorActionFailure submits a failure indicating commitment.
+	ExecutorActionFailure
+
+	executorActionHonestString     = "honest"
+	executorActionWrongString      = "wrong"
+	executorActionWithholdString   = "withhold"
+	executorActionLateString       = "late"
+	executorActionEquivocateString = "equivocate"
+	executorActionFailureString    = "failure"
+
+	// scriptRepeatLimit is the maximum number of repetitions of a single step.
+	scriptRepeatLimit = 1000
+)
+
+// String returns a string representation of an executor action kind.
+func (k ExecutorActionKind) String() string {
+	switch k {
+	case ExecutorActionHonest:
+		return executorActionHonestString
+	case ExecutorActionWrong:
+		return executorActionWrongString
+	case ExecutorActionWithhold:
+		return executorActionWithholdString
+	case ExecutorActionLate:
+		return executorActionLateString
+	case ExecutorActionEquivocate:
+		return executorActionEquivocateString
+	case ExecutorActionFailure:
+		return executorActionFailureString
+	default:
+		return "[unsupported executor action]"
+	}
+}
+
+// ExecutorAction is a single step of an executor script.
+type ExecutorAction struct {
+	// Kind is the kind of the action.
+	Kind ExecutorActionKind
+	// Delay is the delay before submitting the commitment (only for late
+	// commitments).
+	Delay time.Duration
+}
+
+// String returns a string representation of an executor action.
+func (a ExecutorAction) String() string {
+	if a.Kind == ExecutorActionLate {
+		return fmt.Sprintf("%s:%s", a.Kind, a.Delay)
+	}
+	return a.Kind.String()
+}
+
+// ExecutorScript is a sequence of per-round executor actions.
+type ExecutorScript []ExecutorAction
+
+// ParseExecutorScript parses an executor script.
+//
+// A script is a list of steps separated by semicolons or newlines, where each
+// step configures the behavior of the executor in one round. Each step is one
+// of:
+//
+//   - honest
+//   - wrong
+//   - withhold
+//   - late:<duration> (e.g., late:5s)
+//   - equivocate
+//   - failure
+//
+// and can optionally be followed by *N to repeat it N times. Everything
+// following a # until the end of the line is treated as a comment.
+func ParseExecutorScript(s string) (ExecutorScript, error) {
+	var script ExecutorScript
+	for _, line := range strings.Split(s, "\n") {
+		if idx := strings.IndexByte(line, '#'); idx >= 0 {
+			line = line[:idx]
+		}
+		for _, step := range strings.Split(line, ";") {
+			step = strings.TrimSpace(step)
+			if step == "" {
+				continue
+			}
+
+			repeat := 1
+			if idx := strings.LastIndexByte(step, '*'); idx >= 0 {
+				n, err := strconv.Atoi(strings.TrimSpace(step[idx+1:]))
+				if err != nil || n < 1 || n > scriptRepeatLimit {
+					return nil, fmt.Errorf("invalid repeat count in step '%s'", step)
+				}
+				repeat = n
+				step = strings.TrimSpace(step[:idx])
+			}
+
+			action, err := parseExecutorAction(step)
+			if err != nil {
+				return nil, err
+			}
+			for i := 0; i < repeat; i++ {
+				script = append(script, action)
+			}
+		}
+	}
+	if len(script) == 0 {
+		return nil, fmt.Errorf("executor script is empty")
+	}
+	return script, nil
+}
+
+func parseExecutorAction(step string) (ExecutorAction, error) {
+	name, arg := step, ""
+	if idx := strings.IndexByte(step, ':'); idx >= 0 {
+		name, arg = strings.TrimSpace(step[:idx]), strings.TrimSpace(step[idx+1:])
+	}
+
+	var action ExecutorAction
+	switch strings.ToLower(name) {
+	case executorActionHonestString:
+		action.Kind = ExecutorActionHonest
+	case executorActionWrongString:
+		action.Kind = ExecutorActionWrong
+	case executorActionWithholdString:
+		action.Kind = ExecutorActionWithhold
+	case executorActionLateString:
+		action.Kind = ExecutorActionLate
+		if arg == "" {
+			return action, fmt.Errorf("missing delay in step '%s'", step)
+		}
+		delay, err := time.ParseDuration(arg)
+		if err != nil || delay <= 0 {
+			return action, fmt.Errorf("invalid delay in step '%s'", step)
+		}
+		action.Delay = delay
+		return action, nil
+	case executorActionEquivocateString:
+		action.Kind = ExecutorActionEquivocate
+	case executorActionFailureString:
+		action.Kind = ExecutorActionFailure
+	default:
+		return action, fmt.Errorf("invalid executor action: '%s'", name)
+	}
+	if arg != "" {
+		return action, fmt.Errorf("unexpected argument in step '%s'", step)
+	}
+	return action, nil
+}
+
+// executeBatch executes the batch of the current proposal, simulating the
+// test key-value runtime. In case wrong is set, the state is altered
+// incorrectly.
+func (cbc *computeBatchContext) executeBatch(ctx context.Context, b *byzantine, wrong bool) error {
+	// Update current epoch to mimic the test key-value runtime.
+	var encodedEpoch [8]byte
+	binary.BigEndian.PutUint64(encodedEpoch[:], uint64(b.executorCommittee.ValidFor))
+
+	if err := cbc.stateTree.Insert(ctx, []byte{0x02}, encodedEpoch[:]); err != nil {
+		return fmt.Errorf("state tree set failed: %w", err)
+	}
+
+	value := []byte("hello_value")
+	if wrong {
+		// Alter the state incorrectly.
+		value = []byte("wrong")
+		if err := cbc.stateTree.Insert(ctx, []byte("hello_key"), value); err != nil {
+			return fmt.Errorf("state tree set failed: %w", err)
+		}
+	}
+
+	switch len(cbc.txs) {
+	case 0:
+		// No transactions, don't modify anything else.
+	case 1:
+		// A single transaction, simulate the key-value runtime.
+		if !wrong {
+			if err := cbc.stateTree.Insert(ctx, []byte("hello_key"), value); err != nil {
+				return fmt.Errorf("state tree set failed: %w", err)
+			}
+		}
+		if err := cbc.addResultSuccess(ctx, cbc.txs[0], nil, transaction.Tags{
+			transaction.Tag{Key: []byte("kv_op"), Value: []byte("insert")},
+			transaction.Tag{Key: []byte("kv_key"), Value: []byte("hello_key")},
+		}); err != nil {
+			return fmt.Errorf("add result success failed: %w", err)
+		}
+	default:
+		// Unsupported condition.
+		return fmt.Errorf("unsupported number of transactions: %d", len(cbc.txs))
+	}
+	return nil
+}
+
+// runExecutorScript runs the given executor script, performing one script
+// step in each round.
+func (b *byzantine) runExecutorScript(ctx context.Context, script ExecutorScript) error {
+	blocksCh, blocksSub, err := b.tendermint.service.RootHash().WatchBlocks(ctx, b.runtimeID)
+	if err != nil {
+		return fmt.Errorf("failed to watch blocks: %w", err)
+	}
+	defer blocksSub.Close()
+
+	for step, action := range script {
+		var blk *block.Block
+		blk, err = getRoothashLatestBlock(ctx, b.tendermint.service, b.runtimeID)
+		if err != nil {
+			return fmt.Errorf("failed getting latest roothash block: %w", err)
+		}
+
+		if err = b.runExecutorScriptStep(ctx, blk, action); err != nil {
+			return fmt.Errorf("step %d (%s) failed: %w", step, action, err)
+		}
+		logger.Debug("executor script: step completed",
+			"step", step,
+			"action", action,
+			"round", blk.Header.Round+1,
+			"event", LogEventExecutorScriptStepCompleted,
+		)
+
+		// Wait for the round to be finalized (or to fail) before proceeding.
+		if step == len(script)-1 {
+			break
+		}
+	WaitRound:
+		for {
+			select {
+			case annBlk, ok := <-blocksCh:
+				if !ok {
+					return fmt.Errorf("block subscription closed")
+				}
+				if annBlk.Block.Header.Round > blk.Header.Round {
+					break WaitRound
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return nil
+}
+
+func (b *byzantine) runExecutorScriptStep(ctx context.Context, blk *block.Block, action ExecutorAction) error {
+	cbc := newComputeBatchContext(b.runtimeID)
+
+	if schedulerCheckTxScheduler(b.executorCommittee, b.identity.NodeSigner.Public(), blk.Header.Round) {
+		// We are the transaction scheduler for this round, schedule transactions honestly.
+		if _, err := b.receiveAndScheduleTransactions(ctx, cbc, ModeExecutorHonest); err != nil {
+			return fmt.Errorf("transaction scheduling failed: %w", err)
+		}
+	} else {
+		if err := cbc.receiveProposal(b.p2p, blk.Header.Round+1); err != nil {
+			return fmt.Errorf("receive proposal failed: %w", err)
+		}
+		logger.Debug("executor: received proposal", "proposal", cbc.proposal)
+	}
+
+	if err := cbc.openTrees(ctx, blk, b.storageClients[0]); err != nil {
+		return fmt.Errorf("open trees failed: %w", err)
+	}
+	defer cbc.closeTrees()
+
+	if err := cbc.executeBatch(ctx, b, action.Kind == ExecutorActionWrong); err != nil {
+		return err
+	}
+	if err := cbc.commitTrees(ctx); err != nil {
+		return fmt.Errorf("commit trees failed: %w", err)
+	}
+	logger.Debug("executor: committed storage trees",
+		"new_io_root", cbc.newIORoot,
+		"new_state_root", cbc.newStateRoot,
+		"action", action,
+	)
+
+	failure := commitment.FailureNone
+	switch action.Kind {
+	case ExecutorActionWithhold:
+		logger.Debug("executor: withholding commitment")
+		return nil
+	case ExecutorActionLate:
+		logger.Debug("executor: delaying commitment", "delay", action.Delay)
+		select {
+		case <-time.After(action.Delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	case ExecutorActionFailure:
+		failure = commitment.FailureUnknown
+	case ExecutorActionEquivocate:
+		// Submit an honest commitment first, followed by a conflicting one below.
+		if err := cbc.createCommitment(b.identity, b.rak, commitment.FailureNone); err != nil {
+			return fmt.Errorf("create commitment failed: %w", err)
+		}
+		if err := cbc.publishToChain(b.tendermint.service, b.identity); err != nil {
+			return fmt.Errorf("publish to chain failed: %w", err)
+		}
+		cbc.newStateRoot = hash.NewFromBytes(cbc.newStateRoot[:], []byte("equivocate"))
+	}
+
+	if err := cbc.createCommitment(b.identity, b.rak, failure); err != nil {
+		return fmt.Errorf("create commitment failed: %w", err)
+	}
+	if err := cbc.publishToChain(b.tendermint.service, b.identity); err != nil {
+		return fmt.Errorf("publish to chain failed: %w", err)
+	}
+	logger.Debug("executor: commitment sent")
+
+	return nil
+}
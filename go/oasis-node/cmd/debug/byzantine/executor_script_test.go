@@ -0,0 +1,45 @@
+package byzantine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExecutorScript(t *testing.T) {
+	require := require.New(t)
+
+	script, err := ParseExecutorScript(`
+		honest*2; wrong # Trigger a discrepancy.
+		withhold
+		late:2s; equivocate; FAILURE
+	`)
+	require.NoError(err, "ParseExecutorScript")
+	require.Equal(ExecutorScript{
+		{Kind: ExecutorActionHonest},
+		{Kind: ExecutorActionHonest},
+		{Kind: ExecutorActionWrong},
+		{Kind: ExecutorActionWithhold},
+		{Kind: ExecutorActionLate, Delay: 2 * time.Second},
+		{Kind: ExecutorActionEquivocate},
+		{Kind: ExecutorActionFailure},
+	}, script)
+	require.Equal("late:2s", script[4].String())
+
+	for _, s := range []string{
+		"",
+		"# only a comment",
+		"dishonest",
+		"late",
+		"late:forever",
+		"late:-1s",
+		"wrong:1s",
+		"honest*0",
+		"honest*many",
+		"honest*1000000",
+	} {
+		_, err = ParseExecutorScript(s)
+		require.Error(err, "ParseExecutorScript(%q) should fail", s)
+	}
+}
@@ -98,7 +98,7 @@ func (ph *p2pHandle) start(ht *honestTendermint, id *identity.Identity, runtimeI
 
 	ph.context, ph.cancel = context.WithCancel(context.Background())
 	var err error
-	ph.service, err = p2p.New(ph.context, id, ht.service)
+	ph.service, err = p2p.New(ph.context, id, ht.service, nil)
 	if err != nil {
 		return fmt.Errorf("P2P service New: %w", err)
 	}
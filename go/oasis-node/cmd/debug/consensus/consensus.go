@@ -0,0 +1,436 @@
+// Package consensus implements the consensus debug sub-commands.
+package consensus
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/abci"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	_ "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/beacon/state"
+	_ "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/state"
+	_ "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/keymanager/state"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/state"
+	_ "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	cfgDumpStateHeight     = "dump_state.height"
+	cfgDumpStateModule     = "dump_state.module"
+	cfgDumpStateKeyPrefix  = "dump_state.key_prefix"
+	cfgDumpStateOutput     = "dump_state.output"
+	cfgDumpStateReadOnlyDB = "dump_state.read_only_db"
+
+	moduleStaking  = "staking"
+	moduleRegistry = "registry"
+	moduleRootHash = "roothash"
+)
+
+var (
+	consensusCmd = &cobra.Command{
+		Use:   "consensus",
+		Short: "consensus debug utilities",
+	}
+
+	dumpStateCmd = &cobra.Command{
+		Use:   "dump-state",
+		Short: "dump decoded on-disk consensus state at a given height as JSON",
+		Run:   doDumpState,
+	}
+
+	dumpStateFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/consensus")
+)
+
+type stateDump struct {
+	Height int64 `json:"height"`
+
+	Staking  *stakingDump  `json:"staking,omitempty"`
+	Registry *registryDump `json:"registry,omitempty"`
+	RootHash *roothashDump `json:"roothash,omitempty"`
+
+	// Modules contains raw state entries of modules without a decoded dump.
+	Modules map[string][]*rawEntry `json:"modules,omitempty"`
+
+	Raw []*rawEntry `json:"raw,omitempty"`
+}
+
+type stakingDump struct {
+	TotalSupply        *quantity.Quantity `json:"total_supply"`
+	CommonPool         *quantity.Quantity `json:"common_pool"`
+	LastBlockFees      *quantity.Quantity `json:"last_block_fees"`
+	GovernanceDeposits *quantity.Quantity `json:"governance_deposits"`
+
+	Accounts             map[staking.Address]*staking.Account                                   `json:"accounts"`
+	Delegations          map[staking.Address]map[staking.Address]*staking.Delegation            `json:"delegations,omitempty"`
+	DebondingDelegations map[staking.Address]map[staking.Address][]*staking.DebondingDelegation `json:"debonding_delegations,omitempty"`
+}
+
+type registryDump struct {
+	Entities     []*entity.Entity                             `json:"entities"`
+	Nodes        []*node.Node                                 `json:"nodes"`
+	NodeStatuses map[signature.PublicKey]*registry.NodeStatus `json:"node_statuses,omitempty"`
+	Runtimes     []*registry.Runtime                          `json:"runtimes"`
+	Suspended    []*registry.Runtime                          `json:"suspended_runtimes,omitempty"`
+}
+
+type roothashDump struct {
+	RuntimeStates    map[common.Namespace]*roothash.RuntimeState `json:"runtime_states"`
+	LastRoundResults map[common.Namespace]*roothash.RoundResults `json:"last_round_results,omitempty"`
+}
+
+type rawEntry struct {
+	Key     string      `json:"key"`
+	Value   string      `json:"value"`
+	Decoded interface{} `json:"decoded,omitempty"`
+}
+
+func doDumpState(cmd *cobra.Command, args []string) {
+	var ok bool
+	defer func() {
+		if !ok {
+			os.Exit(1)
+		}
+	}()
+
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		logger.Error("data directory must be set")
+		return
+	}
+
+	modules, err := selectedModules()
+	if err != nil {
+		logger.Error("invalid module selection",
+			"err", err,
+		)
+		return
+	}
+	var keyPrefix []byte
+	if s := viper.GetString(cfgDumpStateKeyPrefix); s != "" {
+		if keyPrefix, err = hex.DecodeString(s); err != nil {
+			logger.Error("malformed key prefix",
+				"err", err,
+			)
+			return
+		}
+	}
+
+	// Open the ABCI state storage for access.
+	ctx := context.Background()
+	qs, err := abci.OpenOfflineState(ctx, dataDir, viper.GetInt64(cfgDumpStateHeight), viper.GetBool(cfgDumpStateReadOnlyDB))
+	if err != nil {
+		logger.Error("failed to open consensus state",
+			"err", err,
+		)
+		return
+	}
+	defer qs.Storage().Cleanup()
+
+	height := qs.BlockHeight()
+	dump := &stateDump{
+		Height: height,
+	}
+	for _, module := range modules {
+		switch module {
+		case moduleStaking:
+			dump.Staking, err = dumpStaking(ctx, qs)
+		case moduleRegistry:
+			dump.Registry, err = dumpRegistry(ctx, qs)
+		case moduleRootHash:
+			dump.RootHash, err = dumpRootHash(ctx, qs)
+		default:
+			var entries []*rawEntry
+			if entries, err = dumpModuleRaw(ctx, qs, module); err == nil {
+				if dump.Modules == nil {
+					dump.Modules = make(map[string][]*rawEntry)
+				}
+				dump.Modules[module] = entries
+			}
+		}
+		if err != nil {
+			logger.Error("failed to dump module state",
+				"err", err,
+				"module", module,
+			)
+			return
+		}
+	}
+	if keyPrefix != nil {
+		if dump.Raw, err = dumpRaw(ctx, qs, keyPrefix); err != nil {
+			logger.Error("failed to dump raw state",
+				"err", err,
+				"key_prefix", hex.EncodeToString(keyPrefix),
+			)
+			return
+		}
+	}
+
+	// Write out the dump.
+	w, shouldClose, err := cmdCommon.GetOutputWriter(cmd, cfgDumpStateOutput)
+	if err != nil {
+		logger.Error("failed to get output writer for state dump",
+			"err", err,
+		)
+		return
+	}
+	if shouldClose {
+		defer w.Close()
+	}
+	prettyDump, err := cmdCommon.PrettyJSONMarshal(dump)
+	if err != nil {
+		logger.Error("failed to marshal state dump into JSON",
+			"err", err,
+		)
+		return
+	}
+	if _, err := w.Write(prettyDump); err != nil {
+		logger.Error("failed to write state dump",
+			"err", err,
+		)
+		return
+	}
+
+	ok = true
+}
+
+// selectedModules returns the list of modules that should be dumped. In case neither a module
+// nor a key prefix has been selected, all modules with registered state keys are dumped.
+//
+// Modules other than staking, registry and roothash do not have a decoded dump and are dumped
+// as raw state entries.
+func selectedModules() ([]string, error) {
+	allModules := abciAPI.StateModules()
+	module := viper.GetString(cfgDumpStateModule)
+	if module == "" {
+		if viper.GetString(cfgDumpStateKeyPrefix) != "" {
+			return nil, nil
+		}
+		return allModules, nil
+	}
+	for _, m := range allModules {
+		if m == module {
+			return []string{module}, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported module '%s' (supported: %s)", module, strings.Join(allModules, ", "))
+}
+
+func dumpStaking(ctx context.Context, qs abciAPI.ApplicationQueryState) (*stakingDump, error) {
+	st, err := stakingState.NewImmutableState(ctx, qs, qs.BlockHeight())
+	if err != nil {
+		return nil, fmt.Errorf("consensus: failed to get staking state: %w", err)
+	}
+
+	var dump stakingDump
+	if dump.TotalSupply, err = st.TotalSupply(ctx); err != nil {
+		return nil, fmt.Errorf("consensus: failed to get total supply: %w", err)
+	}
+	if dump.CommonPool, err = st.CommonPool(ctx); err != nil {
+		return nil, fmt.Errorf("consensus: failed to get common pool: %w", err)
+	}
+	if dump.LastBlockFees, err = st.LastBlockFees(ctx); err != nil {
+		return nil, fmt.Errorf("consensus: failed to get last block fees: %w", err)
+	}
+	if dump.GovernanceDeposits, err = st.GovernanceDeposits(ctx); err != nil {
+		return nil, fmt.Errorf("consensus: failed to get governance deposits: %w", err)
+	}
+
+	addresses, err := st.Addresses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("consensus: failed to get account addresses: %w", err)
+	}
+	dump.Accounts = make(map[staking.Address]*staking.Account, len(addresses))
+	for _, addr := range addresses {
+		if dump.Accounts[addr], err = st.Account(ctx, addr); err != nil {
+			return nil, fmt.Errorf("consensus: failed to get account %s: %w", addr, err)
+		}
+	}
+
+	if dump.Delegations, err = st.Delegations(ctx); err != nil {
+		return nil, fmt.Errorf("consensus: failed to get delegations: %w", err)
+	}
+	if dump.DebondingDelegations, err = st.DebondingDelegations(ctx); err != nil {
+		return nil, fmt.Errorf("consensus: failed to get debonding delegations: %w", err)
+	}
+	return &dump, nil
+}
+
+func dumpRegistry(ctx context.Context, qs abciAPI.ApplicationQueryState) (*registryDump, error) {
+	st, err := registryState.NewImmutableState(ctx, qs, qs.BlockHeight())
+	if err != nil {
+		return nil, fmt.Errorf("consensus: failed to get registry state: %w", err)
+	}
+
+	var dump registryDump
+	if dump.Entities, err = st.Entities(ctx); err != nil {
+		return nil, fmt.Errorf("consensus: failed to get entities: %w", err)
+	}
+	if dump.Nodes, err = st.Nodes(ctx); err != nil {
+		return nil, fmt.Errorf("consensus: failed to get nodes: %w", err)
+	}
+	dump.NodeStatuses = make(map[signature.PublicKey]*registry.NodeStatus, len(dump.Nodes))
+	for _, n := range dump.Nodes {
+		if dump.NodeStatuses[n.ID], err = st.NodeStatus(ctx, n.ID); err != nil {
+			return nil, fmt.Errorf("consensus: failed to get status of node %s: %w", n.ID, err)
+		}
+	}
+	if dump.Runtimes, err = st.Runtimes(ctx); err != nil {
+		return nil, fmt.Errorf("consensus: failed to get runtimes: %w", err)
+	}
+	if dump.Suspended, err = st.SuspendedRuntimes(ctx); err != nil {
+		return nil, fmt.Errorf("consensus: failed to get suspended runtimes: %w", err)
+	}
+	return &dump, nil
+}
+
+func dumpRootHash(ctx context.Context, qs abciAPI.ApplicationQueryState) (*roothashDump, error) {
+	st, err := roothashState.NewImmutableState(ctx, qs, qs.BlockHeight())
+	if err != nil {
+		return nil, fmt.Errorf("consensus: failed to get roothash state: %w", err)
+	}
+
+	runtimes, err := st.Runtimes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("consensus: failed to get runtime states: %w", err)
+	}
+	dump := roothashDump{
+		RuntimeStates:    make(map[common.Namespace]*roothash.RuntimeState, len(runtimes)),
+		LastRoundResults: make(map[common.Namespace]*roothash.RoundResults, len(runtimes)),
+	}
+	for _, rs := range runtimes {
+		id := rs.Runtime.ID
+		dump.RuntimeStates[id] = rs
+		if dump.LastRoundResults[id], err = st.LastRoundResults(ctx, id); err != nil {
+			return nil, fmt.Errorf("consensus: failed to get last round results of runtime %s: %w", id, err)
+		}
+	}
+	return &dump, nil
+}
+
+func dumpRaw(ctx context.Context, qs abciAPI.ApplicationQueryState, prefix []byte) ([]*rawEntry, error) {
+	st, err := abciAPI.NewImmutableState(ctx, qs, qs.BlockHeight())
+	if err != nil {
+		return nil, fmt.Errorf("consensus: failed to get state: %w", err)
+	}
+	defer st.Close()
+
+	it := st.NewIterator(ctx)
+	defer it.Close()
+
+	var entries []*rawEntry
+	for it.Seek(prefix); it.Valid(); it.Next() {
+		if !bytes.HasPrefix(it.Key(), prefix) {
+			break
+		}
+		entries = append(entries, newRawEntry(it.Key(), it.Value()))
+	}
+	if it.Err() != nil {
+		return nil, fmt.Errorf("consensus: failed to iterate over state: %w", it.Err())
+	}
+	return entries, nil
+}
+
+func dumpModuleRaw(ctx context.Context, qs abciAPI.ApplicationQueryState, module string) ([]*rawEntry, error) {
+	st, err := abciAPI.NewImmutableState(ctx, qs, qs.BlockHeight())
+	if err != nil {
+		return nil, fmt.Errorf("consensus: failed to get state: %w", err)
+	}
+	defer st.Close()
+
+	var entries []*rawEntry
+	err = st.IterateModule(ctx, module, func(key, value []byte) error {
+		entries = append(entries, newRawEntry(key, value))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("consensus: failed to iterate over %s state: %w", module, err)
+	}
+	return entries, nil
+}
+
+func newRawEntry(key, value []byte) *rawEntry {
+	entry := &rawEntry{
+		Key:   hex.EncodeToString(key),
+		Value: hex.EncodeToString(value),
+	}
+	// Most values are CBOR-encoded, so attempt to decode them generically.
+	var decoded interface{}
+	if err := cbor.Unmarshal(value, &decoded); err == nil {
+		entry.Decoded = jsonCompatible(decoded)
+	}
+	return entry
+}
+
+// jsonCompatible converts a generically decoded CBOR value into a form that can be serialized
+// as JSON (e.g., maps with non-string keys).
+func jsonCompatible(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, mv := range v {
+			var ks string
+			switch k := k.(type) {
+			case []byte:
+				ks = hex.EncodeToString(k)
+			default:
+				ks = fmt.Sprintf("%v", k)
+			}
+			m[ks] = jsonCompatible(mv)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, sv := range v {
+			s[i] = jsonCompatible(sv)
+		}
+		return s
+	case []byte:
+		return hex.EncodeToString(v)
+	default:
+		return v
+	}
+}
+
+// Register registers the consensus sub-commands.
+func Register(parentCmd *cobra.Command) {
+	dumpStateCmd.Flags().AddFlagSet(dumpStateFlags)
+
+	consensusCmd.AddCommand(dumpStateCmd)
+	parentCmd.AddCommand(consensusCmd)
+}
+
+func init() {
+	dumpStateFlags.Int64(cfgDumpStateHeight, 0, "consensus height to dump the state at (0 = most recent)")
+	dumpStateFlags.String(cfgDumpStateModule, "", fmt.Sprintf("only dump state of the given module (%s)", strings.Join(abciAPI.StateModules(), ", ")))
+	dumpStateFlags.String(cfgDumpStateKeyPrefix, "", "also dump raw state entries under the given hex-encoded key prefix")
+	dumpStateFlags.String(cfgDumpStateOutput, "", "path to output file (default: stdout)")
+	dumpStateFlags.Bool(cfgDumpStateReadOnlyDB, false, "read-only DB access")
+	_ = viper.BindPFlags(dumpStateFlags)
+}
@@ -0,0 +1,155 @@
+package consensus
+
+import (
+	"context"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/abci"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	tendermintCommon "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/common"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	storageDB "github.com/oasisprotocol/oasis-core/go/storage/database"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+)
+
+var testRawKey = []byte{0xfe, 0x01}
+
+// createTestState creates a consensus state with some staking state and a raw entry at height 1
+// and opens it for offline access.
+func createTestState(t *testing.T, addr staking.Address) abciAPI.ApplicationQueryState {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	ctx := context.Background()
+
+	ldb, ndb, _, err := abci.InitStateStorage(ctx, &abci.ApplicationConfig{
+		DataDir:        filepath.Join(dataDir, tendermintCommon.StateDir),
+		StorageBackend: storageDB.BackendNameBadgerDB,
+	})
+	require.NoError(err, "InitStateStorage")
+
+	tree := mkvs.New(nil, ndb, storage.RootTypeState)
+	st := stakingState.NewMutableState(tree)
+	require.NoError(st.SetTotalSupply(ctx, quantity.NewFromUint64(1000)), "SetTotalSupply")
+	require.NoError(st.SetCommonPool(ctx, quantity.NewFromUint64(900)), "SetCommonPool")
+	require.NoError(st.SetLastBlockFees(ctx, quantity.NewFromUint64(0)), "SetLastBlockFees")
+	require.NoError(st.SetGovernanceDeposits(ctx, quantity.NewFromUint64(0)), "SetGovernanceDeposits")
+	var acct staking.Account
+	acct.General.Balance = *quantity.NewFromUint64(100)
+	acct.General.Nonce = 5
+	require.NoError(st.SetAccount(ctx, addr, &acct), "SetAccount")
+	require.NoError(tree.Insert(ctx, testRawKey, cbor.Marshal(uint64(42))), "Insert")
+
+	_, rootHash, err := tree.Commit(ctx, common.Namespace{}, 1)
+	require.NoError(err, "Commit")
+	err = ndb.Finalize(ctx, []storage.Root{{Version: 1, Type: storage.RootTypeState, Hash: rootHash}})
+	require.NoError(err, "Finalize")
+	tree.Close()
+	ldb.Cleanup()
+
+	qs, err := abci.OpenOfflineState(ctx, dataDir, 0, true)
+	require.NoError(err, "OpenOfflineState")
+	t.Cleanup(qs.Storage().Cleanup)
+	return qs
+}
+
+func TestDumpState(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var pk signature.PublicKey
+	pk[0] = 1
+	addr := staking.NewAddress(pk)
+	qs := createTestState(t, addr)
+	require.EqualValues(1, qs.BlockHeight())
+
+	t.Run("Staking", func(t *testing.T) {
+		dump, err := dumpStaking(ctx, qs)
+		require.NoError(err, "dumpStaking")
+		require.EqualValues(quantity.NewFromUint64(1000), dump.TotalSupply)
+		require.EqualValues(quantity.NewFromUint64(900), dump.CommonPool)
+		require.Len(dump.Accounts, 1)
+		require.EqualValues(quantity.NewFromUint64(100), &dump.Accounts[addr].General.Balance)
+		require.EqualValues(5, dump.Accounts[addr].General.Nonce)
+	})
+
+	t.Run("ModuleRaw", func(t *testing.T) {
+		entries, err := dumpModuleRaw(ctx, qs, "staking")
+		require.NoError(err, "dumpModuleRaw")
+		require.Len(entries, 5, "all staking state entries should be dumped")
+		for _, entry := range entries {
+			require.NotEqual(hex.EncodeToString(testRawKey), entry.Key, "entries of other modules should not be dumped")
+		}
+
+		_, err = dumpModuleRaw(ctx, qs, "unknown")
+		require.Error(err, "dumpModuleRaw should fail for unknown modules")
+	})
+
+	t.Run("Raw", func(t *testing.T) {
+		entries, err := dumpRaw(ctx, qs, testRawKey[:1])
+		require.NoError(err, "dumpRaw")
+		require.Equal([]*rawEntry{
+			{Key: "fe01", Value: hex.EncodeToString(cbor.Marshal(uint64(42))), Decoded: uint64(42)},
+		}, entries)
+
+		entries, err = dumpRaw(ctx, qs, []byte{0xfd})
+		require.NoError(err, "dumpRaw")
+		require.Empty(entries, "no entries should be dumped for an unused prefix")
+	})
+}
+
+func TestSelectedModules(t *testing.T) {
+	require := require.New(t)
+
+	defer viper.Set(cfgDumpStateModule, "")
+	defer viper.Set(cfgDumpStateKeyPrefix, "")
+
+	modules, err := selectedModules()
+	require.NoError(err, "selectedModules")
+	require.Equal(abciAPI.StateModules(), modules, "all modules should be dumped by default")
+	require.Contains(modules, moduleStaking)
+
+	viper.Set(cfgDumpStateKeyPrefix, "fe")
+	modules, err = selectedModules()
+	require.NoError(err, "selectedModules")
+	require.Empty(modules, "no modules should be dumped when only a key prefix is selected")
+
+	viper.Set(cfgDumpStateModule, moduleStaking)
+	modules, err = selectedModules()
+	require.NoError(err, "selectedModules")
+	require.Equal([]string{moduleStaking}, modules)
+
+	viper.Set(cfgDumpStateModule, "unknown")
+	_, err = selectedModules()
+	require.Error(err, "selectedModules should fail for unknown modules")
+}
+
+func TestJSONCompatible(t *testing.T) {
+	require := require.New(t)
+
+	v := jsonCompatible(map[interface{}]interface{}{
+		"list": []interface{}{
+			[]byte{0xff},
+			map[interface{}]interface{}{uint64(1): "one"},
+		},
+		"key": []byte{0x01, 0x02},
+	})
+	require.Equal(map[string]interface{}{
+		"list": []interface{}{
+			"ff",
+			map[string]interface{}{"1": "one"},
+		},
+		"key": "0102",
+	}, v)
+}
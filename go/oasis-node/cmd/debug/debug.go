@@ -6,11 +6,14 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/beacon"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/byzantine"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/consensus"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/control"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/dumpdb"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/fixgenesis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/scheduler"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/storage"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/txsource"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/upgrade"
 )
 
 var debugCmd = &cobra.Command{
@@ -27,6 +30,9 @@ func Register(parentCmd *cobra.Command) {
 	control.Register(debugCmd)
 	dumpdb.Register(debugCmd)
 	beacon.Register(debugCmd)
+	scheduler.Register(debugCmd)
+	consensus.Register(debugCmd)
+	upgrade.Register(debugCmd)
 
 	parentCmd.AddCommand(debugCmd)
 }
@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -25,7 +24,6 @@ import (
 	roothashApp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash"
 	schedulerApp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler"
 	stakingApp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking"
-	tendermintCommon "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/common"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
@@ -36,9 +34,7 @@ import (
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
-	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
-	storageDB "github.com/oasisprotocol/oasis-core/go/storage/database"
-	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 )
 
 const (
@@ -94,7 +90,7 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	// Initialize the ABCI state storage for access.
+	// Open the ABCI state storage for access.
 	//
 	// Note: While it would be great to always use read-only DB access,
 	// badger will refuse to open a DB that isn't closed properly in
@@ -102,35 +98,14 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 	//
 	// Hope you have backups if you ever run into this.
 	ctx := context.Background()
-	ldb, _, stateRoot, err := abci.InitStateStorage(
-		ctx,
-		&abci.ApplicationConfig{
-			DataDir:             filepath.Join(dataDir, tendermintCommon.StateDir),
-			StorageBackend:      storageDB.BackendNameBadgerDB, // No other backend for now.
-			MemoryOnlyStorage:   false,
-			ReadOnlyStorage:     viper.GetBool(cfgDumpReadOnlyDB),
-			DisableCheckpointer: true,
-		},
-	)
+	qs, err := abci.OpenOfflineState(ctx, dataDir, viper.GetInt64(cfgDumpVersion), viper.GetBool(cfgDumpReadOnlyDB))
 	if err != nil {
-		logger.Error("failed to initialize ABCI storage backend",
+		logger.Error("failed to open consensus state",
 			"err", err,
 		)
-	}
-	defer ldb.Cleanup()
-
-	latestVersion := int64(stateRoot.Version)
-	dumpVersion := viper.GetInt64(cfgDumpVersion)
-	if dumpVersion == 0 {
-		dumpVersion = latestVersion
-	}
-	if dumpVersion <= 0 || dumpVersion > latestVersion {
-		logger.Error("dump requested for version that does not exist",
-			"dump_version", dumpVersion,
-			"latest_version", latestVersion,
-		)
 		return
 	}
+	defer qs.Storage().Cleanup()
 
 	// Generate the dump by querying all of the relevant backends, and
 	// extracting the immutable parameters from the current genesis
@@ -140,10 +115,6 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 	// document without manual intervention, and only the state that
 	// would be exported by the normal dump process will be present
 	// in the dump.
-	qs := &dumpQueryState{
-		ldb:    ldb,
-		height: dumpVersion,
-	}
 	doc := &genesis.Document{
 		Height:    qs.BlockHeight(),
 		Time:      time.Now(), // XXX: Make this deterministic?
@@ -183,9 +154,21 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 		)
 		return
 	}
-	// Add static values to the staking genesis state.
+	// Add token metadata to the staking genesis state, falling back to the old
+	// genesis document in case it has not been stored in state.
 	stakingSt.TokenSymbol = oldDoc.Staking.TokenSymbol
 	stakingSt.TokenValueExponent = oldDoc.Staking.TokenValueExponent
+	tokenMeta, err := dumpTokenMetadata(ctx, qs)
+	if err != nil {
+		logger.Error("failed to dump token metadata",
+			"err", err,
+		)
+		return
+	}
+	if tokenMeta != nil {
+		stakingSt.TokenSymbol = tokenMeta.Symbol
+		stakingSt.TokenValueExponent = tokenMeta.ValueExponent
+	}
 	doc.Staking = *stakingSt
 
 	// KeyManager
@@ -270,7 +253,7 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 	ok = true
 }
 
-func dumpRegistry(ctx context.Context, qs *dumpQueryState) (*registry.Genesis, error) {
+func dumpRegistry(ctx context.Context, qs tendermintAPI.ApplicationQueryState) (*registry.Genesis, error) {
 	qf := registryApp.NewQueryFactory(qs)
 	q, err := qf.QueryAt(ctx, qs.BlockHeight())
 	if err != nil {
@@ -283,7 +266,7 @@ func dumpRegistry(ctx context.Context, qs *dumpQueryState) (*registry.Genesis, e
 	return st, nil
 }
 
-func dumpRootHash(ctx context.Context, qs *dumpQueryState) (*roothash.Genesis, error) {
+func dumpRootHash(ctx context.Context, qs tendermintAPI.ApplicationQueryState) (*roothash.Genesis, error) {
 	qf := roothashApp.NewQueryFactory(qs)
 	q, err := qf.QueryAt(ctx, qs.BlockHeight())
 	if err != nil {
@@ -296,7 +279,7 @@ func dumpRootHash(ctx context.Context, qs *dumpQueryState) (*roothash.Genesis, e
 	return st, nil
 }
 
-func dumpStaking(ctx context.Context, qs *dumpQueryState) (*staking.Genesis, error) {
+func dumpStaking(ctx context.Context, qs tendermintAPI.ApplicationQueryState) (*staking.Genesis, error) {
 	qf := stakingApp.NewQueryFactory(qs)
 	q, err := qf.QueryAt(ctx, qs.BlockHeight())
 	if err != nil {
@@ -309,7 +292,20 @@ func dumpStaking(ctx context.Context, qs *dumpQueryState) (*staking.Genesis, err
 	return st, nil
 }
 
-func dumpKeyManager(ctx context.Context, qs *dumpQueryState) (*keymanager.Genesis, error) {
+func dumpTokenMetadata(ctx context.Context, qs tendermintAPI.ApplicationQueryState) (*token.Metadata, error) {
+	qf := stakingApp.NewQueryFactory(qs)
+	q, err := qf.QueryAt(ctx, qs.BlockHeight())
+	if err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to create staking query: %w", err)
+	}
+	meta, err := q.TokenMetadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to dump token metadata: %w", err)
+	}
+	return meta, nil
+}
+
+func dumpKeyManager(ctx context.Context, qs tendermintAPI.ApplicationQueryState) (*keymanager.Genesis, error) {
 	qf := keymanagerApp.NewQueryFactory(qs)
 	q, err := qf.QueryAt(ctx, qs.BlockHeight())
 	if err != nil {
@@ -322,7 +318,7 @@ func dumpKeyManager(ctx context.Context, qs *dumpQueryState) (*keymanager.Genesi
 	return st, nil
 }
 
-func dumpScheduler(ctx context.Context, qs *dumpQueryState) (*scheduler.Genesis, error) {
+func dumpScheduler(ctx context.Context, qs tendermintAPI.ApplicationQueryState) (*scheduler.Genesis, error) {
 	qf := schedulerApp.NewQueryFactory(qs)
 	q, err := qf.QueryAt(ctx, qs.BlockHeight())
 	if err != nil {
@@ -335,7 +331,7 @@ func dumpScheduler(ctx context.Context, qs *dumpQueryState) (*scheduler.Genesis,
 	return st, nil
 }
 
-func dumpGovernance(ctx context.Context, qs *dumpQueryState) (*governance.Genesis, error) {
+func dumpGovernance(ctx context.Context, qs tendermintAPI.ApplicationQueryState) (*governance.Genesis, error) {
 	qf := governanceApp.NewQueryFactory(qs)
 	q, err := qf.QueryAt(ctx, qs.BlockHeight())
 	if err != nil {
@@ -348,7 +344,7 @@ func dumpGovernance(ctx context.Context, qs *dumpQueryState) (*governance.Genesi
 	return st, nil
 }
 
-func dumpBeacon(ctx context.Context, qs *dumpQueryState) (*beacon.Genesis, error) {
+func dumpBeacon(ctx context.Context, qs tendermintAPI.ApplicationQueryState) (*beacon.Genesis, error) {
 	qf := beaconApp.NewQueryFactory(qs)
 	q, err := qf.QueryAt(ctx, qs.BlockHeight())
 	if err != nil {
@@ -361,7 +357,7 @@ func dumpBeacon(ctx context.Context, qs *dumpQueryState) (*beacon.Genesis, error
 	return st, nil
 }
 
-func dumpConsensus(ctx context.Context, qs *dumpQueryState) (*consensus.Genesis, error) {
+func dumpConsensus(ctx context.Context, qs tendermintAPI.ApplicationQueryState) (*consensus.Genesis, error) {
 	is, err := abciState.NewImmutableState(ctx, qs, qs.BlockHeight())
 	if err != nil {
 		return nil, fmt.Errorf("dumpdb: failed to get consensus state: %w", err)
@@ -376,36 +372,6 @@ func dumpConsensus(ctx context.Context, qs *dumpQueryState) (*consensus.Genesis,
 	}, nil
 }
 
-type dumpQueryState struct {
-	ldb    storage.LocalBackend
-	height int64
-}
-
-func (qs *dumpQueryState) Storage() storage.LocalBackend {
-	return qs.ldb
-}
-
-func (qs *dumpQueryState) Checkpointer() checkpoint.Checkpointer {
-	return nil
-}
-
-func (qs *dumpQueryState) BlockHeight() int64 {
-	return qs.height
-}
-
-func (qs *dumpQueryState) GetEpoch(ctx context.Context, blockHeight int64) (beacon.EpochTime, error) {
-	// This is only required because certain registry backend queries
-	// need the epoch to filter out expired nodes.  It is not
-	// implemented because acquiring a full state dump does not
-	// involve any of the relevant queries.
-	return beacon.EpochTime(0), fmt.Errorf("dumpdb/dumpQueryState: GetEpoch not supported")
-}
-
-func (qs *dumpQueryState) LastRetainedVersion() (int64, error) {
-	// This is not required in the dump process.
-	return 0, fmt.Errorf("dumpdb/dumpQueryState: LastRetainedEpoch not supported")
-}
-
 // Register registers the dumpdb sub-commands.
 func Register(parentCmd *cobra.Command) {
 	dumpDBCmd.Flags().AddFlagSet(flags.GenesisFileFlags)
@@ -0,0 +1,163 @@
+// Package scheduler implements the scheduler debug sub-commands.
+package scheduler
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/abci/types"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	beaconApp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/beacon"
+	registryApp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry"
+	schedulerApp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler"
+	stakingApp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+)
+
+const (
+	cfgSimulateEpoch   = "simulate.epoch"
+	cfgSimulateEntropy = "simulate.entropy"
+)
+
+var (
+	schedulerCmd = &cobra.Command{
+		Use:   "scheduler",
+		Short: "debug the scheduler",
+	}
+
+	simulateCmd = &cobra.Command{
+		Use:   "simulate",
+		Short: "simulate committee elections on top of a state dump",
+		Long: `Recompute the elections for the next epoch on top of the state in the
+given genesis document (e.g., a state dump taken at a specific height) and
+print the elected validators and committees together with the reasons why the
+other nodes were not elected.`,
+		Run: doSimulate,
+	}
+
+	simulateFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/scheduler")
+)
+
+func doSimulate(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	sim, err := doSimulateImpl()
+	if err != nil {
+		logger.Error("failed to simulate elections",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	prettySim, err := cmdCommon.PrettyJSONMarshal(sim)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of simulated elections",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettySim))
+}
+
+func doSimulateImpl() (*schedulerApp.ElectionSimulation, error) {
+	fp, err := genesisFile.NewFileProvider(flags.GenesisFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load genesis document: %w", err)
+	}
+	doc, err := fp.GetGenesisDocument()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get genesis document: %w", err)
+	}
+
+	epoch := beacon.EpochTime(viper.GetUint64(cfgSimulateEpoch))
+	if epoch == 0 {
+		epoch = doc.Beacon.Base + 1
+	}
+	if epoch <= doc.Beacon.Base {
+		return nil, fmt.Errorf("epoch %d is not after the base epoch %d", epoch, doc.Beacon.Base)
+	}
+
+	// Unless explicitly configured, derive the entropy from the document so
+	// that simulations are reproducible.
+	var entropy []byte
+	switch rawEntropy := viper.GetString(cfgSimulateEntropy); rawEntropy {
+	case "":
+		h := doc.Hash()
+		entropy = h[:]
+	default:
+		if entropy, err = hex.DecodeString(rawEntropy); err != nil {
+			return nil, fmt.Errorf("malformed entropy: %w", err)
+		}
+	}
+
+	return Simulate(doc, epoch, entropy)
+}
+
+// Simulate simulates the elections for the given epoch on top of the state
+// in the given genesis document.
+func Simulate(doc *genesis.Document, epoch beacon.EpochTime, entropy []byte) (*schedulerApp.ElectionSimulation, error) {
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		BlockHeight:  doc.Height,
+		BaseEpoch:    doc.Beacon.Base,
+		CurrentEpoch: doc.Beacon.Base,
+		Genesis:      doc,
+	})
+
+	// Initialize the state of all applications that take part in elections.
+	initCtx := appState.NewContext(abciAPI.ContextInitChain, doc.Time)
+	defer initCtx.Close()
+
+	for _, app := range []abciAPI.Application{
+		beaconApp.New(),
+		stakingApp.New(),
+		registryApp.New(),
+		schedulerApp.New(),
+	} {
+		app.OnRegister(appState, &abciAPI.NoopMessageDispatcher{})
+		if err := app.InitChain(initCtx, types.RequestInitChain{}, doc); err != nil {
+			return nil, fmt.Errorf("failed to initialize %s state: %w", app.Name(), err)
+		}
+	}
+
+	appState.UpdateMockApplicationStateConfig(&abciAPI.MockApplicationStateConfig{
+		BlockHeight:  doc.Height + 1,
+		BaseEpoch:    doc.Beacon.Base,
+		CurrentEpoch: epoch,
+		EpochChanged: true,
+		Genesis:      doc,
+	})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock, doc.Time)
+	defer ctx.Close()
+
+	return schedulerApp.SimulateElections(ctx, epoch, entropy)
+}
+
+// Register registers the scheduler sub-command and all of it's children.
+func Register(parentCmd *cobra.Command) {
+	simulateCmd.Flags().AddFlagSet(flags.GenesisFileFlags)
+	simulateCmd.Flags().AddFlagSet(simulateFlags)
+	simulateCmd.Flags().AddFlagSet(flags.DebugDontBlameOasisFlag)
+
+	schedulerCmd.AddCommand(simulateCmd)
+	parentCmd.AddCommand(schedulerCmd)
+}
+
+func init() {
+	simulateFlags.Uint64(cfgSimulateEpoch, 0, "epoch to simulate the elections for (0 = the epoch after the base epoch)")
+	simulateFlags.String(cfgSimulateEntropy, "", "hex-encoded 32-byte election entropy (default: genesis document hash)")
+	_ = viper.BindPFlags(simulateFlags)
+}
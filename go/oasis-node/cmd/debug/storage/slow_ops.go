@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdControl "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/control"
+	storageWorkerAPI "github.com/oasisprotocol/oasis-core/go/worker/storage/api"
+)
+
+var storageSlowOpsCmd = &cobra.Command{
+	Use:   "slow-ops runtime-id (hex)",
+	Short: "show the slowest storage operations recorded by the node",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if err := ValidateRuntimeIDStr(args[0]); err != nil {
+			return fmt.Errorf("malformed runtime id '%v': %w", args[0], err)
+		}
+		return nil
+	},
+	Run: doSlowOps,
+}
+
+func doSlowOps(cmd *cobra.Command, args []string) {
+	conn, _ := cmdControl.DoConnect(cmd)
+	storageWorkerClient := storageWorkerAPI.NewStorageWorkerClient(conn)
+	defer conn.Close()
+
+	var id common.Namespace
+	if err := id.UnmarshalHex(args[0]); err != nil {
+		logger.Error("failed to decode runtime id",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	ops, err := storageWorkerClient.GetSlowOperations(context.Background(), &storageWorkerAPI.GetSlowOperationsRequest{
+		RuntimeID: id,
+	})
+	if err != nil {
+		logger.Error("failed to query slow storage operations",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	pretty, err := cmdCommon.PrettyJSONMarshal(ops)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of slow storage operations",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(pretty))
+}
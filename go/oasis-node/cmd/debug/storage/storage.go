@@ -217,6 +217,8 @@ func Register(parentCmd *cobra.Command) {
 	storageCheckRootsCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
 	storageCheckRootsCmd.PersistentFlags().AddFlagSet(cmdFlags.DebugDontBlameOasisFlag)
 
+	storageSlowOpsCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
+
 	storageExportCmd.Flags().AddFlagSet(storage.Flags)
 	storageExportCmd.Flags().AddFlagSet(cmdFlags.GenesisFileFlags)
 	storageExportCmd.Flags().AddFlagSet(cmdFlags.DebugDontBlameOasisFlag)
@@ -227,5 +229,6 @@ func Register(parentCmd *cobra.Command) {
 	storageCmd.AddCommand(storageCheckRootsCmd)
 	storageCmd.AddCommand(storageExportCmd)
 	storageCmd.AddCommand(storageBenchmarkCmd)
+	storageCmd.AddCommand(storageSlowOpsCmd)
 	parentCmd.AddCommand(storageCmd)
 }
@@ -0,0 +1,411 @@
+// Package upgrade implements the upgrade debug sub-commands.
+package upgrade
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/abci"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/beacon/state"
+	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/state"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/state"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
+)
+
+const (
+	cfgDryRunHeight     = "dry_run.height"
+	cfgDryRunDescriptor = "dry_run.descriptor"
+	cfgDryRunOutput     = "dry_run.output"
+)
+
+var (
+	upgradeCmd = &cobra.Command{
+		Use:   "upgrade",
+		Short: "upgrade debug utilities",
+	}
+
+	dryRunCmd = &cobra.Command{
+		Use:   "dry-run",
+		Short: "simulate consensus upgrade handlers against on-disk consensus state",
+		Long: "Runs the consensus portion of upgrade migration handlers against an in-memory copy of\n" +
+			"the consensus state at the given height and reports the resulting consensus parameter\n" +
+			"and state changes. The on-disk state is never modified.",
+		Run: doDryRun,
+	}
+
+	dryRunFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/upgrade")
+)
+
+type dryRunReport struct {
+	Height int64 `json:"height"`
+
+	Upgrades []*upgradeReport `json:"upgrades"`
+}
+
+type upgradeReport struct {
+	Descriptor *upgrade.Descriptor `json:"descriptor"`
+
+	Error string `json:"error,omitempty"`
+
+	Migrations   []*migrations.ModuleMigrationEvent `json:"migrations,omitempty"`
+	Parameters   map[string]*parameterChange        `json:"parameters,omitempty"`
+	StateChanges []*stateChange                     `json:"state_changes,omitempty"`
+}
+
+type parameterChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type stateChange struct {
+	Key    string `json:"key"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+func doDryRun(cmd *cobra.Command, args []string) {
+	var ok bool
+	defer func() {
+		if !ok {
+			os.Exit(1)
+		}
+	}()
+
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		logger.Error("data directory must be set")
+		return
+	}
+
+	// Open the ABCI state storage for read-only access, all changes made by the upgrade handlers
+	// are only applied to in-memory trees.
+	ctx := context.Background()
+	qs, err := abci.OpenOfflineState(ctx, dataDir, viper.GetInt64(cfgDryRunHeight), true)
+	if err != nil {
+		logger.Error("failed to open consensus state",
+			"err", err,
+		)
+		return
+	}
+	defer qs.Storage().Cleanup()
+
+	height := qs.BlockHeight()
+	ndb := qs.Storage().NodeDB()
+	roots, err := ndb.GetRootsForVersion(ctx, uint64(height))
+	if err != nil || len(roots) != 1 {
+		logger.Error("failed to get state root",
+			"err", err,
+			"height", height,
+			"num_roots", len(roots),
+		)
+		return
+	}
+	newTree := func() mkvs.Tree {
+		return mkvs.NewWithRoot(nil, ndb, roots[0])
+	}
+
+	descriptors, err := upgradeDescriptors(ctx, newTree())
+	if err != nil {
+		logger.Error("failed to determine upgrades to run",
+			"err", err,
+		)
+		return
+	}
+	if len(descriptors) == 0 {
+		logger.Error("no pending upgrades and no upgrade descriptor given")
+		return
+	}
+
+	report := &dryRunReport{
+		Height: height,
+	}
+	for _, desc := range descriptors {
+		var ur *upgradeReport
+		if ur, err = dryRunUpgrade(ctx, newTree, height, desc); err != nil {
+			logger.Error("failed to dry run upgrade",
+				"err", err,
+				"handler", desc.Handler,
+			)
+			return
+		}
+		report.Upgrades = append(report.Upgrades, ur)
+	}
+
+	// Write out the report.
+	w, shouldClose, err := cmdCommon.GetOutputWriter(cmd, cfgDryRunOutput)
+	if err != nil {
+		logger.Error("failed to get output writer for dry run report",
+			"err", err,
+		)
+		return
+	}
+	if shouldClose {
+		defer w.Close()
+	}
+	prettyReport, err := cmdCommon.PrettyJSONMarshal(report)
+	if err != nil {
+		logger.Error("failed to marshal dry run report into JSON",
+			"err", err,
+		)
+		return
+	}
+	if _, err = w.Write(prettyReport); err != nil {
+		logger.Error("failed to write dry run report",
+			"err", err,
+		)
+		return
+	}
+
+	ok = true
+}
+
+// upgradeDescriptors returns the descriptors of the upgrades that should be simulated. In case
+// an upgrade descriptor file is given, only that upgrade is simulated, otherwise all upgrades
+// pending in the governance state are.
+func upgradeDescriptors(ctx context.Context, tree mkvs.Tree) ([]*upgrade.Descriptor, error) {
+	defer tree.Close()
+
+	if fn := viper.GetString(cfgDryRunDescriptor); fn != "" {
+		raw, err := ioutil.ReadFile(fn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read upgrade descriptor: %w", err)
+		}
+		var desc upgrade.Descriptor
+		if err = json.Unmarshal(raw, &desc); err != nil {
+			return nil, fmt.Errorf("failed to parse upgrade descriptor: %w", err)
+		}
+		if err = desc.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("invalid upgrade descriptor: %w", err)
+		}
+		return []*upgrade.Descriptor{&desc}, nil
+	}
+
+	return governanceState.NewMutableState(tree).PendingUpgrades(ctx)
+}
+
+// dryRunUpgrade runs the consensus upgrade handler for the given upgrade against a fresh copy of
+// the state and reports the changes it made.
+func dryRunUpgrade(ctx context.Context, newTree func() mkvs.Tree, height int64, desc *upgrade.Descriptor) (*upgradeReport, error) {
+	report := &upgradeReport{
+		Descriptor: desc,
+	}
+
+	handler, err := migrations.GetHandler(desc.Handler)
+	if err != nil {
+		report.Error = err.Error()
+		return report, nil
+	}
+
+	before := newTree()
+	defer before.Close()
+	after := newTree()
+	defer after.Close()
+
+	migrationCtx := migrations.NewContext(&upgrade.PendingUpgrade{
+		Versioned:     cbor.NewVersioned(upgrade.LatestPendingUpgradeVersion),
+		Descriptor:    desc,
+		UpgradeHeight: height + 1,
+	}, cmdCommon.DataDir())
+
+	// The handler is invoked twice, once in BeginBlock and once in EndBlock of the upgrade block.
+	now := time.Now()
+	for _, mode := range []abciAPI.ContextMode{abciAPI.ContextBeginBlock, abciAPI.ContextEndBlock} {
+		abciCtx := abciAPI.NewContext(
+			ctx,
+			mode,
+			now,
+			abciAPI.NewNopGasAccountant(),
+			nil,
+			after,
+			height+1,
+			abciAPI.NewBlockContext(),
+			1,
+		)
+		err = handler.ConsensusUpgrade(migrationCtx, abciCtx)
+		if err == nil {
+			report.Migrations = append(report.Migrations, moduleMigrations(abciCtx)...)
+		}
+		abciCtx.Close()
+		if err != nil {
+			report.Error = fmt.Sprintf("%s: %s", mode, err)
+			return report, nil
+		}
+	}
+
+	if report.Parameters, err = diffParameters(ctx, before, after); err != nil {
+		return nil, err
+	}
+	if report.StateChanges, err = diffState(ctx, before, after); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// moduleMigrations returns the module migrations performed in the given context.
+func moduleMigrations(ctx *abciAPI.Context) []*migrations.ModuleMigrationEvent {
+	var evs []*migrations.ModuleMigrationEvent
+	for _, ev := range ctx.GetEvents() {
+		if ev.Type != abciAPI.EventTypeForApp(migrations.ModuleName) {
+			continue
+		}
+		for _, attr := range ev.Attributes {
+			var mev migrations.ModuleMigrationEvent
+			if string(attr.Key) != mev.EventKind() {
+				continue
+			}
+			if err := cbor.Unmarshal(attr.Value, &mev); err != nil {
+				continue
+			}
+			evs = append(evs, &mev)
+		}
+	}
+	return evs
+}
+
+// consensusParameters returns the consensus parameters of all modules that support them.
+func consensusParameters(ctx context.Context, tree mkvs.Tree) (map[string]interface{}, error) {
+	params := make(map[string]interface{})
+	var err error
+	if params["beacon"], err = beaconState.NewMutableState(tree).ConsensusParameters(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get beacon consensus parameters: %w", err)
+	}
+	if params["governance"], err = governanceState.NewMutableState(tree).ConsensusParameters(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get governance consensus parameters: %w", err)
+	}
+	if params["registry"], err = registryState.NewMutableState(tree).ConsensusParameters(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get registry consensus parameters: %w", err)
+	}
+	if params["roothash"], err = roothashState.NewMutableState(tree).ConsensusParameters(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get roothash consensus parameters: %w", err)
+	}
+	if params["scheduler"], err = schedulerState.NewMutableState(tree).ConsensusParameters(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get scheduler consensus parameters: %w", err)
+	}
+	if params["staking"], err = stakingState.NewMutableState(tree).ConsensusParameters(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get staking consensus parameters: %w", err)
+	}
+	return params, nil
+}
+
+func diffParameters(ctx context.Context, before, after mkvs.Tree) (map[string]*parameterChange, error) {
+	paramsBefore, err := consensusParameters(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+	paramsAfter, err := consensusParameters(ctx, after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]*parameterChange)
+	for module, pb := range paramsBefore {
+		pa := paramsAfter[module]
+		if reflect.DeepEqual(pb, pa) {
+			continue
+		}
+		changes[module] = &parameterChange{
+			Before: pb,
+			After:  pa,
+		}
+	}
+	return changes, nil
+}
+
+// diffState returns all state entries that differ between the two trees, in key order.
+func diffState(ctx context.Context, before, after mkvs.Tree) ([]*stateChange, error) {
+	itBefore := before.NewIterator(ctx)
+	defer itBefore.Close()
+	itAfter := after.NewIterator(ctx)
+	defer itAfter.Close()
+
+	var changes []*stateChange
+	itBefore.Rewind()
+	itAfter.Rewind()
+	for itBefore.Valid() || itAfter.Valid() {
+		var cmp int
+		switch {
+		case !itBefore.Valid():
+			cmp = 1
+		case !itAfter.Valid():
+			cmp = -1
+		default:
+			cmp = bytes.Compare(itBefore.Key(), itAfter.Key())
+		}
+
+		switch {
+		case cmp < 0:
+			// Removed.
+			changes = append(changes, &stateChange{
+				Key:    hex.EncodeToString(itBefore.Key()),
+				Before: hex.EncodeToString(itBefore.Value()),
+			})
+			itBefore.Next()
+		case cmp > 0:
+			// Added.
+			changes = append(changes, &stateChange{
+				Key:   hex.EncodeToString(itAfter.Key()),
+				After: hex.EncodeToString(itAfter.Value()),
+			})
+			itAfter.Next()
+		default:
+			// Possibly modified.
+			if !bytes.Equal(itBefore.Value(), itAfter.Value()) {
+				changes = append(changes, &stateChange{
+					Key:    hex.EncodeToString(itBefore.Key()),
+					Before: hex.EncodeToString(itBefore.Value()),
+					After:  hex.EncodeToString(itAfter.Value()),
+				})
+			}
+			itBefore.Next()
+			itAfter.Next()
+		}
+	}
+	if err := itBefore.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over original state: %w", err)
+	}
+	if err := itAfter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over upgraded state: %w", err)
+	}
+	return changes, nil
+}
+
+// Register registers the upgrade sub-commands.
+func Register(parentCmd *cobra.Command) {
+	dryRunCmd.Flags().AddFlagSet(dryRunFlags)
+
+	upgradeCmd.AddCommand(dryRunCmd)
+	parentCmd.AddCommand(upgradeCmd)
+}
+
+func init() {
+	dryRunFlags.Int64(cfgDryRunHeight, 0, "consensus height of the state to run the upgrade against (0 = most recent)")
+	dryRunFlags.String(cfgDryRunDescriptor, "", "path to the upgrade descriptor to simulate (default: all pending upgrades)")
+	dryRunFlags.String(cfgDryRunOutput, "", "path to output file (default: stdout)")
+	_ = viper.BindPFlags(dryRunFlags)
+}
@@ -0,0 +1,41 @@
+package upgrade
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+func TestDiffState(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	before := mkvs.New(nil, nil, node.RootTypeState)
+	defer before.Close()
+	after := mkvs.New(nil, nil, node.RootTypeState)
+	defer after.Close()
+
+	for _, tree := range []mkvs.Tree{before, after} {
+		require.NoError(tree.Insert(ctx, []byte("a"), []byte("unchanged")))
+		require.NoError(tree.Insert(ctx, []byte("b"), []byte("old")))
+		require.NoError(tree.Insert(ctx, []byte("c"), []byte("removed")))
+	}
+	require.NoError(after.Insert(ctx, []byte("b"), []byte("new")))
+	require.NoError(after.Remove(ctx, []byte("c")))
+	require.NoError(after.Insert(ctx, []byte("d"), []byte("added")))
+
+	changes, err := diffState(ctx, before, after)
+	require.NoError(err, "diffState")
+
+	enc := func(s string) string { return hex.EncodeToString([]byte(s)) }
+	require.Equal([]*stateChange{
+		{Key: enc("b"), Before: enc("old"), After: enc("new")},
+		{Key: enc("c"), Before: enc("removed")},
+		{Key: enc("d"), After: enc("added")},
+	}, changes)
+}
@@ -33,6 +33,7 @@ import (
 	tendermint "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
+	"github.com/oasisprotocol/oasis-core/go/genesis/migrate"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
@@ -42,6 +43,8 @@ import (
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
 )
 
 const (
@@ -55,10 +58,18 @@ const (
 	cfgChainID       = "chain.id"
 	cfgHaltEpoch     = "halt.epoch"
 	cfgInitialHeight = "initial_height"
+	cfgCheckReport   = "report"
+
+	cfgDumpRuntimeCheckpoints = "embed_runtime_checkpoints"
+
+	cfgMigrateFromVersion = "from_version"
+	cfgMigrateNewGenesis  = "genesis.new_file"
 
 	// Registry config flags.
 	CfgRegistryMaxNodeExpiration             = "registry.max_node_expiration"
 	CfgRegistryDisableRuntimeRegistration    = "registry.disable_runtime_registration"
+	cfgRegistryArchiveRetention              = "registry.archive_retention"
+	cfgRegistryNodeExpirationGracePeriod     = "registry.node_expiration_grace_period"
 	cfgRegistryDebugAllowUnroutableAddresses = "registry.debug.allow_unroutable_addresses"
 	CfgRegistryDebugAllowTestRuntimes        = "registry.debug.allow_test_runtimes"
 	cfgRegistryDebugBypassStake              = "registry.debug.bypass_stake" // nolint: gosec
@@ -86,11 +97,14 @@ const (
 	CfgBeaconVRFAlphaThreshold          = "beacon.vrf.alpha_threshold"
 	CfgBeaconVRFInterval                = "beacon.vrf.interval"
 	CfgBeaconVRFProofSubmissionDelay    = "beacon.vrf.submission_delay"
+	CfgBeaconHistoryRetention           = "beacon.history_retention"
 
 	// Roothash config flags.
 	cfgRoothashDebugDoNotSuspendRuntimes = "roothash.debug.do_not_suspend_runtimes"
 	cfgRoothashDebugBypassStake          = "roothash.debug.bypass_stake" // nolint: gosec
 	cfgRoothashMaxRuntimeMessages        = "roothash.max_runtime_messages"
+	cfgRoothashRoundTimeoutBackoffFactor = "roothash.round_timeout_backoff_factor"
+	cfgRoothashMaxRoundTimeoutBackoff    = "roothash.max_round_timeout_backoff"
 
 	// Staking config flags.
 	CfgStakingTokenSymbol        = "staking.token_symbol"
@@ -119,9 +133,10 @@ const (
 )
 
 var (
-	checkGenesisFlags = flag.NewFlagSet("", flag.ContinueOnError)
-	dumpGenesisFlags  = flag.NewFlagSet("", flag.ContinueOnError)
-	initGenesisFlags  = flag.NewFlagSet("", flag.ContinueOnError)
+	checkGenesisFlags   = flag.NewFlagSet("", flag.ContinueOnError)
+	dumpGenesisFlags    = flag.NewFlagSet("", flag.ContinueOnError)
+	initGenesisFlags    = flag.NewFlagSet("", flag.ContinueOnError)
+	migrateGenesisFlags = flag.NewFlagSet("", flag.ContinueOnError)
 
 	genesisCmd = &cobra.Command{
 		Use:   "genesis",
@@ -146,6 +161,12 @@ var (
 		Run:   doCheckGenesis,
 	}
 
+	migrateGenesisCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "migrate the genesis file to the current format",
+		Run:   doMigrateGenesis,
+	}
+
 	logger = logging.GetLogger("cmd/genesis")
 )
 
@@ -306,6 +327,7 @@ func doInitGenesis(cmd *cobra.Command, args []string) {
 		Parameters: beacon.ConsensusParameters{
 			Backend:          viper.GetString(CfgBeaconBackend),
 			DebugMockBackend: viper.GetBool(CfgBeaconDebugMockBackend),
+			HistoryRetention: viper.GetUint64(CfgBeaconHistoryRetention),
 		},
 	}
 	switch doc.Beacon.Parameters.Backend {
@@ -391,6 +413,8 @@ func AppendRegistryState(doc *genesis.Document, entities, runtimes, nodes []stri
 			GasCosts:                      registry.DefaultGasCosts, // TODO: Make these configurable.
 			MaxNodeExpiration:             viper.GetUint64(CfgRegistryMaxNodeExpiration),
 			DisableRuntimeRegistration:    viper.GetBool(CfgRegistryDisableRuntimeRegistration),
+			ArchiveRetention:              beacon.EpochTime(viper.GetUint64(cfgRegistryArchiveRetention)),
+			NodeExpirationGracePeriod:     beacon.EpochTime(viper.GetUint64(cfgRegistryNodeExpirationGracePeriod)),
 			EnableRuntimeGovernanceModels: make(map[registry.RuntimeGovernanceModel]bool),
 		},
 		Entities: make([]*entity.SignedEntity, 0, len(entities)),
@@ -540,6 +564,8 @@ func AppendRootHashState(doc *genesis.Document, exports []string, l *logging.Log
 			DebugDoNotSuspendRuntimes: viper.GetBool(cfgRoothashDebugDoNotSuspendRuntimes),
 			DebugBypassStake:          viper.GetBool(cfgRoothashDebugBypassStake),
 			MaxRuntimeMessages:        viper.GetUint32(cfgRoothashMaxRuntimeMessages),
+			RoundTimeoutBackoffFactor: uint8(viper.GetUint(cfgRoothashRoundTimeoutBackoffFactor)),
+			MaxRoundTimeoutBackoff:    uint16(viper.GetUint(cfgRoothashMaxRoundTimeoutBackoff)),
 			// TODO: Make these configurable.
 			GasCosts: roothash.DefaultGasCosts,
 		},
@@ -680,6 +706,10 @@ func doDumpGenesis(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	if viper.GetBool(cfgDumpRuntimeCheckpoints) {
+		embedRuntimeCheckpoints(ctx, storage.NewStorageClient(conn), doc)
+	}
+
 	w, shouldClose, err := cmdCommon.GetOutputWriter(cmd, flags.CfgGenesisFile)
 	if err != nil {
 		logger.Error("failed to get writer for genesis file",
@@ -706,12 +736,65 @@ func doDumpGenesis(cmd *cobra.Command, args []string) {
 	}
 }
 
+// embedRuntimeCheckpoints embeds references to storage checkpoints of the
+// runtime state roots into the genesis document, so that storage nodes can
+// restore runtime state from checkpoints after a network restart.
+func embedRuntimeCheckpoints(ctx context.Context, storageClient storage.Backend, doc *genesis.Document) {
+	for id, rtState := range doc.RootHash.RuntimeStates {
+		if rtState.StateRoot.IsEmpty() {
+			continue
+		}
+
+		round := rtState.Round
+		cps, err := storageClient.GetCheckpoints(ctx, &checkpoint.GetCheckpointsRequest{
+			Version:     1,
+			Namespace:   id,
+			RootVersion: &round,
+		})
+		if err != nil {
+			logger.Warn("failed to query runtime checkpoints, not embedding checkpoint",
+				"err", err,
+				"runtime_id", id,
+			)
+			continue
+		}
+
+		for _, cp := range cps {
+			if cp.Root.Type != storage.RootTypeState || !cp.Root.Hash.Equal(&rtState.StateRoot) {
+				continue
+			}
+			rtState.StateCheckpoint = cp
+			break
+		}
+		if rtState.StateCheckpoint == nil {
+			logger.Warn("no checkpoint available for runtime state root, not embedding checkpoint",
+				"runtime_id", id,
+				"round", round,
+				"state_root", rtState.StateRoot,
+			)
+			continue
+		}
+
+		logger.Info("embedded runtime state checkpoint",
+			"runtime_id", id,
+			"round", round,
+			"state_root", rtState.StateRoot,
+			"num_chunks", len(rtState.StateCheckpoint.Chunks),
+		)
+	}
+}
+
 func doCheckGenesis(cmd *cobra.Command, args []string) {
 	if err := cmdCommon.Init(); err != nil {
 		cmdCommon.EarlyLogAndExit(err)
 	}
 
 	filename := flags.GenesisFile()
+	if viper.GetBool(cfgCheckReport) {
+		doCheckGenesisReport(filename)
+		return
+	}
+
 	provider, err := genesisFile.NewFileProvider(filename)
 	if err != nil {
 		logger.Error("failed to open genesis file", "err", err)
@@ -771,17 +854,111 @@ func doCheckGenesis(cmd *cobra.Command, args []string) {
 	}
 }
 
+// doCheckGenesisReport performs a full check of the genesis file and prints a
+// machine-readable report of all of the violations to stdout.
+func doCheckGenesisReport(filename string) {
+	rawGenesis, err := ioutil.ReadFile(filename)
+	if err != nil {
+		logger.Error("failed to read genesis file", "err", err)
+		os.Exit(1)
+	}
+
+	var doc genesis.Document
+	if err = json.Unmarshal(rawGenesis, &doc); err != nil {
+		logger.Error("malformed genesis file", "err", err)
+		os.Exit(1)
+	}
+
+	report := doc.Check()
+
+	canonicalJSON, err := doc.CanonicalJSON()
+	switch {
+	case err != nil:
+		report.Add(genesis.CheckModuleGenesis, "canonical_form", err)
+	case !bytes.Equal(rawGenesis, canonicalJSON):
+		report.Add(genesis.CheckModuleGenesis, "canonical_form", fmt.Errorf("genesis file is not in canonical form"))
+	}
+
+	prettyReport, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		logger.Error("failed to marshal check report", "err", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyReport))
+
+	if !report.IsValid() {
+		os.Exit(1)
+	}
+}
+
+func doMigrateGenesis(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	filename := flags.GenesisFile()
+	raw, err := ioutil.ReadFile(filename)
+	if err != nil {
+		logger.Error("failed to read genesis file", "err", err)
+		os.Exit(1)
+	}
+
+	fromVersion := uint16(viper.GetUint(cfgMigrateFromVersion))
+	doc, err := migrate.Migrate(raw, fromVersion)
+	if err != nil {
+		logger.Error("failed to migrate genesis document",
+			"err", err,
+			"from_version", fromVersion,
+			"supported_versions", migrate.SupportedVersions(),
+		)
+		os.Exit(1)
+	}
+
+	if err = doc.SanityCheck(); err != nil {
+		logger.Error("migrated genesis document sanity check failed", "err", err)
+		os.Exit(1)
+	}
+
+	canonicalJSON, err := doc.CanonicalJSON()
+	if err != nil {
+		logger.Error("failed to get canonical form of migrated genesis file", "err", err)
+		os.Exit(1)
+	}
+
+	w, shouldClose, err := cmdCommon.GetOutputWriter(cmd, cfgMigrateNewGenesis)
+	if err != nil {
+		logger.Error("failed to get writer for migrated genesis file", "err", err)
+		os.Exit(1)
+	}
+	if shouldClose {
+		defer w.Close()
+	}
+	if _, err = w.Write(canonicalJSON); err != nil {
+		logger.Error("failed to write migrated genesis file", "err", err)
+		os.Exit(1)
+	}
+
+	logger.Info("genesis document migrated",
+		"from_version", fromVersion,
+		"to_version", migrate.CurrentVersion(),
+		"old_checksum", fmt.Sprintf("%x", sha256.Sum256(raw)),
+		"chain_context", doc.ChainContext(),
+	)
+}
+
 // Register registers the genesis sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	initGenesisCmd.Flags().AddFlagSet(initGenesisFlags)
 	dumpGenesisCmd.Flags().AddFlagSet(dumpGenesisFlags)
 	dumpGenesisCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
 	checkGenesisCmd.Flags().AddFlagSet(checkGenesisFlags)
+	migrateGenesisCmd.Flags().AddFlagSet(migrateGenesisFlags)
 
 	for _, v := range []*cobra.Command{
 		initGenesisCmd,
 		dumpGenesisCmd,
 		checkGenesisCmd,
+		migrateGenesisCmd,
 	} {
 		genesisCmd.AddCommand(v)
 	}
@@ -790,10 +967,17 @@ func Register(parentCmd *cobra.Command) {
 }
 
 func init() {
+	checkGenesisFlags.Bool(cfgCheckReport, false, "perform a full check and output a JSON report of all violations")
 	_ = viper.BindPFlags(checkGenesisFlags)
 	checkGenesisFlags.AddFlagSet(flags.GenesisFileFlags)
 
+	migrateGenesisFlags.Uint16(cfgMigrateFromVersion, migrate.CurrentVersion()-1, "version of the genesis document to migrate from")
+	migrateGenesisFlags.String(cfgMigrateNewGenesis, "genesis_migrated.json", "path to the migrated genesis document (stdout if empty)")
+	_ = viper.BindPFlags(migrateGenesisFlags)
+	migrateGenesisFlags.AddFlagSet(flags.GenesisFileFlags)
+
 	dumpGenesisFlags.Int64(cfgBlockHeight, consensus.HeightLatest, "block height at which to dump state")
+	dumpGenesisFlags.Bool(cfgDumpRuntimeCheckpoints, false, "embed references to runtime state checkpoints (requires a storage node)")
 	_ = viper.BindPFlags(dumpGenesisFlags)
 	dumpGenesisFlags.AddFlagSet(flags.GenesisFileFlags)
 
@@ -809,6 +993,8 @@ func init() {
 	// Registry config flags.
 	initGenesisFlags.Uint64(CfgRegistryMaxNodeExpiration, 5, "maximum node registration lifespan in epochs")
 	initGenesisFlags.Bool(CfgRegistryDisableRuntimeRegistration, false, "disable non-genesis runtime registration")
+	initGenesisFlags.Uint64(cfgRegistryArchiveRetention, 0, "number of epochs to retain descriptors of deregistered entities and nodes (0 disables archival)")
+	initGenesisFlags.Uint64(cfgRegistryNodeExpirationGracePeriod, 0, "number of epochs after node expiration during which re-registration keeps election eligibility (0 disables the grace period)")
 	initGenesisFlags.Bool(cfgRegistryDebugAllowUnroutableAddresses, false, "allow unroutable addreses (UNSAFE)")
 	initGenesisFlags.Bool(CfgRegistryDebugAllowTestRuntimes, false, "enable test runtime registration")
 	initGenesisFlags.Bool(cfgRegistryDebugBypassStake, false, "bypass all stake checks and operations (UNSAFE)")
@@ -842,12 +1028,15 @@ func init() {
 	initGenesisFlags.Uint64(CfgBeaconVRFAlphaThreshold, 1, "Number of proofs required to allow runtime elections")
 	initGenesisFlags.Int64(CfgBeaconVRFInterval, 86300, "Epoch interval (in blocks)")
 	initGenesisFlags.Int64(CfgBeaconVRFProofSubmissionDelay, 43150, "Proof submission delay (in blocks)")
+	initGenesisFlags.Uint64(CfgBeaconHistoryRetention, 0, "Number of past epochs for which beacons and VRF proofs are kept (0 disables)")
 	_ = initGenesisFlags.MarkHidden(CfgBeaconDebugMockBackend)
 
 	// Roothash config flags.
 	initGenesisFlags.Bool(cfgRoothashDebugDoNotSuspendRuntimes, false, "do not suspend runtimes (UNSAFE)")
 	initGenesisFlags.Bool(cfgRoothashDebugBypassStake, false, "bypass all roothash stake checks and operations (UNSAFE)")
 	initGenesisFlags.Uint32(cfgRoothashMaxRuntimeMessages, 128, "maximum number of runtime messages submitted in a round")
+	initGenesisFlags.Uint8(cfgRoothashRoundTimeoutBackoffFactor, 0, "round timeout multiplier for each consecutive failed round (0 disables)")
+	initGenesisFlags.Uint16(cfgRoothashMaxRoundTimeoutBackoff, 0, "maximum round timeout multiplier after consecutive failed rounds (0 disables)")
 	_ = initGenesisFlags.MarkHidden(cfgRoothashDebugDoNotSuspendRuntimes)
 	_ = initGenesisFlags.MarkHidden(cfgRoothashDebugBypassStake)
 
@@ -13,6 +13,8 @@ import (
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
@@ -23,6 +25,7 @@ import (
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
 	cmdSigner "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/signer"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -30,6 +33,12 @@ const (
 	cfgProposalCancelUpgradeID   = "proposal.cancel_upgrade.id"
 	cfgProposalUpgradeDescriptor = "proposal.upgrade.descriptor"
 
+	cfgProposalChangeTokenMetadataSymbol        = "proposal.change_token_metadata.symbol"
+	cfgProposalChangeTokenMetadataValueExponent = "proposal.change_token_metadata.value_exponent"
+
+	cfgProposalChangeRuntimePauseRuntimeID = "proposal.change_runtime_pause.runtime_id"
+	cfgProposalChangeRuntimePausePaused    = "proposal.change_runtime_pause.paused"
+
 	cfgVote           = "vote"
 	cfgVoteProposalID = "vote.proposal.id"
 
@@ -80,6 +89,12 @@ var (
 		Run:   doListProposals,
 	}
 
+	pendingUpgradesCmd = &cobra.Command{
+		Use:   "pending_upgrades",
+		Short: "lists pending upgrades",
+		Run:   doPendingUpgrades,
+	}
+
 	logger = logging.GetLogger("cmd/governance")
 )
 
@@ -143,9 +158,42 @@ func doGenSubmitProposal(cmd *cobra.Command, args []string) {
 				ProposalID: viper.GetUint64(cfgProposalCancelUpgradeID),
 			},
 		})
+	case viper.GetString(cfgProposalChangeTokenMetadataSymbol) != "":
+		meta := token.Metadata{
+			Symbol:        viper.GetString(cfgProposalChangeTokenMetadataSymbol),
+			ValueExponent: uint8(viper.GetUint(cfgProposalChangeTokenMetadataValueExponent)),
+		}
+		if err := meta.ValidateBasic(); err != nil {
+			logger.Error("submitted token metadata is not valid",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+
+		tx = governance.NewSubmitProposalTx(nonce, fee, &governance.ProposalContent{
+			ChangeTokenMetadata: &governance.ChangeTokenMetadataProposal{
+				Metadata: meta,
+			},
+		})
+	case viper.GetString(cfgProposalChangeRuntimePauseRuntimeID) != "":
+		var runtimeID common.Namespace
+		if err := runtimeID.UnmarshalHex(viper.GetString(cfgProposalChangeRuntimePauseRuntimeID)); err != nil {
+			logger.Error("failed to parse runtime ID",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+
+		tx = governance.NewSubmitProposalTx(nonce, fee, &governance.ProposalContent{
+			ChangeRuntimePause: &governance.ChangeRuntimePauseProposal{
+				RuntimeID: runtimeID,
+				Paused:    viper.GetBool(cfgProposalChangeRuntimePausePaused),
+			},
+		})
 	default:
-		logger.Error(fmt.Sprintf("missing required arguments: either '%v' or '%v' required",
-			cfgProposalUpgradeDescriptor, cfgProposalCancelUpgradeID,
+		logger.Error(fmt.Sprintf("missing required arguments: one of '%v', '%v', '%v' or '%v' required",
+			cfgProposalUpgradeDescriptor, cfgProposalCancelUpgradeID, cfgProposalChangeTokenMetadataSymbol,
+			cfgProposalChangeRuntimePauseRuntimeID,
 		))
 		os.Exit(1)
 	}
@@ -278,6 +326,66 @@ func doListProposals(cmd *cobra.Command, args []string) {
 	fmt.Println(string(prettyProposals))
 }
 
+// pendingUpgrade is a pending upgrade together with the upgrade proposal that scheduled it.
+type pendingUpgrade struct {
+	// ProposalID is the identifier of the upgrade proposal, which can be used to cancel the
+	// upgrade via a cancel upgrade proposal.
+	ProposalID uint64 `json:"proposal_id,omitempty"`
+	// Epoch is the epoch at which the upgrade activates.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// Descriptor is the upgrade descriptor.
+	Descriptor *upgrade.Descriptor `json:"descriptor"`
+}
+
+func doPendingUpgrades(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	ctx := context.Background()
+	upgrades, err := client.PendingUpgrades(ctx, consensus.HeightLatest)
+	if err != nil {
+		logger.Error("error querying pending upgrades", "err", err)
+		os.Exit(1)
+	}
+	proposals, err := client.Proposals(ctx, consensus.HeightLatest)
+	if err != nil {
+		logger.Error("error querying proposals", "err", err)
+		os.Exit(1)
+	}
+
+	pending := make([]*pendingUpgrade, 0, len(upgrades))
+	for _, desc := range upgrades {
+		pu := &pendingUpgrade{
+			Epoch:      desc.Epoch,
+			Descriptor: desc,
+		}
+		// Find the passed upgrade proposal that scheduled the upgrade.
+		for _, p := range proposals {
+			if p.State != governance.StatePassed || p.Content.Upgrade == nil {
+				continue
+			}
+			if p.Content.Upgrade.Descriptor.Equals(desc) {
+				pu.ProposalID = p.ID
+				break
+			}
+		}
+		pending = append(pending, pu)
+	}
+
+	prettyPending, err := cmdCommon.PrettyJSONMarshal(pending)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of pending upgrades",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyPending))
+}
+
 // Register registers the governance sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	for _, c := range []*cobra.Command{
@@ -286,6 +394,7 @@ func Register(parentCmd *cobra.Command) {
 		proposalInfoCmd,
 		proposalVotesCmd,
 		listProposalsCmd,
+		pendingUpgradesCmd,
 	} {
 		governanceCmd.AddCommand(c)
 	}
@@ -302,6 +411,8 @@ func Register(parentCmd *cobra.Command) {
 	listProposalsCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 	listProposalsCmd.Flags().AddFlagSet(listProposalsFlags)
 
+	pendingUpgradesCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+
 	parentCmd.AddCommand(governanceCmd)
 }
 
@@ -312,6 +423,10 @@ func init() {
 
 	submitProposalFlags.String(cfgProposalUpgradeDescriptor, "", "Path to the proposal upgrade descriptor")
 	submitProposalFlags.Uint64(cfgProposalCancelUpgradeID, 0, "Cancel upgrade proposal ID")
+	submitProposalFlags.String(cfgProposalChangeTokenMetadataSymbol, "", "New token ticker symbol")
+	submitProposalFlags.Uint8(cfgProposalChangeTokenMetadataValueExponent, 0, "New token value base-10 exponent")
+	submitProposalFlags.String(cfgProposalChangeRuntimePauseRuntimeID, "", "Runtime ID of the runtime to pause or resume")
+	submitProposalFlags.Bool(cfgProposalChangeRuntimePausePaused, true, "Whether the runtime should be paused (true) or resumed (false)")
 	_ = viper.BindPFlags(submitProposalFlags)
 	submitProposalFlags.AddFlagSet(cmdConsensus.TxFlags)
 	submitProposalFlags.AddFlagSet(cmdFlags.AssumeYesFlag)
@@ -7,15 +7,19 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	tlsCert "github.com/oasisprotocol/oasis-core/go/common/crypto/tls"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	cmnIAS "github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
 	ias "github.com/oasisprotocol/oasis-core/go/ias/api"
 	iasHTTP "github.com/oasisprotocol/oasis-core/go/ias/http"
@@ -39,6 +43,15 @@ const (
 	cfgDebugSkipAuth = "ias.debug.skip_auth"
 	cfgWaitRuntimes  = "ias.wait_runtimes"
 
+	cfgUpstreamURL            = "ias.upstream.url"
+	cfgUpstreamFailureBackoff = "ias.upstream.failure_backoff"
+	cfgCacheTTL               = "ias.cache.ttl"
+
+	cfgAuthClientKeys = "ias.auth.client_keys"
+	cfgQuotaRequests  = "ias.quota.requests"
+	cfgQuotaInterval  = "ias.quota.interval"
+	cfgQuotaRuntime   = "ias.quota.runtime"
+
 	tlsKeyFilename  = "ias_proxy.pem"
 	tlsCertFilename = "ias_proxy_cert.pem"
 )
@@ -120,6 +133,29 @@ func doProxy(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// Initialize the AVR cache, if enabled.
+	if ttl := viper.GetDuration(cfgCacheTTL); ttl > 0 {
+		var commonStore *persistent.CommonStore
+		if commonStore, err = persistent.NewCommonStore(dataDir); err != nil {
+			logger.Error("failed to initialize persistent store",
+				"err", err,
+			)
+			return
+		}
+		defer commonStore.Close()
+
+		var cacheStore *persistent.ServiceStore
+		if cacheStore, err = commonStore.GetServiceStore(iasProxy.CacheServiceName); err != nil {
+			logger.Error("failed to initialize AVR cache store",
+				"err", err,
+			)
+			return
+		}
+		defer cacheStore.Close()
+
+		endpoint = iasProxy.NewCachingEndpoint(endpoint, cacheStore, ttl)
+	}
+
 	// Initialize the gRPC server.
 	env.grpcSrv, err = cmdGrpc.NewServerTCP(cert, false)
 	if err != nil {
@@ -167,8 +203,17 @@ func doProxy(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// Initialize the IAS proxy client policy.
+	policy, err := policyFromFlags()
+	if err != nil {
+		logger.Error("failed to initialize IAS proxy client policy",
+			"err", err,
+		)
+		return
+	}
+
 	// Initialize the IAS proxy.
-	proxy := iasProxy.New(endpoint, authenticator)
+	proxy := iasProxy.New(endpoint, authenticator, policy)
 	ias.RegisterService(env.grpcSrv.Server(), proxy)
 
 	// Start metric server.
@@ -196,7 +241,7 @@ func doProxy(cmd *cobra.Command, args []string) {
 }
 
 func iasEndpointFromFlags() (ias.Endpoint, error) {
-	cfg := &iasHTTP.Config{
+	cfg := iasHTTP.Config{
 		SPID: viper.GetString(cfgSPID),
 	}
 
@@ -224,7 +269,34 @@ func iasEndpointFromFlags() (ias.Endpoint, error) {
 		cfg.IsProduction = viper.GetBool(cfgIsProduction)
 	}
 
-	return iasHTTP.New(cfg)
+	// Configure one provider for each of the upstream URLs, falling back to
+	// the default endpoint if none are configured.
+	upstreamURLs := viper.GetStringSlice(cfgUpstreamURL)
+	if len(upstreamURLs) == 0 || cfg.DebugIsMock {
+		upstreamURLs = []string{""}
+	}
+
+	var providers []iasProxy.Provider
+	for _, u := range upstreamURLs {
+		providerCfg := cfg
+		providerCfg.BaseURL = u
+
+		endpoint, err := iasHTTP.New(&providerCfg)
+		if err != nil {
+			return nil, fmt.Errorf("ias: failed to initialize upstream provider '%s': %w", u, err)
+		}
+
+		name := u
+		if name == "" {
+			name = "default"
+		}
+		providers = append(providers, iasProxy.Provider{
+			Name:     name,
+			Endpoint: endpoint,
+		})
+	}
+
+	return iasProxy.NewFailoverEndpoint(providers, viper.GetDuration(cfgUpstreamFailureBackoff))
 }
 
 func grpcAuthenticatorFromFlags(ctx context.Context, cmd *cobra.Command) (iasProxy.Authenticator, error) {
@@ -239,6 +311,56 @@ func grpcAuthenticatorFromFlags(ctx context.Context, cmd *cobra.Command) (iasPro
 	return newRegistryAuthenticator(ctx, cmd)
 }
 
+func policyFromFlags() (*iasProxy.Policy, error) {
+	policy := &iasProxy.Policy{
+		AuthenticateClients: !viper.GetBool(cfgDebugSkipAuth),
+		DefaultQuota: iasProxy.Quota{
+			Requests: viper.GetUint64(cfgQuotaRequests),
+			Interval: viper.GetDuration(cfgQuotaInterval),
+		},
+		RuntimeQuotas: make(map[common.Namespace]iasProxy.Quota),
+	}
+	if policy.DefaultQuota.Interval <= 0 {
+		return nil, fmt.Errorf("ias: invalid quota interval: %s", policy.DefaultQuota.Interval)
+	}
+
+	for _, v := range viper.GetStringSlice(cfgAuthClientKeys) {
+		var pk signature.PublicKey
+		if err := pk.UnmarshalText([]byte(v)); err != nil {
+			return nil, fmt.Errorf("ias: malformed client public key '%s': %w", v, err)
+		}
+		if policy.AllowedClients == nil {
+			policy.AllowedClients = make(map[signature.PublicKey]bool)
+		}
+		policy.AllowedClients[pk] = true
+	}
+	if len(policy.AllowedClients) > 0 && !policy.AuthenticateClients {
+		return nil, fmt.Errorf("ias: client public keys configured with client authentication disabled")
+	}
+
+	for _, v := range viper.GetStringSlice(cfgQuotaRuntime) {
+		parts := strings.Split(v, "=")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("ias: malformed runtime quota '%s' (expected: <runtime-id>=<requests>)", v)
+		}
+
+		var id common.Namespace
+		if err := id.UnmarshalHex(parts[0]); err != nil {
+			return nil, fmt.Errorf("ias: malformed runtime identifier '%s': %w", parts[0], err)
+		}
+		requests, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("ias: malformed runtime quota '%s': %w", parts[1], err)
+		}
+		policy.RuntimeQuotas[id] = iasProxy.Quota{
+			Requests: requests,
+			Interval: policy.DefaultQuota.Interval,
+		}
+	}
+
+	return policy, nil
+}
+
 // Register registers the ias sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	iasProxyCmd.Flags().AddFlagSet(proxyFlags)
@@ -255,6 +377,13 @@ func init() {
 	proxyFlags.Bool(cfgDebugMock, false, "generate mock IAS AVR responses (UNSAFE)")
 	proxyFlags.Bool(cfgDebugSkipAuth, false, "disable proxy authentication (UNSAFE)")
 	proxyFlags.Int(cfgWaitRuntimes, 0, "wait for N runtimes to be registered before servicing requests")
+	proxyFlags.StringSlice(cfgUpstreamURL, []string{}, "base URLs of IAS-compatible upstream providers, tried in order (default: Intel IAS)")
+	proxyFlags.Duration(cfgUpstreamFailureBackoff, iasProxy.DefaultFailureBackoff, "time to skip an upstream provider for after a failed request")
+	proxyFlags.Duration(cfgCacheTTL, 0, "time to cache AVRs for (0 disables caching)")
+	proxyFlags.StringSlice(cfgAuthClientKeys, []string{}, "node TLS public keys of clients allowed to use the proxy (default: any node)")
+	proxyFlags.Uint64(cfgQuotaRequests, 0, "maximum number of evidence verifications per client and runtime in each quota interval (0 disables quotas)")
+	proxyFlags.Duration(cfgQuotaInterval, iasProxy.DefaultQuotaInterval, "interval over which client quotas are enforced")
+	proxyFlags.StringSlice(cfgQuotaRuntime, []string{}, "runtime-specific client quotas in the form <runtime-id>=<requests>")
 
 	_ = proxyFlags.MarkHidden(cfgDebugMock)
 	_ = proxyFlags.MarkHidden(cfgDebugSkipAuth)
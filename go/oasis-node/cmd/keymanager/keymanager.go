@@ -14,6 +14,7 @@ import (
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
@@ -39,6 +40,10 @@ const (
 	CfgPolicySigFile      = "keymanager.policy.signature.file"
 	CfgPolicyIgnoreSig    = "keymanager.policy.ignore.signature"
 
+	CfgPolicyScheme               = "keymanager.policy.scheme"
+	CfgPolicyChurpThreshold       = "keymanager.policy.churp.threshold"
+	CfgPolicyChurpHandoffInterval = "keymanager.policy.churp.handoff_interval"
+
 	CfgStatusFile        = "keymanager.status.file"
 	CfgStatusID          = "keymanager.status.id"
 	CfgStatusInitialized = "keymanager.status.initialized"
@@ -202,10 +207,32 @@ func policyFromFlags() (*kmApi.PolicySGX, error) {
 		}
 	}
 
+	var scheme kmApi.Scheme
+	if err := scheme.FromString(viper.GetString(CfgPolicyScheme)); err != nil {
+		logger.Error("failed to parse key management scheme",
+			"err", err,
+			"CfgPolicyScheme", viper.GetString(CfgPolicyScheme),
+		)
+		return nil, err
+	}
+
+	var churp *kmApi.ChurpPolicy
+	if scheme == kmApi.SchemeCHURP {
+		churp = &kmApi.ChurpPolicy{
+			Threshold:       uint8(viper.GetUint(CfgPolicyChurpThreshold)),
+			HandoffInterval: beacon.EpochTime(viper.GetUint64(CfgPolicyChurpHandoffInterval)),
+		}
+		if err := churp.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+
 	return &kmApi.PolicySGX{
 		Serial:   serial,
 		ID:       id,
 		Enclaves: enclaves,
+		Scheme:   scheme,
+		Churp:    churp,
 	}, nil
 }
 
@@ -535,6 +562,9 @@ func registerKMInitPolicyFlags(cmd *cobra.Command) {
 		cmd.Flags().String(CfgPolicyEnclaveID, "", "512-bit Key Manager Enclave ID in hex (concatenated MRENCLAVE and MRSIGNER). Multiple Enclave IDs with corresponding permissions can be provided respectively.")
 		cmd.Flags().StringSlice(CfgPolicyMayReplicate, []string{}, "enclave_id1,enclave_id2... list of new enclaves which are allowed to access the master secret. Requires "+CfgPolicyEnclaveID)
 		cmd.Flags().StringToString(CfgPolicyMayQuery, map[string]string{}, "runtime_id=enclave_id1,enclave_id2... sets enclave query permission for runtime_id. Requires "+CfgPolicyEnclaveID)
+		cmd.Flags().String(CfgPolicyScheme, kmApi.SchemeReplicated.String(), "key management scheme (replicated, churp)")
+		cmd.Flags().Uint8(CfgPolicyChurpThreshold, 1, "degree of the CHURP secret-sharing polynomial. Requires "+CfgPolicyScheme+"=churp")
+		cmd.Flags().Uint64(CfgPolicyChurpHandoffInterval, 1, "number of epochs between CHURP handoffs. Requires "+CfgPolicyScheme+"=churp")
 	}
 
 	cmd.Flags().AddFlagSet(policyFileFlag)
@@ -552,6 +582,9 @@ func registerKMInitPolicyFlags(cmd *cobra.Command) {
 		CfgPolicyEnclaveID,
 		CfgPolicyMayReplicate,
 		CfgPolicyMayQuery,
+		CfgPolicyScheme,
+		CfgPolicyChurpThreshold,
+		CfgPolicyChurpHandoffInterval,
 	} {
 		_ = viper.BindPFlag(v, cmd.Flags().Lookup(v))
 	}
@@ -635,6 +668,8 @@ func Register(parentCmd *cobra.Command) {
 		verifyPolicyCmd,
 		initStatusCmd,
 		genUpdateCmd,
+		statusCmd,
+		auditCmd,
 	} {
 		keyManagerCmd.AddCommand(v)
 	}
@@ -643,6 +678,8 @@ func Register(parentCmd *cobra.Command) {
 	registerKMSignPolicyFlags(signPolicyCmd)
 	registerKMVerifyPolicyFlags(verifyPolicyCmd)
 	registerKMInitStatusFlags(initStatusCmd)
+	registerKMStatusFlags(statusCmd)
+	registerKMAuditFlags(auditCmd)
 
 	genUpdateCmd.Flags().AddFlagSet(policyFileFlag)
 	genUpdateCmd.Flags().AddFlagSet(policySigFileFlag)
@@ -0,0 +1,334 @@
+package keymanager
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	kmApi "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+const (
+	// CfgRuntimeID configures the key manager runtime ID to query.
+	CfgRuntimeID = "keymanager.runtime.id"
+	// CfgMaxAttestationAge configures the maximum age of a fresh committee member attestation.
+	CfgMaxAttestationAge = "keymanager.max_attestation_age"
+)
+
+var (
+	statusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "show key manager status as JSON",
+		Run:   doStatus,
+	}
+
+	auditCmd = &cobra.Command{
+		Use:   "audit",
+		Short: "verify that the on-chain key manager policy matches a local policy file",
+		Run:   doAudit,
+	}
+)
+
+// statusReport is the key manager status report.
+type statusReport struct {
+	// ID is the runtime ID of the key manager.
+	ID common.Namespace `json:"id"`
+	// Height is the consensus height at which the status was queried.
+	Height int64 `json:"height"`
+	// Time is the consensus time at the queried height.
+	Time time.Time `json:"time"`
+
+	// IsInitialized is true iff the key manager is done initializing.
+	IsInitialized bool `json:"is_initialized"`
+	// IsSecure is true iff the key manager is secure.
+	IsSecure bool `json:"is_secure"`
+	// Checksum is the hex-encoded key manager master secret verification checksum.
+	Checksum string `json:"checksum"`
+
+	// Scheme is the key management scheme.
+	Scheme string `json:"scheme"`
+	// PolicySerial is the serial number of the key manager policy.
+	PolicySerial *uint32 `json:"policy_serial,omitempty"`
+	// PolicyChecksum is the checksum of the key manager policy.
+	PolicyChecksum *hash.Hash `json:"policy_checksum,omitempty"`
+
+	// Generation is the epoch of the last completed CHURP handoff, in case the key manager
+	// uses the CHURP key management scheme.
+	Generation *beacon.EpochTime `json:"generation,omitempty"`
+
+	// Members are the key manager committee members.
+	Members []*memberStatus `json:"members"`
+}
+
+// memberStatus is the status of a key manager committee member.
+type memberStatus struct {
+	// ID is the node ID.
+	ID signature.PublicKey `json:"id"`
+	// AttestationTime is the time of the member's latest attestation.
+	AttestationTime *time.Time `json:"attestation_time,omitempty"`
+	// AttestationAge is the age of the member's latest attestation in seconds.
+	AttestationAge *uint64 `json:"attestation_age,omitempty"`
+	// IsFresh is true iff the member's attestation is not older than the configured maximum age.
+	IsFresh bool `json:"is_fresh"`
+	// Error is the reason why the attestation could not be inspected, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// auditReport is the key manager policy audit report.
+type auditReport struct {
+	// ID is the runtime ID of the key manager.
+	ID common.Namespace `json:"id"`
+	// Height is the consensus height at which the policy was queried.
+	Height int64 `json:"height"`
+
+	// Match is true iff the on-chain policy matches the local policy.
+	Match bool `json:"match"`
+
+	// LocalSerial is the serial number of the local policy.
+	LocalSerial uint32 `json:"local_serial"`
+	// LocalChecksum is the checksum of the local policy.
+	LocalChecksum hash.Hash `json:"local_checksum"`
+
+	// OnChainSerial is the serial number of the on-chain policy.
+	OnChainSerial *uint32 `json:"on_chain_serial,omitempty"`
+	// OnChainChecksum is the checksum of the on-chain policy.
+	OnChainChecksum *hash.Hash `json:"on_chain_checksum,omitempty"`
+}
+
+func doConnect(cmd *cobra.Command) *grpc.ClientConn {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	conn, err := cmdGrpc.NewClient(cmd)
+	if err != nil {
+		logger.Error("failed to establish connection with node",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	return conn
+}
+
+func getLatestBlock(ctx context.Context, conn *grpc.ClientConn) *consensus.Block {
+	blk, err := consensus.NewConsensusClient(conn).GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		logger.Error("failed to query latest block",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	return blk
+}
+
+func getStatus(ctx context.Context, conn *grpc.ClientConn, id common.Namespace, height int64) *kmApi.Status {
+	status, err := kmApi.NewKeymanagerClient(conn).GetStatus(ctx, &registry.NamespaceQuery{
+		Height: height,
+		ID:     id,
+	})
+	if err != nil {
+		logger.Error("failed to query key manager status",
+			"err", err,
+			"id", id,
+		)
+		os.Exit(1)
+	}
+	return status
+}
+
+func printJSON(v interface{}) {
+	prettyJSON, err := cmdCommon.PrettyJSONMarshal(v)
+	if err != nil {
+		logger.Error("failed to get pretty JSON",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyJSON))
+}
+
+func doStatus(cmd *cobra.Command, args []string) {
+	conn := doConnect(cmd)
+	defer conn.Close()
+
+	var id common.Namespace
+	if err := id.UnmarshalHex(viper.GetString(CfgRuntimeID)); err != nil {
+		logger.Error("failed to parse key manager runtime ID",
+			"err", err,
+			"CfgRuntimeID", viper.GetString(CfgRuntimeID),
+		)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	blk := getLatestBlock(ctx, conn)
+	status := getStatus(ctx, conn, id, blk.Height)
+
+	report := &statusReport{
+		ID:            status.ID,
+		Height:        blk.Height,
+		Time:          blk.Time,
+		IsInitialized: status.IsInitialized,
+		IsSecure:      status.IsSecure,
+		Checksum:      hex.EncodeToString(status.Checksum),
+		Scheme:        status.Scheme().String(),
+	}
+	if status.Policy != nil {
+		policyChecksum := hash.NewFrom(status.Policy.Policy)
+		report.PolicySerial = &status.Policy.Policy.Serial
+		report.PolicyChecksum = &policyChecksum
+	}
+	if status.Churp != nil {
+		report.Generation = &status.Churp.Handoff
+	}
+
+	registryClient := registry.NewRegistryClient(conn)
+	maxAge := viper.GetDuration(CfgMaxAttestationAge)
+	for _, nodeID := range status.Nodes {
+		member := &memberStatus{
+			ID: nodeID,
+		}
+		report.Members = append(report.Members, member)
+
+		n, err := registryClient.GetNode(ctx, &registry.IDQuery{
+			Height: blk.Height,
+			ID:     nodeID,
+		})
+		if err != nil {
+			member.Error = fmt.Sprintf("failed to query node: %s", err)
+			continue
+		}
+		ts, err := attestationTime(n, id)
+		if err != nil {
+			member.Error = err.Error()
+			continue
+		}
+
+		var age uint64
+		if d := blk.Time.Sub(ts); d > 0 {
+			age = uint64(d / time.Second)
+		}
+		member.AttestationTime = &ts
+		member.AttestationAge = &age
+		member.IsFresh = maxAge <= 0 || time.Duration(age)*time.Second <= maxAge
+	}
+
+	printJSON(report)
+}
+
+// attestationTime returns the time of the node's latest attestation for the given runtime.
+func attestationTime(n *node.Node, runtimeID common.Namespace) (time.Time, error) {
+	var tee *node.CapabilityTEE
+	for _, rt := range n.Runtimes {
+		if rt.ID.Equal(&runtimeID) {
+			tee = rt.Capabilities.TEE
+		}
+	}
+	if tee == nil {
+		return time.Time{}, fmt.Errorf("node has no TEE capability for the key manager runtime")
+	}
+	if tee.Hardware != node.TEEHardwareIntelSGX {
+		return time.Time{}, fmt.Errorf("unsupported TEE hardware: %s", tee.Hardware)
+	}
+
+	// The attestation has already been verified during node registration, so only extract the
+	// timestamp of the attestation verification report.
+	var avrBundle ias.AVRBundle
+	if err := cbor.Unmarshal(tee.Attestation, &avrBundle); err != nil {
+		return time.Time{}, fmt.Errorf("malformed attestation: %w", err)
+	}
+	var avr ias.AttestationVerificationReport
+	if err := json.Unmarshal(avrBundle.Body, &avr); err != nil {
+		return time.Time{}, fmt.Errorf("malformed attestation verification report: %w", err)
+	}
+	ts, err := time.Parse(ias.TimestampFormat, avr.Timestamp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("malformed attestation timestamp: %w", err)
+	}
+	return ts, nil
+}
+
+func doAudit(cmd *cobra.Command, args []string) {
+	conn := doConnect(cmd)
+	defer conn.Close()
+
+	policyBytes, err := ioutil.ReadFile(viper.GetString(CfgPolicyFile))
+	if err != nil {
+		logger.Error("failed to read policy file",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	policy, err := unmarshalPolicyCBOR(policyBytes)
+	if err != nil {
+		logger.Error("failed to unmarshal policy file",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	blk := getLatestBlock(ctx, conn)
+	status := getStatus(ctx, conn, policy.ID, blk.Height)
+
+	report := &auditReport{
+		ID:            policy.ID,
+		Height:        blk.Height,
+		LocalSerial:   policy.Serial,
+		LocalChecksum: hash.NewFrom(policy),
+	}
+	if status.Policy != nil {
+		onChainChecksum := hash.NewFrom(status.Policy.Policy)
+		report.OnChainSerial = &status.Policy.Policy.Serial
+		report.OnChainChecksum = &onChainChecksum
+		report.Match = onChainChecksum.Equal(&report.LocalChecksum)
+	}
+
+	printJSON(report)
+
+	if !report.Match {
+		os.Exit(1)
+	}
+}
+
+func registerKMStatusFlags(cmd *cobra.Command) {
+	if !cmd.Flags().Parsed() {
+		cmd.Flags().String(CfgRuntimeID, "", "256-bit key manager runtime ID in hex")
+		cmd.Flags().Duration(CfgMaxAttestationAge, 24*time.Hour, "maximum age of a fresh committee member attestation (0 = no limit)")
+	}
+
+	cmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+
+	_ = cmd.MarkFlagRequired(CfgRuntimeID)
+
+	for _, v := range []string{
+		CfgRuntimeID,
+		CfgMaxAttestationAge,
+	} {
+		_ = viper.BindPFlag(v, cmd.Flags().Lookup(v))
+	}
+}
+
+func registerKMAuditFlags(cmd *cobra.Command) {
+	cmd.Flags().AddFlagSet(policyFileFlag)
+	cmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+}
@@ -2,15 +2,25 @@ package node
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/diskmon"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/service"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	tmAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	workerCommonAPI "github.com/oasisprotocol/oasis-core/go/worker/common/api"
+	executorAPI "github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
 )
 
@@ -176,3 +186,229 @@ func (n *Node) GetRuntimeStatus(ctx context.Context) (map[common.Namespace]contr
 func (n *Node) GetPendingUpgrades(ctx context.Context) ([]*upgrade.PendingUpgrade, error) {
 	return n.Upgrader.PendingUpgrades(ctx)
 }
+
+// Implements control.ControlledNode.
+func (n *Node) GetDiskStatus(ctx context.Context) (*diskmon.Status, error) {
+	if n.diskMonitor == nil {
+		return nil, nil
+	}
+	return n.diskMonitor.Status(), nil
+}
+
+// Implements control.ControlledNode.
+func (n *Node) GetP2PStatus(ctx context.Context) (*workerCommonAPI.P2PStatus, error) {
+	if n.P2P == nil {
+		return nil, nil
+	}
+	return n.P2P.Status(), nil
+}
+
+// Implements control.ControlledNode.
+func (n *Node) PruneConsensus(ctx context.Context, height uint64, progressFn func(height uint64)) error {
+	tmBackend, ok := n.Consensus.(tmAPI.Backend)
+	if !ok {
+		return fmt.Errorf("%w: consensus backend does not support pruning", control.ErrPruneUnsupported)
+	}
+
+	// Pruning the ABCI state also causes Tendermint to prune all blocks below the last retained
+	// version on the next commit.
+	err := tmBackend.Pruner().PruneUntil(ctx, height, progressFn)
+	if errors.Is(err, tmAPI.ErrPruningDisabled) {
+		return fmt.Errorf("%w: %s", control.ErrPruneUnsupported, err)
+	}
+	return err
+}
+
+// Implements control.ControlledNode.
+func (n *Node) CheckConsensusInvariants(ctx context.Context) (*consensus.InvariantsReport, error) {
+	tmBackend, ok := n.Consensus.(tmAPI.Backend)
+	if !ok {
+		return nil, fmt.Errorf("%w: consensus backend does not support invariant checks", consensus.ErrUnsupported)
+	}
+	return tmBackend.CheckInvariants(ctx)
+}
+
+// Implements control.ControlledNode.
+func (n *Node) PruneRuntime(ctx context.Context, runtimeID common.Namespace, round uint64, progressFn func(round uint64)) error {
+	if n.RuntimeRegistry == nil {
+		return fmt.Errorf("%w: node does not support runtimes", control.ErrPruneUnsupported)
+	}
+	rt, err := n.RuntimeRegistry.GetRuntime(runtimeID)
+	if err != nil {
+		return err
+	}
+
+	// Any registered prune handlers (e.g., the storage worker) also prune the runtime storage.
+	return rt.History().Pruner().PruneUntil(ctx, round, progressFn)
+}
+
+// Implements control.ControlledNode.
+func (n *Node) GetTxPool(ctx context.Context, runtimeID common.Namespace) (txpool.TransactionPool, error) {
+	if n.CommonWorker == nil {
+		return nil, control.ErrTxPoolUnavailable
+	}
+	rtNode := n.CommonWorker.GetRuntime(runtimeID)
+	if rtNode == nil || rtNode.TxPool == nil {
+		return nil, control.ErrTxPoolUnavailable
+	}
+	return rtNode.TxPool, nil
+}
+
+// Implements control.ControlledNode.
+func (n *Node) GetExecutionReports(ctx context.Context, runtimeID common.Namespace, limit uint64) ([]*executorAPI.ExecutionReport, error) {
+	if n.ExecutorWorker == nil || !n.ExecutorWorker.Enabled() {
+		return nil, control.ErrExecutionReportsUnavailable
+	}
+	rtNode := n.ExecutorWorker.GetRuntime(runtimeID)
+	if rtNode == nil {
+		return nil, control.ErrExecutionReportsUnavailable
+	}
+	return rtNode.GetExecutionReports(limit)
+}
+
+// Implements control.ControlledNode.
+func (n *Node) AddRuntime(ctx context.Context, req *control.AddRuntimeRequest) error {
+	if n.RuntimeRegistry == nil || !n.CommonWorker.Enabled() {
+		return fmt.Errorf("%w: node does not support runtimes", control.ErrRuntimeManagementUnsupported)
+	}
+
+	n.runtimesLock.Lock()
+	defer n.runtimesLock.Unlock()
+
+	n.logger.Info("adding runtime",
+		"runtime_id", req.RuntimeID,
+		"path", req.Path,
+	)
+
+	hostCfg, err := runtimeRegistry.NewRuntimeHostConfig(cmdCommon.DataDir(), req.RuntimeID, req.Path, req.SGXSignature, nil)
+	if err != nil {
+		return err
+	}
+	rt, err := n.RuntimeRegistry.AddRuntime(ctx, hostCfg)
+	if err != nil {
+		if errors.Is(err, runtimeRegistry.ErrRuntimeManagementNotSupported) {
+			return fmt.Errorf("%w: %s", control.ErrRuntimeManagementUnsupported, err)
+		}
+		return err
+	}
+	if err = n.RuntimeRegistry.FinishInitialization(ctx); err != nil {
+		_ = n.RuntimeRegistry.RemoveRuntime(ctx, req.RuntimeID)
+		return err
+	}
+
+	// Register the runtime with all the workers before starting any of them, the same as it is
+	// done during node startup, so that all hooks are in place before the common node starts.
+	nodes, err := n.registerRuntimeWorkersLocked(rt)
+	if err != nil {
+		n.rollbackRuntimeLocked(ctx, req.RuntimeID, nodes)
+		return err
+	}
+
+	for i, node := range nodes {
+		if err = node.Start(); err != nil {
+			n.rollbackRuntimeLocked(ctx, req.RuntimeID, nodes[i+1:])
+			return err
+		}
+	}
+
+	n.logger.Info("runtime added",
+		"runtime_id", req.RuntimeID,
+	)
+
+	return nil
+}
+
+// registerRuntimeWorkersLocked registers the given runtime with all the runtime workers and
+// returns the (not yet started) runtime nodes of the workers in startup order.
+func (n *Node) registerRuntimeWorkersLocked(rt runtimeRegistry.Runtime) ([]service.BackgroundService, error) {
+	var nodes []service.BackgroundService
+	commonNode, err := n.CommonWorker.AddRuntime(rt)
+	if err != nil {
+		return nodes, err
+	}
+	nodes = append(nodes, commonNode)
+
+	if n.StorageWorker.Enabled() {
+		var storageNode service.BackgroundService
+		if storageNode, err = n.StorageWorker.AddRuntime(commonNode); err != nil {
+			return nodes, err
+		}
+		nodes = append(nodes, storageNode)
+	}
+
+	clientNode, err := n.ClientWorker.AddRuntime(commonNode)
+	if err != nil {
+		return nodes, err
+	}
+	nodes = append(nodes, clientNode)
+
+	return nodes, nil
+}
+
+// rollbackRuntimeLocked removes a partially added runtime. The given registered nodes that were
+// not yet started are started first so that they can be stopped the regular way.
+func (n *Node) rollbackRuntimeLocked(ctx context.Context, runtimeID common.Namespace, unstarted []service.BackgroundService) {
+	for _, node := range unstarted {
+		_ = node.Start()
+	}
+	if err := n.removeRuntimeLocked(ctx, runtimeID); err != nil {
+		n.logger.Error("failed to roll back added runtime",
+			"err", err,
+			"runtime_id", runtimeID,
+		)
+	}
+}
+
+// Implements control.ControlledNode.
+func (n *Node) RemoveRuntime(ctx context.Context, runtimeID common.Namespace) error {
+	if n.RuntimeRegistry == nil || !n.CommonWorker.Enabled() {
+		return fmt.Errorf("%w: node does not support runtimes", control.ErrRuntimeManagementUnsupported)
+	}
+	if _, err := n.RuntimeRegistry.GetRuntime(runtimeID); err != nil {
+		return err
+	}
+
+	n.runtimesLock.Lock()
+	defer n.runtimesLock.Unlock()
+
+	n.logger.Info("removing runtime",
+		"runtime_id", runtimeID,
+	)
+
+	return n.removeRuntimeLocked(ctx, runtimeID)
+}
+
+// removeRuntimeLocked stops all workers of the given runtime in reverse order of startup and
+// removes the runtime from the runtime registry.
+//
+// Workers that do not have the runtime registered are skipped so that this can also be used to
+// roll back a partially added runtime.
+func (n *Node) removeRuntimeLocked(ctx context.Context, runtimeID common.Namespace) error {
+	if n.ClientWorker.GetRuntime(runtimeID) != nil {
+		if err := n.ClientWorker.RemoveRuntime(ctx, runtimeID); err != nil {
+			return err
+		}
+	}
+	if n.StorageWorker.GetRuntime(runtimeID) != nil {
+		if err := n.StorageWorker.RemoveRuntime(ctx, runtimeID); err != nil {
+			return err
+		}
+	}
+	if n.CommonWorker.GetRuntime(runtimeID) != nil {
+		if err := n.CommonWorker.RemoveRuntime(ctx, runtimeID); err != nil {
+			return err
+		}
+	}
+	if err := n.RuntimeRegistry.RemoveRuntime(ctx, runtimeID); err != nil {
+		if errors.Is(err, runtimeRegistry.ErrRuntimeManagementNotSupported) {
+			return fmt.Errorf("%w: %s", control.ErrRuntimeManagementUnsupported, err)
+		}
+		return err
+	}
+
+	n.logger.Info("runtime removed",
+		"runtime_id", runtimeID,
+	)
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/diskmon"
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+)
+
+const (
+	// CfgDiskMonEnabled enables the disk space monitor.
+	CfgDiskMonEnabled = "diskmon.enabled"
+	// CfgDiskMonInterval configures the interval between disk space checks.
+	CfgDiskMonInterval = "diskmon.interval"
+	// CfgDiskMonThresholdLow configures the percentage of free disk space below which unneeded
+	// data is aggressively pruned.
+	CfgDiskMonThresholdLow = "diskmon.threshold.low"
+	// CfgDiskMonThresholdCritical configures the percentage of free disk space below which
+	// non-essential work (e.g., serving public storage RPC) is refused.
+	CfgDiskMonThresholdCritical = "diskmon.threshold.critical"
+	// CfgDiskMonThresholdShutdown configures the percentage of free disk space below which the
+	// node is shut down.
+	CfgDiskMonThresholdShutdown = "diskmon.threshold.shutdown"
+	// CfgDiskMonPruneConsensusNumKept configures the number of consensus versions retained when
+	// pruning due to low disk space.
+	CfgDiskMonPruneConsensusNumKept = "diskmon.prune.consensus_num_kept"
+	// CfgDiskMonPruneRuntimeNumKept configures the number of runtime rounds retained when
+	// pruning due to low disk space.
+	CfgDiskMonPruneRuntimeNumKept = "diskmon.prune.runtime_num_kept"
+
+	// diskPruneRetryInterval is the minimum interval between repeated pruning attempts while
+	// disk space remains low.
+	diskPruneRetryInterval = 10 * time.Minute
+)
+
+// diskMonFlags has the disk space monitor configuration flags.
+var diskMonFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+func (n *Node) initDiskMonitor(dataDir string) error {
+	if !viper.GetBool(CfgDiskMonEnabled) {
+		return nil
+	}
+
+	var err error
+	n.diskMonitor, err = diskmon.New(&diskmon.Config{
+		Paths:             []string{dataDir},
+		Interval:          viper.GetDuration(CfgDiskMonInterval),
+		LowThreshold:      viper.GetFloat64(CfgDiskMonThresholdLow),
+		CriticalThreshold: viper.GetFloat64(CfgDiskMonThresholdCritical),
+		ShutdownThreshold: viper.GetFloat64(CfgDiskMonThresholdShutdown),
+	})
+	if err != nil {
+		return err
+	}
+	n.svcMgr.Register(n.diskMonitor)
+
+	if err = n.diskMonitor.Start(); err != nil {
+		return err
+	}
+	go n.diskGuard(n.svcMgr.Ctx)
+
+	return nil
+}
+
+// diskGuard reacts to disk space degradation level changes.
+func (n *Node) diskGuard(ctx context.Context) {
+	ch, sub := n.diskMonitor.WatchLevel()
+	defer sub.Close()
+
+	var (
+		current    diskmon.Level
+		lastPruned time.Time
+	)
+	for {
+		var level diskmon.Level
+		select {
+		case level = <-ch:
+		case <-ctx.Done():
+			return
+		}
+
+		if level >= diskmon.LevelLow && (level > current || time.Since(lastPruned) >= diskPruneRetryInterval) {
+			n.pruneForDiskSpace(ctx)
+			lastPruned = time.Now()
+		}
+
+		if (level >= diskmon.LevelCritical) != (current >= diskmon.LevelCritical) && n.StorageWorker != nil {
+			n.StorageWorker.SetPublicRPCSuspended(level >= diskmon.LevelCritical)
+		}
+
+		if level >= diskmon.LevelShutdown {
+			n.logger.Error("disk space exhausted, shutting down the node",
+				"disk_status", n.diskMonitor.Status(),
+			)
+			n.Stop()
+			return
+		}
+
+		current = level
+	}
+}
+
+// pruneForDiskSpace starts prune jobs for the consensus state and all runtimes, only retaining
+// the configured number of most recent versions.
+func (n *Node) pruneForDiskSpace(ctx context.Context) {
+	startPrune := func(kind string, fn func() error) {
+		err := fn()
+		switch {
+		case err == nil:
+		case errors.Is(err, control.ErrPruneInProgress):
+		default:
+			n.logger.Error("failed to start pruning due to low disk space",
+				"err", err,
+				"kind", kind,
+			)
+		}
+	}
+
+	startPrune("consensus", func() error {
+		numKept := viper.GetUint64(CfgDiskMonPruneConsensusNumKept)
+		status, err := n.Consensus.GetStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get consensus status: %w", err)
+		}
+		if uint64(status.LatestHeight) <= numKept {
+			return nil
+		}
+		_, err = n.NodeController.PruneConsensus(ctx, uint64(status.LatestHeight)-numKept)
+		return err
+	})
+
+	if n.RuntimeRegistry == nil {
+		return
+	}
+	for _, rt := range n.RuntimeRegistry.Runtimes() {
+		rt := rt
+		startPrune("runtime", func() error {
+			numKept := viper.GetUint64(CfgDiskMonPruneRuntimeNumKept)
+			blk, err := rt.History().GetBlock(ctx, roothash.RoundLatest)
+			if err != nil {
+				return fmt.Errorf("failed to get latest block of runtime %s: %w", rt.ID(), err)
+			}
+			if blk.Header.Round <= numKept {
+				return nil
+			}
+			_, err = n.NodeController.PruneRuntime(ctx, &control.PruneRuntimeRequest{
+				RuntimeID: rt.ID(),
+				Round:     blk.Header.Round - numKept,
+			})
+			return err
+		})
+	}
+}
+
+func init() {
+	diskMonFlags.Bool(CfgDiskMonEnabled, false, "enable disk space monitor")
+	diskMonFlags.Duration(CfgDiskMonInterval, 30*time.Second, "disk space check interval")
+	diskMonFlags.Float64(CfgDiskMonThresholdLow, 10, "percentage of free disk space below which unneeded data is pruned")
+	diskMonFlags.Float64(CfgDiskMonThresholdCritical, 5, "percentage of free disk space below which non-essential work is refused")
+	diskMonFlags.Float64(CfgDiskMonThresholdShutdown, 2, "percentage of free disk space below which the node is shut down")
+	diskMonFlags.Uint64(CfgDiskMonPruneConsensusNumKept, 10000, "number of consensus versions kept when pruning due to low disk space")
+	diskMonFlags.Uint64(CfgDiskMonPruneRuntimeNumKept, 10000, "number of runtime rounds kept when pruning due to low disk space")
+
+	_ = viper.BindPFlags(diskMonFlags)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/badger"
 	"github.com/oasisprotocol/oasis-core/go/common/crash"
+	"github.com/oasisprotocol/oasis-core/go/common/diskmon"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
@@ -35,7 +36,9 @@ import (
 	keymanagerAPI "github.com/oasisprotocol/oasis-core/go/keymanager/api"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/background"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/config"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/gateway"
 	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/metrics"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/pprof"
@@ -92,6 +95,7 @@ func Run(cmd *cobra.Command, args []string) {
 type Node struct {
 	svcMgr       *background.ServiceManager
 	grpcInternal *grpc.Server
+	gateway      service.BackgroundService
 
 	stopOnce sync.Once
 
@@ -109,6 +113,7 @@ type Node struct {
 	IAS      iasAPI.Endpoint
 
 	RuntimeRegistry runtimeRegistry.Registry
+	runtimesLock    sync.Mutex
 
 	CommonWorker       *workerCommon.Worker
 	ExecutorWorker     *executor.Worker
@@ -122,6 +127,8 @@ type Node struct {
 	BeaconWorker       *workerBeacon.Worker
 	readyCh            chan struct{}
 
+	diskMonitor *diskmon.Monitor
+
 	logger *logging.Logger
 }
 
@@ -281,7 +288,7 @@ func (n *Node) initRuntimeWorkers() error {
 		if genesisDoc.Registry.Parameters.DebugAllowUnroutableAddresses {
 			p2p.DebugForceAllowUnroutableAddresses()
 		}
-		n.P2P, err = p2p.New(p2pCtx, n.Identity, n.Consensus)
+		n.P2P, err = p2p.New(p2pCtx, n.Identity, n.Consensus, n.commonStore)
 		if err != nil {
 			return err
 		}
@@ -382,6 +389,7 @@ func (n *Node) initRuntimeWorkers() error {
 	n.ExecutorWorker, err = executor.New(
 		n.CommonWorker,
 		n.RegistrationWorker,
+		n.commonStore,
 	)
 	if err != nil {
 		return err
@@ -557,6 +565,20 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 		"Version", version.SoftwareVersion,
 	)
 
+	// Check the node configuration before initializing anything else.
+	cfgCheck := config.Check(cmdCommon.ConfigFile(), KnownFlags())
+	for _, warning := range cfgCheck.Warnings {
+		logger.Warn(warning)
+	}
+	for _, cfgErr := range cfgCheck.Errors {
+		logger.Error("invalid configuration",
+			"err", cfgErr,
+		)
+	}
+	if err = cfgCheck.Err(); err != nil {
+		return nil, err
+	}
+
 	dataDir := cmdCommon.DataDir()
 	if dataDir == "" {
 		logger.Error("data directory not configured")
@@ -659,6 +681,23 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 		return nil, err
 	}
 
+	// Initialize the HTTP/JSON gateway which forwards requests to the internal gRPC server.
+	socketPath, err := cmdGrpc.LocalSocketPath()
+	if err != nil {
+		logger.Error("failed to determine internal gRPC socket path",
+			"err", err,
+		)
+		return nil, err
+	}
+	node.gateway, err = gateway.New(node.svcMgr.Ctx, "unix:"+socketPath)
+	if err != nil {
+		logger.Error("failed to initialize gateway server",
+			"err", err,
+		)
+		return nil, err
+	}
+	node.svcMgr.Register(node.gateway)
+
 	// Initialize the genesis provider.
 	if err = node.initGenesis(); err != nil {
 		logger.Error("failed to initialize the genesis provider",
@@ -709,6 +748,14 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 		return nil, err
 	}
 
+	// Start the HTTP/JSON gateway.
+	if err = node.gateway.Start(); err != nil {
+		logger.Error("failed to start gateway server",
+			"err", err,
+		)
+		return nil, err
+	}
+
 	// Start the consensus backend service.
 	if err = node.Consensus.Start(); err != nil {
 		logger.Error("failed to start consensus backend service",
@@ -717,12 +764,28 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 		return nil, err
 	}
 
+	// Initialize and start the disk space monitor.
+	if err = node.initDiskMonitor(dataDir); err != nil {
+		logger.Error("failed to initialize disk space monitor",
+			"err", err,
+		)
+		return nil, err
+	}
+
 	logger.Info("initialization complete: ready to serve")
 	startOk = true
 
 	return node, nil
 }
 
+// KnownFlags returns the set of all flags that configure the node.
+func KnownFlags() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.AddFlagSet(cmdCommon.RootFlags)
+	fs.AddFlagSet(Flags)
+	return fs
+}
+
 // Register registers the node maintenance sub-commands and all of it's
 // children.
 func Register(parentCmd *cobra.Command) {
@@ -743,6 +806,7 @@ func init() {
 		cmdGrpc.ServerLocalFlags,
 		cmdSigner.Flags,
 		pprof.Flags,
+		gateway.Flags,
 		tendermint.Flags,
 		seed.Flags,
 		ias.Flags,
@@ -751,11 +815,13 @@ func init() {
 		p2p.Flags,
 		registration.Flags,
 		workerCommon.Flags,
+		workerClient.Flags,
 		workerStorage.Flags,
 		workerSentry.Flags,
 		workerConsensusRPC.Flags,
 		crash.InitFlags(),
 		badger.MigrationFlags,
+		diskMonFlags,
 	} {
 		Flags.AddFlagSet(v)
 	}
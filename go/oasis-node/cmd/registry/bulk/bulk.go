@@ -0,0 +1,581 @@
+// Package bulk implements the registry bulk import and export sub-commands.
+package bulk
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+const (
+	// CfgImportDir configures the directory containing the signed descriptors to import.
+	CfgImportDir = "import.dir"
+	// CfgImportOutput configures the file the imported registry genesis section is written to.
+	CfgImportOutput = "import.output"
+	// CfgExportDir configures the directory the exported signed descriptors are written to.
+	CfgExportDir = "export.dir"
+
+	entityFilePrefix = "entity_"
+	nodeFilePrefix   = "node_"
+	descriptorSuffix = ".json"
+)
+
+var (
+	importFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	exportFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	importCmd = &cobra.Command{
+		Use:   "import",
+		Short: "build the registry genesis section from a directory of signed descriptors",
+		Run:   doImport,
+	}
+
+	exportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "export the signed descriptors from the registry genesis section",
+		Run:   doExport,
+	}
+
+	logger = logging.GetLogger("cmd/registry/bulk")
+)
+
+// DescriptorKind is the kind of a signed registry descriptor.
+type DescriptorKind string
+
+const (
+	// KindUnknown is the kind of descriptors that could not be decoded.
+	KindUnknown DescriptorKind = "unknown"
+	// KindEntity is the kind of signed entity descriptors.
+	KindEntity DescriptorKind = "entity"
+	// KindNode is the kind of signed node descriptors.
+	KindNode DescriptorKind = "node"
+)
+
+// Descriptor is a signed entity or node descriptor.
+type Descriptor struct {
+	// Source is the name of the file the descriptor has been loaded from or written to.
+	Source string
+
+	// Entity is the signed entity descriptor, if any.
+	Entity *entity.SignedEntity
+	// Node is the signed node descriptor, if any.
+	Node *node.MultiSignedNode
+
+	// err is the error encountered while loading the descriptor, if any.
+	err error
+}
+
+// Kind returns the kind of the descriptor.
+func (d *Descriptor) Kind() DescriptorKind {
+	switch {
+	case d.Entity != nil:
+		return KindEntity
+	case d.Node != nil:
+		return KindNode
+	default:
+		return KindUnknown
+	}
+}
+
+// DescriptorReport is the validation report of a single descriptor.
+type DescriptorReport struct {
+	// Source is the name of the file the descriptor has been loaded from or written to.
+	Source string `json:"source"`
+	// Kind is the descriptor kind.
+	Kind DescriptorKind `json:"kind"`
+	// ID is the entity or node identifier, if the descriptor could be opened.
+	ID *signature.PublicKey `json:"id,omitempty"`
+	// EntityID is the identifier of the entity controlling the node, if any.
+	EntityID *signature.PublicKey `json:"entity_id,omitempty"`
+	// Expiration is the epoch at which the node descriptor expires, if any.
+	Expiration uint64 `json:"expiration,omitempty"`
+	// Valid is true iff the descriptor passed all checks.
+	Valid bool `json:"valid"`
+	// Errors are the reasons the descriptor failed validation.
+	Errors []string `json:"errors,omitempty"`
+}
+
+func (r *DescriptorReport) addError(format string, a ...interface{}) {
+	r.Valid = false
+	r.Errors = append(r.Errors, fmt.Sprintf(format, a...))
+}
+
+// Report is the validation report of a set of descriptors.
+type Report struct {
+	// Valid is true iff all descriptors passed all checks.
+	Valid bool `json:"valid"`
+	// Entities is the number of valid entity descriptors.
+	Entities int `json:"entities"`
+	// Nodes is the number of valid node descriptors.
+	Nodes int `json:"nodes"`
+	// Descriptors are the per-descriptor reports.
+	Descriptors []*DescriptorReport `json:"descriptors"`
+}
+
+// LoadDescriptors loads all signed entity and node descriptors (files with a .json extension)
+// from the given directory, ordered by file name.
+//
+// Files that cannot be decoded are returned as descriptors of unknown kind so that they are
+// included in the validation report.
+func LoadDescriptors(dir string) ([]*Descriptor, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var descriptors []*Descriptor
+	for _, fi := range files {
+		if fi.IsDir() || filepath.Ext(fi.Name()) != descriptorSuffix {
+			continue
+		}
+
+		d := &Descriptor{Source: fi.Name()}
+		d.Entity, d.Node, d.err = decodeDescriptor(filepath.Join(dir, fi.Name()))
+		descriptors = append(descriptors, d)
+	}
+	return descriptors, nil
+}
+
+func decodeDescriptor(fn string) (*entity.SignedEntity, *node.MultiSignedNode, error) {
+	b, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Entity descriptors are signed by a single signer, while node descriptors
+	// are signed by all of the node's keys.
+	var probe struct {
+		Signature  json.RawMessage `json:"signature"`
+		Signatures json.RawMessage `json:"signatures"`
+	}
+	if err = json.Unmarshal(b, &probe); err != nil {
+		return nil, nil, fmt.Errorf("malformed descriptor: %w", err)
+	}
+
+	switch {
+	case probe.Signature != nil && probe.Signatures == nil:
+		var sigEnt entity.SignedEntity
+		if err = json.Unmarshal(b, &sigEnt); err != nil {
+			return nil, nil, fmt.Errorf("malformed signed entity descriptor: %w", err)
+		}
+		return &sigEnt, nil, nil
+	case probe.Signatures != nil && probe.Signature == nil:
+		var sigNode node.MultiSignedNode
+		if err = json.Unmarshal(b, &sigNode); err != nil {
+			return nil, nil, fmt.Errorf("malformed signed node descriptor: %w", err)
+		}
+		return nil, &sigNode, nil
+	default:
+		return nil, nil, errors.New("not a signed entity or node descriptor")
+	}
+}
+
+// Validate validates the given signed descriptors as if they were part of the registry genesis
+// section with the given parameters and runtimes at the given base epoch.
+//
+// Each descriptor is checked individually (signatures, descriptor validity, expiration) and
+// against the other descriptors (duplicates, nodes referencing a valid entity which lists them
+// and existing runtimes). An error is only returned in case the runtimes themselves are invalid.
+func Validate(
+	params *registry.ConsensusParameters,
+	runtimes []*registry.Runtime,
+	suspendedRuntimes []*registry.Runtime,
+	epoch beacon.EpochTime,
+	now time.Time,
+	descriptors []*Descriptor,
+) (*Report, error) {
+	runtimeLookup, err := registry.SanityCheckRuntimes(logger, params, runtimes, suspendedRuntimes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Valid:       true,
+		Descriptors: make([]*DescriptorReport, 0, len(descriptors)),
+	}
+	reports := make(map[*Descriptor]*DescriptorReport)
+	for _, d := range descriptors {
+		r := &DescriptorReport{
+			Source: d.Source,
+			Kind:   d.Kind(),
+			Valid:  true,
+		}
+		if d.err != nil {
+			r.addError("%s", d.err)
+		}
+		reports[d] = r
+		report.Descriptors = append(report.Descriptors, r)
+	}
+
+	// Entities need to be checked first as nodes reference them.
+	entities := make(map[signature.PublicKey]*entity.Entity)
+	for _, d := range descriptors {
+		if d.Entity == nil {
+			continue
+		}
+		r := reports[d]
+
+		ent, err := registry.VerifyRegisterEntityArgs(logger, d.Entity, true, true)
+		if err != nil {
+			r.addError("invalid entity descriptor: %s", err)
+			continue
+		}
+		r.ID = &ent.ID
+
+		if _, ok := entities[ent.ID]; ok {
+			r.addError("duplicate entity descriptor")
+			continue
+		}
+		entities[ent.ID] = ent
+		report.Entities++
+	}
+
+	nodeLookup := &nodeLookup{
+		nodes: make(map[signature.PublicKey]*node.Node),
+	}
+	for _, d := range descriptors {
+		if d.Node == nil {
+			continue
+		}
+		r := reports[d]
+
+		var n node.Node
+		if err = d.Node.Open(registry.RegisterGenesisNodeSignatureContext, &n); err != nil {
+			r.addError("invalid node descriptor signature: %s", err)
+			continue
+		}
+		r.ID = &n.ID
+		r.EntityID = &n.EntityID
+		r.Expiration = n.Expiration
+
+		if _, ok := nodeLookup.nodes[n.ID]; ok {
+			r.addError("duplicate node descriptor")
+			continue
+		}
+		if n.IsExpired(uint64(epoch)) {
+			r.addError("node descriptor expired at epoch %d (base epoch: %d)", n.Expiration, epoch)
+		}
+		ent, ok := entities[n.EntityID]
+		if !ok {
+			r.addError("node references a missing or invalid entity")
+			continue
+		}
+		if !ent.HasNode(n.ID) {
+			r.addError("node is not listed in the entity's node list")
+			continue
+		}
+
+		validated, _, err := registry.VerifyRegisterNodeArgs(
+			context.Background(),
+			params,
+			logger,
+			d.Node,
+			ent,
+			now,
+			true,
+			true,
+			epoch,
+			runtimeLookup,
+			nodeLookup,
+		)
+		if err != nil {
+			r.addError("invalid node descriptor: %s", err)
+			continue
+		}
+		if !r.Valid {
+			continue
+		}
+
+		nodeLookup.add(validated)
+		report.Nodes++
+	}
+
+	for _, r := range report.Descriptors {
+		report.Valid = report.Valid && r.Valid
+	}
+
+	return report, nil
+}
+
+// nodeLookup is the node lookup used to detect nodes sharing keys.
+type nodeLookup struct {
+	nodes     map[signature.PublicKey]*node.Node
+	nodesList []*node.Node
+}
+
+func (l *nodeLookup) add(n *node.Node) {
+	l.nodes[n.ID] = n
+	l.nodes[n.Consensus.ID] = n
+	l.nodes[n.P2P.ID] = n
+	l.nodes[n.TLS.PubKey] = n
+	l.nodesList = append(l.nodesList, n)
+}
+
+func (l *nodeLookup) NodeBySubKey(ctx context.Context, key signature.PublicKey) (*node.Node, error) {
+	n, ok := l.nodes[key]
+	if !ok {
+		return nil, registry.ErrNoSuchNode
+	}
+	return n, nil
+}
+
+func (l *nodeLookup) Nodes(ctx context.Context) ([]*node.Node, error) {
+	return l.nodesList, nil
+}
+
+// Import builds the registry genesis section from the given signed descriptors, taking the
+// consensus parameters and runtimes from the given genesis document.
+//
+// The returned registry genesis section is only valid in case the report is valid.
+func Import(doc *genesis.Document, descriptors []*Descriptor) (*registry.Genesis, *Report, error) {
+	report, err := Validate(
+		&doc.Registry.Parameters,
+		doc.Registry.Runtimes,
+		doc.Registry.SuspendedRuntimes,
+		doc.Beacon.Base,
+		doc.Time,
+		descriptors,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid runtimes in genesis document: %w", err)
+	}
+
+	regSt := &registry.Genesis{
+		Parameters:        doc.Registry.Parameters,
+		Runtimes:          doc.Registry.Runtimes,
+		SuspendedRuntimes: doc.Registry.SuspendedRuntimes,
+	}
+	for _, d := range descriptors {
+		switch {
+		case d.Entity != nil:
+			regSt.Entities = append(regSt.Entities, d.Entity)
+		case d.Node != nil:
+			regSt.Nodes = append(regSt.Nodes, d.Node)
+		}
+	}
+
+	return regSt, report, nil
+}
+
+// Export returns the signed descriptors from the registry genesis section of the given genesis
+// document together with their validation report. Descriptor sources are set to the file names
+// the descriptors should be written to.
+func Export(doc *genesis.Document) ([]*Descriptor, *Report, error) {
+	descriptors := make([]*Descriptor, 0, len(doc.Registry.Entities)+len(doc.Registry.Nodes))
+	for i, sigEnt := range doc.Registry.Entities {
+		descriptors = append(descriptors, &Descriptor{
+			Source: exportFilename(entityFilePrefix, i, &sigEnt.Signed),
+			Entity: sigEnt,
+		})
+	}
+	for i, sigNode := range doc.Registry.Nodes {
+		var n node.Node
+		name := fmt.Sprintf("%s%d%s", nodeFilePrefix, i, descriptorSuffix)
+		if err := sigNode.Open(registry.RegisterGenesisNodeSignatureContext, &n); err == nil {
+			name = nodeFilePrefix + hex.EncodeToString(n.ID[:]) + descriptorSuffix
+		}
+		descriptors = append(descriptors, &Descriptor{
+			Source: name,
+			Node:   sigNode,
+		})
+	}
+
+	report, err := Validate(
+		&doc.Registry.Parameters,
+		doc.Registry.Runtimes,
+		doc.Registry.SuspendedRuntimes,
+		doc.Beacon.Base,
+		doc.Time,
+		descriptors,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid runtimes in genesis document: %w", err)
+	}
+
+	return descriptors, report, nil
+}
+
+func exportFilename(prefix string, idx int, signed *signature.Signed) string {
+	// Entity descriptors are signed by the entity key, so the signer
+	// identifies the entity even if the descriptor cannot be opened.
+	if signed != nil && signed.Signature.PublicKey.IsValid() {
+		return prefix + hex.EncodeToString(signed.Signature.PublicKey[:]) + descriptorSuffix
+	}
+	return fmt.Sprintf("%s%d%s", prefix, idx, descriptorSuffix)
+}
+
+func loadGenesis() *genesis.Document {
+	provider, err := genesisFile.NewFileProvider(flags.GenesisFile())
+	if err != nil {
+		logger.Error("failed to load genesis document",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	doc, err := provider.GetGenesisDocument()
+	if err != nil {
+		logger.Error("failed to get genesis document",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	return doc
+}
+
+func printReport(report *Report) {
+	prettyReport, err := cmdCommon.PrettyJSONMarshal(report)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of report",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyReport))
+}
+
+func writeJSON(fn string, v interface{}) error {
+	b, err := cmdCommon.PrettyJSONMarshal(v)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fn, b, 0o600)
+}
+
+func doImport(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	dir, output := viper.GetString(CfgImportDir), viper.GetString(CfgImportOutput)
+	if dir == "" || output == "" {
+		logger.Error("import directory and output file must be set")
+		os.Exit(1)
+	}
+
+	doc := loadGenesis()
+	descriptors, err := LoadDescriptors(dir)
+	if err != nil {
+		logger.Error("failed to load descriptors",
+			"err", err,
+			"dir", dir,
+		)
+		os.Exit(1)
+	}
+
+	regSt, report, err := Import(doc, descriptors)
+	if err != nil {
+		logger.Error("failed to import descriptors",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	printReport(report)
+	if !report.Valid {
+		logger.Error("descriptor validation failed, not writing registry genesis section")
+		os.Exit(1)
+	}
+
+	if err = writeJSON(output, regSt); err != nil {
+		logger.Error("failed to write registry genesis section",
+			"err", err,
+			"filename", output,
+		)
+		os.Exit(1)
+	}
+}
+
+func doExport(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	dir := viper.GetString(CfgExportDir)
+	if dir == "" {
+		logger.Error("export directory must be set")
+		os.Exit(1)
+	}
+
+	doc := loadGenesis()
+	descriptors, report, err := Export(doc)
+	if err != nil {
+		logger.Error("failed to export descriptors",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		logger.Error("failed to create export directory",
+			"err", err,
+			"dir", dir,
+		)
+		os.Exit(1)
+	}
+	for _, d := range descriptors {
+		var v interface{} = d.Entity
+		if d.Kind() == KindNode {
+			v = d.Node
+		}
+		fn := filepath.Join(dir, d.Source)
+		if err = writeJSON(fn, v); err != nil {
+			logger.Error("failed to write descriptor",
+				"err", err,
+				"filename", fn,
+			)
+			os.Exit(1)
+		}
+	}
+
+	// Descriptors are always exported so that they can be fixed up, but the
+	// exit code still signals any validation failures.
+	printReport(report)
+	if !report.Valid {
+		logger.Error("descriptor validation failed")
+		os.Exit(1)
+	}
+}
+
+// Register registers the import and export sub-commands.
+func Register(parentCmd *cobra.Command) {
+	for _, v := range []*cobra.Command{
+		importCmd,
+		exportCmd,
+	} {
+		v.Flags().AddFlagSet(flags.GenesisFileFlags)
+		v.Flags().AddFlagSet(flags.DebugDontBlameOasisFlag)
+		parentCmd.AddCommand(v)
+	}
+
+	importCmd.Flags().AddFlagSet(importFlags)
+	exportCmd.Flags().AddFlagSet(exportFlags)
+}
+
+func init() {
+	importFlags.String(CfgImportDir, "", "directory containing the signed entity and node descriptors")
+	importFlags.String(CfgImportOutput, "", "file to write the registry genesis section to")
+	_ = viper.BindPFlags(importFlags)
+
+	exportFlags.String(CfgExportDir, "", "directory to write the signed entity and node descriptors to")
+	_ = viper.BindPFlags(exportFlags)
+}
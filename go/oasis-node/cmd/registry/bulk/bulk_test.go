@@ -0,0 +1,191 @@
+package bulk
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+func newTestNode(t *testing.T, name string, entityID signature.PublicKey, expiration uint64) (*node.Node, *node.MultiSignedNode) {
+	nodeSigner := memorySigner.NewTestSigner(name + " node signer")
+	consensusSigner := memorySigner.NewTestSigner(name + " consensus signer")
+	p2pSigner := memorySigner.NewTestSigner(name + " P2P signer")
+	tlsSigner := memorySigner.NewTestSigner(name + " TLS signer")
+
+	var consensusAddress node.ConsensusAddress
+	require.NoError(t, consensusAddress.UnmarshalText([]byte("AAAAAAAAAAAAAAAAAAAABBBBBBBBBBBBBBBBBBBBBBA=@127.0.0.1:1234")))
+	var address node.Address
+	require.NoError(t, address.UnmarshalText([]byte("127.0.0.1:1234")))
+
+	n := &node.Node{
+		Versioned:  cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+		ID:         nodeSigner.Public(),
+		EntityID:   entityID,
+		Expiration: expiration,
+		Roles:      node.RoleValidator,
+		TLS: node.TLSInfo{
+			PubKey: tlsSigner.Public(),
+			Addresses: []node.TLSAddress{
+				{PubKey: tlsSigner.Public(), Address: address},
+			},
+		},
+		P2P: node.P2PInfo{
+			ID:        p2pSigner.Public(),
+			Addresses: []node.Address{address},
+		},
+		Consensus: node.ConsensusInfo{
+			ID:        consensusSigner.Public(),
+			Addresses: []node.ConsensusAddress{consensusAddress},
+		},
+	}
+	sigNode, err := node.MultiSignNode(
+		[]signature.Signer{nodeSigner, p2pSigner, tlsSigner, consensusSigner},
+		registry.RegisterGenesisNodeSignatureContext,
+		n,
+	)
+	require.NoError(t, err, "MultiSignNode")
+	return n, sigNode
+}
+
+func newTestDocument() *genesis.Document {
+	return &genesis.Document{
+		Time: time.Unix(1_600_000_000, 0),
+		Registry: registry.Genesis{
+			Parameters: registry.ConsensusParameters{
+				DebugAllowUnroutableAddresses: true,
+				MaxNodeExpiration:             10,
+			},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	require := require.New(t)
+
+	entitySigner := memorySigner.NewTestSigner("bulk test entity signer")
+	otherSigner := memorySigner.NewTestSigner("bulk test other entity signer")
+
+	validNode, sigValidNode := newTestNode(t, "valid", entitySigner.Public(), 5)
+	expiredNode, sigExpiredNode := newTestNode(t, "expired", entitySigner.Public(), 1)
+	_, sigUnlistedNode := newTestNode(t, "unlisted", entitySigner.Public(), 5)
+	_, sigOrphanNode := newTestNode(t, "orphan", otherSigner.Public(), 5)
+
+	ent := &entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+		Nodes:     []signature.PublicKey{validNode.ID, expiredNode.ID},
+	}
+	sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterGenesisEntitySignatureContext, ent)
+	require.NoError(err, "SignEntity")
+	// Entities signed by a key other than the entity key are not valid.
+	sigBadEnt, err := entity.SignEntity(otherSigner, registry.RegisterGenesisEntitySignatureContext, &entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+	})
+	require.NoError(err, "SignEntity")
+
+	doc := newTestDocument()
+	doc.Beacon.Base = 2
+
+	report, err := Validate(&doc.Registry.Parameters, nil, nil, doc.Beacon.Base, doc.Time, []*Descriptor{
+		{Source: "entity", Entity: sigEnt},
+		{Source: "bad_entity", Entity: sigBadEnt},
+		{Source: "duplicate_entity", Entity: sigEnt},
+		{Source: "node", Node: sigValidNode},
+		{Source: "expired_node", Node: sigExpiredNode},
+		{Source: "unlisted_node", Node: sigUnlistedNode},
+		{Source: "orphan_node", Node: sigOrphanNode},
+		{Source: "duplicate_node", Node: sigValidNode},
+	})
+	require.NoError(err, "Validate")
+	require.False(report.Valid, "report should not be valid")
+	require.Equal(1, report.Entities, "valid entities")
+	require.Equal(1, report.Nodes, "valid nodes")
+
+	validity := make(map[string]bool)
+	for _, r := range report.Descriptors {
+		validity[r.Source] = r.Valid
+		if !r.Valid {
+			require.NotEmpty(r.Errors, "invalid descriptors should report errors")
+		}
+	}
+	require.EqualValues(map[string]bool{
+		"entity":           true,
+		"bad_entity":       false,
+		"duplicate_entity": false,
+		"node":             true,
+		"expired_node":     false,
+		"unlisted_node":    false,
+		"orphan_node":      false,
+		"duplicate_node":   false,
+	}, validity)
+	require.Equal(&validNode.ID, report.Descriptors[3].ID, "node ID should be reported")
+	require.Equal(&ent.ID, report.Descriptors[3].EntityID, "node entity ID should be reported")
+	require.EqualValues(5, report.Descriptors[3].Expiration, "node expiration should be reported")
+}
+
+func TestImportExport(t *testing.T) {
+	require := require.New(t)
+
+	entitySigner := memorySigner.NewTestSigner("bulk test entity signer")
+	n, sigNode := newTestNode(t, "valid", entitySigner.Public(), 5)
+	sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterGenesisEntitySignatureContext, &entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+		Nodes:     []signature.PublicKey{n.ID},
+	})
+	require.NoError(err, "SignEntity")
+
+	doc := newTestDocument()
+	doc.Registry.Entities = []*entity.SignedEntity{sigEnt}
+	doc.Registry.Nodes = []*node.MultiSignedNode{sigNode}
+
+	descriptors, report, err := Export(doc)
+	require.NoError(err, "Export")
+	require.True(report.Valid, "exported descriptors should be valid")
+	require.Len(descriptors, 2)
+
+	dir := t.TempDir()
+	for _, d := range descriptors {
+		var v interface{} = d.Entity
+		if d.Kind() == KindNode {
+			v = d.Node
+		}
+		require.NoError(writeJSON(filepath.Join(dir, d.Source), v), "writeJSON")
+	}
+	require.NoError(ioutil.WriteFile(filepath.Join(dir, "garbage.json"), []byte("{}"), 0o600))
+	require.NoError(ioutil.WriteFile(filepath.Join(dir, "README"), []byte("ignored"), 0o600))
+
+	loaded, err := LoadDescriptors(dir)
+	require.NoError(err, "LoadDescriptors")
+	require.Len(loaded, 3, "non-JSON files should be ignored")
+
+	_, report, err = Import(newTestDocument(), loaded)
+	require.NoError(err, "Import")
+	require.False(report.Valid, "undecodable descriptors should invalidate the report")
+	require.Equal(KindUnknown, report.Descriptors[1].Kind, "garbage.json should sort between entity and node")
+
+	loaded = append(loaded[:1], loaded[2:]...)
+	regSt, report, err := Import(newTestDocument(), loaded)
+	require.NoError(err, "Import")
+	require.True(report.Valid, "imported descriptors should be valid")
+
+	// The imported registry genesis section should round-trip.
+	expected, err := json.Marshal(doc.Registry)
+	require.NoError(err)
+	actual, err := json.Marshal(regSt)
+	require.NoError(err)
+	require.JSONEq(string(expected), string(actual), "imported registry genesis section should match")
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	signerFile "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
@@ -27,14 +28,23 @@ import (
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
 	cmdSigner "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/signer"
+	cmdWatch "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/registry/watch"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 )
 
 const (
 	CfgNodeID         = "entity.node.id"
 	CfgNodeDescriptor = "entity.node.descriptor"
+	CfgMetadataURL    = "entity.metadata_url"
+	CfgKeyUsage       = "entity.key_usage"
 	CfgReuseSigner    = "entity.reuse_signer"
+	CfgWatchEntityID  = "entity.watch.id"
 
+	keyUsageNodes      = "nodes"
+	keyUsageRuntimes   = "runtimes"
+	keyUsageGovernance = "governance"
+
+	entityFilename        = "entity.json"
 	entityGenesisFilename = "entity_genesis.json"
 )
 
@@ -43,6 +53,7 @@ var (
 	initFlags                 = flag.NewFlagSet("", flag.ContinueOnError)
 	updateFlags               = flag.NewFlagSet("", flag.ContinueOnError)
 	registerOrDeregisterFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	watchFlags                = flag.NewFlagSet("", flag.ContinueOnError)
 
 	entityCmd = &cobra.Command{
 		Use:   "entity",
@@ -79,6 +90,12 @@ var (
 		Run:   doList,
 	}
 
+	watchCmd = &cobra.Command{
+		Use:   "watch",
+		Short: "watch the registration status of an entity and its nodes",
+		Run:   doWatch,
+	}
+
 	logger = logging.GetLogger("cmd/registry/entity")
 )
 
@@ -225,6 +242,28 @@ func doUpdate(cmd *cobra.Command, args []string) {
 		ent.Nodes = append(ent.Nodes, n.ID)
 	}
 
+	ent.MetadataURL = viper.GetString(CfgMetadataURL)
+	ent.KeyUsage = nil
+	if usages := viper.GetStringSlice(CfgKeyUsage); len(usages) > 0 {
+		ent.KeyUsage = &entity.KeyUsage{}
+		for _, v := range usages {
+			switch v {
+			case keyUsageNodes:
+				ent.KeyUsage.CanSignNodes = true
+			case keyUsageRuntimes:
+				ent.KeyUsage.CanSignRuntimes = true
+			case keyUsageGovernance:
+				ent.KeyUsage.CanSignGovernance = true
+			default:
+				logger.Error("invalid key usage",
+					"key_usage", v,
+				)
+				os.Exit(1)
+			}
+		}
+	}
+	ent.Versioned = cbor.NewVersioned(entity.LatestDescriptorVersion)
+
 	// De-duplicate the entity's nodes.
 	nodeMap := make(map[signature.PublicKey]bool)
 	for _, v := range ent.Nodes {
@@ -235,6 +274,13 @@ func doUpdate(cmd *cobra.Command, args []string) {
 		ent.Nodes = append(ent.Nodes, k)
 	}
 
+	if err = ent.ValidateBasic(true); err != nil {
+		logger.Error("invalid entity descriptor",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
 	// Save the entity descriptor.
 	if err = ent.Save(dataDir); err != nil {
 		logger.Error("failed to persist entity descriptor",
@@ -364,6 +410,60 @@ func doList(cmd *cobra.Command, args []string) {
 	}
 }
 
+func doWatch(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	// Determine the identifier of the watched entity, either explicitly
+	// specified or from the entity descriptor.
+	var entityID signature.PublicKey
+	switch idStr := viper.GetString(CfgWatchEntityID); idStr {
+	case "":
+		dataDir, err := cmdSigner.CLIDirOrPwd()
+		if err != nil {
+			logger.Error("failed to query data directory",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		ent, err := entity.LoadDescriptor(filepath.Join(dataDir, entityFilename))
+		if err != nil {
+			logger.Error("failed to load entity descriptor",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		entityID = ent.ID
+	default:
+		if err := entityID.UnmarshalText([]byte(idStr)); err != nil {
+			logger.Error("malformed entity ID",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	cfg := cmdWatch.NewConfig(os.Stdout)
+	cfg.EntityID = &entityID
+	w, err := cmdWatch.New(cfg, client, beacon.NewBeaconClient(conn))
+	if err != nil {
+		logger.Error("failed to create watcher",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if err = w.Run(context.Background()); err != nil {
+		logger.Error("failed to watch entity",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
 func loadOrGenerateEntity(dataDir string, generate bool) (*entity.Entity, signature.Signer, error) {
 	if cmdFlags.DebugTestEntity() {
 		return entity.TestEntity()
@@ -408,6 +508,7 @@ func Register(parentCmd *cobra.Command) {
 		registerCmd,
 		deregisterCmd,
 		listCmd,
+		watchCmd,
 	} {
 		entityCmd.AddCommand(v)
 	}
@@ -420,6 +521,10 @@ func Register(parentCmd *cobra.Command) {
 	listCmd.Flags().AddFlagSet(cmdFlags.VerboseFlags)
 	listCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 
+	watchCmd.Flags().AddFlagSet(watchFlags)
+	watchCmd.Flags().AddFlagSet(cmdWatch.Flags)
+	watchCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+
 	parentCmd.AddCommand(entityCmd)
 }
 
@@ -437,6 +542,8 @@ func init() {
 
 	updateFlags.StringSlice(CfgNodeID, nil, "ID(s) of nodes associated with this entity")
 	updateFlags.StringSlice(CfgNodeDescriptor, nil, "Node genesis descriptor(s) of nodes associated with this entity")
+	updateFlags.String(CfgMetadataURL, "", "URL of the entity metadata")
+	updateFlags.StringSlice(CfgKeyUsage, nil, "Allowed entity key usage(s): nodes, runtimes, governance (default: unconstrained)")
 	_ = viper.BindPFlags(updateFlags)
 	updateFlags.AddFlagSet(cmdFlags.DebugTestEntityFlags)
 	updateFlags.AddFlagSet(cmdFlags.DebugDontBlameOasisFlag)
@@ -445,4 +552,8 @@ func init() {
 	registerOrDeregisterFlags.AddFlagSet(cmdFlags.DebugTestEntityFlags)
 	registerOrDeregisterFlags.AddFlagSet(cmdConsensus.TxFlags)
 	registerOrDeregisterFlags.AddFlagSet(cmdFlags.AssumeYesFlag)
+
+	watchFlags.String(CfgWatchEntityID, "", "ID of the watched entity (default: entity in the signer directory)")
+	_ = viper.BindPFlags(watchFlags)
+	watchFlags.AddFlagSet(entityFlags)
 }
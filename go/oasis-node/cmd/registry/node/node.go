@@ -14,6 +14,7 @@ import (
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
@@ -26,6 +27,7 @@ import (
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
 	cmdSigner "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/signer"
+	cmdWatch "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/registry/watch"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/configparser"
 )
@@ -39,6 +41,7 @@ const (
 	CfgRole             = "node.role"
 	CfgSelfSigned       = "node.is_self_signed"
 	CfgNodeRuntimeID    = "node.runtime.id"
+	CfgWatchNodeID      = "node.watch.id"
 
 	optRoleComputeWorker = "compute-worker"
 	optRoleKeyManager    = "key-manager"
@@ -50,7 +53,8 @@ const (
 )
 
 var (
-	flags = flag.NewFlagSet("", flag.ContinueOnError)
+	flags      = flag.NewFlagSet("", flag.ContinueOnError)
+	watchFlags = flag.NewFlagSet("", flag.ContinueOnError)
 
 	nodeCmd = &cobra.Command{
 		Use:   "node",
@@ -75,6 +79,12 @@ var (
 		Run:   doIsRegistered,
 	}
 
+	watchCmd = &cobra.Command{
+		Use:   "watch",
+		Short: "watch the registration status of the node",
+		Run:   doWatch,
+	}
+
 	logger = logging.GetLogger("cmd/registry/node")
 )
 
@@ -328,11 +338,7 @@ func doList(cmd *cobra.Command, args []string) {
 	}
 }
 
-func doIsRegistered(cmd *cobra.Command, args []string) {
-	if err := cmdCommon.Init(); err != nil {
-		cmdCommon.EarlyLogAndExit(err)
-	}
-
+func loadNodeID() signature.PublicKey {
 	dataDir, err := cmdCommon.DataDirOrPwd()
 	if err != nil {
 		logger.Error("failed to query data directory",
@@ -356,6 +362,15 @@ func doIsRegistered(cmd *cobra.Command, args []string) {
 		)
 		os.Exit(1)
 	}
+	return nodeIdentity.NodeSigner.Public()
+}
+
+func doIsRegistered(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	nodeID := loadNodeID()
 
 	conn, client := doConnect(cmd)
 	defer conn.Close()
@@ -369,7 +384,7 @@ func doIsRegistered(cmd *cobra.Command, args []string) {
 	}
 
 	for _, node := range nodes {
-		if node.ID.Equal(nodeIdentity.NodeSigner.Public()) {
+		if node.ID.Equal(nodeID) {
 			fmt.Println("node is registered")
 			os.Exit(0)
 		}
@@ -378,6 +393,46 @@ func doIsRegistered(cmd *cobra.Command, args []string) {
 	os.Exit(1)
 }
 
+func doWatch(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	// Determine the identifier of the watched node, either explicitly
+	// specified or from the node's identity.
+	var nodeID signature.PublicKey
+	switch idStr := viper.GetString(CfgWatchNodeID); idStr {
+	case "":
+		nodeID = loadNodeID()
+	default:
+		if err := nodeID.UnmarshalText([]byte(idStr)); err != nil {
+			logger.Error("malformed node ID",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	cfg := cmdWatch.NewConfig(os.Stdout)
+	cfg.NodeID = &nodeID
+	w, err := cmdWatch.New(cfg, client, beacon.NewBeaconClient(conn))
+	if err != nil {
+		logger.Error("failed to create watcher",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if err = w.Run(context.Background()); err != nil {
+		logger.Error("failed to watch node",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
 // Register registers the node sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	initCmd.Flags().AddFlagSet(flags)
@@ -390,10 +445,15 @@ func Register(parentCmd *cobra.Command) {
 
 	isRegisteredCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 
+	watchCmd.Flags().AddFlagSet(watchFlags)
+	watchCmd.Flags().AddFlagSet(cmdWatch.Flags)
+	watchCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+
 	for _, subCmd := range []*cobra.Command{
 		initCmd,
 		listCmd,
 		isRegisteredCmd,
+		watchCmd,
 	} {
 		nodeCmd.AddCommand(subCmd)
 	}
@@ -411,4 +471,7 @@ func init() {
 	flags.StringSlice(CfgNodeRuntimeID, nil, "Hex Encoded Runtime ID(s) of the node.")
 
 	_ = viper.BindPFlags(flags)
+
+	watchFlags.String(CfgWatchNodeID, "", "ID of the watched node (default: node in the data directory)")
+	_ = viper.BindPFlags(watchFlags)
 }
@@ -4,6 +4,7 @@ package registry
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/registry/bulk"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/registry/entity"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/registry/node"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/registry/runtime"
@@ -16,6 +17,7 @@ var registryCmd = &cobra.Command{
 
 // Register registers the registry sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
+	bulk.Register(registryCmd)
 	entity.Register(registryCmd)
 	node.Register(registryCmd)
 	runtime.Register(registryCmd)
@@ -0,0 +1,401 @@
+// Package watch implements the registration watcher used by the entity and
+// node registry watch sub-commands.
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+const (
+	// CfgWebhookURL configures the URL of the webhook that alerts are posted to.
+	CfgWebhookURL = "watch.webhook_url"
+	// CfgExpirationWarning configures the number of epochs before the node
+	// descriptor expiration when an expiration warning is emitted.
+	CfgExpirationWarning = "watch.expiration_warning"
+
+	webhookTimeout = 10 * time.Second
+)
+
+// Flags has the flags used by the registration watcher.
+var Flags = flag.NewFlagSet("", flag.ContinueOnError)
+
+// AlertKind is the kind of a watcher alert.
+type AlertKind string
+
+const (
+	// AlertNodeRegistered is emitted when a watched node (re-)registers.
+	AlertNodeRegistered AlertKind = "node_registered"
+	// AlertNodeDeregistered is emitted when a watched node is removed from the registry.
+	AlertNodeDeregistered AlertKind = "node_deregistered"
+	// AlertNodeExpiring is emitted when a watched node's descriptor is about to expire.
+	AlertNodeExpiring AlertKind = "node_expiring"
+	// AlertNodeExpired is emitted when a watched node's descriptor has expired without the
+	// node re-registering in time.
+	AlertNodeExpired AlertKind = "node_expired"
+	// AlertNodeEligible is emitted when a watched node becomes eligible for committee elections.
+	AlertNodeEligible AlertKind = "node_eligible"
+	// AlertNodeIneligible is emitted when a watched node stops being eligible for committee
+	// elections (e.g., because it has been frozen).
+	AlertNodeIneligible AlertKind = "node_ineligible"
+	// AlertEntityRegistered is emitted when the watched entity (re-)registers.
+	AlertEntityRegistered AlertKind = "entity_registered"
+	// AlertEntityDeregistered is emitted when the watched entity is deregistered.
+	AlertEntityDeregistered AlertKind = "entity_deregistered"
+)
+
+// Alert is an alert emitted by the registration watcher.
+type Alert struct {
+	// Kind is the alert kind.
+	Kind AlertKind `json:"kind"`
+	// Epoch is the epoch at which the alert has been emitted.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// EntityID is the identifier of the entity the alert is about.
+	EntityID signature.PublicKey `json:"entity_id"`
+	// NodeID is the identifier of the node the alert is about, if any.
+	NodeID *signature.PublicKey `json:"node_id,omitempty"`
+	// Expiration is the epoch at which the node descriptor expires, if any.
+	Expiration uint64 `json:"expiration,omitempty"`
+	// Message is a human readable description of the alert.
+	Message string `json:"message"`
+}
+
+// String returns a string representation of the alert.
+func (a *Alert) String() string {
+	if a.NodeID != nil {
+		return fmt.Sprintf("[epoch %d] %s: node %s: %s", a.Epoch, a.Kind, a.NodeID, a.Message)
+	}
+	return fmt.Sprintf("[epoch %d] %s: entity %s: %s", a.Epoch, a.Kind, a.EntityID, a.Message)
+}
+
+// Config is the registration watcher configuration.
+type Config struct {
+	// EntityID is the identifier of the watched entity. If set, all nodes of the entity are
+	// watched and the entity itself is watched for (de)registrations.
+	EntityID *signature.PublicKey
+	// NodeID is the identifier of the watched node. It is ignored if EntityID is set.
+	NodeID *signature.PublicKey
+	// ExpirationWarning is the number of epochs before the node descriptor expiration when an
+	// expiration warning is emitted.
+	ExpirationWarning uint64
+	// WebhookURL is the optional URL of the webhook that alerts are posted to as JSON.
+	WebhookURL string
+	// Output is where alerts are written to.
+	Output io.Writer
+}
+
+// NewConfig creates a new registration watcher configuration from the command line flags.
+func NewConfig(output io.Writer) *Config {
+	return &Config{
+		ExpirationWarning: viper.GetUint64(CfgExpirationWarning),
+		WebhookURL:        viper.GetString(CfgWebhookURL),
+		Output:            output,
+	}
+}
+
+type nodeState struct {
+	node     *node.Node
+	eligible bool
+	expiring bool
+	expired  bool
+}
+
+// Watcher watches the registry for changes of an entity's or a node's registration status.
+type Watcher struct {
+	cfg *Config
+
+	registry registry.Backend
+	beacon   beacon.Backend
+
+	client *http.Client
+	logger *logging.Logger
+
+	epoch beacon.EpochTime
+	nodes map[signature.PublicKey]*nodeState
+}
+
+func (w *Watcher) isWatched(n *node.Node) bool {
+	if w.cfg.EntityID != nil {
+		return n.EntityID.Equal(*w.cfg.EntityID)
+	}
+	return w.cfg.NodeID != nil && n.ID.Equal(*w.cfg.NodeID)
+}
+
+func (w *Watcher) emit(ctx context.Context, alert *Alert) {
+	alert.Epoch = w.epoch
+	fmt.Fprintln(w.cfg.Output, alert)
+
+	if w.cfg.WebhookURL == "" {
+		return
+	}
+	if err := w.postWebhook(ctx, alert); err != nil {
+		w.logger.Error("failed to post alert to webhook",
+			"err", err,
+			"kind", alert.Kind,
+		)
+	}
+}
+
+func (w *Watcher) postWebhook(ctx context.Context, alert *Alert) error {
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.cfg.WebhookURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected webhook response status: %s", resp.Status)
+	}
+	return nil
+}
+
+func (w *Watcher) nodeAlert(kind AlertKind, n *node.Node, msg string) *Alert {
+	return &Alert{
+		Kind:       kind,
+		EntityID:   n.EntityID,
+		NodeID:     &n.ID,
+		Expiration: n.Expiration,
+		Message:    msg,
+	}
+}
+
+func (w *Watcher) isEligible(ctx context.Context, n *node.Node) (bool, error) {
+	status, err := w.registry.GetNodeStatus(ctx, &registry.IDQuery{
+		Height: consensus.HeightLatest,
+		ID:     n.ID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to query node status: %w", err)
+	}
+	return status.IsElectionEligible(w.epoch) && !n.IsExpired(uint64(w.epoch)), nil
+}
+
+// checkNode checks the status of the given node at the current epoch and emits any alerts.
+func (w *Watcher) checkNode(ctx context.Context, st *nodeState) error {
+	n := st.node
+	switch {
+	case n.IsExpired(uint64(w.epoch)):
+		if !st.expired {
+			w.emit(ctx, w.nodeAlert(AlertNodeExpired, n, "node descriptor has expired, node did not re-register in time"))
+			st.expired = true
+		}
+	case n.Expiration <= uint64(w.epoch)+w.cfg.ExpirationWarning:
+		if !st.expiring {
+			w.emit(ctx, w.nodeAlert(AlertNodeExpiring, n, fmt.Sprintf("node descriptor expires at epoch %d", n.Expiration)))
+			st.expiring = true
+		}
+	default:
+		st.expiring = false
+		st.expired = false
+	}
+
+	eligible, err := w.isEligible(ctx, n)
+	if err != nil {
+		return err
+	}
+	switch {
+	case eligible && !st.eligible:
+		w.emit(ctx, w.nodeAlert(AlertNodeEligible, n, "node is eligible for committee elections"))
+	case !eligible && st.eligible:
+		w.emit(ctx, w.nodeAlert(AlertNodeIneligible, n, "node is no longer eligible for committee elections"))
+	}
+	st.eligible = eligible
+
+	return nil
+}
+
+func (w *Watcher) onEpoch(ctx context.Context, epoch beacon.EpochTime) error {
+	w.epoch = epoch
+	for _, st := range w.nodes {
+		if err := w.checkNode(ctx, st); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Watcher) onNodeEvent(ctx context.Context, ev *registry.NodeEvent) error {
+	if !w.isWatched(ev.Node) {
+		return nil
+	}
+
+	if !ev.IsRegistration {
+		delete(w.nodes, ev.Node.ID)
+		w.emit(ctx, w.nodeAlert(AlertNodeDeregistered, ev.Node, "node has been removed from the registry"))
+		return nil
+	}
+
+	st, ok := w.nodes[ev.Node.ID]
+	if !ok {
+		st = &nodeState{}
+		w.nodes[ev.Node.ID] = st
+	}
+	st.node = ev.Node
+	w.emit(ctx, w.nodeAlert(AlertNodeRegistered, ev.Node, fmt.Sprintf("node registered, descriptor expires at epoch %d", ev.Node.Expiration)))
+
+	return w.checkNode(ctx, st)
+}
+
+func (w *Watcher) onEntityEvent(ctx context.Context, ev *registry.EntityEvent) {
+	if w.cfg.EntityID == nil || !ev.Entity.ID.Equal(*w.cfg.EntityID) {
+		return
+	}
+
+	alert := &Alert{
+		Kind:     AlertEntityRegistered,
+		EntityID: ev.Entity.ID,
+		Message:  "entity registered",
+	}
+	if !ev.IsRegistration {
+		alert.Kind = AlertEntityDeregistered
+		alert.Message = "entity has been deregistered"
+	}
+	w.emit(ctx, alert)
+}
+
+func (w *Watcher) init(ctx context.Context) error {
+	epoch, err := w.beacon.GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to query current epoch: %w", err)
+	}
+	w.epoch = epoch
+
+	if w.cfg.EntityID != nil {
+		if _, err = w.registry.GetEntity(ctx, &registry.IDQuery{Height: consensus.HeightLatest, ID: *w.cfg.EntityID}); err != nil {
+			w.emit(ctx, &Alert{
+				Kind:     AlertEntityDeregistered,
+				EntityID: *w.cfg.EntityID,
+				Message:  fmt.Sprintf("entity is not registered: %s", err),
+			})
+		}
+	}
+
+	nodes, err := w.registry.GetNodes(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to query nodes: %w", err)
+	}
+	for _, n := range nodes {
+		if !w.isWatched(n) {
+			continue
+		}
+		w.nodes[n.ID] = &nodeState{node: n}
+	}
+	if w.cfg.EntityID == nil && len(w.nodes) == 0 {
+		fmt.Fprintf(w.cfg.Output, "[epoch %d] node %s is not registered\n", w.epoch, w.cfg.NodeID)
+	}
+
+	// Report the initial eligibility of all watched nodes.
+	for _, st := range w.nodes {
+		if st.eligible, err = w.isEligible(ctx, st.node); err != nil {
+			return err
+		}
+		fmt.Fprintf(w.cfg.Output, "[epoch %d] node %s: registered, descriptor expires at epoch %d, eligible for elections: %t\n",
+			w.epoch, st.node.ID, st.node.Expiration, st.eligible,
+		)
+	}
+	return nil
+}
+
+// Run runs the watcher until the context is canceled or an error occurs.
+func (w *Watcher) Run(ctx context.Context) error {
+	nodeCh, nodeSub, err := w.registry.WatchNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to watch nodes: %w", err)
+	}
+	defer nodeSub.Close()
+
+	entityCh, entitySub, err := w.registry.WatchEntities(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to watch entities: %w", err)
+	}
+	defer entitySub.Close()
+
+	epochCh, epochSub, err := w.beacon.WatchEpochs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to watch epochs: %w", err)
+	}
+	defer epochSub.Close()
+
+	if err = w.init(ctx); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case epoch, ok := <-epochCh:
+			if !ok {
+				return fmt.Errorf("epoch watch channel closed")
+			}
+			if epoch == w.epoch {
+				continue
+			}
+			if err = w.onEpoch(ctx, epoch); err != nil {
+				return err
+			}
+		case ev, ok := <-nodeCh:
+			if !ok {
+				return fmt.Errorf("node watch channel closed")
+			}
+			if err = w.onNodeEvent(ctx, ev); err != nil {
+				return err
+			}
+		case ev, ok := <-entityCh:
+			if !ok {
+				return fmt.Errorf("entity watch channel closed")
+			}
+			w.onEntityEvent(ctx, ev)
+		}
+	}
+}
+
+// New creates a new registration watcher.
+func New(cfg *Config, registryBackend registry.Backend, beaconBackend beacon.Backend) (*Watcher, error) {
+	if cfg.EntityID == nil && cfg.NodeID == nil {
+		return nil, fmt.Errorf("watch: either an entity or a node identifier is required")
+	}
+
+	return &Watcher{
+		cfg:      cfg,
+		registry: registryBackend,
+		beacon:   beaconBackend,
+		client:   &http.Client{},
+		logger:   logging.GetLogger("cmd/registry/watch"),
+		nodes:    make(map[signature.PublicKey]*nodeState),
+	}, nil
+}
+
+func init() {
+	Flags.String(CfgWebhookURL, "", "URL of the webhook that alerts are posted to as JSON")
+	Flags.Uint64(CfgExpirationWarning, 1, "Number of epochs before the node descriptor expiration when a warning is emitted")
+	_ = viper.BindPFlags(Flags)
+}
@@ -0,0 +1,107 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+type testRegistry struct {
+	registry.Backend
+
+	status registry.NodeStatus
+}
+
+func (r *testRegistry) GetNodeStatus(ctx context.Context, query *registry.IDQuery) (*registry.NodeStatus, error) {
+	status := r.status
+	return &status, nil
+}
+
+func TestWatcherAlerts(t *testing.T) {
+	require := require.New(t)
+
+	var alerts []*Alert
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert Alert
+		require.NoError(json.NewDecoder(r.Body).Decode(&alert))
+		alerts = append(alerts, &alert)
+	}))
+	defer srv.Close()
+
+	entityID := signature.NewPublicKey("4ea5328f943ef6f66daaed74cb0e99c3b1c45f76307b425003dbc7cb3638ed35")
+	nodeID := signature.NewPublicKey("ab5f67c9b4b3b4f8b1fc3c0bce1f6e1ad3aa0e4d7fd0b57dcb3e1de0b3b16f7f")
+
+	var out bytes.Buffer
+	reg := &testRegistry{
+		status: registry.NodeStatus{ElectionEligibleAfter: beacon.EpochInvalid},
+	}
+	w, err := New(&Config{
+		EntityID:          &entityID,
+		ExpirationWarning: 1,
+		WebhookURL:        srv.URL,
+		Output:            &out,
+	}, reg, nil)
+	require.NoError(err, "New")
+
+	// Nodes of other entities should be ignored.
+	err = w.onNodeEvent(context.Background(), &registry.NodeEvent{
+		Node:           &node.Node{ID: nodeID, Expiration: 10},
+		IsRegistration: true,
+	})
+	require.NoError(err, "onNodeEvent")
+	require.Empty(alerts, "nodes of other entities should be ignored")
+
+	n := &node.Node{ID: nodeID, EntityID: entityID, Expiration: 10}
+	w.epoch = 5
+	err = w.onNodeEvent(context.Background(), &registry.NodeEvent{Node: n, IsRegistration: true})
+	require.NoError(err, "onNodeEvent")
+	require.Len(alerts, 1)
+	require.Equal(AlertNodeRegistered, alerts[0].Kind)
+	require.EqualValues(5, alerts[0].Epoch)
+	require.True(alerts[0].NodeID.Equal(nodeID))
+
+	// Node becomes eligible for elections.
+	reg.status.ElectionEligibleAfter = 5
+	require.NoError(w.onEpoch(context.Background(), 6), "onEpoch")
+	require.Len(alerts, 2)
+	require.Equal(AlertNodeEligible, alerts[1].Kind)
+
+	// Node descriptor is about to expire.
+	require.NoError(w.onEpoch(context.Background(), 9), "onEpoch")
+	require.Len(alerts, 3)
+	require.Equal(AlertNodeExpiring, alerts[2].Kind)
+
+	// Expiration warning should only be emitted once.
+	require.NoError(w.onEpoch(context.Background(), 10), "onEpoch")
+	require.Len(alerts, 3)
+
+	// Node did not re-register in time.
+	require.NoError(w.onEpoch(context.Background(), 11), "onEpoch")
+	require.Len(alerts, 5)
+	require.Equal(AlertNodeExpired, alerts[3].Kind)
+	require.Equal(AlertNodeIneligible, alerts[4].Kind)
+
+	// Node is removed from the registry.
+	err = w.onNodeEvent(context.Background(), &registry.NodeEvent{Node: n, IsRegistration: false})
+	require.NoError(err, "onNodeEvent")
+	require.Len(alerts, 6)
+	require.Equal(AlertNodeDeregistered, alerts[5].Kind)
+	require.Empty(w.nodes)
+
+	require.Contains(out.String(), "node_expired")
+}
+
+func TestNewWatcher(t *testing.T) {
+	_, err := New(&Config{}, nil, nil)
+	require.Error(t, err, "New should fail without an entity or node identifier")
+}
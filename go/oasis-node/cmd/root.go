@@ -9,6 +9,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/config"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/consensus"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/control"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug"
@@ -22,6 +23,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/signer"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/stake"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/storage"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/wizard"
 )
 
 var rootCmd = &cobra.Command{
@@ -73,6 +75,7 @@ func init() {
 
 	// Register all of the sub-commands.
 	for _, v := range []func(*cobra.Command){
+		config.Register,
 		control.Register,
 		debug.Register,
 		genesis.Register,
@@ -84,6 +87,7 @@ func init() {
 		signer.Register,
 		stake.Register,
 		storage.Register,
+		wizard.Register,
 		consensus.Register,
 		node.Register,
 	} {
@@ -2,15 +2,26 @@
 package signer
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	cmdSigner "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/signer"
 )
 
+const (
+	cfgNewPassphraseEnv = "new_passphrase_env"
+	cfgNewPassphraseFD  = "new_passphrase_fd"
+)
+
 var (
 	signerCmd = &cobra.Command{
 		Use:   "signer",
@@ -23,6 +34,14 @@ var (
 		Run:   doExport,
 	}
 
+	changePassphraseCmd = &cobra.Command{
+		Use:   "change-passphrase",
+		Short: "change the passphrase of the file signer private keys",
+		Run:   doChangePassphrase,
+	}
+
+	changePassphraseFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
 	logger = logging.GetLogger("cmd/signer")
 )
 
@@ -45,10 +64,84 @@ func doExport(cmd *cobra.Command, args []string) {
 	}
 }
 
+func readNewPassphrase() ([]byte, error) {
+	envVar, fd := viper.GetString(cfgNewPassphraseEnv), viper.GetInt(cfgNewPassphraseFD)
+	if envVar != "" || fd >= 0 {
+		return cmdSigner.ReadPassphrase(envVar, fd, "")
+	}
+
+	passphrase, err := cmdSigner.ReadPassphrase("", -1, "New signer passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	confirmation, err := cmdSigner.ReadPassphrase("", -1, "Repeat new signer passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(passphrase, confirmation) {
+		return nil, fmt.Errorf("passphrases do not match")
+	}
+	return passphrase, nil
+}
+
+func doChangePassphrase(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	if cmdSigner.Backend() != fileSigner.SignerName {
+		logger.Error("changing the passphrase is only supported by the file signer backend")
+		os.Exit(1)
+	}
+	signerDir, err := cmdSigner.CLIDirOrPwd()
+	if err != nil {
+		logger.Error("failed to retrieve signer dir",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	factory, err := cmdSigner.NewFactory(cmdSigner.Backend(), signerDir, signature.SignerRoles...)
+	if err != nil {
+		logger.Error("failed to create signer factory",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	passphrase, err := readNewPassphrase()
+	if err != nil {
+		logger.Error("failed to read new passphrase",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if len(passphrase) == 0 {
+		logger.Warn("new passphrase is empty, private keys will be stored unencrypted")
+	}
+
+	if err = factory.(*fileSigner.Factory).ChangePassphrase(passphrase); err != nil {
+		logger.Error("failed to change passphrase",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
 func Register(parentCmd *cobra.Command) {
 	exportCmd.Flags().AddFlagSet(cmdSigner.Flags)
 	exportCmd.Flags().AddFlagSet(cmdSigner.CLIFlags)
 
+	changePassphraseCmd.Flags().AddFlagSet(cmdSigner.Flags)
+	changePassphraseCmd.Flags().AddFlagSet(cmdSigner.CLIFlags)
+	changePassphraseCmd.Flags().AddFlagSet(changePassphraseFlags)
+
 	signerCmd.AddCommand(exportCmd)
+	signerCmd.AddCommand(changePassphraseCmd)
 	parentCmd.AddCommand(signerCmd)
 }
+
+func init() {
+	changePassphraseFlags.String(cfgNewPassphraseEnv, "", "name of the environment variable containing the new passphrase")
+	changePassphraseFlags.Int(cfgNewPassphraseFD, -1, "file descriptor from which to read the new passphrase")
+	_ = viper.BindPFlags(changePassphraseFlags)
+}
@@ -10,7 +10,6 @@ import (
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
-	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	cmdConsensus "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/consensus"
 	cmdContext "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/context"
@@ -150,10 +149,7 @@ func doAccountInfo(cmd *cobra.Command, args []string) {
 	incomingDelegations := getDelegationsTo(ctx, cmd, addr, client)
 	outgoingDebondingDelegationInfos := getDebondingDelegationInfosFor(ctx, cmd, addr, client)
 	incomingDebondingDelegations := getDebondingDelegationsTo(ctx, cmd, addr, client)
-	symbol := getTokenSymbol(ctx, cmd, client)
-	exp := getTokenValueExponent(ctx, cmd, client)
-	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenSymbol, symbol)
-	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, exp)
+	ctx = getTokenMetadata(ctx, cmd, client).NewContext(ctx)
 
 	fmt.Println("Balance:")
 	prettyPrintAccountBalanceAndDelegationsFrom(ctx, addr, acct.General, outgoingDelegationInfos, outgoingDebondingDelegationInfos, "  ", os.Stdout)
@@ -499,6 +495,8 @@ func registerAccountCmd() {
 		accountAmendCommissionScheduleCmd,
 		accountAllowCmd,
 		accountWithdrawCmd,
+		accountDelegationsCmd,
+		accountRewardsCmd,
 	} {
 		accountCmd.AddCommand(v)
 	}
@@ -516,6 +514,11 @@ func registerAccountCmd() {
 	accountAmendCommissionScheduleCmd.Flags().AddFlagSet(commissionScheduleFlags)
 	accountAllowCmd.Flags().AddFlagSet(accountAllowFlags)
 	accountWithdrawCmd.Flags().AddFlagSet(accountWithdrawFlags)
+	accountDelegationsCmd.Flags().AddFlagSet(commonAccountFlags)
+	accountDelegationsCmd.Flags().AddFlagSet(outputFormatFlags)
+	accountRewardsCmd.Flags().AddFlagSet(commonAccountFlags)
+	accountRewardsCmd.Flags().AddFlagSet(outputFormatFlags)
+	accountRewardsCmd.Flags().AddFlagSet(accountRewardsFlags)
 }
 
 func init() {
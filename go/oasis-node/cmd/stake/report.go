@@ -0,0 +1,449 @@
+package stake
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	// CfgOutputFormat configures the output format of the reporting commands.
+	CfgOutputFormat = "stake.output.format"
+
+	// CfgRewardsStartEpoch configures the first epoch of the reward report.
+	CfgRewardsStartEpoch = "stake.rewards.start_epoch"
+
+	// CfgRewardsEndEpoch configures the last epoch of the reward report.
+	CfgRewardsEndEpoch = "stake.rewards.end_epoch"
+
+	outputFormatJSON = "json"
+	outputFormatCSV  = "csv"
+
+	delegationKindActive    = "active"
+	delegationKindDebonding = "debonding"
+)
+
+var (
+	outputFormatFlags   = flag.NewFlagSet("", flag.ContinueOnError)
+	accountRewardsFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	accountDelegationsCmd = &cobra.Command{
+		Use:   "delegations",
+		Short: "list (outgoing) delegations of an account",
+		Run:   doAccountDelegations,
+	}
+
+	accountRewardsCmd = &cobra.Command{
+		Use:   "rewards",
+		Short: "summarize staking rewards of an account per epoch",
+		Run:   doAccountRewards,
+	}
+)
+
+// delegationReportEntry is a single (debonding) delegation in the delegation report.
+type delegationReportEntry struct {
+	Escrow api.Address       `json:"escrow"`
+	Kind   string            `json:"kind"`
+	Shares quantity.Quantity `json:"shares"`
+	// Amount is the amount of base units the shares represent at current share pool rates.
+	Amount quantity.Quantity `json:"amount"`
+	// DebondEndTime is the epoch at which the debonding delegation ends.
+	DebondEndTime *beacon.EpochTime `json:"debond_end_time,omitempty"`
+}
+
+// rewardReportEntry are the rewards received from a single escrow account in a single epoch.
+type rewardReportEntry struct {
+	Epoch  beacon.EpochTime `json:"epoch"`
+	Escrow api.Address      `json:"escrow"`
+	// Reward is the account's share of the (non-commissioned) rewards of the escrow account.
+	Reward quantity.Quantity `json:"reward"`
+	// Commission is the commission received by the account as the escrow account.
+	Commission quantity.Quantity `json:"commission"`
+}
+
+// rewardReport is a summary of staking rewards of an account.
+type rewardReport struct {
+	Address         api.Address         `json:"address"`
+	StartEpoch      beacon.EpochTime    `json:"start_epoch"`
+	EndEpoch        beacon.EpochTime    `json:"end_epoch"`
+	Entries         []rewardReportEntry `json:"entries"`
+	TotalReward     quantity.Quantity   `json:"total_reward"`
+	TotalCommission quantity.Quantity   `json:"total_commission"`
+}
+
+type rewardKey struct {
+	epoch  beacon.EpochTime
+	escrow api.Address
+}
+
+// rewardScanner computes the staking rewards of an account by scanning staking events.
+type rewardScanner struct {
+	addr api.Address
+
+	// delegationsAt returns the account's (outgoing) delegations at the given height.
+	delegationsAt func(height int64) (map[api.Address]*api.Delegation, error)
+	// accountAt returns the given account at the given height.
+	accountAt func(addr api.Address, height int64) (*api.Account, error)
+
+	entries map[rewardKey]*rewardReportEntry
+}
+
+func (rs *rewardScanner) entry(epoch beacon.EpochTime, escrow api.Address) *rewardReportEntry {
+	key := rewardKey{epoch, escrow}
+	e, ok := rs.entries[key]
+	if !ok {
+		e = &rewardReportEntry{Epoch: epoch, Escrow: escrow}
+		rs.entries[key] = e
+	}
+	return e
+}
+
+// processEvents accounts for rewards disbursed by the given staking events of a single block.
+//
+// Escrow account rewards are emitted as escrow events from the common pool that do not issue
+// any new shares. The account's part of such a reward is proportional to its share of the
+// escrow account's active share pool at the given height. Commission is paid out as a transfer
+// from the common pool to the escrow account.
+func (rs *rewardScanner) processEvents(epoch beacon.EpochTime, height int64, events []*api.Event) error {
+	var delegations map[api.Address]*api.Delegation
+	for _, ev := range events {
+		switch {
+		case ev.Escrow != nil && ev.Escrow.Add != nil && ev.Escrow.Add.Owner.Equal(api.CommonPoolAddress):
+			add := ev.Escrow.Add
+			if delegations == nil {
+				var err error
+				if delegations, err = rs.delegationsAt(height); err != nil {
+					return fmt.Errorf("failed to query delegations at height %d: %w", height, err)
+				}
+			}
+			del, ok := delegations[add.Escrow]
+			if !ok || del.Shares.IsZero() {
+				continue
+			}
+
+			acct, err := rs.accountAt(add.Escrow, height)
+			if err != nil {
+				return fmt.Errorf("failed to query escrow account %s at height %d: %w", add.Escrow, height, err)
+			}
+			totalShares := acct.Escrow.Active.TotalShares
+			if totalShares.IsZero() {
+				continue
+			}
+
+			reward := add.Amount.Clone()
+			if err = reward.Mul(&del.Shares); err != nil {
+				return err
+			}
+			if err = reward.Quo(&totalShares); err != nil {
+				return err
+			}
+			e := rs.entry(epoch, add.Escrow)
+			if err = e.Reward.Add(reward); err != nil {
+				return err
+			}
+		case ev.Transfer != nil && ev.Transfer.From.Equal(api.CommonPoolAddress) && ev.Transfer.To.Equal(rs.addr):
+			e := rs.entry(epoch, rs.addr)
+			if err := e.Commission.Add(&ev.Transfer.Amount); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// report returns the reward report for the scanned epochs.
+func (rs *rewardScanner) report(startEpoch, endEpoch beacon.EpochTime) (*rewardReport, error) {
+	r := &rewardReport{
+		Address:    rs.addr,
+		StartEpoch: startEpoch,
+		EndEpoch:   endEpoch,
+		Entries:    make([]rewardReportEntry, 0, len(rs.entries)),
+	}
+	for _, e := range rs.entries {
+		r.Entries = append(r.Entries, *e)
+		if err := r.TotalReward.Add(&e.Reward); err != nil {
+			return nil, err
+		}
+		if err := r.TotalCommission.Add(&e.Commission); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(r.Entries, func(i, j int) bool {
+		if r.Entries[i].Epoch == r.Entries[j].Epoch {
+			return r.Entries[i].Escrow.String() < r.Entries[j].Escrow.String()
+		}
+		return r.Entries[i].Epoch < r.Entries[j].Epoch
+	})
+	return r, nil
+}
+
+func newRewardScanner(ctx context.Context, addr api.Address, client api.Backend) *rewardScanner {
+	return &rewardScanner{
+		addr: addr,
+		delegationsAt: func(height int64) (map[api.Address]*api.Delegation, error) {
+			return client.DelegationsFor(ctx, &api.OwnerQuery{Owner: addr, Height: height})
+		},
+		accountAt: func(addr api.Address, height int64) (*api.Account, error) {
+			return client.Account(ctx, &api.OwnerQuery{Owner: addr, Height: height})
+		},
+		entries: make(map[rewardKey]*rewardReportEntry),
+	}
+}
+
+func getOutputFormat() string {
+	format := viper.GetString(CfgOutputFormat)
+	switch format {
+	case outputFormatJSON, outputFormatCSV:
+	default:
+		logger.Error("unsupported output format",
+			"format", format,
+		)
+		os.Exit(1)
+	}
+	return format
+}
+
+func getAccountAddress() api.Address {
+	var addr api.Address
+	if err := addr.UnmarshalText([]byte(viper.GetString(CfgAccountAddr))); err != nil {
+		logger.Error("failed to parse account address",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	return addr
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+func writeDelegationReport(format string, entries []delegationReportEntry, w io.Writer) error {
+	if format == outputFormatJSON {
+		return writeJSON(w, entries)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"escrow", "kind", "shares", "amount", "debond_end_time"}); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		var endTime string
+		if e.DebondEndTime != nil {
+			endTime = strconv.FormatUint(uint64(*e.DebondEndTime), 10)
+		}
+		if err := cw.Write([]string{e.Escrow.String(), e.Kind, e.Shares.String(), e.Amount.String(), endTime}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeRewardReport(format string, r *rewardReport, w io.Writer) error {
+	if format == outputFormatJSON {
+		return writeJSON(w, r)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"epoch", "escrow", "reward", "commission"}); err != nil {
+		return err
+	}
+	for _, e := range r.Entries {
+		if err := cw.Write([]string{
+			strconv.FormatUint(uint64(e.Epoch), 10),
+			e.Escrow.String(),
+			e.Reward.String(),
+			e.Commission.String(),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func doAccountDelegations(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	format := getOutputFormat()
+	addr := getAccountAddress()
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	ctx := context.Background()
+	delInfos := getDelegationInfosFor(ctx, cmd, addr, client)
+	debDelInfos := getDebondingDelegationInfosFor(ctx, cmd, addr, client)
+
+	entries := make([]delegationReportEntry, 0, len(delInfos)+len(debDelInfos))
+	for escrow, info := range delInfos {
+		entries = append(entries, delegationReportEntry{
+			Escrow: escrow,
+			Kind:   delegationKindActive,
+			Shares: info.Shares,
+			Amount: delegationAmount(info.Shares, info.Pool),
+		})
+	}
+	for escrow, infos := range debDelInfos {
+		for _, info := range infos {
+			endTime := info.DebondEndTime
+			entries = append(entries, delegationReportEntry{
+				Escrow:        escrow,
+				Kind:          delegationKindDebonding,
+				Shares:        info.Shares,
+				Amount:        delegationAmount(info.Shares, info.Pool),
+				DebondEndTime: &endTime,
+			})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Kind != entries[j].Kind {
+			return entries[i].Kind == delegationKindActive
+		}
+		if entries[i].Escrow.Equal(entries[j].Escrow) {
+			return entries[i].DebondEndTime != nil && entries[j].DebondEndTime != nil &&
+				*entries[i].DebondEndTime < *entries[j].DebondEndTime
+		}
+		return entries[i].Escrow.String() < entries[j].Escrow.String()
+	})
+
+	if err := writeDelegationReport(format, entries, os.Stdout); err != nil {
+		logger.Error("failed to write delegation report",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func doAccountRewards(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	format := getOutputFormat()
+	addr := getAccountAddress()
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	ctx := context.Background()
+	beaconClient := beacon.NewBeaconClient(conn)
+	consensusClient := consensus.NewConsensusClient(conn)
+
+	status, err := consensusClient.GetStatus(ctx)
+	if err != nil {
+		logger.Error("failed to query consensus status",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	startEpoch := beacon.EpochTime(viper.GetUint64(CfgRewardsStartEpoch))
+	endEpoch := beacon.EpochTime(viper.GetUint64(CfgRewardsEndEpoch))
+	if endEpoch == beacon.EpochInvalid || endEpoch > status.LatestEpoch {
+		endEpoch = status.LatestEpoch
+	}
+	if startEpoch == beacon.EpochInvalid {
+		startEpoch = endEpoch
+	}
+	if startEpoch > endEpoch {
+		logger.Error("invalid epoch range",
+			"start_epoch", startEpoch,
+			"end_epoch", endEpoch,
+		)
+		os.Exit(1)
+	}
+
+	// Rewards are attributed to the epoch of the block in which they were disbursed.
+	rs := newRewardScanner(ctx, addr, client)
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		startHeight, err := beaconClient.GetEpochBlock(ctx, epoch)
+		if err != nil {
+			logger.Error("failed to query epoch start height",
+				"err", err,
+				"epoch", epoch,
+			)
+			os.Exit(1)
+		}
+		if startHeight < status.LastRetainedHeight {
+			logger.Error("epoch range not available on the node",
+				"epoch", epoch,
+				"last_retained_height", status.LastRetainedHeight,
+			)
+			os.Exit(1)
+		}
+
+		endHeight := status.LatestHeight
+		if epoch < status.LatestEpoch {
+			if endHeight, err = beaconClient.GetEpochBlock(ctx, epoch+1); err != nil {
+				logger.Error("failed to query epoch start height",
+					"err", err,
+					"epoch", epoch+1,
+				)
+				os.Exit(1)
+			}
+			endHeight--
+		}
+
+		for height := startHeight; height <= endHeight; height++ {
+			events, err := client.GetEvents(ctx, height)
+			if err != nil {
+				logger.Error("failed to query staking events",
+					"err", err,
+					"height", height,
+				)
+				os.Exit(1)
+			}
+			if err = rs.processEvents(epoch, height, events); err != nil {
+				logger.Error("failed to process staking events",
+					"err", err,
+					"height", height,
+				)
+				os.Exit(1)
+			}
+		}
+	}
+
+	report, err := rs.report(startEpoch, endEpoch)
+	if err == nil {
+		err = writeRewardReport(format, report, os.Stdout)
+	}
+	if err != nil {
+		logger.Error("failed to write reward report",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	outputFormatFlags.String(CfgOutputFormat, outputFormatJSON, "output format (json, csv)")
+	_ = viper.BindPFlags(outputFormatFlags)
+
+	accountRewardsFlags.Uint64(CfgRewardsStartEpoch, uint64(beacon.EpochInvalid), "first epoch of the reward report (default: end epoch)")
+	accountRewardsFlags.Uint64(CfgRewardsEndEpoch, uint64(beacon.EpochInvalid), "last epoch of the reward report (default: current epoch)")
+	_ = viper.BindPFlags(accountRewardsFlags)
+}
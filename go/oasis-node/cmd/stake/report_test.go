@@ -0,0 +1,101 @@
+package stake
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func testAddress(name string) api.Address {
+	return api.NewAddress(memorySigner.NewTestSigner(name).Public())
+}
+
+func testQuantity(n uint64) quantity.Quantity {
+	return *quantity.NewFromUint64(n)
+}
+
+func TestRewardScanner(t *testing.T) {
+	require := require.New(t)
+
+	addr := testAddress("reward report test: delegator")
+	escrowA := testAddress("reward report test: escrow A")
+	escrowB := testAddress("reward report test: escrow B")
+
+	rs := &rewardScanner{
+		addr: addr,
+		delegationsAt: func(height int64) (map[api.Address]*api.Delegation, error) {
+			return map[api.Address]*api.Delegation{
+				escrowA: {Shares: testQuantity(25)},
+				addr:    {Shares: testQuantity(50)},
+			}, nil
+		},
+		accountAt: func(a api.Address, height int64) (*api.Account, error) {
+			var acct api.Account
+			acct.Escrow.Active.TotalShares = testQuantity(100)
+			return &acct, nil
+		},
+		entries: make(map[rewardKey]*rewardReportEntry),
+	}
+
+	poolReward := func(escrow api.Address, amount uint64) *api.Event {
+		return &api.Event{Escrow: &api.EscrowEvent{Add: &api.AddEscrowEvent{
+			Owner:  api.CommonPoolAddress,
+			Escrow: escrow,
+			Amount: testQuantity(amount),
+		}}}
+	}
+
+	// Epoch 1: rewards for escrow A (25% share) and B (no delegation).
+	err := rs.processEvents(1, 10, []*api.Event{
+		poolReward(escrowA, 1000),
+		poolReward(escrowB, 1000),
+		// Regular escrow event, not a reward.
+		{Escrow: &api.EscrowEvent{Add: &api.AddEscrowEvent{
+			Owner:     addr,
+			Escrow:    escrowA,
+			Amount:    testQuantity(500),
+			NewShares: testQuantity(5),
+		}}},
+	})
+	require.NoError(err, "processEvents")
+	err = rs.processEvents(1, 11, []*api.Event{poolReward(escrowA, 400)})
+	require.NoError(err, "processEvents")
+
+	// Epoch 2: self-delegation reward (50% share) and commission.
+	err = rs.processEvents(2, 20, []*api.Event{
+		{Transfer: &api.TransferEvent{From: api.CommonPoolAddress, To: addr, Amount: testQuantity(30)}},
+		// Regular transfer, not a commission.
+		{Transfer: &api.TransferEvent{From: escrowB, To: addr, Amount: testQuantity(70)}},
+		poolReward(addr, 200),
+	})
+	require.NoError(err, "processEvents")
+
+	report, err := rs.report(1, 2)
+	require.NoError(err, "report")
+	require.Len(report.Entries, 2, "report entries")
+	require.EqualValues(1, report.Entries[0].Epoch)
+	require.Equal(escrowA, report.Entries[0].Escrow)
+	require.Equal(testQuantity(350), report.Entries[0].Reward)
+	require.True(report.Entries[0].Commission.IsZero())
+	require.EqualValues(2, report.Entries[1].Epoch)
+	require.Equal(addr, report.Entries[1].Escrow)
+	require.Equal(testQuantity(100), report.Entries[1].Reward)
+	require.Equal(testQuantity(30), report.Entries[1].Commission)
+	require.Equal(testQuantity(450), report.TotalReward)
+	require.Equal(testQuantity(30), report.TotalCommission)
+
+	var buf bytes.Buffer
+	err = writeRewardReport(outputFormatCSV, report, &buf)
+	require.NoError(err, "writeRewardReport")
+	require.Equal(
+		"epoch,escrow,reward,commission\n"+
+			"1,"+escrowA.String()+",350,0\n"+
+			"2,"+addr.String()+",100,30\n",
+		buf.String(),
+	)
+}
@@ -14,7 +14,6 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
-	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
@@ -70,26 +69,15 @@ func doConnect(cmd *cobra.Command) (*grpc.ClientConn, api.Backend) {
 	return conn, client
 }
 
-func getTokenSymbol(ctx context.Context, cmd *cobra.Command, client api.Backend) string {
-	symbol, err := client.TokenSymbol(ctx)
+func getTokenMetadata(ctx context.Context, cmd *cobra.Command, client api.Backend) *token.Metadata {
+	meta, err := client.TokenMetadata(ctx, consensus.HeightLatest)
 	if err != nil {
-		logger.Error("failed to query token's symbol",
+		logger.Error("failed to query token's metadata",
 			"err", err,
 		)
 		os.Exit(1)
 	}
-	return symbol
-}
-
-func getTokenValueExponent(ctx context.Context, cmd *cobra.Command, client api.Backend) uint8 {
-	exp, err := client.TokenValueExponent(ctx)
-	if err != nil {
-		logger.Error("failed to query token's value exponent",
-			"err", err,
-		)
-		os.Exit(1)
-	}
-	return exp
+	return meta
 }
 
 func getAccount(ctx context.Context, cmd *cobra.Command, addr api.Address, client api.Backend) *api.Account {
@@ -182,14 +170,11 @@ func doInfo(cmd *cobra.Command, args []string) {
 
 	ctx := context.Background()
 
-	symbol := getTokenSymbol(ctx, cmd, client)
-	fmt.Printf("Token's ticker symbol: %s\n", symbol)
-
-	exp := getTokenValueExponent(ctx, cmd, client)
-	fmt.Printf("Token's value base-10 exponent: %d\n", exp)
+	meta := getTokenMetadata(ctx, cmd, client)
+	fmt.Printf("Token's ticker symbol: %s\n", meta.Symbol)
+	fmt.Printf("Token's value base-10 exponent: %d\n", meta.ValueExponent)
 
-	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenSymbol, symbol)
-	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, exp)
+	ctx = meta.NewContext(ctx)
 
 	totalSupply, err := client.TotalSupply(ctx, consensus.HeightLatest)
 	if err != nil {
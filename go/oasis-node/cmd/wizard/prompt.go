@@ -0,0 +1,123 @@
+package wizard
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// prompter asks the operator questions and reads their answers.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+func (p *prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	switch {
+	case err == nil:
+	case err == io.EOF && line != "":
+	default:
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// askString asks a question until the answer passes validation. An empty answer selects the
+// default value, if any.
+func (p *prompter) askString(question, def string, validate func(string) error) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+		} else {
+			fmt.Fprintf(p.out, "%s: ", question)
+		}
+
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = def
+		}
+		if answer == "" {
+			fmt.Fprintln(p.out, "An answer is required.")
+			continue
+		}
+		if validate != nil {
+			if err = validate(answer); err != nil {
+				fmt.Fprintf(p.out, "Invalid answer: %s\n", err)
+				continue
+			}
+		}
+		return answer, nil
+	}
+}
+
+// askOptionalString asks a question that may be left unanswered.
+func (p *prompter) askOptionalString(question string, validate func(string) error) (string, error) {
+	for {
+		fmt.Fprintf(p.out, "%s (optional): ", question)
+
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer != "" && validate != nil {
+			if err = validate(answer); err != nil {
+				fmt.Fprintf(p.out, "Invalid answer: %s\n", err)
+				continue
+			}
+		}
+		return answer, nil
+	}
+}
+
+// askChoice asks the operator to select one of the given options.
+func (p *prompter) askChoice(question string, options []string, def string) (string, error) {
+	return p.askString(
+		fmt.Sprintf("%s (%s)", question, strings.Join(options, "/")),
+		def,
+		func(answer string) error {
+			for _, opt := range options {
+				if answer == opt {
+					return nil
+				}
+			}
+			return fmt.Errorf("must be one of: %s", strings.Join(options, ", "))
+		},
+	)
+}
+
+// askBool asks a yes/no question.
+func (p *prompter) askBool(question string, def bool) (bool, error) {
+	defStr := "n"
+	if def {
+		defStr = "y"
+	}
+	answer, err := p.askString(question+" (y/n)", defStr, func(answer string) error {
+		switch strings.ToLower(answer) {
+		case "y", "yes", "n", "no":
+			return nil
+		default:
+			return fmt.Errorf("please answer (y)es or (n)o")
+		}
+	})
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
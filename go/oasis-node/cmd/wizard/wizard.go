@@ -0,0 +1,361 @@
+// Package wizard implements the interactive node setup sub-command.
+package wizard
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	tmCommon "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/common"
+	"github.com/oasisprotocol/oasis-core/go/ias"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdConfig "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/config"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/node"
+	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	workerP2P "github.com/oasisprotocol/oasis-core/go/worker/common/p2p"
+	"github.com/oasisprotocol/oasis-core/go/worker/registration"
+)
+
+const (
+	roleValidator    = "validator"
+	roleNonValidator = "non-validator"
+	roleCompute      = "compute"
+	roleClient       = "client"
+
+	defaultConfigFile    = "config.yml"
+	defaultSandboxBinary = "/usr/bin/bwrap"
+	defaultP2PPort       = "9200"
+
+	entityDescriptorFilename = "entity.json"
+)
+
+var (
+	wizardCmd = &cobra.Command{
+		Use:   "wizard",
+		Short: "interactively set up a new node",
+		Run:   doWizard,
+	}
+
+	logger = logging.GetLogger("cmd/wizard")
+)
+
+// answers are the operator's answers to the wizard questions.
+type answers struct {
+	Role string
+
+	DataDir     string
+	ConfigFile  string
+	GenesisFile string
+	Seeds       []string
+
+	ExternalAddress string
+	EntityDir       string
+
+	RuntimeID     string
+	RuntimePath   string
+	SandboxBinary string
+	P2PPort       string
+
+	SGX       bool
+	SGXLoader string
+	IASProxy  string
+}
+
+func (a *answers) requiresEntity() bool {
+	return a.Role == roleValidator || a.Role == roleCompute
+}
+
+func (a *answers) hostsRuntime() bool {
+	return a.Role == roleCompute || a.Role == roleClient
+}
+
+func validateFile(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	}
+	return nil
+}
+
+func validateEntityDir(path string) error {
+	return validateFile(filepath.Join(path, entityDescriptorFilename))
+}
+
+func validateSeed(seed string) error {
+	atIdx := strings.Index(seed, "@")
+	if atIdx <= 0 {
+		return fmt.Errorf("seed must be in the form <id>@<host>:<port>")
+	}
+	_, _, err := net.SplitHostPort(seed[atIdx+1:])
+	return err
+}
+
+func validateAddress(addr string) error {
+	_, _, err := net.SplitHostPort(addr)
+	return err
+}
+
+func validateRuntimeID(id string) error {
+	var ns common.Namespace
+	return ns.UnmarshalHex(id)
+}
+
+// ask interrogates the operator about the node setup.
+func ask(p *prompter) (*answers, error) {
+	var (
+		a   answers
+		err error
+	)
+
+	if a.Role, err = p.askChoice("Node role", []string{roleValidator, roleNonValidator, roleCompute, roleClient}, roleNonValidator); err != nil {
+		return nil, err
+	}
+	if a.DataDir, err = p.askString("Node data directory", "/node/data", nil); err != nil {
+		return nil, err
+	}
+	if a.ConfigFile, err = p.askString("Configuration file to write", filepath.Join(filepath.Dir(a.DataDir), defaultConfigFile), nil); err != nil {
+		return nil, err
+	}
+
+	// Network.
+	if a.GenesisFile, err = p.askString("Path to the genesis file of the network", "", validateFile); err != nil {
+		return nil, err
+	}
+	seed, err := p.askString("Seed node address (<id>@<host>:<port>)", "", validateSeed)
+	if err != nil {
+		return nil, err
+	}
+	a.Seeds = append(a.Seeds, seed)
+	for {
+		if seed, err = p.askOptionalString("Additional seed node address", validateSeed); err != nil {
+			return nil, err
+		}
+		if seed == "" {
+			break
+		}
+		a.Seeds = append(a.Seeds, seed)
+	}
+
+	// Entity.
+	if a.requiresEntity() {
+		if a.ExternalAddress, err = p.askString("Externally reachable consensus address (<host>:<port>)", "", validateAddress); err != nil {
+			return nil, err
+		}
+		if a.EntityDir, err = p.askString("Entity directory (containing "+entityDescriptorFilename+")", "", validateEntityDir); err != nil {
+			return nil, err
+		}
+	}
+
+	// Runtime.
+	if a.hostsRuntime() {
+		if a.RuntimeID, err = p.askString("Runtime ID (hex)", "", validateRuntimeID); err != nil {
+			return nil, err
+		}
+		if a.RuntimePath, err = p.askString("Path to the runtime bundle", "", validateFile); err != nil {
+			return nil, err
+		}
+		if a.SandboxBinary, err = p.askString("Path to the sandbox binary", defaultSandboxBinary, validateFile); err != nil {
+			return nil, err
+		}
+	}
+	if a.Role == roleCompute {
+		if a.P2PPort, err = p.askString("Runtime P2P port", defaultP2PPort, nil); err != nil {
+			return nil, err
+		}
+		if a.SGX, err = p.askBool("Is Intel SGX available on this machine", false); err != nil {
+			return nil, err
+		}
+		if a.SGX {
+			if a.SGXLoader, err = p.askString("Path to the SGX runtime loader", "", validateFile); err != nil {
+				return nil, err
+			}
+			if a.IASProxy, err = p.askString("IAS proxy address (<id>@<host>:<port>)", "", validateSeed); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &a, nil
+}
+
+// setKey sets a (dotted) configuration key in a nested configuration map.
+func setKey(cfg map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		sub, ok := cfg[part].(map[string]interface{})
+		if !ok {
+			sub = make(map[string]interface{})
+			cfg[part] = sub
+		}
+		cfg = sub
+	}
+	cfg[parts[len(parts)-1]] = value
+}
+
+// buildConfig builds the node configuration from the operator's answers.
+func buildConfig(a *answers) map[string]interface{} {
+	cfg := make(map[string]interface{})
+	setKey(cfg, cmdCommon.CfgDataDir, a.DataDir)
+	setKey(cfg, flags.CfgGenesisFile, a.GenesisFile)
+	setKey(cfg, tmCommon.CfgP2PSeed, a.Seeds)
+
+	if a.requiresEntity() {
+		setKey(cfg, tmCommon.CfgCoreListenAddress, "tcp://0.0.0.0:26656")
+		setKey(cfg, tmCommon.CfgCoreExternalAddress, "tcp://"+a.ExternalAddress)
+		setKey(cfg, registration.CfgRegistrationEntity, filepath.Join(a.EntityDir, entityDescriptorFilename))
+	}
+	if a.Role == roleValidator {
+		setKey(cfg, flags.CfgConsensusValidator, true)
+	}
+
+	if a.hostsRuntime() {
+		mode := runtimeRegistry.RuntimeModeClient
+		if a.Role == roleCompute {
+			mode = runtimeRegistry.RuntimeModeCompute
+		}
+		setKey(cfg, runtimeRegistry.CfgRuntimeMode, string(mode))
+		setKey(cfg, runtimeRegistry.CfgRuntimeProvisioner, runtimeRegistry.RuntimeProvisionerSandboxed)
+		setKey(cfg, runtimeRegistry.CfgSandboxBinary, a.SandboxBinary)
+		setKey(cfg, runtimeRegistry.CfgRuntimePaths, map[string]interface{}{
+			a.RuntimeID: a.RuntimePath,
+		})
+	}
+	if a.Role == roleCompute {
+		setKey(cfg, workerP2P.CfgP2pPort, a.P2PPort)
+		if a.SGX {
+			setKey(cfg, runtimeRegistry.CfgRuntimeSGXLoader, a.SGXLoader)
+			setKey(cfg, ias.CfgProxyAddress, []string{a.IASProxy})
+		}
+	}
+
+	return cfg
+}
+
+// writeConfig writes the node configuration to the given file and validates it.
+func writeConfig(cfg map[string]interface{}, path string) (*cmdConfig.Result, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize configuration: %w", err)
+	}
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write configuration: %w", err)
+	}
+
+	viper.SetConfigFile(path)
+	if err = viper.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return cmdConfig.Check(path, node.KnownFlags()), nil
+}
+
+func printNextSteps(out io.Writer, a *answers, nodeID string) {
+	fmt.Fprintf(out, "\nNode ID: %s\n", nodeID)
+	fmt.Fprintln(out, "\nNext steps:")
+
+	step := 1
+	if a.requiresEntity() {
+		fmt.Fprintf(out, "  %d. Add the node to your entity descriptor:\n", step)
+		fmt.Fprintf(out, "       oasis-node registry entity update --signer.dir %s --entity.node.id %s\n", a.EntityDir, nodeID)
+		step++
+
+		fmt.Fprintf(out, "  %d. Generate the entity registration transaction:\n", step)
+		fmt.Fprintf(out, "       oasis-node registry entity gen_register --signer.dir %s --genesis.file %s \\\n", a.EntityDir, a.GenesisFile)
+		fmt.Fprintln(out, "         --transaction.file register_entity.tx --transaction.nonce <nonce> \\")
+		fmt.Fprintln(out, "         --transaction.fee.gas <gas> --transaction.fee.amount <amount>")
+		step++
+
+		fmt.Fprintf(out, "  %d. Make sure your entity has enough stake escrowed for the %s role.\n", step, a.Role)
+		step++
+	}
+
+	fmt.Fprintf(out, "  %d. Start the node:\n", step)
+	fmt.Fprintf(out, "       oasis-node --config %s\n", a.ConfigFile)
+	step++
+
+	if a.requiresEntity() {
+		fmt.Fprintf(out, "  %d. Once the node is synced, submit the entity registration transaction:\n", step)
+		fmt.Fprintf(out, "       oasis-node consensus submit_tx --transaction.file register_entity.tx -a unix:%s\n",
+			filepath.Join(a.DataDir, cmdGrpc.LocalSocketFilename),
+		)
+	}
+}
+
+func run(in io.Reader, out io.Writer) error {
+	p := newPrompter(in, out)
+
+	fmt.Fprintln(out, "This wizard will set up a new Oasis node.")
+	a, err := ask(p)
+	if err != nil {
+		return err
+	}
+
+	if _, err = os.Stat(a.ConfigFile); err == nil {
+		var overwrite bool
+		if overwrite, err = p.askBool(fmt.Sprintf("Configuration file %s already exists, overwrite it", a.ConfigFile), false); err != nil {
+			return err
+		}
+		if !overwrite {
+			return fmt.Errorf("configuration file already exists")
+		}
+	}
+
+	// Provision the node identity.
+	if err = common.Mkdir(a.DataDir); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	signerFactory, err := fileSigner.NewFactory(a.DataDir, identity.RequiredSignerRoles...)
+	if err != nil {
+		return fmt.Errorf("failed to create identity signer factory: %w", err)
+	}
+	ident, err := identity.LoadOrGenerate(a.DataDir, signerFactory, true)
+	if err != nil {
+		return fmt.Errorf("failed to load or generate node identity: %w", err)
+	}
+	fmt.Fprintf(out, "\nNode identity is in: %s\n", a.DataDir)
+
+	result, err := writeConfig(buildConfig(a), a.ConfigFile)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Configuration written to: %s\n", a.ConfigFile)
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(out, "warning: %s\n", warning)
+	}
+	if err = result.Err(); err != nil {
+		return err
+	}
+
+	printNextSteps(out, a, ident.NodeSigner.Public().String())
+
+	return nil
+}
+
+func doWizard(cmd *cobra.Command, args []string) {
+	if err := run(os.Stdin, os.Stdout); err != nil {
+		logger.Error("node setup failed",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+// Register registers the wizard sub-command.
+func Register(parentCmd *cobra.Command) {
+	parentCmd.AddCommand(wizardCmd)
+}
@@ -0,0 +1,111 @@
+package wizard
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testRuntimeID = "8000000000000000000000000000000000000000000000000000000000000000"
+
+func touch(t *testing.T, path string) {
+	require.NoError(t, os.WriteFile(path, []byte{}, 0o600), "WriteFile")
+}
+
+func TestAsk(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	genesisFile := filepath.Join(dir, "genesis.json")
+	touch(t, genesisFile)
+	runtimeFile := filepath.Join(dir, "runtime.orc")
+	touch(t, runtimeFile)
+	sandboxFile := filepath.Join(dir, "bwrap")
+	touch(t, sandboxFile)
+	entityDir := filepath.Join(dir, "entity")
+	require.NoError(os.Mkdir(entityDir, 0o700), "Mkdir")
+	touch(t, filepath.Join(entityDir, entityDescriptorFilename))
+
+	input := strings.Join([]string{
+		"unknown-role",          // Invalid role should be asked again.
+		"compute",               // Node role.
+		filepath.Join(dir, "n"), // Data directory.
+		"",                      // Configuration file (default).
+		filepath.Join(dir, "missing.json"),
+		genesisFile,
+		"invalid-seed",
+		"abcd@127.0.0.1:26656",
+		"", // No additional seeds.
+		"1.2.3.4:26656",
+		entityDir,
+		testRuntimeID,
+		runtimeFile,
+		sandboxFile,
+		"", // Runtime P2P port (default).
+		"", // No SGX (default).
+	}, "\n") + "\n"
+
+	var out bytes.Buffer
+	a, err := ask(newPrompter(strings.NewReader(input), &out))
+	require.NoError(err, "ask")
+	require.Equal(roleCompute, a.Role)
+	require.Equal(filepath.Join(dir, defaultConfigFile), a.ConfigFile)
+	require.Equal(genesisFile, a.GenesisFile)
+	require.Equal([]string{"abcd@127.0.0.1:26656"}, a.Seeds)
+	require.Equal(entityDir, a.EntityDir)
+	require.Equal(defaultP2PPort, a.P2PPort)
+	require.False(a.SGX)
+	require.Contains(out.String(), "Invalid answer", "invalid answers should be reported")
+
+	// Running out of input should fail.
+	_, err = ask(newPrompter(strings.NewReader("compute\n"), &out))
+	require.Error(err, "ask should fail on EOF")
+
+	data, err := yaml.Marshal(buildConfig(a))
+	require.NoError(err, "Marshal")
+	var cfg struct {
+		Runtime struct {
+			Mode  string            `yaml:"mode"`
+			Paths map[string]string `yaml:"paths"`
+		} `yaml:"runtime"`
+		Worker struct {
+			Registration struct {
+				Entity string `yaml:"entity"`
+			} `yaml:"registration"`
+		} `yaml:"worker"`
+	}
+	require.NoError(yaml.Unmarshal(data, &cfg), "Unmarshal")
+	require.Equal("compute", cfg.Runtime.Mode)
+	require.Equal(map[string]string{testRuntimeID: runtimeFile}, cfg.Runtime.Paths)
+	require.Equal(filepath.Join(entityDir, entityDescriptorFilename), cfg.Worker.Registration.Entity)
+}
+
+func TestRun(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	genesisFile := filepath.Join(dir, "genesis.json")
+	touch(t, genesisFile)
+	configFile := filepath.Join(dir, "config.yml")
+
+	input := strings.Join([]string{
+		"non-validator",
+		filepath.Join(dir, "node"),
+		configFile,
+		genesisFile,
+		"abcd@127.0.0.1:26656",
+		"",
+	}, "\n") + "\n"
+
+	var out bytes.Buffer
+	err := run(strings.NewReader(input), &out)
+	require.NoError(err, "run")
+	require.FileExists(configFile)
+	require.FileExists(filepath.Join(dir, "node", "identity.pem"))
+	require.Contains(out.String(), "oasis-node --config "+configFile)
+}
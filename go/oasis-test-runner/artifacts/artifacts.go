@@ -0,0 +1,284 @@
+// Package artifacts implements collection of scenario failure artifacts.
+//
+// Artifacts (node logs, metrics snapshots, consensus state dumps, core files) are collected into a
+// single compressed tarball together with an index file describing its contents, so that failed
+// scenario runs can be inspected offline.
+package artifacts
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+)
+
+const (
+	// IndexFile is the name of the index file at the root of the bundle.
+	IndexFile = "index.json"
+
+	// BundleExt is the file extension of artifact bundles.
+	BundleExt = ".tar.gz"
+)
+
+// Artifact kinds.
+const (
+	// KindLog is the kind of log files.
+	KindLog = "log"
+	// KindMetrics is the kind of metrics snapshots.
+	KindMetrics = "metrics"
+	// KindStateDump is the kind of consensus state dumps.
+	KindStateDump = "state_dump"
+	// KindCore is the kind of core files.
+	KindCore = "core"
+	// KindScenarioInfo is the kind of scenario instance information files.
+	KindScenarioInfo = "scenario_info"
+)
+
+// Entry is an artifact bundle index entry.
+type Entry struct {
+	// Path is the path of the artifact within the bundle.
+	Path string `json:"path"`
+	// Kind is the artifact kind.
+	Kind string `json:"kind"`
+	// Size is the artifact size in bytes.
+	Size int64 `json:"size"`
+}
+
+// Index is the artifact bundle index.
+type Index struct {
+	// Scenario is the information about the failed scenario run.
+	Scenario *env.ScenarioInstanceInfo `json:"scenario,omitempty"`
+	// Time is the time when the bundle was created.
+	Time time.Time `json:"time"`
+	// Error is the error that caused the scenario to fail.
+	Error string `json:"error,omitempty"`
+
+	// Entries are the artifacts contained in the bundle.
+	Entries []Entry `json:"entries"`
+	// CollectionErrors are the errors encountered while collecting artifacts.
+	CollectionErrors []string `json:"collection_errors,omitempty"`
+}
+
+type artifact struct {
+	kind string
+
+	src  string
+	data []byte
+}
+
+// Bundle is an artifact bundle under construction.
+type Bundle struct {
+	name      string
+	index     Index
+	artifacts map[string]*artifact
+}
+
+// AddFile adds the file at the given source path to the bundle under the given name.
+func (b *Bundle) AddFile(kind, name, src string) {
+	b.artifacts[path.Clean(filepath.ToSlash(name))] = &artifact{
+		kind: kind,
+		src:  src,
+	}
+}
+
+// AddData adds the given data to the bundle under the given name.
+func (b *Bundle) AddData(kind, name string, data []byte) {
+	b.artifacts[path.Clean(filepath.ToSlash(name))] = &artifact{
+		kind: kind,
+		data: data,
+	}
+}
+
+// AddError records an error encountered while collecting artifacts.
+func (b *Bundle) AddError(err error) {
+	b.index.CollectionErrors = append(b.index.CollectionErrors, err.Error())
+}
+
+// AddDir adds all recognized artifacts found under the given directory to the bundle. Names of
+// the added artifacts are relative to the directory.
+//
+// Files that are not recognized as artifacts (e.g., node databases) are skipped.
+func (b *Bundle) AddDir(dir string) error {
+	return filepath.Walk(dir, func(fn string, info os.FileInfo, err error) error {
+		if err != nil {
+			// Files may disappear while walking, do not abort the whole collection.
+			b.AddError(fmt.Errorf("artifacts: failed to walk %s: %w", fn, err))
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		kind, ok := Classify(info.Name())
+		if !ok {
+			return nil
+		}
+		name, err := filepath.Rel(dir, fn)
+		if err != nil {
+			return err
+		}
+		b.AddFile(kind, name, fn)
+		return nil
+	})
+}
+
+// Write writes the bundle as a gzip-compressed tarball into the given directory and returns the
+// path of the written file.
+func (b *Bundle) Write(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("artifacts: failed to create output directory: %w", err)
+	}
+
+	fn := filepath.Join(dir, b.name+BundleExt)
+	f, err := os.Create(fn)
+	if err != nil {
+		return "", fmt.Errorf("artifacts: failed to create bundle: %w", err)
+	}
+	defer f.Close()
+
+	if err = b.writeTo(f); err != nil {
+		return "", err
+	}
+	if err = f.Close(); err != nil {
+		return "", fmt.Errorf("artifacts: failed to close bundle: %w", err)
+	}
+	return fn, nil
+}
+
+func (b *Bundle) writeTo(w io.Writer) error {
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+
+	names := make([]string, 0, len(b.artifacts))
+	for name := range b.artifacts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Stat all files first, so that the index can be written at the start of the bundle.
+	index := b.index
+	index.Time = time.Now()
+	index.Entries = []Entry{}
+	var toWrite []*artifact
+	for _, name := range names {
+		a := b.artifacts[name]
+		size := int64(len(a.data))
+		if a.data == nil {
+			fi, err := os.Stat(a.src)
+			if err != nil {
+				index.CollectionErrors = append(index.CollectionErrors,
+					fmt.Sprintf("artifacts: failed to stat %s: %s", a.src, err),
+				)
+				continue
+			}
+			size = fi.Size()
+		}
+		index.Entries = append(index.Entries, Entry{
+			Path: name,
+			Kind: a.kind,
+			Size: size,
+		})
+		toWrite = append(toWrite, a)
+	}
+
+	rawIndex, err := json.MarshalIndent(&index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("artifacts: failed to marshal index: %w", err)
+	}
+	if err = b.writeEntry(tw, IndexFile, int64(len(rawIndex)), bytes.NewReader(rawIndex)); err != nil {
+		return err
+	}
+
+	for i, a := range toWrite {
+		entry := index.Entries[i]
+		if a.data != nil {
+			err = b.writeEntry(tw, entry.Path, entry.Size, bytes.NewReader(a.data))
+		} else {
+			err = b.writeFile(tw, entry.Path, entry.Size, a.src)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return fmt.Errorf("artifacts: failed to finalize tarball: %w", err)
+	}
+	if err = zw.Close(); err != nil {
+		return fmt.Errorf("artifacts: failed to finalize compression: %w", err)
+	}
+	return nil
+}
+
+func (b *Bundle) writeFile(tw *tar.Writer, name string, size int64, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("artifacts: failed to open %s: %w", src, err)
+	}
+	defer f.Close()
+
+	// Files (e.g., logs) may still grow, only include the part that has been indexed.
+	return b.writeEntry(tw, name, size, io.LimitReader(f, size))
+}
+
+func (b *Bundle) writeEntry(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	hdr := &tar.Header{
+		Name:    path.Join(b.name, name),
+		Mode:    0o600,
+		Size:    size,
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("artifacts: failed to write header for %s: %w", name, err)
+	}
+	if _, err := io.Copy(tw, r); err != nil {
+		return fmt.Errorf("artifacts: failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// Classify returns the artifact kind of the file with the given name and whether the file is
+// recognized as an artifact.
+func Classify(fileName string) (string, bool) {
+	switch {
+	case fileName == "scenario_info.json":
+		return KindScenarioInfo, true
+	case fileName == "core" || strings.HasPrefix(fileName, "core."):
+		return KindCore, true
+	case strings.HasSuffix(fileName, ".log"):
+		return KindLog, true
+	case strings.HasSuffix(fileName, ".prom"):
+		return KindMetrics, true
+	default:
+		return "", false
+	}
+}
+
+// BundleName returns the bundle name for the given scenario environment name.
+func BundleName(envName string) string {
+	return strings.NewReplacer("/", "-", string(filepath.Separator), "-").Replace(envName)
+}
+
+// NewBundle creates a new empty artifact bundle for the given failed scenario run.
+func NewBundle(name string, scenarioInfo *env.ScenarioInstanceInfo, scenarioErr error) *Bundle {
+	b := &Bundle{
+		name: name,
+		index: Index{
+			Scenario: scenarioInfo,
+		},
+		artifacts: make(map[string]*artifact),
+	}
+	if scenarioErr != nil {
+		b.index.Error = scenarioErr.Error()
+	}
+	return b
+}
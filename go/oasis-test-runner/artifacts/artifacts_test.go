@@ -0,0 +1,97 @@
+package artifacts
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+)
+
+func TestBundle(t *testing.T) {
+	require := require.New(t)
+
+	srcDir, err := ioutil.TempDir("", "oasis-test-runner-artifacts-src")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(srcDir)
+	outDir, err := ioutil.TempDir("", "oasis-test-runner-artifacts-out")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(outDir)
+
+	files := map[string]string{
+		"scenario_info.json":           "{}",
+		"network/validator-0/node.log": "node log",
+		"network/validator-0/core":     "core dump",
+		"network/validator-0/data.db":  "database",
+	}
+	for name, data := range files {
+		fn := filepath.Join(srcDir, name)
+		require.NoError(os.MkdirAll(filepath.Dir(fn), 0o700), "MkdirAll")
+		require.NoError(ioutil.WriteFile(fn, []byte(data), 0o600), "WriteFile")
+	}
+
+	name := BundleName("e2e/runtime/runtime/1")
+	require.Equal("e2e-runtime-runtime-1", name)
+
+	b := NewBundle(name, &env.ScenarioInstanceInfo{Scenario: "e2e/runtime/runtime"}, errors.New("scenario failed"))
+	require.NoError(b.AddDir(srcDir), "AddDir")
+	b.AddData(KindMetrics, "metrics/oasis-test-runner.prom", []byte("up 0\n"))
+	b.AddFile(KindLog, "missing.log", filepath.Join(srcDir, "missing.log"))
+	b.AddError(errors.New("collection failed"))
+
+	fn, err := b.Write(outDir)
+	require.NoError(err, "Write")
+	require.Equal(filepath.Join(outDir, name+BundleExt), fn)
+
+	// Read the bundle back.
+	f, err := os.Open(fn)
+	require.NoError(err, "Open")
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	require.NoError(err, "gzip.NewReader")
+	tr := tar.NewReader(zr)
+
+	contents := make(map[string]string)
+	var order []string
+	for {
+		var hdr *tar.Header
+		hdr, err = tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err, "tar.Next")
+		var data []byte
+		data, err = ioutil.ReadAll(tr)
+		require.NoError(err, "ReadAll")
+		contents[hdr.Name] = string(data)
+		order = append(order, hdr.Name)
+	}
+	require.Equal(filepath.Join(name, IndexFile), order[0], "index should be the first entry")
+	require.Len(contents, 5, "database files and missing files should be skipped")
+	require.Equal("node log", contents[filepath.Join(name, "network/validator-0/node.log")])
+	require.Equal("core dump", contents[filepath.Join(name, "network/validator-0/core")])
+	require.Equal("up 0\n", contents[filepath.Join(name, "metrics/oasis-test-runner.prom")])
+
+	var index Index
+	require.NoError(json.Unmarshal([]byte(contents[order[0]]), &index), "unmarshal index")
+	require.Equal("e2e/runtime/runtime", index.Scenario.Scenario)
+	require.Equal("scenario failed", index.Error)
+	require.Len(index.CollectionErrors, 2, "collection errors should include the missing file")
+	require.Equal(
+		[]Entry{
+			{Path: "metrics/oasis-test-runner.prom", Kind: KindMetrics, Size: 5},
+			{Path: "network/validator-0/core", Kind: KindCore, Size: 9},
+			{Path: "network/validator-0/node.log", Kind: KindLog, Size: 8},
+			{Path: "scenario_info.json", Kind: KindScenarioInfo, Size: 2},
+		},
+		index.Entries,
+	)
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/metrics"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/artifacts"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/cli"
+)
+
+const (
+	cfgArtifactsDir          = "artifacts.dir"
+	cfgArtifactsCoreDir      = "artifacts.core_dir"
+	cfgArtifactsNoStateDumps = "artifacts.no_state_dumps"
+
+	// metricsSnapshotTimeout is the timeout for fetching the Prometheus push gateway metrics.
+	metricsSnapshotTimeout = 10 * time.Second
+)
+
+// artifactsFlags has the failure artifact collection configuration flags.
+var artifactsFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+// collectArtifacts collects the artifacts of a failed scenario run into a bundle, in case
+// artifact collection is enabled.
+//
+// Collection is best effort and any errors are recorded in the bundle index.
+func collectArtifacts(logger *logging.Logger, childEnv *env.Env, net *oasis.Network, started time.Time, scenarioErr error) {
+	outDir := viper.GetString(cfgArtifactsDir)
+	if outDir == "" {
+		return
+	}
+
+	bundle := artifacts.NewBundle(artifacts.BundleName(childEnv.Name()), childEnv.ScenarioInfo(), scenarioErr)
+
+	// Dump the consensus state first, so that the dump command logs are collected as well.
+	if net != nil && !viper.GetBool(cfgArtifactsNoStateDumps) {
+		dumpConsensusState(logger, childEnv, net, bundle)
+	}
+	if err := bundle.AddDir(childEnv.Dir()); err != nil {
+		bundle.AddError(fmt.Errorf("failed to collect scenario directory: %w", err))
+	}
+	snapshotMetrics(bundle)
+	if coreDir := viper.GetString(cfgArtifactsCoreDir); coreDir != "" {
+		collectCoreFiles(bundle, coreDir, started)
+	}
+
+	fn, err := bundle.Write(outDir)
+	if err != nil {
+		logger.Error("failed to write failure artifacts",
+			"err", err,
+			"scenario", childEnv.Name(),
+		)
+		return
+	}
+
+	logger.Info("wrote failure artifacts",
+		"scenario", childEnv.Name(),
+		"path", fn,
+	)
+}
+
+// dumpConsensusState dumps the consensus state of all validators into the bundle.
+//
+// This must only be called after the network has been stopped.
+func dumpConsensusState(logger *logging.Logger, childEnv *env.Env, net *oasis.Network, bundle *artifacts.Bundle) {
+	for _, val := range net.Validators() {
+		name := "artifacts-dumpdb-" + val.Name
+		dumpPath := filepath.Join(childEnv.Dir(), name, "state_dump.json")
+		args := []string{
+			"debug", "dumpdb",
+			"--datadir", val.DataDir(),
+			"-g", net.GenesisPath(),
+			"--dump.output", dumpPath,
+			"--debug.dont_blame_oasis",
+			"--debug.allow_test_keys",
+		}
+		if err := cli.RunSubCommand(childEnv, logger, name, net.Config().NodeBinary, args); err != nil {
+			bundle.AddError(fmt.Errorf("failed to dump consensus state of %s: %w", val.Name, err))
+			continue
+		}
+		bundle.AddFile(artifacts.KindStateDump, filepath.Join(name, "state_dump.json"), dumpPath)
+	}
+}
+
+// snapshotMetrics adds a snapshot of the test runner metrics and, if configured, of the metrics
+// pushed by the nodes to the Prometheus push gateway to the bundle.
+func snapshotMetrics(bundle *artifacts.Bundle) {
+	mfs, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		bundle.AddError(fmt.Errorf("failed to gather test runner metrics: %w", err))
+	} else {
+		var buf bytes.Buffer
+		enc := expfmt.NewEncoder(&buf, expfmt.FmtText)
+		for _, mf := range mfs {
+			if err = enc.Encode(mf); err != nil {
+				bundle.AddError(fmt.Errorf("failed to encode test runner metrics: %w", err))
+				break
+			}
+		}
+		bundle.AddData(artifacts.KindMetrics, "metrics/oasis-test-runner.prom", buf.Bytes())
+	}
+
+	if !viper.IsSet(metrics.CfgMetricsAddr) {
+		return
+	}
+	data, err := fetchPushGatewayMetrics(viper.GetString(metrics.CfgMetricsAddr))
+	if err != nil {
+		bundle.AddError(fmt.Errorf("failed to fetch push gateway metrics: %w", err))
+		return
+	}
+	bundle.AddData(artifacts.KindMetrics, "metrics/pushgateway.prom", data)
+}
+
+func fetchPushGatewayMetrics(addr string) ([]byte, error) {
+	// Use the same address conventions as the Prometheus pusher.
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	addr = strings.TrimSuffix(addr, "/")
+
+	ctx, cancel := context.WithTimeout(context.Background(), metricsSnapshotTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, addr+"/metrics", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// collectCoreFiles adds the core files written into the given directory after the scenario has
+// been started to the bundle.
+func collectCoreFiles(bundle *artifacts.Bundle, coreDir string, started time.Time) {
+	entries, err := ioutil.ReadDir(coreDir)
+	if err != nil {
+		bundle.AddError(fmt.Errorf("failed to read core file directory: %w", err))
+		return
+	}
+	for _, fi := range entries {
+		if !fi.Mode().IsRegular() || fi.ModTime().Before(started) {
+			continue
+		}
+		if kind, ok := artifacts.Classify(fi.Name()); !ok || kind != artifacts.KindCore {
+			continue
+		}
+		bundle.AddFile(artifacts.KindCore, filepath.Join("cores", fi.Name()), filepath.Join(coreDir, fi.Name()))
+	}
+}
+
+func init() {
+	artifactsFlags.String(cfgArtifactsDir, "", "directory to write failed scenario artifact bundles to (empty = disabled)")
+	artifactsFlags.String(cfgArtifactsCoreDir, "", "directory to collect core files from on scenario failure")
+	artifactsFlags.Bool(cfgArtifactsNoStateDumps, false, "do not dump the consensus state on scenario failure")
+	_ = viper.BindPFlags(artifactsFlags)
+}
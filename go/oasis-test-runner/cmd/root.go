@@ -430,7 +430,9 @@ func runRoot(cmd *cobra.Command, args []string) error { // nolint: gocyclo
 					pusher = pusher.Gatherer(prometheus.DefaultGatherer)
 				}
 
-				if err = doScenario(childEnv, v); err != nil {
+				started := time.Now()
+				var net *oasis.Network
+				if net, err = doScenario(childEnv, v); err != nil {
 					logger.Error("failed to run scenario",
 						"err", err,
 						"scenario", name,
@@ -451,6 +453,8 @@ func runRoot(cmd *cobra.Command, args []string) error { // nolint: gocyclo
 				}
 
 				if err != nil {
+					// Collect artifacts after cleanup so that all processes have terminated.
+					collectArtifacts(logger, childEnv, net, started, err)
 					return err
 				}
 
@@ -466,7 +470,7 @@ func runRoot(cmd *cobra.Command, args []string) error { // nolint: gocyclo
 	return nil
 }
 
-func doScenario(childEnv *env.Env, sc scenario.Scenario) (err error) {
+func doScenario(childEnv *env.Env, sc scenario.Scenario) (net *oasis.Network, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("root: panic caught running scenario: %v: %s", r, debug.Stack())
@@ -486,7 +490,6 @@ func doScenario(childEnv *env.Env, sc scenario.Scenario) (err error) {
 
 	// Instantiate fixture if it is non-nil. Otherwise assume Init will do
 	// something on its own.
-	var net *oasis.Network
 	if fixture != nil {
 		if net, err = fixture.Create(childEnv); err != nil {
 			err = fmt.Errorf("root: failed to instantiate fixture: %w", err)
@@ -612,6 +615,7 @@ func init() {
 	_ = viper.BindPFlags(rootFlags)
 	rootCmd.Flags().AddFlagSet(rootFlags)
 	rootCmd.Flags().AddFlagSet(env.Flags)
+	rootCmd.Flags().AddFlagSet(artifactsFlags)
 	rootCmd.AddCommand(listCmd)
 
 	cmp.Register(rootCmd)
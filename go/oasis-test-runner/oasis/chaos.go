@@ -0,0 +1,576 @@
+package oasis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+const (
+	// ChaosFaultPartition isolates the nodes from the rest of the network by
+	// dropping all traffic destined to their ports (requires iptables).
+	ChaosFaultPartition ChaosFaultKind = "partition"
+	// ChaosFaultNetem degrades the network of the nodes by introducing
+	// latency and/or packet loss (requires tc).
+	ChaosFaultNetem ChaosFaultKind = "netem"
+	// ChaosFaultPause pauses the node processes with SIGSTOP.
+	ChaosFaultPause ChaosFaultKind = "pause"
+	// ChaosFaultClockSkew restarts the nodes with a skewed clock (requires
+	// libfaketime).
+	ChaosFaultClockSkew ChaosFaultKind = "clock_skew"
+	// ChaosFaultDiskFill fills the disk under the node data directories.
+	ChaosFaultDiskFill ChaosFaultKind = "disk_fill"
+
+	chaosIface           = "lo"
+	chaosDiskFillFile    = "chaos-disk-fill"
+	chaosDiskFillChunk   = 1024 * 1024
+	chaosNetemFirstBand  = 4
+	chaosNetemMaxBands   = 16
+	chaosRuleCommentBase = "oasis-chaos-"
+
+	defaultFaketimeLibrary = "/usr/lib/x86_64-linux-gnu/faketime/libfaketime.so.1"
+)
+
+// ChaosFaultKind is the kind of an injected fault.
+type ChaosFaultKind string
+
+// ChaosFaultCfg is a declarative description of a fault injected into the
+// test network.
+type ChaosFaultCfg struct { // nolint: maligned
+	// Kind is the kind of the fault.
+	Kind ChaosFaultKind `json:"kind"`
+
+	// Nodes are the names of the nodes affected by the fault.
+	Nodes []string `json:"nodes"`
+
+	// Start is the time after the network has been started at which the
+	// fault is injected.
+	Start time.Duration `json:"start"`
+
+	// Duration is the time after which the fault is reverted. Zero means
+	// that the fault is active until the network is stopped.
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// Latency is the latency added to each packet (netem only).
+	Latency time.Duration `json:"latency,omitempty"`
+
+	// Jitter is the latency jitter (netem only).
+	Jitter time.Duration `json:"jitter,omitempty"`
+
+	// PacketLoss is the packet loss percentage (netem only).
+	PacketLoss float64 `json:"packet_loss,omitempty"`
+
+	// ClockSkew is the clock offset (clock_skew only).
+	ClockSkew time.Duration `json:"clock_skew,omitempty"`
+
+	// FaketimeLibrary is the path to the libfaketime library (clock_skew
+	// only).
+	FaketimeLibrary string `json:"faketime_library,omitempty"`
+
+	// DiskFillBytes is the number of bytes to write under each node's data
+	// directory (disk_fill only). Writing stops early if the disk is full.
+	DiskFillBytes uint64 `json:"disk_fill_bytes,omitempty"`
+}
+
+// ValidateBasic performs basic fault configuration validity checks.
+func (cfg *ChaosFaultCfg) ValidateBasic() error {
+	if len(cfg.Nodes) == 0 {
+		return fmt.Errorf("no nodes specified")
+	}
+	if cfg.Start < 0 || cfg.Duration < 0 {
+		return fmt.Errorf("negative start or duration")
+	}
+
+	switch cfg.Kind {
+	case ChaosFaultPartition, ChaosFaultPause:
+	case ChaosFaultNetem:
+		if cfg.Latency <= 0 && cfg.PacketLoss <= 0 {
+			return fmt.Errorf("netem fault requires latency or packet loss")
+		}
+		if cfg.Jitter < 0 || cfg.Latency < 0 || cfg.PacketLoss < 0 || cfg.PacketLoss > 100 {
+			return fmt.Errorf("invalid netem parameters")
+		}
+	case ChaosFaultClockSkew:
+		if cfg.ClockSkew == 0 {
+			return fmt.Errorf("clock skew fault requires a non-zero clock skew")
+		}
+	case ChaosFaultDiskFill:
+		if cfg.DiskFillBytes == 0 {
+			return fmt.Errorf("disk fill fault requires a non-zero size")
+		}
+	default:
+		return fmt.Errorf("unsupported fault kind: %s", cfg.Kind)
+	}
+	return nil
+}
+
+// netemArgs returns the netem qdisc arguments for the fault.
+func (cfg *ChaosFaultCfg) netemArgs() []string {
+	args := []string{"netem"}
+	if cfg.Latency > 0 {
+		args = append(args, "delay", fmt.Sprintf("%dms", cfg.Latency.Milliseconds()))
+		if cfg.Jitter > 0 {
+			args = append(args, fmt.Sprintf("%dms", cfg.Jitter.Milliseconds()))
+		}
+	}
+	if cfg.PacketLoss > 0 {
+		args = append(args, "loss", strconv.FormatFloat(cfg.PacketLoss, 'f', -1, 64)+"%")
+	}
+	return args
+}
+
+// ChaosFault is an injected fault.
+type ChaosFault struct {
+	cfg   ChaosFaultCfg
+	id    int
+	nodes []*Node
+
+	// revertFns are called in reverse order to revert the fault.
+	revertFns []func() error
+}
+
+// Config returns the fault configuration.
+func (f *ChaosFault) Config() ChaosFaultCfg {
+	return f.cfg
+}
+
+// ChaosInjector injects faults into the test network.
+type ChaosInjector struct {
+	sync.Mutex
+
+	net    *Network
+	logger *logging.Logger
+
+	nextID    int
+	active    map[int]*ChaosFault
+	usedBands map[int]bool
+	hasQdisc  bool
+
+	cancelFn context.CancelFunc
+	wg       sync.WaitGroup
+	stopping bool
+}
+
+func (c *ChaosInjector) run(ctx context.Context, name string, args ...string) error {
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput() // nolint: gosec
+	if err != nil {
+		return fmt.Errorf("oasis/chaos: '%s %s' failed: %w (output: %s)", name, strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (c *ChaosInjector) resolveNodes(names []string) ([]*Node, error) {
+	nodes := make(map[string]*Node)
+	for _, n := range c.net.Nodes() {
+		nodes[n.Name] = n
+	}
+
+	var resolved []*Node
+	for _, name := range names {
+		n, ok := nodes[name]
+		if !ok {
+			return nil, fmt.Errorf("oasis/chaos: unknown node: %s", name)
+		}
+		resolved = append(resolved, n)
+	}
+	return resolved, nil
+}
+
+// nodePorts returns the TCP ports the node is listening on for peers.
+func nodePorts(n *Node) []uint16 {
+	var ports []uint16
+	for name, port := range n.assignedPorts {
+		switch name {
+		case nodePortPprof, nodePortClient:
+		default:
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+func (c *ChaosInjector) injectPartition(ctx context.Context, f *ChaosFault) error {
+	comment := chaosRuleCommentBase + strconv.Itoa(f.id)
+	for _, n := range f.nodes {
+		for _, port := range nodePorts(n) {
+			rule := []string{
+				"INPUT", "-i", chaosIface, "-p", "tcp", "--dport", strconv.Itoa(int(port)),
+				"-m", "comment", "--comment", comment, "-j", "DROP",
+			}
+			if err := c.run(ctx, "iptables", append([]string{"-I"}, rule...)...); err != nil {
+				return err
+			}
+			f.revertFns = append(f.revertFns, func() error {
+				return c.run(context.Background(), "iptables", append([]string{"-D"}, rule...)...)
+			})
+		}
+	}
+	return nil
+}
+
+func (c *ChaosInjector) allocBand() (int, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	for band := chaosNetemFirstBand; band <= chaosNetemMaxBands; band++ {
+		if !c.usedBands[band] {
+			c.usedBands[band] = true
+			return band, nil
+		}
+	}
+	return 0, fmt.Errorf("oasis/chaos: too many concurrent netem faults")
+}
+
+func (c *ChaosInjector) freeBand(band int) error {
+	c.Lock()
+	defer c.Unlock()
+
+	delete(c.usedBands, band)
+	if len(c.usedBands) > 0 || !c.hasQdisc {
+		return nil
+	}
+	c.hasQdisc = false
+	return c.run(context.Background(), "tc", "qdisc", "del", "dev", chaosIface, "root")
+}
+
+func (c *ChaosInjector) ensureQdisc(ctx context.Context) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.hasQdisc {
+		return nil
+	}
+	// Use a priority qdisc with the default priority map so that only the
+	// traffic matched by the fault filters is affected.
+	err := c.run(ctx, "tc", "qdisc", "add", "dev", chaosIface, "root", "handle", "1:", "prio",
+		"bands", strconv.Itoa(chaosNetemMaxBands),
+		"priomap", "1", "2", "2", "2", "1", "2", "0", "0", "1", "1", "1", "1", "1", "1", "1", "1",
+	)
+	if err != nil {
+		return err
+	}
+	c.hasQdisc = true
+	return nil
+}
+
+func (c *ChaosInjector) injectNetem(ctx context.Context, f *ChaosFault) error {
+	band, err := c.allocBand()
+	if err != nil {
+		return err
+	}
+	f.revertFns = append(f.revertFns, func() error {
+		return c.freeBand(band)
+	})
+
+	if err = c.ensureQdisc(ctx); err != nil {
+		return err
+	}
+
+	flowID := fmt.Sprintf("1:%d", band)
+	args := append([]string{"qdisc", "add", "dev", chaosIface, "parent", flowID, "handle", fmt.Sprintf("%d:", band*10)}, f.cfg.netemArgs()...)
+	if err = c.run(ctx, "tc", args...); err != nil {
+		return err
+	}
+	f.revertFns = append(f.revertFns, func() error {
+		return c.run(context.Background(), "tc", "qdisc", "del", "dev", chaosIface, "parent", flowID)
+	})
+
+	prio := strconv.Itoa(band)
+	f.revertFns = append(f.revertFns, func() error {
+		return c.run(context.Background(), "tc", "filter", "del", "dev", chaosIface, "parent", "1:", "prio", prio)
+	})
+	for _, n := range f.nodes {
+		for _, port := range nodePorts(n) {
+			for _, dir := range []string{"dport", "sport"} {
+				if err = c.run(ctx, "tc", "filter", "add", "dev", chaosIface, "parent", "1:", "protocol", "ip",
+					"prio", prio, "u32", "match", "ip", dir, strconv.Itoa(int(port)), "0xffff", "flowid", flowID,
+				); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func signalNode(n *Node, sig syscall.Signal) error {
+	n.Lock()
+	defer n.Unlock()
+
+	if n.cmd == nil || n.cmd.Process == nil {
+		return fmt.Errorf("oasis/chaos: node %s is not running", n.Name)
+	}
+	return n.cmd.Process.Signal(sig)
+}
+
+func (c *ChaosInjector) injectPause(ctx context.Context, f *ChaosFault) error {
+	for _, n := range f.nodes {
+		n := n
+		if err := signalNode(n, syscall.SIGSTOP); err != nil {
+			return err
+		}
+		f.revertFns = append(f.revertFns, func() error {
+			return signalNode(n, syscall.SIGCONT)
+		})
+	}
+	return nil
+}
+
+func (c *ChaosInjector) injectClockSkew(ctx context.Context, f *ChaosFault) error {
+	lib := f.cfg.FaketimeLibrary
+	if lib == "" {
+		lib = defaultFaketimeLibrary
+	}
+	skew := strconv.FormatFloat(f.cfg.ClockSkew.Seconds(), 'f', -1, 64)
+	if f.cfg.ClockSkew > 0 {
+		skew = "+" + skew
+	}
+
+	for _, n := range f.nodes {
+		n := n
+		n.Lock()
+		n.extraEnv = []string{"LD_PRELOAD=" + lib, "FAKETIME=" + skew + "s"}
+		n.Unlock()
+		if err := n.Restart(ctx); err != nil {
+			return err
+		}
+		f.revertFns = append(f.revertFns, func() error {
+			n.Lock()
+			n.extraEnv = nil
+			n.Unlock()
+
+			// No need to restart the node if the network is being stopped.
+			c.Lock()
+			stopping := c.stopping
+			c.Unlock()
+			if stopping {
+				return nil
+			}
+			return n.Restart(context.Background())
+		})
+	}
+	return nil
+}
+
+func (c *ChaosInjector) injectDiskFill(ctx context.Context, f *ChaosFault) error {
+	chunk := make([]byte, chaosDiskFillChunk)
+	for _, n := range f.nodes {
+		path := filepath.Join(n.DataDir(), chaosDiskFillFile)
+		fd, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return fmt.Errorf("oasis/chaos: failed to create disk fill file: %w", err)
+		}
+		f.revertFns = append(f.revertFns, func() error {
+			return os.Remove(path)
+		})
+
+		for remaining := f.cfg.DiskFillBytes; remaining > 0 && ctx.Err() == nil; {
+			toWrite := chunk
+			if remaining < uint64(len(chunk)) {
+				toWrite = chunk[:remaining]
+			}
+			if _, err = fd.Write(toWrite); err != nil {
+				break
+			}
+			remaining -= uint64(len(toWrite))
+		}
+		_ = fd.Close()
+
+		switch {
+		case err == nil:
+		case errors.Is(err, syscall.ENOSPC):
+			c.logger.Info("disk filled up",
+				"node", n.Name,
+			)
+		default:
+			return fmt.Errorf("oasis/chaos: failed to fill disk: %w", err)
+		}
+	}
+	return nil
+}
+
+// Inject injects the given fault into the network immediately. The fault is
+// active until it is reverted or the network is stopped.
+func (c *ChaosInjector) Inject(ctx context.Context, cfg ChaosFaultCfg) (*ChaosFault, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("oasis/chaos: invalid fault: %w", err)
+	}
+	nodes, err := c.resolveNodes(cfg.Nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	f := &ChaosFault{
+		cfg:   cfg,
+		id:    c.nextID,
+		nodes: nodes,
+	}
+	c.nextID++
+	c.Unlock()
+
+	c.logger.Info("injecting fault",
+		"kind", cfg.Kind,
+		"nodes", cfg.Nodes,
+		"id", f.id,
+	)
+
+	switch cfg.Kind {
+	case ChaosFaultPartition:
+		err = c.injectPartition(ctx, f)
+	case ChaosFaultNetem:
+		err = c.injectNetem(ctx, f)
+	case ChaosFaultPause:
+		err = c.injectPause(ctx, f)
+	case ChaosFaultClockSkew:
+		err = c.injectClockSkew(ctx, f)
+	case ChaosFaultDiskFill:
+		err = c.injectDiskFill(ctx, f)
+	}
+	if err != nil {
+		// Roll back any partially applied fault.
+		_ = c.revertFault(f)
+		return nil, err
+	}
+
+	c.Lock()
+	c.active[f.id] = f
+	c.Unlock()
+
+	return f, nil
+}
+
+func (c *ChaosInjector) revertFault(f *ChaosFault) error {
+	var errs []string
+	for i := len(f.revertFns) - 1; i >= 0; i-- {
+		if err := f.revertFns[i](); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	f.revertFns = nil
+	if len(errs) > 0 {
+		return fmt.Errorf("oasis/chaos: failed to revert fault: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Revert reverts a previously injected fault.
+func (c *ChaosInjector) Revert(f *ChaosFault) error {
+	c.Lock()
+	_, ok := c.active[f.id]
+	delete(c.active, f.id)
+	c.Unlock()
+	if !ok {
+		return nil
+	}
+
+	c.logger.Info("reverting fault",
+		"kind", f.cfg.Kind,
+		"nodes", f.cfg.Nodes,
+		"id", f.id,
+	)
+	return c.revertFault(f)
+}
+
+// start schedules all of the given faults.
+func (c *ChaosInjector) start(faults []ChaosFaultCfg) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Lock()
+	c.cancelFn = cancel
+	c.Unlock()
+
+	for _, cfg := range faults {
+		cfg := cfg
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+
+			select {
+			case <-time.After(cfg.Start):
+			case <-ctx.Done():
+				return
+			}
+
+			f, err := c.Inject(ctx, cfg)
+			if err != nil {
+				if ctx.Err() == nil {
+					c.net.errCh <- err
+				}
+				return
+			}
+			if cfg.Duration == 0 {
+				return
+			}
+
+			select {
+			case <-time.After(cfg.Duration):
+			case <-ctx.Done():
+				return
+			}
+			if err = c.Revert(f); err != nil {
+				c.net.errCh <- err
+			}
+		}()
+	}
+}
+
+// validate checks that all of the given faults are valid.
+func (c *ChaosInjector) validate(faults []ChaosFaultCfg) error {
+	for i, cfg := range faults {
+		if err := cfg.ValidateBasic(); err != nil {
+			return fmt.Errorf("oasis/chaos: invalid fault %d: %w", i, err)
+		}
+		if _, err := c.resolveNodes(cfg.Nodes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop cancels all scheduled faults and reverts all active faults.
+func (c *ChaosInjector) Stop() {
+	c.Lock()
+	c.stopping = true
+	cancelFn := c.cancelFn
+	c.Unlock()
+
+	if cancelFn != nil {
+		cancelFn()
+	}
+	c.wg.Wait()
+
+	c.Lock()
+	var active []*ChaosFault
+	for _, f := range c.active {
+		active = append(active, f)
+	}
+	c.Unlock()
+
+	for _, f := range active {
+		if err := c.Revert(f); err != nil {
+			c.logger.Error("failed to revert fault",
+				"err", err,
+				"id", f.id,
+			)
+		}
+	}
+}
+
+func newChaosInjector(net *Network) *ChaosInjector {
+	return &ChaosInjector{
+		net:       net,
+		logger:    net.logger.With("component", "chaos"),
+		active:    make(map[int]*ChaosFault),
+		usedBands: make(map[int]bool),
+	}
+}
@@ -0,0 +1,46 @@
+package oasis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaosFaultCfg(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		cfg   ChaosFaultCfg
+		valid bool
+		msg   string
+	}{
+		{ChaosFaultCfg{Kind: ChaosFaultPause, Nodes: []string{"validator-0"}}, true, "pause"},
+		{ChaosFaultCfg{Kind: ChaosFaultPause}, false, "no nodes"},
+		{ChaosFaultCfg{Kind: "invalid", Nodes: []string{"validator-0"}}, false, "invalid kind"},
+		{ChaosFaultCfg{Kind: ChaosFaultPartition, Nodes: []string{"validator-0"}, Start: -1}, false, "negative start"},
+		{ChaosFaultCfg{Kind: ChaosFaultNetem, Nodes: []string{"validator-0"}}, false, "netem without parameters"},
+		{ChaosFaultCfg{Kind: ChaosFaultNetem, Nodes: []string{"validator-0"}, PacketLoss: 101}, false, "netem with invalid loss"},
+		{ChaosFaultCfg{Kind: ChaosFaultNetem, Nodes: []string{"validator-0"}, Latency: time.Second}, true, "netem"},
+		{ChaosFaultCfg{Kind: ChaosFaultClockSkew, Nodes: []string{"validator-0"}}, false, "clock skew without skew"},
+		{ChaosFaultCfg{Kind: ChaosFaultClockSkew, Nodes: []string{"validator-0"}, ClockSkew: -time.Minute}, true, "clock skew"},
+		{ChaosFaultCfg{Kind: ChaosFaultDiskFill, Nodes: []string{"validator-0"}}, false, "disk fill without size"},
+		{ChaosFaultCfg{Kind: ChaosFaultDiskFill, Nodes: []string{"validator-0"}, DiskFillBytes: 1024}, true, "disk fill"},
+	} {
+		err := tc.cfg.ValidateBasic()
+		switch tc.valid {
+		case true:
+			require.NoError(err, tc.msg)
+		case false:
+			require.Error(err, tc.msg)
+		}
+	}
+
+	cfg := ChaosFaultCfg{
+		Kind:       ChaosFaultNetem,
+		Latency:    100 * time.Millisecond,
+		Jitter:     10 * time.Millisecond,
+		PacketLoss: 2.5,
+	}
+	require.EqualValues([]string{"netem", "delay", "100ms", "10ms", "loss", "2.5%"}, cfg.netemArgs())
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strconv"
@@ -73,6 +74,8 @@ type Network struct { // nolint: maligned
 	controller       *Controller
 	clientController *Controller
 
+	chaos *ChaosInjector
+
 	errCh chan error
 }
 
@@ -144,6 +147,10 @@ type NetworkCfg struct { // nolint: maligned
 	// left empty. Nodes are started in the order in which they appear here (automatically created
 	// nodes are appended).
 	Nodes []string
+
+	// Chaos are the faults that are injected into the network after it has
+	// been started.
+	Chaos []ChaosFaultCfg `json:"chaos,omitempty"`
 }
 
 // SetMockEpoch force-enables the mock epoch time keeping.
@@ -162,6 +169,11 @@ func (cfg *NetworkCfg) SetInsecureBeacon() {
 	}
 }
 
+// Chaos returns the network's fault injector.
+func (net *Network) Chaos() *ChaosInjector {
+	return net.chaos
+}
+
 // Config returns the network configuration.
 func (net *Network) Config() *NetworkCfg {
 	return net.cfg
@@ -498,6 +510,14 @@ func (net *Network) Start() error { // nolint: gocyclo
 		break
 	}
 
+	// Schedule any declaratively configured faults.
+	if len(net.cfg.Chaos) > 0 {
+		if err = net.chaos.validate(net.cfg.Chaos); err != nil {
+			return err
+		}
+		net.chaos.start(net.cfg.Chaos)
+	}
+
 	net.logger.Info("network started")
 	net.running = true
 
@@ -506,6 +526,7 @@ func (net *Network) Start() error { // nolint: gocyclo
 
 // Stop stops the network.
 func (net *Network) Stop() {
+	net.chaos.Stop()
 	net.env.Cleanup()
 	net.running = false
 }
@@ -644,6 +665,9 @@ func (net *Network) startOasisNode(
 	cmd.SysProcAttr = env.CmdAttrs
 	cmd.Stdout = w
 	cmd.Stderr = w
+	if len(node.extraEnv) > 0 {
+		cmd.Env = append(os.Environ(), node.extraEnv...)
+	}
 
 	net.logger.Info("launching Oasis node",
 		"args", strings.Join(args, " "),
@@ -941,6 +965,8 @@ func New(env *env.Env, cfg *NetworkCfg) (*Network, error) {
 		nextNodePort: baseNodePort,
 		errCh:        make(chan error, maxNodes),
 	}
+	net.chaos = newChaosInjector(net)
+	env.AddOnCleanup(net.chaos.Stop)
 
 	// Pre-provision node objects if they were listed in the top-level network fixture.
 	for _, nodeName := range cfg.Nodes {
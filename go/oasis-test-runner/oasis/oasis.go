@@ -86,6 +86,7 @@ type Node struct { // nolint: maligned
 	cmd *exec.Cmd
 
 	extraArgs      []Argument
+	extraEnv       []string
 	features       []Feature
 	hasValidators  bool
 	assignedPorts  map[string]uint16
@@ -0,0 +1,106 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+const (
+	consensusChaosPauseStart    = 10 * time.Second
+	consensusChaosPauseDuration = 20 * time.Second
+)
+
+// ConsensusChaosPause is the scenario where validators are paused for a while
+// and the network is expected to recover once they are resumed.
+var ConsensusChaosPause scenario.Scenario = &consensusChaosImpl{
+	E2E: *NewE2E("consensus-chaos-pause"),
+}
+
+type consensusChaosImpl struct {
+	E2E
+}
+
+func (sc *consensusChaosImpl) Clone() scenario.Scenario {
+	return &consensusChaosImpl{
+		E2E: sc.E2E.Clone(),
+	}
+}
+
+func (sc *consensusChaosImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.E2E.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	f.Network.SetInsecureBeacon()
+
+	// Pause two out of three validators which halts consensus as not enough
+	// voting power remains.
+	f.Network.Chaos = []oasis.ChaosFaultCfg{
+		{
+			Kind:     oasis.ChaosFaultPause,
+			Nodes:    []string{"validator-1", "validator-2"},
+			Start:    consensusChaosPauseStart,
+			Duration: consensusChaosPauseDuration,
+		},
+	}
+
+	return f, nil
+}
+
+func (sc *consensusChaosImpl) Run(childEnv *env.Env) error {
+	if err := sc.Net.Start(); err != nil {
+		return err
+	}
+	started := time.Now()
+
+	ctx := context.Background()
+	sc.Logger.Info("waiting for network to come up")
+	if err := sc.Net.Controller().WaitNodesRegistered(ctx, len(sc.Net.Validators())); err != nil {
+		return err
+	}
+
+	// Wait for the fault to be reverted.
+	select {
+	case <-time.After(time.Until(started.Add(consensusChaosPauseStart + consensusChaosPauseDuration))):
+	case err := <-sc.Net.Errors():
+		return fmt.Errorf("network error during fault injection: %w", err)
+	}
+
+	blk, err := sc.Net.Controller().Consensus.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	sc.Logger.Info("validators resumed, waiting for the network to make progress",
+		"height", blk.Height,
+	)
+
+	// The network should make progress again.
+	blockCh, blockSub, err := sc.Net.Controller().Consensus.WatchBlocks(ctx)
+	if err != nil {
+		return err
+	}
+	defer blockSub.Close()
+
+	for {
+		select {
+		case newBlk := <-blockCh:
+			if newBlk.Height < blk.Height+5 {
+				continue
+			}
+			sc.Logger.Info("network recovered",
+				"height", newBlk.Height,
+			)
+			return nil
+		case <-time.After(60 * time.Second):
+			return fmt.Errorf("timed out waiting for the network to recover")
+		}
+	}
+}
@@ -420,6 +420,8 @@ func RegisterScenarios() error {
 		EarlyQueryInitHeight,
 		// Consensus state sync.
 		ConsensusStateSync,
+		// Consensus chaos tests.
+		ConsensusChaosPause,
 		// Multiple seeds test.
 		MultipleSeeds,
 		// Seed API test.
@@ -191,6 +191,12 @@ func (sc *seedAPI) Run(childEnv *env.Env) error { // nolint: gocyclo
 		return fmt.Errorf("seed node GetParameters should fail with unsupported")
 	}
 
+	sc.Logger.Info("testing GetValidatorSet")
+	_, err = seedCtrl.Consensus.GetValidatorSet(ctx, consensusAPI.HeightLatest)
+	if err != consensusAPI.ErrUnsupported {
+		return fmt.Errorf("seed node GetValidatorSet should fail with unsupported")
+	}
+
 	sc.Logger.Info("testing RequestShutdown")
 	if err := seedCtrl.RequestShutdown(ctx, true); err != nil {
 		return fmt.Errorf("seed node request shutdown error: %w", err)
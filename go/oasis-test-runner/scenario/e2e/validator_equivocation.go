@@ -229,6 +229,18 @@ WaitLoop:
 		}
 	}
 
+	// Make sure the evidence has been recorded.
+	recentEvidence, err := ctrl.Staking.Evidence(ctx, consensusAPI.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("Evidence: %w", err)
+	}
+	if len(recentEvidence) != 1 {
+		return fmt.Errorf("expected exactly one recorded evidence, got: %d", len(recentEvidence))
+	}
+	if ev := recentEvidence[0]; ev.Reason != staking.SlashConsensusEquivocation || !ev.NodeID.Equal(identity.NodeSigner.Public()) {
+		return fmt.Errorf("unexpected recorded evidence: %+v", ev)
+	}
+
 	// Make sure the node is frozen.
 	nodeStatus, err := ctrl.Consensus.Registry().GetNodeStatus(ctx, &registry.IDQuery{ID: identity.NodeSigner.Public(), Height: consensusAPI.HeightLatest})
 	if err != nil {
@@ -19,6 +19,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/proof"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
@@ -129,6 +130,8 @@ var (
 	MethodUnfreezeNode = transaction.NewMethodName(ModuleName, "UnfreezeNode", UnfreezeNode{})
 	// MethodRegisterRuntime is the method name for registering runtimes.
 	MethodRegisterRuntime = transaction.NewMethodName(ModuleName, "RegisterRuntime", Runtime{})
+	// MethodProveFreshness is the method name for freshness proofs.
+	MethodProveFreshness = transaction.NewMethodName(ModuleName, "ProveFreshness", Blob{})
 
 	// Methods is the list of all methods supported by the registry backend.
 	Methods = []transaction.MethodName{
@@ -137,6 +140,7 @@ var (
 		MethodRegisterNode,
 		MethodUnfreezeNode,
 		MethodRegisterRuntime,
+		MethodProveFreshness,
 	}
 
 	// RuntimesRequiredRoles are the Node roles that require runtimes.
@@ -169,6 +173,11 @@ type Backend interface {
 	// GetEntities gets a list of all registered entities.
 	GetEntities(context.Context, int64) ([]*entity.Entity, error)
 
+	// GetArchivedEntity gets the archived descriptor of a deregistered entity by ID.
+	//
+	// Archived descriptors are only kept for ArchiveRetention epochs.
+	GetArchivedEntity(context.Context, *IDQuery) (*ArchivedEntity, error)
+
 	// WatchEntities returns a channel that produces a stream of
 	// EntityEvent on entity registration changes.
 	WatchEntities(context.Context) (<-chan *EntityEvent, pubsub.ClosableSubscription, error)
@@ -176,9 +185,18 @@ type Backend interface {
 	// GetNode gets a node by ID.
 	GetNode(context.Context, *IDQuery) (*node.Node, error)
 
+	// GetNodeWithProof gets a node by ID together with a proof of the node state against the
+	// consensus state root.
+	GetNodeWithProof(context.Context, *IDQuery) (*NodeWithProof, error)
+
 	// GetNodeStatus returns a node's status.
 	GetNodeStatus(context.Context, *IDQuery) (*NodeStatus, error)
 
+	// GetArchivedNode gets the archived descriptor of a removed node by ID.
+	//
+	// Archived descriptors are only kept for ArchiveRetention epochs.
+	GetArchivedNode(context.Context, *IDQuery) (*ArchivedNode, error)
+
 	// GetNodes gets a list of all registered nodes.
 	GetNodes(context.Context, int64) ([]*node.Node, error)
 
@@ -202,6 +220,16 @@ type Backend interface {
 	// GetRuntime gets a runtime by ID.
 	GetRuntime(context.Context, *NamespaceQuery) (*Runtime, error)
 
+	// GetRuntimeWithProof gets a runtime by ID together with a proof of the runtime state against
+	// the consensus state root.
+	GetRuntimeWithProof(context.Context, *NamespaceQuery) (*RuntimeWithProof, error)
+
+	// GetRuntimeStatus returns a runtime's status, including the reason
+	// why the runtime has been suspended (if any).
+	//
+	// Unlike GetRuntime this also works for suspended runtimes.
+	GetRuntimeStatus(context.Context, *NamespaceQuery) (*RuntimeStatus, error)
+
 	// GetRuntimes returns the registered Runtimes at the specified
 	// block height.
 	GetRuntimes(context.Context, *GetRuntimesQuery) ([]*Runtime, error)
@@ -216,6 +244,9 @@ type Backend interface {
 	// GetEvents returns the events at specified block height.
 	GetEvents(ctx context.Context, height int64) ([]*Event, error)
 
+	// WatchEvents returns a channel that produces a stream of Events.
+	WatchEvents(ctx context.Context) (<-chan *Event, pubsub.ClosableSubscription, error)
+
 	// Cleanup cleans up the registry backend.
 	Cleanup()
 }
@@ -232,6 +263,22 @@ type NamespaceQuery struct {
 	ID     common.Namespace `json:"id"`
 }
 
+// NodeWithProof is a node descriptor together with a proof of the node state.
+type NodeWithProof struct {
+	// Node is the node descriptor.
+	Node *node.Node `json:"node"`
+	// Proof is the proof of the node state against the consensus state root.
+	Proof *proof.StateProof `json:"proof"`
+}
+
+// RuntimeWithProof is a runtime descriptor together with a proof of the runtime state.
+type RuntimeWithProof struct {
+	// Runtime is the runtime descriptor.
+	Runtime *Runtime `json:"runtime"`
+	// Proof is the proof of the runtime state against the consensus state root.
+	Proof *proof.StateProof `json:"proof"`
+}
+
 // GetRuntimesQuery is a registry get runtimes query.
 type GetRuntimesQuery struct {
 	Height           int64 `json:"height"`
@@ -274,6 +321,14 @@ func NewRegisterRuntimeTx(nonce uint64, fee *transaction.Fee, rt *Runtime) *tran
 	return transaction.NewTransaction(nonce, fee, MethodRegisterRuntime, rt)
 }
 
+// Blob is an arbitrary fixed-size blob submitted in a freshness proof transaction.
+type Blob [32]byte
+
+// NewProveFreshnessTx creates a new prove freshness transaction.
+func NewProveFreshnessTx(nonce uint64, fee *transaction.Fee, blob *Blob) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodProveFreshness, blob)
+}
+
 // EntityEvent is the event that is returned via WatchEntities to signify
 // entity registration changes and updates.
 type EntityEvent struct {
@@ -293,6 +348,24 @@ type RuntimeEvent struct {
 	Runtime *Runtime `json:"runtime"`
 }
 
+// RuntimeSuspendedEvent signifies a runtime suspension.
+type RuntimeSuspendedEvent struct {
+	RuntimeID common.Namespace        `json:"runtime_id"`
+	Reason    RuntimeSuspensionReason `json:"reason"`
+}
+
+// RuntimeResumedEvent signifies a suspended runtime resumption.
+type RuntimeResumedEvent struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+}
+
+// NodeExpiringEvent signifies that a node's registration will expire at the
+// end of the current epoch unless the node re-registers.
+type NodeExpiringEvent struct {
+	NodeID     signature.PublicKey `json:"node_id"`
+	Expiration uint64              `json:"expiration"`
+}
+
 // NodeUnfrozenEvent signifies when node becomes unfrozen.
 type NodeUnfrozenEvent struct {
 	NodeID signature.PublicKey `json:"node_id"`
@@ -303,10 +376,13 @@ type Event struct {
 	Height int64     `json:"height,omitempty"`
 	TxHash hash.Hash `json:"tx_hash,omitempty"`
 
-	RuntimeEvent      *RuntimeEvent      `json:"runtime,omitempty"`
-	EntityEvent       *EntityEvent       `json:"entity,omitempty"`
-	NodeEvent         *NodeEvent         `json:"node,omitempty"`
-	NodeUnfrozenEvent *NodeUnfrozenEvent `json:"node_unfrozen,omitempty"`
+	RuntimeEvent          *RuntimeEvent          `json:"runtime,omitempty"`
+	RuntimeSuspendedEvent *RuntimeSuspendedEvent `json:"runtime_suspended,omitempty"`
+	RuntimeResumedEvent   *RuntimeResumedEvent   `json:"runtime_resumed,omitempty"`
+	EntityEvent           *EntityEvent           `json:"entity,omitempty"`
+	NodeEvent             *NodeEvent             `json:"node,omitempty"`
+	NodeUnfrozenEvent     *NodeUnfrozenEvent     `json:"node_unfrozen,omitempty"`
+	NodeExpiringEvent     *NodeExpiringEvent     `json:"node_expiring,omitempty"`
 }
 
 // NodeList is a per-epoch immutable node list.
@@ -990,6 +1066,23 @@ func VerifyRuntime( // nolint: gocyclo
 		return fmt.Errorf("%w: invalid admission policy", ErrInvalidArgument)
 	}
 
+	// Ensure valid per-role admission policies if present.
+	for role, rp := range rt.AdmissionPolicy.PerRole {
+		if !role.IsSingleRole() {
+			logger.Error("RegisterRuntime: non-single role in per-role admission policy",
+				"role", role,
+			)
+			return fmt.Errorf("%w: non-single role in per-role admission policy", ErrInvalidArgument)
+		}
+		if err := rp.ValidateBasic(); err != nil {
+			logger.Error("RegisterRuntime: invalid per-role admission policy",
+				"role", role,
+				"err", err,
+			)
+			return fmt.Errorf("%w: invalid per-role admission policy for role %s: %s", ErrInvalidArgument, role, err)
+		}
+	}
+
 	// Using runtime governance for non-compute runtimes is invalid.
 	if rt.GovernanceModel == GovernanceRuntime && rt.Kind != KindCompute {
 		logger.Error("RegisterRuntime: runtime governance can only be used with compute runtimes")
@@ -1182,6 +1275,18 @@ type ConsensusParameters struct {
 
 	// EnableRuntimeGovernanceModels is a set of enabled runtime governance models.
 	EnableRuntimeGovernanceModels map[RuntimeGovernanceModel]bool `json:"enable_runtime_governance_models,omitempty"`
+
+	// ArchiveRetention is the number of epochs for which descriptors of deregistered entities
+	// and removed nodes are kept in the archive. Zero disables archival.
+	ArchiveRetention beacon.EpochTime `json:"archive_retention,omitempty"`
+
+	// NodeExpirationGracePeriod is the number of epochs after a node's expiration during which
+	// the node may re-register without losing its election eligibility. Zero disables the grace
+	// period.
+	//
+	// Note that expired nodes are removed after the debonding interval, after which they can no
+	// longer re-register within the grace period.
+	NodeExpirationGracePeriod beacon.EpochTime `json:"node_expiration_grace_period,omitempty"`
 }
 
 const (
@@ -1201,6 +1306,8 @@ const (
 	// GasOpUpdateKeyManager is the gas operation identifier for key manager
 	// policy updates costs.
 	GasOpUpdateKeyManager transaction.Op = "update_keymanager"
+	// GasOpProveFreshness is the gas operation identifier for freshness proofs.
+	GasOpProveFreshness transaction.Op = "prove_freshness"
 )
 
 // XXX: Define reasonable default gas costs.
@@ -1214,6 +1321,7 @@ var DefaultGasCosts = transaction.Costs{
 	GasOpRegisterRuntime:         1000,
 	GasOpRuntimeEpochMaintenance: 1000,
 	GasOpUpdateKeyManager:        1000,
+	GasOpProveFreshness:          1000,
 }
 
 const (
@@ -1269,6 +1377,15 @@ func StakeThresholdsForNode(n *node.Node, rts []*Runtime) (thresholds []staking.
 				thresholds = append(thresholds, staking.StakeThreshold{Constant: q.Clone()})
 			}
 		}
+
+		// Add per-role admission policy thresholds if non-zero.
+		for _, role := range node.Roles() {
+			rp, ok := rt.AdmissionPolicy.PerRole[role]
+			if !ok || !n.HasRoles(role) || rp.MinStake == nil || rp.MinStake.IsZero() {
+				continue
+			}
+			thresholds = append(thresholds, staking.StakeThreshold{Constant: rp.MinStake.Clone()})
+		}
 	}
 	return
 }
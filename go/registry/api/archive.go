@@ -0,0 +1,25 @@
+package api
+
+import (
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+)
+
+// ArchivedEntity is the archived descriptor of a deregistered entity.
+type ArchivedEntity struct {
+	// Entity is the last signed entity descriptor before the entity was deregistered.
+	Entity *entity.SignedEntity `json:"entity"`
+
+	// DeregisteredAt is the epoch in which the entity was deregistered.
+	DeregisteredAt beacon.EpochTime `json:"deregistered_at"`
+}
+
+// ArchivedNode is the archived descriptor of a removed node.
+type ArchivedNode struct {
+	// Node is the last signed node descriptor before the node was removed.
+	Node *node.MultiSignedNode `json:"node"`
+
+	// DeregisteredAt is the epoch in which the node was removed from the registry.
+	DeregisteredAt beacon.EpochTime `json:"deregistered_at"`
+}
@@ -21,14 +21,24 @@ var (
 	methodGetEntities = serviceName.NewMethod("GetEntities", int64(0))
 	// methodGetNode is the GetNode method.
 	methodGetNode = serviceName.NewMethod("GetNode", IDQuery{})
+	// methodGetNodeWithProof is the GetNodeWithProof method.
+	methodGetNodeWithProof = serviceName.NewMethod("GetNodeWithProof", IDQuery{})
 	// methodGetNodeByConsensusAddress is the GetNodeByConsensusAddress method.
 	methodGetNodeByConsensusAddress = serviceName.NewMethod("GetNodeByConsensusAddress", ConsensusAddressQuery{})
 	// methodGetNodeStatus is the GetNodeStatus method.
 	methodGetNodeStatus = serviceName.NewMethod("GetNodeStatus", IDQuery{})
+	// methodGetArchivedEntity is the GetArchivedEntity method.
+	methodGetArchivedEntity = serviceName.NewMethod("GetArchivedEntity", IDQuery{})
+	// methodGetArchivedNode is the GetArchivedNode method.
+	methodGetArchivedNode = serviceName.NewMethod("GetArchivedNode", IDQuery{})
 	// methodGetNodes is the GetNodes method.
 	methodGetNodes = serviceName.NewMethod("GetNodes", int64(0))
 	// methodGetRuntime is the GetRuntime method.
 	methodGetRuntime = serviceName.NewMethod("GetRuntime", NamespaceQuery{})
+	// methodGetRuntimeWithProof is the GetRuntimeWithProof method.
+	methodGetRuntimeWithProof = serviceName.NewMethod("GetRuntimeWithProof", NamespaceQuery{})
+	// methodGetRuntimeStatus is the GetRuntimeStatus method.
+	methodGetRuntimeStatus = serviceName.NewMethod("GetRuntimeStatus", NamespaceQuery{})
 	// methodGetRuntimes is the GetRuntimes method.
 	methodGetRuntimes = serviceName.NewMethod("GetRuntimes", int64(0))
 	// methodStateToGenesis is the StateToGenesis method.
@@ -44,6 +54,8 @@ var (
 	methodWatchNodeList = serviceName.NewMethod("WatchNodeList", nil)
 	// methodWatchRuntimes is the WatchRuntimes method.
 	methodWatchRuntimes = serviceName.NewMethod("WatchRuntimes", nil)
+	// methodWatchEvents is the WatchEvents method.
+	methodWatchEvents = serviceName.NewMethod("WatchEvents", nil)
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -62,6 +74,10 @@ var (
 				MethodName: methodGetNode.ShortName(),
 				Handler:    handlerGetNode,
 			},
+			{
+				MethodName: methodGetNodeWithProof.ShortName(),
+				Handler:    handlerGetNodeWithProof,
+			},
 			{
 				MethodName: methodGetNodeByConsensusAddress.ShortName(),
 				Handler:    handlerGetNodeByConsensusAddress,
@@ -70,6 +86,14 @@ var (
 				MethodName: methodGetNodeStatus.ShortName(),
 				Handler:    handlerGetNodeStatus,
 			},
+			{
+				MethodName: methodGetArchivedEntity.ShortName(),
+				Handler:    handlerGetArchivedEntity,
+			},
+			{
+				MethodName: methodGetArchivedNode.ShortName(),
+				Handler:    handlerGetArchivedNode,
+			},
 			{
 				MethodName: methodGetNodes.ShortName(),
 				Handler:    handlerGetNodes,
@@ -78,6 +102,14 @@ var (
 				MethodName: methodGetRuntime.ShortName(),
 				Handler:    handlerGetRuntime,
 			},
+			{
+				MethodName: methodGetRuntimeWithProof.ShortName(),
+				Handler:    handlerGetRuntimeWithProof,
+			},
+			{
+				MethodName: methodGetRuntimeStatus.ShortName(),
+				Handler:    handlerGetRuntimeStatus,
+			},
 			{
 				MethodName: methodGetRuntimes.ShortName(),
 				Handler:    handlerGetRuntimes,
@@ -112,6 +144,11 @@ var (
 				Handler:       handlerWatchRuntimes,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchEvents.ShortName(),
+				Handler:       handlerWatchEvents,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -185,6 +222,29 @@ func handlerGetNode( // nolint: golint
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerGetNodeWithProof( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query IDQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetNodeWithProof(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetNodeWithProof.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetNodeWithProof(ctx, req.(*IDQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerGetNodeByConsensusAddress( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -231,6 +291,52 @@ func handlerGetNodeStatus( // nolint: golint
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerGetArchivedEntity( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query IDQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetArchivedEntity(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetArchivedEntity.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetArchivedEntity(ctx, req.(*IDQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerGetArchivedNode( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query IDQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetArchivedNode(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetArchivedNode.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetArchivedNode(ctx, req.(*IDQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerGetNodes( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -277,6 +383,52 @@ func handlerGetRuntime( // nolint: golint
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerGetRuntimeWithProof( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query NamespaceQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetRuntimeWithProof(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetRuntimeWithProof.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetRuntimeWithProof(ctx, req.(*NamespaceQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerGetRuntimeStatus( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query NamespaceQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetRuntimeStatus(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetRuntimeStatus.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetRuntimeStatus(ctx, req.(*NamespaceQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerGetRuntimes( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -458,6 +610,34 @@ func handlerWatchRuntimes(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerWatchEvents(srv interface{}, stream grpc.ServerStream) error {
+	if err := stream.RecvMsg(nil); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(Backend).WatchEvents(ctx)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // RegisterService registers a new registry backend service with the given gRPC server.
 func RegisterService(server *grpc.Server, service Backend) {
 	server.RegisterService(&serviceDesc, service)
@@ -526,6 +706,14 @@ func (c *registryClient) GetNode(ctx context.Context, query *IDQuery) (*node.Nod
 	return &rsp, nil
 }
 
+func (c *registryClient) GetNodeWithProof(ctx context.Context, query *IDQuery) (*NodeWithProof, error) {
+	var rsp NodeWithProof
+	if err := c.conn.Invoke(ctx, methodGetNodeWithProof.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *registryClient) GetNodeByConsensusAddress(ctx context.Context, query *ConsensusAddressQuery) (*node.Node, error) {
 	var rsp node.Node
 	if err := c.conn.Invoke(ctx, methodGetNodeByConsensusAddress.FullName(), query, &rsp); err != nil {
@@ -542,6 +730,22 @@ func (c *registryClient) GetNodeStatus(ctx context.Context, query *IDQuery) (*No
 	return &rsp, nil
 }
 
+func (c *registryClient) GetArchivedEntity(ctx context.Context, query *IDQuery) (*ArchivedEntity, error) {
+	var rsp ArchivedEntity
+	if err := c.conn.Invoke(ctx, methodGetArchivedEntity.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *registryClient) GetArchivedNode(ctx context.Context, query *IDQuery) (*ArchivedNode, error) {
+	var rsp ArchivedNode
+	if err := c.conn.Invoke(ctx, methodGetArchivedNode.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *registryClient) GetNodes(ctx context.Context, height int64) ([]*node.Node, error) {
 	var rsp []*node.Node
 	if err := c.conn.Invoke(ctx, methodGetNodes.FullName(), height, &rsp); err != nil {
@@ -628,6 +832,22 @@ func (c *registryClient) GetRuntime(ctx context.Context, query *NamespaceQuery)
 	return &rsp, nil
 }
 
+func (c *registryClient) GetRuntimeWithProof(ctx context.Context, query *NamespaceQuery) (*RuntimeWithProof, error) {
+	var rsp RuntimeWithProof
+	if err := c.conn.Invoke(ctx, methodGetRuntimeWithProof.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *registryClient) GetRuntimeStatus(ctx context.Context, query *NamespaceQuery) (*RuntimeStatus, error) {
+	var rsp RuntimeStatus
+	if err := c.conn.Invoke(ctx, methodGetRuntimeStatus.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *registryClient) GetRuntimes(ctx context.Context, query *GetRuntimesQuery) ([]*Runtime, error) {
 	var rsp []*Runtime
 	if err := c.conn.Invoke(ctx, methodGetRuntimes.FullName(), query, &rsp); err != nil {
@@ -671,6 +891,41 @@ func (c *registryClient) WatchRuntimes(ctx context.Context) (<-chan *Runtime, pu
 	return ch, sub, nil
 }
 
+func (c *registryClient) WatchEvents(ctx context.Context) (<-chan *Event, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[4], methodWatchEvents.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(nil); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *Event)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev Event
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 func (c *registryClient) StateToGenesis(ctx context.Context, height int64) (*Genesis, error) {
 	var rsp Genesis
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
@@ -95,6 +96,14 @@ type ExecutorParameters struct {
 	// MaxMessages is the maximum number of messages that can be emitted by the runtime in a
 	// single round.
 	MaxMessages uint32 `json:"max_messages"`
+
+	// MaxMessageGas is the maximum amount of consensus gas that a single runtime message can
+	// consume when executed. Zero means that there is no per-message limit.
+	MaxMessageGas transaction.Gas `json:"max_message_gas,omitempty"`
+
+	// MaxRoundMessageGas is the maximum amount of consensus gas that all runtime messages emitted
+	// in a single round can consume when executed. Zero means that there is no per-round limit.
+	MaxRoundMessageGas transaction.Gas `json:"max_round_message_gas,omitempty"`
 }
 
 // ValidateBasic performs basic executor parameter validity checks.
@@ -110,6 +119,10 @@ func (e *ExecutorParameters) ValidateBasic() error {
 		return fmt.Errorf("round timeout too small")
 	}
 
+	if e.MaxRoundMessageGas > 0 && e.MaxMessageGas > e.MaxRoundMessageGas {
+		return fmt.Errorf("max message gas larger than max round message gas")
+	}
+
 	return nil
 }
 
@@ -206,6 +219,71 @@ type EntityWhitelistConfig struct {
 type RuntimeAdmissionPolicy struct {
 	AnyNode         *AnyNodeRuntimeAdmissionPolicy         `json:"any_node,omitempty"`
 	EntityWhitelist *EntityWhitelistRuntimeAdmissionPolicy `json:"entity_whitelist,omitempty"`
+
+	// PerRole are the per-role admission policies that nodes with the given role must satisfy in
+	// addition to the global admission policy. The map keys must be single roles.
+	PerRole map[node.RolesMask]PerRoleAdmissionPolicy `json:"per_role,omitempty"`
+}
+
+// PerRoleAdmissionPolicy is an admission policy for nodes with a specific role.
+//
+// Multiple fields may be set in which case ALL the requirements must be satisfied.
+type PerRoleAdmissionPolicy struct {
+	// EntityWhitelist restricts registration of nodes with the given role to the specified
+	// entities.
+	EntityWhitelist *EntityWhitelistRoleAdmissionPolicy `json:"entity_whitelist,omitempty"`
+
+	// MaxNodesPerEntity is the maximum number of nodes with the given role that any single
+	// entity can register for the runtime. Zero means unlimited.
+	MaxNodesPerEntity uint16 `json:"max_nodes_per_entity,omitempty"`
+
+	// MinStake is the minimum stake required for each node with the given role registered for
+	// the runtime. It is required in addition to the global and per-runtime staking thresholds.
+	MinStake *quantity.Quantity `json:"min_stake,omitempty"`
+}
+
+// EntityWhitelistRoleAdmissionPolicy is a per-role entity whitelist.
+type EntityWhitelistRoleAdmissionPolicy struct {
+	Entities map[signature.PublicKey]EntityWhitelistRoleConfig `json:"entities"`
+}
+
+// EntityWhitelistRoleConfig is the per-role configuration of a whitelisted entity.
+type EntityWhitelistRoleConfig struct {
+	// MaxNodes is the maximum number of nodes with the given role that the entity can register
+	// for the runtime. Zero means unlimited.
+	MaxNodes uint16 `json:"max_nodes,omitempty"`
+}
+
+// ValidateBasic performs basic per-role admission policy validity checks.
+func (p *PerRoleAdmissionPolicy) ValidateBasic() error {
+	if p.EntityWhitelist != nil {
+		for ent := range p.EntityWhitelist.Entities {
+			if !ent.IsValid() {
+				return fmt.Errorf("invalid entity ID in entity whitelist: %s", ent)
+			}
+		}
+	}
+	if p.MinStake != nil && !p.MinStake.IsValid() {
+		return fmt.Errorf("invalid minimum stake")
+	}
+	return nil
+}
+
+// MaxNodesForEntity returns the maximum number of nodes with the given role that the given entity
+// can register for the runtime where zero means unlimited. In case the entity is not allowed to
+// register any nodes with the given role, false is returned.
+func (p *PerRoleAdmissionPolicy) MaxNodesForEntity(id signature.PublicKey) (uint16, bool) {
+	maxNodes := p.MaxNodesPerEntity
+	if p.EntityWhitelist != nil {
+		cfg, ok := p.EntityWhitelist.Entities[id]
+		if !ok {
+			return 0, false
+		}
+		if cfg.MaxNodes != 0 && (maxNodes == 0 || cfg.MaxNodes < maxNodes) {
+			maxNodes = cfg.MaxNodes
+		}
+	}
+	return maxNodes, true
 }
 
 // SchedulingConstraints are the node scheduling constraints.
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+func TestRuntimeAdmissionPolicySerialization(t *testing.T) {
+	require := require.New(t)
+
+	var entityID signature.PublicKey
+	require.NoError(entityID.UnmarshalHex("4ea5328f943ef6f66daaed74cb0e99c3b1c45f76307b425003dbc7cb3638ed35"))
+	minStake := quantity.NewFromUint64(1000)
+
+	// NOTE: These cases should be synced with tests in runtime/src/consensus/registry.rs.
+	for _, tc := range []struct {
+		rr             RuntimeAdmissionPolicy
+		expectedBase64 string
+	}{
+		{RuntimeAdmissionPolicy{}, "oA=="},
+		{RuntimeAdmissionPolicy{AnyNode: &AnyNodeRuntimeAdmissionPolicy{}}, "oWhhbnlfbm9kZaA="},
+		{RuntimeAdmissionPolicy{
+			AnyNode: &AnyNodeRuntimeAdmissionPolicy{},
+			PerRole: map[node.RolesMask]PerRoleAdmissionPolicy{
+				node.RoleComputeWorker: {
+					EntityWhitelist: &EntityWhitelistRoleAdmissionPolicy{
+						Entities: map[signature.PublicKey]EntityWhitelistRoleConfig{
+							entityID: {MaxNodes: 3},
+						},
+					},
+					MaxNodesPerEntity: 5,
+					MinStake:          minStake,
+				},
+				node.RoleKeyManager: {},
+			},
+		}, "omhhbnlfbm9kZaBocGVyX3JvbGWiAaNpbWluX3N0YWtlQgPocGVudGl0eV93aGl0ZWxpc3ShaGVudGl0aWVzoVggTqUyj5Q+9vZtqu10yw6Zw7HEX3Ywe0JQA9vHyzY47TWhaW1heF9ub2RlcwN0bWF4X25vZGVzX3Blcl9lbnRpdHkFBKA="},
+	} {
+		enc := cbor.Marshal(tc.rr)
+		require.Equal(tc.expectedBase64, base64.StdEncoding.EncodeToString(enc), "serialization should match")
+
+		var dec RuntimeAdmissionPolicy
+		err := cbor.Unmarshal(enc, &dec)
+		require.NoError(err, "Unmarshal")
+		require.EqualValues(tc.rr, dec, "RuntimeAdmissionPolicy serialization should round-trip")
+	}
+}
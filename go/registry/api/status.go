@@ -1,6 +1,8 @@
 package api
 
 import (
+	"fmt"
+
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 )
@@ -35,6 +37,15 @@ func (ns NodeStatus) IsFrozen() bool {
 	return ns.FreezeEndTime > 0
 }
 
+// IsElectionEligible returns true if the node is eligible to be included in
+// non-validator committee elections at the given epoch.
+func (ns NodeStatus) IsElectionEligible(epoch beacon.EpochTime) bool {
+	if ns.IsFrozen() {
+		return false
+	}
+	return ns.ElectionEligibleAfter != beacon.EpochInvalid && epoch > ns.ElectionEligibleAfter
+}
+
 // Unfreeze makes the node unfrozen.
 func (ns *NodeStatus) Unfreeze() {
 	ns.FreezeEndTime = 0
@@ -44,3 +55,70 @@ func (ns *NodeStatus) Unfreeze() {
 type UnfreezeNode struct {
 	NodeID signature.PublicKey `json:"node_id"`
 }
+
+// RuntimeSuspensionReason is the reason why a runtime has been suspended.
+type RuntimeSuspensionReason uint8
+
+const (
+	// SuspensionReasonUnknown is used for runtimes suspended without a recorded reason (e.g.,
+	// runtimes that were already suspended at genesis).
+	SuspensionReasonUnknown RuntimeSuspensionReason = 0
+	// SuspensionReasonInsufficientStake is used for runtimes suspended because the owning entity
+	// no longer has enough stake to cover the runtime's stake claims.
+	SuspensionReasonInsufficientStake RuntimeSuspensionReason = 1
+	// SuspensionReasonNoCommittee is used for runtimes suspended because no committee could be
+	// elected (e.g., no nodes paid maintenance fees by registering for the runtime).
+	SuspensionReasonNoCommittee RuntimeSuspensionReason = 2
+
+	srUnknown           = "unknown"
+	srInsufficientStake = "insufficient_stake"
+	srNoCommittee       = "no_committee"
+)
+
+// String returns a string representation of a runtime suspension reason.
+func (r RuntimeSuspensionReason) String() string {
+	reason, err := r.MarshalText()
+	if err != nil {
+		return "[unsupported runtime suspension reason]"
+	}
+	return string(reason)
+}
+
+// MarshalText encodes a RuntimeSuspensionReason into text form.
+func (r RuntimeSuspensionReason) MarshalText() ([]byte, error) {
+	switch r {
+	case SuspensionReasonUnknown:
+		return []byte(srUnknown), nil
+	case SuspensionReasonInsufficientStake:
+		return []byte(srInsufficientStake), nil
+	case SuspensionReasonNoCommittee:
+		return []byte(srNoCommittee), nil
+	default:
+		return nil, fmt.Errorf("registry: unsupported runtime suspension reason: %d", r)
+	}
+}
+
+// UnmarshalText decodes a text slice into a RuntimeSuspensionReason.
+func (r *RuntimeSuspensionReason) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case srUnknown:
+		*r = SuspensionReasonUnknown
+	case srInsufficientStake:
+		*r = SuspensionReasonInsufficientStake
+	case srNoCommittee:
+		*r = SuspensionReasonNoCommittee
+	default:
+		return fmt.Errorf("registry: unsupported runtime suspension reason: '%s'", string(text))
+	}
+	return nil
+}
+
+// RuntimeStatus is live status of a runtime.
+type RuntimeStatus struct {
+	// Suspended is a flag specifying whether the runtime is currently suspended.
+	Suspended bool `json:"suspended"`
+	// SuspensionReason is the reason why the runtime has been suspended.
+	//
+	// This field is only meaningful if the runtime is suspended.
+	SuspensionReason RuntimeSuspensionReason `json:"suspension_reason"`
+}
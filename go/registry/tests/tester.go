@@ -28,7 +28,9 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx"
 	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
 	tmcrypto "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/crypto"
+	tendermintTests "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/tests"
 	"github.com/oasisprotocol/oasis-core/go/registry/api"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
@@ -253,6 +255,7 @@ func testRegistryEntityNodes( // nolint: gocyclo
 	t.Run("NodeRegistration", func(t *testing.T) {
 		require := require.New(t)
 
+		var provedNode bool
 		for _, tns := range nodes {
 			for _, tn := range tns {
 				for _, v := range tn.invalidBefore {
@@ -292,6 +295,13 @@ func testRegistryEntityNodes( // nolint: gocyclo
 				require.NoError(err, "GetNode")
 				require.EqualValues(tn.Node, nod, "retrieved node")
 
+				// Node state proofs should verify (only check one node to avoid waiting for
+				// blocks for every registered node).
+				if !provedNode {
+					testNodeWithProof(t, backend, consensus, tn.Node)
+					provedNode = true
+				}
+
 				var nodeByConsensus *node.Node
 				nodeByConsensus, err = backend.GetNodeByConsensusAddress(
 					ctx,
@@ -694,6 +704,40 @@ func testRegistryRuntime(t *testing.T, backend api.Backend, consensus consensusA
 			false,
 			false,
 		},
+		// Runtime using per-role admission policies.
+		{
+			"PerRoleAdmissionPolicy",
+			func(rt *api.Runtime) {
+				rt.AdmissionPolicy.PerRole = map[node.RolesMask]api.PerRoleAdmissionPolicy{
+					node.RoleComputeWorker: {
+						MaxNodesPerEntity: 2,
+						MinStake:          quantity.NewFromUint64(1000),
+					},
+					node.RoleStorageRPC: {
+						EntityWhitelist: &api.EntityWhitelistRoleAdmissionPolicy{
+							Entities: map[signature.PublicKey]api.EntityWhitelistRoleConfig{
+								entity.Entity.ID: {MaxNodes: 1},
+							},
+						},
+					},
+				}
+			},
+			false,
+			true,
+		},
+		// Runtime using per-role admission policy with a non-single role.
+		{
+			"PerRoleAdmissionPolicyInvalidRole",
+			func(rt *api.Runtime) {
+				rt.AdmissionPolicy.PerRole = map[node.RolesMask]api.PerRoleAdmissionPolicy{
+					node.RoleComputeWorker | node.RoleStorageRPC: {
+						MaxNodesPerEntity: 1,
+					},
+				}
+			},
+			false,
+			false,
+		},
 		// Runtime using custom staking thresholds.
 		{
 			"StakingThresholds",
@@ -881,10 +925,24 @@ func testRegistryRuntime(t *testing.T, backend api.Backend, consensus consensusA
 	registeredRuntimes, err := backend.GetRuntimes(context.Background(), query)
 	require.NoError(err, "GetRuntimes")
 	require.Len(registeredRuntimes, len(existingRuntimes)+len(rtMap), "registry has all the new runtimes")
+
+	// Runtime state proofs should verify.
+	for _, rt := range rtMap {
+		testRuntimeWithProof(t, backend, consensus, rt)
+		break
+	}
 	for _, regRuntime := range registeredRuntimes {
 		if rtMap[regRuntime.ID] != nil {
 			require.EqualValues(rtMap[regRuntime.ID], regRuntime, "expected runtime is registered")
 			delete(rtMap, regRuntime.ID)
+
+			var status *api.RuntimeStatus
+			status, err = backend.GetRuntimeStatus(context.Background(), &api.NamespaceQuery{
+				Height: consensusAPI.HeightLatest,
+				ID:     regRuntime.ID,
+			})
+			require.NoError(err, "GetRuntimeStatus")
+			require.False(status.Suspended, "registered runtime should not be suspended")
 		}
 	}
 	require.Len(rtMap, 0, "all runtimes were registered")
@@ -931,6 +989,44 @@ func testRegistryRuntime(t *testing.T, backend api.Backend, consensus consensusA
 	return rtMapByName["WithoutKM"].ID, rtMapByName["EntityWhitelist"].ID
 }
 
+func testNodeWithProof(t *testing.T, backend api.Backend, consensus consensusAPI.Backend, n *node.Node) {
+	require := require.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), recvTimeout)
+	defer cancel()
+
+	blk, err := consensus.GetBlock(ctx, consensusAPI.HeightLatest)
+	require.NoError(err, "GetBlock")
+
+	nwp, err := backend.GetNodeWithProof(ctx, &api.IDQuery{ID: n.ID, Height: blk.Height})
+	require.NoError(err, "GetNodeWithProof")
+	require.EqualValues(n, nwp.Node, "GetNodeWithProof should return the registered node")
+
+	root, err := tendermintTests.WaitStateRoot(ctx, consensus, blk.Height)
+	require.NoError(err, "WaitStateRoot")
+	proven, err := registryState.VerifyNodeProof(ctx, *root, n.ID, nwp.Proof)
+	require.NoError(err, "VerifyNodeProof")
+	require.EqualValues(n, proven, "proven node should match")
+}
+
+func testRuntimeWithProof(t *testing.T, backend api.Backend, consensus consensusAPI.Backend, rt *api.Runtime) {
+	require := require.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), recvTimeout)
+	defer cancel()
+
+	blk, err := consensus.GetBlock(ctx, consensusAPI.HeightLatest)
+	require.NoError(err, "GetBlock")
+
+	rwp, err := backend.GetRuntimeWithProof(ctx, &api.NamespaceQuery{ID: rt.ID, Height: blk.Height})
+	require.NoError(err, "GetRuntimeWithProof")
+	require.EqualValues(rt, rwp.Runtime, "GetRuntimeWithProof should return the registered runtime")
+
+	root, err := tendermintTests.WaitStateRoot(ctx, consensus, blk.Height)
+	require.NoError(err, "WaitStateRoot")
+	proven, err := registryState.VerifyRuntimeProof(ctx, *root, rt.ID, rwp.Proof)
+	require.NoError(err, "VerifyRuntimeProof")
+	require.EqualValues(rt, proven, "proven runtime should match")
+}
+
 // EnsureRegistryClean enforces that the registry is in a clean state before running the registry tests.
 func EnsureRegistryClean(t *testing.T, backend api.Backend) {
 	registeredEntities, err := backend.GetEntities(context.Background(), consensusAPI.HeightLatest)
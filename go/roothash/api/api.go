@@ -12,11 +12,14 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/proof"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 )
 
 const (
@@ -73,6 +76,17 @@ var (
 	// ErrInvalidEvidence is the error return when an invalid evidence is submitted.
 	ErrInvalidEvidence = errors.New(ModuleName, 10, "roothash: invalid evidence")
 
+	// ErrMessageOutOfGas is the error returned when a runtime message exceeds the per-message or
+	// per-round message gas limit configured in the runtime descriptor.
+	ErrMessageOutOfGas = errors.New(ModuleName, 11, "roothash: runtime message out of gas")
+
+	// ErrRuntimePaused is the error returned when the passed runtime is paused.
+	ErrRuntimePaused = errors.New(ModuleName, 12, "roothash: runtime is paused")
+
+	// ErrForbidden is the error returned when an operation is forbidden by the runtime governance
+	// policy.
+	ErrForbidden = errors.New(ModuleName, 13, "roothash: forbidden by policy")
+
 	// MethodExecutorCommit is the method name for executor commit submission.
 	MethodExecutorCommit = transaction.NewMethodName(ModuleName, "ExecutorCommit", ExecutorCommit{})
 
@@ -82,11 +96,15 @@ var (
 	// MethodEvidence is the method name for submitting evidence of node misbehavior.
 	MethodEvidence = transaction.NewMethodName(ModuleName, "Evidence", Evidence{})
 
+	// MethodSetRuntimePaused is the method name for pausing or resuming a runtime.
+	MethodSetRuntimePaused = transaction.NewMethodName(ModuleName, "SetRuntimePaused", SetRuntimePaused{})
+
 	// Methods is a list of all methods supported by the roothash backend.
 	Methods = []transaction.MethodName{
 		MethodExecutorCommit,
 		MethodExecutorProposerTimeout,
 		MethodEvidence,
+		MethodSetRuntimePaused,
 	}
 )
 
@@ -104,6 +122,10 @@ type Backend interface {
 	// GetRuntimeState returns the given runtime's state.
 	GetRuntimeState(ctx context.Context, request *RuntimeRequest) (*RuntimeState, error)
 
+	// GetRuntimeStateWithProof returns the given runtime's state together with a proof of the
+	// runtime state against the consensus state root.
+	GetRuntimeStateWithProof(ctx context.Context, request *RuntimeRequest) (*RuntimeStateWithProof, error)
+
 	// GetLastRoundResults returns the given runtime's last normal round results.
 	GetLastRoundResults(ctx context.Context, request *RuntimeRequest) (*RoundResults, error)
 
@@ -121,6 +143,9 @@ type Backend interface {
 	// TrackRuntime adds a runtime the history of which should be tracked.
 	TrackRuntime(ctx context.Context, history BlockHistory) error
 
+	// UntrackRuntime removes a runtime the history of which should no longer be tracked.
+	UntrackRuntime(ctx context.Context, runtimeID common.Namespace) error
+
 	// StateToGenesis returns the genesis state at specified block height.
 	StateToGenesis(ctx context.Context, height int64) (*Genesis, error)
 
@@ -319,10 +344,40 @@ func NewEvidenceTx(nonce uint64, fee *transaction.Fee, evidence *Evidence) *tran
 	return transaction.NewTransaction(nonce, fee, MethodEvidence, evidence)
 }
 
+// SetRuntimePaused is the argument set for the SetRuntimePaused method.
+//
+// Only entity-governed runtimes can be paused or resumed via this method and the transaction must
+// be signed by the runtime owner. Other runtimes can be paused or resumed via governance.
+type SetRuntimePaused struct {
+	// ID is the runtime identifier.
+	ID common.Namespace `json:"id"`
+	// Paused specifies whether the runtime should be paused or resumed.
+	Paused bool `json:"paused"`
+}
+
+// NewSetRuntimePausedTx creates a new set runtime paused transaction.
+func NewSetRuntimePausedTx(nonce uint64, fee *transaction.Fee, runtimeID common.Namespace, paused bool) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodSetRuntimePaused, &SetRuntimePaused{
+		ID:     runtimeID,
+		Paused: paused,
+	})
+}
+
+// RuntimeStateWithProof is the per-runtime state together with a proof of the runtime state.
+type RuntimeStateWithProof struct {
+	// RuntimeState is the per-runtime state.
+	RuntimeState *RuntimeState `json:"runtime_state"`
+	// Proof is the proof of the runtime state against the consensus state root.
+	Proof *proof.StateProof `json:"proof"`
+}
+
 // RuntimeState is the per-runtime state.
 type RuntimeState struct {
 	Runtime   *registry.Runtime `json:"runtime"`
 	Suspended bool              `json:"suspended,omitempty"`
+	// Paused signals that the runtime has been paused and does not accept executor commitments
+	// until it is resumed.
+	Paused bool `json:"paused,omitempty"`
 
 	GenesisBlock *block.Block `json:"genesis_block"`
 
@@ -336,6 +391,31 @@ type RuntimeState struct {
 	LastNormalHeight int64 `json:"last_normal_height"`
 
 	ExecutorPool *commitment.Pool `json:"executor_pool"`
+
+	// FailedRounds is the number of consecutive failed rounds (including liveness failures) since
+	// the last normally processed round.
+	FailedRounds uint64 `json:"failed_rounds,omitempty"`
+	// LivenessFailures is the number of consecutive liveness failure rounds since the last
+	// normally processed round.
+	LivenessFailures uint64 `json:"liveness_failures,omitempty"`
+}
+
+// RoundTimeout returns the executor round timeout (in consensus blocks) that should be used for
+// the current round, taking into account the backoff due to consecutive failed rounds.
+func (s *RuntimeState) RoundTimeout(params *ConsensusParameters) int64 {
+	timeout := s.Runtime.Executor.RoundTimeout
+	if params.RoundTimeoutBackoffFactor <= 1 || params.MaxRoundTimeoutBackoff <= 1 {
+		return timeout
+	}
+
+	backoff := uint64(1)
+	for i := uint64(0); i < s.FailedRounds && backoff < uint64(params.MaxRoundTimeoutBackoff); i++ {
+		backoff *= uint64(params.RoundTimeoutBackoffFactor)
+	}
+	if backoff > uint64(params.MaxRoundTimeoutBackoff) {
+		backoff = uint64(params.MaxRoundTimeoutBackoff)
+	}
+	return timeout * int64(backoff)
 }
 
 // AnnotatedBlock is an annotated roothash block.
@@ -366,11 +446,32 @@ type FinalizedEvent struct {
 	Round uint64 `json:"round"`
 }
 
+// RoundFailedEvent is a round failed event.
+type RoundFailedEvent struct {
+	// Round is the round that failed.
+	Round uint64 `json:"round"`
+	// LivenessFailure signals whether the round failed due to a liveness failure.
+	LivenessFailure bool `json:"liveness_failure,omitempty"`
+	// FailedRounds is the number of consecutive failed rounds, including this one.
+	FailedRounds uint64 `json:"failed_rounds"`
+}
+
+// RuntimePausedEvent is a runtime paused or resumed event.
+type RuntimePausedEvent struct {
+	// Paused signals whether the runtime has been paused or resumed.
+	Paused bool `json:"paused"`
+	// Round is the runtime round at which the runtime has been paused or resumed.
+	Round uint64 `json:"round"`
+}
+
 // MessageEvent is a runtime message processed event.
 type MessageEvent struct {
 	Module string `json:"module,omitempty"`
 	Code   uint32 `json:"code,omitempty"`
 	Index  uint32 `json:"index,omitempty"`
+
+	// GasUsed is the amount of consensus gas used when executing the message.
+	GasUsed transaction.Gas `json:"gas_used,omitempty"`
 }
 
 // IsSuccess returns true if the event indicates that the message was successfully processed.
@@ -388,7 +489,9 @@ type Event struct {
 	ExecutorCommitted            *ExecutorCommittedEvent            `json:"executor_committed,omitempty"`
 	ExecutionDiscrepancyDetected *ExecutionDiscrepancyDetectedEvent `json:"execution_discrepancy,omitempty"`
 	Finalized                    *FinalizedEvent                    `json:"finalized,omitempty"`
+	RoundFailed                  *RoundFailedEvent                  `json:"round_failed,omitempty"`
 	Message                      *MessageEvent                      `json:"message,omitempty"`
+	RuntimePaused                *RuntimePausedEvent                `json:"runtime_paused,omitempty"`
 }
 
 // MetricsMonitorable is the interface exposed by backends capable of
@@ -407,6 +510,38 @@ type GenesisRuntimeState struct {
 
 	// MessageResults are the message results emitted at the last processed round.
 	MessageResults []*MessageEvent `json:"message_results,omitempty"`
+
+	// StateCheckpoint is an optional reference to a storage checkpoint of the
+	// runtime state at genesis. If specified, storage nodes that do not have
+	// the genesis state available locally restore it from this checkpoint.
+	StateCheckpoint *checkpoint.Metadata `json:"state_checkpoint,omitempty"`
+
+	// Paused signals that the runtime is paused at genesis.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// SanityCheck does basic sanity checking of GenesisRuntimeState.
+// isGenesis is true, if it is called during consensus chain init.
+func (rts *GenesisRuntimeState) SanityCheck(isGenesis bool) error {
+	if err := rts.RuntimeGenesis.SanityCheck(isGenesis); err != nil {
+		return err
+	}
+
+	if cp := rts.StateCheckpoint; cp != nil {
+		if cp.Root.Type != mkvsNode.RootTypeState {
+			return fmt.Errorf("roothash: sanity check failed: state checkpoint has invalid root type: %s", cp.Root.Type)
+		}
+		if cp.Root.Version != rts.Round {
+			return fmt.Errorf("roothash: sanity check failed: state checkpoint version (%d) does not match round (%d)", cp.Root.Version, rts.Round)
+		}
+		if !cp.Root.Hash.Equal(&rts.StateRoot) {
+			return fmt.Errorf("roothash: sanity check failed: state checkpoint root does not match state root")
+		}
+		if len(cp.Chunks) == 0 {
+			return fmt.Errorf("roothash: sanity check failed: state checkpoint has no chunks")
+		}
+	}
+	return nil
 }
 
 // Genesis is the roothash genesis state.
@@ -437,6 +572,14 @@ type ConsensusParameters struct {
 
 	// MaxEvidenceAge is the maximum age of submitted evidence in the number of rounds.
 	MaxEvidenceAge uint64 `json:"max_evidence_age"`
+
+	// RoundTimeoutBackoffFactor is the factor by which the executor round timeout is multiplied
+	// for each consecutive failed round. Zero and one both mean that there is no backoff.
+	RoundTimeoutBackoffFactor uint8 `json:"round_timeout_backoff_factor,omitempty"`
+
+	// MaxRoundTimeoutBackoff is the maximum multiplier of the executor round timeout after
+	// consecutive failed rounds. Zero and one both mean that there is no backoff.
+	MaxRoundTimeoutBackoff uint16 `json:"max_round_timeout_backoff,omitempty"`
 }
 
 const (
@@ -448,15 +591,19 @@ const (
 
 	// GasOpEvidence is the gas operation identifier for evidence submission transaction cost.
 	GasOpEvidence transaction.Op = "evidence"
+
+	// GasOpSetRuntimePaused is the gas operation identifier for pausing or resuming a runtime.
+	GasOpSetRuntimePaused transaction.Op = "set_runtime_paused"
 )
 
 // XXX: Define reasonable default gas costs.
 
 // DefaultGasCosts are the "default" gas costs for operations.
 var DefaultGasCosts = transaction.Costs{
-	GasOpComputeCommit:   1000,
-	GasOpProposerTimeout: 1000,
-	GasOpEvidence:        1000,
+	GasOpComputeCommit:    1000,
+	GasOpProposerTimeout:  1000,
+	GasOpEvidence:         1000,
+	GasOpSetRuntimePaused: 1000,
 }
 
 // SanityCheckBlocks examines the blocks table.
@@ -479,10 +626,13 @@ func (g *Genesis) SanityCheck() error {
 	}
 
 	// Check blocks.
-	for _, rtg := range g.RuntimeStates {
+	for id, rtg := range g.RuntimeStates {
 		if err := rtg.SanityCheck(true); err != nil {
 			return err
 		}
+		if cp := rtg.StateCheckpoint; cp != nil && !cp.Root.Namespace.Equal(&id) {
+			return fmt.Errorf("roothash: sanity check failed: state checkpoint for runtime %s has invalid namespace", id)
+		}
 	}
 	return nil
 }
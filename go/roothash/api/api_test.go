@@ -10,10 +10,43 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 	genesisTestHelpers "github.com/oasisprotocol/oasis-core/go/genesis/tests"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
 )
 
+func TestRuntimeStateRoundTimeout(t *testing.T) {
+	require := require.New(t)
+
+	rtState := &RuntimeState{
+		Runtime: &registry.Runtime{
+			Executor: registry.ExecutorParameters{
+				RoundTimeout: 10,
+			},
+		},
+	}
+	params := &ConsensusParameters{}
+	require.EqualValues(10, rtState.RoundTimeout(params), "timeout without failed rounds")
+	rtState.FailedRounds = 3
+	require.EqualValues(10, rtState.RoundTimeout(params), "timeout with backoff disabled")
+
+	params.RoundTimeoutBackoffFactor = 2
+	params.MaxRoundTimeoutBackoff = 4
+	rtState.FailedRounds = 0
+	require.EqualValues(10, rtState.RoundTimeout(params), "timeout without failed rounds")
+	rtState.FailedRounds = 1
+	require.EqualValues(20, rtState.RoundTimeout(params), "timeout after one failed round")
+	rtState.FailedRounds = 2
+	require.EqualValues(40, rtState.RoundTimeout(params), "timeout after two failed rounds")
+	rtState.FailedRounds = 100
+	require.EqualValues(40, rtState.RoundTimeout(params), "timeout backoff should be capped")
+
+	params.RoundTimeoutBackoffFactor = 3
+	params.MaxRoundTimeoutBackoff = 5
+	rtState.FailedRounds = 2
+	require.EqualValues(50, rtState.RoundTimeout(params), "timeout backoff should be capped")
+}
+
 func TestEvidenceHash(t *testing.T) {
 	require := require.New(t)
 
@@ -61,6 +61,14 @@ const (
 	// Such a header contains no transactions but advances the round as
 	// normal.
 	Suspended HeaderType = 4
+
+	// LivenessFailure is a header resulting from a round that failed
+	// because the executor committee or the transaction scheduler did
+	// not respond in time.
+	//
+	// Such a header contains no transactions but advances the round as
+	// normal.
+	LivenessFailure HeaderType = 5
 )
 
 // Header is a block header.
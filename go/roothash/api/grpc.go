@@ -21,6 +21,8 @@ var (
 	methodGetLatestBlock = serviceName.NewMethod("GetLatestBlock", RuntimeRequest{})
 	// methodGetRuntimeState is the GetRuntimeState method.
 	methodGetRuntimeState = serviceName.NewMethod("GetRuntimeState", RuntimeRequest{})
+	// methodGetRuntimeStateWithProof is the GetRuntimeStateWithProof method.
+	methodGetRuntimeStateWithProof = serviceName.NewMethod("GetRuntimeStateWithProof", RuntimeRequest{})
 	// methodGetLastRoundResults is the GetLastRoundResults method.
 	methodGetLastRoundResults = serviceName.NewMethod("GetLastRoundResults", RuntimeRequest{})
 	// methodStateToGenesis is the StateToGenesis method.
@@ -52,6 +54,10 @@ var (
 				MethodName: methodGetRuntimeState.ShortName(),
 				Handler:    handlerGetRuntimeState,
 			},
+			{
+				MethodName: methodGetRuntimeStateWithProof.ShortName(),
+				Handler:    handlerGetRuntimeStateWithProof,
+			},
 			{
 				MethodName: methodGetLastRoundResults.ShortName(),
 				Handler:    handlerGetLastRoundResults,
@@ -153,6 +159,29 @@ func handlerGetRuntimeState( // nolint: golint
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerGetRuntimeStateWithProof( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq RuntimeRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetRuntimeStateWithProof(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetRuntimeStateWithProof.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetRuntimeStateWithProof(ctx, req.(*RuntimeRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerGetLastRoundResults( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -336,6 +365,14 @@ func (c *roothashClient) GetRuntimeState(ctx context.Context, request *RuntimeRe
 	return &rsp, nil
 }
 
+func (c *roothashClient) GetRuntimeStateWithProof(ctx context.Context, request *RuntimeRequest) (*RuntimeStateWithProof, error) {
+	var rsp RuntimeStateWithProof
+	if err := c.conn.Invoke(ctx, methodGetRuntimeStateWithProof.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *roothashClient) GetLastRoundResults(ctx context.Context, request *RuntimeRequest) (*RoundResults, error) {
 	var rsp RoundResults
 	if err := c.conn.Invoke(ctx, methodGetLastRoundResults.FullName(), request, &rsp); err != nil {
@@ -348,6 +385,10 @@ func (c *roothashClient) TrackRuntime(ctx context.Context, history BlockHistory)
 	return ErrInvalidArgument
 }
 
+func (c *roothashClient) UntrackRuntime(ctx context.Context, runtimeID common.Namespace) error {
+	return ErrInvalidArgument
+}
+
 func (c *roothashClient) StateToGenesis(ctx context.Context, height int64) (*Genesis, error) {
 	var rsp Genesis
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
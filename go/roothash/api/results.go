@@ -14,4 +14,11 @@ type RoundResults struct {
 	// BadComputeEntities are the public keys of compute nodes' controlling entities that
 	// negatively contributed to the round by causing discrepancies.
 	BadComputeEntities []signature.PublicKey `json:"bad_compute_entities,omitempty"`
+
+	// FailedRounds is the number of consecutive failed rounds (including liveness failures) that
+	// preceded this round.
+	FailedRounds uint64 `json:"failed_rounds,omitempty"`
+	// LivenessFailures is the number of consecutive liveness failure rounds that preceded this
+	// round.
+	LivenessFailures uint64 `json:"liveness_failures,omitempty"`
 }
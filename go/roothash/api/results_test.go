@@ -38,6 +38,12 @@ func TestRoundResultsSerialization(t *testing.T) {
 				signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001"),
 			},
 		}, "o2htZXNzYWdlc4GjZGNvZGUYKmVpbmRleAFmbW9kdWxlZHRlc3R0YmFkX2NvbXB1dGVfZW50aXRpZXOBWCAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAXVnb29kX2NvbXB1dGVfZW50aXRpZXOCWCAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAFggAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAI="},
+		{RoundResults{
+			Messages:         []*MessageEvent{{Module: "test", Code: 1, Index: 0}},
+			FailedRounds:     3,
+			LivenessFailures: 2,
+		}, "o2htZXNzYWdlc4GiZGNvZGUBZm1vZHVsZWR0ZXN0bWZhaWxlZF9yb3VuZHMDcWxpdmVuZXNzX2ZhaWx1cmVzAg=="},
+		{RoundResults{Messages: []*MessageEvent{{Module: "test", Code: 0, Index: 2, GasUsed: 1000}}}, "oWhtZXNzYWdlc4GjZWluZGV4AmZtb2R1bGVkdGVzdGhnYXNfdXNlZBkD6A=="},
 	} {
 		enc := cbor.Marshal(tc.rr)
 		require.Equal(tc.expectedBase64, base64.StdEncoding.EncodeToString(enc), "serialization should match")
@@ -20,6 +20,8 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/state"
+	tendermintTests "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/tests"
 	registryTests "github.com/oasisprotocol/oasis-core/go/registry/tests"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
@@ -86,6 +88,10 @@ func RootHashImplementationTests(t *testing.T, backend api.Backend, consensus co
 		testConsensusParameters(t, backend)
 	})
 
+	t.Run("RuntimeStateWithProof", func(t *testing.T) {
+		testRuntimeStateWithProof(t, backend, consensus, rtStates[0])
+	})
+
 	// Run the various tests. (Ordering matters)
 	for _, v := range rtStates {
 		t.Run("GenesisBlock/"+v.id, func(t *testing.T) {
@@ -131,6 +137,29 @@ func testConsensusParameters(t *testing.T, backend api.Backend) {
 	require.EqualValues(t, 32, params.MaxRuntimeMessages, "expected max runtime messages value")
 }
 
+func testRuntimeStateWithProof(t *testing.T, backend api.Backend, consensus consensusAPI.Backend, state *runtimeState) {
+	require := require.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), recvTimeout)
+	defer cancel()
+
+	blk, err := consensus.GetBlock(ctx, consensusAPI.HeightLatest)
+	require.NoError(err, "GetBlock")
+
+	id := state.rt.Runtime.ID
+	query := &api.RuntimeRequest{RuntimeID: id, Height: blk.Height}
+	rs, err := backend.GetRuntimeState(ctx, query)
+	require.NoError(err, "GetRuntimeState")
+	rswp, err := backend.GetRuntimeStateWithProof(ctx, query)
+	require.NoError(err, "GetRuntimeStateWithProof")
+	require.EqualValues(rs, rswp.RuntimeState, "GetRuntimeStateWithProof should return the same state as GetRuntimeState")
+
+	root, err := tendermintTests.WaitStateRoot(ctx, consensus, blk.Height)
+	require.NoError(err, "WaitStateRoot")
+	proven, err := roothashState.VerifyRuntimeStateProof(ctx, *root, id, rswp.Proof)
+	require.NoError(err, "VerifyRuntimeStateProof")
+	require.EqualValues(rs, proven, "proven runtime state should match")
+}
+
 func testGenesisBlock(t *testing.T, backend api.Backend, state *runtimeState) {
 	require := require.New(t)
 
@@ -487,7 +516,7 @@ WaitForRoundTimeoutBlocks:
 
 			// Next round must be a failure.
 			require.EqualValues(child.Header.Round+1, header.Round, "block round")
-			require.EqualValues(block.RoundFailed, header.HeaderType, "block header type must be RoundFailed")
+			require.EqualValues(block.LivenessFailure, header.HeaderType, "block header type must be LivenessFailure")
 
 			// Nothing more to do after the block was received.
 			return
@@ -661,7 +690,7 @@ WaitForProposerTimeoutBlocks:
 
 			// Next round must be a failure.
 			require.EqualValues(child.Header.Round+1, header.Round, "block round")
-			require.EqualValues(block.RoundFailed, header.HeaderType, "block header type must be RoundFailed")
+			require.EqualValues(block.LivenessFailure, header.HeaderType, "block header type must be LivenessFailure")
 
 			// Nothing more to do after the failed block was received.
 			return
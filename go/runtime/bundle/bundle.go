@@ -0,0 +1,296 @@
+// Package bundle implements support for runtime bundles.
+//
+// A runtime bundle is a single (zip) file containing the runtime executables together with a
+// manifest describing the runtime and the digests of all the bundled files.
+package bundle
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/sigstruct"
+)
+
+// FileExtension is the file extension used for runtime bundles.
+const FileExtension = ".orc"
+
+// maxFileSize is the maximum size of a single file inside a runtime bundle.
+const maxFileSize = 256 * 1024 * 1024
+
+// Bundle is a runtime bundle instance.
+type Bundle struct {
+	// Manifest is the bundle manifest.
+	Manifest *Manifest
+	// Data are the bundled files, keyed by their names.
+	Data map[string][]byte
+}
+
+// Validate validates the runtime bundle for well-formedness.
+func (bnd *Bundle) Validate() error {
+	if bnd.Manifest == nil {
+		return fmt.Errorf("runtime/bundle: missing manifest")
+	}
+	if err := bnd.Manifest.Validate(); err != nil {
+		return err
+	}
+
+	for name, expected := range bnd.Manifest.Digests {
+		data, ok := bnd.Data[name]
+		if !ok {
+			return fmt.Errorf("runtime/bundle: missing file '%s'", name)
+		}
+		if h := hash.NewFromBytes(data); !h.Equal(&expected) {
+			return fmt.Errorf("runtime/bundle: digest mismatch for '%s' (expected: %s got: %s)", name, expected, h)
+		}
+	}
+	for name := range bnd.Data {
+		if _, ok := bnd.Manifest.Digests[name]; !ok {
+			return fmt.Errorf("runtime/bundle: file '%s' not in manifest", name)
+		}
+	}
+
+	return nil
+}
+
+// Add adds a file to the bundle and records its digest in the manifest.
+func (bnd *Bundle) Add(name string, data []byte) error {
+	if err := validateFileName(name); err != nil {
+		return err
+	}
+	if _, ok := bnd.Data[name]; ok {
+		return fmt.Errorf("runtime/bundle: duplicate file '%s'", name)
+	}
+
+	if bnd.Data == nil {
+		bnd.Data = make(map[string][]byte)
+	}
+	if bnd.Manifest.Digests == nil {
+		bnd.Manifest.Digests = make(map[string]hash.Hash)
+	}
+	bnd.Data[name] = data
+	bnd.Manifest.Digests[name] = hash.NewFromBytes(data)
+
+	return nil
+}
+
+// Write serializes the runtime bundle to the given file.
+func (bnd *Bundle) Write(fn string) error {
+	if err := bnd.Validate(); err != nil {
+		return fmt.Errorf("runtime/bundle: refusing to write invalid bundle: %w", err)
+	}
+
+	rawManifest, err := json.Marshal(bnd.Manifest)
+	if err != nil {
+		return fmt.Errorf("runtime/bundle: failed to serialize manifest: %w", err)
+	}
+
+	f, err := os.Create(fn)
+	if err != nil {
+		return fmt.Errorf("runtime/bundle: failed to create bundle file: %w", err)
+	}
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	writeFile := func(name string, data []byte) error {
+		fw, werr := w.Create(name)
+		if werr != nil {
+			return fmt.Errorf("runtime/bundle: failed to create file '%s': %w", name, werr)
+		}
+		if _, werr = fw.Write(data); werr != nil {
+			return fmt.Errorf("runtime/bundle: failed to write file '%s': %w", name, werr)
+		}
+		return nil
+	}
+
+	// The manifest goes first, followed by the files in a deterministic order.
+	if err = writeFile(manifestName, rawManifest); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(bnd.Data))
+	for name := range bnd.Data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err = writeFile(name, bnd.Data[name]); err != nil {
+			return err
+		}
+	}
+
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("runtime/bundle: failed to finalize bundle: %w", err)
+	}
+	return f.Close()
+}
+
+// ExplodedPath returns the path under the given base directory that the runtime bundle is
+// exploded into, optionally joined with the given bundled file name.
+func (bnd *Bundle) ExplodedPath(baseDir, name string) string {
+	subDir := fmt.Sprintf("%s-%s", bnd.Manifest.ID, bnd.Manifest.Version)
+	return filepath.Join(baseDir, subDir, name)
+}
+
+// WriteExploded writes the bundled files into a subdirectory of the given base directory and
+// returns the path of the subdirectory.
+//
+// In case the bundle has already been exploded, the existing files are verified against the
+// manifest instead.
+func (bnd *Bundle) WriteExploded(baseDir string) (string, error) {
+	if err := bnd.Validate(); err != nil {
+		return "", fmt.Errorf("runtime/bundle: refusing to explode invalid bundle: %w", err)
+	}
+
+	subDir := bnd.ExplodedPath(baseDir, "")
+	if _, err := os.Stat(subDir); err == nil {
+		for name, expected := range bnd.Manifest.Digests {
+			data, rerr := ioutil.ReadFile(filepath.Join(subDir, name))
+			if rerr != nil {
+				return "", fmt.Errorf("runtime/bundle: failed to read exploded file '%s': %w", name, rerr)
+			}
+			if h := hash.NewFromBytes(data); !h.Equal(&expected) {
+				return "", fmt.Errorf("runtime/bundle: corrupted exploded file '%s'", name)
+			}
+		}
+		return subDir, nil
+	}
+
+	// Write the files into a temporary directory first so that a partially exploded bundle is
+	// never mistaken for a complete one.
+	if err := common.Mkdir(baseDir); err != nil {
+		return "", fmt.Errorf("runtime/bundle: failed to create bundle directory: %w", err)
+	}
+	tmpDir, err := ioutil.TempDir(baseDir, "explode-")
+	if err != nil {
+		return "", fmt.Errorf("runtime/bundle: failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	executables := map[string]bool{
+		bnd.Manifest.Executable: true,
+	}
+	for name, data := range bnd.Data {
+		fn := filepath.Join(tmpDir, name)
+		if err = os.MkdirAll(filepath.Dir(fn), 0o700); err != nil {
+			return "", fmt.Errorf("runtime/bundle: failed to create directory for '%s': %w", name, err)
+		}
+		mode := os.FileMode(0o600)
+		if executables[name] {
+			mode = 0o700
+		}
+		if err = ioutil.WriteFile(fn, data, mode); err != nil {
+			return "", fmt.Errorf("runtime/bundle: failed to write exploded file '%s': %w", name, err)
+		}
+	}
+	if err = os.Rename(tmpDir, subDir); err != nil {
+		return "", fmt.Errorf("runtime/bundle: failed to move exploded bundle: %w", err)
+	}
+
+	return subDir, nil
+}
+
+// MrEnclave returns the MRENCLAVE of the bundled SGX enclave.
+func (bnd *Bundle) MrEnclave() (*sgx.MrEnclave, error) {
+	if !bnd.Manifest.IsSGX() {
+		return nil, fmt.Errorf("runtime/bundle: no SGX enclave in bundle")
+	}
+
+	var mrEnclave sgx.MrEnclave
+	if err := mrEnclave.FromSgxsBytes(bnd.Data[bnd.Manifest.SGX.Executable]); err != nil {
+		return nil, fmt.Errorf("runtime/bundle: failed to derive MRENCLAVE: %w", err)
+	}
+	return &mrEnclave, nil
+}
+
+// EnclaveIdentity returns the identity of the bundled SGX enclave.
+//
+// In case the bundle does not contain an enclave signature, the given detached SIGSTRUCT is used
+// instead.
+func (bnd *Bundle) EnclaveIdentity(detachedSig []byte) (*sgx.EnclaveIdentity, error) {
+	mrEnclave, err := bnd.MrEnclave()
+	if err != nil {
+		return nil, err
+	}
+
+	sig := detachedSig
+	if bnd.Manifest.SGX.Signature != "" {
+		sig = bnd.Data[bnd.Manifest.SGX.Signature]
+	}
+	if sig == nil {
+		return nil, fmt.Errorf("runtime/bundle: enclave signature not available")
+	}
+	pk, ss, err := sigstruct.Verify(sig)
+	if err != nil {
+		return nil, fmt.Errorf("runtime/bundle: invalid enclave signature: %w", err)
+	}
+	if ss.EnclaveHash != *mrEnclave {
+		return nil, fmt.Errorf("runtime/bundle: enclave/signature mismatch")
+	}
+
+	id := sgx.EnclaveIdentity{
+		MrEnclave: *mrEnclave,
+	}
+	if err = id.MrSigner.FromPublicKey(pk); err != nil {
+		return nil, fmt.Errorf("runtime/bundle: failed to derive MRSIGNER: %w", err)
+	}
+	return &id, nil
+}
+
+// Open opens and validates a runtime bundle instance.
+func Open(fn string) (*Bundle, error) {
+	r, err := zip.OpenReader(fn)
+	if err != nil {
+		return nil, fmt.Errorf("runtime/bundle: failed to open bundle: %w", err)
+	}
+	defer r.Close()
+
+	bnd := &Bundle{
+		Data: make(map[string][]byte),
+	}
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if f.UncompressedSize64 > maxFileSize {
+			return nil, fmt.Errorf("runtime/bundle: file '%s' too large", f.Name)
+		}
+		if _, ok := bnd.Data[f.Name]; ok {
+			return nil, fmt.Errorf("runtime/bundle: duplicate file '%s'", f.Name)
+		}
+
+		rd, rerr := f.Open()
+		if rerr != nil {
+			return nil, fmt.Errorf("runtime/bundle: failed to open '%s': %w", f.Name, rerr)
+		}
+		data, rerr := ioutil.ReadAll(rd)
+		rd.Close()
+		if rerr != nil {
+			return nil, fmt.Errorf("runtime/bundle: failed to read '%s': %w", f.Name, rerr)
+		}
+		bnd.Data[f.Name] = data
+	}
+
+	rawManifest, ok := bnd.Data[manifestName]
+	if !ok {
+		return nil, fmt.Errorf("runtime/bundle: missing manifest")
+	}
+	delete(bnd.Data, manifestName)
+
+	var manifest Manifest
+	if err = json.Unmarshal(rawManifest, &manifest); err != nil {
+		return nil, fmt.Errorf("runtime/bundle: malformed manifest: %w", err)
+	}
+	bnd.Manifest = &manifest
+
+	if err = bnd.Validate(); err != nil {
+		return nil, err
+	}
+	return bnd, nil
+}
@@ -0,0 +1,146 @@
+package bundle
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/sigstruct"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+)
+
+func newTestBundle(t *testing.T) *Bundle {
+	var id common.Namespace
+	require.NoError(t, id.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"))
+
+	bnd := &Bundle{
+		Manifest: &Manifest{
+			Name:       "test-runtime",
+			ID:         id,
+			Version:    version.Version{Major: 1, Minor: 2, Patch: 3},
+			Executable: "runtime.bin",
+			SGX: &SGXMetadata{
+				Executable: "runtime.sgxs",
+			},
+		},
+	}
+	require.NoError(t, bnd.Add("runtime.bin", []byte("not an elf binary")))
+	require.NoError(t, bnd.Add("runtime.sgxs", []byte("not an sgxs binary")))
+	return bnd
+}
+
+func TestBundle(t *testing.T) {
+	require := require.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "oasis-runtime-bundle-test")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(tmpDir)
+
+	bnd := newTestBundle(t)
+	require.NoError(bnd.Validate(), "Validate")
+	require.Error(bnd.Add("runtime.bin", nil), "Add should reject duplicate files")
+	require.Error(bnd.Add("../escape", nil), "Add should reject files outside the bundle")
+	require.Error(bnd.Add(manifestName, nil), "Add should reject the manifest name")
+
+	fn := filepath.Join(tmpDir, "bundle"+FileExtension)
+	require.NoError(bnd.Write(fn), "Write")
+
+	opened, err := Open(fn)
+	require.NoError(err, "Open")
+	require.EqualValues(bnd.Manifest, opened.Manifest, "opened bundle manifest should match")
+	require.EqualValues(bnd.Data, opened.Data, "opened bundle data should match")
+
+	baseDir := filepath.Join(tmpDir, "exploded")
+	path, err := opened.WriteExploded(baseDir)
+	require.NoError(err, "WriteExploded")
+	require.Equal(opened.ExplodedPath(baseDir, ""), path)
+	data, err := ioutil.ReadFile(filepath.Join(path, "runtime.bin"))
+	require.NoError(err, "ReadFile")
+	require.Equal([]byte("not an elf binary"), data)
+
+	// Exploding again should verify the existing files.
+	_, err = opened.WriteExploded(baseDir)
+	require.NoError(err, "WriteExploded (existing)")
+	require.NoError(ioutil.WriteFile(filepath.Join(path, "runtime.bin"), []byte("tampered"), 0o600))
+	_, err = opened.WriteExploded(baseDir)
+	require.Error(err, "WriteExploded should detect tampered exploded files")
+}
+
+func TestBundleCorrupted(t *testing.T) {
+	require := require.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "oasis-runtime-bundle-test")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(tmpDir)
+
+	bnd := newTestBundle(t)
+	bnd.Data["runtime.bin"] = []byte("tampered")
+	require.Error(bnd.Validate(), "Validate should detect digest mismatch")
+	fn := filepath.Join(tmpDir, "bundle"+FileExtension)
+	require.Error(bnd.Write(fn), "Write should refuse invalid bundles")
+
+	// Write a bundle with a file that is not in the manifest.
+	f, err := os.Create(fn)
+	require.NoError(err, "Create")
+	w := zip.NewWriter(f)
+	fw, err := w.Create(manifestName)
+	require.NoError(err, "Create manifest")
+	_, err = fw.Write([]byte(`{"executable":"runtime.bin","digests":{}}`))
+	require.NoError(err, "Write manifest")
+	fw, err = w.Create("runtime.bin")
+	require.NoError(err, "Create executable")
+	_, err = fw.Write([]byte("not an elf binary"))
+	require.NoError(err, "Write executable")
+	require.NoError(w.Close(), "Close")
+	require.NoError(f.Close(), "Close")
+
+	_, err = Open(fn)
+	require.Error(err, "Open should reject bundles with files not in the manifest")
+}
+
+func TestBundleEnclaveIdentity(t *testing.T) {
+	require := require.New(t)
+
+	viper.Set(cmdFlags.CfgDebugDontBlameOasis, true)
+	defer viper.Set(cmdFlags.CfgDebugDontBlameOasis, false)
+
+	bnd := newTestBundle(t)
+	sgxs := bnd.Data[bnd.Manifest.SGX.Executable]
+	sig, err := sigstruct.UnsafeDebugForEnclave(sgxs)
+	require.NoError(err, "UnsafeDebugForEnclave")
+
+	var mrEnclave sgx.MrEnclave
+	require.NoError(mrEnclave.FromSgxsBytes(sgxs))
+
+	_, err = bnd.EnclaveIdentity(nil)
+	require.Error(err, "EnclaveIdentity should fail without a signature")
+
+	// Detached signature.
+	id, err := bnd.EnclaveIdentity(sig)
+	require.NoError(err, "EnclaveIdentity (detached)")
+	require.Equal(mrEnclave, id.MrEnclave)
+	require.Equal(sgx.FortanixDummyMrSigner, id.MrSigner)
+
+	// Bundled signature.
+	bnd.Manifest.SGX.Signature = "runtime.sig"
+	require.NoError(bnd.Add("runtime.sig", sig))
+	require.NoError(bnd.Validate(), "Validate")
+	id, err = bnd.EnclaveIdentity(nil)
+	require.NoError(err, "EnclaveIdentity (bundled)")
+	require.Equal(mrEnclave, id.MrEnclave)
+
+	// Signature for a different enclave.
+	otherSig, err := sigstruct.UnsafeDebugForEnclave([]byte("another enclave"))
+	require.NoError(err, "UnsafeDebugForEnclave")
+	bnd.Manifest.SGX.Signature = ""
+	_, err = bnd.EnclaveIdentity(otherSig)
+	require.Error(err, "EnclaveIdentity should reject signatures for other enclaves")
+}
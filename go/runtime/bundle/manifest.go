@@ -0,0 +1,96 @@
+package bundle
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+)
+
+// manifestName is the name (path) of the manifest within a runtime bundle.
+const manifestName = "META-INF/MANIFEST.MF"
+
+// Manifest is a runtime bundle manifest.
+type Manifest struct {
+	// Name is the optional human readable runtime name.
+	Name string `json:"name,omitempty"`
+
+	// ID is the runtime identifier.
+	ID common.Namespace `json:"id"`
+
+	// Version is the runtime version.
+	Version version.Version `json:"version"`
+
+	// Executable is the name of the runtime ELF executable file.
+	Executable string `json:"executable,omitempty"`
+
+	// SGX is the optional SGX specific manifest metadata.
+	SGX *SGXMetadata `json:"sgx,omitempty"`
+
+	// Digests is the manifest of file digests.
+	Digests map[string]hash.Hash `json:"digests,omitempty"`
+}
+
+// SGXMetadata is the SGX specific manifest metadata.
+type SGXMetadata struct {
+	// Executable is the name of the SGX enclave SGXS executable file.
+	Executable string `json:"executable"`
+
+	// Signature is the name of the optional SGX enclave SIGSTRUCT file. In case it is omitted,
+	// a detached signature must be provided when the runtime is configured.
+	Signature string `json:"signature,omitempty"`
+}
+
+// Validate validates the manifest structure for well-formedness.
+func (m *Manifest) Validate() error {
+	if m.Executable == "" && m.SGX == nil {
+		return fmt.Errorf("runtime/bundle: manifest does not specify any executables")
+	}
+
+	names := []string{m.Executable}
+	if m.SGX != nil {
+		if m.SGX.Executable == "" {
+			return fmt.Errorf("runtime/bundle: manifest is missing the SGX executable")
+		}
+		names = append(names, m.SGX.Executable, m.SGX.Signature)
+	}
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if _, ok := m.Digests[name]; !ok {
+			return fmt.Errorf("runtime/bundle: manifest is missing digest for '%s'", name)
+		}
+	}
+	for name := range m.Digests {
+		if err := validateFileName(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsSGX returns true iff the bundle contains an SGX enclave.
+func (m *Manifest) IsSGX() bool {
+	return m.SGX != nil
+}
+
+// validateFileName makes sure that a bundle file name is a clean relative path that does not
+// escape the bundle directory.
+func validateFileName(name string) error {
+	switch {
+	case name == "",
+		name == manifestName,
+		filepath.IsAbs(name),
+		filepath.Clean(name) != name,
+		name == "..",
+		strings.HasPrefix(name, "../"):
+		return fmt.Errorf("runtime/bundle: invalid file name '%s'", name)
+	default:
+		return nil
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
@@ -18,6 +19,10 @@ const (
 
 	// RoundLatest is a special round number always referring to the latest round.
 	RoundLatest = roothash.RoundLatest
+
+	// MaxMinRoundWait is the maximum amount of time a query waits for the node to reach the
+	// requested minimum round.
+	MaxMinRoundWait = 30 * time.Second
 )
 
 var (
@@ -34,6 +39,12 @@ var (
 	ErrCheckTxFailed = errors.New(ModuleName, 5, "client: transaction check failed")
 	// ErrNoHostedRuntime is returned when the hosted runtime is not available locally.
 	ErrNoHostedRuntime = errors.New(ModuleName, 6, "client: no hosted runtime is available")
+	// ErrMinRoundNotReached is returned when the node does not reach the minimum round requested
+	// by a query in time.
+	ErrMinRoundNotReached = errors.New(ModuleName, 7, "client: minimum round not reached")
+	// ErrQueryBudgetExceeded is returned when a query exceeds its execution deadline or when too
+	// many queries are being executed concurrently.
+	ErrQueryBudgetExceeded = errors.New(ModuleName, 8, "client: query budget exceeded")
 )
 
 // RuntimeClient is the runtime client interface.
@@ -56,12 +67,23 @@ type RuntimeClient interface {
 	// CheckTx asks the local runtime to check the specified transaction.
 	CheckTx(ctx context.Context, request *CheckTxRequest) error
 
+	// CheckTxMeta asks the local runtime to check the specified transaction without submitting
+	// it for execution.
+	//
+	// In contrast to CheckTx, the full check result is returned, including any transaction
+	// metadata (e.g., priority and weights) and the structured error in case the transaction
+	// failed the check.
+	CheckTxMeta(ctx context.Context, request *CheckTxRequest) (*protocol.CheckTxResult, error)
+
 	// GetGenesisBlock returns the genesis block.
 	GetGenesisBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error)
 
 	// GetBlock fetches the given runtime block.
 	GetBlock(ctx context.Context, request *GetBlockRequest) (*block.Block, error)
 
+	// GetBlockByHash fetches the runtime block with the given header hash.
+	GetBlockByHash(ctx context.Context, request *GetBlockByHashRequest) (*block.Block, error)
+
 	// GetLastRetainedBlock returns the last retained block.
 	GetLastRetainedBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error)
 
@@ -80,6 +102,10 @@ type RuntimeClient interface {
 
 	// WatchBlocks subscribes to blocks for a specific runtimes.
 	WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error)
+
+	// WatchBlockHeaders subscribes to block headers for a specific runtime. Each header is
+	// accompanied by the block-level tags emitted by the runtime in that block.
+	WatchBlockHeaders(ctx context.Context, runtimeID common.Namespace) (<-chan *BlockHeader, pubsub.ClosableSubscription, error)
 }
 
 // SubmitTxResult is the raw result of submitting a transaction for processing.
@@ -103,7 +129,8 @@ type SubmitTxMetaResponse struct {
 	// BatchOrder is the order of the transaction in the execution batch.
 	BatchOrder uint32 `json:"batch_order,omitempty"`
 
-	// CheckTxError is the CheckTx error in case transaction failed the transaction check.
+	// CheckTxError is the CheckTx error in case transaction failed the transaction check. It
+	// includes any structured error details provided by the runtime.
 	CheckTxError *protocol.Error `json:"check_tx_error,omitempty"`
 }
 
@@ -119,6 +146,23 @@ type GetBlockRequest struct {
 	Round     uint64           `json:"round"`
 }
 
+// GetBlockByHashRequest is a GetBlockByHash request.
+type GetBlockByHashRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	BlockHash hash.Hash        `json:"block_hash"`
+}
+
+// BlockHeader is a runtime block header together with block-level tags.
+type BlockHeader struct {
+	// Height is the consensus height at which the block was finalized.
+	Height int64 `json:"height"`
+	// Header is the runtime block header.
+	Header block.Header `json:"header"`
+	// Tags are the tags emitted by the runtime in this block that are not tied to any specific
+	// transaction.
+	Tags []*PlainEvent `json:"tags,omitempty"`
+}
+
 // GetTransactionsRequest is a GetTransactions request.
 type GetTransactionsRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
@@ -162,6 +206,12 @@ type QueryRequest struct {
 	Round     uint64           `json:"round"`
 	Method    string           `json:"method"`
 	Args      []byte           `json:"args"`
+
+	// MinRound is an optional consistency token. When non-zero, the query is only performed
+	// after the node has processed a block of at least this round, waiting for at most
+	// MaxMinRoundWait. Passing the round returned by SubmitTxMeta ensures that the query
+	// observes the effects of the submitted transaction.
+	MinRound uint64 `json:"min_round,omitempty"`
 }
 
 // QueryResponse is a response to the runtime query.
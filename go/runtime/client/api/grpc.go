@@ -13,6 +13,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 )
 
 var (
@@ -27,10 +28,14 @@ var (
 	methodSubmitTxNoWait = serviceName.NewMethod("SubmitTxNoWait", SubmitTxRequest{})
 	// methodCheckTx is the CheckTx method.
 	methodCheckTx = serviceName.NewMethod("CheckTx", CheckTxRequest{})
+	// methodCheckTxMeta is the CheckTxMeta method.
+	methodCheckTxMeta = serviceName.NewMethod("CheckTxMeta", CheckTxRequest{})
 	// methodGetGenesisBlock is the GetGenesisBlock method.
 	methodGetGenesisBlock = serviceName.NewMethod("GetGenesisBlock", common.Namespace{})
 	// methodGetBlock is the GetBlock method.
 	methodGetBlock = serviceName.NewMethod("GetBlock", GetBlockRequest{})
+	// methodGetBlockByHash is the GetBlockByHash method.
+	methodGetBlockByHash = serviceName.NewMethod("GetBlockByHash", GetBlockByHashRequest{})
 	// methodGetLastRetainedBlock is the GetLastRetainedBlock method.
 	methodGetLastRetainedBlock = serviceName.NewMethod("GetLastRetainedBlock", common.Namespace{})
 	// methodGetTransactions is the GetTransactions method.
@@ -44,6 +49,8 @@ var (
 
 	// methodWatchBlocks is the WatchBlocks method.
 	methodWatchBlocks = serviceName.NewMethod("WatchBlocks", common.Namespace{})
+	// methodWatchBlockHeaders is the WatchBlockHeaders method.
+	methodWatchBlockHeaders = serviceName.NewMethod("WatchBlockHeaders", common.Namespace{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -66,6 +73,10 @@ var (
 				MethodName: methodCheckTx.ShortName(),
 				Handler:    handlerCheckTx,
 			},
+			{
+				MethodName: methodCheckTxMeta.ShortName(),
+				Handler:    handlerCheckTxMeta,
+			},
 			{
 				MethodName: methodGetGenesisBlock.ShortName(),
 				Handler:    handlerGetGenesisBlock,
@@ -74,6 +85,10 @@ var (
 				MethodName: methodGetBlock.ShortName(),
 				Handler:    handlerGetBlock,
 			},
+			{
+				MethodName: methodGetBlockByHash.ShortName(),
+				Handler:    handlerGetBlockByHash,
+			},
 			{
 				MethodName: methodGetLastRetainedBlock.ShortName(),
 				Handler:    handlerGetLastRetainedBlock,
@@ -101,6 +116,11 @@ var (
 				Handler:       handlerWatchBlocks,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchBlockHeaders.ShortName(),
+				Handler:       handlerWatchBlockHeaders,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -197,6 +217,29 @@ func handlerCheckTx( // nolint: golint
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerCheckTxMeta( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq CheckTxRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).CheckTxMeta(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodCheckTxMeta.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).CheckTxMeta(ctx, req.(*CheckTxRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 // wrappedErrNotFound is a wrapped ErrNotFound error so that it corresponds
 // to the gRPC NotFound error code. It is required because Rust's gRPC bindings
 // do not support fetching error details.
@@ -274,6 +317,31 @@ func handlerGetBlock( // nolint: golint
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerGetBlockByHash( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq GetBlockByHashRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		rsp, err := srv.(RuntimeClient).GetBlockByHash(ctx, &rq)
+		return rsp, errorWrapNotFound(err)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetBlockByHash.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		rsp, err := srv.(RuntimeClient).GetBlockByHash(ctx, req.(*GetBlockByHashRequest))
+		return rsp, errorWrapNotFound(err)
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerGetLastRetainedBlock( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -422,6 +490,35 @@ func handlerWatchBlocks(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerWatchBlockHeaders(srv interface{}, stream grpc.ServerStream) error {
+	var runtimeID common.Namespace
+	if err := stream.RecvMsg(&runtimeID); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(RuntimeClient).WatchBlockHeaders(ctx, runtimeID)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case hdr, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(hdr); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // RegisterService registers a new runtime client service with the given gRPC server.
 func RegisterService(server *grpc.Server, service RuntimeClient) {
 	server.RegisterService(&serviceDesc, service)
@@ -455,6 +552,14 @@ func (c *runtimeClient) CheckTx(ctx context.Context, request *CheckTxRequest) er
 	return c.conn.Invoke(ctx, methodCheckTx.FullName(), request, nil)
 }
 
+func (c *runtimeClient) CheckTxMeta(ctx context.Context, request *CheckTxRequest) (*protocol.CheckTxResult, error) {
+	var rsp protocol.CheckTxResult
+	if err := c.conn.Invoke(ctx, methodCheckTxMeta.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) GetGenesisBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error) {
 	var rsp block.Block
 	if err := c.conn.Invoke(ctx, methodGetGenesisBlock.FullName(), runtimeID, &rsp); err != nil {
@@ -471,6 +576,14 @@ func (c *runtimeClient) GetBlock(ctx context.Context, request *GetBlockRequest)
 	return &rsp, nil
 }
 
+func (c *runtimeClient) GetBlockByHash(ctx context.Context, request *GetBlockByHashRequest) (*block.Block, error) {
+	var rsp block.Block
+	if err := c.conn.Invoke(ctx, methodGetBlockByHash.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) GetLastRetainedBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error) {
 	var rsp block.Block
 	if err := c.conn.Invoke(ctx, methodGetLastRetainedBlock.FullName(), runtimeID, &rsp); err != nil {
@@ -546,6 +659,41 @@ func (c *runtimeClient) WatchBlocks(ctx context.Context, runtimeID common.Namesp
 	return ch, sub, nil
 }
 
+func (c *runtimeClient) WatchBlockHeaders(ctx context.Context, runtimeID common.Namespace) (<-chan *BlockHeader, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[1], methodWatchBlockHeaders.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(runtimeID); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *BlockHeader)
+	go func() {
+		defer close(ch)
+
+		for {
+			var hdr BlockHeader
+			if serr := stream.RecvMsg(&hdr); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &hdr:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 // NewRuntimeClient creates a new gRPC runtime client service.
 func NewRuntimeClient(c *grpc.ClientConn) RuntimeClient {
 	return &runtimeClient{
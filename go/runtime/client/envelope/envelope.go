@@ -0,0 +1,105 @@
+// Package envelope implements the X25519-DeoxysII transaction encryption envelope used to submit
+// confidential transactions and queries to runtimes using a key manager.
+//
+// Calls are encrypted with an ephemeral X25519 key pair against the runtime's public key published
+// by the key manager. Results are encrypted by the runtime using the same symmetric key so only the
+// caller holding the ephemeral private key is able to decrypt them.
+package envelope
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/deoxysii"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/mrae/api"
+	mraeDeoxysii "github.com/oasisprotocol/oasis-core/go/common/crypto/mrae/deoxysii"
+)
+
+// NonceSize is the size of the envelope nonce in bytes.
+const NonceSize = deoxysii.NonceSize
+
+// Nonce is an envelope nonce.
+type Nonce [NonceSize]byte
+
+// CallEnvelope is an encrypted call envelope.
+type CallEnvelope struct {
+	// PublicKey is the caller's ephemeral X25519 public key.
+	PublicKey PublicKey `json:"pk"`
+	// Nonce is the nonce used for encrypting the call.
+	Nonce Nonce `json:"nonce"`
+	// Data is the encrypted call data.
+	Data []byte `json:"data"`
+}
+
+// ResultEnvelope is an encrypted result envelope.
+type ResultEnvelope struct {
+	// Nonce is the nonce used for encrypting the result.
+	Nonce Nonce `json:"nonce"`
+	// Data is the encrypted result data.
+	Data []byte `json:"data"`
+}
+
+// Opener opens result envelopes for a sealed call.
+type Opener struct {
+	runtimePublicKey PublicKey
+	privateKey       [32]byte
+}
+
+// Open decrypts the given result envelope.
+func (o *Opener) Open(envelope *ResultEnvelope) ([]byte, error) {
+	pk := [32]byte(o.runtimePublicKey)
+	data, err := mraeDeoxysii.Box.Open(nil, envelope.Nonce[:], envelope.Data, nil, &pk, &o.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("envelope: failed to open result: %w", err)
+	}
+	return data, nil
+}
+
+// OpenRaw decrypts the given CBOR-serialized result envelope.
+func (o *Opener) OpenRaw(raw []byte) ([]byte, error) {
+	var envelope ResultEnvelope
+	if err := cbor.Unmarshal(raw, &envelope); err != nil {
+		return nil, fmt.Errorf("envelope: malformed result envelope: %w", err)
+	}
+	return o.Open(&envelope)
+}
+
+// Reset clears the ephemeral private key.
+func (o *Opener) Reset() {
+	api.Bzero(o.privateKey[:])
+}
+
+// Seal encrypts the given call data for a runtime with the given public key.
+//
+// The returned opener can be used to decrypt the corresponding result envelope. In case rng is nil,
+// the system random number generator is used.
+func Seal(rng io.Reader, runtimePublicKey PublicKey, plaintext []byte) (*CallEnvelope, *Opener, error) {
+	if rng == nil {
+		rng = rand.Reader
+	}
+
+	publicKey, privateKey, err := api.GenerateKeyPair(rng)
+	if err != nil {
+		return nil, nil, fmt.Errorf("envelope: failed to generate ephemeral key pair: %w", err)
+	}
+	defer api.Bzero(privateKey[:])
+
+	envelope := CallEnvelope{
+		PublicKey: PublicKey(*publicKey),
+	}
+	if _, err = io.ReadFull(rng, envelope.Nonce[:]); err != nil {
+		return nil, nil, fmt.Errorf("envelope: failed to generate nonce: %w", err)
+	}
+
+	pk := [32]byte(runtimePublicKey)
+	envelope.Data = mraeDeoxysii.Box.Seal(nil, envelope.Nonce[:], plaintext, nil, &pk, privateKey)
+
+	opener := &Opener{
+		runtimePublicKey: runtimePublicKey,
+		privateKey:       *privateKey,
+	}
+	return &envelope, opener, nil
+}
@@ -0,0 +1,131 @@
+package envelope
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/mrae/api"
+	mraeDeoxysii "github.com/oasisprotocol/oasis-core/go/common/crypto/mrae/deoxysii"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+)
+
+type testKeySource struct {
+	pk    *SignedPublicKey
+	calls int
+}
+
+func (ts *testKeySource) GetPublicKey(ctx context.Context, runtimeID common.Namespace, keyPairID KeyPairID) (*SignedPublicKey, error) {
+	ts.calls++
+	return ts.pk, nil
+}
+
+func TestSealOpen(t *testing.T) {
+	require := require.New(t)
+
+	// Generate the runtime key pair.
+	rtPk, rtSk, err := api.GenerateKeyPair(rand.Reader)
+	require.NoError(err, "GenerateKeyPair")
+
+	plaintext := []byte("this is a confidential call")
+	envelope, opener, err := Seal(nil, PublicKey(*rtPk), plaintext)
+	require.NoError(err, "Seal")
+	require.NotEqual(plaintext, envelope.Data, "call data should be encrypted")
+
+	// The envelope should survive serialization.
+	var decEnvelope CallEnvelope
+	err = cbor.Unmarshal(cbor.Marshal(envelope), &decEnvelope)
+	require.NoError(err, "cbor.Unmarshal")
+	require.EqualValues(*envelope, decEnvelope)
+
+	// Decrypt the call on the runtime side.
+	callerPk := [32]byte(decEnvelope.PublicKey)
+	data, err := mraeDeoxysii.Box.Open(nil, decEnvelope.Nonce[:], decEnvelope.Data, nil, &callerPk, rtSk)
+	require.NoError(err, "Box.Open")
+	require.EqualValues(plaintext, data)
+
+	// Encrypt the result on the runtime side.
+	result := ResultEnvelope{Nonce: Nonce{1, 2, 3}}
+	result.Data = mraeDeoxysii.Box.Seal(nil, result.Nonce[:], []byte("result"), nil, &callerPk, rtSk)
+
+	data, err = opener.OpenRaw(cbor.Marshal(result))
+	require.NoError(err, "OpenRaw")
+	require.EqualValues([]byte("result"), data)
+
+	// Tampered results should be rejected.
+	result.Data[0] ^= 0xff
+	_, err = opener.Open(&result)
+	require.Error(err, "Open should fail for tampered results")
+
+	opener.Reset()
+}
+
+func TestSignedPublicKey(t *testing.T) {
+	require := require.New(t)
+
+	signer := memorySigner.NewTestSigner("envelope test signer")
+	otherSigner := memorySigner.NewTestSigner("envelope test signer 2")
+
+	pk := SignedPublicKey{
+		Key:      PublicKey{1, 2, 3},
+		Checksum: []byte("checksum"),
+	}
+	body := append(append([]byte{}, pk.Key[:]...), pk.Checksum...)
+	sig, err := signer.ContextSign(PublicKeySignatureContext, body)
+	require.NoError(err, "ContextSign")
+	copy(pk.Signature[:], sig)
+
+	require.NoError(pk.Verify(signer.Public()), "Verify")
+	require.ErrorIs(pk.Verify(otherSigner.Public()), ErrInvalidSignature)
+
+	pk.Checksum = []byte("other checksum")
+	require.ErrorIs(pk.Verify(signer.Public()), ErrInvalidSignature)
+}
+
+func TestClientCache(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	var runtimeID common.Namespace
+	src := &testKeySource{pk: &SignedPublicKey{Key: PublicKey{1}}}
+
+	// Without caching, keys should always be fetched.
+	client := NewClient(src, &Config{})
+	_, err := client.GetPublicKey(ctx, runtimeID, KeyPairID{})
+	require.NoError(err, "GetPublicKey")
+	_, err = client.GetPublicKey(ctx, runtimeID, KeyPairID{})
+	require.NoError(err, "GetPublicKey")
+	require.Equal(2, src.calls)
+
+	// With caching, keys should only be fetched once per key pair.
+	src.calls = 0
+	client = NewClient(src, &Config{CacheTTL: time.Hour})
+	for i := 0; i < 3; i++ {
+		_, err = client.GetPublicKey(ctx, runtimeID, KeyPairID{})
+		require.NoError(err, "GetPublicKey")
+	}
+	require.Equal(1, src.calls)
+	_, err = client.GetPublicKey(ctx, runtimeID, KeyPairID{1})
+	require.NoError(err, "GetPublicKey")
+	require.Equal(2, src.calls)
+
+	client.Purge()
+	_, _, err = client.Seal(ctx, runtimeID, KeyPairID{}, []byte("call"))
+	require.NoError(err, "Seal")
+	require.Equal(3, src.calls)
+
+	// Keys failing verification should be rejected.
+	client = NewClient(src, &Config{
+		Verifier: func(ctx context.Context, runtimeID common.Namespace, pk *SignedPublicKey) error {
+			return ErrInvalidSignature
+		},
+		CacheTTL: time.Hour,
+	})
+	_, err = client.GetPublicKey(ctx, runtimeID, KeyPairID{})
+	require.ErrorIs(err, ErrInvalidSignature)
+}
@@ -0,0 +1,240 @@
+package envelope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+// PublicKeySignatureContext is the context used for key manager public key signatures.
+//
+// Make sure this always matches the appropriate context in `keymanager-api-common/src/api.rs`.
+var PublicKeySignatureContext = signature.NewContext("EkKmPubK")
+
+var (
+	// ErrNoPublicKey is the error returned when the key manager does not have a public key for
+	// the requested key pair.
+	ErrNoPublicKey = errors.New("envelope: no public key")
+
+	// ErrInvalidSignature is the error returned when the public key signature cannot be verified.
+	ErrInvalidSignature = errors.New("envelope: invalid public key signature")
+)
+
+// KeyPairID is a key manager key pair identifier.
+type KeyPairID [32]byte
+
+// PublicKey is an X25519 public key.
+type PublicKey [32]byte
+
+// SignedPublicKey is a key manager public key signed by a key manager node.
+type SignedPublicKey struct {
+	// Key is the public key.
+	Key PublicKey `json:"key"`
+	// Checksum is the checksum of the key manager state.
+	Checksum []byte `json:"checksum"`
+	// Signature is the signature over the public key and checksum.
+	Signature signature.RawSignature `json:"signature"`
+}
+
+// Verify verifies the public key signature against the given key manager node RAK.
+func (pk *SignedPublicKey) Verify(rak signature.PublicKey) error {
+	body := append(append([]byte{}, pk.Key[:]...), pk.Checksum...)
+	if !rak.Verify(PublicKeySignatureContext, body, pk.Signature[:]) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// KeySource is a source of key manager public keys.
+type KeySource interface {
+	// GetPublicKey returns the signed public key of the given key pair of the given runtime.
+	GetPublicKey(ctx context.Context, runtimeID common.Namespace, keyPairID KeyPairID) (*SignedPublicKey, error)
+}
+
+type queryKeySource struct {
+	client api.RuntimeClient
+	method string
+}
+
+// Implements KeySource.
+func (qs *queryKeySource) GetPublicKey(ctx context.Context, runtimeID common.Namespace, keyPairID KeyPairID) (*SignedPublicKey, error) {
+	rsp, err := qs.client.Query(ctx, &api.QueryRequest{
+		RuntimeID: runtimeID,
+		Round:     api.RoundLatest,
+		Method:    qs.method,
+		Args:      cbor.Marshal(keyPairID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("envelope: failed to query public key: %w", err)
+	}
+
+	var pk *SignedPublicKey
+	if err = cbor.Unmarshal(rsp.Data, &pk); err != nil {
+		return nil, fmt.Errorf("envelope: malformed public key: %w", err)
+	}
+	if pk == nil {
+		return nil, ErrNoPublicKey
+	}
+	return pk, nil
+}
+
+// NewQueryKeySource creates a new key source that fetches public keys by querying the runtime
+// using the given method. The runtime is expected to accept a CBOR-serialized key pair identifier
+// and return the (optional) signed public key obtained from its key manager.
+func NewQueryKeySource(client api.RuntimeClient, method string) KeySource {
+	return &queryKeySource{
+		client: client,
+		method: method,
+	}
+}
+
+// KeyVerifier verifies signed public keys fetched for a given runtime.
+type KeyVerifier func(ctx context.Context, runtimeID common.Namespace, pk *SignedPublicKey) error
+
+// KeyManagerStatusBackend is the subset of the key manager backend needed for verifying keys.
+type KeyManagerStatusBackend interface {
+	// GetStatus returns a key manager status by key manager ID.
+	GetStatus(context.Context, *registry.NamespaceQuery) (*keymanager.Status, error)
+}
+
+// NewKeyManagerVerifier creates a new key verifier that accepts public keys signed by any of the
+// currently active nodes of the key manager used by the runtime.
+func NewKeyManagerVerifier(reg registry.Backend, km KeyManagerStatusBackend) KeyVerifier {
+	return func(ctx context.Context, runtimeID common.Namespace, pk *SignedPublicKey) error {
+		rt, err := reg.GetRuntime(ctx, &registry.NamespaceQuery{
+			ID:     runtimeID,
+			Height: consensus.HeightLatest,
+		})
+		if err != nil {
+			return fmt.Errorf("envelope: failed to fetch runtime descriptor: %w", err)
+		}
+		if rt.KeyManager == nil {
+			return fmt.Errorf("envelope: runtime does not use a key manager")
+		}
+
+		status, err := km.GetStatus(ctx, &registry.NamespaceQuery{
+			ID:     *rt.KeyManager,
+			Height: consensus.HeightLatest,
+		})
+		if err != nil {
+			return fmt.Errorf("envelope: failed to fetch key manager status: %w", err)
+		}
+
+		for _, nodeID := range status.Nodes {
+			n, nerr := reg.GetNode(ctx, &registry.IDQuery{
+				ID:     nodeID,
+				Height: consensus.HeightLatest,
+			})
+			if nerr != nil {
+				continue
+			}
+			nodeRt := n.GetRuntime(*rt.KeyManager)
+			if nodeRt == nil || nodeRt.Capabilities.TEE == nil {
+				continue
+			}
+			if pk.Verify(nodeRt.Capabilities.TEE.RAK) == nil {
+				return nil
+			}
+		}
+		return ErrInvalidSignature
+	}
+}
+
+type cacheKey struct {
+	runtimeID common.Namespace
+	keyPairID KeyPairID
+}
+
+type cacheEntry struct {
+	pk        *SignedPublicKey
+	fetchedAt time.Time
+}
+
+// Config is the envelope client configuration.
+type Config struct {
+	// Verifier is an optional verifier of fetched public keys. In case it is not set, fetched
+	// public keys are not verified.
+	Verifier KeyVerifier
+
+	// CacheTTL is the duration for which fetched public keys are cached. Zero disables caching.
+	CacheTTL time.Duration
+}
+
+// Client seals calls against key manager public keys, fetching and caching them as needed.
+type Client struct {
+	sync.Mutex
+
+	source KeySource
+	cfg    Config
+
+	cache map[cacheKey]*cacheEntry
+}
+
+// GetPublicKey returns the (possibly cached) public key of the given key pair of the given
+// runtime.
+func (c *Client) GetPublicKey(ctx context.Context, runtimeID common.Namespace, keyPairID KeyPairID) (*SignedPublicKey, error) {
+	key := cacheKey{runtimeID, keyPairID}
+
+	c.Lock()
+	entry, ok := c.cache[key]
+	c.Unlock()
+	if ok && time.Since(entry.fetchedAt) < c.cfg.CacheTTL {
+		return entry.pk, nil
+	}
+
+	pk, err := c.source.GetPublicKey(ctx, runtimeID, keyPairID)
+	if err != nil {
+		return nil, err
+	}
+	if c.cfg.Verifier != nil {
+		if err = c.cfg.Verifier(ctx, runtimeID, pk); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.cfg.CacheTTL > 0 {
+		c.Lock()
+		c.cache[key] = &cacheEntry{pk: pk, fetchedAt: time.Now()}
+		c.Unlock()
+	}
+	return pk, nil
+}
+
+// Seal encrypts the given call data using the public key of the given key pair of the given
+// runtime.
+//
+// The returned opener can be used to decrypt the corresponding result envelope.
+func (c *Client) Seal(ctx context.Context, runtimeID common.Namespace, keyPairID KeyPairID, plaintext []byte) (*CallEnvelope, *Opener, error) {
+	pk, err := c.GetPublicKey(ctx, runtimeID, keyPairID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return Seal(nil, pk.Key, plaintext)
+}
+
+// Purge removes all cached public keys.
+func (c *Client) Purge() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.cache = make(map[cacheKey]*cacheEntry)
+}
+
+// NewClient creates a new envelope client.
+func NewClient(source KeySource, cfg *Config) *Client {
+	return &Client{
+		source: source,
+		cfg:    *cfg,
+		cache:  make(map[cacheKey]*cacheEntry),
+	}
+}
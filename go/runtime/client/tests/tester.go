@@ -11,6 +11,8 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/mock"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
@@ -84,7 +86,13 @@ func testFailSubmitTransaction(
 	require.EqualValues(t, &protocol.Error{
 		Module: "mock",
 		Code:   1,
+		Details: protocol.NewErrorDetails(&protocol.InsufficientFeeDetails{
+			Required: *quantity.NewFromUint64(1),
+		}),
 	}, resp.CheckTxError, "SubmitTxMeta should fail check tx")
+	details, err := resp.CheckTxError.Details.Payload()
+	require.NoError(t, err, "CheckTxError details payload")
+	require.IsType(t, &protocol.InsufficientFeeDetails{}, details, "CheckTxError details payload type")
 
 	_, err = c.SubmitTx(ctx, &api.SubmitTxRequest{Data: mock.CheckTxFailInput, RuntimeID: runtimeID})
 	require.Error(t, err, "SubmitTx should fail check tx")
@@ -135,6 +143,14 @@ func testQuery(
 	require.NoError(t, err, "GetBlock(RoundLatest)")
 	require.EqualValues(t, expectedLatestRound, blkLatest.Header.Round)
 
+	// Fetch block by hash.
+	blkByHash, err := c.GetBlockByHash(ctx, &api.GetBlockByHashRequest{RuntimeID: runtimeID, BlockHash: blkLatest.Header.EncodedHash()})
+	require.NoError(t, err, "GetBlockByHash")
+	require.EqualValues(t, blkLatest, blkByHash, "GetBlockByHash should return the correct block")
+
+	_, err = c.GetBlockByHash(ctx, &api.GetBlockByHashRequest{RuntimeID: runtimeID, BlockHash: hash.NewFromBytes([]byte("unknown block"))})
+	require.Error(t, err, "GetBlockByHash should fail for unknown block")
+
 	// Out of bounds block round.
 	_, err = c.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: runtimeID, Round: expectedLatestRound + 1})
 	require.Error(t, err, "GetBlock")
@@ -224,12 +240,44 @@ func testQuery(
 	require.NoError(t, err, "cbor.Unmarshal(<QueryResponse.Data>)")
 	require.True(t, strings.HasPrefix(decResp4, "hello world"), "Query response at latest round should be correct")
 
+	// Queries with a minimum round that has already been reached should not block.
+	rsp, err = c.Query(ctx, &api.QueryRequest{
+		RuntimeID: runtimeID,
+		Round:     api.RoundLatest,
+		Method:    "hello",
+		MinRound:  blk.Header.Round,
+	})
+	require.NoError(t, err, "Query with reached minimum round")
+	var decResp5 string
+	err = cbor.Unmarshal(rsp.Data, &decResp5)
+	require.NoError(t, err, "cbor.Unmarshal(<QueryResponse.Data>)")
+	require.True(t, strings.HasPrefix(decResp5, "hello world"), "Query response with reached minimum round should be correct")
+
+	// Queries with a minimum round that is not reached should block.
+	waitCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_, err = c.Query(waitCtx, &api.QueryRequest{
+		RuntimeID: runtimeID,
+		Round:     api.RoundLatest,
+		Method:    "hello",
+		MinRound:  blk.Header.Round + 1000,
+	})
+	require.Error(t, err, "Query with unreached minimum round should fail")
+
 	// Execute CheckTx using the mock runtime host.
 	err = c.CheckTx(ctx, &api.CheckTxRequest{
 		RuntimeID: runtimeID,
 		Data:      []byte("test checktx request"),
 	})
 	require.NoError(t, err, "CheckTx")
+
+	// Execute CheckTxMeta using the mock runtime host.
+	checkResult, err := c.CheckTxMeta(ctx, &api.CheckTxRequest{
+		RuntimeID: runtimeID,
+		Data:      []byte("test checktx request"),
+	})
+	require.NoError(t, err, "CheckTxMeta")
+	require.True(t, checkResult.IsSuccess(), "CheckTxMeta result should indicate success")
 }
 
 func testSubmitTransactionNoWait(
@@ -243,12 +291,32 @@ func testSubmitTransactionNoWait(
 	testInput := []byte(input)
 
 	// Query current block.
-	_, err := c.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: runtimeID, Round: api.RoundLatest})
+	blk, err := c.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: runtimeID, Round: api.RoundLatest})
 	require.NoError(t, err, "GetBlock(RoundLatest)")
 
+	// Watch block headers so we can observe the transaction being included.
+	ch, sub, err := c.WatchBlockHeaders(ctx, runtimeID)
+	require.NoError(t, err, "WatchBlockHeaders")
+	defer sub.Close()
+
 	// Submit a test transaction.
 	err = c.SubmitTxNoWait(ctx, &api.SubmitTxRequest{Data: testInput, RuntimeID: runtimeID})
 
 	// Check if everything is in order.
 	require.NoError(t, err, "SubmitTxNoWait")
+
+	for {
+		select {
+		case hdr := <-ch:
+			require.NotNil(t, hdr, "WatchBlockHeaders channel should not be closed")
+			if hdr.Header.Round <= blk.Header.Round {
+				continue
+			}
+			// The mock runtime does not emit any block-level tags.
+			require.Empty(t, hdr.Tags, "block header should not include any tags")
+			return
+		case <-ctx.Done():
+			t.Fatalf("failed to receive block header: %s", ctx.Err())
+		}
+	}
 }
@@ -9,12 +9,13 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 )
 
-const dbVersion = 1
+const dbVersion = 2
 
 var (
 	// metadataKeyFmt is the metadata key format.
@@ -29,6 +30,10 @@ var (
 	//
 	// Value is CBOR-serialized roothash.RoundResults.
 	roundResultsKeyFmt = keyformat.New(0x03, uint64(0))
+	// blockHashKeyFmt is the block hash index key format.
+	//
+	// Value is the CBOR-serialized round of the block with the given header hash.
+	blockHashKeyFmt = keyformat.New(0x04, &hash.Hash{})
 )
 
 type dbMetadata struct {
@@ -75,6 +80,10 @@ func newDB(fn string, runtimeID common.Namespace) (*DB, error) {
 		d.close()
 		return nil, err
 	}
+	if err = d.migrate(); err != nil {
+		d.close()
+		return nil, err
+	}
 
 	return d, nil
 }
@@ -111,8 +120,8 @@ func (d *DB) ensureMetadata(runtimeID common.Namespace) error {
 			return err
 		}
 
-		// Verify metadata section.
-		if meta.Version != dbVersion {
+		// Verify metadata section. Version 1 databases are migrated after opening.
+		if meta.Version != dbVersion && meta.Version != 1 {
 			return fmt.Errorf("runtime/history: unsupported database version (expected: %d got: %d)",
 				dbVersion,
 				meta.Version,
@@ -129,6 +138,67 @@ func (d *DB) ensureMetadata(runtimeID common.Namespace) error {
 	})
 }
 
+// migrate migrates older databases to the current database version.
+func (d *DB) migrate() error {
+	meta, err := d.metadata()
+	if err != nil {
+		return err
+	}
+	if meta.Version != 1 {
+		return nil
+	}
+
+	d.logger.Info("migrating database, populating block hash index")
+
+	// Populate the block hash index in batches as the history may be large.
+	wb := d.db.NewWriteBatch()
+	defer wb.Cancel()
+
+	var count int
+	err = d.db.View(func(tx *badger.Txn) error {
+		it := tx.NewIterator(badger.IteratorOptions{
+			Prefix:         blockKeyFmt.Encode(),
+			PrefetchValues: true,
+		})
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var blk roothash.AnnotatedBlock
+			if err := it.Item().Value(func(val []byte) error {
+				return cbor.UnmarshalTrusted(val, &blk)
+			}); err != nil {
+				return err
+			}
+
+			blkHash := blk.Block.Header.EncodedHash()
+			if err := wb.Set(blockHashKeyFmt.Encode(&blkHash), cbor.Marshal(blk.Block.Header.Round)); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("runtime/history: failed to migrate database: %w", err)
+	}
+	if err = wb.Flush(); err != nil {
+		return fmt.Errorf("runtime/history: failed to migrate database: %w", err)
+	}
+
+	err = d.db.Update(func(tx *badger.Txn) error {
+		meta.Version = dbVersion
+		return tx.Set(metadataKeyFmt.Encode(), cbor.Marshal(meta))
+	})
+	if err != nil {
+		return fmt.Errorf("runtime/history: failed to migrate database: %w", err)
+	}
+
+	d.logger.Info("database migrated",
+		"indexed_blocks", count,
+	)
+	return nil
+}
+
 func (d *DB) metadata() (*dbMetadata, error) {
 	var meta *dbMetadata
 	err := d.db.View(func(tx *badger.Txn) error {
@@ -199,6 +269,11 @@ func (d *DB) commit(blk *roothash.AnnotatedBlock, roundResults *roothash.RoundRe
 			return err
 		}
 
+		blkHash := blk.Block.Header.EncodedHash()
+		if err = tx.Set(blockHashKeyFmt.Encode(&blkHash), cbor.Marshal(blk.Block.Header.Round)); err != nil {
+			return err
+		}
+
 		meta.LastRound = blk.Block.Header.Round
 		if blk.Height > meta.LastConsensusHeight {
 			meta.LastConsensusHeight = blk.Height
@@ -230,6 +305,28 @@ func (d *DB) getBlock(round uint64) (*roothash.AnnotatedBlock, error) {
 	return &blk, nil
 }
 
+func (d *DB) getBlockRoundByHash(blockHash hash.Hash) (uint64, error) {
+	var round uint64
+	txErr := d.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(blockHashKeyFmt.Encode(&blockHash))
+		switch err {
+		case nil:
+		case badger.ErrKeyNotFound:
+			return roothash.ErrNotFound
+		default:
+			return err
+		}
+
+		return item.Value(func(val []byte) error {
+			return cbor.UnmarshalTrusted(val, &round)
+		})
+	})
+	if txErr != nil {
+		return 0, txErr
+	}
+	return round, nil
+}
+
 func (d *DB) getEarliestBlock() (*roothash.AnnotatedBlock, error) {
 	var blk roothash.AnnotatedBlock
 	txErr := d.db.View(func(tx *badger.Txn) error {
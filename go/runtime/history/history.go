@@ -10,6 +10,7 @@ import (
 	"github.com/eapache/channels"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
@@ -45,6 +46,9 @@ func NewDefaultConfig() *Config {
 type History interface {
 	roothash.BlockHistory
 
+	// GetBlockByHash returns the block with the given header hash.
+	GetBlockByHash(ctx context.Context, blockHash hash.Hash) (*block.Block, error)
+
 	// Pruner returns the history pruner.
 	Pruner() Pruner
 
@@ -84,6 +88,10 @@ func (h *nopHistory) GetEarliestBlock(ctx context.Context) (*block.Block, error)
 	return nil, errNopHistory
 }
 
+func (h *nopHistory) GetBlockByHash(ctx context.Context, blockHash hash.Hash) (*block.Block, error) {
+	return nil, errNopHistory
+}
+
 func (h *nopHistory) GetRoundResults(ctx context.Context, round uint64) (*roothash.RoundResults, error) {
 	return nil, errNopHistory
 }
@@ -191,6 +199,17 @@ func (h *runtimeHistory) GetEarliestBlock(ctx context.Context) (*block.Block, er
 	return annBlk.Block, nil
 }
 
+func (h *runtimeHistory) GetBlockByHash(ctx context.Context, blockHash hash.Hash) (*block.Block, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	round, err := h.db.getBlockRoundByHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return h.GetBlock(ctx, round)
+}
+
 func (h *runtimeHistory) GetRoundResults(ctx context.Context, round uint64) (*roothash.RoundResults, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
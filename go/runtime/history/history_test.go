@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 )
@@ -110,6 +111,14 @@ func TestHistory(t *testing.T) {
 	require.NoError(err, "GetRoundResults")
 	require.Equal(roundResults, gotResults, "GetRoundResults should return the correct results")
 
+	gotBlk, err = history.GetBlockByHash(context.Background(), putBlk.Header.EncodedHash())
+	require.NoError(err, "GetBlockByHash")
+	require.Equal(&putBlk, gotBlk, "GetBlockByHash should return the correct block")
+
+	_, err = history.GetBlockByHash(context.Background(), hash.NewFromBytes([]byte("unknown block")))
+	require.Error(err, "GetBlockByHash should fail for non-indexed block")
+	require.Equal(roothash.ErrNotFound, err)
+
 	// Close history and try to reopen and continue.
 	history.Close()
 
@@ -243,12 +252,15 @@ func TestHistoryPrune(t *testing.T) {
 
 	// Ensure we can only lookup the last 10 blocks.
 	for i := 0; i <= 50; i++ {
-		_, err = history.GetBlock(context.Background(), uint64(i))
+		blk, err := history.GetBlock(context.Background(), uint64(i))
 		if i <= 40 {
 			require.Error(err, "GetBlock should fail for pruned block %d", i)
 			require.Equal(roothash.ErrNotFound, err)
 		} else {
 			require.NoError(err, "GetBlock(%d)", i)
+
+			_, err = history.GetBlockByHash(context.Background(), blk.Header.EncodedHash())
+			require.NoError(err, "GetBlockByHash(%d)", i)
 		}
 
 		roundResults, err := history.GetRoundResults(context.Background(), uint64(i))
@@ -315,3 +327,63 @@ func TestHistoryPruneError(t *testing.T) {
 		require.NoError(err, "GetBlock(%d)", i)
 	}
 }
+
+func TestHistoryPruneUntil(t *testing.T) {
+	require := require.New(t)
+
+	// Create a new random temporary directory under /tmp.
+	dataDir, err := ioutil.TempDir("", "oasis-runtime-history-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("history prune until test ns"), 0)
+
+	history, err := New(dataDir, runtimeID, &Config{
+		Pruner:        NewNonePruner(),
+		PruneInterval: 100 * time.Millisecond,
+	})
+	require.NoError(err, "New")
+	defer history.Close()
+
+	ph := testPruneHandler{
+		doneCh:     make(chan struct{}),
+		waitRounds: 200,
+	}
+	history.Pruner().RegisterHandler(&ph)
+
+	// Create some blocks.
+	for i := 0; i <= 200; i++ {
+		blk := roothash.AnnotatedBlock{
+			Height: int64(i),
+			Block:  block.NewGenesisBlock(runtimeID, 0),
+		}
+		blk.Block.Header.Round = uint64(i)
+
+		err = history.Commit(&blk, nil)
+		require.NoError(err, "Commit")
+	}
+
+	ctx := context.Background()
+	var progress []uint64
+	err = history.Pruner().PruneUntil(ctx, 150, func(round uint64) {
+		progress = append(progress, round)
+	})
+	require.NoError(err, "PruneUntil")
+	require.EqualValues([]uint64{63, 127, 149}, progress, "progress should be reported for each batch")
+	require.Len(ph.prunedRounds, 150)
+
+	blk, err := history.GetEarliestBlock(ctx)
+	require.NoError(err, "GetEarliestBlock")
+	require.EqualValues(150, blk.Header.Round, "all rounds before 150 should be pruned")
+
+	// The latest round should never be pruned.
+	err = history.Pruner().PruneUntil(ctx, 1000, nil)
+	require.NoError(err, "PruneUntil")
+	blk, err = history.GetEarliestBlock(ctx)
+	require.NoError(err, "GetEarliestBlock")
+	require.EqualValues(200, blk.Header.Round, "latest round should be retained")
+
+	// Pruning on demand is not supported without history.
+	err = NewNop(runtimeID).Pruner().PruneUntil(ctx, 10, nil)
+	require.Error(err, "PruneUntil should fail for no-op history")
+}
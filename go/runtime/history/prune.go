@@ -7,7 +7,9 @@ import (
 
 	"github.com/dgraph-io/badger/v3"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 )
 
 const (
@@ -42,6 +44,13 @@ type Pruner interface {
 	// Prune purges unneeded history, given the latest round.
 	Prune(ctx context.Context, latestRound uint64) error
 
+	// PruneUntil purges all history below the given round, regardless of
+	// the configured pruning strategy. The latest round is never pruned.
+	//
+	// The optional progress callback is called after each pruned batch
+	// with the last pruned round.
+	PruneUntil(ctx context.Context, round uint64, progressFn func(round uint64)) error
+
 	// RegisterHandler registers a prune handler.
 	RegisterHandler(handler PruneHandler)
 }
@@ -49,6 +58,12 @@ type Pruner interface {
 type prunerBase struct {
 	sync.RWMutex
 
+	// pruneLock serializes automatic and on-demand pruning.
+	pruneLock sync.Mutex
+
+	logger *logging.Logger
+	db     *DB
+
 	handlers []PruneHandler
 }
 
@@ -59,54 +74,66 @@ func (p *prunerBase) RegisterHandler(handler PruneHandler) {
 	p.handlers = append(p.handlers, handler)
 }
 
-func newPrunerBase() prunerBase {
-	return prunerBase{}
-}
-
-type nonePruner struct{}
-
-func (p *nonePruner) RegisterHandler(handler PruneHandler) {
-}
-
-func (p *nonePruner) Prune(ctx context.Context, latestRound uint64) error {
-	return nil
-}
+func (p *prunerBase) PruneUntil(ctx context.Context, round uint64, progressFn func(round uint64)) error {
+	if p.db == nil {
+		return errNopHistory
+	}
 
-// NewNonePruner creates a new pruner that never prunes anything.
-func NewNonePruner() PrunerFactory {
-	return func(db *DB) (Pruner, error) {
-		return &nonePruner{}, nil
+	meta, err := p.db.metadata()
+	if err != nil {
+		return err
+	}
+	if round > meta.LastRound {
+		round = meta.LastRound
+	}
+	if round == 0 {
+		return nil
 	}
-}
 
-type keepLastPruner struct {
-	prunerBase
+	p.pruneLock.Lock()
+	defer p.pruneLock.Unlock()
 
-	logger *logging.Logger
-	db     *DB
+	p.logger.Info("pruning history on demand",
+		"last_round", meta.LastRound,
+		"preserve_from", round,
+	)
 
-	numKept uint64
-}
+	for {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
 
-func (p *keepLastPruner) Prune(ctx context.Context, latestRound uint64) error {
-	if latestRound < p.numKept {
-		return nil
+		var pruned []uint64
+		if pruned, err = p.pruneBatch(ctx, round-1); err != nil {
+			return err
+		}
+		if len(pruned) == 0 {
+			return nil
+		}
+		if progressFn != nil {
+			progressFn(pruned[len(pruned)-1])
+		}
 	}
+}
 
-	p.prunerBase.RLock()
-	defer p.prunerBase.RUnlock()
-
-	lastPrunedRound := latestRound - p.numKept
-
-	return p.db.db.Update(func(tx *badger.Txn) error {
-		// NOTE: Do not prefetch values as we are only looking at keys.
+// pruneBatch prunes a single batch of rounds up to and including the given
+// round and returns the pruned rounds.
+//
+// Guarded by p.pruneLock.
+func (p *prunerBase) pruneBatch(ctx context.Context, lastPrunedRound uint64) ([]uint64, error) {
+	p.RLock()
+	defer p.RUnlock()
+
+	var pruned []uint64
+	err := p.db.db.Update(func(tx *badger.Txn) error {
+		// NOTE: Do not prefetch values as they are only needed to determine block hashes.
 		it := tx.NewIterator(badger.IteratorOptions{
 			Prefix: blockKeyFmt.Encode(),
 		})
 		defer it.Close()
 
 		// Start with the smallest round and proceed forward.
-		var pruned []uint64
+		pruned = nil
 		for it.Rewind(); it.Valid() && len(pruned) < maxBatchSize; it.Next() {
 			item := it.Item()
 
@@ -120,7 +147,14 @@ func (p *keepLastPruner) Prune(ctx context.Context, latestRound uint64) error {
 				break
 			}
 
-			if err := tx.Delete(roundResultsKeyFmt.Encode(round)); err != nil {
+			var blk roothash.AnnotatedBlock
+			if err := item.Value(func(val []byte) error {
+				return cbor.UnmarshalTrusted(val, &blk)
+			}); err != nil {
+				return err
+			}
+			blkHash := blk.Block.Header.EncodedHash()
+			if err := tx.Delete(blockHashKeyFmt.Encode(&blkHash)); err != nil {
 				if err == badger.ErrTxnTooBig {
 					// We can't prune any more rounds in this transaction.
 					break
@@ -128,6 +162,10 @@ func (p *keepLastPruner) Prune(ctx context.Context, latestRound uint64) error {
 				return err
 			}
 
+			if err := tx.Delete(roundResultsKeyFmt.Encode(round)); err != nil {
+				return err
+			}
+
 			if err := tx.Delete(item.KeyCopy(nil)); err != nil {
 				return err
 			}
@@ -142,7 +180,7 @@ func (p *keepLastPruner) Prune(ctx context.Context, latestRound uint64) error {
 
 		// Before pruning anything, run all prune handlers. If any of them
 		// fails we abort the prune.
-		for _, ph := range p.prunerBase.handlers {
+		for _, ph := range p.handlers {
 			if err := ph.Prune(ctx, pruned); err != nil {
 				p.logger.Error("prune handler failed, aborting prune",
 					"err", err,
@@ -156,6 +194,54 @@ func (p *keepLastPruner) Prune(ctx context.Context, latestRound uint64) error {
 
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return pruned, nil
+}
+
+func newPrunerBase(logger *logging.Logger, db *DB) prunerBase {
+	return prunerBase{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// nonePruner is a pruner that never prunes automatically, but still supports
+// pruning on demand.
+type nonePruner struct {
+	prunerBase
+}
+
+func (p *nonePruner) Prune(ctx context.Context, latestRound uint64) error {
+	return nil
+}
+
+// NewNonePruner creates a new pruner that never prunes anything automatically.
+func NewNonePruner() PrunerFactory {
+	return func(db *DB) (Pruner, error) {
+		return &nonePruner{
+			prunerBase: newPrunerBase(logging.GetLogger("history/prune/none"), db),
+		}, nil
+	}
+}
+
+type keepLastPruner struct {
+	prunerBase
+
+	numKept uint64
+}
+
+func (p *keepLastPruner) Prune(ctx context.Context, latestRound uint64) error {
+	if latestRound < p.numKept {
+		return nil
+	}
+
+	p.pruneLock.Lock()
+	defer p.pruneLock.Unlock()
+
+	_, err := p.pruneBatch(ctx, latestRound-p.numKept)
+	return err
 }
 
 // NewKeepLastPruner creates a pruner that keeps the last configured
@@ -163,9 +249,7 @@ func (p *keepLastPruner) Prune(ctx context.Context, latestRound uint64) error {
 func NewKeepLastPruner(numKept uint64) PrunerFactory {
 	return func(db *DB) (Pruner, error) {
 		return &keepLastPruner{
-			prunerBase: newPrunerBase(),
-			logger:     logging.GetLogger("history/prune/keep_last"),
-			db:         db,
+			prunerBase: newPrunerBase(logging.GetLogger("history/prune/keep_last"), db),
 			numKept:    numKept,
 		}, nil
 	}
@@ -0,0 +1,58 @@
+package host
+
+import (
+	"path/filepath"
+
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+)
+
+// RuntimeBundle is a runtime bundle that has been exploded on disk.
+type RuntimeBundle struct {
+	// Manifest is the runtime bundle manifest.
+	Manifest *bundle.Manifest
+
+	// Path is the path to the directory that the runtime bundle was exploded into.
+	Path string
+
+	// MrEnclave is the MRENCLAVE of the bundled SGX enclave (if any).
+	MrEnclave *sgx.MrEnclave
+
+	// MrSigner is the MRSIGNER of the bundled SGX enclave in case an enclave signature is
+	// available.
+	MrSigner *sgx.MrSigner
+}
+
+// ExplodedPath returns the path to the given exploded runtime bundle file.
+func (b *RuntimeBundle) ExplodedPath(name string) string {
+	return filepath.Join(b.Path, name)
+}
+
+// NewRuntimeBundle explodes the given runtime bundle into the given base directory.
+//
+// The detached SGX enclave signature is only used in case the bundle contains an SGX enclave
+// but no enclave signature and may be nil.
+func NewRuntimeBundle(bnd *bundle.Bundle, baseDir string, detachedSig []byte) (*RuntimeBundle, error) {
+	path, err := bnd.WriteExploded(baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	rb := &RuntimeBundle{
+		Manifest: bnd.Manifest,
+		Path:     path,
+	}
+	if bnd.Manifest.IsSGX() {
+		if rb.MrEnclave, err = bnd.MrEnclave(); err != nil {
+			return nil, err
+		}
+		if bnd.Manifest.SGX.Signature != "" || detachedSig != nil {
+			var id *sgx.EnclaveIdentity
+			if id, err = bnd.EnclaveIdentity(detachedSig); err != nil {
+				return nil, err
+			}
+			rb.MrSigner = &id.MrSigner
+		}
+	}
+	return rb, nil
+}
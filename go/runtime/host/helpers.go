@@ -36,6 +36,17 @@ type RichRuntime interface {
 		batch transaction.RawBatch,
 	) ([]protocol.CheckTxResult, error)
 
+	// CheckTxSingle requests the runtime to check a single transaction. Unlike CheckTx this uses a
+	// dedicated request so that interactive checks are not bundled with large batch checks.
+	CheckTxSingle(
+		ctx context.Context,
+		rb *block.Block,
+		lb *consensus.LightBlock,
+		epoch beacon.EpochTime,
+		maxMessages uint32,
+		tx []byte,
+	) (*protocol.CheckTxResult, error)
+
 	// Query requests the runtime to answer a runtime-specific query.
 	Query(
 		ctx context.Context,
@@ -93,6 +104,37 @@ func (r *richRuntime) CheckTx(
 	return resp.RuntimeCheckTxBatchResponse.Results, nil
 }
 
+// Implements RichRuntime.
+func (r *richRuntime) CheckTxSingle(
+	ctx context.Context,
+	rb *block.Block,
+	lb *consensus.LightBlock,
+	epoch beacon.EpochTime,
+	maxMessages uint32,
+	tx []byte,
+) (*protocol.CheckTxResult, error) {
+	if rb == nil || lb == nil {
+		return nil, ErrInvalidArgument
+	}
+
+	resp, err := r.Call(ctx, &protocol.Body{
+		RuntimeCheckTxRequest: &protocol.RuntimeCheckTxRequest{
+			ConsensusBlock: *lb,
+			Input:          tx,
+			Block:          *rb,
+			Epoch:          epoch,
+			MaxMessages:    maxMessages,
+		},
+	})
+	switch {
+	case err != nil:
+		return nil, errors.WithContext(ErrInternal, err.Error())
+	case resp.RuntimeCheckTxResponse == nil:
+		return nil, errors.WithContext(ErrInternal, "malformed runtime response")
+	}
+	return &resp.RuntimeCheckTxResponse.Result, nil
+}
+
 // Implements RichRuntime.
 func (r *richRuntime) Query(
 	ctx context.Context,
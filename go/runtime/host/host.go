@@ -21,6 +21,10 @@ type Config struct {
 	// used provisioner.
 	Path string
 
+	// Bundle is the optional runtime bundle. In case it is set, provisioners use the runtime
+	// bundle's executables instead of Path.
+	Bundle *RuntimeBundle
+
 	// Extra is an optional provisioner-specific configuration.
 	Extra interface{}
 
@@ -5,12 +5,15 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
@@ -18,24 +21,147 @@ import (
 	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 )
 
-type provisioner struct{}
-
 // CheckTxFailInput is the input that will cause a CheckTx failure in the mock runtime.
 var CheckTxFailInput = []byte("checktx-mock-fail")
 
+// ErrAborted is the error returned by calls that were interrupted by an abort request.
+var ErrAborted = fmt.Errorf("(mock) aborted")
+
+// Protocol methods that can be programmed. Runtime protocol methods are identified by the name of
+// the request field in the protocol body (see protocol.Body.Type).
+const (
+	MethodExecuteTxBatch = "RuntimeExecuteTxBatchRequest"
+	MethodCheckTxBatch   = "RuntimeCheckTxBatchRequest"
+	MethodCheckTx        = "RuntimeCheckTxRequest"
+	MethodQuery          = "RuntimeQueryRequest"
+	MethodConsensusSync  = "RuntimeConsensusSyncRequest"
+	MethodRPCCall        = "RuntimeRPCCallRequest"
+	MethodLocalRPCCall   = "RuntimeLocalRPCCallRequest"
+	MethodPing           = "RuntimePingRequest"
+	MethodAbortRequest   = "RuntimeAbortRequest"
+
+	// MethodStart is the pseudo-method used to program host.Runtime.Start.
+	MethodStart = "Start"
+	// MethodAbort is the pseudo-method used to program host.Runtime.Abort.
+	MethodAbort = "Abort"
+)
+
+// HandlerFunc is a function that handles a runtime protocol request.
+type HandlerFunc func(ctx context.Context, body *protocol.Body) (*protocol.Body, error)
+
+// Behavior is the programmed behavior of the mock runtime for a single method.
+type Behavior struct {
+	// Latency is the latency injected before handling a call. The latency is interrupted in case
+	// the call context is canceled or the runtime is aborted.
+	Latency time.Duration
+
+	// Error is the error returned instead of a response.
+	Error error
+
+	// Response is the canned response returned instead of the default response.
+	Response *protocol.Body
+
+	// Handler is the function used to handle the call instead of the default handler. If both
+	// Response and Handler are set, Response takes precedence.
+	Handler HandlerFunc
+
+	// Times is the number of calls the behavior applies to. After the behavior has been used the
+	// given number of times, the next programmed behavior (or the default one) is used. Zero
+	// means that the behavior applies to all subsequent calls.
+	Times int
+}
+
+// Provisioner is a mock runtime provisioner with programmable behavior.
+//
+// The programmed behaviors and the call log are shared among all runtimes provisioned by the same
+// provisioner.
+type Provisioner struct {
+	sync.Mutex
+
+	behaviors map[string][]*Behavior
+	calls     map[string][]*protocol.Body
+}
+
+// Program appends behaviors for the given method. Behaviors are used in the order in which they
+// were programmed.
+func (p *Provisioner) Program(method string, behaviors ...Behavior) *Provisioner {
+	p.Lock()
+	defer p.Unlock()
+
+	for i := range behaviors {
+		b := behaviors[i]
+		p.behaviors[method] = append(p.behaviors[method], &b)
+	}
+	return p
+}
+
+// Reset removes all programmed behaviors and clears the call log.
+func (p *Provisioner) Reset() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.behaviors = make(map[string][]*Behavior)
+	p.calls = make(map[string][]*protocol.Body)
+}
+
+// Calls returns the number of calls of the given method.
+func (p *Provisioner) Calls(method string) int {
+	p.Lock()
+	defer p.Unlock()
+
+	return len(p.calls[method])
+}
+
+// Requests returns the request bodies of all calls of the given method.
+func (p *Provisioner) Requests(method string) []*protocol.Body {
+	p.Lock()
+	defer p.Unlock()
+
+	return append([]*protocol.Body{}, p.calls[method]...)
+}
+
+// record records a call of the given method and returns the behavior to use for it (if any).
+func (p *Provisioner) record(method string, body *protocol.Body) *Behavior {
+	p.Lock()
+	defer p.Unlock()
+
+	p.calls[method] = append(p.calls[method], body)
+
+	bs := p.behaviors[method]
+	if len(bs) == 0 {
+		return nil
+	}
+	b := bs[0]
+	if b.Times > 0 {
+		b.Times--
+		if b.Times == 0 {
+			p.behaviors[method] = bs[1:]
+		}
+	}
+	return b
+}
+
 // Implements host.Provisioner.
-func (p *provisioner) NewRuntime(ctx context.Context, cfg host.Config) (host.Runtime, error) {
+func (p *Provisioner) NewRuntime(ctx context.Context, cfg host.Config) (host.Runtime, error) {
 	r := &runtime{
-		runtimeID: cfg.RuntimeID,
-		notifier:  pubsub.NewBroker(false),
+		runtimeID:   cfg.RuntimeID,
+		provisioner: p,
+		notifier:    pubsub.NewBroker(false),
+		abortCh:     make(chan struct{}),
+		partialCh:   make(chan struct{}),
 	}
 	return r, nil
 }
 
 type runtime struct {
-	runtimeID common.Namespace
+	sync.Mutex
 
-	notifier *pubsub.Broker
+	runtimeID   common.Namespace
+	provisioner *Provisioner
+
+	notifier  *pubsub.Broker
+	abortCh   chan struct{}
+	partialCh chan struct{}
 }
 
 // Implements host.Runtime.
@@ -43,12 +169,77 @@ func (r *runtime) ID() common.Namespace {
 	return r.runtimeID
 }
 
+// apply records a call of the given method and applies the programmed behavior, returning true
+// iff the call has been handled by it.
+//
+// Any injected latency is also interrupted when a partial abort request is received on the given
+// channel, in which case the call is handled as usual.
+func (r *runtime) apply(ctx context.Context, method string, body *protocol.Body, partialCh <-chan struct{}) (bool, *protocol.Body, error) {
+	// Make sure that any aborts after the call has been recorded interrupt it.
+	r.Lock()
+	abortCh := r.abortCh
+	r.Unlock()
+
+	b := r.provisioner.record(method, body)
+	if b == nil {
+		return false, nil, nil
+	}
+
+	if b.Latency > 0 {
+		select {
+		case <-time.After(b.Latency):
+		case <-abortCh:
+			return true, nil, ErrAborted
+		case <-partialCh:
+		case <-ctx.Done():
+			return true, nil, ctx.Err()
+		}
+	}
+
+	switch {
+	case b.Error != nil:
+		return true, nil, b.Error
+	case b.Response != nil:
+		return true, b.Response, nil
+	case b.Handler != nil:
+		rsp, err := b.Handler(ctx, body)
+		return true, rsp, err
+	default:
+		return false, nil, nil
+	}
+}
+
 // Implements host.Runtime.
 func (r *runtime) Call(ctx context.Context, body *protocol.Body) (*protocol.Body, error) {
+	// Make sure that any partial aborts after the call has been recorded interrupt it.
+	r.Lock()
+	partialCh := r.partialCh
+	r.Unlock()
+
+	if handled, rsp, err := r.apply(ctx, body.Type(), body, partialCh); handled {
+		return rsp, err
+	}
+
+	var partial bool
+	select {
+	case <-partialCh:
+		partial = true
+	default:
+	}
+	return r.handleDefault(ctx, body, partial)
+}
+
+func (r *runtime) handleDefault(ctx context.Context, body *protocol.Body, partial bool) (*protocol.Body, error) {
 	switch {
 	case body.RuntimeExecuteTxBatchRequest != nil:
 		rq := body.RuntimeExecuteTxBatchRequest
 
+		// In case of a partial abort, only the first half of the batch is executed.
+		inputs := rq.Inputs
+		if partial {
+			inputs = inputs[:len(inputs)/2]
+		}
+
 		tags := transaction.Tags{
 			transaction.Tag{Key: []byte("txn_foo"), Value: []byte("txn_bar")},
 		}
@@ -63,10 +254,10 @@ func (r *runtime) Call(ctx context.Context, body *protocol.Body) (*protocol.Body
 		tree := transaction.NewTree(nil, emptyRoot)
 		defer tree.Close()
 
-		for i := 0; i < len(rq.Inputs); i++ {
+		for i := 0; i < len(inputs); i++ {
 			err := tree.AddTransaction(ctx, transaction.Transaction{
-				Input:  rq.Inputs[0],
-				Output: rq.Inputs[0],
+				Input:  inputs[0],
+				Output: inputs[0],
 			}, tags)
 			if err != nil {
 				return nil, fmt.Errorf("(mock) failed to create I/O tree: %w", err)
@@ -92,6 +283,8 @@ func (r *runtime) Call(ctx context.Context, body *protocol.Body) (*protocol.Body
 				},
 				IOWriteLog: ioWriteLog,
 			},
+			Partial:        partial,
+			ExecutedInputs: uint64(len(inputs)),
 			// No RakSig in mock response.
 		}}, nil
 	case body.RuntimeCheckTxBatchRequest != nil:
@@ -99,26 +292,28 @@ func (r *runtime) Call(ctx context.Context, body *protocol.Body) (*protocol.Body
 
 		var results []protocol.CheckTxResult
 		for _, input := range rq.Inputs {
-			switch {
-			case bytes.Equal(input, CheckTxFailInput):
-				results = append(results, protocol.CheckTxResult{
-					Error: protocol.Error{
-						Module: "mock",
-						Code:   1,
-					},
-				})
-			default:
-				results = append(results, protocol.CheckTxResult{
-					Error: protocol.Error{
-						Code: errors.CodeNoError,
-					},
-				})
-			}
+			results = append(results, checkTx(input))
 		}
 
 		return &protocol.Body{RuntimeCheckTxBatchResponse: &protocol.RuntimeCheckTxBatchResponse{
 			Results: results,
 		}}, nil
+	case body.RuntimeCheckTxRequest != nil:
+		return &protocol.Body{RuntimeCheckTxResponse: &protocol.RuntimeCheckTxResponse{
+			Result: checkTx(body.RuntimeCheckTxRequest.Input),
+		}}, nil
+	case body.RuntimePingRequest != nil:
+		return &protocol.Body{Empty: &protocol.Empty{}}, nil
+	case body.RuntimeAbortRequest != nil:
+		if body.RuntimeAbortRequest.Partial {
+			// Interrupt any in-flight calls, making them return partial results.
+			r.Lock()
+			close(r.partialCh)
+			r.partialCh = make(chan struct{})
+			r.Unlock()
+		}
+
+		return &protocol.Body{RuntimeAbortResponse: &protocol.Empty{}}, nil
 	case body.RuntimeQueryRequest != nil:
 		rq := body.RuntimeQueryRequest
 
@@ -138,6 +333,25 @@ func (r *runtime) Call(ctx context.Context, body *protocol.Body) (*protocol.Body
 	}
 }
 
+func checkTx(input []byte) protocol.CheckTxResult {
+	if bytes.Equal(input, CheckTxFailInput) {
+		return protocol.CheckTxResult{
+			Error: protocol.Error{
+				Module: "mock",
+				Code:   1,
+				Details: protocol.NewErrorDetails(&protocol.InsufficientFeeDetails{
+					Required: *quantity.NewFromUint64(1),
+				}),
+			},
+		}
+	}
+	return protocol.CheckTxResult{
+		Error: protocol.Error{
+			Code: errors.CodeNoError,
+		},
+	}
+}
+
 // Implements host.Runtime.
 func (r *runtime) WatchEvents(ctx context.Context) (<-chan *host.Event, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *host.Event)
@@ -149,6 +363,13 @@ func (r *runtime) WatchEvents(ctx context.Context) (<-chan *host.Event, pubsub.C
 
 // Implements host.Runtime.
 func (r *runtime) Start() error {
+	if _, _, err := r.apply(context.Background(), MethodStart, nil, nil); err != nil {
+		r.notifier.Broadcast(&host.Event{
+			FailedToStart: &host.FailedToStartEvent{Error: err},
+		})
+		return nil
+	}
+
 	r.notifier.Broadcast(&host.Event{
 		Started: &host.StartedEvent{},
 	})
@@ -157,6 +378,25 @@ func (r *runtime) Start() error {
 
 // Implements host.Runtime.
 func (r *runtime) Abort(ctx context.Context, force bool) error {
+	if _, _, err := r.apply(ctx, MethodAbort, nil, nil); err != nil {
+		return err
+	}
+
+	// Interrupt any in-flight calls.
+	r.Lock()
+	close(r.abortCh)
+	r.abortCh = make(chan struct{})
+	r.Unlock()
+
+	if force {
+		// Simulate a runtime restart.
+		r.notifier.Broadcast(&host.Event{
+			Stopped: &host.StoppedEvent{},
+		})
+		r.notifier.Broadcast(&host.Event{
+			Started: &host.StartedEvent{},
+		})
+	}
 	return nil
 }
 
@@ -169,5 +409,12 @@ func (r *runtime) Stop() {
 
 // New creates a new mock runtime provisioner useful for tests.
 func New() host.Provisioner {
-	return &provisioner{}
+	return NewProgrammable()
+}
+
+// NewProgrammable creates a new mock runtime provisioner whose behavior can be programmed.
+func NewProgrammable() *Provisioner {
+	p := &Provisioner{}
+	p.Reset()
+	return p
 }
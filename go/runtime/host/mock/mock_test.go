@@ -0,0 +1,89 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+func TestProgrammableProvisioner(t *testing.T) {
+	require := require.New(t)
+
+	p := NewProgrammable()
+	rt, err := p.NewRuntime(context.Background(), host.Config{})
+	require.NoError(err, "NewRuntime")
+
+	ctx := context.Background()
+	query := &protocol.Body{RuntimeQueryRequest: &protocol.RuntimeQueryRequest{Method: "hello"}}
+
+	// Default behavior.
+	rsp, err := rt.Call(ctx, query)
+	require.NoError(err, "Call")
+	require.NotNil(rsp.RuntimeQueryResponse)
+
+	// Programmed behaviors are used in order.
+	testErr := fmt.Errorf("test error")
+	canned := &protocol.Body{RuntimeQueryResponse: &protocol.RuntimeQueryResponse{Data: []byte("canned")}}
+	p.Program(MethodQuery,
+		Behavior{Error: testErr, Times: 2},
+		Behavior{Response: canned, Times: 1},
+	)
+	for i := 0; i < 2; i++ {
+		_, err = rt.Call(ctx, query)
+		require.ErrorIs(err, testErr, "Call should return the programmed error")
+	}
+	rsp, err = rt.Call(ctx, query)
+	require.NoError(err, "Call")
+	require.Equal(canned, rsp, "Call should return the canned response")
+	rsp, err = rt.Call(ctx, query)
+	require.NoError(err, "Call")
+	require.NotEqual(canned, rsp, "Call should fall back to the default behavior")
+	require.Equal(5, p.Calls(MethodQuery))
+	require.Len(p.Requests(MethodQuery), 5)
+
+	// Injected latency should be interrupted by aborts.
+	p.Program(MethodQuery, Behavior{Latency: time.Hour, Times: 1})
+	errCh := make(chan error, 1)
+	go func() {
+		_, cerr := rt.Call(ctx, query)
+		errCh <- cerr
+	}()
+	require.Eventually(func() bool { return p.Calls(MethodQuery) == 6 }, time.Second, 10*time.Millisecond)
+	require.NoError(rt.Abort(ctx, false), "Abort")
+	require.ErrorIs(<-errCh, ErrAborted, "Call should be interrupted by abort")
+
+	// Partial aborts should make in-flight batches return partial results.
+	p.Program(MethodExecuteTxBatch, Behavior{Latency: time.Hour, Times: 1})
+	rspCh := make(chan *protocol.Body, 1)
+	go func() {
+		crsp, cerr := rt.Call(ctx, &protocol.Body{RuntimeExecuteTxBatchRequest: &protocol.RuntimeExecuteTxBatchRequest{
+			Inputs: [][]byte{[]byte("tx1"), []byte("tx2"), []byte("tx3"), []byte("tx4")},
+		}})
+		errCh <- cerr
+		rspCh <- crsp
+	}()
+	require.Eventually(func() bool { return p.Calls(MethodExecuteTxBatch) == 1 }, time.Second, 10*time.Millisecond)
+	rsp, err = rt.Call(ctx, &protocol.Body{RuntimeAbortRequest: &protocol.RuntimeAbortRequest{Partial: true}})
+	require.NoError(err, "Call(RuntimeAbortRequest)")
+	require.NotNil(rsp.RuntimeAbortResponse)
+	require.NoError(<-errCh, "Call(RuntimeExecuteTxBatchRequest)")
+	rsp = <-rspCh
+	require.NotNil(rsp.RuntimeExecuteTxBatchResponse)
+	require.True(rsp.RuntimeExecuteTxBatchResponse.Partial, "batch should be partial")
+	require.EqualValues(2, rsp.RuntimeExecuteTxBatchResponse.ExecutedInputs)
+
+	// Aborts can be programmed to fail.
+	p.Program(MethodAbort, Behavior{Error: testErr, Times: 1})
+	require.ErrorIs(rt.Abort(ctx, false), testErr, "Abort should return the programmed error")
+	require.NoError(rt.Abort(ctx, false), "Abort")
+
+	// Reset should clear everything.
+	p.Reset()
+	require.Equal(0, p.Calls(MethodQuery))
+}
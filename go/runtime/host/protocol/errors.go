@@ -0,0 +1,112 @@
+package protocol
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+const (
+	// ErrorDetailsKindInsufficientFee is the kind of the InsufficientFeeDetails payload.
+	ErrorDetailsKindInsufficientFee = "insufficient_fee"
+	// ErrorDetailsKindAlreadyExecuted is the kind of the AlreadyExecutedDetails payload.
+	ErrorDetailsKindAlreadyExecuted = "already_executed"
+)
+
+var registeredErrorDetails sync.Map
+
+// ErrorDetails are structured details of a runtime error.
+//
+// Error details kinds that are not registered via RegisterErrorDetails are module-specific and
+// their data can be decoded by the caller using DecodeData.
+type ErrorDetails struct {
+	// Kind is the kind of the error details payload.
+	Kind string `json:"kind"`
+	// Data is the CBOR-encoded error details payload.
+	Data cbor.RawMessage `json:"data,omitempty"`
+}
+
+// ErrorDetailsPayload is a typed error details payload.
+type ErrorDetailsPayload interface {
+	// ErrorDetailsKind returns the kind of the error details payload.
+	ErrorDetailsKind() string
+}
+
+// DecodeData decodes the error details payload into the given destination.
+func (d *ErrorDetails) DecodeData(dst interface{}) error {
+	return cbor.Unmarshal(d.Data, dst)
+}
+
+// Payload decodes the error details into the registered well-known payload type for its kind.
+func (d *ErrorDetails) Payload() (ErrorDetailsPayload, error) {
+	ty, ok := registeredErrorDetails.Load(d.Kind)
+	if !ok {
+		return nil, fmt.Errorf("runtime/host/protocol: unknown error details kind: %s", d.Kind)
+	}
+
+	p := reflect.New(ty.(reflect.Type))
+	if err := d.DecodeData(p.Interface()); err != nil {
+		return nil, fmt.Errorf("runtime/host/protocol: malformed %s error details: %w", d.Kind, err)
+	}
+	return p.Interface().(ErrorDetailsPayload), nil
+}
+
+// NewErrorDetails creates new error details from the given typed payload.
+func NewErrorDetails(p ErrorDetailsPayload) *ErrorDetails {
+	return &ErrorDetails{
+		Kind: p.ErrorDetailsKind(),
+		Data: cbor.Marshal(p),
+	}
+}
+
+// RegisterErrorDetails registers a well-known error details payload type. The payload must be
+// a pointer to a structure.
+//
+// Kinds must be unique. If they are not, this method will panic.
+func RegisterErrorDetails(p ErrorDetailsPayload) {
+	ty := reflect.TypeOf(p)
+	if ty.Kind() != reflect.Ptr {
+		panic(fmt.Sprintf("runtime/host/protocol: error details payload must be a pointer: %s", ty))
+	}
+
+	kind := p.ErrorDetailsKind()
+	if _, isRegistered := registeredErrorDetails.LoadOrStore(kind, ty.Elem()); isRegistered {
+		panic(fmt.Sprintf("runtime/host/protocol: error details kind already registered: %s", kind))
+	}
+}
+
+// InsufficientFeeDetails are the error details of a transaction that was rejected due to an
+// insufficient fee.
+type InsufficientFeeDetails struct {
+	// Required is the minimum fee amount required for the transaction to be accepted.
+	Required quantity.Quantity `json:"required"`
+	// Denomination is the denomination of the required fee (empty for the native denomination).
+	Denomination string `json:"denomination,omitempty"`
+}
+
+// ErrorDetailsKind returns the kind of the error details payload.
+func (d *InsufficientFeeDetails) ErrorDetailsKind() string {
+	return ErrorDetailsKindInsufficientFee
+}
+
+// AlreadyExecutedDetails are the error details of a transaction that was rejected because it
+// has already been executed in a recent runtime block.
+type AlreadyExecutedDetails struct {
+	// Round is the runtime round in which the transaction has been executed.
+	Round uint64 `json:"round"`
+	// Index is the index of the transaction in the executed batch.
+	Index uint32 `json:"index"`
+}
+
+// ErrorDetailsKind returns the kind of the error details payload.
+func (d *AlreadyExecutedDetails) ErrorDetailsKind() string {
+	return ErrorDetailsKindAlreadyExecuted
+}
+
+func init() {
+	RegisterErrorDetails(&InsufficientFeeDetails{})
+	RegisterErrorDetails(&AlreadyExecutedDetails{})
+}
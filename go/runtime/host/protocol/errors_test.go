@@ -0,0 +1,61 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+func TestErrorDetails(t *testing.T) {
+	require := require.New(t)
+
+	// Well-known payloads.
+	fee := &InsufficientFeeDetails{
+		Required:     *quantity.NewFromUint64(1000),
+		Denomination: "TEST",
+	}
+	rtErr := Error{
+		Module:  "test",
+		Code:    1,
+		Message: "insufficient fee",
+		Details: NewErrorDetails(fee),
+	}
+
+	var dec Error
+	err := cbor.Unmarshal(cbor.Marshal(rtErr), &dec)
+	require.NoError(err, "Unmarshal")
+	require.EqualValues(rtErr, dec, "error details should survive serialization")
+
+	p, err := dec.Details.Payload()
+	require.NoError(err, "Payload")
+	require.EqualValues(fee, p, "decoded payload should be of the registered type")
+
+	// Module-specific payloads.
+	type moduleDetails struct {
+		Nonce uint64 `json:"nonce"`
+	}
+	rtErr.Details = &ErrorDetails{
+		Kind: "test.invalid_nonce",
+		Data: cbor.Marshal(&moduleDetails{Nonce: 42}),
+	}
+	_, err = rtErr.Details.Payload()
+	require.Error(err, "Payload should fail for unregistered kinds")
+
+	var md moduleDetails
+	err = rtErr.Details.DecodeData(&md)
+	require.NoError(err, "DecodeData")
+	require.EqualValues(42, md.Nonce)
+
+	// Errors without details should serialize as before.
+	rtErr.Details = nil
+	require.EqualValues(cbor.Marshal(map[string]interface{}{
+		"module":  "test",
+		"code":    uint32(1),
+		"message": "insufficient fee",
+	}), cbor.Marshal(rtErr))
+
+	require.Panics(func() { RegisterErrorDetails(&InsufficientFeeDetails{}) }, "duplicate kinds should panic")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	consensusTx "github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
@@ -81,9 +82,11 @@ type Body struct {
 	RuntimeLocalRPCCallResponse           *RuntimeLocalRPCCallResponse           `json:",omitempty"`
 	RuntimeCheckTxBatchRequest            *RuntimeCheckTxBatchRequest            `json:",omitempty"`
 	RuntimeCheckTxBatchResponse           *RuntimeCheckTxBatchResponse           `json:",omitempty"`
+	RuntimeCheckTxRequest                 *RuntimeCheckTxRequest                 `json:",omitempty"`
+	RuntimeCheckTxResponse                *RuntimeCheckTxResponse                `json:",omitempty"`
 	RuntimeExecuteTxBatchRequest          *RuntimeExecuteTxBatchRequest          `json:",omitempty"`
 	RuntimeExecuteTxBatchResponse         *RuntimeExecuteTxBatchResponse         `json:",omitempty"`
-	RuntimeAbortRequest                   *Empty                                 `json:",omitempty"`
+	RuntimeAbortRequest                   *RuntimeAbortRequest                   `json:",omitempty"`
 	RuntimeAbortResponse                  *Empty                                 `json:",omitempty"`
 	RuntimeKeyManagerPolicyUpdateRequest  *RuntimeKeyManagerPolicyUpdateRequest  `json:",omitempty"`
 	RuntimeKeyManagerPolicyUpdateResponse *Empty                                 `json:",omitempty"`
@@ -93,16 +96,20 @@ type Body struct {
 	RuntimeConsensusSyncResponse          *Empty                                 `json:",omitempty"`
 
 	// Host interface.
-	HostRPCCallRequest              *HostRPCCallRequest              `json:",omitempty"`
-	HostRPCCallResponse             *HostRPCCallResponse             `json:",omitempty"`
-	HostStorageSyncRequest          *HostStorageSyncRequest          `json:",omitempty"`
-	HostStorageSyncResponse         *HostStorageSyncResponse         `json:",omitempty"`
-	HostLocalStorageGetRequest      *HostLocalStorageGetRequest      `json:",omitempty"`
-	HostLocalStorageGetResponse     *HostLocalStorageGetResponse     `json:",omitempty"`
-	HostLocalStorageSetRequest      *HostLocalStorageSetRequest      `json:",omitempty"`
-	HostLocalStorageSetResponse     *Empty                           `json:",omitempty"`
-	HostFetchConsensusBlockRequest  *HostFetchConsensusBlockRequest  `json:",omitempty"`
-	HostFetchConsensusBlockResponse *HostFetchConsensusBlockResponse `json:",omitempty"`
+	HostRPCCallRequest               *HostRPCCallRequest              `json:",omitempty"`
+	HostRPCCallResponse              *HostRPCCallResponse             `json:",omitempty"`
+	HostStorageSyncRequest           *HostStorageSyncRequest          `json:",omitempty"`
+	HostStorageSyncResponse          *HostStorageSyncResponse         `json:",omitempty"`
+	HostLocalStorageGetRequest       *HostLocalStorageGetRequest      `json:",omitempty"`
+	HostLocalStorageGetResponse      *HostLocalStorageGetResponse     `json:",omitempty"`
+	HostLocalStorageSetRequest       *HostLocalStorageSetRequest      `json:",omitempty"`
+	HostLocalStorageSetResponse      *Empty                           `json:",omitempty"`
+	HostLocalStorageBatchSetRequest  *HostLocalStorageBatchSetRequest `json:",omitempty"`
+	HostLocalStorageBatchSetResponse *Empty                           `json:",omitempty"`
+	HostFetchConsensusBlockRequest   *HostFetchConsensusBlockRequest  `json:",omitempty"`
+	HostFetchConsensusBlockResponse  *HostFetchConsensusBlockResponse `json:",omitempty"`
+	HostProveFreshnessRequest        *HostProveFreshnessRequest       `json:",omitempty"`
+	HostProveFreshnessResponse       *HostProveFreshnessResponse      `json:",omitempty"`
 }
 
 // Type returns the message type by determining the name of the first non-nil member.
@@ -124,10 +131,16 @@ type Error struct {
 	Module  string `json:"module,omitempty"`
 	Code    uint32 `json:"code,omitempty"`
 	Message string `json:"message,omitempty"`
+
+	// Details are optional structured error details.
+	Details *ErrorDetails `json:"details,omitempty"`
 }
 
 // String returns a string representation of this runtime error.
 func (e Error) String() string {
+	if e.Details != nil {
+		return fmt.Sprintf("runtime error: module: %s code: %d message: %s details: %s", e.Module, e.Code, e.Message, e.Details.Kind)
+	}
 	return fmt.Sprintf("runtime error: module: %s code: %d message: %s", e.Module, e.Code, e.Message)
 }
 
@@ -261,6 +274,30 @@ type RuntimeCheckTxBatchResponse struct {
 	Results []CheckTxResult `json:"results"`
 }
 
+// RuntimeCheckTxRequest is a worker single transaction check request message body.
+type RuntimeCheckTxRequest struct {
+	// ConsensusBlock is the consensus light block at the last finalized round
+	// height (e.g., corresponding to .Block.Header.Round).
+	ConsensusBlock consensus.LightBlock `json:"consensus_block"`
+
+	// Input is the runtime input to check.
+	Input []byte `json:"input"`
+	// Block on which the check should be based.
+	Block block.Block `json:"block"`
+	// Epoch is the current epoch number.
+	Epoch beacon.EpochTime `json:"epoch"`
+
+	// MaxMessages is the maximum number of messages that can be emitted in this
+	// round. Any more messages will be rejected by the consensus layer.
+	MaxMessages uint32 `json:"max_messages"`
+}
+
+// RuntimeCheckTxResponse is a worker single transaction check response message body.
+type RuntimeCheckTxResponse struct {
+	// Result is the CheckTx result for the transaction passed on input.
+	Result CheckTxResult `json:"result"`
+}
+
 // ComputedBatch is a computed batch.
 type ComputedBatch struct {
 	// Header is the compute results header.
@@ -309,6 +346,21 @@ type RuntimeExecuteTxBatchRequest struct {
 type RuntimeExecuteTxBatchResponse struct {
 	Batch             ComputedBatch                 `json:"batch"`
 	BatchWeightLimits map[transaction.Weight]uint64 `json:"batch_weight_limits"`
+
+	// Partial is true iff the runtime stopped executing the batch early due to a partial abort
+	// request and the computed batch only contains the first ExecutedInputs inputs. Partial
+	// batches are only used to determine the batch cut and must never be committed.
+	Partial bool `json:"partial,omitempty"`
+	// ExecutedInputs is the number of inputs executed in a partial batch.
+	ExecutedInputs uint64 `json:"executed_inputs,omitempty"`
+}
+
+// RuntimeAbortRequest is a runtime abort request message body.
+type RuntimeAbortRequest struct {
+	// Partial requests the runtime to stop executing the in-flight batch and to respond to the
+	// pending execute request with the results of the already executed transactions instead of
+	// discarding all work.
+	Partial bool `json:"partial,omitempty"`
 }
 
 // RuntimeKeyManagerPolicyUpdateRequest is a runtime key manager policy request
@@ -398,6 +450,14 @@ type HostLocalStorageSetRequest struct {
 	Value []byte `json:"value"`
 }
 
+// HostLocalStorageBatchSetRequest is a host local storage batch set request message body.
+//
+// All entries are set atomically, either all or none of the keys are updated. Batches that are too
+// large to be written in a single local storage transaction are rejected.
+type HostLocalStorageBatchSetRequest struct {
+	Entries []HostLocalStorageSetRequest `json:"entries"`
+}
+
 // HostFetchConsensusBlockRequest is a request to host to fetch the given consensus light block.
 type HostFetchConsensusBlockRequest struct {
 	Height uint64 `json:"height"`
@@ -407,3 +467,16 @@ type HostFetchConsensusBlockRequest struct {
 type HostFetchConsensusBlockResponse struct {
 	Block consensus.LightBlock `json:"block"`
 }
+
+// HostProveFreshnessRequest is a request to host to prove state freshness.
+type HostProveFreshnessRequest struct {
+	Blob [32]byte `json:"blob"`
+}
+
+// HostProveFreshnessResponse is a response from host proving state freshness.
+type HostProveFreshnessResponse struct {
+	// SignedTx is the signed prove freshness transaction that was submitted to consensus.
+	SignedTx *consensusTx.SignedTransaction `json:"signed_tx"`
+	// Proof is the proof of the transaction's inclusion in a consensus block.
+	Proof *consensusTx.Proof `json:"proof"`
+}
@@ -57,7 +57,16 @@ func (b *fdPipeBuilder) close() {
 }
 
 // NewBubbleWrap creates a Bubblewrap-based sandbox.
+//
+// The sandboxed process runs in new namespaces (including user, mount and an empty network
+// namespace) with all capabilities dropped and a SECCOMP policy generated from the configured
+// profile.
 func NewBubbleWrap(cfg Config) (Process, error) {
+	seccompProfile, err := cfg.Seccomp.Effective()
+	if err != nil {
+		return nil, fmt.Errorf("sandbox: bad SECCOMP profile: %w", err)
+	}
+
 	var fdPipes fdPipeBuilder
 	// Make sure the sandbox starts in the given time.
 	fdPipes.deadline = time.Now().Add(sandboxStartTimeout)
@@ -97,6 +106,10 @@ func NewBubbleWrap(cfg Config) (Process, error) {
 		// Entrypoint binary.
 		"--ro-bind", cfg.Path, sandboxMountBinary,
 	}
+	if cfg.RequireUserNamespace {
+		// Fail in case a user namespace cannot be created.
+		fdArgs = append(fdArgs, "--unshare-user")
+	}
 	for key, value := range cfg.Env {
 		fdArgs = append(fdArgs, "--setenv", key, value)
 	}
@@ -198,7 +211,7 @@ func NewBubbleWrap(cfg Config) (Process, error) {
 	}
 
 	// Prepare and send SECCOMP policy.
-	if err = generateSeccompPolicy(seccompPipe); err != nil {
+	if err = generateSeccompPolicy(seccompPipe, seccompProfile); err != nil {
 		return nil, fmt.Errorf("sandbox: error while generating seccomp policy: %w", err)
 	}
 	if err = seccompPipe.Close(); err != nil {
@@ -42,6 +42,14 @@ type Config struct {
 	// SandboxBinaryPath is the path to the sandbox support binary.
 	SandboxBinaryPath string
 
+	// Seccomp is the SECCOMP profile applied on top of the default template. If not specified,
+	// the default template is used.
+	Seccomp *SeccompProfile
+
+	// RequireUserNamespace makes the sandbox fail to start in case a new user namespace cannot be
+	// created instead of silently continuing without one.
+	RequireUserNamespace bool
+
 	extraFiles []*os.File
 }
 
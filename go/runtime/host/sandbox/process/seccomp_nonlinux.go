@@ -8,6 +8,6 @@ import (
 	"os"
 )
 
-func generateSeccompPolicy(out *os.File) error {
+func generateSeccompPolicy(out *os.File, profile *EffectiveSeccompProfile) error {
 	return errors.New("generateSeccompPolicy only implemented for Linux")
 }
@@ -0,0 +1,49 @@
+package process
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeccompProfileEffective(t *testing.T) {
+	require := require.New(t)
+
+	// A nil profile should result in the default template.
+	var p *SeccompProfile
+	eff, err := p.Effective()
+	require.NoError(err, "Effective")
+	require.True(eff.AllowClone, "clone should be allowed by default")
+	require.Len(eff.Allow, len(syscallAllArgsWhitelist))
+	require.True(sort.StringsAreSorted(eff.Allow), "allowed syscalls should be sorted")
+
+	// Denying and allowing syscalls.
+	p = &SeccompProfile{
+		Allow: []string{"acct"},
+		Deny:  []string{"ptrace_does_not_exist"},
+	}
+	_, err = p.Effective()
+	require.Error(err, "Effective should fail when denying syscalls not in the template")
+
+	p = &SeccompProfile{
+		Allow:     []string{"acct"},
+		Deny:      []string{"socket", "connect"},
+		DenyClone: true,
+	}
+	eff, err = p.Effective()
+	require.NoError(err, "Effective")
+	require.False(eff.AllowClone, "clone should be denied")
+	require.Len(eff.Allow, len(syscallAllArgsWhitelist)-1)
+	require.Contains(eff.Allow, "acct")
+	require.NotContains(eff.Allow, "socket")
+	require.NotContains(eff.Allow, "connect")
+	require.True(sort.StringsAreSorted(eff.Allow), "allowed syscalls should be sorted")
+
+	// Clone must not be allowed with any arguments.
+	p = &SeccompProfile{
+		Allow: []string{syscallClone},
+	}
+	_, err = p.Effective()
+	require.Error(err, "Effective should fail when allowing clone with any arguments")
+}
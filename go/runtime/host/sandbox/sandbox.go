@@ -56,6 +56,16 @@ type Config struct {
 
 	// InsecureNoSandbox disables the sandbox and runs the runtime binary directly.
 	InsecureNoSandbox bool
+
+	// SeccompProfiles are per-runtime SECCOMP profiles applied on top of the default template.
+	SeccompProfiles map[common.Namespace]*process.SeccompProfile
+
+	// RequireUserNamespace makes the sandbox fail to start in case a new user namespace cannot be
+	// created.
+	RequireUserNamespace bool
+
+	// DebugPrintSeccompProfile logs the effective SECCOMP profile when starting a runtime.
+	DebugPrintSeccompProfile bool
 }
 
 type provisioner struct {
@@ -238,6 +248,18 @@ func (r *sandboxedRuntime) startProcess() (err error) {
 			cfg.BindRW = make(map[string]string)
 		}
 		cfg.BindRW[hostSocket] = bindHostSocketPath
+		cfg.Seccomp = r.cfg.SeccompProfiles[r.rtCfg.RuntimeID]
+		cfg.RequireUserNamespace = r.cfg.RequireUserNamespace
+
+		if r.cfg.DebugPrintSeccompProfile {
+			profile, pErr := cfg.Seccomp.Effective()
+			if pErr != nil {
+				return fmt.Errorf("bad SECCOMP profile: %w", pErr)
+			}
+			r.logger.Info("effective SECCOMP profile",
+				"profile", profile,
+			)
+		}
 
 		p, err = process.NewBubbleWrap(cfg)
 		if err != nil {
@@ -354,7 +376,7 @@ func (r *sandboxedRuntime) handleAbortRequest(rq *abortRequest) error {
 	ctx, cancel := context.WithTimeout(context.Background(), runtimeInterruptTimeout)
 	defer cancel()
 
-	response, err := r.conn.Call(ctx, &protocol.Body{RuntimeAbortRequest: &protocol.Empty{}})
+	response, err := r.conn.Call(ctx, &protocol.Body{RuntimeAbortRequest: &protocol.RuntimeAbortRequest{}})
 	if err == nil && response.RuntimeAbortResponse != nil && !rq.force {
 		// Successful response, and no force restart required.
 		return nil
@@ -506,8 +528,16 @@ func New(cfg Config) (host.Provisioner, error) {
 	// Use a default GetSandboxConfig if none was provided.
 	if cfg.GetSandboxConfig == nil {
 		cfg.GetSandboxConfig = func(hostCfg host.Config, socketPath, runtimeDir string) (process.Config, error) {
+			path := hostCfg.Path
+			if bnd := hostCfg.Bundle; bnd != nil {
+				if bnd.Manifest.Executable == "" {
+					return process.Config{}, fmt.Errorf("runtime bundle does not contain an ELF executable")
+				}
+				path = bnd.ExplodedPath(bnd.Manifest.Executable)
+			}
+
 			return process.Config{
-				Path: hostCfg.Path,
+				Path: path,
 				Env: map[string]string{
 					"OASIS_WORKER_HOST": socketPath,
 				},
@@ -66,6 +66,16 @@ type Config struct {
 
 	// InsecureNoSandbox disables the sandbox and runs the loader directly.
 	InsecureNoSandbox bool
+
+	// SeccompProfiles are per-runtime SECCOMP profiles applied on top of the default template.
+	SeccompProfiles map[common.Namespace]*process.SeccompProfile
+
+	// RequireUserNamespace makes the sandbox fail to start in case a new user namespace cannot be
+	// created.
+	RequireUserNamespace bool
+
+	// DebugPrintSeccompProfile logs the effective SECCOMP profile when starting a runtime.
+	DebugPrintSeccompProfile bool
 }
 
 // RuntimeExtra is the extra configuration for SGX runtimes.
@@ -106,7 +116,24 @@ func (s *sgxProvisioner) loadEnclaveBinaries(rtCfg host.Config) ([]byte, []byte,
 		err         error
 	)
 
-	if sgxs, err = ioutil.ReadFile(rtCfg.Path); err != nil {
+	rtExtra, ok := rtCfg.Extra.(*RuntimeExtra)
+	if !ok {
+		return nil, nil, fmt.Errorf("sgx enclave configuration not available")
+	}
+
+	// Prefer the enclave (and its signature) from the runtime bundle, if any.
+	sgxsPath, sigPath := rtCfg.Path, rtExtra.SignaturePath
+	if bnd := rtCfg.Bundle; bnd != nil {
+		if !bnd.Manifest.IsSGX() {
+			return nil, nil, fmt.Errorf("runtime bundle does not contain an SGX enclave")
+		}
+		sgxsPath = bnd.ExplodedPath(bnd.Manifest.SGX.Executable)
+		if bnd.Manifest.SGX.Signature != "" {
+			sigPath = bnd.ExplodedPath(bnd.Manifest.SGX.Signature)
+		}
+	}
+
+	if sgxs, err = ioutil.ReadFile(sgxsPath); err != nil {
 		return nil, nil, fmt.Errorf("failed to load enclave: %w", err)
 	}
 	if err = enclaveHash.FromSgxsBytes(sgxs); err != nil {
@@ -114,13 +141,8 @@ func (s *sgxProvisioner) loadEnclaveBinaries(rtCfg host.Config) ([]byte, []byte,
 	}
 
 	// If the path to an existing SIGSTRUCT is provided, load it.
-	rtExtra, ok := rtCfg.Extra.(*RuntimeExtra)
-	if !ok {
-		return nil, nil, fmt.Errorf("sgx enclave configuration not available")
-	}
-
-	if rtExtra.SignaturePath != "" {
-		sig, err = ioutil.ReadFile(rtExtra.SignaturePath)
+	if sigPath != "" {
+		sig, err = ioutil.ReadFile(sigPath)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to load SIGSTRUCT: %w", err)
 		}
@@ -399,6 +421,10 @@ func New(cfg Config) (host.Provisioner, error) {
 		HostInitializer:   s.hostInitializer,
 		InsecureNoSandbox: cfg.InsecureNoSandbox,
 		Logger:            s.logger,
+
+		SeccompProfiles:          cfg.SeccompProfiles,
+		RequireUserNamespace:     cfg.RequireUserNamespace,
+		DebugPrintSeccompProfile: cfg.DebugPrintSeccompProfile,
 	})
 	if err != nil {
 		return nil, err
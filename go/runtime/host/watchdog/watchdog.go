@@ -0,0 +1,370 @@
+// Package watchdog implements a runtime provisioner that monitors the health of runtimes
+// provisioned by another provisioner and restarts unresponsive ones.
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+const (
+	// restartBudgetWindow is the window over which the restart budget is computed.
+	restartBudgetWindow = time.Hour
+	// restartTimeout is the maximum time to wait for a runtime restart request to complete.
+	restartTimeout = 30 * time.Second
+	// maxHistoryLength is the maximum number of restart records kept in the restart history.
+	maxHistoryLength = 32
+)
+
+// Restart reasons.
+const (
+	// ReasonUnresponsive is the reason for restarts triggered by the watchdog after the runtime
+	// failed to respond to a ping request.
+	ReasonUnresponsive = "unresponsive"
+	// ReasonTerminated is the reason for restarts that happened after the runtime terminated
+	// without being restarted by the watchdog (e.g., because it crashed).
+	ReasonTerminated = "terminated"
+)
+
+// Config contains the watchdog provisioner configuration options.
+type Config struct {
+	// PingInterval is the interval at which hosted runtimes are pinged.
+	PingInterval time.Duration
+
+	// PingTimeout is the maximum time to wait for a runtime to respond to a ping request. In case
+	// it is not specified, PingInterval is used.
+	PingTimeout time.Duration
+
+	// MaxRestartsPerHour is the maximum number of restarts the watchdog may trigger within any
+	// one hour window. Zero means that the number of restarts is not limited.
+	MaxRestartsPerHour int
+
+	// Logger is an optional logger to use with this provisioner. In case it is not specified a
+	// default logger will be created.
+	Logger *logging.Logger
+}
+
+// Restart is a record of a runtime restart.
+type Restart struct {
+	// Time is the time of the restart.
+	Time time.Time `json:"time"`
+	// Reason is the reason for the restart.
+	Reason string `json:"reason"`
+	// Error is the error that caused the restart, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Status is the watchdog status of a hosted runtime.
+type Status struct {
+	// LastPing is the time of the last successful ping.
+	LastPing time.Time `json:"last_ping"`
+	// RestartsLastHour is the number of restarts triggered by the watchdog within the last hour.
+	RestartsLastHour int `json:"restarts_last_hour"`
+	// BudgetExhausted is true iff the watchdog has refrained from restarting an unresponsive
+	// runtime as the restart budget has been exhausted.
+	BudgetExhausted bool `json:"budget_exhausted"`
+	// History are the most recent runtime restarts, oldest first.
+	History []Restart `json:"history"`
+}
+
+// StatusProvider is the interface implemented by runtimes monitored by the watchdog.
+type StatusProvider interface {
+	// WatchdogStatus returns the watchdog status of the runtime.
+	WatchdogStatus() *Status
+}
+
+type provisioner struct {
+	cfg   Config
+	inner host.Provisioner
+}
+
+// Implements host.Provisioner.
+func (p *provisioner) NewRuntime(ctx context.Context, cfg host.Config) (host.Runtime, error) {
+	rt, err := p.inner.NewRuntime(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &watchdogRuntime{
+		Runtime: rt,
+		cfg:     p.cfg,
+		stopCh:  make(chan struct{}),
+		logger:  p.cfg.Logger.With("runtime_id", cfg.RuntimeID),
+	}
+	return r, nil
+}
+
+type watchdogRuntime struct {
+	host.Runtime
+
+	sync.Mutex
+
+	cfg Config
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stopCh    chan struct{}
+
+	lastPing        time.Time
+	restarts        []time.Time
+	budgetExhausted bool
+	history         []Restart
+
+	logger *logging.Logger
+}
+
+// Implements host.Runtime.
+func (r *watchdogRuntime) Start() (err error) {
+	r.startOnce.Do(func() {
+		// Subscribe before starting the runtime so that no events are missed.
+		var (
+			evCh <-chan *host.Event
+			sub  pubsub.ClosableSubscription
+		)
+		evCh, sub, err = r.Runtime.WatchEvents(context.Background())
+		if err != nil {
+			err = fmt.Errorf("failed to watch runtime events: %w", err)
+			return
+		}
+		go r.watchdog(evCh, sub)
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.Runtime.Start()
+}
+
+// Implements host.Runtime.
+func (r *watchdogRuntime) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stopCh)
+	})
+	r.Runtime.Stop()
+}
+
+// Implements StatusProvider.
+func (r *watchdogRuntime) WatchdogStatus() *Status {
+	r.Lock()
+	defer r.Unlock()
+
+	r.pruneRestartsLocked(time.Now())
+
+	return &Status{
+		LastPing:         r.lastPing,
+		RestartsLastHour: len(r.restarts),
+		BudgetExhausted:  r.budgetExhausted,
+		History:          append([]Restart{}, r.history...),
+	}
+}
+
+func (r *watchdogRuntime) pruneRestartsLocked(now time.Time) {
+	var i int
+	for i < len(r.restarts) && now.Sub(r.restarts[i]) >= restartBudgetWindow {
+		i++
+	}
+	r.restarts = r.restarts[i:]
+}
+
+func (r *watchdogRuntime) recordRestart(reason string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	now := time.Now()
+	rec := Restart{
+		Time:   now,
+		Reason: reason,
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	r.history = append(r.history, rec)
+	if len(r.history) > maxHistoryLength {
+		r.history = r.history[len(r.history)-maxHistoryLength:]
+	}
+
+	if reason == ReasonUnresponsive {
+		r.restarts = append(r.restarts, now)
+	}
+}
+
+func (r *watchdogRuntime) ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.PingTimeout)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-r.stopCh:
+			cancel()
+		}
+	}()
+
+	rsp, err := r.Runtime.Call(ctx, &protocol.Body{RuntimePingRequest: &protocol.Empty{}})
+	if err != nil {
+		return err
+	}
+	if rsp.Empty == nil {
+		return fmt.Errorf("malformed runtime response to ping request")
+	}
+
+	r.Lock()
+	r.lastPing = time.Now()
+	r.Unlock()
+
+	return nil
+}
+
+// tryRestart restarts the runtime unless the restart budget has been exhausted. It returns whether
+// the restart has been attempted and whether it has succeeded.
+func (r *watchdogRuntime) tryRestart(pingErr error) (bool, bool) {
+	r.Lock()
+	r.pruneRestartsLocked(time.Now())
+	exhausted := r.cfg.MaxRestartsPerHour > 0 && len(r.restarts) >= r.cfg.MaxRestartsPerHour
+	wasExhausted := r.budgetExhausted
+	r.budgetExhausted = exhausted
+	r.Unlock()
+
+	if exhausted {
+		if !wasExhausted {
+			r.logger.Error("runtime is unresponsive but the restart budget has been exhausted",
+				"err", pingErr,
+				"max_restarts_per_hour", r.cfg.MaxRestartsPerHour,
+			)
+		}
+		return false, false
+	}
+
+	r.logger.Warn("restarting unresponsive runtime",
+		"err", pingErr,
+	)
+	r.recordRestart(ReasonUnresponsive, pingErr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), restartTimeout)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-r.stopCh:
+			cancel()
+		}
+	}()
+
+	if err := r.Runtime.Abort(ctx, true); err != nil {
+		r.logger.Error("failed to restart runtime",
+			"err", err,
+		)
+		return true, false
+	}
+	return true, true
+}
+
+func (r *watchdogRuntime) watchdog(evCh <-chan *host.Event, sub pubsub.ClosableSubscription) {
+	defer sub.Close()
+
+	ticker := time.NewTicker(r.cfg.PingInterval)
+	defer ticker.Stop()
+
+	boff := cmnBackoff.NewExponentialBackOff()
+	boff.InitialInterval = r.cfg.PingInterval
+	boff.MaxInterval = restartBudgetWindow / 4
+	boff.Reset()
+
+	var (
+		running        bool
+		pendingStops   int
+		notBefore      time.Time
+		restartPending bool
+	)
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case ev := <-evCh:
+			switch {
+			case ev.Started != nil:
+				running = true
+			case ev.Stopped != nil:
+				running = false
+				if pendingStops > 0 {
+					// Stop caused by a restart triggered by the watchdog.
+					pendingStops--
+					continue
+				}
+
+				r.logger.Warn("runtime terminated unexpectedly")
+				r.recordRestart(ReasonTerminated, nil)
+			}
+		case <-ticker.C:
+			if !running {
+				continue
+			}
+
+			err := r.ping()
+			if err == nil {
+				if restartPending {
+					// The runtime is responsive again after a restart.
+					restartPending = false
+					boff.Reset()
+				}
+				continue
+			}
+
+			r.logger.Warn("runtime failed to respond to ping request",
+				"err", err,
+			)
+
+			if now := time.Now(); now.Before(notBefore) {
+				continue
+			}
+			attempted, ok := r.tryRestart(err)
+			if !attempted {
+				continue
+			}
+			if ok {
+				pendingStops++
+			}
+			restartPending = true
+
+			delay := boff.NextBackOff()
+			if delay == backoff.Stop {
+				delay = boff.MaxInterval
+			}
+			notBefore = time.Now().Add(delay)
+		}
+	}
+}
+
+// New creates a new runtime provisioner that monitors the health of runtimes provisioned by the
+// given provisioner and restarts unresponsive ones.
+func New(inner host.Provisioner, cfg Config) (host.Provisioner, error) {
+	if cfg.PingInterval <= 0 {
+		return nil, fmt.Errorf("invalid ping interval: %s", cfg.PingInterval)
+	}
+	if cfg.MaxRestartsPerHour < 0 {
+		return nil, fmt.Errorf("invalid maximum number of restarts per hour: %d", cfg.MaxRestartsPerHour)
+	}
+	// Use the ping interval as the ping timeout if none was provided.
+	if cfg.PingTimeout <= 0 {
+		cfg.PingTimeout = cfg.PingInterval
+	}
+	// Use a default Logger if none was provided.
+	if cfg.Logger == nil {
+		cfg.Logger = logging.GetLogger("runtime/host/watchdog")
+	}
+	return &provisioner{
+		cfg:   cfg,
+		inner: inner,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/mock"
+)
+
+const (
+	pingInterval = 10 * time.Millisecond
+	waitTimeout  = 5 * time.Second
+)
+
+func TestWatchdog(t *testing.T) {
+	require := require.New(t)
+
+	mp := mock.NewProgrammable()
+	p, err := New(mp, Config{
+		PingInterval:       pingInterval,
+		MaxRestartsPerHour: 2,
+	})
+	require.NoError(err, "New")
+
+	rt, err := p.NewRuntime(context.Background(), host.Config{})
+	require.NoError(err, "NewRuntime")
+	require.NoError(rt.Start(), "Start")
+	defer rt.Stop()
+
+	sp, ok := rt.(StatusProvider)
+	require.True(ok, "runtime should provide the watchdog status")
+
+	// A healthy runtime should be pinged but not restarted.
+	require.Eventually(func() bool { return mp.Calls(mock.MethodPing) >= 3 }, waitTimeout, pingInterval)
+	require.Equal(0, mp.Calls(mock.MethodAbort), "healthy runtime should not be restarted")
+	status := sp.WatchdogStatus()
+	require.False(status.LastPing.IsZero(), "last ping time should be set")
+	require.Empty(status.History, "restart history should be empty")
+
+	// Restarts not triggered by the watchdog should be recorded.
+	require.NoError(rt.Abort(context.Background(), true), "Abort")
+	require.Eventually(func() bool { return len(sp.WatchdogStatus().History) == 1 }, waitTimeout, pingInterval)
+	status = sp.WatchdogStatus()
+	require.Equal(ReasonTerminated, status.History[0].Reason)
+	require.Equal(0, status.RestartsLastHour, "restarts not triggered by the watchdog should not use the budget")
+
+	// An unresponsive runtime should be restarted until the budget is exhausted.
+	mp.Program(mock.MethodPing, mock.Behavior{Error: fmt.Errorf("unresponsive")})
+	require.Eventually(func() bool { return sp.WatchdogStatus().BudgetExhausted }, waitTimeout, pingInterval)
+	status = sp.WatchdogStatus()
+	require.Equal(2, status.RestartsLastHour)
+	require.Len(status.History, 3)
+	for _, rec := range status.History[1:] {
+		require.Equal(ReasonUnresponsive, rec.Reason)
+		require.Contains(rec.Error, "unresponsive")
+	}
+
+	// No more restarts should be triggered once the budget is exhausted.
+	pings := mp.Calls(mock.MethodPing)
+	require.Eventually(func() bool { return mp.Calls(mock.MethodPing) >= pings+3 }, waitTimeout, pingInterval)
+	require.Equal(3, mp.Calls(mock.MethodAbort), "runtime should not be restarted after the budget is exhausted")
+}
+
+func TestWatchdogConfig(t *testing.T) {
+	require := require.New(t)
+
+	_, err := New(mock.New(), Config{})
+	require.Error(err, "New should fail without a ping interval")
+	_, err = New(mock.New(), Config{PingInterval: time.Second, MaxRestartsPerHour: -1})
+	require.Error(err, "New should fail with a negative restart budget")
+}
@@ -18,6 +18,9 @@ import (
 )
 
 var (
+	// ErrBatchTooLarge is the error returned when a batch is too large to be written atomically.
+	ErrBatchTooLarge = errors.New("local storage batch too large")
+
 	errInvalidKey = errors.New("invalid local storage key")
 
 	_ LocalStorage = (*localStorage)(nil)
@@ -31,10 +34,27 @@ type LocalStorage interface {
 	// Set sets a key to a specific value.
 	Set(key, value []byte) error
 
+	// BatchSet atomically sets multiple keys to specific values. Either all
+	// or none of the keys are updated.
+	//
+	// As the batch is written in a single database transaction, its size is
+	// limited by the underlying database. Batches exceeding the limit (a few
+	// MiB in total) are rejected with ErrBatchTooLarge and should be split by
+	// the caller if atomicity is not required.
+	BatchSet(entries []Entry) error
+
 	// Stop stops local storage.
 	Stop()
 }
 
+// Entry is a local storage key/value pair.
+type Entry struct {
+	// Key is the key.
+	Key []byte
+	// Value is the value.
+	Value []byte
+}
+
 type localStorage struct {
 	logger *logging.Logger
 
@@ -92,6 +112,34 @@ func (s *localStorage) Set(key, value []byte) error {
 	return nil
 }
 
+func (s *localStorage) BatchSet(entries []Entry) error {
+	for _, entry := range entries {
+		if len(entry.Key) == 0 {
+			return errInvalidKey
+		}
+	}
+
+	if err := s.db.Update(func(tx *badger.Txn) error {
+		for _, entry := range entries {
+			if err := tx.Set(entry.Key, entry.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		s.logger.Error("failed batch put",
+			"err", err,
+			"num_entries", len(entries),
+		)
+		if errors.Is(err, badger.ErrTxnTooBig) {
+			return ErrBatchTooLarge
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (s *localStorage) Stop() {
 	s.gc.Close()
 	if err := s.db.Close(); err != nil {
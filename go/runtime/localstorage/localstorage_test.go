@@ -0,0 +1,61 @@
+package localstorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+)
+
+func TestBatchSet(t *testing.T) {
+	require := require.New(t)
+
+	s, err := New(t.TempDir(), "localstorage.test.db", common.Namespace{})
+	require.NoError(err, "New")
+	defer s.Stop()
+
+	requireValue := func(key, expected []byte) {
+		value, err := s.Get(key)
+		require.NoError(err, "Get")
+		require.EqualValues(expected, value, "value for key %s", key)
+	}
+
+	err = s.Set([]byte("key 1"), []byte("value 1"))
+	require.NoError(err, "Set")
+
+	err = s.BatchSet([]Entry{
+		{Key: []byte("key 1"), Value: []byte("new value 1")},
+		{Key: []byte("key 2"), Value: []byte("value 2")},
+	})
+	require.NoError(err, "BatchSet")
+	requireValue([]byte("key 1"), []byte("new value 1"))
+	requireValue([]byte("key 2"), []byte("value 2"))
+
+	// Batches with an empty key should be rejected without updating any keys.
+	err = s.BatchSet([]Entry{
+		{Key: []byte("key 1"), Value: []byte("value 1")},
+		{Key: []byte{}, Value: []byte("empty")},
+		{Key: []byte("key 3"), Value: []byte("value 3")},
+	})
+	require.ErrorIs(err, errInvalidKey, "BatchSet with an empty key")
+	requireValue([]byte("key 1"), []byte("new value 1"))
+	requireValue([]byte("key 3"), []byte{})
+
+	// Batches too large for a single transaction should be rejected without updating any keys.
+	value := make([]byte, 1024)
+	var entries []Entry
+	for i := 0; i < 20_000; i++ {
+		entries = append(entries, Entry{Key: []byte(fmt.Sprintf("large %d", i)), Value: value})
+	}
+	entries = append(entries, Entry{Key: []byte("key 1"), Value: []byte("value 1")})
+	err = s.BatchSet(entries)
+	require.ErrorIs(err, ErrBatchTooLarge, "BatchSet with a large batch")
+	requireValue([]byte("large 0"), []byte{})
+	requireValue([]byte("key 1"), []byte("new value 1"))
+
+	// Empty batches should succeed.
+	err = s.BatchSet(nil)
+	require.NoError(err, "BatchSet with no entries")
+}
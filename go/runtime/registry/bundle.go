@@ -0,0 +1,49 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	runtimeHost "github.com/oasisprotocol/oasis-core/go/runtime/host"
+)
+
+// verifyBundle verifies the runtime bundle against the runtime's registry descriptor.
+func (r *runtime) verifyBundle(rt *registry.Runtime, bnd *runtimeHost.RuntimeBundle) error {
+	if bnd.Manifest.ID != rt.ID {
+		return fmt.Errorf("runtime bundle is for runtime %s, not %s", bnd.Manifest.ID, rt.ID)
+	}
+	if bnd.Manifest.Version != rt.Version.Version {
+		r.logger.Warn("runtime bundle version differs from the registry descriptor",
+			"bundle_version", bnd.Manifest.Version,
+			"descriptor_version", rt.Version.Version,
+		)
+	}
+
+	switch rt.TEEHardware {
+	case node.TEEHardwareIntelSGX:
+		if bnd.MrEnclave == nil {
+			// The bundle can only be used with a non-SGX provisioner, which will fail if this
+			// is not allowed.
+			return nil
+		}
+
+		var cs node.SGXConstraints
+		if err := cbor.Unmarshal(rt.Version.TEE, &cs); err != nil {
+			return fmt.Errorf("malformed runtime SGX constraints: %w", err)
+		}
+		for _, id := range cs.Enclaves {
+			if id.MrEnclave != *bnd.MrEnclave {
+				continue
+			}
+			if bnd.MrSigner != nil && id.MrSigner != *bnd.MrSigner {
+				continue
+			}
+			return nil
+		}
+		return fmt.Errorf("runtime bundle enclave identity (MRENCLAVE: %s) not allowed by the registry descriptor", bnd.MrEnclave)
+	default:
+		return nil
+	}
+}
@@ -1,9 +1,13 @@
 package registry
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -15,12 +19,15 @@ import (
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	ias "github.com/oasisprotocol/oasis-core/go/ias/api"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
 	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 	runtimeHost "github.com/oasisprotocol/oasis-core/go/runtime/host"
 	hostMock "github.com/oasisprotocol/oasis-core/go/runtime/host/mock"
 	hostProtocol "github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 	hostSandbox "github.com/oasisprotocol/oasis-core/go/runtime/host/sandbox"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/sandbox/process"
 	hostSgx "github.com/oasisprotocol/oasis-core/go/runtime/host/sgx"
+	hostWatchdog "github.com/oasisprotocol/oasis-core/go/runtime/host/watchdog"
 )
 
 const (
@@ -35,6 +42,17 @@ const (
 	CfgRuntimePaths = "runtime.paths"
 	// CfgSandboxBinary configures the runtime sandbox binary location.
 	CfgSandboxBinary = "runtime.sandbox.binary"
+	// CfgSandboxSeccompProfiles configures per-runtime SECCOMP profiles applied on top of the
+	// default profile template.
+	//
+	// The value should be a map of runtime IDs to corresponding JSON profile paths.
+	CfgSandboxSeccompProfiles = "runtime.sandbox.seccomp_profiles"
+	// CfgSandboxRequireUserNamespace configures the sandbox to fail in case a new user namespace
+	// cannot be created.
+	CfgSandboxRequireUserNamespace = "runtime.sandbox.require_user_namespace"
+	// CfgDebugSandboxPrintSeccompProfile enables logging of the effective SECCOMP profile when
+	// starting sandboxed runtimes.
+	CfgDebugSandboxPrintSeccompProfile = "runtime.sandbox.debug.print_seccomp_profile"
 	// CfgRuntimeSGXLoader configures the runtime loader binary required for SGX runtimes.
 	//
 	// The same loader is used for all runtimes.
@@ -47,6 +65,16 @@ const (
 	// CfgRuntimeConfig configures node-local runtime configuration.
 	CfgRuntimeConfig = "runtime.config"
 
+	// CfgRuntimeWatchdogPingInterval configures the interval at which hosted runtimes are pinged
+	// by the runtime watchdog. Zero disables the watchdog.
+	CfgRuntimeWatchdogPingInterval = "runtime.watchdog.ping_interval"
+	// CfgRuntimeWatchdogPingTimeout configures the maximum time to wait for a hosted runtime to
+	// respond to a watchdog ping.
+	CfgRuntimeWatchdogPingTimeout = "runtime.watchdog.ping_timeout"
+	// CfgRuntimeWatchdogMaxRestartsPerHour configures the maximum number of restarts of
+	// unresponsive runtimes the watchdog may trigger within an hour.
+	CfgRuntimeWatchdogMaxRestartsPerHour = "runtime.watchdog.max_restarts_per_hour"
+
 	// CfgHistoryPrunerStrategy configures the history pruner strategy.
 	CfgHistoryPrunerStrategy = "runtime.history.pruner.strategy"
 	// CfgHistoryPrunerInterval configures the history pruner interval.
@@ -152,7 +180,90 @@ type RuntimeHostConfig struct {
 	Runtimes map[common.Namespace]*runtimeHost.Config
 }
 
-func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, error) {
+// NewRuntimeHostConfig creates a new runtime host configuration for the runtime with the given
+// identifier, path and optional (detached) SGX signature path.
+//
+// In case the path points to a runtime bundle, the bundle is exploded into the node's data
+// directory.
+func NewRuntimeHostConfig(
+	dataDir string,
+	id common.Namespace,
+	path string,
+	sigPath string,
+	localConfig map[string]interface{},
+) (*runtimeHost.Config, error) {
+	runtimeHostCfg := &runtimeHost.Config{
+		RuntimeID:   id,
+		Path:        path,
+		LocalConfig: localConfig,
+	}
+
+	// This config is SGX specific, but that's all that's supported
+	// right now that needs this anyway, the non-SGX provisioner
+	// currently ignores this.
+	if sigPath != "" {
+		runtimeHostCfg.Extra = &hostSgx.RuntimeExtra{
+			SignaturePath: sigPath,
+		}
+	} else {
+		// HACK HACK HACK: Allow dummy SIGSTRUCT generation.
+		runtimeHostCfg.Extra = &hostSgx.RuntimeExtra{
+			UnsafeDebugGenerateSigstruct: true,
+		}
+	}
+
+	if filepath.Ext(path) == bundle.FileExtension {
+		bnd, err := bundle.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load runtime bundle '%s': %w", path, err)
+		}
+		if bnd.Manifest.ID != id {
+			return nil, fmt.Errorf("runtime bundle '%s' is for runtime %s, not %s", path, bnd.Manifest.ID, id)
+		}
+
+		var detachedSig []byte
+		if sigPath != "" && bnd.Manifest.IsSGX() && bnd.Manifest.SGX.Signature == "" {
+			if detachedSig, err = ioutil.ReadFile(sigPath); err != nil {
+				return nil, fmt.Errorf("failed to load detached SGX signature: %w", err)
+			}
+		}
+
+		bundleDir := filepath.Join(dataDir, RuntimesDir, BundlesDir)
+		if runtimeHostCfg.Bundle, err = runtimeHost.NewRuntimeBundle(bnd, bundleDir, detachedSig); err != nil {
+			return nil, fmt.Errorf("failed to explode runtime bundle '%s': %w", path, err)
+		}
+	}
+
+	return runtimeHostCfg, nil
+}
+
+func loadSeccompProfiles() (map[common.Namespace]*process.SeccompProfile, error) {
+	profiles := make(map[common.Namespace]*process.SeccompProfile)
+	for runtimeID, path := range viper.GetStringMapString(CfgSandboxSeccompProfiles) {
+		var id common.Namespace
+		if err := id.UnmarshalHex(runtimeID); err != nil {
+			return nil, fmt.Errorf("bad runtime identifier '%s': %w", runtimeID, err)
+		}
+
+		raw, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load SECCOMP profile '%s': %w", path, err)
+		}
+		var profile process.SeccompProfile
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err = dec.Decode(&profile); err != nil {
+			return nil, fmt.Errorf("malformed SECCOMP profile '%s': %w", path, err)
+		}
+		if _, err = profile.Effective(); err != nil {
+			return nil, fmt.Errorf("bad SECCOMP profile '%s': %w", path, err)
+		}
+		profiles[id] = &profile
+	}
+	return profiles, nil
+}
+
+func newConfig(dataDir string, consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, error) {
 	var cfg RuntimeConfig
 
 	// Parse configured runtime mode.
@@ -193,6 +304,14 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 			ConsensusChainContext:    chainCtx,
 		}
 
+		// Load per-runtime SECCOMP profiles.
+		seccompProfiles, err := loadSeccompProfiles()
+		if err != nil {
+			return nil, err
+		}
+		requireUserNamespace := viper.GetBool(CfgSandboxRequireUserNamespace)
+		printSeccompProfile := viper.GetBool(CfgDebugSandboxPrintSeccompProfile)
+
 		// Register provisioners based on the configured provisioner.
 		var insecureNoSandbox bool
 		sandboxBinary := viper.GetString(CfgSandboxBinary)
@@ -226,6 +345,10 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 				HostInfo:          hostInfo,
 				InsecureNoSandbox: insecureNoSandbox,
 				SandboxBinaryPath: sandboxBinary,
+
+				SeccompProfiles:          seccompProfiles,
+				RequireUserNamespace:     requireUserNamespace,
+				DebugPrintSeccompProfile: printSeccompProfile,
 			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to create runtime provisioner: %w", err)
@@ -238,6 +361,10 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 					HostInfo:          hostInfo,
 					InsecureNoSandbox: insecureNoSandbox,
 					SandboxBinaryPath: sandboxBinary,
+
+					SeccompProfiles:          seccompProfiles,
+					RequireUserNamespace:     requireUserNamespace,
+					DebugPrintSeccompProfile: printSeccompProfile,
 				})
 				if err != nil {
 					return nil, fmt.Errorf("failed to create runtime provisioner: %w", err)
@@ -250,6 +377,10 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 					IAS:               ias,
 					SandboxBinaryPath: sandboxBinary,
 					InsecureNoSandbox: insecureNoSandbox,
+
+					SeccompProfiles:          seccompProfiles,
+					RequireUserNamespace:     requireUserNamespace,
+					DebugPrintSeccompProfile: printSeccompProfile,
 				})
 				if err != nil {
 					return nil, fmt.Errorf("failed to create SGX runtime provisioner: %w", err)
@@ -259,6 +390,20 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 			return nil, fmt.Errorf("unsupported runtime provisioner: %s", p)
 		}
 
+		// Monitor the health of hosted runtimes if configured.
+		if pingInterval := viper.GetDuration(CfgRuntimeWatchdogPingInterval); pingInterval > 0 {
+			for hw, p := range rh.Provisioners {
+				rh.Provisioners[hw], err = hostWatchdog.New(p, hostWatchdog.Config{
+					PingInterval:       pingInterval,
+					PingTimeout:        viper.GetDuration(CfgRuntimeWatchdogPingTimeout),
+					MaxRestartsPerHour: viper.GetInt(CfgRuntimeWatchdogMaxRestartsPerHour),
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to create runtime watchdog: %w", err)
+				}
+			}
+		}
+
 		// Configure runtimes.
 		runtimeSGXSignatures := viper.GetStringMapString(CfgRuntimeSGXSignatures)
 		rh.Runtimes = make(map[common.Namespace]*runtimeHost.Config)
@@ -276,27 +421,10 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 				}
 			}
 
-			runtimeHostCfg := &runtimeHost.Config{
-				RuntimeID:   id,
-				Path:        path,
-				LocalConfig: localConfig,
-			}
-
-			// This config is SGX specific, but that's all that's supported
-			// right now that needs this anyway, the non-SGX provisioner
-			// currently ignores this.
-			if sigPath := runtimeSGXSignatures[runtimeID]; sigPath != "" {
-				runtimeHostCfg.Extra = &hostSgx.RuntimeExtra{
-					SignaturePath: sigPath,
-				}
-			} else {
-				// HACK HACK HACK: Allow dummy SIGSTRUCT generation.
-				runtimeHostCfg.Extra = &hostSgx.RuntimeExtra{
-					UnsafeDebugGenerateSigstruct: true,
-				}
+			rh.Runtimes[id], err = NewRuntimeHostConfig(dataDir, id, path, runtimeSGXSignatures[runtimeID], localConfig)
+			if err != nil {
+				return nil, err
 			}
-
-			rh.Runtimes[id] = runtimeHostCfg
 		}
 		if len(rh.Runtimes) == 0 {
 			return nil, fmt.Errorf("no runtimes configured")
@@ -329,9 +457,16 @@ func init() {
 	Flags.String(CfgRuntimeProvisioner, RuntimeProvisionerSandboxed, "Runtime provisioner to use")
 	Flags.StringToString(CfgRuntimePaths, nil, "Paths to runtime resources (format: <rt1-ID>=<path>,<rt2-ID>=<path>)")
 	Flags.String(CfgSandboxBinary, "/usr/bin/bwrap", "Path to the sandbox binary (bubblewrap)")
+	Flags.StringToString(CfgSandboxSeccompProfiles, nil, "Paths to per-runtime SECCOMP profiles (format: <rt1-ID>=<path>,<rt2-ID>=<path>)")
+	Flags.Bool(CfgSandboxRequireUserNamespace, false, "Fail to start sandboxed runtimes in case a user namespace cannot be created")
+	Flags.Bool(CfgDebugSandboxPrintSeccompProfile, false, "Log the effective SECCOMP profile when starting sandboxed runtimes")
 	Flags.String(CfgRuntimeSGXLoader, "", "(for SGX runtimes) Path to SGXS runtime loader binary")
 	Flags.StringToString(CfgRuntimeSGXSignatures, nil, "(for SGX runtimes) Paths to signatures (format: <rt1-ID>=<path>,<rt2-ID>=<path>")
 
+	Flags.Duration(CfgRuntimeWatchdogPingInterval, 30*time.Second, "Interval at which hosted runtimes are pinged by the watchdog (0 = disabled)")
+	Flags.Duration(CfgRuntimeWatchdogPingTimeout, 10*time.Second, "Maximum time to wait for a hosted runtime to respond to a watchdog ping")
+	Flags.Int(CfgRuntimeWatchdogMaxRestartsPerHour, 5, "Maximum number of restarts of unresponsive runtimes per hour (0 = unlimited)")
+
 	Flags.String(CfgHistoryPrunerStrategy, history.PrunerStrategyNone, "History pruner strategy")
 	Flags.Duration(CfgHistoryPrunerInterval, 2*time.Minute, "History pruning interval")
 	Flags.Uint64(CfgHistoryPrunerKeepLastNum, 600, "Keep last history pruner: number of last rounds to keep")
@@ -12,6 +12,10 @@ const (
 	// RuntimesDir is the name of the directory located inside the node's data
 	// directory which contains the per-runtime state.
 	RuntimesDir = "runtimes"
+
+	// BundlesDir is the name of the directory located inside the node's runtimes directory which
+	// contains the exploded runtime bundles.
+	BundlesDir = "bundles"
 )
 
 func GetRuntimeStateDir(dataDir string, runtimeID common.Namespace) string {
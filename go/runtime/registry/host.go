@@ -10,6 +10,7 @@ import (
 	"github.com/eapache/channels"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	keymanagerApi "github.com/oasisprotocol/oasis-core/go/keymanager/api"
@@ -18,6 +19,8 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/watchdog"
+	"github.com/oasisprotocol/oasis-core/go/runtime/localstorage"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
@@ -34,6 +37,7 @@ type RuntimeHostNode struct {
 
 	runtime       host.RichRuntime
 	runtimeNotify chan struct{}
+	watchdog      watchdog.StatusProvider
 }
 
 // ProvisionHostedRuntime provisions the configured runtime.
@@ -58,6 +62,7 @@ func (n *RuntimeHostNode) ProvisionHostedRuntime(ctx context.Context) (host.Rich
 	n.Lock()
 	n.runtime = rr
 	n.notifier = notifier
+	n.watchdog, _ = prt.(watchdog.StatusProvider)
 	n.Unlock()
 
 	close(n.runtimeNotify)
@@ -73,6 +78,19 @@ func (n *RuntimeHostNode) GetHostedRuntime() host.RichRuntime {
 	return rt
 }
 
+// GetHostedRuntimeWatchdogStatus returns the watchdog status of the provisioned hosted runtime. It
+// returns nil in case no runtime has been provisioned or the runtime is not monitored.
+func (n *RuntimeHostNode) GetHostedRuntimeWatchdogStatus() *watchdog.Status {
+	n.Lock()
+	wd := n.watchdog
+	n.Unlock()
+
+	if wd == nil {
+		return nil
+	}
+	return wd.WatchdogStatus()
+}
+
 // WaitHostedRuntime waits for the hosted runtime to be provisioned and returns it.
 func (n *RuntimeHostNode) WaitHostedRuntime(ctx context.Context) (host.RichRuntime, error) {
 	select {
@@ -117,6 +135,9 @@ type RuntimeHostHandlerEnvironment interface {
 
 	// GetKeyManagerClient returns the key manager client for this runtime.
 	GetKeyManagerClient(ctx context.Context) (keymanagerClientApi.Client, error)
+
+	// GetNodeIdentity returns the identity of a node running this runtime.
+	GetNodeIdentity(ctx context.Context) (*identity.Identity, error)
 }
 
 // RuntimeHostHandler is a runtime host handler suitable for compute runtimes. It provides the
@@ -197,6 +218,16 @@ func (h *runtimeHostHandler) Handle(ctx context.Context, body *protocol.Body) (*
 		}
 		return &protocol.Body{HostLocalStorageSetResponse: &protocol.Empty{}}, nil
 	}
+	if body.HostLocalStorageBatchSetRequest != nil {
+		entries := make([]localstorage.Entry, 0, len(body.HostLocalStorageBatchSetRequest.Entries))
+		for _, e := range body.HostLocalStorageBatchSetRequest.Entries {
+			entries = append(entries, localstorage.Entry{Key: e.Key, Value: e.Value})
+		}
+		if err := h.runtime.LocalStorage().BatchSet(entries); err != nil {
+			return nil, err
+		}
+		return &protocol.Body{HostLocalStorageBatchSetResponse: &protocol.Empty{}}, nil
+	}
 	// Consensus light client.
 	if body.HostFetchConsensusBlockRequest != nil {
 		lb, err := h.consensus.GetLightBlock(ctx, int64(body.HostFetchConsensusBlockRequest.Height))
@@ -207,6 +238,23 @@ func (h *runtimeHostHandler) Handle(ctx context.Context, body *protocol.Body) (*
 			Block: *lb,
 		}}, nil
 	}
+	// Freshness proofs.
+	if body.HostProveFreshnessRequest != nil {
+		identity, err := h.env.GetNodeIdentity(ctx)
+		if err != nil {
+			return nil, err
+		}
+		blob := registry.Blob(body.HostProveFreshnessRequest.Blob)
+		tx := registry.NewProveFreshnessTx(0, nil, &blob)
+		sigTx, proof, err := consensus.SignAndSubmitTxWithProof(ctx, h.consensus, identity.NodeSigner, tx)
+		if err != nil {
+			return nil, err
+		}
+		return &protocol.Body{HostProveFreshnessResponse: &protocol.HostProveFreshnessResponse{
+			SignedTx: sigTx,
+			Proof:    proof,
+		}}, nil
+	}
 
 	return nil, errMethodNotSupported
 }
@@ -35,9 +35,15 @@ const (
 	LocalStorageFile = "worker-local-storage.badger.db"
 )
 
-// ErrRuntimeHostNotConfigured is the error returned when the runtime host is not configured for a
-// specified runtime and a request is made to get the runtime host provisioner.
-var ErrRuntimeHostNotConfigured = errors.New("runtime/registry: runtime host not configured")
+var (
+	// ErrRuntimeHostNotConfigured is the error returned when the runtime host is not configured for a
+	// specified runtime and a request is made to get the runtime host provisioner.
+	ErrRuntimeHostNotConfigured = errors.New("runtime/registry: runtime host not configured")
+
+	// ErrRuntimeManagementNotSupported is the error returned when runtimes are added or removed
+	// while the node is running in a runtime mode that does not support it.
+	ErrRuntimeManagementNotSupported = errors.New("runtime/registry: runtime management not supported in current mode")
+)
 
 // Registry is the running node's runtime registry interface.
 type Registry interface {
@@ -63,6 +69,16 @@ type Registry interface {
 	// in the request.
 	StorageRouter() storageAPI.Backend
 
+	// AddRuntime adds a new supported runtime that is hosted using the given configuration.
+	//
+	// The caller must call FinishInitialization after any workers have been set up for the
+	// new runtime.
+	AddRuntime(ctx context.Context, hostCfg *runtimeHost.Config) (Runtime, error)
+
+	// RemoveRuntime stops and removes a supported runtime. Any workers using the runtime must
+	// be stopped before calling this method.
+	RemoveRuntime(ctx context.Context, runtimeID common.Namespace) error
+
 	// Cleanup performs post-termination cleanup.
 	Cleanup()
 
@@ -252,6 +268,12 @@ func (r *runtime) Host(ctx context.Context) (runtimeHost.Config, runtimeHost.Pro
 		return runtimeHost.Config{}, nil, fmt.Errorf("no provisioner suitable for TEE hardware '%s'", rt.TEEHardware)
 	}
 
+	if bnd := r.hostConfig.Bundle; bnd != nil {
+		if err = r.verifyBundle(rt, bnd); err != nil {
+			return runtimeHost.Config{}, nil, err
+		}
+	}
+
 	return *r.hostConfig, provisioner, nil
 }
 
@@ -471,6 +493,64 @@ func (r *runtimeRegistry) StorageRouter() storageAPI.Backend {
 	)
 }
 
+func (r *runtimeRegistry) canManageRuntimes() error {
+	switch r.cfg.Mode {
+	case RuntimeModeClient, RuntimeModeClientStateless:
+	default:
+		return ErrRuntimeManagementNotSupported
+	}
+	if r.cfg.Host == nil {
+		return ErrRuntimeHostNotConfigured
+	}
+	return nil
+}
+
+func (r *runtimeRegistry) AddRuntime(ctx context.Context, hostCfg *runtimeHost.Config) (Runtime, error) {
+	if err := r.canManageRuntimes(); err != nil {
+		return nil, err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	id := hostCfg.RuntimeID
+	if _, ok := r.runtimes[id]; ok {
+		return nil, fmt.Errorf("runtime/registry: runtime already registered: %s", id)
+	}
+
+	r.cfg.Host.Runtimes[id] = hostCfg
+	if err := r.addSupportedRuntimeLocked(ctx, id); err != nil {
+		delete(r.cfg.Host.Runtimes, id)
+		return nil, err
+	}
+	return r.runtimes[id], nil
+}
+
+func (r *runtimeRegistry) RemoveRuntime(ctx context.Context, runtimeID common.Namespace) error {
+	if err := r.canManageRuntimes(); err != nil {
+		return err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	rt, ok := r.runtimes[runtimeID]
+	if !ok {
+		return fmt.Errorf("runtime/registry: runtime %s is not supported", runtimeID)
+	}
+
+	// Stop tracking the runtime before closing its history.
+	if err := r.consensus.RootHash().UntrackRuntime(ctx, runtimeID); err != nil {
+		return fmt.Errorf("runtime/registry: cannot untrack runtime %s: %w", runtimeID, err)
+	}
+
+	rt.stop()
+	delete(r.runtimes, runtimeID)
+	delete(r.cfg.Host.Runtimes, runtimeID)
+
+	return nil
+}
+
 func (r *runtimeRegistry) Cleanup() {
 	r.Lock()
 	defer r.Unlock()
@@ -492,10 +572,14 @@ func (r *runtimeRegistry) FinishInitialization(ctx context.Context) error {
 	return nil
 }
 
-func (r *runtimeRegistry) addSupportedRuntime(ctx context.Context, id common.Namespace) (rerr error) {
+func (r *runtimeRegistry) addSupportedRuntime(ctx context.Context, id common.Namespace) error {
 	r.Lock()
 	defer r.Unlock()
 
+	return r.addSupportedRuntimeLocked(ctx, id)
+}
+
+func (r *runtimeRegistry) addSupportedRuntimeLocked(ctx context.Context, id common.Namespace) (rerr error) {
 	if len(r.runtimes) >= MaxRuntimeCount {
 		return fmt.Errorf("runtime/registry: too many registered runtimes")
 	}
@@ -585,7 +669,7 @@ func newRuntime(
 
 // New creates a new runtime registry.
 func New(ctx context.Context, dataDir string, consensus consensus.Backend, identity *identity.Identity, ias ias.Endpoint) (Registry, error) {
-	cfg, err := newConfig(consensus, ias)
+	cfg, err := newConfig(dataDir, consensus, ias)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,184 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
+	runtimeHost "github.com/oasisprotocol/oasis-core/go/runtime/host"
+	hostSgx "github.com/oasisprotocol/oasis-core/go/runtime/host/sgx"
+)
+
+type testRootHash struct {
+	roothash.Backend
+
+	tracked  map[common.Namespace]bool
+	trackErr error
+}
+
+func (rh *testRootHash) TrackRuntime(ctx context.Context, h roothash.BlockHistory) error {
+	if rh.trackErr != nil {
+		return rh.trackErr
+	}
+	rh.tracked[h.RuntimeID()] = true
+	return nil
+}
+
+func (rh *testRootHash) UntrackRuntime(ctx context.Context, runtimeID common.Namespace) error {
+	delete(rh.tracked, runtimeID)
+	return nil
+}
+
+type testConsensus struct {
+	consensus.Backend
+
+	rootHash *testRootHash
+}
+
+func (c *testConsensus) RootHash() roothash.Backend {
+	return c.rootHash
+}
+
+func (c *testConsensus) Synced() <-chan struct{} {
+	// Never synced, so that the runtime descriptor watchers stay idle.
+	return make(chan struct{})
+}
+
+func newTestRegistry(t *testing.T, mode RuntimeMode, host *RuntimeHostConfig) (*runtimeRegistry, *testRootHash) {
+	rootHash := &testRootHash{
+		tracked: make(map[common.Namespace]bool),
+	}
+	return &runtimeRegistry{
+		logger:  logging.GetLogger("runtime/registry/test"),
+		dataDir: t.TempDir(),
+		cfg: &RuntimeConfig{
+			Mode:    mode,
+			Host:    host,
+			History: *history.NewDefaultConfig(),
+		},
+		consensus: &testConsensus{rootHash: rootHash},
+		runtimes:  make(map[common.Namespace]*runtime),
+	}, rootHash
+}
+
+func newTestHostConfig(t *testing.T, runtimeID common.Namespace, path string) *runtimeHost.Config {
+	cfg, err := NewRuntimeHostConfig(t.TempDir(), runtimeID, path, "", nil)
+	require.NoError(t, err, "NewRuntimeHostConfig")
+	return cfg
+}
+
+func TestNewRuntimeHostConfig(t *testing.T) {
+	require := require.New(t)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("runtime registry test runtime"), 0)
+	localConfig := map[string]interface{}{"key": "value"}
+
+	cfg, err := NewRuntimeHostConfig(t.TempDir(), runtimeID, "/path/to/runtime", "/path/to/runtime.sig", localConfig)
+	require.NoError(err, "NewRuntimeHostConfig")
+	require.Equal(runtimeID, cfg.RuntimeID, "RuntimeID")
+	require.Equal("/path/to/runtime", cfg.Path, "Path")
+	require.Equal(localConfig, cfg.LocalConfig, "LocalConfig")
+	require.Equal(&hostSgx.RuntimeExtra{SignaturePath: "/path/to/runtime.sig"}, cfg.Extra, "Extra")
+
+	cfg, err = NewRuntimeHostConfig(t.TempDir(), runtimeID, "/path/to/runtime", "", nil)
+	require.NoError(err, "NewRuntimeHostConfig without a signature")
+	require.Equal(&hostSgx.RuntimeExtra{UnsafeDebugGenerateSigstruct: true}, cfg.Extra, "Extra without a signature")
+
+	_, err = NewRuntimeHostConfig(t.TempDir(), runtimeID, "/path/to/missing"+bundle.FileExtension, "", nil)
+	require.Error(err, "NewRuntimeHostConfig with a missing bundle")
+}
+
+func TestRuntimeManagement(t *testing.T) {
+	ctx := context.Background()
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("runtime registry test runtime"), 0)
+	newHost := func() *RuntimeHostConfig {
+		return &RuntimeHostConfig{
+			Provisioners: make(map[node.TEEHardware]runtimeHost.Provisioner),
+			Runtimes:     make(map[common.Namespace]*runtimeHost.Config),
+		}
+	}
+
+	t.Run("UnsupportedMode", func(t *testing.T) {
+		require := require.New(t)
+
+		for _, mode := range []RuntimeMode{RuntimeModeNone, RuntimeModeCompute, RuntimeModeKeymanager} {
+			r, _ := newTestRegistry(t, mode, newHost())
+			_, err := r.AddRuntime(ctx, newTestHostConfig(t, runtimeID, "/path/to/runtime"))
+			require.ErrorIs(err, ErrRuntimeManagementNotSupported, "AddRuntime (%s)", mode)
+			err = r.RemoveRuntime(ctx, runtimeID)
+			require.ErrorIs(err, ErrRuntimeManagementNotSupported, "RemoveRuntime (%s)", mode)
+		}
+
+		r, _ := newTestRegistry(t, RuntimeModeClient, nil)
+		_, err := r.AddRuntime(ctx, newTestHostConfig(t, runtimeID, "/path/to/runtime"))
+		require.ErrorIs(err, ErrRuntimeHostNotConfigured, "AddRuntime without a runtime host")
+	})
+
+	t.Run("AddRemove", func(t *testing.T) {
+		require := require.New(t)
+
+		host := newHost()
+		r, rootHash := newTestRegistry(t, RuntimeModeClient, host)
+		defer r.Cleanup()
+
+		hostCfg := newTestHostConfig(t, runtimeID, "/path/to/runtime")
+		rt, err := r.AddRuntime(ctx, hostCfg)
+		require.NoError(err, "AddRuntime")
+		require.Equal(runtimeID, rt.ID(), "added runtime should have the correct identifier")
+		require.True(rt.HasHost(), "added runtime should be hosted")
+		require.True(rootHash.tracked[runtimeID], "added runtime should be tracked")
+		require.Equal(hostCfg, host.Runtimes[runtimeID], "host configuration should be registered")
+
+		got, err := r.GetRuntime(runtimeID)
+		require.NoError(err, "GetRuntime")
+		require.Equal(rt, got, "GetRuntime should return the added runtime")
+
+		// Adding the same runtime again should fail without replacing the configuration.
+		_, err = r.AddRuntime(ctx, newTestHostConfig(t, runtimeID, "/path/to/other"))
+		require.Error(err, "AddRuntime with an already registered runtime")
+		require.Equal(hostCfg, host.Runtimes[runtimeID], "host configuration should not be replaced")
+
+		err = r.RemoveRuntime(ctx, runtimeID)
+		require.NoError(err, "RemoveRuntime")
+		require.False(rootHash.tracked[runtimeID], "removed runtime should no longer be tracked")
+		require.NotContains(host.Runtimes, runtimeID, "host configuration should be removed")
+		_, err = r.GetRuntime(runtimeID)
+		require.Error(err, "GetRuntime after RemoveRuntime")
+
+		err = r.RemoveRuntime(ctx, runtimeID)
+		require.Error(err, "RemoveRuntime with an unknown runtime")
+
+		// Removing a runtime should release its resources so that it can be added again.
+		_, err = r.AddRuntime(ctx, hostCfg)
+		require.NoError(err, "AddRuntime after RemoveRuntime")
+	})
+
+	t.Run("TrackFailure", func(t *testing.T) {
+		require := require.New(t)
+
+		host := newHost()
+		r, rootHash := newTestRegistry(t, RuntimeModeClientStateless, host)
+		defer r.Cleanup()
+
+		rootHash.trackErr = fmt.Errorf("track failed")
+		_, err := r.AddRuntime(ctx, newTestHostConfig(t, runtimeID, "/path/to/runtime"))
+		require.ErrorIs(err, rootHash.trackErr, "AddRuntime with a failing roothash backend")
+		require.NotContains(host.Runtimes, runtimeID, "host configuration should be rolled back")
+		_, err = r.GetRuntime(runtimeID)
+		require.Error(err, "GetRuntime after a failed AddRuntime")
+
+		// The failed attempt should not leave any resources behind.
+		rootHash.trackErr = nil
+		_, err = r.AddRuntime(ctx, newTestHostConfig(t, runtimeID, "/path/to/runtime"))
+		require.NoError(err, "AddRuntime after a failed AddRuntime")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
@@ -16,6 +17,9 @@ type pendingTx struct {
 	Meta     *TransactionMeta
 	NotifyCh chan *protocol.CheckTxResult
 
+	// ReceivedAt is the time when the transaction was queued for checks.
+	ReceivedAt time.Time
+
 	element *list.Element
 }
 
@@ -89,6 +93,33 @@ func (q *checkTxQueue) RemoveBatch(batch []*pendingTx) {
 	}
 }
 
+// GetAll returns all queued transactions, oldest first.
+func (q *checkTxQueue) GetAll() []*pendingTx {
+	q.Lock()
+	defer q.Unlock()
+
+	txs := make([]*pendingTx, 0, q.queue.Len())
+	for current := q.queue.Back(); current != nil; current = current.Prev() {
+		txs = append(txs, current.Value.(*pendingTx))
+	}
+	return txs
+}
+
+// Remove removes a transaction from the queue and returns true if it was queued.
+func (q *checkTxQueue) Remove(txHash hash.Hash) bool {
+	q.Lock()
+	defer q.Unlock()
+
+	tx, ok := q.transactions[txHash]
+	if !ok {
+		return false
+	}
+	q.queue.Remove(tx.element)
+	delete(q.transactions, txHash)
+	tx.element = nil
+	return true
+}
+
 // IsQueued checks if a transactions is already queued.
 func (q *checkTxQueue) IsQueued(txHash hash.Hash) bool {
 	q.Lock()
@@ -103,3 +103,30 @@ func TestCheckTxQueueRemoveBatch(t *testing.T) {
 	})
 	require.EqualValues(t, 2, queue.Size(), "Size")
 }
+
+func TestCheckTxQueueRemove(t *testing.T) {
+	queue := newCheckTxQueue(51, 10)
+
+	for _, tx := range [][]byte{
+		[]byte("one"),
+		[]byte("two"),
+		[]byte("three"),
+	} {
+		require.NoError(t, queue.Add(newPendingTx(tx)), "Add")
+	}
+
+	all := queue.GetAll()
+	require.Len(t, all, 3, "GetAll")
+	require.EqualValues(t, []byte("one"), all[0].Tx, "GetAll should return oldest first")
+	require.EqualValues(t, []byte("three"), all[2].Tx, "GetAll should return oldest first")
+
+	require.True(t, queue.Remove(hash.NewFromBytes([]byte("two"))), "Remove")
+	require.False(t, queue.Remove(hash.NewFromBytes([]byte("two"))), "Remove(already removed)")
+	require.EqualValues(t, 2, queue.Size(), "Size")
+	require.False(t, queue.IsQueued(hash.NewFromBytes([]byte("two"))), "IsQueued")
+
+	all = queue.GetAll()
+	require.Len(t, all, 2, "GetAll")
+	require.EqualValues(t, []byte("one"), all[0].Tx)
+	require.EqualValues(t, []byte("three"), all[1].Tx)
+}
@@ -0,0 +1,127 @@
+package txpool
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+const (
+	// ErrorModule is the module name of errors returned by the transaction pool as a result of
+	// transaction checks.
+	ErrorModule = "txpool"
+
+	// ErrorCodeAlreadyExecuted is the error code returned when a transaction has already been
+	// executed in a recent runtime block.
+	ErrorCodeAlreadyExecuted uint32 = 1
+)
+
+// ExecutedTransaction is information about a transaction that has been executed in a finalized
+// runtime block.
+type ExecutedTransaction struct {
+	// Hash is the transaction hash.
+	Hash hash.Hash `json:"hash"`
+	// Round is the runtime round in which the transaction has been executed.
+	Round uint64 `json:"round"`
+	// Index is the index of the transaction in the executed batch.
+	Index uint32 `json:"index"`
+}
+
+// alreadyExecutedResult returns the check result of a transaction that has already been executed.
+func (tx *ExecutedTransaction) alreadyExecutedResult() *protocol.CheckTxResult {
+	return &protocol.CheckTxResult{
+		Error: protocol.Error{
+			Module:  ErrorModule,
+			Code:    ErrorCodeAlreadyExecuted,
+			Message: fmt.Sprintf("transaction already executed in round %d (index %d)", tx.Round, tx.Index),
+			Details: protocol.NewErrorDetails(&protocol.AlreadyExecutedDetails{
+				Round: tx.Round,
+				Index: tx.Index,
+			}),
+		},
+	}
+}
+
+type executedRound struct {
+	round  uint64
+	hashes []hash.Hash
+}
+
+// executedTxIndex is an index of transactions executed in the last few finalized runtime rounds.
+type executedTxIndex struct {
+	sync.Mutex
+
+	maxRounds uint64
+	txs       map[hash.Hash]*ExecutedTransaction
+	// rounds are the indexed rounds, ordered by round number.
+	rounds []*executedRound
+}
+
+// Add records the given transactions as executed in the given round and prunes any rounds that
+// fall outside the index window.
+func (idx *executedTxIndex) Add(round uint64, txs []*ExecutedTransaction) {
+	if idx.maxRounds == 0 || len(txs) == 0 {
+		return
+	}
+
+	idx.Lock()
+	defer idx.Unlock()
+
+	// Rounds are normally finalized in order, ignore any that are already outside the window.
+	if n := len(idx.rounds); n > 0 && idx.rounds[n-1].round >= idx.maxRounds && round <= idx.rounds[n-1].round-idx.maxRounds {
+		return
+	}
+
+	er := &executedRound{round: round}
+	for _, tx := range txs {
+		idx.txs[tx.Hash] = tx
+		er.hashes = append(er.hashes, tx.Hash)
+	}
+
+	// Keep rounds sorted, the same round may be reported more than once.
+	pos := len(idx.rounds)
+	for pos > 0 && idx.rounds[pos-1].round > round {
+		pos--
+	}
+	idx.rounds = append(idx.rounds, nil)
+	copy(idx.rounds[pos+1:], idx.rounds[pos:])
+	idx.rounds[pos] = er
+
+	// Prune rounds outside the window.
+	latest := idx.rounds[len(idx.rounds)-1].round
+	var pruned int
+	for _, r := range idx.rounds {
+		if latest < idx.maxRounds || r.round > latest-idx.maxRounds {
+			break
+		}
+		for _, h := range r.hashes {
+			if tx, ok := idx.txs[h]; ok && tx.Round == r.round {
+				delete(idx.txs, h)
+			}
+		}
+		pruned++
+	}
+	idx.rounds = idx.rounds[pruned:]
+}
+
+// Get looks up the given transaction in the index.
+func (idx *executedTxIndex) Get(txHash hash.Hash) (*ExecutedTransaction, bool) {
+	if idx.maxRounds == 0 {
+		return nil, false
+	}
+
+	idx.Lock()
+	defer idx.Unlock()
+
+	tx, ok := idx.txs[txHash]
+	return tx, ok
+}
+
+func newExecutedTxIndex(maxRounds uint64) *executedTxIndex {
+	return &executedTxIndex{
+		maxRounds: maxRounds,
+		txs:       make(map[hash.Hash]*ExecutedTransaction),
+	}
+}
@@ -0,0 +1,71 @@
+package txpool
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+func newExecutedTxs(round uint64, n int) []*ExecutedTransaction {
+	txs := make([]*ExecutedTransaction, 0, n)
+	for i := 0; i < n; i++ {
+		txs = append(txs, &ExecutedTransaction{
+			Hash:  hash.NewFromBytes([]byte(fmt.Sprintf("round %d tx %d", round, i))),
+			Round: round,
+			Index: uint32(i),
+		})
+	}
+	return txs
+}
+
+func TestExecutedTxIndex(t *testing.T) {
+	require := require.New(t)
+
+	idx := newExecutedTxIndex(3)
+	batches := make(map[uint64][]*ExecutedTransaction)
+	for round := uint64(1); round <= 5; round++ {
+		batches[round] = newExecutedTxs(round, 4)
+		idx.Add(round, batches[round])
+	}
+
+	// Only the last three rounds should be indexed.
+	for _, round := range []uint64{1, 2} {
+		_, ok := idx.Get(batches[round][0].Hash)
+		require.False(ok, "transactions from pruned rounds should not be indexed")
+	}
+	for _, round := range []uint64{3, 4, 5} {
+		for i, tx := range batches[round] {
+			etx, ok := idx.Get(tx.Hash)
+			require.True(ok, "transactions from recent rounds should be indexed")
+			require.EqualValues(round, etx.Round)
+			require.EqualValues(i, etx.Index)
+		}
+	}
+	require.Len(idx.rounds, 3)
+
+	// Rounds outside the window should be ignored.
+	stale := newExecutedTxs(2, 1)
+	idx.Add(2, stale)
+	_, ok := idx.Get(stale[0].Hash)
+	require.False(ok, "transactions from stale rounds should not be indexed")
+
+	// The rejection result should carry the original round and index.
+	etx, _ := idx.Get(batches[4][2].Hash)
+	res := etx.alreadyExecutedResult()
+	require.False(res.IsSuccess())
+	require.Equal(ErrorModule, res.Error.Module)
+	require.Equal(ErrorCodeAlreadyExecuted, res.Error.Code)
+	p, err := res.Error.Details.Payload()
+	require.NoError(err, "Payload")
+	require.EqualValues(&protocol.AlreadyExecutedDetails{Round: 4, Index: 2}, p)
+
+	// A disabled index should not record anything.
+	idx = newExecutedTxIndex(0)
+	idx.Add(1, batches[5])
+	_, ok = idx.Get(batches[5][0].Hash)
+	require.False(ok, "disabled index should not record transactions")
+}
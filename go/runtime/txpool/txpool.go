@@ -36,6 +36,10 @@ type Config struct {
 	// RecheckInterval is the interval (in rounds) when any pending transactions are subject to a
 	// recheck and any non-passing transactions are removed.
 	RecheckInterval uint64
+
+	// MaxExecutedTxIndexRounds is the number of most recent rounds for which executed transactions
+	// are indexed so that their resubmissions can be rejected. Zero disables the index.
+	MaxExecutedTxIndexRounds uint64
 }
 
 // TransactionMeta contains the per-transaction metadata.
@@ -51,6 +55,33 @@ type TransactionMeta struct {
 	Recheck bool
 }
 
+// TransactionStatus is the status of a transaction in the transaction pool.
+type TransactionStatus string
+
+const (
+	// TransactionStatusPendingCheck is the status of transactions that are waiting to be checked.
+	TransactionStatusPendingCheck TransactionStatus = "pending_check"
+	// TransactionStatusChecked is the status of transactions that have passed checks and are
+	// waiting to be scheduled.
+	TransactionStatusChecked TransactionStatus = "checked"
+)
+
+// TransactionInfo is information about a transaction in the transaction pool.
+type TransactionInfo struct {
+	// Hash is the transaction hash.
+	Hash hash.Hash `json:"hash"`
+	// Size is the size of the transaction in bytes.
+	Size uint64 `json:"size"`
+	// Priority is the transaction priority as reported by the runtime. It is only available for
+	// transactions that have passed checks.
+	Priority uint64 `json:"priority"`
+	// Status is the status of the transaction.
+	Status TransactionStatus `json:"status"`
+	// ReceivedAt is the time when the transaction was received. It may be the zero time in case
+	// the information is no longer available.
+	ReceivedAt time.Time `json:"received_at"`
+}
+
 // TransactionPool is an interface for managing a pool of transactions.
 type TransactionPool interface {
 	// Start starts the service.
@@ -66,12 +97,32 @@ type TransactionPool interface {
 	// invoking the runtime. This method waits for the checks to complete.
 	SubmitTx(ctx context.Context, tx []byte, meta *TransactionMeta) (*protocol.CheckTxResult, error)
 
+	// CheckTx checks the given transaction by invoking the runtime directly, without queuing it
+	// behind pending batch checks. The transaction is not added to the transaction pool.
+	CheckTx(ctx context.Context, tx []byte) (*protocol.CheckTxResult, error)
+
 	// SubmitTxNoWait adds the transaction into the transaction pool and returns immediately.
 	SubmitTxNoWait(ctx context.Context, tx []byte, meta *TransactionMeta) error
 
 	// RemoveTxBatch removes a transaction batch from the transaction pool.
 	RemoveTxBatch(txs []hash.Hash)
 
+	// RemoveExecutedTxBatch removes a batch of transactions executed in a finalized runtime block
+	// from the transaction pool and records them in the executed transaction index, so that any
+	// resubmissions are rejected during checks.
+	RemoveExecutedTxBatch(round uint64, txs []*ExecutedTransaction)
+
+	// GetExecutedTx looks up a transaction in the executed transaction index.
+	GetExecutedTx(txHash hash.Hash) (*ExecutedTransaction, bool)
+
+	// RemoveTx removes a transaction from the transaction pool, regardless of whether it is still
+	// waiting to be checked or has already been checked. It returns false in case the transaction
+	// is not in the transaction pool.
+	RemoveTx(txHash hash.Hash) bool
+
+	// GetTransactions returns information about all transactions in the transaction pool.
+	GetTransactions() []*TransactionInfo
+
 	// GetScheduledBatch returns a batch of transactions ready for scheduling.
 	GetScheduledBatch(force bool) []*transaction.CheckedTransaction
 
@@ -158,6 +209,11 @@ type txPool struct {
 	// staleCache maps from transaction hashes to *transaction.CheckedTransaction. It is populated
 	// when clearing the txpool and consulted only when fetching known batches.
 	staleCache *lru.Cache
+	// receivedCache maps from transaction hashes to time.Time that specifies when a transaction
+	// that passed checks was first received.
+	receivedCache *lru.Cache
+	// executedTxs is the index of transactions executed in recent rounds.
+	executedTxs *executedTxIndex
 
 	checkTxCh       *channels.RingChannel
 	checkTxQueue    *checkTxQueue
@@ -215,23 +271,53 @@ func (t *txPool) SubmitTx(ctx context.Context, rawTx []byte, meta *TransactionMe
 	}
 }
 
+func (t *txPool) CheckTx(ctx context.Context, rawTx []byte) (*protocol.CheckTxResult, error) {
+	rr, err := t.host.WaitHostedRuntime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bi, err := t.getCurrentBlockInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	return rr.CheckTxSingle(ctx, bi.RuntimeBlock, bi.ConsensusBlock, bi.Epoch, bi.ActiveDescriptor.Executor.MaxMessages, rawTx)
+}
+
 func (t *txPool) SubmitTxNoWait(ctx context.Context, tx []byte, meta *TransactionMeta) error {
 	return t.submitTx(ctx, tx, meta, nil)
 }
 
 func (t *txPool) submitTx(ctx context.Context, rawTx []byte, meta *TransactionMeta, notifyCh chan *protocol.CheckTxResult) error {
-	// Skip recently seen transactions.
 	txHash := hash.NewFromBytes(rawTx)
+
+	// Reject transactions that have already been executed.
+	if etx, executed := t.executedTxs.Get(txHash); executed {
+		t.logger.Debug("rejecting already executed transaction",
+			"tx", rawTx,
+			"round", etx.Round,
+			"index", etx.Index,
+		)
+		if notifyCh != nil {
+			notifyCh <- etx.alreadyExecutedResult()
+			close(notifyCh)
+		}
+		return nil
+	}
+
+	// Skip recently seen transactions.
 	if _, seen := t.seenCache.Peek(txHash); seen && !meta.Recheck {
 		t.logger.Debug("ignoring already seen transaction", "tx", rawTx)
 		return nil
 	}
 
 	tx := &pendingTx{
-		Tx:       rawTx,
-		TxHash:   txHash,
-		Meta:     meta,
-		NotifyCh: notifyCh,
+		Tx:         rawTx,
+		TxHash:     txHash,
+		Meta:       meta,
+		NotifyCh:   notifyCh,
+		ReceivedAt: time.Now(),
 	}
 
 	// Queue transaction for checks.
@@ -267,6 +353,74 @@ func (t *txPool) RemoveTxBatch(txs []hash.Hash) {
 	pendingScheduleSize.With(t.getMetricLabels()).Set(float64(t.scheduler.UnscheduledSize()))
 }
 
+func (t *txPool) RemoveExecutedTxBatch(round uint64, txs []*ExecutedTransaction) {
+	hashes := make([]hash.Hash, 0, len(txs))
+	for _, tx := range txs {
+		hashes = append(hashes, tx.Hash)
+	}
+	t.RemoveTxBatch(hashes)
+
+	t.executedTxs.Add(round, txs)
+}
+
+func (t *txPool) GetExecutedTx(txHash hash.Hash) (*ExecutedTransaction, bool) {
+	return t.executedTxs.Get(txHash)
+}
+
+func (t *txPool) RemoveTx(txHash hash.Hash) bool {
+	removed := t.checkTxQueue.Remove(txHash)
+	pendingCheckSize.With(t.getMetricLabels()).Set(float64(t.PendingCheckSize()))
+
+	t.schedulerLock.Lock()
+	defer t.schedulerLock.Unlock()
+
+	if t.scheduler != nil && t.scheduler.IsQueued(txHash) {
+		t.scheduler.RemoveTxBatch([]hash.Hash{txHash})
+		removed = true
+
+		pendingScheduleSize.With(t.getMetricLabels()).Set(float64(t.scheduler.UnscheduledSize()))
+	}
+	_ = t.receivedCache.Remove(txHash)
+
+	return removed
+}
+
+func (t *txPool) GetTransactions() []*TransactionInfo {
+	var txs []*TransactionInfo
+	for _, tx := range t.checkTxQueue.GetAll() {
+		// Transactions being rechecked are reported as checked below.
+		if tx.Meta.Recheck {
+			continue
+		}
+		txs = append(txs, &TransactionInfo{
+			Hash:       tx.TxHash,
+			Size:       uint64(len(tx.Tx)),
+			Status:     TransactionStatusPendingCheck,
+			ReceivedAt: tx.ReceivedAt,
+		})
+	}
+
+	t.schedulerLock.Lock()
+	defer t.schedulerLock.Unlock()
+
+	if t.scheduler == nil {
+		return txs
+	}
+	for _, tx := range t.scheduler.GetTransactions(0) {
+		info := &TransactionInfo{
+			Hash:     tx.Hash(),
+			Size:     tx.Size(),
+			Priority: tx.Priority(),
+			Status:   TransactionStatusChecked,
+		}
+		if receivedAt, ok := t.receivedCache.Peek(tx.Hash()); ok {
+			info.ReceivedAt = receivedAt.(time.Time)
+		}
+		txs = append(txs, info)
+	}
+	return txs
+}
+
 func (t *txPool) GetScheduledBatch(force bool) []*transaction.CheckedTransaction {
 	t.schedulerLock.Lock()
 	defer t.schedulerLock.Unlock()
@@ -416,6 +570,7 @@ func (t *txPool) Clear() {
 		t.scheduler.Clear()
 	}
 	t.seenCache.Clear()
+	t.receivedCache.Clear()
 
 	pendingScheduleSize.With(t.getMetricLabels()).Set(0)
 }
@@ -493,6 +648,7 @@ func (t *txPool) checkTxBatch(ctx context.Context, rr host.RichRuntime) {
 
 	txs := make([]*transaction.CheckedTransaction, 0, len(results))
 	isLocal := make([]bool, 0, len(results))
+	receivedAt := make([]time.Time, 0, len(results))
 	var unschedule []hash.Hash
 	for i, res := range results {
 		// Send back the result of running the checks.
@@ -521,6 +677,7 @@ func (t *txPool) checkTxBatch(ctx context.Context, rr host.RichRuntime) {
 
 		txs = append(txs, res.ToCheckedTransaction(rawTxBatch[i]))
 		isLocal = append(isLocal, batch[i].Meta.Local)
+		receivedAt = append(receivedAt, batch[i].ReceivedAt)
 	}
 
 	// Unschedule any transactions that are being rechecked and have failed checks.
@@ -543,6 +700,7 @@ func (t *txPool) checkTxBatch(ctx context.Context, rr host.RichRuntime) {
 			t.logger.Error("unable to schedule transaction", "tx", tx)
 			continue
 		}
+		_ = t.receivedCache.Put(tx.Hash(), receivedAt[i])
 		t.schedulerLock.Unlock()
 
 		// Publish local transactions immediately.
@@ -805,6 +963,11 @@ func New(
 		return nil, fmt.Errorf("error creating stale cache: %w", err)
 	}
 
+	receivedCache, err := lru.New(lru.Capacity(cfg.MaxPoolSize, false))
+	if err != nil {
+		return nil, fmt.Errorf("error creating received cache: %w", err)
+	}
+
 	return &txPool{
 		logger:            logging.GetLogger("runtime/txpool"),
 		stopCh:            make(chan struct{}),
@@ -816,6 +979,8 @@ func New(
 		txPublisher:       txPublisher,
 		seenCache:         seenCache,
 		staleCache:        staleCache,
+		receivedCache:     receivedCache,
+		executedTxs:       newExecutedTxIndex(cfg.MaxExecutedTxIndexRounds),
 		checkTxQueue:      newCheckTxQueue(cfg.MaxPoolSize, cfg.MaxCheckTxBatchSize),
 		checkTxCh:         channels.NewRingChannel(1),
 		checkTxNotifier:   pubsub.NewBroker(false),
@@ -0,0 +1,97 @@
+package txpool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/mock"
+)
+
+type testHostProvisioner struct {
+	rr host.RichRuntime
+}
+
+func (p *testHostProvisioner) WaitHostedRuntime(ctx context.Context) (host.RichRuntime, error) {
+	return p.rr, nil
+}
+
+type testTxPublisher struct{}
+
+func (p *testTxPublisher) PublishTx(ctx context.Context, tx []byte) error {
+	return nil
+}
+
+func (p *testTxPublisher) GetMinRepublishInterval() time.Duration {
+	return time.Minute
+}
+
+func newTestTxPool(t *testing.T) (*txPool, *mock.Provisioner) {
+	require := require.New(t)
+
+	p := mock.NewProgrammable()
+	rt, err := p.NewRuntime(context.Background(), host.Config{})
+	require.NoError(err, "NewRuntime")
+
+	var runtimeID common.Namespace
+	tp, err := New(runtimeID, &Config{
+		MaxPoolSize:          100,
+		MaxCheckTxBatchSize:  10,
+		MaxLastSeenCacheSize: 100,
+		MaxStaleCacheSize:    100,
+		RepublishInterval:    time.Minute,
+	}, &testHostProvisioner{host.NewRichRuntime(rt)}, &testTxPublisher{})
+	require.NoError(err, "New")
+
+	return tp.(*txPool), p
+}
+
+func TestCheckTxSingle(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	tp, p := newTestTxPool(t)
+
+	// Checks should fail while no block is available.
+	_, err := tp.CheckTx(ctx, []byte("tx"))
+	require.Error(err, "CheckTx should fail without a current block")
+	require.Zero(p.Calls(mock.MethodCheckTx), "runtime should not be called without a current block")
+
+	var blk block.Block
+	blk.Header.Round = 1
+	tp.blockInfo = &BlockInfo{
+		RuntimeBlock:   &blk,
+		ConsensusBlock: &consensus.LightBlock{Height: 10},
+		Epoch:          2,
+		ActiveDescriptor: &registry.Runtime{
+			Executor: registry.ExecutorParameters{MaxMessages: 32},
+		},
+	}
+
+	result, err := tp.CheckTx(ctx, []byte("tx"))
+	require.NoError(err, "CheckTx")
+	require.True(result.IsSuccess(), "transaction should pass the check")
+
+	result, err = tp.CheckTx(ctx, mock.CheckTxFailInput)
+	require.NoError(err, "CheckTx")
+	require.False(result.IsSuccess(), "transaction should fail the check")
+
+	// Single transaction checks should bypass the batch check queue.
+	require.Equal(2, p.Calls(mock.MethodCheckTx), "runtime should be called for each check")
+	require.Zero(p.Calls(mock.MethodCheckTxBatch), "batch checks should not be used")
+	require.Zero(tp.PendingCheckSize(), "checked transactions should not be queued")
+
+	rq := p.Requests(mock.MethodCheckTx)[0].RuntimeCheckTxRequest
+	require.EqualValues([]byte("tx"), rq.Input)
+	require.EqualValues(1, rq.Block.Header.Round)
+	require.EqualValues(10, rq.ConsensusBlock.Height)
+	require.EqualValues(2, rq.Epoch)
+	require.EqualValues(32, rq.MaxMessages)
+}
@@ -13,9 +13,9 @@ import (
 
 var (
 	// AddressV0Context is the unique context for v0 staking account addresses.
-	AddressV0Context = address.NewContext("oasis-core/address: staking", 0)
+	AddressV0Context = address.StakingV0Context
 	// AddressRuntimeV0Context is the unique context for v0 runtime account addresses.
-	AddressRuntimeV0Context = address.NewContext("oasis-core/address: runtime", 0)
+	AddressRuntimeV0Context = address.RuntimeV0Context
 	// AddressBech32HRP is the unique human readable part of Bech32 encoded
 	// staking account addresses.
 	AddressBech32HRP = address.NewBech32HRP("oasis")
@@ -88,14 +88,24 @@ func (a Address) IsValid() bool {
 
 // NewAddress creates a new address from the given public key, i.e. entity ID.
 func NewAddress(pk signature.PublicKey) (a Address) {
-	pkData, _ := pk.MarshalBinary()
-	return (Address)(address.NewAddress(AddressV0Context, pkData))
+	return (Address)(address.NewStakingAddress(pk))
 }
 
 // NewRuntimeAddress creates a new runtime address for the given runtime ID.
 func NewRuntimeAddress(id common.Namespace) (a Address) {
-	nsData, _ := id.MarshalBinary()
-	return (Address)(address.NewAddress(AddressRuntimeV0Context, nsData))
+	return (Address)(address.NewRuntimeAddress(id))
+}
+
+// NewModuleAddress creates a new address for an account of the given kind owned by the given
+// module.
+func NewModuleAddress(module, kind string) (a Address) {
+	return (Address)(address.NewModuleAddress(module, kind))
+}
+
+// NewRuntimeMessageAddress creates a new address for an account originating runtime messages on
+// behalf of the given runtime-specific origin.
+func NewRuntimeMessageAddress(id common.Namespace, origin []byte) (a Address) {
+	return (Address)(address.NewRuntimeMessageAddress(id, origin))
 }
 
 // NewReservedAddress creates a new reserved address from the given public key
@@ -115,3 +125,14 @@ func NewReservedAddress(pk signature.PublicKey) (a Address) {
 
 	return addr
 }
+
+// NewReservedModuleAddress creates a new reserved address for an account of the given kind owned
+// by the given module or panics.
+func NewReservedModuleAddress(module, kind string) (a Address) {
+	addr := NewModuleAddress(module, kind)
+	if err := addr.Reserve(); err != nil {
+		panic(err)
+	}
+
+	return addr
+}
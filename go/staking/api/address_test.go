@@ -58,3 +58,17 @@ func TestRuntimeAddress(t *testing.T) {
 	addrPk1 := NewAddress(pk1)
 	require.NotEqualValues(addr1, addrPk1, "runtime addresses should be separated from staking addresses")
 }
+
+func TestModuleAddress(t *testing.T) {
+	require := require.New(t)
+
+	addr := NewModuleAddress("address test", "pool")
+	require.True(addr.IsValid(), "module address should be valid")
+	require.EqualValues(addr, NewModuleAddress("address test", "pool"), "module address derivation should be deterministic")
+
+	reserved := NewReservedModuleAddress("address test", "reserved pool")
+	require.True(reserved.IsReserved(), "reserved module address should be reserved")
+	require.False(reserved.IsValid(), "reserved module address should be invalid")
+	require.Panics(func() { NewReservedModuleAddress("address test", "reserved pool") },
+		"reserving the same module address twice should panic")
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/proof"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 )
@@ -77,6 +78,18 @@ var (
 	// consensus parameters.
 	ErrUnderMinDelegationAmount = errors.New(ModuleName, 8, "staking: amount is lower than the minimum delegation amount")
 
+	// ErrTooManyRedelegations is the error returned when the number of pending re-delegations
+	// per account would exceed the maximum allowed number.
+	ErrTooManyRedelegations = errors.New(ModuleName, 9, "staking: too many redelegations")
+
+	// ErrUnderMinTransferAmount is the error returned when the given transfer amount is lower
+	// than the minimum transfer amount specified in the consensus parameters.
+	ErrUnderMinTransferAmount = errors.New(ModuleName, 10, "staking: amount is lower than the minimum transfer amount")
+
+	// ErrUnderMinBalance is the error returned when the remaining general balance of an account
+	// would be lower than the minimum balance specified in the consensus parameters.
+	ErrUnderMinBalance = errors.New(ModuleName, 11, "staking: remaining balance is lower than the minimum balance")
+
 	// MethodTransfer is the method name for transfers.
 	MethodTransfer = transaction.NewMethodName(ModuleName, "Transfer", Transfer{})
 	// MethodBurn is the method name for burns.
@@ -89,8 +102,11 @@ var (
 	MethodAmendCommissionSchedule = transaction.NewMethodName(ModuleName, "AmendCommissionSchedule", AmendCommissionSchedule{})
 	// MethodAllow is the method name for setting a beneficiary allowance.
 	MethodAllow = transaction.NewMethodName(ModuleName, "Allow", Allow{})
-	// MethodWithdraw is the method name for
+	// MethodWithdraw is the method name for withdrawing from an account using a beneficiary
+	// allowance.
 	MethodWithdraw = transaction.NewMethodName(ModuleName, "Withdraw", Withdraw{})
+	// MethodRedelegate is the method name for moving escrow from one account to another.
+	MethodRedelegate = transaction.NewMethodName(ModuleName, "Redelegate", Redelegate{})
 
 	// Methods is the list of all methods supported by the staking backend.
 	Methods = []transaction.MethodName{
@@ -101,6 +117,7 @@ var (
 		MethodAmendCommissionSchedule,
 		MethodAllow,
 		MethodWithdraw,
+		MethodRedelegate,
 	}
 
 	_ prettyprint.PrettyPrinter = (*Transfer)(nil)
@@ -110,6 +127,7 @@ var (
 	_ prettyprint.PrettyPrinter = (*AmendCommissionSchedule)(nil)
 	_ prettyprint.PrettyPrinter = (*Allow)(nil)
 	_ prettyprint.PrettyPrinter = (*Withdraw)(nil)
+	_ prettyprint.PrettyPrinter = (*Redelegate)(nil)
 	_ prettyprint.PrettyPrinter = (*SharePool)(nil)
 	_ prettyprint.PrettyPrinter = (*StakeThreshold)(nil)
 	_ prettyprint.PrettyPrinter = (*StakeAccumulator)(nil)
@@ -127,6 +145,10 @@ type Backend interface {
 	// 1 token = 10**TokenValueExponent base units.
 	TokenValueExponent(ctx context.Context) (uint8, error)
 
+	// TokenMetadata returns the token metadata (ticker symbol and value
+	// base-10 exponent) at the specified block height.
+	TokenMetadata(ctx context.Context, height int64) (*token.Metadata, error)
+
 	// TotalSupply returns the total number of base units.
 	TotalSupply(ctx context.Context, height int64) (*quantity.Quantity, error)
 
@@ -149,6 +171,10 @@ type Backend interface {
 	// Account returns the account descriptor for the given account.
 	Account(ctx context.Context, query *OwnerQuery) (*Account, error)
 
+	// AccountWithProof returns the account descriptor for the given account together with
+	// a proof of the account state against the consensus state root.
+	AccountWithProof(ctx context.Context, query *OwnerQuery) (*AccountWithProof, error)
+
 	// DelegationsFor returns the list of (outgoing) delegations for the given
 	// owner (delegator).
 	DelegationsFor(ctx context.Context, query *OwnerQuery) (map[Address]*Delegation, error)
@@ -173,6 +199,10 @@ type Backend interface {
 	// delegations to the given account.
 	DebondingDelegationsTo(ctx context.Context, query *OwnerQuery) (map[Address][]*DebondingDelegation, error)
 
+	// RedelegationsFor returns the list of pending re-delegations for the
+	// given owner (delegator), keyed by the source escrow account.
+	RedelegationsFor(ctx context.Context, query *OwnerQuery) (map[Address][]*Redelegation, error)
+
 	// Allowance looks up the allowance for the given owner/beneficiary combination.
 	Allowance(ctx context.Context, query *AllowanceQuery) (*quantity.Quantity, error)
 
@@ -182,9 +212,21 @@ type Backend interface {
 	// ConsensusParameters returns the staking consensus parameters.
 	ConsensusParameters(ctx context.Context, height int64) (*ConsensusParameters, error)
 
+	// Evidence returns the evidence of validator misbehavior at the consensus layer that has been
+	// processed during the last debonding interval.
+	Evidence(ctx context.Context, height int64) ([]*EvidenceEvent, error)
+
+	// FeeStatistics returns the fee statistics of recent blocks containing transactions which
+	// can be used for fee estimation.
+	FeeStatistics(ctx context.Context, height int64) (*FeeStatistics, error)
+
 	// GetEvents returns the events at specified block height.
 	GetEvents(ctx context.Context, height int64) ([]*Event, error)
 
+	// GetEventsFrom returns a page of events starting at the given cursor,
+	// spanning multiple block heights if needed.
+	GetEventsFrom(ctx context.Context, query *EventsQuery) (*EventsPage, error)
+
 	// WatchEvents returns a channel that produces a stream of Events.
 	WatchEvents(ctx context.Context) (<-chan *Event, pubsub.ClosableSubscription, error)
 
@@ -204,6 +246,14 @@ type OwnerQuery struct {
 	Owner  Address `json:"owner"`
 }
 
+// AccountWithProof is an account descriptor together with a proof of the account state.
+type AccountWithProof struct {
+	// Account is the account descriptor.
+	Account *Account `json:"account"`
+	// Proof is the proof of the account state against the consensus state root.
+	Proof *proof.StateProof `json:"proof"`
+}
+
 // AllowanceQuery is an allowance query.
 type AllowanceQuery struct {
 	Height      int64   `json:"height"`
@@ -241,6 +291,9 @@ type EscrowEvent struct {
 	Take           *TakeEscrowEvent           `json:"take,omitempty"`
 	DebondingStart *DebondingStartEscrowEvent `json:"debonding_start,omitempty"`
 	Reclaim        *ReclaimEscrowEvent        `json:"reclaim,omitempty"`
+
+	RedelegationStart *RedelegationStartEscrowEvent `json:"redelegation_start,omitempty"`
+	Redelegate        *RedelegateEscrowEvent        `json:"redelegate,omitempty"`
 }
 
 // Event signifies a staking event, returned via GetEvents.
@@ -252,6 +305,9 @@ type Event struct {
 	Burn            *BurnEvent            `json:"burn,omitempty"`
 	Escrow          *EscrowEvent          `json:"escrow,omitempty"`
 	AllowanceChange *AllowanceChangeEvent `json:"allowance_change,omitempty"`
+	Evidence        *EvidenceEvent        `json:"evidence,omitempty"`
+	FeeSummary      *FeeSummaryEvent      `json:"fee_summary,omitempty"`
+	AccountReaped   *AccountReapedEvent   `json:"account_reaped,omitempty"`
 }
 
 // AddEscrowEvent is the event emitted when stake is transferred into an escrow
@@ -315,6 +371,44 @@ func (e *ReclaimEscrowEvent) EventKind() string {
 	return "reclaim_escrow"
 }
 
+// RedelegationStartEscrowEvent is the event emitted when a re-delegation has
+// started and the given number of active shares have been moved into the
+// debonding pool of the source escrow account.
+//
+// Note that the given amount is valid at the time of re-delegation start and
+// may not correspond to the final re-delegated amount in case any escrowed
+// stake is subject to slashing.
+type RedelegationStartEscrowEvent struct {
+	Owner           Address           `json:"owner"`
+	From            Address           `json:"from"`
+	To              Address           `json:"to"`
+	Amount          quantity.Quantity `json:"amount"`
+	ActiveShares    quantity.Quantity `json:"active_shares"`
+	DebondingShares quantity.Quantity `json:"debonding_shares"`
+	DebondEndTime   beacon.EpochTime  `json:"debond_end_time"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *RedelegationStartEscrowEvent) EventKind() string {
+	return "redelegation_start"
+}
+
+// RedelegateEscrowEvent is the event emitted when a re-delegation completes
+// after the debonding period and the stake has been escrowed to the
+// destination account.
+type RedelegateEscrowEvent struct {
+	Owner     Address           `json:"owner"`
+	From      Address           `json:"from"`
+	To        Address           `json:"to"`
+	Amount    quantity.Quantity `json:"amount"`
+	NewShares quantity.Quantity `json:"new_shares"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *RedelegateEscrowEvent) EventKind() string {
+	return "redelegate"
+}
+
 // AllowanceChangeEvent is the event emitted when allowance is changed for a beneficiary.
 type AllowanceChangeEvent struct { // nolint: maligned
 	Owner        Address           `json:"owner"`
@@ -329,6 +423,68 @@ func (e *AllowanceChangeEvent) EventKind() string {
 	return "allowance_change"
 }
 
+// EvidenceEvent is the event emitted when evidence of validator misbehavior at the consensus
+// layer has been processed.
+type EvidenceEvent struct {
+	// Reason is the slashing reason corresponding to the evidence type.
+	Reason SlashReason `json:"reason"`
+	// Height is the height at which the misbehavior occurred.
+	Height int64 `json:"height"`
+	// Epoch is the epoch in which the evidence has been processed.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// NodeID is the identifier of the misbehaving node.
+	NodeID signature.PublicKey `json:"node_id"`
+	// EntityID is the identifier of the entity controlling the misbehaving node.
+	EntityID signature.PublicKey `json:"entity_id"`
+	// Amount is the amount of stake slashed from the entity's escrow account. It is zero in case
+	// the node has already been frozen.
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *EvidenceEvent) EventKind() string {
+	return "evidence"
+}
+
+// FeeSummaryEvent is the event emitted at the end of each block containing transactions and
+// summarizes the fees paid in that block.
+type FeeSummaryEvent struct {
+	// Height is the height of the block.
+	Height int64 `json:"height"`
+	// NumTransactions is the number of transactions in the block.
+	NumTransactions uint64 `json:"num_transactions"`
+	// TotalFees is the total amount of fees paid in the block, excluding tips.
+	TotalFees quantity.Quantity `json:"total_fees"`
+	// TotalTips is the total amount of tips paid in the block.
+	TotalTips quantity.Quantity `json:"total_tips"`
+	// MinGasPrice is the minimum effective gas price (including tips) paid in the block.
+	MinGasPrice quantity.Quantity `json:"min_gas_price"`
+	// MedianGasPrice is the median effective gas price (including tips) paid in the
+	// block.
+	MedianGasPrice quantity.Quantity `json:"median_gas_price"`
+	// MaxGasPrice is the maximum effective gas price (including tips) paid in the block.
+	MaxGasPrice quantity.Quantity `json:"max_gas_price"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *FeeSummaryEvent) EventKind() string {
+	return "fee_summary"
+}
+
+// AccountReapedEvent is the event emitted when a dust account has been removed from state. The
+// remaining general balance of the account (if any) has been transferred to the common pool.
+type AccountReapedEvent struct {
+	// Account is the address of the removed account.
+	Account Address `json:"account"`
+	// Amount is the general balance of the account that has been transferred to the common pool.
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *AccountReapedEvent) EventKind() string {
+	return "account_reaped"
+}
+
 // Transfer is a stake transfer.
 type Transfer struct {
 	To     Address           `json:"to"`
@@ -506,11 +662,40 @@ func (wt Withdraw) PrettyType() (interface{}, error) {
 	return wt, nil
 }
 
-// NewWithdrawTx creates a new beneficiary allowance configuration transaction.
+// NewWithdrawTx creates a new beneficiary allowance withdrawal transaction.
 func NewWithdrawTx(nonce uint64, fee *transaction.Fee, withdraw *Withdraw) *transaction.Transaction {
 	return transaction.NewTransaction(nonce, fee, MethodWithdraw, withdraw)
 }
 
+// Redelegate is a re-delegation of stake from one escrow account to another.
+//
+// The given active shares start debonding in the source escrow account and
+// the resulting stake is escrowed to the destination account once the
+// debonding period ends, without passing through the general balance.
+type Redelegate struct {
+	From   Address           `json:"from"`
+	To     Address           `json:"to"`
+	Shares quantity.Quantity `json:"shares"`
+}
+
+// PrettyPrint writes a pretty-printed representation of Redelegate to the given writer.
+func (rd Redelegate) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sFrom:   %s\n", prefix, rd.From)
+	fmt.Fprintf(w, "%sTo:     %s\n", prefix, rd.To)
+
+	fmt.Fprintf(w, "%sShares: %s\n", prefix, rd.Shares)
+}
+
+// PrettyType returns a representation of Redelegate that can be used for pretty printing.
+func (rd Redelegate) PrettyType() (interface{}, error) {
+	return rd, nil
+}
+
+// NewRedelegateTx creates a new re-delegation transaction.
+func NewRedelegateTx(nonce uint64, fee *transaction.Fee, redelegate *Redelegate) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodRedelegate, redelegate)
+}
+
 // SharePool is a combined balance of several entries, the relative sizes
 // of which are tracked through shares.
 type SharePool struct {
@@ -1055,6 +1240,16 @@ type DebondingDelegationInfo struct {
 	Pool SharePool `json:"pool"`
 }
 
+// Redelegation is a pending re-delegation descriptor.
+//
+// The shares are debonding shares of the source escrow account which are
+// also accounted for in the debonding delegation with the same end time.
+type Redelegation struct {
+	To            Address           `json:"to"`
+	Shares        quantity.Quantity `json:"shares"`
+	DebondEndTime beacon.EpochTime  `json:"debond_end"`
+}
+
 // Genesis is the initial staking state for use in the genesis block.
 type Genesis struct {
 	// Parameters are the staking consensus parameters.
@@ -1085,6 +1280,9 @@ type Genesis struct {
 	// DebondingDelegations is a nested map of staking delegations of the form:
 	// DEBONDING-DELEGATEE-ACCOUNT-ADDRESS: DEBONDING-DELEGATOR-ACCOUNT-ADDRESS: list of DEBONDING-DELEGATIONs.
 	DebondingDelegations map[Address]map[Address][]*DebondingDelegation `json:"debonding_delegations,omitempty"`
+	// Redelegations is a nested map of pending re-delegations of the form:
+	// DELEGATOR-ACCOUNT-ADDRESS: SOURCE-ESCROW-ACCOUNT-ADDRESS: list of REDELEGATIONs.
+	Redelegations map[Address]map[Address][]*Redelegation `json:"redelegations,omitempty"`
 }
 
 // ConsensusParameters are the staking consensus parameters.
@@ -1110,6 +1308,10 @@ type ConsensusParameters struct { // nolint: maligned
 	// MaxAllowances is the maximum number of allowances an account can have. Zero means disabled.
 	MaxAllowances uint32 `json:"max_allowances,omitempty"`
 
+	// MaxRedelegations is the maximum number of pending re-delegations an account can have.
+	// Zero means disabled.
+	MaxRedelegations uint32 `json:"max_redelegations,omitempty"`
+
 	// FeeSplitWeightPropose is the proportion of block fee portions that go to the proposer.
 	FeeSplitWeightPropose quantity.Quantity `json:"fee_split_weight_propose"`
 	// FeeSplitWeightVote is the proportion of block fee portions that go to the validator that votes.
@@ -1123,6 +1325,16 @@ type ConsensusParameters struct { // nolint: maligned
 	// RewardFactorBlockProposed is the factor for a reward distributed per block
 	// to the entity that proposed the block.
 	RewardFactorBlockProposed quantity.Quantity `json:"reward_factor_block_proposed"`
+
+	// MinTransfer is the minimum amount of base units that can be transferred.
+	MinTransfer quantity.Quantity `json:"min_transfer,omitempty"`
+	// MinBalance is the minimum general balance that must remain in an account after it has been
+	// debited, unless the balance is depleted entirely.
+	MinBalance quantity.Quantity `json:"min_balance,omitempty"`
+	// ReapDustAccounts enables removal of dust accounts from state at epoch boundaries. An account
+	// is considered dust in case its general balance is lower than MinBalance (or zero) and it has
+	// no other state (e.g., escrow, allowances or delegations).
+	ReapDustAccounts bool `json:"reap_dust_accounts,omitempty"`
 }
 
 const (
@@ -1140,4 +1352,6 @@ const (
 	GasOpAllow transaction.Op = "allow"
 	// GasOpWithdraw is the gas operation identifier for withdraw.
 	GasOpWithdraw transaction.Op = "withdraw"
+	// GasOpRedelegate is the gas operation identifier for redelegate.
+	GasOpRedelegate transaction.Op = "redelegate"
 )
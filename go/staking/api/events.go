@@ -0,0 +1,180 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+)
+
+// MaxEventsLimit is the maximum number of events that can be returned by a
+// single GetEventsFrom query.
+const MaxEventsLimit = 1000
+
+// MaxEventsHeights is the maximum number of block heights that are scanned by
+// a single GetEventsFrom query.
+const MaxEventsHeights = 100
+
+// EventBody is the interface implemented by all typed staking event bodies.
+type EventBody interface {
+	// EventKind returns a string representation of this event's kind.
+	EventKind() string
+}
+
+var eventBodyKinds = map[string]func() EventBody{
+	(&TransferEvent{}).EventKind():                func() EventBody { return &TransferEvent{} },
+	(&BurnEvent{}).EventKind():                    func() EventBody { return &BurnEvent{} },
+	(&AddEscrowEvent{}).EventKind():               func() EventBody { return &AddEscrowEvent{} },
+	(&TakeEscrowEvent{}).EventKind():              func() EventBody { return &TakeEscrowEvent{} },
+	(&DebondingStartEscrowEvent{}).EventKind():    func() EventBody { return &DebondingStartEscrowEvent{} },
+	(&ReclaimEscrowEvent{}).EventKind():           func() EventBody { return &ReclaimEscrowEvent{} },
+	(&RedelegationStartEscrowEvent{}).EventKind(): func() EventBody { return &RedelegationStartEscrowEvent{} },
+	(&RedelegateEscrowEvent{}).EventKind():        func() EventBody { return &RedelegateEscrowEvent{} },
+	(&AllowanceChangeEvent{}).EventKind():         func() EventBody { return &AllowanceChangeEvent{} },
+	(&EvidenceEvent{}).EventKind():                func() EventBody { return &EvidenceEvent{} },
+	(&FeeSummaryEvent{}).EventKind():              func() EventBody { return &FeeSummaryEvent{} },
+	(&AccountReapedEvent{}).EventKind():           func() EventBody { return &AccountReapedEvent{} },
+}
+
+// EventKinds returns the sorted list of all staking event kinds.
+func EventKinds() []string {
+	kinds := make([]string, 0, len(eventBodyKinds))
+	for kind := range eventBodyKinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// DecodeEventBody decodes a CBOR-encoded staking event body of the given kind.
+func DecodeEventBody(kind string, raw []byte) (EventBody, error) {
+	newBody, ok := eventBodyKinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("staking: unknown event kind: %s", kind)
+	}
+
+	body := newBody()
+	if err := cbor.Unmarshal(raw, body); err != nil {
+		return nil, fmt.Errorf("staking: corrupt %s event: %w", kind, err)
+	}
+	return body, nil
+}
+
+// NewEvent creates a new staking event with the given typed body.
+func NewEvent(height int64, txHash hash.Hash, body EventBody) (*Event, error) {
+	ev := &Event{
+		Height: height,
+		TxHash: txHash,
+	}
+
+	switch b := body.(type) {
+	case *TransferEvent:
+		ev.Transfer = b
+	case *BurnEvent:
+		ev.Burn = b
+	case *AddEscrowEvent:
+		ev.Escrow = &EscrowEvent{Add: b}
+	case *TakeEscrowEvent:
+		ev.Escrow = &EscrowEvent{Take: b}
+	case *DebondingStartEscrowEvent:
+		ev.Escrow = &EscrowEvent{DebondingStart: b}
+	case *ReclaimEscrowEvent:
+		ev.Escrow = &EscrowEvent{Reclaim: b}
+	case *RedelegationStartEscrowEvent:
+		ev.Escrow = &EscrowEvent{RedelegationStart: b}
+	case *RedelegateEscrowEvent:
+		ev.Escrow = &EscrowEvent{Redelegate: b}
+	case *AllowanceChangeEvent:
+		ev.AllowanceChange = b
+	case *EvidenceEvent:
+		ev.Evidence = b
+	case *FeeSummaryEvent:
+		ev.FeeSummary = b
+	case *AccountReapedEvent:
+		ev.AccountReaped = b
+	default:
+		return nil, fmt.Errorf("staking: unsupported event body type: %T", body)
+	}
+	return ev, nil
+}
+
+// Body returns the typed body of the event or nil if the event is empty.
+func (e *Event) Body() EventBody {
+	switch {
+	case e.Transfer != nil:
+		return e.Transfer
+	case e.Burn != nil:
+		return e.Burn
+	case e.Escrow != nil:
+		switch {
+		case e.Escrow.Add != nil:
+			return e.Escrow.Add
+		case e.Escrow.Take != nil:
+			return e.Escrow.Take
+		case e.Escrow.DebondingStart != nil:
+			return e.Escrow.DebondingStart
+		case e.Escrow.Reclaim != nil:
+			return e.Escrow.Reclaim
+		case e.Escrow.RedelegationStart != nil:
+			return e.Escrow.RedelegationStart
+		case e.Escrow.Redelegate != nil:
+			return e.Escrow.Redelegate
+		}
+	case e.AllowanceChange != nil:
+		return e.AllowanceChange
+	case e.Evidence != nil:
+		return e.Evidence
+	case e.FeeSummary != nil:
+		return e.FeeSummary
+	case e.AccountReaped != nil:
+		return e.AccountReaped
+	}
+	return nil
+}
+
+// Kind returns the kind of the event or an empty string if the event is empty.
+func (e *Event) Kind() string {
+	body := e.Body()
+	if body == nil {
+		return ""
+	}
+	return body.EventKind()
+}
+
+// EventCursor is a position in the stream of staking events.
+//
+// Events at each height are ordered in the order in which they were emitted
+// during block execution (begin block, transactions in block order, end
+// block) and the cursor index refers to the position of the event in this
+// ordering.
+type EventCursor struct {
+	// Height is the block height.
+	Height int64 `json:"height"`
+	// Index is the index of the event within the block.
+	Index uint64 `json:"index"`
+}
+
+// EventsQuery is a cursor-based staking events query.
+type EventsQuery struct {
+	// Cursor is the position of the first event to return.
+	Cursor EventCursor `json:"cursor"`
+	// Limit is the maximum number of events to return. If zero or greater
+	// than MaxEventsLimit, MaxEventsLimit is used.
+	Limit uint64 `json:"limit,omitempty"`
+	// Kinds is the optional set of event kinds to return. If empty, events
+	// of all kinds are returned.
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+// EventsPage is a page of staking events returned by a cursor-based query.
+//
+// A page may contain fewer events than requested (or none at all) even if
+// more events are available, in which case the query should be repeated
+// with the returned cursor.
+type EventsPage struct {
+	// Events are the returned events.
+	Events []*Event `json:"events"`
+	// Next is the cursor of the first event that has not been scanned yet.
+	Next EventCursor `json:"next"`
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+func TestEventBodies(t *testing.T) {
+	require := require.New(t)
+
+	var txHash hash.Hash
+	txHash.FromBytes([]byte("tx"))
+
+	bodies := []EventBody{
+		&TransferEvent{From: CommonPoolAddress, To: FeeAccumulatorAddress, Amount: *quantity.NewFromUint64(1)},
+		&BurnEvent{Owner: CommonPoolAddress, Amount: *quantity.NewFromUint64(2)},
+		&AddEscrowEvent{Owner: CommonPoolAddress, Escrow: FeeAccumulatorAddress},
+		&TakeEscrowEvent{Owner: CommonPoolAddress},
+		&DebondingStartEscrowEvent{Owner: CommonPoolAddress, DebondEndTime: 42},
+		&ReclaimEscrowEvent{Owner: CommonPoolAddress},
+		&RedelegationStartEscrowEvent{Owner: CommonPoolAddress, DebondEndTime: 42},
+		&RedelegateEscrowEvent{Owner: CommonPoolAddress},
+		&AllowanceChangeEvent{Owner: CommonPoolAddress, Negative: true},
+		&EvidenceEvent{Height: 10},
+		&FeeSummaryEvent{Height: 10, NumTransactions: 3},
+		&AccountReapedEvent{Account: CommonPoolAddress},
+	}
+	require.Len(EventKinds(), len(bodies), "all event kinds should be covered")
+
+	for _, body := range bodies {
+		kind := body.EventKind()
+
+		decoded, err := DecodeEventBody(kind, cbor.Marshal(body))
+		require.NoError(err, "DecodeEventBody(%s)", kind)
+		require.EqualValues(body, decoded, "DecodeEventBody(%s)", kind)
+
+		ev, err := NewEvent(10, txHash, decoded)
+		require.NoError(err, "NewEvent(%s)", kind)
+		require.EqualValues(10, ev.Height, "NewEvent(%s): height", kind)
+		require.EqualValues(txHash, ev.TxHash, "NewEvent(%s): tx hash", kind)
+		require.Equal(kind, ev.Kind(), "Kind(%s)", kind)
+		require.EqualValues(body, ev.Body(), "Body(%s)", kind)
+	}
+
+	_, err := DecodeEventBody("not_an_event", cbor.Marshal(42))
+	require.Error(err, "DecodeEventBody should fail for unknown kinds")
+	_, err = DecodeEventBody((&TransferEvent{}).EventKind(), []byte("garbage"))
+	require.Error(err, "DecodeEventBody should fail for corrupt bodies")
+
+	require.Empty((&Event{}).Kind(), "empty event should have no kind")
+	require.Nil((&Event{Escrow: &EscrowEvent{}}).Body(), "empty escrow event should have no body")
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+// FeeStatisticsWindow is the number of most recent blocks for which fee summaries are kept in
+// state and used to compute the fee statistics.
+const FeeStatisticsWindow = 100
+
+// FeeStatistics are the fee statistics of recent blocks containing transactions.
+type FeeStatistics struct {
+	// Blocks are the fee summaries of recent blocks containing transactions, ordered by height.
+	Blocks []*FeeSummaryEvent `json:"blocks"`
+
+	// MinGasPrice is the minimum effective gas price paid in any of the recent blocks.
+	MinGasPrice quantity.Quantity `json:"min_gas_price"`
+	// MedianGasPrice is the median of the median effective gas prices of the recent blocks.
+	MedianGasPrice quantity.Quantity `json:"median_gas_price"`
+	// MaxGasPrice is the maximum effective gas price paid in any of the recent blocks.
+	MaxGasPrice quantity.Quantity `json:"max_gas_price"`
+}
+
+// NewFeeSummaryEvent creates a new fee summary for a block given the fees and tips paid in the
+// block and the effective gas prices of all of its transactions.
+func NewFeeSummaryEvent(
+	height int64,
+	totalFees *quantity.Quantity,
+	totalTips *quantity.Quantity,
+	gasPrices []*quantity.Quantity,
+) *FeeSummaryEvent {
+	ev := &FeeSummaryEvent{
+		Height:          height,
+		NumTransactions: uint64(len(gasPrices)),
+		TotalFees:       *totalFees.Clone(),
+		TotalTips:       *totalTips.Clone(),
+	}
+	ev.MinGasPrice, ev.MedianGasPrice, ev.MaxGasPrice = quantityStats(gasPrices)
+	return ev
+}
+
+// NewFeeStatistics computes the fee statistics from the given per-block fee summaries.
+func NewFeeStatistics(blocks []*FeeSummaryEvent) *FeeStatistics {
+	stats := &FeeStatistics{
+		Blocks: blocks,
+	}
+	if len(blocks) == 0 {
+		return stats
+	}
+
+	medians := make([]*quantity.Quantity, 0, len(blocks))
+	for _, b := range blocks {
+		medians = append(medians, &b.MedianGasPrice)
+	}
+	_, stats.MedianGasPrice, _ = quantityStats(medians)
+
+	stats.MinGasPrice = blocks[0].MinGasPrice
+	stats.MaxGasPrice = blocks[0].MaxGasPrice
+	for _, b := range blocks[1:] {
+		if b.MinGasPrice.Cmp(&stats.MinGasPrice) < 0 {
+			stats.MinGasPrice = b.MinGasPrice
+		}
+		if b.MaxGasPrice.Cmp(&stats.MaxGasPrice) > 0 {
+			stats.MaxGasPrice = b.MaxGasPrice
+		}
+	}
+	return stats
+}
+
+// quantityStats returns the minimum, median and maximum of the given quantities.
+func quantityStats(qs []*quantity.Quantity) (min, median, max quantity.Quantity) {
+	if len(qs) == 0 {
+		return
+	}
+
+	sorted := make([]*quantity.Quantity, len(qs))
+	copy(sorted, qs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+
+	min = *sorted[0].Clone()
+	max = *sorted[len(sorted)-1].Clone()
+
+	mid := len(sorted) / 2
+	median = *sorted[mid].Clone()
+	if len(sorted)%2 == 0 {
+		// Use the average of the two middle values.
+		if err := median.Add(sorted[mid-1]); err != nil {
+			// Should never happen.
+			panic(err)
+		}
+		if err := median.Quo(quantity.NewFromUint64(2)); err != nil {
+			// Should never happen.
+			panic(err)
+		}
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+func TestFeeSummary(t *testing.T) {
+	require := require.New(t)
+
+	q := quantity.NewFromUint64
+
+	ev := NewFeeSummaryEvent(42, q(1000), q(100), nil)
+	require.EqualValues(42, ev.Height)
+	require.EqualValues(0, ev.NumTransactions)
+	require.True(ev.MinGasPrice.IsZero(), "min gas price of empty block should be zero")
+	require.True(ev.MedianGasPrice.IsZero(), "median gas price of empty block should be zero")
+	require.True(ev.MaxGasPrice.IsZero(), "max gas price of empty block should be zero")
+
+	ev = NewFeeSummaryEvent(42, q(1000), q(100), []*quantity.Quantity{q(5), q(1), q(3)})
+	require.EqualValues(3, ev.NumTransactions)
+	require.EqualValues(*q(1000), ev.TotalFees)
+	require.EqualValues(*q(100), ev.TotalTips)
+	require.EqualValues(*q(1), ev.MinGasPrice)
+	require.EqualValues(*q(3), ev.MedianGasPrice)
+	require.EqualValues(*q(5), ev.MaxGasPrice)
+
+	ev = NewFeeSummaryEvent(43, q(1000), q(0), []*quantity.Quantity{q(10), q(1), q(2), q(4)})
+	require.EqualValues(4, ev.NumTransactions)
+	require.EqualValues(*q(1), ev.MinGasPrice)
+	require.EqualValues(*q(3), ev.MedianGasPrice)
+	require.EqualValues(*q(10), ev.MaxGasPrice)
+}
+
+func TestFeeStatistics(t *testing.T) {
+	require := require.New(t)
+
+	q := quantity.NewFromUint64
+
+	stats := NewFeeStatistics(nil)
+	require.Empty(stats.Blocks)
+	require.True(stats.MedianGasPrice.IsZero(), "median gas price without blocks should be zero")
+
+	blocks := []*FeeSummaryEvent{
+		NewFeeSummaryEvent(10, q(0), q(0), []*quantity.Quantity{q(2), q(4), q(6)}),
+		NewFeeSummaryEvent(11, q(0), q(0), []*quantity.Quantity{q(1), q(8)}),
+		NewFeeSummaryEvent(12, q(0), q(0), []*quantity.Quantity{q(5)}),
+	}
+	stats = NewFeeStatistics(blocks)
+	require.Len(stats.Blocks, 3)
+	require.EqualValues(*q(1), stats.MinGasPrice)
+	require.EqualValues(*q(4), stats.MedianGasPrice)
+	require.EqualValues(*q(8), stats.MaxGasPrice)
+}
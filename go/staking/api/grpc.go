@@ -8,6 +8,7 @@ import (
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 )
 
 var (
@@ -18,6 +19,8 @@ var (
 	methodTokenSymbol = serviceName.NewMethod("TokenSymbol", nil)
 	// methodTokenValueExponent is the TokenValueExponent method.
 	methodTokenValueExponent = serviceName.NewMethod("TokenValueExponent", nil)
+	// methodTokenMetadata is the TokenMetadata method.
+	methodTokenMetadata = serviceName.NewMethod("TokenMetadata", int64(0))
 	// methodTotalSupply is the TotalSupply method.
 	methodTotalSupply = serviceName.NewMethod("TotalSupply", int64(0))
 	// methodCommonPool is the CommonPool method.
@@ -32,6 +35,8 @@ var (
 	methodAddresses = serviceName.NewMethod("Addresses", int64(0))
 	// methodAccount is the Account method.
 	methodAccount = serviceName.NewMethod("Account", OwnerQuery{})
+	// methodAccountWithProof is the AccountWithProof method.
+	methodAccountWithProof = serviceName.NewMethod("AccountWithProof", OwnerQuery{})
 	// methodDelegationsFor is the DelegationsFor method.
 	methodDelegationsFor = serviceName.NewMethod("DelegationsFor", OwnerQuery{})
 	// methodDelegationInfosFor is the DelegationInfosFor method.
@@ -44,14 +49,22 @@ var (
 	methodDebondingDelegationInfosFor = serviceName.NewMethod("DebondingDelegationInfosFor", OwnerQuery{})
 	// methodDebondingDelegationsTo is the DebondingDelegationsTo method.
 	methodDebondingDelegationsTo = serviceName.NewMethod("DebondingDelegationsTo", OwnerQuery{})
+	// methodRedelegationsFor is the RedelegationsFor method.
+	methodRedelegationsFor = serviceName.NewMethod("RedelegationsFor", OwnerQuery{})
 	// methodAllowance is the Allowance method.
 	methodAllowance = serviceName.NewMethod("Allowance", AllowanceQuery{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodConsensusParameters is the ConsensusParameters method.
 	methodConsensusParameters = serviceName.NewMethod("ConsensusParameters", int64(0))
+	// methodEvidence is the Evidence method.
+	methodEvidence = serviceName.NewMethod("Evidence", int64(0))
+	// methodFeeStatistics is the FeeStatistics method.
+	methodFeeStatistics = serviceName.NewMethod("FeeStatistics", int64(0))
 	// methodGetEvents is the GetEvents method.
 	methodGetEvents = serviceName.NewMethod("GetEvents", int64(0))
+	// methodGetEventsFrom is the GetEventsFrom method.
+	methodGetEventsFrom = serviceName.NewMethod("GetEventsFrom", EventsQuery{})
 
 	// methodWatchEvents is the WatchEvents method.
 	methodWatchEvents = serviceName.NewMethod("WatchEvents", nil)
@@ -69,6 +82,10 @@ var (
 				MethodName: methodTokenValueExponent.ShortName(),
 				Handler:    handlerTokenValueExponent,
 			},
+			{
+				MethodName: methodTokenMetadata.ShortName(),
+				Handler:    handlerTokenMetadata,
+			},
 			{
 				MethodName: methodTotalSupply.ShortName(),
 				Handler:    handlerTotalSupply,
@@ -97,6 +114,10 @@ var (
 				MethodName: methodAccount.ShortName(),
 				Handler:    handlerAccount,
 			},
+			{
+				MethodName: methodAccountWithProof.ShortName(),
+				Handler:    handlerAccountWithProof,
+			},
 			{
 				MethodName: methodDelegationsFor.ShortName(),
 				Handler:    handlerDelegationsFor,
@@ -121,6 +142,10 @@ var (
 				MethodName: methodDebondingDelegationsTo.ShortName(),
 				Handler:    handlerDebondingDelegationsTo,
 			},
+			{
+				MethodName: methodRedelegationsFor.ShortName(),
+				Handler:    handlerRedelegationsFor,
+			},
 			{
 				MethodName: methodAllowance.ShortName(),
 				Handler:    handlerAllowance,
@@ -133,10 +158,22 @@ var (
 				MethodName: methodConsensusParameters.ShortName(),
 				Handler:    handlerConsensusParameters,
 			},
+			{
+				MethodName: methodEvidence.ShortName(),
+				Handler:    handlerEvidence,
+			},
+			{
+				MethodName: methodFeeStatistics.ShortName(),
+				Handler:    handlerFeeStatistics,
+			},
 			{
 				MethodName: methodGetEvents.ShortName(),
 				Handler:    handlerGetEvents,
 			},
+			{
+				MethodName: methodGetEventsFrom.ShortName(),
+				Handler:    handlerGetEventsFrom,
+			},
 		},
 		Streams: []grpc.StreamDesc{
 			{
@@ -186,6 +223,29 @@ func handlerTokenValueExponent( // nolint: golint
 	return interceptor(ctx, nil, info, handler)
 }
 
+func handlerTokenMetadata( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).TokenMetadata(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodTokenMetadata.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).TokenMetadata(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerTotalSupply( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -347,6 +407,29 @@ func handlerAccount( // nolint: golint
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerAccountWithProof( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query OwnerQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).AccountWithProof(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodAccountWithProof.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).AccountWithProof(ctx, req.(*OwnerQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerDelegationsFor( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -485,6 +568,29 @@ func handlerDebondingDelegationsTo( // nolint: golint
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerRedelegationsFor( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query OwnerQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).RedelegationsFor(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodRedelegationsFor.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).RedelegationsFor(ctx, req.(*OwnerQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerAllowance( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -554,6 +660,52 @@ func handlerConsensusParameters( // nolint: golint
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerEvidence( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).Evidence(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodEvidence.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).Evidence(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
+func handlerFeeStatistics( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).FeeStatistics(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodFeeStatistics.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).FeeStatistics(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerGetEvents( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -577,6 +729,29 @@ func handlerGetEvents( // nolint: golint
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetEventsFrom( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query EventsQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetEventsFrom(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetEventsFrom.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetEventsFrom(ctx, req.(*EventsQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerWatchEvents(srv interface{}, stream grpc.ServerStream) error {
 	if err := stream.RecvMsg(nil); err != nil {
 		return err
@@ -630,6 +805,14 @@ func (c *stakingClient) TokenValueExponent(ctx context.Context) (uint8, error) {
 	return rsp, nil
 }
 
+func (c *stakingClient) TokenMetadata(ctx context.Context, height int64) (*token.Metadata, error) {
+	var rsp token.Metadata
+	if err := c.conn.Invoke(ctx, methodTokenMetadata.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *stakingClient) TotalSupply(ctx context.Context, height int64) (*quantity.Quantity, error) {
 	var rsp quantity.Quantity
 	if err := c.conn.Invoke(ctx, methodTotalSupply.FullName(), height, &rsp); err != nil {
@@ -686,6 +869,14 @@ func (c *stakingClient) Account(ctx context.Context, query *OwnerQuery) (*Accoun
 	return &rsp, nil
 }
 
+func (c *stakingClient) AccountWithProof(ctx context.Context, query *OwnerQuery) (*AccountWithProof, error) {
+	var rsp AccountWithProof
+	if err := c.conn.Invoke(ctx, methodAccountWithProof.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *stakingClient) DelegationsFor(ctx context.Context, query *OwnerQuery) (map[Address]*Delegation, error) {
 	var rsp map[Address]*Delegation
 	if err := c.conn.Invoke(ctx, methodDelegationsFor.FullName(), query, &rsp); err != nil {
@@ -734,6 +925,14 @@ func (c *stakingClient) DebondingDelegationsTo(ctx context.Context, query *Owner
 	return rsp, nil
 }
 
+func (c *stakingClient) RedelegationsFor(ctx context.Context, query *OwnerQuery) (map[Address][]*Redelegation, error) {
+	var rsp map[Address][]*Redelegation
+	if err := c.conn.Invoke(ctx, methodRedelegationsFor.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *stakingClient) Allowance(ctx context.Context, query *AllowanceQuery) (*quantity.Quantity, error) {
 	var rsp quantity.Quantity
 	if err := c.conn.Invoke(ctx, methodAllowance.FullName(), query, &rsp); err != nil {
@@ -758,6 +957,22 @@ func (c *stakingClient) ConsensusParameters(ctx context.Context, height int64) (
 	return &rsp, nil
 }
 
+func (c *stakingClient) Evidence(ctx context.Context, height int64) ([]*EvidenceEvent, error) {
+	var rsp []*EvidenceEvent
+	if err := c.conn.Invoke(ctx, methodEvidence.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *stakingClient) FeeStatistics(ctx context.Context, height int64) (*FeeStatistics, error) {
+	var rsp FeeStatistics
+	if err := c.conn.Invoke(ctx, methodFeeStatistics.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *stakingClient) GetEvents(ctx context.Context, height int64) ([]*Event, error) {
 	var rsp []*Event
 	if err := c.conn.Invoke(ctx, methodGetEvents.FullName(), height, &rsp); err != nil {
@@ -766,6 +981,14 @@ func (c *stakingClient) GetEvents(ctx context.Context, height int64) ([]*Event,
 	return rsp, nil
 }
 
+func (c *stakingClient) GetEventsFrom(ctx context.Context, query *EventsQuery) (*EventsPage, error) {
+	var rsp EventsPage
+	if err := c.conn.Invoke(ctx, methodGetEventsFrom.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *stakingClient) WatchEvents(ctx context.Context) (<-chan *Event, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -3,7 +3,6 @@ package api
 
 import (
 	"fmt"
-	"regexp"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
@@ -37,6 +36,14 @@ func (p *ConsensusParameters) SanityCheck() error {
 		return fmt.Errorf("fee split proportions are all zero")
 	}
 
+	// Minimum amounts.
+	if !p.MinTransfer.IsValid() {
+		return fmt.Errorf("minimum transfer amount has invalid value")
+	}
+	if !p.MinBalance.IsValid() {
+		return fmt.Errorf("minimum balance has invalid value")
+	}
+
 	return nil
 }
 
@@ -239,20 +246,12 @@ func (g *Genesis) SanityCheck(now beacon.EpochTime) error { // nolint: gocyclo
 		return fmt.Errorf("staking: sanity check failed: %w", err)
 	}
 
-	tokenSymbolLength := len(g.TokenSymbol)
-	if tokenSymbolLength == 0 {
-		return fmt.Errorf("staking: sanity check failed: token symbol is empty")
-	}
-	if tokenSymbolLength > token.TokenSymbolMaxLength {
-		return fmt.Errorf("staking: sanity check failed: token symbol exceeds maximum length")
+	tokenMeta := token.Metadata{
+		Symbol:        g.TokenSymbol,
+		ValueExponent: g.TokenValueExponent,
 	}
-	match := regexp.MustCompile(token.TokenSymbolRegexp).FindString(g.TokenSymbol)
-	if match == "" {
-		return fmt.Errorf("staking: sanity check failed: token symbol should match '%s'", token.TokenSymbolRegexp)
-	}
-
-	if g.TokenValueExponent > token.TokenValueExponentMaxValue {
-		return fmt.Errorf("staking: sanity check failed: token value exponent is invalid")
+	if err := tokenMeta.ValidateBasic(); err != nil {
+		return fmt.Errorf("staking: sanity check failed: %w", err)
 	}
 
 	if !g.TotalSupply.IsValid() {
@@ -327,5 +326,63 @@ func (g *Genesis) SanityCheck(now beacon.EpochTime) error { // nolint: gocyclo
 		}
 	}
 
+	// All re-delegations must be backed by debonding delegations.
+	for delegatorAddr, redelegations := range g.Redelegations {
+		for escrowAddr, rds := range redelegations {
+			if err := SanityCheckRedelegations(delegatorAddr, escrowAddr, rds, g.DebondingDelegations[escrowAddr][delegatorAddr]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// SanityCheckRedelegations examines the pending re-delegations of a delegator out of the given
+// escrow account against its debonding delegations.
+func SanityCheckRedelegations(
+	delegatorAddr Address,
+	escrowAddr Address,
+	redelegations []*Redelegation,
+	debondingDelegations []*DebondingDelegation,
+) error {
+	if !delegatorAddr.IsValid() || !escrowAddr.IsValid() {
+		return fmt.Errorf(
+			"staking: sanity check failed: redelegation from %s by %s: address is invalid",
+			escrowAddr, delegatorAddr,
+		)
+	}
+
+	// The re-delegated shares can't exceed the debonding delegation with the same end time.
+	redelegated := make(map[beacon.EpochTime]*quantity.Quantity)
+	for _, rd := range redelegations {
+		if rd == nil {
+			return fmt.Errorf("staking: sanity check failed: redelegation from %s by %s is nil", escrowAddr, delegatorAddr)
+		}
+		if !rd.To.IsValid() || rd.To.Equal(escrowAddr) {
+			return fmt.Errorf(
+				"staking: sanity check failed: redelegation from %s by %s: destination address %s is invalid",
+				escrowAddr, delegatorAddr, rd.To,
+			)
+		}
+		if redelegated[rd.DebondEndTime] == nil {
+			redelegated[rd.DebondEndTime] = quantity.NewQuantity()
+		}
+		_ = redelegated[rd.DebondEndTime].Add(&rd.Shares)
+	}
+	for endTime, shares := range redelegated {
+		var debonding quantity.Quantity
+		for _, d := range debondingDelegations {
+			if d.DebondEndTime == endTime {
+				_ = debonding.Add(&d.Shares)
+			}
+		}
+		if shares.Cmp(&debonding) > 0 {
+			return fmt.Errorf(
+				"staking: sanity check failed: redelegations from %s by %s ending at %d (%s) exceed debonding delegation shares (%s)",
+				escrowAddr, delegatorAddr, endTime, shares, &debonding,
+			)
+		}
+	}
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
@@ -23,6 +24,23 @@ func ConvertToTokenAmount(amount quantity.Quantity, tokenValueExponent uint8) (s
 	return prettyprint.QuantityFrac(amount, tokenValueExponent), nil
 }
 
+// NewContext returns a new context carrying the token metadata so that amounts
+// are pretty-printed in tokens instead of base units.
+func (m *Metadata) NewContext(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenSymbol, m.Symbol)
+	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, m.ValueExponent)
+	return ctx
+}
+
+// FormatAmount returns a pretty-printed representation of the given amount.
+//
+// See PrettyPrintAmount for details on how the context is used.
+func FormatAmount(ctx context.Context, amount interface{}) string {
+	var b strings.Builder
+	PrettyPrintAmount(ctx, amount, &b)
+	return b.String()
+}
+
 // PrettyPrintAmount writes a pretty-printed representation of the given amount
 // to the given writer.
 //
@@ -112,3 +112,40 @@ func TestPrettyPrintAmount(t *testing.T) {
 
 	}
 }
+
+func TestFormatAmount(t *testing.T) {
+	require := require.New(t)
+
+	amount := quantity.NewFromUint64(1500000000)
+	require.Equal("1500000000 base units", FormatAmount(context.Background(), *amount))
+
+	meta := Metadata{Symbol: "TEST", ValueExponent: 9}
+	ctx := meta.NewContext(context.Background())
+	require.Equal("1.5 TEST", FormatAmount(ctx, *amount))
+
+	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueSign, "-")
+	require.Equal("-1.5 TEST", FormatAmount(ctx, *amount))
+}
+
+func TestMetadataValidateBasic(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		meta  Metadata
+		valid bool
+	}{
+		{Metadata{Symbol: "ROSE", ValueExponent: 9}, true},
+		{Metadata{Symbol: "ROSE", ValueExponent: TokenValueExponentMaxValue}, true},
+		{Metadata{Symbol: "", ValueExponent: 9}, false},
+		{Metadata{Symbol: "rose", ValueExponent: 9}, false},
+		{Metadata{Symbol: "LONGSYMBOL", ValueExponent: 9}, false},
+		{Metadata{Symbol: "ROSE", ValueExponent: TokenValueExponentMaxValue + 1}, false},
+	} {
+		err := tc.meta.ValidateBasic()
+		if tc.valid {
+			require.NoError(err, "ValidateBasic(%+v)", tc.meta)
+		} else {
+			require.Error(err, "ValidateBasic(%+v)", tc.meta)
+		}
+	}
+}
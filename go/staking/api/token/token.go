@@ -1,7 +1,12 @@
 // Package token implements the token-related parts of the staking API.
 package token
 
-import "github.com/oasisprotocol/oasis-core/go/common/errors"
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+)
 
 const (
 	// ModuleName is a unique module name for the staking/token module.
@@ -15,6 +20,36 @@ const (
 	TokenValueExponentMaxValue = 20
 )
 
-// ErrInvalidTokenValueExponent is the error returned when an invalid token's
-// value base-10 exponent is specified.
-var ErrInvalidTokenValueExponent = errors.New(ModuleName, 1, "staking/token: invalid token's value exponent")
+var (
+	// ErrInvalidTokenValueExponent is the error returned when an invalid token's
+	// value base-10 exponent is specified.
+	ErrInvalidTokenValueExponent = errors.New(ModuleName, 1, "staking/token: invalid token's value exponent")
+
+	tokenSymbolRegexp = regexp.MustCompile(TokenSymbolRegexp)
+)
+
+// Metadata is the token metadata used to display amounts in human units.
+type Metadata struct {
+	// Symbol is the token's ticker symbol.
+	Symbol string `json:"symbol"`
+	// ValueExponent is the token's value base-10 exponent, i.e.
+	// 1 token = 10**ValueExponent base units.
+	ValueExponent uint8 `json:"value_exponent"`
+}
+
+// ValidateBasic performs basic token metadata validity checks.
+func (m *Metadata) ValidateBasic() error {
+	if len(m.Symbol) == 0 {
+		return fmt.Errorf("token symbol is empty")
+	}
+	if len(m.Symbol) > TokenSymbolMaxLength {
+		return fmt.Errorf("token symbol exceeds maximum length")
+	}
+	if tokenSymbolRegexp.FindString(m.Symbol) == "" {
+		return fmt.Errorf("token symbol should match '%s'", TokenSymbolRegexp)
+	}
+	if m.ValueExponent > TokenValueExponentMaxValue {
+		return fmt.Errorf("token value exponent is invalid")
+	}
+	return nil
+}
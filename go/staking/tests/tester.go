@@ -18,6 +18,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
 	tendermintTests "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/tests"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/staking/api"
@@ -25,6 +26,33 @@ import (
 
 const recvTimeout = 5 * time.Second
 
+// skipFeeSummaries returns a channel of staking events without the fee summary events emitted at
+// the end of each block containing transactions.
+func skipFeeSummaries(ctx context.Context, ch <-chan *api.Event) <-chan *api.Event {
+	filtered := make(chan *api.Event)
+	go func() {
+		for {
+			select {
+			case ev, ok := <-ch:
+				if !ok {
+					return
+				}
+				if ev.FeeSummary != nil {
+					continue
+				}
+				select {
+				case filtered <- ev:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return filtered
+}
+
 // accountData holds information and additional data about a staking account.
 type accountData struct {
 	account
@@ -152,9 +180,12 @@ func StakingImplementationTests(
 		fn func(*testing.T, *stakingTestsState, api.Backend, consensusAPI.Backend)
 	}{
 		{"Thresholds", testThresholds},
+		{"TokenMetadata", testTokenMetadata},
+		{"AccountWithProof", testAccountWithProof},
 		{"CommonPool", testCommonPool},
 		{"LastBlockFees", testLastBlockFees},
 		{"GovernanceDeposits", testGovernanceDeposits},
+		{"Evidence", testEvidence},
 		{"Delegations", testDelegations},
 		{"Transfer", testTransfer},
 		{"TransferSelf", testSelfTransfer},
@@ -162,6 +193,7 @@ func StakingImplementationTests(
 		{"Escrow", testEscrow},
 		{"EscrowSelf", testSelfEscrow},
 		{"Allowance", testAllowance},
+		{"FeeStatistics", testFeeStatistics},
 	} {
 		state := newStakingTestsState(t, backend, consensus)
 		t.Run(tc.n, func(t *testing.T) { tc.fn(t, state, backend, consensus) })
@@ -182,6 +214,7 @@ func StakingClientImplementationTests(t *testing.T, backend api.Backend, consens
 		fn func(*testing.T, *stakingTestsState, api.Backend, consensusAPI.Backend)
 	}{
 		{"Thresholds", testThresholds},
+		{"AccountWithProof", testAccountWithProof},
 		{"LastBlockFees", testLastBlockFees},
 		{"Delegations", testDelegations},
 		{"Transfer", testTransfer},
@@ -213,6 +246,52 @@ func testThresholds(t *testing.T, state *stakingTestsState, backend api.Backend,
 	}
 }
 
+func testAccountWithProof(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
+	require := require.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), recvTimeout)
+	defer cancel()
+
+	blk, err := consensus.GetBlock(ctx, consensusAPI.HeightLatest)
+	require.NoError(err, "GetBlock")
+
+	addr := state.accounts.GetAddress(1)
+	query := &api.OwnerQuery{Owner: addr, Height: blk.Height}
+	acct, err := backend.Account(ctx, query)
+	require.NoError(err, "Account")
+	awp, err := backend.AccountWithProof(ctx, query)
+	require.NoError(err, "AccountWithProof")
+	require.EqualValues(acct, awp.Account, "AccountWithProof should return the same account as Account")
+
+	root, err := tendermintTests.WaitStateRoot(ctx, consensus, blk.Height)
+	require.NoError(err, "WaitStateRoot")
+	proven, err := stakingState.VerifyAccountProof(ctx, *root, addr, awp.Proof)
+	require.NoError(err, "VerifyAccountProof")
+	require.EqualValues(acct, proven, "proven account should match")
+
+	_, err = stakingState.VerifyAccountProof(ctx, *root, state.accounts.GetAddress(2), awp.Proof)
+	require.Error(err, "VerifyAccountProof should fail for a different account")
+
+	_, err = backend.AccountWithProof(ctx, &api.OwnerQuery{Owner: api.CommonPoolAddress, Height: blk.Height})
+	require.Error(err, "AccountWithProof should fail for reserved accounts")
+}
+
+func testTokenMetadata(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	meta, err := backend.TokenMetadata(ctx, consensusAPI.HeightLatest)
+	require.NoError(err, "TokenMetadata")
+	require.NoError(meta.ValidateBasic(), "token metadata should be valid")
+
+	symbol, err := backend.TokenSymbol(ctx)
+	require.NoError(err, "TokenSymbol")
+	require.Equal(meta.Symbol, symbol, "TokenSymbol should match token metadata")
+
+	exp, err := backend.TokenValueExponent(ctx)
+	require.NoError(err, "TokenValueExponent")
+	require.Equal(meta.ValueExponent, exp, "TokenValueExponent should match token metadata")
+}
+
 func testCommonPool(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
 	require := require.New(t)
 
@@ -248,6 +327,31 @@ func testGovernanceDeposits(t *testing.T, state *stakingTestsState, backend api.
 	require.True(governanceDepositsAcc.General.Balance.IsZero(), "GovernaceDeposits Account - initial value")
 }
 
+func testEvidence(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
+	require := require.New(t)
+
+	evidence, err := backend.Evidence(context.Background(), consensusAPI.HeightLatest)
+	require.NoError(err, "Evidence")
+	require.Empty(evidence, "Evidence - initial value")
+}
+
+func testFeeStatistics(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
+	require := require.New(t)
+
+	stats, err := backend.FeeStatistics(context.Background(), consensusAPI.HeightLatest)
+	require.NoError(err, "FeeStatistics")
+	require.NotEmpty(stats.Blocks, "FeeStatistics should include recent blocks with transactions")
+
+	var lastHeight int64
+	for _, fs := range stats.Blocks {
+		require.True(fs.Height > lastHeight, "FeeStatistics blocks should be ordered by height")
+		require.NotZero(fs.NumTransactions, "FeeStatistics blocks should contain transactions")
+		require.True(fs.MinGasPrice.Cmp(&fs.MaxGasPrice) <= 0, "min gas price should not exceed max gas price")
+		lastHeight = fs.Height
+	}
+	require.True(stats.MinGasPrice.Cmp(&stats.MaxGasPrice) <= 0, "min gas price should not exceed max gas price")
+}
+
 func testDelegations(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
 	require := require.New(t)
 
@@ -591,32 +695,60 @@ func testBurn(t *testing.T, state *stakingTestsState, backend api.Backend, conse
 	err = consensusAPI.SignAndSubmitTx(context.Background(), consensus, accData.Signer, tx)
 	require.NoError(err, "Burn")
 
-	select {
-	case ev := <-ch:
-		if ev.Burn == nil {
-			t.Fatalf("expected burn event, got: %+v", ev)
+	// Skip fee summary events emitted at the end of each block.
+	var ev *api.Event
+	for ev == nil || ev.FeeSummary != nil {
+		select {
+		case ev = <-ch:
+		case <-time.After(recvTimeout):
+			t.Fatalf("failed to receive burn event")
 		}
-		be := ev.Burn
-
-		require.Equal(accData.Address, be.Owner, "Event: owner")
-		require.Equal(burn.Amount, be.Amount, "Event: amount")
-
-		// Make sure that GetEvents also returns the burn event.
-		evts, grr := backend.GetEvents(context.Background(), consensusAPI.HeightLatest)
-		require.NoError(grr, "GetEvents")
-		var gotIt bool
-		for _, evt := range evts {
-			if evt.Burn != nil {
-				if evt.Burn.Owner.Equal(be.Owner) && evt.Burn.Amount.Cmp(&be.Amount) == 0 {
-					gotIt = true
-					break
-				}
+	}
+	if ev.Burn == nil {
+		t.Fatalf("expected burn event, got: %+v", ev)
+	}
+	be := ev.Burn
+
+	require.Equal(accData.Address, be.Owner, "Event: owner")
+	require.Equal(burn.Amount, be.Amount, "Event: amount")
+
+	// Make sure that GetEvents also returns the burn event.
+	evts, err := backend.GetEvents(context.Background(), ev.Height)
+	require.NoError(err, "GetEvents")
+	var gotIt, gotFeeSummary bool
+	for _, evt := range evts {
+		if evt.Burn != nil {
+			if evt.Burn.Owner.Equal(be.Owner) && evt.Burn.Amount.Cmp(&be.Amount) == 0 {
+				gotIt = true
 			}
 		}
-		require.EqualValues(true, gotIt, "GetEvents should return burn event")
-	case <-time.After(recvTimeout):
-		t.Fatalf("failed to receive burn event")
+		if evt.FeeSummary != nil {
+			require.EqualValues(ev.Height, evt.FeeSummary.Height, "FeeSummary: height")
+			require.NotZero(evt.FeeSummary.NumTransactions, "FeeSummary: number of transactions")
+			gotFeeSummary = true
+		}
 	}
+	require.EqualValues(true, gotIt, "GetEvents should return burn event")
+	require.EqualValues(true, gotFeeSummary, "GetEvents should return fee summary event")
+
+	// Make sure that GetEventsFrom returns the same events in the same order.
+	page, err := backend.GetEventsFrom(context.Background(), &api.EventsQuery{
+		Cursor: api.EventCursor{Height: ev.Height},
+		Limit:  uint64(len(evts)),
+	})
+	require.NoError(err, "GetEventsFrom")
+	require.EqualValues(evts, page.Events, "GetEventsFrom should return the same events as GetEvents")
+	require.EqualValues(api.EventCursor{Height: ev.Height + 1}, page.Next, "GetEventsFrom: next cursor")
+
+	page, err = backend.GetEventsFrom(context.Background(), &api.EventsQuery{
+		Cursor: api.EventCursor{Height: ev.Height},
+		Limit:  1,
+		Kinds:  []string{be.EventKind()},
+	})
+	require.NoError(err, "GetEventsFrom")
+	require.Len(page.Events, 1, "GetEventsFrom should return the burn event")
+	require.Equal(be.EventKind(), page.Events[0].Kind(), "GetEventsFrom: event kind")
+	require.EqualValues(be, page.Events[0].Body(), "GetEventsFrom: event body")
 
 	_ = totalSupply.Sub(&burn.Amount)
 	newTotalSupply, err := backend.TotalSupply(context.Background(), consensusAPI.HeightLatest)
@@ -667,9 +799,13 @@ func testEscrowHelper( // nolint: gocyclo
 	require.True(dstAcc.Escrow.Debonding.Balance.IsZero(), "dst: debonding escrow balance == 0")
 	require.True(dstAcc.Escrow.Debonding.TotalShares.IsZero(), "dst: debonding escrow total shares == 0")
 
-	ch, sub, err := backend.WatchEvents(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rawCh, sub, err := backend.WatchEvents(ctx)
 	require.NoError(err, "WatchEvents")
 	defer sub.Close()
+	ch := skipFeeSummaries(ctx, rawCh)
 
 	totalEscrowed := dstAcc.Escrow.Active.Balance.Clone()
 
@@ -1129,9 +1265,13 @@ func testSlashConsensusEquivocation(
 	acc, err := backend.Account(ctx, &api.OwnerQuery{Owner: accData.Address, Height: consensusAPI.HeightLatest})
 	require.NoError(err, "Account")
 
-	ch, sub, err := backend.WatchEvents(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rawCh, sub, err := backend.WatchEvents(ctx)
 	require.NoError(err, "WatchEvents")
 	defer sub.Close()
+	ch := skipFeeSummaries(ctx, rawCh)
 
 	entAddr := api.NewAddress(ent.ID)
 
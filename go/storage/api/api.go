@@ -214,6 +214,11 @@ type Backend interface {
 
 	// GetDiff returns an iterator of write log entries that must be applied
 	// to get from the first given root to the second one.
+	//
+	// The roots must be of the same type and namespace and the end root must not
+	// have an earlier version than the start root. The roots may be several versions
+	// apart, allowing callers to catch up multiple rounds in a single request. The
+	// write log can be verified against both roots using mkvs.VerifyDiff.
 	GetDiff(ctx context.Context, request *GetDiffRequest) (WriteLogIterator, error)
 
 	// Cleanup closes/cleans up the storage backend.
@@ -18,7 +18,7 @@ var (
 	errInvalidRequestType = fmt.Errorf("invalid request type")
 
 	// ServiceName is the gRPC service name.
-	ServiceName = cmnGrpc.NewServiceName("Storage")
+	ServiceName = cmnGrpc.NewServiceName("Storage").WithDefaultCompression(cmnGrpc.CompressorZstd)
 
 	// MethodSyncGet is the SyncGet method.
 	MethodSyncGet = ServiceName.NewMethod("SyncGet", GetRequest{}).
@@ -58,12 +58,47 @@ var (
 
 type metricsWrapper struct {
 	Backend
+
+	profiler *Profiler
+}
+
+func (w *metricsWrapper) profile(kind string, root Root, keys int, start time.Time, err error) {
+	if w.profiler == nil {
+		return
+	}
+	duration := time.Since(start)
+	if !w.profiler.isSlow(duration) {
+		return
+	}
+
+	op := &SlowOperation{
+		Kind:      kind,
+		Root:      root,
+		Keys:      keys,
+		StartedAt: start,
+		Duration:  duration,
+	}
+	if err != nil {
+		op.Error = err.Error()
+	}
+	if lb, ok := w.Backend.(LocalBackend); ok {
+		if size, serr := lb.NodeDB().Size(); serr == nil {
+			op.DBSize = size
+		}
+	}
+	w.profiler.record(op)
+}
+
+// Profiler returns the profiler used to record slow operations, if any.
+func (w *metricsWrapper) Profiler() *Profiler {
+	return w.profiler
 }
 
 func (w *metricsWrapper) SyncGet(ctx context.Context, request *GetRequest) (*ProofResponse, error) {
 	start := time.Now()
 	res, err := w.Backend.SyncGet(ctx, request)
 	storageLatency.With(labelSyncGet).Observe(time.Since(start).Seconds())
+	w.profile(labelSyncGet["call"], request.Tree.Root, 1, start, err)
 	if err != nil {
 		storageFailures.With(labelSyncGet).Inc()
 		return nil, err
@@ -77,6 +112,7 @@ func (w *metricsWrapper) SyncGetPrefixes(ctx context.Context, request *GetPrefix
 	start := time.Now()
 	res, err := w.Backend.SyncGetPrefixes(ctx, request)
 	storageLatency.With(labelSyncGetPrefixes).Observe(time.Since(start).Seconds())
+	w.profile(labelSyncGetPrefixes["call"], request.Tree.Root, len(request.Prefixes), start, err)
 	if err != nil {
 		storageFailures.With(labelSyncGetPrefixes).Inc()
 		return nil, err
@@ -90,6 +126,7 @@ func (w *metricsWrapper) SyncIterate(ctx context.Context, request *IterateReques
 	start := time.Now()
 	res, err := w.Backend.SyncIterate(ctx, request)
 	storageLatency.With(labelSyncIterate).Observe(time.Since(start).Seconds())
+	w.profile(labelSyncIterate["call"], request.Tree.Root, int(request.Prefetch), start, err)
 	if err != nil {
 		storageFailures.With(labelSyncIterate).Inc()
 		return nil, err
@@ -107,6 +144,12 @@ func (w *metricsWrapper) Apply(ctx context.Context, request *ApplyRequest) error
 	start := time.Now()
 	err := w.Backend.(LocalBackend).Apply(ctx, request)
 	storageLatency.With(labelApply).Observe(time.Since(start).Seconds())
+	w.profile(labelApply["call"], Root{
+		Namespace: request.Namespace,
+		Version:   request.DstRound,
+		Type:      request.RootType,
+		Hash:      request.DstRoot,
+	}, len(request.WriteLog), start, err)
 
 	var size int
 	for _, entry := range request.WriteLog {
@@ -143,11 +186,17 @@ func (w *clientMetricsWrapper) EnsureCommitteeVersion(ctx context.Context, versi
 }
 
 func NewMetricsWrapper(base Backend) Backend {
+	return NewProfilingMetricsWrapper(base, nil)
+}
+
+// NewProfilingMetricsWrapper wraps a storage backend with instrumentation that also records slow
+// operations using the given profiler. A nil profiler disables slow operation recording.
+func NewProfilingMetricsWrapper(base Backend, profiler *Profiler) Backend {
 	metricsOnce.Do(func() {
 		prometheus.MustRegister(storageCollectors...)
 	})
 
-	w := metricsWrapper{Backend: base}
+	w := metricsWrapper{Backend: base, profiler: profiler}
 
 	switch base.(type) {
 	case LocalBackend:
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+// SlowOperation is a record of a slow storage operation.
+type SlowOperation struct {
+	// Kind is the kind of the operation (e.g., apply or sync_get).
+	Kind string `json:"kind"`
+	// Root is the storage root the operation was performed against.
+	Root Root `json:"root"`
+	// Keys is the number of keys touched by the operation.
+	Keys int `json:"keys"`
+	// StartedAt is the time when the operation started.
+	StartedAt time.Time `json:"started_at"`
+	// Duration is the duration of the operation.
+	Duration time.Duration `json:"duration"`
+	// Error is the error returned by the operation, if any.
+	Error string `json:"error,omitempty"`
+	// DBSize is the size of the node database after the operation (in bytes), if available.
+	DBSize int64 `json:"db_size,omitempty"`
+}
+
+// ProfilingBackend is a storage backend that may record slow operations.
+type ProfilingBackend interface {
+	// Profiler returns the profiler used to record slow operations or nil in case profiling is
+	// not enabled.
+	Profiler() *Profiler
+}
+
+// Profiler keeps track of the slowest storage operations.
+type Profiler struct {
+	sync.Mutex
+
+	maxOps    int
+	threshold time.Duration
+
+	// ops are the slowest recorded operations, slowest first.
+	ops []*SlowOperation
+
+	logger *logging.Logger
+}
+
+// isSlow returns true iff an operation with the given duration should be recorded.
+func (p *Profiler) isSlow(d time.Duration) bool {
+	if p.threshold > 0 && d >= p.threshold {
+		return true
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	if p.maxOps == 0 {
+		return false
+	}
+	return len(p.ops) < p.maxOps || d > p.ops[len(p.ops)-1].Duration
+}
+
+// record records a slow storage operation.
+func (p *Profiler) record(op *SlowOperation) {
+	if p.threshold > 0 && op.Duration >= p.threshold {
+		p.logger.Warn("slow storage operation",
+			"kind", op.Kind,
+			"namespace", op.Root.Namespace,
+			"round", op.Root.Version,
+			"root_type", op.Root.Type,
+			"root", op.Root.Hash,
+			"keys", op.Keys,
+			"duration", op.Duration,
+			"db_size", op.DBSize,
+			"err", op.Error,
+		)
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	if p.maxOps == 0 {
+		return
+	}
+
+	idx := sort.Search(len(p.ops), func(i int) bool {
+		return p.ops[i].Duration < op.Duration
+	})
+	if idx >= p.maxOps {
+		return
+	}
+	if len(p.ops) < p.maxOps {
+		p.ops = append(p.ops, nil)
+	}
+	copy(p.ops[idx+1:], p.ops[idx:])
+	p.ops[idx] = op
+}
+
+// SlowOperations returns the slowest recorded storage operations, slowest first.
+func (p *Profiler) SlowOperations() []*SlowOperation {
+	p.Lock()
+	defer p.Unlock()
+
+	ops := make([]*SlowOperation, 0, len(p.ops))
+	for _, op := range p.ops {
+		opCopy := *op
+		ops = append(ops, &opCopy)
+	}
+	return ops
+}
+
+// NewProfiler creates a new storage profiler that keeps track of the maxOps slowest operations
+// and logs all operations that take at least the given threshold. A zero threshold disables
+// slow operation logging.
+func NewProfiler(maxOps int, threshold time.Duration) *Profiler {
+	return &Profiler{
+		maxOps:    maxOps,
+		threshold: threshold,
+		logger:    logging.GetLogger("storage/profiler"),
+	}
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiler(t *testing.T) {
+	require := require.New(t)
+
+	p := NewProfiler(3, 0)
+	for _, d := range []time.Duration{5, 1, 3, 7, 2, 6} {
+		if p.isSlow(d) {
+			p.record(&SlowOperation{Kind: "apply", Duration: d})
+		}
+	}
+
+	ops := p.SlowOperations()
+	require.Len(ops, 3, "only the slowest operations should be kept")
+	for i, d := range []time.Duration{7, 6, 5} {
+		require.Equal(d, ops[i].Duration, "operations should be ordered slowest first")
+	}
+	require.False(p.isSlow(4), "operations faster than all recorded ones should not be recorded")
+
+	// Operations above the threshold are always considered slow.
+	p = NewProfiler(0, 10)
+	require.False(p.isSlow(9), "operations below the threshold should not be recorded")
+	require.True(p.isSlow(10), "operations above the threshold should be recorded")
+	p.record(&SlowOperation{Kind: "apply", Duration: 10})
+	require.Empty(p.SlowOperations(), "operations should not be kept when disabled")
+}
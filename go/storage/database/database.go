@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 
 	"github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
 	nodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	badgerNodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
@@ -133,7 +134,34 @@ func (ba *databaseBackend) SyncIterate(ctx context.Context, request *api.Iterate
 }
 
 func (ba *databaseBackend) GetDiff(ctx context.Context, request *api.GetDiffRequest) (api.WriteLogIterator, error) {
-	return ba.nodedb.GetWriteLog(ctx, request.StartRoot, request.EndRoot)
+	it, err := ba.nodedb.GetWriteLog(ctx, request.StartRoot, request.EndRoot)
+	switch {
+	case err == nil:
+		return it, nil
+	case errors.Is(err, api.ErrWriteLogNotFound), errors.Is(err, api.ErrRootMustFollowOld):
+		// The roots are not connected by stored write logs (e.g., because they are several
+		// versions apart), compute the difference between the trees instead.
+	default:
+		return nil, err
+	}
+
+	start, end := request.StartRoot, request.EndRoot
+	if start.Type != end.Type || !start.Namespace.Equal(&end.Namespace) || end.Version < start.Version {
+		return nil, api.ErrRootMustFollowOld
+	}
+	if !ba.nodedb.HasRoot(start) || !ba.nodedb.HasRoot(end) {
+		return nil, api.ErrRootNotFound
+	}
+
+	startTree, err := ba.rootCache.GetTree(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	endTree, err := ba.rootCache.GetTree(ctx, end)
+	if err != nil {
+		return nil, err
+	}
+	return mkvs.Diff(ctx, startTree, endTree), nil
 }
 
 func (ba *databaseBackend) GetCheckpoints(ctx context.Context, request *checkpoint.GetCheckpointsRequest) ([]*checkpoint.Metadata, error) {
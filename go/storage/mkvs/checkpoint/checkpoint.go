@@ -35,6 +35,13 @@ var (
 
 	// ErrChunkCorrupted is the error when a chunk is corrupted.
 	ErrChunkCorrupted = errors.New(moduleName, 7, "chunk: corrupted chunk")
+
+	// ErrBaseRootNotFound is the error when the base root of a diff checkpoint is not available.
+	ErrBaseRootNotFound = errors.New(moduleName, 8, "checkpoint: base root not found")
+
+	// ErrRestoreIncomplete is the error when a restored diff checkpoint references nodes which are
+	// neither part of the base root nor of any of its chunks.
+	ErrRestoreIncomplete = errors.New(moduleName, 9, "checkpoint: restored tree is incomplete")
 )
 
 // ChunkProvider is a chunk provider.
@@ -54,6 +61,9 @@ type GetCheckpointsRequest struct {
 	// RootVersion specifies an optional root version to limit the request to. If specified, only
 	// checkpoints for roots with the specific version will be considered.
 	RootVersion *uint64 `json:"root_version,omitempty"`
+
+	// IncludeDiffs specifies whether diff checkpoints should also be returned.
+	IncludeDiffs bool `json:"include_diffs,omitempty"`
 }
 
 // Creator is a checkpoint creator.
@@ -63,10 +73,14 @@ type Creator interface {
 	// CreateCheckpoint creates a new checkpoint at the given root.
 	CreateCheckpoint(ctx context.Context, root node.Root, chunkSize uint64) (*Metadata, error)
 
+	// CreateDiffCheckpoint creates a new diff checkpoint at the given root, containing only the
+	// nodes that changed since the given base root.
+	CreateDiffCheckpoint(ctx context.Context, baseRoot, root node.Root, chunkSize uint64) (*Metadata, error)
+
 	// GetCheckpoint retrieves checkpoint metadata for a specific checkpoint.
 	GetCheckpoint(ctx context.Context, version uint16, root node.Root) (*Metadata, error)
 
-	// DeleteCheckpoint deletes a specific checkpoint.
+	// DeleteCheckpoint deletes a specific checkpoint together with its diff checkpoint (if any).
 	DeleteCheckpoint(ctx context.Context, version uint16, root node.Root) error
 }
 
@@ -74,6 +88,9 @@ type Creator interface {
 type Restorer interface {
 	// StartRestore starts a checkpoint restoration process.
 	//
+	// In case of a diff checkpoint, the base root must already be present in the underlying node
+	// database.
+	//
 	// Multipart management in the underlying database is the responsibility of the caller.
 	StartRestore(ctx context.Context, checkpoint *Metadata) error
 
@@ -124,6 +141,9 @@ type ChunkMetadata struct {
 	Root    node.Root `json:"root"`
 	Index   uint64    `json:"index"`
 	Digest  hash.Hash `json:"digest"`
+
+	// BaseRoot is the base root in case the chunk belongs to a diff checkpoint.
+	BaseRoot *node.Root `json:"base_root,omitempty"`
 }
 
 // Metadata is checkpoint metadata.
@@ -131,6 +151,16 @@ type Metadata struct {
 	Version uint16      `json:"version"`
 	Root    node.Root   `json:"root"`
 	Chunks  []hash.Hash `json:"chunks"`
+
+	// BaseRoot is the root of the previous checkpoint in case this is a diff checkpoint. Diff
+	// checkpoints only contain the nodes that changed since the base root and can only be
+	// restored on top of the base root.
+	BaseRoot *node.Root `json:"base_root,omitempty"`
+}
+
+// IsDiff returns true iff the checkpoint is a diff checkpoint.
+func (m *Metadata) IsDiff() bool {
+	return m.BaseRoot != nil
 }
 
 // EncodedHash returns the encoded cryptographic hash of the checkpoint metadata.
@@ -145,9 +175,10 @@ func (m Metadata) GetChunkMetadata(idx uint64) (*ChunkMetadata, error) {
 	}
 
 	return &ChunkMetadata{
-		Version: m.Version,
-		Root:    m.Root,
-		Index:   idx,
-		Digest:  m.Chunks[int(idx)],
+		Version:  m.Version,
+		Root:     m.Root,
+		Index:    idx,
+		Digest:   m.Chunks[int(idx)],
+		BaseRoot: m.BaseRoot,
 	}, nil
 }
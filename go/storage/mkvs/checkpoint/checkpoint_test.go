@@ -479,3 +479,197 @@ func TestPruneGapAfterCheckpointRestore(t *testing.T) {
 	err = ndb2.Prune(ctx, checkpointRootVersion)
 	require.NoError(err, "Prune(%d)", checkpointRootVersion)
 }
+
+func TestFileDiffCheckpointCreator(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "mkvs.checkpoint")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	ndb, err := badgerDb.New(&db.Config{
+		DB:           filepath.Join(dir, "db"),
+		Namespace:    testNs,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+
+	// Generate the base root.
+	ctx := context.Background()
+	tree := mkvs.New(nil, ndb, node.RootTypeState)
+	for i := 0; i < 1000; i++ {
+		err = tree.Insert(ctx, []byte(strconv.Itoa(i)), []byte(strconv.Itoa(i)))
+		require.NoError(err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, testNs, 1)
+	require.NoError(err, "Commit")
+	baseRoot := node.Root{
+		Namespace: testNs,
+		Version:   1,
+		Type:      node.RootTypeState,
+		Hash:      rootHash,
+	}
+	err = ndb.Finalize(ctx, []node.Root{baseRoot})
+	require.NoError(err, "Finalize")
+
+	// Generate the next roots by changing a few keys over multiple versions.
+	tree = mkvs.NewWithRoot(nil, ndb, baseRoot)
+	for _, i := range []int{1, 500} {
+		err = tree.Insert(ctx, []byte(strconv.Itoa(i)), []byte("updated"))
+		require.NoError(err, "Insert")
+	}
+	err = tree.Remove(ctx, []byte("42"))
+	require.NoError(err, "Remove")
+	_, rootHash, err = tree.Commit(ctx, testNs, 2)
+	require.NoError(err, "Commit")
+	err = ndb.Finalize(ctx, []node.Root{{Namespace: testNs, Version: 2, Type: node.RootTypeState, Hash: rootHash}})
+	require.NoError(err, "Finalize")
+
+	err = tree.Insert(ctx, []byte("999"), []byte("updated"))
+	require.NoError(err, "Insert")
+	err = tree.Insert(ctx, []byte("new key"), []byte("new value"))
+	require.NoError(err, "Insert")
+	_, rootHash, err = tree.Commit(ctx, testNs, 3)
+	require.NoError(err, "Commit")
+	root := node.Root{
+		Namespace: testNs,
+		Version:   3,
+		Type:      node.RootTypeState,
+		Hash:      rootHash,
+	}
+	err = ndb.Finalize(ctx, []node.Root{root})
+	require.NoError(err, "Finalize")
+
+	fc, err := NewFileCreator(filepath.Join(dir, "checkpoints"), ndb)
+	require.NoError(err, "NewFileCreator")
+
+	_, err = fc.CreateDiffCheckpoint(ctx, baseRoot, root, 16*1024)
+	require.ErrorIs(err, ErrCheckpointNotFound, "CreateDiffCheckpoint should fail without a checkpoint")
+
+	baseCp, err := fc.CreateCheckpoint(ctx, baseRoot, 16*1024)
+	require.NoError(err, "CreateCheckpoint")
+	fullCp, err := fc.CreateCheckpoint(ctx, root, 16*1024)
+	require.NoError(err, "CreateCheckpoint")
+
+	_, err = fc.CreateDiffCheckpoint(ctx, root, baseRoot, 16*1024)
+	require.Error(err, "CreateDiffCheckpoint should fail with a later base root")
+
+	cp, err := fc.CreateDiffCheckpoint(ctx, baseRoot, root, 16*1024)
+	require.NoError(err, "CreateDiffCheckpoint")
+	require.True(cp.IsDiff(), "checkpoint should be a diff checkpoint")
+	require.EqualValues(root, cp.Root, "checkpoint root should be correct")
+	require.EqualValues(baseRoot, *cp.BaseRoot, "checkpoint base root should be correct")
+	require.NotEmpty(cp.Chunks, "there should be at least one chunk")
+
+	existingCp, err := fc.CreateDiffCheckpoint(ctx, baseRoot, root, 16*1024)
+	require.NoError(err, "CreateDiffCheckpoint on an existing root should work")
+	require.Equal(cp, existingCp, "created checkpoint should be correct")
+
+	// Diff checkpoints should only be returned when requested.
+	cps, err := fc.GetCheckpoints(ctx, &GetCheckpointsRequest{Version: 1})
+	require.NoError(err, "GetCheckpoints")
+	require.Len(cps, 2, "diff checkpoints should not be returned by default")
+	cps, err = fc.GetCheckpoints(ctx, &GetCheckpointsRequest{Version: 1, IncludeDiffs: true})
+	require.NoError(err, "GetCheckpoints")
+	require.Len(cps, 3, "diff checkpoints should be returned when requested")
+
+	// The diff checkpoint should be much smaller than the full checkpoint.
+	fetchChunks := func(cp *Metadata) [][]byte {
+		var chunks [][]byte
+		for i := range cp.Chunks {
+			cm, cerr := cp.GetChunkMetadata(uint64(i))
+			require.NoError(cerr, "GetChunkMetadata")
+
+			var buf bytes.Buffer
+			cerr = fc.GetCheckpointChunk(ctx, cm, &buf)
+			require.NoError(cerr, "GetCheckpointChunk")
+			chunks = append(chunks, buf.Bytes())
+		}
+		return chunks
+	}
+	chunkSize := func(chunks [][]byte) (size int) {
+		for _, c := range chunks {
+			size += len(c)
+		}
+		return
+	}
+	baseChunks := fetchChunks(baseCp)
+	diffChunks := fetchChunks(cp)
+	require.Less(chunkSize(diffChunks)*4, chunkSize(fetchChunks(fullCp)), "diff checkpoint should be smaller")
+
+	// Fetching a diff chunk against a different base root should fail.
+	cm, err := cp.GetChunkMetadata(0)
+	require.NoError(err, "GetChunkMetadata")
+	cm.BaseRoot = &root
+	err = fc.GetCheckpointChunk(ctx, cm, ioutil.Discard)
+	require.ErrorIs(err, ErrChunkNotFound, "GetCheckpointChunk should fail for a different base root")
+
+	// Create a fresh node database to restore into.
+	ndb2, err := badgerDb.New(&db.Config{
+		DB:           filepath.Join(dir, "db2"),
+		Namespace:    testNs,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+	rs, err := NewRestorer(ndb2)
+	require.NoError(err, "NewRestorer")
+
+	restore := func(cp *Metadata, chunks [][]byte) {
+		rerr := ndb2.StartMultipartInsert(cp.Root.Version)
+		require.NoError(rerr, "StartMultipartInsert")
+		rerr = rs.StartRestore(ctx, cp)
+		require.NoError(rerr, "StartRestore")
+		for i, chunk := range chunks {
+			done, rerr := rs.RestoreChunk(ctx, uint64(i), bytes.NewReader(chunk))
+			require.NoError(rerr, "RestoreChunk")
+			require.Equal(i == len(chunks)-1, done, "RestoreChunk should signal completion correctly")
+		}
+		rerr = ndb2.Finalize(ctx, []node.Root{cp.Root})
+		require.NoError(rerr, "Finalize")
+	}
+
+	// Restoring a diff checkpoint without the base root should fail.
+	err = rs.StartRestore(ctx, cp)
+	require.ErrorIs(err, ErrBaseRootNotFound, "StartRestore should fail without the base root")
+
+	// Restore the base checkpoint and then the diff checkpoint on top of it.
+	restore(baseCp, baseChunks)
+	restore(cp, diffChunks)
+
+	// Verify that everything has been restored.
+	tree = mkvs.NewWithRoot(nil, ndb2, root)
+	defer tree.Close()
+	for i := 0; i < 1000; i++ {
+		var value []byte
+		value, err = tree.Get(ctx, []byte(strconv.Itoa(i)))
+		require.NoError(err, "Get")
+		switch i {
+		case 1, 500, 999:
+			require.Equal([]byte("updated"), value)
+		case 42:
+			require.Nil(value)
+		default:
+			require.Equal([]byte(strconv.Itoa(i)), value)
+		}
+	}
+	value, err := tree.Get(ctx, []byte("new key"))
+	require.NoError(err, "Get")
+	require.Equal([]byte("new value"), value)
+
+	// Pruning the base version should not remove nodes shared with the restored root.
+	err = ndb2.Prune(ctx, baseRoot.Version)
+	require.NoError(err, "Prune")
+	prunedTree := mkvs.NewWithRoot(nil, ndb2, root)
+	defer prunedTree.Close()
+	for i := 0; i < 1000; i++ {
+		_, err = prunedTree.Get(ctx, []byte(strconv.Itoa(i)))
+		require.NoError(err, "Get after pruning the base version")
+	}
+
+	// Deleting the checkpoint should also delete the diff checkpoint.
+	err = fc.DeleteCheckpoint(ctx, 1, root)
+	require.NoError(err, "DeleteCheckpoint")
+	cps, err = fc.GetCheckpoints(ctx, &GetCheckpointsRequest{Version: 1, IncludeDiffs: true})
+	require.NoError(err, "GetCheckpoints")
+	require.Len(cps, 1, "diff checkpoint should be deleted together with the checkpoint")
+}
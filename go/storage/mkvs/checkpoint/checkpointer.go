@@ -40,6 +40,10 @@ type CheckpointerConfig struct {
 	//
 	// This must return exactly RootsPerVersion roots.
 	GetRoots func(context.Context, uint64) ([]node.Root, error)
+
+	// CreateDiffs specifies whether a diff checkpoint against the previous checkpoint of the same
+	// root type should be created together with each checkpoint.
+	CreateDiffs bool
 }
 
 // CreationParameters are the checkpoint creation parameters used by the checkpointer.
@@ -182,10 +186,63 @@ func (c *checkpointer) checkpoint(ctx context.Context, version uint64, params *C
 			)
 			return fmt.Errorf("checkpointer: failed to create checkpoint: %w", err)
 		}
+
+		if c.cfg.CreateDiffs {
+			c.maybeCreateDiff(ctx, root, params)
+		}
 	}
 	return nil
 }
 
+func (c *checkpointer) maybeCreateDiff(ctx context.Context, root node.Root, params *CreationParameters) {
+	// Diffs are built from the write logs between consecutive versions which only exist for state
+	// roots.
+	if root.Type != node.RootTypeState {
+		return
+	}
+
+	cps, err := c.creator.GetCheckpoints(ctx, &GetCheckpointsRequest{
+		Version:   checkpointVersion,
+		Namespace: c.cfg.Namespace,
+	})
+	if err != nil {
+		c.logger.Warn("failed to get existing checkpoints, not creating diff checkpoint",
+			"root", root,
+			"err", err,
+		)
+		return
+	}
+
+	// Find the most recent earlier checkpoint of the same root type.
+	var baseRoot *node.Root
+	for _, cp := range cps {
+		if cp.Root.Type != root.Type || cp.Root.Version >= root.Version {
+			continue
+		}
+		if baseRoot == nil || cp.Root.Version > baseRoot.Version {
+			baseRoot = &cp.Root
+		}
+	}
+	if baseRoot == nil || !c.ndb.HasRoot(*baseRoot) {
+		return
+	}
+
+	c.logger.Info("creating new diff checkpoint",
+		"root", root,
+		"base_root", *baseRoot,
+		"chunk_size", params.ChunkSize,
+	)
+
+	// Failing to create a diff checkpoint is not fatal as the full checkpoint is available.
+	if _, err = c.creator.CreateDiffCheckpoint(ctx, *baseRoot, root, params.ChunkSize); err != nil {
+		c.logger.Warn("failed to create diff checkpoint",
+			"root", root,
+			"base_root", *baseRoot,
+			"err", err,
+		)
+	}
+}
+
 func (c *checkpointer) maybeCheckpoint(ctx context.Context, version uint64, params *CreationParameters) error {
 	// Get a list of all current checkpoints.
 	cps, err := c.creator.GetCheckpoints(ctx, &GetCheckpointsRequest{
@@ -15,6 +15,7 @@ import (
 	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
 )
 
 func createChunk(
@@ -56,25 +57,239 @@ func createChunk(
 	it.Next()
 	nextOffset = it.Key()
 
+	chunkHash, err = writeChunk(proof, w)
+	return
+}
+
+func writeChunk(proof *syncer.Proof, w io.Writer) (hash.Hash, error) {
 	hb := hash.NewBuilder()
 	sw := snappy.NewBufferedWriter(io.MultiWriter(w, hb))
 	enc := cbor.NewEncoder(sw)
 	for _, entry := range proof.Entries {
-		if err = enc.Encode(entry); err != nil {
-			err = fmt.Errorf("chunk: failed to encode chunk part: %w", err)
-			return
+		if err := enc.Encode(entry); err != nil {
+			return hash.Hash{}, fmt.Errorf("chunk: failed to encode chunk part: %w", err)
 		}
 	}
-	if err = sw.Close(); err != nil {
-		err = fmt.Errorf("chunk: failed to close chunk: %w", err)
-		return
+	if err := sw.Close(); err != nil {
+		return hash.Hash{}, fmt.Errorf("chunk: failed to close chunk: %w", err)
 	}
 
-	chunkHash = hb.Build()
-	return
+	return hb.Build(), nil
+}
+
+// diffChunker splits the nodes of a tree which are not part of a base tree into chunks.
+//
+// Each chunk is a proof for the tree root where the unchanged subtrees are only referenced by
+// their hashes. As all ancestors of a changed node are changed as well, every chunk also contains
+// the path from the tree root to its nodes so that chunks can be verified independently.
+type diffChunker struct {
+	ndb       db.NodeDB
+	root      node.Root
+	chunkSize uint64
+
+	// emitChunk is called for each created chunk.
+	emitChunk func(proof *syncer.Proof) error
+
+	// path contains the ancestors of the node that is currently being visited.
+	path []node.Node
+	pb   *syncer.ProofBuilder
+}
+
+// changedKeys returns the keys that were modified between the base root and the root.
+//
+// The keys are collected from the write logs of all intermediate versions so the cost is
+// proportional to the number of changes instead of the size of the base tree.
+func changedKeys(ctx context.Context, ndb db.NodeDB, baseRoot, root node.Root) ([]node.Key, error) {
+	seen := make(map[string]bool)
+	var keys []node.Key
+
+	// Follow the chain of roots backwards, from the root towards the base root.
+	endRoot := root
+	for endRoot.Version > baseRoot.Version {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		candidates := []node.Root{baseRoot}
+		if endRoot.Version > baseRoot.Version+1 {
+			var err error
+			if candidates, err = ndb.GetRootsForVersion(ctx, endRoot.Version-1); err != nil {
+				return nil, fmt.Errorf("chunk: failed to get roots for version %d: %w", endRoot.Version-1, err)
+			}
+		}
+
+		var (
+			startRoot node.Root
+			it        writelog.Iterator
+		)
+		for _, r := range candidates {
+			if r.Type != endRoot.Type {
+				continue
+			}
+			var err error
+			if it, err = ndb.GetWriteLog(ctx, r, endRoot); err == nil {
+				startRoot = r
+				break
+			}
+		}
+		if it == nil {
+			return nil, fmt.Errorf("chunk: no write log leading to root %s: %w", endRoot, db.ErrWriteLogNotFound)
+		}
+		for {
+			more, err := it.Next()
+			if err != nil {
+				return nil, fmt.Errorf("chunk: failed to iterate write log: %w", err)
+			}
+			if !more {
+				break
+			}
+			entry, err := it.Value()
+			if err != nil {
+				return nil, fmt.Errorf("chunk: failed to get write log entry: %w", err)
+			}
+			if seen[string(entry.Key)] {
+				continue
+			}
+			seen[string(entry.Key)] = true
+			keys = append(keys, node.Key(entry.Key))
+		}
+
+		endRoot = startRoot
+	}
+	if !endRoot.Equal(&baseRoot) {
+		return nil, fmt.Errorf("chunk: root %s does not follow base root %s", root, baseRoot)
+	}
+
+	return keys, nil
+}
+
+func (dc *diffChunker) include(n node.Node) {
+	if dc.pb == nil {
+		dc.pb = syncer.NewProofBuilder(dc.root.Hash, dc.root.Hash)
+		for _, an := range dc.path {
+			dc.pb.Include(an)
+		}
+	}
+	dc.pb.Include(n)
+}
+
+func (dc *diffChunker) flush(ctx context.Context) error {
+	if dc.pb == nil {
+		return nil
+	}
+
+	proof, err := dc.pb.Build(ctx)
+	if err != nil {
+		return fmt.Errorf("chunk: failed to build proof: %w", err)
+	}
+	dc.pb = nil
+
+	return dc.emitChunk(proof)
 }
 
-func restoreChunk(ctx context.Context, ndb db.NodeDB, chunk *ChunkMetadata, r io.Reader) error {
+// walk includes all nodes on the lookup paths of the given keys.
+//
+// Any node that changed since the base root lies on the lookup path of at least one changed key,
+// everything else is only referenced by its hash.
+func (dc *diffChunker) walk(ctx context.Context, ptr *node.Pointer, bitDepth node.Depth, keys []node.Key) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if ptr == nil || ptr.Hash.IsEmpty() {
+		return nil
+	}
+
+	n, err := dc.ndb.GetNode(dc.root, ptr)
+	if err != nil {
+		return fmt.Errorf("chunk: failed to get node: %w", err)
+	}
+	// The internal leaf node is always included together with the internal node.
+	dc.include(n)
+
+	in, ok := n.(*node.InternalNode)
+	if !ok {
+		return nil
+	}
+
+	if dc.pb != nil && dc.pb.Size() >= dc.chunkSize {
+		if err = dc.flush(ctx); err != nil {
+			return err
+		}
+	}
+
+	// Route the keys to the subtrees in the same way as lookups do.
+	bitLength := bitDepth + in.LabelBitLength
+	var left, right []node.Key
+	for _, key := range keys {
+		if key.BitLength() <= bitLength {
+			// Key either ends in the internal leaf node or is not present in the subtree.
+			continue
+		}
+		if key.GetBit(bitLength) {
+			right = append(right, key)
+		} else {
+			left = append(left, key)
+		}
+	}
+
+	dc.path = append(dc.path, n)
+	defer func() {
+		dc.path = dc.path[:len(dc.path)-1]
+	}()
+
+	if len(left) > 0 {
+		if err = dc.walk(ctx, in.Left, bitLength, left); err != nil {
+			return err
+		}
+	}
+	if len(right) > 0 {
+		return dc.walk(ctx, in.Right, bitLength, right)
+	}
+	return nil
+}
+
+// createDiffChunks creates chunks containing all nodes of the tree at the given root which are not
+// part of the tree at the given base root.
+//
+// The changed nodes are located using the write logs between the two roots which must be
+// available in the node database. The root node is always included, so at least one chunk is
+// created.
+func createDiffChunks(
+	ctx context.Context,
+	ndb db.NodeDB,
+	baseRoot node.Root,
+	root node.Root,
+	chunkSize uint64,
+	emitChunk func(proof *syncer.Proof) error,
+) error {
+	keys, err := changedKeys(ctx, ndb, baseRoot, root)
+	if err != nil {
+		return err
+	}
+
+	dc := &diffChunker{
+		ndb:       ndb,
+		root:      root,
+		chunkSize: chunkSize,
+		emitChunk: emitChunk,
+	}
+
+	rootPtr := &node.Pointer{Clean: true, Hash: root.Hash}
+	if err = dc.walk(ctx, rootPtr, 0, keys); err != nil {
+		return err
+	}
+	if dc.pb == nil {
+		// Make sure the last chunk is always emitted, even for empty trees.
+		dc.pb = syncer.NewProofBuilder(root.Hash, root.Hash)
+	}
+	return dc.flush(ctx)
+}
+
+// restoreChunk verifies the given chunk and imports it into the node database.
+//
+// For chunks of diff checkpoints it returns the hashes of all subtrees which are only referenced
+// by the chunk and need to be available from either the base root or the other chunks.
+func restoreChunk(ctx context.Context, ndb db.NodeDB, chunk *ChunkMetadata, r io.Reader) ([]hash.Hash, error) {
 	hb := hash.NewBuilder()
 	tr := io.TeeReader(r, hb)
 	sr := snappy.NewReader(tr)
@@ -85,7 +300,7 @@ func restoreChunk(ctx context.Context, ndb db.NodeDB, chunk *ChunkMetadata, r io
 	var p syncer.Proof
 	for {
 		if ctx.Err() != nil {
-			return ctx.Err()
+			return nil, ctx.Err()
 		}
 
 		var entry []byte
@@ -108,7 +323,7 @@ func restoreChunk(ctx context.Context, ndb db.NodeDB, chunk *ChunkMetadata, r io
 	// Verify overall chunk integrity.
 	chunkHash := hb.Build()
 	if !chunk.Digest.Equal(&chunkHash) {
-		return fmt.Errorf("%w: digest incorrect (expected: %s got: %s)",
+		return nil, fmt.Errorf("%w: digest incorrect (expected: %s got: %s)",
 			ErrChunkCorrupted,
 			chunk.Digest,
 			chunkHash,
@@ -117,42 +332,65 @@ func restoreChunk(ctx context.Context, ndb db.NodeDB, chunk *ChunkMetadata, r io
 
 	// Treat decode errors after integrity verification as proof verification failures.
 	if decodeErr != nil {
-		return fmt.Errorf("%w: %s", ErrChunkProofVerificationFailed, decodeErr.Error())
+		return nil, fmt.Errorf("%w: %s", ErrChunkProofVerificationFailed, decodeErr.Error())
 	}
 
 	// Verify the proof.
 	var pv syncer.ProofVerifier
 	ptr, err := pv.VerifyProof(ctx, chunk.Root.Hash, &p)
 	if err != nil {
-		return fmt.Errorf("%w: %s", ErrChunkProofVerificationFailed, err.Error())
+		return nil, fmt.Errorf("%w: %s", ErrChunkProofVerificationFailed, err.Error())
 	}
 
 	// Import chunk into the node database.
-	emptyRoot := node.Root{
+	oldRoot := node.Root{
 		Namespace: chunk.Root.Namespace,
 		Version:   chunk.Root.Version,
 		Type:      chunk.Root.Type,
 	}
-	emptyRoot.Hash.Empty()
+	oldRoot.Hash.Empty()
 
-	batch, err := ndb.NewBatch(emptyRoot, chunk.Root.Version, true)
+	var refs []hash.Hash
+	if chunk.BaseRoot != nil {
+		// Diff chunks are imported on top of the base root.
+		oldRoot = *chunk.BaseRoot
+		collectReferences(ptr, &refs)
+	}
+
+	batch, err := ndb.NewBatch(oldRoot, chunk.Root.Version, true)
 	if err != nil {
-		return fmt.Errorf("chunk: failed to create batch: %w", err)
+		return nil, fmt.Errorf("chunk: failed to create batch: %w", err)
 	}
 	defer batch.Reset()
 
 	subtree := batch.MaybeStartSubtree(nil, 0, ptr)
 	if err = doRestoreChunk(ctx, batch, subtree, 0, ptr); err != nil {
-		return fmt.Errorf("chunk: node import failed: %w", err)
+		return nil, fmt.Errorf("chunk: node import failed: %w", err)
 	}
 	if err = subtree.Commit(); err != nil {
-		return fmt.Errorf("chunk: node import failed: %w", err)
+		return nil, fmt.Errorf("chunk: node import failed: %w", err)
 	}
 	if err = batch.Commit(chunk.Root); err != nil {
-		return fmt.Errorf("chunk: node import failed: %w", err)
+		return nil, fmt.Errorf("chunk: node import failed: %w", err)
 	}
 
-	return nil
+	return refs, nil
+}
+
+// collectReferences appends the hashes of all non-empty subtrees which are only referenced by
+// hash to the given slice.
+func collectReferences(ptr *node.Pointer, refs *[]hash.Hash) {
+	if ptr == nil || ptr.Hash.IsEmpty() {
+		return
+	}
+
+	switch n := ptr.Node.(type) {
+	case nil:
+		*refs = append(*refs, ptr.Hash)
+	case *node.InternalNode:
+		collectReferences(n.Left, refs)
+		collectReferences(n.Right, refs)
+	}
 }
 
 func doRestoreChunk(
@@ -15,10 +15,12 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
 const (
 	chunksDir              = "chunks"
+	diffDir                = "diff"
 	checkpointMetadataFile = "meta"
 	checkpointVersion      = 1
 )
@@ -104,6 +106,96 @@ func (fc *fileCreator) CreateCheckpoint(ctx context.Context, root node.Root, chu
 	return meta, nil
 }
 
+func (fc *fileCreator) CreateDiffCheckpoint(
+	ctx context.Context,
+	baseRoot node.Root,
+	root node.Root,
+	chunkSize uint64,
+) (meta *Metadata, err error) {
+	if !baseRoot.Namespace.Equal(&root.Namespace) || baseRoot.Type != root.Type || baseRoot.Version >= root.Version {
+		return nil, fmt.Errorf("checkpoint: base root must be an earlier root of the same type")
+	}
+	if !fc.ndb.HasRoot(baseRoot) {
+		return nil, ErrBaseRootNotFound
+	}
+
+	// Diff checkpoints are stored together with the corresponding checkpoint so that they are
+	// removed together.
+	checkpointDir := filepath.Join(
+		fc.dataDir,
+		strconv.FormatUint(root.Version, 10),
+		root.Hash.String(),
+	)
+	if _, err = os.Stat(filepath.Join(checkpointDir, checkpointMetadataFile)); err != nil {
+		return nil, ErrCheckpointNotFound
+	}
+
+	// Check if the diff checkpoint already exists and just return the existing metadata in this
+	// case. A diff checkpoint against a different base root is replaced.
+	diffCheckpointDir := filepath.Join(checkpointDir, diffDir)
+	data, err := ioutil.ReadFile(filepath.Join(diffCheckpointDir, checkpointMetadataFile))
+	if err == nil {
+		var existing Metadata
+		if err = cbor.Unmarshal(data, &existing); err != nil {
+			return nil, fmt.Errorf("checkpoint: corrupted diff checkpoint metadata: %w", err)
+		}
+		if existing.BaseRoot != nil && existing.BaseRoot.Equal(&baseRoot) {
+			return &existing, nil
+		}
+	}
+	if err = os.RemoveAll(diffCheckpointDir); err != nil {
+		return nil, fmt.Errorf("checkpoint: failed to remove stale diff checkpoint: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			// In case we have failed to create a checkpoint, make sure to clean up after ourselves.
+			_ = os.RemoveAll(diffCheckpointDir)
+		}
+	}()
+
+	// Create chunks directory.
+	chunksDir := filepath.Join(diffCheckpointDir, chunksDir)
+	if err = common.Mkdir(chunksDir); err != nil {
+		return nil, fmt.Errorf("checkpoint: failed to create chunk directory: %w", err)
+	}
+
+	// Create chunks covering only the changed subtrees.
+	var chunks []hash.Hash
+	err = createDiffChunks(ctx, fc.ndb, baseRoot, root, chunkSize, func(proof *syncer.Proof) error {
+		chunkIndex := len(chunks)
+		dataFilename := filepath.Join(chunksDir, strconv.Itoa(chunkIndex))
+
+		f, cerr := os.Create(dataFilename)
+		if cerr != nil {
+			return fmt.Errorf("checkpoint: failed to create chunk file for chunk %d: %w", chunkIndex, cerr)
+		}
+		chunkHash, cerr := writeChunk(proof, f)
+		f.Close()
+		if cerr != nil {
+			return fmt.Errorf("checkpoint: failed to create chunk %d: %w", chunkIndex, cerr)
+		}
+
+		chunks = append(chunks, chunkHash)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate and write diff checkpoint metadata.
+	meta = &Metadata{
+		Version:  checkpointVersion,
+		Root:     root,
+		Chunks:   chunks,
+		BaseRoot: &baseRoot,
+	}
+
+	if err = ioutil.WriteFile(filepath.Join(diffCheckpointDir, checkpointMetadataFile), cbor.Marshal(meta), 0o600); err != nil {
+		return nil, fmt.Errorf("checkpoint: failed to create diff checkpoint metadata: %w", err)
+	}
+	return meta, nil
+}
+
 func (fc *fileCreator) GetCheckpoints(ctx context.Context, request *GetCheckpointsRequest) ([]*Metadata, error) {
 	// Currently we only support a single version so we report no checkpoints for other versions.
 	if request.Version != checkpointVersion {
@@ -120,6 +212,14 @@ func (fc *fileCreator) GetCheckpoints(ctx context.Context, request *GetCheckpoin
 	if err != nil {
 		return nil, fmt.Errorf("checkpoint: failed to enumerate checkpoints: %w", err)
 	}
+	if request.IncludeDiffs {
+		var diffMatches []string
+		diffMatches, err = filepath.Glob(filepath.Join(fc.dataDir, versionGlob, "*", diffDir, checkpointMetadataFile))
+		if err != nil {
+			return nil, fmt.Errorf("checkpoint: failed to enumerate diff checkpoints: %w", err)
+		}
+		matches = append(matches, diffMatches...)
+	}
 
 	var cps []*Metadata
 	for _, m := range matches {
@@ -207,13 +307,27 @@ func (fc *fileCreator) GetCheckpointChunk(ctx context.Context, chunk *ChunkMetad
 		return ErrChunkNotFound
 	}
 
-	chunkFilename := filepath.Join(
+	checkpointDir := filepath.Join(
 		fc.dataDir,
 		strconv.FormatUint(chunk.Root.Version, 10),
 		chunk.Root.Hash.String(),
-		chunksDir,
-		strconv.FormatUint(chunk.Index, 10),
 	)
+	if chunk.BaseRoot != nil {
+		// Make sure that the diff checkpoint is against the requested base root.
+		checkpointDir = filepath.Join(checkpointDir, diffDir)
+		data, err := ioutil.ReadFile(filepath.Join(checkpointDir, checkpointMetadataFile))
+		if err != nil {
+			return ErrChunkNotFound
+		}
+		var cp Metadata
+		if err = cbor.Unmarshal(data, &cp); err != nil {
+			return fmt.Errorf("checkpoint: corrupted diff checkpoint metadata: %w", err)
+		}
+		if cp.BaseRoot == nil || !cp.BaseRoot.Equal(chunk.BaseRoot) {
+			return ErrChunkNotFound
+		}
+	}
+	chunkFilename := filepath.Join(checkpointDir, chunksDir, strconv.FormatUint(chunk.Index, 10))
 
 	f, err := os.Open(chunkFilename)
 	if err != nil {
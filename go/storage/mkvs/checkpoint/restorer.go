@@ -3,10 +3,13 @@ package checkpoint
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"sync"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 )
 
 // restorer is a checkpoint restorer.
@@ -20,6 +23,8 @@ type restorer struct {
 	currentCheckpoint *Metadata
 	// pendingChunks is a set of pending chunks.
 	pendingChunks map[uint64]bool
+	// references is a set of subtrees referenced by the restored chunks of a diff checkpoint.
+	references map[hash.Hash]bool
 }
 
 // Implements Restorer.
@@ -31,7 +36,21 @@ func (rs *restorer) StartRestore(ctx context.Context, checkpoint *Metadata) erro
 		return ErrRestoreAlreadyInProgress
 	}
 
+	// Diff checkpoints can only be restored on top of their base root.
+	if checkpoint.IsDiff() {
+		baseRoot := checkpoint.BaseRoot
+		if baseRoot.Type != checkpoint.Root.Type ||
+			!baseRoot.Namespace.Equal(&checkpoint.Root.Namespace) ||
+			baseRoot.Version >= checkpoint.Root.Version {
+			return fmt.Errorf("checkpoint: base root %s is not an earlier root of %s", baseRoot, checkpoint.Root)
+		}
+		if !rs.ndb.HasRoot(*baseRoot) {
+			return ErrBaseRootNotFound
+		}
+	}
+
 	rs.currentCheckpoint = checkpoint
+	rs.references = make(map[hash.Hash]bool)
 	rs.pendingChunks = make(map[uint64]bool)
 	for idx := range checkpoint.Chunks {
 		rs.pendingChunks[uint64(idx)] = true
@@ -45,6 +64,7 @@ func (rs *restorer) AbortRestore(ctx context.Context) error {
 	defer rs.Unlock()
 
 	rs.pendingChunks = nil
+	rs.references = nil
 	rs.currentCheckpoint = nil
 
 	return nil
@@ -83,7 +103,7 @@ func (rs *restorer) RestoreChunk(ctx context.Context, idx uint64, r io.Reader) (
 		return false, err
 	}
 
-	err = restoreChunk(ctx, rs.ndb, chunk, r)
+	refs, err := restoreChunk(ctx, rs.ndb, chunk, r)
 	switch {
 	case err == nil:
 	case errors.Is(err, ErrChunkProofVerificationFailed):
@@ -100,11 +120,25 @@ func (rs *restorer) RestoreChunk(ctx context.Context, idx uint64, r io.Reader) (
 
 	// Mark the given chunk as restored.
 	delete(rs.pendingChunks, idx)
+	for _, h := range refs {
+		rs.references[h] = true
+	}
 
 	// If there are no more pending chunks, restore is done.
 	if len(rs.pendingChunks) == 0 {
+		root := rs.currentCheckpoint.Root
+		references := rs.references
 		rs.pendingChunks = nil
+		rs.references = nil
 		rs.currentCheckpoint = nil
+
+		// Make sure that all subtrees referenced by a diff checkpoint are available, otherwise the
+		// restored tree would be missing nodes.
+		for h := range references {
+			if _, err = rs.ndb.GetNode(root, &node.Pointer{Clean: true, Hash: h}); err != nil {
+				return false, fmt.Errorf("%w: subtree %s: %s", ErrRestoreIncomplete, h, err.Error())
+			}
+		}
 		return true, nil
 	}
 
@@ -113,7 +113,8 @@ type NodeDB interface {
 	// The chunk argument specifies whether the given batch is being used to import a chunk of an
 	// existing root. Chunks may contain unresolved pointers (e.g., pointers that point to hashes
 	// which are not present in the database). Committing a chunk batch will prevent the version
-	// from being finalized.
+	// from being finalized. When importing a chunk of a diff checkpoint, oldRoot is the base root
+	// which may be from any earlier version.
 	NewBatch(oldRoot node.Root, version uint64, chunk bool) (Batch, error)
 
 	// HasRoot checks whether the given root exists.
@@ -995,7 +995,10 @@ func (ba *badgerBatch) Commit(root node.Root) error {
 	if err := ba.db.sanityCheckNamespace(root.Namespace); err != nil {
 		return err
 	}
-	if !root.Follows(&ba.oldRoot) {
+	// Chunks of diff checkpoints are imported on top of an earlier base root of the same type.
+	diffChunk := ba.chunk && !ba.oldRoot.Hash.IsEmpty() && ba.oldRoot.Type == root.Type &&
+		ba.oldRoot.Namespace.Equal(&root.Namespace) && ba.oldRoot.Version < root.Version
+	if !diffChunk && !root.Follows(&ba.oldRoot) {
 		return api.ErrRootMustFollowOld
 	}
 
@@ -1048,6 +1051,37 @@ func (ba *badgerBatch) Commit(root node.Root) error {
 		if err = tx.Set(key, cbor.Marshal([]updatedNode{})); err != nil {
 			return fmt.Errorf("mkvs/badger: set returned error: %w", err)
 		}
+
+		// Link diff chunks to their base root so that the nodes shared with the base root are
+		// not pruned together with the base version.
+		if diffChunk {
+			oldRootHash := typedHashFromRoot(ba.oldRoot)
+
+			var oldRootsMeta *rootsMetadata
+			oldRootsMeta, err = loadRootsMetadata(tx, ba.oldRoot.Version)
+			if err != nil {
+				return err
+			}
+
+			derivedRoots, ok := oldRootsMeta.Roots[oldRootHash]
+			if !ok {
+				return api.ErrRootNotFound
+			}
+
+			var linked bool
+			for _, h := range derivedRoots {
+				if h.Equal(&rootHash) {
+					linked = true
+					break
+				}
+			}
+			if !linked {
+				oldRootsMeta.Roots[oldRootHash] = append(derivedRoots, rootHash)
+				if err = oldRootsMeta.save(tx); err != nil {
+					return fmt.Errorf("mkvs/badger: failed to save old roots metadata: %w", err)
+				}
+			}
+		}
 	} else {
 		// Update the root link for the old root.
 		oldRootHash := typedHashFromRoot(ba.oldRoot)
@@ -0,0 +1,182 @@
+package mkvs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+)
+
+// ErrDiffRootMismatch is the error returned by VerifyDiff when applying the write log to the start
+// root does not result in the end root.
+var ErrDiffRootMismatch = errors.New("mkvs: diff does not result in the end root")
+
+var _ writelog.Iterator = (*diffIterator)(nil)
+
+type diffIterator struct {
+	ctx context.Context
+
+	startTree Tree
+	endTree   Tree
+	start     Iterator
+	end       Iterator
+
+	started bool
+	done    bool
+	cached  *writelog.LogEntry
+}
+
+func (d *diffIterator) close() {
+	if d.done {
+		return
+	}
+	d.done = true
+	d.cached = nil
+	d.start.Close()
+	d.end.Close()
+	d.startTree.Close()
+	d.endTree.Close()
+}
+
+func (d *diffIterator) Next() (bool, error) {
+	if d.done {
+		return false, nil
+	}
+	if !d.started {
+		d.started = true
+		d.start.Rewind()
+		d.end.Rewind()
+	}
+
+	for {
+		if err := d.ctx.Err(); err != nil {
+			d.close()
+			return false, err
+		}
+		if err := d.start.Err(); err != nil {
+			d.close()
+			return false, err
+		}
+		if err := d.end.Err(); err != nil {
+			d.close()
+			return false, err
+		}
+
+		var cmp int
+		switch {
+		case !d.start.Valid() && !d.end.Valid():
+			d.close()
+			return false, nil
+		case !d.start.Valid():
+			cmp = 1
+		case !d.end.Valid():
+			cmp = -1
+		default:
+			cmp = bytes.Compare(d.start.Key(), d.end.Key())
+		}
+
+		switch {
+		case cmp < 0:
+			// Key has been removed.
+			d.cached = &writelog.LogEntry{Key: d.start.Key()}
+			d.start.Next()
+			return true, nil
+		case cmp > 0:
+			// Key has been inserted.
+			d.cached = &writelog.LogEntry{Key: d.end.Key(), Value: d.end.Value()}
+			d.end.Next()
+			return true, nil
+		default:
+			// Key exists in both trees, emit only if the value has been changed.
+			var entry *writelog.LogEntry
+			if !bytes.Equal(d.start.Value(), d.end.Value()) {
+				entry = &writelog.LogEntry{Key: d.end.Key(), Value: d.end.Value()}
+			}
+			d.start.Next()
+			d.end.Next()
+			if entry != nil {
+				d.cached = entry
+				return true, nil
+			}
+		}
+	}
+}
+
+func (d *diffIterator) Value() (writelog.LogEntry, error) {
+	if d.cached == nil {
+		return writelog.LogEntry{}, writelog.ErrIteratorInvalid
+	}
+	return *d.cached, nil
+}
+
+// Diff returns a write log iterator that transforms the state of the start tree into the state
+// of the end tree. Entries are returned in key order.
+//
+// The write log is computed by walking both trees so the cost is proportional to the size of the
+// trees, not to the size of the difference. Callers can verify the write log by applying it to
+// the start root and checking that the resulting root matches the end root.
+//
+// The iterator takes ownership of the trees and closes them once it has been exhausted.
+func Diff(ctx context.Context, start, end Tree) writelog.Iterator {
+	return &diffIterator{
+		ctx:       ctx,
+		startTree: start,
+		endTree:   end,
+		start:     start.NewIterator(ctx),
+		end:       end.NewIterator(ctx),
+	}
+}
+
+// VerifyDiff verifies that the write log returned by the given iterator transforms the state at the
+// start root into the state at the end root and returns the verified write log.
+//
+// Any parts of the start state needed to apply the write log are fetched via the given read syncer
+// and verified against the start root using proofs, so the caller does not need to have a local
+// copy of the start state.
+func VerifyDiff(
+	ctx context.Context,
+	rs syncer.ReadSyncer,
+	startRoot node.Root,
+	endRoot node.Root,
+	it writelog.Iterator,
+) (writelog.WriteLog, error) {
+	if startRoot.Type != endRoot.Type || !startRoot.Namespace.Equal(&endRoot.Namespace) || endRoot.Version < startRoot.Version {
+		return nil, fmt.Errorf("mkvs: end root must follow start root")
+	}
+
+	var wl writelog.WriteLog
+	for {
+		more, err := it.Next()
+		if err != nil {
+			return nil, fmt.Errorf("mkvs: failed to fetch write log: %w", err)
+		}
+		if !more {
+			break
+		}
+
+		entry, err := it.Value()
+		if err != nil {
+			return nil, fmt.Errorf("mkvs: failed to fetch write log: %w", err)
+		}
+		wl = append(wl, entry)
+	}
+
+	tree := NewWithRoot(rs, nil, startRoot)
+	defer tree.Close()
+
+	if err := tree.ApplyWriteLog(ctx, writelog.NewStaticIterator(wl)); err != nil {
+		return nil, fmt.Errorf("mkvs: failed to apply write log: %w", err)
+	}
+	_, rootHash, err := tree.Commit(ctx, endRoot.Namespace, endRoot.Version)
+	if err != nil {
+		return nil, fmt.Errorf("mkvs: failed to commit write log: %w", err)
+	}
+	if !rootHash.Equal(&endRoot.Hash) {
+		return nil, ErrDiffRootMismatch
+	}
+	return wl, nil
+}
@@ -0,0 +1,181 @@
+package mkvs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+)
+
+// newDiffTestTree creates an in-memory tree with the given state committed at the given version.
+func newDiffTestTree(t *testing.T, state map[string][]byte, version uint64) (Tree, node.Root) {
+	ctx := context.Background()
+	tree := New(nil, nil, node.RootTypeState)
+	for key, value := range state {
+		err := tree.Insert(ctx, []byte(key), value)
+		require.NoError(t, err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, testNs, version)
+	require.NoError(t, err, "Commit")
+
+	return tree, node.Root{
+		Namespace: testNs,
+		Version:   version,
+		Type:      node.RootTypeState,
+		Hash:      rootHash,
+	}
+}
+
+func foldDiff(t *testing.T, it writelog.Iterator) writelog.WriteLog {
+	var wl writelog.WriteLog
+	for {
+		more, err := it.Next()
+		require.NoError(t, err, "Next")
+		if !more {
+			return wl
+		}
+		entry, err := it.Value()
+		require.NoError(t, err, "Value")
+		wl = append(wl, entry)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	ctx := context.Background()
+
+	startState := make(map[string][]byte)
+	keys, values := generateKeyValuePairsEx("", 100)
+	for i, key := range keys {
+		startState[string(key)] = values[i]
+	}
+
+	// Remove, update and insert some keys.
+	endState := make(map[string][]byte)
+	for key, value := range startState {
+		endState[key] = value
+	}
+	var expectedWl writelog.WriteLog
+	for i := 0; i < 10; i++ {
+		removed, updated, inserted := keys[i], keys[50+i], []byte(fmt.Sprintf("inserted %d", i))
+		delete(endState, string(removed))
+		endState[string(updated)] = []byte(fmt.Sprintf("updated %d", i))
+		endState[string(inserted)] = []byte(fmt.Sprintf("value %d", i))
+		expectedWl = append(expectedWl,
+			writelog.LogEntry{Key: removed},
+			writelog.LogEntry{Key: updated, Value: endState[string(updated)]},
+			writelog.LogEntry{Key: inserted, Value: endState[string(inserted)]},
+		)
+	}
+	sort.Slice(expectedWl, func(i, j int) bool {
+		return string(expectedWl[i].Key) < string(expectedWl[j].Key)
+	})
+
+	startTree, startRoot := newDiffTestTree(t, startState, 1)
+	endTree, endRoot := newDiffTestTree(t, endState, 3)
+
+	it := Diff(ctx, startTree, endTree)
+	_, err := it.Value()
+	require.ErrorIs(t, err, writelog.ErrIteratorInvalid, "Value before Next")
+	wl := foldDiff(t, it)
+	require.Equal(t, expectedWl, wl, "diff should contain all changes in key order")
+
+	t.Run("Identical", func(t *testing.T) {
+		startTree, _ := newDiffTestTree(t, startState, 1)
+		sameTree, _ := newDiffTestTree(t, startState, 2)
+		require.Empty(t, foldDiff(t, Diff(ctx, startTree, sameTree)), "diff of identical trees should be empty")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		startTree, _ := newDiffTestTree(t, startState, 1)
+		emptyTree, _ := newDiffTestTree(t, nil, 2)
+		wl := foldDiff(t, Diff(ctx, startTree, emptyTree))
+		require.Len(t, wl, len(startState), "diff to an empty tree should remove all keys")
+		for _, entry := range wl {
+			require.Nil(t, entry.Value, "diff to an empty tree should remove all keys")
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		startTree, _ := newDiffTestTree(t, startState, 1)
+		endTree, _ := newDiffTestTree(t, endState, 3)
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := Diff(cctx, startTree, endTree).Next()
+		require.ErrorIs(t, err, context.Canceled, "Next with a canceled context")
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		require := require.New(t)
+
+		// Round-trip the diff through verification, fetching the start state from a "remote"
+		// tree via the syncer interface.
+		remoteTree, _ := newDiffTestTree(t, startState, 1)
+		defer remoteTree.Close()
+		stats := syncer.NewStatsCollector(remoteTree)
+
+		verifiedWl, err := VerifyDiff(ctx, stats, startRoot, endRoot, writelog.NewStaticIterator(wl))
+		require.NoError(err, "VerifyDiff")
+		require.Equal(wl, verifiedWl, "VerifyDiff should return the verified write log")
+		require.NotZero(stats.SyncGetCount, "start state should be fetched via the syncer")
+
+		// Verifying the diff of the actual trees should also work.
+		startTree, _ := newDiffTestTree(t, startState, 1)
+		endTree, _ := newDiffTestTree(t, endState, 3)
+		_, err = VerifyDiff(ctx, remoteTree, startRoot, endRoot, Diff(ctx, startTree, endTree))
+		require.NoError(err, "VerifyDiff")
+
+		// An empty diff should only verify between identical states.
+		_, err = VerifyDiff(ctx, remoteTree, startRoot, node.Root{
+			Namespace: startRoot.Namespace,
+			Version:   2,
+			Type:      startRoot.Type,
+			Hash:      startRoot.Hash,
+		}, writelog.NewStaticIterator(nil))
+		require.NoError(err, "VerifyDiff with an empty diff")
+		_, err = VerifyDiff(ctx, remoteTree, startRoot, endRoot, writelog.NewStaticIterator(nil))
+		require.ErrorIs(err, ErrDiffRootMismatch, "VerifyDiff with a missing diff")
+	})
+
+	t.Run("VerifyTampered", func(t *testing.T) {
+		remoteTree, _ := newDiffTestTree(t, startState, 1)
+		defer remoteTree.Close()
+
+		tamper := func(fn func(wl writelog.WriteLog) writelog.WriteLog) writelog.WriteLog {
+			tampered := make(writelog.WriteLog, len(wl))
+			copy(tampered, wl)
+			return fn(tampered)
+		}
+		for name, tamperedWl := range map[string]writelog.WriteLog{
+			"ModifiedValue": tamper(func(wl writelog.WriteLog) writelog.WriteLog {
+				wl[1] = writelog.LogEntry{Key: wl[1].Key, Value: []byte("tampered")}
+				return wl
+			}),
+			"DroppedEntry": tamper(func(wl writelog.WriteLog) writelog.WriteLog {
+				return wl[1:]
+			}),
+			"ExtraEntry": tamper(func(wl writelog.WriteLog) writelog.WriteLog {
+				return append(wl, writelog.LogEntry{Key: []byte("extra"), Value: []byte("extra")})
+			}),
+		} {
+			_, err := VerifyDiff(ctx, remoteTree, startRoot, endRoot, writelog.NewStaticIterator(tamperedWl))
+			require.ErrorIs(t, err, ErrDiffRootMismatch, "VerifyDiff with a tampered diff (%s)", name)
+		}
+
+		// Start state not matching the start root should fail proof verification.
+		otherTree, _ := newDiffTestTree(t, endState, 3)
+		defer otherTree.Close()
+		_, err := VerifyDiff(ctx, otherTree, startRoot, endRoot, writelog.NewStaticIterator(wl))
+		require.Error(t, err, "VerifyDiff with a start state not matching the start root")
+		require.NotErrorIs(t, err, ErrDiffRootMismatch, "proof verification should fail before applying the diff")
+
+		// Roots going backwards should be rejected.
+		_, err = VerifyDiff(ctx, remoteTree, endRoot, startRoot, writelog.NewStaticIterator(nil))
+		require.Error(t, err, "VerifyDiff with roots going backwards")
+	})
+}
@@ -1,6 +1,7 @@
 package mkvs
 
 import (
+	"bytes"
 	"context"
 	"errors"
 
@@ -35,11 +36,27 @@ func (t *tree) SyncIterate(ctx context.Context, request *syncer.IterateRequest)
 	)
 	defer it.Close()
 
-	it.Seek(request.Key)
+	// Resume from the continuation in case one was given.
+	seekKey := request.Key
+	if request.Continuation != nil {
+		if bytes.Compare(request.Continuation, request.Key) < 0 {
+			return nil, syncer.ErrInvalidContinuation
+		}
+		seekKey = request.Continuation
+	}
+
+	it.Seek(seekKey)
 	if it.Err() != nil {
 		return nil, it.Err()
 	}
+	var continuation []byte
 	for i := 0; it.Valid() && i < int(request.Prefetch); i++ {
+		// Stop early in case the proof grew too large, making sure that each request makes
+		// some progress. The client can resume from the current key.
+		if i > 0 && request.MaxProofSize > 0 && it.GetProofBuilder().Size() >= request.MaxProofSize {
+			continuation = append([]byte{}, it.Key()...)
+			break
+		}
 		it.Next()
 	}
 	if it.Err() != nil {
@@ -53,7 +70,8 @@ func (t *tree) SyncIterate(ctx context.Context, request *syncer.IterateRequest)
 	}
 
 	return &syncer.ProofResponse{
-		Proof: *proof,
+		Proof:        *proof,
+		Continuation: continuation,
 	}, nil
 }
 
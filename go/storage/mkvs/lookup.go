@@ -48,14 +48,16 @@ func (t *tree) SyncGet(ctx context.Context, request *syncer.GetRequest) (*syncer
 	// Remember where the path from root to target node ends (will end).
 	t.cache.markPosition()
 
-	pb := syncer.NewProofBuilder(request.Tree.Root.Hash, request.Tree.Position)
-	opts := doGetOptions{
-		proofBuilder:    pb,
-		includeSiblings: request.IncludeSiblings,
-	}
-	if _, err := t.doGet(ctx, t.cache.pendingRoot, 0, request.Key, opts, false); err != nil {
+	pb, err := t.buildGetProof(ctx, request, request.IncludeSiblings)
+	if err != nil {
 		return nil, err
 	}
+	if request.IncludeSiblings && request.MaxProofSize > 0 && pb.Size() > request.MaxProofSize {
+		// The proof with siblings is too large, retry without the siblings.
+		if pb, err = t.buildGetProof(ctx, request, false); err != nil {
+			return nil, err
+		}
+	}
 	proof, err := pb.Build(ctx)
 	if err != nil {
 		return nil, err
@@ -66,6 +68,18 @@ func (t *tree) SyncGet(ctx context.Context, request *syncer.GetRequest) (*syncer
 	}, nil
 }
 
+func (t *tree) buildGetProof(ctx context.Context, request *syncer.GetRequest, includeSiblings bool) (*syncer.ProofBuilder, error) {
+	pb := syncer.NewProofBuilder(request.Tree.Root.Hash, request.Tree.Position)
+	opts := doGetOptions{
+		proofBuilder:    pb,
+		includeSiblings: includeSiblings,
+	}
+	if _, err := t.doGet(ctx, t.cache.pendingRoot, 0, request.Key, opts, false); err != nil {
+		return nil, err
+	}
+	return pb, nil
+}
+
 func (t *tree) newFetcherSyncGet(key node.Key, includeSiblings bool) readSyncFetcher {
 	return func(ctx context.Context, ptr *node.Pointer, rs syncer.ReadSyncer) (*syncer.Proof, error) {
 		rsp, err := rs.SyncGet(ctx, &syncer.GetRequest{
@@ -63,6 +63,16 @@ type OverlayTree interface {
 
 	// Commit commits any modifications to the underlying tree.
 	Commit(ctx context.Context) error
+
+	// Rebase replaces the underlying tree with the given tree while preserving any modifications
+	// that have not yet been committed, returning the previous underlying tree. The modifications
+	// take precedence over the contents of the new underlying tree.
+	//
+	// The overlay does not close the previous underlying tree, the caller is responsible for
+	// closing it if needed.
+	//
+	// This can be used to rebase speculative updates onto a newer version of the underlying tree.
+	Rebase(inner KeyValueTree) KeyValueTree
 }
 
 // Tree is a general MKVS tree interface.
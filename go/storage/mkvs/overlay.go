@@ -116,6 +116,13 @@ func (o *treeOverlay) Commit(ctx context.Context) error {
 	return nil
 }
 
+// Implements OverlayTree.
+func (o *treeOverlay) Rebase(inner KeyValueTree) KeyValueTree {
+	prev := o.inner
+	o.inner = inner
+	return prev
+}
+
 // Implements ClosableTree.
 func (o *treeOverlay) Close() {
 	if o.inner == nil {
@@ -170,3 +170,91 @@ func TestOverlay(t *testing.T) {
 	_, err = tree.Get(ctx, []byte("key"))
 	require.NoError(err, "Get")
 }
+
+func TestOverlayRebase(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	base := New(nil, nil, node.RootTypeState)
+	defer base.Close()
+	err := base.ApplyWriteLog(ctx, writelog.NewStaticIterator(writelog.WriteLog{
+		writelog.LogEntry{Key: []byte("key 1"), Value: []byte("one")},
+		writelog.LogEntry{Key: []byte("key 2"), Value: []byte("two")},
+	}))
+	require.NoError(err, "ApplyWriteLog")
+
+	// Perform some speculative updates on top of the base tree.
+	overlay := NewOverlay(base)
+	defer overlay.Close()
+	err = overlay.Insert(ctx, []byte("key 3"), []byte("three"))
+	require.NoError(err, "Insert")
+	err = overlay.Remove(ctx, []byte("key 2"))
+	require.NoError(err, "Remove")
+
+	// Rebase the overlay onto a newer tree.
+	newer := New(nil, nil, node.RootTypeState)
+	defer newer.Close()
+	err = newer.ApplyWriteLog(ctx, writelog.NewStaticIterator(writelog.WriteLog{
+		writelog.LogEntry{Key: []byte("key 1"), Value: []byte("uno")},
+		writelog.LogEntry{Key: []byte("key 2"), Value: []byte("dos")},
+		writelog.LogEntry{Key: []byte("key 4"), Value: []byte("cuatro")},
+	}))
+	require.NoError(err, "ApplyWriteLog")
+	prev := overlay.Rebase(newer)
+	require.Equal(base, prev, "Rebase should return the previous underlying tree")
+
+	// Keys not modified in the overlay should come from the new tree while the overlay
+	// modifications should take precedence.
+	items := writelog.WriteLog{
+		writelog.LogEntry{Key: []byte("key 1"), Value: []byte("uno")},
+		writelog.LogEntry{Key: []byte("key 3"), Value: []byte("three")},
+		writelog.LogEntry{Key: []byte("key 4"), Value: []byte("cuatro")},
+	}
+	tests := []testCase{
+		{seek: node.Key("key 1"), pos: 0},
+		{seek: node.Key("key 2"), pos: 1},
+		{seek: node.Key("key 4"), pos: 2},
+		{seek: node.Key("key 5"), pos: -1},
+	}
+
+	t.Run("Rebased/Get", func(t *testing.T) {
+		for _, item := range items {
+			var value []byte
+			value, err = overlay.Get(ctx, item.Key)
+			require.NoError(err, "Get")
+			require.Equal(item.Value, value, "value from rebased overlay should be correct")
+		}
+		var value []byte
+		value, err = overlay.Get(ctx, []byte("key 2"))
+		require.NoError(err, "Get")
+		require.Nil(value, "removed key should not exist in rebased overlay")
+	})
+
+	t.Run("Rebased/Iterator", func(t *testing.T) {
+		it := overlay.NewIterator(ctx)
+		defer it.Close()
+
+		testIterator(t, items, it, tests)
+	})
+
+	// Committing the overlay should only update the new tree.
+	err = overlay.Commit(ctx)
+	require.NoError(err, "Commit")
+
+	t.Run("Committed/Get", func(t *testing.T) {
+		var value []byte
+		value, err = newer.Get(ctx, []byte("key 2"))
+		require.NoError(err, "Get")
+		require.Nil(value, "removed key should not exist in new tree")
+		value, err = newer.Get(ctx, []byte("key 3"))
+		require.NoError(err, "Get")
+		require.Equal([]byte("three"), value, "value in new tree should be updated")
+
+		value, err = base.Get(ctx, []byte("key 2"))
+		require.NoError(err, "Get")
+		require.Equal([]byte("two"), value, "value in old tree should be unchanged")
+		value, err = base.Get(ctx, []byte("key 3"))
+		require.NoError(err, "Get")
+		require.Nil(value, "value should not exist in old tree")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
@@ -72,13 +73,38 @@ func (t *tree) SyncGetPrefixes(ctx context.Context, request *syncer.GetPrefixesR
 		}
 	}
 
+	// Resume from the continuation in case one was given.
+	var (
+		start    int
+		startKey []byte
+	)
+	if request.Continuation != nil {
+		var pc prefixesContinuation
+		if err := cbor.Unmarshal(request.Continuation, &pc); err != nil {
+			return nil, syncer.ErrInvalidContinuation
+		}
+		if int(pc.Prefix) >= len(request.Prefixes) || !bytes.HasPrefix(pc.Key, request.Prefixes[pc.Prefix]) {
+			return nil, syncer.ErrInvalidContinuation
+		}
+		start = int(pc.Prefix)
+		startKey = pc.Key
+	}
+
 	it := t.NewIterator(ctx, WithProof(request.Tree.Root.Hash))
 	defer it.Close()
 
-	var total int
+	var (
+		total        int
+		continuation []byte
+	)
 prefixLoop:
-	for _, prefix := range request.Prefixes {
-		it.Seek(prefix)
+	for idx := start; idx < len(request.Prefixes); idx++ {
+		prefix := request.Prefixes[idx]
+		if idx == start && startKey != nil {
+			it.Seek(startKey)
+		} else {
+			it.Seek(prefix)
+		}
 		if it.Err() != nil {
 			return nil, it.Err()
 		}
@@ -89,6 +115,15 @@ prefixLoop:
 			if !bytes.HasPrefix(it.Key(), prefix) {
 				break
 			}
+			// Stop early in case the proof grew too large, making sure that each request
+			// makes some progress. The client can resume from the current key.
+			if total > 0 && request.MaxProofSize > 0 && it.GetProofBuilder().Size() >= request.MaxProofSize {
+				continuation = cbor.Marshal(prefixesContinuation{
+					Prefix: uint16(idx),
+					Key:    append([]byte{}, it.Key()...),
+				})
+				break prefixLoop
+			}
 			it.Next()
 		}
 		if it.Err() != nil {
@@ -102,6 +137,15 @@ prefixLoop:
 	}
 
 	return &syncer.ProofResponse{
-		Proof: *proof,
+		Proof:        *proof,
+		Continuation: continuation,
 	}, nil
 }
+
+// prefixesContinuation is the continuation token used by SyncGetPrefixes.
+type prefixesContinuation struct {
+	// Prefix is the index of the prefix to resume from.
+	Prefix uint16 `json:"prefix"`
+	// Key is the key to resume from.
+	Key []byte `json:"key"`
+}
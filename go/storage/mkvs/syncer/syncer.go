@@ -18,6 +18,9 @@ var (
 	ErrInvalidRoot = errors.New("mkvs: invalid root")
 	// ErrUnsupported is the error returned when a ReadSyncer method is not supported.
 	ErrUnsupported = errors.New("mkvs: method not supported")
+	// ErrInvalidContinuation is the error returned when a ReadSyncer request contains an
+	// invalid continuation token.
+	ErrInvalidContinuation = errors.New("mkvs: invalid continuation token")
 )
 
 // TreeID identifies a specific tree and a position within that tree.
@@ -34,6 +37,10 @@ type GetRequest struct {
 	Tree            TreeID `json:"tree"`
 	Key             []byte `json:"key"`
 	IncludeSiblings bool   `json:"include_siblings,omitempty"`
+
+	// MaxProofSize is the maximum size of the returned proof in bytes. In case the proof with
+	// siblings would exceed this size, the siblings are omitted. Zero means no limit.
+	MaxProofSize uint64 `json:"max_proof_size,omitempty"`
 }
 
 // GetPrefixesRequest is a request for the SyncGetPrefixes operation.
@@ -41,6 +48,14 @@ type GetPrefixesRequest struct {
 	Tree     TreeID   `json:"tree"`
 	Prefixes [][]byte `json:"prefixes"`
 	Limit    uint16   `json:"limit"`
+
+	// MaxProofSize is the maximum size of the returned proof in bytes. In case the proof would
+	// exceed this size, it is truncated and a continuation token is returned. Zero means no
+	// limit.
+	MaxProofSize uint64 `json:"max_proof_size,omitempty"`
+	// Continuation is the continuation token returned by a previous request with the same
+	// prefixes, used to resume proof retrieval.
+	Continuation []byte `json:"continuation,omitempty"`
 }
 
 // IterateRequest is a request for the SyncIterate operation.
@@ -48,11 +63,23 @@ type IterateRequest struct {
 	Tree     TreeID `json:"tree"`
 	Key      []byte `json:"key"`
 	Prefetch uint16 `json:"prefetch"`
+
+	// MaxProofSize is the maximum size of the returned proof in bytes. In case the proof would
+	// exceed this size, it is truncated and a continuation token is returned. Zero means no
+	// limit.
+	MaxProofSize uint64 `json:"max_proof_size,omitempty"`
+	// Continuation is the continuation token returned by a previous request, used to resume
+	// proof retrieval. If set, iteration starts at the continuation instead of at Key.
+	Continuation []byte `json:"continuation,omitempty"`
 }
 
 // ProofResponse is a response for requests that produce proofs.
 type ProofResponse struct {
 	Proof Proof `json:"proof"`
+
+	// Continuation is the continuation token in case the proof has been truncated due to the
+	// maximum proof size. It can be passed in a subsequent request to resume proof retrieval.
+	Continuation []byte `json:"continuation,omitempty"`
 }
 
 // ReadSyncer is the interface for synchronizing the in-memory cache
@@ -819,6 +819,108 @@ func testSyncerPrefetchPrefixes(t *testing.T, ndb db.NodeDB, factory NodeDBFacto
 	require.EqualValues(t, 0, stats.SyncIterateCount, "SyncIterate should not be called")
 }
 
+func testSyncerProofSizeLimit(t *testing.T, ndb db.NodeDB, factory NodeDBFactory) {
+	ctx := context.Background()
+	keys, _, root, tree := generatePopulatedTree(t, ndb)
+	treeID := syncer.TreeID{Root: root, Position: root.Hash}
+
+	var pv syncer.ProofVerifier
+
+	// Iterating with a tiny proof size limit should require resuming multiple times.
+	var (
+		continuation []byte
+		requests     int
+	)
+	for {
+		rsp, err := tree.SyncIterate(ctx, &syncer.IterateRequest{
+			Tree:         treeID,
+			Key:          keys[0],
+			Prefetch:     uint16(len(keys)),
+			MaxProofSize: 1,
+			Continuation: continuation,
+		})
+		require.NoError(t, err, "SyncIterate")
+		_, err = pv.VerifyProof(ctx, root.Hash, &rsp.Proof)
+		require.NoError(t, err, "VerifyProof")
+		requests++
+		if rsp.Continuation == nil {
+			break
+		}
+		require.True(t, bytes.Compare(rsp.Continuation, continuation) > 0, "continuation should make progress")
+		continuation = rsp.Continuation
+	}
+	require.True(t, requests > 1, "SyncIterate should be resumed")
+	require.True(t, requests <= len(keys), "SyncIterate should make progress")
+
+	// Without a limit, no continuation should be returned.
+	rsp, err := tree.SyncIterate(ctx, &syncer.IterateRequest{
+		Tree:     treeID,
+		Key:      keys[0],
+		Prefetch: uint16(len(keys)),
+	})
+	require.NoError(t, err, "SyncIterate")
+	require.Nil(t, rsp.Continuation, "SyncIterate should not return a continuation without a limit")
+
+	// Continuations before the start key are invalid.
+	_, err = tree.SyncIterate(ctx, &syncer.IterateRequest{
+		Tree:         treeID,
+		Key:          []byte("key 1"),
+		Prefetch:     10,
+		Continuation: []byte("key 0"),
+	})
+	require.ErrorIs(t, err, syncer.ErrInvalidContinuation, "SyncIterate should reject invalid continuation")
+
+	// Fetching prefixes with a tiny proof size limit should require resuming multiple times.
+	prefixes := [][]byte{[]byte("key 1"), []byte("key 2")}
+	continuation = nil
+	requests = 0
+	for {
+		rsp, err = tree.SyncGetPrefixes(ctx, &syncer.GetPrefixesRequest{
+			Tree:         treeID,
+			Prefixes:     prefixes,
+			Limit:        uint16(len(keys)),
+			MaxProofSize: 1,
+			Continuation: continuation,
+		})
+		require.NoError(t, err, "SyncGetPrefixes")
+		_, err = pv.VerifyProof(ctx, root.Hash, &rsp.Proof)
+		require.NoError(t, err, "VerifyProof")
+		requests++
+		if rsp.Continuation == nil {
+			break
+		}
+		continuation = rsp.Continuation
+		require.True(t, requests <= len(keys), "SyncGetPrefixes should make progress")
+	}
+	require.True(t, requests > 1, "SyncGetPrefixes should be resumed")
+
+	_, err = tree.SyncGetPrefixes(ctx, &syncer.GetPrefixesRequest{
+		Tree:         treeID,
+		Prefixes:     prefixes,
+		Limit:        10,
+		Continuation: []byte("garbage"),
+	})
+	require.ErrorIs(t, err, syncer.ErrInvalidContinuation, "SyncGetPrefixes should reject invalid continuation")
+
+	// Siblings should be omitted in case the proof would be too large.
+	rsp, err = tree.SyncGet(ctx, &syncer.GetRequest{
+		Tree:            treeID,
+		Key:             keys[0],
+		IncludeSiblings: true,
+	})
+	require.NoError(t, err, "SyncGet")
+	rspLimited, err := tree.SyncGet(ctx, &syncer.GetRequest{
+		Tree:            treeID,
+		Key:             keys[0],
+		IncludeSiblings: true,
+		MaxProofSize:    1,
+	})
+	require.NoError(t, err, "SyncGet")
+	require.True(t, len(rspLimited.Proof.Entries) < len(rsp.Proof.Entries), "SyncGet should omit siblings")
+	_, err = pv.VerifyProof(ctx, root.Hash, &rspLimited.Proof)
+	require.NoError(t, err, "VerifyProof")
+}
+
 func testValueEviction(t *testing.T, ndb db.NodeDB, factory NodeDBFactory) {
 	ctx := context.Background()
 	tree := New(nil, ndb, node.RootTypeState, Capacity(0, 512)).(*tree)
@@ -2221,6 +2323,7 @@ func testBackend(
 		{"SyncerInsert", testSyncerInsert},
 		{"SyncerNilNodes", testSyncerNilNodes},
 		{"SyncerPrefetchPrefixes", testSyncerPrefetchPrefixes},
+		{"SyncerProofSizeLimit", testSyncerProofSizeLimit},
 		{"ValueEviction", testValueEviction},
 		{"NodeEviction", testNodeEviction},
 		{"DoubleInsertWithEviction", testDoubleInsertWithEviction},
@@ -16,6 +16,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
 )
 
 var testValues = [][]byte{
@@ -181,6 +182,11 @@ func testBasic(t *testing.T, localBackend api.LocalBackend, backend api.Backend,
 	})
 	require.NoError(t, err, "Apply() should not return an error")
 
+	// Test diffs spanning multiple versions.
+	t.Run("GetDiffMultipleVersions", func(t *testing.T) {
+		testGetDiffMultipleVersions(t, localBackend, backend, newRoot, wl)
+	})
+
 	// Test checkpoints.
 	t.Run("Checkpoints", func(t *testing.T) {
 		// Create a new checkpoint with the local backend.
@@ -205,3 +211,88 @@ func testBasic(t *testing.T, localBackend api.LocalBackend, backend api.Backend,
 		require.Equal(t, cp.Chunks[0], hb.Build(), "GetCheckpointChunk must return correct chunk")
 	})
 }
+
+func testGetDiffMultipleVersions(t *testing.T, localBackend api.LocalBackend, backend api.Backend, startRoot api.Root, startWl api.WriteLog) {
+	ctx := context.Background()
+
+	// Apply some changes in each of the following versions.
+	stateWl := make(api.WriteLog, len(startWl))
+	copy(stateWl, startWl)
+	updates := []api.WriteLog{
+		{
+			{Key: []byte("0"), Value: []byte("updated in first version")},
+			{Key: []byte("1"), Value: nil},
+			{Key: []byte("new key"), Value: []byte("inserted in first version")},
+		},
+		{
+			{Key: []byte("0"), Value: []byte("updated in second version")},
+			{Key: []byte("new key"), Value: nil},
+		},
+	}
+	root := startRoot
+	for _, update := range updates {
+		stateWl = mergeWriteLog(stateWl, update)
+		newRoot := api.Root{
+			Namespace: root.Namespace,
+			Version:   root.Version + 1,
+			Type:      root.Type,
+			Hash:      CalculateExpectedNewRoot(t, stateWl, root.Namespace, root.Version+1),
+		}
+		err := localBackend.Apply(ctx, &api.ApplyRequest{
+			Namespace: root.Namespace,
+			RootType:  root.Type,
+			SrcRound:  root.Version,
+			SrcRoot:   root.Hash,
+			DstRound:  newRoot.Version,
+			DstRoot:   newRoot.Hash,
+			WriteLog:  update,
+		})
+		require.NoError(t, err, "Apply")
+		root = newRoot
+	}
+
+	it, err := backend.GetDiff(ctx, &api.GetDiffRequest{StartRoot: startRoot, EndRoot: root})
+	require.NoError(t, err, "GetDiff")
+	diffWl := foldWriteLogIterator(t, it)
+	require.Equal(t, api.WriteLog{
+		{Key: []byte("0"), Value: []byte("updated in second version")},
+		{Key: []byte("1"), Value: nil},
+	}, diffWl, "GetDiff should return the combined write log")
+
+	// Verify the write log against both roots, fetching the start state from the backend.
+	verifiedWl, err := mkvs.VerifyDiff(ctx, backend, startRoot, root, writelog.NewStaticIterator(diffWl))
+	require.NoError(t, err, "VerifyDiff")
+	require.Equal(t, diffWl, verifiedWl, "VerifyDiff should return the verified write log")
+
+	// Tampered write logs should not verify.
+	tamperedWl := append(api.WriteLog{}, diffWl...)
+	tamperedWl[0].Value = []byte("tampered")
+	_, err = mkvs.VerifyDiff(ctx, backend, startRoot, root, writelog.NewStaticIterator(tamperedWl))
+	require.ErrorIs(t, err, mkvs.ErrDiffRootMismatch, "VerifyDiff should fail for tampered write logs")
+
+	// Diffs going backwards are not allowed.
+	_, err = backend.GetDiff(ctx, &api.GetDiffRequest{StartRoot: root, EndRoot: startRoot})
+	require.Error(t, err, "GetDiff should fail for roots going backwards")
+}
+
+// mergeWriteLog applies the updates to the given write log containing only insertions.
+func mergeWriteLog(wl, updates api.WriteLog) api.WriteLog {
+	state := make(map[string][]byte)
+	for _, entry := range wl {
+		state[string(entry.Key)] = entry.Value
+	}
+	for _, entry := range updates {
+		if entry.Value == nil {
+			delete(state, string(entry.Key))
+			continue
+		}
+		state[string(entry.Key)] = entry.Value
+	}
+
+	merged := make(api.WriteLog, 0, len(state))
+	for key, value := range state {
+		merged = append(merged, api.LogEntry{Key: []byte(key), Value: value})
+	}
+	sort.Slice(merged, makeWriteLogLess(merged))
+	return merged
+}
@@ -0,0 +1,173 @@
+package migrations
+
+import (
+	"fmt"
+	"sync"
+
+	abciState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/abci/state"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+)
+
+var (
+	registeredModuleMigrations sync.Map
+
+	// ErrMissingModuleMigration is the error returned when a module migration is not registered.
+	ErrMissingModuleMigration = fmt.Errorf("missing module migration")
+)
+
+// ModuleMigrationFunc migrates the state of a consensus module from one state version to the
+// next one.
+//
+// It is only called from EndBlock.
+type ModuleMigrationFunc func(ctx *abciAPI.Context) error
+
+// ModuleVersion is the target state version of a consensus module.
+type ModuleVersion struct {
+	// Module is the name of the consensus module (ABCI application).
+	Module string `json:"module"`
+	// Version is the target state version.
+	Version uint64 `json:"version"`
+}
+
+// ModuleMigrationEvent is the event emitted when a module migration has been performed.
+type ModuleMigrationEvent struct {
+	// Module is the name of the migrated consensus module.
+	Module string `json:"module"`
+	// FromVersion is the state version before the migration.
+	FromVersion uint64 `json:"from_version"`
+	// ToVersion is the state version after the migration.
+	ToVersion uint64 `json:"to_version"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (ev *ModuleMigrationEvent) EventKind() string {
+	return "module_migration"
+}
+
+type moduleMigrationKey struct {
+	module      string
+	fromVersion uint64
+}
+
+// RegisterModuleMigration registers a new module migration that migrates the state of the given
+// consensus module from the given state version to the next one.
+func RegisterModuleMigration(module string, fromVersion uint64, fn ModuleMigrationFunc) {
+	if fromVersion < abciState.InitialStateVersion {
+		panic(fmt.Errorf("module migration for %s has invalid version: %d", module, fromVersion))
+	}
+	key := moduleMigrationKey{module, fromVersion}
+	if _, isRegistered := registeredModuleMigrations.Load(key); isRegistered {
+		panic(fmt.Errorf("module migration already registered: %s (version %d)", module, fromVersion))
+	}
+	registeredModuleMigrations.Store(key, fn)
+}
+
+// PlanModuleMigrations returns the module migrations that need to be performed in order to
+// migrate the state of the given consensus modules to the given target versions, in the order
+// in which they will be performed.
+//
+// Modules are migrated in the order they are given in, each one version at a time.
+func PlanModuleMigrations(ctx *abciAPI.Context, targets []ModuleVersion) ([]*ModuleMigrationEvent, error) {
+	state := abciState.NewMutableState(ctx.State())
+
+	var plan []*ModuleMigrationEvent
+	seen := make(map[string]bool)
+	for _, target := range targets {
+		if seen[target.Module] {
+			return nil, fmt.Errorf("duplicate module migration target: %s", target.Module)
+		}
+		seen[target.Module] = true
+
+		version, err := state.StateVersion(ctx, target.Module)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get state version of %s: %w", target.Module, err)
+		}
+		if version > target.Version {
+			return nil, fmt.Errorf("state version of %s (%d) is newer than target version (%d)",
+				target.Module, version, target.Version,
+			)
+		}
+
+		for v := version; v < target.Version; v++ {
+			if _, exists := registeredModuleMigrations.Load(moduleMigrationKey{target.Module, v}); !exists {
+				return nil, fmt.Errorf("%w: %s (version %d)", ErrMissingModuleMigration, target.Module, v)
+			}
+			plan = append(plan, &ModuleMigrationEvent{
+				Module:      target.Module,
+				FromVersion: v,
+				ToVersion:   v + 1,
+			})
+		}
+	}
+	return plan, nil
+}
+
+// RunModuleMigrations migrates the state of the given consensus modules to the given target
+// versions, records the new state versions and emits an event for each performed migration.
+//
+// Nothing is migrated unless all required module migrations are registered. This method must
+// only be called from EndBlock.
+func RunModuleMigrations(ctx *Context, abciCtx *abciAPI.Context, targets []ModuleVersion) error {
+	if abciCtx.Mode() != abciAPI.ContextEndBlock {
+		return fmt.Errorf("module migrations called in unexpected context: %s", abciCtx.Mode())
+	}
+
+	plan, err := PlanModuleMigrations(abciCtx, targets)
+	if err != nil {
+		return err
+	}
+
+	state := abciState.NewMutableState(abciCtx.State())
+	for _, m := range plan {
+		ctx.Logger.Info("performing module migration",
+			"module", m.Module,
+			"from_version", m.FromVersion,
+			"to_version", m.ToVersion,
+		)
+
+		fn, _ := registeredModuleMigrations.Load(moduleMigrationKey{m.Module, m.FromVersion})
+		if err = fn.(ModuleMigrationFunc)(abciCtx); err != nil {
+			return fmt.Errorf("failed to migrate %s from version %d: %w", m.Module, m.FromVersion, err)
+		}
+		if err = state.SetStateVersion(abciCtx, m.Module, m.ToVersion); err != nil {
+			return fmt.Errorf("failed to set state version of %s: %w", m.Module, err)
+		}
+
+		abciCtx.EmitEvent(abciAPI.NewEventBuilder(ModuleName).TypedAttribute(m))
+	}
+	return nil
+}
+
+var _ Handler = (*moduleMigrationHandler)(nil)
+
+type moduleMigrationHandler struct {
+	targets []ModuleVersion
+}
+
+func (h *moduleMigrationHandler) StartupUpgrade(ctx *Context) error {
+	return nil
+}
+
+func (h *moduleMigrationHandler) ConsensusUpgrade(ctx *Context, privateCtx interface{}) error {
+	abciCtx := privateCtx.(*abciAPI.Context)
+	switch abciCtx.Mode() {
+	case abciAPI.ContextBeginBlock:
+		// Make sure that all required migrations are available before doing anything.
+		_, err := PlanModuleMigrations(abciCtx, h.targets)
+		return err
+	case abciAPI.ContextEndBlock:
+		return RunModuleMigrations(ctx, abciCtx, h.targets)
+	default:
+		return fmt.Errorf("upgrade handler called in unexpected context: %s", abciCtx.Mode())
+	}
+}
+
+// NewModuleMigrationHandler creates a new migration handler that migrates the state of the given
+// consensus modules to the given target versions during the consensus upgrade.
+//
+// Modules are migrated in the given order.
+func NewModuleMigrationHandler(targets ...ModuleVersion) Handler {
+	return &moduleMigrationHandler{
+		targets: targets,
+	}
+}
@@ -0,0 +1,106 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	abciState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/abci/state"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+)
+
+func TestModuleMigrations(t *testing.T) {
+	require := require.New(t)
+
+	var performed []string
+	migration := func(module string, from uint64) ModuleMigrationFunc {
+		return func(ctx *abciAPI.Context) error {
+			performed = append(performed, fmt.Sprintf("%s/%d", module, from))
+			return nil
+		}
+	}
+	RegisterModuleMigration("__test-a", 1, migration("__test-a", 1))
+	RegisterModuleMigration("__test-a", 2, migration("__test-a", 2))
+	RegisterModuleMigration("__test-b", 1, migration("__test-b", 1))
+	RegisterModuleMigration("__test-c", 1, func(ctx *abciAPI.Context) error {
+		return errors.New("migration failed")
+	})
+
+	require.Panics(func() { RegisterModuleMigration("__test-a", 1, migration("__test-a", 1)) }, "duplicate registration")
+	require.Panics(func() { RegisterModuleMigration("__test-a", 0, migration("__test-a", 0)) }, "invalid version")
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	migrationCtx := NewContext(nil, "")
+	state := abciState.NewMutableState(ctx.State())
+
+	// Module b already has a recorded state version, module a uses the initial version.
+	require.NoError(state.SetStateVersion(ctx, "__test-b", 1), "SetStateVersion")
+
+	targets := []ModuleVersion{
+		{Module: "__test-b", Version: 2},
+		{Module: "__test-a", Version: 3},
+	}
+	plan, err := PlanModuleMigrations(ctx, targets)
+	require.NoError(err, "PlanModuleMigrations")
+	require.Equal([]*ModuleMigrationEvent{
+		{Module: "__test-b", FromVersion: 1, ToVersion: 2},
+		{Module: "__test-a", FromVersion: 1, ToVersion: 2},
+		{Module: "__test-a", FromVersion: 2, ToVersion: 3},
+	}, plan)
+
+	// Missing migrations should be detected before anything is migrated.
+	_, err = PlanModuleMigrations(ctx, []ModuleVersion{{Module: "__test-b", Version: 3}})
+	require.ErrorIs(err, ErrMissingModuleMigration)
+	_, err = PlanModuleMigrations(ctx, []ModuleVersion{{Module: "__test-b", Version: 2}, {Module: "__test-b", Version: 2}})
+	require.Error(err, "duplicate targets should be rejected")
+
+	// Module migrations must only run in EndBlock.
+	beginCtx := appState.NewContext(abciAPI.ContextBeginBlock, now)
+	err = RunModuleMigrations(migrationCtx, beginCtx, targets)
+	beginCtx.Close()
+	require.Error(err, "RunModuleMigrations should fail outside EndBlock")
+
+	err = RunModuleMigrations(migrationCtx, ctx, targets)
+	require.NoError(err, "RunModuleMigrations")
+	require.Equal([]string{"__test-b/1", "__test-a/1", "__test-a/2"}, performed, "migrations should run in order")
+
+	versions, err := state.StateVersions(ctx)
+	require.NoError(err, "StateVersions")
+	require.EqualValues(2, versions["__test-b"])
+	require.EqualValues(3, versions["__test-a"])
+
+	// An event should be emitted for each performed migration.
+	var events []*ModuleMigrationEvent
+	for _, ev := range ctx.GetEvents() {
+		require.Equal(abciAPI.EventTypeForApp(ModuleName), ev.Type)
+		for _, attr := range ev.Attributes {
+			var mev ModuleMigrationEvent
+			require.Equal(mev.EventKind(), string(attr.Key))
+			require.NoError(cbor.Unmarshal(attr.Value, &mev), "unmarshal event")
+			events = append(events, &mev)
+		}
+	}
+	require.Equal(plan, events)
+
+	// Running again should be a no-op as all modules are already at their target versions.
+	performed = nil
+	err = RunModuleMigrations(migrationCtx, ctx, targets)
+	require.NoError(err, "RunModuleMigrations")
+	require.Empty(performed, "no migrations should be performed again")
+
+	// Downgrades are not supported.
+	_, err = PlanModuleMigrations(ctx, []ModuleVersion{{Module: "__test-a", Version: 2}})
+	require.Error(err, "downgrades should be rejected")
+
+	// Failed migrations should be reported.
+	err = RunModuleMigrations(migrationCtx, ctx, []ModuleVersion{{Module: "__test-c", Version: 2}})
+	require.Error(err, "failed migrations should be reported")
+}
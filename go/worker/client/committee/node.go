@@ -28,6 +28,22 @@ type pendingTx struct {
 	ch     chan *api.SubmitTxResult
 }
 
+type roundWaiter struct {
+	round uint64
+	ch    chan struct{}
+}
+
+// QueryBudget is the per-runtime budget of client queries.
+type QueryBudget struct {
+	// Timeout is the maximum time a caller waits for a single query. Zero means no limit.
+	//
+	// The timeout only abandons the caller. A query that has already been dispatched keeps
+	// running in the runtime until it completes, and it no longer counts towards MaxConcurrent.
+	Timeout time.Duration
+	// MaxConcurrent is the maximum number of concurrent queries. Zero means no limit.
+	MaxConcurrent uint64
+}
+
 // Node is a client node.
 type Node struct {
 	commonNode *committee.Node
@@ -40,6 +56,12 @@ type Node struct {
 	checkCh *channels.InfiniteChannel
 	txCh    *channels.InfiniteChannel
 
+	// Guarded by CrossNode.
+	roundWaiters []*roundWaiter
+
+	queryTimeout time.Duration
+	querySlots   chan struct{}
+
 	logger *logging.Logger
 }
 
@@ -79,10 +101,6 @@ func (n *Node) HandlePeerTx(ctx context.Context, tx []byte) error {
 	return nil
 }
 
-// Guarded by CrossNode.
-func (n *Node) HandleEpochTransitionLocked(*committee.EpochSnapshot) {
-}
-
 // Guarded by CrossNode.
 func (n *Node) HandleNewBlockEarlyLocked(*block.Block) {
 }
@@ -91,6 +109,17 @@ func (n *Node) HandleNewBlockEarlyLocked(*block.Block) {
 func (n *Node) HandleNewBlockLocked(blk *block.Block) {
 	// Queue block for checks.
 	n.checkCh.In() <- blk
+
+	// Notify any queries waiting for this round.
+	filtered := n.roundWaiters[:0]
+	for _, w := range n.roundWaiters {
+		if w.round <= blk.Header.Round {
+			close(w.ch)
+			continue
+		}
+		filtered = append(filtered, w)
+	}
+	n.roundWaiters = filtered
 }
 
 // Guarded by CrossNode.
@@ -129,7 +158,54 @@ func (n *Node) SubmitTx(ctx context.Context, tx []byte) (<-chan *api.SubmitTxRes
 }
 
 func (n *Node) CheckTx(ctx context.Context, tx []byte) (*protocol.CheckTxResult, error) {
-	return n.commonNode.TxPool.SubmitTx(ctx, tx, &txpool.TransactionMeta{Local: true, Discard: true})
+	// Use the single transaction check fast path so that interactive checks are not queued behind
+	// pending batch checks.
+	return n.commonNode.TxPool.CheckTx(ctx, tx)
+}
+
+// WaitForRound waits until the node has processed a block of at least the given round or until
+// api.MaxMinRoundWait elapses, in which case api.ErrMinRoundNotReached is returned.
+func (n *Node) WaitForRound(ctx context.Context, round uint64) error {
+	n.commonNode.CrossNode.Lock()
+	if blk := n.commonNode.CurrentBlock; blk != nil && blk.Header.Round >= round {
+		n.commonNode.CrossNode.Unlock()
+		return nil
+	}
+	w := &roundWaiter{
+		round: round,
+		ch:    make(chan struct{}),
+	}
+	n.roundWaiters = append(n.roundWaiters, w)
+	n.commonNode.CrossNode.Unlock()
+
+	waitCtx, cancel := context.WithTimeout(ctx, api.MaxMinRoundWait)
+	defer cancel()
+
+	select {
+	case <-w.ch:
+		return nil
+	case <-waitCtx.Done():
+	}
+
+	n.commonNode.CrossNode.Lock()
+	defer n.commonNode.CrossNode.Unlock()
+
+	for i, v := range n.roundWaiters {
+		if v == w {
+			n.roundWaiters = append(n.roundWaiters[:i], n.roundWaiters[i+1:]...)
+			break
+		}
+	}
+	select {
+	case <-w.ch:
+		// The round was reached concurrently with the timeout.
+		return nil
+	default:
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return api.ErrMinRoundNotReached
 }
 
 func (n *Node) Query(ctx context.Context, round uint64, method string, args []byte) ([]byte, error) {
@@ -138,6 +214,35 @@ func (n *Node) Query(ctx context.Context, round uint64, method string, args []by
 		return nil, api.ErrNoHostedRuntime
 	}
 
+	// Enforce the concurrent query limit without queuing so that expensive queries cannot stall
+	// other clients.
+	if n.querySlots != nil {
+		select {
+		case n.querySlots <- struct{}{}:
+			defer func() { <-n.querySlots }()
+		default:
+			return nil, api.ErrQueryBudgetExceeded
+		}
+	}
+
+	// Enforce the query deadline. This only abandons the caller. The runtime cannot abort
+	// individual queries, so a query that was already dispatched keeps running.
+	if n.queryTimeout > 0 {
+		var cancel context.CancelFunc
+		parentCtx := ctx
+		ctx, cancel = context.WithTimeout(ctx, n.queryTimeout)
+		defer cancel()
+
+		data, err := n.query(ctx, hrt, round, method, args)
+		if err != nil && ctx.Err() == context.DeadlineExceeded && parentCtx.Err() == nil {
+			return nil, api.ErrQueryBudgetExceeded
+		}
+		return data, err
+	}
+	return n.query(ctx, hrt, round, method, args)
+}
+
+func (n *Node) query(ctx context.Context, hrt host.RichRuntime, round uint64, method string, args []byte) ([]byte, error) {
 	// Fetch the active descriptor so we can get the current message limits.
 	n.commonNode.CrossNode.Lock()
 	dsc := n.commonNode.CurrentDescriptor
@@ -197,7 +302,7 @@ func (n *Node) checkBlock(ctx context.Context, blk *block.Block, pending map[has
 		return fmt.Errorf("error getting block I/O from storage: %w", err)
 	}
 
-	var processed []hash.Hash
+	var processed []*txpool.ExecutedTransaction
 	for txHash, tx := range matches {
 		pTx := pending[txHash]
 		pTx.ch <- &api.SubmitTxResult{
@@ -209,11 +314,15 @@ func (n *Node) checkBlock(ctx context.Context, blk *block.Block, pending map[has
 		}
 		close(pTx.ch)
 		delete(pending, txHash)
-		processed = append(processed, txHash)
+		processed = append(processed, &txpool.ExecutedTransaction{
+			Hash:  txHash,
+			Round: blk.Header.Round,
+			Index: tx.BatchOrder,
+		})
 	}
 
 	// Remove processed transactions from pool.
-	n.commonNode.TxPool.RemoveTxBatch(processed)
+	n.commonNode.TxPool.RemoveExecutedTxBatch(blk.Header.Round, processed)
 
 	return nil
 }
@@ -295,15 +404,19 @@ func (n *Node) worker() {
 }
 
 // NewNode creates a new client node.
-func NewNode(commonNode *committee.Node) (*Node, error) {
+func NewNode(commonNode *committee.Node, budget *QueryBudget) (*Node, error) {
 	n := &Node{
-		commonNode: commonNode,
-		stopCh:     make(chan struct{}),
-		quitCh:     make(chan struct{}),
-		initCh:     make(chan struct{}),
-		checkCh:    channels.NewInfiniteChannel(),
-		txCh:       channels.NewInfiniteChannel(),
-		logger:     logging.GetLogger("worker/client/committee").With("runtime_id", commonNode.Runtime.ID()),
+		commonNode:   commonNode,
+		queryTimeout: budget.Timeout,
+		stopCh:       make(chan struct{}),
+		quitCh:       make(chan struct{}),
+		initCh:       make(chan struct{}),
+		checkCh:      channels.NewInfiniteChannel(),
+		txCh:         channels.NewInfiniteChannel(),
+		logger:       logging.GetLogger("worker/client/committee").With("runtime_id", commonNode.Runtime.ID()),
+	}
+	if budget.MaxConcurrent > 0 {
+		n.querySlots = make(chan struct{}, budget.MaxConcurrent)
 	}
 	return n, nil
 }
@@ -0,0 +1,86 @@
+package committee
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/mock"
+	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/committee"
+)
+
+type testHostProvisioner struct {
+	rr host.RichRuntime
+}
+
+func (p *testHostProvisioner) WaitHostedRuntime(ctx context.Context) (host.RichRuntime, error) {
+	return p.rr, nil
+}
+
+type testTxPublisher struct{}
+
+func (p *testTxPublisher) PublishTx(ctx context.Context, tx []byte) error {
+	return nil
+}
+
+func (p *testTxPublisher) GetMinRepublishInterval() time.Duration {
+	return time.Minute
+}
+
+func TestCheckTxBypassesTxPool(t *testing.T) {
+	require := require.New(t)
+
+	p := mock.NewProgrammable()
+	rt, err := p.NewRuntime(context.Background(), host.Config{})
+	require.NoError(err, "NewRuntime")
+
+	var runtimeID common.Namespace
+	tp, err := txpool.New(runtimeID, &txpool.Config{
+		MaxPoolSize:          100,
+		MaxCheckTxBatchSize:  10,
+		MaxLastSeenCacheSize: 100,
+		MaxStaleCacheSize:    100,
+		RepublishInterval:    time.Minute,
+	}, &testHostProvisioner{host.NewRichRuntime(rt)}, &testTxPublisher{})
+	require.NoError(err, "txpool.New")
+
+	var blk block.Block
+	err = tp.ProcessBlock(&txpool.BlockInfo{
+		RuntimeBlock:   &blk,
+		ConsensusBlock: &consensus.LightBlock{},
+		ActiveDescriptor: &registry.Runtime{
+			TxnScheduler: registry.TxnSchedulerParameters{
+				Algorithm:         registry.TxnSchedulerSimple,
+				BatchFlushTimeout: time.Second,
+				MaxBatchSize:      10,
+				MaxBatchSizeBytes: 1024,
+			},
+		},
+	})
+	require.NoError(err, "ProcessBlock")
+
+	n := &Node{commonNode: &committee.Node{TxPool: tp}}
+
+	ctx := context.Background()
+	result, err := n.CheckTx(ctx, []byte("tx"))
+	require.NoError(err, "CheckTx")
+	require.True(result.IsSuccess(), "transaction should pass the check")
+
+	result, err = n.CheckTx(ctx, mock.CheckTxFailInput)
+	require.NoError(err, "CheckTx")
+	require.False(result.IsSuccess(), "transaction should fail the check")
+
+	// Client checks should be performed directly by the runtime without entering the pool.
+	require.Equal(2, p.Calls(mock.MethodCheckTx), "runtime should be called for each check")
+	require.Zero(p.Calls(mock.MethodCheckTxBatch), "batch checks should not be used")
+	require.Zero(tp.PendingCheckSize(), "checked transactions should not be queued for checks")
+	require.Zero(tp.PendingScheduleSize(), "checked transactions should not be scheduled")
+}
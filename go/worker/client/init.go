@@ -0,0 +1,66 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/worker/client/committee"
+)
+
+const (
+	// CfgQueryTimeout configures the maximum time a single runtime query may take.
+	CfgQueryTimeout = "worker.client.query.timeout"
+	// CfgQueryMaxConcurrent configures the maximum number of concurrent runtime queries.
+	CfgQueryMaxConcurrent = "worker.client.query.max_concurrent"
+	// CfgQueryRuntimeTimeouts configures per-runtime overrides of the query timeout.
+	//
+	// The value should be a map of runtime IDs to corresponding durations.
+	CfgQueryRuntimeTimeouts = "worker.client.query.runtime_timeouts"
+	// CfgQueryRuntimeMaxConcurrent configures per-runtime overrides of the maximum number of
+	// concurrent queries.
+	//
+	// The value should be a map of runtime IDs to corresponding limits.
+	CfgQueryRuntimeMaxConcurrent = "worker.client.query.runtime_max_concurrent"
+)
+
+// Flags has the configuration flags.
+var Flags = flag.NewFlagSet("", flag.ContinueOnError)
+
+// queryBudgetForRuntime returns the configured query budget for the given runtime.
+func queryBudgetForRuntime(id common.Namespace) (*committee.QueryBudget, error) {
+	budget := &committee.QueryBudget{
+		Timeout:       viper.GetDuration(CfgQueryTimeout),
+		MaxConcurrent: viper.GetUint64(CfgQueryMaxConcurrent),
+	}
+
+	if raw, ok := viper.GetStringMapString(CfgQueryRuntimeTimeouts)[id.String()]; ok {
+		timeout, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("bad query timeout for runtime %s: %w", id, err)
+		}
+		budget.Timeout = timeout
+	}
+	if raw, ok := viper.GetStringMapString(CfgQueryRuntimeMaxConcurrent)[id.String()]; ok {
+		maxConcurrent, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad concurrent query limit for runtime %s: %w", id, err)
+		}
+		budget.MaxConcurrent = maxConcurrent
+	}
+
+	return budget, nil
+}
+
+func init() {
+	Flags.Duration(CfgQueryTimeout, 0, "Maximum time a single runtime query may take (0 = unlimited)")
+	Flags.Uint64(CfgQueryMaxConcurrent, 0, "Maximum number of concurrent queries per runtime (0 = unlimited)")
+	Flags.StringToString(CfgQueryRuntimeTimeouts, nil, "Per-runtime query timeouts (format: <rt1-ID>=<duration>,<rt2-ID>=<duration>)")
+	Flags.StringToString(CfgQueryRuntimeMaxConcurrent, nil, "Per-runtime concurrent query limits (format: <rt1-ID>=<limit>,<rt2-ID>=<limit>)")
+
+	_ = viper.BindPFlags(Flags)
+}
@@ -22,7 +22,7 @@ type service struct {
 }
 
 func (s *service) submitTx(ctx context.Context, request *api.SubmitTxRequest) (<-chan *api.SubmitTxResult, *protocol.Error, error) {
-	rt := s.w.runtimes[request.RuntimeID]
+	rt := s.w.GetRuntime(request.RuntimeID)
 	if rt == nil {
 		return nil, nil, api.ErrNoHostedRuntime
 	}
@@ -86,7 +86,7 @@ func (s *service) SubmitTxNoWait(ctx context.Context, request *api.SubmitTxReque
 
 // Implements api.RuntimeClient.
 func (s *service) CheckTx(ctx context.Context, request *api.CheckTxRequest) error {
-	rt := s.w.runtimes[request.RuntimeID]
+	rt := s.w.GetRuntime(request.RuntimeID)
 	if rt == nil {
 		return api.ErrNoHostedRuntime
 	}
@@ -102,11 +102,75 @@ func (s *service) CheckTx(ctx context.Context, request *api.CheckTxRequest) erro
 	return nil
 }
 
+// Implements api.RuntimeClient.
+func (s *service) CheckTxMeta(ctx context.Context, request *api.CheckTxRequest) (*protocol.CheckTxResult, error) {
+	rt := s.w.GetRuntime(request.RuntimeID)
+	if rt == nil {
+		return nil, api.ErrNoHostedRuntime
+	}
+
+	return rt.CheckTx(ctx, request.Data)
+}
+
 // Implements api.RuntimeClient.
 func (s *service) WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
 	return s.w.commonWorker.Consensus.RootHash().WatchBlocks(ctx, runtimeID)
 }
 
+// Implements api.RuntimeClient.
+func (s *service) WatchBlockHeaders(ctx context.Context, runtimeID common.Namespace) (<-chan *api.BlockHeader, pubsub.ClosableSubscription, error) {
+	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(runtimeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blkCh, blkSub, err := s.w.commonWorker.Consensus.RootHash().WatchBlocks(ctx, runtimeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+	ch := make(chan *api.BlockHeader)
+	go func() {
+		defer close(ch)
+		defer blkSub.Close()
+
+		for {
+			var annBlk *roothash.AnnotatedBlock
+			select {
+			case annBlk = <-blkCh:
+				if annBlk == nil {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			tags, err := s.getBlockTags(ctx, rt.Storage(), annBlk.Block)
+			if err != nil {
+				s.w.logger.Error("failed to fetch block tags",
+					"err", err,
+					"runtime_id", runtimeID,
+					"round", annBlk.Block.Header.Round,
+				)
+				return
+			}
+
+			select {
+			case ch <- &api.BlockHeader{
+				Height: annBlk.Height,
+				Header: annBlk.Block.Header,
+				Tags:   tags,
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 // Implements api.RuntimeClient.
 func (s *service) GetGenesisBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error) {
 	return s.w.commonWorker.Consensus.RootHash().GetGenesisBlock(ctx, &roothash.RuntimeRequest{
@@ -124,6 +188,15 @@ func (s *service) GetBlock(ctx context.Context, request *api.GetBlockRequest) (*
 	return rt.History().GetBlock(ctx, request.Round)
 }
 
+// Implements api.RuntimeClient.
+func (s *service) GetBlockByHash(ctx context.Context, request *api.GetBlockByHashRequest) (*block.Block, error) {
+	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(request.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+	return rt.History().GetBlockByHash(ctx, request.BlockHash)
+}
+
 // Implements api.RuntimeClient.
 func (s *service) GetLastRetainedBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error) {
 	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(runtimeID)
@@ -165,6 +238,28 @@ func (s *service) getTxnTree(backend storage.Backend, blk *block.Block) *transac
 	return transaction.NewTree(backend, ioRoot)
 }
 
+func (s *service) getBlockTags(ctx context.Context, backend storage.Backend, blk *block.Block) ([]*api.PlainEvent, error) {
+	tree := s.getTxnTree(backend, blk)
+	defer tree.Close()
+
+	tags, err := tree.GetTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var blockTags []*api.PlainEvent
+	for _, tag := range tags {
+		if !tag.TxHash.Equal(&transaction.TagBlockTxHash) {
+			continue
+		}
+		blockTags = append(blockTags, &api.PlainEvent{
+			Key:   tag.Key,
+			Value: tag.Value,
+		})
+	}
+	return blockTags, nil
+}
+
 // Implements api.RuntimeClient.
 func (s *service) GetTransactions(ctx context.Context, request *api.GetTransactionsRequest) ([][]byte, error) {
 	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(request.RuntimeID)
@@ -270,11 +365,17 @@ func (s *service) GetEvents(ctx context.Context, request *api.GetEventsRequest)
 
 // Implements api.RuntimeClient.
 func (s *service) Query(ctx context.Context, request *api.QueryRequest) (*api.QueryResponse, error) {
-	rt := s.w.runtimes[request.RuntimeID]
+	rt := s.w.GetRuntime(request.RuntimeID)
 	if rt == nil {
 		return nil, api.ErrNoHostedRuntime
 	}
 
+	if request.MinRound > 0 {
+		if err := rt.WaitForRound(ctx, request.MinRound); err != nil {
+			return nil, err
+		}
+	}
+
 	data, err := rt.Query(ctx, request.Round, request.Method, request.Args)
 	if err != nil {
 		return nil, err
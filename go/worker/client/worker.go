@@ -2,6 +2,10 @@
 package client
 
 import (
+	"context"
+	"fmt"
+	"sync"
+
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
@@ -18,7 +22,8 @@ type Worker struct {
 
 	commonWorker *workerCommon.Worker
 
-	runtimes map[common.Namespace]*committee.Node
+	runtimesLock sync.RWMutex
+	runtimes     map[common.Namespace]*committee.Node
 
 	quitCh chan struct{}
 	initCh chan struct{}
@@ -46,14 +51,14 @@ func (w *Worker) Start() error {
 	go func() {
 		defer close(w.quitCh)
 
-		for _, rt := range w.runtimes {
+		for _, rt := range w.getRuntimes() {
 			<-rt.Quit()
 		}
 	}()
 
 	// Wait for all runtimes to be initialized.
 	go func() {
-		for _, rt := range w.runtimes {
+		for _, rt := range w.getRuntimes() {
 			<-rt.Initialized()
 		}
 
@@ -61,7 +66,7 @@ func (w *Worker) Start() error {
 	}()
 
 	// Start runtime services.
-	for id, rt := range w.runtimes {
+	for id, rt := range w.getRuntimes() {
 		w.logger.Info("starting services for runtime",
 			"runtime_id", id,
 		)
@@ -81,7 +86,7 @@ func (w *Worker) Stop() {
 		return
 	}
 
-	for id, rt := range w.runtimes {
+	for id, rt := range w.getRuntimes() {
 		w.logger.Info("stopping services for runtime",
 			"runtime_id", id,
 		)
@@ -106,7 +111,7 @@ func (w *Worker) Cleanup() {
 		return
 	}
 
-	for _, rt := range w.runtimes {
+	for _, rt := range w.getRuntimes() {
 		rt.Cleanup()
 	}
 }
@@ -117,6 +122,66 @@ func (w *Worker) Initialized() <-chan struct{} {
 	return w.initCh
 }
 
+func (w *Worker) getRuntimes() map[common.Namespace]*committee.Node {
+	w.runtimesLock.RLock()
+	defer w.runtimesLock.RUnlock()
+
+	runtimes := make(map[common.Namespace]*committee.Node, len(w.runtimes))
+	for id, rt := range w.runtimes {
+		runtimes[id] = rt
+	}
+	return runtimes
+}
+
+// GetRuntime returns a client committee node for the given runtime (if available).
+//
+// In case the runtime with the specified id was not configured for this node it returns nil.
+func (w *Worker) GetRuntime(id common.Namespace) *committee.Node {
+	w.runtimesLock.RLock()
+	defer w.runtimesLock.RUnlock()
+
+	return w.runtimes[id]
+}
+
+// AddRuntime registers a runtime that was added after the worker has been started and returns
+// its client committee node.
+//
+// The node is not started so that it can be started after the common committee node.
+func (w *Worker) AddRuntime(commonNode *committeeCommon.Node) (*committee.Node, error) {
+	if !w.enabled {
+		return nil, fmt.Errorf("worker/client: worker is disabled")
+	}
+	if err := w.registerRuntime(commonNode); err != nil {
+		return nil, err
+	}
+	return w.GetRuntime(commonNode.Runtime.ID()), nil
+}
+
+// RemoveRuntime stops the client committee node of the given runtime and removes it.
+func (w *Worker) RemoveRuntime(ctx context.Context, id common.Namespace) error {
+	w.runtimesLock.Lock()
+	rt, ok := w.runtimes[id]
+	delete(w.runtimes, id)
+	w.runtimesLock.Unlock()
+	if !ok {
+		return fmt.Errorf("worker/client: runtime %s is not registered", id)
+	}
+
+	w.logger.Info("stopping services for runtime",
+		"runtime_id", id,
+	)
+
+	rt.Stop()
+	select {
+	case <-rt.Quit():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	rt.Cleanup()
+
+	return nil
+}
+
 func (w *Worker) registerRuntime(commonNode *committeeCommon.Node) error {
 	id := commonNode.Runtime.ID()
 
@@ -124,12 +189,23 @@ func (w *Worker) registerRuntime(commonNode *committeeCommon.Node) error {
 		"runtime_id", id,
 	)
 
+	budget, err := queryBudgetForRuntime(id)
+	if err != nil {
+		return err
+	}
+
 	// Create committee node for the given runtime.
-	node, err := committee.NewNode(commonNode)
+	node, err := committee.NewNode(commonNode, budget)
 	if err != nil {
 		return err
 	}
 
+	w.runtimesLock.Lock()
+	defer w.runtimesLock.Unlock()
+
+	if _, ok := w.runtimes[id]; ok {
+		return fmt.Errorf("worker/client: runtime %s is already registered", id)
+	}
 	commonNode.AddHooks(node)
 	w.runtimes[id] = node
 
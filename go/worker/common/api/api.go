@@ -1,6 +1,10 @@
 package api
 
 import (
+	"time"
+
+	keymanagerClient "github.com/oasisprotocol/oasis-core/go/keymanager/client/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/watchdog"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 )
 
@@ -19,4 +23,36 @@ type Status struct {
 
 	// Peers is the list of peers in the runtime P2P network.
 	Peers []string `json:"peers"`
+
+	// KeyManager is the key manager client status in case the runtime requires a key manager.
+	KeyManager *keymanagerClient.Status `json:"key_manager,omitempty"`
+
+	// Watchdog is the runtime watchdog status in case the hosted runtime is monitored.
+	Watchdog *watchdog.Status `json:"watchdog,omitempty"`
+}
+
+// P2PStatus is the status of the runtime worker P2P network.
+type P2PStatus struct {
+	// PeerScores are the reputation scores of peers that misbehaved recently, lowest first.
+	PeerScores []PeerScore `json:"peer_scores"`
+}
+
+// PeerScore is the reputation score of a runtime worker P2P peer.
+type PeerScore struct {
+	// PeerID is the libp2p peer identifier.
+	PeerID string `json:"peer_id"`
+
+	// Score is the current (decayed) reputation score of the peer. Misbehavior lowers the score
+	// and the peer is temporarily banned once the score falls below the ban threshold.
+	Score float64 `json:"score"`
+
+	// InvalidMessages is the number of undecodable messages received from the peer.
+	InvalidMessages uint64 `json:"invalid_messages"`
+	// ProtocolViolations is the number of messages from the peer that could never be authorized.
+	ProtocolViolations uint64 `json:"protocol_violations"`
+	// SpamMessages is the number of messages from the peer that exceeded the message rate limit.
+	SpamMessages uint64 `json:"spam_messages"`
+
+	// BannedUntil is the time until which the peer is banned, if it is currently banned.
+	BannedUntil *time.Time `json:"banned_until,omitempty"`
 }
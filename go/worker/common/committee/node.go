@@ -11,6 +11,7 @@ import (
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
 	keymanagerApi "github.com/oasisprotocol/oasis-core/go/keymanager/api"
@@ -68,6 +69,7 @@ var (
 		failedRoundCount,
 		epochTransitionCount,
 		epochNumber,
+		transitionHookPanicCount,
 	}
 
 	metricsOnce sync.Once
@@ -79,8 +81,6 @@ type NodeHooks interface {
 	// HandlePeerTx handles a transaction received from a (non-local) peer.
 	HandlePeerTx(ctx context.Context, tx []byte) error
 
-	// Guarded by CrossNode.
-	HandleEpochTransitionLocked(*EpochSnapshot)
 	// Guarded by CrossNode.
 	HandleNewBlockEarlyLocked(*block.Block)
 	// Guarded by CrossNode.
@@ -117,7 +117,10 @@ type Node struct {
 	quitCh    chan struct{}
 	initCh    chan struct{}
 
-	hooks []NodeHooks
+	hooks       []NodeHooks
+	transitions *transitionHooks
+
+	announcedBlocks *pubsub.Broker
 
 	// Mutable and shared between nodes' workers.
 	// Guarded by .CrossNode.
@@ -129,6 +132,9 @@ type Node struct {
 	CurrentEpoch          beacon.EpochTime
 	Height                int64
 
+	// Guarded by .CrossNode.
+	prevEpochSnapshot *EpochSnapshot
+
 	logger *logging.Logger
 }
 
@@ -181,6 +187,15 @@ func (n *Node) AddHooks(hooks NodeHooks) {
 	n.hooks = append(n.hooks, hooks)
 }
 
+// AddTransitionHook adds a hook to be called on committee transitions of the given kind.
+//
+// Hooks are called in ascending priority order and hooks with equal priority are called in
+// registration order. The name is used to identify the hook in logs and metrics.
+// There is no going back.
+func (n *Node) AddTransitionHook(kind TransitionKind, name string, priority int, hook TransitionHook) {
+	n.transitions.add(kind, name, priority, hook)
+}
+
 // GetStatus returns the common committee node status.
 func (n *Node) GetStatus(ctx context.Context) (*api.Status, error) {
 	n.CrossNode.Lock()
@@ -200,6 +215,12 @@ func (n *Node) GetStatus(ctx context.Context) (*api.Status, error) {
 
 	status.Peers = n.P2P.Peers(n.Runtime.ID())
 
+	if n.KeyManagerClient != nil {
+		status.KeyManager = n.KeyManagerClient.GetStatus()
+	}
+
+	status.Watchdog = n.GetHostedRuntimeWatchdogStatus()
+
 	return &status, nil
 }
 
@@ -224,9 +245,7 @@ func (n *Node) handleEpochTransitionLocked(height int64) {
 
 	epoch := n.Group.GetEpochSnapshot()
 	epochNumber.With(n.getMetricLabels()).Set(float64(epoch.epochNumber))
-	for _, hooks := range n.hooks {
-		hooks.HandleEpochTransitionLocked(epoch)
-	}
+	n.dispatchTransitionLocked(TransitionEpoch, height, epoch)
 }
 
 // Guarded by n.CrossNode.
@@ -237,9 +256,18 @@ func (n *Node) handleSuspendLocked(height int64) {
 	n.Group.Suspend(n.ctx)
 
 	epoch := n.Group.GetEpochSnapshot()
-	for _, hooks := range n.hooks {
-		hooks.HandleEpochTransitionLocked(epoch)
-	}
+	n.dispatchTransitionLocked(TransitionSuspend, height, epoch)
+}
+
+// Guarded by n.CrossNode.
+func (n *Node) dispatchTransitionLocked(kind TransitionKind, height int64, epoch *EpochSnapshot) {
+	n.transitions.dispatch(&Transition{
+		Kind:          kind,
+		Height:        height,
+		Epoch:         epoch,
+		PreviousEpoch: n.prevEpochSnapshot,
+	})
+	n.prevEpochSnapshot = epoch
 }
 
 // Guarded by n.CrossNode.
@@ -312,13 +340,15 @@ func (n *Node) handleNewBlockLocked(blk *block.Block, height int64) {
 			// Normal block.
 			n.Group.RoundTransition()
 		}
-	case block.RoundFailed:
+	case block.RoundFailed, block.LivenessFailure:
 		if firstBlockReceived {
 			n.logger.Warn("forcing an epoch transition on first received block")
 			n.handleEpochTransitionLocked(height)
 		} else {
 			// Round has failed.
-			n.logger.Warn("round has failed")
+			n.logger.Warn("round has failed",
+				"liveness_failure", header.HeaderType == block.LivenessFailure,
+			)
 			n.Group.RoundTransition()
 
 			failedRoundCount.With(n.getMetricLabels()).Inc()
@@ -336,6 +366,9 @@ func (n *Node) handleNewBlockLocked(blk *block.Block, height int64) {
 		return
 	}
 
+	// Announce the new block to any consumer peers.
+	n.P2P.PublishBlock(n.ctx, n.Runtime.ID(), &p2p.BlockMessage{Block: blk})
+
 	err = n.TxPool.ProcessBlock(&txpool.BlockInfo{
 		RuntimeBlock:     n.CurrentBlock,
 		ConsensusBlock:   n.CurrentConsensusBlock,
@@ -403,7 +436,8 @@ func (n *Node) worker() {
 	if rt.KeyManager != nil {
 		n.logger.Info("runtime indicates a key manager is required, waiting for it to be ready")
 
-		n.KeyManagerClient, err = keymanagerClient.New(n.ctx, n.Runtime, n.Consensus, n.Identity)
+		var kmClient *keymanagerClient.Client
+		kmClient, err = keymanagerClient.New(n.ctx, n.Runtime, n.Consensus, n.Identity)
 		if err != nil {
 			n.logger.Error("failed to create key manager client",
 				"err", err,
@@ -411,13 +445,18 @@ func (n *Node) worker() {
 			return
 		}
 
+		// Make the key manager client status available while waiting for initialization.
+		n.CrossNode.Lock()
+		n.KeyManagerClient = kmClient
+		n.CrossNode.Unlock()
+
 		select {
 		case <-n.ctx.Done():
 			n.logger.Error("failed to wait for key manager",
 				"err", err,
 			)
 			return
-		case <-n.KeyManagerClient.Initialized():
+		case <-kmClient.Initialized():
 		}
 
 		n.logger.Info("runtime has a key manager available")
@@ -580,7 +619,12 @@ func NewNode(
 		quitCh:     make(chan struct{}),
 		initCh:     make(chan struct{}),
 		logger:     logging.GetLogger("worker/common/committee").With("runtime_id", runtime.ID()),
+
+		announcedBlocks: pubsub.NewBroker(false),
+
+		prevEpochSnapshot: &EpochSnapshot{},
 	}
+	n.transitions = newTransitionHooks(n.getMetricLabels(), n.logger)
 
 	// Prepare the runtime host node helpers.
 	rhn, err := runtimeRegistry.NewRuntimeHostNode(n)
@@ -598,6 +642,8 @@ func NewNode(
 
 	// Register transaction message handler as that is something that all workers must handle.
 	p2pHost.RegisterHandler(runtime.ID(), p2p.TopicKindTx, &txMsgHandler{n})
+	// Register block announcement handler so that consumer nodes can receive new blocks.
+	p2pHost.RegisterHandler(runtime.ID(), p2p.TopicKindBlock, &blockMsgHandler{n})
 
 	return n, nil
 }
@@ -2,10 +2,15 @@ package committee
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/p2p"
+	p2pError "github.com/oasisprotocol/oasis-core/go/worker/common/p2p/error"
 )
 
 type txMsgHandler struct {
@@ -38,6 +43,72 @@ func (h *txMsgHandler) HandleMessage(ctx context.Context, peerID signature.Publi
 	return nil
 }
 
+type blockMsgHandler struct {
+	n *Node
+}
+
+func (h *blockMsgHandler) DecodeMessage(msg []byte) (interface{}, error) {
+	var dec p2p.BlockMessage
+	if err := cbor.Unmarshal(msg, &dec); err != nil {
+		return nil, err
+	}
+	if dec.Block == nil {
+		return nil, fmt.Errorf("missing block")
+	}
+	return &dec, nil
+}
+
+func (h *blockMsgHandler) AuthorizeMessage(ctx context.Context, peerID signature.PublicKey, msg interface{}) error {
+	bm := msg.(*p2p.BlockMessage) // Ensured by DecodeMessage.
+
+	runtimeID := h.n.Runtime.ID()
+	if !bm.Block.Header.Namespace.Equal(&runtimeID) {
+		return p2pError.Permanent(fmt.Errorf("block for a different runtime"))
+	}
+
+	// Only registered nodes supporting the runtime are allowed to announce blocks.
+	node := h.n.P2P.PeerNode(peerID)
+	if node == nil {
+		return p2pError.Permanent(fmt.Errorf("peer is not a registered node"))
+	}
+	if node.GetRuntime(runtimeID) == nil {
+		return p2pError.Permanent(fmt.Errorf("peer does not support the runtime"))
+	}
+	return nil
+}
+
+func (h *blockMsgHandler) HandleMessage(ctx context.Context, peerID signature.PublicKey, msg interface{}, isOwn bool) error {
+	bm := msg.(*p2p.BlockMessage) // Ensured by DecodeMessage.
+
+	// Ignore own messages as those blocks are already known.
+	if isOwn {
+		return nil
+	}
+
+	// Ignore announcements of blocks that are not newer than the current block.
+	h.n.CrossNode.Lock()
+	current := h.n.CurrentBlock
+	h.n.CrossNode.Unlock()
+	if current != nil && bm.Block.Header.Round <= current.Header.Round {
+		return nil
+	}
+
+	h.n.announcedBlocks.Broadcast(bm.Block)
+	return nil
+}
+
+// WatchAnnouncedBlocks subscribes to runtime blocks announced by peers via P2P gossipsub.
+//
+// Announced blocks are not verified against the consensus layer and may arrive before the
+// node has processed the corresponding consensus block.
+func (n *Node) WatchAnnouncedBlocks() (<-chan *block.Block, *pubsub.Subscription) {
+	sub := n.announcedBlocks.Subscribe()
+	ch := make(chan *block.Block)
+	sub.Unwrap(ch)
+
+	return ch, sub
+}
+
 // PublishTx publishes a transaction via P2P gossipsub.
 func (n *Node) PublishTx(ctx context.Context, tx []byte) error {
 	n.P2P.PublishTx(ctx, n.Runtime.ID(), tx)
@@ -3,6 +3,7 @@ package committee
 import (
 	"context"
 
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	keymanagerClientApi "github.com/oasisprotocol/oasis-core/go/keymanager/client/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host"
@@ -38,6 +39,11 @@ func (env *nodeEnvironment) GetKeyManagerClient(ctx context.Context) (keymanager
 	return env.n.KeyManagerClient, nil
 }
 
+// Implements RuntimeHostHandlerEnvironment.
+func (env *nodeEnvironment) GetNodeIdentity(ctx context.Context) (*identity.Identity, error) {
+	return env.n.Identity, nil
+}
+
 // Implements RuntimeHostHandlerFactory.
 func (n *Node) NewRuntimeHostHandler() protocol.Handler {
 	return runtimeRegistry.NewRuntimeHostHandler(&nodeEnvironment{n}, n.Runtime, n.Consensus)
@@ -0,0 +1,166 @@
+package committee
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+var transitionHookPanicCount = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "oasis_worker_transition_hook_panic_count",
+		Help: "Number of panics recovered in committee transition hooks.",
+	},
+	[]string{"runtime", "hook"},
+)
+
+// TransitionKind is the kind of a committee node transition.
+type TransitionKind uint8
+
+const (
+	// TransitionEpoch is an epoch transition.
+	TransitionEpoch TransitionKind = iota
+	// TransitionCommitteeChange is a change of the local node's executor committee roles.
+	TransitionCommitteeChange
+	// TransitionSuspend is the runtime being suspended.
+	TransitionSuspend
+)
+
+// String returns a string representation of the transition kind.
+func (k TransitionKind) String() string {
+	switch k {
+	case TransitionEpoch:
+		return "epoch"
+	case TransitionCommitteeChange:
+		return "committee change"
+	case TransitionSuspend:
+		return "suspend"
+	default:
+		return fmt.Sprintf("[unknown transition kind: %d]", uint8(k))
+	}
+}
+
+// Transition describes a committee node transition.
+type Transition struct {
+	// Kind is the kind of the transition.
+	Kind TransitionKind
+	// Height is the consensus height at which the transition happened.
+	Height int64
+	// Epoch is the epoch snapshot after the transition.
+	Epoch *EpochSnapshot
+	// PreviousEpoch is the epoch snapshot before the transition.
+	PreviousEpoch *EpochSnapshot
+}
+
+// TransitionHook is a function that is called on committee node transitions.
+//
+// Hooks are called synchronously from the common node's worker while holding the CrossNode lock,
+// so they must not block.
+type TransitionHook func(*Transition)
+
+type transitionHook struct {
+	name     string
+	priority int
+	hook     TransitionHook
+}
+
+// transitionHooks is a registry of transition hooks.
+//
+// Hooks of the same kind are called in ascending priority order and hooks with equal priority are
+// called in registration order. For a single transition, epoch and suspend hooks are always called
+// before committee change hooks.
+type transitionHooks struct {
+	hooks map[TransitionKind][]*transitionHook
+
+	labels prometheus.Labels
+	logger *logging.Logger
+}
+
+func (th *transitionHooks) add(kind TransitionKind, name string, priority int, hook TransitionHook) {
+	hooks := append(th.hooks[kind], &transitionHook{
+		name:     name,
+		priority: priority,
+		hook:     hook,
+	})
+	sort.SliceStable(hooks, func(i, j int) bool {
+		return hooks[i].priority < hooks[j].priority
+	})
+	th.hooks[kind] = hooks
+}
+
+func (th *transitionHooks) dispatch(t *Transition) {
+	th.dispatchKind(t)
+
+	if t.Kind != TransitionCommitteeChange && committeeRolesChanged(t.PreviousEpoch, t.Epoch) {
+		th.dispatchKind(&Transition{
+			Kind:          TransitionCommitteeChange,
+			Height:        t.Height,
+			Epoch:         t.Epoch,
+			PreviousEpoch: t.PreviousEpoch,
+		})
+	}
+}
+
+func (th *transitionHooks) dispatchKind(t *Transition) {
+	for _, h := range th.hooks[t.Kind] {
+		th.call(h, t)
+	}
+}
+
+// call calls a single hook, making sure that a panic in one hook does not prevent the other hooks
+// from being called.
+func (th *transitionHooks) call(h *transitionHook, t *Transition) {
+	defer func() {
+		if r := recover(); r != nil {
+			th.logger.Error("transition hook panicked",
+				"hook", h.name,
+				"kind", t.Kind,
+				"height", t.Height,
+				"panic", r,
+			)
+
+			labels := prometheus.Labels{"hook": h.name}
+			for k, v := range th.labels {
+				labels[k] = v
+			}
+			transitionHookPanicCount.With(labels).Inc()
+		}
+	}()
+
+	h.hook(t)
+}
+
+func newTransitionHooks(labels prometheus.Labels, logger *logging.Logger) *transitionHooks {
+	return &transitionHooks{
+		hooks:  make(map[TransitionKind][]*transitionHook),
+		labels: labels,
+		logger: logger,
+	}
+}
+
+func committeeRoles(epoch *EpochSnapshot) []scheduler.Role {
+	if epoch == nil || epoch.executorCommittee == nil {
+		return nil
+	}
+	return epoch.executorCommittee.Roles
+}
+
+// committeeRolesChanged checks whether the local node's executor committee roles differ between
+// the given epoch snapshots.
+func committeeRolesChanged(prev, cur *EpochSnapshot) bool {
+	prevRoles, curRoles := committeeRoles(prev), committeeRoles(cur)
+	if len(prevRoles) != len(curRoles) {
+		return true
+	}
+	for _, role := range prevRoles {
+		if cur.executorCommittee.HasRole(role) {
+			continue
+		}
+		return true
+	}
+	return false
+}
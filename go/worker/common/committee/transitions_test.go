@@ -0,0 +1,68 @@
+package committee
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+func TestTransitionHooks(t *testing.T) {
+	require := require.New(t)
+
+	th := newTransitionHooks(prometheus.Labels{"runtime": "test"}, logging.GetLogger("test"))
+
+	var calls []string
+	record := func(name string) TransitionHook {
+		return func(t *Transition) {
+			calls = append(calls, name+":"+t.Kind.String())
+		}
+	}
+	th.add(TransitionCommitteeChange, "committee", 0, record("committee"))
+	th.add(TransitionEpoch, "second", 10, record("second"))
+	th.add(TransitionEpoch, "panic", 5, func(*Transition) {
+		panic("hook failure")
+	})
+	th.add(TransitionEpoch, "first", 0, record("first"))
+	th.add(TransitionEpoch, "first-again", 0, record("first-again"))
+	th.add(TransitionSuspend, "suspend", 0, record("suspend"))
+
+	worker := &EpochSnapshot{executorCommittee: &CommitteeInfo{Roles: []scheduler.Role{scheduler.RoleWorker}}}
+	backup := &EpochSnapshot{executorCommittee: &CommitteeInfo{Roles: []scheduler.Role{scheduler.RoleBackupWorker}}}
+
+	// Epoch transition without a committee change.
+	th.dispatch(&Transition{Kind: TransitionEpoch, Epoch: worker, PreviousEpoch: worker})
+	require.Equal([]string{"first:epoch", "first-again:epoch", "second:epoch"}, calls,
+		"hooks should be called in priority order and a panicking hook should not stop others")
+
+	// Epoch transition with a committee change.
+	calls = nil
+	th.dispatch(&Transition{Kind: TransitionEpoch, Epoch: backup, PreviousEpoch: worker})
+	require.Equal([]string{"first:epoch", "first-again:epoch", "second:epoch", "committee:committee change"}, calls,
+		"committee change hooks should be called after epoch hooks")
+
+	// Suspension.
+	calls = nil
+	th.dispatch(&Transition{Kind: TransitionSuspend, Epoch: &EpochSnapshot{}, PreviousEpoch: backup})
+	require.Equal([]string{"suspend:suspend", "committee:committee change"}, calls,
+		"committee change hooks should be called after suspend hooks")
+}
+
+func TestCommitteeRolesChanged(t *testing.T) {
+	require := require.New(t)
+
+	empty := &EpochSnapshot{}
+	worker := &EpochSnapshot{executorCommittee: &CommitteeInfo{Roles: []scheduler.Role{scheduler.RoleWorker}}}
+	backup := &EpochSnapshot{executorCommittee: &CommitteeInfo{Roles: []scheduler.Role{scheduler.RoleBackupWorker}}}
+	nonMember := &EpochSnapshot{executorCommittee: &CommitteeInfo{}}
+
+	require.False(committeeRolesChanged(nil, empty))
+	require.False(committeeRolesChanged(empty, nonMember))
+	require.False(committeeRolesChanged(worker, worker))
+	require.True(committeeRolesChanged(empty, worker))
+	require.True(committeeRolesChanged(worker, backup))
+	require.True(committeeRolesChanged(backup, empty))
+}
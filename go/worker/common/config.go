@@ -18,7 +18,8 @@ var (
 	// CfgClientPort configures the worker client port.
 	CfgClientPort = "worker.client.port"
 
-	cfgClientAddresses = "worker.client.addresses"
+	cfgClientAddresses   = "worker.client.addresses"
+	cfgClientPeerMetrics = "worker.client.peer_metrics"
 
 	// CfgSentryAddresses configures addresses and public keys of sentry nodes the worker should
 	// connect to.
@@ -29,6 +30,10 @@ var (
 	cfgStaleTxCacheSize    = "worker.tx_pool.stale_tx_cache_size"
 	cfgCheckTxMaxBatchSize = "worker.tx_pool.check_tx_max_batch_size"
 	cfgRecheckInterval     = "worker.tx_pool.recheck_interval"
+	cfgExecutedIndexRounds = "worker.tx_pool.executed_index_rounds"
+
+	cfgExecutorBatchDeadline       = "worker.executor.batch_deadline"
+	cfgExecutorMaxExecutionReports = "worker.executor.max_execution_reports"
 
 	// Flags has the configuration flags.
 	Flags = flag.NewFlagSet("", flag.ContinueOnError)
@@ -40,8 +45,19 @@ type Config struct { // nolint: maligned
 	ClientAddresses []node.Address
 	SentryAddresses []node.TLSAddress
 
+	// ClientPeerMetrics enables gRPC server metrics labeled by the identity class of the caller.
+	ClientPeerMetrics bool
+
 	TxPool txpool.Config
 
+	// ExecutorBatchDeadline is the maximum duration of batch execution after which the
+	// transaction scheduler only proposes the already executed transactions. Zero disables
+	// partial batches.
+	ExecutorBatchDeadline time.Duration
+	// ExecutorMaxExecutionReports is the number of most recent per-round execution reports
+	// retained by the executor for each runtime. Zero disables execution reports.
+	ExecutorMaxExecutionReports uint64
+
 	logger *logging.Logger
 }
 
@@ -89,9 +105,10 @@ func NewConfig() (*Config, error) {
 	}
 
 	cfg := Config{
-		ClientPort:      uint16(viper.GetInt(CfgClientPort)),
-		ClientAddresses: clientAddresses,
-		SentryAddresses: sentryAddresses,
+		ClientPort:        uint16(viper.GetInt(CfgClientPort)),
+		ClientAddresses:   clientAddresses,
+		SentryAddresses:   sentryAddresses,
+		ClientPeerMetrics: viper.GetBool(cfgClientPeerMetrics),
 		TxPool: txpool.Config{
 			MaxPoolSize:          viper.GetUint64(cfgMaxTxPoolSize),
 			MaxCheckTxBatchSize:  viper.GetUint64(cfgCheckTxMaxBatchSize),
@@ -102,8 +119,12 @@ func NewConfig() (*Config, error) {
 			RepublishInterval: 60 * time.Second,
 
 			RecheckInterval: viper.GetUint64(cfgRecheckInterval),
+
+			MaxExecutedTxIndexRounds: viper.GetUint64(cfgExecutedIndexRounds),
 		},
-		logger: logging.GetLogger("worker/config"),
+		ExecutorBatchDeadline:       viper.GetDuration(cfgExecutorBatchDeadline),
+		ExecutorMaxExecutionReports: viper.GetUint64(cfgExecutorMaxExecutionReports),
+		logger:                      logging.GetLogger("worker/config"),
 	}
 
 	return &cfg, nil
@@ -112,6 +133,7 @@ func NewConfig() (*Config, error) {
 func init() {
 	Flags.Uint16(CfgClientPort, 9100, "Port to use for incoming gRPC client connections")
 	Flags.StringSlice(cfgClientAddresses, []string{}, "Address/port(s) to use for client connections when registering this node (if not set, all non-loopback local interfaces will be used)")
+	Flags.Bool(cfgClientPeerMetrics, false, "Export gRPC server metrics labeled by the identity class of the caller")
 	Flags.StringSlice(CfgSentryAddresses, []string{}, "Address(es) of sentry node(s) to connect to of the form [PubKey@]ip:port (where PubKey@ part represents base64 encoded node TLS public key)")
 
 	Flags.Uint64(cfgMaxTxPoolSize, 10_000, "Maximum size of the scheduling transaction pool")
@@ -119,6 +141,10 @@ func init() {
 	Flags.Uint64(cfgStaleTxCacheSize, 64, "Maximum cache size of recently cleared transactions")
 	Flags.Uint64(cfgCheckTxMaxBatchSize, 10_000, "Maximum check tx batch size")
 	Flags.Uint64(cfgRecheckInterval, 32, "Transaction recheck interval (in rounds)")
+	Flags.Uint64(cfgExecutedIndexRounds, 100, "Number of recent rounds for which executed transactions are indexed to reject resubmissions (0 disables)")
+
+	Flags.Duration(cfgExecutorBatchDeadline, 0, "Maximum batch execution time after which the transaction scheduler only proposes already executed transactions (0 disables)")
+	Flags.Uint64(cfgExecutorMaxExecutionReports, 1000, "Number of most recent per-round execution reports retained for each runtime (0 disables)")
 
 	_ = viper.BindPFlags(Flags)
 }
@@ -49,7 +49,8 @@ type Handler interface {
 }
 
 type topicHandler struct {
-	ctx context.Context
+	ctx       context.Context
+	cancelCtx context.CancelFunc
 
 	p2p *P2P
 
@@ -81,6 +82,20 @@ func (h *topicHandler) topicMessageValidator(ctx context.Context, unused core.Pe
 		"received_from", envelope.ReceivedFrom,
 	)
 
+	// Drop messages from banned peers and peers exceeding their message rate.
+	if h.p2p.scorer.isBanned(peerID) || h.p2p.scorer.isBanned(envelope.ReceivedFrom) {
+		return false
+	}
+	// When relaying is enabled, peers that are not registered nodes are consumers and are
+	// subject to a separate rate limit.
+	isConsumer := h.p2p.relayEnabled && !h.p2p.PeerManager.isKnownPeer(peerID)
+	if peerID != h.host.ID() && !h.p2p.scorer.recordMessage(peerID, isConsumer) {
+		h.logger.Debug("peer exceeded message rate limit, dropping message",
+			"peer_id", peerID,
+		)
+		return false
+	}
+
 	id, err := peerIDToPublicKey(peerID)
 	if err != nil {
 		h.logger.Error("error while extracting public key from peer ID",
@@ -96,6 +111,7 @@ func (h *topicHandler) topicMessageValidator(ctx context.Context, unused core.Pe
 			"err", err,
 			"peer_id", peerID,
 		)
+		h.p2p.scorer.penalize(peerID, ViolationInvalidMessage)
 		return false
 	}
 
@@ -114,6 +130,11 @@ func (h *topicHandler) topicMessageValidator(ctx context.Context, unused core.Pe
 		return false
 	}
 
+	// Consumer-only nodes handle messages locally but never forward them.
+	if h.p2p.relayConsumer && peerID != h.host.ID() {
+		return false
+	}
+
 	// Note: Messages that may become valid (in-line dispatch
 	// failed due to non-permanent error, retry started) will be
 	// relayed.
@@ -165,6 +186,10 @@ func (h *topicHandler) dispatchMessage(peerID core.PeerID, m *queuedMsg, isIniti
 		// the local node is just behind.  This does result in stale messages
 		// getting retried though.
 		if err := h.handler.AuthorizeMessage(ctx, m.from, m.msg); err != nil {
+			// Messages that can never be authorized are a protocol violation.
+			if isInitial && p2pError.IsPermanent(err) {
+				h.p2p.scorer.penalize(peerID, ViolationProtocol)
+			}
 			return err
 		}
 	}
@@ -231,6 +256,17 @@ func (h *topicHandler) tryPublishing(rawMsg []byte) error {
 	return h.topic.Publish(h.ctx, rawMsg)
 }
 
+// stop stops the topic handler and leaves the topic.
+func (h *topicHandler) stop() {
+	h.cancelCtx()
+	h.cancelRelay()
+	if err := h.topic.Close(); err != nil {
+		h.logger.Warn("failed to close topic",
+			"err", err,
+		)
+	}
+}
+
 // pendingMessagesWorker handles retrying for P2P messages when there are no connected peers.
 func (h *topicHandler) pendingMessagesWorker() {
 	mgrInitCh := h.p2p.PeerManager.Initialized()
@@ -272,8 +308,10 @@ func newTopicHandler(p *P2P, runtimeID common.Namespace, kind TopicKind, handler
 		return "", nil, fmt.Errorf("worker/common/p2p: failed to join topic '%s': %w", topicID, err)
 	}
 
+	ctx, cancel := context.WithCancel(p.ctx)
 	h := &topicHandler{
-		ctx:          p.ctx, // TODO: Should this support individual cancelation?
+		ctx:          ctx,
+		cancelCtx:    cancel,
 		p2p:          p,
 		topic:        topic,
 		host:         p.host,
@@ -288,6 +326,7 @@ func newTopicHandler(p *P2P, runtimeID common.Namespace, kind TopicKind, handler
 			"err", err,
 		)
 		_ = topic.Close()
+		cancel()
 
 		return "", nil, fmt.Errorf("worker/common/p2p: failed to relay topic '%s': %w", topicID, err)
 	}
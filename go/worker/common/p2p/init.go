@@ -1,6 +1,8 @@
 package p2p
 
 import (
+	"time"
+
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
@@ -24,6 +26,23 @@ const (
 	// CfgP2PConnectednessLowWater sets the ratio of connected to unconnected peers at which
 	// the peer manager will try to reconnect to disconnected nodes.
 	CfgP2PConnectednessLowWater = "worker.p2p.connectedness_low_water"
+	// CfgP2PPeerBanThreshold sets the (negative) peer score at which misbehaving peers are banned.
+	CfgP2PPeerBanThreshold = "worker.p2p.peer_ban_threshold"
+	// CfgP2PPeerBanDuration sets the duration for which misbehaving peers are banned.
+	CfgP2PPeerBanDuration = "worker.p2p.peer_ban_duration"
+	// CfgP2PPeerMaxMessageRate sets the maximum number of messages per second accepted from
+	// a single peer before the peer is penalized for spamming.
+	CfgP2PPeerMaxMessageRate = "worker.p2p.peer_max_message_rate"
+
+	// CfgP2PRelayEnabled enables relaying runtime block announcements and gossip to consumer
+	// peers that are not registered nodes.
+	CfgP2PRelayEnabled = "worker.p2p.relay.enabled"
+	// CfgP2PRelayConsumer configures the node to only consume gossip without forwarding messages
+	// received from other peers.
+	CfgP2PRelayConsumer = "worker.p2p.relay.consumer"
+	// CfgP2PRelayMaxMessageRate sets the maximum number of messages per second accepted from
+	// a single consumer peer when relaying is enabled.
+	CfgP2PRelayMaxMessageRate = "worker.p2p.relay.max_message_rate"
 )
 
 // Flags has the configuration flags.
@@ -37,6 +56,13 @@ func init() {
 	Flags.Int64(CfgP2PValidateConcurrency, 1024, "Set libp2p gossipsub per topic validator concurrency limit")
 	Flags.Int64(CfgP2PValidateThrottle, 8192, "Set libp2p gossipsub validator concurrency limit")
 	Flags.Float64(CfgP2PConnectednessLowWater, 0.2, "Set the low water mark at which the peer manager will try to reconnect to peers")
+	Flags.Float64(CfgP2PPeerBanThreshold, 100, "Set the peer score magnitude below which misbehaving peers are banned")
+	Flags.Duration(CfgP2PPeerBanDuration, 1*time.Hour, "Set the duration for which misbehaving peers are banned")
+	Flags.Uint64(CfgP2PPeerMaxMessageRate, 500, "Set the maximum number of messages per second accepted from a single peer (0 = unlimited)")
+
+	Flags.Bool(CfgP2PRelayEnabled, false, "Enable relaying block announcements and gossip to consumer peers")
+	Flags.Bool(CfgP2PRelayConsumer, false, "Only consume gossip without forwarding messages from other peers")
+	Flags.Uint64(CfgP2PRelayMaxMessageRate, 10, "Set the maximum number of messages per second accepted from a single consumer peer (0 = unlimited)")
 
 	_ = viper.BindPFlags(Flags)
 }
@@ -24,9 +24,11 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	registryAPI "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/configparser"
 )
 
@@ -42,6 +44,9 @@ const (
 	TopicKindCommittee TopicKind = "committee"
 	// TopicKindTx is the topic kind for the topic that is used to gossip transactions.
 	TopicKindTx TopicKind = "tx"
+	// TopicKindBlock is the topic kind for the topic that is used to announce new runtime blocks
+	// to consumer nodes.
+	TopicKindBlock TopicKind = "block"
 )
 
 var allowUnroutableAddresses bool
@@ -66,6 +71,11 @@ type P2P struct {
 	registerAddresses []multiaddr.Multiaddr
 	topics            map[common.Namespace]map[TopicKind]*topicHandler
 
+	scorer *peerScorer
+
+	relayEnabled  bool
+	relayConsumer bool
+
 	logger *logging.Logger
 }
 
@@ -106,6 +116,7 @@ func (p *P2P) Addresses() []node.Address {
 func (p *P2P) Peers(runtimeID common.Namespace) []string {
 	allPeers := p.pubsub.ListPeers(p.topicIDForRuntime(runtimeID, TopicKindCommittee))
 	allPeers = append(allPeers, p.pubsub.ListPeers(p.topicIDForRuntime(runtimeID, TopicKindTx))...)
+	allPeers = append(allPeers, p.pubsub.ListPeers(p.topicIDForRuntime(runtimeID, TopicKindBlock))...)
 
 	var peers []string
 	for _, peerID := range allPeers {
@@ -122,6 +133,13 @@ func (p *P2P) Peers(runtimeID common.Namespace) []string {
 	return peers
 }
 
+// Status returns the status of the P2P network, including the reputation scores of peers.
+func (p *P2P) Status() *api.P2PStatus {
+	return &api.P2PStatus{
+		PeerScores: p.scorer.peerScores(),
+	}
+}
+
 func filterGloballyReachableAddresses(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
 	ret := make([]multiaddr.Multiaddr, 0, len(addrs))
 	for _, addr := range addrs {
@@ -198,6 +216,17 @@ func (p *P2P) PublishTx(ctx context.Context, runtimeID common.Namespace, msg TxM
 	p.publish(ctx, runtimeID, TopicKindTx, msg)
 }
 
+// PublishBlock publishes a block announcement message.
+//
+// Block announcements are only published when relaying is enabled and the node is not
+// a consumer-only node.
+func (p *P2P) PublishBlock(ctx context.Context, runtimeID common.Namespace, msg *BlockMessage) {
+	if !p.relayEnabled || p.relayConsumer {
+		return
+	}
+	p.publish(ctx, runtimeID, TopicKindBlock, msg)
+}
+
 // RegisterHandler registers a message handler for the specified runtime and topic kind.
 func (p *P2P) RegisterHandler(runtimeID common.Namespace, kind TopicKind, handler Handler) {
 	p.Lock()
@@ -230,6 +259,23 @@ func (p *P2P) RegisterHandler(runtimeID common.Namespace, kind TopicKind, handle
 	)
 }
 
+// UnregisterHandlers unregisters all message handlers for the specified runtime.
+func (p *P2P) UnregisterHandlers(runtimeID common.Namespace) {
+	p.Lock()
+	defer p.Unlock()
+
+	for kind, h := range p.topics[runtimeID] {
+		_ = p.pubsub.UnregisterTopicValidator(p.topicIDForRuntime(runtimeID, kind))
+		h.stop()
+
+		p.logger.Debug("unregistered topic handler",
+			"runtime_id", runtimeID,
+			"kind", kind,
+		)
+	}
+	delete(p.topics, runtimeID)
+}
+
 func (p *P2P) handleConnection(conn core.Conn) {
 	if conn.Stat().Direction != network.DirInbound {
 		return
@@ -238,6 +284,22 @@ func (p *P2P) handleConnection(conn core.Conn) {
 	p.logger.Debug("new connection from peer",
 		"peer_id", conn.RemotePeer(),
 	)
+
+	if p.scorer.isBanned(conn.RemotePeer()) {
+		p.logger.Debug("closing connection from banned peer",
+			"peer_id", conn.RemotePeer(),
+		)
+		_ = conn.Close()
+	}
+}
+
+func (p *P2P) banPeer(peerID core.PeerID) {
+	if err := p.host.Network().ClosePeer(peerID); err != nil {
+		p.logger.Warn("failed to close connections to banned peer",
+			"err", err,
+			"peer_id", peerID,
+		)
+	}
 }
 
 func (p *P2P) topicIDForRuntime(runtimeID common.Namespace, kind TopicKind) string {
@@ -265,7 +327,9 @@ func messageIdFn(pmsg *pb.Message) string {
 }
 
 // New creates a new P2P node.
-func New(ctx context.Context, identity *identity.Identity, consensus consensus.Backend) (*P2P, error) {
+//
+// If a common store is given, peer reputation scores are persisted in it across restarts.
+func New(ctx context.Context, identity *identity.Identity, consensus consensus.Backend, store *persistent.CommonStore) (*P2P, error) {
 	// Instantiate the libp2p host.
 	addresses, err := configparser.ParseAddressList(viper.GetStringSlice(cfgP2pAddresses))
 	if err != nil {
@@ -321,16 +385,36 @@ func New(ctx context.Context, identity *identity.Identity, consensus consensus.B
 		return nil, fmt.Errorf("worker/common/p2p: failed to get consensus chain context: %w", err)
 	}
 
+	var scoreStore *persistent.ServiceStore
+	if store != nil {
+		if scoreStore, err = store.GetServiceStore(peerScoreServiceName); err != nil {
+			return nil, fmt.Errorf("worker/common/p2p: failed to open peer score store: %w", err)
+		}
+	}
+
 	p := &P2P{
-		PeerManager:       newPeerManager(ctx, host, consensus),
 		ctx:               ctx,
 		chainContext:      chainContext,
 		host:              host,
 		pubsub:            pubsub,
 		registerAddresses: registerAddresses,
 		topics:            make(map[common.Namespace]map[TopicKind]*topicHandler),
+		relayEnabled:      viper.GetBool(CfgP2PRelayEnabled),
+		relayConsumer:     viper.GetBool(CfgP2PRelayConsumer),
 		logger:            logging.GetLogger("worker/common/p2p"),
 	}
+	if p.scorer, err = newPeerScorer(
+		scoreStore,
+		viper.GetFloat64(CfgP2PPeerBanThreshold),
+		viper.GetDuration(CfgP2PPeerBanDuration),
+		viper.GetUint64(CfgP2PPeerMaxMessageRate),
+		viper.GetUint64(CfgP2PRelayMaxMessageRate),
+		p.banPeer,
+	); err != nil {
+		return nil, fmt.Errorf("worker/common/p2p: failed to load peer scores: %w", err)
+	}
+	p.PeerManager = newPeerManager(ctx, host, consensus, p.scorer)
+	go p.scorer.worker(ctx)
 	p.host.Network().SetConnHandler(p.handleConnection)
 
 	p.logger.Info("p2p host initialized",
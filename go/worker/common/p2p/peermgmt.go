@@ -35,8 +35,9 @@ type PeerManager struct {
 
 	ctx context.Context
 
-	host  core.Host
-	peers map[core.PeerID]*p2pPeer
+	host   core.Host
+	peers  map[core.PeerID]*p2pPeer
+	scorer *peerScorer
 
 	initCh   chan struct{}
 	initOnce sync.Once
@@ -73,6 +74,33 @@ func (mgr *PeerManager) KnownPeers() []core.PeerID {
 	return peers
 }
 
+// isKnownPeer returns true iff the given peer is a known (registered) node.
+func (mgr *PeerManager) isKnownPeer(peerID core.PeerID) bool {
+	mgr.RLock()
+	defer mgr.RUnlock()
+
+	_, ok := mgr.peers[peerID]
+	return ok
+}
+
+// PeerNode returns the registered node descriptor of the given peer or nil in case the peer is
+// not a known node.
+func (mgr *PeerManager) PeerNode(peerID signature.PublicKey) *node.Node {
+	id, err := publicKeyToPeerID(peerID)
+	if err != nil {
+		return nil
+	}
+
+	mgr.RLock()
+	defer mgr.RUnlock()
+
+	p := mgr.peers[id]
+	if p == nil {
+		return nil
+	}
+	return p.node
+}
+
 // SetNodes sets the membership of the gossipsub network.
 func (mgr *PeerManager) SetNodes(nodes []*node.Node) {
 	mgr.Lock()
@@ -276,11 +304,12 @@ func (mgr *PeerManager) watchRegistryNodes(consensus consensus.Backend) {
 	}
 }
 
-func newPeerManager(ctx context.Context, host core.Host, consensus consensus.Backend) *PeerManager {
+func newPeerManager(ctx context.Context, host core.Host, consensus consensus.Backend, scorer *peerScorer) *PeerManager {
 	mgr := &PeerManager{
 		ctx:    ctx,
 		host:   host,
 		peers:  make(map[core.PeerID]*p2pPeer),
+		scorer: scorer,
 		initCh: make(chan struct{}),
 		logger: logging.GetLogger("worker/common/p2p/peermgr"),
 	}
@@ -311,6 +340,11 @@ func (p *p2pPeer) connectWorker(mgr *PeerManager, peerID core.PeerID) {
 	bctx := backoff.WithContext(cmnBackoff.NewExponentialBackOff(), p.ctx)
 
 	err = backoff.Retry(func() (retError error) {
+		// Do not connect to banned peers, the connection would be closed anyway.
+		if mgr.scorer.isBanned(peerID) {
+			return backoff.Permanent(fmt.Errorf("peer is banned"))
+		}
+
 		// This is blocking, which is stupid.
 		if perr := mgr.host.Connect(p.ctx, *ai); perr != nil {
 			switch perr {
@@ -0,0 +1,338 @@
+package p2p
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	core "github.com/libp2p/go-libp2p-core"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/api"
+)
+
+const (
+	peerScoreServiceName = "worker/common/p2p"
+	peerScoreStoreKey    = "peer_scores"
+
+	// peerScoreDecayHalfLife is the half-life of (negative) peer scores.
+	peerScoreDecayHalfLife = 10 * time.Minute
+	// peerScorePersistInterval is the interval at which peer scores are persisted.
+	peerScorePersistInterval = 1 * time.Minute
+	// peerScorePruneThreshold is the score magnitude below which unbanned peers are forgotten.
+	peerScorePruneThreshold = 0.1
+
+	// peerMessageRateWindow is the window over which per-peer message rates are measured.
+	peerMessageRateWindow = 10 * time.Second
+)
+
+// PeerViolation is the kind of misbehavior that a peer was penalized for.
+type PeerViolation uint8
+
+const (
+	// ViolationInvalidMessage is a message that could not be decoded.
+	ViolationInvalidMessage PeerViolation = iota
+	// ViolationProtocol is a message that can never be authorized.
+	ViolationProtocol
+	// ViolationSpam is a message exceeding the per-peer message rate limit.
+	ViolationSpam
+)
+
+// String returns a string representation of the peer violation.
+func (v PeerViolation) String() string {
+	switch v {
+	case ViolationInvalidMessage:
+		return "invalid message"
+	case ViolationProtocol:
+		return "protocol violation"
+	case ViolationSpam:
+		return "spam"
+	default:
+		return "[unknown violation]"
+	}
+}
+
+func (v PeerViolation) penalty() float64 {
+	switch v {
+	case ViolationInvalidMessage:
+		return 10
+	case ViolationProtocol:
+		return 5
+	case ViolationSpam:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// peerScore is the persisted reputation state of a single peer.
+type peerScore struct {
+	Score      float64   `json:"score"`
+	LastUpdate time.Time `json:"last_update"`
+
+	InvalidMessages    uint64 `json:"invalid_messages"`
+	ProtocolViolations uint64 `json:"protocol_violations"`
+	SpamMessages       uint64 `json:"spam_messages"`
+
+	BannedUntil time.Time `json:"banned_until"`
+
+	rateWindowStart time.Time
+	rateCount       uint64
+}
+
+func (s *peerScore) decay(now time.Time) {
+	elapsed := now.Sub(s.LastUpdate)
+	if elapsed <= 0 {
+		return
+	}
+	s.Score *= math.Pow(0.5, float64(elapsed)/float64(peerScoreDecayHalfLife))
+	s.LastUpdate = now
+}
+
+func (s *peerScore) isBanned(now time.Time) bool {
+	return now.Before(s.BannedUntil)
+}
+
+// peerScorer tracks peer reputation scores and temporarily bans misbehaving peers.
+type peerScorer struct {
+	sync.Mutex
+
+	store *persistent.ServiceStore
+
+	banThreshold    float64
+	banDuration     time.Duration
+	maxMessageCount uint64
+	// maxConsumerMessageCount is the message limit for consumer peers.
+	maxConsumerMessageCount uint64
+
+	scores map[core.PeerID]*peerScore
+	onBan  func(core.PeerID)
+
+	// nowFn is the time source, overridable in tests.
+	nowFn func() time.Time
+
+	logger *logging.Logger
+}
+
+func (ps *peerScorer) getLocked(peerID core.PeerID, now time.Time) *peerScore {
+	s := ps.scores[peerID]
+	if s == nil {
+		s = &peerScore{LastUpdate: now}
+		ps.scores[peerID] = s
+	}
+	s.decay(now)
+	return s
+}
+
+// isBanned returns true iff the given peer is currently banned.
+func (ps *peerScorer) isBanned(peerID core.PeerID) bool {
+	ps.Lock()
+	defer ps.Unlock()
+
+	s := ps.scores[peerID]
+	if s == nil {
+		return false
+	}
+	return s.isBanned(ps.nowFn())
+}
+
+// recordMessage accounts a message received from the given peer towards its message rate and
+// returns false in case the peer exceeded its rate limit (in which case it is penalized).
+//
+// Consumer peers are subject to a separate (usually lower) rate limit.
+func (ps *peerScorer) recordMessage(peerID core.PeerID, isConsumer bool) bool {
+	ps.Lock()
+	now := ps.nowFn()
+	s := ps.getLocked(peerID, now)
+	if now.Sub(s.rateWindowStart) >= peerMessageRateWindow {
+		s.rateWindowStart = now
+		s.rateCount = 0
+	}
+	s.rateCount++
+	maxCount := ps.maxMessageCount
+	if isConsumer {
+		maxCount = ps.maxConsumerMessageCount
+	}
+	withinLimit := maxCount == 0 || s.rateCount <= maxCount
+	ps.Unlock()
+
+	if !withinLimit {
+		ps.penalize(peerID, ViolationSpam)
+	}
+	return withinLimit
+}
+
+// penalize lowers the score of the given peer for the given violation and bans the peer in case
+// its score falls below the ban threshold.
+func (ps *peerScorer) penalize(peerID core.PeerID, violation PeerViolation) {
+	ps.Lock()
+	now := ps.nowFn()
+	s := ps.getLocked(peerID, now)
+	s.Score -= violation.penalty()
+	switch violation {
+	case ViolationInvalidMessage:
+		s.InvalidMessages++
+	case ViolationProtocol:
+		s.ProtocolViolations++
+	case ViolationSpam:
+		s.SpamMessages++
+	}
+
+	var banned bool
+	if s.Score <= ps.banThreshold && !s.isBanned(now) {
+		s.BannedUntil = now.Add(ps.banDuration)
+		banned = true
+	}
+	score := s.Score
+	ps.Unlock()
+
+	ps.logger.Debug("penalized peer",
+		"peer_id", peerID,
+		"violation", violation,
+		"score", score,
+	)
+
+	if !banned {
+		return
+	}
+	ps.logger.Warn("banning misbehaving peer",
+		"peer_id", peerID,
+		"score", score,
+		"ban_duration", ps.banDuration,
+	)
+	if ps.onBan != nil {
+		ps.onBan(peerID)
+	}
+}
+
+// peerScores returns the current scores of all tracked peers.
+func (ps *peerScorer) peerScores() []api.PeerScore {
+	ps.Lock()
+	defer ps.Unlock()
+
+	now := ps.nowFn()
+	scores := make([]api.PeerScore, 0, len(ps.scores))
+	for peerID, s := range ps.scores {
+		s.decay(now)
+
+		score := api.PeerScore{
+			PeerID:             peerID.Pretty(),
+			Score:              s.Score,
+			InvalidMessages:    s.InvalidMessages,
+			ProtocolViolations: s.ProtocolViolations,
+			SpamMessages:       s.SpamMessages,
+		}
+		if s.isBanned(now) {
+			bannedUntil := s.BannedUntil
+			score.BannedUntil = &bannedUntil
+		}
+		scores = append(scores, score)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].Score < scores[j].Score
+	})
+	return scores
+}
+
+// pruneLocked forgets peers that are not banned and whose score decayed close to zero.
+func (ps *peerScorer) pruneLocked(now time.Time) {
+	for peerID, s := range ps.scores {
+		s.decay(now)
+		if s.isBanned(now) || math.Abs(s.Score) >= peerScorePruneThreshold {
+			continue
+		}
+		if now.Sub(s.rateWindowStart) < peerMessageRateWindow {
+			continue
+		}
+		delete(ps.scores, peerID)
+	}
+}
+
+func (ps *peerScorer) load() error {
+	if ps.store == nil {
+		return nil
+	}
+
+	var stored map[string]*peerScore
+	switch err := ps.store.GetCBOR([]byte(peerScoreStoreKey), &stored); err {
+	case nil:
+	case persistent.ErrNotFound:
+		return nil
+	default:
+		return err
+	}
+
+	ps.Lock()
+	defer ps.Unlock()
+
+	for peerID, s := range stored {
+		ps.scores[core.PeerID(peerID)] = s
+	}
+	ps.pruneLocked(ps.nowFn())
+	return nil
+}
+
+func (ps *peerScorer) persist() {
+	if ps.store == nil {
+		return
+	}
+
+	ps.Lock()
+	ps.pruneLocked(ps.nowFn())
+	stored := make(map[string]*peerScore, len(ps.scores))
+	for peerID, s := range ps.scores {
+		sc := *s
+		stored[string(peerID)] = &sc
+	}
+	ps.Unlock()
+
+	if err := ps.store.PutCBOR([]byte(peerScoreStoreKey), stored); err != nil {
+		ps.logger.Error("failed to persist peer scores",
+			"err", err,
+		)
+	}
+}
+
+func (ps *peerScorer) worker(ctx context.Context) {
+	ticker := time.NewTicker(peerScorePersistInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			ps.persist()
+			return
+		case <-ticker.C:
+			ps.persist()
+		}
+	}
+}
+
+func newPeerScorer(
+	store *persistent.ServiceStore,
+	banThreshold float64,
+	banDuration time.Duration,
+	maxMessageRate uint64,
+	maxConsumerMessageRate uint64,
+	onBan func(core.PeerID),
+) (*peerScorer, error) {
+	ps := &peerScorer{
+		store:           store,
+		banThreshold:    -banThreshold,
+		banDuration:     banDuration,
+		maxMessageCount: maxMessageRate * uint64(peerMessageRateWindow/time.Second),
+		scores:          make(map[core.PeerID]*peerScore),
+		onBan:           onBan,
+		nowFn:           time.Now,
+		logger:          logging.GetLogger("worker/common/p2p/scoring"),
+
+		maxConsumerMessageCount: maxConsumerMessageRate * uint64(peerMessageRateWindow/time.Second),
+	}
+	if err := ps.load(); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
@@ -0,0 +1,88 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	core "github.com/libp2p/go-libp2p-core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+)
+
+func TestPeerScorer(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	commonStore, err := persistent.NewCommonStore(dataDir)
+	require.NoError(err, "NewCommonStore")
+	store, err := commonStore.GetServiceStore(peerScoreServiceName)
+	require.NoError(err, "GetServiceStore")
+
+	// Persisted timestamps have second granularity.
+	now := time.Now().Truncate(time.Second)
+	var banned []core.PeerID
+	ps, err := newPeerScorer(store, 100, time.Hour, 2, 1, func(peerID core.PeerID) {
+		banned = append(banned, peerID)
+	})
+	require.NoError(err, "newPeerScorer")
+	ps.nowFn = func() time.Time { return now }
+
+	peerA := core.PeerID("peer-a")
+	peerB := core.PeerID("peer-b")
+
+	// Message rate limiting.
+	for i := 0; i < 2*int(peerMessageRateWindow/time.Second); i++ {
+		require.True(ps.recordMessage(peerA, false), "messages within the rate limit should be accepted")
+	}
+	require.False(ps.recordMessage(peerA, false), "messages over the rate limit should be rejected")
+	now = now.Add(peerMessageRateWindow)
+	require.True(ps.recordMessage(peerA, false), "rate limit should reset after the window")
+
+	// Consumer peers have a separate rate limit.
+	now = now.Add(peerMessageRateWindow)
+	for i := 0; i < int(peerMessageRateWindow/time.Second); i++ {
+		require.True(ps.recordMessage(peerA, true), "consumer messages within the rate limit should be accepted")
+	}
+	require.False(ps.recordMessage(peerA, true), "consumer messages over the rate limit should be rejected")
+
+	// Banning.
+	for i := 0; i < 9; i++ {
+		ps.penalize(peerB, ViolationInvalidMessage)
+	}
+	require.False(ps.isBanned(peerB), "peer should not be banned before reaching the threshold")
+	ps.penalize(peerB, ViolationInvalidMessage)
+	require.True(ps.isBanned(peerB), "peer should be banned after reaching the threshold")
+	require.Equal([]core.PeerID{peerB}, banned, "ban callback should be invoked once")
+	require.False(ps.isBanned(peerA), "other peers should not be banned")
+
+	scores := ps.peerScores()
+	require.Len(scores, 2)
+	require.Equal(peerB.Pretty(), scores[0].PeerID, "scores should be sorted lowest first")
+	require.EqualValues(10, scores[0].InvalidMessages)
+	require.NotNil(scores[0].BannedUntil)
+	require.EqualValues(2, scores[1].SpamMessages)
+	require.Nil(scores[1].BannedUntil)
+
+	// Persistence.
+	ps.persist()
+	commonStore.Close()
+
+	commonStore, err = persistent.NewCommonStore(dataDir)
+	require.NoError(err, "NewCommonStore")
+	defer commonStore.Close()
+	store, err = commonStore.GetServiceStore(peerScoreServiceName)
+	require.NoError(err, "GetServiceStore")
+
+	ps, err = newPeerScorer(store, 100, time.Hour, 2, 1, nil)
+	require.NoError(err, "newPeerScorer")
+	ps.nowFn = func() time.Time { return now }
+	require.True(ps.isBanned(peerB), "ban should be persisted across restarts")
+
+	// Bans expire and scores decay.
+	now = now.Add(time.Hour)
+	require.False(ps.isBanned(peerB), "ban should expire")
+	scores = ps.peerScores()
+	require.Len(scores, 2)
+	require.InDelta(-100.0/64, scores[0].Score, 0.001, "score should decay")
+}
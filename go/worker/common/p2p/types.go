@@ -2,6 +2,7 @@ package p2p
 
 import (
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
 )
 
@@ -15,8 +16,19 @@ type CommitteeMessage struct {
 
 	// Proposal is a batch proposal.
 	Proposal *commitment.Proposal `json:",omitempty"`
+
+	// Commitment is an executor commitment that should be aggregated by the transaction scheduler
+	// and submitted to the consensus layer.
+	Commitment *commitment.ExecutorCommitment `json:",omitempty"`
 }
 
 // TxMessage is a message published to nodes via gossipsub on the transaction topic. It contains the
 // raw signed transaction with runtime-dependent semantics.
 type TxMessage []byte
+
+// BlockMessage is a message published to nodes via gossipsub on the block topic. It announces
+// a new runtime block to consumer nodes that are not members of any committee.
+type BlockMessage struct {
+	// Block is the announced runtime block.
+	Block *block.Block `json:"block"`
+}
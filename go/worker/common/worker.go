@@ -3,8 +3,10 @@ package common
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc"
 	policyAPI "github.com/oasisprotocol/oasis-core/go/common/grpc/policy/api"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
@@ -31,13 +33,15 @@ type Worker struct {
 	Consensus         consensus.Backend
 	Grpc              *grpc.Server
 	GrpcPolicyWatcher policyAPI.PolicyWatcher
+	GrpcClassifier    *grpc.CommitteePeerClassifier
 	P2P               *p2p.P2P
 	IAS               ias.Endpoint
 	KeyManager        keymanagerApi.Backend
 	RuntimeRegistry   runtimeRegistry.Registry
 	GenesisDoc        *genesis.Document
 
-	runtimes map[common.Namespace]*committee.Node
+	runtimesLock sync.RWMutex
+	runtimes     map[common.Namespace]*committee.Node
 
 	ctx       context.Context
 	cancelCtx context.CancelFunc
@@ -67,7 +71,7 @@ func (w *Worker) Start() error {
 	go func() {
 		defer close(w.quitCh)
 
-		for _, rt := range w.runtimes {
+		for _, rt := range w.GetRuntimes() {
 			<-rt.Quit()
 		}
 
@@ -76,7 +80,7 @@ func (w *Worker) Start() error {
 
 	// Wait for all runtimes to be initialized.
 	go func() {
-		for _, rt := range w.runtimes {
+		for _, rt := range w.GetRuntimes() {
 			<-rt.Initialized()
 		}
 
@@ -84,7 +88,7 @@ func (w *Worker) Start() error {
 	}()
 
 	// Start runtime services.
-	for id, rt := range w.runtimes {
+	for id, rt := range w.GetRuntimes() {
 		w.logger.Info("starting services for runtime",
 			"runtime_id", id,
 		)
@@ -104,7 +108,7 @@ func (w *Worker) Stop() {
 		return
 	}
 
-	for id, rt := range w.runtimes {
+	for id, rt := range w.GetRuntimes() {
 		w.logger.Info("stopping services for runtime",
 			"runtime_id", id,
 		)
@@ -132,7 +136,7 @@ func (w *Worker) Cleanup() {
 		return
 	}
 
-	for _, rt := range w.runtimes {
+	for _, rt := range w.GetRuntimes() {
 		rt.Cleanup()
 	}
 
@@ -152,16 +156,73 @@ func (w *Worker) GetConfig() Config {
 
 // GetRuntimes returns a map of configured runtimes.
 func (w *Worker) GetRuntimes() map[common.Namespace]*committee.Node {
-	return w.runtimes
+	w.runtimesLock.RLock()
+	defer w.runtimesLock.RUnlock()
+
+	runtimes := make(map[common.Namespace]*committee.Node, len(w.runtimes))
+	for id, rt := range w.runtimes {
+		runtimes[id] = rt
+	}
+	return runtimes
 }
 
 // GetRuntime returns a common committee node for the given runtime (if available).
 //
 // In case the runtime with the specified id was not configured for this node it returns nil.
 func (w *Worker) GetRuntime(id common.Namespace) *committee.Node {
+	w.runtimesLock.RLock()
+	defer w.runtimesLock.RUnlock()
+
 	return w.runtimes[id]
 }
 
+// AddRuntime registers a runtime that was added to the runtime registry after the worker has
+// been created and returns its common committee node.
+//
+// The node is not started so that other workers can register their hooks first.
+func (w *Worker) AddRuntime(runtime runtimeRegistry.Runtime) (*committee.Node, error) {
+	if !w.enabled {
+		return nil, fmt.Errorf("worker/common: worker is disabled")
+	}
+	if err := w.registerRuntime(runtime); err != nil {
+		return nil, err
+	}
+	return w.GetRuntime(runtime.ID()), nil
+}
+
+// RemoveRuntime stops the common committee node of the given runtime and removes it.
+func (w *Worker) RemoveRuntime(ctx context.Context, id common.Namespace) error {
+	w.runtimesLock.Lock()
+	rt, ok := w.runtimes[id]
+	delete(w.runtimes, id)
+	w.runtimesLock.Unlock()
+	if !ok {
+		return fmt.Errorf("worker/common: runtime %s is not registered", id)
+	}
+
+	w.logger.Info("stopping services for runtime",
+		"runtime_id", id,
+	)
+
+	rt.Stop()
+	select {
+	case <-rt.Quit():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	rt.Cleanup()
+	w.P2P.UnregisterHandlers(id)
+	if w.GrpcClassifier != nil {
+		w.GrpcClassifier.SetCommitteeMembers(id.String(), nil)
+	}
+
+	w.logger.Info("runtime removed",
+		"runtime_id", id,
+	)
+
+	return nil
+}
+
 func (w *Worker) registerRuntime(runtime runtimeRegistry.Runtime) error {
 	id := runtime.ID()
 	w.logger.Info("registering new runtime",
@@ -180,6 +241,18 @@ func (w *Worker) registerRuntime(runtime runtimeRegistry.Runtime) error {
 	if err != nil {
 		return err
 	}
+	if w.GrpcClassifier != nil {
+		classifierHook := w.newGrpcClassifierHook(id)
+		node.AddTransitionHook(committee.TransitionEpoch, "grpc-classifier", 0, classifierHook)
+		node.AddTransitionHook(committee.TransitionSuspend, "grpc-classifier", 0, classifierHook)
+	}
+
+	w.runtimesLock.Lock()
+	defer w.runtimesLock.Unlock()
+
+	if _, ok := w.runtimes[id]; ok {
+		return fmt.Errorf("worker/common: runtime %s is already registered", id)
+	}
 	w.runtimes[id] = node
 
 	w.logger.Info("new runtime registered",
@@ -189,6 +262,24 @@ func (w *Worker) registerRuntime(runtime runtimeRegistry.Runtime) error {
 	return nil
 }
 
+// newGrpcClassifierHook returns a transition hook that keeps the committee members of the given
+// runtime known to the gRPC peer classifier up to date, so that server metrics can distinguish
+// calls made by committee members.
+func (w *Worker) newGrpcClassifierHook(id common.Namespace) committee.TransitionHook {
+	return func(t *committee.Transition) {
+		var keys []signature.PublicKey
+		if t.Epoch.IsValid() {
+			for _, n := range t.Epoch.Nodes().GetNodes() {
+				keys = append(keys, n.TLS.PubKey)
+				if n.TLS.NextPubKey.IsValid() {
+					keys = append(keys, n.TLS.NextPubKey)
+				}
+			}
+		}
+		w.GrpcClassifier.SetCommitteeMembers(id.String(), keys)
+	}
+}
+
 func newWorker(
 	ctx context.Context,
 	cancelCtx context.CancelFunc,
@@ -198,6 +289,7 @@ func newWorker(
 	consensus consensus.Backend,
 	grpc *grpc.Server,
 	grpcPolicyWatcher policyAPI.PolicyWatcher,
+	grpcClassifier *grpc.CommitteePeerClassifier,
 	p2p *p2p.P2P,
 	ias ias.Endpoint,
 	keyManager keymanagerApi.Backend,
@@ -223,6 +315,7 @@ func newWorker(
 		Consensus:         consensus,
 		Grpc:              grpc,
 		GrpcPolicyWatcher: grpcPolicyWatcher,
+		GrpcClassifier:    grpcClassifier,
 		P2P:               p2p,
 		IAS:               ias,
 		KeyManager:        keyManager,
@@ -273,6 +366,11 @@ func New(
 		Port:     cfg.ClientPort,
 		Identity: identity,
 	}
+	var grpcClassifier *grpc.CommitteePeerClassifier
+	if cfg.ClientPeerMetrics {
+		grpcClassifier = grpc.NewCommitteePeerClassifier()
+		serverConfig.PeerClassifier = grpcClassifier
+	}
 	grpc, err := grpc.NewServer(serverConfig)
 	if err != nil {
 		return nil, err
@@ -290,6 +388,7 @@ func New(
 		consensus,
 		grpc,
 		grpcPolicyWatcher,
+		grpcClassifier,
 		p2p,
 		ias,
 		keyManager,
@@ -0,0 +1,43 @@
+// Package api implements the executor worker API.
+package api
+
+import (
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+)
+
+// ModuleName is the executor worker module name.
+const ModuleName = "worker/executor"
+
+// ErrExecutionReportsDisabled is the error returned when trying to query execution reports
+// without execution report persistence being enabled.
+var ErrExecutionReportsDisabled = errors.New(ModuleName, 1, "worker/executor: execution reports not enabled")
+
+// ExecutionReport is a report about the execution of a single round by the executor worker.
+//
+// Reports are only recorded for rounds in which the node submitted an executor commitment.
+type ExecutionReport struct {
+	// Round is the round the batch has been executed for.
+	Round uint64 `json:"round"`
+	// Timestamp is the time at which the round has been finalized.
+	Timestamp time.Time `json:"timestamp"`
+
+	// BatchSize is the number of transactions executed in the batch.
+	BatchSize uint64 `json:"batch_size"`
+	// Messages is the number of runtime messages emitted by the batch.
+	Messages uint64 `json:"messages"`
+
+	// ExecutionTime is the time the runtime took to execute the batch.
+	ExecutionTime time.Duration `json:"execution_time"`
+	// StorageApplyTime is the time it took to apply the batch write logs to local storage.
+	StorageApplyTime time.Duration `json:"storage_apply_time"`
+	// CommitLatency is the time between submitting the executor commitment and the round being
+	// finalized.
+	CommitLatency time.Duration `json:"commit_latency"`
+
+	// Discrepancy is true iff an execution discrepancy has been detected in the round.
+	Discrepancy bool `json:"discrepancy"`
+	// Finalized is true iff the round has been finalized with the batch proposed by the node.
+	Finalized bool `json:"finalized"`
+}
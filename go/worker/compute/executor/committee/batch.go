@@ -2,9 +2,11 @@ package committee
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
 	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
 )
@@ -17,6 +19,17 @@ type unresolvedBatch struct {
 	missingTxs map[hash.Hash]int
 
 	maxBatchSizeBytes uint64
+
+	// trial is the result of executing the batch while cutting it, if any.
+	trial *trialExecution
+}
+
+// trialExecution is the result of executing a scheduled batch in full before proposing it.
+type trialExecution struct {
+	consensusHeight int64
+	ioRoot          hash.Hash
+	rsp             *protocol.RuntimeExecuteTxBatchResponse
+	executionTime   time.Duration
 }
 
 func (ub *unresolvedBatch) String() string {
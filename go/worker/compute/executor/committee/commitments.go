@@ -0,0 +1,245 @@
+package committee
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/p2p"
+)
+
+// commitmentPool is a pool of executor commitments for a single round that the transaction
+// scheduler collects from the committee and submits to the consensus layer in aggregate.
+type commitmentPool struct {
+	round uint64
+
+	commits   map[signature.PublicKey]*commitment.ExecutorCommitment
+	submitted map[signature.PublicKey]bool
+
+	// submitPending is true when a delayed submission has been scheduled.
+	submitPending bool
+}
+
+func newCommitmentPool(round uint64) *commitmentPool {
+	return &commitmentPool{
+		round:     round,
+		commits:   make(map[signature.PublicKey]*commitment.ExecutorCommitment),
+		submitted: make(map[signature.PublicKey]bool),
+	}
+}
+
+// add adds a commitment to the pool. It returns false in case the commitment is for a different
+// round or a commitment from the same node has already been added.
+func (p *commitmentPool) add(ec *commitment.ExecutorCommitment) bool {
+	if ec.Header.Round != p.round {
+		return false
+	}
+	if _, ok := p.commits[ec.NodeID]; ok || p.submitted[ec.NodeID] {
+		return false
+	}
+	p.commits[ec.NodeID] = ec
+	return true
+}
+
+// markSubmitted marks the commitment of the given node as already submitted.
+func (p *commitmentPool) markSubmitted(id signature.PublicKey) {
+	delete(p.commits, id)
+	p.submitted[id] = true
+}
+
+// hasAllWorkers checks whether the pool has seen commitments from all primary workers in the
+// given committee.
+func (p *commitmentPool) hasAllWorkers(committee *scheduler.Committee) bool {
+	for _, m := range committee.Members {
+		if m.Role != scheduler.RoleWorker {
+			continue
+		}
+		if _, ok := p.commits[m.PublicKey]; !ok && !p.submitted[m.PublicKey] {
+			return false
+		}
+	}
+	return true
+}
+
+// takePending removes all commitments that have not yet been submitted from the pool, marks them
+// as submitted and returns them ordered by node identifier.
+func (p *commitmentPool) takePending() []commitment.ExecutorCommitment {
+	commits := make([]commitment.ExecutorCommitment, 0, len(p.commits))
+	for id, ec := range p.commits {
+		commits = append(commits, *ec)
+		p.submitted[id] = true
+	}
+	sort.Slice(commits, func(i, j int) bool {
+		return bytes.Compare(commits[i].NodeID[:], commits[j].NodeID[:]) < 0
+	})
+	p.commits = make(map[signature.PublicKey]*commitment.ExecutorCommitment)
+	p.submitPending = false
+	return commits
+}
+
+// Guarded by n.commonNode.CrossNode.
+func (n *Node) signAndSubmitCommitment(roundCtx context.Context, ec *commitment.ExecutorCommitment) error {
+	err := ec.Sign(n.commonNode.Identity.NodeSigner, n.commonNode.Runtime.ID())
+	if err != nil {
+		n.logger.Error("failed to sign commitment",
+			"commit", ec,
+			"err", err,
+		)
+		return err
+	}
+
+	// Publish the commitment to the committee so that the transaction scheduler can aggregate it.
+	n.commonNode.P2P.PublishCommittee(roundCtx, n.commonNode.Runtime.ID(), &p2p.CommitteeMessage{
+		Epoch:      n.commonNode.CurrentEpoch,
+		Commitment: ec,
+	})
+
+	if n.commonNode.Group.GetEpochSnapshot().IsTransactionScheduler(n.commonNode.CurrentBlock.Header.Round) {
+		n.addCommitmentLocked(ec)
+		return nil
+	}
+
+	// In case the transaction scheduler fails to include our commitment in time, submit it
+	// directly so that the round can still make progress.
+	round := ec.Header.Round
+	go func() {
+		select {
+		case <-roundCtx.Done():
+			return
+		case <-n.clock.After(commitmentFallbackTimeout):
+		}
+
+		n.commonNode.CrossNode.Lock()
+		included := n.ownCommitRound == round
+		n.commonNode.CrossNode.Unlock()
+		if included {
+			return
+		}
+
+		n.logger.Warn("commitment not included by the transaction scheduler, submitting directly",
+			"round", round,
+		)
+		n.submitCommitments(roundCtx, []commitment.ExecutorCommitment{*ec})
+	}()
+
+	return nil
+}
+
+// addCommitmentLocked adds an executor commitment to the pool of commitments that will be
+// submitted by this node in aggregate. The commitment is ignored in case the node is not the
+// transaction scheduler for the current round.
+//
+// Guarded by n.commonNode.CrossNode.
+func (n *Node) addCommitmentLocked(ec *commitment.ExecutorCommitment) {
+	epoch := n.commonNode.Group.GetEpochSnapshot()
+	round := n.commonNode.CurrentBlock.Header.Round
+	if !epoch.IsTransactionScheduler(round) {
+		return
+	}
+
+	n.poolCommitmentLocked(ec, round+1, epoch.GetExecutorCommittee().Committee)
+}
+
+// poolCommitmentLocked adds an executor commitment for the given round to the pool and schedules
+// the submission of the pooled commitments.
+//
+// Guarded by n.commonNode.CrossNode.
+func (n *Node) poolCommitmentLocked(ec *commitment.ExecutorCommitment, round uint64, committee *scheduler.Committee) {
+	if n.commitPool == nil || n.commitPool.round != round {
+		n.commitPool = newCommitmentPool(round)
+	}
+	if !n.commitPool.add(ec) {
+		return
+	}
+
+	// Submit immediately in case all workers have already committed.
+	if n.commitPool.hasAllWorkers(committee) {
+		n.submitPendingCommitmentsLocked()
+		return
+	}
+
+	// Otherwise wait a bit for the other commitments to arrive.
+	if n.commitPool.submitPending {
+		return
+	}
+	n.commitPool.submitPending = true
+
+	pool := n.commitPool
+	roundCtx := n.roundCtx
+	go func() {
+		select {
+		case <-roundCtx.Done():
+			return
+		case <-n.clock.After(commitmentAggregationTimeout):
+		}
+
+		n.commonNode.CrossNode.Lock()
+		defer n.commonNode.CrossNode.Unlock()
+
+		if n.commitPool != pool {
+			return
+		}
+		n.submitPendingCommitmentsLocked()
+	}()
+}
+
+// Guarded by n.commonNode.CrossNode.
+func (n *Node) submitPendingCommitmentsLocked() {
+	commits := n.commitPool.takePending()
+	if len(commits) == 0 {
+		return
+	}
+
+	n.logger.Debug("submitting aggregated executor commitments",
+		"round", n.commitPool.round,
+		"num_commits", len(commits),
+	)
+
+	go n.submitCommitments(n.roundCtx, commits)
+}
+
+func (n *Node) submitCommitments(roundCtx context.Context, commits []commitment.ExecutorCommitment) {
+	tx := roothash.NewExecutorCommitTx(0, nil, n.commonNode.Runtime.ID(), commits)
+	err := consensus.SignAndSubmitTx(roundCtx, n.commonNode.Consensus, n.commonNode.Identity.NodeSigner, tx)
+	switch {
+	case err == nil:
+		n.logger.Info("executor commit finalized",
+			"num_commits", len(commits),
+		)
+		return
+	case len(commits) == 1 || roundCtx.Err() != nil:
+		n.logger.Error("failed to submit executor commit",
+			"commits", commits,
+			"err", err,
+		)
+		return
+	default:
+	}
+
+	// A single invalid or already submitted commitment causes the whole transaction to fail, so
+	// fall back to submitting each commitment separately.
+	n.logger.Warn("failed to submit aggregated executor commit, submitting separately",
+		"num_commits", len(commits),
+		"err", err,
+	)
+	for i := range commits {
+		n.submitCommitments(roundCtx, commits[i:i+1])
+	}
+}
+
+// handleCommitmentEventLocked processes an executor committed event.
+//
+// Guarded by n.commonNode.CrossNode.
+func (n *Node) handleCommitmentEventLocked(ev *roothash.ExecutorCommittedEvent) {
+	if ev.Commit.NodeID.Equal(n.commonNode.Identity.NodeSigner.Public()) {
+		n.ownCommitRound = ev.Commit.Header.Round
+	}
+	if n.commitPool != nil && n.commitPool.round == ev.Commit.Header.Round {
+		n.commitPool.markSubmitted(ev.Commit.NodeID)
+	}
+}
@@ -0,0 +1,291 @@
+package committee
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/clock"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/committee"
+)
+
+type testSubmissionManager struct {
+	consensus.SubmissionManager
+
+	// fail returns the error that the submission of the given commitments should fail with.
+	fail func(commits []commitment.ExecutorCommitment) error
+	// submitCh receives the commitments of each submission attempt.
+	submitCh chan []commitment.ExecutorCommitment
+}
+
+func (m *testSubmissionManager) SignAndSubmitTx(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error {
+	var ec roothash.ExecutorCommit
+	if err := cbor.Unmarshal(tx.Body, &ec); err != nil {
+		return err
+	}
+	m.submitCh <- ec.Commits
+
+	if m.fail != nil {
+		return m.fail(ec.Commits)
+	}
+	return nil
+}
+
+type testConsensus struct {
+	consensus.Backend
+
+	sm *testSubmissionManager
+}
+
+func (c *testConsensus) SubmissionManager() consensus.SubmissionManager {
+	return c.sm
+}
+
+type testRuntime struct {
+	runtimeRegistry.Runtime
+}
+
+func (r *testRuntime) ID() common.Namespace {
+	return common.Namespace{}
+}
+
+func newTestCommitmentNode(t *testing.T) (*Node, *testSubmissionManager, *clock.Mock, context.CancelFunc) {
+	signer, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(t, err, "NewSigner")
+
+	sm := &testSubmissionManager{
+		submitCh: make(chan []commitment.ExecutorCommitment, 16),
+	}
+	mockClock := clock.NewMock(time.Unix(1_600_000_000, 0))
+	roundCtx, cancel := context.WithCancel(context.Background())
+
+	n := &Node{
+		commonNode: &committee.Node{
+			Runtime:   &testRuntime{},
+			Identity:  &identity.Identity{NodeSigner: signer},
+			Consensus: &testConsensus{sm: sm},
+		},
+		clock:    mockClock,
+		roundCtx: roundCtx,
+		logger:   logging.GetLogger("worker/executor/committee/test"),
+	}
+	return n, sm, mockClock, cancel
+}
+
+func newTestCommitment(round uint64, id byte) *commitment.ExecutorCommitment {
+	var ec commitment.ExecutorCommitment
+	ec.NodeID[0] = id
+	ec.Header.Round = round
+	return &ec
+}
+
+func newTestCommittee(ids ...byte) *scheduler.Committee {
+	var c scheduler.Committee
+	for _, id := range ids {
+		var pk signature.PublicKey
+		pk[0] = id
+		c.Members = append(c.Members, &scheduler.CommitteeNode{
+			Role:      scheduler.RoleWorker,
+			PublicKey: pk,
+		})
+	}
+	return &c
+}
+
+func requireSubmission(t *testing.T, sm *testSubmissionManager, ids ...byte) {
+	select {
+	case commits := <-sm.submitCh:
+		require.Len(t, commits, len(ids), "submission should include the expected commitments")
+		for i, id := range ids {
+			require.EqualValues(t, id, commits[i].NodeID[0], "submitted commitments should be ordered")
+		}
+	case <-time.After(time.Second):
+		require.FailNow(t, "commitments should be submitted")
+	}
+}
+
+func requireNoSubmission(t *testing.T, sm *testSubmissionManager) {
+	require.Never(t, func() bool { return len(sm.submitCh) > 0 }, 100*time.Millisecond, 10*time.Millisecond,
+		"commitments should not be submitted",
+	)
+}
+
+func TestCommitmentPool(t *testing.T) {
+	require := require.New(t)
+
+	pool := newCommitmentPool(10)
+	require.False(pool.add(newTestCommitment(9, 1)), "commitments for other rounds should be rejected")
+	require.True(pool.add(newTestCommitment(10, 2)), "add")
+	require.False(pool.add(newTestCommitment(10, 2)), "duplicate commitments should be rejected")
+	require.True(pool.add(newTestCommitment(10, 1)), "add")
+
+	committee := newTestCommittee(1, 2, 3)
+	require.False(pool.hasAllWorkers(committee), "not all workers have committed")
+
+	var id signature.PublicKey
+	id[0] = 3
+	pool.markSubmitted(id)
+	require.True(pool.hasAllWorkers(committee), "submitted commitments should count as seen")
+	require.False(pool.add(newTestCommitment(10, 3)), "already submitted commitments should be rejected")
+
+	pool.submitPending = true
+	commits := pool.takePending()
+	require.Len(commits, 2)
+	require.EqualValues(1, commits[0].NodeID[0], "commitments should be ordered by node identifier")
+	require.EqualValues(2, commits[1].NodeID[0], "commitments should be ordered by node identifier")
+	require.False(pool.submitPending, "taking pending commitments should clear the submission flag")
+	require.Empty(pool.takePending(), "taken commitments should not be taken again")
+	require.False(pool.add(newTestCommitment(10, 1)), "taken commitments should be rejected")
+}
+
+func TestPoolCommitmentAllWorkers(t *testing.T) {
+	n, sm, _, cancel := newTestCommitmentNode(t)
+	defer cancel()
+
+	committee := newTestCommittee(1, 2)
+
+	n.commonNode.CrossNode.Lock()
+	n.poolCommitmentLocked(newTestCommitment(5, 2), 5, committee)
+	n.poolCommitmentLocked(newTestCommitment(5, 2), 5, committee)
+	require.Len(t, n.commitPool.commits, 1, "duplicate commitments should be ignored")
+	n.poolCommitmentLocked(newTestCommitment(5, 1), 5, committee)
+	n.commonNode.CrossNode.Unlock()
+
+	// Commitments should be submitted immediately once all workers have committed.
+	requireSubmission(t, sm, 1, 2)
+
+	n.commonNode.CrossNode.Lock()
+	n.poolCommitmentLocked(newTestCommitment(5, 1), 5, committee)
+	n.commonNode.CrossNode.Unlock()
+	requireNoSubmission(t, sm)
+}
+
+func TestPoolCommitmentAggregationTimeout(t *testing.T) {
+	n, sm, mockClock, cancel := newTestCommitmentNode(t)
+	defer cancel()
+
+	committee := newTestCommittee(1, 2, 3)
+
+	n.commonNode.CrossNode.Lock()
+	n.poolCommitmentLocked(newTestCommitment(5, 2), 5, committee)
+	n.poolCommitmentLocked(newTestCommitment(5, 1), 5, committee)
+	n.commonNode.CrossNode.Unlock()
+
+	// Only a single delayed submission should be scheduled.
+	mockClock.BlockUntil(1)
+	require.Equal(t, 1, mockClock.Waiters())
+	requireNoSubmission(t, sm)
+
+	// Collected commitments should be submitted after the aggregation timeout.
+	mockClock.Advance(commitmentAggregationTimeout)
+	requireSubmission(t, sm, 1, 2)
+}
+
+func TestPoolCommitmentTimerCancel(t *testing.T) {
+	t.Run("RoundCanceled", func(t *testing.T) {
+		n, sm, mockClock, cancel := newTestCommitmentNode(t)
+
+		n.commonNode.CrossNode.Lock()
+		n.poolCommitmentLocked(newTestCommitment(5, 1), 5, newTestCommittee(1, 2))
+		n.commonNode.CrossNode.Unlock()
+		mockClock.BlockUntil(1)
+
+		// Delayed submissions should be canceled when the round ends.
+		cancel()
+		mockClock.Advance(commitmentAggregationTimeout)
+		requireNoSubmission(t, sm)
+	})
+
+	t.Run("PoolReplaced", func(t *testing.T) {
+		n, sm, mockClock, cancel := newTestCommitmentNode(t)
+		defer cancel()
+
+		committee := newTestCommittee(1, 2)
+
+		n.commonNode.CrossNode.Lock()
+		n.poolCommitmentLocked(newTestCommitment(5, 1), 5, committee)
+		n.commonNode.CrossNode.Unlock()
+		mockClock.BlockUntil(1)
+
+		// A commitment for the next round replaces the pool, the delayed submission of the
+		// previous pool should not submit anything.
+		n.commonNode.CrossNode.Lock()
+		n.poolCommitmentLocked(newTestCommitment(6, 2), 6, committee)
+		n.commonNode.CrossNode.Unlock()
+		mockClock.BlockUntil(2)
+
+		mockClock.Advance(commitmentAggregationTimeout)
+		requireSubmission(t, sm, 2)
+		requireNoSubmission(t, sm)
+	})
+}
+
+func TestSubmitCommitmentsFallback(t *testing.T) {
+	errAggregate := fmt.Errorf("aggregate submission failed")
+
+	t.Run("Aggregate", func(t *testing.T) {
+		n, sm, _, cancel := newTestCommitmentNode(t)
+		defer cancel()
+		sm.fail = func(commits []commitment.ExecutorCommitment) error {
+			if len(commits) > 1 {
+				return errAggregate
+			}
+			return nil
+		}
+
+		// Failed aggregate submissions should fall back to submitting each commitment separately.
+		n.submitCommitments(n.roundCtx, []commitment.ExecutorCommitment{
+			*newTestCommitment(5, 1),
+			*newTestCommitment(5, 2),
+		})
+		requireSubmission(t, sm, 1, 2)
+		requireSubmission(t, sm, 1)
+		requireSubmission(t, sm, 2)
+		require.Empty(t, sm.submitCh, "there should be no further submissions")
+	})
+
+	t.Run("Single", func(t *testing.T) {
+		n, sm, _, cancel := newTestCommitmentNode(t)
+		defer cancel()
+		sm.fail = func(commits []commitment.ExecutorCommitment) error {
+			return errAggregate
+		}
+
+		// Failed single submissions should not be retried.
+		n.submitCommitments(n.roundCtx, []commitment.ExecutorCommitment{*newTestCommitment(5, 1)})
+		requireSubmission(t, sm, 1)
+		require.Empty(t, sm.submitCh, "there should be no further submissions")
+	})
+
+	t.Run("RoundCanceled", func(t *testing.T) {
+		n, sm, _, cancel := newTestCommitmentNode(t)
+		sm.fail = func(commits []commitment.ExecutorCommitment) error {
+			cancel()
+			return errAggregate
+		}
+
+		// There should be no fallback once the round has ended.
+		n.submitCommitments(n.roundCtx, []commitment.ExecutorCommitment{
+			*newTestCommitment(5, 1),
+			*newTestCommitment(5, 2),
+		})
+		requireSubmission(t, sm, 1, 2)
+		require.Empty(t, sm.submitCh, "there should be no further submissions")
+	})
+}
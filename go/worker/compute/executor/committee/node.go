@@ -10,10 +10,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/clock"
 	"github.com/oasisprotocol/oasis-core/go/common/crash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -23,22 +25,25 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/runtime/host"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	commonWorker "github.com/oasisprotocol/oasis-core/go/worker/common"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/committee"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/p2p"
 	p2pError "github.com/oasisprotocol/oasis-core/go/worker/common/p2p/error"
+	"github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
 )
 
 var (
-	errSeenNewerBlock     = fmt.Errorf("executor: seen newer block")
-	errRuntimeAborted     = fmt.Errorf("executor: runtime aborted batch processing")
-	errIncompatibleHeader = p2pError.Permanent(fmt.Errorf("executor: incompatible header"))
-	errBatchTooLarge      = p2pError.Permanent(fmt.Errorf("executor: batch too large"))
-	errIncorrectRole      = fmt.Errorf("executor: incorrect role")
-	errIncorrectState     = fmt.Errorf("executor: incorrect state")
-	errMsgFromNonTxnSched = fmt.Errorf("executor: received txn scheduler dispatch msg from non-txn scheduler")
+	errSeenNewerBlock      = fmt.Errorf("executor: seen newer block")
+	errRuntimeAborted      = fmt.Errorf("executor: runtime aborted batch processing")
+	errIncompatibleHeader  = p2pError.Permanent(fmt.Errorf("executor: incompatible header"))
+	errBatchTooLarge       = p2pError.Permanent(fmt.Errorf("executor: batch too large"))
+	errIncorrectRole       = fmt.Errorf("executor: incorrect role")
+	errIncorrectState      = fmt.Errorf("executor: incorrect state")
+	errMsgFromNonTxnSched  = fmt.Errorf("executor: received txn scheduler dispatch msg from non-txn scheduler")
+	errCommitFromNonMember = fmt.Errorf("executor: received commitment from non-committee member")
 
 	// Transaction scheduling errors.
 	errNoBlocks    = fmt.Errorf("executor: no blocks")
@@ -48,6 +53,12 @@ var (
 	proposeTimeoutDelay = 2 * time.Second
 	// abortTimeout is the duration to wait for the runtime to abort.
 	abortTimeout = 5 * time.Second
+	// commitmentAggregationTimeout is the duration the transaction scheduler waits for executor
+	// commitments from the rest of the committee before submitting the ones it has collected.
+	commitmentAggregationTimeout = 1 * time.Second
+	// commitmentFallbackTimeout is the duration to wait for the transaction scheduler to include
+	// our executor commitment before submitting it directly.
+	commitmentFallbackTimeout = 5 * time.Second
 )
 
 var (
@@ -93,6 +104,13 @@ var (
 		},
 		[]string{"runtime"},
 	)
+	partialBatchCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_partial_batch_count",
+			Help: "Number of partial batches proposed after exceeding the batch deadline.",
+		},
+		[]string{"runtime"},
+	)
 	batchSize = prometheus.NewSummaryVec(
 		prometheus.SummaryOpts{
 			Name: "oasis_worker_batch_size",
@@ -107,6 +125,7 @@ var (
 		batchReadTime,
 		batchProcessingTime,
 		batchRuntimeProcessingTime,
+		partialBatchCount,
 		batchSize,
 	}
 
@@ -125,12 +144,26 @@ type Node struct { // nolint: maligned
 
 	// Guarded by .commonNode.CrossNode.
 	proposingTimeout bool
-	prevEpochWorker  bool
+	// commitPool is the pool of executor commitments collected when the node is the transaction
+	// scheduler. Guarded by .commonNode.CrossNode.
+	commitPool *commitmentPool
+	// ownCommitRound is the last round for which our executor commitment has been included by
+	// the consensus layer. Guarded by .commonNode.CrossNode.
+	ownCommitRound uint64
+	// roundDiscrepancy is true iff an execution discrepancy has been detected in our executor
+	// committee during the current round. Guarded by .commonNode.CrossNode.
+	roundDiscrepancy bool
+
+	// reports is the store of recent execution reports, nil if disabled.
+	reports *reportStore
 
 	commonNode   *committee.Node
 	commonCfg    commonWorker.Config
 	roleProvider registration.RoleProvider
 
+	// clock is the time source used for round timeouts.
+	clock clock.Clock
+
 	ctx       context.Context
 	cancelCtx context.CancelFunc
 	stopCh    chan struct{}
@@ -276,12 +309,15 @@ func (n *Node) transitionLocked(state NodeState) {
 	n.bumpReselect()
 }
 
-// HandleEpochTransitionLocked implements NodeHooks.
+// HandleTransitionLocked implements committee.TransitionHook for epoch transitions and runtime
+// suspension.
 // Guarded by n.commonNode.CrossNode.
-func (n *Node) HandleEpochTransitionLocked(epoch *committee.EpochSnapshot) {
+func (n *Node) HandleTransitionLocked(t *committee.Transition) {
+	epoch := t.Epoch
+
 	switch {
 	case epoch.IsExecutorWorker():
-		if !n.prevEpochWorker {
+		if !t.PreviousEpoch.IsExecutorWorker() {
 			// Clear incoming queue and cache of any stale transactions in case
 			// we were not part of the compute committee in previous epoch.
 			n.commonNode.TxPool.Clear()
@@ -292,7 +328,6 @@ func (n *Node) HandleEpochTransitionLocked(epoch *committee.EpochSnapshot) {
 	default:
 		n.transitionLocked(StateNotReady{})
 	}
-	n.prevEpochWorker = epoch.IsExecutorWorker()
 }
 
 // HandleNewBlockEarlyLocked implements NodeHooks.
@@ -360,6 +395,11 @@ func (n *Node) HandleNewBlockLocked(blk *block.Block) {
 		func() {
 			defer n.transitionLocked(StateWaitingForBatch{})
 
+			var finalized bool
+			defer func() {
+				n.recordExecutionReportLocked(&state, finalized)
+			}()
+
 			// A new block means the round has been finalized.
 			n.logger.Info("considering the round finalized",
 				"round", header.Round,
@@ -379,6 +419,8 @@ func (n *Node) HandleNewBlockLocked(blk *block.Block) {
 				return
 			}
 
+			finalized = true
+
 			// Record time taken for successfully processing a batch.
 			batchProcessingTime.With(n.getMetricLabels()).Observe(time.Since(state.batchStartTime).Seconds())
 
@@ -387,7 +429,7 @@ func (n *Node) HandleNewBlockLocked(blk *block.Block) {
 				"io_root", header.IORoot,
 			)
 			// Removed processed transactions from queue.
-			if err := n.removeTxBatch(state.raw); err != nil {
+			if err := n.removeTxBatch(header.Round, state.raw); err != nil {
 				n.logger.Warn("failed removing processed batch from queue",
 					"err", err,
 					"batch_size", len(state.raw),
@@ -398,6 +440,10 @@ func (n *Node) HandleNewBlockLocked(blk *block.Block) {
 
 	// Clear the potentially set "is proposing timeout" flag from the previous round.
 	n.proposingTimeout = false
+	// Clear the discrepancy flag from the previous round.
+	n.roundDiscrepancy = false
+	// Discard any executor commitments collected during the previous round.
+	n.commitPool = nil
 
 	if header.HeaderType != block.Normal {
 		// If last round was not successful, make sure we re-query the round weight limits
@@ -437,15 +483,19 @@ func (n *Node) handleNewCheckedTransactions(txs []*transaction.CheckedTransactio
 	}
 }
 
-// removeTxBatch removes a batch from scheduling queue.
-func (n *Node) removeTxBatch(batch transaction.RawBatch) error {
-	hashes := make([]hash.Hash, len(batch))
+// removeTxBatch removes a batch executed in the given round from scheduling queue.
+func (n *Node) removeTxBatch(round uint64, batch transaction.RawBatch) error {
+	txs := make([]*txpool.ExecutedTransaction, len(batch))
 	for i, b := range batch {
-		hashes[i] = hash.NewFromBytes(b)
+		txs[i] = &txpool.ExecutedTransaction{
+			Hash:  hash.NewFromBytes(b),
+			Round: round,
+			Index: uint32(i),
+		}
 	}
 
-	// Remove transactions from the transaction pool.
-	n.commonNode.TxPool.RemoveTxBatch(hashes)
+	// Remove transactions from the transaction pool and remember them as executed.
+	n.commonNode.TxPool.RemoveExecutedTxBatch(round, txs)
 
 	return nil
 }
@@ -524,7 +574,7 @@ func (n *Node) proposeTimeoutLocked(roundCtx context.Context) error {
 		// scheduler nodes would be faster in proposing a timeout than the
 		// scheduler node proposing a batch.
 		select {
-		case <-time.After(proposeTimeoutDelay):
+		case <-n.clock.After(proposeTimeoutDelay):
 		case <-roundCtx.Done():
 			n.logger.Info("not requesting proposer timeout, round context canceled")
 			return
@@ -723,6 +773,14 @@ func (n *Node) handleScheduleBatch(force bool) {
 
 	// Scheduler node starts batch processing.
 
+	// In case a batch deadline is configured, make sure that the proposed batch can be executed
+	// in time. As all committee members execute the proposed batch, the cut must be made before
+	// proposing the batch. This is done without holding the cross-node lock.
+	var trial *trialExecution
+	if len(batch) > 0 && n.commonCfg.ExecutorBatchDeadline > 0 {
+		batch, trial = n.cutScheduledBatch(roundCtx, blk, lb, epoch.GetEpochNumber(), rtState, roundResults, batch)
+	}
+
 	// Generate the initial I/O root containing only the inputs (outputs and
 	// tags will be added later by the executor nodes).
 	emptyRoot := storage.Root{
@@ -819,6 +877,7 @@ func (n *Node) handleScheduleBatch(force bool) {
 	n.maybeStartProcessingBatchLocked(&unresolvedBatch{
 		proposal: proposal,
 		batch:    rawBatch,
+		trial:    trial,
 	})
 }
 
@@ -1036,33 +1095,48 @@ func (n *Node) startProcessingBatchLocked(batch *unresolvedBatch) {
 		}
 		batchSize.With(n.getMetricLabels()).Observe(float64(len(resolvedBatch)))
 
-		rtStartTime := time.Now()
-		defer func() {
-			batchRuntimeProcessingTime.With(n.getMetricLabels()).Observe(time.Since(rtStartTime).Seconds())
-		}()
-
-		rsp, err := rt.Call(ctx, rq)
-		switch {
-		case err == nil:
-		case errors.Is(err, context.Canceled):
-			// Context was canceled while the runtime was processing a request.
-			n.logger.Error("batch processing aborted by context, restarting runtime")
-
-			// Abort the runtime, so we can start processing the next batch.
-			abortCtx, cancel := context.WithTimeout(n.ctx, abortTimeout)
-			defer cancel()
-
-			if err = rt.Abort(abortCtx, false); err != nil {
-				n.logger.Error("failed to abort the runtime",
+		var (
+			rsp           *protocol.Body
+			executionTime time.Duration
+		)
+		switch trial := batch.trial; {
+		case trial != nil && trial.consensusHeight == consensusBlk.Height && trial.ioRoot.Equal(&rq.RuntimeExecuteTxBatchRequest.IORoot):
+			// The batch has already been executed in full against the same state while it was
+			// being cut, so there is no need to execute it again.
+			n.logger.Debug("reusing batch execution result from the batch cut")
+
+			rsp = &protocol.Body{RuntimeExecuteTxBatchResponse: trial.rsp}
+			executionTime = trial.executionTime
+		default:
+			rtStartTime := time.Now()
+			defer func() {
+				batchRuntimeProcessingTime.With(n.getMetricLabels()).Observe(time.Since(rtStartTime).Seconds())
+			}()
+
+			rsp, err = rt.Call(ctx, rq)
+			executionTime = time.Since(rtStartTime)
+			switch {
+			case err == nil:
+			case errors.Is(err, context.Canceled):
+				// Context was canceled while the runtime was processing a request.
+				n.logger.Error("batch processing aborted by context, restarting runtime")
+
+				// Abort the runtime, so we can start processing the next batch.
+				abortCtx, cancel := context.WithTimeout(n.ctx, abortTimeout)
+				defer cancel()
+
+				if err = rt.Abort(abortCtx, false); err != nil {
+					n.logger.Error("failed to abort the runtime",
+						"err", err,
+					)
+				}
+				return
+			default:
+				n.logger.Error("error while sending batch processing request to runtime",
 					"err", err,
 				)
+				return
 			}
-			return
-		default:
-			n.logger.Error("error while sending batch processing request to runtime",
-				"err", err,
-			)
-			return
 		}
 		crash.Here(crashPointBatchProcessStartAfter)
 
@@ -1073,6 +1147,16 @@ func (n *Node) startProcessingBatchLocked(batch *unresolvedBatch) {
 			return
 		}
 
+		// Proposed batches are always executed in full, as the cut is only ever made by the
+		// transaction scheduler before proposing the batch.
+		if rsp.RuntimeExecuteTxBatchResponse.Partial {
+			n.logger.Error("unexpected partial batch response from runtime",
+				"executed_inputs", rsp.RuntimeExecuteTxBatchResponse.ExecutedInputs,
+				"batch_size", len(resolvedBatch),
+			)
+			return
+		}
+
 		// Update round batch weight limits.
 		n.limitsLastUpdateLock.Lock()
 		if err = n.commonNode.TxPool.UpdateWeightLimits(rsp.RuntimeExecuteTxBatchResponse.BatchWeightLimits); err != nil {
@@ -1099,12 +1183,189 @@ func (n *Node) startProcessingBatchLocked(batch *unresolvedBatch) {
 
 		// Submit response to the executor worker.
 		done <- &processedBatch{
-			computed: &rsp.RuntimeExecuteTxBatchResponse.Batch,
-			raw:      resolvedBatch,
+			computed:      &rsp.RuntimeExecuteTxBatchResponse.Batch,
+			raw:           resolvedBatch,
+			executionTime: executionTime,
 		}
 	}()
 }
 
+// cutScheduledBatch executes the scheduled batch with the configured batch deadline and returns
+// the prefix of the batch that the runtime managed to execute in time. In case the full batch was
+// executed, the execution result is also returned so that it can be reused when processing the
+// proposed batch.
+//
+// In case the deadline is exceeded, the runtime is requested to stop executing and to report the
+// number of already executed transactions. Only that prefix is then proposed, so all committee
+// members execute the same batch. Transactions that did not make the cut stay in the pool for
+// a later round. At least one transaction is always kept so that a single slow transaction
+// cannot stall the runtime forever.
+func (n *Node) cutScheduledBatch(
+	ctx context.Context,
+	blk *block.Block,
+	lb *consensus.LightBlock,
+	epoch beacon.EpochTime,
+	rtState *roothash.RuntimeState,
+	roundResults *roothash.RoundResults,
+	batch []*transaction.CheckedTransaction,
+) ([]*transaction.CheckedTransaction, *trialExecution) {
+	rt := n.commonNode.GetHostedRuntime()
+	if rt == nil {
+		return batch, nil
+	}
+
+	emptyRoot := storage.Root{
+		Namespace: blk.Header.Namespace,
+		Version:   blk.Header.Round + 1,
+		Type:      storage.RootTypeIO,
+	}
+	emptyRoot.Hash.Empty()
+
+	ioTree := transaction.NewTree(nil, emptyRoot)
+	defer ioTree.Close()
+
+	inputs := make(transaction.RawBatch, len(batch))
+	for idx, tx := range batch {
+		if err := ioTree.AddTransaction(ctx, transaction.Transaction{Input: tx.Raw(), BatchOrder: uint32(idx)}, nil); err != nil {
+			n.logger.Error("failed to create I/O tree",
+				"err", err,
+			)
+			return batch, nil
+		}
+		inputs[idx] = tx.Raw()
+	}
+	_, ioRoot, err := ioTree.Commit(ctx)
+	if err != nil {
+		n.logger.Error("failed to create I/O tree",
+			"err", err,
+		)
+		return batch, nil
+	}
+
+	rtStartTime := time.Now()
+	rsp, err := n.executeTxBatch(ctx, rt, &protocol.Body{
+		RuntimeExecuteTxBatchRequest: &protocol.RuntimeExecuteTxBatchRequest{
+			ConsensusBlock: *lb,
+			RoundResults:   roundResults,
+			IORoot:         ioRoot,
+			Inputs:         inputs,
+			Block:          *blk,
+			Epoch:          epoch,
+			MaxMessages:    rtState.Runtime.Executor.MaxMessages,
+		},
+	})
+	executionTime := time.Since(rtStartTime)
+	if err != nil {
+		n.logger.Warn("failed to execute batch within the deadline, proposing full batch",
+			"err", err,
+		)
+		return batch, nil
+	}
+
+	executed, err := batchCut(rsp, len(batch))
+	if err != nil {
+		n.logger.Error("malformed response from runtime",
+			"err", err,
+		)
+		return batch, nil
+	}
+	if executed < len(batch) {
+		n.logger.Warn("batch deadline exceeded, proposing partial batch",
+			"executed_inputs", executed,
+			"batch_size", len(batch),
+		)
+		partialBatchCount.With(n.getMetricLabels()).Inc()
+		return batch[:executed], nil
+	}
+	if rsp.RuntimeExecuteTxBatchResponse.Partial {
+		return batch, nil
+	}
+	return batch, &trialExecution{
+		consensusHeight: lb.Height,
+		ioRoot:          ioRoot,
+		rsp:             rsp.RuntimeExecuteTxBatchResponse,
+		executionTime:   executionTime,
+	}
+}
+
+// batchCut returns the number of transactions that should be proposed based on the response to
+// a batch execution request with a deadline.
+func batchCut(rsp *protocol.Body, batchSize int) (int, error) {
+	if rsp.RuntimeExecuteTxBatchResponse == nil {
+		return 0, fmt.Errorf("missing batch execution response")
+	}
+	if !rsp.RuntimeExecuteTxBatchResponse.Partial {
+		return batchSize, nil
+	}
+
+	executed := rsp.RuntimeExecuteTxBatchResponse.ExecutedInputs
+	if executed > uint64(batchSize) {
+		return 0, fmt.Errorf("executed inputs (%d) exceed batch size (%d)", executed, batchSize)
+	}
+	if executed == 0 {
+		executed = 1
+	}
+	return int(executed), nil
+}
+
+// executeTxBatch sends the batch execution request to the runtime.
+//
+// In case the runtime fails to execute the batch before the batch deadline, the runtime is
+// requested to stop executing and to return a partial batch. Runtimes that do not support partial
+// batches will either keep executing the full batch or abort it. In case the runtime does not
+// respond within the abort timeout, it is aborted and an error is returned.
+func (n *Node) executeTxBatch(ctx context.Context, rt host.Runtime, rq *protocol.Body) (*protocol.Body, error) {
+	type callResult struct {
+		rsp *protocol.Body
+		err error
+	}
+	resultCh := make(chan *callResult, 1)
+	go func() {
+		rsp, err := rt.Call(ctx, rq)
+		resultCh <- &callResult{rsp, err}
+	}()
+
+	select {
+	case res := <-resultCh:
+		return res.rsp, res.err
+	case <-n.clock.After(n.commonCfg.ExecutorBatchDeadline):
+	}
+
+	n.logger.Warn("batch execution deadline exceeded, requesting partial batch",
+		"deadline", n.commonCfg.ExecutorBatchDeadline,
+	)
+
+	abortCtx, cancel := context.WithTimeout(ctx, abortTimeout)
+	defer cancel()
+
+	_, err := rt.Call(abortCtx, &protocol.Body{
+		RuntimeAbortRequest: &protocol.RuntimeAbortRequest{Partial: true},
+	})
+	if err != nil {
+		n.logger.Warn("failed to request partial batch from runtime",
+			"err", err,
+		)
+	}
+
+	select {
+	case res := <-resultCh:
+		return res.rsp, res.err
+	case <-n.clock.After(abortTimeout):
+	}
+
+	n.logger.Error("runtime did not stop executing the batch in time, aborting runtime")
+
+	abortCtx, cancel = context.WithTimeout(n.ctx, abortTimeout)
+	defer cancel()
+
+	if err = rt.Abort(abortCtx, false); err != nil {
+		n.logger.Error("failed to abort the runtime",
+			"err", err,
+		)
+	}
+	return nil, errRuntimeAborted
+}
+
 // Guarded by n.commonNode.CrossNode.
 func (n *Node) abortBatchLocked(reason error) {
 	state, ok := n.state.(StateProcessingBatch)
@@ -1160,6 +1421,7 @@ func (n *Node) proposeBatch(
 	}
 
 	// Commit I/O and state write logs to storage.
+	storageStart := time.Now()
 	storageErr := func() error {
 		start := time.Now()
 		defer storageCommitLatency.With(n.getMetricLabels()).Observe(time.Since(start).Seconds())
@@ -1196,6 +1458,7 @@ func (n *Node) proposeBatch(
 
 		return nil
 	}()
+	storageApplyTime := time.Since(storageStart)
 	if storageErr != nil {
 		n.logger.Error("storage failure, submitting failure indicating commitment",
 			"err", storageErr,
@@ -1233,6 +1496,14 @@ func (n *Node) proposeBatch(
 			batchStartTime: state.batchStartTime,
 			raw:            processed.raw,
 			proposedIORoot: *ec.Header.IORoot,
+			commitTime:     n.clock.Now(),
+			report: &api.ExecutionReport{
+				Round:            lastHeader.Round + 1,
+				BatchSize:        uint64(len(processed.raw)),
+				Messages:         uint64(len(batch.Messages)),
+				ExecutionTime:    processed.executionTime,
+				StorageApplyTime: storageApplyTime,
+			},
 		})
 	default:
 		n.abortBatchLocked(storageErr)
@@ -1241,37 +1512,12 @@ func (n *Node) proposeBatch(
 	crash.Here(crashPointBatchProposeAfter)
 }
 
-func (n *Node) signAndSubmitCommitment(roundCtx context.Context, ec *commitment.ExecutorCommitment) error {
-	err := ec.Sign(n.commonNode.Identity.NodeSigner, n.commonNode.Runtime.ID())
-	if err != nil {
-		n.logger.Error("failed to sign commitment",
-			"commit", ec,
-			"err", err,
-		)
-		return err
-	}
-
-	tx := roothash.NewExecutorCommitTx(0, nil, n.commonNode.Runtime.ID(), []commitment.ExecutorCommitment{*ec})
-	go func() {
-		commitErr := consensus.SignAndSubmitTx(roundCtx, n.commonNode.Consensus, n.commonNode.Identity.NodeSigner, tx)
-		switch commitErr {
-		case nil:
-			n.logger.Info("executor commit finalized")
-		default:
-			n.logger.Error("failed to submit executor commit",
-				"commit", ec,
-				"err", commitErr,
-			)
-		}
-	}()
-
-	return nil
-}
-
 // HandleNewEventLocked implements NodeHooks.
 // Guarded by n.commonNode.CrossNode.
 func (n *Node) HandleNewEventLocked(ev *roothash.Event) {
 	switch {
+	case ev.ExecutorCommitted != nil:
+		n.handleCommitmentEventLocked(ev.ExecutorCommitted)
 	case ev.ExecutionDiscrepancyDetected != nil:
 		n.logger.Warn("execution discrepancy detected")
 
@@ -1279,10 +1525,13 @@ func (n *Node) HandleNewEventLocked(ev *roothash.Event) {
 
 		discrepancyDetectedCount.With(n.getMetricLabels()).Inc()
 
+		n.roundDiscrepancy = true
+
+		epoch := n.commonNode.Group.GetEpochSnapshot()
+
 		// If the node is not a backup worker in this epoch, no need to do anything. Also if the
 		// node is an executor worker in this epoch, then it has already processed and submitted
 		// a commitment, so no need to do anything.
-		epoch := n.commonNode.Group.GetEpochSnapshot()
 		if !epoch.IsExecutorBackupWorker() || epoch.IsExecutorWorker() {
 			return
 		}
@@ -1310,6 +1559,36 @@ func (n *Node) HandleNewEventLocked(ev *roothash.Event) {
 	}
 }
 
+// Guarded by n.commonNode.CrossNode.
+func (n *Node) recordExecutionReportLocked(state *StateWaitingForFinalize, finalized bool) {
+	if n.reports == nil || state.report == nil {
+		return
+	}
+
+	now := n.clock.Now()
+	report := state.report
+	report.Timestamp = now
+	report.CommitLatency = now.Sub(state.commitTime)
+	report.Discrepancy = n.roundDiscrepancy
+	report.Finalized = finalized
+
+	if err := n.reports.append(report); err != nil {
+		n.logger.Error("failed to persist execution report",
+			"err", err,
+			"round", report.Round,
+		)
+	}
+}
+
+// GetExecutionReports returns up to limit most recent execution reports, newest first. A zero
+// limit returns all retained reports.
+func (n *Node) GetExecutionReports(limit uint64) ([]*api.ExecutionReport, error) {
+	if n.reports == nil {
+		return nil, api.ErrExecutionReportsDisabled
+	}
+	return n.reports.recent(limit)
+}
+
 // Guarded by n.commonNode.CrossNode.
 func (n *Node) handleExternalBatchLocked(batch *unresolvedBatch) error {
 	// If we are not waiting for a batch, don't do anything.
@@ -1520,6 +1799,7 @@ func NewNode(
 	commonNode *committee.Node,
 	commonCfg commonWorker.Config,
 	roleProvider registration.RoleProvider,
+	reportsStore *persistent.ServiceStore,
 ) (*Node, error) {
 	metricsOnce.Do(func() {
 		prometheus.MustRegister(nodeCollectors...)
@@ -1531,6 +1811,7 @@ func NewNode(
 		commonNode:       commonNode,
 		commonCfg:        commonCfg,
 		roleProvider:     roleProvider,
+		clock:            clock.System,
 		ctx:              ctx,
 		cancelCtx:        cancel,
 		stopCh:           make(chan struct{}),
@@ -1542,6 +1823,14 @@ func NewNode(
 		logger:           logging.GetLogger("worker/executor/committee").With("runtime_id", commonNode.Runtime.ID()),
 	}
 
+	if reportsStore != nil && commonCfg.ExecutorMaxExecutionReports > 0 {
+		var err error
+		if n.reports, err = newReportStore(reportsStore, commonNode.Runtime.ID(), commonCfg.ExecutorMaxExecutionReports); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+
 	// Register prune handler.
 	commonNode.Runtime.History().Pruner().RegisterHandler(&pruneHandler{commonNode: commonNode})
 
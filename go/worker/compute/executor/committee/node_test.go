@@ -0,0 +1,120 @@
+package committee
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/clock"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/mock"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+	commonWorker "github.com/oasisprotocol/oasis-core/go/worker/common"
+)
+
+func TestBatchDeadline(t *testing.T) {
+	require := require.New(t)
+
+	const deadline = 10 * time.Second
+
+	mockClock := clock.NewMock(time.Unix(1_600_000_000, 0))
+	n := &Node{
+		ctx:       context.Background(),
+		commonCfg: commonWorker.Config{ExecutorBatchDeadline: deadline},
+		clock:     mockClock,
+		logger:    logging.GetLogger("worker/executor/committee/test"),
+	}
+
+	p := mock.NewProgrammable()
+	rt, err := p.NewRuntime(context.Background(), host.Config{})
+	require.NoError(err, "NewRuntime")
+
+	ctx := context.Background()
+	inputs := transaction.RawBatch{[]byte("tx1"), []byte("tx2"), []byte("tx3"), []byte("tx4")}
+	rq := &protocol.Body{RuntimeExecuteTxBatchRequest: &protocol.RuntimeExecuteTxBatchRequest{
+		Inputs: inputs,
+	}}
+
+	// Batches executed before the deadline should be proposed in full.
+	rsp, err := n.executeTxBatch(ctx, rt, rq)
+	require.NoError(err, "executeTxBatch")
+	executed, err := batchCut(rsp, len(inputs))
+	require.NoError(err, "batchCut")
+	require.Equal(len(inputs), executed, "batch executed in time should not be cut")
+	require.Zero(p.Calls(mock.MethodAbort), "runtime should not be aborted")
+	// Fire the unused deadline timer.
+	mockClock.Advance(deadline)
+	require.Zero(mockClock.Waiters())
+
+	// Batches exceeding the deadline should only propose the executed prefix.
+	p.Program(mock.MethodExecuteTxBatch, mock.Behavior{Latency: time.Hour, Times: 1})
+	type result struct {
+		rsp *protocol.Body
+		err error
+	}
+	resultCh := make(chan *result, 1)
+	go func() {
+		crsp, cerr := n.executeTxBatch(ctx, rt, rq)
+		resultCh <- &result{crsp, cerr}
+	}()
+	mockClock.BlockUntil(1)
+	require.Eventually(func() bool { return p.Calls(mock.MethodExecuteTxBatch) == 2 }, time.Second, 10*time.Millisecond)
+	mockClock.Advance(deadline)
+
+	res := <-resultCh
+	require.NoError(res.err, "executeTxBatch")
+	require.True(res.rsp.RuntimeExecuteTxBatchResponse.Partial, "batch exceeding the deadline should be partial")
+	executed, err = batchCut(res.rsp, len(inputs))
+	require.NoError(err, "batchCut")
+	require.Equal(2, executed, "only the executed prefix should be proposed")
+
+	// Runtimes ignoring the partial abort request should be aborted after the abort timeout.
+	p.Program(mock.MethodExecuteTxBatch, mock.Behavior{Latency: time.Hour, Times: 1})
+	p.Program(mock.MethodAbortRequest, mock.Behavior{
+		Response: &protocol.Body{RuntimeAbortResponse: &protocol.Empty{}},
+		Times:    1,
+	})
+	go func() {
+		crsp, cerr := n.executeTxBatch(ctx, rt, rq)
+		resultCh <- &result{crsp, cerr}
+	}()
+	mockClock.BlockUntil(1)
+	require.Eventually(func() bool { return p.Calls(mock.MethodExecuteTxBatch) == 3 }, time.Second, 10*time.Millisecond)
+	mockClock.Advance(deadline)
+	mockClock.BlockUntil(1)
+	require.Equal(2, p.Calls(mock.MethodAbortRequest), "partial batch should be requested")
+	mockClock.Advance(abortTimeout)
+
+	res = <-resultCh
+	require.ErrorIs(res.err, errRuntimeAborted, "executeTxBatch should fail when the runtime does not respond")
+	require.Equal(1, p.Calls(mock.MethodAbort), "runtime should be aborted")
+}
+
+func TestBatchCut(t *testing.T) {
+	require := require.New(t)
+
+	partial := func(executed uint64) *protocol.Body {
+		return &protocol.Body{RuntimeExecuteTxBatchResponse: &protocol.RuntimeExecuteTxBatchResponse{
+			Partial:        true,
+			ExecutedInputs: executed,
+		}}
+	}
+
+	_, err := batchCut(&protocol.Body{}, 4)
+	require.Error(err, "batchCut should fail without an execute response")
+
+	_, err = batchCut(partial(5), 4)
+	require.Error(err, "batchCut should fail when more inputs than proposed were executed")
+
+	executed, err := batchCut(partial(0), 4)
+	require.NoError(err, "batchCut")
+	require.Equal(1, executed, "at least one transaction should always be proposed")
+
+	executed, err = batchCut(partial(3), 4)
+	require.NoError(err, "batchCut")
+	require.Equal(3, executed)
+}
@@ -92,6 +92,28 @@ func (h *committeeMsgHandler) HandleMessage(ctx context.Context, peerID signatur
 			return err
 		}
 		return nil
+	case cm.Commitment != nil:
+		// Ignore own messages as those are handled separately.
+		if isOwn {
+			return nil
+		}
+
+		ec := cm.Commitment
+
+		// Only commitments from committee members are accepted.
+		committee := h.n.commonNode.Group.GetEpochSnapshot().GetExecutorCommittee()
+		if committee == nil || !committee.PublicKeys[ec.NodeID] {
+			return p2pError.Permanent(errCommitFromNonMember)
+		}
+		if err := ec.Verify(h.n.commonNode.Runtime.ID()); err != nil {
+			return p2pError.Permanent(err)
+		}
+
+		h.n.commonNode.CrossNode.Lock()
+		defer h.n.commonNode.CrossNode.Unlock()
+
+		h.n.addCommitmentLocked(ec)
+		return nil
 	default:
 		return p2pError.ErrUnhandledMessage
 	}
@@ -0,0 +1,108 @@
+package committee
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
+)
+
+var (
+	reportsMetaKeySuffix  = []byte("meta")
+	reportsEntryKeySuffix = []byte("report")
+)
+
+type reportsMeta struct {
+	// Next is the sequence number of the next report.
+	Next uint64 `json:"next"`
+	// MaxReports is the number of report slots.
+	MaxReports uint64 `json:"max_reports"`
+}
+
+// reportStore is a persistent ring buffer of the most recent execution reports of a runtime.
+type reportStore struct {
+	sync.Mutex
+
+	store     *persistent.ServiceStore
+	runtimeID common.Namespace
+	meta      reportsMeta
+}
+
+func (s *reportStore) metaKey() []byte {
+	return append(append([]byte{}, s.runtimeID[:]...), reportsMetaKeySuffix...)
+}
+
+func (s *reportStore) entryKey(seq uint64) []byte {
+	var slot [8]byte
+	binary.BigEndian.PutUint64(slot[:], seq%s.meta.MaxReports)
+
+	key := append(append([]byte{}, s.runtimeID[:]...), reportsEntryKeySuffix...)
+	return append(key, slot[:]...)
+}
+
+// append stores a new report, replacing the oldest one when the store is full.
+func (s *reportStore) append(report *api.ExecutionReport) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if err := s.store.PutCBOR(s.entryKey(s.meta.Next), report); err != nil {
+		return fmt.Errorf("failed to store execution report: %w", err)
+	}
+	s.meta.Next++
+	if err := s.store.PutCBOR(s.metaKey(), &s.meta); err != nil {
+		return fmt.Errorf("failed to store execution report metadata: %w", err)
+	}
+	return nil
+}
+
+// recent returns up to limit most recent reports, newest first. A zero limit returns all
+// retained reports.
+func (s *reportStore) recent(limit uint64) ([]*api.ExecutionReport, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	n := s.meta.Next
+	if n > s.meta.MaxReports {
+		n = s.meta.MaxReports
+	}
+	if limit > 0 && n > limit {
+		n = limit
+	}
+
+	reports := make([]*api.ExecutionReport, 0, n)
+	for i := uint64(1); i <= n; i++ {
+		var report api.ExecutionReport
+		if err := s.store.GetCBOR(s.entryKey(s.meta.Next-i), &report); err != nil {
+			return nil, fmt.Errorf("failed to load execution report: %w", err)
+		}
+		reports = append(reports, &report)
+	}
+	return reports, nil
+}
+
+func newReportStore(store *persistent.ServiceStore, runtimeID common.Namespace, maxReports uint64) (*reportStore, error) {
+	s := &reportStore{
+		store:     store,
+		runtimeID: runtimeID,
+	}
+
+	switch err := store.GetCBOR(s.metaKey(), &s.meta); err {
+	case nil:
+	case persistent.ErrNotFound:
+	default:
+		return nil, fmt.Errorf("failed to load execution report metadata: %w", err)
+	}
+
+	// Changing the number of retained reports changes the slot layout, so start over. Existing
+	// reports are overwritten as new ones are stored.
+	if s.meta.MaxReports != maxReports {
+		s.meta = reportsMeta{
+			MaxReports: maxReports,
+		}
+	}
+
+	return s, nil
+}
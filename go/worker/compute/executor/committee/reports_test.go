@@ -0,0 +1,106 @@
+package committee
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
+)
+
+func TestReportStore(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	openStore := func() (*persistent.CommonStore, *persistent.ServiceStore) {
+		commonStore, err := persistent.NewCommonStore(dataDir)
+		require.NoError(err, "NewCommonStore")
+		store, err := commonStore.GetServiceStore("executor_reports_test")
+		require.NoError(err, "GetServiceStore")
+		return commonStore, store
+	}
+	commonStore, store := openStore()
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("execution report test runtime"), 0)
+	otherRuntimeID := common.NewTestNamespaceFromSeed([]byte("execution report test runtime 2"), 0)
+
+	// Persisted timestamps have second granularity.
+	now := time.Now().Truncate(time.Second)
+	newReport := func(round uint64) *api.ExecutionReport {
+		return &api.ExecutionReport{
+			Round:         round,
+			Timestamp:     now.Add(time.Duration(round) * time.Second),
+			BatchSize:     round * 10,
+			ExecutionTime: time.Duration(round) * time.Millisecond,
+			Finalized:     round%2 == 0,
+		}
+	}
+	requireRounds := func(s *reportStore, limit uint64, expected ...uint64) {
+		reports, err := s.recent(limit)
+		require.NoError(err, "recent")
+		var rounds []uint64
+		for _, report := range reports {
+			rounds = append(rounds, report.Round)
+		}
+		require.Equal(expected, rounds, "recent should return the most recent reports, newest first")
+	}
+
+	s, err := newReportStore(store, runtimeID, 3)
+	require.NoError(err, "newReportStore")
+	requireRounds(s, 0)
+
+	for round := uint64(1); round <= 2; round++ {
+		err = s.append(newReport(round))
+		require.NoError(err, "append")
+	}
+	requireRounds(s, 0, 2, 1)
+
+	// Appending to a full store should replace the oldest reports.
+	for round := uint64(3); round <= 5; round++ {
+		err = s.append(newReport(round))
+		require.NoError(err, "append")
+	}
+	requireRounds(s, 0, 5, 4, 3)
+	requireRounds(s, 2, 5, 4)
+	requireRounds(s, 10, 5, 4, 3)
+
+	reports, err := s.recent(1)
+	require.NoError(err, "recent")
+	require.Len(reports, 1, "recent")
+	require.True(newReport(5).Timestamp.Equal(reports[0].Timestamp), "Timestamp should round-trip")
+	// Ignore the timestamp location when comparing the rest of the report.
+	reports[0].Timestamp = newReport(5).Timestamp
+	require.Equal(newReport(5), reports[0], "report should round-trip")
+
+	// Reports of different runtimes should not interfere.
+	other, err := newReportStore(store, otherRuntimeID, 3)
+	require.NoError(err, "newReportStore")
+	requireRounds(other, 0)
+	err = other.append(newReport(42))
+	require.NoError(err, "append")
+	requireRounds(other, 0, 42)
+	requireRounds(s, 0, 5, 4, 3)
+
+	// Reports should be persisted across restarts.
+	commonStore.Close()
+	commonStore, store = openStore()
+	defer commonStore.Close()
+
+	s, err = newReportStore(store, runtimeID, 3)
+	require.NoError(err, "newReportStore after restart")
+	requireRounds(s, 0, 5, 4, 3)
+	err = s.append(newReport(6))
+	require.NoError(err, "append after restart")
+	requireRounds(s, 0, 6, 5, 4)
+
+	// Changing the number of retained reports should start over.
+	s, err = newReportStore(store, runtimeID, 5)
+	require.NoError(err, "newReportStore with a different limit")
+	requireRounds(s, 0)
+	err = s.append(newReport(7))
+	require.NoError(err, "append")
+	requireRounds(s, 0, 7)
+}
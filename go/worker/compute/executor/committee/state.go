@@ -8,6 +8,7 @@ import (
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+	"github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
 )
 
 // StateName is a symbolic state without the attached values.
@@ -201,8 +202,9 @@ type StateProcessingBatch struct {
 }
 
 type processedBatch struct {
-	computed *protocol.ComputedBatch
-	raw      transaction.RawBatch
+	computed      *protocol.ComputedBatch
+	raw           transaction.RawBatch
+	executionTime time.Duration
 }
 
 // Name returns the name of the state.
@@ -227,6 +229,11 @@ type StateWaitingForFinalize struct {
 	batchStartTime time.Time
 	raw            transaction.RawBatch
 	proposedIORoot hash.Hash
+
+	// Time at which the executor commitment has been submitted.
+	commitTime time.Time
+	// Execution report for the round, nil if the batch has been aborted.
+	report *api.ExecutionReport
 }
 
 // Name returns the name of the state.
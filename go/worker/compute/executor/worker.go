@@ -7,6 +7,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
 	workerCommon "github.com/oasisprotocol/oasis-core/go/worker/common"
 	committeeCommon "github.com/oasisprotocol/oasis-core/go/worker/common/committee"
@@ -14,12 +15,15 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
 )
 
+const reportsServiceStoreName = "executor_reports"
+
 // Worker is an executor worker handling many runtimes.
 type Worker struct {
 	enabled bool
 
 	commonWorker *workerCommon.Worker
 	registration *registration.Worker
+	reportsStore *persistent.ServiceStore
 
 	runtimes map[common.Namespace]*committee.Node
 
@@ -150,12 +154,15 @@ func (w *Worker) registerRuntime(commonNode *committeeCommon.Node) error {
 		commonNode,
 		w.commonWorker.GetConfig(),
 		rp,
+		w.reportsStore,
 	)
 	if err != nil {
 		return err
 	}
 
 	commonNode.AddHooks(node)
+	commonNode.AddTransitionHook(committeeCommon.TransitionEpoch, "executor", 0, node.HandleTransitionLocked)
+	commonNode.AddTransitionHook(committeeCommon.TransitionSuspend, "executor", 0, node.HandleTransitionLocked)
 	w.runtimes[id] = node
 
 	w.logger.Info("new runtime registered",
@@ -169,6 +176,7 @@ func (w *Worker) registerRuntime(commonNode *committeeCommon.Node) error {
 func New(
 	commonWorker *workerCommon.Worker,
 	registration *registration.Worker,
+	store *persistent.CommonStore,
 ) (*Worker, error) {
 	ctx, cancelCtx := context.WithCancel(context.Background())
 
@@ -197,6 +205,13 @@ func New(
 		return w, nil
 	}
 
+	if store != nil {
+		var err error
+		if w.reportsStore, err = store.GetServiceStore(reportsServiceStoreName); err != nil {
+			return nil, fmt.Errorf("failed to open execution reports store: %w", err)
+		}
+	}
+
 	// Register all configured runtimes.
 	for _, rt := range commonWorker.GetRuntimes() {
 		if err := w.registerRuntime(rt); err != nil {
@@ -0,0 +1,207 @@
+package keymanager
+
+import (
+	"context"
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	"github.com/oasisprotocol/oasis-core/go/runtime/localstorage"
+)
+
+// churpSharesKey is the local storage key under which the bivariate shares are stored.
+var churpSharesKey = []byte("worker.keymanager.churp.shares")
+
+// churpShare is a bivariate share prepared by the key manager enclave for a handoff.
+type churpShare struct {
+	// Epoch is the epoch of the handoff.
+	Epoch beacon.EpochTime `json:"epoch"`
+
+	// Checksum is the checksum of the verification matrix of the share.
+	Checksum hash.Hash `json:"checksum"`
+
+	// Share is the share, sealed by the key manager enclave.
+	Share []byte `json:"share"`
+}
+
+// churpShares are the locally stored bivariate shares.
+type churpShares struct {
+	// Current is the share handed off in the last completed handoff (if any).
+	Current *churpShare `json:"current,omitempty"`
+
+	// Next is the share prepared for the next handoff (if any).
+	Next *churpShare `json:"next,omitempty"`
+}
+
+// churpShareStore is the bivariate share storage backed by the key manager's local storage,
+// which is also accessible to the key manager enclave.
+type churpShareStore struct {
+	storage localstorage.LocalStorage
+}
+
+func (s *churpShareStore) load() (*churpShares, error) {
+	raw, err := s.storage.Get(churpSharesKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var shares churpShares
+	if len(raw) == 0 {
+		return &shares, nil
+	}
+	if err = cbor.Unmarshal(raw, &shares); err != nil {
+		return nil, fmt.Errorf("malformed CHURP shares: %w", err)
+	}
+	return &shares, nil
+}
+
+func (s *churpShareStore) store(shares *churpShares) error {
+	return s.storage.Set(churpSharesKey, cbor.Marshal(shares))
+}
+
+// handleChurpStatus processes a status update of a CHURP key manager.
+//
+// Once a handoff completes, the share prepared for it becomes the current share. When the node
+// is an active key manager node and has not yet applied for the next handoff, it requests the
+// enclave to prepare a new share and submits an application.
+func (w *Worker) handleChurpStatus(status *api.Status) error {
+	w.churpLock.Lock()
+	defer w.churpLock.Unlock()
+
+	if status.Scheme() != api.SchemeCHURP || status.Churp == nil {
+		return nil
+	}
+
+	shares, err := w.churpShares.load()
+	if err != nil {
+		return fmt.Errorf("failed to load CHURP shares: %w", err)
+	}
+
+	nodeID := w.commonWorker.Identity.NodeSigner.Public()
+	if next := shares.Next; next != nil && next.Epoch <= status.Churp.Handoff {
+		// The handoff for which the share was prepared has ended.
+		if next.Epoch == status.Churp.Handoff && status.Churp.IsMember(nodeID) && next.Checksum.Equal(status.Churp.Checksum) {
+			w.logger.Info("CHURP handoff completed",
+				"handoff", next.Epoch,
+				"checksum", next.Checksum,
+			)
+			shares.Current = next
+		}
+		shares.Next = nil
+
+		if err = w.churpShares.store(shares); err != nil {
+			return fmt.Errorf("failed to store CHURP shares: %w", err)
+		}
+	}
+
+	// Only active key manager nodes may apply for the next handoff.
+	var isActive bool
+	for _, id := range status.Nodes {
+		if id.Equal(nodeID) {
+			isActive = true
+			break
+		}
+	}
+	if !isActive {
+		return nil
+	}
+	if _, ok := status.Churp.Applications[nodeID]; ok {
+		return nil
+	}
+
+	epoch := status.Churp.NextHandoff
+	if shares.Next == nil || shares.Next.Epoch != epoch {
+		if shares.Next, err = w.prepareChurpShare(status, epoch); err != nil {
+			return fmt.Errorf("failed to prepare CHURP share: %w", err)
+		}
+		if err = w.churpShares.store(shares); err != nil {
+			return fmt.Errorf("failed to store CHURP shares: %w", err)
+		}
+	}
+
+	w.logger.Info("applying for CHURP handoff",
+		"handoff", epoch,
+		"checksum", shares.Next.Checksum,
+	)
+
+	tx := api.NewApplyChurpTx(0, nil, &api.ChurpApplication{
+		ID:       status.ID,
+		Epoch:    epoch,
+		Checksum: shares.Next.Checksum,
+	})
+	if err = consensus.SignAndSubmitTx(w.ctx, w.commonWorker.Consensus, w.commonWorker.Identity.NodeSigner, tx); err != nil {
+		return fmt.Errorf("failed to submit CHURP application: %w", err)
+	}
+	return nil
+}
+
+// prepareChurpShare requests the key manager enclave to prepare a bivariate share for the given
+// handoff.
+func (w *Worker) prepareChurpShare(status *api.Status, epoch beacon.EpochTime) (*churpShare, error) {
+	type PrepareRequest struct {
+		Epoch     beacon.EpochTime `json:"epoch"`
+		Threshold uint8            `json:"threshold"`
+		Handoff   beacon.EpochTime `json:"handoff"`
+		Checksum  *hash.Hash       `json:"checksum,omitempty"`
+	}
+	type PrepareCall struct { // nolint: maligned
+		Method string         `json:"method"`
+		Args   PrepareRequest `json:"args"`
+	}
+	type PrepareResponse struct {
+		Checksum hash.Hash `json:"checksum"`
+		Share    []byte    `json:"share"`
+	}
+
+	call := PrepareCall{
+		Method: "churp_prepare",
+		Args: PrepareRequest{
+			Epoch:     epoch,
+			Threshold: status.Policy.Policy.Churp.Threshold,
+			Handoff:   status.Churp.Handoff,
+			Checksum:  status.Churp.Checksum,
+		},
+	}
+	req := &protocol.Body{
+		RuntimeLocalRPCCallRequest: &protocol.RuntimeLocalRPCCallRequest{
+			Request: cbor.Marshal(&call),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(w.ctx, rpcCallTimeout)
+	defer cancel()
+
+	rt := w.GetHostedRuntime()
+	if rt == nil {
+		return nil, fmt.Errorf("key manager runtime is not yet provisioned")
+	}
+	response, err := rt.Call(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := response.RuntimeLocalRPCCallResponse
+	if resp == nil {
+		return nil, errMalformedResponse
+	}
+
+	innerResp, err := extractMessageResponsePayload(resp.Response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract rpc response payload: %w", err)
+	}
+
+	var prepareResp PrepareResponse
+	if err = cbor.Unmarshal(innerResp, &prepareResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &churpShare{
+		Epoch:    epoch,
+		Checksum: prepareResp.Checksum,
+		Share:    prepareResp.Share,
+	}, nil
+}
@@ -75,6 +75,16 @@ func (h *hostHandler) Handle(ctx context.Context, body *protocol.Body) (*protoco
 		}
 		return &protocol.Body{HostLocalStorageSetResponse: &protocol.Empty{}}, nil
 	}
+	if body.HostLocalStorageBatchSetRequest != nil {
+		entries := make([]localstorage.Entry, 0, len(body.HostLocalStorageBatchSetRequest.Entries))
+		for _, e := range body.HostLocalStorageBatchSetRequest.Entries {
+			entries = append(entries, localstorage.Entry{Key: e.Key, Value: e.Value})
+		}
+		if err := h.localStorage.BatchSet(entries); err != nil {
+			return nil, err
+		}
+		return &protocol.Body{HostLocalStorageBatchSetResponse: &protocol.Empty{}}, nil
+	}
 	// RPC.
 	if body.HostRPCCallRequest != nil {
 		switch body.HostRPCCallRequest.Endpoint {
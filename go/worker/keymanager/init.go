@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/clock"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc/policy"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
@@ -52,6 +53,7 @@ func New(
 
 	w := &Worker{
 		logger:       logging.GetLogger("worker/keymanager"),
+		clock:        clock.System,
 		ctx:          ctx,
 		cancelCtx:    cancelFn,
 		stopCh:       make(chan struct{}),
@@ -95,6 +97,7 @@ func New(
 	}
 
 	w.runtimeHostHandler = newHostHandler(w, commonWorker, localStorage)
+	w.churpShares = &churpShareStore{storage: localStorage}
 
 	// Prepare the runtime host node helpers.
 	w.RuntimeHostNode, err = runtimeRegistry.NewRuntimeHostNode(w)
@@ -1,7 +1,12 @@
 package keymanager
 
 import (
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/accessctl"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/api"
 	enclaverpc "github.com/oasisprotocol/oasis-core/go/runtime/enclaverpc/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/committee"
 )
@@ -28,3 +33,38 @@ var (
 		},
 	}
 )
+
+// mayQueryConstraints checks whether the given runtime may query the key manager according to
+// the key manager policy. In case it may, it also returns the CBOR-serialized SGX constraints that
+// the runtime's nodes must satisfy, or nil in case no constraints should be enforced.
+func mayQueryConstraints(status *api.Status, runtimeID common.Namespace) (bool, []byte) {
+	switch {
+	case !status.IsSecure && status.Policy == nil:
+		// Insecure test keymanagers can be without a policy.
+		return true, nil
+	case status.Policy == nil:
+		return false, nil
+	}
+
+	var (
+		allowed  bool
+		enclaves []sgx.EnclaveIdentity
+	)
+	for _, enc := range status.Policy.Policy.Enclaves {
+		ids, ok := enc.MayQuery[runtimeID]
+		if !ok {
+			continue
+		}
+		allowed = true
+		enclaves = append(enclaves, ids...)
+	}
+	if !allowed {
+		return false, nil
+	}
+	if !status.IsSecure {
+		// Insecure key managers cannot rely on node attestations.
+		return true, nil
+	}
+
+	return true, cbor.Marshal(&node.SGXConstraints{Enclaves: enclaves})
+}
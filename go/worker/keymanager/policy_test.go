@@ -0,0 +1,82 @@
+package keymanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/api"
+)
+
+func newTestEnclaveIdentity(id byte) sgx.EnclaveIdentity {
+	var eid sgx.EnclaveIdentity
+	eid.MrEnclave[0] = id
+	eid.MrSigner[0] = id
+	return eid
+}
+
+func newTestPolicyStatus(isSecure bool, mayQuery map[byte]map[common.Namespace][]sgx.EnclaveIdentity) *api.Status {
+	enclaves := make(map[sgx.EnclaveIdentity]*api.EnclavePolicySGX)
+	for id, mq := range mayQuery {
+		enclaves[newTestEnclaveIdentity(id)] = &api.EnclavePolicySGX{MayQuery: mq}
+	}
+	return &api.Status{
+		IsSecure: isSecure,
+		Policy: &api.SignedPolicySGX{
+			Policy: api.PolicySGX{Enclaves: enclaves},
+		},
+	}
+}
+
+func TestMayQueryConstraints(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID, otherRuntimeID common.Namespace
+	runtimeID[0] = 1
+	otherRuntimeID[0] = 2
+
+	// Insecure key managers without a policy allow everyone.
+	allowed, constraints := mayQueryConstraints(&api.Status{IsSecure: false}, runtimeID)
+	require.True(allowed, "insecure key managers without a policy should allow queries")
+	require.Nil(constraints, "insecure key managers should not enforce constraints")
+
+	// Secure key managers without a policy allow no one.
+	allowed, _ = mayQueryConstraints(&api.Status{IsSecure: true}, runtimeID)
+	require.False(allowed, "secure key managers without a policy should not allow queries")
+
+	mayQuery := map[byte]map[common.Namespace][]sgx.EnclaveIdentity{
+		1: {runtimeID: {newTestEnclaveIdentity(10)}},
+		2: {runtimeID: {newTestEnclaveIdentity(20), newTestEnclaveIdentity(21)}},
+		3: {otherRuntimeID: {newTestEnclaveIdentity(30)}},
+	}
+
+	// Runtimes not in the policy are not allowed.
+	var unknownRuntimeID common.Namespace
+	unknownRuntimeID[0] = 3
+	for _, isSecure := range []bool{false, true} {
+		allowed, _ = mayQueryConstraints(newTestPolicyStatus(isSecure, mayQuery), unknownRuntimeID)
+		require.False(allowed, "runtimes not in the policy should not be allowed (secure: %t)", isSecure)
+	}
+
+	// Insecure key managers cannot rely on node attestations.
+	allowed, constraints = mayQueryConstraints(newTestPolicyStatus(false, mayQuery), runtimeID)
+	require.True(allowed, "runtimes in the policy should be allowed")
+	require.Nil(constraints, "insecure key managers should not enforce constraints")
+
+	// Secure key managers require the enclaves of all policy entries for the runtime.
+	allowed, constraints = mayQueryConstraints(newTestPolicyStatus(true, mayQuery), runtimeID)
+	require.True(allowed, "runtimes in the policy should be allowed")
+	require.NotNil(constraints, "secure key managers should enforce constraints")
+
+	var sc node.SGXConstraints
+	require.NoError(cbor.Unmarshal(constraints, &sc), "constraints should be valid SGX constraints")
+	require.ElementsMatch([]sgx.EnclaveIdentity{
+		newTestEnclaveIdentity(10),
+		newTestEnclaveIdentity(20),
+		newTestEnclaveIdentity(21),
+	}, sc.Enclaves, "constraints should only include enclaves that may query the key manager")
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/accessctl"
 	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/clock"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc/policy"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
@@ -52,6 +53,9 @@ type Worker struct { // nolint: maligned
 
 	logger *logging.Logger
 
+	// clock is the time source used for verifying client runtime node attestations.
+	clock clock.Clock
+
 	ctx       context.Context
 	cancelCtx context.CancelFunc
 	stopCh    chan struct{}
@@ -73,6 +77,9 @@ type Worker struct { // nolint: maligned
 
 	grpcPolicy *policy.DynamicRuntimePolicyChecker
 
+	churpLock   sync.Mutex
+	churpShares *churpShareStore
+
 	enabled     bool
 	mayGenerate bool
 }
@@ -354,30 +361,27 @@ func (w *Worker) startClientRuntimeWatcher(rt *registry.Runtime, status *api.Sta
 	if rt.Kind != registry.KindCompute || rt.KeyManager == nil || !rt.KeyManager.Equal(&runtimeID) {
 		return nil
 	}
-	if w.clientRuntimes[rt.ID] != nil {
-		return nil
-	}
-
-	w.logger.Info("seen new runtime using us as a key manager",
-		"runtime_id", rt.ID,
-	)
 
 	// Check policy document if runtime is allowed to query any of the
 	// key manager enclaves.
-	var found bool
-	switch {
-	case !status.IsSecure && status.Policy == nil:
-		// Insecure test keymanagers can be without a policy.
-		found = true
-	case status.Policy != nil:
-		for _, enc := range status.Policy.Policy.Enclaves {
-			if _, ok := enc.MayQuery[rt.ID]; ok {
-				found = true
-				break
-			}
+	allowed, constraints := mayQueryConstraints(status, rt.ID)
+
+	if crw := w.clientRuntimes[rt.ID]; crw != nil {
+		if !allowed {
+			// The runtime is no longer allowed to query the key manager, revoke access.
+			w.logger.Warn("runtime no longer in keymanager policy, revoking access",
+				"runtime_id", rt.ID,
+			)
+			crw.stop()
+			delete(w.clientRuntimes, rt.ID)
+			return nil
 		}
+
+		crw.setConstraints(constraints)
+		return nil
 	}
-	if !found {
+
+	if !allowed {
 		w.logger.Warn("runtime not found in keymanager policy, skipping",
 			"runtime_id", rt.ID,
 			"status", status,
@@ -385,8 +389,14 @@ func (w *Worker) startClientRuntimeWatcher(rt *registry.Runtime, status *api.Sta
 		return nil
 	}
 
-	nodes, err := nodes.NewVersionedNodeDescriptorWatcher(w.ctx, w.commonWorker.Consensus)
+	w.logger.Info("seen new runtime using us as a key manager",
+		"runtime_id", rt.ID,
+	)
+
+	ctx, cancel := context.WithCancel(w.ctx)
+	nodes, err := nodes.NewVersionedNodeDescriptorWatcher(ctx, w.commonWorker.Consensus)
 	if err != nil {
+		cancel()
 		w.logger.Error("unable to create new client runtime node watcher",
 			"err", err,
 			"runtime_id", rt.ID,
@@ -394,9 +404,12 @@ func (w *Worker) startClientRuntimeWatcher(rt *registry.Runtime, status *api.Sta
 		return err
 	}
 	crw := &clientRuntimeWatcher{
-		w:         w,
-		runtimeID: rt.ID,
-		nodes:     nodes,
+		w:           w,
+		ctx:         ctx,
+		cancelCtx:   cancel,
+		runtimeID:   rt.ID,
+		nodes:       nodes,
+		constraints: constraints,
 	}
 	go crw.worker()
 
@@ -589,6 +602,14 @@ func (w *Worker) worker() { // nolint: gocyclo
 				)
 				continue
 			}
+			// Take part in CHURP handoffs.
+			go func(status *api.Status) {
+				if err := w.handleChurpStatus(status); err != nil {
+					w.logger.Error("failed to handle CHURP status",
+						"err", err,
+					)
+				}
+			}(currentStatus)
 		case <-w.initTickerCh:
 			if currentStatus == nil || currentStartedEvent == nil {
 				continue
@@ -623,9 +644,19 @@ func (w *Worker) worker() { // nolint: gocyclo
 }
 
 type clientRuntimeWatcher struct {
+	sync.Mutex
+
 	w         *Worker
+	ctx       context.Context
+	cancelCtx context.CancelFunc
 	runtimeID common.Namespace
 	nodes     nodes.VersionedNodeDescriptorWatcher
+
+	// constraints are the SGX constraints derived from the key manager policy that the client
+	// runtime nodes must satisfy in order to be granted access. In case they are nil, no
+	// constraints are enforced (e.g., for insecure key managers).
+	constraints []byte
+	stopped     bool
 }
 
 func (crw *clientRuntimeWatcher) worker() {
@@ -641,7 +672,7 @@ func (crw *clientRuntimeWatcher) worker() {
 
 	for {
 		select {
-		case <-crw.w.ctx.Done():
+		case <-crw.ctx.Done():
 			return
 		case <-ch:
 			crw.updateExternalServicePolicy()
@@ -652,7 +683,7 @@ func (crw *clientRuntimeWatcher) worker() {
 func (crw *clientRuntimeWatcher) epochTransition() {
 	crw.nodes.Reset()
 
-	cms, err := crw.w.commonWorker.Consensus.Scheduler().GetCommittees(crw.w.ctx, &scheduler.GetCommitteesRequest{
+	cms, err := crw.w.commonWorker.Consensus.Scheduler().GetCommittees(crw.ctx, &scheduler.GetCommitteesRequest{
 		Height:    consensus.HeightLatest,
 		RuntimeID: crw.runtimeID,
 	})
@@ -670,7 +701,7 @@ func (crw *clientRuntimeWatcher) epochTransition() {
 		}
 
 		for _, member := range cm.Members {
-			_, _ = crw.nodes.WatchNode(crw.w.ctx, member.PublicKey)
+			_, _ = crw.nodes.WatchNode(crw.ctx, member.PublicKey)
 		}
 	}
 
@@ -679,12 +710,67 @@ func (crw *clientRuntimeWatcher) epochTransition() {
 	crw.updateExternalServicePolicy()
 }
 
+// setConstraints updates the constraints that client runtime nodes must satisfy in order to be
+// granted access and refreshes the access policy.
+func (crw *clientRuntimeWatcher) setConstraints(constraints []byte) {
+	crw.Lock()
+	crw.constraints = constraints
+	crw.Unlock()
+
+	crw.updateExternalServicePolicy()
+}
+
+// stop stops the watcher and revokes access for all client runtime nodes.
+func (crw *clientRuntimeWatcher) stop() {
+	crw.Lock()
+	defer crw.Unlock()
+
+	crw.stopped = true
+	crw.cancelCtx()
+
+	crw.w.grpcPolicy.SetAccessPolicy(accessctl.NewPolicy(), crw.runtimeID)
+}
+
+// isNodeAllowedLocked checks whether the given client runtime node satisfies the constraints
+// derived from the key manager policy.
+func (crw *clientRuntimeWatcher) isNodeAllowedLocked(n *node.Node) bool {
+	if crw.constraints == nil {
+		return true
+	}
+
+	rt := n.GetRuntime(crw.runtimeID)
+	if rt == nil || rt.Capabilities.TEE == nil {
+		return false
+	}
+	return rt.Capabilities.TEE.Verify(crw.w.clock.Now(), crw.constraints) == nil
+}
+
 func (crw *clientRuntimeWatcher) updateExternalServicePolicy() {
+	crw.Lock()
+	defer crw.Unlock()
+
+	if crw.stopped {
+		return
+	}
+
+	// Only allow nodes running enclaves that may query the key manager according to its policy.
+	var allowedNodes []*node.Node
+	for _, n := range crw.nodes.GetNodes() {
+		if !crw.isNodeAllowedLocked(n) {
+			crw.w.logger.Warn("client runtime node not allowed by keymanager policy",
+				"node_id", n.ID,
+				"runtime_id", crw.runtimeID,
+			)
+			continue
+		}
+		allowedNodes = append(allowedNodes, n)
+	}
+
 	// Update key manager access control policy.
 	policy := accessctl.NewPolicy()
 
 	// Apply rules to current executor committee members.
-	executorCommitteePolicy.AddRulesForNodes(&policy, crw.nodes.GetNodes())
+	executorCommitteePolicy.AddRulesForNodes(&policy, allowedNodes)
 
 	// Apply rules for configured sentry nodes.
 	for _, addr := range crw.w.commonWorker.GetConfig().SentryAddresses {
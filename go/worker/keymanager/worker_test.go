@@ -0,0 +1,217 @@
+package keymanager
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/accessctl"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/clock"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/grpc/policy"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
+	enclaverpc "github.com/oasisprotocol/oasis-core/go/runtime/enclaverpc/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/nodes"
+	workerCommon "github.com/oasisprotocol/oasis-core/go/worker/common"
+)
+
+var (
+	// testAVRFreshTime is a time at which the test AVR signing certificate is valid.
+	testAVRFreshTime = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	// testAVRStaleTime is a time at which the test AVR signing certificate has expired.
+	testAVRStaleTime = time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type testNodeWatcher struct {
+	nodes.VersionedNodeDescriptorWatcher
+
+	nodes []*node.Node
+}
+
+func (nw *testNodeWatcher) GetNodes() []*node.Node {
+	return nw.nodes
+}
+
+type testPolicyWatcher struct {
+	policies map[common.Namespace]accessctl.Policy
+}
+
+func (pw *testPolicyWatcher) PolicyUpdated(service grpc.ServiceName, accessPolicies map[common.Namespace]accessctl.Policy) {
+	pw.policies = accessPolicies
+}
+
+// loadTestAVRBundle loads the IAS test vector, signed by a certificate that is valid from
+// 2016-11-22 until 2026-11-20.
+func loadTestAVRBundle(t *testing.T) (*ias.AVRBundle, sgx.EnclaveIdentity) {
+	require := require.New(t)
+
+	var (
+		bundle ias.AVRBundle
+		err    error
+	)
+	bundle.Body, err = ioutil.ReadFile("../../common/sgx/ias/testdata/avr_v4_body_sw_hardening_needed.json")
+	require.NoError(err, "Read test vector")
+	bundle.Signature, err = ioutil.ReadFile("../../common/sgx/ias/testdata/avr_v4_body_sw_hardening_needed.sig")
+	require.NoError(err, "Read signature")
+	bundle.CertificateChain, err = ioutil.ReadFile("../../common/sgx/ias/testdata/avr_certificates_urlencoded.pem")
+	require.NoError(err, "Read certificate chain")
+
+	avr, err := bundle.Open(ias.IntelTrustRoots, testAVRFreshTime)
+	require.NoError(err, "Open")
+	q, err := avr.Quote()
+	require.NoError(err, "Quote")
+
+	return &bundle, sgx.EnclaveIdentity{
+		MrEnclave: q.Report.MRENCLAVE,
+		MrSigner:  q.Report.MRSIGNER,
+	}
+}
+
+func newTestClientNode(t *testing.T, runtimeID common.Namespace, tee *node.CapabilityTEE) *node.Node {
+	signer, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(t, err, "NewSigner")
+
+	n := &node.Node{
+		ID:  signer.Public(),
+		TLS: node.TLSInfo{PubKey: signer.Public()},
+	}
+	if tee != nil {
+		n.Runtimes = []*node.Runtime{
+			{
+				ID:           runtimeID,
+				Capabilities: node.Capabilities{TEE: tee},
+			},
+		}
+	}
+	return n
+}
+
+func TestClientRuntimeWatcherPolicy(t *testing.T) {
+	// The test vector is for a debug enclave.
+	ias.SetAllowDebugEnclaves()
+	defer ias.UnsetAllowDebugEnclaves()
+
+	var runtimeID common.Namespace
+	runtimeID[0] = 1
+
+	bundle, eid := loadTestAVRBundle(t)
+	rak, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(t, err, "NewSigner")
+	sgxTEE := &node.CapabilityTEE{
+		Hardware:    node.TEEHardwareIntelSGX,
+		RAK:         rak.Public(),
+		Attestation: cbor.Marshal(bundle),
+	}
+
+	noRuntimeNode := newTestClientNode(t, runtimeID, nil)
+	noTEENode := newTestClientNode(t, runtimeID, nil)
+	noTEENode.Runtimes = []*node.Runtime{{ID: runtimeID}}
+	invalidHardwareNode := newTestClientNode(t, runtimeID, &node.CapabilityTEE{
+		Hardware:    node.TEEHardwareInvalid,
+		Attestation: sgxTEE.Attestation,
+	})
+	malformedAttestationNode := newTestClientNode(t, runtimeID, &node.CapabilityTEE{
+		Hardware:    node.TEEHardwareIntelSGX,
+		Attestation: []byte("not an attestation"),
+	})
+	sgxNode := newTestClientNode(t, runtimeID, sgxTEE)
+
+	allNodes := []*node.Node{noRuntimeNode, noTEENode, invalidHardwareNode, malformedAttestationNode, sgxNode}
+	action := accessctl.Action(enclaverpc.MethodCallEnclave.FullName())
+
+	for _, tc := range []struct {
+		name        string
+		now         time.Time
+		constraints []byte
+		allowed     []*node.Node
+		sgxErr      error
+	}{
+		{
+			name:    "NoConstraints",
+			now:     testAVRFreshTime,
+			allowed: allNodes,
+		},
+		{
+			name:        "BadEnclaveIdentity",
+			now:         testAVRFreshTime,
+			constraints: cbor.Marshal(&node.SGXConstraints{Enclaves: []sgx.EnclaveIdentity{newTestEnclaveIdentity(1)}}),
+			sgxErr:      node.ErrBadEnclaveIdentity,
+		},
+		{
+			// The attestation is for an allowed enclave, but does not bind the node's RAK.
+			name:        "RAKHashMismatch",
+			now:         testAVRFreshTime,
+			constraints: cbor.Marshal(&node.SGXConstraints{Enclaves: []sgx.EnclaveIdentity{eid}}),
+			sgxErr:      node.ErrRAKHashMismatch,
+		},
+		{
+			// The attestation is for an allowed enclave, but its signing certificate has expired.
+			name:        "Stale",
+			now:         testAVRStaleTime,
+			constraints: cbor.Marshal(&node.SGXConstraints{Enclaves: []sgx.EnclaveIdentity{eid}}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			if tc.constraints != nil {
+				// Make sure the SGX node is rejected for the expected reason.
+				err := sgxTEE.Verify(tc.now, tc.constraints)
+				require.Error(err, "Verify")
+				if tc.sgxErr != nil {
+					require.ErrorIs(err, tc.sgxErr, "Verify")
+				}
+			}
+
+			pw := &testPolicyWatcher{}
+			w := &Worker{
+				logger:       logging.GetLogger("worker/keymanager/test"),
+				clock:        clock.NewMock(tc.now),
+				commonWorker: &workerCommon.Worker{},
+				grpcPolicy:   policy.NewDynamicRuntimePolicyChecker(enclaverpc.ServiceName, pw),
+			}
+			crw := &clientRuntimeWatcher{
+				w:           w,
+				runtimeID:   runtimeID,
+				nodes:       &testNodeWatcher{nodes: allNodes},
+				constraints: tc.constraints,
+			}
+			crw.updateExternalServicePolicy()
+
+			policy, ok := pw.policies[runtimeID]
+			require.True(ok, "access policy should be set for the client runtime")
+			allowed := make(map[signature.PublicKey]bool)
+			for _, n := range tc.allowed {
+				allowed[n.ID] = true
+			}
+			for _, n := range allNodes {
+				require.Equal(
+					allowed[n.ID],
+					policy.IsAllowed(accessctl.SubjectFromPublicKey(n.TLS.PubKey), action),
+					"only nodes satisfying the constraints should be allowed",
+				)
+			}
+
+			// Stopped watchers should revoke access for all nodes.
+			crw.cancelCtx = func() {}
+			crw.stop()
+			crw.updateExternalServicePolicy()
+			for _, n := range allNodes {
+				require.False(
+					pw.policies[runtimeID].IsAllowed(accessctl.SubjectFromPublicKey(n.TLS.PubKey), action),
+					"stopped watchers should revoke access",
+				)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/accessctl"
 	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/clock"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
@@ -170,6 +171,9 @@ type Worker struct { // nolint: maligned
 	p2p             *p2p.P2P
 	ctx             context.Context
 
+	// clock is the time source used for registration retries and status timestamps.
+	clock clock.Clock
+
 	// Bandaid: Idempotent Stop for testing.
 	stopped      uint32
 	stopCh       chan struct{} // closed internally to trigger stop
@@ -255,7 +259,7 @@ func (w *Worker) registrationLoop() { // nolint: gocyclo
 
 		switch retry {
 		case true:
-			off = cmnBackoff.NewExponentialBackOff()
+			off = cmnBackoff.NewExponentialBackOffWithClock(w.clock)
 		case false:
 			off = &backoff.StopBackOff{}
 		}
@@ -267,7 +271,7 @@ func (w *Worker) registrationLoop() { // nolint: gocyclo
 		// w.ctx being canceled will break out of the loop correctly
 		// but it's entirely possible to sit around in an infinite
 		// retry loop with no hope of success.
-		return backoff.Retry(func() error {
+		return cmnBackoff.Retry(w.clock, func() error {
 			// Update the epoch if it happens to change while retrying.
 			var ok bool
 			select {
@@ -295,6 +299,16 @@ func (w *Worker) registrationLoop() { // nolint: gocyclo
 	entityCh, entitySub, _ := w.registry.WatchEntities(w.ctx)
 	defer entitySub.Close()
 
+	// (re-)register the node early in case its registration is about to expire.
+	regEvCh, regEvSub, err := w.registry.WatchEvents(w.ctx)
+	if err != nil {
+		w.logger.Error("failed to watch registry events",
+			"err", err,
+		)
+		return
+	}
+	defer regEvSub.Close()
+
 	var (
 		epoch                beacon.EpochTime
 		lastTLSRotationEpoch beacon.EpochTime
@@ -344,6 +358,14 @@ Loop:
 			if !ev.IsRegistration || !ev.Entity.ID.Equal(w.entityID) {
 				continue
 			}
+		case ev := <-regEvCh:
+			// Registry event, check if our registration is about to expire.
+			if ev.NodeExpiringEvent == nil || !ev.NodeExpiringEvent.NodeID.Equal(w.identity.NodeSigner.Public()) {
+				continue
+			}
+			w.logger.Warn("node registration is about to expire, forcing re-registration",
+				"expiration", ev.NodeExpiringEvent.Expiration,
+			)
 		case <-w.registerCh:
 			// Notification that a role provider has been updated.
 		}
@@ -604,6 +626,32 @@ func (w *Worker) NewRuntimeRoleProvider(role node.RolesMask, runtimeID common.Na
 	return w.newRoleProvider(role, &runtimeID)
 }
 
+// RemoveRuntimeRoleProviders removes all role provider slots for the given runtime.
+//
+// This should be used when a runtime is removed from a running node so that its (unavailable)
+// role providers do not block node registration.
+func (w *Worker) RemoveRuntimeRoleProviders(runtimeID common.Namespace) {
+	w.Lock()
+	roleProviders := make([]*roleProvider, 0, len(w.roleProviders))
+	for _, rp := range w.roleProviders {
+		if rp.runtimeID != nil && *rp.runtimeID == runtimeID {
+			w.logger.Debug("removing role provider",
+				"id", runtimeID,
+				"role", rp.role,
+			)
+			continue
+		}
+		roleProviders = append(roleProviders, rp)
+	}
+	w.roleProviders = roleProviders
+	w.Unlock()
+
+	select {
+	case w.registerCh <- struct{}{}:
+	default:
+	}
+}
+
 func (w *Worker) newRoleProvider(role node.RolesMask, runtimeID *common.Namespace) (RoleProvider, error) {
 	w.logger.Debug("new role provider",
 		"id", runtimeID,
@@ -835,7 +883,7 @@ func (w *Worker) registerNode(epoch beacon.EpochTime, hook RegisterNodeHook) err
 
 	// Update the registration status on successful registration.
 	w.RLock()
-	w.status.LastRegistration = time.Now()
+	w.status.LastRegistration = w.clock.Now()
 	w.status.Descriptor = &nodeDesc
 	w.RUnlock()
 
@@ -1012,6 +1060,7 @@ func New(
 		initialRegCh:       make(chan struct{}),
 		stopRegCh:          make(chan struct{}),
 		ctx:                context.Background(),
+		clock:              clock.System,
 		logger:             logger,
 		consensus:          consensus,
 		p2p:                p2p,
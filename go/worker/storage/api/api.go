@@ -17,6 +17,9 @@ var (
 	// ErrCantPauseCheckpointer is the error returned when trying to pause the checkpointer without
 	// setting the debug flag.
 	ErrCantPauseCheckpointer = errors.New(ModuleName, 2, "worker/storage: pausing checkpointer only available in debug mode")
+	// ErrProfilingDisabled is the error returned when trying to query slow storage operations
+	// without storage profiling being enabled.
+	ErrProfilingDisabled = errors.New(ModuleName, 3, "worker/storage: storage profiling not enabled")
 )
 
 // StorageWorker is the storage worker control API interface.
@@ -31,6 +34,9 @@ type StorageWorker interface {
 
 	// PauseCheckpointer pauses or unpauses the storage worker's checkpointer.
 	PauseCheckpointer(ctx context.Context, request *PauseCheckpointerRequest) error
+
+	// GetSlowOperations returns the slowest recorded storage operations, slowest first.
+	GetSlowOperations(ctx context.Context, request *GetSlowOperationsRequest) ([]*storage.SlowOperation, error)
 }
 
 // GetLastSyncedRoundRequest is a GetLastSyncedRound request.
@@ -63,6 +69,11 @@ type PauseCheckpointerRequest struct {
 	Pause     bool             `json:"pause"`
 }
 
+// GetSlowOperationsRequest is a GetSlowOperations request.
+type GetSlowOperationsRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+}
+
 // Status is the storage worker status.
 type Status struct {
 	// LastFinalizedRound is the last synced and finalized round.
@@ -6,6 +6,7 @@ import (
 	"google.golang.org/grpc"
 
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 )
 
 var (
@@ -18,6 +19,8 @@ var (
 	methodWaitForRound = serviceName.NewMethod("WaitForRound", &WaitForRoundRequest{})
 	// methodPauseCheckpointer is the PauseCheckpointer method.
 	methodPauseCheckpointer = serviceName.NewMethod("PauseCheckpointer", &PauseCheckpointerRequest{})
+	// methodGetSlowOperations is the GetSlowOperations method.
+	methodGetSlowOperations = serviceName.NewMethod("GetSlowOperations", &GetSlowOperationsRequest{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -36,6 +39,10 @@ var (
 				MethodName: methodPauseCheckpointer.ShortName(),
 				Handler:    handlerPauseCheckpointer,
 			},
+			{
+				MethodName: methodGetSlowOperations.ShortName(),
+				Handler:    handlerGetSlowOperations,
+			},
 		},
 		Streams: []grpc.StreamDesc{},
 	}
@@ -110,6 +117,29 @@ func handlerPauseCheckpointer( // nolint: golint
 	return interceptor(ctx, rq, info, handler)
 }
 
+func handlerGetSlowOperations( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(GetSlowOperationsRequest)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageWorker).GetSlowOperations(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetSlowOperations.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageWorker).GetSlowOperations(ctx, req.(*GetSlowOperationsRequest))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
 // RegisterService registers a new storage worker service with the given gRPC server.
 func RegisterService(server *grpc.Server, service StorageWorker) {
 	server.RegisterService(&serviceDesc, service)
@@ -139,6 +169,14 @@ func (c *storageWorkerClient) PauseCheckpointer(ctx context.Context, req *PauseC
 	return c.conn.Invoke(ctx, methodPauseCheckpointer.FullName(), req, nil)
 }
 
+func (c *storageWorkerClient) GetSlowOperations(ctx context.Context, req *GetSlowOperationsRequest) ([]*storage.SlowOperation, error) {
+	var rsp []*storage.SlowOperation
+	if err := c.conn.Invoke(ctx, methodGetSlowOperations.FullName(), req, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 // NewStorageWorkerClient creates a new gRPC transaction scheduler
 // client service.
 func NewStorageWorkerClient(c *grpc.ClientConn) StorageWorker {
@@ -0,0 +1,287 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/peer"
+
+	policyAPI "github.com/oasisprotocol/oasis-core/go/common/grpc/policy/api"
+)
+
+// chunkWaiter is a checkpoint chunk request waiting for a stream slot.
+type chunkWaiter struct {
+	ch      chan struct{}
+	granted bool
+}
+
+// chunkServer limits the resources used for serving checkpoint chunks.
+//
+// It caps the number of concurrent chunk streams (in total and per peer),
+// distributes free stream slots among waiting peers in a round-robin fashion
+// and limits the total bandwidth used by all chunk streams.
+type chunkServer struct {
+	sync.Mutex
+
+	maxStreams        int
+	maxStreamsPerPeer int
+
+	active        int
+	activePerPeer map[string]int
+
+	waiters map[string][]*chunkWaiter
+	order   []string
+
+	bandwidth *bandwidthLimiter
+}
+
+// acquire waits until the given peer may start a new chunk stream. The
+// returned function must be called after the stream has completed.
+func (s *chunkServer) acquire(ctx context.Context, peer string) (func(), error) {
+	release := func() {
+		s.release(peer)
+	}
+
+	// Always go through the queue so that new requests cannot starve queued
+	// ones.
+	w := &chunkWaiter{ch: make(chan struct{})}
+	s.Lock()
+	if len(s.waiters[peer]) == 0 {
+		s.order = append(s.order, peer)
+	}
+	s.waiters[peer] = append(s.waiters[peer], w)
+	s.dispatchLocked()
+	s.Unlock()
+
+	select {
+	case <-w.ch:
+		return release, nil
+	case <-ctx.Done():
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if w.granted {
+		// The slot was granted concurrently with the cancellation.
+		s.stopLocked(peer)
+		s.dispatchLocked()
+		return nil, ctx.Err()
+	}
+	s.removeWaiterLocked(peer, w)
+	return nil, ctx.Err()
+}
+
+func (s *chunkServer) release(peer string) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.stopLocked(peer)
+	s.dispatchLocked()
+}
+
+func (s *chunkServer) canStartLocked(peer string) bool {
+	if s.maxStreams > 0 && s.active >= s.maxStreams {
+		return false
+	}
+	if s.maxStreamsPerPeer > 0 && s.activePerPeer[peer] >= s.maxStreamsPerPeer {
+		return false
+	}
+	return true
+}
+
+func (s *chunkServer) startLocked(peer string) {
+	s.active++
+	s.activePerPeer[peer]++
+}
+
+func (s *chunkServer) stopLocked(peer string) {
+	s.active--
+	s.activePerPeer[peer]--
+	if s.activePerPeer[peer] <= 0 {
+		delete(s.activePerPeer, peer)
+	}
+}
+
+// dispatchLocked grants free stream slots to waiting peers in round-robin
+// order.
+func (s *chunkServer) dispatchLocked() {
+	for skipped := 0; skipped < len(s.order); {
+		if s.maxStreams > 0 && s.active >= s.maxStreams {
+			return
+		}
+
+		peer := s.order[0]
+		s.order = s.order[1:]
+		if !s.canStartLocked(peer) {
+			// Peer is at its stream limit, try the next one.
+			s.order = append(s.order, peer)
+			skipped++
+			continue
+		}
+		skipped = 0
+
+		queue := s.waiters[peer]
+		w := queue[0]
+		if len(queue) > 1 {
+			s.waiters[peer] = queue[1:]
+			s.order = append(s.order, peer)
+		} else {
+			delete(s.waiters, peer)
+		}
+
+		s.startLocked(peer)
+		w.granted = true
+		close(w.ch)
+	}
+}
+
+func (s *chunkServer) removeWaiterLocked(peer string, w *chunkWaiter) {
+	queue := s.waiters[peer]
+	for i, v := range queue {
+		if v != w {
+			continue
+		}
+		queue = append(queue[:i], queue[i+1:]...)
+		break
+	}
+	if len(queue) > 0 {
+		s.waiters[peer] = queue
+		return
+	}
+
+	delete(s.waiters, peer)
+	for i, v := range s.order {
+		if v == peer {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// writer returns a writer that is subject to the bandwidth limit.
+func (s *chunkServer) writer(ctx context.Context, w io.Writer) io.Writer {
+	if s.bandwidth == nil {
+		return w
+	}
+	return &throttledWriter{
+		ctx:     ctx,
+		w:       w,
+		limiter: s.bandwidth,
+	}
+}
+
+func newChunkServer(maxStreams, maxStreamsPerPeer int, bandwidth uint64) *chunkServer {
+	s := &chunkServer{
+		maxStreams:        maxStreams,
+		maxStreamsPerPeer: maxStreamsPerPeer,
+		activePerPeer:     make(map[string]int),
+		waiters:           make(map[string][]*chunkWaiter),
+	}
+	if bandwidth > 0 {
+		s.bandwidth = newBandwidthLimiter(bandwidth)
+	}
+	return s
+}
+
+// bandwidthLimiter is a token bucket limiting the number of bytes per second.
+type bandwidthLimiter struct {
+	sync.Mutex
+
+	rate   float64
+	bucket tokenBucket
+
+	now func() time.Time
+}
+
+// reserve reserves n bytes and returns the duration the caller must wait for
+// before sending them.
+func (l *bandwidthLimiter) reserve(n int) time.Duration {
+	l.Lock()
+	defer l.Unlock()
+
+	now := l.now()
+	if elapsed := now.Sub(l.bucket.lastUpdate).Seconds(); elapsed > 0 {
+		l.bucket.tokens += elapsed * l.rate
+		if l.bucket.tokens > l.rate {
+			l.bucket.tokens = l.rate
+		}
+	}
+	l.bucket.lastUpdate = now
+
+	l.bucket.tokens -= float64(n)
+	if l.bucket.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.bucket.tokens / l.rate * float64(time.Second))
+}
+
+// maxReservation returns the maximum number of bytes that may be reserved at
+// once.
+func (l *bandwidthLimiter) maxReservation() int {
+	return int(l.rate)
+}
+
+func newBandwidthLimiter(bytesPerSecond uint64) *bandwidthLimiter {
+	return &bandwidthLimiter{
+		rate: float64(bytesPerSecond),
+		bucket: tokenBucket{
+			tokens:     float64(bytesPerSecond),
+			lastUpdate: time.Now(),
+		},
+		now: time.Now,
+	}
+}
+
+type throttledWriter struct {
+	ctx     context.Context
+	w       io.Writer
+	limiter *bandwidthLimiter
+}
+
+func (tw *throttledWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		n := len(p)
+		if max := tw.limiter.maxReservation(); max > 0 && n > max {
+			n = max
+		}
+
+		if delay := tw.limiter.reserve(n); delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-tw.ctx.Done():
+				return written, tw.ctx.Err()
+			}
+		}
+
+		m, err := tw.w.Write(p[:n])
+		written += m
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// peerFromContext returns an identifier of the gRPC peer, based on its TLS
+// certificate if available and its address otherwise.
+func peerFromContext(ctx context.Context) string {
+	if subject, err := policyAPI.SubjectFromGRPCContext(ctx); err == nil {
+		return subject
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return addr
+}
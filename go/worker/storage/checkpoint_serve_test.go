@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkServerStreamLimits(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	s := newChunkServer(2, 1, 0)
+
+	releaseA, err := s.acquire(ctx, "alice")
+	require.NoError(err, "acquire")
+	releaseB, err := s.acquire(ctx, "bob")
+	require.NoError(err, "acquire")
+
+	// Both the total and the per-peer limits are reached.
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = s.acquire(waitCtx, "alice")
+	require.ErrorIs(err, context.DeadlineExceeded, "acquire over the per-peer limit should block")
+	_, err = s.acquire(waitCtx, "carol")
+	require.ErrorIs(err, context.DeadlineExceeded, "acquire over the total limit should block")
+	require.Empty(s.waiters, "cancelled waiters should be removed")
+	require.Empty(s.order, "cancelled waiters should be removed")
+
+	// Queue two requests from alice followed by one from carol.
+	granted := make(chan string, 3)
+	queue := func(peer string) {
+		release, qerr := s.acquire(ctx, peer)
+		require.NoError(qerr, "acquire")
+		granted <- peer
+		<-time.After(10 * time.Millisecond)
+		release()
+	}
+	go queue("alice")
+	go queue("alice")
+	require.Eventually(func() bool {
+		s.Lock()
+		defer s.Unlock()
+		return len(s.waiters["alice"]) == 2
+	}, time.Second, time.Millisecond)
+	go queue("carol")
+	require.Eventually(func() bool {
+		s.Lock()
+		defer s.Unlock()
+		return len(s.waiters["carol"]) == 1
+	}, time.Second, time.Millisecond)
+
+	// Releasing bob's slot should not let alice exceed the per-peer limit, so
+	// the slot should go to carol.
+	releaseB()
+	require.Equal("carol", <-granted, "free slot should go to the next eligible peer")
+
+	releaseA()
+	require.Equal("alice", <-granted)
+	require.Equal("alice", <-granted)
+
+	require.Eventually(func() bool {
+		s.Lock()
+		defer s.Unlock()
+		return s.active == 0 && len(s.activePerPeer) == 0
+	}, time.Second, time.Millisecond, "all slots should be released")
+}
+
+func TestChunkServerBandwidthLimit(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	l := newBandwidthLimiter(100)
+	l.now = func() time.Time { return now }
+	l.bucket.lastUpdate = now
+
+	// Burst of up to one second worth of bytes is allowed.
+	require.Zero(l.reserve(100), "reservation within burst should not wait")
+	require.Equal(500*time.Millisecond, l.reserve(50), "reservation over burst should wait")
+
+	// Tokens are refilled over time.
+	now = now.Add(time.Second)
+	require.Zero(l.reserve(50), "reservation after refill should not wait")
+
+	// Writes are split into chunks and passed through.
+	s := newChunkServer(0, 0, 1<<20)
+	var buf bytes.Buffer
+	data := bytes.Repeat([]byte{0x42}, 1<<10)
+	n, err := s.writer(context.Background(), &buf).Write(data)
+	require.NoError(err, "Write")
+	require.Equal(len(data), n)
+	require.Equal(data, buf.Bytes())
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/cenkalti/backoff/v4"
 
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/runtime/nodes/grpc"
 	storageApi "github.com/oasisprotocol/oasis-core/go/storage/api"
 	storageClient "github.com/oasisprotocol/oasis-core/go/storage/client"
@@ -141,16 +142,25 @@ func (n *Node) nodeWorker(
 		chunkCtx, cancel := context.WithTimeout(ctx, cpRestoreTimeout)
 		defer cancel()
 
-		restoreCh := make(chan *restoreResult)
+		restoreCh := make(chan *restoreResult, 1)
 		rd, wr := io.Pipe()
-		go func() {
-			done, err := n.localStorage.Checkpointer().RestoreChunk(chunkCtx, chunk.Index, rd)
+		_, err := n.workPool.Submit(WorkClassCheckpoint, func() {
+			done, rerr := n.localStorage.Checkpointer().RestoreChunk(chunkCtx, chunk.Index, rd)
 			restoreCh <- &restoreResult{
 				done: done,
-				err:  err,
+				err:  rerr,
 			}
-		}()
-		err := api.GetCheckpointChunk(chunkCtx, chunk, wr)
+		})
+		if err != nil {
+			cancel()
+			n.logger.Warn("failed to schedule chunk restoration",
+				"chunk", chunk.Index,
+				"err", err,
+			)
+			chunkReturnCh <- chunk
+			return err
+		}
+		err = api.GetCheckpointChunk(chunkCtx, chunk, wr)
 		wr.Close()
 		result := <-restoreCh
 		cancel()
@@ -189,7 +199,8 @@ func (n *Node) nodeWorker(
 			case errors.Is(result.err, checkpoint.ErrChunkCorrupted):
 				chunkReturnCh <- chunk
 				return result.err
-			case errors.Is(result.err, checkpoint.ErrChunkProofVerificationFailed):
+			case errors.Is(result.err, checkpoint.ErrChunkProofVerificationFailed),
+				errors.Is(result.err, checkpoint.ErrRestoreIncomplete):
 				errorCh <- checkpointStatusNext
 				return backoff.Permanent(result.err)
 			default:
@@ -202,6 +213,10 @@ func (n *Node) nodeWorker(
 
 func (n *Node) handleCheckpoint(check *checkpoint.Metadata, nodesClient grpc.NodesClient, groupSize uint16) (cpStatus int, rerr error) {
 	if err := n.localStorage.Checkpointer().StartRestore(n.ctx, check); err != nil {
+		if errors.Is(err, checkpoint.ErrBaseRootNotFound) {
+			// The base root of a diff checkpoint is gone, try the full checkpoint instead.
+			return checkpointStatusNext, err
+		}
 		// Any previous restores were already aborted by the driver up the call stack, so
 		// things should have been going smoothly here; bail.
 		return checkpointStatusBail, fmt.Errorf("can't start checkpoint restore: %w", err)
@@ -252,10 +267,11 @@ func (n *Node) handleCheckpoint(check *checkpoint.Metadata, nodesClient grpc.Nod
 
 	for i, c := range check.Chunks {
 		heap.Push(chunks, &checkpoint.ChunkMetadata{
-			Version: 1,
-			Index:   uint64(i),
-			Digest:  c,
-			Root:    check.Root,
+			Version:  1,
+			Index:    uint64(i),
+			Digest:   c,
+			Root:     check.Root,
+			BaseRoot: check.BaseRoot,
 		})
 	}
 	n.logger.Debug("checkpoint chunks prepared for dispatch",
@@ -310,8 +326,9 @@ func (n *Node) getCheckpointList(nodesClient grpc.NodesClient) ([]*checkpoint.Me
 	// Get checkpoint list from all current committee members.
 	listCh := make(chan []*checkpoint.Metadata)
 	req := &checkpoint.GetCheckpointsRequest{
-		Version:   1,
-		Namespace: n.commonNode.Runtime.ID(),
+		Version:      1,
+		Namespace:    n.commonNode.Runtime.ID(),
+		IncludeDiffs: true,
 	}
 	getter := func(opCtx context.Context, conn *grpc.ConnWithNodeMeta) error {
 		ctx, cancel := context.WithTimeout(opCtx, cpListTimeout)
@@ -319,6 +336,13 @@ func (n *Node) getCheckpointList(nodesClient grpc.NodesClient) ([]*checkpoint.Me
 
 		api := storageApi.NewStorageClient(conn.ClientConn)
 		meta, err := api.GetCheckpoints(ctx, req)
+		if err != nil {
+			// Nodes that do not support diff checkpoints reject the unknown request field, so
+			// retry with only full checkpoints.
+			fullReq := *req
+			fullReq.IncludeDiffs = false
+			meta, err = api.GetCheckpoints(ctx, &fullReq)
+		}
 		if err != nil {
 			n.logger.Error("error calling GetCheckpoints",
 				"err", err,
@@ -359,33 +383,57 @@ resultLoop:
 		}
 	}
 
-	// Prepare the list: sort and deduplicate.
+	// Prepare the list: sort and deduplicate. Diff checkpoints of a root are ordered before its
+	// full checkpoints so that the full checkpoint is used as a fallback.
 	sort.Slice(list, func(i, j int) bool {
 		// Descending!
-		if list[j].Root.Version == list[i].Root.Version {
+		if list[j].Root.Version != list[i].Root.Version {
+			return list[j].Root.Version < list[i].Root.Version
+		}
+		if !list[j].Root.Hash.Equal(&list[i].Root.Hash) {
 			return bytes.Compare(list[j].Root.Hash[:], list[i].Root.Hash[:]) < 0
 		}
-		return list[j].Root.Version < list[i].Root.Version
+		if list[i].IsDiff() != list[j].IsDiff() {
+			return list[i].IsDiff()
+		}
+		if list[i].IsDiff() {
+			// Prefer the most recent base root as it results in the smallest diff.
+			return list[j].BaseRoot.Version < list[i].BaseRoot.Version
+		}
+		return false
 	})
 	retList := make([]*checkpoint.Metadata, len(list))
 	var prevCheckpoint *checkpoint.Metadata
 	cursor := 0
 	for i := 0; i < len(list); i++ {
-		if prevCheckpoint == nil || !list[i].Root.Equal(&prevCheckpoint.Root) {
+		if prevCheckpoint == nil || !sameCheckpoint(list[i], prevCheckpoint) {
 			retList[cursor] = list[i]
 			cursor++
 		}
+		prevCheckpoint = list[i]
 	}
 
 	return retList[:cursor], nil
 }
 
+// sameCheckpoint returns true iff both checkpoints restore the same root from the same base root.
+func sameCheckpoint(a, b *checkpoint.Metadata) bool {
+	if !a.Root.Equal(&b.Root) || a.IsDiff() != b.IsDiff() {
+		return false
+	}
+	return !a.IsDiff() || a.BaseRoot.Equal(b.BaseRoot)
+}
+
 func (n *Node) checkCheckpointUsable(cp *checkpoint.Metadata, remainingMask outstandingMask) bool {
 	namespace := n.commonNode.Runtime.ID()
 	if !namespace.Equal(&cp.Root.Namespace) {
 		// Not for the right runtime.
 		return false
 	}
+	if cp.IsDiff() && !n.localStorage.NodeDB().HasRoot(*cp.BaseRoot) {
+		// Diff checkpoints can only be restored on top of a local base root.
+		return false
+	}
 	blk, err := n.commonNode.Runtime.History().GetBlock(n.ctx, cp.Root.Version)
 	if err != nil {
 		n.logger.Error("can't get block information for checkpoint, skipping", "err", err, "root", cp.Root)
@@ -411,11 +459,39 @@ func (n *Node) checkCheckpointUsable(cp *checkpoint.Metadata, remainingMask outs
 	return false
 }
 
-func (n *Node) syncCheckpoints(genesisRound uint64) (*blockSummary, error) {
+// getGenesisCheckpoint returns the runtime state checkpoint embedded in the genesis document, if
+// any.
+func (n *Node) getGenesisCheckpoint(genesisBlock *block.Block) *checkpoint.Metadata {
+	doc, err := n.commonNode.Consensus.GetGenesisDocument(n.ctx)
+	if err != nil {
+		n.logger.Warn("failed to get genesis document, ignoring genesis checkpoint", "err", err)
+		return nil
+	}
+
+	rtState := doc.RootHash.RuntimeStates[n.commonNode.Runtime.ID()]
+	if rtState == nil || rtState.StateCheckpoint == nil {
+		return nil
+	}
+	cp := rtState.StateCheckpoint
+	if cp.Root.Version != genesisBlock.Header.Round || !cp.Root.Hash.Equal(&genesisBlock.Header.StateRoot) {
+		// The genesis block was not derived from this runtime state (e.g., the runtime has been
+		// re-registered with a different genesis), so the checkpoint is useless.
+		n.logger.Warn("genesis checkpoint does not match genesis block, ignoring",
+			"checkpoint_root", cp.Root,
+			"genesis_round", genesisBlock.Header.Round,
+			"genesis_state_root", genesisBlock.Header.StateRoot,
+		)
+		return nil
+	}
+	return cp
+}
+
+func (n *Node) syncCheckpoints(genesisBlock *block.Block) (*blockSummary, error) {
 	// Store roots and round info for checkpoints that finished syncing.
 	// Round and namespace info will get overwritten as rounds are skipped
 	// for errors, driven by remainingRoots.
 	var syncState blockSummary
+	genesisRound := genesisBlock.Header.Round
 
 	// Try getting the active descriptor first.
 	descriptor, err := n.commonNode.Runtime.ActiveDescriptor(n.ctx)
@@ -429,6 +505,22 @@ func (n *Node) syncCheckpoints(genesisRound uint64) (*blockSummary, error) {
 		return nil, fmt.Errorf("can't get checkpoint list from storage committee: %w", err)
 	}
 
+	// If the genesis document references a checkpoint of the genesis state, use it as a last
+	// resort in case no more recent checkpoints are available. As the genesis block has an empty
+	// I/O root, the state root is enough for the genesis round.
+	if cp := n.getGenesisCheckpoint(genesisBlock); cp != nil {
+		var found bool
+		for _, check := range metadata {
+			if check.Root.Equal(&cp.Root) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			metadata = append(metadata, cp)
+		}
+	}
+
 	// Try all the checkpoints now, from most recent backwards.
 	var (
 		prevVersion      uint64 = ^uint64(0)
@@ -468,6 +560,11 @@ func (n *Node) syncCheckpoints(genesisRound uint64) (*blockSummary, error) {
 			remainingRoots = outstandingMaskFull
 			prevVersion = check.Root.Version
 			syncState.Roots = nil
+
+			if check.Root.Version == genesisRound && genesisBlock.Header.IORoot.IsEmpty() {
+				// There is nothing to restore for the empty genesis I/O root.
+				remainingRoots.remove(storageApi.RootTypeIO)
+			}
 		}
 
 		// Compute how many nodes we will be asking for checkpoints.
@@ -102,8 +102,45 @@ const (
 
 	// getDiffTimeout is the timeout for fetching a diff from a node.
 	getDiffTimeout = 15 * time.Second
+
+	// WorkClassDiff is the worker pool class used for fetching storage diffs.
+	WorkClassDiff = "diff"
+	// WorkClassCheckpoint is the worker pool class used for restoring checkpoint chunks, which
+	// includes verifying the chunk proofs.
+	WorkClassCheckpoint = "checkpoint"
+
+	// diffQueueSize is the maximum number of queued diff fetches across all runtimes.
+	diffQueueSize = 4 * maxInFlightRounds
+	// checkpointQueueSize is the maximum number of queued checkpoint chunk restores across all
+	// runtimes.
+	checkpointQueueSize = 64
 )
 
+// NewWorkerPool creates a new worker pool with the given number of workers that can be shared by
+// the storage committee nodes of all runtimes.
+//
+// Diff fetches have priority over checkpoint chunk restores, which may use at most half of the
+// workers so that a long-running checkpoint restore cannot starve round syncing.
+func NewWorkerPool(workers uint) (*workerpool.PriorityPool, error) {
+	checkpointConcurrency := workers / 2
+	if checkpointConcurrency == 0 {
+		checkpointConcurrency = 1
+	}
+
+	return workerpool.NewPriority("storage", workers,
+		workerpool.ClassConfig{
+			Name:      WorkClassDiff,
+			Priority:  1,
+			QueueSize: diffQueueSize,
+		},
+		workerpool.ClassConfig{
+			Name:           WorkClassCheckpoint,
+			MaxConcurrency: checkpointConcurrency,
+			QueueSize:      checkpointQueueSize,
+		},
+	)
+}
+
 type roundItem interface {
 	GetRound() uint64
 }
@@ -179,7 +216,11 @@ type Node struct { // nolint: maligned
 	grpcPolicy     *policy.DynamicRuntimePolicyChecker
 	undefinedRound uint64
 
-	fetchPool *workerpool.Pool
+	policyLock         sync.Mutex
+	publicRPCSuspended bool
+	publicReadEnabled  bool
+
+	workPool *workerpool.PriorityPool
 
 	stateStore *persistent.ServiceStore
 
@@ -209,7 +250,7 @@ type Node struct { // nolint: maligned
 func NewNode(
 	commonNode *committee.Node,
 	grpcPolicy *policy.DynamicRuntimePolicyChecker,
-	fetchPool *workerpool.Pool,
+	workPool *workerpool.PriorityPool,
 	store *persistent.ServiceStore,
 	roleProvider registration.RoleProvider,
 	rpcRoleProvider registration.RoleProvider,
@@ -217,6 +258,7 @@ func NewNode(
 	localStorage storageApi.LocalBackend,
 	checkpointerCfg *checkpoint.CheckpointerConfig,
 	checkpointSyncDisabled bool,
+	publicReadEnabled bool,
 ) (*Node, error) {
 	n := &Node{
 		commonNode: commonNode,
@@ -231,11 +273,12 @@ func NewNode(
 		localStorage: localStorage,
 		grpcPolicy:   grpcPolicy,
 
-		fetchPool: fetchPool,
+		workPool: workPool,
 
 		stateStore: store,
 
 		checkpointSyncDisabled: checkpointSyncDisabled,
+		publicReadEnabled:      publicReadEnabled,
 
 		blockCh:    channels.NewInfiniteChannel(),
 		diffCh:     make(chan *fetchedDiff),
@@ -298,6 +341,7 @@ func NewNode(
 			Namespace:       commonNode.Runtime.ID(),
 			CheckInterval:   checkpointerCfg.CheckInterval,
 			RootsPerVersion: 2, // State root and I/O root.
+			CreateDiffs:     checkpointerCfg.CreateDiffs,
 			GetParameters: func(ctx context.Context) (*checkpoint.CreationParameters, error) {
 				rt, rerr := commonNode.Runtime.ActiveDescriptor(ctx)
 				if rerr != nil {
@@ -450,6 +494,25 @@ func (n *Node) PauseCheckpointer(pause bool) error {
 	return nil
 }
 
+// SetPublicRPCSuspended suspends or resumes serving public storage RPC requests.
+//
+// While suspended, the storage RPC methods are only allowed for the configured sentry nodes even
+// when public storage RPC is enabled in the configuration.
+func (n *Node) SetPublicRPCSuspended(suspended bool) {
+	n.policyLock.Lock()
+	defer n.policyLock.Unlock()
+
+	if n.publicRPCSuspended == suspended {
+		return
+	}
+	n.publicRPCSuspended = suspended
+	n.updateExternalServicePolicyLocked()
+
+	n.logger.Info("public storage RPC suspension changed",
+		"suspended", suspended,
+	)
+}
+
 // GetLocalStorage returns the local storage backend used by this storage node.
 func (n *Node) GetLocalStorage() storageApi.LocalBackend {
 	return n.localStorage
@@ -462,11 +525,6 @@ func (n *Node) HandlePeerTx(ctx context.Context, tx []byte) error {
 	return nil
 }
 
-// Guarded by CrossNode.
-func (n *Node) HandleEpochTransitionLocked(snapshot *committee.EpochSnapshot) {
-	// Nothing to do here.
-}
-
 // Guarded by CrossNode.
 func (n *Node) HandleNewBlockEarlyLocked(*block.Block) {
 	// Nothing to do here.
@@ -709,6 +767,13 @@ func (n *Node) flushSyncedState(summary *blockSummary) uint64 {
 }
 
 func (n *Node) updateExternalServicePolicy() {
+	n.policyLock.Lock()
+	defer n.policyLock.Unlock()
+
+	n.updateExternalServicePolicyLocked()
+}
+
+func (n *Node) updateExternalServicePolicyLocked() {
 	// Create new storage gRPC access policy for the current runtime.
 	policy := accessctl.NewPolicy()
 
@@ -718,13 +783,21 @@ func (n *Node) updateExternalServicePolicy() {
 	}
 
 	// If public storage RPC was enabled in the config, then the normally gated methods need to be
-	// allowed for everyone.
-	if n.rpcRoleProvider != nil {
+	// allowed for everyone (unless public storage RPC is temporarily suspended).
+	if n.rpcRoleProvider != nil && !n.publicRPCSuspended {
 		for _, act := range storageRpcNodesPolicy.Actions {
 			policy.AllowAll(act)
 		}
 	}
 
+	// If public reads were enabled for this runtime, anonymous clients are also allowed to read
+	// the state (unless public storage RPC is temporarily suspended).
+	if n.publicReadEnabled && !n.publicRPCSuspended {
+		for _, act := range publicReadPolicy.Actions {
+			policy.AllowAnonymous(act)
+		}
+	}
+
 	// Update storage gRPC access policy for the current runtime.
 	n.grpcPolicy.SetAccessPolicy(policy, n.commonNode.Runtime.ID())
 	n.logger.Debug("set new storage gRPC access policy", "policy", policy)
@@ -1077,7 +1150,7 @@ func (n *Node) worker() { // nolint: gocyclo
 		)
 	CheckpointSyncRetry:
 		for {
-			summary, err = n.syncCheckpoints(genesisBlock.Header.Round)
+			summary, err = n.syncCheckpoints(genesisBlock)
 			if err == nil {
 				break
 			}
@@ -1176,12 +1249,22 @@ func (n *Node) worker() { // nolint: gocyclo
 				if !syncing.outstanding.contains(rootType) && syncing.awaitingRetry.contains(rootType) {
 					syncing.scheduleDiff(rootType)
 					fetcherGroup.Add(1)
-					n.fetchPool.Submit(func(round uint64, prevRoot, thisRoot storageApi.Root) func() {
+					_, err = n.workPool.Submit(WorkClassDiff, func(round uint64, prevRoot, thisRoot storageApi.Root) func() {
 						return func() {
 							defer fetcherGroup.Done()
 							n.fetchDiff(round, prevRoot, thisRoot)
 						}
 					}(this.Round, prevRoots[i], this.Roots[i]))
+					if err != nil {
+						// The diff will be retried once more rounds need to be fetched.
+						n.logger.Warn("failed to schedule diff fetch",
+							"err", err,
+							"round", this.Round,
+							"root_type", rootType,
+						)
+						syncing.retry(rootType)
+						fetcherGroup.Done()
+					}
 				}
 			}
 		}
@@ -16,6 +16,13 @@ var (
 			accessctl.Action(api.MethodSyncIterate.FullName()),
 		},
 	}
+	publicReadPolicy = &committee.AccessPolicy{
+		Actions: []accessctl.Action{
+			accessctl.Action(api.MethodSyncGet.FullName()),
+			accessctl.Action(api.MethodSyncGetPrefixes.FullName()),
+			accessctl.Action(api.MethodSyncIterate.FullName()),
+		},
+	}
 	sentryNodesPolicy = &committee.AccessPolicy{
 		Actions: []accessctl.Action{
 			accessctl.Action(api.MethodSyncGet.FullName()),
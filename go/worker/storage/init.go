@@ -25,10 +25,35 @@ const (
 	// storage committee members.
 	CfgWorkerPublicRPCEnabled = "worker.storage.public_rpc.enabled"
 
+	// CfgWorkerPublicReadRuntimes configures the runtimes for which read-only storage state
+	// access is allowed for anonymous clients.
+	CfgWorkerPublicReadRuntimes = "worker.storage.public_read.runtimes"
+	// CfgWorkerPublicReadRateLimit configures the maximum number of anonymous read requests per
+	// second for each client.
+	CfgWorkerPublicReadRateLimit = "worker.storage.public_read.rate_limit"
+	// CfgWorkerPublicReadRateLimitBurst configures the maximum burst of anonymous read requests
+	// for each client.
+	CfgWorkerPublicReadRateLimitBurst = "worker.storage.public_read.rate_limit_burst"
+
+	// CfgWorkerMaxProofSize configures the maximum size of proofs returned to external clients.
+	CfgWorkerMaxProofSize = "worker.storage.max_proof_size"
+
 	// CfgWorkerCheckpointerDisabled disables the storage checkpointer.
 	CfgWorkerCheckpointerDisabled = "worker.storage.checkpointer.disabled"
 	// CfgWorkerCheckpointCheckInterval configures the checkpointer check interval.
 	CfgWorkerCheckpointCheckInterval = "worker.storage.checkpointer.check_interval"
+	// CfgWorkerCheckpointerDiffs enables creation of diff checkpoints.
+	CfgWorkerCheckpointerDiffs = "worker.storage.checkpointer.diffs"
+
+	// CfgWorkerCheckpointServeMaxStreams configures the maximum number of concurrently served
+	// checkpoint chunk streams.
+	CfgWorkerCheckpointServeMaxStreams = "worker.storage.checkpoint_serve.max_streams"
+	// CfgWorkerCheckpointServeMaxStreamsPerPeer configures the maximum number of concurrently
+	// served checkpoint chunk streams for each peer.
+	CfgWorkerCheckpointServeMaxStreamsPerPeer = "worker.storage.checkpoint_serve.max_streams_per_peer"
+	// CfgWorkerCheckpointServeBandwidthLimit configures the maximum number of bytes per second
+	// used for serving checkpoint chunks.
+	CfgWorkerCheckpointServeBandwidthLimit = "worker.storage.checkpoint_serve.bandwidth_limit"
 
 	// CfgCheckpointSyncDisabled disables syncing from checkpoints on worker startup.
 	CfgWorkerCheckpointSyncDisabled = "worker.storage.checkpoint_sync.disabled"
@@ -39,6 +64,12 @@ const (
 	// CfgMaxCacheSize configures the maximum in-memory cache size.
 	CfgMaxCacheSize = "worker.storage.max_cache_size"
 
+	// CfgProfilingMaxSlowOps configures the number of slowest storage operations to record.
+	CfgProfilingMaxSlowOps = "worker.storage.profiling.max_slow_ops"
+	// CfgProfilingSlowOpThreshold configures the duration above which storage operations are
+	// logged.
+	CfgProfilingSlowOpThreshold = "worker.storage.profiling.slow_op_threshold"
+
 	cfgCrashEnabled = "worker.storage.crash.enabled"
 )
 
@@ -83,19 +114,37 @@ func NewLocalBackend(
 		impl = newCrashingWrapper(impl)
 	}
 
-	return api.NewMetricsWrapper(impl).(api.LocalBackend), nil
+	var profiler *api.Profiler
+	maxSlowOps := viper.GetInt(CfgProfilingMaxSlowOps)
+	slowOpThreshold := viper.GetDuration(CfgProfilingSlowOpThreshold)
+	if maxSlowOps > 0 || slowOpThreshold > 0 {
+		profiler = api.NewProfiler(maxSlowOps, slowOpThreshold)
+	}
+
+	return api.NewProfilingMetricsWrapper(impl, profiler).(api.LocalBackend), nil
 }
 
 func init() {
-	Flags.Uint(cfgWorkerFetcherCount, 4, "Number of concurrent storage diff fetchers")
+	Flags.Uint(cfgWorkerFetcherCount, 4, "Number of storage workers used for fetching diffs and restoring checkpoints")
 	Flags.Bool(CfgWorkerPublicRPCEnabled, false, "Enable storage RPC access for all nodes")
+	Flags.StringSlice(CfgWorkerPublicReadRuntimes, []string{}, "Runtimes with anonymous read-only storage access enabled")
+	Flags.Float64(CfgWorkerPublicReadRateLimit, 10, "Maximum anonymous storage read requests per second per client (0 = unlimited)")
+	Flags.Uint64(CfgWorkerPublicReadRateLimitBurst, 20, "Maximum anonymous storage read request burst per client")
+	Flags.String(CfgWorkerMaxProofSize, "16mb", "Maximum size of storage proofs returned to external clients (0 = unlimited)")
 	Flags.Bool(CfgWorkerCheckpointerDisabled, false, "Disable the storage checkpointer")
 	Flags.Duration(CfgWorkerCheckpointCheckInterval, 1*time.Minute, "Storage checkpointer check interval")
+	Flags.Bool(CfgWorkerCheckpointerDiffs, false, "Create diff checkpoints against the previous checkpoint")
+	Flags.Uint(CfgWorkerCheckpointServeMaxStreams, 8, "Maximum number of concurrently served checkpoint chunk streams (0 = unlimited)")
+	Flags.Uint(CfgWorkerCheckpointServeMaxStreamsPerPeer, 2, "Maximum number of concurrently served checkpoint chunk streams per peer (0 = unlimited)")
+	Flags.String(CfgWorkerCheckpointServeBandwidthLimit, "0", "Maximum bandwidth used for serving checkpoint chunks per second (0 = unlimited)")
 	Flags.Bool(CfgWorkerCheckpointSyncDisabled, false, "Disable initial storage sync from checkpoints")
 
 	Flags.String(CfgBackend, database.BackendNameBadgerDB, "Storage backend")
 	Flags.String(CfgMaxCacheSize, "64mb", "Maximum in-memory cache size")
 
+	Flags.Int(CfgProfilingMaxSlowOps, 0, "Number of slowest storage operations to record (0 = disabled)")
+	Flags.Duration(CfgProfilingSlowOpThreshold, 0, "Log storage operations slower than the given duration (0 = disabled)")
+
 	Flags.Bool(cfgCrashEnabled, false, "UNSAFE: Enable the crashing storage wrapper")
 	_ = Flags.MarkHidden(cfgCrashEnabled)
 
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiterCleanupInterval is the interval at which idle client buckets are removed.
+const rateLimiterCleanupInterval = 1 * time.Minute
+
+type tokenBucket struct {
+	tokens     float64
+	lastUpdate time.Time
+}
+
+// rateLimiter is a per-client token bucket rate limiter.
+type rateLimiter struct {
+	sync.Mutex
+
+	rate  float64
+	burst float64
+
+	buckets     map[string]*tokenBucket
+	lastCleanup time.Time
+
+	now func() time.Time
+}
+
+// Allow checks whether the given client is allowed to perform a request and if so, consumes a
+// token from the client's bucket.
+func (l *rateLimiter) Allow(client string) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastCleanup) >= rateLimiterCleanupInterval {
+		l.cleanupLocked(now)
+	}
+
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &tokenBucket{
+			tokens:     l.burst,
+			lastUpdate: now,
+		}
+		l.buckets[client] = b
+	}
+	l.refill(b, now)
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (l *rateLimiter) refill(b *tokenBucket, now time.Time) {
+	if elapsed := now.Sub(b.lastUpdate).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * l.rate
+		if b.tokens > l.burst {
+			b.tokens = l.burst
+		}
+	}
+	b.lastUpdate = now
+}
+
+func (l *rateLimiter) cleanupLocked(now time.Time) {
+	// Buckets that have been refilled completely are equivalent to new buckets.
+	for client, b := range l.buckets {
+		l.refill(b, now)
+		if b.tokens >= l.burst {
+			delete(l.buckets, client)
+		}
+	}
+	l.lastCleanup = now
+}
+
+// newRateLimiter creates a new per-client rate limiter allowing rate requests per second with the
+// given burst size.
+func newRateLimiter(rate float64, burst uint64) *rateLimiter {
+	return &rateLimiter{
+		rate:        rate,
+		burst:       float64(burst),
+		buckets:     make(map[string]*tokenBucket),
+		lastCleanup: time.Now(),
+		now:         time.Now,
+	}
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	l := newRateLimiter(2, 3)
+	l.now = func() time.Time { return now }
+	l.lastCleanup = now
+
+	// Burst is allowed.
+	for i := 0; i < 3; i++ {
+		require.True(l.Allow("alice"), "request %d within burst should be allowed", i)
+	}
+	require.False(l.Allow("alice"), "request over burst should be rejected")
+	// Other clients are not affected.
+	require.True(l.Allow("bob"), "other client should be allowed")
+
+	// Tokens are refilled over time.
+	now = now.Add(500 * time.Millisecond)
+	require.True(l.Allow("alice"), "request after refill should be allowed")
+	require.False(l.Allow("alice"), "request over refilled tokens should be rejected")
+
+	// Refill is capped at burst size.
+	now = now.Add(10 * time.Second)
+	for i := 0; i < 3; i++ {
+		require.True(l.Allow("alice"), "request %d within burst should be allowed", i)
+	}
+	require.False(l.Allow("alice"), "request over burst should be rejected")
+
+	// Idle clients are removed.
+	now = now.Add(rateLimiterCleanupInterval)
+	require.True(l.Allow("alice"), "request after cleanup should be allowed")
+	require.Len(l.buckets, 1, "idle client buckets should be removed")
+}
@@ -3,6 +3,12 @@ package storage
 import (
 	"context"
 	"io"
+	"net"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"github.com/oasisprotocol/oasis-core/go/common/grpc/auth"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc/policy"
@@ -22,7 +28,45 @@ type storageService struct {
 }
 
 func (s *storageService) AuthFunc(ctx context.Context, fullMethodName string, req interface{}) error {
-	return policy.GRPCAuthenticationFunction(s.w.grpcPolicy)(ctx, fullMethodName, req)
+	if err := policy.GRPCAuthenticationFunction(s.w.grpcPolicy)(ctx, fullMethodName, req); err != nil {
+		return err
+	}
+	return s.checkRateLimit(ctx)
+}
+
+// checkRateLimit enforces the public read rate limit for anonymous clients.
+func (s *storageService) checkRateLimit(ctx context.Context) error {
+	if s.w.publicReadLimiter == nil {
+		return nil
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "storage: failed to obtain connection peer from context")
+	}
+	if tlsAuth, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsAuth.State.PeerCertificates) > 0 {
+		// Authenticated clients are not rate limited.
+		return nil
+	}
+
+	client := p.Addr.String()
+	if host, _, err := net.SplitHostPort(client); err == nil {
+		client = host
+	}
+	if !s.w.publicReadLimiter.Allow(client) {
+		return status.Errorf(codes.ResourceExhausted, "storage: public read rate limit exceeded")
+	}
+	return nil
+}
+
+// limitProofSize caps the requested maximum proof size to the configured maximum.
+func (s *storageService) limitProofSize(maxProofSize *uint64) {
+	if s.w.maxProofSize == 0 {
+		return
+	}
+	if *maxProofSize == 0 || *maxProofSize > s.w.maxProofSize {
+		*maxProofSize = s.w.maxProofSize
+	}
 }
 
 func (s *storageService) ensureInitialized(ctx context.Context) error {
@@ -38,6 +82,7 @@ func (s *storageService) SyncGet(ctx context.Context, request *api.GetRequest) (
 	if err := s.ensureInitialized(ctx); err != nil {
 		return nil, err
 	}
+	s.limitProofSize(&request.MaxProofSize)
 	return s.storage.SyncGet(ctx, request)
 }
 
@@ -45,6 +90,7 @@ func (s *storageService) SyncGetPrefixes(ctx context.Context, request *api.GetPr
 	if err := s.ensureInitialized(ctx); err != nil {
 		return nil, err
 	}
+	s.limitProofSize(&request.MaxProofSize)
 	return s.storage.SyncGetPrefixes(ctx, request)
 }
 
@@ -52,6 +98,7 @@ func (s *storageService) SyncIterate(ctx context.Context, request *api.IterateRe
 	if err := s.ensureInitialized(ctx); err != nil {
 		return nil, err
 	}
+	s.limitProofSize(&request.MaxProofSize)
 	return s.storage.SyncIterate(ctx, request)
 }
 
@@ -73,7 +120,14 @@ func (s *storageService) GetCheckpointChunk(ctx context.Context, chunk *checkpoi
 	if err := s.ensureInitialized(ctx); err != nil {
 		return err
 	}
-	return s.storage.GetCheckpointChunk(ctx, chunk, w)
+
+	release, err := s.w.chunkServer.acquire(ctx, peerFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return s.storage.GetCheckpointChunk(ctx, chunk, s.w.chunkServer.writer(ctx, w))
 }
 
 func (s *storageService) Cleanup() {
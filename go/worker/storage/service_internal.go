@@ -3,13 +3,14 @@ package storage
 import (
 	"context"
 
+	storageApi "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/storage/api"
 )
 
 var _ api.StorageWorker = (*Worker)(nil)
 
 func (w *Worker) GetLastSyncedRound(ctx context.Context, request *api.GetLastSyncedRoundRequest) (*api.GetLastSyncedRoundResponse, error) {
-	node := w.runtimes[request.RuntimeID]
+	node := w.GetRuntime(request.RuntimeID)
 	if node == nil {
 		return nil, api.ErrRuntimeNotFound
 	}
@@ -23,7 +24,7 @@ func (w *Worker) GetLastSyncedRound(ctx context.Context, request *api.GetLastSyn
 }
 
 func (w *Worker) WaitForRound(ctx context.Context, request *api.WaitForRoundRequest) (*api.WaitForRoundResponse, error) {
-	node := w.runtimes[request.RuntimeID]
+	node := w.GetRuntime(request.RuntimeID)
 	if node == nil {
 		return nil, api.ErrRuntimeNotFound
 	}
@@ -42,10 +43,23 @@ func (w *Worker) WaitForRound(ctx context.Context, request *api.WaitForRoundRequ
 }
 
 func (w *Worker) PauseCheckpointer(ctx context.Context, request *api.PauseCheckpointerRequest) error {
-	node := w.runtimes[request.RuntimeID]
+	node := w.GetRuntime(request.RuntimeID)
 	if node == nil {
 		return api.ErrRuntimeNotFound
 	}
 
 	return node.PauseCheckpointer(request.Pause)
 }
+
+func (w *Worker) GetSlowOperations(ctx context.Context, request *api.GetSlowOperationsRequest) ([]*storageApi.SlowOperation, error) {
+	node := w.GetRuntime(request.RuntimeID)
+	if node == nil {
+		return nil, api.ErrRuntimeNotFound
+	}
+
+	profiled, ok := node.GetLocalStorage().(storageApi.ProfilingBackend)
+	if !ok || profiled.Profiler() == nil {
+		return nil, api.ErrProfilingDisabled
+	}
+	return profiled.Profiler().SlowOperations(), nil
+}
@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
 	"github.com/spf13/viper"
 
@@ -36,11 +38,22 @@ type Worker struct {
 	initCh chan struct{}
 	quitCh chan struct{}
 
-	runtimes   map[common.Namespace]*committee.Node
+	runtimesLock sync.RWMutex
+	runtimes     map[common.Namespace]*committee.Node
+
+	checkpointerCfg *checkpoint.CheckpointerConfig
+
 	watchState *persistent.ServiceStore
-	fetchPool  *workerpool.Pool
+	workPool   *workerpool.PriorityPool
 
 	grpcPolicy *policy.DynamicRuntimePolicyChecker
+
+	publicRead        map[common.Namespace]bool
+	publicReadLimiter *rateLimiter
+
+	maxProofSize uint64
+
+	chunkServer *chunkServer
 }
 
 // New constructs a new storage worker.
@@ -76,8 +89,34 @@ func New(
 
 	var err error
 
-	s.fetchPool = workerpool.New("storage_fetch")
-	s.fetchPool.Resize(viper.GetUint(cfgWorkerFetcherCount))
+	s.publicRead = make(map[common.Namespace]bool)
+	for _, rawID := range viper.GetStringSlice(CfgWorkerPublicReadRuntimes) {
+		var id common.Namespace
+		if err = id.UnmarshalHex(rawID); err != nil {
+			return nil, fmt.Errorf("worker/storage: malformed public read runtime identifier '%s': %w", rawID, err)
+		}
+		s.publicRead[id] = true
+	}
+	if rate := viper.GetFloat64(CfgWorkerPublicReadRateLimit); len(s.publicRead) > 0 && rate > 0 {
+		burst := viper.GetUint64(CfgWorkerPublicReadRateLimitBurst)
+		if burst == 0 {
+			return nil, fmt.Errorf("worker/storage: public read rate limit burst must be greater than zero")
+		}
+		s.publicReadLimiter = newRateLimiter(rate, burst)
+	}
+
+	s.maxProofSize = uint64(viper.GetSizeInBytes(CfgWorkerMaxProofSize))
+
+	s.chunkServer = newChunkServer(
+		int(viper.GetUint(CfgWorkerCheckpointServeMaxStreams)),
+		int(viper.GetUint(CfgWorkerCheckpointServeMaxStreamsPerPeer)),
+		uint64(viper.GetSizeInBytes(CfgWorkerCheckpointServeBandwidthLimit)),
+	)
+
+	s.workPool, err = committee.NewWorkerPool(viper.GetUint(cfgWorkerFetcherCount))
+	if err != nil {
+		return nil, fmt.Errorf("worker/storage: failed to create worker pool: %w", err)
+	}
 
 	s.watchState, err = commonStore.GetServiceStore(workerStorageDBBucketName)
 	if err != nil {
@@ -94,7 +133,7 @@ func New(
 			return node.GetLocalStorage(), nil
 		},
 		func() {
-			for _, node := range s.runtimes {
+			for _, node := range s.getRuntimes() {
 				<-node.Initialized()
 			}
 		},
@@ -105,16 +144,16 @@ func New(
 		storage: localRouter,
 	})
 
-	var checkpointerCfg *checkpoint.CheckpointerConfig
 	if !viper.GetBool(CfgWorkerCheckpointerDisabled) {
-		checkpointerCfg = &checkpoint.CheckpointerConfig{
+		s.checkpointerCfg = &checkpoint.CheckpointerConfig{
 			CheckInterval: viper.GetDuration(CfgWorkerCheckpointCheckInterval),
+			CreateDiffs:   viper.GetBool(CfgWorkerCheckpointerDiffs),
 		}
 	}
 
 	// Start storage node for every runtime.
 	for _, rt := range s.commonWorker.GetRuntimes() {
-		if err := s.registerRuntime(commonWorker.DataDir, rt, checkpointerCfg); err != nil {
+		if err := s.registerRuntime(rt); err != nil {
 			return nil, err
 		}
 	}
@@ -125,7 +164,7 @@ func New(
 	return s, nil
 }
 
-func (w *Worker) registerRuntime(dataDir string, commonNode *committeeCommon.Node, checkpointerCfg *checkpoint.CheckpointerConfig) error {
+func (w *Worker) registerRuntime(commonNode *committeeCommon.Node) error {
 	id := commonNode.Runtime.ID()
 	w.logger.Info("registering new runtime",
 		"runtime_id", id,
@@ -143,7 +182,7 @@ func (w *Worker) registerRuntime(dataDir string, commonNode *committeeCommon.Nod
 		}
 	}
 
-	path, err := runtimeRegistry.EnsureRuntimeStateDir(dataDir, id)
+	path, err := runtimeRegistry.EnsureRuntimeStateDir(w.commonWorker.DataDir, id)
 	if err != nil {
 		return err
 	}
@@ -156,18 +195,27 @@ func (w *Worker) registerRuntime(dataDir string, commonNode *committeeCommon.Nod
 	node, err := committee.NewNode(
 		commonNode,
 		w.grpcPolicy,
-		w.fetchPool,
+		w.workPool,
 		w.watchState,
 		rp,
 		rpRPC,
 		w.commonWorker.GetConfig(),
 		localStorage,
-		checkpointerCfg,
+		w.checkpointerCfg,
 		viper.GetBool(CfgWorkerCheckpointSyncDisabled),
+		w.publicRead[id],
 	)
 	if err != nil {
 		return err
 	}
+
+	w.runtimesLock.Lock()
+	defer w.runtimesLock.Unlock()
+
+	if _, ok := w.runtimes[id]; ok {
+		localStorage.Cleanup()
+		return fmt.Errorf("worker/storage: runtime %s is already registered", id)
+	}
 	commonNode.Runtime.RegisterStorage(newSyncedLocalStorage(node, localStorage))
 	commonNode.AddHooks(node)
 	w.runtimes[id] = node
@@ -210,24 +258,25 @@ func (w *Worker) Start() error {
 	go func() {
 		defer close(w.quitCh)
 
-		for _, r := range w.runtimes {
+		for _, r := range w.getRuntimes() {
 			<-r.Quit()
 		}
-		if w.fetchPool != nil {
-			<-w.fetchPool.Quit()
+		if w.workPool != nil {
+			<-w.workPool.Quit()
 		}
 	}()
 
 	// Start all runtimes and wait for initialization.
 	go func() {
-		w.logger.Info("starting storage sync services", "num_runtimes", len(w.runtimes))
+		runtimes := w.getRuntimes()
+		w.logger.Info("starting storage sync services", "num_runtimes", len(runtimes))
 
-		for _, r := range w.runtimes {
+		for _, r := range runtimes {
 			_ = r.Start()
 		}
 
 		// Wait for runtimes to be initialized and the node to be registered.
-		for _, r := range w.runtimes {
+		for _, r := range runtimes {
 			<-r.Initialized()
 		}
 
@@ -248,11 +297,11 @@ func (w *Worker) Stop() {
 		return
 	}
 
-	for _, r := range w.runtimes {
+	for _, r := range w.getRuntimes() {
 		r.Stop()
 	}
-	if w.fetchPool != nil {
-		w.fetchPool.Stop()
+	if w.workPool != nil {
+		w.workPool.Stop()
 	}
 	if w.watchState != nil {
 		w.watchState.Close()
@@ -272,5 +321,64 @@ func (w *Worker) Cleanup() {
 //
 // In case the runtime with the specified id was not configured for this node it returns nil.
 func (w *Worker) GetRuntime(id common.Namespace) *committee.Node {
+	w.runtimesLock.RLock()
+	defer w.runtimesLock.RUnlock()
+
 	return w.runtimes[id]
 }
+
+func (w *Worker) getRuntimes() map[common.Namespace]*committee.Node {
+	w.runtimesLock.RLock()
+	defer w.runtimesLock.RUnlock()
+
+	runtimes := make(map[common.Namespace]*committee.Node, len(w.runtimes))
+	for id, rt := range w.runtimes {
+		runtimes[id] = rt
+	}
+	return runtimes
+}
+
+// AddRuntime registers a runtime that was added after the worker has been started and returns
+// its storage committee node.
+//
+// The node is not started so that it can be started after the common committee node.
+func (w *Worker) AddRuntime(commonNode *committeeCommon.Node) (*committee.Node, error) {
+	if !w.enabled {
+		return nil, fmt.Errorf("worker/storage: worker is disabled")
+	}
+	if err := w.registerRuntime(commonNode); err != nil {
+		return nil, err
+	}
+	return w.GetRuntime(commonNode.Runtime.ID()), nil
+}
+
+// RemoveRuntime stops the storage committee node of the given runtime and removes it.
+//
+// The runtime's local storage is closed when the runtime itself is stopped.
+func (w *Worker) RemoveRuntime(ctx context.Context, id common.Namespace) error {
+	w.runtimesLock.Lock()
+	rt, ok := w.runtimes[id]
+	delete(w.runtimes, id)
+	w.runtimesLock.Unlock()
+	if !ok {
+		return fmt.Errorf("worker/storage: runtime %s is not registered", id)
+	}
+
+	rt.Stop()
+	select {
+	case <-rt.Quit():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	rt.Cleanup()
+	w.registration.RemoveRuntimeRoleProviders(id)
+
+	return nil
+}
+
+// SetPublicRPCSuspended suspends or resumes serving public storage RPC requests for all runtimes.
+func (w *Worker) SetPublicRPCSuspended(suspended bool) {
+	for _, r := range w.getRuntimes() {
+		r.SetPublicRPCSuspended(suspended)
+	}
+}
@@ -70,7 +70,7 @@ impl From<u64> for Version {
 // the worker host.
 pub const PROTOCOL_VERSION: Version = Version {
     major: 4,
-    minor: 0,
+    minor: 5,
     patch: 0,
 };
 
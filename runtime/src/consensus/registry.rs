@@ -48,6 +48,16 @@ pub struct ExecutorParameters {
     /// Maximum number of messages that can be emitted by the runtime
     /// in a single round.
     pub max_messages: u32,
+    /// Maximum amount of consensus gas that a single runtime message can
+    /// consume when executed (zero means no limit).
+    #[cbor(optional)]
+    #[cbor(default)]
+    pub max_message_gas: u64,
+    /// Maximum amount of consensus gas that all runtime messages emitted
+    /// in a single round can consume when executed (zero means no limit).
+    #[cbor(optional)]
+    #[cbor(default)]
+    pub max_round_message_gas: u64,
 }
 
 /// Parameters for the runtime transaction scheduler.
@@ -157,21 +167,60 @@ pub struct EntityWhitelistConfig {
     pub max_nodes: Option<BTreeMap<RolesMask, u16>>,
 }
 
+/// Policy that allows any node to register.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct AnyNodeRuntimeAdmissionPolicy {}
+
+/// Per-role entity whitelist.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct EntityWhitelistRoleAdmissionPolicy {
+    /// Per-role configuration for each whitelisted entity.
+    #[cbor(optional)]
+    pub entities: Option<BTreeMap<PublicKey, EntityWhitelistRoleConfig>>,
+}
+
+/// Per-role configuration of a whitelisted entity.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct EntityWhitelistRoleConfig {
+    /// Maximum number of nodes with the given role that the entity can
+    /// register for the runtime (zero means unlimited).
+    #[cbor(optional, default)]
+    pub max_nodes: u16,
+}
+
+/// Admission policy for nodes with a specific role.
+///
+/// Multiple fields may be set in which case ALL the requirements must be
+/// satisfied.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct PerRoleAdmissionPolicy {
+    /// Restricts registration of nodes with the given role to the specified
+    /// entities.
+    #[cbor(optional)]
+    pub entity_whitelist: Option<EntityWhitelistRoleAdmissionPolicy>,
+    /// Maximum number of nodes with the given role that any single entity
+    /// can register for the runtime (zero means unlimited).
+    #[cbor(optional, default)]
+    pub max_nodes_per_entity: u16,
+    /// Minimum stake required for each node with the given role registered
+    /// for the runtime.
+    #[cbor(optional)]
+    pub min_stake: Option<quantity::Quantity>,
+}
+
 /// Specification of which nodes are allowed to register for a runtime.
-#[derive(Clone, Debug, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
-pub enum RuntimeAdmissionPolicy {
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct RuntimeAdmissionPolicy {
     /// Allow any node to register.
-    #[cbor(rename = "any_node")]
-    AnyNode {},
+    #[cbor(optional)]
+    pub any_node: Option<AnyNodeRuntimeAdmissionPolicy>,
     /// Allow only the whitelisted entities' nodes to register.
-    #[cbor(rename = "entity_whitelist")]
-    EntityWhitelist(EntityWhitelistRuntimeAdmissionPolicy),
-}
-
-impl Default for RuntimeAdmissionPolicy {
-    fn default() -> Self {
-        RuntimeAdmissionPolicy::AnyNode {}
-    }
+    #[cbor(optional)]
+    pub entity_whitelist: Option<EntityWhitelistRuntimeAdmissionPolicy>,
+    /// Per-role admission policies that nodes with the given role must
+    /// satisfy in addition to the global admission policy.
+    #[cbor(optional)]
+    pub per_role: Option<BTreeMap<RolesMask, PerRoleAdmissionPolicy>>,
 }
 
 /// Runtime governance model.
@@ -281,3 +330,65 @@ pub struct RuntimeGenesis {
     /// Runtime round in the genesis.
     pub round: u64,
 }
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_consistent_runtime_admission_policies() {
+        let entity_id =
+            PublicKey::from("4ea5328f943ef6f66daaed74cb0e99c3b1c45f76307b425003dbc7cb3638ed35");
+
+        // NOTE: These tests MUST be synced with go/registry/api/runtime_test.go.
+        let tcs = vec![
+            ("oA==", RuntimeAdmissionPolicy::default()),
+            (
+                "oWhhbnlfbm9kZaA=",
+                RuntimeAdmissionPolicy {
+                    any_node: Some(AnyNodeRuntimeAdmissionPolicy {}),
+                    ..Default::default()
+                },
+            ),
+            (
+                "omhhbnlfbm9kZaBocGVyX3JvbGWiAaNpbWluX3N0YWtlQgPocGVudGl0eV93aGl0ZWxpc3ShaGVudGl0aWVzoVggTqUyj5Q+9vZtqu10yw6Zw7HEX3Ywe0JQA9vHyzY47TWhaW1heF9ub2RlcwN0bWF4X25vZGVzX3Blcl9lbnRpdHkFBKA=",
+                RuntimeAdmissionPolicy {
+                    any_node: Some(AnyNodeRuntimeAdmissionPolicy {}),
+                    per_role: Some(
+                        [
+                            (
+                                RolesMask::RoleComputeWorker,
+                                PerRoleAdmissionPolicy {
+                                    entity_whitelist: Some(EntityWhitelistRoleAdmissionPolicy {
+                                        entities: Some(
+                                            [(entity_id, EntityWhitelistRoleConfig { max_nodes: 3 })]
+                                                .iter()
+                                                .cloned()
+                                                .collect(),
+                                        ),
+                                    }),
+                                    max_nodes_per_entity: 5,
+                                    min_stake: Some(quantity::Quantity::from(1000u32)),
+                                },
+                            ),
+                            (RolesMask::RoleKeyManager, PerRoleAdmissionPolicy::default()),
+                        ]
+                        .iter()
+                        .cloned()
+                        .collect(),
+                    ),
+                    ..Default::default()
+                },
+            ),
+        ];
+        for (encoded_base64, rr) in tcs {
+            let dec: RuntimeAdmissionPolicy =
+                cbor::from_slice(&base64::decode(encoded_base64).unwrap())
+                    .expect("runtime admission policy should deserialize correctly");
+            assert_eq!(
+                dec, rr,
+                "decoded runtime admission policy should match the expected value"
+            );
+        }
+    }
+}
@@ -54,6 +54,7 @@ pub enum HeaderType {
     RoundFailed = 2,
     EpochTransition = 3,
     Suspended = 4,
+    LivenessFailure = 5,
 }
 
 impl Default for HeaderType {
@@ -118,6 +119,10 @@ pub struct MessageEvent {
     #[cbor(optional)]
     #[cbor(default)]
     pub index: u32,
+
+    #[cbor(optional)]
+    #[cbor(default)]
+    pub gas_used: u64,
 }
 
 impl MessageEvent {
@@ -145,6 +150,15 @@ pub struct RoundResults {
     #[cbor(optional)]
     #[cbor(default)]
     pub bad_compute_entities: Vec<PublicKey>,
+
+    /// Number of consecutive failed rounds (including liveness failures) that preceded this round.
+    #[cbor(optional)]
+    #[cbor(default)]
+    pub failed_rounds: u64,
+    /// Number of consecutive liveness failure rounds that preceded this round.
+    #[cbor(optional)]
+    #[cbor(default)]
+    pub liveness_failures: u64,
 }
 
 /// Block header.
@@ -306,6 +320,7 @@ mod tests {
                 allowed_stragglers: 1,
                 round_timeout: 10,
                 max_messages: 32,
+                ..Default::default()
             },
             txn_scheduler: registry::TxnSchedulerParameters {
                 algorithm: "simple".to_string(),
@@ -319,9 +334,12 @@ mod tests {
                 checkpoint_num_kept: 0,
                 checkpoint_chunk_size: 0,
             },
-            admission_policy: registry::RuntimeAdmissionPolicy::EntityWhitelist(
-                registry::EntityWhitelistRuntimeAdmissionPolicy { entities: Some(wl) },
-            ),
+            admission_policy: registry::RuntimeAdmissionPolicy {
+                entity_whitelist: Some(registry::EntityWhitelistRuntimeAdmissionPolicy {
+                    entities: Some(wl),
+                }),
+                ..Default::default()
+            },
             constraints: {
                 let mut cs = BTreeMap::new();
                 cs.insert(scheduler::CommitteeKind::ComputeExecutor, {
@@ -411,12 +429,12 @@ mod tests {
         let tcs = vec![
             ("oA==", RoundResults::default()),
             ("oWhtZXNzYWdlc4GiZGNvZGUBZm1vZHVsZWR0ZXN0", RoundResults {
-                messages: vec![MessageEvent{module: "test".to_owned(), code: 1, index: 0}],
+                messages: vec![MessageEvent{module: "test".to_owned(), code: 1, index: 0, ..Default::default()}],
                 ..Default::default()
             }),
             ("omhtZXNzYWdlc4GjZGNvZGUYKmVpbmRleAFmbW9kdWxlZHRlc3R1Z29vZF9jb21wdXRlX2VudGl0aWVzg1ggAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABYIAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABWCAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAg==",
                 RoundResults {
-                    messages: vec![MessageEvent{module: "test".to_owned(), code: 42, index: 1}],
+                    messages: vec![MessageEvent{module: "test".to_owned(), code: 42, index: 1, ..Default::default()}],
                     good_compute_entities: vec![
                         "0000000000000000000000000000000000000000000000000000000000000000".into(),
                         "0000000000000000000000000000000000000000000000000000000000000001".into(),
@@ -426,7 +444,7 @@ mod tests {
                 }),
             ("o2htZXNzYWdlc4GjZGNvZGUYKmVpbmRleAFmbW9kdWxlZHRlc3R0YmFkX2NvbXB1dGVfZW50aXRpZXOBWCAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAXVnb29kX2NvbXB1dGVfZW50aXRpZXOCWCAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAFggAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAI=",
                 RoundResults {
-                    messages: vec![MessageEvent{module: "test".to_owned(), code: 42, index: 1}],
+                    messages: vec![MessageEvent{module: "test".to_owned(), code: 42, index: 1, ..Default::default()}],
                     good_compute_entities: vec![
                         "0000000000000000000000000000000000000000000000000000000000000000".into(),
                         "0000000000000000000000000000000000000000000000000000000000000002".into(),
@@ -434,12 +452,28 @@ mod tests {
                     bad_compute_entities: vec![
                         "0000000000000000000000000000000000000000000000000000000000000001".into(),
                     ],
+                    ..Default::default()
                 }),
+            ("o2htZXNzYWdlc4GiZGNvZGUBZm1vZHVsZWR0ZXN0bWZhaWxlZF9yb3VuZHMDcWxpdmVuZXNzX2ZhaWx1cmVzAg==", RoundResults {
+                messages: vec![MessageEvent{module: "test".to_owned(), code: 1, index: 0, ..Default::default()}],
+                failed_rounds: 3,
+                liveness_failures: 2,
+                ..Default::default()
+            }),
+            ("oWhtZXNzYWdlc4GjZWluZGV4AmZtb2R1bGVkdGVzdGhnYXNfdXNlZBkD6A==", RoundResults {
+                messages: vec![MessageEvent{module: "test".to_owned(), code: 0, index: 2, gas_used: 1000}],
+                ..Default::default()
+            }),
         ];
         for (encoded_base64, rr) in tcs {
             let dec: RoundResults = cbor::from_slice(&base64::decode(encoded_base64).unwrap())
                 .expect("round results should deserialize correctly");
             assert_eq!(dec, rr, "decoded results should match the expected value");
+            assert_eq!(
+                base64::encode(cbor::to_vec(rr)),
+                encoded_base64,
+                "round results should serialize correctly"
+            );
         }
     }
 }
@@ -0,0 +1,20 @@
+//! Consensus transaction structures.
+use crate::common::crypto::signature::SignatureBundle;
+
+/// Signed consensus transaction.
+#[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct SignedTransaction {
+    /// Serialized transaction.
+    pub untrusted_raw_value: Vec<u8>,
+    /// Transaction signature.
+    pub signature: SignatureBundle,
+}
+
+/// A proof of transaction inclusion in a block.
+#[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct Proof {
+    /// Block height at which the transaction was published.
+    pub height: u64,
+    /// Actual raw proof.
+    pub raw_proof: Vec<u8>,
+}
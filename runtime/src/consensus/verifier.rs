@@ -29,6 +29,7 @@ impl From<Error> for types::Error {
             module: "verifier".to_string(),
             code: 1,
             message: e.to_string(),
+            details: None,
         }
     }
 }
@@ -150,6 +150,7 @@ pub struct Dispatcher {
     queue_tx: mpsc::Sender<Command>,
     rak: Arc<RAK>,
     abort_batch: Arc<AtomicBool>,
+    partial_batch: Arc<AtomicBool>,
 
     state: Mutex<Option<ProtocolState>>,
     state_cond: Condvar,
@@ -183,6 +184,7 @@ impl Dispatcher {
             queue_tx: tx,
             rak,
             abort_batch: Arc::new(AtomicBool::new(false)),
+            partial_batch: Arc::new(AtomicBool::new(false)),
             state: Mutex::new(None),
             state_cond: Condvar::new(),
             tokio_runtime: Self::new_tokio_runtime(),
@@ -226,6 +228,12 @@ impl Dispatcher {
         Ok(())
     }
 
+    /// Signals to dispatcher that it should stop executing the current batch and return the
+    /// results of the already executed transactions.
+    pub fn request_partial_batch(&self) {
+        self.partial_batch.store(true, Ordering::SeqCst);
+    }
+
     fn run(self: &Arc<Self>, initializer: Box<dyn Initializer>, mut rx: mpsc::Receiver<Command>) {
         // Wait for the state to be available.
         let ProtocolState {
@@ -252,6 +260,7 @@ impl Dispatcher {
             Box::new(TxnNoopDispatcher::new())
         };
         txn_dispatcher.set_abort_batch_flag(self.abort_batch.clone());
+        txn_dispatcher.set_partial_batch_flag(self.partial_batch.clone());
 
         let state = State {
             protocol: protocol.clone(),
@@ -375,6 +384,47 @@ impl Dispatcher {
                 )
                 .await
             }
+            Body::RuntimeCheckTxRequest {
+                consensus_block,
+                input,
+                block,
+                epoch,
+                max_messages,
+            } => {
+                // Single transaction check.
+                let response = self
+                    .dispatch_txn(
+                        ctx,
+                        state.cache_set,
+                        &state.txn_dispatcher,
+                        &state.protocol,
+                        Hash::default(),
+                        TxnBatch::new(vec![input]),
+                        TxDispatchState {
+                            consensus_block,
+                            consensus_verifier: state.consensus_verifier,
+                            header: block.header,
+                            epoch,
+                            round_results: Default::default(),
+                            max_messages,
+                            check_only: true,
+                        },
+                    )
+                    .await?;
+
+                match response {
+                    Body::RuntimeCheckTxBatchResponse { mut results } if results.len() == 1 => {
+                        Ok(Body::RuntimeCheckTxResponse {
+                            result: results.remove(0),
+                        })
+                    }
+                    _ => Err(Error::new(
+                        "rhp/dispatcher",
+                        1,
+                        "malformed check tx batch response",
+                    )),
+                }
+            }
             Body::RuntimeQueryRequest {
                 consensus_block,
                 header,
@@ -552,6 +602,9 @@ impl Dispatcher {
         });
         let mut overlay = OverlayTree::new(cache.tree_mut());
 
+        // Clear any stale partial batch requests that arrived after the previous batch.
+        self.partial_batch.store(false, Ordering::SeqCst);
+
         let txn_ctx = TxnContext::new(
             ctx.clone(),
             protocol,
@@ -565,6 +618,15 @@ impl Dispatcher {
         );
         let mut results = txn_dispatcher.execute_batch(txn_ctx, &inputs)?;
 
+        // In case a partial batch has been requested, the dispatcher may have stopped early in
+        // which case the results only cover a prefix of the inputs.
+        let partial = self.partial_batch.swap(false, Ordering::SeqCst)
+            && results.results.len() < inputs.len();
+        let executed_inputs = results.results.len() as u64;
+        if partial {
+            inputs.truncate(results.results.len());
+        }
+
         // Finalize state.
         let (state_write_log, new_state_root) = overlay
             .commit_both(
@@ -604,7 +666,8 @@ impl Dispatcher {
         let (_, old_io_root) = txn_tree
             .commit(Context::create_child(&ctx))
             .expect("io commit must succeed");
-        if old_io_root != io_root {
+        // Partial batches only cover a prefix of the inputs and are never committed.
+        if !partial && old_io_root != io_root {
             panic!(
                 "dispatcher: I/O root inconsistent with inputs (expected: {:?} got: {:?})",
                 io_root, old_io_root
@@ -638,11 +701,13 @@ impl Dispatcher {
             messages_hash: Some(roothash::Message::messages_hash(&results.messages)),
         };
 
-        // Since we've computed the batch, we can trust it.
-        state
-            .consensus_verifier
-            .trust(&header)
-            .expect("trusting a computed header must succeed");
+        // Since we've computed the batch, we can trust it. Partial batches are never committed.
+        if !partial {
+            state
+                .consensus_verifier
+                .trust(&header)
+                .expect("trusting a computed header must succeed");
+        }
 
         debug!(self.logger, "Transaction batch execution complete";
             "previous_hash" => ?header.previous_hash,
@@ -671,6 +736,8 @@ impl Dispatcher {
                 messages: results.messages,
             },
             batch_weight_limits: results.batch_weight_limits,
+            partial,
+            executed_inputs,
         })
     }
 
@@ -21,7 +21,10 @@ use crate::{
     dispatcher::Dispatcher,
     rak::RAK,
     storage::KeyValue,
-    types::{Body, Error, Message, MessageType, RuntimeInfoRequest, RuntimeInfoResponse},
+    types::{
+        Body, Error, LocalStorageEntry, Message, MessageType, RuntimeInfoRequest,
+        RuntimeInfoResponse,
+    },
     BUILD_INFO,
 };
 
@@ -62,6 +65,7 @@ impl From<ProtocolError> for Error {
             module: "protocol".to_string(),
             code: 1,
             message: err.to_string(),
+            details: None,
         }
     }
 }
@@ -341,7 +345,13 @@ impl Protocol {
                 info!(self.logger, "Received worker shutdown request");
                 Err(ProtocolError::MethodNotSupported.into())
             }
-            Body::RuntimeAbortRequest {} => {
+            Body::RuntimeAbortRequest { partial: true } => {
+                info!(self.logger, "Received worker partial abort request");
+                self.can_handle_runtime_requests()?;
+                self.dispatcher.request_partial_batch();
+                Ok(Some(Body::RuntimeAbortResponse {}))
+            }
+            Body::RuntimeAbortRequest { partial: false } => {
                 info!(self.logger, "Received worker abort request");
                 self.can_handle_runtime_requests()?;
                 self.dispatcher.abort_and_wait()?;
@@ -528,4 +538,20 @@ impl KeyValue for ProtocolUntrustedLocalStorage {
             _ => Err(ProtocolError::InvalidResponse.into()),
         }
     }
+
+    fn insert_batch(&self, entries: Vec<(Vec<u8>, Vec<u8>)>) -> Result<(), Error> {
+        let ctx = Context::create_child(&self.ctx);
+        let entries = entries
+            .into_iter()
+            .map(|(key, value)| LocalStorageEntry { key, value })
+            .collect();
+
+        match self
+            .protocol
+            .call_host(ctx, Body::HostLocalStorageBatchSetRequest { entries })?
+        {
+            Body::HostLocalStorageBatchSetResponse {} => Ok(()),
+            _ => Err(ProtocolError::InvalidResponse.into()),
+        }
+    }
 }
@@ -28,6 +28,11 @@ pub struct GetRequest {
     #[cbor(optional)]
     #[cbor(default)]
     pub include_siblings: bool,
+    /// Maximum size of the returned proof in bytes. In case the proof with siblings would
+    /// exceed this size, the siblings are omitted. Zero means no limit.
+    #[cbor(optional)]
+    #[cbor(default)]
+    pub max_proof_size: u64,
 }
 
 /// Request for the SyncGetPrefixes operation.
@@ -36,6 +41,14 @@ pub struct GetPrefixesRequest {
     pub tree: TreeID,
     pub prefixes: Vec<Prefix>,
     pub limit: u16,
+    /// Maximum size of the returned proof in bytes. Zero means no limit.
+    #[cbor(optional)]
+    #[cbor(default)]
+    pub max_proof_size: u64,
+    /// Continuation token returned by a previous request.
+    #[cbor(optional)]
+    #[cbor(default)]
+    pub continuation: Vec<u8>,
 }
 
 /// Request for the SyncIterate operation.
@@ -44,12 +57,24 @@ pub struct IterateRequest {
     pub tree: TreeID,
     pub key: Vec<u8>,
     pub prefetch: u16,
+    /// Maximum size of the returned proof in bytes. Zero means no limit.
+    #[cbor(optional)]
+    #[cbor(default)]
+    pub max_proof_size: u64,
+    /// Continuation token returned by a previous request.
+    #[cbor(optional)]
+    #[cbor(default)]
+    pub continuation: Vec<u8>,
 }
 
 /// Response for requests that produce proofs.
 #[derive(Clone, Debug, Default, PartialEq, cbor::Encode, cbor::Decode)]
 pub struct ProofResponse {
     pub proof: Proof,
+    /// Continuation token in case the proof has been truncated due to the maximum proof size.
+    #[cbor(optional)]
+    #[cbor(default)]
+    pub continuation: Vec<u8>,
 }
 
 /// ReadSync is the interface for synchronizing the in-memory cache
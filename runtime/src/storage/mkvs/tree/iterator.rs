@@ -34,6 +34,7 @@ impl<'a> ReadSyncFetcher for FetcherSyncIterate<'a> {
                 },
                 key: self.key.clone(),
                 prefetch: self.prefetch as u16,
+                ..Default::default()
             },
         )?;
         Ok(rsp.proof)
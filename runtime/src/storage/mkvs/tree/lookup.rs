@@ -36,6 +36,7 @@ impl<'a> ReadSyncFetcher for FetcherSyncGet<'a> {
                 },
                 key: self.key.clone(),
                 include_siblings: self.include_siblings,
+                ..Default::default()
             },
         )?;
         Ok(rsp.proof)
@@ -70,6 +70,15 @@ impl<T: mkvs::FallibleMKVS> OverlayTree<T> {
         Ok(value)
     }
 
+    /// Replace the underlying tree with the given tree while preserving any modifications that
+    /// have not yet been committed, returning the previous underlying tree. The modifications take
+    /// precedence over the contents of the new underlying tree.
+    ///
+    /// This can be used to rebase speculative updates onto a newer version of the underlying tree.
+    pub fn rebase(&mut self, inner: T) -> T {
+        std::mem::replace(&mut self.inner, inner)
+    }
+
     /// Return an iterator over the tree.
     pub fn iter(&self, ctx: Context) -> OverlayTreeIterator<T> {
         OverlayTreeIterator::new(ctx, self)
@@ -31,6 +31,7 @@ impl<'a> ReadSyncFetcher for FetcherSyncGetPrefixes<'a> {
                 },
                 prefixes: self.prefixes.clone(),
                 limit: self.limit,
+                ..Default::default()
             },
         )?;
         Ok(rsp.proof)
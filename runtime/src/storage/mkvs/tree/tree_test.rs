@@ -1176,3 +1176,62 @@ fn test_special_case_4() {
 fn test_special_case_5() {
     test_special_case_from_json("case-5.json")
 }
+
+#[test]
+fn test_overlay_rebase() {
+    let make_tree = |items: &[(&str, &str)]| {
+        let mut tree = Tree::make()
+            .with_root_type(RootType::State)
+            .new(Box::new(NoopReadSyncer));
+        for (key, value) in items {
+            tree.insert(Context::background(), key.as_bytes(), value.as_bytes())
+                .expect("insert");
+        }
+        tree
+    };
+
+    let base = make_tree(&[("key 1", "one"), ("key 2", "two")]);
+    let mut overlay = OverlayTree::new(base);
+    overlay
+        .insert(Context::background(), b"key 3", b"three")
+        .expect("insert");
+    overlay
+        .remove(Context::background(), b"key 2")
+        .expect("remove");
+
+    // Rebase the overlay onto a newer tree.
+    let newer = make_tree(&[("key 1", "uno"), ("key 2", "dos"), ("key 4", "cuatro")]);
+    let old = overlay.rebase(newer);
+    assert_eq!(
+        old.get(Context::background(), b"key 2").expect("get"),
+        Some(b"two".to_vec())
+    );
+
+    // Keys not modified in the overlay should come from the new tree.
+    assert_eq!(
+        overlay.get(Context::background(), b"key 1").expect("get"),
+        Some(b"uno".to_vec())
+    );
+    assert_eq!(
+        overlay.get(Context::background(), b"key 4").expect("get"),
+        Some(b"cuatro".to_vec())
+    );
+    // Modifications in the overlay should take precedence.
+    assert_eq!(
+        overlay.get(Context::background(), b"key 2").expect("get"),
+        None
+    );
+    assert_eq!(
+        overlay.get(Context::background(), b"key 3").expect("get"),
+        Some(b"three".to_vec())
+    );
+
+    let keys: Vec<Vec<u8>> = overlay
+        .iter(Context::background())
+        .map(|(key, _)| key)
+        .collect();
+    assert_eq!(
+        keys,
+        vec![b"key 1".to_vec(), b"key 3".to_vec(), b"key 4".to_vec()]
+    );
+}
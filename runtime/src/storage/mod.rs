@@ -15,6 +15,12 @@ pub trait KeyValue: Send + Sync {
 
     /// Store a specific key/value into storage.
     fn insert(&self, key: Vec<u8>, value: Vec<u8>) -> Result<(), Error>;
+
+    /// Atomically store multiple key/values into storage. Either all or none
+    /// of the key/values are stored.
+    ///
+    /// Batches that are too large to be stored atomically are rejected.
+    fn insert_batch(&self, entries: Vec<(Vec<u8>, Vec<u8>)>) -> Result<(), Error>;
 }
 
 impl<T: ?Sized + KeyValue> KeyValue for Arc<T> {
@@ -25,4 +31,8 @@ impl<T: ?Sized + KeyValue> KeyValue for Arc<T> {
     fn insert(&self, key: Vec<u8>, value: Vec<u8>) -> Result<(), Error> {
         KeyValue::insert(&**self, key, value)
     }
+
+    fn insert_batch(&self, entries: Vec<(Vec<u8>, Vec<u8>)>) -> Result<(), Error> {
+        KeyValue::insert_batch(&**self, entries)
+    }
 }
@@ -40,6 +40,14 @@ pub trait Dispatcher: Send + Sync {
         // Default implementation does nothing.
     }
 
+    /// Configure partial batch flag.
+    ///
+    /// When the flag is set, the dispatcher should stop executing the current batch and return
+    /// the results of the already executed transactions, which must be a prefix of the batch.
+    fn set_partial_batch_flag(&mut self, _partial_batch: Arc<AtomicBool>) {
+        // Default implementation does nothing.
+    }
+
     /// Process a query.
     fn query(&self, _ctx: Context, _method: &str, _args: Vec<u8>) -> Result<Vec<u8>, RuntimeError> {
         // Default implementation returns an error.
@@ -76,6 +84,10 @@ impl<T: Dispatcher + ?Sized> Dispatcher for Box<T> {
         T::set_abort_batch_flag(&mut *self, abort_batch)
     }
 
+    fn set_partial_batch_flag(&mut self, partial_batch: Arc<AtomicBool>) {
+        T::set_partial_batch_flag(&mut *self, partial_batch)
+    }
+
     fn query(&self, ctx: Context, method: &str, args: Vec<u8>) -> Result<Vec<u8>, RuntimeError> {
         T::query(&*self, ctx, method, args)
     }
@@ -106,6 +118,10 @@ impl<T: Dispatcher + ?Sized> Dispatcher for Arc<T> {
         unimplemented!()
     }
 
+    fn set_partial_batch_flag(&mut self, _partial_batch: Arc<AtomicBool>) {
+        unimplemented!()
+    }
+
     fn query(&self, ctx: Context, method: &str, args: Vec<u8>) -> Result<Vec<u8>, RuntimeError> {
         T::query(&*self, ctx, method, args)
     }
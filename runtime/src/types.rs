@@ -16,6 +16,7 @@ use crate::{
     consensus::{
         beacon::EpochTime,
         roothash::{self, Block, ComputeResultsHeader, Header},
+        transaction::{Proof, SignedTransaction},
         LightBlock,
     },
     storage::mkvs::{sync, WriteLog},
@@ -116,7 +117,10 @@ pub enum Body {
     RuntimeInfoResponse(RuntimeInfoResponse),
     RuntimePingRequest {},
     RuntimeShutdownRequest {},
-    RuntimeAbortRequest {},
+    RuntimeAbortRequest {
+        #[cbor(optional, default)]
+        partial: bool,
+    },
     RuntimeAbortResponse {},
     RuntimeCapabilityTEERakInitRequest {
         target_info: Vec<u8>,
@@ -154,6 +158,16 @@ pub enum Body {
     RuntimeCheckTxBatchResponse {
         results: Vec<CheckTxResult>,
     },
+    RuntimeCheckTxRequest {
+        consensus_block: LightBlock,
+        input: Vec<u8>,
+        block: Block,
+        epoch: EpochTime,
+        max_messages: u32,
+    },
+    RuntimeCheckTxResponse {
+        result: CheckTxResult,
+    },
     RuntimeExecuteTxBatchRequest {
         consensus_block: LightBlock,
         round_results: roothash::RoundResults,
@@ -168,6 +182,10 @@ pub enum Body {
         batch: ComputedBatch,
         #[cbor(optional)]
         batch_weight_limits: Option<BTreeMap<TransactionWeight, u64>>,
+        #[cbor(optional, default)]
+        partial: bool,
+        #[cbor(optional, default)]
+        executed_inputs: u64,
     },
     RuntimeKeyManagerPolicyUpdateRequest {
         signed_policy_raw: Vec<u8>,
@@ -212,12 +230,23 @@ pub enum Body {
         value: Vec<u8>,
     },
     HostLocalStorageSetResponse {},
+    HostLocalStorageBatchSetRequest {
+        entries: Vec<LocalStorageEntry>,
+    },
+    HostLocalStorageBatchSetResponse {},
     HostFetchConsensusBlockRequest {
         height: u64,
     },
     HostFetchConsensusBlockResponse {
         block: LightBlock,
     },
+    HostProveFreshnessRequest {
+        blob: [u8; 32],
+    },
+    HostProveFreshnessResponse {
+        signed_tx: SignedTransaction,
+        proof: Proof,
+    },
 }
 
 /// A serializable error.
@@ -235,6 +264,9 @@ pub struct Error {
     #[cbor(optional)]
     #[cbor(default)]
     pub message: String,
+
+    #[cbor(optional)]
+    pub details: Option<ErrorDetails>,
 }
 
 impl Error {
@@ -244,8 +276,15 @@ impl Error {
             module: module.to_owned(),
             code,
             message: msg.to_owned(),
+            details: None,
         }
     }
+
+    /// Attach structured error details to the error.
+    pub fn with_details(mut self, details: ErrorDetails) -> Self {
+        self.details = Some(details);
+        self
+    }
 }
 
 impl From<anyhow::Error> for Error {
@@ -254,10 +293,42 @@ impl From<anyhow::Error> for Error {
             module: "unknown".to_string(),
             code: 1,
             message: err.to_string(),
+            details: None,
         }
     }
 }
 
+/// Structured error details.
+#[derive(Clone, Debug, Default, cbor::Encode, cbor::Decode)]
+pub struct ErrorDetails {
+    /// Kind of the error details payload.
+    pub kind: String,
+
+    /// Error details payload.
+    #[cbor(optional)]
+    pub data: Option<cbor::Value>,
+}
+
+impl ErrorDetails {
+    /// Kind of the well-known insufficient fee error details payload.
+    pub const KIND_INSUFFICIENT_FEE: &'static str = "insufficient_fee";
+
+    /// Create new error details of the given kind with the given payload.
+    pub fn new<T: cbor::Encode>(kind: &str, data: T) -> Self {
+        Self {
+            kind: kind.to_owned(),
+            data: Some(cbor::to_value(data)),
+        }
+    }
+}
+
+/// Untrusted local storage key/value entry.
+#[derive(Clone, Debug, Default, cbor::Encode, cbor::Decode)]
+pub struct LocalStorageEntry {
+    pub key: Vec<u8>,
+    pub value: Vec<u8>,
+}
+
 /// Runtime information request.
 #[derive(Clone, Debug, cbor::Encode, cbor::Decode)]
 pub struct RuntimeInfoRequest {
@@ -143,6 +143,7 @@ impl Dispatcher {
             module: "test".to_string(),
             code: 1,
             message: "malformed transaction batch".to_string(),
+            details: None,
         })?;
 
         let mut tx_ctx = TxContext::new(ctx);
@@ -169,6 +170,7 @@ impl Dispatcher {
                     module: "test".to_string(),
                     code: 1,
                     message: err.to_string(),
+                    details: None,
                 },
                 meta: None,
             }),